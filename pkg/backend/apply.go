@@ -27,6 +27,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
@@ -88,10 +89,11 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 	//
 	// Instead of using a `defer`, we manually close `eventsChannel` on every exit of this function.
 	eventsChannel := make(chan engine.Event)
+	eventsDone := make(chan bool)
 
 	var events []engine.Event
 	go func() {
-		// pull the events from the channel and store them locally
+		// pull the events from the channel and store them locally, passing them along to the caller if it asked
 		for e := range eventsChannel {
 			if e.Type == engine.ResourcePreEvent ||
 				e.Type == engine.ResourceOutputsEvent ||
@@ -99,8 +101,17 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 
 				events = append(events, e)
 			}
+
+			if op.Events != nil {
+				op.Events <- e
+			}
 		}
+		close(eventsDone)
 	}()
+	closeEvents := func() {
+		close(eventsChannel)
+		<-eventsDone
+	}
 
 	// Perform the update operations, passing true for dryRun, so that we get a preview.
 	// We perform the preview (DryRun), but don't display the cloud link since the
@@ -113,20 +124,19 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 
 	changes, res := apply(ctx, kind, stack, op, opts, eventsChannel)
 	if res != nil {
-		close(eventsChannel)
+		closeEvents()
 		return changes, res
 	}
 
 	// If there are no changes, or we're auto-approving or just previewing, we can skip the confirmation prompt.
 	if op.Opts.AutoApprove || kind == apitype.PreviewUpdate {
-		close(eventsChannel)
+		closeEvents()
 		return changes, nil
 	}
 
 	// Otherwise, ensure the user wants to proceed.
-	res = confirmBeforeUpdating(kind, stack, events, op.Opts)
-	close(eventsChannel)
-	return changes, res
+	closeEvents()
+	return changes, confirmBeforeUpdating(kind, stack, events, op.Opts)
 }
 
 // confirmBeforeUpdating asks the user whether to proceed. A nil error means yes.
@@ -191,10 +201,64 @@ func confirmBeforeUpdating(kind apitype.UpdateKind, stack Stack,
 	}
 }
 
+// confirmStackName ensures that the name of the stack has been confirmed if the project's confirmation rules require
+// it for this operation, either by the ConfirmStackName option or by asking the user to type it.
+func confirmStackName(ctx context.Context, kind apitype.UpdateKind, stack Stack, op UpdateOperation) result.Result {
+	if op.Proj == nil || op.Proj.Confirmation == nil {
+		return nil
+	}
+
+	tags, err := GetStackTags(ctx, stack)
+	if err != nil {
+		return result.FromError(errors.Wrap(err, "getting stack tags"))
+	}
+
+	name := stack.Ref().Name().String()
+	if !op.Proj.Confirmation.RequiresStackName(string(kind), name, tags) {
+		return nil
+	}
+	switch {
+	case op.Opts.ConfirmStackName == name:
+		return nil
+	case op.Opts.ConfirmStackName != "":
+		return result.Errorf("--confirm-stack=%s does not match the name of the stack, '%s'",
+			op.Opts.ConfirmStackName, name)
+	case !op.Opts.Display.IsInteractive:
+		return result.Errorf("the project requires the name of the stack to be confirmed for this %s; "+
+			"pass --confirm-stack=%s to proceed", kind, name)
+	}
+
+	cmdutil.EndKeypadTransmitMode()
+
+	var response string
+	prompt := op.Opts.Display.Color.Colorize(colors.SpecPrompt +
+		fmt.Sprintf("This project requires the name of the stack to be typed to confirm this %s. Stack name:",
+			kind) + colors.Reset)
+	if err := survey.AskOne(&survey.Input{Message: prompt}, &response, nil); err != nil {
+		return result.FromError(errors.Wrapf(err, "confirmation cancelled, not proceeding with the %s", kind))
+	}
+	if strings.TrimSpace(response) != name {
+		fmt.Printf("confirmation declined, not proceeding with the %s\n", kind)
+		return result.Bail()
+	}
+	return nil
+}
+
 func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind, stack Stack,
 	op UpdateOperation, apply Applier) (engine.ResourceChanges, result.Result) {
-	// Preview the operation to the user and ask them if they want to proceed.
+	// Share the provider configuration checked by the preview with the update that follows it.
+	if op.Opts.Engine.ProviderConfigs == nil {
+		op.Opts.Engine.ProviderConfigs = providers.NewConfigCache()
+	}
 
+	// Refuse to change the state of a frozen stack before doing any work.
+	if kind != apitype.PreviewUpdate {
+		if err := CheckStackFreeze(ctx, stack, op.Opts.OverrideFreeze); err != nil {
+			return nil, result.FromError(err)
+		}
+	}
+
+	// Preview the operation to the user and ask them if they want to proceed.
 	if !op.Opts.SkipPreview {
 		changes, res := PreviewThenPrompt(ctx, kind, stack, op, apply)
 		if res != nil || kind == apitype.PreviewUpdate {
@@ -202,13 +266,18 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 		}
 	}
 
+	// Some projects also require the stack's name to be typed, even if the update was approved with --yes.
+	if res := confirmStackName(ctx, kind, stack, op); res != nil {
+		return nil, res
+	}
+
 	// Perform the change (!DryRun) and show the cloud link to the result.
-	// We don't care about the events it issues, so just pass a nil channel along.
+	// We only care about the events it issues if the caller does.
 	opts := ApplierOptions{
 		DryRun:   false,
 		ShowLink: true,
 	}
-	return apply(ctx, kind, stack, op, opts, nil /*events*/)
+	return apply(ctx, kind, stack, op, opts, op.Events)
 }
 
 func createDiff(updateKind apitype.UpdateKind, events []engine.Event, displayOpts display.Options) string {
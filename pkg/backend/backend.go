@@ -53,6 +53,21 @@ func (e StackAlreadyExistsError) Error() string {
 	return fmt.Sprintf("stack '%v' already exists", e.StackName)
 }
 
+// StackFrozenError is returned when an operation would change the state of a frozen stack without overriding the
+// stack's freeze.
+type StackFrozenError struct {
+	StackName string
+	Reason    string
+}
+
+func (e StackFrozenError) Error() string {
+	msg := fmt.Sprintf("stack '%v' is frozen", e.StackName)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg + "; pass --override-freeze to change it anyway"
+}
+
 // OverStackLimitError is returned from CreateStack when the organization is billed per-stack and
 // is over its stack limit.
 type OverStackLimitError struct {
@@ -169,6 +184,9 @@ type Backend interface {
 	// GetHistory returns all updates for the stack. The returned UpdateInfo slice will be in
 	// descending order (newest first).
 	GetHistory(ctx context.Context, stackRef StackReference) ([]UpdateInfo, error)
+	// GetUpdateEvents returns the engine events recorded for an update of the stack. Updates are numbered from 1 in
+	// the order in which they ran, so the newest update's number is the length of the stack's history.
+	GetUpdateEvents(ctx context.Context, stackRef StackReference, version int) ([]apitype.EngineEvent, error)
 	// GetLogs fetches a list of log entries for the given stack, with optional filtering/querying.
 	GetLogs(ctx context.Context, stack Stack, cfg StackConfiguration,
 		query operations.LogQuery) ([]operations.LogEntry, error)
@@ -179,6 +197,10 @@ type Backend interface {
 	GetStackTags(ctx context.Context, stack Stack) (map[apitype.StackTagName]string, error)
 	// UpdateStackTags updates the stacks's tags, replacing all existing tags.
 	UpdateStackTags(ctx context.Context, stack Stack, tags map[apitype.StackTagName]string) error
+	// GetStackFreeze fetches the stack's freeze, or nil if the stack is not frozen.
+	GetStackFreeze(ctx context.Context, stack Stack) (*apitype.StackFreeze, error)
+	// UpdateStackFreeze freezes the stack with the given freeze, or unfreezes it if the freeze is nil.
+	UpdateStackFreeze(ctx context.Context, stack Stack, freeze *apitype.StackFreeze) error
 
 	// ExportDeployment exports the deployment for the given stack as an opaque JSON message.
 	ExportDeployment(ctx context.Context, stack Stack) (*apitype.UntypedDeployment, error)
@@ -210,6 +232,10 @@ type UpdateOperation struct {
 	SecretsManager     secrets.Manager
 	StackConfiguration StackConfiguration
 	Scopes             CancellationScopeSource
+
+	// Events, if non-nil, receives a copy of every engine event emitted by the operation, including those of the
+	// preview that precedes an update, refresh, or destroy. It is never closed.
+	Events chan<- engine.Event
 }
 
 // QueryOperation configures a query operation.
@@ -239,6 +265,11 @@ type UpdateOptions struct {
 	AutoApprove bool
 	// SkipPreview, when true, causes the preview step to be skipped.
 	SkipPreview bool
+	// OverrideFreeze, when true, allows the operation to change the state of a frozen stack.
+	OverrideFreeze bool
+	// ConfirmStackName confirms an operation that the project requires the stack's name to be typed for, without
+	// prompting for it. It must equal the stack's name.
+	ConfirmStackName string
 }
 
 // QueryOptions configures a query to operate against a backend and the engine.
@@ -272,7 +303,9 @@ type backendClient struct {
 	backend Backend
 }
 
-// GetStackOutputs returns the outputs of the stack with the given name.
+// GetStackOutputs returns the outputs of the stack with the given name. Outputs that the stack marks as internal are
+// withheld: only their names are returned, under resource.InternalStackOutputsKey, so that callers can report
+// references to them.
 func (c *backendClient) GetStackOutputs(ctx context.Context, name string) (resource.PropertyMap, error) {
 	ref, err := c.backend.ParseStackReference(name)
 	if err != nil {
@@ -296,7 +329,16 @@ func (c *backendClient) GetStackOutputs(ctx context.Context, name string) (resou
 	if res == nil {
 		return resource.PropertyMap{}, nil
 	}
-	return res.Outputs, nil
+
+	outputs, internal := resource.PublicStackOutputs(res.Outputs)
+	if len(internal) != 0 {
+		names := make([]resource.PropertyValue, len(internal))
+		for i, name := range internal {
+			names[i] = resource.NewStringProperty(name)
+		}
+		outputs[resource.InternalStackOutputsKey] = resource.NewArrayProperty(names)
+	}
+	return outputs, nil
 }
 
 func (c *backendClient) GetStackResourceOutputs(
@@ -322,9 +364,15 @@ func (c *backendClient) GetStackResourceOutputs(
 			continue
 		}
 
+		outputs := r.Outputs
+		if r.Type == resource.RootStackType {
+			// The root stack's internal outputs must not be reachable through its resources either.
+			outputs, _ = resource.PublicStackOutputs(outputs)
+		}
+
 		resc := resource.PropertyMap{
 			resource.PropertyKey("type"):    resource.NewStringProperty(string(r.Type)),
-			resource.PropertyKey("outputs"): resource.NewObjectProperty(r.Outputs)}
+			resource.PropertyKey("outputs"): resource.NewObjectProperty(outputs)}
 		pm[resource.PropertyKey(r.URN)] = resource.NewObjectProperty(resc)
 	}
 	return pm, nil
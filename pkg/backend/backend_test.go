@@ -96,6 +96,44 @@ func TestGetStackResourceOutputs(t *testing.T) {
 	assert.False(t, exists)
 }
 
+func TestGetStackOutputsWithholdsInternalOutputs(t *testing.T) {
+	root := liveState(string(resource.RootStackType), "stack", resource.NewPropertyMapFromMap(map[string]interface{}{
+		"url":                                    "https://example.com",
+		"dbHost":                                 "db.internal",
+		string(resource.InternalStackOutputsKey): []interface{}{"dbHost"},
+	}))
+
+	be := &MockBackend{
+		ParseStackReferenceF: func(s string) (StackReference, error) {
+			return nil, nil
+		},
+		GetStackF: func(ctx context.Context, stackRef StackReference) (Stack, error) {
+			return &MockStack{
+				SnapshotF: func(ctx context.Context) (*deploy.Snapshot, error) {
+					return &deploy.Snapshot{Resources: []*resource.State{root}}, nil
+				},
+			}, nil
+		},
+	}
+	client := &backendClient{backend: be}
+
+	// Only the names of internal outputs are returned.
+	outs, err := client.GetStackOutputs(context.Background(), "fakeStack")
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewPropertyMapFromMap(map[string]interface{}{
+		"url":                                    "https://example.com",
+		string(resource.InternalStackOutputsKey): []interface{}{"dbHost"},
+	}), outs)
+
+	// Internal outputs are not available through the stack's resource outputs, either.
+	resources, err := client.GetStackResourceOutputs(context.Background(), "fakeStack")
+	assert.NoError(t, err)
+	rootOuts := resources[resource.PropertyKey(root.URN)].ObjectValue()["outputs"].ObjectValue()
+	assert.Equal(t, resource.NewPropertyMapFromMap(map[string]interface{}{
+		"url": "https://example.com",
+	}), rootOuts)
+}
+
 //
 // Helpers.
 //
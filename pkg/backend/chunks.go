@@ -0,0 +1,77 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// ChunkKey returns the content address of a chunk, i.e. the hex-encoded SHA-256 hash of its bytes.
+func ChunkKey(chunk []byte) string {
+	sum := sha256.Sum256(chunk)
+	return hex.EncodeToString(sum[:])
+}
+
+// SplitDeployment separates the resources of a JSON-encoded deployment from the rest of it, so that each resource may
+// be stored as a chunk of its own. The resources are compacted, so identical resources always have identical chunk
+// keys regardless of how the deployment was formatted.
+func SplitDeployment(deployment json.RawMessage) (json.RawMessage, []json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(deployment, &fields); err != nil {
+		return nil, nil, errors.Wrap(err, "splitting deployment")
+	}
+
+	var resources []json.RawMessage
+	if raw, ok := fields["resources"]; ok {
+		if err := json.Unmarshal(raw, &resources); err != nil {
+			return nil, nil, errors.Wrap(err, "splitting deployment")
+		}
+		delete(fields, "resources")
+	}
+	for i, res := range resources {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, res); err != nil {
+			return nil, nil, errors.Wrap(err, "splitting deployment")
+		}
+		resources[i] = compact.Bytes()
+	}
+
+	header, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "splitting deployment")
+	}
+	return header, resources, nil
+}
+
+// JoinDeployment reassembles a deployment that was separated by SplitDeployment.
+func JoinDeployment(header json.RawMessage, resources []json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(header, &fields); err != nil {
+		return nil, errors.Wrap(err, "joining deployment")
+	}
+	if len(resources) > 0 {
+		raw, err := json.Marshal(resources)
+		if err != nil {
+			return nil, errors.Wrap(err, "joining deployment")
+		}
+		fields["resources"] = raw
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,53 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDeployment(t *testing.T) {
+	deployment := json.RawMessage(`{
+		"manifest": {"time": "2020-01-01T00:00:00Z"},
+		"resources": [
+			{"urn": "a", "outputs": {"x": 1}},
+			{"urn": "b",   "outputs": {}}
+		]
+	}`)
+
+	header, resources, err := SplitDeployment(deployment)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"manifest": {"time": "2020-01-01T00:00:00Z"}}`, string(header))
+	if assert.Len(t, resources, 2) {
+		// Resources are compacted so that their keys do not depend on formatting.
+		assert.Equal(t, `{"urn":"b","outputs":{}}`, string(resources[1]))
+		assert.Equal(t, ChunkKey([]byte(`{"urn":"a","outputs":{"x":1}}`)), ChunkKey(resources[0]))
+	}
+
+	joined, err := JoinDeployment(header, resources)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(deployment), string(joined))
+
+	// A deployment without resources round-trips without gaining any.
+	header, resources, err = SplitDeployment(json.RawMessage(`{"manifest": {}}`))
+	assert.NoError(t, err)
+	assert.Empty(t, resources)
+	joined, err = JoinDeployment(header, resources)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"manifest": {}}`, string(joined))
+}
@@ -21,6 +21,7 @@ import (
 	"math"
 	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -116,6 +117,8 @@ func RenderDiffEvent(action apitype.UpdateKind, event engine.Event,
 		return renderDiffDiagEvent(event.Payload().(engine.DiagEventPayload), opts)
 	case engine.PolicyViolationEvent:
 		return renderDiffPolicyViolationEvent(event.Payload().(engine.PolicyViolationEventPayload), opts)
+	case engine.HoldEvent:
+		return opts.Color.Colorize(renderHoldEvent(event.Payload().(engine.HoldEventPayload))) + "\n"
 
 	default:
 		contract.Failf("unknown event type '%s'", event.Type)
@@ -130,6 +133,34 @@ func renderDiffDiagEvent(payload engine.DiagEventPayload, opts Options) string {
 	return opts.Color.Colorize(payload.Prefix + payload.Message)
 }
 
+// renderHoldEvent describes a change in the state of a hold point.
+func renderHoldEvent(payload engine.HoldEventPayload) string {
+	name := payload.URN.Name()
+	switch payload.Status {
+	case deploy.HoldWaiting:
+		msg := fmt.Sprintf("%sHolding before %s after %d changes%s", colors.SpecAttention, name, payload.Changes,
+			colors.Reset)
+		switch {
+		case payload.Approvable && payload.Timeout != 0:
+			msg += fmt.Sprintf(": type 'yes' and press enter to continue, or 'no' to stop, within %v", payload.Timeout)
+		case payload.Approvable:
+			msg += ": type 'yes' and press enter to continue, or 'no' to stop"
+		default:
+			msg += fmt.Sprintf(" for %v", payload.Timeout)
+		}
+		return msg
+	case deploy.HoldApproved:
+		return fmt.Sprintf("Continuing before %s: approved", name)
+	case deploy.HoldRejected:
+		return fmt.Sprintf("%sStopping before %s: rejected%s", colors.SpecError, name, colors.Reset)
+	case deploy.HoldTimedOut:
+		return fmt.Sprintf("Hold before %s timed out after %v", name, payload.Timeout)
+	default:
+		contract.Failf("unknown hold status '%s'", payload.Status)
+		return ""
+	}
+}
+
 func renderDiffPolicyViolationEvent(payload engine.PolicyViolationEventPayload, opts Options) string {
 	return opts.Color.Colorize(payload.Prefix + payload.Message)
 }
@@ -222,6 +253,9 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 	// Print policy packs loaded. Data is rendered as a table of {policy-pack-name, version}.
 	renderPolicyPacks(out, event.PolicyPacks, opts)
 
+	// Print the configuration keys that are set but unused, and those that were read but are not set.
+	renderConfigUsage(out, event.ConfigUsage, opts)
+
 	// For actual deploys, we print some additional summary information
 	if !event.IsPreview {
 		// Round up to the nearest second.  It's not useful to spit out time with 9 digits of
@@ -263,6 +297,24 @@ func renderPolicyPacks(out io.Writer, policyPacks map[string]string, opts Option
 	}
 }
 
+func renderConfigUsage(out io.Writer, usage *engine.ConfigUsage, opts Options) {
+	if usage == nil || len(usage.Unused) == 0 && len(usage.Missing) == 0 {
+		return
+	}
+	fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%sConfiguration:%s\n",
+		colors.SpecHeadline, colors.Reset)))
+
+	renderKeys := func(keys []string, description string) {
+		if len(keys) == 0 {
+			return
+		}
+		fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("    %s%d %s %s:%s %s\n", colors.SpecWarning,
+			len(keys), description, english.PluralWord(len(keys), "key", ""), colors.Reset, strings.Join(keys, ", "))))
+	}
+	renderKeys(usage.Unused, "unused")
+	renderKeys(usage.Missing, "missing")
+}
+
 func renderPreludeEvent(event engine.PreludeEventPayload, opts Options) string {
 	// Only if we have been instructed to show configuration values will we print anything during the prelude.
 	if !opts.ShowConfig {
@@ -1,13 +1,20 @@
 package display
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 )
 
@@ -67,6 +74,19 @@ func ConvertEngineEvent(e engine.Event) (apitype.EngineEvent, error) {
 			EnforcementLevel:     string(p.EnforcementLevel),
 		}
 
+	case engine.HoldEvent:
+		p, ok := e.Payload().(engine.HoldEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.HoldEvent = &apitype.HoldEvent{
+			URN:            string(p.URN),
+			Changes:        p.Changes,
+			Status:         string(p.Status),
+			TimeoutSeconds: int(p.Timeout.Seconds()),
+			Approvable:     p.Approvable,
+		}
+
 	case engine.PreludeEvent:
 		p, ok := e.Payload().(engine.PreludeEventPayload)
 		if !ok {
@@ -220,3 +240,209 @@ func convertStepEventStateMetadata(md *engine.StepEventStateMetadata) *apitype.S
 		InitErrors: md.InitErrors,
 	}
 }
+
+// ConvertJSONEvent converts an apitype.EngineEvent, such as one read back from a persisted event log, into the
+// engine.Event it was created from so that it can be rendered by the display. Because ConvertEngineEvent is lossy,
+// so is the result: secret values are replaced with "[secret]", and the raw resource state is not available.
+func ConvertJSONEvent(apiEvent apitype.EngineEvent) (engine.Event, error) {
+	switch {
+	case apiEvent.CancelEvent != nil:
+		return engine.NewEvent(engine.CancelEvent, nil), nil
+
+	case apiEvent.StdoutEvent != nil:
+		p := apiEvent.StdoutEvent
+		return engine.NewEvent(engine.StdoutColorEvent, engine.StdoutEventPayload{
+			Message: p.Message,
+			Color:   colors.Colorization(p.Color),
+		}), nil
+
+	case apiEvent.DiagnosticEvent != nil:
+		p := apiEvent.DiagnosticEvent
+		return engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			URN:       resource.URN(p.URN),
+			Prefix:    p.Prefix,
+			Message:   p.Message,
+			Color:     colors.Colorization(p.Color),
+			Severity:  diag.Severity(p.Severity),
+			Ephemeral: p.Ephemeral,
+		}), nil
+
+	case apiEvent.PolicyEvent != nil:
+		p := apiEvent.PolicyEvent
+		return engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       resource.URN(p.ResourceURN),
+			Message:           p.Message,
+			Color:             colors.Colorization(p.Color),
+			PolicyName:        p.PolicyName,
+			PolicyPackName:    p.PolicyPackName,
+			PolicyPackVersion: p.PolicyPackVersion,
+			EnforcementLevel:  apitype.EnforcementLevel(p.EnforcementLevel),
+		}), nil
+
+	case apiEvent.HoldEvent != nil:
+		p := apiEvent.HoldEvent
+		return engine.NewEvent(engine.HoldEvent, engine.HoldEventPayload{
+			URN:        resource.URN(p.URN),
+			Changes:    p.Changes,
+			Status:     deploy.HoldStatus(p.Status),
+			Timeout:    time.Duration(p.TimeoutSeconds) * time.Second,
+			Approvable: p.Approvable,
+		}), nil
+
+	case apiEvent.PreludeEvent != nil:
+		return engine.NewEvent(engine.PreludeEvent, engine.PreludeEventPayload{
+			Config: apiEvent.PreludeEvent.Config,
+		}), nil
+
+	case apiEvent.SummaryEvent != nil:
+		p := apiEvent.SummaryEvent
+		changes := make(engine.ResourceChanges)
+		for op, count := range p.ResourceChanges {
+			changes[deploy.StepOp(op)] = count
+		}
+		return engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			MaybeCorrupt:    p.MaybeCorrupt,
+			Duration:        time.Duration(p.DurationSeconds) * time.Second,
+			ResourceChanges: changes,
+			PolicyPacks:     p.PolicyPacks,
+		}), nil
+
+	case apiEvent.ResourcePreEvent != nil:
+		md, err := convertJSONStepEventMetadata(apiEvent.ResourcePreEvent.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		return engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{
+			Metadata: md,
+			Planning: apiEvent.ResourcePreEvent.Planning,
+		}), nil
+
+	case apiEvent.ResOutputsEvent != nil:
+		md, err := convertJSONStepEventMetadata(apiEvent.ResOutputsEvent.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		return engine.NewEvent(engine.ResourceOutputsEvent, engine.ResourceOutputsEventPayload{
+			Metadata: md,
+			Planning: apiEvent.ResOutputsEvent.Planning,
+		}), nil
+
+	case apiEvent.ResOpFailedEvent != nil:
+		md, err := convertJSONStepEventMetadata(apiEvent.ResOpFailedEvent.Metadata)
+		if err != nil {
+			return engine.Event{}, err
+		}
+		return engine.NewEvent(engine.ResourceOperationFailed, engine.ResourceOperationFailedPayload{
+			Metadata: md,
+			Status:   resource.Status(apiEvent.ResOpFailedEvent.Status),
+			Steps:    apiEvent.ResOpFailedEvent.Steps,
+		}), nil
+
+	default:
+		return engine.Event{}, errors.Errorf("unknown event with sequence number %d", apiEvent.Sequence)
+	}
+}
+
+func convertJSONStepEventMetadata(md apitype.StepEventMetadata) (engine.StepEventMetadata, error) {
+	oldState, err := convertJSONStepEventStateMetadata(md.Old)
+	if err != nil {
+		return engine.StepEventMetadata{}, err
+	}
+	newState, err := convertJSONStepEventStateMetadata(md.New)
+	if err != nil {
+		return engine.StepEventMetadata{}, err
+	}
+	res := newState
+	if res == nil {
+		res = oldState
+	}
+
+	var keys []resource.PropertyKey
+	for _, k := range md.Keys {
+		keys = append(keys, resource.PropertyKey(k))
+	}
+	var diffs []resource.PropertyKey
+	for _, k := range md.Diffs {
+		diffs = append(diffs, resource.PropertyKey(k))
+	}
+	var detailedDiff map[string]plugin.PropertyDiff
+	if md.DetailedDiff != nil {
+		detailedDiff = make(map[string]plugin.PropertyDiff)
+		for k, v := range md.DetailedDiff {
+			var d plugin.DiffKind
+			switch v.Kind {
+			case apitype.DiffAdd:
+				d = plugin.DiffAdd
+			case apitype.DiffAddReplace:
+				d = plugin.DiffAddReplace
+			case apitype.DiffDelete:
+				d = plugin.DiffDelete
+			case apitype.DiffDeleteReplace:
+				d = plugin.DiffDeleteReplace
+			case apitype.DiffUpdate:
+				d = plugin.DiffUpdate
+			case apitype.DiffUpdateReplace:
+				d = plugin.DiffUpdateReplace
+			default:
+				return engine.StepEventMetadata{}, errors.Errorf("unrecognized diff kind %v", v.Kind)
+			}
+			detailedDiff[k] = plugin.PropertyDiff{
+				Kind:      d,
+				InputDiff: v.InputDiff,
+			}
+		}
+	}
+
+	return engine.StepEventMetadata{
+		Op:   deploy.StepOp(md.Op),
+		URN:  resource.URN(md.URN),
+		Type: tokens.Type(md.Type),
+
+		Old: oldState,
+		New: newState,
+		Res: res,
+
+		Keys:         keys,
+		Diffs:        diffs,
+		DetailedDiff: detailedDiff,
+		Logical:      md.Logical,
+		Provider:     md.Provider,
+	}, nil
+}
+
+// blindedSecretDecrypter decrypts the secrets that ConvertEngineEvent blinded to the placeholder "[secret]".
+type blindedSecretDecrypter struct{}
+
+func (blindedSecretDecrypter) DecryptValue(ciphertext string) (string, error) {
+	return `"[secret]"`, nil
+}
+
+func convertJSONStepEventStateMetadata(md *apitype.StepEventStateMetadata) (*engine.StepEventStateMetadata, error) {
+	if md == nil {
+		return nil, nil
+	}
+
+	inputs, err := stack.DeserializeProperties(md.Inputs, blindedSecretDecrypter{}, config.BlindingCrypter)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := stack.DeserializeProperties(md.Outputs, blindedSecretDecrypter{}, config.BlindingCrypter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &engine.StepEventStateMetadata{
+		Type: tokens.Type(md.Type),
+		URN:  resource.URN(md.URN),
+
+		Custom:     md.Custom,
+		Delete:     md.Delete,
+		ID:         resource.ID(md.ID),
+		Parent:     resource.URN(md.Parent),
+		Protect:    md.Protect,
+		Inputs:     inputs,
+		Outputs:    outputs,
+		Provider:   md.Provider,
+		InitErrors: md.InitErrors,
+	}, nil
+}
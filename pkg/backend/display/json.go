@@ -83,10 +83,12 @@ func stateForJSONOutput(s *resource.State, opts Options) *resource.State {
 		outputs = resource.PropertyMap{}
 	}
 
-	return resource.NewState(s.Type, s.URN, s.Custom, s.Delete, s.ID, inputs,
+	state := resource.NewState(s.Type, s.URN, s.Custom, s.Delete, s.ID, inputs,
 		outputs, s.Parent, s.Protect, s.External, s.Dependencies, s.InitErrors, s.Provider,
 		s.PropertyDependencies, s.PendingReplacement, s.AdditionalSecretOutputs, s.Aliases, &s.CustomTimeouts,
 		s.ImportID)
+	state.SerializationGroup = s.SerializationGroup
+	return state
 }
 
 // ShowJSONEvents renders engine events from a preview into a well-formed JSON document. Note that this does not
@@ -175,7 +177,7 @@ func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.E
 
 				digest.Steps = append(digest.Steps, step)
 			}
-		case engine.ResourceOutputsEvent, engine.ResourceOperationFailed:
+		case engine.ResourceOutputsEvent, engine.ResourceOperationFailed, engine.HoldEvent:
 			// Because we are only JSON serializing previews, we don't need to worry about outputs
 			// resolving or operations failing. In the future, if we serialize actual deployments, we will
 			// need to come up with a scheme for matching the failure to the associated step.
@@ -187,6 +189,7 @@ func ShowJSONEvents(op string, action apitype.UpdateKind, events <-chan engine.E
 			digest.Duration = p.Duration
 			digest.ChangeSummary = p.ResourceChanges
 			digest.MaybeCorrupt = p.MaybeCorrupt
+			digest.ConfigUsage = p.ConfigUsage
 		default:
 			contract.Failf("unknown event type '%s'", e.Type)
 		}
@@ -215,6 +218,8 @@ type previewDigest struct {
 	ChangeSummary engine.ResourceChanges `json:"changeSummary,omitempty"`
 	// MaybeCorrupt indicates whether one or more resources may be corrupt.
 	MaybeCorrupt bool `json:"maybeCorrupt,omitempty"`
+	// ConfigUsage records the configuration keys read by the program, if the program reported them.
+	ConfigUsage *engine.ConfigUsage `json:"configUsage,omitempty"`
 }
 
 // propertyDiff contains information about the difference in a single property value.
@@ -990,6 +990,12 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	case engine.StdoutColorEvent:
 		display.handleSystemEvent(event.Payload().(engine.StdoutEventPayload))
 		return
+	case engine.HoldEvent:
+		display.handleSystemEvent(engine.StdoutEventPayload{
+			Message: renderHoldEvent(event.Payload().(engine.HoldEventPayload)) + "\n",
+			Color:   display.opts.Color,
+		})
+		return
 	}
 
 	// At this point, all events should relate to resources.
@@ -77,6 +77,10 @@ func ShowWatchEvents(op string, action apitype.UpdateKind, events <-chan engine.
 				PrintfWithWatchPrefix(time.Now(), string(p.Metadata.URN.Name()),
 					"failed %s %s\n", p.Metadata.Op, p.Metadata.URN.Type())
 			}
+		case engine.HoldEvent:
+			p := e.Payload().(engine.HoldEventPayload)
+			PrintfWithWatchPrefix(time.Now(), string(p.URN.Name()),
+				"%s\n", opts.Color.Colorize(renderHoldEvent(p)))
 		default:
 			contract.Failf("unknown event type '%s'", e.Type)
 		}
@@ -0,0 +1,196 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/secrets"
+	"github.com/pulumi/pulumi/pkg/v2/util/cancel"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// EmbeddedStack runs previews, updates, refreshes and destroys of a single stack in-process, without a backend or the
+// CLI. It is intended for Go programs that orchestrate deployments themselves, e.g. custom deployment services.
+//
+// The caller owns the stack's state: the stack's current snapshot is read from Target.Snapshot, and each new snapshot
+// is handed to Persister as the deployment progresses. Once an operation completes, Target.Snapshot is replaced by the
+// last snapshot that was persisted, so the same EmbeddedStack may be used for subsequent operations. An EmbeddedStack
+// must not be used by more than one operation at a time.
+//
+// The plugins used by an operation are loaded by the engine.UpdateOptions.Host that is passed to it, or from the
+// workspace if that host is nil.
+type EmbeddedStack struct {
+	// Root is the directory that contains the stack's project.
+	Root string
+	// Project is the stack's project.
+	Project *workspace.Project
+	// Target is the stack being deployed, including its configuration and current snapshot.
+	Target *deploy.Target
+	// Persister saves the snapshots produced by operations that change the stack. It is not used by previews.
+	Persister SnapshotPersister
+	// Events, if non-nil, is called with every event emitted by the engine, in order. It must not block.
+	Events func(engine.Event)
+	// BackendClient, if non-nil, resolves references to the outputs of other stacks.
+	BackendClient deploy.BackendClient
+}
+
+// Preview computes the changes that an update of the stack would make without making them.
+func (s *EmbeddedStack) Preview(ctx context.Context,
+	opts engine.UpdateOptions) (engine.ResourceChanges, result.Result) {
+
+	return s.run(ctx, true, func(engineCtx *engine.Context) (engine.ResourceChanges, result.Result) {
+		return engine.Update(s, engineCtx, opts, true)
+	})
+}
+
+// Update runs the stack's program and makes the resulting changes to its resources.
+func (s *EmbeddedStack) Update(ctx context.Context,
+	opts engine.UpdateOptions) (engine.ResourceChanges, result.Result) {
+
+	return s.run(ctx, false, func(engineCtx *engine.Context) (engine.ResourceChanges, result.Result) {
+		return engine.Update(s, engineCtx, opts, false)
+	})
+}
+
+// Refresh reconciles the stack's state with the actual state of its resources.
+func (s *EmbeddedStack) Refresh(ctx context.Context,
+	opts engine.UpdateOptions) (engine.ResourceChanges, result.Result) {
+
+	return s.run(ctx, false, func(engineCtx *engine.Context) (engine.ResourceChanges, result.Result) {
+		return engine.Refresh(s, engineCtx, opts, false)
+	})
+}
+
+// Destroy deletes all of the stack's resources.
+func (s *EmbeddedStack) Destroy(ctx context.Context,
+	opts engine.UpdateOptions) (engine.ResourceChanges, result.Result) {
+
+	return s.run(ctx, false, func(engineCtx *engine.Context) (engine.ResourceChanges, result.Result) {
+		return engine.Destroy(s, engineCtx, opts, false)
+	})
+}
+
+// GetRoot implements engine.UpdateInfo.
+func (s *EmbeddedStack) GetRoot() string {
+	return s.Root
+}
+
+// GetProject implements engine.UpdateInfo.
+func (s *EmbeddedStack) GetProject() *workspace.Project {
+	return s.Project
+}
+
+// GetTarget implements engine.UpdateInfo.
+func (s *EmbeddedStack) GetTarget() *deploy.Target {
+	return s.Target
+}
+
+// run wires up the engine context for a single operation and invokes it. Cancelling ctx requests that the operation
+// stop gracefully, i.e. that it finish the steps that are in flight but start no new ones.
+func (s *EmbeddedStack) run(ctx context.Context, dryRun bool,
+	op func(*engine.Context) (engine.ResourceChanges, result.Result)) (engine.ResourceChanges, result.Result) {
+
+	contract.Require(s.Project != nil, "Project")
+	contract.Require(s.Target != nil, "Target")
+	contract.Require(dryRun || s.Persister != nil, "Persister")
+
+	cancelCtx, cancelSource := cancel.NewContext(context.Background())
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancelSource.Cancel()
+		case <-stop:
+		}
+	}()
+
+	events := make(chan engine.Event)
+	eventsDone := make(chan bool)
+	go func() {
+		for e := range events {
+			if s.Events != nil {
+				s.Events(e)
+			}
+		}
+		close(eventsDone)
+	}()
+
+	engineCtx := &engine.Context{
+		Cancel:        cancelCtx,
+		Events:        events,
+		BackendClient: s.BackendClient,
+	}
+
+	var persister *embeddedPersister
+	var manager *SnapshotManager
+	if !dryRun {
+		persister = &embeddedPersister{inner: s.Persister}
+		manager = NewSnapshotManager(persister, s.Target.Snapshot)
+		engineCtx.SnapshotManager = manager
+	}
+
+	changes, res := op(engineCtx)
+
+	close(events)
+	<-eventsDone
+
+	if manager != nil {
+		if err := manager.Close(); err != nil && res == nil {
+			res = result.FromError(err)
+		}
+		if snap, ok := persister.last(); ok {
+			s.Target.Snapshot = snap
+		}
+	}
+
+	return changes, res
+}
+
+// embeddedPersister forwards snapshots to an EmbeddedStack's persister and remembers the last one that was saved.
+type embeddedPersister struct {
+	inner SnapshotPersister
+
+	m     sync.Mutex
+	snap  *deploy.Snapshot
+	saved bool
+}
+
+func (p *embeddedPersister) Save(snapshot *deploy.Snapshot) error {
+	if err := p.inner.Save(snapshot); err != nil {
+		return err
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.snap, p.saved = snapshot, true
+	return nil
+}
+
+func (p *embeddedPersister) SecretsManager() secrets.Manager {
+	return p.inner.SecretsManager()
+}
+
+func (p *embeddedPersister) last() (*deploy.Snapshot, bool) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.snap, p.saved
+}
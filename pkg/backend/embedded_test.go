@@ -0,0 +1,80 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestEmbeddedStack(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	persister := &MockStackPersister{}
+	var events []engine.Event
+	stack := &EmbeddedStack{
+		Project: &workspace.Project{
+			Name:    "test",
+			Runtime: workspace.NewProjectRuntimeInfo("test", nil),
+		},
+		Target:    &deploy.Target{Name: "test", Config: config.Map{}},
+		Persister: persister,
+		Events:    func(e engine.Event) { events = append(events, e) },
+	}
+	opts := engine.UpdateOptions{Host: host}
+
+	// A preview must neither persist nor change the stack's snapshot.
+	_, res := stack.Preview(context.Background(), opts)
+	assert.Nil(t, res)
+	assert.Empty(t, persister.SavedSnapshots)
+	assert.Nil(t, stack.Target.Snapshot)
+	assert.NotEmpty(t, events)
+
+	// An update creates the provider and the resource, and the stack's snapshot reflects them.
+	_, res = stack.Update(context.Background(), opts)
+	assert.Nil(t, res)
+	assert.NotEmpty(t, persister.SavedSnapshots)
+	if assert.NotNil(t, stack.Target.Snapshot) {
+		assert.Len(t, stack.Target.Snapshot.Resources, 2)
+	}
+
+	// A destroy starts from the snapshot left by the update and deletes everything.
+	_, res = stack.Destroy(context.Background(), opts)
+	assert.Nil(t, res)
+	if assert.NotNil(t, stack.Target.Snapshot) {
+		assert.Len(t, stack.Target.Snapshot.Resources, 0)
+	}
+}
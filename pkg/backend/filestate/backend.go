@@ -24,6 +24,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -49,6 +50,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
@@ -381,6 +383,20 @@ func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack, new
 	file := b.stackPath(stackName)
 	backupTarget(b.bucket, file)
 
+	// Carry the stack's freeze, if any, over to its new name.
+	freeze, err := b.getFreeze(ctx, stackName)
+	if err != nil {
+		return err
+	}
+	if freeze != nil {
+		if err = b.saveFreeze(ctx, newName, freeze); err != nil {
+			return err
+		}
+		if err = b.saveFreeze(ctx, stackName, nil); err != nil {
+			return err
+		}
+	}
+
 	// And rename the histoy folder as well.
 	return b.renameHistory(stackName, newName)
 }
@@ -414,7 +430,7 @@ func (b *localBackend) Preview(ctx context.Context, stack backend.Stack,
 		DryRun:   true,
 		ShowLink: true,
 	}
-	return b.apply(ctx, apitype.PreviewUpdate, stack, op, opts, nil /*events*/)
+	return b.apply(ctx, apitype.PreviewUpdate, stack, op, opts, op.Events)
 }
 
 func (b *localBackend) Update(ctx context.Context, stack backend.Stack,
@@ -474,11 +490,15 @@ func (b *localBackend) apply(
 	// Create a separate event channel for engine events that we'll pipe to both listening streams.
 	engineEvents := make(chan engine.Event)
 
+	// Record the update's events alongside its history so they can be replayed by `pulumi logs --events`.
+	eventLog := newEventLog()
+
 	scope := op.Scopes.NewScope(engineEvents, opts.DryRun)
 	eventsDone := make(chan bool)
 	go func() {
 		// Pull in all events from the engine and send them to the two listeners.
 		for e := range engineEvents {
+			eventLog.record(e)
 			displayEvents <- e
 
 			// If the caller also wants to see the events, stream them there also.
@@ -550,7 +570,10 @@ func (b *localBackend) apply(
 	var saveErr error
 	var backupErr error
 	if !opts.DryRun {
-		saveErr = b.addToHistory(stackName, info)
+		var events []byte
+		if events, saveErr = eventLog.bytes(); saveErr == nil {
+			saveErr = b.addToHistory(stackName, info, events)
+		}
 		backupErr = b.backupStack(stackName)
 	}
 
@@ -613,6 +636,12 @@ func (b *localBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 	return updates, nil
 }
 
+func (b *localBackend) GetUpdateEvents(ctx context.Context, stackRef backend.StackReference,
+	version int) ([]apitype.EngineEvent, error) {
+
+	return b.getUpdateEvents(stackRef.Name(), version)
+}
+
 func (b *localBackend) GetLogs(ctx context.Context, stack backend.Stack, cfg backend.StackConfiguration,
 	query operations.LogQuery) ([]operations.LogEntry, error) {
 
@@ -635,8 +664,16 @@ func GetLogsForTarget(target *deploy.Target, query operations.LogQuery) ([]opera
 		return nil, err
 	}
 
+	// Resource providers are asked for logs first, so give them a host to run in for the duration of the query.
+	plugctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(plugctx)
+
 	components := operations.NewResourceTree(target.Snapshot.Resources)
-	ops := components.OperationsProvider(config)
+	ops := components.OperationsProvider(config,
+		operations.NewProviderSource(plugctx.Host, target.Snapshot.Resources))
 	logs, err := ops.GetLogs(query)
 	if logs == nil {
 		return nil, err
@@ -673,6 +710,18 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 	}, nil
 }
 
+// ExportDeploymentForVersion exports the deployment recorded in a stack's history by the given update. As with the
+// Pulumi Console, the first update is version "1", the second "2", and so on.
+func (b *localBackend) ExportDeploymentForVersion(ctx context.Context, stk backend.Stack,
+	version string) (*apitype.UntypedDeployment, error) {
+
+	versionNumber, err := strconv.Atoi(version)
+	if err != nil || versionNumber <= 0 {
+		return nil, errors.Errorf("%q is not a valid stack version. It should be a positive integer.", version)
+	}
+	return b.loadHistoryDeployment(stk.Ref().Name(), versionNumber)
+}
+
 func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 	deployment *apitype.UntypedDeployment) error {
 
@@ -0,0 +1,217 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/fsutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+const (
+	// checkpointFileSuffix is the suffix of a full copy of a checkpoint in a stack's history. Older versions of the
+	// CLI recorded each update's checkpoint this way.
+	checkpointFileSuffix = ".checkpoint.json"
+	// chunkedCheckpointFileSuffix is the suffix of a checkpoint in a stack's history whose resources are stored as
+	// content-addressed chunks. Such a file is only meaningful together with the stack's chunk store under
+	// `.pulumi/chunks/<stack>`: copying or restoring a history directory without it leaves checkpoints that cannot be
+	// read.
+	chunkedCheckpointFileSuffix = ".checkpoint.chunked.json"
+)
+
+// chunkedCheckpoint is a checkpoint whose latest deployment's resources are stored as content-addressed chunks. A
+// resource that is unchanged across many updates is stored only once, however many checkpoints refer to it.
+type chunkedCheckpoint struct {
+	// Version is the version of the checkpoint.
+	Version int `json:"version"`
+	// Checkpoint is the checkpoint, without the resources of its latest deployment.
+	Checkpoint json.RawMessage `json:"checkpoint"`
+	// Resources are the keys of the chunks that hold the resources of the latest deployment, in order.
+	Resources []string `json:"resources,omitempty"`
+}
+
+func (b *localBackend) chunkDirectory(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.ChunkDir, fsutil.QnamePath(stack))
+}
+
+// saveHistoryCheckpoint records the stack's current checkpoint in its history under the given path prefix, writing
+// only the resources that are not already in the stack's chunk store.
+func (b *localBackend) saveHistoryCheckpoint(name tokens.QName, pathPrefix string) error {
+	data, err := b.bucket.ReadAll(context.TODO(), b.stackPath(name))
+	if err != nil {
+		return err
+	}
+
+	var versioned apitype.VersionedCheckpoint
+	if err = json.Unmarshal(data, &versioned); err != nil {
+		return errors.Wrap(err, "reading checkpoint")
+	}
+	var checkpoint map[string]json.RawMessage
+	if err = json.Unmarshal(versioned.Checkpoint, &checkpoint); err != nil {
+		return errors.Wrap(err, "reading checkpoint")
+	}
+
+	var keys []string
+	if latest, ok := checkpoint["latest"]; ok && string(latest) != "null" {
+		header, resources, err := backend.SplitDeployment(latest)
+		if err != nil {
+			return err
+		}
+		checkpoint["latest"] = header
+
+		// List the chunk store once rather than checking for each chunk, which would cost a request per resource
+		// against cloud storage.
+		chunkDir := b.chunkDirectory(name)
+		existing, err := b.listChunks(name)
+		if err != nil {
+			return err
+		}
+		for _, res := range resources {
+			key := backend.ChunkKey(res)
+			keys = append(keys, key)
+			if existing[key] {
+				continue
+			}
+
+			chunkFile := path.Join(chunkDir, key+".json")
+			if err = b.bucket.WriteAll(context.TODO(), chunkFile, res, nil); err != nil {
+				return errors.Wrap(err, "writing checkpoint chunk")
+			}
+			existing[key] = true
+		}
+	}
+
+	chunked := chunkedCheckpoint{Version: versioned.Version, Resources: keys}
+	if chunked.Checkpoint, err = json.Marshal(checkpoint); err != nil {
+		return err
+	}
+	byts, err := json.MarshalIndent(&chunked, "", "    ")
+	if err != nil {
+		return err
+	}
+	return b.bucket.WriteAll(context.TODO(), pathPrefix+chunkedCheckpointFileSuffix, byts, nil)
+}
+
+// listChunks returns the keys of the chunks in the stack's chunk store.
+func (b *localBackend) listChunks(name tokens.QName) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	files, err := listBucket(b.bucket, b.chunkDirectory(name))
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return keys, nil
+		}
+		return nil, err
+	}
+	for _, file := range files {
+		keys[strings.TrimSuffix(objectName(file), ".json")] = true
+	}
+	return keys, nil
+}
+
+// loadHistoryCheckpoint returns the checkpoint recorded in a stack's history under the given path prefix, in the form
+// of the stack's checkpoint file. It is the one place that knows how to reassemble history checkpoints from the chunk
+// store, so anything that reads them must go through it rather than reading the history files directly.
+//
+// Chunks are shared by every checkpoint in the stack's history, and are only deleted when the stack itself is
+// removed. Nothing prunes individual history entries, so nothing garbage-collects unreferenced chunks either.
+func (b *localBackend) loadHistoryCheckpoint(name tokens.QName, pathPrefix string) ([]byte, error) {
+	data, err := b.bucket.ReadAll(context.TODO(), pathPrefix+chunkedCheckpointFileSuffix)
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return b.bucket.ReadAll(context.TODO(), pathPrefix+checkpointFileSuffix)
+		}
+		return nil, err
+	}
+
+	var chunked chunkedCheckpoint
+	if err = json.Unmarshal(data, &chunked); err != nil {
+		return nil, errors.Wrap(err, "reading checkpoint")
+	}
+	var checkpoint map[string]json.RawMessage
+	if err = json.Unmarshal(chunked.Checkpoint, &checkpoint); err != nil {
+		return nil, errors.Wrap(err, "reading checkpoint")
+	}
+
+	if len(chunked.Resources) > 0 {
+		chunkDir := b.chunkDirectory(name)
+		resources := make([]json.RawMessage, len(chunked.Resources))
+		for i, key := range chunked.Resources {
+			if resources[i], err = b.bucket.ReadAll(context.TODO(), path.Join(chunkDir, key+".json")); err != nil {
+				return nil, errors.Wrapf(err, "reading checkpoint chunk %s", key)
+			}
+		}
+		latest, err := backend.JoinDeployment(checkpoint["latest"], resources)
+		if err != nil {
+			return nil, err
+		}
+		checkpoint["latest"] = latest
+	}
+
+	versioned := apitype.VersionedCheckpoint{Version: chunked.Version}
+	if versioned.Checkpoint, err = json.Marshal(checkpoint); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(&versioned, "", "    ")
+}
+
+// loadHistoryDeployment returns the deployment recorded in a stack's history by the given update. Updates are numbered
+// from 1, oldest first.
+func (b *localBackend) loadHistoryDeployment(name tokens.QName, version int) (*apitype.UntypedDeployment, error) {
+	prefixes, err := b.historyPrefixes(name)
+	if err != nil {
+		return nil, err
+	}
+	if version < 1 || version > len(prefixes) {
+		return nil, errors.Errorf("stack '%s' has no update %d; its updates are numbered 1 through %d",
+			name, version, len(prefixes))
+	}
+
+	data, err := b.loadHistoryCheckpoint(name, prefixes[version-1])
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil, errors.Errorf("no checkpoint was recorded for update %d of stack '%s'", version, name)
+		}
+		return nil, err
+	}
+	chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pass the deployment through as it was recorded, so that its secrets stay encrypted.
+	deployment := chk.Latest
+	if deployment == nil {
+		deployment = &apitype.DeploymentV3{}
+	}
+	bytes, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+	return &apitype.UntypedDeployment{Version: 3, Deployment: bytes}, nil
+}
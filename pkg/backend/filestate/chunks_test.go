@@ -0,0 +1,104 @@
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v2/secrets/b64"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func TestHistoryCheckpointChunks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	mustNotHaveError(t, "TempDir", err)
+	defer os.RemoveAll(dir)
+
+	be, err := New(cmdutil.Diag(), "file://"+filepath.ToSlash(dir))
+	mustNotHaveError(t, "New", err)
+	b := be.(*localBackend)
+
+	name := tokens.QName("dev")
+	sm := b64.NewBase64SecretsManager()
+	newResource := func(name string, value string) *resource.State {
+		return &resource.State{
+			Type:    "test:index:Resource",
+			URN:     resource.NewURN("dev", "proj", "", "test:index:Resource", tokens.QName(name)),
+			Inputs:  resource.PropertyMap{},
+			Outputs: resource.PropertyMap{"value": resource.NewStringProperty(value)},
+		}
+	}
+	save := func(prefix string, resources ...*resource.State) string {
+		snap := deploy.NewSnapshot(deploy.Manifest{Time: time.Now()}, sm, resources, nil)
+		_, err := b.saveStack(name, snap, sm)
+		mustNotHaveError(t, "saveStack", err)
+		pathPrefix := path.Join(b.historyDirectory(name), prefix)
+		mustNotHaveError(t, "saveHistoryCheckpoint", b.saveHistoryCheckpoint(name, pathPrefix))
+		return pathPrefix
+	}
+	load := func(pathPrefix string) map[resource.URN]string {
+		data, err := b.loadHistoryCheckpoint(name, pathPrefix)
+		mustNotHaveError(t, "loadHistoryCheckpoint", err)
+		chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(data)
+		mustNotHaveError(t, "UnmarshalVersionedCheckpointToLatestCheckpoint", err)
+		snap, err := stack.DeserializeCheckpoint(chk)
+		mustNotHaveError(t, "DeserializeCheckpoint", err)
+		values := make(map[resource.URN]string)
+		for _, res := range snap.Resources {
+			values[res.URN] = res.Outputs["value"].StringValue()
+		}
+		return values
+	}
+
+	a, b1, b2 := newResource("a", "1"), newResource("b", "1"), newResource("b", "2")
+	first := save("dev-1", a, b1)
+	second := save("dev-2", a, b2)
+
+	// The unchanged resource is stored only once.
+	chunks, err := listBucket(b.bucket, b.chunkDirectory(name))
+	mustNotHaveError(t, "listBucket", err)
+	assert.Len(t, chunks, 3)
+
+	assert.Equal(t, map[resource.URN]string{a.URN: "1", b1.URN: "1"}, load(first))
+	assert.Equal(t, map[resource.URN]string{a.URN: "1", b2.URN: "2"}, load(second))
+
+	// Checkpoints recorded in full by older versions of the CLI are still readable.
+	legacy := path.Join(b.historyDirectory(name), "dev-3")
+	err = b.bucket.Copy(context.TODO(), legacy+checkpointFileSuffix, b.stackPath(name), nil)
+	mustNotHaveError(t, "Copy", err)
+	assert.Equal(t, map[resource.URN]string{a.URN: "1", b2.URN: "2"}, load(legacy))
+
+	// Each update's checkpoint can be exported from the stack's history.
+	for _, res := range []*resource.State{b1, b2} {
+		snap := deploy.NewSnapshot(deploy.Manifest{Time: time.Now()}, sm, []*resource.State{a, res}, nil)
+		_, err := b.saveStack(name, snap, sm)
+		mustNotHaveError(t, "saveStack", err)
+		mustNotHaveError(t, "addToHistory", b.addToHistory(name, backend.UpdateInfo{}, nil))
+	}
+	exported := func(version int) map[resource.URN]string {
+		deployment, err := b.loadHistoryDeployment(name, version)
+		mustNotHaveError(t, "loadHistoryDeployment", err)
+		snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+		mustNotHaveError(t, "DeserializeUntypedDeployment", err)
+		values := make(map[resource.URN]string)
+		for _, res := range snap.Resources {
+			values[res.URN] = res.Outputs["value"].StringValue()
+		}
+		return values
+	}
+	assert.Equal(t, map[resource.URN]string{a.URN: "1", b1.URN: "1"}, exported(1))
+	assert.Equal(t, map[resource.URN]string{a.URN: "1", b2.URN: "2"}, exported(2))
+	_, err = b.loadHistoryDeployment(name, 3)
+	assert.Error(t, err)
+}
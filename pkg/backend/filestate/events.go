@@ -0,0 +1,197 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// EventLogRetentionEnvVar is the number of most recent updates per stack whose engine events are kept. When unset,
+// the events of every update are kept.
+const EventLogRetentionEnvVar = "PULUMI_EVENT_LOG_RETENTION"
+
+const (
+	historyFileSuffix = ".history.json"
+	eventsFileSuffix  = ".events.json.gz"
+)
+
+// eventLog records the engine events of an update as a gzipped stream of JSON-encoded apitype.EngineEvents.
+type eventLog struct {
+	buf      bytes.Buffer
+	gz       *gzip.Writer
+	encoder  *json.Encoder
+	sequence int
+}
+
+func newEventLog() *eventLog {
+	l := &eventLog{}
+	l.gz = gzip.NewWriter(&l.buf)
+	l.encoder = json.NewEncoder(l.gz)
+	return l
+}
+
+// record appends an event to the log. Events that cannot be converted are skipped rather than failing the update.
+func (l *eventLog) record(e engine.Event) {
+	apiEvent, err := display.ConvertEngineEvent(e)
+	if err != nil {
+		logging.V(7).Infof("failed to record event: %v", err)
+		return
+	}
+	apiEvent.Sequence, l.sequence = l.sequence, l.sequence+1
+	apiEvent.Timestamp = int(time.Now().Unix())
+	if err = l.encoder.Encode(apiEvent); err != nil {
+		logging.V(7).Infof("failed to record event: %v", err)
+	}
+}
+
+// bytes flushes the log and returns its compressed contents.
+func (l *eventLog) bytes() ([]byte, error) {
+	if err := l.gz.Close(); err != nil {
+		return nil, err
+	}
+	return l.buf.Bytes(), nil
+}
+
+// decodeEventLog reads the events written by an eventLog.
+func decodeEventLog(data []byte) ([]apitype.EngineEvent, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(gz)
+
+	var events []apitype.EngineEvent
+	decoder := json.NewDecoder(gz)
+	for {
+		var e apitype.EngineEvent
+		if err := decoder.Decode(&e); err != nil {
+			if err == io.EOF {
+				return events, nil
+			}
+			return nil, err
+		}
+		events = append(events, e)
+	}
+}
+
+// eventLogRetention returns the number of updates whose events should be kept, or -1 if there is no limit.
+func eventLogRetention() int {
+	v := os.Getenv(EventLogRetentionEnvVar)
+	if v == "" {
+		return -1
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		logging.V(7).Infof("ignoring invalid %s value %q", EventLogRetentionEnvVar, v)
+		return -1
+	}
+	return n
+}
+
+// historyPrefixes returns the path prefixes of a stack's history entries, oldest first.
+func (b *localBackend) historyPrefixes(name tokens.QName) ([]string, error) {
+	allFiles, err := listBucket(b.bucket, b.historyDirectory(name))
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var prefixes []string
+	for _, file := range allFiles {
+		if strings.HasSuffix(file.Key, historyFileSuffix) {
+			prefixes = append(prefixes, strings.TrimSuffix(file.Key, historyFileSuffix))
+		}
+	}
+	return prefixes, nil
+}
+
+// pruneEventLogs deletes the event logs of all but the most recent updates allowed by EventLogRetentionEnvVar.
+func (b *localBackend) pruneEventLogs(name tokens.QName) error {
+	keep := eventLogRetention()
+	if keep < 0 {
+		return nil
+	}
+
+	prefixes, err := b.historyPrefixes(name)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(prefixes)-keep; i++ {
+		eventsFile := prefixes[i] + eventsFileSuffix
+		exists, err := b.bucket.Exists(context.TODO(), eventsFile)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err = b.bucket.Delete(context.TODO(), eventsFile); err != nil {
+				return errors.Wrap(err, "deleting event log")
+			}
+		}
+	}
+	return nil
+}
+
+func (b *localBackend) getUpdateEvents(name tokens.QName, version int) ([]apitype.EngineEvent, error) {
+	contract.Require(name != "", "name")
+
+	prefixes, err := b.historyPrefixes(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(prefixes) == 0 {
+		return nil, errors.Errorf("stack '%s' has no updates", name)
+	}
+	if version == 0 {
+		version = len(prefixes)
+	}
+	if version < 1 || version > len(prefixes) {
+		return nil, errors.Errorf("stack '%s' has no update %d; its updates are numbered 1 through %d",
+			name, version, len(prefixes))
+	}
+
+	eventsFile := prefixes[version-1] + eventsFileSuffix
+	data, err := b.bucket.ReadAll(context.TODO(), eventsFile)
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil, errors.Errorf("no events were recorded for update %d of stack '%s'", version, name)
+		}
+		return nil, errors.Wrapf(err, "reading event log %s", eventsFile)
+	}
+	events, err := decodeEventLog(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading event log %s", eventsFile)
+	}
+	return events, nil
+}
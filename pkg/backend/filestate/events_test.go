@@ -0,0 +1,69 @@
+package filestate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+)
+
+func TestEventLogRoundTrip(t *testing.T) {
+	log := newEventLog()
+	log.record(engine.NewEvent(engine.StdoutColorEvent, engine.StdoutEventPayload{
+		Message: "hello",
+		Color:   colors.Never,
+	}))
+	log.record(engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+		Message:  "oops",
+		Color:    colors.Never,
+		Severity: diag.Warning,
+	}))
+	log.record(engine.NewEvent(engine.CancelEvent, nil))
+
+	data, err := log.bytes()
+	assert.NoError(t, err)
+
+	events, err := decodeEventLog(data)
+	assert.NoError(t, err)
+	if !assert.Len(t, events, 3) {
+		return
+	}
+	for i, e := range events {
+		assert.Equal(t, i, e.Sequence)
+	}
+
+	replayed, err := display.ConvertJSONEvent(events[0])
+	assert.NoError(t, err)
+	assert.Equal(t, engine.StdoutColorEvent, replayed.Type)
+	assert.Equal(t, "hello", replayed.Payload().(engine.StdoutEventPayload).Message)
+
+	replayed, err = display.ConvertJSONEvent(events[1])
+	assert.NoError(t, err)
+	assert.Equal(t, engine.DiagEvent, replayed.Type)
+	assert.Equal(t, diag.Warning, replayed.Payload().(engine.DiagEventPayload).Severity)
+
+	replayed, err = display.ConvertJSONEvent(events[2])
+	assert.NoError(t, err)
+	assert.Equal(t, engine.CancelEvent, replayed.Type)
+}
+
+func TestEventLogRetention(t *testing.T) {
+	defer os.Unsetenv(EventLogRetentionEnvVar)
+
+	os.Unsetenv(EventLogRetentionEnvVar)
+	assert.Equal(t, -1, eventLogRetention())
+
+	os.Setenv(EventLogRetentionEnvVar, "5")
+	assert.Equal(t, 5, eventLogRetention())
+
+	os.Setenv(EventLogRetentionEnvVar, "0")
+	assert.Equal(t, 0, eventLogRetention())
+
+	os.Setenv(EventLogRetentionEnvVar, "lots")
+	assert.Equal(t, -1, eventLogRetention())
+}
@@ -0,0 +1,86 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/fsutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// freezePath returns the path of the file that holds the freeze of the given stack, if the stack is frozen. Freezes
+// are kept apart from the stack's checkpoint so that they survive the updates that override them.
+func (b *localBackend) freezePath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.FreezeDir, fsutil.QnamePath(stack)+".json")
+}
+
+// getFreeze returns the freeze of the given stack, or nil if the stack is not frozen.
+func (b *localBackend) getFreeze(ctx context.Context, stack tokens.QName) (*apitype.StackFreeze, error) {
+	file := b.freezePath(stack)
+	exists, err := b.bucket.Exists(ctx, file)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	bytes, err := b.bucket.ReadAll(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	var freeze apitype.StackFreeze
+	if err = json.Unmarshal(bytes, &freeze); err != nil {
+		return nil, errors.Wrapf(err, "reading the freeze of stack '%s'", stack)
+	}
+	return &freeze, nil
+}
+
+// saveFreeze records the freeze of the given stack, or removes the stack's freeze if the freeze is nil.
+func (b *localBackend) saveFreeze(ctx context.Context, stack tokens.QName, freeze *apitype.StackFreeze) error {
+	file := b.freezePath(stack)
+	if freeze == nil {
+		exists, err := b.bucket.Exists(ctx, file)
+		if err != nil || !exists {
+			return err
+		}
+		return b.bucket.Delete(ctx, file)
+	}
+
+	bytes, err := json.Marshal(freeze)
+	if err != nil {
+		return err
+	}
+	return b.bucket.WriteAll(ctx, file, bytes, nil)
+}
+
+// GetStackFreeze fetches the stack's freeze, or nil if the stack is not frozen.
+func (b *localBackend) GetStackFreeze(ctx context.Context, stack backend.Stack) (*apitype.StackFreeze, error) {
+	return b.getFreeze(ctx, stack.Ref().Name())
+}
+
+// UpdateStackFreeze freezes the stack with the given freeze, or unfreezes it if the freeze is nil.
+func (b *localBackend) UpdateStackFreeze(ctx context.Context, stack backend.Stack,
+	freeze *apitype.StackFreeze) error {
+
+	return b.saveFreeze(ctx, stack.Ref().Name(), freeze)
+}
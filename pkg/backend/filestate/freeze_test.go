@@ -0,0 +1,69 @@
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func TestStackFreeze(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filestate")
+	mustNotHaveError(t, "TempDir", err)
+	defer os.RemoveAll(dir)
+
+	b, err := New(cmdutil.Diag(), "file://"+filepath.ToSlash(dir))
+	mustNotHaveError(t, "New", err)
+
+	ctx := context.Background()
+	ref, err := b.ParseStackReference("dev")
+	mustNotHaveError(t, "ParseStackReference", err)
+	s, err := b.CreateStack(ctx, ref, nil)
+	mustNotHaveError(t, "CreateStack", err)
+
+	// New stacks are not frozen.
+	freeze, err := b.GetStackFreeze(ctx, s)
+	mustNotHaveError(t, "GetStackFreeze", err)
+	assert.Nil(t, freeze)
+	assert.NoError(t, backend.CheckStackFreeze(ctx, s, false))
+
+	// Frozen stacks refuse changes unless the freeze is overridden.
+	mustNotHaveError(t, "UpdateStackFreeze", b.UpdateStackFreeze(ctx, s, &apitype.StackFreeze{Reason: "incident"}))
+	freeze, err = b.GetStackFreeze(ctx, s)
+	mustNotHaveError(t, "GetStackFreeze", err)
+	assert.Equal(t, &apitype.StackFreeze{Reason: "incident"}, freeze)
+	assert.Equal(t, backend.StackFrozenError{StackName: "dev", Reason: "incident"},
+		backend.CheckStackFreeze(ctx, s, false))
+	assert.NoError(t, backend.CheckStackFreeze(ctx, s, true))
+
+	// The freeze does not appear as a stack.
+	stacks, err := b.ListStacks(ctx, backend.ListStacksFilter{})
+	mustNotHaveError(t, "ListStacks", err)
+	assert.Len(t, stacks, 1)
+
+	// Renaming a stack keeps its freeze.
+	mustNotHaveError(t, "RenameStack", b.RenameStack(ctx, s, "prod"))
+	ref, err = b.ParseStackReference("prod")
+	mustNotHaveError(t, "ParseStackReference", err)
+	s, err = b.GetStack(ctx, ref)
+	mustNotHaveError(t, "GetStack", err)
+	freeze, err = b.GetStackFreeze(ctx, s)
+	mustNotHaveError(t, "GetStackFreeze", err)
+	assert.Equal(t, &apitype.StackFreeze{Reason: "incident"}, freeze)
+	old, err := b.(*localBackend).getFreeze(ctx, tokens.QName("dev"))
+	mustNotHaveError(t, "getFreeze", err)
+	assert.Nil(t, old)
+
+	// Unfreezing a stack allows changes again.
+	mustNotHaveError(t, "UpdateStackFreeze", b.UpdateStackFreeze(ctx, s, nil))
+	assert.NoError(t, backend.CheckStackFreeze(ctx, s, false))
+	mustNotHaveError(t, "UpdateStackFreeze", b.UpdateStackFreeze(ctx, s, nil))
+}
@@ -250,7 +250,13 @@ func (b *localBackend) removeStack(name tokens.QName) error {
 	backupTarget(b.bucket, file)
 
 	historyDir := b.historyDirectory(name)
-	return removeAllByPrefix(b.bucket, historyDir)
+	if err := removeAllByPrefix(b.bucket, historyDir); err != nil {
+		return err
+	}
+	if err := b.saveFreeze(context.TODO(), name, nil); err != nil {
+		return err
+	}
+	return removeAllByPrefix(b.bucket, b.chunkDirectory(name))
 }
 
 // backupTarget makes a backup of an existing file, in preparation for writing a new one.  Instead of a copy, it
@@ -387,11 +393,29 @@ func (b *localBackend) renameHistory(oldName tokens.QName, newName tokens.QName)
 		}
 	}
 
+	// Chunks are named by their contents rather than by the stack, so they move without being renamed.
+	oldChunks := b.chunkDirectory(oldName)
+	newChunks := b.chunkDirectory(newName)
+
+	allChunks, err := listBucket(b.bucket, oldChunks)
+	if err != nil {
+		if gcerrors.Code(errors.Cause(err)) == gcerrors.NotFound {
+			return nil
+		}
+		return err
+	}
+
+	for _, chunk := range allChunks {
+		if err := renameObject(b.bucket, chunk.Key, path.Join(newChunks, objectName(chunk))); err != nil {
+			return errors.Wrap(err, "moving checkpoint chunk")
+		}
+	}
+
 	return nil
 }
 
-// addToHistory saves the UpdateInfo and makes a copy of the current Checkpoint file.
-func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo) error {
+// addToHistory saves the UpdateInfo, the update's event log, and the current Checkpoint file.
+func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo, events []byte) error {
 	contract.Require(name != "", "name")
 
 	dir := b.historyDirectory(name)
@@ -410,7 +434,16 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 		return err
 	}
 
-	// Make a copy of the checkpoint file. (Assuming it already exists.)
-	checkpointFile := fmt.Sprintf("%s.checkpoint.json", pathPrefix)
-	return b.bucket.Copy(context.TODO(), checkpointFile, b.stackPath(name), nil)
+	if events != nil {
+		eventsFile := pathPrefix + eventsFileSuffix
+		if err = b.bucket.WriteAll(context.TODO(), eventsFile, events, nil); err != nil {
+			return err
+		}
+		if err = b.pruneEventLogs(name); err != nil {
+			return err
+		}
+	}
+
+	// Record the checkpoint file. (Assuming it already exists.)
+	return b.saveHistoryCheckpoint(name, pathPrefix)
 }
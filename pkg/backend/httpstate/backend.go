@@ -804,7 +804,7 @@ func (b *cloudBackend) Preview(ctx context.Context, stack backend.Stack,
 		ShowLink: true,
 	}
 	return b.apply(
-		ctx, apitype.PreviewUpdate, stack, op, opts, nil /*events*/)
+		ctx, apitype.PreviewUpdate, stack, op, opts, op.Events)
 }
 
 func (b *cloudBackend) Update(ctx context.Context, stack backend.Stack,
@@ -1096,6 +1096,14 @@ func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 	return beUpdates, nil
 }
 
+func (b *cloudBackend) GetUpdateEvents(ctx context.Context, stackRef backend.StackReference,
+	version int) ([]apitype.EngineEvent, error) {
+
+	// The Pulumi Service records the events of every update, but does not yet offer a way to download them.
+	return nil, errors.Errorf("replaying update events is not supported for stacks managed by %s; "+
+		"view update %d in the Pulumi Console instead", b.Name(), version)
+}
+
 func (b *cloudBackend) GetLatestConfiguration(ctx context.Context,
 	stack backend.Stack) (config.Map, error) {
 
@@ -1427,6 +1435,31 @@ func (b *cloudBackend) UpdateStackTags(ctx context.Context,
 	return b.client.UpdateStackTags(ctx, stackID, tags)
 }
 
+// GetStackFreeze fetches the stack's freeze, or nil if the stack is not frozen. Freezes are stored in the stack's tags.
+func (b *cloudBackend) GetStackFreeze(ctx context.Context, stack backend.Stack) (*apitype.StackFreeze, error) {
+	reason, ok := stack.(Stack).Tags()[apitype.StackFrozenTag]
+	if !ok {
+		return nil, nil
+	}
+	return &apitype.StackFreeze{Reason: reason}, nil
+}
+
+// UpdateStackFreeze freezes the stack with the given freeze, or unfreezes it if the freeze is nil.
+func (b *cloudBackend) UpdateStackFreeze(ctx context.Context, stack backend.Stack,
+	freeze *apitype.StackFreeze) error {
+
+	tags := make(map[apitype.StackTagName]string)
+	for k, v := range stack.(Stack).Tags() {
+		tags[k] = v
+	}
+	if freeze == nil {
+		delete(tags, apitype.StackFrozenTag)
+	} else {
+		tags[apitype.StackFrozenTag] = freeze.Reason
+	}
+	return b.UpdateStackTags(ctx, stack, tags)
+}
+
 type httpstateBackendClient struct {
 	backend Backend
 }
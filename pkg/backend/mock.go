@@ -48,8 +48,11 @@ type MockBackend struct {
 	QueryF                  func(context.Context, QueryOperation) result.Result
 	GetLatestConfigurationF func(context.Context, Stack) (config.Map, error)
 	GetHistoryF             func(context.Context, StackReference) ([]UpdateInfo, error)
+	GetUpdateEventsF        func(context.Context, StackReference, int) ([]apitype.EngineEvent, error)
 	GetStackTagsF           func(context.Context, Stack) (map[apitype.StackTagName]string, error)
 	UpdateStackTagsF        func(context.Context, Stack, map[apitype.StackTagName]string) error
+	GetStackFreezeF         func(context.Context, Stack) (*apitype.StackFreeze, error)
+	UpdateStackFreezeF      func(context.Context, Stack, *apitype.StackFreeze) error
 	ExportDeploymentF       func(context.Context, Stack) (*apitype.UntypedDeployment, error)
 	ImportDeploymentF       func(context.Context, Stack, *apitype.UntypedDeployment) error
 	LogoutF                 func() error
@@ -231,6 +234,15 @@ func (be *MockBackend) GetHistory(ctx context.Context, stackRef StackReference)
 	panic("not implemented")
 }
 
+func (be *MockBackend) GetUpdateEvents(ctx context.Context, stackRef StackReference,
+	version int) ([]apitype.EngineEvent, error) {
+
+	if be.GetUpdateEventsF != nil {
+		return be.GetUpdateEventsF(ctx, stackRef, version)
+	}
+	panic("not implemented")
+}
+
 func (be *MockBackend) GetLogs(ctx context.Context, stack Stack, cfg StackConfiguration,
 	query operations.LogQuery) ([]operations.LogEntry, error) {
 
@@ -267,6 +279,20 @@ func (be *MockBackend) UpdateStackTags(ctx context.Context, stack Stack,
 	panic("not implemented")
 }
 
+func (be *MockBackend) GetStackFreeze(ctx context.Context, stack Stack) (*apitype.StackFreeze, error) {
+	if be.GetStackFreezeF != nil {
+		return be.GetStackFreezeF(ctx, stack)
+	}
+	panic("not implemented")
+}
+
+func (be *MockBackend) UpdateStackFreeze(ctx context.Context, stack Stack, freeze *apitype.StackFreeze) error {
+	if be.UpdateStackFreezeF != nil {
+		return be.UpdateStackFreezeF(ctx, stack, freeze)
+	}
+	panic("not implemented")
+}
+
 func (be *MockBackend) ExportDeployment(ctx context.Context,
 	stack Stack) (*apitype.UntypedDeployment, error) {
 
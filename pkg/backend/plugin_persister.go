@@ -0,0 +1,130 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v2/secrets"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// PluginSnapshotPersister is a SnapshotPersister that stores a stack's checkpoints with a persister plugin. Rather
+// than sending each snapshot in its entirety, it sends the plugin only the resources that changed since the last
+// snapshot it saved.
+type PluginSnapshotPersister struct {
+	persister plugin.Persister
+	stackName tokens.QName
+	sm        secrets.Manager
+
+	stream    plugin.CheckpointStream // the stream to the plugin, opened by the first call to Save.
+	resources []json.RawMessage       // the resources of the last snapshot that was sent to the plugin.
+}
+
+var _ SnapshotPersister = (*PluginSnapshotPersister)(nil)
+
+// NewPluginSnapshotPersister creates a persister that stores the checkpoints of the given stack with the given plugin.
+// The persister must be closed once the update completes.
+func NewPluginSnapshotPersister(persister plugin.Persister, stackName tokens.QName,
+	sm secrets.Manager) *PluginSnapshotPersister {
+
+	return &PluginSnapshotPersister{persister: persister, stackName: stackName, sm: sm}
+}
+
+func (p *PluginSnapshotPersister) Save(snap *deploy.Snapshot) error {
+	deployment, err := stack.SerializeDeployment(snap, p.sm, false /* showSecrets */)
+	if err != nil {
+		return errors.Wrap(err, "serializing deployment")
+	}
+
+	resources := make([]json.RawMessage, len(deployment.Resources))
+	for i, res := range deployment.Resources {
+		if resources[i], err = json.Marshal(res); err != nil {
+			return errors.Wrap(err, "serializing resource")
+		}
+	}
+	deployment.Resources = nil
+	header, err := json.Marshal(deployment)
+	if err != nil {
+		return errors.Wrap(err, "serializing deployment")
+	}
+
+	if p.stream == nil {
+		if p.stream, err = p.persister.Save(p.stackName); err != nil {
+			return errors.Wrapf(err, "saving checkpoint with persister %s", p.persister.Name())
+		}
+	}
+
+	// Send only the run of resources that differs from the last snapshot.
+	start, end, oldEnd := 0, len(resources), len(p.resources)
+	for start < end && start < oldEnd && bytes.Equal(resources[start], p.resources[start]) {
+		start++
+	}
+	for end > start && oldEnd > start && bytes.Equal(resources[end-1], p.resources[oldEnd-1]) {
+		end, oldEnd = end-1, oldEnd-1
+	}
+
+	if err = p.stream.Send(plugin.CheckpointMutation{
+		Version:     apitype.DeploymentSchemaVersionCurrent,
+		Header:      header,
+		Start:       start,
+		DeleteCount: oldEnd - start,
+		Resources:   resources[start:end],
+	}); err != nil {
+		return errors.Wrapf(err, "saving checkpoint with persister %s", p.persister.Name())
+	}
+	p.resources = resources
+	return nil
+}
+
+func (p *PluginSnapshotPersister) SecretsManager() secrets.Manager {
+	return p.sm
+}
+
+// Close closes the persister's stream to the plugin, waiting until the plugin has made every snapshot durable. It
+// does not close the plugin itself.
+func (p *PluginSnapshotPersister) Close() error {
+	if p.stream == nil {
+		return nil
+	}
+	stream := p.stream
+	p.stream, p.resources = nil, nil
+	return stream.Close()
+}
+
+// LoadPluginSnapshot loads the latest snapshot of the given stack from a persister plugin. It returns nil if the stack
+// has no checkpoint.
+func LoadPluginSnapshot(persister plugin.Persister, stackName tokens.QName,
+	secretsProvider stack.SecretsProvider) (*deploy.Snapshot, error) {
+
+	version, deployment, err := persister.Load(stackName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading checkpoint with persister %s", persister.Name())
+	}
+	if version == 0 {
+		return nil, nil
+	}
+	return stack.DeserializeUntypedDeployment(&apitype.UntypedDeployment{
+		Version:    version,
+		Deployment: deployment,
+	}, secretsProvider)
+}
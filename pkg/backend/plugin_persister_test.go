@@ -0,0 +1,138 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v2/secrets/b64"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// MockPersisterPlugin is an in-memory persister plugin that records the mutations it receives.
+type MockPersisterPlugin struct {
+	Mutations []plugin.CheckpointMutation
+	Version   int
+	Header    json.RawMessage
+	Resources []json.RawMessage
+}
+
+func (m *MockPersisterPlugin) Close() error { return nil }
+func (m *MockPersisterPlugin) Name() string { return "mock" }
+
+func (m *MockPersisterPlugin) Load(stack tokens.QName) (int, json.RawMessage, error) {
+	if m.Version == 0 {
+		return 0, nil, nil
+	}
+	var deployment map[string]interface{}
+	if err := json.Unmarshal(m.Header, &deployment); err != nil {
+		return 0, nil, err
+	}
+	deployment["resources"] = m.Resources
+	bytes, err := json.Marshal(deployment)
+	return m.Version, bytes, err
+}
+
+func (m *MockPersisterPlugin) Save(stack tokens.QName) (plugin.CheckpointStream, error) {
+	m.Resources = nil
+	return m, nil
+}
+
+func (m *MockPersisterPlugin) Send(mutation plugin.CheckpointMutation) error {
+	resources, err := mutation.Apply(m.Resources)
+	if err != nil {
+		return err
+	}
+	m.Mutations = append(m.Mutations, mutation)
+	m.Version, m.Header, m.Resources = mutation.Version, mutation.Header, resources
+	return nil
+}
+
+func (m *MockPersisterPlugin) GetPluginInfo() (workspace.PluginInfo, error) {
+	return workspace.PluginInfo{Name: "mock", Kind: workspace.PersisterPlugin}, nil
+}
+
+func TestPluginSnapshotPersister(t *testing.T) {
+	mock := &MockPersisterPlugin{}
+	persister := NewPluginSnapshotPersister(mock, "test", b64.NewBase64SecretsManager())
+
+	save := func(resources ...*resource.State) {
+		assert.NoError(t, persister.Save(NewSnapshot(resources)))
+	}
+	loaded := func() []resource.URN {
+		snap, err := LoadPluginSnapshot(mock, "test", stack.DefaultSecretsProvider)
+		if !assert.NoError(t, err) || !assert.NotNil(t, snap) {
+			t.FailNow()
+		}
+		var urns []resource.URN
+		for _, res := range snap.Resources {
+			urns = append(urns, res.URN)
+		}
+		return urns
+	}
+
+	// Nothing has been saved yet.
+	snap, err := LoadPluginSnapshot(mock, "test", stack.DefaultSecretsProvider)
+	assert.NoError(t, err)
+	assert.Nil(t, snap)
+
+	a, b, c := NewResource("a"), NewResource("b"), NewResource("c")
+
+	// The first snapshot is sent in its entirety.
+	save(a, b)
+	assert.Equal(t, []resource.URN{"a", "b"}, loaded())
+	assert.Equal(t, 2, len(mock.Mutations[0].Resources))
+
+	// Appending a resource sends only that resource.
+	save(a, b, c)
+	assert.Equal(t, []resource.URN{"a", "b", "c"}, loaded())
+	last := mock.Mutations[len(mock.Mutations)-1]
+	assert.Equal(t, 2, last.Start)
+	assert.Equal(t, 0, last.DeleteCount)
+	assert.Equal(t, 1, len(last.Resources))
+
+	// Changing a resource in the middle sends only that resource.
+	b2 := NewResource("b")
+	b2.Outputs["foo"] = resource.NewStringProperty("bar")
+	save(a, b2, c)
+	assert.Equal(t, []resource.URN{"a", "b", "c"}, loaded())
+	last = mock.Mutations[len(mock.Mutations)-1]
+	assert.Equal(t, 1, last.Start)
+	assert.Equal(t, 1, last.DeleteCount)
+	assert.Equal(t, 1, len(last.Resources))
+
+	// Removing resources sends no resources at all.
+	save(c)
+	assert.Equal(t, []resource.URN{"c"}, loaded())
+	last = mock.Mutations[len(mock.Mutations)-1]
+	assert.Equal(t, 0, last.Start)
+	assert.Equal(t, 2, last.DeleteCount)
+	assert.Empty(t, last.Resources)
+
+	assert.NoError(t, persister.Close())
+
+	// A snapshot saved after the persister is closed starts a new stream.
+	save(a)
+	assert.Equal(t, []resource.URN{"a"}, loaded())
+	assert.Equal(t, 1, len(mock.Mutations[len(mock.Mutations)-1].Resources))
+	assert.NoError(t, persister.Close())
+}
@@ -200,6 +200,12 @@ func (ssm *sameSnapshotMutation) mustWrite(step *deploy.SameStep) bool {
 		return true
 	}
 
+	// If the resource's serialization group has changed, we must write the checkpoint so that its deletion is
+	// serialized with the right group.
+	if old.SerializationGroup != new.SerializationGroup {
+		return true
+	}
+
 	contract.Assert(old.ID == new.ID)
 
 	// If this resource's provider has changed, we must write the checkpoint. This can happen in scenarios involving
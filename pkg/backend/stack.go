@@ -129,6 +129,31 @@ func UpdateStackTags(ctx context.Context, s Stack, tags map[apitype.StackTagName
 	return s.Backend().UpdateStackTags(ctx, s, tags)
 }
 
+// GetStackFreeze fetches the stack's freeze, or nil if the stack is not frozen.
+func GetStackFreeze(ctx context.Context, s Stack) (*apitype.StackFreeze, error) {
+	return s.Backend().GetStackFreeze(ctx, s)
+}
+
+// UpdateStackFreeze freezes the stack with the given freeze, or unfreezes it if the freeze is nil.
+func UpdateStackFreeze(ctx context.Context, s Stack, freeze *apitype.StackFreeze) error {
+	return s.Backend().UpdateStackFreeze(ctx, s, freeze)
+}
+
+// CheckStackFreeze returns a StackFrozenError if the stack is frozen, unless the freeze is overridden.
+func CheckStackFreeze(ctx context.Context, s Stack, override bool) error {
+	if override {
+		return nil
+	}
+	freeze, err := GetStackFreeze(ctx, s)
+	if err != nil {
+		return errors.Wrap(err, "getting stack freeze")
+	}
+	if freeze != nil {
+		return StackFrozenError{StackName: s.Ref().String(), Reason: freeze.Reason}
+	}
+	return nil
+}
+
 // GetMergedStackTags returns the stack's existing tags merged with fresh tags from the environment
 // and Pulumi.yaml file.
 func GetMergedStackTags(ctx context.Context, s Stack) (map[apitype.StackTagName]string, error) {
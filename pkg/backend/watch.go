@@ -33,6 +33,9 @@ import (
 // Watch watches the project's working directory for changes and automatically updates the active
 // stack.
 func Watch(ctx context.Context, b Backend, stack Stack, op UpdateOperation, apply Applier) result.Result {
+	if err := CheckStackFreeze(ctx, stack, op.Opts.OverrideFreeze); err != nil {
+		return result.FromError(err)
+	}
 
 	opts := ApplierOptions{
 		DryRun:   false,
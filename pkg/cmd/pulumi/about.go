@@ -0,0 +1,365 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/backend/state"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/version"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// runtimeVersionTimeout bounds how long `pulumi about` waits for a language runtime to report its version.
+const runtimeVersionTimeout = 5 * time.Second
+
+func newAboutCmd() *cobra.Command {
+	var jsonOut bool
+	var stack string
+
+	cmd := &cobra.Command{
+		Use:   "about",
+		Short: "Print information about the Pulumi environment",
+		Long: "Print information about the Pulumi environment\n" +
+			"\n" +
+			"Prints the version of the CLI, the backend it is logged into, the current project and its\n" +
+			"language runtime, the state of the project's dependencies, and the current stack along with\n" +
+			"the plugins its last update used.\n" +
+			"\n" +
+			"Information that cannot be determined, e.g. because there is no current project, is omitted\n" +
+			"and the reason is reported instead of failing the command. The --json output is intended for\n" +
+			"editors and other tools that need to discover the state of a project.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			about := getAboutInfo(stack)
+			if jsonOut {
+				return printJSON(about)
+			}
+			about.print()
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit output as JSON")
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "", "The name of the stack to describe. Defaults to the current stack")
+
+	return cmd
+}
+
+// aboutInfo is the shape of the --json output of `pulumi about`. While we can add fields to this structure in the
+// future, we should not change existing fields.
+type aboutInfo struct {
+	CLI     aboutCLI      `json:"cli"`
+	Backend *aboutBackend `json:"backend,omitempty"`
+	Project *aboutProject `json:"project,omitempty"`
+	Stack   *aboutStack   `json:"stack,omitempty"`
+	// Errors lists the reasons that any of the sections above were omitted or incomplete.
+	Errors []string `json:"errors,omitempty"`
+}
+
+type aboutCLI struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+type aboutBackend struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	User string `json:"user,omitempty"`
+}
+
+type aboutProject struct {
+	Name         string             `json:"name"`
+	Path         string             `json:"path"`
+	Runtime      aboutRuntime       `json:"runtime"`
+	Dependencies *aboutDependencies `json:"dependencies,omitempty"`
+}
+
+type aboutRuntime struct {
+	Name string `json:"name"`
+	// Executable is the language toolchain used to find the runtime's version, e.g. `node`.
+	Executable string `json:"executable,omitempty"`
+	// Version is the version that the executable reported, if it was found.
+	Version string `json:"version,omitempty"`
+}
+
+type aboutDependencies struct {
+	// Lockfiles are the lockfiles in the project directory, relative to it.
+	Lockfiles []string `json:"lockfiles"`
+	// Environment is the directory that the CLI installs the project's dependencies into, if it manages one.
+	Environment string `json:"environment,omitempty"`
+	// Installed is true if the environment exists and its dependencies were installed from the current manifests.
+	Installed bool `json:"installed"`
+}
+
+type aboutStack struct {
+	Name       string        `json:"name"`
+	Resources  int           `json:"resources"`
+	LastUpdate string        `json:"lastUpdate,omitempty"`
+	Plugins    []aboutPlugin `json:"plugins"`
+}
+
+type aboutPlugin struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Version string `json:"version,omitempty"`
+}
+
+// getAboutInfo gathers information about the CLI's environment. Each section is gathered independently, so a missing
+// project or stack only omits the sections that depend on it.
+func getAboutInfo(stackName string) *aboutInfo {
+	about := &aboutInfo{
+		CLI: aboutCLI{
+			Version:   version.Version,
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+		},
+	}
+	addError := func(section string, err error) {
+		about.Errors = append(about.Errors, fmt.Sprintf("%s: %v", section, err))
+	}
+
+	if proj, root, err := readProject(); err != nil {
+		addError("project", err)
+	} else {
+		about.Project = getAboutProject(proj, root)
+	}
+
+	b, err := currentBackend(display.Options{Color: cmdutil.GetGlobalColorization()})
+	if err != nil {
+		addError("backend", err)
+		return about
+	}
+	about.Backend = &aboutBackend{Name: b.Name(), URL: b.URL()}
+	if user, err := b.CurrentUser(); err == nil {
+		about.Backend.User = user
+	}
+
+	var s backend.Stack
+	if stackName != "" {
+		ref, err := b.ParseStackReference(stackName)
+		if err == nil {
+			s, err = b.GetStack(commandContext(), ref)
+			if err == nil && s == nil {
+				err = fmt.Errorf("no stack named '%s' found", stackName)
+			}
+		}
+		if err != nil {
+			addError("stack", err)
+			return about
+		}
+	} else if s, err = state.CurrentStack(commandContext(), b); err != nil {
+		addError("stack", err)
+		return about
+	} else if s == nil {
+		return about
+	}
+
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		addError("stack", err)
+		return about
+	}
+	about.Stack = getAboutStack(s.Ref().String(), snap)
+	return about
+}
+
+// getAboutProject describes the project at the given root, its language runtime and its dependencies.
+func getAboutProject(proj *workspace.Project, root string) *aboutProject {
+	runtimeName := proj.Runtime.Name()
+	about := &aboutProject{
+		Name:    string(proj.Name),
+		Path:    root,
+		Runtime: aboutRuntime{Name: runtimeName},
+	}
+
+	if executable, args := runtimeVersionCommand(runtimeName); executable != "" {
+		about.Runtime.Executable = executable
+		about.Runtime.Version = getRuntimeVersion(executable, args...)
+	}
+
+	deps := &aboutDependencies{Lockfiles: []string{}}
+	for _, lockfile := range runtimeLockfiles(runtimeName) {
+		if _, err := os.Stat(filepath.Join(root, lockfile)); err == nil {
+			deps.Lockfiles = append(deps.Lockfiles, lockfile)
+		}
+	}
+	if env, err := getRuntimeEnv(proj, root); err == nil && env != nil {
+		deps.Environment = env.dir
+		if digest, err := env.digest(root); err == nil {
+			stamp, err := ioutil.ReadFile(filepath.Join(env.dir, runtimeEnvStampFile))
+			deps.Installed = err == nil && string(stamp) == digest
+		}
+	}
+	about.Dependencies = deps
+
+	return about
+}
+
+// getAboutStack describes a stack from its most recent snapshot.
+func getAboutStack(name string, snap *deploy.Snapshot) *aboutStack {
+	about := &aboutStack{Name: name, Plugins: []aboutPlugin{}}
+	if snap == nil {
+		return about
+	}
+
+	about.Resources = len(snap.Resources)
+	if !snap.Manifest.Time.IsZero() {
+		about.LastUpdate = snap.Manifest.Time.UTC().Format(time.RFC3339)
+	}
+	for _, plugin := range snap.Manifest.Plugins {
+		var version string
+		if plugin.Version != nil {
+			version = plugin.Version.String()
+		}
+		about.Plugins = append(about.Plugins, aboutPlugin{Name: plugin.Name, Kind: string(plugin.Kind), Version: version})
+	}
+	return about
+}
+
+// runtimeVersionCommand returns the command that prints the version of the given language runtime's toolchain.
+//
+// TODO[pulumi/pulumi#1334]: move to the language plugins so we don't have to hard code here.
+func runtimeVersionCommand(runtimeName string) (string, []string) {
+	switch strings.ToLower(runtimeName) {
+	case "nodejs":
+		return "node", []string{"--version"}
+	case "python":
+		return "python3", []string{"--version"}
+	case "go":
+		return "go", []string{"version"}
+	case "dotnet":
+		return "dotnet", []string{"--version"}
+	default:
+		return "", nil
+	}
+}
+
+// runtimeLockfiles returns the names of the lockfiles that pin the dependencies of projects in the given language.
+func runtimeLockfiles(runtimeName string) []string {
+	switch strings.ToLower(runtimeName) {
+	case "nodejs":
+		return []string{"package-lock.json", "yarn.lock"}
+	case "python":
+		return []string{"Pipfile.lock", "poetry.lock"}
+	case "go":
+		return []string{"go.sum"}
+	case "dotnet":
+		return []string{"packages.lock.json"}
+	default:
+		return nil
+	}
+}
+
+// getRuntimeVersion runs the given executable and returns the first line of its output, or the empty string if it
+// could not be run.
+func getRuntimeVersion(executable string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), runtimeVersionTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, executable, args...).CombinedOutput()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+}
+
+// print writes the information to stdout in a form meant for people.
+func (about *aboutInfo) print() {
+	fmt.Printf("CLI\n")
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"VERSION", "GO VERSION", "OS", "ARCH"},
+		Rows: []cmdutil.TableRow{{
+			Columns: []string{about.CLI.Version, about.CLI.GoVersion, about.CLI.OS, about.CLI.Arch},
+		}},
+	})
+
+	if b := about.Backend; b != nil {
+		fmt.Printf("\nBackend\n")
+		cmdutil.PrintTable(cmdutil.Table{
+			Headers: []string{"NAME", "URL", "USER"},
+			Rows:    []cmdutil.TableRow{{Columns: []string{b.Name, b.URL, b.User}}},
+		})
+	}
+
+	if p := about.Project; p != nil {
+		fmt.Printf("\nProject\n")
+		runtimeVersion := p.Runtime.Version
+		if runtimeVersion == "" && p.Runtime.Executable != "" {
+			runtimeVersion = fmt.Sprintf("unknown (%s not found)", p.Runtime.Executable)
+		}
+		cmdutil.PrintTable(cmdutil.Table{
+			Headers: []string{"NAME", "RUNTIME", "RUNTIME VERSION", "PATH"},
+			Rows:    []cmdutil.TableRow{{Columns: []string{p.Name, p.Runtime.Name, runtimeVersion, p.Path}}},
+		})
+
+		if d := p.Dependencies; d != nil {
+			lockfiles := "none"
+			if len(d.Lockfiles) != 0 {
+				lockfiles = strings.Join(d.Lockfiles, ", ")
+			}
+			fmt.Printf("\nLockfiles: %s\n", lockfiles)
+			if d.Environment != "" {
+				status := "needs install"
+				if d.Installed {
+					status = "up to date"
+				}
+				fmt.Printf("Dependencies: %s (%s)\n", d.Environment, status)
+			}
+		}
+	}
+
+	if s := about.Stack; s != nil {
+		fmt.Printf("\nCurrent Stack: %s\n", s.Name)
+		fmt.Printf("Resources: %d\n", s.Resources)
+		if s.LastUpdate != "" {
+			fmt.Printf("Last Update: %s\n", s.LastUpdate)
+		}
+		if len(s.Plugins) != 0 {
+			fmt.Printf("\nPlugins\n")
+			rows := make([]cmdutil.TableRow, len(s.Plugins))
+			for i, plugin := range s.Plugins {
+				rows[i] = cmdutil.TableRow{Columns: []string{plugin.Name, plugin.Kind, plugin.Version}}
+			}
+			cmdutil.PrintTable(cmdutil.Table{Headers: []string{"NAME", "KIND", "VERSION"}, Rows: rows})
+		}
+	}
+
+	if len(about.Errors) != 0 {
+		fmt.Printf("\nErrors\n")
+		for _, err := range about.Errors {
+			fmt.Printf("  %s\n", err)
+		}
+	}
+}
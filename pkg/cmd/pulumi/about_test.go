@@ -0,0 +1,79 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestGetAboutProject(t *testing.T) {
+	root, err := ioutil.TempDir("", "pulumi-about-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name":"a"}`), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "yarn.lock"), []byte("lock"), 0600))
+
+	proj := &workspace.Project{Name: "website", Runtime: workspace.NewProjectRuntimeInfo("nodejs", nil)}
+	about := getAboutProject(proj, root)
+	assert.Equal(t, "website", about.Name)
+	assert.Equal(t, "nodejs", about.Runtime.Name)
+	assert.Equal(t, "node", about.Runtime.Executable)
+	if assert.NotNil(t, about.Dependencies) {
+		assert.Equal(t, []string{"yarn.lock"}, about.Dependencies.Lockfiles)
+		assert.Equal(t, filepath.Join(root, "node_modules"), about.Dependencies.Environment)
+		assert.False(t, about.Dependencies.Installed)
+	}
+
+	// Once the dependencies are installed from the current manifests, the environment is reported as installed.
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "node_modules"), 0700))
+	assert.NoError(t, ensureRuntimeEnv(proj, root))
+	assert.True(t, getAboutProject(proj, root).Dependencies.Installed)
+}
+
+func TestGetAboutStack(t *testing.T) {
+	v := semver.MustParse("2.1.0")
+	updated := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	snap := deploy.NewSnapshot(deploy.Manifest{
+		Time: updated,
+		Plugins: []workspace.PluginInfo{
+			{Name: "aws", Kind: workspace.ResourcePlugin, Version: &v},
+			{Name: "nodejs", Kind: workspace.LanguagePlugin},
+		},
+	}, nil, nil, nil)
+
+	about := getAboutStack("dev", snap)
+	assert.Equal(t, &aboutStack{
+		Name:       "dev",
+		LastUpdate: "2020-06-01T12:00:00Z",
+		Plugins: []aboutPlugin{
+			{Name: "aws", Kind: "resource", Version: "2.1.0"},
+			{Name: "nodejs", Kind: "language"},
+		},
+	}, about)
+
+	// A stack that has never been updated has no snapshot.
+	assert.Equal(t, &aboutStack{Name: "dev", Plugins: []aboutPlugin{}}, getAboutStack("dev", nil))
+}
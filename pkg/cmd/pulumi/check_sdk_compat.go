@@ -0,0 +1,147 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/v2/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/nodejs"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/python"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+// sdkSurfaces maps the name of each language to the function that computes the surface of its generated SDK.
+var sdkSurfaces = map[string]func(tool string, pkg *schema.Package) (codegen.Surface, error){
+	"dotnet": dotnet.SDKSurface,
+	"go":     gogen.SDKSurface,
+	"nodejs": nodejs.SDKSurface,
+	"python": python.SDKSurface,
+}
+
+// newCheckSDKCompatCmd returns a new command that reports the source-incompatible changes between the SDKs generated
+// for two versions of a package's schema. It is hidden since it's meant for the release processes of providers.
+func newCheckSDKCompatCmd() *cobra.Command {
+	var languages []string
+
+	cmd := &cobra.Command{
+		Use:   "check-sdk-compat <OLD_SCHEMA> <NEW_SCHEMA>",
+		Args:  cmdutil.ExactArgs(2),
+		Short: "Report source-incompatible changes between the SDKs generated for two schemas",
+		Long: "Report source-incompatible changes between the SDKs generated for two schemas.\n" +
+			"\n" +
+			"Compares the exported types and functions of the SDK that each language generates for the old\n" +
+			"schema with those of the SDK that it generates for the new schema, and lists the changes that\n" +
+			"break code written against the old SDK: removed modules, classes, functions, and properties,\n" +
+			"renamed classes, changed property types, and new required arguments. The command fails if\n" +
+			"there are any such changes, so it can gate the release of a provider.",
+		Hidden: true,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			for _, language := range languages {
+				if _, ok := sdkSurfaces[language]; !ok {
+					return errors.Errorf("unknown language %q", language)
+				}
+			}
+
+			old, err := readPackageSchema(args[0])
+			if err != nil {
+				return err
+			}
+			new, err := readPackageSchema(args[1])
+			if err != nil {
+				return err
+			}
+
+			changes, err := compareSDKSurfaces(old, new, languages)
+			if err != nil {
+				return err
+			}
+
+			count := 0
+			for _, language := range languages {
+				if len(changes[language]) == 0 {
+					continue
+				}
+				fmt.Printf("%s:\n", language)
+				for _, c := range changes[language] {
+					fmt.Printf("    %s\n", c)
+				}
+				count += len(changes[language])
+			}
+			if count != 0 {
+				return errors.Errorf("found %d source-incompatible changes", count)
+			}
+			fmt.Printf("No source-incompatible changes in %s.\n", strings.Join(languages, ", "))
+			return nil
+		}),
+	}
+
+	var allLanguages []string
+	for language := range sdkSurfaces {
+		allLanguages = append(allLanguages, language)
+	}
+	sort.Strings(allLanguages)
+
+	cmd.PersistentFlags().StringSliceVarP(
+		&languages, "language", "l", allLanguages,
+		"The languages whose SDKs to compare")
+
+	return cmd
+}
+
+// readPackageSchema reads and binds the JSON schema of a package.
+func readPackageSchema(path string) (*schema.Package, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec schema.PackageSpec
+	if err = json.Unmarshal(contents, &spec); err != nil {
+		return nil, errors.Wrapf(err, "reading schema %s", path)
+	}
+	pkg, err := schema.ImportSpec(spec, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "binding schema %s", path)
+	}
+	return pkg, nil
+}
+
+// compareSDKSurfaces returns the source-incompatible changes between the SDKs generated for the two packages in each
+// of the given languages.
+func compareSDKSurfaces(old, new *schema.Package, languages []string) (map[string][]codegen.SurfaceChange, error) {
+	changes := map[string][]codegen.SurfaceChange{}
+	for _, language := range languages {
+		oldSurface, err := sdkSurfaces[language]("pulumi", old)
+		if err != nil {
+			return nil, errors.Wrapf(err, "generating the %s SDK for %s@%v", language, old.Name, old.Version)
+		}
+		newSurface, err := sdkSurfaces[language]("pulumi", new)
+		if err != nil {
+			return nil, errors.Wrapf(err, "generating the %s SDK for %s@%v", language, new.Name, new.Version)
+		}
+		changes[language] = codegen.CompareSurfaces(oldSurface, newSurface)
+	}
+	return changes, nil
+}
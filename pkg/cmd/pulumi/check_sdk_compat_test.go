@@ -0,0 +1,96 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestCompareSDKSurfaces(t *testing.T) {
+	importPackage := func(version, resources string) *schema.Package {
+		var spec schema.PackageSpec
+		err := json.Unmarshal([]byte(`{
+			"name": "example",
+			"version": "`+version+`",
+			"meta": {"moduleFormat": "(.*)"},
+			"resources": `+resources+`
+		}`), &spec)
+		assert.NoError(t, err)
+
+		pkg, err := schema.ImportSpec(spec, nil)
+		assert.NoError(t, err)
+		return pkg
+	}
+
+	old := importPackage("1.0.0", `{
+		"example:web:Site": {
+			"properties": {"url": {"type": "string"}, "port": {"type": "integer"}},
+			"required": ["url", "port"],
+			"inputProperties": {"domain": {"type": "string"}}
+		},
+		"example:dns:Record": {
+			"properties": {"name": {"type": "string"}},
+			"required": ["name"]
+		}
+	}`)
+	new := importPackage("2.0.0", `{
+		"example:web:Site": {
+			"properties": {"url": {"type": "string"}, "port": {"type": "string"}},
+			"required": ["url", "port"],
+			"inputProperties": {"domain": {"type": "string"}, "zone": {"type": "string"}},
+			"requiredInputs": ["zone"]
+		}
+	}`)
+
+	changes, err := compareSDKSurfaces(old, new, []string{"dotnet", "go", "nodejs", "python"})
+	assert.NoError(t, err)
+
+	describe := func(language string) []string {
+		var descriptions []string
+		for _, c := range changes[language] {
+			descriptions = append(descriptions, c.String())
+		}
+		return descriptions
+	}
+	assert.Equal(t, []string{
+		"Pulumi.Example.Dns: module removed",
+		"Pulumi.Example.Web.Site.Port: type changed from Output<int> to Output<string>",
+		"Pulumi.Example.Web.SiteArgs.Zone: required argument added",
+	}, describe("dotnet"))
+	assert.Equal(t, []string{
+		"example/dns: module removed",
+		"example/web.Site.Port: type changed from pulumi.IntOutput to pulumi.StringOutput",
+		"example/web.SiteArgs.Zone: required argument added",
+	}, describe("go"))
+	assert.Equal(t, []string{
+		"example.dns: module removed",
+		"example.web.Site.port: type changed from pulumi.Output<number> to pulumi.Output<string>",
+		"example.web.SiteArgs.zone: required argument added",
+	}, describe("nodejs"))
+	assert.Equal(t, []string{
+		"pulumi_example.dns: module removed",
+		"pulumi_example.web.Site(zone): required argument added",
+		"pulumi_example.web.Site.port: type changed from pulumi.Output[float] to pulumi.Output[str]",
+	}, describe("python"))
+
+	changes, err = compareSDKSurfaces(old, old, []string{"go"})
+	assert.NoError(t, err)
+	assert.Empty(t, changes["go"])
+}
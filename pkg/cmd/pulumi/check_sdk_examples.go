@@ -0,0 +1,182 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/v2/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/nodejs"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/python"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// exampleWorkspaces maps the name of each language to the function that lays out its examples for compilation.
+var exampleWorkspaces = map[string]func(tool string, pkg *schema.Package) (*codegen.ExampleWorkspace, error){
+	"dotnet": dotnet.ExampleWorkspace,
+	"go":     gogen.ExampleWorkspace,
+	"nodejs": nodejs.ExampleWorkspace,
+	"python": python.ExampleWorkspace,
+}
+
+// exampleFailure records an example that failed to compile and the output of the compiler.
+type exampleFailure struct {
+	Example codegen.Example
+	Output  string
+}
+
+// newCheckSDKExamplesCmd returns a new command that compiles the examples in a package's schema against the SDKs
+// generated for the package. It is hidden since it's meant for the release processes of providers.
+func newCheckSDKExamplesCmd() *cobra.Command {
+	var languages []string
+	var keepWorkspace bool
+
+	cmd := &cobra.Command{
+		Use:   "check-sdk-examples <SCHEMA>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Compile the examples in a schema against the SDKs generated for it",
+		Long: "Compile the examples in a schema against the SDKs generated for it.\n" +
+			"\n" +
+			"Extracts the code snippets in each language from the examples in the descriptions of the\n" +
+			"package, its resources, and its functions, generates the package's SDK for that language, and\n" +
+			"compiles each snippet against the SDK. The command fails if any example does not compile, so\n" +
+			"it can keep a provider's documentation in step with its SDKs. The toolchain for each language\n" +
+			"(Node.js, Python, Go, or .NET) must be installed.",
+		Hidden: true,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			for _, language := range languages {
+				if _, ok := exampleWorkspaces[language]; !ok {
+					return errors.Errorf("unknown language %q", language)
+				}
+			}
+
+			pkg, err := readPackageSchema(args[0])
+			if err != nil {
+				return err
+			}
+
+			count := 0
+			for _, language := range languages {
+				workspace, err := exampleWorkspaces[language]("pulumi", pkg)
+				if err != nil {
+					return errors.Wrapf(err, "generating the %s SDK for %s", language, pkg.Name)
+				}
+				if len(workspace.Builds) == 0 {
+					fmt.Printf("%s: no examples\n", language)
+					continue
+				}
+
+				dir, err := ioutil.TempDir("", "pulumi-examples-"+language+"-")
+				if err != nil {
+					return err
+				}
+				if keepWorkspace {
+					fmt.Printf("%s: compiling %d examples in %s\n", language, len(workspace.Builds), dir)
+				} else {
+					defer func() { contract.IgnoreError(os.RemoveAll(dir)) }()
+				}
+
+				failures, err := compileExamples(dir, workspace)
+				if err != nil {
+					return errors.Wrapf(err, "compiling %s examples", language)
+				}
+				if len(failures) == 0 {
+					fmt.Printf("%s: %d examples compiled\n", language, len(workspace.Builds))
+					continue
+				}
+
+				fmt.Printf("%s: %d of %d examples failed to compile:\n", language, len(failures), len(workspace.Builds))
+				for _, f := range failures {
+					fmt.Printf("    %s:\n", f.Example)
+					for _, line := range strings.Split(strings.TrimRight(f.Output, "\n"), "\n") {
+						fmt.Printf("        %s\n", line)
+					}
+				}
+				count += len(failures)
+			}
+			if count != 0 {
+				return errors.Errorf("%d examples failed to compile", count)
+			}
+			return nil
+		}),
+	}
+
+	var allLanguages []string
+	for language := range exampleWorkspaces {
+		allLanguages = append(allLanguages, language)
+	}
+	sort.Strings(allLanguages)
+
+	cmd.PersistentFlags().StringSliceVarP(
+		&languages, "language", "l", allLanguages,
+		"The languages whose examples to compile")
+	cmd.PersistentFlags().BoolVar(
+		&keepWorkspace, "keep-workspace", false,
+		"Keep the directories in which the examples are compiled and print their paths")
+
+	return cmd
+}
+
+// compileExamples writes a workspace to the given directory, runs its setup commands, and then compiles each of its
+// examples. It returns the examples that failed to compile. An error is returned if the workspace cannot be set up.
+func compileExamples(dir string, workspace *codegen.ExampleWorkspace) ([]exampleFailure, error) {
+	for p, contents := range workspace.Files {
+		path := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(path, contents, 0600); err != nil {
+			return nil, err
+		}
+	}
+
+	run := func(subdir string, command []string) (string, error) {
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Dir = filepath.Join(dir, filepath.FromSlash(subdir))
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+
+	for _, command := range workspace.Setup {
+		if output, err := run(".", command); err != nil {
+			return nil, errors.Wrapf(err, "running %s:\n%s", strings.Join(command, " "), output)
+		}
+	}
+
+	var failures []exampleFailure
+	for _, build := range workspace.Builds {
+		output, err := run(build.Dir, build.Command)
+		if err != nil {
+			if _, ok := err.(*exec.ExitError); !ok {
+				return nil, errors.Wrapf(err, "running %s", strings.Join(build.Command, " "))
+			}
+			failures = append(failures, exampleFailure{Example: build.Example, Output: output})
+		}
+	}
+	return failures, nil
+}
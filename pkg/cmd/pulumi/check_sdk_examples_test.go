@@ -0,0 +1,67 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestCompileExamples(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 is not installed")
+	}
+
+	example := func(title, code string) string {
+		return "{{% example %}}\n### " + title + "\n```python\n" + code + "\n```\n{{% /example %}}\n"
+	}
+	var spec schema.PackageSpec
+	err := json.Unmarshal([]byte(`{"name": "example", "language": {"python": {}}}`), &spec)
+	assert.NoError(t, err)
+	spec.Resources = map[string]schema.ResourceSpec{
+		"example::Site": {
+			ObjectTypeSpec: schema.ObjectTypeSpec{
+				Description: "{{% examples %}}\n" +
+					example("Valid", "import pulumi_example as example\nsite = example.Site(\"site\")") +
+					example("Invalid", "site = example.Site(\"site\"") +
+					"{{% /examples %}}",
+			},
+		},
+	}
+	pkg, err := schema.ImportSpec(spec, nil)
+	assert.NoError(t, err)
+
+	workspace, err := exampleWorkspaces["python"]("pulumi", pkg)
+	assert.NoError(t, err)
+	assert.Len(t, workspace.Builds, 2)
+
+	dir, err := ioutil.TempDir("", "pulumi-examples-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	failures, err := compileExamples(dir, workspace)
+	assert.NoError(t, err)
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, `example::Site example "Invalid"`, failures[0].Example.String())
+		assert.Contains(t, failures[0].Output, "SyntaxError")
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/djherbis/times"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// completionCacheTTL is how long completions that were fetched from the backend are reused before they are fetched
+// again. Listing stacks or loading a snapshot can take seconds on large backends, which is too slow to do on every
+// press of the tab key.
+const completionCacheTTL = 5 * time.Minute
+
+// completionFunc computes the completions for an argument or flag value given the text typed so far.
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// flagCompletions maps the names of flags that are shared by many commands to the functions that complete their
+// values.
+var flagCompletions = map[string]completionFunc{
+	"stack":          completeStackNames,
+	"target":         completeResourceURNs,
+	"replace":        completeResourceURNs,
+	"target-replace": completeResourceURNs,
+}
+
+// registerFlagCompletions registers dynamic completions for the flags in flagCompletions on the given command and
+// all of its subcommands. Commands that create stacks, whose --stack flags name stacks that don't exist yet, are
+// skipped.
+func registerFlagCompletions(cmd *cobra.Command) {
+	registered := make(map[*pflag.Flag]bool)
+
+	var register func(cmd *cobra.Command)
+	register = func(cmd *cobra.Command) {
+		for name, complete := range flagCompletions {
+			if name == "stack" && createsStack(cmd) {
+				continue
+			}
+
+			for _, flags := range []*pflag.FlagSet{cmd.LocalNonPersistentFlags(), cmd.PersistentFlags()} {
+				if f := flags.Lookup(name); f != nil && !registered[f] {
+					registered[f] = true
+					contract.IgnoreError(cmd.RegisterFlagCompletionFunc(name, complete))
+				}
+			}
+		}
+
+		for _, c := range cmd.Commands() {
+			register(c)
+		}
+	}
+	register(cmd)
+}
+
+// createsStack returns true if the given command creates the stack named by its --stack flag.
+func createsStack(cmd *cobra.Command) bool {
+	switch cmd.CommandPath() {
+	case "pulumi new", "pulumi stack init":
+		return true
+	default:
+		return false
+	}
+}
+
+// completeFirstArg wraps a completion function so that it only completes the first positional argument of a command.
+func completeFirstArg(complete completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// completeStackNames completes the names of the stacks in the current backend. If the current directory is in a
+// project, only that project's stacks are offered.
+func completeStackNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := chdirForCompletion(cmd); err != nil {
+		return completionError(err)
+	}
+
+	b, err := currentBackend(display.Options{Color: cmdutil.GetGlobalColorization()})
+	if err != nil {
+		return completionError(err)
+	}
+
+	var filter backend.ListStacksFilter
+	if proj, err := workspace.DetectProject(); err == nil {
+		projName := string(proj.Name)
+		filter.Project = &projName
+	}
+
+	cacheKey := "stacks\x00" + b.URL()
+	if filter.Project != nil {
+		cacheKey += "\x00" + *filter.Project
+	}
+	names, err := cachedCompletions(cacheKey, func() ([]string, error) {
+		summaries, err := b.ListStacks(commandContext(), filter)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, len(summaries))
+		for i, summary := range summaries {
+			names[i] = summary.Name().String()
+		}
+		return names, nil
+	})
+	if err != nil {
+		return completionError(err)
+	}
+
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigKeys completes the configuration keys that are set in the stack's configuration file. Keys in the
+// project's namespace are offered without it, as `pulumi config` prints them. The configuration file is local, so
+// these completions are not cached.
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := chdirForCompletion(cmd); err != nil {
+		return completionError(err)
+	}
+
+	proj, err := workspace.DetectProject()
+	if err != nil {
+		return completionError(err)
+	}
+
+	var ps *workspace.ProjectStack
+	if stackConfigFile != "" {
+		ps, err = workspace.LoadProjectStack(stackConfigFile)
+	} else {
+		var stackName string
+		if stackName, err = completionStackName(cmd); err == nil {
+			ps, err = workspace.DetectProjectStack(tokens.QName(stackName))
+		}
+	}
+	if err != nil {
+		return completionError(err)
+	}
+
+	keys := make([]string, 0, len(ps.Config))
+	for k := range ps.Config {
+		keys = append(keys, prettyKeyForProject(k, proj))
+	}
+
+	return filterCompletions(keys, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeResourceURNs completes the URNs of the resources in the stack's most recent snapshot.
+func completeResourceURNs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := chdirForCompletion(cmd); err != nil {
+		return completionError(err)
+	}
+
+	var stackName string
+	if f := cmd.Flag("stack"); f != nil {
+		stackName = f.Value.String()
+	}
+	s, err := requireStack(stackName, false, display.Options{Color: cmdutil.GetGlobalColorization()}, false)
+	if err != nil {
+		return completionError(err)
+	}
+
+	cacheKey := "urns\x00" + s.Backend().URL() + "\x00" + s.Ref().String()
+	urns, err := cachedCompletions(cacheKey, func() ([]string, error) {
+		snap, err := s.Snapshot(commandContext())
+		if err != nil || snap == nil {
+			return nil, err
+		}
+
+		var urns []string
+		seen := make(map[string]bool)
+		for _, res := range snap.Resources {
+			if urn := string(res.URN); !seen[urn] {
+				seen[urn] = true
+				urns = append(urns, urn)
+			}
+		}
+		return urns, nil
+	})
+	if err != nil {
+		return completionError(err)
+	}
+
+	return filterCompletions(urns, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionStackName returns the unqualified name of the stack that a command being completed operates on: the
+// value of its --stack flag if it has one, or else the current stack.
+func completionStackName(cmd *cobra.Command) (string, error) {
+	var name string
+	if f := cmd.Flag("stack"); f != nil {
+		name = f.Value.String()
+	}
+	if name == "" {
+		w, err := workspace.New()
+		if err != nil {
+			return "", err
+		}
+		name = w.Settings().Stack
+	}
+	if name == "" {
+		return "", errors.New("no stack selected")
+	}
+
+	// Stack references may be qualified by an owner and project, but configuration files are named after the stack.
+	return name[strings.LastIndex(name, "/")+1:], nil
+}
+
+// chdirForCompletion changes to the directory named by the --cwd flag, if any. Completion requests don't run the
+// root command's pre-run hook, which does this for ordinary commands.
+func chdirForCompletion(cmd *cobra.Command) error {
+	if f := cmd.Flag("cwd"); f != nil && f.Value.String() != "" {
+		return os.Chdir(f.Value.String())
+	}
+	return nil
+}
+
+// completionError logs an error encountered while computing completions and tells the shell to ignore them. Errors
+// are not printed, as they would be mixed into the user's command line.
+func completionError(err error) ([]string, cobra.ShellCompDirective) {
+	logging.V(5).Infof("computing completions: %v", err)
+	return nil, cobra.ShellCompDirectiveError
+}
+
+// filterCompletions returns the sorted candidates that begin with the given prefix.
+func filterCompletions(candidates []string, prefix string) []string {
+	var completions []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) {
+			completions = append(completions, c)
+		}
+	}
+	sort.Strings(completions)
+	return completions
+}
+
+// cachedCompletions returns the completions cached under the given key if they were cached less than
+// completionCacheTTL ago. Otherwise, it calls fetch and caches the result. Failing to read or write the cache is not
+// an error; the completions are fetched instead.
+func cachedCompletions(key string, fetch func() ([]string, error)) ([]string, error) {
+	sum := sha256.Sum256([]byte(key))
+	path, err := workspace.GetPulumiPath("completions", hex.EncodeToString(sum[:])+".json")
+	if err != nil {
+		return fetch()
+	}
+
+	if ts, err := times.Stat(path); err == nil && time.Now().Before(ts.ModTime().Add(completionCacheTTL)) {
+		var cached []string
+		if b, err := ioutil.ReadFile(path); err == nil && json.Unmarshal(b, &cached) == nil {
+			return cached, nil
+		}
+	}
+
+	completions, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeCompletionCache(path, completions); err != nil {
+		logging.V(5).Infof("could not cache completions: %v", err)
+	}
+	return completions, nil
+}
+
+// writeCompletionCache saves completions to the cache file at the given path.
+func writeCompletionCache(path string, completions []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(completions)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
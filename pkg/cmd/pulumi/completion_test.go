@@ -0,0 +1,88 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterCompletions(t *testing.T) {
+	candidates := []string{"prod", "dev", "dev2", "staging"}
+	assert.Equal(t, []string{"dev", "dev2", "prod", "staging"}, filterCompletions(candidates, ""))
+	assert.Equal(t, []string{"dev", "dev2"}, filterCompletions(candidates, "de"))
+	assert.Nil(t, filterCompletions(candidates, "test"))
+}
+
+func TestCachedCompletions(t *testing.T) {
+	home, err := ioutil.TempDir("", "pulumi-completion-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	defer os.Setenv("PULUMI_HOME", os.Getenv("PULUMI_HOME"))
+	os.Setenv("PULUMI_HOME", home)
+
+	fetches := 0
+	fetch := func() ([]string, error) {
+		fetches++
+		return []string{"dev", "prod"}, nil
+	}
+
+	// The first request fetches the completions, and later requests for the same key read them from the cache.
+	for i := 0; i < 2; i++ {
+		completions, err := cachedCompletions("stacks\x00file://~", fetch)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"dev", "prod"}, completions)
+		assert.Equal(t, 1, fetches)
+	}
+
+	// Requests for other keys are fetched separately.
+	_, err = cachedCompletions("stacks\x00https://api.pulumi.com", fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestCompleteConfigKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-completion-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "Pulumi.yaml"), []byte("name: website\nruntime: nodejs\n"), 0600)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "Pulumi.dev.yaml"), []byte(`config:
+  aws:region: us-west-2
+  website:domain: example.com
+  website:indexDocument: index.html
+`), 0600)
+	assert.NoError(t, err)
+
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.Chdir(cwd)) }()
+
+	var out bytes.Buffer
+	cmd := NewPulumiCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(ioutil.Discard)
+	cmd.SetArgs([]string{cobra.ShellCompRequestCmd, "config", "get", "--cwd", dir, "--stack", "dev", ""})
+	assert.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "aws:region\ndomain\nindexDocument\n:4\n", out.String())
+}
@@ -79,8 +79,13 @@ func newConfigCmd() *cobra.Command {
 	cmd.AddCommand(newConfigGetCmd(&stack))
 	cmd.AddCommand(newConfigRmCmd(&stack))
 	cmd.AddCommand(newConfigSetCmd(&stack))
+	cmd.AddCommand(newConfigSetAllCmd(&stack))
+	cmd.AddCommand(newConfigExportCmd(&stack))
 	cmd.AddCommand(newConfigRefreshCmd(&stack))
 	cmd.AddCommand(newConfigCopyCmd(&stack))
+	cmd.AddCommand(newConfigDiffCmd(&stack))
+	cmd.AddCommand(newConfigAuditCmd(&stack))
+	cmd.AddCommand(newConfigImportOutputsCmd(&stack))
 
 	return cmd
 }
@@ -249,7 +254,8 @@ func newConfigGetCmd(stack *string) *cobra.Command {
 			"if the value of `outer` is a map `inner: value`.\n" +
 			"    - `pulumi config get --path names[0]` will get the value of the first item, " +
 			"if the value of `names` is a list.",
-		Args: cmdutil.SpecificArgs([]string{"key"}),
+		Args:              cmdutil.SpecificArgs([]string{"key"}),
+		ValidArgsFunction: completeFirstArg(completeConfigKeys),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -290,7 +296,8 @@ func newConfigRmCmd(stack *string) *cobra.Command {
 			"if the value of `outer` is a map `inner: value`.\n" +
 			"    - `pulumi config rm --path names[0]` will remove the first item, " +
 			"if the value of `names` is a list.",
-		Args: cmdutil.SpecificArgs([]string{"key"}),
+		Args:              cmdutil.SpecificArgs([]string{"key"}),
+		ValidArgsFunction: completeFirstArg(completeConfigKeys),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -315,6 +322,9 @@ func newConfigRmCmd(stack *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if !path {
+				delete(ps.ConfigSources, key.String())
+			}
 
 			return saveProjectStack(s, ps)
 		}),
@@ -415,7 +425,8 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 			"will set the value of `parent` to a map `nested: value`.\n" +
 			"    - `pulumi config set --path '[\"parent.name\"].[\"nested.name\"]' value` will set the value of \n" +
 			"	`parent.name` to a map `nested.name: value`.",
-		Args: cmdutil.RangeArgs(1, 2),
+		Args:              cmdutil.RangeArgs(1, 2),
+		ValidArgsFunction: completeFirstArg(completeConfigKeys),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -489,6 +500,10 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if !path {
+				// The value no longer comes from another stack's outputs.
+				delete(ps.ConfigSources, key.String())
+			}
 
 			return saveProjectStack(s, ps)
 		}),
@@ -570,6 +585,8 @@ type configValueJSON struct {
 	Value       *string     `json:"value,omitempty"`
 	ObjectValue interface{} `json:"objectValue,omitempty"`
 	Secret      bool        `json:"secret"`
+	// If the value was imported from another stack's outputs, Source records where it came from.
+	Source *workspace.ConfigSource `json:"source,omitempty"`
 }
 
 func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
@@ -627,6 +644,10 @@ func listConfig(stack backend.Stack, showSecrets bool, jsonOut bool) error {
 				entry.ObjectValue = nil
 			}
 
+			if source, ok := ps.ConfigSources[key.String()]; ok {
+				entry.Source = &source
+			}
+
 			configValues[key.String()] = entry
 		}
 		out, err := json.MarshalIndent(configValues, "", "  ")
@@ -686,6 +707,9 @@ func getConfig(stack backend.Stack, key config.Key, path, jsonOut bool) error {
 				Value:  &raw,
 				Secret: v.Secure(),
 			}
+			if source, ok := ps.ConfigSources[key.String()]; ok && !path {
+				value.Source = &source
+			}
 
 			if v.Object() {
 				var obj interface{}
@@ -0,0 +1,191 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+func newConfigAuditCmd(stack *string) *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "List the configuration keys that the program reads",
+		Long: "List the configuration keys that the program reads.\n" +
+			"\n" +
+			"Previews the program and lists each configuration key that it reads or that is set for the stack\n" +
+			"in the project's namespace. Keys that are set but never read are reported as unused, and keys that\n" +
+			"are read but not set are reported as missing. Keys in other namespaces, e.g. `aws:region`, are\n" +
+			"read by providers rather than by the program, so they are listed only if the program reads them.\n" +
+			"\n" +
+			"The configuration keys that a program reads are reported by its language SDK, so this command\n" +
+			"requires an SDK that reports them.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			opts := display.Options{
+				Color:         cmdutil.GetGlobalColorization(),
+				IsInteractive: cmdutil.Interactive(),
+				Type:          display.DisplayProgress,
+			}
+
+			s, err := requireStack(*stack, true, opts, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			usage, res := previewConfigUsage(s, opts)
+			if res != nil {
+				return res
+			}
+			if usage == nil {
+				return result.Errorf("the program did not report the configuration keys that it reads; " +
+					"upgrade its Pulumi SDK to audit its configuration")
+			}
+
+			fmt.Println()
+			printConfigAudit(auditConfigUsage(usage))
+			return nil
+		}),
+	}
+
+	return auditCmd
+}
+
+// previewConfigUsage previews the program of the given stack and returns the configuration keys that the program
+// reported reading, or nil if it did not report them.
+func previewConfigUsage(s backend.Stack, opts display.Options) (*engine.ConfigUsage, result.Result) {
+	proj, root, err := readProject()
+	if err != nil {
+		return nil, result.FromError(err)
+	}
+	if err = ensureRuntimeEnv(proj, root); err != nil {
+		return nil, result.FromError(err)
+	}
+
+	m, err := getUpdateMetadata("", root)
+	if err != nil {
+		return nil, result.FromError(errors.Wrap(err, "gathering environment metadata"))
+	}
+	sm, err := getStackSecretsManager(s)
+	if err != nil {
+		return nil, result.FromError(errors.Wrap(err, "getting secrets manager"))
+	}
+	cfg, err := getStackConfiguration(s, sm)
+	if err != nil {
+		return nil, result.FromError(errors.Wrap(err, "getting stack configuration"))
+	}
+
+	var usage *engine.ConfigUsage
+	events, done := make(chan engine.Event), make(chan bool)
+	go func() {
+		for e := range events {
+			if e.Type == engine.SummaryEvent {
+				usage = e.Payload().(engine.SummaryEventPayload).ConfigUsage
+			}
+		}
+		close(done)
+	}()
+
+	_, res := s.Preview(commandContext(), backend.UpdateOperation{
+		Proj: proj,
+		Root: root,
+		M:    m,
+		Opts: backend.UpdateOptions{
+			Engine: engine.UpdateOptions{
+				UseLegacyDiff: useLegacyDiff(),
+			},
+			Display: opts,
+		},
+		StackConfiguration: cfg,
+		SecretsManager:     sm,
+		Scopes:             cancellationScopes,
+		Events:             events,
+	})
+	close(events)
+	<-done
+	if res != nil {
+		return nil, res
+	}
+	return usage, nil
+}
+
+// configKeyStatus describes how a program uses a configuration key.
+type configKeyStatus string
+
+const (
+	configKeyRead    configKeyStatus = "read"
+	configKeyUnused  configKeyStatus = "unused"
+	configKeyMissing configKeyStatus = "missing"
+)
+
+// configKeyAudit describes how a program uses a single configuration key.
+type configKeyAudit struct {
+	Key    config.Key
+	Status configKeyStatus
+}
+
+// auditConfigUsage returns the status of each key that the program read or left unused, sorted by key.
+func auditConfigUsage(usage *engine.ConfigUsage) []configKeyAudit {
+	statuses := map[string]configKeyStatus{}
+	for _, k := range usage.Read {
+		statuses[k] = configKeyRead
+	}
+	for _, k := range usage.Unused {
+		statuses[k] = configKeyUnused
+	}
+	for _, k := range usage.Missing {
+		statuses[k] = configKeyMissing
+	}
+
+	var audits []configKeyAudit
+	for k, status := range statuses {
+		key, err := config.ParseKey(k)
+		if err != nil {
+			continue
+		}
+		audits = append(audits, configKeyAudit{Key: key, Status: status})
+	}
+	sort.Slice(audits, func(i, j int) bool {
+		return audits[i].Key.String() < audits[j].Key.String()
+	})
+	return audits
+}
+
+func printConfigAudit(audits []configKeyAudit) {
+	if len(audits) == 0 {
+		fmt.Println("The program does not read any configuration keys")
+		return
+	}
+
+	rows := []cmdutil.TableRow{}
+	for _, audit := range audits {
+		rows = append(rows, cmdutil.TableRow{Columns: []string{prettyKey(audit.Key), string(audit.Status)}})
+	}
+
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"KEY", "STATUS"},
+		Rows:    rows,
+	})
+}
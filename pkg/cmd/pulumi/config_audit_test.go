@@ -0,0 +1,39 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+)
+
+func TestAuditConfigUsage(t *testing.T) {
+	audits := auditConfigUsage(&engine.ConfigUsage{
+		Read:    []string{"aws:region", "proj:missing", "proj:used"},
+		Unused:  []string{"proj:unused"},
+		Missing: []string{"proj:missing"},
+	})
+
+	assert.Equal(t, []configKeyAudit{
+		{Key: config.MustMakeKey("aws", "region"), Status: configKeyRead},
+		{Key: config.MustMakeKey("proj", "missing"), Status: configKeyMissing},
+		{Key: config.MustMakeKey("proj", "unused"), Status: configKeyUnused},
+		{Key: config.MustMakeKey("proj", "used"), Status: configKeyRead},
+	}, audits)
+}
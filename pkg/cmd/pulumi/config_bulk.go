@@ -0,0 +1,490 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// configFileFormat is the format of a file used to import or export configuration in bulk.
+type configFileFormat string
+
+const (
+	configFormatEnv  configFileFormat = "env"
+	configFormatJSON configFileFormat = "json"
+	configFormatYAML configFileFormat = "yaml"
+)
+
+// detectConfigFileFormat returns the format of the given configuration file. An explicit format takes precedence over
+// the file's name.
+func detectConfigFileFormat(file, format string) (configFileFormat, error) {
+	switch configFileFormat(format) {
+	case configFormatEnv, configFormatJSON, configFormatYAML:
+		return configFileFormat(format), nil
+	case "":
+		// Detect the format from the file name below.
+	default:
+		return "", errors.Errorf("unknown format '%s'; expected one of env, json, or yaml", format)
+	}
+
+	base := filepath.Base(file)
+	if base == ".env" || strings.HasPrefix(base, ".env.") || filepath.Ext(base) == ".env" {
+		return configFormatEnv, nil
+	}
+	if m, _ := encoding.Detect(file); m != nil {
+		if m.IsJSONLike() {
+			return configFormatJSON, nil
+		}
+		return configFormatYAML, nil
+	}
+	return "", errors.Errorf("could not detect the format of '%s'; pass --format to specify it", file)
+}
+
+func newConfigSetAllCmd(stack *string) *cobra.Command {
+	var file string
+	var format string
+	var plaintext bool
+	var secretPatterns []string
+
+	setAllCmd := &cobra.Command{
+		Use:   "set-all",
+		Short: "Set multiple configuration values from a file",
+		Long: "Sets configuration values in bulk from a dotenv, JSON, or YAML file. The format is detected from the\n" +
+			"file's name unless `--format` is given. JSON and YAML files must contain a single object whose\n" +
+			"properties are the configuration keys to set; nested objects and lists are stored as structured values.\n" +
+			"\n" +
+			"Values whose keys match any of the `--secret` patterns are encrypted, e.g.\n" +
+			"`pulumi config set-all --file .env --secret '*_PASSWORD' --secret '*_TOKEN'`.\n" +
+			"\n" +
+			"All values are validated and encrypted before the stack's configuration file is written, and the file\n" +
+			"is replaced in a single step, so a failure leaves the existing configuration untouched.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if file == "" {
+				return errors.New("missing required flag --file")
+			}
+			fileFormat, err := detectConfigFileFormat(file, format)
+			if err != nil {
+				return err
+			}
+			for _, pattern := range secretPatterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return errors.Wrapf(err, "invalid secret pattern '%s'", pattern)
+				}
+			}
+
+			s, err := requireStack(*stack, true, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			b, err := ioutil.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			values, err := parseConfigFile(b, fileFormat)
+			if err != nil {
+				return errors.Wrapf(err, "could not read configuration from '%s'", file)
+			}
+
+			// Only ask for an encrypter--which may prompt for a passphrase--if there are secrets to encrypt.
+			var encrypter config.Encrypter = config.NewPanicCrypter()
+			for name := range values {
+				if configKeyIsSecret(name, secretPatterns) {
+					if encrypter, err = getStackEncrypter(s); err != nil {
+						return err
+					}
+					break
+				}
+			}
+
+			newConfig, err := buildConfigValues(values, secretPatterns, plaintext, encrypter)
+			if err != nil {
+				return err
+			}
+
+			ps, err := loadProjectStack(s)
+			if err != nil {
+				return err
+			}
+			for key, value := range newConfig {
+				if err = ps.Config.Set(key, value, false); err != nil {
+					return err
+				}
+				delete(ps.ConfigSources, key.String())
+			}
+			return saveProjectStack(s, ps)
+		}),
+	}
+
+	setAllCmd.PersistentFlags().StringVarP(
+		&file, "file", "f", "",
+		"The dotenv, JSON, or YAML file to read configuration values from")
+	setAllCmd.PersistentFlags().StringVar(
+		&format, "format", "",
+		"The format of the file: env, json, or yaml. Detected from the file name if not specified")
+	setAllCmd.PersistentFlags().BoolVar(
+		&plaintext, "plaintext", false,
+		"Save values that look like secrets but match no --secret pattern as plaintext (unencrypted)")
+	setAllCmd.PersistentFlags().StringArrayVar(
+		&secretPatterns, "secret", nil,
+		"Encrypt the values of keys that match this pattern. May be specified multiple times")
+
+	return setAllCmd
+}
+
+// configKeyIsSecret returns true if the given key name matches any of the given patterns.
+func configKeyIsSecret(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// buildConfigValues converts the values read from a configuration file into configuration values, encrypting those
+// whose keys match the given secret patterns.
+func buildConfigValues(values map[string]config.Value, secretPatterns []string, plaintext bool,
+	encrypter config.Encrypter) (config.Map, error) {
+
+	result := make(config.Map)
+	for name, value := range values {
+		key, err := parseConfigKey(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid configuration key '%s'", name)
+		}
+		if value.Secure() {
+			return nil, errors.Errorf("the value of '%s' is already encrypted; import the plaintext instead", name)
+		}
+
+		raw, err := value.Value(config.NewPanicCrypter())
+		contract.AssertNoError(err)
+		if containsSecretPlaceholder(raw, value.Object()) {
+			return nil, errors.Errorf("the value of '%s' is a redacted secret written by `pulumi config export`; "+
+				"export with `--secrets omit` to leave secrets untouched, or with `--secrets show` to import them",
+				name)
+		}
+
+		switch {
+		case configKeyIsSecret(name, secretPatterns):
+			if value.Object() {
+				return nil, errors.Errorf("the value of '%s' is an object and cannot be encrypted as a whole; "+
+					"use `pulumi config set --path --secret` to encrypt its members", name)
+			}
+			enc, err := encrypter.EncryptValue(raw)
+			if err != nil {
+				return nil, err
+			}
+			value = config.NewSecureValue(enc)
+		case !plaintext && !value.Object() && looksLikeSecret(key, raw):
+			return nil, errors.Errorf(
+				"the value of '%s' looks like a secret; "+
+					"add a matching --secret pattern to encrypt it, or pass --plaintext to store it in plaintext", name)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// configSecretPlaceholder is the text that `pulumi config export` writes in place of secret values by default.
+const configSecretPlaceholder = "[secret]"
+
+// containsSecretPlaceholder returns true if the given raw configuration value is, or if it is an object, contains the
+// placeholder that `pulumi config export` writes in place of secrets. Importing such a value would replace the secret
+// with the placeholder's plaintext.
+func containsSecretPlaceholder(raw string, object bool) bool {
+	if !object {
+		return raw == configSecretPlaceholder
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return false
+	}
+	var visit func(v interface{}) bool
+	visit = func(v interface{}) bool {
+		switch v := v.(type) {
+		case string:
+			return v == configSecretPlaceholder
+		case []interface{}:
+			for _, e := range v {
+				if visit(e) {
+					return true
+				}
+			}
+		case map[string]interface{}:
+			for _, e := range v {
+				if visit(e) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return visit(v)
+}
+
+// parseConfigFile parses the contents of a configuration file in the given format into a map from key names to
+// plaintext configuration values.
+func parseConfigFile(b []byte, format configFileFormat) (map[string]config.Value, error) {
+	switch format {
+	case configFormatEnv:
+		return parseDotEnv(b)
+	case configFormatJSON:
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return nil, err
+		}
+		values := make(map[string]config.Value)
+		for name, msg := range raw {
+			msg = bytes.TrimSpace(msg)
+			switch {
+			case len(msg) > 0 && msg[0] == '"':
+				var s string
+				if err := json.Unmarshal(msg, &s); err != nil {
+					return nil, err
+				}
+				values[name] = config.NewValue(s)
+			case len(msg) > 0 && (msg[0] == '{' || msg[0] == '['):
+				var compact bytes.Buffer
+				if err := json.Compact(&compact, msg); err != nil {
+					return nil, err
+				}
+				values[name] = config.NewObjectValue(compact.String())
+			default:
+				// Numbers, booleans, and nulls are stored as strings, just as they would be by `pulumi config set`.
+				values[name] = config.NewValue(string(msg))
+			}
+		}
+		return values, nil
+	case configFormatYAML:
+		var values map[string]config.Value
+		if err := encoding.YAML.Unmarshal(b, &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	default:
+		return nil, errors.Errorf("unknown format '%s'", format)
+	}
+}
+
+// parseDotEnv parses the contents of a dotenv file. Each non-empty line that is not a comment holds an assignment
+// of the form `KEY=VALUE`, optionally preceded by `export`. Values may be single-quoted, in which case they are taken
+// literally, or double-quoted, in which case Go escape sequences are interpreted.
+func parseDotEnv(b []byte) (map[string]config.Value, error) {
+	values := make(map[string]config.Value)
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		eq := strings.Index(line, "=")
+		if eq <= 0 {
+			return nil, errors.Errorf("line %d: expected an assignment of the form KEY=VALUE", lineNumber)
+		}
+		name, value := strings.TrimSpace(line[:eq]), strings.TrimSpace(line[eq+1:])
+
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", lineNumber)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			// Unquoted values may be followed by a comment.
+			if hash := strings.Index(value, " #"); hash != -1 {
+				value = strings.TrimSpace(value[:hash])
+			}
+		}
+		values[name] = config.NewValue(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// configSecretsMode controls how `pulumi config export` writes secret values.
+type configSecretsMode string
+
+const (
+	configSecretsRedact configSecretsMode = "redact"
+	configSecretsOmit   configSecretsMode = "omit"
+	configSecretsShow   configSecretsMode = "show"
+)
+
+func newConfigExportCmd(stack *string) *cobra.Command {
+	var file string
+	var format string
+	var secrets string
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export configuration values to a file",
+		Long: "Writes the stack's configuration to a dotenv, JSON, or YAML file, or to standard out if no file is\n" +
+			"given.\n" +
+			"\n" +
+			"By default, secret values are replaced with `[secret]`. Pass `--secrets omit` to leave them out\n" +
+			"entirely, or `--secrets show` to write them in plaintext. Only output written with `--secrets omit`\n" +
+			"or `--secrets show` can be read back with `pulumi config set-all`, which rejects redacted secrets.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if format == "" && file == "" {
+				format = string(configFormatEnv)
+			}
+			fileFormat, err := detectConfigFileFormat(file, format)
+			if err != nil {
+				return err
+			}
+			mode := configSecretsMode(secrets)
+			switch mode {
+			case configSecretsRedact, configSecretsOmit, configSecretsShow:
+			default:
+				return errors.Errorf("unknown secrets mode '%s'; expected one of redact, omit, or show", secrets)
+			}
+
+			s, err := requireStack(*stack, true, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			ps, err := loadProjectStack(s)
+			if err != nil {
+				return err
+			}
+
+			decrypter := config.NewBlindingDecrypter()
+			if mode == configSecretsShow && ps.Config.HasSecureValue() {
+				if decrypter, err = getStackDecrypter(s); err != nil {
+					return err
+				}
+			}
+
+			b, err := formatConfigFile(ps.Config, fileFormat, mode, decrypter)
+			if err != nil {
+				return err
+			}
+			if file == "" {
+				_, err = os.Stdout.Write(b)
+				return err
+			}
+			return ioutil.WriteFile(file, b, 0600)
+		}),
+	}
+
+	exportCmd.PersistentFlags().StringVarP(
+		&file, "file", "f", "",
+		"The file to write configuration values to. Defaults to standard out")
+	exportCmd.PersistentFlags().StringVar(
+		&format, "format", "",
+		"The format to write: env, json, or yaml. Detected from the file name if not specified")
+	exportCmd.PersistentFlags().StringVar(
+		&secrets, "secrets", string(configSecretsRedact),
+		"How to write secret values: redact, omit, or show")
+
+	return exportCmd
+}
+
+// formatConfigFile formats the given configuration as a file in the given format. Keys are written as they are
+// displayed by `pulumi config`.
+func formatConfigFile(cfg config.Map, format configFileFormat, mode configSecretsMode,
+	decrypter config.Decrypter) ([]byte, error) {
+
+	var keys config.KeyArray
+	for key, value := range cfg {
+		if mode == configSecretsOmit && value.Secure() {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Sort(keys)
+
+	switch format {
+	case configFormatEnv:
+		var buf bytes.Buffer
+		for _, key := range keys {
+			value, err := cfg[key].Value(decrypter)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not decrypt configuration value")
+			}
+			if strings.ContainsAny(value, " \t\r\n\"'#\\") {
+				value = strconv.Quote(value)
+			}
+			fmt.Fprintf(&buf, "%s=%s\n", prettyKey(key), value)
+		}
+		return buf.Bytes(), nil
+	case configFormatJSON, configFormatYAML:
+		values := make(map[string]interface{})
+		for _, key := range keys {
+			value, err := cfg[key].Value(decrypter)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not decrypt configuration value")
+			}
+			if cfg[key].Object() {
+				var obj interface{}
+				if err := json.Unmarshal([]byte(value), &obj); err != nil {
+					return nil, err
+				}
+				values[prettyKey(key)] = obj
+			} else {
+				values[prettyKey(key)] = value
+			}
+		}
+		if format == configFormatYAML {
+			return encoding.YAML.Marshal(values)
+		}
+		b, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	default:
+		return nil, errors.Errorf("unknown format '%s'", format)
+	}
+}
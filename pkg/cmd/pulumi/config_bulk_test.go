@@ -0,0 +1,169 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+)
+
+func TestDetectConfigFileFormat(t *testing.T) {
+	for file, expected := range map[string]configFileFormat{
+		".env":            configFormatEnv,
+		"ci/.env.staging": configFormatEnv,
+		"prod.env":        configFormatEnv,
+		"config.json":     configFormatJSON,
+		"config.yaml":     configFormatYAML,
+		"config.yml":      configFormatYAML,
+	} {
+		format, err := detectConfigFileFormat(file, "")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, format, file)
+	}
+
+	format, err := detectConfigFileFormat("config.txt", "json")
+	assert.NoError(t, err)
+	assert.Equal(t, configFormatJSON, format)
+
+	_, err = detectConfigFileFormat("config.txt", "")
+	assert.Error(t, err)
+	_, err = detectConfigFileFormat("config.json", "toml")
+	assert.Error(t, err)
+}
+
+func TestParseConfigFile(t *testing.T) {
+	env := `
+# Database settings
+export app:host=db.example.com
+app:port = 5432 # the default
+app:motd="hello\nworld"
+app:raw='a \n b'
+`
+	values, err := parseConfigFile([]byte(env), configFormatEnv)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]config.Value{
+		"app:host": config.NewValue("db.example.com"),
+		"app:port": config.NewValue("5432"),
+		"app:motd": config.NewValue("hello\nworld"),
+		"app:raw":  config.NewValue(`a \n b`),
+	}, values)
+
+	_, err = parseConfigFile([]byte("not an assignment"), configFormatEnv)
+	assert.Error(t, err)
+
+	values, err = parseConfigFile([]byte(`{"app:host": "db", "app:port": 5432, "app:tags": {"env": "dev"}}`),
+		configFormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]config.Value{
+		"app:host": config.NewValue("db"),
+		"app:port": config.NewValue("5432"),
+		"app:tags": config.NewObjectValue(`{"env":"dev"}`),
+	}, values)
+
+	values, err = parseConfigFile([]byte("app:host: db\napp:port: 5432\napp:tags:\n  env: dev\n"), configFormatYAML)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]config.Value{
+		"app:host": config.NewValue("db"),
+		"app:port": config.NewValue("5432"),
+		"app:tags": config.NewObjectValue(`{"env":"dev"}`),
+	}, values)
+}
+
+func TestBuildConfigValues(t *testing.T) {
+	crypter := config.NewSymmetricCrypterFromPassphrase("test", []byte("salt"))
+
+	values := map[string]config.Value{
+		"app:host":       config.NewValue("db.example.com"),
+		"app:dbPassword": config.NewValue("hunter2"),
+	}
+	cfg, err := buildConfigValues(values, []string{"*Password"}, false, crypter)
+	assert.NoError(t, err)
+	assert.Equal(t, config.NewValue("db.example.com"), cfg[config.MustMakeKey("app", "host")])
+	password := cfg[config.MustMakeKey("app", "dbPassword")]
+	assert.True(t, password.Secure())
+	plaintext, err := password.Value(crypter)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", plaintext)
+
+	// Values that look like secrets must be encrypted or explicitly stored in plaintext.
+	values = map[string]config.Value{"app:token": config.NewValue("1415fc1f4eaeb5e096ee58c1480016638fff29bf")}
+	_, err = buildConfigValues(values, nil, false, crypter)
+	assert.Error(t, err)
+	_, err = buildConfigValues(values, nil, true, crypter)
+	assert.NoError(t, err)
+
+	// Objects cannot be encrypted as a whole, and ciphertext from elsewhere is rejected.
+	values = map[string]config.Value{"app:tags": config.NewObjectValue(`{"env":"dev"}`)}
+	_, err = buildConfigValues(values, []string{"app:*"}, false, crypter)
+	assert.Error(t, err)
+	values = map[string]config.Value{"app:key": config.NewSecureValue("AAABAJ==")}
+	_, err = buildConfigValues(values, nil, false, crypter)
+	assert.Error(t, err)
+
+	// Secrets redacted by `pulumi config export` are rejected rather than stored as "[secret]", even when they are
+	// encrypted or nested in an object.
+	enc, err := crypter.EncryptValue("hunter2")
+	assert.NoError(t, err)
+	exported, err := formatConfigFile(config.Map{
+		config.MustMakeKey("app", "pw"):   config.NewSecureValue(enc),
+		config.MustMakeKey("app", "host"): config.NewValue("db.example.com"),
+	}, configFormatYAML, configSecretsRedact, config.NewBlindingDecrypter())
+	assert.NoError(t, err)
+	values, err = parseConfigFile(exported, configFormatYAML)
+	assert.NoError(t, err)
+	_, err = buildConfigValues(values, []string{"*pw"}, false, crypter)
+	assert.Error(t, err)
+	_, err = buildConfigValues(values, nil, true, crypter)
+	assert.Error(t, err)
+	values = map[string]config.Value{"app:db": config.NewObjectValue(`{"users":[{"password":"[secret]"}]}`)}
+	_, err = buildConfigValues(values, nil, true, crypter)
+	assert.Error(t, err)
+}
+
+func TestFormatConfigFile(t *testing.T) {
+	crypter := config.NewSymmetricCrypterFromPassphrase("test", []byte("salt"))
+	secret, err := crypter.EncryptValue("hunter2")
+	assert.NoError(t, err)
+
+	cfg := config.Map{
+		config.MustMakeKey("app", "host"):     config.NewValue("db.example.com"),
+		config.MustMakeKey("app", "motd"):     config.NewValue("hello world"),
+		config.MustMakeKey("app", "password"): config.NewSecureValue(secret),
+		config.MustMakeKey("app", "tags"):     config.NewObjectValue(`{"env":"dev"}`),
+	}
+
+	b, err := formatConfigFile(cfg, configFormatEnv, configSecretsRedact, config.NewBlindingDecrypter())
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"app:host=db.example.com\napp:motd=\"hello world\"\napp:password=[secret]\n"+
+			`app:tags="{\"env\":\"dev\"}"`+"\n",
+		string(b))
+
+	b, err = formatConfigFile(cfg, configFormatJSON, configSecretsOmit, config.NewBlindingDecrypter())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"app:host": "db.example.com", "app:motd": "hello world", "app:tags": {"env": "dev"}}`,
+		string(b))
+
+	// Exported files can be read back in.
+	b, err = formatConfigFile(cfg, configFormatYAML, configSecretsShow, crypter)
+	assert.NoError(t, err)
+	values, err := parseConfigFile(b, configFormatYAML)
+	assert.NoError(t, err)
+	assert.Equal(t, config.NewValue("hunter2"), values["app:password"])
+	assert.Equal(t, config.NewObjectValue(`{"env":"dev"}`), values["app:tags"])
+}
@@ -0,0 +1,247 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newConfigDiffCmd(stack *string) *cobra.Command {
+	var jsonOut bool
+
+	diffCmd := &cobra.Command{
+		Use:   "diff <other-stack>",
+		Short: "Compare the configuration of two stacks",
+		Long: "Compares the configuration of the current stack with that of another stack, listing the keys that\n" +
+			"the other stack adds, removes, or changes.\n" +
+			"\n" +
+			"Secret values are decrypted with each stack's secrets provider and compared by digest, so two stacks\n" +
+			"that hold the same secret are reported as equal even though their ciphertexts differ. Secret values\n" +
+			"are never displayed.",
+		Args: cmdutil.ExactArgs(1),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			currentStack, err := requireStack(*stack, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			if currentStack.Ref().Name().String() == args[0] {
+				return errors.New("current stack and other stack are the same")
+			}
+			otherStack, err := requireStack(args[0], false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			currentConfig, currentDecrypter, err := loadConfigForDiff(currentStack)
+			if err != nil {
+				return err
+			}
+			otherConfig, otherDecrypter, err := loadConfigForDiff(otherStack)
+			if err != nil {
+				return err
+			}
+
+			changes, err := diffConfig(currentConfig, currentDecrypter, otherConfig, otherDecrypter)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				return printConfigChangesJSON(changes)
+			}
+			printConfigChanges(changes, currentStack.Ref().Name().String(), otherStack.Ref().Name().String())
+			return nil
+		}),
+	}
+
+	diffCmd.Flags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+
+	return diffCmd
+}
+
+// loadConfigForDiff loads the configuration of the given stack along with a decrypter for its secret values.
+func loadConfigForDiff(stack backend.Stack) (config.Map, config.Decrypter, error) {
+	ps, err := loadProjectStack(stack)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ps.Config.HasSecureValue() {
+		return ps.Config, config.NewPanicCrypter(), nil
+	}
+	dec, err := getStackDecrypter(stack)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not create a decrypter for stack '%s'", stack.Ref())
+	}
+	return ps.Config, dec, nil
+}
+
+// configChangeKind describes how a configuration key differs between two stacks.
+type configChangeKind string
+
+const (
+	configKeyAdded   configChangeKind = "added"
+	configKeyRemoved configChangeKind = "removed"
+	configKeyChanged configChangeKind = "changed"
+)
+
+// configChange describes a single configuration key that differs between two stacks. The old and new values are
+// suitable for display: secret values are blinded.
+type configChange struct {
+	Key      config.Key
+	Kind     configChangeKind
+	Old, New *string
+}
+
+// diffConfig returns the keys that differ between the two configuration maps, sorted by key. Each map's secret values
+// are decrypted with its own decrypter and compared by digest, so that equal secrets compare as equal regardless of
+// how they were encrypted.
+func diffConfig(oldConfig config.Map, oldDecrypter config.Decrypter,
+	newConfig config.Map, newDecrypter config.Decrypter) ([]configChange, error) {
+
+	var keys config.KeyArray
+	for key := range oldConfig {
+		keys = append(keys, key)
+	}
+	for key := range newConfig {
+		if _, has := oldConfig[key]; !has {
+			keys = append(keys, key)
+		}
+	}
+	sort.Sort(keys)
+
+	var changes []configChange
+	for _, key := range keys {
+		oldValue, hasOld := oldConfig[key]
+		newValue, hasNew := newConfig[key]
+
+		change := configChange{Key: key}
+		switch {
+		case !hasOld:
+			change.Kind = configKeyAdded
+		case !hasNew:
+			change.Kind = configKeyRemoved
+		default:
+			equal, err := configValuesEqual(oldValue, oldDecrypter, newValue, newDecrypter)
+			if err != nil {
+				return nil, errors.Wrapf(err, "comparing configuration key '%s'", prettyKey(key))
+			}
+			if equal {
+				continue
+			}
+			change.Kind = configKeyChanged
+		}
+
+		if hasOld {
+			shown, err := oldValue.Value(config.NewBlindingDecrypter())
+			if err != nil {
+				return nil, err
+			}
+			change.Old = &shown
+		}
+		if hasNew {
+			shown, err := newValue.Value(config.NewBlindingDecrypter())
+			if err != nil {
+				return nil, err
+			}
+			change.New = &shown
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// configValuesEqual returns true if the two values are both secret or both plaintext and have the same plaintext.
+func configValuesEqual(oldValue config.Value, oldDecrypter config.Decrypter,
+	newValue config.Value, newDecrypter config.Decrypter) (bool, error) {
+
+	if oldValue.Secure() != newValue.Secure() || oldValue.Object() != newValue.Object() {
+		return false, nil
+	}
+	oldDigest, err := configValueDigest(oldValue, oldDecrypter)
+	if err != nil {
+		return false, err
+	}
+	newDigest, err := configValueDigest(newValue, newDecrypter)
+	if err != nil {
+		return false, err
+	}
+	return oldDigest == newDigest, nil
+}
+
+// configValueDigest returns a digest of the plaintext of the given value. The plaintext itself is discarded.
+func configValueDigest(v config.Value, decrypter config.Decrypter) ([sha256.Size]byte, error) {
+	plaintext, err := v.Value(decrypter)
+	if err != nil {
+		return [sha256.Size]byte{}, errors.Wrap(err, "could not decrypt configuration value")
+	}
+	return sha256.Sum256([]byte(plaintext)), nil
+}
+
+func printConfigChanges(changes []configChange, oldStack, newStack string) {
+	if len(changes) == 0 {
+		fmt.Printf("The configuration of stacks '%s' and '%s' is the same\n", oldStack, newStack)
+		return
+	}
+
+	valueOrEmpty := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+
+	rows := []cmdutil.TableRow{}
+	for _, change := range changes {
+		rows = append(rows, cmdutil.TableRow{Columns: []string{
+			prettyKey(change.Key), string(change.Kind), valueOrEmpty(change.Old), valueOrEmpty(change.New),
+		}})
+	}
+
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"KEY", "CHANGE", oldStack, newStack},
+		Rows:    rows,
+	})
+}
+
+// configChangeJSON is the shape of the --json output of `pulumi config diff`.
+type configChangeJSON struct {
+	Change configChangeKind `json:"change"`
+	Old    *string          `json:"old,omitempty"`
+	New    *string          `json:"new,omitempty"`
+}
+
+func printConfigChangesJSON(changes []configChange) error {
+	out := make(map[string]configChangeJSON)
+	for _, change := range changes {
+		out[change.Key.String()] = configChangeJSON{Change: change.Kind, Old: change.Old, New: change.New}
+	}
+	return printJSON(out)
+}
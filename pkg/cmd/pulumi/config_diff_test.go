@@ -0,0 +1,69 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+)
+
+func TestDiffConfig(t *testing.T) {
+	devCrypter := config.NewSymmetricCrypterFromPassphrase("dev", []byte("dev-salt"))
+	prodCrypter := config.NewSymmetricCrypterFromPassphrase("prod", []byte("prod-salt"))
+
+	encrypt := func(crypter config.Crypter, plaintext string) config.Value {
+		ciphertext, err := crypter.EncryptValue(plaintext)
+		assert.NoError(t, err)
+		return config.NewSecureValue(ciphertext)
+	}
+
+	dev := config.Map{
+		config.MustMakeKey("app", "region"):   config.NewValue("us-west-2"),
+		config.MustMakeKey("app", "size"):     config.NewValue("small"),
+		config.MustMakeKey("app", "debug"):    config.NewValue("true"),
+		config.MustMakeKey("app", "apiKey"):   encrypt(devCrypter, "shared"),
+		config.MustMakeKey("app", "password"): encrypt(devCrypter, "hunter2"),
+	}
+	prod := config.Map{
+		config.MustMakeKey("app", "region"):   config.NewValue("us-west-2"),
+		config.MustMakeKey("app", "size"):     config.NewValue("large"),
+		config.MustMakeKey("app", "replicas"): config.NewValue("3"),
+		config.MustMakeKey("app", "apiKey"):   encrypt(prodCrypter, "shared"),
+		config.MustMakeKey("app", "password"): encrypt(prodCrypter, "correct horse"),
+	}
+
+	changes, err := diffConfig(dev, devCrypter, prod, prodCrypter)
+	assert.NoError(t, err)
+
+	str := func(s string) *string { return &s }
+	assert.Equal(t, []configChange{
+		{Key: config.MustMakeKey("app", "debug"), Kind: configKeyRemoved, Old: str("true")},
+		{Key: config.MustMakeKey("app", "password"), Kind: configKeyChanged, Old: str("[secret]"), New: str("[secret]")},
+		{Key: config.MustMakeKey("app", "replicas"), Kind: configKeyAdded, New: str("3")},
+		{Key: config.MustMakeKey("app", "size"), Kind: configKeyChanged, Old: str("small"), New: str("large")},
+	}, changes)
+
+	// A value that becomes a secret is reported as changed even if its plaintext is the same.
+	secretDebug := config.Map{config.MustMakeKey("app", "debug"): encrypt(prodCrypter, "true")}
+	changes, err = diffConfig(config.Map{config.MustMakeKey("app", "debug"): config.NewValue("true")},
+		devCrypter, secretDebug, prodCrypter)
+	assert.NoError(t, err)
+	assert.Equal(t, []configChange{
+		{Key: config.MustMakeKey("app", "debug"), Kind: configKeyChanged, Old: str("true"), New: str("[secret]")},
+	}, changes)
+}
@@ -0,0 +1,221 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func newConfigImportOutputsCmd(stackName *string) *cobra.Command {
+	var secret bool
+
+	importCmd := &cobra.Command{
+		Use:   "import-outputs <source-stack> <output>[=<key>]...",
+		Short: "Import outputs of another stack as configuration",
+		Long: "Import outputs of another stack as configuration.\n" +
+			"\n" +
+			"Each named output of the source stack is copied into the configuration of the current stack, under\n" +
+			"the given key or, if no key is given, under a key with the same name as the output. Secret outputs\n" +
+			"are decrypted with the source stack's secrets provider and encrypted again with the current stack's,\n" +
+			"so their values are never shown. Pass --secret to encrypt the other outputs as well.\n" +
+			"\n" +
+			"The stack, output and time of each import are recorded alongside the configuration, and are shown\n" +
+			"by `pulumi config --json` and `pulumi config get --json`. Setting or removing the key clears them.",
+		Args: cmdutil.ArgsFunc(cobra.MinimumNArgs(2)),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			imports, err := parseOutputImports(args[1:])
+			if err != nil {
+				return err
+			}
+
+			s, err := requireStack(*stackName, true, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			source, err := requireStack(args[0], false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			if source.Ref().String() == s.Ref().String() {
+				return errors.New("cannot import outputs of a stack into its own configuration")
+			}
+
+			snap, err := source.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			values, err := resolveOutputImports(snap, source.Ref().String(), imports)
+			if err != nil {
+				return err
+			}
+
+			// Only ask for the encrypter if we need it, as doing so may prompt for a passphrase.
+			var encrypter config.Encrypter = config.NewPanicCrypter()
+			for _, v := range values {
+				if v.secret || secret {
+					if encrypter, err = getStackEncrypter(s); err != nil {
+						return err
+					}
+					break
+				}
+			}
+
+			ps, err := loadProjectStack(s)
+			if err != nil {
+				return err
+			}
+			var updated time.Time
+			if snap != nil {
+				updated = snap.Manifest.Time
+			}
+			if err = applyOutputImports(ps, source.Ref().String(), updated, values, secret, encrypter,
+				time.Now()); err != nil {
+				return err
+			}
+			if err = saveProjectStack(s, ps); err != nil {
+				return err
+			}
+
+			for _, v := range values {
+				fmt.Printf("Imported output '%s' of stack '%s' as '%s'\n", v.output, source.Ref(), prettyKey(v.key))
+			}
+			return nil
+		}),
+	}
+
+	importCmd.PersistentFlags().BoolVar(
+		&secret, "secret", false,
+		"Encrypt every imported value, not only the values of secret outputs")
+
+	return importCmd
+}
+
+// outputImport names a stack output to import and the configuration key to import it as.
+type outputImport struct {
+	output string
+	key    config.Key
+}
+
+// parseOutputImports parses arguments of the form `output` or `output=key`.
+func parseOutputImports(args []string) ([]outputImport, error) {
+	imports := make([]outputImport, len(args))
+	for i, arg := range args {
+		output, key := arg, arg
+		if eq := strings.Index(arg, "="); eq != -1 {
+			output, key = arg[:eq], arg[eq+1:]
+		}
+		if output == "" || key == "" {
+			return nil, errors.Errorf("invalid output '%s': expected 'output' or 'output=key'", arg)
+		}
+
+		k, err := parseConfigKey(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid configuration key '%s'", key)
+		}
+		imports[i] = outputImport{output: output, key: k}
+	}
+	return imports, nil
+}
+
+// importedOutput is the plaintext value of an output that is being imported.
+type importedOutput struct {
+	outputImport
+	value  string
+	secret bool
+}
+
+// resolveOutputImports looks up the value of each imported output in the source stack's snapshot. Values that are not
+// strings are imported as JSON, which `config.requireObject` and its equivalents in each language will parse.
+func resolveOutputImports(snap *deploy.Snapshot, source string, imports []outputImport) ([]importedOutput, error) {
+	state, err := stack.GetRootStackResource(snap)
+	if err != nil {
+		return nil, err
+	}
+	var outputs resource.PropertyMap
+	if state != nil {
+		outputs = state.Outputs
+	}
+
+	values := make([]importedOutput, len(imports))
+	for i, imp := range imports {
+		v, ok := outputs[resource.PropertyKey(imp.output)]
+		if !ok || v.IsNull() {
+			return nil, errors.Errorf("stack '%s' does not have an output named '%s'", source, imp.output)
+		}
+		if v.ContainsUnknowns() {
+			return nil, errors.Errorf("the value of output '%s' of stack '%s' is not known", imp.output, source)
+		}
+
+		plain := display.MassageSecrets(resource.PropertyMap{"v": v}, true /*showSecrets*/)
+		values[i] = importedOutput{
+			outputImport: imp,
+			value:        stringifyOutput(plain["v"].Mappable()),
+			secret:       v.ContainsSecrets(),
+		}
+	}
+	return values, nil
+}
+
+// applyOutputImports sets the imported values in a stack's configuration, encrypting those that are secret, and
+// records where each value came from.
+func applyOutputImports(ps *workspace.ProjectStack, source string, updated time.Time, values []importedOutput,
+	secret bool, encrypter config.Encrypter, now time.Time) error {
+
+	for _, v := range values {
+		value := config.NewValue(v.value)
+		if v.secret || secret {
+			ciphertext, err := encrypter.EncryptValue(v.value)
+			if err != nil {
+				return errors.Wrapf(err, "encrypting output '%s'", v.output)
+			}
+			value = config.NewSecureValue(ciphertext)
+		}
+		if err := ps.Config.Set(v.key, value, false); err != nil {
+			return err
+		}
+
+		provenance := workspace.ConfigSource{
+			Stack:    source,
+			Output:   v.output,
+			Secret:   v.secret,
+			Imported: now.UTC().Format(time.RFC3339),
+		}
+		if !updated.IsZero() {
+			provenance.Updated = updated.UTC().Format(time.RFC3339)
+		}
+		if ps.ConfigSources == nil {
+			ps.ConfigSources = make(map[string]workspace.ConfigSource)
+		}
+		ps.ConfigSources[v.key.String()] = provenance
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestParseOutputImports(t *testing.T) {
+	imports, err := parseOutputImports([]string{"db:password", "endpoint=app:dbEndpoint"})
+	assert.NoError(t, err)
+	assert.Equal(t, []outputImport{
+		{output: "db:password", key: config.MustMakeKey("db", "password")},
+		{output: "endpoint", key: config.MustMakeKey("app", "dbEndpoint")},
+	}, imports)
+
+	_, err = parseOutputImports([]string{"=app:key"})
+	assert.Error(t, err)
+	_, err = parseOutputImports([]string{"output="})
+	assert.Error(t, err)
+}
+
+func TestImportStackOutputs(t *testing.T) {
+	updated := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	urn := resource.NewURN("source", "proj", "", resource.RootStackType, "proj-source")
+	root := resource.NewState(resource.RootStackType, urn, false, false, "", resource.PropertyMap{},
+		resource.PropertyMap{
+			"endpoint": resource.NewStringProperty("db.example.com"),
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+			"ports":    resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(5432)}),
+		}, "", false, false, nil, nil, "", nil, false, nil, nil, nil, "")
+	snap := deploy.NewSnapshot(deploy.Manifest{Time: updated}, nil, []*resource.State{root}, nil)
+
+	values, err := resolveOutputImports(snap, "org/proj/source", []outputImport{
+		{output: "endpoint", key: config.MustMakeKey("app", "endpoint")},
+		{output: "password", key: config.MustMakeKey("app", "dbPassword")},
+		{output: "ports", key: config.MustMakeKey("app", "ports")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "db.example.com", values[0].value)
+	assert.False(t, values[0].secret)
+	assert.Equal(t, "hunter2", values[1].value)
+	assert.True(t, values[1].secret)
+	assert.Equal(t, "[5432]", values[2].value)
+
+	_, err = resolveOutputImports(snap, "org/proj/source", []outputImport{
+		{output: "missing", key: config.MustMakeKey("app", "missing")},
+	})
+	assert.EqualError(t, err, "stack 'org/proj/source' does not have an output named 'missing'")
+
+	crypter := config.NewSymmetricCrypter(make([]byte, 32))
+	now := updated.Add(time.Hour)
+	ps := &workspace.ProjectStack{Config: make(config.Map)}
+	err = applyOutputImports(ps, "org/proj/source", updated, values, false, crypter, now)
+	assert.NoError(t, err)
+
+	endpoint := ps.Config[config.MustMakeKey("app", "endpoint")]
+	assert.False(t, endpoint.Secure())
+	password := ps.Config[config.MustMakeKey("app", "dbPassword")]
+	assert.True(t, password.Secure())
+	plaintext, err := password.Value(crypter)
+	assert.NoError(t, err)
+	assert.Equal(t, "hunter2", plaintext)
+
+	assert.Equal(t, workspace.ConfigSource{
+		Stack:    "org/proj/source",
+		Output:   "password",
+		Secret:   true,
+		Updated:  "2020-06-01T12:00:00Z",
+		Imported: "2020-06-01T13:00:00Z",
+	}, ps.ConfigSources["app:dbPassword"])
+	assert.Len(t, ps.ConfigSources, 3)
+}
@@ -17,6 +17,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -24,6 +25,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
@@ -31,6 +33,8 @@ import (
 
 func newDestroyCmd() *cobra.Command {
 	var debug bool
+	var expectNop bool
+	var detailedExitCode bool
 	var stack string
 
 	var message string
@@ -40,6 +44,7 @@ func newDestroyCmd() *cobra.Command {
 	var eventLogPath string
 	var parallel int
 	var refresh bool
+	var fromRefresh bool
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
@@ -47,6 +52,8 @@ func newDestroyCmd() *cobra.Command {
 	var suppressOutputs bool
 	var yes bool
 	var targets *[]string
+	var confirmStack string
+	var overrideFreeze bool
 	var targetDependents bool
 
 	var cmd = &cobra.Command{
@@ -59,7 +66,12 @@ func newDestroyCmd() *cobra.Command {
 			"loaded from the associated state file in the workspace.  After running to completion,\n" +
 			"all of this stack's resources and associated state will be gone.\n" +
 			"\n" +
-			"Warning: this command is generally irreversible and should be used with great care.",
+			"Warning: this command is generally irreversible and should be used with great care.\n" +
+			"\n" +
+			"Use `--from-refresh` to clean up a stack whose state is stale. The stack is refreshed first, so that\n" +
+			"resources that were already deleted outside of Pulumi are skipped and the rest are destroyed based on\n" +
+			"their live state, and a report of the differences found between the state and the live resources is\n" +
+			"printed afterwards." + exitCodesHelp,
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			yes = yes || skipConfirmations()
@@ -72,6 +84,8 @@ func newDestroyCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			opts.ConfirmStackName = confirmStack
+			opts.OverrideFreeze = overrideFreeze
 
 			var displayType = display.DisplayProgress
 			if diffDisplay {
@@ -119,16 +133,52 @@ func newDestroyCmd() *cobra.Command {
 				targetUrns = append(targetUrns, resource.URN(t))
 			}
 
+			// With --from-refresh, the stack is refreshed as a separate operation so that the state it leaves behind can
+			// be compared with the original state for the reconciliation report.
+			var before, refreshed *deploy.Snapshot
+			if fromRefresh {
+				if before, err = s.Snapshot(commandContext()); err != nil {
+					return result.FromError(errors.Wrap(err, "getting stack snapshot"))
+				}
+
+				refreshOpts := opts
+				refreshOpts.Engine = engine.UpdateOptions{
+					Parallel:      parallel,
+					Debug:         debug,
+					UseLegacyDiff: useLegacyDiff(),
+				}
+				_, res := s.Refresh(commandContext(), backend.UpdateOperation{
+					Proj:               proj,
+					Root:               root,
+					M:                  m,
+					Opts:               refreshOpts,
+					StackConfiguration: cfg,
+					SecretsManager:     sm,
+					Scopes:             cancellationScopes,
+				})
+				if res != nil {
+					if res.Error() == context.Canceled {
+						return result.FromError(errors.New("refresh cancelled"))
+					}
+					return PrintEngineResult(res)
+				}
+
+				if refreshed, err = s.Snapshot(commandContext()); err != nil {
+					return result.FromError(errors.Wrap(err, "getting stack snapshot"))
+				}
+			}
+
 			opts.Engine = engine.UpdateOptions{
 				Parallel:         parallel,
 				Debug:            debug,
-				Refresh:          refresh,
+				Refresh:          refresh && !fromRefresh,
 				DestroyTargets:   targetUrns,
 				TargetDependents: targetDependents,
 				UseLegacyDiff:    useLegacyDiff(),
 			}
 
-			_, res := s.Destroy(commandContext(), backend.UpdateOperation{
+			outcome := newOperationOutcome(false, expectNop, detailedExitCode)
+			changes, res := s.Destroy(commandContext(), backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
 				M:                  m,
@@ -136,7 +186,18 @@ func newDestroyCmd() *cobra.Command {
 				StackConfiguration: cfg,
 				SecretsManager:     sm,
 				Scopes:             cancellationScopes,
+				Events:             outcome.events,
 			})
+			outcome.close()
+
+			if fromRefresh && (res == nil || res.Error() != context.Canceled) {
+				after, err := s.Snapshot(commandContext())
+				if err != nil {
+					return result.FromError(errors.Wrap(err, "getting stack snapshot"))
+				}
+				fmt.Println()
+				reconcileDestroy(before, refreshed, after).print(os.Stdout)
+			}
 
 			if res == nil && len(*targets) == 0 {
 				fmt.Printf("The resources in the stack have been deleted, but the history and configuration "+
@@ -145,13 +206,19 @@ func newDestroyCmd() *cobra.Command {
 			} else if res != nil && res.Error() == context.Canceled {
 				return result.FromError(errors.New("destroy cancelled"))
 			}
-			return PrintEngineResult(res)
+			return outcome.result(changes, res)
 		}),
 	}
 
 	cmd.PersistentFlags().BoolVarP(
 		&debug, "debug", "d", false,
 		"Print detailed debugging output during resource operations")
+	cmd.PersistentFlags().BoolVar(
+		&expectNop, "expect-no-changes", false,
+		"Return an error if any changes occur during this destroy")
+	cmd.PersistentFlags().BoolVar(
+		&detailedExitCode, "detailed-exit-code", false,
+		"Exit with a code that says whether this destroy changed the stack")
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
@@ -180,6 +247,10 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
+	cmd.PersistentFlags().BoolVar(
+		&fromRefresh, "from-refresh", false,
+		"Refresh the stack first and destroy its resources based on their live state, skipping resources that "+
+			"were already deleted, then report the differences found")
 	cmd.PersistentFlags().BoolVar(
 		&showConfig, "show-config", false,
 		"Show configuration keys and variables")
@@ -199,6 +270,12 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the destroy after previewing it")
+	cmd.PersistentFlags().StringVar(
+		&confirmStack, "confirm-stack", "",
+		"Confirm the destroy by giving the stack's name, if the project requires it to be typed")
+	cmd.PersistentFlags().BoolVar(
+		&overrideFreeze, "override-freeze", false,
+		"Perform the destroy even if the stack is frozen")
 
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
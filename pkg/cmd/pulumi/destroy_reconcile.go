@@ -0,0 +1,91 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// destroyReconciliation reports how the live resources of a stack compared with its state during a
+// `pulumi destroy --from-refresh`.
+type destroyReconciliation struct {
+	AlreadyDeleted []resource.URN // resources in the state that the refresh found no longer exist.
+	Drifted        []resource.URN // resources whose live state differed from the state.
+	Destroyed      []resource.URN // resources that were destroyed.
+	Remaining      []resource.URN // resources that still exist, e.g. because their deletion failed.
+}
+
+// reconcileDestroy compares the snapshots taken before the refresh, after the refresh, and after the destroy. Only
+// custom resources are reported, as they are the only resources that the refresh reads from their providers.
+func reconcileDestroy(before, refreshed, after *deploy.Snapshot) destroyReconciliation {
+	live := func(snap *deploy.Snapshot) map[resource.URN]*resource.State {
+		states := make(map[resource.URN]*resource.State)
+		if snap == nil {
+			return states
+		}
+		for _, res := range snap.Resources {
+			if res.Custom && !res.Delete && !providers.IsProviderType(res.Type) {
+				states[res.URN] = res
+			}
+		}
+		return states
+	}
+	refreshedStates, afterStates := live(refreshed), live(after)
+
+	var rec destroyReconciliation
+	if before != nil {
+		for _, old := range before.Resources {
+			if !old.Custom || old.Delete || providers.IsProviderType(old.Type) {
+				continue
+			}
+			res, has := refreshedStates[old.URN]
+			switch {
+			case !has:
+				rec.AlreadyDeleted = append(rec.AlreadyDeleted, old.URN)
+				continue
+			case !res.Outputs.DeepEquals(old.Outputs):
+				rec.Drifted = append(rec.Drifted, old.URN)
+			}
+			if _, has := afterStates[old.URN]; has {
+				rec.Remaining = append(rec.Remaining, old.URN)
+			} else {
+				rec.Destroyed = append(rec.Destroyed, old.URN)
+			}
+		}
+	}
+	return rec
+}
+
+// print writes the reconciliation report to the given writer.
+func (rec destroyReconciliation) print(w io.Writer) {
+	fmt.Fprintf(w, "Reconciliation:\n")
+	section := func(label string, urns []resource.URN, list bool) {
+		fmt.Fprintf(w, "    %d %s\n", len(urns), label)
+		if list {
+			for _, urn := range urns {
+				fmt.Fprintf(w, "        %s\n", urn)
+			}
+		}
+	}
+	section("already deleted outside of Pulumi", rec.AlreadyDeleted, true)
+	section("drifted from the stack's state", rec.Drifted, true)
+	section("destroyed", rec.Destroyed, false)
+	section("remaining", rec.Remaining, true)
+}
@@ -0,0 +1,71 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func TestReconcileDestroy(t *testing.T) {
+	state := func(name string, custom bool, outputs resource.PropertyMap) *resource.State {
+		typ, id := tokens.Type("aws:s3/bucket:Bucket"), resource.ID(name)
+		if !custom {
+			typ, id = "my:component:Component", ""
+		}
+		urn := resource.NewURN("dev", "proj", "", typ, tokens.QName(name))
+		return resource.NewState(typ, urn, custom, false, id, resource.PropertyMap{}, outputs,
+			"", false, false, nil, nil, "", nil, false, nil, nil, nil, "")
+	}
+	snapshot := func(states ...*resource.State) *deploy.Snapshot {
+		return deploy.NewSnapshot(deploy.Manifest{}, nil, states, nil)
+	}
+	outputs := func(v string) resource.PropertyMap {
+		return resource.PropertyMap{"tag": resource.NewStringProperty(v)}
+	}
+
+	component := state("component", false, nil)
+	gone := state("gone", true, outputs("a"))
+	same := state("same", true, outputs("a"))
+	drifted := state("drifted", true, outputs("a"))
+	stuck := state("stuck", true, outputs("a"))
+
+	before := snapshot(component, gone, same, drifted, stuck)
+	refreshed := snapshot(component, same, state("drifted", true, outputs("b")), stuck)
+	after := snapshot(stuck)
+
+	rec := reconcileDestroy(before, refreshed, after)
+	assert.Equal(t, []resource.URN{gone.URN}, rec.AlreadyDeleted)
+	assert.Equal(t, []resource.URN{drifted.URN}, rec.Drifted)
+	assert.Equal(t, []resource.URN{same.URN, drifted.URN}, rec.Destroyed)
+	assert.Equal(t, []resource.URN{stuck.URN}, rec.Remaining)
+
+	var buf bytes.Buffer
+	rec.print(&buf)
+	assert.Equal(t, "Reconciliation:\n"+
+		"    1 already deleted outside of Pulumi\n"+
+		"        "+string(gone.URN)+"\n"+
+		"    1 drifted from the stack's state\n"+
+		"        "+string(drifted.URN)+"\n"+
+		"    2 destroyed\n"+
+		"    1 remaining\n"+
+		"        "+string(stuck.URN)+"\n", buf.String())
+}
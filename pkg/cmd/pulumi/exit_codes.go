@@ -0,0 +1,132 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+// exitCodesHelp documents the exit codes of the commands that operate on stacks. It is appended to their help text.
+const exitCodesHelp = "\n" +
+	"\n" +
+	"Exit codes:\n" +
+	"  0    the operation succeeded (with --detailed-exit-code, it made or proposed no changes)\n" +
+	"  3    the preview succeeded and proposed changes (with --detailed-exit-code)\n" +
+	"  4    the operation succeeded and changed the stack (with --detailed-exit-code)\n" +
+	"  5    changes were proposed or made, but --expect-no-changes was passed\n" +
+	"  6    a resource violated a mandatory policy\n" +
+	"  7    another update to the stack is in progress\n" +
+	"  8    a resource provider failed to create, read, update, or delete a resource\n" +
+	"  255  any other failure"
+
+// operationOutcome watches the engine events of an operation on a stack so that the command that ran the operation
+// can exit with a code that describes its outcome.
+type operationOutcome struct {
+	preview          bool // true if the operation is a preview.
+	expectNop        bool // true if the operation is expected to make or propose no changes.
+	detailedExitCode bool // true if the command should exit with a code that says whether there were changes.
+
+	events chan engine.Event
+	done   chan bool
+
+	policyViolation bool // true if a resource violated a mandatory policy.
+	resourceFailure bool // true if a provider failed to operate on a resource.
+}
+
+// newOperationOutcome starts watching the events sent to the outcome's events channel, which should be passed to the
+// operation in its backend.UpdateOperation.
+func newOperationOutcome(preview, expectNop, detailedExitCode bool) *operationOutcome {
+	o := &operationOutcome{
+		preview:          preview,
+		expectNop:        expectNop,
+		detailedExitCode: detailedExitCode,
+		events:           make(chan engine.Event),
+		done:             make(chan bool),
+	}
+	go func() {
+		for e := range o.events {
+			o.observe(e)
+		}
+		close(o.done)
+	}()
+	return o
+}
+
+// observe records the failures that an engine event reports.
+func (o *operationOutcome) observe(e engine.Event) {
+	switch e.Type {
+	case engine.PolicyViolationEvent:
+		if e.Payload().(engine.PolicyViolationEventPayload).EnforcementLevel == apitype.Mandatory {
+			o.policyViolation = true
+		}
+	case engine.ResourceOperationFailed:
+		o.resourceFailure = true
+	}
+}
+
+// close stops watching for events. It must be called once the operation has returned.
+func (o *operationOutcome) close() {
+	close(o.events)
+	<-o.done
+}
+
+// result returns the result of a command whose operation returned the given changes and result. The command exits
+// with a code that describes why the operation failed or, if it was asked to, whether the operation made changes.
+func (o *operationOutcome) result(changes engine.ResourceChanges, res result.Result) result.Result {
+	hasChanges := changes != nil && changes.HasChanges()
+
+	switch {
+	case res != nil:
+		return cmdutil.ResultWithExitCode(o.failureExitCode(res), PrintEngineResult(res))
+	case o.expectNop && hasChanges:
+		msg := "no changes were expected but changes occurred"
+		if o.preview {
+			msg = "no changes were expected but changes were proposed"
+		}
+		return cmdutil.ResultWithExitCode(cmdutil.ExitUnexpectedChanges, result.FromError(errors.New(msg)))
+	case o.detailedExitCode && hasChanges:
+		if o.preview {
+			return cmdutil.ResultWithExitCode(cmdutil.ExitPreviewChanges, nil)
+		}
+		return cmdutil.ResultWithExitCode(cmdutil.ExitChangesApplied, nil)
+	default:
+		return nil
+	}
+}
+
+// failureExitCode returns the code with which a command exits when its operation failed with the given result.
+func (o *operationOutcome) failureExitCode(res result.Result) int {
+	if err := res.Error(); err != nil {
+		switch errors.Cause(err).(type) {
+		case backend.ConflictingUpdateError, *backend.ConflictingUpdateError:
+			return cmdutil.ExitConflict
+		}
+	}
+
+	switch {
+	case o.policyViolation:
+		return cmdutil.ExitPolicyViolation
+	case o.resourceFailure:
+		return cmdutil.ExitProviderFailure
+	default:
+		return cmdutil.ExitFailure
+	}
+}
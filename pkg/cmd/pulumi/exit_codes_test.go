@@ -0,0 +1,84 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+func TestOperationOutcomeExitCodes(t *testing.T) {
+	changes := engine.ResourceChanges{deploy.OpCreate: 1}
+	noChanges := engine.ResourceChanges{deploy.OpSame: 2}
+
+	policyViolation := func(level apitype.EnforcementLevel) engine.Event {
+		return engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{EnforcementLevel: level})
+	}
+	operationFailed := engine.NewEvent(engine.ResourceOperationFailed, engine.ResourceOperationFailedPayload{})
+
+	tests := []struct {
+		name             string
+		preview          bool
+		expectNop        bool
+		detailedExitCode bool
+		events           []engine.Event
+		changes          engine.ResourceChanges
+		res              result.Result
+		code             int
+	}{
+		{name: "changes", changes: changes, code: cmdutil.ExitSuccess},
+		{name: "detailed no changes", detailedExitCode: true, changes: noChanges, code: cmdutil.ExitSuccess},
+		{name: "detailed changes", detailedExitCode: true, changes: changes, code: cmdutil.ExitChangesApplied},
+		{name: "detailed preview", preview: true, detailedExitCode: true, changes: changes,
+			code: cmdutil.ExitPreviewChanges},
+		{name: "unexpected changes", expectNop: true, detailedExitCode: true, changes: changes,
+			code: cmdutil.ExitUnexpectedChanges},
+		{name: "expected no changes", expectNop: true, changes: noChanges, code: cmdutil.ExitSuccess},
+		{name: "failure", res: result.Bail(), code: cmdutil.ExitFailure},
+		{name: "advisory policy", events: []engine.Event{policyViolation(apitype.Advisory)}, res: result.Bail(),
+			code: cmdutil.ExitFailure},
+		{name: "mandatory policy", events: []engine.Event{policyViolation(apitype.Mandatory)}, res: result.Bail(),
+			code: cmdutil.ExitPolicyViolation},
+		{name: "provider failure", events: []engine.Event{operationFailed}, res: result.Bail(),
+			code: cmdutil.ExitProviderFailure},
+		{name: "conflict", res: result.FromError(errors.Wrap(backend.ConflictingUpdateError{Err: errors.New("409")},
+			"starting update")), code: cmdutil.ExitConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := newOperationOutcome(tt.preview, tt.expectNop, tt.detailedExitCode)
+			for _, e := range tt.events {
+				outcome.events <- e
+			}
+			outcome.close()
+
+			assert.Equal(t, tt.code, cmdutil.ExitCode(outcome.result(tt.changes, tt.res)))
+		})
+	}
+	// The CLI adds its own "error: " prefix when it prints the message.
+	outcome := newOperationOutcome(true /*preview*/, true /*expectNop*/, false /*detailedExitCode*/)
+	outcome.close()
+	res := outcome.result(changes, nil)
+	assert.EqualError(t, res.Error(), "no changes were expected but changes were proposed")
+}
@@ -190,14 +190,14 @@ func genZshCompletion(out io.Writer, root *cobra.Command) error {
 		return err
 	}
 
-	if _, err := fmt.Fprint(out, zshHead); err != nil {
+	if _, err := io.WriteString(out, zshHead); err != nil {
 		return err
 	}
 
-	if _, err := fmt.Fprint(out, buf.String()); err != nil {
+	if _, err := io.WriteString(out, buf.String()); err != nil {
 		return err
 	}
 
-	_, err := fmt.Fprint(out, zshTail)
+	_, err := io.WriteString(out, zshTail)
 	return err
 }
@@ -0,0 +1,167 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/lint"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+func newLintCmd() *cobra.Command {
+	var disabled []string
+
+	var rules strings.Builder
+	for _, r := range lint.DefaultRules() {
+		fmt.Fprintf(&rules, "  %-22s%s\n", r.Name(), r.Description())
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lint [dir]",
+		Args:  cmdutil.MaximumNArgs(1),
+		Short: "Check a PCL program for common mistakes",
+		Long: "Check a PCL program for common mistakes.\n" +
+			"\n" +
+			"Binds the PCL program in the given directory (by default, the current directory) and runs a\n" +
+			"set of rules against it, e.g. to find config variables that are not used or secrets that are\n" +
+			"exported as plain outputs. The program is analyzed without running it. Each problem is printed\n" +
+			"as a warning that names the rule that found it, and the command fails if any problems are found.\n" +
+			"\n" +
+			"Rules:\n" +
+			rules.String(),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			enabled, err := lintRules(disabled)
+			if err != nil {
+				return err
+			}
+
+			program, err := bindLintProgram(dir)
+			if err != nil {
+				return err
+			}
+
+			problems := lint.Lint(program, enabled)
+			if len(problems) == 0 {
+				return nil
+			}
+
+			diagnostics := make(hcl.Diagnostics, len(problems))
+			for i, p := range problems {
+				d := *p.Diagnostic
+				d.Summary = fmt.Sprintf("%s [%s]", d.Summary, p.Rule)
+				diagnostics[i] = &d
+			}
+			writer := program.NewDiagnosticWriter(os.Stderr, 0, cmdutil.GetGlobalColorization() != colors.Never)
+			contract.IgnoreError(writer.WriteDiagnostics(diagnostics))
+
+			if len(problems) == 1 {
+				return errors.New("1 problem found")
+			}
+			return errors.Errorf("%d problems found", len(problems))
+		}),
+	}
+
+	cmd.PersistentFlags().StringSliceVar(
+		&disabled, "disable", nil,
+		"The names of rules that should not be run")
+
+	return cmd
+}
+
+// lintRules returns the default lint rules, less those with the given names. It is an error to name a rule that does
+// not exist.
+func lintRules(disabled []string) ([]lint.Rule, error) {
+	rules := lint.DefaultRules()
+
+	names := map[string]bool{}
+	for _, r := range rules {
+		names[r.Name()] = true
+	}
+	skip := map[string]bool{}
+	for _, name := range disabled {
+		if !names[name] {
+			return nil, errors.Errorf("unknown rule %q", name)
+		}
+		skip[name] = true
+	}
+
+	var enabled []lint.Rule
+	for _, r := range rules {
+		if !skip[r.Name()] {
+			enabled = append(enabled, r)
+		}
+	}
+	return enabled, nil
+}
+
+// bindLintProgram parses and binds the PCL program made up of the .pp files in the given directory. An error is
+// returned if the program cannot be parsed or bound, in which case the problems are printed to stderr.
+func bindLintProgram(dir string) (*hcl2.Program, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pp"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, errors.Errorf("no PCL files (*.pp) found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	parser := syntax.NewParser()
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		err = parser.ParseFile(f, filepath.Base(path))
+		contract.IgnoreClose(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+	}
+	if parser.Diagnostics.HasErrors() {
+		writer := parser.NewDiagnosticWriter(os.Stderr, 0, cmdutil.GetGlobalColorization() != colors.Never)
+		contract.IgnoreError(writer.WriteDiagnostics(parser.Diagnostics))
+		return nil, errors.New("failed to parse the program")
+	}
+
+	program, diagnostics, err := hcl2.BindProgram(parser.Files)
+	if err != nil {
+		return nil, err
+	}
+	if diagnostics.HasErrors() {
+		writer := program.NewDiagnosticWriter(os.Stderr, 0, cmdutil.GetGlobalColorization() != colors.Never)
+		contract.IgnoreError(writer.WriteDiagnostics(diagnostics))
+		return nil, errors.New("failed to bind the program")
+	}
+	return program, nil
+}
@@ -23,9 +23,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
 	"github.com/pulumi/pulumi/pkg/v2/operations"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 )
 
@@ -41,11 +44,19 @@ func newLogsCmd() *cobra.Command {
 	var since string
 	var resource string
 	var jsonOut bool
+	var events bool
+	var version int
 
 	logsCmd := &cobra.Command{
 		Use:   "logs",
 		Short: "[PREVIEW] Show aggregated logs for a stack",
-		Args:  cmdutil.NoArgs,
+		Long: "[PREVIEW] Show aggregated logs for a stack\n" +
+			"\n" +
+			"By default, this command shows the logs emitted by the stack's resources. Pass `--events` to instead\n" +
+			"replay the engine events recorded during one of the stack's updates, rendered as they were when the\n" +
+			"update ran. Updates are numbered from 1, oldest first; `--version` selects one (the default is the\n" +
+			"most recent update).",
+		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -56,6 +67,13 @@ func newLogsCmd() *cobra.Command {
 				return err
 			}
 
+			if events {
+				return showUpdateEvents(s, version, opts, jsonOut)
+			}
+			if cmd.Flags().Changed("version") {
+				return errors.New("--version may only be used with --events")
+			}
+
 			sm, err := getStackSecretsManager(s)
 			if err != nil {
 				return errors.Wrap(err, "getting secrets manager")
@@ -174,10 +192,80 @@ func newLogsCmd() *cobra.Command {
 	logsCmd.PersistentFlags().StringVarP(
 		&resource, "resource", "r", "",
 		"Only return logs for the requested resource ('name', 'type::name' or full URN).  Defaults to returning all logs.")
+	logsCmd.PersistentFlags().BoolVar(
+		&events, "events", false,
+		"Replay the engine events recorded for an update instead of showing resource logs")
+	logsCmd.PersistentFlags().IntVar(
+		&version, "version", 0,
+		"The update whose events to replay with --events.  Defaults to the most recent update.")
 
 	return logsCmd
 }
 
+// showUpdateEvents renders the engine events recorded for the given update of a stack. With jsonOut, the raw events
+// are printed instead.
+func showUpdateEvents(s backend.Stack, version int, opts display.Options, jsonOut bool) error {
+	if version < 0 {
+		return errors.Errorf("invalid update version %d", version)
+	}
+
+	b := s.Backend()
+	history, err := b.GetHistory(commandContext(), s.Ref())
+	if err != nil {
+		return errors.Wrap(err, "getting stack history")
+	}
+	if len(history) == 0 {
+		return errors.Errorf("stack %s has no updates", s.Ref())
+	}
+	if version == 0 {
+		version = len(history)
+	}
+	if version > len(history) {
+		return errors.Errorf("stack %s has no update %d; its updates are numbered 1 through %d",
+			s.Ref(), version, len(history))
+	}
+
+	apiEvents, err := b.GetUpdateEvents(commandContext(), s.Ref(), version)
+	if err != nil {
+		return errors.Wrapf(err, "getting events for update %d", version)
+	}
+	if jsonOut {
+		return printJSON(apiEvents)
+	}
+
+	// GetHistory returns the newest update first.
+	kind := history[len(history)-version].Kind
+	projectName := tokens.PackageName("")
+	if proj, _, err := readProject(); err == nil {
+		projectName = proj.Name
+	}
+
+	opts.Type = display.DisplayDiff
+	opts.ShowConfig = false
+	engineEvents, done := make(chan engine.Event), make(chan bool)
+	go display.ShowEvents(strings.ToLower(backend.ActionLabel(kind, false)), kind, s.Ref().Name(), projectName,
+		engineEvents, done, opts, false)
+
+	// The display stops at the first cancellation event, which the engine emits once an update has finished. Make
+	// sure one is sent even if the log was cut short.
+	var replayErr error
+	for _, apiEvent := range apiEvents {
+		e, err := display.ConvertJSONEvent(apiEvent)
+		if err != nil {
+			replayErr = errors.Wrapf(err, "replaying event %d", apiEvent.Sequence)
+			break
+		}
+		engineEvents <- e
+		if e.Type == engine.CancelEvent {
+			<-done
+			return nil
+		}
+	}
+	engineEvents <- engine.NewEvent(engine.CancelEvent, nil)
+	<-done
+	return replayErr
+}
+
 func parseSince(since string, reference time.Time) (*time.Time, error) {
 	startTimestamp, err := mobytime.GetTimestamp(since, reference)
 	if err != nil {
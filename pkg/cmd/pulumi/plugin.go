@@ -43,6 +43,7 @@ func newPluginCmd() *cobra.Command {
 		Args: cmdutil.NoArgs,
 	}
 
+	cmd.AddCommand(newPluginGCCmd())
 	cmd.AddCommand(newPluginInstallCmd())
 	cmd.AddCommand(newPluginLsCmd())
 	cmd.AddCommand(newPluginRmCmd())
@@ -0,0 +1,187 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// pluginCacheQuotaEnvVar names an environment variable that gives the default for `pulumi plugin gc --max-size`,
+// e.g. "10GB".
+const pluginCacheQuotaEnvVar = "PULUMI_PLUGIN_CACHE_QUOTA"
+
+func newPluginGCCmd() *cobra.Command {
+	var maxAge string
+	var maxSize string
+	var dryRun bool
+	var yes bool
+	var cmd = &cobra.Command{
+		Use:   "gc",
+		Args:  cmdutil.NoArgs,
+		Short: "Remove unused plugins from the download cache",
+		Long: "Remove unused plugins from the download cache.\n" +
+			"\n" +
+			"Plugins that have not been used for longer than --max-age are removed. If --max-size is\n" +
+			"given, the least recently used plugins are then removed until the cache is no larger\n" +
+			"than that size. Before anything is removed, the plugins are listed along with the\n" +
+			"stack that most recently used each of them.\n" +
+			"\n" +
+			"If --max-size is not given, the size in PULUMI_PLUGIN_CACHE_QUOTA (e.g. 10GB) is used.\n" +
+			"Updates never remove plugins themselves, since another update may be running them.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			var policy workspace.PluginEvictionPolicy
+			if maxAge != "" {
+				age, err := parsePluginMaxAge(maxAge)
+				if err != nil {
+					return err
+				}
+				policy.MaxAge = age
+			}
+			if maxSize != "" {
+				size, err := humanize.ParseBytes(maxSize)
+				if err != nil {
+					return errors.Wrapf(err, "invalid --max-size %q", maxSize)
+				}
+				policy.MaxSize = int64(size)
+			} else if quota := os.Getenv(pluginCacheQuotaEnvVar); quota != "" {
+				size, err := humanize.ParseBytes(quota)
+				if err != nil {
+					return errors.Wrapf(err, "invalid %s %q", pluginCacheQuotaEnvVar, quota)
+				}
+				policy.MaxSize = int64(size)
+			}
+			if policy.MaxAge == 0 && policy.MaxSize == 0 {
+				return errors.New("please pass --max-age and/or --max-size")
+			}
+
+			plugins, err := workspace.GetPlugins()
+			if err != nil {
+				return errors.Wrap(err, "loading plugins")
+			}
+			deletes := workspace.SelectPluginsToEvict(plugins, policy, time.Now())
+			if len(deletes) == 0 {
+				fmt.Println("No plugins to remove")
+				return nil
+			}
+
+			var suffix string
+			if len(deletes) != 1 {
+				suffix = "s"
+			}
+			var freed uint64
+			for _, del := range deletes {
+				freed += uint64(del.Size)
+			}
+			fmt.Print(
+				opts.Color.Colorize(
+					fmt.Sprintf("%sThis will remove %d plugin%s (%s) from the cache:%s\n",
+						colors.SpecAttention, len(deletes), suffix, humanize.Bytes(freed), colors.Reset)))
+			printPluginGCTable(deletes)
+
+			if dryRun || !yes && !confirmPrompt("", "yes", opts) {
+				return nil
+			}
+			var result error
+			for _, plugin := range deletes {
+				if err := plugin.Delete(); err != nil {
+					result = multierror.Append(
+						result, errors.Wrapf(err, "failed to delete %s plugin %s", plugin.Kind, plugin))
+				}
+			}
+			return result
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&maxAge, "max-age", "",
+		"Remove plugins that have not been used for this long (e.g. 30d or 720h)")
+	cmd.PersistentFlags().StringVar(
+		&maxSize, "max-size", "",
+		"Remove the least recently used plugins until the cache is no larger than this (e.g. 5GB)")
+	cmd.PersistentFlags().BoolVar(
+		&dryRun, "dry-run", false,
+		"List the plugins that would be removed without removing them")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with removal anyway")
+
+	return cmd
+}
+
+// parsePluginMaxAge parses a duration, additionally accepting a whole number of days such as "30d".
+func parsePluginMaxAge(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || days <= 0 {
+			return 0, errors.Errorf("invalid --max-age %q", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	age, err := time.ParseDuration(s)
+	if err != nil || age <= 0 {
+		return 0, errors.Errorf("invalid --max-age %q", s)
+	}
+	return age, nil
+}
+
+// printPluginGCTable lists the given plugins along with the stack that most recently used each of them.
+func printPluginGCTable(plugins []workspace.PluginInfo) {
+	rows := []cmdutil.TableRow{}
+	for _, plugin := range plugins {
+		lastUsed, usedBy := humanize.Time(plugin.InstallTime), "-"
+		if !plugin.LastUsedTime.IsZero() {
+			lastUsed = humanize.Time(plugin.LastUsedTime)
+		}
+		if usages, err := plugin.Usages(); err == nil && len(usages) > 0 {
+			usedBy = fmt.Sprintf("%s/%s", usages[0].Project, usages[0].Stack)
+			if len(usages) > 1 {
+				usedBy += fmt.Sprintf(" (+%d more)", len(usages)-1)
+			}
+		}
+
+		var version string
+		if plugin.Version != nil {
+			version = plugin.Version.String()
+		}
+
+		rows = append(rows, cmdutil.TableRow{Columns: []string{
+			plugin.Name, string(plugin.Kind), version, humanize.Bytes(uint64(plugin.Size)), lastUsed, usedBy,
+		}})
+	}
+
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"NAME", "KIND", "VERSION", "SIZE", "LAST USED", "LAST USED BY"},
+		Rows:    rows,
+	})
+}
@@ -35,6 +35,9 @@ func newPluginInstallCmd() *cobra.Command {
 	var file string
 	var reinstall bool
 	var verbose bool
+	var checksum string
+	var image string
+	var platform string
 
 	var cmd = &cobra.Command{
 		Use:   "install [KIND NAME VERSION]",
@@ -48,12 +51,24 @@ func newPluginInstallCmd() *cobra.Command {
 			"project.  VERSION cannot be a range: it must be a specific number.\n" +
 			"\n" +
 			"If you let Pulumi compute the set to download, it is conservative and may end up\n" +
-			"downloading more plugins than is strictly necessary.",
+			"downloading more plugins than is strictly necessary.\n" +
+			"\n" +
+			"A resource plugin that has no native binary for this machine may be installed as a\n" +
+			"container image using --image. The image's entrypoint must be the plugin's executable.\n" +
+			"Containerized plugins are run with the container runtime named by\n" +
+			"PULUMI_PLUGIN_CONTAINER_RUNTIME (by default, docker) and require a Linux host.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			displayOpts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
 			}
 
+			if image != "" && file != "" {
+				return errors.New("--image and --file (-f) cannot be used together")
+			}
+			if platform != "" && image == "" {
+				return errors.New("--platform is only valid with --image")
+			}
+
 			// Parse the kind, name, and version, if specified.
 			var installs []workspace.PluginInfo
 			if len(args) > 0 {
@@ -68,16 +83,26 @@ func newPluginInstallCmd() *cobra.Command {
 				if err != nil {
 					return errors.Wrap(err, "invalid plugin semver")
 				}
+				if image != "" && args[0] != string(workspace.ResourcePlugin) {
+					return errors.New("--image is only valid for resource plugins")
+				}
 				installs = append(installs, workspace.PluginInfo{
 					Kind:      workspace.PluginKind(args[0]),
 					Name:      args[1],
 					Version:   &version,
 					ServerURL: serverURL, // If empty, will use default plugin source.
+					Checksum:  checksum,
 				})
 			} else {
 				if file != "" {
 					return errors.New("--file (-f) is only valid if a specific package is being installed")
 				}
+				if checksum != "" {
+					return errors.New("--checksum is only valid if a specific package is being installed")
+				}
+				if image != "" {
+					return errors.New("--image is only valid if a specific package is being installed")
+				}
 
 				// If a specific plugin wasn't given, compute the set of plugins the current project needs.
 				plugins, err := getProjectPlugins()
@@ -121,6 +146,17 @@ func newPluginInstallCmd() *cobra.Command {
 					}
 				}
 
+				// Plugins that run in containers have nothing to download; the runtime pulls the image on first use.
+				if image != "" {
+					if err := install.InstallContainer(workspace.PluginContainer{
+						Image:    image,
+						Platform: platform,
+					}); err != nil {
+						return errors.Wrapf(err, "installing %s from %s", label, image)
+					}
+					continue
+				}
+
 				// If we got here, actually try to do the download.
 				var source string
 				var tarball io.ReadCloser
@@ -131,7 +167,7 @@ func newPluginInstallCmd() *cobra.Command {
 							diag.Message("", "%s downloading from %s"), label, install.ServerURL)
 					}
 					var size int64
-					if tarball, size, err = install.Download(); err != nil {
+					if tarball, size, err = install.DownloadWithContext(commandContext()); err != nil {
 						return errors.Wrapf(err, "%s downloading from %s", label, install.ServerURL)
 					}
 					tarball = workspace.ReadCloserProgressBar(tarball, size, "Downloading plugin", displayOpts.Color)
@@ -168,6 +204,12 @@ func newPluginInstallCmd() *cobra.Command {
 		"reinstall", false, "Reinstall a plugin even if it already exists")
 	cmd.PersistentFlags().BoolVar(&verbose,
 		"verbose", false, "Print detailed information about the installation steps")
+	cmd.PersistentFlags().StringVar(&checksum,
+		"checksum", "", "The expected SHA256 checksum of the plugin's tarball")
+	cmd.PersistentFlags().StringVar(&image,
+		"image", "", "Install a resource plugin that runs in the given container image, instead of downloading it")
+	cmd.PersistentFlags().StringVar(&platform,
+		"platform", "", "The platform of the image given by --image to run, e.g. linux/amd64")
 
 	return cmd
 }
@@ -15,6 +15,9 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -29,6 +32,8 @@ import (
 func newPreviewCmd() *cobra.Command {
 	var debug bool
 	var expectNop bool
+	var detailedExitCode bool
+	var diffBase string
 	var message string
 	var stack string
 	var configArray []string
@@ -51,6 +56,7 @@ func newPreviewCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var allowReplace []string
 
 	var cmd = &cobra.Command{
 		Use:        "preview",
@@ -67,7 +73,11 @@ func newPreviewCmd() *cobra.Command {
 			"actually take place.\n" +
 			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
-			"`--cwd` flag to use a different directory.",
+			"`--cwd` flag to use a different directory.\n" +
+			"\n" +
+			"Use `--diff-base` to see what a change to the program will do, e.g. before merging a pull request. The\n" +
+			"program is also previewed at the given git revision, against the same state and configuration, and\n" +
+			"the resources for which the two previews plan different steps are reported afterwards." + exitCodesHelp,
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			var displayType = display.DisplayProgress
@@ -92,6 +102,9 @@ func newPreviewCmd() *cobra.Command {
 			if err := validatePolicyPackConfig(policyPackPaths, policyPackConfigPaths); err != nil {
 				return result.FromError(err)
 			}
+			if diffBase != "" && jsonDisplay {
+				return result.FromError(errors.New("--diff-base cannot be used with --json"))
+			}
 
 			s, err := requireStack(stack, true, displayOpts, true /*setCurrent*/)
 			if err != nil {
@@ -108,6 +121,10 @@ func newPreviewCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
+			if err = ensureRuntimeEnv(proj, root); err != nil {
+				return result.FromError(err)
+			}
+
 			m, err := getUpdateMetadata(message, root)
 			if err != nil {
 				return result.FromError(errors.Wrap(err, "gathering environment metadata"))
@@ -148,11 +165,13 @@ func newPreviewCmd() *cobra.Command {
 					UseLegacyDiff:    useLegacyDiff(),
 					UpdateTargets:    targetURNs,
 					TargetDependents: targetDependents,
+
+					ProtectedReplaceTypes: protectedReplaceTypes(proj, allowReplace),
 				},
 				Display: displayOpts,
 			}
 
-			changes, res := s.Preview(commandContext(), backend.UpdateOperation{
+			op := backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
 				M:                  m,
@@ -160,16 +179,34 @@ func newPreviewCmd() *cobra.Command {
 				StackConfiguration: cfg,
 				SecretsManager:     sm,
 				Scopes:             cancellationScopes,
-			})
+			}
 
-			switch {
-			case res != nil:
+			if diffBase == "" {
+				outcome := newOperationOutcome(true, expectNop, detailedExitCode)
+				op.Events = outcome.events
+				changes, res := s.Preview(commandContext(), op)
+				outcome.close()
+				return outcome.result(changes, res)
+			}
+
+			fmt.Printf("Previewing the program at %s:\n", diffBase)
+			basePlan, res := previewAtRevision(s, diffBase, op)
+			if res != nil {
 				return PrintEngineResult(res)
-			case expectNop && changes != nil && changes.HasChanges():
-				return result.FromError(errors.New("error: no changes were expected but changes were proposed"))
-			default:
-				return nil
 			}
+
+			fmt.Printf("\nPreviewing the program in the working tree:\n")
+			outcome := newOperationOutcome(true, expectNop, detailedExitCode)
+			headPlan := newPreviewPlan(outcome.events)
+			op.Events = headPlan.events
+			changes, res := s.Preview(commandContext(), op)
+			headPlan.close()
+			outcome.close()
+			if res == nil {
+				fmt.Println()
+				printPlanDeltas(os.Stdout, diffBase, diffPlans(basePlan, headPlan))
+			}
+			return outcome.result(changes, res)
 		}),
 	}
 
@@ -179,6 +216,12 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&expectNop, "expect-no-changes", false,
 		"Return an error if any changes are proposed by this preview")
+	cmd.PersistentFlags().BoolVar(
+		&detailedExitCode, "detailed-exit-code", false,
+		"Exit with a code that says whether this preview proposed changes")
+	cmd.PersistentFlags().StringVar(
+		&diffBase, "diff-base", "",
+		"Also preview the program at this git revision and report how the two previews differ")
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
@@ -210,6 +253,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().StringArrayVar(
+		&allowReplace, "allow-replace", nil,
+		"Allow resources of this type to be replaced, if the project only allows it explicitly")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
@@ -0,0 +1,254 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// previewAtRevision previews the program at the given git revision of the repository that contains the project in
+// op, and returns the steps that the preview plans. The program is checked out into a temporary worktree, so the
+// working tree is left alone. The preview runs against the stack's current state and configuration, so that it
+// differs from a preview of the working tree only in the program.
+func previewAtRevision(s backend.Stack, rev string, op backend.UpdateOperation) (*previewPlan, result.Result) {
+	dir, remove, err := checkoutGitWorktree(op.Root, rev)
+	if err != nil {
+		return nil, result.FromError(errors.Wrapf(err, "checking out %s", rev))
+	}
+	defer remove()
+
+	path, err := workspace.DetectProjectPathFrom(dir)
+	if err != nil {
+		return nil, result.FromError(err)
+	} else if path == "" || filepath.Dir(path) != dir {
+		return nil, result.Errorf("no Pulumi project found at %s in %s", rev, dir)
+	}
+	proj, err := workspace.LoadProject(path)
+	if err != nil {
+		return nil, result.FromError(errors.Wrapf(err, "loading the Pulumi project at %s", rev))
+	}
+	if proj.Name != op.Proj.Name {
+		return nil, result.Errorf("the project is named %q at %s but %q in the working tree", proj.Name, rev,
+			op.Proj.Name)
+	}
+
+	if err = ensureRuntimeEnv(proj, dir); err != nil {
+		return nil, result.FromError(err)
+	}
+	m, err := getUpdateMetadata(op.M.Message, dir)
+	if err != nil {
+		return nil, result.FromError(errors.Wrap(err, "gathering environment metadata"))
+	}
+
+	plan := newPreviewPlan(nil)
+	op.Proj, op.Root, op.M, op.Events = proj, dir, m, plan.events
+	_, res := s.Preview(commandContext(), op)
+	plan.close()
+	if res != nil {
+		return nil, res
+	}
+	return plan, nil
+}
+
+// plannedStep is the step that a preview plans for a resource.
+type plannedStep struct {
+	Op    deploy.StepOp
+	Diffs []resource.PropertyKey // the properties whose changes cause the step, sorted.
+}
+
+func (s *plannedStep) String() string {
+	if s == nil {
+		return "no step"
+	}
+	if len(s.Diffs) == 0 {
+		return string(s.Op)
+	}
+
+	diffs := make([]string, len(s.Diffs))
+	for i, k := range s.Diffs {
+		diffs[i] = string(k)
+	}
+	return fmt.Sprintf("%s [diff: %s]", s.Op, strings.Join(diffs, ", "))
+}
+
+// previewPlan collects the steps that a preview plans from the engine events sent to its events channel.
+type previewPlan struct {
+	steps map[resource.URN]*plannedStep
+
+	events chan engine.Event
+	done   chan bool
+}
+
+// newPreviewPlan starts collecting the steps planned by a preview. Events sent to the plan are passed on to next, if
+// it is not nil.
+func newPreviewPlan(next chan<- engine.Event) *previewPlan {
+	p := &previewPlan{
+		steps:  make(map[resource.URN]*plannedStep),
+		events: make(chan engine.Event),
+		done:   make(chan bool),
+	}
+	go func() {
+		for e := range p.events {
+			p.observe(e)
+			if next != nil {
+				next <- e
+			}
+		}
+		close(p.done)
+	}()
+	return p
+}
+
+// observe records the step announced by a resource-pre event. A replacement is recorded as a single replace step
+// rather than as the create and delete steps that carry it out.
+func (p *previewPlan) observe(e engine.Event) {
+	if e.Type != engine.ResourcePreEvent {
+		return
+	}
+
+	step := e.Payload().(engine.ResourcePreEventPayload).Metadata
+	if step.Op == deploy.OpCreateReplacement || step.Op == deploy.OpDeleteReplaced {
+		return
+	}
+
+	diffs := append([]resource.PropertyKey(nil), step.Diffs...)
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i] < diffs[j] })
+	p.steps[step.URN] = &plannedStep{Op: step.Op, Diffs: diffs}
+}
+
+// close stops collecting steps. It must be called once the preview has returned.
+func (p *previewPlan) close() {
+	close(p.events)
+	<-p.done
+}
+
+// planDelta is a resource for which two previews plan different steps. A nil step means that the preview planned
+// nothing for the resource.
+type planDelta struct {
+	URN  resource.URN
+	Base *plannedStep
+	Head *plannedStep
+}
+
+// diffPlans returns the resources for which the base and head previews plan different steps, sorted by URN.
+func diffPlans(base, head *previewPlan) []planDelta {
+	urns := make(map[resource.URN]bool)
+	for urn := range base.steps {
+		urns[urn] = true
+	}
+	for urn := range head.steps {
+		urns[urn] = true
+	}
+
+	var deltas []planDelta
+	for urn := range urns {
+		b, h := base.steps[urn], head.steps[urn]
+		if b == nil || h == nil || b.Op != h.Op || !reflect.DeepEqual(b.Diffs, h.Diffs) {
+			deltas = append(deltas, planDelta{URN: urn, Base: b, Head: h})
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool { return deltas[i].URN < deltas[j].URN })
+	return deltas
+}
+
+// printPlanDeltas writes the differences between the preview of the program at the base revision and the preview of
+// the program in the working tree.
+func printPlanDeltas(w io.Writer, baseRev string, deltas []planDelta) {
+	if len(deltas) == 0 {
+		fmt.Fprintf(w, "The program plans the same steps as it does at %s.\n", baseRev)
+		return
+	}
+
+	fmt.Fprintf(w, "The program plans different steps than it does at %s for %d resources:\n", baseRev, len(deltas))
+	for _, d := range deltas {
+		fmt.Fprintf(w, "    %s\n", d.URN)
+		fmt.Fprintf(w, "        %s: %v\n", baseRev, d.Base)
+		fmt.Fprintf(w, "        working tree: %v\n", d.Head)
+	}
+}
+
+// checkoutGitWorktree checks out the given revision of the git repository that contains dir into a new, detached
+// worktree. It returns the directory in the worktree that corresponds to dir and a function that removes the worktree.
+func checkoutGitWorktree(dir, rev string) (string, func(), error) {
+	gitBin, err := exec.LookPath("git")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		return "", nil, err
+	}
+	top, err := runGit(gitBin, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, err
+	}
+	if top, err = filepath.EvalSymlinks(top); err != nil {
+		return "", nil, err
+	}
+	rel, err := filepath.Rel(top, dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	worktree, err := ioutil.TempDir("", "pulumi-diff-base-")
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err = runGit(gitBin, top, "worktree", "add", "--detach", worktree, rev); err != nil {
+		contract.IgnoreError(os.RemoveAll(worktree))
+		return "", nil, err
+	}
+
+	remove := func() {
+		if _, err := runGit(gitBin, top, "worktree", "remove", "--force", worktree); err != nil {
+			logging.V(3).Infof("removing worktree %s: %v", worktree, err)
+		}
+		contract.IgnoreError(os.RemoveAll(worktree))
+	}
+	return filepath.Join(worktree, rel), remove, nil
+}
+
+// runGit runs git with the given arguments in dir and returns its trimmed output.
+func runGit(gitBin, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(gitBin, args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "'git %s' failed: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+func collectPlan(steps ...engine.StepEventMetadata) *previewPlan {
+	plan := newPreviewPlan(nil)
+	for _, step := range steps {
+		plan.events <- engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{Metadata: step})
+	}
+	plan.close()
+	return plan
+}
+
+func TestDiffPlans(t *testing.T) {
+	const (
+		bucket  = resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::bucket")
+		policy  = resource.URN("urn:pulumi:dev::proj::aws:s3/bucketPolicy:BucketPolicy::policy")
+		website = resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::website")
+		logs    = resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs")
+	)
+
+	base := collectPlan(
+		engine.StepEventMetadata{Op: deploy.OpSame, URN: bucket},
+		engine.StepEventMetadata{Op: deploy.OpUpdate, URN: policy, Diffs: []resource.PropertyKey{"policy"}},
+		engine.StepEventMetadata{Op: deploy.OpUpdate, URN: website, Diffs: []resource.PropertyKey{"website"}},
+	)
+	head := collectPlan(
+		engine.StepEventMetadata{Op: deploy.OpSame, URN: bucket},
+		engine.StepEventMetadata{Op: deploy.OpCreateReplacement, URN: policy},
+		engine.StepEventMetadata{Op: deploy.OpReplace, URN: policy, Diffs: []resource.PropertyKey{"policy", "bucket"}},
+		engine.StepEventMetadata{Op: deploy.OpDeleteReplaced, URN: policy},
+		engine.StepEventMetadata{Op: deploy.OpUpdate, URN: website, Diffs: []resource.PropertyKey{"website"}},
+		engine.StepEventMetadata{Op: deploy.OpCreate, URN: logs},
+	)
+
+	deltas := diffPlans(base, head)
+	assert.Equal(t, []planDelta{
+		{URN: logs, Head: &plannedStep{Op: deploy.OpCreate}},
+		{
+			URN:  policy,
+			Base: &plannedStep{Op: deploy.OpUpdate, Diffs: []resource.PropertyKey{"policy"}},
+			Head: &plannedStep{Op: deploy.OpReplace, Diffs: []resource.PropertyKey{"bucket", "policy"}},
+		},
+	}, deltas)
+
+	var out bytes.Buffer
+	printPlanDeltas(&out, "main", deltas)
+	assert.Equal(t, `The program plans different steps than it does at main for 2 resources:
+    urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs
+        main: no step
+        working tree: create
+    urn:pulumi:dev::proj::aws:s3/bucketPolicy:BucketPolicy::policy
+        main: update [diff: policy]
+        working tree: replace [diff: bucket, policy]
+`, out.String())
+}
+
+func TestCheckoutGitWorktree(t *testing.T) {
+	gitBin, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo, err := ioutil.TempDir("", "pulumi-diff-base-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(repo)
+
+	git := func(args ...string) {
+		_, err := runGit(gitBin, repo, append([]string{"-c", "user.name=test", "-c", "user.email=test@test"},
+			args...)...)
+		assert.NoError(t, err)
+	}
+
+	// Commit one version of a project in a subdirectory, then change it in the working tree.
+	project := filepath.Join(repo, "infra")
+	assert.NoError(t, os.MkdirAll(project, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(project, "Pulumi.yaml"), []byte("name: base\n"), 0600))
+	git("init")
+	git("add", ".")
+	git("commit", "-m", "base")
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(project, "Pulumi.yaml"), []byte("name: head\n"), 0600))
+
+	dir, remove, err := checkoutGitWorktree(project, "HEAD")
+	assert.NoError(t, err)
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "Pulumi.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "name: base\n", string(contents))
+
+	remove()
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
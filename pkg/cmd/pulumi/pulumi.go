@@ -43,6 +43,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/version"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/httputil"
@@ -58,6 +59,7 @@ func NewPulumiCmd() *cobra.Command {
 	var tracing string
 	var tracingHeaderFlag string
 	var profiling string
+	var resourceUsage bool
 	var verbose int
 	var color string
 
@@ -124,9 +126,15 @@ func NewPulumiCmd() *cobra.Command {
 					logging.Warningf("could not initialize profiling: %v", err)
 				}
 			}
+			if profiling != "" || resourceUsage {
+				plugin.SampleResourceUsage(resourceUsageInterval)
+			}
 
 			if cmdutil.IsTruthy(os.Getenv("PULUMI_SKIP_UPDATE_CHECK")) {
 				logging.V(5).Infof("skipping update check")
+			} else if cmd.Name() == cobra.ShellCompRequestCmd {
+				// Completion requests must be fast, and their output is not shown to the user.
+				logging.V(5).Infof("skipping update check for completion request")
 			} else {
 				// Run the version check in parallel so that it doesn't block executing the command.
 				// If there is a new version to report, we will do so after the command has finished.
@@ -156,6 +164,12 @@ func NewPulumiCmd() *cobra.Command {
 				if err := cmdutil.CloseProfiling(profiling); err != nil {
 					logging.Warningf("could not close profiling: %v", err)
 				}
+				if err := writeResourceUsageProfile(profiling); err != nil {
+					logging.Warningf("could not write plugin resource usage profile: %v", err)
+				}
+			}
+			if resourceUsage && !isJSON {
+				printResourceUsage()
 			}
 		},
 	}
@@ -175,7 +189,10 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&tracing, "tracing", "",
 		"Emit tracing to the specified endpoint. Use the `file:` scheme to write tracing data to a local file")
 	cmd.PersistentFlags().StringVar(&profiling, "profiling", "",
-		"Emit CPU and memory profiles and an execution trace to '[filename].[pid].{cpu,mem,trace}', respectively")
+		"Emit CPU and memory profiles, an execution trace, and the resource usage of plugins to "+
+			"'[filename].[pid].{cpu,mem,trace,plugins}', respectively")
+	cmd.PersistentFlags().BoolVar(&resourceUsage, "resource-usage", false,
+		"Print a summary of the CPU and memory used by plugins when the command exits")
 	cmd.PersistentFlags().IntVarP(&verbose, "verbose", "v", 0,
 		"Enable verbose logging (e.g., v=3); anything >3 is very verbose")
 	cmd.PersistentFlags().StringVar(
@@ -202,15 +219,22 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.AddCommand(newCancelCmd())
 	cmd.AddCommand(newRefreshCmd())
 	cmd.AddCommand(newStateCmd())
+	cmd.AddCommand(newRefactorCmd())
+	cmd.AddCommand(newResourceCmd())
 	//     - Other Commands:
 	cmd.AddCommand(newLogsCmd())
 	cmd.AddCommand(newPluginCmd())
 	cmd.AddCommand(newVersionCmd())
+	cmd.AddCommand(newAboutCmd())
 	cmd.AddCommand(newHistoryCmd())
+	cmd.AddCommand(newLintCmd())
 
 	// Less common, and thus hidden, commands:
 	cmd.AddCommand(newGenCompletionCmd(cmd))
 	cmd.AddCommand(newGenMarkdownCmd(cmd))
+	cmd.AddCommand(newCheckSDKCompatCmd())
+	cmd.AddCommand(newCheckSDKExamplesCmd())
+	cmd.AddCommand(newSandboxRelayCmd())
 
 	// We have a set of commands that are still experimental and that we add only when PULUMI_EXPERIMENTAL is set
 	// to true.
@@ -234,6 +258,9 @@ func NewPulumiCmd() *cobra.Command {
 		cmd.AddCommand(newQueryCmd())
 	}
 
+	// Complete stack names and resource URNs for the flags that accept them.
+	registerFlagCompletions(cmd)
+
 	return cmd
 }
 
@@ -0,0 +1,316 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/engine"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+func newRefactorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refactor",
+		Short: "Plan refactorings of a stack's program",
+		Long: `Plan refactorings of a stack's program
+
+Subcommands of this command help restructure a Pulumi program, e.g. by moving resources into a component, without
+deleting and recreating the resources whose names change as a result.`,
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newRefactorPlanCmd())
+	return cmd
+}
+
+func newRefactorPlanCmd() *cobra.Command {
+	var stack string
+	var configArray []string
+	var configPath bool
+	var showPreview bool
+	var jsonOut bool
+
+	var cmd = &cobra.Command{
+		Use:   "plan",
+		Short: "Find resources that the program has moved",
+		Long: "Find resources that the program has moved\n" +
+			"\n" +
+			"This command previews an update of the stack and looks for resources that the update would delete\n" +
+			"and create again under a different URN, e.g. because they were moved into a component. Each resource\n" +
+			"the program would create is matched with a resource it would delete that has the same type and the\n" +
+			"same inputs or, failing that, the same type and name.\n" +
+			"\n" +
+			"For each match, the plan proposes an alias that tells Pulumi that the new resource is the old one, so\n" +
+			"that the next update renames the resource in the stack's state instead of replacing it. No changes to\n" +
+			"the stack take place.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			displayOpts := display.Options{
+				Color:         cmdutil.GetGlobalColorization(),
+				IsInteractive: cmdutil.Interactive(),
+				Type:          display.DisplayProgress,
+			}
+
+			s, err := requireStack(stack, false, displayOpts, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			// Save any config values passed via flags.
+			if err = parseAndSaveConfigArray(s, configArray, configPath); err != nil {
+				return result.FromError(err)
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			if err = ensureRuntimeEnv(proj, root); err != nil {
+				return result.FromError(err)
+			}
+
+			m, err := getUpdateMetadata("", root)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "gathering environment metadata"))
+			}
+
+			sm, err := getStackSecretsManager(s)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting secrets manager"))
+			}
+
+			cfg, err := getStackConfiguration(s, sm)
+			if err != nil {
+				return result.FromError(errors.Wrap(err, "getting stack configuration"))
+			}
+
+			// Unless asked to show it, keep the preview itself off the console and show only the plan.
+			if !showPreview {
+				displayOpts.Type = display.DisplayDiff
+				displayOpts.JSONDisplay = false
+				displayOpts.IsInteractive = false
+			}
+
+			// Gather the resources that the update would create and delete.
+			var creates, deletes []*resource.State
+			events := make(chan engine.Event)
+			eventsDone := make(chan bool)
+			go func() {
+				for e := range events {
+					if e.Type != engine.ResourcePreEvent {
+						continue
+					}
+					step := e.Payload().(engine.ResourcePreEventPayload).Metadata
+					switch {
+					case step.Op == deploy.OpCreate && step.New != nil:
+						creates = append(creates, step.New.State)
+					case step.Op == deploy.OpDelete && step.Old != nil:
+						deletes = append(deletes, step.Old.State)
+					}
+				}
+				close(eventsDone)
+			}()
+
+			_, res := s.Preview(commandContext(), backend.UpdateOperation{
+				Proj: proj,
+				Root: root,
+				M:    m,
+				Opts: backend.UpdateOptions{
+					Engine: engine.UpdateOptions{
+						UseLegacyDiff: useLegacyDiff(),
+					},
+					Display: displayOpts,
+				},
+				StackConfiguration: cfg,
+				SecretsManager:     sm,
+				Scopes:             cancellationScopes,
+				Events:             events,
+			})
+			close(events)
+			<-eventsDone
+			if res != nil {
+				return PrintEngineResult(res)
+			}
+
+			plan := planRefactor(creates, deletes)
+			if jsonOut {
+				return result.WrapIfNonNil(printJSON(plan))
+			}
+			fmt.Println()
+			plan.print(os.Stdout)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&stackConfigFile, "config-file", "",
+		"Use the configuration values in the specified file rather than detecting the file name")
+	cmd.PersistentFlags().StringArrayVarP(
+		&configArray, "config", "c", []string{},
+		"Config to use during the preview")
+	cmd.PersistentFlags().BoolVar(
+		&configPath, "config-path", false,
+		"Config keys contain a path to a property in a map or list to set")
+	cmd.PersistentFlags().BoolVar(
+		&showPreview, "show-preview", false,
+		"Show the progress of the preview that the plan is computed from")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit the plan as JSON")
+
+	return cmd
+}
+
+// refactorMove is a resource whose URN differs between the stack's state and its program.
+type refactorMove struct {
+	Type     tokens.Type  `json:"type"`
+	From     resource.URN `json:"from"`
+	To       resource.URN `json:"to"`
+	ByInputs bool         `json:"byInputs"` // true if matched by inputs, false if by name.
+}
+
+// refactorPlan is the set of moves proposed by `pulumi refactor plan`.
+type refactorPlan struct {
+	Moves     []refactorMove `json:"moves"`
+	Ambiguous []resource.URN `json:"ambiguous,omitempty"` // created resources that match more than one deletion.
+}
+
+// planRefactor matches the resources that an update would create with those it would delete. A pair matches if its
+// resources have the same type and inputs or, failing that, the same type and name. Only pairs that match uniquely
+// are proposed as moves.
+func planRefactor(creates, deletes []*resource.State) refactorPlan {
+	candidate := func(res *resource.State) bool {
+		return res != nil && !providers.IsProviderType(res.Type) && res.Type != resource.RootStackType
+	}
+	var pendingCreates, pendingDeletes []*resource.State
+	for _, res := range creates {
+		if candidate(res) {
+			pendingCreates = append(pendingCreates, res)
+		}
+	}
+	for _, res := range deletes {
+		if candidate(res) {
+			pendingDeletes = append(pendingDeletes, res)
+		}
+	}
+
+	var plan refactorPlan
+	ambiguous := make(map[resource.URN]bool)
+
+	match := func(byInputs bool, key func(*resource.State) string) {
+		createsByKey, deletesByKey := make(map[string][]*resource.State), make(map[string][]*resource.State)
+		for _, res := range pendingCreates {
+			createsByKey[key(res)] = append(createsByKey[key(res)], res)
+		}
+		for _, res := range pendingDeletes {
+			deletesByKey[key(res)] = append(deletesByKey[key(res)], res)
+		}
+
+		matched := make(map[*resource.State]bool)
+		for _, res := range pendingCreates {
+			k := key(res)
+			switch cs, ds := createsByKey[k], deletesByKey[k]; {
+			case len(ds) == 0:
+				continue
+			case len(cs) == 1 && len(ds) == 1:
+				plan.Moves = append(plan.Moves, refactorMove{Type: res.Type, From: ds[0].URN, To: res.URN,
+					ByInputs: byInputs})
+				matched[res], matched[ds[0]] = true, true
+				delete(ambiguous, res.URN)
+			default:
+				ambiguous[res.URN] = true
+			}
+		}
+
+		pendingCreates, pendingDeletes = unmatched(pendingCreates, matched), unmatched(pendingDeletes, matched)
+	}
+	match(true, refactorFingerprint)
+	match(false, func(res *resource.State) string {
+		return string(res.Type) + "::" + string(res.URN.Name())
+	})
+
+	for _, res := range pendingCreates {
+		if ambiguous[res.URN] {
+			plan.Ambiguous = append(plan.Ambiguous, res.URN)
+		}
+	}
+	return plan
+}
+
+// refactorFingerprint identifies a resource by its type and inputs.
+func refactorFingerprint(res *resource.State) string {
+	inputs, err := json.Marshal(res.Inputs.Mappable())
+	if err != nil {
+		// Resources whose inputs cannot be fingerprinted can still be matched by name.
+		return string(res.URN)
+	}
+	return string(res.Type) + "::" + string(inputs)
+}
+
+func unmatched(states []*resource.State, matched map[*resource.State]bool) []*resource.State {
+	var result []*resource.State
+	for _, res := range states {
+		if !matched[res] {
+			result = append(result, res)
+		}
+	}
+	return result
+}
+
+// print writes the plan to the given writer.
+func (plan refactorPlan) print(w io.Writer) {
+	if len(plan.Moves) == 0 && len(plan.Ambiguous) == 0 {
+		fmt.Fprintf(w, "No moved resources were found.\n")
+		return
+	}
+
+	if len(plan.Moves) > 0 {
+		fmt.Fprintf(w, "%d moved resources were found. To keep them rather than replace them, add these aliases:\n",
+			len(plan.Moves))
+		for _, move := range plan.Moves {
+			basis := "name"
+			if move.ByInputs {
+				basis = "inputs"
+			}
+			fmt.Fprintf(w, "    %s (matched by %s)\n", move.To, basis)
+			fmt.Fprintf(w, "        alias: %s\n", move.From)
+		}
+	}
+	if len(plan.Ambiguous) > 0 {
+		fmt.Fprintf(w, "%d created resources match more than one deleted resource and need an alias chosen by hand:\n",
+			len(plan.Ambiguous))
+		for _, urn := range plan.Ambiguous {
+			fmt.Fprintf(w, "    %s\n", urn)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func TestPlanRefactor(t *testing.T) {
+	bucket := tokens.Type("aws:s3/bucket:Bucket")
+	state := func(parent tokens.Type, typ tokens.Type, name string, acl string) *resource.State {
+		urn := resource.NewURN("dev", "proj", parent, typ, tokens.QName(name))
+		inputs := resource.PropertyMap{"acl": resource.NewStringProperty(acl)}
+		return &resource.State{Type: typ, URN: urn, Inputs: inputs}
+	}
+	const component = tokens.Type("my:component:Component")
+
+	// A resource moved into a component keeps its inputs.
+	oldLogs, newLogs := state("", bucket, "logs", "private"), state(component, bucket, "logs", "private")
+	// A resource that is also renamed is still matched by its inputs.
+	oldSite, newSite := state("", bucket, "site", "public-read"), state(component, bucket, "www", "public-read")
+	// A resource whose inputs changed as well is matched by its name.
+	oldData, newData := state("", bucket, "data", "log-delivery-write"), state(component, bucket, "data", "authenticated-read")
+	// Resources that only differ in type are never matched.
+	oldQueue, newQueue := state("", "aws:sqs/queue:Queue", "q", "x"), state(component, "aws:sns/topic:Topic", "q", "x")
+
+	plan := planRefactor(
+		[]*resource.State{newLogs, newSite, newData, newQueue},
+		[]*resource.State{oldLogs, oldSite, oldData, oldQueue})
+	assert.Equal(t, []refactorMove{
+		{Type: bucket, From: oldLogs.URN, To: newLogs.URN, ByInputs: true},
+		{Type: bucket, From: oldSite.URN, To: newSite.URN, ByInputs: true},
+		{Type: bucket, From: oldData.URN, To: newData.URN, ByInputs: false},
+	}, plan.Moves)
+	assert.Empty(t, plan.Ambiguous)
+
+	// Two identical resources moved at once cannot be told apart.
+	a1, a2 := state("", bucket, "a1", "private"), state(component, bucket, "a2", "private")
+	b1, b2 := state("", bucket, "b1", "private"), state(component, bucket, "b2", "private")
+	plan = planRefactor([]*resource.State{a2, b2}, []*resource.State{a1, b1})
+	assert.Empty(t, plan.Moves)
+	assert.Equal(t, []resource.URN{a2.URN, b2.URN}, plan.Ambiguous)
+
+	var buf bytes.Buffer
+	planRefactor([]*resource.State{newLogs}, []*resource.State{oldLogs}).print(&buf)
+	assert.Contains(t, buf.String(), "alias: "+string(oldLogs.URN))
+}
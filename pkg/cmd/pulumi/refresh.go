@@ -31,6 +31,7 @@ import (
 func newRefreshCmd() *cobra.Command {
 	var debug bool
 	var expectNop bool
+	var detailedExitCode bool
 	var message string
 	var stack string
 
@@ -45,6 +46,9 @@ func newRefreshCmd() *cobra.Command {
 	var suppressOutputs bool
 	var yes bool
 	var targets *[]string
+	var confirmStack string
+	var overrideFreeze bool
+	var adoptOrphans bool
 
 	var cmd = &cobra.Command{
 		Use:   "refresh",
@@ -56,8 +60,13 @@ func newRefreshCmd() *cobra.Command {
 			"the program text isn't updated accordingly, subsequent updates may still appear to be out of\n" +
 			"synch with respect to the cloud provider's source of truth.\n" +
 			"\n" +
+			"Some providers report resources they discover beneath the resources they read, such as nodes\n" +
+			"created by an autoscaling group. Such children are listed but left out of the stack unless\n" +
+			"`--adopt-orphans` is passed, in which case they are recorded as read-only references that\n" +
+			"may later be imported or excluded.\n" +
+			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
-			"`--cwd` flag to use a different directory.",
+			"`--cwd` flag to use a different directory." + exitCodesHelp,
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			yes = yes || skipConfirmations()
@@ -70,6 +79,8 @@ func newRefreshCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			opts.ConfirmStackName = confirmStack
+			opts.OverrideFreeze = overrideFreeze
 
 			var displayType = display.DisplayProgress
 			if diffDisplay {
@@ -123,8 +134,10 @@ func newRefreshCmd() *cobra.Command {
 				Debug:          debug,
 				UseLegacyDiff:  useLegacyDiff(),
 				RefreshTargets: targetUrns,
+				AdoptOrphans:   adoptOrphans,
 			}
 
+			outcome := newOperationOutcome(false, expectNop, detailedExitCode)
 			changes, res := s.Refresh(commandContext(), backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
@@ -133,18 +146,13 @@ func newRefreshCmd() *cobra.Command {
 				StackConfiguration: cfg,
 				SecretsManager:     sm,
 				Scopes:             cancellationScopes,
+				Events:             outcome.events,
 			})
-
-			switch {
-			case res != nil && res.Error() == context.Canceled:
+			outcome.close()
+			if res != nil && res.Error() == context.Canceled {
 				return result.FromError(errors.New("refresh cancelled"))
-			case res != nil:
-				return PrintEngineResult(res)
-			case expectNop && changes != nil && changes.HasChanges():
-				return result.FromError(errors.New("error: no changes were expected but changes occurred"))
-			default:
-				return nil
 			}
+			return outcome.result(changes, res)
 		}),
 	}
 
@@ -153,7 +161,10 @@ func newRefreshCmd() *cobra.Command {
 		"Print detailed debugging output during resource operations")
 	cmd.PersistentFlags().BoolVar(
 		&expectNop, "expect-no-changes", false,
-		"Return an error if any changes occur during this update")
+		"Return an error if any changes occur during this refresh")
+	cmd.PersistentFlags().BoolVar(
+		&detailedExitCode, "detailed-exit-code", false,
+		"Exit with a code that says whether this refresh changed the stack")
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
@@ -168,6 +179,9 @@ func newRefreshCmd() *cobra.Command {
 	targets = cmd.PersistentFlags().StringArrayP(
 		"target", "t", []string{},
 		"Specify a single resource URN to refresh. Multiple resource can be specified using: --target urn1 --target urn2")
+	cmd.PersistentFlags().BoolVar(
+		&adoptOrphans, "adopt-orphans", false,
+		"Record unmanaged children discovered by providers in the stack as read-only references")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().BoolVar(
@@ -191,6 +205,12 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the refresh after previewing it")
+	cmd.PersistentFlags().StringVar(
+		&confirmStack, "confirm-stack", "",
+		"Confirm the refresh by giving the stack's name, if the project requires it to be typed")
+	cmd.PersistentFlags().BoolVar(
+		&overrideFreeze, "override-freeze", false,
+		"Perform the refresh even if the stack is frozen")
 
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
@@ -0,0 +1,36 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newResourceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resource",
+		Short: "Act on the resources in a stack",
+		Long: `Act on the resources in a stack
+
+Subcommands of this command perform operational actions, such as rebooting an instance or rotating a key, on the
+live resources that a stack manages. Unlike 'pulumi state', they do not change the stack's state.`,
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newResourceCallCmd())
+	return cmd
+}
@@ -0,0 +1,134 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/operations"
+	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+func newResourceCallCmd() *cobra.Command {
+	var stackName string
+	var argsJSON string
+	var showSecrets bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "call <resource URN> <method>",
+		Short: "Call a method on a resource in a stack",
+		Long: `Call a method on a resource in a stack
+
+This command runs one of the methods that a resource's provider declares in its schema, such as rebooting an
+instance or rotating a key, against the live resource. The arguments to the method are given with --args as a JSON
+object. Any values that the method returns are printed as JSON.
+
+The provider is loaded and configured with the same settings that the stack last used for it.`,
+		Args: cmdutil.ExactArgs(2),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			urn, method := resource.URN(args[0]), args[1]
+
+			var callArgs map[string]interface{}
+			if argsJSON != "" {
+				if err := json.Unmarshal([]byte(argsJSON), &callArgs); err != nil {
+					return result.FromError(errors.Wrap(err, "--args must be a JSON object"))
+				}
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			if snap == nil {
+				return result.Errorf("stack '%v' has no resources", s.Ref())
+			}
+			res, err := locateStackResource(opts, snap, urn)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			if !yes && !skipConfirmations() && cmdutil.Interactive() {
+				confirm := false
+				surveycore.DisableColor = true
+				surveycore.QuestionIcon = ""
+				surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+				prompt := fmt.Sprintf("This command will call %v on the live resource %v. Confirm?", method, res.URN)
+				cmdutil.EndKeypadTransmitMode()
+				if err = survey.AskOne(&survey.Confirm{
+					Message: opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": " + prompt),
+				}, &confirm, nil); err != nil || !confirm {
+					fmt.Println("confirmation declined")
+					return result.Bail()
+				}
+			}
+
+			plugctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, "", nil, nil)
+			if err != nil {
+				return result.FromError(err)
+			}
+			defer contract.IgnoreClose(plugctx)
+
+			ret, err := operations.CallMethod(operations.NewProviderSource(plugctx.Host, snap.Resources), res,
+				method, resource.NewPropertyMapFromMap(callArgs))
+			if err != nil {
+				return result.FromError(err)
+			}
+			if len(ret) == 0 {
+				return nil
+			}
+
+			// As with stack outputs, secrets are removed before serializing unless they were asked for, so a panic
+			// crypter is safe here.
+			outputs, err := stack.SerializeProperties(display.MassageSecrets(ret, showSecrets),
+				config.NewPanicCrypter(), showSecrets)
+			if err != nil {
+				return result.FromError(err)
+			}
+			return result.WrapIfNonNil(printJSON(outputs))
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().StringVar(&argsJSON, "args", "", "The arguments to the method, as a JSON object")
+	cmd.Flags().BoolVar(
+		&showSecrets, "show-secrets", false, "Display returned values which are marked as secret in plaintext")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+// resourceUsageInterval is how often the resource usage of plugins is sampled when it is being reported.
+const resourceUsageInterval = time.Second
+
+// writeResourceUsageProfile writes the sampled resource usage of the plugins that were launched by this process to
+// '[prefix].[pid].plugins' as JSON.
+func writeResourceUsageProfile(prefix string) error {
+	b, err := json.MarshalIndent(plugin.ResourceUsage(), "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal plugin resource usage")
+	}
+	if err = ioutil.WriteFile(fmt.Sprintf("%s.%v.plugins", prefix, os.Getpid()), b, 0600); err != nil {
+		return errors.Wrap(err, "could not write plugin resource usage")
+	}
+	return nil
+}
+
+// printResourceUsage prints a summary of the sampled resource usage of the plugins that were launched by this process.
+func printResourceUsage() {
+	usages := plugin.ResourceUsage()
+	if len(usages) == 0 {
+		fmt.Printf("\nNo plugin resource usage was recorded.\n")
+		return
+	}
+
+	var totalCPU time.Duration
+	rows := []cmdutil.TableRow{}
+	for _, usage := range usages {
+		peakMemory := humanize.Bytes(usage.PeakMemory)
+		if usage.MemoryLimitExceeded {
+			peakMemory += " (limit exceeded)"
+		}
+		rows = append(rows, cmdutil.TableRow{
+			Columns: []string{usage.Name, fmt.Sprintf("%d", usage.PID), usage.CPUTime.Round(time.Millisecond).String(),
+				peakMemory, fmt.Sprintf("%d", usage.Samples)},
+		})
+		totalCPU += usage.CPUTime
+	}
+
+	fmt.Printf("\nPlugin resource usage:\n")
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"PLUGIN", "PID", "CPU TIME", "PEAK MEMORY", "SAMPLES"},
+		Rows:    rows,
+	})
+	fmt.Printf("\nTOTAL plugin CPU time: %v\n", totalCPU.Round(time.Millisecond))
+}
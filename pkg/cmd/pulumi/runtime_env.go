@@ -0,0 +1,179 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/npm"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	"github.com/pulumi/pulumi/sdk/v2/python"
+)
+
+// skipDependencyInstallEnvVar names an environment variable that, when truthy, stops the CLI from installing a
+// project's dependencies before running its program.
+const skipDependencyInstallEnvVar = "PULUMI_SKIP_DEPENDENCY_INSTALL"
+
+// runtimeEnvStampFile is the name of the file, kept inside a project's runtime environment, that records a digest of
+// the dependency manifests that were last installed into it.
+const runtimeEnvStampFile = ".pulumi-dependencies.sha256"
+
+// runtimeEnv describes the isolated environment into which a project's dependencies are installed.
+type runtimeEnv struct {
+	// dir is the environment's directory, e.g. node_modules or a Python virtual environment.
+	dir string
+	// manifests are the files, relative to the project root, that list the project's dependencies.
+	manifests []string
+	// install creates the environment if necessary and installs the project's dependencies into it.
+	install func() error
+}
+
+// getRuntimeEnv returns the runtime environment for the given project, or nil if the CLI does not manage the
+// environment for the project's runtime.
+//
+// TODO[pulumi/pulumi#1334]: move to the language plugins so we don't have to hard code here.
+func getRuntimeEnv(proj *workspace.Project, root string) (*runtimeEnv, error) {
+	switch strings.ToLower(proj.Runtime.Name()) {
+	case "nodejs":
+		if _, err := os.Stat(filepath.Join(root, "package.json")); err != nil {
+			return nil, nil
+		}
+		return &runtimeEnv{
+			dir:       filepath.Join(root, "node_modules"),
+			manifests: []string{"package.json", "package-lock.json", "yarn.lock"},
+			install: func() error {
+				bin, err := npm.Install(root, os.Stderr, os.Stderr)
+				return errors.Wrapf(err, "%s install failed", bin)
+			},
+		}, nil
+	case "python":
+		virtualenv, ok := proj.Runtime.Options()["virtualenv"].(string)
+		if !ok || virtualenv == "" {
+			return nil, nil
+		}
+		if !filepath.IsAbs(virtualenv) {
+			virtualenv = filepath.Join(root, virtualenv)
+		}
+		return &runtimeEnv{
+			dir:       virtualenv,
+			manifests: []string{"requirements.txt"},
+			install: func() error {
+				if !python.IsVirtualEnv(virtualenv) {
+					if err := python.CreateVirtualEnv(virtualenv); err != nil {
+						return err
+					}
+				}
+				return python.InstallRequirements(root, virtualenv, false /*showOutput*/)
+			},
+		}, nil
+	case "dotnet":
+		var manifests []string
+		for _, pattern := range []string{"*.csproj", "*.fsproj", "*.vbproj", "nuget.config"} {
+			matches, err := filepath.Glob(filepath.Join(root, pattern))
+			if err != nil {
+				return nil, err
+			}
+			for _, match := range matches {
+				manifests = append(manifests, filepath.Base(match))
+			}
+		}
+		if len(manifests) == 0 {
+			return nil, nil
+		}
+		return &runtimeEnv{
+			dir:       filepath.Join(root, "obj"),
+			manifests: manifests,
+			install: func() error {
+				cmd := exec.Command("dotnet", "restore")
+				cmd.Dir = root
+				if output, err := cmd.CombinedOutput(); err != nil {
+					os.Stderr.Write(output)
+					return errors.Wrap(err, "`dotnet restore` failed")
+				}
+				return nil
+			},
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// digest returns a digest of the contents of the environment's manifests. Missing manifests are skipped.
+func (env *runtimeEnv) digest(root string) (string, error) {
+	h := sha256.New()
+	for _, manifest := range env.manifests {
+		b, err := ioutil.ReadFile(filepath.Join(root, manifest))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", manifest, len(b))
+		h.Write(b) // nolint: errcheck
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ensureRuntimeEnv makes sure that the project's runtime environment exists and that the dependencies installed into
+// it are up to date, so that a project's program can be run without setting up its toolchain by hand. Dependencies
+// are only installed if the environment is missing or its manifests have changed since they were last installed; an
+// environment that was set up by hand is adopted as is.
+func ensureRuntimeEnv(proj *workspace.Project, root string) error {
+	if cmdutil.IsTruthy(os.Getenv(skipDependencyInstallEnvVar)) {
+		return nil
+	}
+
+	env, err := getRuntimeEnv(proj, root)
+	if err != nil || env == nil {
+		return err
+	}
+	digest, err := env.digest(root)
+	if err != nil {
+		return errors.Wrap(err, "reading dependency manifests")
+	}
+
+	stampPath := filepath.Join(env.dir, runtimeEnvStampFile)
+	if _, err = os.Stat(env.dir); err == nil {
+		stamp, err := ioutil.ReadFile(stampPath)
+		switch {
+		case os.IsNotExist(err):
+			logging.V(5).Infof("adopting existing runtime environment %s", env.dir)
+			return ioutil.WriteFile(stampPath, []byte(digest), 0600)
+		case err == nil && string(stamp) == digest:
+			logging.V(5).Infof("runtime environment %s is up to date", env.dir)
+			return nil
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Installing dependencies for %s project into %s...\n", proj.Runtime.Name(), env.dir)
+	if err = env.install(); err != nil {
+		return errors.Wrapf(err, "installing dependencies (set %s=true to skip this step)", skipDependencyInstallEnvVar)
+	}
+	return ioutil.WriteFile(stampPath, []byte(digest), 0600)
+}
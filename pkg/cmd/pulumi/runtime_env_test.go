@@ -0,0 +1,71 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestEnsureRuntimeEnvAdoptsExistingEnv(t *testing.T) {
+	root, err := ioutil.TempDir("", "runtime-env")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name":"a"}`), 0600))
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "node_modules"), 0700))
+
+	proj := &workspace.Project{Name: "test", Runtime: workspace.NewProjectRuntimeInfo("nodejs", nil)}
+	env, err := getRuntimeEnv(proj, root)
+	assert.NoError(t, err)
+	if !assert.NotNil(t, env) {
+		return
+	}
+
+	// An environment that was set up by hand is adopted without installing anything.
+	assert.NoError(t, ensureRuntimeEnv(proj, root))
+	stamp, err := ioutil.ReadFile(filepath.Join(root, "node_modules", runtimeEnvStampFile))
+	assert.NoError(t, err)
+	digest, err := env.digest(root)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, string(stamp))
+
+	// Changing a manifest changes the digest, so the next run reinstalls.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "yarn.lock"), []byte("lock"), 0600))
+	changed, err := env.digest(root)
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest, changed)
+}
+
+func TestGetRuntimeEnvPythonRequiresVirtualEnv(t *testing.T) {
+	proj := &workspace.Project{Name: "test", Runtime: workspace.NewProjectRuntimeInfo("python", nil)}
+	env, err := getRuntimeEnv(proj, "/project")
+	assert.NoError(t, err)
+	assert.Nil(t, env)
+
+	proj.Runtime.SetOption("virtualenv", "venv")
+	env, err = getRuntimeEnv(proj, "/project")
+	assert.NoError(t, err)
+	if assert.NotNil(t, env) {
+		assert.Equal(t, filepath.Join("/project", "venv"), env.dir)
+		assert.Equal(t, []string{"requirements.txt"}, env.manifests)
+	}
+}
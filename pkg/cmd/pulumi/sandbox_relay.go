@@ -0,0 +1,41 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+)
+
+// newSandboxRelayCmd returns the hidden command that the plugin sandbox runs to relay a sandboxed plugin's
+// connections to and from the engine. It is not meant to be run by hand.
+func newSandboxRelayCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    plugin.SandboxRelayCommand + " <dir> <engine-address> <plugin> [args...]",
+		Short:  "Relay a sandboxed plugin's connections to the engine",
+		Hidden: true,
+		// The plugin's arguments are passed through untouched.
+		DisableFlagParsing: true,
+		// The relay runs inside the sandbox, so it skips the root command's logging, tracing, and update checks.
+		PersistentPreRun:  func(cmd *cobra.Command, args []string) {},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {},
+		Run: func(cmd *cobra.Command, args []string) {
+			os.Exit(plugin.RunSandboxRelay(args))
+		},
+	}
+}
@@ -23,6 +23,7 @@ import (
 	humanize "github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/backend/httpstate"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
@@ -87,6 +88,14 @@ func newStackCmd() *cobra.Command {
 				}
 			}
 
+			if freeze, err := backend.GetStackFreeze(commandContext(), s); err == nil && freeze != nil {
+				reason := freeze.Reason
+				if reason == "" {
+					reason = "no reason given"
+				}
+				fmt.Printf("    Frozen: %s\n", reason)
+			}
+
 			if snap != nil {
 				if t := snap.Manifest.Time; t.IsZero() && startTime == "" {
 					fmt.Printf("    Last update time unknown\n")
@@ -169,15 +178,20 @@ func newStackCmd() *cobra.Command {
 	cmd.Flags().BoolVar(
 		&showStackName, "show-name", false, "Display only the stack name")
 
+	cmd.AddCommand(newStackBundleCmd())
 	cmd.AddCommand(newStackExportCmd())
+	cmd.AddCommand(newStackFreezeCmd())
 	cmd.AddCommand(newStackGraphCmd())
 	cmd.AddCommand(newStackImportCmd())
 	cmd.AddCommand(newStackInitCmd())
 	cmd.AddCommand(newStackLsCmd())
 	cmd.AddCommand(newStackOutputCmd())
+	cmd.AddCommand(newStackResourcesCmd())
+	cmd.AddCommand(newStackRestoreCmd())
 	cmd.AddCommand(newStackRmCmd())
 	cmd.AddCommand(newStackSelectCmd())
 	cmd.AddCommand(newStackTagCmd())
+	cmd.AddCommand(newStackUnfreezeCmd())
 	cmd.AddCommand(newStackRenameCmd())
 
 	return cmd
@@ -0,0 +1,387 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/pkg/v2/backend/state"
+	"github.com/pulumi/pulumi/pkg/v2/version"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// stackBundleVersion is the version of the stack bundle format written by `pulumi stack bundle`.
+const stackBundleVersion = 1
+
+// stackBundle is everything needed to restore a stack or to reproduce its last update: its checkpoint, its
+// configuration, the plugins its resources were last updated with, and the source revision that the bundle was made
+// from.
+type stackBundle struct {
+	Version    int                       `json:"version"`
+	CreatedAt  time.Time                 `json:"createdAt"`
+	CLIVersion string                    `json:"cliVersion"`
+	Backend    string                    `json:"backend"`
+	Project    string                    `json:"project"`
+	Stack      string                    `json:"stack"`
+	Deployment apitype.UntypedDeployment `json:"deployment"`
+	Config     *workspace.ProjectStack   `json:"config"`
+	// RedactedKeys lists the secret configuration keys that were left out of the bundle.
+	RedactedKeys []string `json:"redactedKeys,omitempty"`
+	// Plugins lists the plugins that the stack's last update used.
+	Plugins []apitype.PluginInfoV1 `json:"plugins,omitempty"`
+	// Source describes the state of the source repository that the bundle was made from, e.g. its current commit.
+	Source map[string]string `json:"source,omitempty"`
+}
+
+func newStackBundleCmd() *cobra.Command {
+	var stackName string
+	var file string
+	var redactSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Args:  cmdutil.NoArgs,
+		Short: "Bundle a stack's state, configuration and provenance into a single file",
+		Long: "Bundle a stack's state, configuration and provenance into a single file.\n" +
+			"\n" +
+			"The bundle contains the stack's checkpoint, its configuration file, the plugins that its last\n" +
+			"update used, and the git commit of the project's source, so that it can be used to recover the\n" +
+			"stack with `pulumi stack restore` or attached to a support request. Secrets in the checkpoint and\n" +
+			"the configuration stay encrypted with the stack's secrets provider. Pass `--redact-secrets` to\n" +
+			"leave secret configuration values out of the bundle altogether.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return err
+			}
+			ps, err := loadProjectStack(s)
+			if err != nil {
+				return err
+			}
+
+			deployment, err := s.ExportDeployment(commandContext())
+			if err != nil {
+				return err
+			}
+
+			m, err := getUpdateMetadata("", root)
+			if err != nil {
+				return errors.Wrap(err, "gathering environment metadata")
+			}
+
+			bundle, err := newStackBundle(s, proj, ps, deployment, m.Environment, redactSecrets)
+			if err != nil {
+				return err
+			}
+
+			writer := io.Writer(os.Stdout)
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return errors.Wrap(err, "could not open file")
+				}
+				defer f.Close()
+				writer = f
+			}
+
+			enc := json.NewEncoder(writer)
+			enc.SetIndent("", "    ")
+			if err = enc.Encode(bundle); err != nil {
+				return errors.Wrap(err, "could not write bundle")
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&stackConfigFile, "config-file", "",
+		"Use the configuration values in the specified file rather than detecting the file name")
+	cmd.PersistentFlags().StringVar(
+		&file, "file", "", "A filename to write the bundle to. Defaults to standard out")
+	cmd.PersistentFlags().BoolVar(
+		&redactSecrets, "redact-secrets", false, "Leave secret configuration values out of the bundle")
+	return cmd
+}
+
+// newStackBundle assembles a bundle for the given stack. Only the source metadata that describes the repository is
+// recorded; the environment may also contain values that describe the machine the bundle was made on.
+func newStackBundle(s backend.Stack, proj *workspace.Project, ps *workspace.ProjectStack,
+	deployment *apitype.UntypedDeployment, env map[string]string, redactSecrets bool) (*stackBundle, error) {
+
+	bundle := &stackBundle{
+		Version:    stackBundleVersion,
+		CreatedAt:  time.Now().UTC(),
+		CLIVersion: version.Version,
+		Backend:    s.Backend().URL(),
+		Project:    string(proj.Name),
+		Stack:      s.Ref().Name().String(),
+		Deployment: *deployment,
+		Config:     ps,
+		Source:     make(map[string]string),
+	}
+
+	if redactSecrets {
+		bundle.Config, bundle.RedactedKeys = redactSecretConfig(ps)
+	}
+
+	if deployment.Version == apitype.DeploymentSchemaVersionCurrent {
+		var d apitype.DeploymentV3
+		if err := json.Unmarshal(deployment.Deployment, &d); err != nil {
+			return nil, errors.Wrap(err, "reading deployment")
+		}
+		bundle.Plugins = d.Manifest.Plugins
+	}
+
+	for _, key := range []string{
+		backend.GitHead, backend.GitHeadName, backend.GitDirty,
+		backend.GitCommitter, backend.GitCommitterEmail, backend.GitAuthor, backend.GitAuthorEmail,
+		backend.VCSRepoOwner, backend.VCSRepoName, backend.VCSRepoKind,
+	} {
+		if v, ok := env[key]; ok {
+			bundle.Source[key] = v
+		}
+	}
+
+	return bundle, nil
+}
+
+// redactSecretConfig returns a copy of the given stack configuration without its secret values, and the sorted keys
+// of the values that were removed.
+func redactSecretConfig(ps *workspace.ProjectStack) (*workspace.ProjectStack, []string) {
+	redacted := *ps
+	redacted.Config = make(config.Map)
+
+	var keys []string
+	for k, v := range ps.Config {
+		if v.Secure() {
+			keys = append(keys, k.String())
+			continue
+		}
+		redacted.Config[k] = v
+	}
+	sort.Strings(keys)
+	return &redacted, keys
+}
+
+func newStackRestoreCmd() *cobra.Command {
+	var stackName string
+	var force bool
+	var overrideFreeze bool
+
+	cmd := &cobra.Command{
+		Use:   "restore <bundle>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Restore a stack from a bundle",
+		Long: "Restore a stack from a bundle.\n" +
+			"\n" +
+			"This command restores the stack in a bundle made by `pulumi stack bundle`, creating the stack if it\n" +
+			"does not exist. The bundle's checkpoint is imported into the stack, and its configuration is written\n" +
+			"to the stack's configuration file unless that file already exists. Pass `--force` to overwrite an\n" +
+			"existing configuration file, and to import a checkpoint whose resources belong to another stack.\n" +
+			"\n" +
+			"The plugins that the bundle lists but that are not installed, and the source commit that the bundle\n" +
+			"was made from, are printed afterwards so that the stack's last update can be reproduced.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			bundle, err := readStackBundle(args[0])
+			if err != nil {
+				return err
+			}
+
+			proj, _, err := readProject()
+			if err != nil {
+				return err
+			}
+			if string(proj.Name) != bundle.Project {
+				return errors.Errorf("the bundle is for project '%s', but the current project is '%s'",
+					bundle.Project, proj.Name)
+			}
+
+			if stackName == "" {
+				stackName = bundle.Stack
+			}
+			b, err := currentBackend(opts)
+			if err != nil {
+				return err
+			}
+			stackRef, err := b.ParseStackReference(stackName)
+			if err != nil {
+				return err
+			}
+
+			s, err := b.GetStack(commandContext(), stackRef)
+			if err != nil {
+				return err
+			}
+			if s != nil {
+				if err = backend.CheckStackFreeze(commandContext(), s, overrideFreeze); err != nil {
+					return err
+				}
+			}
+
+			// Check the checkpoint before writing anything, so that a checkpoint that cannot be imported leaves
+			// neither a new stack nor its configuration file behind.
+			deployment, err := checkStackDeployment(stackRef.Name(), &bundle.Deployment, force)
+			if err != nil {
+				return err
+			}
+
+			// Restore the configuration next, so that a newly created stack uses the bundle's secrets provider.
+			if err = restoreStackConfig(stackRef.Name(), bundle.Config, force); err != nil {
+				return err
+			}
+
+			if s == nil {
+				if s, err = createStack(b, stackRef, nil, true, bundle.Config.SecretsProvider); err != nil {
+					return err
+				}
+			} else if err = state.SetCurrentStack(s.Ref().String()); err != nil {
+				return err
+			}
+
+			if err = s.ImportDeployment(commandContext(), deployment); err != nil {
+				return errors.Wrap(err, "could not import deployment")
+			}
+
+			fmt.Printf("Restored stack '%s' from a bundle of '%s' made at %v.\n", s.Ref(), bundle.Stack,
+				bundle.CreatedAt.Local().Format(time.RFC1123))
+			printStackBundleProvenance(os.Stdout, bundle)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to restore into. Defaults to the bundle's stack")
+	cmd.PersistentFlags().StringVar(
+		&stackConfigFile, "config-file", "",
+		"Write the configuration to the specified file rather than detecting the file name")
+	cmd.PersistentFlags().BoolVarP(
+		&force, "force", "f", false,
+		"Overwrite the stack's configuration file and ignore resources from other stacks (not recommended)")
+	cmd.PersistentFlags().BoolVar(
+		&overrideFreeze, "override-freeze", false,
+		"Restore the stack even if it is frozen")
+	return cmd
+}
+
+// readStackBundle reads a bundle written by `pulumi stack bundle`.
+func readStackBundle(path string) (*stackBundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open bundle")
+	}
+	defer f.Close()
+
+	var bundle stackBundle
+	if err = json.NewDecoder(f).Decode(&bundle); err != nil {
+		return nil, errors.Wrap(err, "could not read bundle")
+	}
+	if bundle.Version != stackBundleVersion {
+		return nil, errors.Errorf("the bundle has version %d, but this version of the CLI only reads version %d",
+			bundle.Version, stackBundleVersion)
+	}
+	if bundle.Config == nil {
+		bundle.Config = &workspace.ProjectStack{}
+	}
+	return &bundle, nil
+}
+
+// restoreStackConfig writes the configuration from a bundle to the stack's configuration file. An existing file is
+// only overwritten if force is true.
+func restoreStackConfig(stackName tokens.QName, ps *workspace.ProjectStack, force bool) error {
+	path := stackConfigFile
+	if path == "" {
+		p, err := workspace.DetectProjectStackPath(stackName)
+		if err != nil {
+			return err
+		}
+		path = p
+	}
+
+	if _, err := os.Stat(path); err == nil && !force {
+		cmdutil.Diag().Warningf(diag.Message("", "not overwriting the existing configuration file %s; rerun with "+
+			"--force to replace it with the bundle's configuration"), path)
+		return nil
+	} else if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return ps.Save(path)
+}
+
+// printStackBundleProvenance writes what is needed to reproduce the last update of a restored stack: the secret
+// configuration values that must be set again, the plugins that are not installed, and the source commit.
+func printStackBundleProvenance(w io.Writer, bundle *stackBundle) {
+	if len(bundle.RedactedKeys) > 0 {
+		fmt.Fprintf(w, "\nThese secret configuration values were left out of the bundle and must be set again:\n")
+		for _, k := range bundle.RedactedKeys {
+			fmt.Fprintf(w, "    %s\n", k)
+		}
+	}
+
+	var missing []apitype.PluginInfoV1
+	for _, p := range bundle.Plugins {
+		info := workspace.PluginInfo{Name: p.Name, Kind: p.Type}
+		if v, err := semver.ParseTolerant(p.Version); err == nil {
+			info.Version = &v
+		}
+		if p.Type != workspace.LanguagePlugin && !workspace.HasPlugin(info) {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(w, "\nThe stack's last update used these plugins, which are not installed:\n")
+		for _, p := range missing {
+			fmt.Fprintf(w, "    pulumi plugin install %s %s %s\n", p.Type, p.Name, p.Version)
+		}
+	}
+
+	if head := bundle.Source[backend.GitHead]; head != "" {
+		dirty := ""
+		if bundle.Source[backend.GitDirty] == "true" {
+			dirty = ", with uncommitted changes"
+		}
+		fmt.Fprintf(w, "\nThe bundle was made from commit %s%s.\n", head, dirty)
+	}
+}
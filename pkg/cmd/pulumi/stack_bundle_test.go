@@ -0,0 +1,110 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestRedactSecretConfig(t *testing.T) {
+	ps := &workspace.ProjectStack{
+		EncryptionSalt: "v1:salt",
+		Config: config.Map{
+			config.MustMakeKey("proj", "region"):   config.NewValue("us-west-2"),
+			config.MustMakeKey("proj", "password"): config.NewSecureValue("c2VjcmV0"),
+			config.MustMakeKey("aws", "secretKey"): config.NewSecureValue("a2V5"),
+		},
+	}
+
+	redacted, keys := redactSecretConfig(ps)
+	assert.Equal(t, []string{"aws:secretKey", "proj:password"}, keys)
+	assert.Equal(t, config.Map{config.MustMakeKey("proj", "region"): config.NewValue("us-west-2")}, redacted.Config)
+	assert.Equal(t, "v1:salt", redacted.EncryptionSalt)
+
+	// The original configuration is left alone.
+	assert.Len(t, ps.Config, 3)
+}
+
+func TestReadStackBundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-stack-bundle-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "bundle.json")
+	write := func(bundle stackBundle) {
+		bytes, err := json.Marshal(bundle)
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(path, bytes, 0600))
+	}
+
+	write(stackBundle{Version: stackBundleVersion, Project: "proj", Stack: "dev"})
+	bundle, err := readStackBundle(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", bundle.Stack)
+	assert.NotNil(t, bundle.Config)
+
+	write(stackBundle{Version: stackBundleVersion + 1})
+	_, err = readStackBundle(path)
+	assert.Error(t, err)
+}
+
+func TestCheckStackDeployment(t *testing.T) {
+	deployment, err := json.Marshal(apitype.DeploymentV3{
+		Resources: []apitype.ResourceV3{{
+			URN:    "urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev",
+			Type:   "pulumi:pulumi:Stack",
+			Custom: false,
+		}},
+	})
+	assert.NoError(t, err)
+	untyped := &apitype.UntypedDeployment{Version: 3, Deployment: deployment}
+
+	dep, err := checkStackDeployment("dev", untyped, false)
+	assert.NoError(t, err)
+	assert.Equal(t, apitype.DeploymentSchemaVersionCurrent, dep.Version)
+
+	// A checkpoint restored into another stack is rejected unless forced, before any stack or configuration file is
+	// created for it.
+	_, err = checkStackDeployment("restored", untyped, false)
+	assert.Error(t, err)
+	_, err = checkStackDeployment("restored", untyped, true)
+	assert.NoError(t, err)
+}
+
+func TestPrintStackBundleProvenance(t *testing.T) {
+	var out bytes.Buffer
+	printStackBundleProvenance(&out, &stackBundle{
+		RedactedKeys: []string{"proj:password"},
+		Source:       map[string]string{backend.GitHead: "abc123", backend.GitDirty: "true"},
+	})
+	assert.Equal(t, `
+These secret configuration values were left out of the bundle and must be set again:
+    proj:password
+
+The bundle was made from commit abc123, with uncommitted changes.
+`, out.String())
+}
@@ -0,0 +1,101 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend"
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newStackFreezeCmd() *cobra.Command {
+	var stack string
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "freeze",
+		Short: "Freeze a stack to prevent changes to its state",
+		Long: "Freeze a stack to prevent changes to its state\n" +
+			"\n" +
+			"A frozen stack cannot be updated, refreshed, or destroyed, and its state cannot be\n" +
+			"imported, edited, renamed, or removed, e.g. during incident response or a change\n" +
+			"freeze. Previews are still allowed. Each command that would change the stack's state\n" +
+			"accepts an `--override-freeze` flag to proceed anyway. The freeze is stored in the\n" +
+			"backend, so it applies to everyone who uses the stack.\n" +
+			"\n" +
+			"Use `pulumi stack unfreeze` to lift the freeze.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(stack, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			if err = backend.UpdateStackFreeze(commandContext(), s, &apitype.StackFreeze{Reason: reason}); err != nil {
+				return err
+			}
+			fmt.Printf("Froze stack '%s'\n", s.Ref())
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVarP(
+		&reason, "reason", "r", "",
+		"Why the stack is frozen. The reason is shown to anyone who tries to change the stack")
+
+	return cmd
+}
+
+func newStackUnfreezeCmd() *cobra.Command {
+	var stack string
+
+	cmd := &cobra.Command{
+		Use:   "unfreeze",
+		Short: "Lift a stack's freeze, allowing changes to its state",
+		Args:  cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(stack, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			if err = backend.UpdateStackFreeze(commandContext(), s, nil); err != nil {
+				return err
+			}
+			fmt.Printf("Unfroze stack '%s'\n", s.Ref())
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
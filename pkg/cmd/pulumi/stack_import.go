@@ -23,10 +23,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 )
 
@@ -34,6 +36,7 @@ func newStackImportCmd() *cobra.Command {
 	var force bool
 	var file string
 	var stackName string
+	var overrideFreeze bool
 	cmd := &cobra.Command{
 		Use:   "import",
 		Args:  cmdutil.MaximumNArgs(0),
@@ -54,7 +57,9 @@ func newStackImportCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			stackName := s.Ref().Name()
+			if err = backend.CheckStackFreeze(commandContext(), s, overrideFreeze); err != nil {
+				return err
+			}
 
 			// Read from stdin or a specified file
 			reader := os.Stdin
@@ -72,73 +77,9 @@ func newStackImportCmd() *cobra.Command {
 				return err
 			}
 
-			// We do, however, now want to unmarshal the json.RawMessage into a real, typed deployment.  We do this so
-			// we can check that the deployment doesn't contain resources from a stack other than the selected one. This
-			// catches errors wherein someone imports the wrong stack's deployment (which can seriously hork things).
-			snapshot, err := stack.DeserializeUntypedDeployment(&deployment, stack.DefaultSecretsProvider)
-			if err != nil {
-				return checkDeploymentVersionError(err, stackName.String())
-			}
-			var result error
-			for _, res := range snapshot.Resources {
-				if res.URN.Stack() != stackName {
-					msg := fmt.Sprintf("resource '%s' is from a different stack (%s != %s)",
-						res.URN, res.URN.Stack(), stackName)
-					if force {
-						// If --force was passed, just issue a warning and proceed anyway.
-						// Note: we could associate this diagnostic with the resource URN
-						// we have.  However, this sort of message seems to be better as
-						// something associated with the stack as a whole.
-						cmdutil.Diag().Warningf(diag.Message("" /*urn*/, msg))
-					} else {
-						// Otherwise, gather up an error so that we can quit before doing damage.
-						result = multierror.Append(result, errors.New(msg))
-					}
-				}
-			}
-			// Validate the stack. If --force was passed, issue an error if validation fails. Otherwise, issue a warning.
-			if err := snapshot.VerifyIntegrity(); err != nil {
-				msg := fmt.Sprintf("state file contains errors: %v", err)
-				if force {
-					cmdutil.Diag().Warningf(diag.Message("", msg))
-				} else {
-					result = multierror.Append(result, errors.New(msg))
-				}
-			}
-			if result != nil {
-				return multierror.Append(result,
-					errors.New("importing this file could be dangerous; rerun with --force to proceed anyway"))
-			}
-
-			// Explicitly clear-out any pending operations.
-			if snapshot.PendingOperations != nil {
-				for _, op := range snapshot.PendingOperations {
-					msg := fmt.Sprintf(
-						"removing pending operation '%s' on '%s' from snapshot", op.Type, op.Resource.URN)
-					cmdutil.Diag().Warningf(diag.Message(op.Resource.URN, msg))
-				}
-
-				snapshot.PendingOperations = nil
-			}
-			sdp, err := stack.SerializeDeployment(snapshot, snapshot.SecretsManager, false /* showSecrets */)
-			if err != nil {
-				return errors.Wrap(err, "constructing deployment for upload")
-			}
-
-			bytes, err := json.Marshal(sdp)
-			if err != nil {
+			if err = importStackDeployment(s, &deployment, force); err != nil {
 				return err
 			}
-
-			dep := apitype.UntypedDeployment{
-				Version:    apitype.DeploymentSchemaVersionCurrent,
-				Deployment: bytes,
-			}
-
-			// Now perform the deployment.
-			if err = s.ImportDeployment(commandContext(), &dep); err != nil {
-				return errors.Wrap(err, "could not import deployment")
-			}
 			fmt.Printf("Import successful.\n")
 			return nil
 		}),
@@ -151,6 +92,93 @@ func newStackImportCmd() *cobra.Command {
 		"Force the import to occur, even if apparent errors are discovered beforehand (not recommended)")
 	cmd.PersistentFlags().StringVarP(
 		&file, "file", "", "", "A filename to read stack input from")
+	cmd.PersistentFlags().BoolVar(
+		&overrideFreeze, "override-freeze", false,
+		"Import the deployment even if the stack is frozen")
 
 	return cmd
 }
+
+// importStackDeployment replaces the given stack's deployment with the given one after checking it with
+// checkStackDeployment.
+func importStackDeployment(s backend.Stack, deployment *apitype.UntypedDeployment, force bool) error {
+	dep, err := checkStackDeployment(s.Ref().Name(), deployment, force)
+	if err != nil {
+		return err
+	}
+	if err = s.ImportDeployment(commandContext(), dep); err != nil {
+		return errors.Wrap(err, "could not import deployment")
+	}
+	return nil
+}
+
+// checkStackDeployment checks that the given deployment can be imported into the stack with the given name, and
+// returns the deployment to import. The deployment is checked for resources from other stacks and for integrity errors;
+// if force is true, these are reported as warnings rather than errors. Any pending operations in the deployment are
+// removed. Nothing is written, so callers may check a deployment before creating the stack it is imported into.
+func checkStackDeployment(stackName tokens.QName, deployment *apitype.UntypedDeployment,
+	force bool) (*apitype.UntypedDeployment, error) {
+
+	// We do, however, now want to unmarshal the json.RawMessage into a real, typed deployment.  We do this so
+	// we can check that the deployment doesn't contain resources from a stack other than the selected one. This
+	// catches errors wherein someone imports the wrong stack's deployment (which can seriously hork things).
+	snapshot, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+	if err != nil {
+		return nil, checkDeploymentVersionError(err, stackName.String())
+	}
+	var result error
+	for _, res := range snapshot.Resources {
+		if res.URN.Stack() != stackName {
+			msg := fmt.Sprintf("resource '%s' is from a different stack (%s != %s)",
+				res.URN, res.URN.Stack(), stackName)
+			if force {
+				// If --force was passed, just issue a warning and proceed anyway.
+				// Note: we could associate this diagnostic with the resource URN
+				// we have.  However, this sort of message seems to be better as
+				// something associated with the stack as a whole.
+				cmdutil.Diag().Warningf(diag.Message("" /*urn*/, msg))
+			} else {
+				// Otherwise, gather up an error so that we can quit before doing damage.
+				result = multierror.Append(result, errors.New(msg))
+			}
+		}
+	}
+	// Validate the stack. If --force was passed, issue an error if validation fails. Otherwise, issue a warning.
+	if err := snapshot.VerifyIntegrity(); err != nil {
+		msg := fmt.Sprintf("state file contains errors: %v", err)
+		if force {
+			cmdutil.Diag().Warningf(diag.Message("", msg))
+		} else {
+			result = multierror.Append(result, errors.New(msg))
+		}
+	}
+	if result != nil {
+		return nil, multierror.Append(result,
+			errors.New("importing this file could be dangerous; rerun with --force to proceed anyway"))
+	}
+
+	// Explicitly clear-out any pending operations.
+	if snapshot.PendingOperations != nil {
+		for _, op := range snapshot.PendingOperations {
+			msg := fmt.Sprintf(
+				"removing pending operation '%s' on '%s' from snapshot", op.Type, op.Resource.URN)
+			cmdutil.Diag().Warningf(diag.Message(op.Resource.URN, msg))
+		}
+
+		snapshot.PendingOperations = nil
+	}
+	sdp, err := stack.SerializeDeployment(snapshot, snapshot.SecretsManager, false /* showSecrets */)
+	if err != nil {
+		return nil, errors.Wrap(err, "constructing deployment for upload")
+	}
+
+	bytes, err := json.Marshal(sdp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: bytes,
+	}, nil
+}
@@ -16,13 +16,17 @@ package main
 
 import (
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 )
@@ -31,6 +35,8 @@ func newStackOutputCmd() *cobra.Command {
 	var jsonOut bool
 	var showSecrets bool
 	var stackName string
+	var follow bool
+	var interval time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "output [property-name]",
@@ -39,7 +45,11 @@ func newStackOutputCmd() *cobra.Command {
 		Long: "Show a stack's output properties.\n" +
 			"\n" +
 			"By default, this command lists all output properties exported from a stack.\n" +
-			"If a specific property-name is supplied, just that property's value is shown.",
+			"If a specific property-name is supplied, just that property's value is shown.\n" +
+			"\n" +
+			"With --follow, the command keeps running after printing the outputs, and prints them again\n" +
+			"each time an update of the stack changes them. If a property-name is supplied and the stack\n" +
+			"does not have that output yet, the command waits for an update to add it.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -50,6 +60,15 @@ func newStackOutputCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+
+			if follow {
+				var name string
+				if len(args) > 0 {
+					name = args[0]
+				}
+				return followStackOutputs(s, name, jsonOut, showSecrets, interval)
+			}
+
 			snap, err := s.Snapshot(commandContext())
 			if err != nil {
 				return err
@@ -95,6 +114,10 @@ func newStackOutputCmd() *cobra.Command {
 		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
 	cmd.PersistentFlags().BoolVar(
 		&showSecrets, "show-secrets", false, "Display outputs which are marked as secret in plaintext")
+	cmd.PersistentFlags().BoolVarP(
+		&follow, "follow", "f", false, "Keep running and print the outputs again whenever they change")
+	cmd.PersistentFlags().DurationVar(
+		&interval, "interval", 10*time.Second, "How often to check for changed outputs when following")
 
 	return cmd
 }
@@ -109,9 +132,87 @@ func getStackOutputs(snap *deploy.Snapshot, showSecrets bool) (map[string]interf
 		return map[string]interface{}{}, nil
 	}
 
+	// The list of the stack's internal outputs is bookkeeping for stack references rather than an output of its own.
+	// The internal outputs themselves are shown, as they are visible to the stack's owners.
+	outputs := resource.PropertyMap{}
+	for k, v := range state.Outputs {
+		if k != resource.InternalStackOutputsKey {
+			outputs[k] = v
+		}
+	}
+
 	// massageSecrets will remove all the secrets from the property map, so it should be safe to pass a panic
 	// crypter. This also ensure that if for some reason we didn't remove everything, we don't accidentally disclose
 	// secret values!
-	return stack.SerializeProperties(display.MassageSecrets(state.Outputs, showSecrets),
+	return stack.SerializeProperties(display.MassageSecrets(outputs, showSecrets),
 		config.NewPanicCrypter(), showSecrets)
 }
+
+// followStackOutputs prints a stack's outputs, or just the named output if name is non-empty, and then prints them
+// again each time they change. Neither backend can notify us when an update completes, so we poll: each poll fetches
+// a fresh copy of the stack, as the snapshot of a backend.Stack is read only once.
+func followStackOutputs(s backend.Stack, name string, jsonOut, showSecrets bool, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+
+	var last interface{}
+	first, waiting := true, false
+	for {
+		current, err := s.Backend().GetStack(commandContext(), s.Ref())
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return errors.Errorf("stack '%v' no longer exists", s.Ref())
+		}
+		snap, err := current.Snapshot(commandContext())
+		if err != nil {
+			return err
+		}
+		outputs, err := getStackOutputs(snap, showSecrets)
+		if err != nil {
+			return errors.Wrap(err, "getting outputs")
+		}
+		if outputs == nil {
+			outputs = make(map[string]interface{})
+		}
+
+		var value interface{} = outputs
+		has := true
+		if name != "" {
+			value, has = outputs[name]
+		}
+
+		switch {
+		case !has:
+			if !waiting && !jsonOut {
+				fmt.Printf("Waiting for stack '%v' to have an output property '%v'...\n", s.Ref(), name)
+			}
+			waiting = true
+		case first || waiting || !reflect.DeepEqual(value, last):
+			if !first && !jsonOut {
+				fmt.Printf("\nOutputs changed at %v:\n", time.Now().Format(timeFormat))
+			}
+			if err := printFollowedOutputs(value, name, jsonOut); err != nil {
+				return err
+			}
+			last, waiting = value, false
+		}
+		first = false
+
+		time.Sleep(interval)
+	}
+}
+
+func printFollowedOutputs(value interface{}, name string, jsonOut bool) error {
+	switch {
+	case jsonOut:
+		return printJSON(value)
+	case name != "":
+		fmt.Printf("%v\n", stringifyOutput(value))
+	default:
+		printStackOutputs(value.(map[string]interface{}))
+	}
+	return nil
+}
@@ -18,6 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 )
 
 func TestStringifyOutput(t *testing.T) {
@@ -36,3 +39,23 @@ func TestStringifyOutput(t *testing.T) {
 	assert.Equal(t, "[\"hello\",\"goodbye\"]", stringifyOutput(arr))
 	assert.Equal(t, "{\"bar\":{\"baz\":true},\"foo\":42}", stringifyOutput(obj))
 }
+
+func TestGetStackOutputsOmitsInternalOutputList(t *testing.T) {
+	urn := resource.NewURN("dev", "proj", "", resource.RootStackType, "proj-dev")
+	root := resource.NewState(resource.RootStackType, urn, false, false, "", resource.PropertyMap{},
+		resource.PropertyMap{
+			"endpoint": resource.NewStringProperty("db.example.com"),
+			"password": resource.NewStringProperty("hunter2"),
+			resource.InternalStackOutputsKey: resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewStringProperty("password"),
+			}),
+		}, "", false, false, nil, nil, "", nil, false, nil, nil, nil, "")
+	snap := deploy.NewSnapshot(deploy.Manifest{}, nil, []*resource.State{root}, nil)
+
+	outputs, err := getStackOutputs(snap, false)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "db.example.com",
+		"password": "hunter2",
+	}, outputs)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/backend/state"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
@@ -32,6 +33,7 @@ import (
 
 func newStackRenameCmd() *cobra.Command {
 	var stack string
+	var overrideFreeze bool
 	var cmd = &cobra.Command{
 		Use:   "rename <new-stack-name>",
 		Args:  cmdutil.ExactArgs(1),
@@ -55,6 +57,9 @@ func newStackRenameCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err = backend.CheckStackFreeze(commandContext(), s, overrideFreeze); err != nil {
+				return err
+			}
 
 			oldConfigPath, err := workspace.DetectProjectStackPath(s.Ref().Name())
 			if err != nil {
@@ -96,5 +101,8 @@ func newStackRenameCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVar(
+		&overrideFreeze, "override-freeze", false,
+		"Rename the stack even if it is frozen")
 	return cmd
 }
@@ -0,0 +1,290 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v2/backend/display"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+)
+
+func newStackResourcesCmd() *cobra.Command {
+	var stackName string
+	var types []string
+	var urns []string
+	var where []string
+	var columns []string
+	var jsonOut bool
+	var showSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "resources",
+		Args:  cmdutil.NoArgs,
+		Short: "List and search a stack's resources",
+		Long: "List and search a stack's resources\n" +
+			"\n" +
+			"This command lists the resources in the stack's current state. The list can be narrowed with\n" +
+			"--type and --urn, which accept globs where '*' matches any sequence of characters, and with --where,\n" +
+			"which tests a resource's output properties. A --where predicate is a property path followed by\n" +
+			"'=value' or '!=value', where the value is also a glob, or a bare property path, which requires the\n" +
+			"property to be present. For example:\n" +
+			"\n" +
+			"    pulumi stack resources --type 'aws:ec2/*' --where 'tags.env=prod' --columns urn,id,instanceType\n" +
+			"\n" +
+			"Resources must match every predicate and, when given, one of the types and one of the URNs.\n" +
+			"\n" +
+			"The columns to show are chosen with --columns. The columns 'urn', 'type', 'name', 'id', 'parent',\n" +
+			"'provider' and 'protect' show the resource's metadata; any other column is a path to an output property.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			filter, err := newResourceFilter(types, urns, where)
+			if err != nil {
+				return err
+			}
+			cols, err := parseResourceColumns(columns)
+			if err != nil {
+				return err
+			}
+
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+
+			var matches []*resource.State
+			if snap != nil {
+				for _, res := range snap.Resources {
+					if filter.matches(res, showSecrets) {
+						matches = append(matches, res)
+					}
+				}
+			}
+
+			if jsonOut {
+				rows := make([]map[string]interface{}, len(matches))
+				for i, res := range matches {
+					row := make(map[string]interface{})
+					for _, col := range cols {
+						if v, ok := col.value(res, showSecrets); ok {
+							row[col.name] = v
+						}
+					}
+					rows[i] = row
+				}
+				return printJSON(rows)
+			}
+
+			if len(matches) == 0 {
+				fmt.Printf("No matching resources\n")
+				return nil
+			}
+			headers := make([]string, len(cols))
+			for i, col := range cols {
+				headers[i] = strings.ToUpper(col.name)
+			}
+			rows := make([]cmdutil.TableRow, len(matches))
+			for i, res := range matches {
+				columns := make([]string, len(cols))
+				for j, col := range cols {
+					if v, ok := col.value(res, showSecrets); ok {
+						columns[j] = stringifyOutput(v)
+					}
+				}
+				rows[i] = cmdutil.TableRow{Columns: columns}
+			}
+			cmdutil.PrintTable(cmdutil.Table{Headers: headers, Rows: rows})
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().StringSliceVarP(
+		&types, "type", "t", nil, "Only list resources whose type matches one of these globs")
+	cmd.Flags().StringSliceVarP(
+		&urns, "urn", "u", nil, "Only list resources whose URN matches one of these globs")
+	cmd.Flags().StringArrayVarP(
+		&where, "where", "w", nil, "Only list resources whose output properties satisfy this predicate")
+	cmd.Flags().StringSliceVarP(
+		&columns, "columns", "c", []string{"type", "name", "id"}, "The columns to show for each resource")
+	cmd.Flags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit output as JSON")
+	cmd.Flags().BoolVar(
+		&showSecrets, "show-secrets", false, "Display and match properties which are marked as secret in plaintext")
+
+	return cmd
+}
+
+// compileGlob converts a glob, in which '*' matches any sequence of characters and '?' any single character, into a
+// regular expression that matches the whole of a string.
+func compileGlob(glob string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(glob)
+	pattern = strings.Replace(pattern, `\*`, ".*", -1)
+	pattern = strings.Replace(pattern, `\?`, ".", -1)
+	return regexp.MustCompile("^" + pattern + "$")
+}
+
+func matchesAnyGlob(globs []*regexp.Regexp, s string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	for _, glob := range globs {
+		if glob.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// propertyPredicate tests a single output property of a resource.
+type propertyPredicate struct {
+	path   resource.PropertyPath
+	value  *regexp.Regexp // nil if the predicate only requires that the property is present.
+	negate bool
+}
+
+func parsePropertyPredicate(s string) (propertyPredicate, error) {
+	var pred propertyPredicate
+	path := s
+	if i := strings.Index(s, "="); i != -1 {
+		path, pred.value = s[:i], compileGlob(s[i+1:])
+		if strings.HasSuffix(path, "!") {
+			path, pred.negate = path[:len(path)-1], true
+		}
+	}
+
+	p, err := resource.ParsePropertyPath(path)
+	if err != nil || path == "" {
+		return propertyPredicate{}, errors.Errorf("invalid predicate '%v': expected a property path, optionally "+
+			"followed by '=value' or '!=value'", s)
+	}
+	pred.path = p
+	return pred, nil
+}
+
+func (pred propertyPredicate) matches(outputs resource.PropertyValue) bool {
+	v, ok := pred.path.Get(outputs)
+	if !ok || v.IsNull() {
+		// A missing property can never equal a value, so it always satisfies a '!=' predicate.
+		return pred.negate
+	}
+	if pred.value == nil {
+		return true
+	}
+	return pred.value.MatchString(stringifyOutput(v.Mappable())) != pred.negate
+}
+
+// resourceFilter selects the resources listed by `pulumi stack resources`.
+type resourceFilter struct {
+	types      []*regexp.Regexp
+	urns       []*regexp.Regexp
+	predicates []propertyPredicate
+}
+
+func newResourceFilter(types, urns, where []string) (*resourceFilter, error) {
+	var filter resourceFilter
+	for _, t := range types {
+		filter.types = append(filter.types, compileGlob(t))
+	}
+	for _, u := range urns {
+		filter.urns = append(filter.urns, compileGlob(u))
+	}
+	for _, w := range where {
+		pred, err := parsePropertyPredicate(w)
+		if err != nil {
+			return nil, err
+		}
+		filter.predicates = append(filter.predicates, pred)
+	}
+	return &filter, nil
+}
+
+func (f *resourceFilter) matches(res *resource.State, showSecrets bool) bool {
+	if !matchesAnyGlob(f.types, string(res.Type)) || !matchesAnyGlob(f.urns, string(res.URN)) {
+		return false
+	}
+	if len(f.predicates) == 0 {
+		return true
+	}
+
+	// Secret values are masked before they are tested so that predicates cannot be used to discover them.
+	outputs := resource.NewObjectProperty(display.MassageSecrets(res.Outputs, showSecrets))
+	for _, pred := range f.predicates {
+		if !pred.matches(outputs) {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceColumn is a column in the output of `pulumi stack resources`: either a piece of resource metadata or the
+// value of an output property.
+type resourceColumn struct {
+	name string
+	path resource.PropertyPath // nil for metadata columns.
+}
+
+var resourceMetadataColumns = map[string]func(res *resource.State) interface{}{
+	"urn":      func(res *resource.State) interface{} { return string(res.URN) },
+	"type":     func(res *resource.State) interface{} { return string(res.Type) },
+	"name":     func(res *resource.State) interface{} { return string(res.URN.Name()) },
+	"id":       func(res *resource.State) interface{} { return string(res.ID) },
+	"parent":   func(res *resource.State) interface{} { return string(res.Parent) },
+	"provider": func(res *resource.State) interface{} { return res.Provider },
+	"protect":  func(res *resource.State) interface{} { return res.Protect },
+}
+
+func parseResourceColumns(names []string) ([]resourceColumn, error) {
+	var cols []resourceColumn
+	for _, name := range names {
+		if _, ok := resourceMetadataColumns[name]; ok {
+			cols = append(cols, resourceColumn{name: name})
+			continue
+		}
+		path, err := resource.ParsePropertyPath(name)
+		if err != nil || name == "" {
+			return nil, errors.Errorf("invalid column '%v': expected a property path or one of urn, type, name, id, "+
+				"parent, provider or protect", name)
+		}
+		cols = append(cols, resourceColumn{name: name, path: path})
+	}
+	return cols, nil
+}
+
+// value returns the contents of the column for the given resource, or false if the resource has no such property.
+func (col resourceColumn) value(res *resource.State, showSecrets bool) (interface{}, bool) {
+	if col.path == nil {
+		return resourceMetadataColumns[col.name](res), true
+	}
+	outputs := resource.NewObjectProperty(display.MassageSecrets(res.Outputs, showSecrets))
+	v, ok := col.path.Get(outputs)
+	if !ok || v.IsNull() {
+		return nil, false
+	}
+	return v.Mappable(), true
+}
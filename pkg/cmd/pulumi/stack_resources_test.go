@@ -0,0 +1,102 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func newTestResourceState(typ, name string, outputs resource.PropertyMap) *resource.State {
+	urn := resource.NewURN("dev", "proj", "", tokens.Type(typ), tokens.QName(name))
+	return &resource.State{Type: tokens.Type(typ), URN: urn, Custom: true, ID: resource.ID(name + "-id"),
+		Outputs: outputs}
+}
+
+func TestResourceFilter(t *testing.T) {
+	web := newTestResourceState("aws:ec2/instance:Instance", "web", resource.NewPropertyMapFromMap(
+		map[string]interface{}{
+			"instanceType": "t2.micro",
+			"tags":         map[string]interface{}{"env": "prod"},
+		}))
+	web.Outputs["password"] = resource.MakeSecret(resource.NewStringProperty("hunter2"))
+	bucket := newTestResourceState("aws:s3/bucket:Bucket", "logs", resource.NewPropertyMapFromMap(
+		map[string]interface{}{
+			"tags": map[string]interface{}{"env": "dev"},
+		}))
+
+	tests := []struct {
+		types, urns, where []string
+		showSecrets        bool
+		want               []*resource.State
+	}{
+		{want: []*resource.State{web, bucket}},
+		{types: []string{"aws:ec2/*"}, want: []*resource.State{web}},
+		{types: []string{"aws:ec2/*", "*:Bucket"}, want: []*resource.State{web, bucket}},
+		{urns: []string{"*::logs"}, want: []*resource.State{bucket}},
+		{where: []string{"tags.env=prod"}, want: []*resource.State{web}},
+		{where: []string{"tags.env!=prod"}, want: []*resource.State{bucket}},
+		{where: []string{"tags.env=*"}, want: []*resource.State{web, bucket}},
+		{where: []string{"instanceType"}, want: []*resource.State{web}},
+		{where: []string{"instanceType!=t2.*"}, want: []*resource.State{bucket}},
+		{where: []string{"tags.env=prod", "instanceType=t3.*"}, want: nil},
+		{where: []string{"password=hunter2"}, want: nil},
+		{where: []string{"password=hunter2"}, showSecrets: true, want: []*resource.State{web}},
+	}
+	for _, test := range tests {
+		filter, err := newResourceFilter(test.types, test.urns, test.where)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		var got []*resource.State
+		for _, res := range []*resource.State{web, bucket} {
+			if filter.matches(res, test.showSecrets) {
+				got = append(got, res)
+			}
+		}
+		assert.Equal(t, test.want, got, "types=%v urns=%v where=%v", test.types, test.urns, test.where)
+	}
+
+	_, err := newResourceFilter(nil, nil, []string{"=prod"})
+	assert.Error(t, err)
+}
+
+func TestResourceColumns(t *testing.T) {
+	res := newTestResourceState("aws:ec2/instance:Instance", "web", resource.NewPropertyMapFromMap(
+		map[string]interface{}{
+			"ports": []interface{}{80, 443},
+		}))
+	res.Outputs["password"] = resource.MakeSecret(resource.NewStringProperty("hunter2"))
+
+	cols, err := parseResourceColumns([]string{"name", "id", "ports", "ports[1]", "password", "missing"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var got []string
+	for _, col := range cols {
+		v, ok := col.value(res, false)
+		if !ok {
+			got = append(got, "<none>")
+			continue
+		}
+		got = append(got, stringifyOutput(v))
+	}
+	assert.Equal(t, []string{"web", "web-id", "[80,443]", "443", "[secret]", "<none>"}, got)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/backend/state"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
@@ -35,6 +36,7 @@ func newStackRmCmd() *cobra.Command {
 	var yes bool
 	var force bool
 	var preserveConfig bool
+	var overrideFreeze bool
 	var cmd = &cobra.Command{
 		Use:   "rm [<stack-name>]",
 		Args:  cmdutil.MaximumNArgs(1),
@@ -45,6 +47,7 @@ func newStackRmCmd() *cobra.Command {
 			"`destroy` command for removing a resources, as this is a distinct operation.\n" +
 			"\n" +
 			"After this command completes, the stack will no longer be available for updates.",
+		ValidArgsFunction: completeFirstArg(completeStackNames),
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			yes = yes || skipConfirmations()
 			// Use the stack provided or, if missing, default to the current one.
@@ -63,6 +66,9 @@ func newStackRmCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			if err = backend.CheckStackFreeze(commandContext(), s, overrideFreeze); err != nil {
+				return result.FromError(err)
+			}
 
 			// Ensure the user really wants to do this.
 			prompt := fmt.Sprintf("This will permanently remove the '%s' stack!", s.Ref())
@@ -109,6 +115,9 @@ func newStackRmCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&preserveConfig, "preserve-config", false,
 		"Do not delete the corresponding Pulumi.<stack-name>.yaml configuration file for the stack")
+	cmd.PersistentFlags().BoolVar(
+		&overrideFreeze, "override-freeze", false,
+		"Remove the stack even if it is frozen")
 
 	return cmd
 }
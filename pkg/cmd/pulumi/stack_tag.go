@@ -138,6 +138,16 @@ func printStackTags(tags map[apitype.StackTagName]string) {
 	})
 }
 
+// checkUserStackTag returns an error if the named tag cannot be set or removed with `pulumi stack tag`. The tag that
+// freezes a stack is managed by `pulumi stack freeze` and `pulumi stack unfreeze` alone, so that a freeze cannot be
+// lifted or changed as a side effect of editing tags.
+func checkUserStackTag(name apitype.StackTagName) error {
+	if name == apitype.StackFrozenTag {
+		return errors.Errorf("stack tag '%s' is managed by `pulumi stack freeze` and `pulumi stack unfreeze`", name)
+	}
+	return nil
+}
+
 func newStackTagRmCmd(stack *string) *cobra.Command {
 	return &cobra.Command{
 		Use:   "rm <name>",
@@ -145,6 +155,9 @@ func newStackTagRmCmd(stack *string) *cobra.Command {
 		Args:  cmdutil.SpecificArgs([]string{"name"}),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			name := args[0]
+			if err := checkUserStackTag(name); err != nil {
+				return err
+			}
 
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -176,6 +189,9 @@ func newStackTagSetCmd(stack *string) *cobra.Command {
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 			value := args[1]
+			if err := checkUserStackTag(name); err != nil {
+				return err
+			}
 
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
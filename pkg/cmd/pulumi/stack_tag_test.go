@@ -0,0 +1,29 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/apitype"
+)
+
+func TestCheckUserStackTag(t *testing.T) {
+	assert.NoError(t, checkUserStackTag("owner"))
+	assert.NoError(t, checkUserStackTag(apitype.GitHubOwnerNameTag))
+	assert.Error(t, checkUserStackTag(apitype.StackFrozenTag))
+}
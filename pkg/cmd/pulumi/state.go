@@ -23,6 +23,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 
 	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/v2/backend"
 	"github.com/pulumi/pulumi/pkg/v2/backend/display"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/resource/edit"
@@ -113,21 +114,25 @@ func locateStackResource(opts display.Options, snap *deploy.Snapshot, urn resour
 }
 
 // runStateEdit runs the given state edit function on a resource with the given URN in a given stack.
-func runStateEdit(stackName string, showPrompt bool, urn resource.URN, operation edit.OperationFunc) result.Result {
-	return runTotalStateEdit(stackName, showPrompt, func(opts display.Options, snap *deploy.Snapshot) error {
+func runStateEdit(stackName string, showPrompt, overrideFreeze bool, urn resource.URN,
+	operation edit.OperationFunc) result.Result {
+
+	editResource := func(opts display.Options, snap *deploy.Snapshot) error {
 		res, err := locateStackResource(opts, snap, urn)
 		if err != nil {
 			return err
 		}
 
 		return operation(snap, res)
-	})
+	}
+	return runTotalStateEdit(stackName, showPrompt, overrideFreeze, editResource)
 }
 
 // runTotalStateEdit runs a snapshot-mutating function on the entirety of the given stack's snapshot.
-// Before mutating, the user may be prompted to for confirmation if the current session is interactive.
+// Before mutating, the user may be prompted to for confirmation if the current session is interactive. Frozen stacks
+// are not edited unless overrideFreeze is true.
 func runTotalStateEdit(
-	stackName string, showPrompt bool,
+	stackName string, showPrompt, overrideFreeze bool,
 	operation func(opts display.Options, snap *deploy.Snapshot) error) result.Result {
 	opts := display.Options{
 		Color: cmdutil.GetGlobalColorization(),
@@ -136,6 +141,9 @@ func runTotalStateEdit(
 	if err != nil {
 		return result.FromError(err)
 	}
+	if err = backend.CheckStackFreeze(commandContext(), s, overrideFreeze); err != nil {
+		return result.FromError(err)
+	}
 	snap, err := s.Snapshot(commandContext())
 	if err != nil {
 		return result.FromError(err)
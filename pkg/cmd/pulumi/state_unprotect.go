@@ -32,6 +32,7 @@ func newStateUnprotectCommand() *cobra.Command {
 	var unprotectAll bool
 	var stack string
 	var yes bool
+	var overrideFreeze bool
 
 	cmd := &cobra.Command{
 		Use:   "unprotect <resource URN>",
@@ -39,14 +40,15 @@ func newStateUnprotectCommand() *cobra.Command {
 		Long: `Unprotect resource in a stack's state
 
 This command clears the 'protect' bit on one or more resources, allowing those resources to be deleted.`,
-		Args: cmdutil.MaximumNArgs(1),
+		Args:              cmdutil.MaximumNArgs(1),
+		ValidArgsFunction: completeFirstArg(completeResourceURNs),
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			yes = yes || skipConfirmations()
 			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
 			showPrompt := !yes
 
 			if unprotectAll {
-				return unprotectAllResources(stack, showPrompt)
+				return unprotectAllResources(stack, showPrompt, overrideFreeze)
 			}
 
 			if len(args) != 1 {
@@ -54,7 +56,7 @@ This command clears the 'protect' bit on one or more resources, allowing those r
 			}
 
 			urn := resource.URN(args[0])
-			return unprotectResource(stack, urn, showPrompt)
+			return unprotectResource(stack, urn, showPrompt, overrideFreeze)
 		}),
 	}
 
@@ -63,12 +65,13 @@ This command clears the 'protect' bit on one or more resources, allowing those r
 		"The name of the stack to operate on. Defaults to the current stack")
 	cmd.Flags().BoolVar(&unprotectAll, "all", false, "Unprotect all resources in the checkpoint")
 	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	cmd.Flags().BoolVar(&overrideFreeze, "override-freeze", false, "Unprotect resources even if the stack is frozen")
 
 	return cmd
 }
 
-func unprotectAllResources(stackName string, showPrompt bool) result.Result {
-	res := runTotalStateEdit(stackName, showPrompt, func(_ display.Options, snap *deploy.Snapshot) error {
+func unprotectAllResources(stackName string, showPrompt, overrideFreeze bool) result.Result {
+	res := runTotalStateEdit(stackName, showPrompt, overrideFreeze, func(_ display.Options, snap *deploy.Snapshot) error {
 		// Protects against Panic when a user tries to unprotect non-existing resources
 		if snap == nil {
 			return fmt.Errorf("no resources found to unprotect")
@@ -89,8 +92,8 @@ func unprotectAllResources(stackName string, showPrompt bool) result.Result {
 	return nil
 }
 
-func unprotectResource(stackName string, urn resource.URN, showPrompt bool) result.Result {
-	res := runStateEdit(stackName, showPrompt, urn, edit.UnprotectResource)
+func unprotectResource(stackName string, urn resource.URN, showPrompt, overrideFreeze bool) result.Result {
+	res := runStateEdit(stackName, showPrompt, overrideFreeze, urn, edit.UnprotectResource)
 	if res != nil {
 		return res
 	}
@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/v2/backend"
@@ -46,6 +47,7 @@ const (
 func newUpCmd() *cobra.Command {
 	var debug bool
 	var expectNop bool
+	var detailedExitCode bool
 	var message string
 	var stack string
 	var configArray []string
@@ -70,6 +72,17 @@ func newUpCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var allowReplace []string
+	var confirmStack string
+	var overrideFreeze bool
+	var holdAfter int
+	var holdBefore []string
+	var holdTimeout time.Duration
+	var holdContinue bool
+
+	// The hold points requested by the --hold-* flags, and what approves the update past them.
+	var holds []deploy.HoldPoint
+	var holdApprover deploy.HoldApprover
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
 	upWorkingDirectory := func(opts backend.UpdateOptions) result.Result {
@@ -88,6 +101,10 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(err)
 		}
 
+		if err = ensureRuntimeEnv(proj, root); err != nil {
+			return result.FromError(err)
+		}
+
 		m, err := getUpdateMetadata(message, root)
 		if err != nil {
 			return result.FromError(errors.Wrap(err, "gathering environment metadata"))
@@ -128,8 +145,13 @@ func newUpCmd() *cobra.Command {
 			UseLegacyDiff:    useLegacyDiff(),
 			UpdateTargets:    targetURNs,
 			TargetDependents: targetDependents,
+
+			ProtectedReplaceTypes: protectedReplaceTypes(proj, allowReplace),
+			Holds:                 holds,
+			HoldApprover:          holdApprover,
 		}
 
+		outcome := newOperationOutcome(false, expectNop, detailedExitCode)
 		changes, res := s.Update(commandContext(), backend.UpdateOperation{
 			Proj:               proj,
 			Root:               root,
@@ -138,17 +160,13 @@ func newUpCmd() *cobra.Command {
 			StackConfiguration: cfg,
 			SecretsManager:     sm,
 			Scopes:             cancellationScopes,
+			Events:             outcome.events,
 		})
-		switch {
-		case res != nil && res.Error() == context.Canceled:
+		outcome.close()
+		if res != nil && res.Error() == context.Canceled {
 			return result.FromError(errors.New("update cancelled"))
-		case res != nil:
-			return PrintEngineResult(res)
-		case expectNop && changes != nil && changes.HasChanges():
-			return result.FromError(errors.New("error: no changes were expected but changes occurred"))
-		default:
-			return nil
 		}
+		return outcome.result(changes, res)
 	}
 
 	// up implementation used when the source of the Pulumi program is a template name or a URL to a template.
@@ -285,6 +303,10 @@ func newUpCmd() *cobra.Command {
 			Parallel:         parallel,
 			Debug:            debug,
 			Refresh:          refresh,
+
+			ProtectedReplaceTypes: protectedReplaceTypes(proj, allowReplace),
+			Holds:                 holds,
+			HoldApprover:          holdApprover,
 		}
 
 		// TODO for the URL case:
@@ -292,6 +314,7 @@ func newUpCmd() *cobra.Command {
 		// - attempt `destroy` on any update errors.
 		// - show template.Quickstart?
 
+		outcome := newOperationOutcome(false, expectNop, detailedExitCode)
 		changes, res := s.Update(commandContext(), backend.UpdateOperation{
 			Proj:               proj,
 			Root:               root,
@@ -300,17 +323,13 @@ func newUpCmd() *cobra.Command {
 			StackConfiguration: cfg,
 			SecretsManager:     sm,
 			Scopes:             cancellationScopes,
+			Events:             outcome.events,
 		})
-		switch {
-		case res != nil && res.Error() == context.Canceled:
+		outcome.close()
+		if res != nil && res.Error() == context.Canceled {
 			return result.FromError(errors.New("update cancelled"))
-		case res != nil:
-			return PrintEngineResult(res)
-		case expectNop && changes != nil && changes.HasChanges():
-			return result.FromError(errors.New("error: no changes were expected but changes occurred"))
-		default:
-			return nil
 		}
+		return outcome.result(changes, res)
 	}
 
 	var cmd = &cobra.Command{
@@ -328,7 +347,13 @@ func newUpCmd() *cobra.Command {
 			"afterwards so that the stack may be updated incrementally again later on.\n" +
 			"\n" +
 			"The program to run is loaded from the project in the current directory by default. Use the `-C` or\n" +
-			"`--cwd` flag to use a different directory.",
+			"`--cwd` flag to use a different directory.\n" +
+			"\n" +
+			"Use `--hold-after` or `--hold-before` to pause the update partway through, for example to check a\n" +
+			"canary before the rest of the stack changes. While held, type 'yes' to continue or 'no' to stop the\n" +
+			"update. Use `--hold-timeout` to fail the update if it is not approved in time, or pass\n" +
+			"`--hold-continue` as well to continue once the timeout elapses instead, which allows holds in\n" +
+			"non-interactive mode." + exitCodesHelp,
 		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			yes = yes || skipConfirmations()
@@ -342,6 +367,16 @@ func newUpCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			opts.ConfirmStackName = confirmStack
+			opts.OverrideFreeze = overrideFreeze
+
+			if interactive {
+				holdApprover = newLineHoldApprover(os.Stdin)
+			}
+			holds, err = makeHoldPoints(holdAfter, holdBefore, holdTimeout, holdContinue, holdApprover != nil)
+			if err != nil {
+				return result.FromError(err)
+			}
 
 			if err = validatePolicyPackConfig(policyPackPaths, policyPackConfigPaths); err != nil {
 				return result.FromError(err)
@@ -379,6 +414,9 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&expectNop, "expect-no-changes", false,
 		"Return an error if any changes occur during this update")
+	cmd.PersistentFlags().BoolVar(
+		&detailedExitCode, "detailed-exit-code", false,
+		"Exit with a code that says whether this update changed the stack")
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
@@ -454,6 +492,27 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the update after previewing it")
+	cmd.PersistentFlags().StringArrayVar(
+		&allowReplace, "allow-replace", nil,
+		"Allow resources of this type to be replaced, if the project only allows it explicitly")
+	cmd.PersistentFlags().StringVar(
+		&confirmStack, "confirm-stack", "",
+		"Confirm the update by giving the stack's name, if the project requires it to be typed")
+	cmd.PersistentFlags().BoolVar(
+		&overrideFreeze, "override-freeze", false,
+		"Perform the update even if the stack is frozen")
+	cmd.PersistentFlags().IntVar(
+		&holdAfter, "hold-after", 0,
+		"Hold the update for approval once this many resources have changed")
+	cmd.PersistentFlags().StringArrayVar(
+		&holdBefore, "hold-before", nil,
+		"Hold the update for approval before the resource with this URN changes")
+	cmd.PersistentFlags().DurationVar(
+		&holdTimeout, "hold-timeout", 0,
+		"Fail the update if a hold is not approved within this duration (e.g. 10m)")
+	cmd.PersistentFlags().BoolVar(
+		&holdContinue, "hold-continue", false,
+		"Continue the update rather than failing it when a hold's --hold-timeout elapses")
 
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
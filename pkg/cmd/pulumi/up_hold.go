@@ -0,0 +1,121 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// makeHoldPoints builds the hold points requested by the --hold-* flags of `pulumi up`. Without an approver, each hold
+// point must have a timeout.
+func makeHoldPoints(after int, before []string, timeout time.Duration, continueOnTimeout,
+	approvable bool) ([]deploy.HoldPoint, error) {
+
+	if after < 0 {
+		return nil, errors.New("--hold-after must not be negative")
+	}
+	if timeout < 0 {
+		return nil, errors.New("--hold-timeout must not be negative")
+	}
+	if after == 0 && len(before) == 0 {
+		if timeout != 0 || continueOnTimeout {
+			return nil, errors.New("--hold-timeout and --hold-continue require --hold-after or --hold-before")
+		}
+		return nil, nil
+	}
+	if continueOnTimeout && timeout == 0 {
+		return nil, errors.New("--hold-continue requires --hold-timeout")
+	}
+	if !approvable && timeout == 0 {
+		return nil, errors.New("--hold-timeout must be passed to hold an update in non-interactive mode")
+	}
+
+	var holds []deploy.HoldPoint
+	if after > 0 {
+		holds = append(holds, deploy.HoldPoint{
+			AfterChanges:      after,
+			Timeout:           timeout,
+			ContinueOnTimeout: continueOnTimeout,
+		})
+	}
+	for _, urn := range before {
+		if !resource.URN(urn).IsValid() {
+			return nil, errors.Errorf("invalid URN '%s' passed to --hold-before", urn)
+		}
+		holds = append(holds, deploy.HoldPoint{
+			Before:            resource.URN(urn),
+			Timeout:           timeout,
+			ContinueOnTimeout: continueOnTimeout,
+		})
+	}
+	return holds, nil
+}
+
+// lineHoldApprover approves hold points with lines of input: "yes" approves a hold and "no" rejects it.
+type lineHoldApprover struct {
+	input io.Reader
+
+	once  sync.Once
+	lines chan string
+}
+
+func newLineHoldApprover(input io.Reader) *lineHoldApprover {
+	return &lineHoldApprover{input: input}
+}
+
+func (a *lineHoldApprover) Approve(ctx context.Context, hold deploy.Hold) (bool, error) {
+	// Input is read in the background so that waiting for it can be abandoned when the context is done.
+	a.once.Do(func() {
+		a.lines = make(chan string)
+		go func() {
+			reader := bufio.NewReader(a.input)
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					close(a.lines)
+					return
+				}
+				a.lines <- line
+			}
+		}()
+	})
+
+	for {
+		select {
+		case line, ok := <-a.lines:
+			if !ok {
+				return false, errors.Errorf("no approval was given before the input ended")
+			}
+			switch strings.ToLower(strings.TrimSpace(line)) {
+			case "yes", "y":
+				return true, nil
+			case "no", "n":
+				return false, nil
+			}
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+}
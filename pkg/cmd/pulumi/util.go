@@ -46,6 +46,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/util/cancel"
 	"github.com/pulumi/pulumi/pkg/v2/util/tracing"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/ciutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
@@ -708,6 +709,22 @@ func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOpt
 	}, nil
 }
 
+// protectedReplaceTypes returns the resource types that the project only allows to be replaced explicitly, less the
+// types that --allow-replace allows.
+func protectedReplaceTypes(proj *workspace.Project, allowReplace []string) map[tokens.Type]bool {
+	if proj.Confirmation == nil || len(proj.Confirmation.ReplaceTypes) == 0 {
+		return nil
+	}
+	protected := make(map[tokens.Type]bool)
+	for _, typ := range proj.Confirmation.ReplaceTypes {
+		protected[tokens.Type(typ)] = true
+	}
+	for _, typ := range allowReplace {
+		delete(protected, tokens.Type(typ))
+	}
+	return protected
+}
+
 func checkDeploymentVersionError(err error, stackName string) error {
 	switch err {
 	case stack.ErrDeploymentSchemaVersionTooOld:
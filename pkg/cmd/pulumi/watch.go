@@ -45,6 +45,7 @@ func newWatchCmd() *cobra.Command {
 	var showReplacementSteps bool
 	var showSames bool
 	var secretsProvider string
+	var overrideFreeze bool
 
 	var cmd = &cobra.Command{
 		Use:        "watch",
@@ -65,6 +66,7 @@ func newWatchCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			opts.OverrideFreeze = overrideFreeze
 
 			opts.Display = display.Options{
 				Color:                cmdutil.GetGlobalColorization(),
@@ -96,6 +98,10 @@ func newWatchCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
+			if err = ensureRuntimeEnv(proj, root); err != nil {
+				return result.FromError(err)
+			}
+
 			m, err := getUpdateMetadata(message, root)
 			if err != nil {
 				return result.FromError(errors.Wrap(err, "gathering environment metadata"))
@@ -185,6 +191,9 @@ func newWatchCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&showSames, "show-sames", false,
 		"Show resources that don't need be updated because they haven't changed, alongside those that do")
+	cmd.PersistentFlags().BoolVar(
+		&overrideFreeze, "override-freeze", false,
+		"Update the stack even if it is frozen")
 
 	return cmd
 }
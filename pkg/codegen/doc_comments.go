@@ -0,0 +1,393 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pgavlin/goldmark"
+	"github.com/pgavlin/goldmark/ast"
+	"github.com/pgavlin/goldmark/extension"
+	east "github.com/pgavlin/goldmark/extension/ast"
+	"github.com/pgavlin/goldmark/parser"
+	"github.com/pgavlin/goldmark/text"
+	"github.com/pgavlin/goldmark/util"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// DocCommentFormat identifies the markup used by the documentation comments of a target language.
+type DocCommentFormat int
+
+const (
+	// MarkdownDocFormat is the format of TSDoc and JSDoc comments, which render Markdown natively.
+	MarkdownDocFormat DocCommentFormat = iota
+	// GoDocFormat is the plain text format of Go doc comments. Code blocks and tables are indented so that godoc
+	// renders them as preformatted text.
+	GoDocFormat
+	// XMLDocFormat is the format of C# XML documentation comments.
+	XMLDocFormat
+	// ReSTDocFormat is the reStructuredText format of Python docstrings.
+	ReSTDocFormat
+)
+
+// ConvertDocs converts a schema description from Markdown to the markup used by documentation comments in the given
+// format. Shortcodes are replaced by their contents; callers that need to filter examples should do so beforehand.
+func ConvertDocs(description string, format DocCommentFormat) string {
+	if description == "" || format == MarkdownDocFormat {
+		return description
+	}
+
+	source := []byte(description)
+	p := goldmark.DefaultParser()
+	p.AddOptions(
+		parser.WithBlockParsers(util.Prioritized(schema.NewShortcodeParser(), 50)),
+		parser.WithParagraphTransformers(util.Prioritized(extension.NewTableParagraphTransformer(), 200)))
+	document := p.Parse(text.NewReader(source))
+
+	c := &docConverter{source: source, format: format}
+	return strings.Join(c.blocks(document), "\n\n") + "\n"
+}
+
+type docConverter struct {
+	source []byte
+	format DocCommentFormat
+}
+
+// blocks converts the block children of a node. Each element of the result is a block of one or more lines.
+func (c *docConverter) blocks(node ast.Node) []string {
+	var blocks []string
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		switch child := child.(type) {
+		case *schema.Shortcode, *ast.Document:
+			blocks = append(blocks, c.blocks(child)...)
+		default:
+			if block := c.block(child); block != "" {
+				blocks = append(blocks, block)
+			}
+		}
+	}
+	return blocks
+}
+
+func (c *docConverter) block(node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.Paragraph, *ast.TextBlock:
+		return c.inlines(node)
+	case *ast.Heading:
+		return c.heading(node)
+	case *ast.FencedCodeBlock:
+		return c.code(node, string(node.Language(c.source)))
+	case *ast.CodeBlock:
+		return c.code(node, "")
+	case *ast.Blockquote:
+		body := strings.Join(c.blocks(node), "\n\n")
+		if c.format == ReSTDocFormat {
+			return indent(body, "    ", "    ")
+		}
+		return body
+	case *ast.List:
+		return c.list(node)
+	case *ast.HTMLBlock:
+		return c.escape(strings.TrimRight(c.lines(node), "\n"))
+	case *east.Table:
+		return c.table(node)
+	default:
+		// Thematic breaks and other decorations have no equivalent in doc comments.
+		return ""
+	}
+}
+
+func (c *docConverter) heading(node *ast.Heading) string {
+	title := c.inlines(node)
+	switch c.format {
+	case XMLDocFormat:
+		return "<b>" + title + "</b>"
+	case ReSTDocFormat:
+		// Docstrings may not contain section titles, so headings are rendered as bold paragraphs.
+		return "**" + title + "**"
+	default:
+		return title
+	}
+}
+
+func (c *docConverter) code(node ast.Node, language string) string {
+	code := strings.TrimRight(c.lines(node), "\n")
+	switch c.format {
+	case XMLDocFormat:
+		return "<code>\n" + c.escape(code) + "\n</code>"
+	case ReSTDocFormat:
+		directive := "::"
+		if language != "" {
+			directive = ".. code-block:: " + language
+		}
+		return directive + "\n\n" + indent(code, "    ", "    ")
+	default:
+		return indent(code, "\t", "\t")
+	}
+}
+
+func (c *docConverter) list(node *ast.List) string {
+	var items []string
+	number := node.Start
+	for item := node.FirstChild(); item != nil; item = item.NextSibling() {
+		body := strings.Join(c.blocks(item), "\n\n")
+		switch c.format {
+		case XMLDocFormat:
+			items = append(items, "<item><description>"+body+"</description></item>")
+		default:
+			marker := "- "
+			if node.IsOrdered() {
+				marker = fmt.Sprintf("%d. ", number)
+				number++
+			}
+			prefix := ""
+			if c.format == GoDocFormat {
+				// Indenting the list causes godoc to preserve its layout.
+				prefix = "  "
+			}
+			items = append(items, indent(body, prefix+marker, prefix+strings.Repeat(" ", len(marker))))
+		}
+	}
+
+	switch c.format {
+	case XMLDocFormat:
+		listType := "bullet"
+		if node.IsOrdered() {
+			listType = "number"
+		}
+		return fmt.Sprintf("<list type=\"%s\">\n%s\n</list>", listType, strings.Join(items, "\n"))
+	case ReSTDocFormat:
+		if !node.IsTight {
+			return strings.Join(items, "\n\n")
+		}
+		return strings.Join(items, "\n")
+	default:
+		return strings.Join(items, "\n")
+	}
+}
+
+func (c *docConverter) table(node *east.Table) string {
+	var rows [][]string
+	for row := node.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, strings.Replace(c.inlines(cell), "\n", " ", -1))
+		}
+		rows = append(rows, cells)
+	}
+
+	if c.format == XMLDocFormat {
+		// XML doc tables pair a term with a description, so any additional columns are folded into the description.
+		lines := []string{"<list type=\"table\">"}
+		for i, row := range rows {
+			tag := "item"
+			if i == 0 {
+				tag = "listheader"
+			}
+			term, description := "", ""
+			if len(row) > 0 {
+				term, description = row[0], strings.Join(row[1:], " | ")
+			}
+			lines = append(lines,
+				fmt.Sprintf("<%s><term>%s</term><description>%s</description></%s>", tag, term, description, tag))
+		}
+		return strings.Join(append(lines, "</list>"), "\n")
+	}
+
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := utf8.RuneCountInString(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	formatRow := func(row []string, left, separator, right string) string {
+		cells := make([]string, len(widths))
+		for i, w := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			cells[i] = cell + strings.Repeat(" ", w-utf8.RuneCountInString(cell))
+		}
+		return strings.TrimRight(left+strings.Join(cells, separator)+right, " ")
+	}
+	rule := func(fill string) []string {
+		rule := make([]string, len(widths))
+		for i, w := range widths {
+			rule[i] = strings.Repeat(fill, w)
+		}
+		return rule
+	}
+
+	var lines []string
+	if c.format == ReSTDocFormat {
+		// reStructuredText grid tables.
+		border := formatRow(rule("-"), "+-", "-+-", "-+")
+		lines = append(lines, border)
+		for i, row := range rows {
+			lines = append(lines, formatRow(row, "| ", " | ", " |"))
+			if i == 0 {
+				lines = append(lines, formatRow(rule("="), "+=", "=+=", "=+"))
+			} else {
+				lines = append(lines, border)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	for i, row := range rows {
+		lines = append(lines, formatRow(row, "\t", "  ", ""))
+		if i == 0 {
+			lines = append(lines, formatRow(rule("-"), "\t", "  ", ""))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inlines converts the inline children of a node. Line breaks in the source are preserved.
+func (c *docConverter) inlines(node ast.Node) string {
+	var b strings.Builder
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		b.WriteString(c.inline(child))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func (c *docConverter) inline(node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.Text:
+		text := node.Text(c.source)
+		if !node.IsRaw() {
+			text = util.ResolveEntityNames(util.ResolveNumericReferences(util.UnescapePunctuations(text)))
+		}
+		result := c.escape(string(text))
+		if node.SoftLineBreak() || node.HardLineBreak() {
+			result += "\n"
+		}
+		return result
+	case *ast.String:
+		return c.escape(string(node.Value))
+	case *ast.CodeSpan:
+		var code strings.Builder
+		for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+			if text, ok := child.(*ast.Text); ok {
+				code.Write(text.Segment.Value(c.source))
+			}
+		}
+		switch c.format {
+		case XMLDocFormat:
+			return "<c>" + c.escape(code.String()) + "</c>"
+		case ReSTDocFormat:
+			return "``" + code.String() + "``"
+		default:
+			return "`" + code.String() + "`"
+		}
+	case *ast.Emphasis:
+		body := c.inlines(node)
+		switch c.format {
+		case XMLDocFormat:
+			if node.Level == 2 {
+				return "<b>" + body + "</b>"
+			}
+			return "<i>" + body + "</i>"
+		case ReSTDocFormat:
+			marker := strings.Repeat("*", node.Level)
+			return marker + body + marker
+		default:
+			return body
+		}
+	case *ast.Link:
+		return c.link(c.inlines(node), string(node.Destination))
+	case *ast.AutoLink:
+		url := string(node.URL(c.source))
+		if c.format == XMLDocFormat {
+			return fmt.Sprintf("<see href=\"%s\"/>", c.escape(url))
+		}
+		return url
+	case *ast.Image:
+		return c.inlines(node)
+	case *ast.RawHTML:
+		var html strings.Builder
+		for i := 0; i < node.Segments.Len(); i++ {
+			segment := node.Segments.At(i)
+			html.Write(segment.Value(c.source))
+		}
+		return c.escape(html.String())
+	default:
+		return c.inlines(node)
+	}
+}
+
+func (c *docConverter) link(label, destination string) string {
+	// References to entities in the schema and fragment-only links have no meaning outside of the schema's docs.
+	if destination == "" || strings.HasPrefix(destination, "schema:") || strings.HasPrefix(destination, "#") {
+		return label
+	}
+
+	switch c.format {
+	case XMLDocFormat:
+		return fmt.Sprintf("<see href=\"%s\">%s</see>", c.escape(destination), label)
+	case ReSTDocFormat:
+		return fmt.Sprintf("`%s <%s>`__", label, destination)
+	default:
+		if label == destination {
+			return destination
+		}
+		return fmt.Sprintf("%s (%s)", label, destination)
+	}
+}
+
+// lines returns the concatenated source lines of a block.
+func (c *docConverter) lines(node ast.Node) string {
+	var b strings.Builder
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		b.Write(segment.Value(c.source))
+	}
+	return b.String()
+}
+
+var xmlDocEscaper = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;")
+
+// escape escapes text for inclusion in a doc comment of the converter's format.
+func (c *docConverter) escape(text string) string {
+	if c.format == XMLDocFormat {
+		return xmlDocEscaper.Replace(text)
+	}
+	return text
+}
+
+// indent prefixes the first line of text with first and each subsequent non-empty line with rest.
+func indent(text, first, rest string) string {
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		switch {
+		case i == 0:
+			lines[i] = first + l
+		case l != "":
+			lines[i] = rest + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,76 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertDocs(t *testing.T) {
+	description := "Provides an S3 **bucket**. See [the docs](https://example.com) & `acl`.\n\n" +
+		"## Example Usage\n\n" +
+		codeFence + "typescript\nconst bucket = new aws.s3.Bucket(\"b\");\n" + codeFence + "\n\n" +
+		"* public\n* private\n\n" +
+		"| Name | Value |\n|------|-------|\n| acl | `private` |\n"
+
+	cases := []struct {
+		format   DocCommentFormat
+		expected string
+	}{
+		{
+			format:   MarkdownDocFormat,
+			expected: description,
+		},
+		{
+			format: GoDocFormat,
+			expected: "Provides an S3 bucket. See the docs (https://example.com) & `acl`.\n\n" +
+				"Example Usage\n\n" +
+				"\tconst bucket = new aws.s3.Bucket(\"b\");\n\n" +
+				"  - public\n  - private\n\n" +
+				"\tName  Value\n\t----  ---------\n\tacl   `private`\n",
+		},
+		{
+			format: XMLDocFormat,
+			expected: "Provides an S3 <b>bucket</b>. See <see href=\"https://example.com\">the docs</see> &amp; " +
+				"<c>acl</c>.\n\n" +
+				"<b>Example Usage</b>\n\n" +
+				"<code>\nconst bucket = new aws.s3.Bucket(\"b\");\n</code>\n\n" +
+				"<list type=\"bullet\">\n<item><description>public</description></item>\n" +
+				"<item><description>private</description></item>\n</list>\n\n" +
+				"<list type=\"table\">\n<listheader><term>Name</term><description>Value</description></listheader>\n" +
+				"<item><term>acl</term><description><c>private</c></description></item>\n</list>\n",
+		},
+		{
+			format: ReSTDocFormat,
+			expected: "Provides an S3 **bucket**. See `the docs <https://example.com>`__ & ``acl``.\n\n" +
+				"**Example Usage**\n\n" +
+				".. code-block:: typescript\n\n    const bucket = new aws.s3.Bucket(\"b\");\n\n" +
+				"- public\n- private\n\n" +
+				"+------+-------------+\n| Name | Value       |\n+======+=============+\n" +
+				"| acl  | ``private`` |\n+------+-------------+\n",
+		},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, ConvertDocs(description, c.format))
+	}
+}
+
+func TestConvertDocsEntityReferences(t *testing.T) {
+	description := "Refers to [Bucket](#/resources/aws:s3%2Fbucket:Bucket)."
+	assert.Equal(t, "Refers to Bucket.\n", ConvertDocs(description, GoDocFormat))
+	assert.Equal(t, "Refers to Bucket.\n", ConvertDocs(description, ReSTDocFormat))
+}
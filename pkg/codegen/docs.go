@@ -15,6 +15,10 @@
 package codegen
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"github.com/pgavlin/goldmark/ast"
 
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
@@ -95,3 +99,28 @@ func FilterExamples(description string, lang string) string {
 	filterExamples(source, parsed, lang)
 	return schema.RenderDocsToString(source, parsed)
 }
+
+// PropertyComment returns the comment for a property. If the property's type is an enum type with deprecated values,
+// the comment is followed by a paragraph for each such value. The generated SDKs represent enum types using their
+// element types, so the comments of the properties that use an enum type are where its deprecated values are surfaced.
+func PropertyComment(p *schema.Property) string {
+	enum, ok := p.Type.(*schema.EnumType)
+	if !ok {
+		return p.Comment
+	}
+
+	var paragraphs []string
+	if p.Comment != "" {
+		paragraphs = append(paragraphs, strings.TrimRight(p.Comment, "\n"))
+	}
+	for _, e := range enum.Elements {
+		if e.DeprecationMessage != "" {
+			value, err := json.Marshal(e.Value)
+			if err != nil {
+				value = []byte(fmt.Sprintf("%v", e.Value))
+			}
+			paragraphs = append(paragraphs, fmt.Sprintf("The value `%s` is deprecated: %s", value, e.DeprecationMessage))
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -18,6 +18,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 )
 
 const codeFence = "```"
@@ -107,3 +109,19 @@ func fakeFunc() {
 			"unexpected Example 2 section. section should have been excluded")
 	})
 }
+
+func TestPropertyComment(t *testing.T) {
+	size := &schema.EnumType{
+		Token:       "example:index:Size",
+		ElementType: schema.StringType,
+		Elements: []*schema.Enum{
+			{Value: "small"},
+			{Value: "large", DeprecationMessage: "Use small instead."},
+		},
+	}
+
+	assert.Equal(t, "The size.", PropertyComment(&schema.Property{Comment: "The size.", Type: schema.StringType}))
+	assert.Equal(t, "The size.\n\nThe value `\"large\"` is deprecated: Use small instead.",
+		PropertyComment(&schema.Property{Comment: "The size.\n", Type: size}))
+	assert.Equal(t, "The value `\"large\"` is deprecated: Use small instead.", PropertyComment(&schema.Property{Type: size}))
+}
@@ -106,7 +106,7 @@ func isImmutableArrayType(t schema.Type, wrapInput bool) bool {
 
 func isValueType(t schema.Type) bool {
 	switch t {
-	case schema.BoolType, schema.IntType, schema.NumberType:
+	case schema.BoolType, schema.IntType, schema.Int64Type, schema.NumberType:
 		return true
 	default:
 		return false
@@ -125,6 +125,7 @@ type modContext struct {
 	mod                    string
 	propertyNames          map[*schema.Property]string
 	types                  []*schema.ObjectType
+	enums                  []*schema.EnumType
 	resources              []*schema.Resource
 	functions              []*schema.Function
 	typeDetails            map[*schema.ObjectType]*typeDetails
@@ -242,6 +243,9 @@ func (mod *modContext) typeString(t schema.Type, qualifier string, input, state,
 		case mod.details(t).functionType:
 			typ += "Result"
 		}
+	case *schema.EnumType:
+		// Use the element type for now.
+		return mod.typeString(t.ElementType, qualifier, input, state, wrapInput, requireInitializers, optional)
 	case *schema.TokenType:
 		// Use the underlying type for now.
 		if t.UnderlyingType != nil {
@@ -260,7 +264,7 @@ func (mod *modContext) typeString(t schema.Type, qualifier string, input, state,
 
 		elementTypeSet := stringSet{}
 		var elementTypes []string
-		for _, e := range t.ElementTypes {
+		for _, e := range unionMembers(t, input) {
 			et := mod.typeString(e, qualifier, input, state, false, false, false)
 			if !elementTypeSet.has(et) {
 				elementTypeSet.add(et)
@@ -286,9 +290,9 @@ func (mod *modContext) typeString(t schema.Type, qualifier string, input, state,
 			typ = "bool"
 		case schema.IntType:
 			typ = "int"
-		case schema.NumberType:
+		case schema.NumberType, schema.Int64Type:
 			typ = "double"
-		case schema.StringType:
+		case schema.StringType, schema.BytesType:
 			typ = "string"
 		case schema.ArchiveType:
 			typ = "Archive"
@@ -315,14 +319,8 @@ func (mod *modContext) typeString(t schema.Type, qualifier string, input, state,
 	return typ
 }
 
-var docCommentEscaper = strings.NewReplacer(
-	`&`, "&amp;",
-	`<`, "&lt;",
-	`>`, "&gt;",
-)
-
 func printComment(w io.Writer, comment string, indent string) {
-	lines := strings.Split(docCommentEscaper.Replace(comment), "\n")
+	lines := strings.Split(codegen.ConvertDocs(comment, codegen.XMLDocFormat), "\n")
 	for len(lines) > 0 && lines[len(lines)-1] == "" {
 		lines = lines[:len(lines)-1]
 	}
@@ -336,6 +334,13 @@ func printComment(w io.Writer, comment string, indent string) {
 	}
 }
 
+// printObsoleteAttribute emits an Obsolete attribute with the given message if the message is not empty.
+func printObsoleteAttribute(w io.Writer, deprecationMessage, indent string) {
+	if deprecationMessage != "" {
+		fmt.Fprintf(w, "%s[Obsolete(@\"%s\")]\n", indent, strings.Replace(deprecationMessage, `"`, `""`, -1))
+	}
+}
+
 type plainType struct {
 	mod                   *modContext
 	res                   *schema.Resource
@@ -364,6 +369,8 @@ func (pt *plainType) genInputProperty(w io.Writer, prop *schema.Property, indent
 			json = false
 		} else if t, ok := prop.Type.(*schema.TokenType); ok && t.UnderlyingType == schema.StringType {
 			json = false
+		} else if t, ok := prop.Type.(*schema.EnumType); ok && t.ElementType == schema.StringType {
+			json = false
 		}
 		if json {
 			attributeArgs += ", json: true"
@@ -381,13 +388,11 @@ func (pt *plainType) genInputProperty(w io.Writer, prop *schema.Property, indent
 		fmt.Fprintf(w, "%s    [Input(\"%s\"%s)]\n", indent, wireName, attributeArgs)
 		fmt.Fprintf(w, "%s    private %s? %s;\n", indent, backingFieldType, backingFieldName)
 
-		if prop.Comment != "" {
+		if comment := codegen.PropertyComment(prop); comment != "" {
 			fmt.Fprintf(w, "\n")
-			printComment(w, prop.Comment, indent+"    ")
-		}
-		if prop.DeprecationMessage != "" {
-			fmt.Fprintf(w, "%s    [Obsolete(@\"%s\")]\n", indent, strings.Replace(prop.DeprecationMessage, `"`, `""`, -1))
+			printComment(w, comment, indent+"    ")
 		}
+		printObsoleteAttribute(w, prop.DeprecationMessage, indent+"    ")
 
 		// Note that we use the backing field type--which is just the property type without any nullable annotation--to
 		// ensure that the user does not see warnings when initializing these properties using object or collection
@@ -403,7 +408,8 @@ func (pt *plainType) genInputProperty(w io.Writer, prop *schema.Property, indent
 			initializer = " = null!;"
 		}
 
-		printComment(w, prop.Comment, indent+"    ")
+		printComment(w, codegen.PropertyComment(prop), indent+"    ")
+		printObsoleteAttribute(w, prop.DeprecationMessage, indent+"    ")
 		fmt.Fprintf(w, "%s    [Input(\"%s\"%s)]\n", indent, wireName, attributeArgs)
 		fmt.Fprintf(w, "%s    public %s %s { get; set; }%s\n", indent, propertyType, propertyName, initializer)
 	}
@@ -481,7 +487,8 @@ func (pt *plainType) genOutputType(w io.Writer, level int) {
 		fieldName := pt.mod.propertyName(prop)
 		required := prop.IsRequired || pt.mod.isK8sCompatMode()
 		fieldType := pt.mod.typeString(prop.Type, pt.propertyTypeQualifier, false, false, false, false, !required)
-		printComment(w, prop.Comment, indent+"    ")
+		printComment(w, codegen.PropertyComment(prop), indent+"    ")
+		printObsoleteAttribute(w, prop.DeprecationMessage, indent+"    ")
 		fmt.Fprintf(w, "%s    public readonly %s %s;\n", indent, fieldType, fieldName)
 	}
 	if len(pt.properties) > 0 {
@@ -540,9 +547,9 @@ func primitiveValue(value interface{}) (string, error) {
 			return "true", nil
 		}
 		return "false", nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return strconv.FormatInt(v.Int(), 10), nil
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return strconv.FormatUint(v.Uint(), 10), nil
 	case reflect.Float32, reflect.Float64:
 		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
@@ -570,7 +577,7 @@ func (mod *modContext) getDefaultValue(dv *schema.DefaultValue, t schema.Type) (
 			getType = "Boolean"
 		case schema.IntType:
 			getType = "Int32"
-		case schema.NumberType:
+		case schema.NumberType, schema.Int64Type:
 			getType = "Double"
 		}
 
@@ -635,9 +642,7 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	if r.IsProvider {
 		baseType = "Pulumi.ProviderResource"
 	}
-	if r.DeprecationMessage != "" {
-		fmt.Fprintf(w, "    [Obsolete(@\"%s\")]\n", strings.Replace(r.DeprecationMessage, `"`, `""`, -1))
-	}
+	printObsoleteAttribute(w, r.DeprecationMessage, "    ")
 	fmt.Fprintf(w, "    public partial class %s : %s\n", className, baseType)
 	fmt.Fprintf(w, "    {\n")
 
@@ -662,7 +667,8 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 			secretProps = append(secretProps, prop.Name)
 		}
 
-		printComment(w, prop.Comment, "        ")
+		printComment(w, codegen.PropertyComment(prop), "        ")
+		printObsoleteAttribute(w, prop.DeprecationMessage, "        ")
 		fmt.Fprintf(w, "        [Output(\"%s\")]\n", wireName)
 		fmt.Fprintf(w, "        public Output<%s> %s { get; private set; } = null!;\n", propertyType, propertyName)
 		fmt.Fprintf(w, "\n")
@@ -895,9 +901,7 @@ func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) error {
 		argsParamRef = fmt.Sprintf("args ?? new %sArgs()", className)
 	}
 
-	if fun.DeprecationMessage != "" {
-		fmt.Fprintf(w, "    [Obsolete(@\"%s\")]\n", strings.Replace(fun.DeprecationMessage, `"`, `""`, -1))
-	}
+	printObsoleteAttribute(w, fun.DeprecationMessage, "    ")
 	// Open the class we'll use for datasources.
 	fmt.Fprintf(w, "    public static class %s\n", className)
 	fmt.Fprintf(w, "    {\n")
@@ -946,6 +950,27 @@ func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) error {
 	return nil
 }
 
+// unionMembers returns the members of a union type in the order in which they appear in its .NET type. Unions of more
+// than two members nest, and a value of a nested member cannot be converted to the union implicitly, so an input union
+// starts with its default member, if any, so that values of the most common member can be passed directly.
+func unionMembers(t *schema.UnionType, input bool) []schema.Type {
+	if !input || t.DefaultType == nil {
+		return t.ElementTypes
+	}
+
+	members := []schema.Type{t.DefaultType}
+	for _, e := range t.ElementTypes {
+		if e != t.DefaultType {
+			members = append(members, e)
+		}
+	}
+	if len(members) != len(t.ElementTypes) {
+		// The default type is not a member of the union.
+		return t.ElementTypes
+	}
+	return members
+}
+
 func visitObjectTypesAcc(t schema.Type, visitor func(*schema.ObjectType), visited codegen.Set) {
 	if visited.Has(t) {
 		return
@@ -1037,16 +1062,18 @@ func (mod *modContext) getConfigProperty(schemaType schema.Type) (string, string
 	var getFunc string
 	nullableSigil := "?"
 	switch schemaType {
-	case schema.StringType:
+	case schema.StringType, schema.BytesType:
 		getFunc = "Get"
 	case schema.BoolType:
 		getFunc = "GetBoolean"
 	case schema.IntType:
 		getFunc = "GetInt32"
-	case schema.NumberType:
+	case schema.NumberType, schema.Int64Type:
 		getFunc = "GetDouble"
 	default:
 		switch t := schemaType.(type) {
+		case *schema.EnumType:
+			return mod.getConfigProperty(t.ElementType)
 		case *schema.TokenType:
 			if t.UnderlyingType != nil {
 				return mod.getConfigProperty(t.UnderlyingType)
@@ -1092,7 +1119,8 @@ func (mod *modContext) genConfig(variables []*schema.Property) (string, error) {
 			initializer += " ?? " + dv
 		}
 
-		printComment(w, p.Comment, "        ")
+		printComment(w, codegen.PropertyComment(p), "        ")
+		printObsoleteAttribute(w, p.DeprecationMessage, "        ")
 		fmt.Fprintf(w, "        public static %s %s { get; set; } = %s;\n", propertyType, propertyName, initializer)
 		fmt.Fprintf(w, "\n")
 	}
@@ -1119,7 +1147,8 @@ func (mod *modContext) genConfig(variables []*schema.Property) (string, error) {
 					initializer = " = null!;"
 				}
 
-				printComment(w, prop.Comment, "            ")
+				printComment(w, codegen.PropertyComment(prop), "            ")
+				printObsoleteAttribute(w, prop.DeprecationMessage, "            ")
 				fmt.Fprintf(w, "                public %s %s { get; set; }%s\n", typ, name, initializer)
 			}
 
@@ -1163,6 +1192,21 @@ func (mod *modContext) genUtilities() (string, error) {
 	return w.String(), nil
 }
 
+// genModuleIndex summarizes the resources, functions, and types in the module.
+func (mod *modContext) genModuleIndex() *codegen.ModuleIndex {
+	idx := codegen.NewModuleIndex(mod.pkg, mod.mod, mod.namespaceName)
+	for _, r := range mod.resources {
+		idx.AddResource(resourceName(r), r)
+	}
+	for _, f := range mod.functions {
+		idx.AddFunction(tokenToFunctionName(f.Token), f)
+	}
+	for _, t := range mod.types {
+		idx.AddType(tokenToName(t.Token), t)
+	}
+	return idx
+}
+
 func (mod *modContext) gen(fs fs) error {
 	nsComponents := strings.Split(mod.namespaceName, ".")
 	if len(nsComponents) > 0 {
@@ -1192,11 +1236,17 @@ func (mod *modContext) gen(fs fs) error {
 		fs.add(p, []byte(contents))
 	}
 
-	// Ensure that the target module directory contains a README.md file.
+	// Ensure that the target module directory contains a README.md file. The README of a module other than the root
+	// module summarizes the module's members.
 	readme := mod.pkg.Description
 	if readme != "" && readme[len(readme)-1] != '\n' {
 		readme += "\n"
 	}
+	if mod.mod != "" {
+		if idx := mod.genModuleIndex(); !idx.IsEmpty() {
+			readme = idx.Markdown()
+		}
+	}
 	fs.add(filepath.Join(dir, "README.md"), []byte(readme))
 
 	// Utilities, config
@@ -1290,6 +1340,124 @@ func (mod *modContext) gen(fs fs) error {
 		}
 	}
 
+	// Enums
+	if len(mod.enums) > 0 {
+		buffer := &bytes.Buffer{}
+		mod.genHeader(buffer, []string{
+			"System",
+			"System.Collections.Immutable",
+			"System.Globalization",
+		})
+
+		fmt.Fprintf(buffer, "namespace %s\n", mod.tokenToNamespace(mod.enums[0].Token, ""))
+		fmt.Fprintf(buffer, "{\n")
+		for i, e := range mod.enums {
+			if i != 0 {
+				fmt.Fprintf(buffer, "\n")
+			}
+			if err := mod.genEnum(buffer, e); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(buffer, "}\n")
+
+		addFile("Enums.cs", buffer.String())
+	}
+
+	return nil
+}
+
+// genEnum emits an enum type as a struct that wraps a value of the enum's element type. The struct has a static
+// property for each value along with members that list, parse, and validate the values.
+func (mod *modContext) genEnum(w io.Writer, t *schema.EnumType) error {
+	memberNames, err := codegen.EnumMemberNames(t)
+	if err != nil {
+		return err
+	}
+	name := tokenToName(t.Token)
+	elementType := mod.typeString(t.ElementType, "", false, false, false, false, false)
+
+	values := make([]string, len(t.Elements))
+	for i, e := range t.Elements {
+		if values[i], err = primitiveValue(e.Value); err != nil {
+			return err
+		}
+	}
+
+	var toString, hashCode string
+	switch t.ElementType {
+	case schema.StringType:
+		toString, hashCode = "_value", "_value?.GetHashCode() ?? 0"
+	case schema.BoolType:
+		toString, hashCode = `_value ? "true" : "false"`, "_value.GetHashCode()"
+	default:
+		toString, hashCode = "_value.ToString(CultureInfo.InvariantCulture)", "_value.GetHashCode()"
+	}
+
+	printComment(w, t.Comment, "    ")
+	fmt.Fprintf(w, "    public readonly struct %s : IEquatable<%s>\n", name, name)
+	fmt.Fprintf(w, "    {\n")
+	fmt.Fprintf(w, "        private readonly %s _value;\n", elementType)
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "        private %s(%s value)\n", name, elementType)
+	fmt.Fprintf(w, "        {\n")
+	fmt.Fprintf(w, "            _value = value;\n")
+	fmt.Fprintf(w, "        }\n")
+	fmt.Fprintf(w, "\n")
+
+	for i, e := range t.Elements {
+		printComment(w, e.Comment, "        ")
+		printObsoleteAttribute(w, e.DeprecationMessage, "        ")
+		fmt.Fprintf(w, "        public static %s %s { get; } = new %s(%s);\n", name, memberNames[i], name, values[i])
+	}
+	fmt.Fprintf(w, "\n")
+
+	// The list of values constructs each value rather than referring to the properties so that obsolete values do
+	// not cause warnings. It is not stored in a static field: the runtime cannot load a struct that has a static field
+	// of a generic struct type instantiated with the struct itself.
+	constructors := make([]string, len(values))
+	for i, v := range values {
+		constructors[i] = fmt.Sprintf("new %s(%s)", name, v)
+	}
+	printComment(w, fmt.Sprintf("The values of %s.", name), "        ")
+	fmt.Fprintf(w, "        public static ImmutableArray<%s> Values => ImmutableArray.Create(%s);\n", name,
+		strings.Join(constructors, ", "))
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "        /// <summary>\n")
+	fmt.Fprintf(w, "        /// Returns the value of %s whose string form is <paramref name=\"s\"/>.\n", name)
+	fmt.Fprintf(w, "        /// </summary>\n")
+	fmt.Fprintf(w, "        /// <exception cref=\"ArgumentException\">No value of %s has the given string form.</exception>\n",
+		name)
+	fmt.Fprintf(w, "        public static %s Parse(string s)\n", name)
+	fmt.Fprintf(w, "        {\n")
+	fmt.Fprintf(w, "            foreach (var value in Values)\n")
+	fmt.Fprintf(w, "            {\n")
+	fmt.Fprintf(w, "                if (value.ToString() == s)\n")
+	fmt.Fprintf(w, "                {\n")
+	fmt.Fprintf(w, "                    return value;\n")
+	fmt.Fprintf(w, "                }\n")
+	fmt.Fprintf(w, "            }\n")
+	fmt.Fprintf(w, "            throw new ArgumentException($\"\\\"{s}\\\" is not a valid %s\", nameof(s));\n", name)
+	fmt.Fprintf(w, "        }\n")
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "        /// <summary>\n")
+	fmt.Fprintf(w, "        /// Returns true if <paramref name=\"value\"/> is one of the values of %s.\n", name)
+	fmt.Fprintf(w, "        /// </summary>\n")
+	fmt.Fprintf(w, "        public static bool IsValid(%s value) => Values.Contains(new %s(value));\n", elementType, name)
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "        public static implicit operator %s(%s value) => value._value;\n", elementType, name)
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "        public static bool operator ==(%s left, %s right) => left.Equals(right);\n", name, name)
+	fmt.Fprintf(w, "        public static bool operator !=(%s left, %s right) => !left.Equals(right);\n", name, name)
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "        public bool Equals(%s other) => _value == other._value;\n", name)
+	fmt.Fprintf(w, "        public override bool Equals(object? obj) => obj is %s other && Equals(other);\n", name)
+	fmt.Fprintf(w, "        public override int GetHashCode() => %s;\n", hashCode)
+	fmt.Fprintf(w, "        public override string ToString() => %s;\n", toString)
+	fmt.Fprintf(w, "    }\n")
 	return nil
 }
 
@@ -1487,9 +1655,13 @@ func generateModuleContextMap(tool string, pkg *schema.Package, info CSharpPacka
 
 	// Find nested types.
 	for _, t := range pkg.Types {
-		if obj, ok := t.(*schema.ObjectType); ok {
-			mod := getModFromToken(obj.Token)
-			mod.types = append(mod.types, obj)
+		switch t := t.(type) {
+		case *schema.ObjectType:
+			mod := getModFromToken(t.Token)
+			mod.types = append(mod.types, t)
+		case *schema.EnumType:
+			mod := getModFromToken(t.Token)
+			mod.enums = append(mod.enums, t)
 		}
 	}
 
@@ -0,0 +1,67 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotnet
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// ExampleWorkspace returns a workspace that compiles the C# examples in the given package's schema against the SDK that
+// GeneratePackage generates for the package. Each example is compiled by its own class library project that references
+// the generated SDK's project.
+func ExampleWorkspace(tool string, pkg *schema.Package) (*codegen.ExampleWorkspace, error) {
+	sdk, err := GeneratePackage(tool, pkg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info, _ := pkg.Language["csharp"].(CSharpPackageInfo)
+	assemblyName := "Pulumi." + namespaceName(info.Namespaces, pkg.Name)
+
+	files := map[string][]byte{}
+	for p, contents := range sdk {
+		files[path.Join("sdk", p)] = contents
+	}
+
+	workspace := &codegen.ExampleWorkspace{Files: files}
+	for i, example := range codegen.PackageExamples(pkg, "csharp") {
+		dir := fmt.Sprintf("examples/%03d", i)
+		files[path.Join(dir, "Example.cs")] = []byte(example.Code)
+		files[path.Join(dir, "Example.csproj")] = []byte(fmt.Sprintf(`<Project Sdk="Microsoft.NET.Sdk">
+
+  <PropertyGroup>
+    <OutputType>Library</OutputType>
+    <TargetFramework>netcoreapp3.1</TargetFramework>
+    <Nullable>enable</Nullable>
+  </PropertyGroup>
+
+  <ItemGroup>
+    <ProjectReference Include="../../sdk/%s.csproj" />
+  </ItemGroup>
+
+</Project>
+`, assemblyName))
+		workspace.Builds = append(workspace.Builds, codegen.ExampleBuild{
+			Example: example,
+			Dir:     dir,
+			Command: []string{"dotnet", "build"},
+		})
+	}
+	return workspace, nil
+}
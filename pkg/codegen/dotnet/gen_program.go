@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
@@ -39,12 +40,28 @@ type generator struct {
 	// Type names per invoke function token.
 	functionArgs map[string]string
 	// Whether awaits are needed, and therefore an async Initialize method should be declared.
-	asyncInit     bool
-	configCreated bool
-	diagnostics   hcl.Diagnostics
+	asyncInit        bool
+	configCreated    bool
+	configNamespaces codegen.StringSet
+	diagnostics      hcl.Diagnostics
+
+	// The component whose constructor is being generated, if any.
+	component *hcl2.Component
+	// The writer that records the source map for the generated program, if any.
+	sourceMap *codegen.SourceMapWriter
+	// The report that records the fidelity of the conversion, if any.
+	report *codegen.ConversionReport
 }
 
 func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
+	return GenerateProgramWithOptions(program, codegen.GenerateProgramOptions{})
+}
+
+// GenerateProgramWithOptions generates a C# program for the given PCL program. The options control the
+// generator's optional outputs, e.g. a source map for the generated program.
+func GenerateProgramWithOptions(program *hcl2.Program,
+	options codegen.GenerateProgramOptions) (map[string][]byte, hcl.Diagnostics, error) {
+
 	// Linearize the nodes into an order appropriate for procedural code generation.
 	nodes := hcl2.Linearize(program)
 
@@ -67,39 +84,56 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 	}
 
 	g := &generator{
-		program:      program,
-		namespaces:   namespaces,
-		functionArgs: functionArgs,
+		program:          program,
+		namespaces:       namespaces,
+		functionArgs:     functionArgs,
+		configNamespaces: codegen.NewStringSet(),
 	}
 	g.Formatter = format.NewFormatter(g)
 
 	for _, n := range nodes {
-		if r, ok := n.(*hcl2.Resource); ok && requiresAsyncInit(r) {
+		if r, ok := hcl2.NodeResource(n); ok && requiresAsyncInit(r) {
 			g.asyncInit = true
 			break
 		}
 	}
 
+	g.report = options.Report
+	program.Report(g.report)
+
 	var index bytes.Buffer
-	g.genPreamble(&index, program)
+	var w io.Writer = &index
+	if options.SourceMap {
+		g.sourceMap = codegen.NewSourceMapWriter(&index, "MyStack.cs")
+		w = g.sourceMap
+	}
+
+	g.genPreamble(w, program)
 
 	g.Indented(func() {
 		// Emit async Initialize if needed
 		if g.asyncInit {
-			g.genInitialize(&index, nodes)
+			g.genInitialize(w, nodes)
 		}
 
 		g.Indented(func() {
 			for _, n := range nodes {
-				g.genNode(&index, n)
+				g.genNode(w, n)
 			}
 		})
 	})
-	g.genPostamble(&index, nodes)
+	g.genPostamble(w, nodes)
 
 	files := map[string][]byte{
 		"MyStack.cs": index.Bytes(),
 	}
+	if g.sourceMap != nil {
+		sourceMap, err := g.sourceMap.SourceMap().JSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		files["MyStack.cs.map"] = sourceMap
+	}
 	return files, g.diagnostics, nil
 }
 
@@ -130,15 +164,42 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 	// to sort them later on.
 	systemUsings := codegen.NewStringSet()
 	pulumiUsings := codegen.NewStringSet()
-	for _, n := range program.Nodes {
-		if r, isResource := n.(*hcl2.Resource); isResource {
-			pkg, _, _, _ := r.DecomposeToken()
+	for _, n := range hcl2.ProgramNodes(program) {
+		// Components register their outputs using a dictionary, and their inputs and outputs may be lists or maps.
+		if c, ok := n.(*hcl2.Component); ok {
+			systemUsings.Add("System.Collections.Generic")
+			for _, t := range c.OutputType.(*model.ObjectType).Properties {
+				switch model.ResolveOutputs(t).(type) {
+				case *model.ListType, *model.MapType:
+					systemUsings.Add("System.Collections.Immutable")
+				}
+			}
+			for _, t := range c.InputType.(*model.ObjectType).Properties {
+				switch t.(type) {
+				case *model.ListType, *model.MapType:
+					systemUsings.Add("System.Collections.Immutable")
+				}
+			}
+		}
+
+		if r, isResource := hcl2.NodeResource(n); isResource && r.Component == nil {
+			pkg, mod, name, _ := r.DecomposeToken()
+			if pkg == "pulumi" && mod == "providers" {
+				pkg = name
+			}
 			if pkg != "pulumi" {
 				namespace := namespaceName(g.namespaces[pkg], pkg)
 				pulumiUsings.Add(fmt.Sprintf("%s = Pulumi.%[1]s", namespace))
 			}
 			if r.Options != nil && r.Options.Range != nil {
 				systemUsings.Add("System.Collections.Generic")
+				if r.HasMapRange() {
+					systemUsings.Add("System.Linq")
+				}
+			}
+			if r.Options != nil && r.Options.DependsOn != nil && !hcl2.IsStaticResourceList(r.Options.DependsOn) {
+				systemUsings.Add("System.Collections.Generic")
+				systemUsings.Add("System.Linq")
 			}
 		}
 		diags := n.VisitExpressions(nil, func(n model.Expression) (model.Expression, hcl.Diagnostics) {
@@ -173,6 +234,13 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 
 	g.Fprint(w, "\n")
 
+	// Emit the classes for any components ahead of the stack.
+	for _, n := range program.Nodes {
+		if c, ok := n.(*hcl2.Component); ok {
+			g.sourceMap.Map(c.SyntaxNode().Range(), func() { g.genComponent(w, c) })
+		}
+	}
+
 	// Emit Stack class signature
 	g.Fprint(w, "class MyStack : Stack\n")
 	g.Fprint(w, "{\n")
@@ -233,16 +301,20 @@ func (g *generator) genPostamble(w io.Writer, nodes []hcl2.Node) {
 }
 
 func (g *generator) genNode(w io.Writer, n hcl2.Node) {
-	switch n := n.(type) {
-	case *hcl2.Resource:
-		g.genResource(w, n)
-	case *hcl2.ConfigVariable:
-		g.genConfigVariable(w, n)
-	case *hcl2.LocalVariable:
-		g.genLocalVariable(w, n)
-	case *hcl2.OutputVariable:
-		g.genOutputAssignment(w, n)
-	}
+	g.sourceMap.Map(n.SyntaxNode().Range(), func() {
+		switch n := n.(type) {
+		case *hcl2.Resource:
+			g.genResource(w, n)
+		case *hcl2.Provider:
+			g.genResource(w, n.Resource)
+		case *hcl2.ConfigVariable:
+			g.genConfigVariable(w, n)
+		case *hcl2.LocalVariable:
+			g.genLocalVariable(w, n)
+		case *hcl2.OutputVariable:
+			g.genOutputAssignment(w, n)
+		}
+	})
 }
 
 // requiresAsyncInit returns true if the program requires awaits in the code, and therefore an asynchronous
@@ -299,7 +371,7 @@ func (g *generator) functionName(tokenArg model.Expression) (string, string) {
 
 // argumentTypeName computes the C# argument class name for the given expression and model type.
 func (g *generator) argumentTypeName(expr model.Expression, destType model.Type) string {
-	schemaType, ok := hcl2.GetSchemaForType(destType.(model.Type))
+	schemaType, ok := g.program.GetSchemaForType(destType.(model.Type))
 	if !ok {
 		return ""
 	}
@@ -340,36 +412,61 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type)
 // makeResourceName returns the expression that should be emitted for a resource's "name" parameter given its base name
 // and the count variable name, if any.
 func (g *generator) makeResourceName(baseName, count string) string {
+	// The names of the resources inside of a component are prefixed with the name of the component instance.
+	if g.component != nil {
+		baseName = "{name}-" + baseName
+		if count == "" {
+			return fmt.Sprintf("$\"%s\"", baseName)
+		}
+	}
 	if count == "" {
 		return fmt.Sprintf(`"%s"`, baseName)
 	}
 	return fmt.Sprintf("$\"%s-{%s}\"", baseName, count)
 }
 
-func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
+func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions, optionsType string) string {
 	if opts == nil {
-		return ""
+		if g.component == nil {
+			return ""
+		}
+		opts = &hcl2.ResourceOptions{}
 	}
 
 	var result bytes.Buffer
-	appendOption := func(name string, value model.Expression) {
+	openOptions := func() {
 		if result.Len() == 0 {
-			_, err := fmt.Fprintf(&result, ", new CustomResourceOptions\n%s{", g.Indent)
+			_, err := fmt.Fprintf(&result, ", new %s\n%s{", optionsType, g.Indent)
 			g.Indent += "    "
 			contract.IgnoreError(err)
 		}
-
+	}
+	appendOption := func(name string, value model.Expression) {
+		openOptions()
 		g.Fgenf(&result, "\n%s%s = %v,", g.Indent, name, g.lowerExpression(value, value.Type()))
 	}
+	appendResourceList := func(name string, value model.Expression) {
+		openOptions()
+		g.Fgenf(&result, "\n%s%s = ", g.Indent, name)
+		g.genResourceList(&result, value)
+		g.Fgen(&result, ".ToArray(),")
+	}
 
 	if opts.Parent != nil {
 		appendOption("Parent", opts.Parent)
+	} else if g.component != nil {
+		// Resources inside of a component are children of the component unless they specify another parent.
+		appendOption("Parent", model.VariableReference(thisVariable))
 	}
 	if opts.Provider != nil {
 		appendOption("Provider", opts.Provider)
 	}
 	if opts.DependsOn != nil {
-		appendOption("DependsOn", opts.DependsOn)
+		if hcl2.IsStaticResourceList(opts.DependsOn) {
+			appendOption("DependsOn", opts.DependsOn)
+		} else {
+			appendResourceList("DependsOn", opts.DependsOn)
+		}
 	}
 	if opts.Protect != nil {
 		appendOption("Protect", opts.Protect)
@@ -377,6 +474,49 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	if opts.IgnoreChanges != nil {
 		appendOption("IgnoreChanges", opts.IgnoreChanges)
 	}
+	if opts.Aliases != nil {
+		openOptions()
+		g.Fgenf(&result, "\n%sAliases =\n%s{", g.Indent, g.Indent)
+		g.Indented(func() {
+			tuple, ok := opts.Aliases.(*model.TupleConsExpression)
+			if !ok {
+				return
+			}
+			for _, alias := range tuple.Expressions {
+				g.Fgenf(&result, "\n%snew Alias\n%s{", g.Indent, g.Indent)
+				g.Indented(func() {
+					obj, ok := alias.(*model.ObjectConsExpression)
+					if !ok {
+						g.Fgenf(&result, "\n%sUrn = %v,", g.Indent, g.lowerExpression(alias, alias.Type()))
+						return
+					}
+					for _, item := range obj.Items {
+						key := item.Key.(*model.LiteralValueExpression).Value.AsString()
+						g.Fgenf(&result, "\n%s%s = %v,", g.Indent, Title(key),
+							g.lowerExpression(item.Value, item.Value.Type()))
+					}
+				})
+				g.Fgenf(&result, "\n%s},", g.Indent)
+			}
+		})
+		g.Fgenf(&result, "\n%s},", g.Indent)
+	}
+	if opts.CustomTimeouts != nil {
+		openOptions()
+		g.Fgenf(&result, "\n%sCustomTimeouts = new CustomTimeouts\n%s{", g.Indent, g.Indent)
+		g.Indented(func() {
+			for _, timeout := range hcl2.CustomTimeouts(opts.CustomTimeouts) {
+				g.Fgenf(&result, "\n%s%s = %s,", g.Indent, Title(timeout.Operation), timeSpan(timeout.Duration))
+			}
+		})
+		g.Fgenf(&result, "\n%s},", g.Indent)
+	}
+	if opts.DeleteBeforeReplace != nil {
+		appendOption("DeleteBeforeReplace", opts.DeleteBeforeReplace)
+	}
+	if opts.Version != nil {
+		appendOption("Version", opts.Version)
+	}
 
 	if result.Len() != 0 {
 		g.Indent = g.Indent[:len(g.Indent)-4]
@@ -387,14 +527,156 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	return result.String()
 }
 
+// timeSpan generates a C# expression of type System.TimeSpan for the given duration. The expression uses the largest
+// unit that represents the duration exactly, e.g. System.TimeSpan.FromMinutes(5) for a duration of 5m.
+func timeSpan(d time.Duration) string {
+	units := []struct {
+		method string
+		unit   time.Duration
+	}{
+		{"FromHours", time.Hour},
+		{"FromMinutes", time.Minute},
+		{"FromSeconds", time.Second},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("System.TimeSpan.%s(%d)", u.method, d/u.unit)
+		}
+	}
+	return fmt.Sprintf("System.TimeSpan.FromMilliseconds(%g)", float64(d)/float64(time.Millisecond))
+}
+
+// genResourceList generates a C# expression of type IEnumerable<Resource> for a computed list of resources, e.g. the
+// value of a dependsOn option that contains for expressions, conditionals, or references to ranged resources.
+func (g *generator) genResourceList(w io.Writer, expr model.Expression) {
+	// The list itself is not lowered: lowering would turn its for expressions into calls to the map intrinsic and wrap
+	// its parts in conversions. Only the individual resources, conditions, and collections are lowered.
+	lower := func(x model.Expression) model.Expression {
+		return g.lowerExpression(x, x.Type())
+	}
+
+	switch expr := expr.(type) {
+	case *model.TupleConsExpression:
+		// Group runs of individual resources into array literals and concatenate them with any nested lists.
+		var parts []func()
+		var singles []model.Expression
+		flush := func() {
+			if len(singles) == 0 {
+				return
+			}
+			items := singles
+			parts = append(parts, func() {
+				g.Fgen(w, "new Resource[] { ")
+				for i, item := range items {
+					if i > 0 {
+						g.Fgen(w, ", ")
+					}
+					g.Fgenf(w, "%.v", lower(item))
+				}
+				g.Fgen(w, " }")
+			})
+			singles = nil
+		}
+		for _, item := range expr.Expressions {
+			switch model.ResolveOutputs(item.Type()).(type) {
+			case *model.ListType, *model.TupleType, *model.UnionType:
+				flush()
+				item := item
+				parts = append(parts, func() { g.genResourceList(w, item) })
+			default:
+				singles = append(singles, item)
+			}
+		}
+		flush()
+
+		if len(parts) == 0 {
+			g.Fgen(w, "new Resource[] { }")
+			return
+		}
+		parts[0]()
+		for _, part := range parts[1:] {
+			g.Fgen(w, ".Concat(")
+			part()
+			g.Fgen(w, ")")
+		}
+	case *model.ConditionalExpression:
+		g.Fgenf(w, "(%.4v ? (IEnumerable<Resource>)", lower(expr.Condition))
+		g.genResourceList(w, expr.TrueResult)
+		g.Fgen(w, " : ")
+		g.genResourceList(w, expr.FalseResult)
+		g.Fgen(w, ")")
+	case *model.ForExpression:
+		params := expr.ValueVariable.Name
+		if expr.KeyVariable != nil {
+			// The indexed overloads of Where and Select number the elements of their own source, so the value may only
+			// refer to the key if no elements have been filtered out.
+			switch model.ResolveOutputs(expr.Collection.Type()).(type) {
+			case *model.ListType, *model.TupleType:
+				if expr.Condition != nil && isVariableReferenced(expr.Value, expr.KeyVariable) {
+					g.genNYI(w, expr, "filtered for expressions that refer to their keys are not supported in resource lists")
+					return
+				}
+			default:
+				g.genNYI(w, expr, "keyed for expressions over maps are not supported in resource lists")
+				return
+			}
+			params = fmt.Sprintf("(%s, %s)", expr.ValueVariable.Name, expr.KeyVariable.Name)
+		}
+		g.Fgenf(w, "%.20v", lower(expr.Collection))
+		if expr.Condition != nil {
+			g.Fgenf(w, ".Where(%s => %.v)", params, lower(expr.Condition))
+		}
+		switch model.ResolveOutputs(expr.Value.Type()).(type) {
+		case *model.ListType, *model.TupleType, *model.UnionType:
+			g.Fgenf(w, ".SelectMany(%s => ", params)
+			g.genResourceList(w, expr.Value)
+			g.Fgen(w, ")")
+		default:
+			g.Fgenf(w, ".Select(%s => (Resource)%.v)", params, lower(expr.Value))
+		}
+	default:
+		switch model.ResolveOutputs(expr.Type()).(type) {
+		case *model.ListType, *model.TupleType:
+			g.Fgenf(w, "%.20v.Cast<Resource>()", lower(expr))
+		default:
+			if lit, ok := expr.(*model.LiteralValueExpression); ok && lit.Value.IsNull() {
+				g.Fgen(w, "new Resource[] { }")
+				return
+			}
+			g.Fgenf(w, "new Resource[] { %.v }", lower(expr))
+		}
+	}
+}
+
+// isVariableReferenced returns true if the given expression refers to v.
+func isVariableReferenced(expr model.Expression, v *model.Variable) bool {
+	referenced := false
+	visitor := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		if traversal, ok := x.(*model.ScopeTraversalExpression); ok && traversal.Parts[0] == v {
+			referenced = true
+		}
+		return x, nil
+	}
+	_, diags := model.VisitExpression(expr, visitor, model.IdentityVisitor)
+	contract.Assert(len(diags) == 0)
+	return referenced
+}
+
 // genResource handles the generation of instantiations of non-builtin resources.
 func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
-	qualifiedMemberName := g.resourceTypeName(r)
+	var qualifiedMemberName string
+	optionsType := "CustomResourceOptions"
+	if r.Component != nil {
+		qualifiedMemberName, optionsType = componentClassName(r.Component), "ComponentResourceOptions"
+	} else {
+		qualifiedMemberName = g.resourceTypeName(r)
+	}
 
 	// Add conversions to input properties
 	for _, input := range r.Inputs {
 		destType, diagnostics := r.InputType.Traverse(hcl.TraverseAttr{Name: input.Name})
 		g.diagnostics = append(g.diagnostics, diagnostics...)
+		input.Value = hcl2.RewriteSecrets(input.Value, destType.(model.Type))
 		input.Value = g.lowerExpression(input.Value, destType.(model.Type))
 	}
 
@@ -416,14 +698,18 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 				g.Fgenf(w, " %.v,\n", attr.Value)
 			}
 		})
-		g.Fgenf(w, "%s}%s)", g.Indent, g.genResourceOptions(r.Options))
+		g.Fgenf(w, "%s}%s)", g.Indent, g.genResourceOptions(r.Options, optionsType))
 	}
 
 	if r.Options != nil && r.Options.Range != nil {
 		rangeType := model.ResolveOutputs(r.Options.Range.Type())
 		rangeExpr := g.lowerExpression(r.Options.Range, rangeType)
 
-		g.Fgenf(w, "%svar %s = new List<%s>();\n", g.Indent, variableName, qualifiedMemberName)
+		if r.HasMapRange() {
+			g.Fgenf(w, "%svar %s = new Dictionary<string, %s>();\n", g.Indent, variableName, qualifiedMemberName)
+		} else {
+			g.Fgenf(w, "%svar %s = new List<%s>();\n", g.Indent, variableName, qualifiedMemberName)
+		}
 
 		resKey := "Key"
 		if model.InputType(model.NumberType).ConversionFrom(rangeExpr.Type()) != model.NoConversion {
@@ -442,7 +728,11 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 
 		resName := g.makeResourceName(name, "range."+resKey)
 		g.Indented(func() {
-			g.Fgenf(w, "%s%s.Add(", g.Indent, variableName)
+			if r.HasMapRange() {
+				g.Fgenf(w, "%s%s.Add(range.Key, ", g.Indent, variableName)
+			} else {
+				g.Fgenf(w, "%s%s.Add(", g.Indent, variableName)
+			}
 			instantiate(resName)
 			g.Fgenf(w, ");\n")
 		})
@@ -457,11 +747,25 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 }
 
 func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
-	if !g.configCreated {
+	configObject, name, key := "config", v.Name(), v.Name()
+	if namespace := v.Namespace(); namespace != "" {
+		configObject, key = makeValidIdentifier(namespace+"Config"), v.Key()
+		name = makeValidIdentifier(v.VariableName(g.program))
+		if !g.configNamespaces.Has(namespace) {
+			g.Fprintf(w, "%svar %s = new Config(\"%s\");\n", g.Indent, configObject, namespace)
+			g.configNamespaces.Add(namespace)
+		}
+	} else if !g.configCreated {
 		g.Fprintf(w, "%svar config = new Config();\n", g.Indent)
 		g.configCreated = true
 	}
 
+	if v.ProviderConfig != nil {
+		if description := hcl2.DescribeDefaultValue(v.ProviderConfig); description != "" {
+			g.Fgenf(w, "%s// %s %s.\n", g.Indent, v.Name(), description)
+		}
+	}
+
 	getType := "Object<dynamic>"
 	switch v.Type() {
 	case model.StringType:
@@ -477,7 +781,7 @@ func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
 		getOrRequire = "Require"
 	}
 
-	g.Fgenf(w, "%[1]svar %[2]s = config.%[3]s%[4]s(\"%[2]s\")", g.Indent, v.Name(), getOrRequire, getType)
+	g.Fgenf(w, "%svar %s = %s.%s%s(\"%s\")", g.Indent, name, configObject, getOrRequire, getType, key)
 	if v.DefaultValue != nil {
 		g.Fgenf(w, " ?? %.v", g.lowerExpression(v.DefaultValue, v.DefaultValue.Type()))
 	}
@@ -506,12 +810,10 @@ func (g *generator) genOutputProperty(w io.Writer, v *hcl2.OutputVariable) {
 	g.Fgenf(w, "%spublic Output<string> %s { get; set; }\n", g.Indent, propertyName(v.Name()))
 }
 
-func (g *generator) genNYI(w io.Writer, reason string, vs ...interface{}) {
-	message := fmt.Sprintf("not yet implemented: %s", fmt.Sprintf(reason, vs...))
-	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
-		Severity: hcl.DiagError,
-		Summary:  message,
-		Detail:   message,
-	})
-	g.Fgenf(w, "\"TODO: %s\"", fmt.Sprintf(reason, vs...))
+// genNYI replaces an untranslatable expression with a commented null.
+func (g *generator) genNYI(w io.Writer, expr model.Expression, reason string, vs ...interface{}) {
+	construct := g.program.NewUnsupportedConstruct(expr, reason, vs...)
+	g.diagnostics = append(g.diagnostics, construct.Diagnostic())
+	construct.Report(g.report)
+	g.Fgenf(w, "/* %s */ null", construct.Comment())
 }
@@ -0,0 +1,138 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotnet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+)
+
+// thisVariable is the variable that refers to the component whose constructor is being generated. Resources inside of
+// a component use it as their default parent.
+var thisVariable = &model.Variable{
+	Name:         "this",
+	VariableType: model.DynamicType,
+}
+
+// componentClassName returns the name of the class generated for the given component.
+func componentClassName(c *hcl2.Component) string {
+	return propertyName(c.Name())
+}
+
+// componentTypeName returns the C# type for a value of the given type. Types that have no simple C# equivalent are
+// typed as object.
+func componentTypeName(t model.Type) string {
+	switch t := t.(type) {
+	case *model.ListType:
+		return fmt.Sprintf("ImmutableArray<%s>", componentTypeName(t.ElementType))
+	case *model.MapType:
+		return fmt.Sprintf("ImmutableDictionary<string, %s>", componentTypeName(t.ElementType))
+	}
+
+	switch t {
+	case model.BoolType:
+		return "bool"
+	case model.IntType:
+		return "int"
+	case model.NumberType:
+		return "double"
+	case model.StringType:
+		return "string"
+	default:
+		return "object"
+	}
+}
+
+// componentInputTypeName returns the C# type of the argument property for a component input of the given type.
+func componentInputTypeName(t model.Type) string {
+	switch t := t.(type) {
+	case *model.ListType:
+		return fmt.Sprintf("InputList<%s>", componentTypeName(t.ElementType))
+	case *model.MapType:
+		return fmt.Sprintf("InputMap<%s>", componentTypeName(t.ElementType))
+	default:
+		return fmt.Sprintf("Input<%s>", componentTypeName(t))
+	}
+}
+
+// genComponent generates an arguments class and a ComponentResource class for a component. The resources inside of the
+// component are children of the component, and their names are prefixed with the name of the component instance.
+func (g *generator) genComponent(w io.Writer, c *hcl2.Component) {
+	className := componentClassName(c)
+	inputType := c.InputType.(*model.ObjectType)
+	outputType := c.OutputType.(*model.ObjectType)
+
+	g.Fgenf(w, "%sclass %sArgs\n", g.Indent, className)
+	g.Fgenf(w, "%s{\n", g.Indent)
+	g.Indented(func() {
+		for _, input := range c.Inputs {
+			g.Fgenf(w, "%spublic %s %s { get; set; } = null!;\n", g.Indent,
+				componentInputTypeName(inputType.Properties[input.Name]), propertyName(input.Name))
+		}
+	})
+	g.Fgenf(w, "%s}\n\n", g.Indent)
+
+	g.Fgenf(w, "%sclass %s : ComponentResource\n", g.Indent, className)
+	g.Fgenf(w, "%s{\n", g.Indent)
+	g.Indented(func() {
+		for _, output := range c.Outputs {
+			typeName := componentTypeName(model.ResolveOutputs(outputType.Properties[output.Name]))
+			g.Fgenf(w, "%spublic Output<%s> %s { get; }\n", g.Indent, typeName, propertyName(output.Name))
+		}
+		if len(c.Outputs) != 0 {
+			g.Fgenf(w, "\n")
+		}
+
+		g.Fgenf(w, "%spublic %s(string name, %sArgs args, ComponentResourceOptions? options = null)\n", g.Indent,
+			className, className)
+		g.Fgenf(w, "%s    : base(\"components:index:%s\", name, options)\n", g.Indent, className)
+		g.Fgenf(w, "%s{\n", g.Indent)
+		g.Indented(func() {
+			for _, input := range c.Inputs {
+				g.Fgenf(w, "%svar %s = args.%s.ToOutput();\n", g.Indent, makeValidIdentifier(input.Name),
+					propertyName(input.Name))
+			}
+
+			g.component = c
+			for _, n := range hcl2.LinearizeComponent(c) {
+				g.genNode(w, n)
+			}
+			g.component = nil
+
+			for _, output := range c.Outputs {
+				value := g.lowerExpression(output.Value, output.Type())
+				if _, isOutput := value.Type().(*model.OutputType); isOutput {
+					g.Fgenf(w, "%sthis.%s = %v;\n", g.Indent, propertyName(output.Name), value)
+				} else {
+					g.Fgenf(w, "%sthis.%s = Output.Create(%v);\n", g.Indent, propertyName(output.Name), value)
+				}
+			}
+
+			g.Fgenf(w, "%sthis.RegisterOutputs(new Dictionary<string, object?>\n", g.Indent)
+			g.Fgenf(w, "%s{\n", g.Indent)
+			g.Indented(func() {
+				for _, output := range c.Outputs {
+					g.Fgenf(w, "%s{ \"%s\", this.%s },\n", g.Indent, output.Name, propertyName(output.Name))
+				}
+			})
+			g.Fgenf(w, "%s});\n", g.Indent)
+		})
+		g.Fgenf(w, "%s}\n", g.Indent)
+	})
+	g.Fgenf(w, "%s}\n\n", g.Indent)
+}
@@ -47,9 +47,18 @@ func (g *generator) lowerExpression(expr model.Expression, typ model.Type) model
 	} else {
 		expr = g.outputInvokes(expr)
 	}
+	expr, temps, diags := hcl2.NewLowerer(loweringOptions).Lower(expr)
+	contract.Assert(len(temps) == 0 && len(diags) == 0)
 	return expr
 }
 
+// loweringOptions lowers splat expressions and for expressions into calls to the map intrinsic, which are generated as
+// calls to LINQ's Select and Where methods.
+var loweringOptions = hcl2.LoweringOptions{
+	SplatExpressions: hcl2.LowerToMap,
+	ForExpressions:   hcl2.LowerToMap,
+}
+
 // outputInvokes wraps each call to `invoke` with a call to the `output` intrinsic. This rewrite should only be used if
 // resources are instantiated within a stack constructor, where `await` operator is not available. We want to avoid the
 // nastiness of working with raw `Task` and wrap it into Pulumi's Output immediately to be able to `Apply` on it.
@@ -210,7 +219,7 @@ func (g *generator) GenConditionalExpression(w io.Writer, expr *model.Conditiona
 }
 
 func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) {
-	g.genNYI(w, "ForExpression")
+	g.genNYI(w, expr, "for expressions are not supported")
 }
 
 func (g *generator) genApply(w io.Writer, expr *model.FunctionCallExpression) {
@@ -235,7 +244,7 @@ func (g *generator) genApply(w io.Writer, expr *model.FunctionCallExpression) {
 }
 
 func (g *generator) genRange(w io.Writer, call *model.FunctionCallExpression, entries bool) {
-	g.genNYI(w, "Range %.v %.v", call, entries)
+	g.genNYI(w, call, "function %v is not supported", call.Name)
 }
 
 var functionNamespaces = map[string][]string{
@@ -245,6 +254,12 @@ var functionNamespaces = map[string][]string{
 }
 
 func (g *generator) genFunctionUsings(x *model.FunctionCallExpression) []string {
+	if x.Name == "toJSON" && model.ContainsOutputs(x.Signature.ReturnType) {
+		return []string{"System.Collections.Generic"}
+	}
+	if emitter, ok := g.program.IntrinsicEmitter(x.Name, "csharp"); ok {
+		return emitter.Imports
+	}
 	if x.Name != hcl2.Invoke {
 		return functionNamespaces[x.Name]
 	}
@@ -264,6 +279,13 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		}
 	case hcl2.IntrinsicApply:
 		g.genApply(w, expr)
+	case hcl2.IntrinsicMap:
+		collection, then, filter := hcl2.ParseMapCall(expr)
+		g.Fgenf(w, "%.20v", collection)
+		if filter != nil {
+			g.Fgenf(w, ".Where(%.v)", filter)
+		}
+		g.Fgenf(w, ".Select(%.v).ToList()", then)
 	case intrinsicAwait:
 		g.Fgenf(w, "await %.17v", expr.Args[0])
 	case intrinsicOutput:
@@ -279,7 +301,8 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 			}
 			g.Fgenf(w, "%.20v.Select((v, k)", expr.Args[0])
 		case *model.MapType, *model.ObjectType:
-			g.genNYI(w, "MapOrObjectEntries")
+			g.Fgenf(w, "%.20v.Select(pair => new { pair.Key, pair.Value })", expr.Args[0])
+			return
 		}
 		g.Fgenf(w, " => new { Key = k, Value = v })")
 	case "fileArchive":
@@ -315,11 +338,21 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 	case "split":
 		g.Fgenf(w, "%.20v.Split(%v)", expr.Args[1], expr.Args[0])
 	case "toJSON":
-		g.Fgen(w, "JsonSerializer.Serialize(")
-		g.genDictionary(w, expr.Args[0])
-		g.Fgen(w, ")")
+		if model.ContainsOutputs(expr.Signature.ReturnType) {
+			g.Fgen(w, "Output.JsonSerialize(Output.Create(")
+			g.genDictionary(w, expr.Args[0])
+			g.Fgen(w, "))")
+		} else {
+			g.Fgen(w, "JsonSerializer.Serialize(")
+			g.genDictionary(w, expr.Args[0])
+			g.Fgen(w, ")")
+		}
 	default:
-		g.genNYI(w, "call %v", expr.Name)
+		if emitter, ok := g.program.IntrinsicEmitter(expr.Name, "csharp"); ok {
+			emitter.Emit(g.Formatter, w, expr)
+			return
+		}
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 	}
 }
 
@@ -337,7 +370,17 @@ func (g *generator) genDictionary(w io.Writer, expr model.Expression) {
 		})
 		g.Fgenf(w, "%s}", g.Indent)
 	case *model.TupleConsExpression:
-		g.Fgen(w, "new[]\n")
+		// An implicitly-typed array needs a best type for its elements, which e.g. a string and an output do not have.
+		arrayType := "new[]"
+		if tuple, ok := expr.Type().(*model.TupleType); ok && len(tuple.ElementTypes) > 0 {
+			for _, t := range tuple.ElementTypes[1:] {
+				if !t.Equals(tuple.ElementTypes[0]) {
+					arrayType = "new object?[]"
+					break
+				}
+			}
+		}
+		g.Fgenf(w, "%s\n", arrayType)
 		g.Indented(func() {
 			g.Fgenf(w, "%[1]s{\n", g.Indent)
 			g.Indented(func() {
@@ -478,7 +521,12 @@ func (g *generator) genRelativeTraversal(w io.Writer,
 
 		switch key.Type() {
 		case cty.String:
-			g.Fgenf(w, ".%s", propertyName(key.AsString()))
+			// Maps, e.g. of resources created by ranging over a map, are indexed by key.
+			if _, isMap := model.ResolveOutputs(model.GetTraversableType(parts[i])).(*model.MapType); isMap {
+				g.Fgenf(w, "[%q]", key.AsString())
+			} else {
+				g.Fgenf(w, ".%s", propertyName(key.AsString()))
+			}
 		case cty.Number:
 			idx, _ := key.AsBigFloat().Int64()
 			g.Fgenf(w, "[%d]", idx)
@@ -495,15 +543,26 @@ func (g *generator) GenRelativeTraversalExpression(w io.Writer, expr *model.Rela
 
 func (g *generator) GenScopeTraversalExpression(w io.Writer, expr *model.ScopeTraversalExpression) {
 	rootName := makeValidIdentifier(expr.RootName)
-	if _, ok := expr.Parts[0].(*model.SplatVariable); ok {
-		rootName = "__item"
+	switch expr.Parts[0] {
+	case thisVariable:
+		rootName = "this"
+	default:
+		switch v := expr.Parts[0].(type) {
+		case *model.SplatVariable:
+			rootName = "__item"
+		case *model.Variable:
+			// `each` is an alias for the range variable of a ranged resource.
+			if v.Name == "range" {
+				rootName = "range"
+			}
+		}
 	}
 
 	g.Fgen(w, rootName)
 
 	var objType *schema.ObjectType
 	if resource, ok := expr.Parts[0].(*hcl2.Resource); ok {
-		if schemaType, ok := hcl2.GetSchemaForType(resource.InputType); ok {
+		if schemaType, ok := g.program.GetSchemaForType(resource.InputType); ok {
 			objType, _ = schemaType.(*schema.ObjectType)
 		}
 	}
@@ -545,7 +604,7 @@ func (g *generator) GenTemplateExpression(w io.Writer, expr *model.TemplateExpre
 }
 
 func (g *generator) GenTemplateJoinExpression(w io.Writer, expr *model.TemplateJoinExpression) {
-	g.genNYI(w, "TemplateJoinExpression")
+	g.genNYI(w, expr, "template join expressions are not supported")
 }
 
 func (g *generator) GenTupleConsExpression(w io.Writer, expr *model.TupleConsExpression) {
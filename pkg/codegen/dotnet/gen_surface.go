@@ -0,0 +1,139 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotnet
+
+import (
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// SDKSurface returns the exported symbols of the .NET SDK that GeneratePackage generates for the given package, named
+// by their fully qualified C# names.
+func SDKSurface(tool string, pkg *schema.Package) (codegen.Surface, error) {
+	if err := pkg.ImportLanguages(map[string]schema.Language{"csharp": Importer}); err != nil {
+		return nil, err
+	}
+	info, _ := pkg.Language["csharp"].(CSharpPackageInfo)
+
+	modules, err := generateModuleContextMap(tool, pkg, info)
+	if err != nil {
+		return nil, err
+	}
+
+	surface := codegen.Surface{}
+	for _, mod := range modules {
+		module := mod.namespaceName
+		addClass := func(namespace, name, token, parent string) string {
+			return surface.Add(namespace+"."+name, codegen.SurfaceSymbol{
+				Module:    module,
+				Parent:    parent,
+				Token:     token,
+				Signature: "class",
+			})
+		}
+		addInputs := func(class string, props []*schema.Property, state, wrapInput bool) {
+			for _, p := range props {
+				surface.Add(class+"."+mod.propertyName(p), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    class,
+					Signature: mod.typeString(p.Type, "Inputs", true, state, wrapInput, false, !p.IsRequired),
+					Argument:  true,
+					Optional:  !p.IsRequired,
+				})
+			}
+		}
+		addOutputs := func(class string, props []*schema.Property) {
+			for _, p := range props {
+				required := p.IsRequired || mod.isK8sCompatMode()
+				surface.Add(class+"."+mod.propertyName(p), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    class,
+					Signature: mod.typeString(p.Type, "Outputs", false, false, false, false, !required),
+				})
+			}
+		}
+
+		for _, r := range mod.resources {
+			name := resourceName(r)
+			res := addClass(module, name, r.Token, "")
+			for _, p := range r.Properties {
+				required := p.IsRequired || mod.isK8sCompatMode()
+				propertyType := mod.typeString(p.Type, "Outputs", false, false, false, false, !required)
+				if r.IsProvider && !schema.IsPrimitiveType(p.Type) {
+					propertyType = "string"
+					if !p.IsRequired {
+						propertyType += "?"
+					}
+				}
+				surface.Add(res+"."+mod.propertyName(p), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    res,
+					Signature: "Output<" + propertyType + ">",
+				})
+			}
+
+			args := addClass(module, name+"Args", "", res)
+			addInputs(args, r.InputProperties, false, true)
+			if r.StateInputs != nil {
+				state := addClass(module, name+"State", "", res)
+				addInputs(state, r.StateInputs.Properties, true, true)
+			}
+		}
+
+		for _, f := range mod.functions {
+			name := tokenToFunctionName(f.Token)
+			fn := addClass(module, name, f.Token, "")
+			if f.Inputs != nil {
+				args := addClass(module, name+"Args", "", fn)
+				addInputs(args, f.Inputs.Properties, false, false)
+			}
+			if f.Outputs != nil {
+				result := addClass(module, name+"Result", "", fn)
+				addOutputs(result, f.Outputs.Properties)
+			}
+		}
+
+		for _, t := range mod.types {
+			details, name := mod.details(t), tokenToName(t.Token)
+			if details.inputType {
+				args := addClass(mod.tokenToNamespace(t.Token, "Inputs"), name+"Args", t.Token+"#input", "")
+				addInputs(args, t.Properties, false, !details.functionType)
+			}
+			if details.stateType {
+				state := addClass(mod.tokenToNamespace(t.Token, "Inputs"), name+"GetArgs", t.Token+"#state", "")
+				addInputs(state, t.Properties, true, true)
+			}
+			if details.outputType {
+				suffix := ""
+				if details.functionType {
+					suffix = "Result"
+				}
+				output := addClass(mod.tokenToNamespace(t.Token, "Outputs"), name+suffix, t.Token+"#output", "")
+				addOutputs(output, t.Properties)
+			}
+		}
+
+		if mod.mod == "config" {
+			for _, p := range pkg.Config {
+				propertyType, _ := mod.getConfigProperty(p.Type)
+				surface.Add(module+".Config."+mod.propertyName(p), codegen.SurfaceSymbol{
+					Module:    module,
+					Signature: propertyType,
+				})
+			}
+		}
+	}
+	return surface, nil
+}
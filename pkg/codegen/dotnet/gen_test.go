@@ -0,0 +1,83 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestUnionTypeString(t *testing.T) {
+	mod := &modContext{namespaceName: "Pulumi.Test"}
+	union := &schema.UnionType{
+		ElementTypes: []schema.Type{schema.IntType, schema.BoolType, schema.StringType},
+		DefaultType:  schema.StringType,
+	}
+
+	// Input unions start with their default member.
+	assert.Equal(t, "InputUnion<string, InputUnion<int, bool>>",
+		mod.typeString(union, "", true, false, true, false, false))
+	// Output unions keep the order of the schema.
+	assert.Equal(t, "Union<int, Union<bool, string>>",
+		mod.typeString(union, "", false, false, false, false, false))
+
+	// Unions without a default keep the order of the schema.
+	union.DefaultType = nil
+	assert.Equal(t, "InputUnion<int, InputUnion<bool, string>>",
+		mod.typeString(union, "", true, false, true, false, false))
+}
+
+func TestGenerateEnums(t *testing.T) {
+	var spec schema.PackageSpec
+	err := json.Unmarshal([]byte(`{
+		"name": "example",
+		"version": "1.0.0",
+		"types": {
+			"example:index:Size": {
+				"type": "integer",
+				"description": "The size of a widget.",
+				"enum": [
+					{"name": "Small", "value": 1, "description": "A small widget."},
+					{"name": "Large", "value": 2, "deprecationMessage": "Use Small instead."}
+				]
+			},
+			"example:index:Region": {
+				"type": "string",
+				"enum": [{"value": "us-east-1"}, {"value": "eu-west-2"}]
+			}
+		}
+	}`), &spec)
+	assert.NoError(t, err)
+	pkg, err := schema.ImportSpec(spec, nil)
+	assert.NoError(t, err)
+
+	// Generate the modules directly rather than the whole package, whose metadata includes a downloaded icon.
+	modules, err := generateModuleContextMap("test", pkg, CSharpPackageInfo{})
+	assert.NoError(t, err)
+	files := fs{}
+	assert.NoError(t, modules[""].gen(files))
+	enums := string(files["Enums.cs"])
+	assert.Contains(t, enums, "    public readonly struct Size : IEquatable<Size>\n")
+	assert.Contains(t, enums, "        [Obsolete(@\"Use Small instead.\")]\n        public static Size Large { get; } = new Size(2);\n")
+	assert.Contains(t, enums, "        public static ImmutableArray<Size> Values => ImmutableArray.Create(new Size(1), new Size(2));\n")
+	assert.Contains(t, enums, "        public static Size Parse(string s)\n")
+	assert.Contains(t, enums, "        public static bool IsValid(int value) => Values.Contains(new Size(value));\n")
+	assert.Contains(t, enums, "        public static Region UsEast1 { get; } = new Region(\"us-east-1\");\n")
+	assert.Contains(t, enums, "        public override string ToString() => _value;\n")
+}
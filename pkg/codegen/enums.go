@@ -0,0 +1,62 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// EnumMemberNames returns the PascalCase names of the values of the given enum type, in order. A value that has no
+// name in the schema is named after the value itself, e.g. "us-east-1" becomes "UsEast1" and 42 becomes "Value42".
+// It is an error for two values to end up with the same name.
+func EnumMemberNames(t *schema.EnumType) ([]string, error) {
+	names, seen := make([]string, len(t.Elements)), NewStringSet()
+	for i, e := range t.Elements {
+		name := e.Name
+		if name == "" {
+			name = fmt.Sprintf("%v", e.Value)
+		}
+
+		// Title-case each run of letters and digits and drop everything else.
+		var b strings.Builder
+		for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			runes := []rune(word)
+			b.WriteRune(unicode.ToUpper(runes[0]))
+			b.WriteString(string(runes[1:]))
+		}
+		name = b.String()
+
+		switch {
+		case name == "":
+			return nil, errors.Errorf("cannot derive a name for value %v of enum type %v", e.Value, t.Token)
+		case unicode.IsDigit([]rune(name)[0]):
+			name = "Value" + name
+		}
+		if seen.Has(name) {
+			return nil, errors.Errorf("enum type %v has more than one value named %v", t.Token, name)
+		}
+		seen.Add(name)
+		names[i] = name
+	}
+	return names, nil
+}
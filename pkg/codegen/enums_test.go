@@ -0,0 +1,51 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumMemberNames(t *testing.T) {
+	names, err := EnumMemberNames(&schema.EnumType{
+		Token: "aws:index:Region",
+		Elements: []*schema.Enum{
+			{Value: "us-east-1"},
+			{Value: "eu_west_1", Name: "Ireland"},
+			{Value: "ap south"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"UsEast1", "Ireland", "ApSouth"}, names)
+
+	names, err = EnumMemberNames(&schema.EnumType{
+		Token:    "aws:index:Size",
+		Elements: []*schema.Enum{{Value: int32(1)}, {Value: 2.5}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Value1", "Value25"}, names)
+
+	_, err = EnumMemberNames(&schema.EnumType{
+		Token:    "aws:index:Size",
+		Elements: []*schema.Enum{{Value: int32(1)}, {Value: int32(-1)}},
+	})
+	assert.EqualError(t, err, "enum type aws:index:Size has more than one value named Value1")
+
+	_, err = EnumMemberNames(&schema.EnumType{Token: "aws:index:Symbol", Elements: []*schema.Enum{{Value: "*"}}})
+	assert.Error(t, err)
+}
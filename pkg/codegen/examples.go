@@ -0,0 +1,139 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pgavlin/goldmark/ast"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// Example is a code snippet taken from an example in the description of a package, resource, or function.
+type Example struct {
+	// Token is the token of the resource or function whose description contains the example. The token is empty for
+	// examples in the package's description.
+	Token string
+	// Title is the title of the example, if it has one.
+	Title string
+	// Language is the language of the snippet as written in its code fence, e.g. "typescript" or "csharp".
+	Language string
+	// Code is the snippet's source code.
+	Code string
+}
+
+// ExtractExamples returns the snippets in the given language from the examples in a description. Only code blocks
+// inside of an `{{% example %}}` shortcode are considered to be examples. The token is recorded in each example.
+func ExtractExamples(token, description, language string) []Example {
+	if description == "" {
+		return nil
+	}
+
+	source := []byte(description)
+	parsed := schema.ParseDocs(source)
+
+	var examples []Example
+	var example *schema.Shortcode
+	var title string
+	err := ast.Walk(parsed, func(n ast.Node, enter bool) (ast.WalkStatus, error) {
+		switch n := n.(type) {
+		case *schema.Shortcode:
+			if string(n.Name) == schema.ExampleShortcode {
+				if enter {
+					example, title = n, ""
+				} else {
+					example = nil
+				}
+			}
+		case *ast.Heading:
+			if enter && example != nil && n.Level == 3 && title == "" {
+				title = strings.TrimSpace(string(n.Text(source)))
+			}
+		case *ast.FencedCodeBlock:
+			if enter && example != nil && string(n.Language(source)) == language {
+				var code bytes.Buffer
+				lines := n.Lines()
+				for i := 0; i < lines.Len(); i++ {
+					line := lines.At(i)
+					code.Write(line.Value(source))
+				}
+				examples = append(examples, Example{
+					Token:    token,
+					Title:    title,
+					Language: language,
+					Code:     code.String(),
+				})
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	contract.AssertNoError(err)
+
+	return examples
+}
+
+// PackageExamples returns the snippets in the given language from the examples in the descriptions of a package, its
+// provider, its resources, and its functions, in that order.
+func PackageExamples(pkg *schema.Package, language string) []Example {
+	examples := ExtractExamples("", pkg.Description, language)
+	if pkg.Provider != nil {
+		examples = append(examples, ExtractExamples(pkg.Provider.Token, pkg.Provider.Comment, language)...)
+	}
+	for _, r := range pkg.Resources {
+		examples = append(examples, ExtractExamples(r.Token, r.Comment, language)...)
+	}
+	for _, f := range pkg.Functions {
+		examples = append(examples, ExtractExamples(f.Token, f.Comment, language)...)
+	}
+	return examples
+}
+
+// String returns a description of the example for use in diagnostics, e.g. `aws:s3/bucket:Bucket example "Private
+// Bucket w/ Tags"`.
+func (e Example) String() string {
+	subject := e.Token
+	if subject == "" {
+		subject = "package"
+	}
+	if e.Title == "" {
+		return subject + " example"
+	}
+	return subject + " example " + `"` + e.Title + `"`
+}
+
+// ExampleBuild describes how to compile a single example in an ExampleWorkspace.
+type ExampleBuild struct {
+	// Example is the example to compile.
+	Example Example
+	// Dir is the directory, relative to the root of the workspace, in which to run the build command.
+	Dir string
+	// Command is the build command and its arguments. The command fails if the example does not compile.
+	Command []string
+}
+
+// ExampleWorkspace is a set of files that contains a generated SDK and the examples from its package's schema, laid out
+// so that each example can be compiled against the SDK.
+type ExampleWorkspace struct {
+	// Files maps the paths of the workspace's files, relative to its root, to their contents.
+	Files map[string][]byte
+	// Setup is a list of commands to run in the root of the workspace before the examples are compiled, e.g. to install
+	// dependencies.
+	Setup [][]string
+	// Builds lists the examples and how to compile each of them.
+	Builds []ExampleBuild
+}
@@ -0,0 +1,95 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestExtractExamples(t *testing.T) {
+	description := `Provides a bucket.
+
+` + codeFence + `typescript
+// Not part of an example.
+` + codeFence + `
+
+{{% examples %}}
+## Example Usage
+
+{{% example %}}
+### Private Bucket
+
+` + codeFence + `typescript
+const bucket = new aws.s3.Bucket("b", {acl: "private"});
+` + codeFence + `
+` + codeFence + `python
+bucket = aws.s3.Bucket("b", acl="private")
+` + codeFence + `
+{{% /example %}}
+
+{{% example %}}
+` + codeFence + `typescript
+const website = new aws.s3.Bucket("w", {
+    website: {indexDocument: "index.html"},
+});
+` + codeFence + `
+{{% /example %}}
+{{% /examples %}}`
+
+	examples := ExtractExamples("aws:s3/bucket:Bucket", description, "typescript")
+	assert.Equal(t, []Example{
+		{
+			Token:    "aws:s3/bucket:Bucket",
+			Title:    "Private Bucket",
+			Language: "typescript",
+			Code:     "const bucket = new aws.s3.Bucket(\"b\", {acl: \"private\"});\n",
+		},
+		{
+			Token:    "aws:s3/bucket:Bucket",
+			Language: "typescript",
+			Code:     "const website = new aws.s3.Bucket(\"w\", {\n    website: {indexDocument: \"index.html\"},\n});\n",
+		},
+	}, examples)
+	assert.Equal(t, `aws:s3/bucket:Bucket example "Private Bucket"`, examples[0].String())
+	assert.Equal(t, `aws:s3/bucket:Bucket example`, examples[1].String())
+
+	assert.Len(t, ExtractExamples("aws:s3/bucket:Bucket", description, "python"), 1)
+	assert.Empty(t, ExtractExamples("aws:s3/bucket:Bucket", description, "go"))
+}
+
+func TestPackageExamples(t *testing.T) {
+	example := func(code string) string {
+		return "{{% examples %}}\n{{% example %}}\n" + codeFence + "go\n" + code + "\n" + codeFence +
+			"\n{{% /example %}}\n{{% /examples %}}"
+	}
+
+	pkg := &schema.Package{
+		Description: example("// package"),
+		Provider:    &schema.Resource{Token: "pulumi:providers:example", Comment: example("// provider")},
+		Resources:   []*schema.Resource{{Token: "example::Resource", Comment: example("// resource")}},
+		Functions:   []*schema.Function{{Token: "example::getResource", Comment: example("// function")}},
+	}
+
+	var tokens, code []string
+	for _, e := range PackageExamples(pkg, "go") {
+		tokens, code = append(tokens, e.Token), append(code, e.Code)
+	}
+	assert.Equal(t, []string{"", "pulumi:providers:example", "example::Resource", "example::getResource"}, tokens)
+	assert.Equal(t, []string{"// package\n", "// provider\n", "// resource\n", "// function\n"}, code)
+}
@@ -106,6 +106,7 @@ type pkgContext struct {
 	importBasePath string
 	typeDetails    map[*schema.ObjectType]*typeDetails
 	types          []*schema.ObjectType
+	enums          []*schema.EnumType
 	resources      []*schema.Resource
 	functions      []*schema.Function
 	names          stringSet
@@ -188,6 +189,9 @@ func (pkg *pkgContext) plainType(t schema.Type, optional bool) string {
 		return "map[string]" + pkg.plainType(t.ElementType, false)
 	case *schema.ObjectType:
 		typ = pkg.tokenToType(t.Token)
+	case *schema.EnumType:
+		// Use the element type for now.
+		return pkg.plainType(t.ElementType, optional)
 	case *schema.TokenType:
 		// Use the underlying type for now.
 		if t.UnderlyingType != nil {
@@ -203,10 +207,14 @@ func (pkg *pkgContext) plainType(t schema.Type, optional bool) string {
 			typ = "bool"
 		case schema.IntType:
 			typ = "int"
+		case schema.Int64Type:
+			typ = "int64"
 		case schema.NumberType:
 			typ = "float64"
 		case schema.StringType:
 			typ = "string"
+		case schema.BytesType:
+			typ = "[]byte"
 		case schema.ArchiveType:
 			return "pulumi.Archive"
 		case schema.AssetType:
@@ -235,6 +243,9 @@ func (pkg *pkgContext) inputType(t schema.Type, optional bool) string {
 		return strings.TrimSuffix(en, "Input") + "MapInput"
 	case *schema.ObjectType:
 		typ = pkg.tokenToType(t.Token)
+	case *schema.EnumType:
+		// Use the element type for now.
+		return pkg.inputType(t.ElementType, optional)
 	case *schema.TokenType:
 		// Use the underlying type for now.
 		if t.UnderlyingType != nil {
@@ -250,9 +261,11 @@ func (pkg *pkgContext) inputType(t schema.Type, optional bool) string {
 			typ = "pulumi.Bool"
 		case schema.IntType:
 			typ = "pulumi.Int"
+		case schema.Int64Type:
+			typ = "pulumi.Int64"
 		case schema.NumberType:
 			typ = "pulumi.Float64"
-		case schema.StringType:
+		case schema.StringType, schema.BytesType:
 			typ = "pulumi.String"
 		case schema.ArchiveType:
 			return "pulumi.ArchiveInput"
@@ -288,6 +301,9 @@ func (pkg *pkgContext) outputType(t schema.Type, optional bool) string {
 		return en + "MapOutput"
 	case *schema.ObjectType:
 		typ = pkg.tokenToType(t.Token)
+	case *schema.EnumType:
+		// Use the element type for now.
+		return pkg.outputType(t.ElementType, optional)
 	case *schema.TokenType:
 		// Use the underlying type for now.
 		if t.UnderlyingType != nil {
@@ -303,9 +319,11 @@ func (pkg *pkgContext) outputType(t schema.Type, optional bool) string {
 			typ = "pulumi.Bool"
 		case schema.IntType:
 			typ = "pulumi.Int"
+		case schema.Int64Type:
+			typ = "pulumi.Int64"
 		case schema.NumberType:
 			typ = "pulumi.Float64"
-		case schema.StringType:
+		case schema.StringType, schema.BytesType:
 			typ = "pulumi.String"
 		case schema.ArchiveType:
 			return "pulumi.ArchiveOutput"
@@ -325,7 +343,7 @@ func (pkg *pkgContext) outputType(t schema.Type, optional bool) string {
 }
 
 func printComment(w io.Writer, comment string, indent bool) int {
-	comment = codegen.FilterExamples(comment, "go")
+	comment = codegen.ConvertDocs(codegen.FilterExamples(comment, "go"), codegen.GoDocFormat)
 
 	lines := strings.Split(comment, "\n")
 	for len(lines) > 0 && lines[len(lines)-1] == "" {
@@ -441,7 +459,7 @@ func (pkg *pkgContext) genPlainType(w io.Writer, name, comment, deprecationMessa
 	printCommentWithDeprecationMessage(w, comment, deprecationMessage, false)
 	fmt.Fprintf(w, "type %s struct {\n", name)
 	for _, p := range properties {
-		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+		printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, true)
 		fmt.Fprintf(w, "\t%s %s `pulumi:\"%s\"`\n", Title(p.Name), pkg.plainType(p.Type, !p.IsRequired), p.Name)
 	}
 	fmt.Fprintf(w, "}\n\n")
@@ -456,7 +474,7 @@ func (pkg *pkgContext) genInputTypes(w io.Writer, t *schema.ObjectType, details
 	printComment(w, t.Comment, false)
 	fmt.Fprintf(w, "type %sArgs struct {\n", name)
 	for _, p := range t.Properties {
-		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+		printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, true)
 		fmt.Fprintf(w, "\t%s %s `pulumi:\"%s\"`\n", Title(p.Name), pkg.inputType(p.Type, !p.IsRequired), p.Name)
 	}
 	fmt.Fprintf(w, "}\n\n")
@@ -532,7 +550,7 @@ func (pkg *pkgContext) genOutputTypes(w io.Writer, t *schema.ObjectType, details
 	}
 
 	for _, p := range t.Properties {
-		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, false)
+		printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, false)
 		outputType, applyType := pkg.outputType(p.Type, !p.IsRequired), pkg.plainType(p.Type, !p.IsRequired)
 
 		fmt.Fprintf(w, "func (o %sOutput) %s() %s {\n", name, Title(p.Name), outputType)
@@ -550,7 +568,7 @@ func (pkg *pkgContext) genOutputTypes(w io.Writer, t *schema.ObjectType, details
 		fmt.Fprintf(w, "}\n\n")
 
 		for _, p := range t.Properties {
-			printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, false)
+			printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, false)
 			outputType, applyType := pkg.outputType(p.Type, true), pkg.plainType(p.Type, true)
 			deref := ""
 			// If the property was required, but the type it needs to return is an explicit pointer type, then we need
@@ -607,9 +625,9 @@ func goPrimitiveValue(value interface{}) (string, error) {
 			return "true", nil
 		}
 		return "false", nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return strconv.FormatInt(v.Int(), 10), nil
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return strconv.FormatUint(v.Uint(), 10), nil
 	case reflect.Float32, reflect.Float64:
 		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
@@ -652,6 +670,8 @@ func (pkg *pkgContext) getDefaultValue(dv *schema.DefaultValue, t schema.Type) (
 			parser, typDefault, typ = "parseEnvBool", "false", "bool"
 		case schema.IntType:
 			parser, typDefault, typ = "parseEnvInt", "0", "int"
+		case schema.Int64Type:
+			parser, typDefault, typ = "parseEnvInt64", "0", "int64"
 		case schema.NumberType:
 			parser, typDefault, typ = "parseEnvFloat", "0.0", "float64"
 		}
@@ -683,7 +703,7 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 	}
 	var secretProps []string
 	for _, p := range r.Properties {
-		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+		printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, true)
 		fmt.Fprintf(w, "\t%s %s `pulumi:\"%s\"`\n", Title(p.Name), pkg.outputType(p.Type, !p.IsRequired), p.Name)
 
 		if p.Secret {
@@ -693,7 +713,9 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 	fmt.Fprintf(w, "}\n\n")
 
 	// Create a constructor function that registers a new instance of this resource.
-	fmt.Fprintf(w, "// New%s registers a new resource with the given unique name, arguments, and options.\n", name)
+	printCommentWithDeprecationMessage(w,
+		fmt.Sprintf("New%s registers a new resource with the given unique name, arguments, and options.", name),
+		r.DeprecationMessage, false)
 	fmt.Fprintf(w, "func New%s(ctx *pulumi.Context,\n", name)
 	fmt.Fprintf(w, "\tname string, args *%[1]sArgs, opts ...pulumi.ResourceOption) (*%[1]s, error) {\n", name)
 
@@ -782,8 +804,9 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 
 	// Emit a factory function that reads existing instances of this resource.
 	if !r.IsProvider {
-		fmt.Fprintf(w, "// Get%[1]s gets an existing %[1]s resource's state with the given name, ID, and optional\n", name)
-		fmt.Fprintf(w, "// state properties that are used to uniquely qualify the lookup (nil if not required).\n")
+		printCommentWithDeprecationMessage(w, fmt.Sprintf("Get%[1]s gets an existing %[1]s resource's state with the "+
+			"given name, ID, and optional\nstate properties that are used to uniquely qualify the lookup (nil if not "+
+			"required).", name), r.DeprecationMessage, false)
 		fmt.Fprintf(w, "func Get%s(ctx *pulumi.Context,\n", name)
 		fmt.Fprintf(w, "\tname string, id pulumi.IDInput, state *%[1]sState, opts ...pulumi.ResourceOption) (*%[1]s, error) {\n", name)
 		fmt.Fprintf(w, "\tvar resource %s\n", name)
@@ -798,14 +821,14 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 		fmt.Fprintf(w, "// Input properties used for looking up and filtering %s resources.\n", name)
 		fmt.Fprintf(w, "type %sState struct {\n", camel(name))
 		for _, p := range r.Properties {
-			printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+			printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, true)
 			fmt.Fprintf(w, "\t%s %s `pulumi:\"%s\"`\n", Title(p.Name), pkg.plainType(p.Type, true), p.Name)
 		}
 		fmt.Fprintf(w, "}\n\n")
 
 		fmt.Fprintf(w, "type %sState struct {\n", name)
 		for _, p := range r.Properties {
-			printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+			printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, true)
 			fmt.Fprintf(w, "\t%s %s\n", Title(p.Name), pkg.inputType(p.Type, true))
 		}
 		fmt.Fprintf(w, "}\n\n")
@@ -818,7 +841,7 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 	// Emit the args types.
 	fmt.Fprintf(w, "type %sArgs struct {\n", camel(name))
 	for _, p := range r.InputProperties {
-		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+		printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, true)
 		fmt.Fprintf(w, "\t%s %s `pulumi:\"%s\"`\n", Title(p.Name), pkg.plainType(p.Type, !p.IsRequired), p.Name)
 	}
 	fmt.Fprintf(w, "}\n\n")
@@ -826,7 +849,7 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource) error {
 	fmt.Fprintf(w, "// The set of arguments for constructing a %s resource.\n", name)
 	fmt.Fprintf(w, "type %sArgs struct {\n", name)
 	for _, p := range r.InputProperties {
-		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
+		printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, true)
 		fmt.Fprintf(w, "\t%s %s\n", Title(p.Name), pkg.inputType(p.Type, !p.IsRequired))
 	}
 	fmt.Fprintf(w, "}\n\n")
@@ -905,6 +928,60 @@ func (pkg *pkgContext) genType(w io.Writer, obj *schema.ObjectType) {
 	pkg.genOutputTypes(w, obj, pkg.details(obj))
 }
 
+// genEnum emits the type of an enum along with functions that list, parse, and validate its values.
+func (pkg *pkgContext) genEnum(w io.Writer, t *schema.EnumType) error {
+	memberNames, err := codegen.EnumMemberNames(t)
+	if err != nil {
+		return err
+	}
+	name := pkg.tokenToType(t.Token)
+
+	printComment(w, t.Comment, false)
+	fmt.Fprintf(w, "type %s %s\n\n", name, pkg.plainType(t.ElementType, false))
+
+	fmt.Fprintf(w, "const (\n")
+	for i, e := range t.Elements {
+		value, err := goPrimitiveValue(e.Value)
+		if err != nil {
+			return err
+		}
+		printCommentWithDeprecationMessage(w, e.Comment, e.DeprecationMessage, true)
+		fmt.Fprintf(w, "\t%s%s = %s(%s)\n", name, memberNames[i], name, value)
+	}
+	fmt.Fprintf(w, ")\n\n")
+
+	fmt.Fprintf(w, "// %sValues returns the values of %s.\n", name, name)
+	fmt.Fprintf(w, "func %sValues() []%s {\n", name, name)
+	fmt.Fprintf(w, "\treturn []%s{\n", name)
+	for _, memberName := range memberNames {
+		fmt.Fprintf(w, "\t\t%s%s,\n", name, memberName)
+	}
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "// Parse%s returns the value of %s whose string form is s.\n", name, name)
+	fmt.Fprintf(w, "func Parse%s(s string) (%s, error) {\n", name, name)
+	fmt.Fprintf(w, "\tfor _, v := range %sValues() {\n", name)
+	fmt.Fprintf(w, "\t\tif fmt.Sprint(v) == s {\n")
+	fmt.Fprintf(w, "\t\t\treturn v, nil\n")
+	fmt.Fprintf(w, "\t\t}\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\tvar zero %s\n", name)
+	fmt.Fprintf(w, "\treturn zero, fmt.Errorf(\"%%q is not a valid %s\", s)\n", name)
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "// IsValid returns true if e is one of the values of %s.\n", name)
+	fmt.Fprintf(w, "func (e %s) IsValid() bool {\n", name)
+	fmt.Fprintf(w, "\tfor _, v := range %sValues() {\n", name)
+	fmt.Fprintf(w, "\t\tif v == e {\n")
+	fmt.Fprintf(w, "\t\t\treturn true\n")
+	fmt.Fprintf(w, "\t\t}\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\treturn false\n")
+	fmt.Fprintf(w, "}\n\n")
+	return nil
+}
+
 func (pkg *pkgContext) genTypeRegistrations(w io.Writer, types []*schema.ObjectType) {
 	fmt.Fprintf(w, "func init() {\n")
 	for _, obj := range types {
@@ -1044,21 +1121,9 @@ func (pkg *pkgContext) genConfig(w io.Writer, variables []*schema.Property) erro
 
 	for _, p := range variables {
 		getfunc := "Get"
+		getType, funcType := configType(p.Type)
 
-		var getType string
-		var funcType string
-		switch p.Type {
-		case schema.BoolType:
-			getType, funcType = "bool", "Bool"
-		case schema.IntType:
-			getType, funcType = "int", "Int"
-		case schema.NumberType:
-			getType, funcType = "float64", "Float64"
-		default:
-			getType, funcType = "string", ""
-		}
-
-		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, false)
+		printCommentWithDeprecationMessage(w, codegen.PropertyComment(p), p.DeprecationMessage, false)
 		configKey := fmt.Sprintf("\"%s:%s\"", pkg.pkg.Name, camel(p.Name))
 
 		fmt.Fprintf(w, "func Get%s(ctx *pulumi.Context) %s {\n", Title(p.Name), getType)
@@ -1082,6 +1147,23 @@ func (pkg *pkgContext) genConfig(w io.Writer, variables []*schema.Property) erro
 	return nil
 }
 
+// configType returns the Go type of the given config variable type and the suffix of the config functions that read
+// values of that type.
+func configType(t schema.Type) (string, string) {
+	switch t {
+	case schema.BoolType:
+		return "bool", "Bool"
+	case schema.IntType:
+		return "int", "Int"
+	case schema.Int64Type:
+		return "int64", "Int64"
+	case schema.NumberType:
+		return "float64", "Float64"
+	default:
+		return "string", ""
+	}
+}
+
 // generatePackageContextMap groups resources, types, and functions into Go packages.
 func generatePackageContextMap(tool string, pkg *schema.Package, goInfo GoPackageInfo) map[string]*pkgContext {
 	packages := map[string]*pkgContext{}
@@ -1150,6 +1232,9 @@ func generatePackageContextMap(tool string, pkg *schema.Package, goInfo GoPackag
 			pkg := getPkgFromToken(t.Token)
 			pkg.types = append(pkg.types, t)
 			markOptionalPropertyTypesAsRequiringPtr(seenMap, t.Properties, false)
+		case *schema.EnumType:
+			pkg := getPkgFromToken(t.Token)
+			pkg.enums = append(pkg.enums, t)
 		}
 	}
 
@@ -1251,6 +1336,21 @@ func LanguageResources(tool string, pkg *schema.Package) (map[string]LanguageRes
 	return resources, nil
 }
 
+// genModuleIndex summarizes the resources, functions, and types in the package.
+func (pkg *pkgContext) genModuleIndex() *codegen.ModuleIndex {
+	idx := codegen.NewModuleIndex(pkg.pkg, pkg.mod, pkg.mod)
+	for _, r := range pkg.resources {
+		idx.AddResource(resourceName(r), r)
+	}
+	for _, f := range pkg.functions {
+		idx.AddFunction(pkg.functionNames[f], f)
+	}
+	for _, t := range pkg.types {
+		idx.AddType(pkg.tokenToType(t.Token), t)
+	}
+	return idx
+}
+
 func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error) {
 	if err := pkg.ImportLanguages(map[string]schema.Language{"go": Importer}); err != nil {
 		return nil, err
@@ -1310,6 +1410,18 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 
 				setFile(path.Join(mod, "config.go"), buffer.String())
 			}
+
+		default:
+			if idx := pkg.genModuleIndex(); !idx.IsEmpty() {
+				buffer := &bytes.Buffer{}
+				fmt.Fprintf(buffer, "// Package %[1]s exports types and functions for provisioning %[2]s resources.\n",
+					path.Base(mod), mod)
+				fmt.Fprintf(buffer, "//\n")
+				printComment(buffer, idx.MarkdownBody(), false)
+				fmt.Fprintf(buffer, "package %s\n", path.Base(mod))
+
+				setFile(path.Join(mod, "doc.go"), buffer.String())
+			}
 		}
 
 		// Resources
@@ -1359,6 +1471,20 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 			setFile(path.Join(mod, "pulumiTypes.go"), buffer.String())
 		}
 
+		// Enums
+		if len(pkg.enums) > 0 {
+			buffer := &bytes.Buffer{}
+			pkg.genHeader(buffer, []string{"fmt"}, nil)
+
+			for _, e := range pkg.enums {
+				if err := pkg.genEnum(buffer, e); err != nil {
+					return nil, err
+				}
+			}
+
+			setFile(path.Join(mod, "pulumiEnums.go"), buffer.String())
+		}
+
 		// Utilities
 		if pkg.needsUtils {
 			buffer := &bytes.Buffer{}
@@ -1392,6 +1518,14 @@ func parseEnvInt(v string) interface{} {
 	return int(i)
 }
 
+func parseEnvInt64(v string) interface{} {
+	i, err := strconv.ParseInt(v, 0, 64)
+	if err != nil {
+		return nil
+	}
+	return i
+}
+
 func parseEnvFloat(v string) interface{} {
 	f, err := strconv.ParseFloat(v, 64)
 	if err != nil {
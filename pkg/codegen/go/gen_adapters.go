@@ -0,0 +1,349 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// majorVersionSegment matches the major version suffix of a Go module path, e.g. the "/v2" in
+// "github.com/pulumi/pulumi-aws/sdk/v2/go/aws".
+var majorVersionSegment = regexp.MustCompile(`/v[0-9]+(/|$)`)
+
+// VersionedImportPath returns the import path that corresponds to the given import path in the given major version of a
+// Go module. Go modules with a major version of 2 or more carry the version as a "/vN" path segment, which is added,
+// replaced, or removed as necessary. If the import path has no version segment, the segment is added to the end of
+// the path.
+func VersionedImportPath(importPath string, major uint64) string {
+	segment := ""
+	if major > 1 {
+		segment = fmt.Sprintf("/v%d", major)
+	}
+
+	if loc := majorVersionSegment.FindStringIndex(importPath); loc != nil {
+		rest := importPath[loc[1]:]
+		if rest != "" {
+			rest = "/" + rest
+		}
+		return importPath[:loc[0]] + segment + rest
+	}
+	return importPath + segment
+}
+
+// GenerateVersionAdapters generates, for each module of the Go SDK of pkg, functions that convert the values of its
+// types to and from the values of the same types in the Go SDK of prev, the previous major version of the package.
+// Only the types whose shape is unchanged between the versions are converted. A type named Bucket gets the functions
+// BucketFromV5 and BucketToV5 when prev is version 5. The adapters let a large Go codebase move to a new major version
+// of a provider one package at a time.
+//
+// The previous SDK is imported from the import base path in prev's Go metadata or, if there is none, from the import
+// base path of pkg with its major version replaced.
+func GenerateVersionAdapters(tool string, pkg, prev *schema.Package) (map[string][]byte, error) {
+	if pkg.Version == nil || prev.Version == nil {
+		return nil, errors.New("both versions of the package must have a version")
+	}
+	if pkg.Name != prev.Name || prev.Version.Major+1 != pkg.Version.Major {
+		return nil, errors.Errorf("%s@%s is not the major version before %s@%s", prev.Name, prev.Version, pkg.Name,
+			pkg.Version)
+	}
+
+	languages := map[string]schema.Language{"go": Importer}
+	if err := pkg.ImportLanguages(languages); err != nil {
+		return nil, err
+	}
+	if err := prev.ImportLanguages(languages); err != nil {
+		return nil, err
+	}
+
+	goInfo, _ := pkg.Language["go"].(GoPackageInfo)
+	prevInfo, _ := prev.Language["go"].(GoPackageInfo)
+	if goInfo.ImportBasePath == "" {
+		return nil, errors.Errorf("package %s has no Go import base path", pkg.Name)
+	}
+	if prevInfo.ImportBasePath == "" {
+		prevInfo.ImportBasePath = VersionedImportPath(goInfo.ImportBasePath, prev.Version.Major)
+	}
+
+	g := &adapterGenerator{
+		prev:         prev,
+		prevInfo:     prevInfo,
+		packages:     generatePackageContextMap(tool, pkg, goInfo),
+		prevPackages: generatePackageContextMap(tool, prev, prevInfo),
+		prevTypes:    map[string]*schema.ObjectType{},
+		prevMajor:    prev.Version.Major,
+		unchanged:    map[*schema.ObjectType]bool{},
+	}
+	for _, t := range prev.Types {
+		if obj, ok := t.(*schema.ObjectType); ok {
+			g.prevTypes[obj.Token] = obj
+		}
+	}
+
+	var mods []string
+	for mod := range g.packages {
+		mods = append(mods, mod)
+	}
+	sort.Strings(mods)
+
+	files := map[string][]byte{}
+	for _, mod := range mods {
+		ctx := g.packages[mod]
+
+		var types []*schema.ObjectType
+		for _, t := range ctx.types {
+			if g.isUnchanged(t) {
+				types = append(types, t)
+			}
+		}
+		if len(types) == 0 {
+			continue
+		}
+
+		imports := stringSet{}
+		body := &bytes.Buffer{}
+		for _, t := range types {
+			g.genAdapters(body, ctx, t, imports)
+		}
+
+		buffer := &bytes.Buffer{}
+		ctx.genHeader(buffer, nil, imports)
+		buffer.Write(body.Bytes())
+
+		source, err := format.Source(buffer.Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid Go source code:\n\n%s", buffer.String())
+		}
+		files[path.Join(pkg.Name, mod, fmt.Sprintf("pulumiAdaptersV%d.go", g.prevMajor))] = source
+	}
+	return files, nil
+}
+
+type adapterGenerator struct {
+	prev         *schema.Package
+	prevInfo     GoPackageInfo
+	packages     map[string]*pkgContext
+	prevPackages map[string]*pkgContext
+	prevTypes    map[string]*schema.ObjectType
+	prevMajor    uint64
+
+	unchanged map[*schema.ObjectType]bool
+}
+
+// isUnchanged returns true if the previous version of the package has an object type with the same token and shape as
+// the given type.
+func (g *adapterGenerator) isUnchanged(t *schema.ObjectType) bool {
+	if unchanged, ok := g.unchanged[t]; ok {
+		return unchanged
+	}
+	prev, ok := g.prevTypes[t.Token]
+	if !ok {
+		g.unchanged[t] = false
+		return false
+	}
+
+	// Assume that the type is unchanged while comparing its properties, so that recursive types terminate.
+	g.unchanged[t] = true
+	unchanged := len(t.Properties) == len(prev.Properties)
+	for i := 0; unchanged && i < len(t.Properties); i++ {
+		p, q := t.Properties[i], prev.Properties[i]
+		unchanged = p.Name == q.Name && p.IsRequired == q.IsRequired && g.sameShape(p.Type, q.Type)
+	}
+	g.unchanged[t] = unchanged
+	return unchanged
+}
+
+// sameShape returns true if values of the given types have the same Go representation in the two versions of the SDK,
+// up to the conversion of the object types that they contain.
+func (g *adapterGenerator) sameShape(t, prev schema.Type) bool {
+	if tt, ok := t.(*schema.TokenType); ok && tt.UnderlyingType != nil {
+		t = tt.UnderlyingType
+	}
+	if pt, ok := prev.(*schema.TokenType); ok && pt.UnderlyingType != nil {
+		prev = pt.UnderlyingType
+	}
+	if et, ok := t.(*schema.EnumType); ok {
+		t = et.ElementType
+	}
+	if pt, ok := prev.(*schema.EnumType); ok {
+		prev = pt.ElementType
+	}
+
+	switch t := t.(type) {
+	case *schema.ArrayType:
+		p, ok := prev.(*schema.ArrayType)
+		return ok && g.sameShape(t.ElementType, p.ElementType)
+	case *schema.MapType:
+		p, ok := prev.(*schema.MapType)
+		return ok && g.sameShape(t.ElementType, p.ElementType)
+	case *schema.ObjectType:
+		p, ok := prev.(*schema.ObjectType)
+		return ok && t.Token == p.Token && g.isUnchanged(t)
+	case *schema.UnionType:
+		_, ok := prev.(*schema.UnionType)
+		return ok
+	case *schema.TokenType:
+		return false
+	default:
+		return t == prev
+	}
+}
+
+// prevAlias returns the alias under which the given module of the previous SDK is imported.
+func (g *adapterGenerator) prevAlias(mod string) string {
+	name := strings.Replace(mod, "/", "", -1)
+	if name == "" {
+		name = g.prev.Name
+	}
+	return fmt.Sprintf("%sv%d", name, g.prevMajor)
+}
+
+// prevTypeName returns the name of the given object type in the previous SDK, qualified by its module's alias, and
+// adds the module's import to imports.
+func (g *adapterGenerator) prevTypeName(t *schema.ObjectType, imports stringSet) string {
+	mod := tokenToPackage(g.prev, g.prevInfo.ModuleToPackage, t.Token)
+	ctx := g.prevPackages[mod]
+
+	alias := g.prevAlias(mod)
+	imports.add(fmt.Sprintf("%s %q", alias, path.Join(ctx.importBasePath, mod)))
+	return alias + "." + ctx.tokenToType(t.Token)
+}
+
+// plainTypeName returns the Go type of a plain value of the given type in either SDK.
+func (g *adapterGenerator) plainTypeName(ctx *pkgContext, t schema.Type, optional, prev bool,
+	imports stringSet) string {
+
+	switch t := t.(type) {
+	case *schema.ArrayType:
+		return "[]" + g.plainTypeName(ctx, t.ElementType, false, prev, imports)
+	case *schema.MapType:
+		return "map[string]" + g.plainTypeName(ctx, t.ElementType, false, prev, imports)
+	case *schema.TokenType:
+		return g.plainTypeName(ctx, t.UnderlyingType, optional, prev, imports)
+	case *schema.ObjectType:
+		var name string
+		if prev {
+			name = g.prevTypeName(t, imports)
+		} else {
+			ctx.getTypeImports(t, false, imports, map[schema.Type]struct{}{})
+			name = ctx.tokenToType(t.Token)
+		}
+		if optional {
+			return "*" + name
+		}
+		return name
+	default:
+		return ctx.plainType(t, optional)
+	}
+}
+
+// needsConversion returns true if values of the given type must be converted between the two SDKs.
+func needsConversion(t schema.Type) bool {
+	switch t := t.(type) {
+	case *schema.ArrayType:
+		return needsConversion(t.ElementType)
+	case *schema.MapType:
+		return needsConversion(t.ElementType)
+	case *schema.TokenType:
+		return needsConversion(t.UnderlyingType)
+	case *schema.ObjectType:
+		return true
+	default:
+		return false
+	}
+}
+
+// convert returns an expression that converts expr, a plain value of the given type in one SDK, to the same value in
+// the other. If toPrev is true, the value is converted from the current SDK to the previous one.
+func (g *adapterGenerator) convert(ctx *pkgContext, expr string, t schema.Type, optional, toPrev bool,
+	imports stringSet) string {
+
+	if !needsConversion(t) {
+		return expr
+	}
+
+	from := g.plainTypeName(ctx, t, optional, !toPrev, imports)
+	to := g.plainTypeName(ctx, t, optional, toPrev, imports)
+
+	switch t := t.(type) {
+	case *schema.ArrayType:
+		elem := g.convert(ctx, "e", t.ElementType, false, toPrev, imports)
+		return fmt.Sprintf("func(a %s) %s {\nif a == nil {\nreturn nil\n}\nr := make(%s, len(a))\n"+
+			"for i, e := range a {\nr[i] = %s\n}\nreturn r\n}(%s)", from, to, to, elem, expr)
+	case *schema.MapType:
+		elem := g.convert(ctx, "e", t.ElementType, false, toPrev, imports)
+		return fmt.Sprintf("func(m %s) %s {\nif m == nil {\nreturn nil\n}\nr := make(%s, len(m))\n"+
+			"for k, e := range m {\nr[k] = %s\n}\nreturn r\n}(%s)", from, to, to, elem, expr)
+	case *schema.TokenType:
+		return g.convert(ctx, expr, t.UnderlyingType, optional, toPrev, imports)
+	case *schema.ObjectType:
+		adapter := g.adapterName(ctx, t, toPrev)
+		if optional {
+			return fmt.Sprintf("func(p %s) %s {\nif p == nil {\nreturn nil\n}\nr := %s(*p)\nreturn &r\n}(%s)",
+				from, to, adapter, expr)
+		}
+		return fmt.Sprintf("%s(%s)", adapter, expr)
+	default:
+		return expr
+	}
+}
+
+// adapterName returns the name of the function that converts values of the given object type, qualified by its module
+// if the type belongs to a different module than ctx, as the type's name is.
+func (g *adapterGenerator) adapterName(ctx *pkgContext, t *schema.ObjectType, toPrev bool) string {
+	name := ctx.tokenToType(t.Token)
+	suffix := fmt.Sprintf("FromV%d", g.prevMajor)
+	if toPrev {
+		suffix = fmt.Sprintf("ToV%d", g.prevMajor)
+	}
+	return name + suffix
+}
+
+// genAdapters generates the functions that convert the given object type to and from the previous SDK.
+func (g *adapterGenerator) genAdapters(w *bytes.Buffer, ctx *pkgContext, t *schema.ObjectType, imports stringSet) {
+	name, prevName := ctx.tokenToType(t.Token), g.prevTypeName(t, imports)
+
+	for _, toPrev := range []bool{false, true} {
+		from, to := prevName, name
+		adapter := fmt.Sprintf("%sFromV%d", name, g.prevMajor)
+		comment := fmt.Sprintf("// %s converts a %s from version %d of the SDK to this version.\n", adapter, name,
+			g.prevMajor)
+		if toPrev {
+			from, to = name, prevName
+			adapter = fmt.Sprintf("%sToV%d", name, g.prevMajor)
+			comment = fmt.Sprintf("// %s converts a %s from this version of the SDK to version %d.\n", adapter, name,
+				g.prevMajor)
+		}
+
+		fmt.Fprint(w, comment)
+		fmt.Fprintf(w, "func %s(v %s) %s {\n", adapter, from, to)
+		fmt.Fprintf(w, "\treturn %s{\n", to)
+		for _, p := range t.Properties {
+			field := Title(p.Name)
+			fmt.Fprintf(w, "\t\t%s: %s,\n", field, g.convert(ctx, "v."+field, p.Type, !p.IsRequired, toPrev, imports))
+		}
+		fmt.Fprintf(w, "\t}\n")
+		fmt.Fprintf(w, "}\n\n")
+	}
+}
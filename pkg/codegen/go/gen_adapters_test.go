@@ -0,0 +1,87 @@
+package gen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestVersionedImportPath(t *testing.T) {
+	assert.Equal(t, "github.com/pulumi/pulumi-aws/sdk/v5/go/aws",
+		VersionedImportPath("github.com/pulumi/pulumi-aws/sdk/v6/go/aws", 5))
+	assert.Equal(t, "github.com/pulumi/pulumi-aws/sdk/go/aws",
+		VersionedImportPath("github.com/pulumi/pulumi-aws/sdk/v2/go/aws", 1))
+	assert.Equal(t, "github.com/pulumi/pulumi-random/sdk/v3",
+		VersionedImportPath("github.com/pulumi/pulumi-random/sdk/v2", 3))
+	assert.Equal(t, "github.com/example/provider/v2", VersionedImportPath("github.com/example/provider", 2))
+}
+
+func TestGenerateVersionAdapters(t *testing.T) {
+	// Only the current version of the package names its import path, so that the adapters derive the import path of the
+	// previous version.
+	importPackage := func(version, webSpec string) *schema.Package {
+		language := `{"go": {"importBasePath": "github.com/example/sdk/v6/go/example"}}`
+		if version != "6.0.0" {
+			language = `{}`
+		}
+
+		var spec schema.PackageSpec
+		err := json.Unmarshal([]byte(`{
+			"name": "example",
+			"version": "`+version+`",
+			"meta": {"moduleFormat": "(.*)"},
+			"language": `+language+`,
+			"types": {
+				"example:web:Rule": {
+					"properties": {"path": {"type": "string"}, "weight": {"type": "integer"}},
+					"required": ["path"],
+					"type": "object"
+				},
+				"example:web:Site": `+webSpec+`
+			}
+		}`), &spec)
+		assert.NoError(t, err)
+
+		pkg, err := schema.ImportSpec(spec, nil)
+		assert.NoError(t, err)
+		return pkg
+	}
+
+	site := `{
+		"properties": {
+			"index": {"$ref": "#/types/example:web:Rule"},
+			"rules": {"type": "array", "items": {"$ref": "#/types/example:web:Rule"}}
+		},
+		"type": "object"
+	}`
+	changedSite := `{
+		"properties": {
+			"index": {"type": "string"}
+		},
+		"type": "object"
+	}`
+
+	files, err := GenerateVersionAdapters("test", importPackage("6.0.0", site), importPackage("5.1.0", site))
+	assert.NoError(t, err)
+	source := string(files["example/web/pulumiAdaptersV5.go"])
+	assert.Contains(t, source, `webv5 "github.com/example/sdk/v5/go/example/web"`)
+	assert.Contains(t, source, "func RuleFromV5(v webv5.Rule) Rule {")
+	assert.Contains(t, source, "func RuleToV5(v Rule) webv5.Rule {")
+	assert.Contains(t, source, "func SiteFromV5(v webv5.Site) Site {")
+	assert.Contains(t, source, "r := RuleFromV5(*p)")
+	assert.Contains(t, source, "r[i] = RuleToV5(e)")
+
+	// Types whose shape changed get no adapters.
+	files, err = GenerateVersionAdapters("test", importPackage("6.0.0", changedSite),
+		importPackage("5.1.0", site))
+	assert.NoError(t, err)
+	source = string(files["example/web/pulumiAdaptersV5.go"])
+	assert.Contains(t, source, "func RuleFromV5(v webv5.Rule) Rule {")
+	assert.NotContains(t, source, "SiteFromV5")
+
+	_, err = GenerateVersionAdapters("test", importPackage("6.0.0", site), importPackage("4.0.0", site))
+	assert.Error(t, err)
+}
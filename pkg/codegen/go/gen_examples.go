@@ -0,0 +1,62 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// ExampleWorkspace returns a workspace that compiles the Go examples in the given package's schema against the SDK that
+// GeneratePackage generates for the package. The workspace is a Go module that replaces the SDK's import path with the
+// generated SDK; each example is a main package in its own directory.
+func ExampleWorkspace(tool string, pkg *schema.Package) (*codegen.ExampleWorkspace, error) {
+	sdk, err := GeneratePackage(tool, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	goInfo, _ := pkg.Language["go"].(GoPackageInfo)
+	importBasePath := goInfo.ImportBasePath
+	if importBasePath == "" {
+		importBasePath = fmt.Sprintf("github.com/pulumi/pulumi-%[1]s/sdk/go/%[1]s", pkg.Name)
+	}
+
+	files := map[string][]byte{}
+	for p, contents := range sdk {
+		files[path.Join("sdk", p)] = contents
+	}
+	files[path.Join("sdk", pkg.Name, "go.mod")] = []byte(fmt.Sprintf("module %s\n\ngo 1.14\n", importBasePath))
+	files["go.mod"] = []byte(fmt.Sprintf(
+		"module examples\n\ngo 1.14\n\nrequire %[1]s v0.0.0\n\nreplace %[1]s => ./sdk/%[2]s\n", importBasePath, pkg.Name))
+
+	workspace := &codegen.ExampleWorkspace{
+		Files: files,
+		Setup: [][]string{{"go", "mod", "tidy"}},
+	}
+	for i, example := range codegen.PackageExamples(pkg, "go") {
+		dir := fmt.Sprintf("examples/%03d", i)
+		files[path.Join(dir, "main.go")] = []byte(example.Code)
+		workspace.Builds = append(workspace.Builds, codegen.ExampleBuild{
+			Example: example,
+			Dir:     dir,
+			Command: []string{"go", "build"},
+		})
+	}
+	return workspace, nil
+}
@@ -25,16 +25,33 @@ type generator struct {
 	contexts            map[string]map[string]*pkgContext
 	diagnostics         hcl.Diagnostics
 	jsonTempSpiller     *jsonSpiller
-	ternaryTempSpiller  *tempSpiller
 	readDirTempSpiller  *readDirSpiller
-	splatSpiller        *splatSpiller
+	lowerer             *hcl2.Lowerer
 	optionalSpiller     *optionalSpiller
 	scopeTraversalRoots codegen.StringSet
 	arrayHelpers        map[string]*promptToInputArrayHelper
 	isErrAssigned       bool
+	dependsOnCount      int
+	configCreated       bool
+	configNamespaces    codegen.StringSet
+
+	// The component whose constructor is being generated, if any.
+	component *hcl2.Component
+	// The writer that records the source map for the generated program, if any.
+	sourceMap *codegen.SourceMapWriter
+	// The report that records the fidelity of the conversion, if any.
+	report *codegen.ConversionReport
 }
 
 func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
+	return GenerateProgramWithOptions(program, codegen.GenerateProgramOptions{})
+}
+
+// GenerateProgramWithOptions generates a Go program for the given PCL program. The options control the
+// generator's optional outputs, e.g. a source map for the generated program.
+func GenerateProgramWithOptions(program *hcl2.Program,
+	options codegen.GenerateProgramOptions) (map[string][]byte, hcl.Diagnostics, error) {
+
 	// Linearize the nodes into an order appropriate for procedural code generation.
 	nodes := hcl2.Linearize(program)
 
@@ -48,28 +65,37 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 		program:             program,
 		contexts:            contexts,
 		jsonTempSpiller:     &jsonSpiller{},
-		ternaryTempSpiller:  &tempSpiller{},
 		readDirTempSpiller:  &readDirSpiller{},
-		splatSpiller:        &splatSpiller{},
+		lowerer:             newLowerer(),
 		optionalSpiller:     &optionalSpiller{},
 		scopeTraversalRoots: codegen.NewStringSet(),
 		arrayHelpers:        make(map[string]*promptToInputArrayHelper),
+		configNamespaces:    codegen.NewStringSet(),
 	}
 
 	g.Formatter = format.NewFormatter(g)
 
-	var index bytes.Buffer
-	g.genPreamble(&index, program)
-
 	for _, n := range nodes {
 		g.collectScopeRoots(n)
 	}
 
+	g.report = options.Report
+	program.Report(g.report)
+
+	var index bytes.Buffer
+	var w io.Writer = &index
+	if options.SourceMap {
+		g.sourceMap = codegen.NewSourceMapWriter(&index, "main.go")
+		w = g.sourceMap
+	}
+
+	g.genPreamble(w, program)
+
 	for _, n := range nodes {
-		g.genNode(&index, n)
+		g.genNode(w, n)
 	}
 
-	g.genPostamble(&index, nodes)
+	g.genPostamble(w, nodes)
 
 	// Run Go formatter on the code before saving to disk
 	formattedSource, err := gofmt.Source(index.Bytes())
@@ -80,6 +106,16 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 	files := map[string][]byte{
 		"main.go": formattedSource,
 	}
+	if g.sourceMap != nil {
+		sourceMap := g.sourceMap.SourceMap()
+		sourceMap.Reformat(index.Bytes(), formattedSource)
+
+		sourceMapJSON, err := sourceMap.JSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		files["main.go.map"] = sourceMapJSON
+	}
 	return files, g.diagnostics, nil
 }
 
@@ -120,6 +156,14 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 	}
 
 	g.Fprintf(w, ")\n")
+
+	// Components are generated as top-level declarations ahead of main.
+	for _, n := range program.Nodes {
+		if c, ok := n.(*hcl2.Component); ok {
+			g.sourceMap.Map(c.SyntaxNode().Range(), func() { g.genComponent(w, c) })
+		}
+	}
+
 	g.Fprintf(w, "func main() {\n")
 	g.Fprintf(w, "pulumi.Run(func(ctx *pulumi.Context) error {\n")
 }
@@ -130,10 +174,25 @@ func (g *generator) collectImports(w io.Writer, program *hcl2.Program) (codegen.
 	pulumiImports := codegen.NewStringSet()
 	stdImports := codegen.NewStringSet()
 	for _, n := range program.Nodes {
-		if r, isResource := n.(*hcl2.Resource); isResource {
+		if _, isConfig := n.(*hcl2.ConfigVariable); isConfig {
+			pulumiImports.Add("github.com/pulumi/pulumi/sdk/v2/go/pulumi/config")
+			break
+		}
+	}
+	for _, n := range hcl2.ProgramNodes(program) {
+		// The resources inside of a component are named using fmt.Sprintf.
+		if c, ok := n.(*hcl2.Component); ok {
+			for _, n := range c.Nodes {
+				if _, isResource := n.(*hcl2.Resource); isResource {
+					stdImports.Add("fmt")
+				}
+			}
+		}
+
+		if r, isResource := hcl2.NodeResource(n); isResource && r.Component == nil {
 			pkg, mod, name, _ := r.DecomposeToken()
 			if pkg == "pulumi" && mod == "providers" {
-				pkg = name
+				pkg, mod = name, ""
 			}
 
 			version := -1
@@ -157,6 +216,11 @@ func (g *generator) collectImports(w io.Writer, program *hcl2.Program) (codegen.
 			} else {
 				pulumiImports.Add(fmt.Sprintf("github.com/pulumi/pulumi-%s/sdk%s/go/%s/%s", pkg, vPath, pkg, mod))
 			}
+
+			// Ranged resources name each instance using fmt.Sprintf.
+			if r.Options != nil && r.Options.Range != nil {
+				stdImports.Add("fmt")
+			}
 		}
 
 		diags := n.VisitExpressions(nil, func(n model.Expression) (model.Expression, hcl.Diagnostics) {
@@ -234,54 +298,97 @@ func (g *generator) genHelpers(w io.Writer) {
 }
 
 func (g *generator) genNode(w io.Writer, n hcl2.Node) {
-	switch n := n.(type) {
-	case *hcl2.Resource:
-		g.genResource(w, n)
-	case *hcl2.OutputVariable:
-		g.genOutputAssignment(w, n)
-	// TODO
-	// case *hcl2.ConfigVariable:
-	// 	g.genConfigVariable(w, n)
-	case *hcl2.LocalVariable:
-		g.genLocalVariable(w, n)
-	}
+	g.sourceMap.Map(n.SyntaxNode().Range(), func() {
+		switch n := n.(type) {
+		case *hcl2.Resource:
+			g.genResource(w, n)
+		case *hcl2.Provider:
+			g.genResource(w, n.Resource)
+		case *hcl2.OutputVariable:
+			g.genOutputAssignment(w, n)
+		case *hcl2.ConfigVariable:
+			// The inputs of a component are read from its args rather than from configuration.
+			if g.component == nil {
+				g.genConfigVariable(w, n)
+			}
+		case *hcl2.LocalVariable:
+			g.genLocalVariable(w, n)
+		}
+	})
 }
 
 var resourceType = model.MustNewOpaqueType("pulumi.Resource")
 
 func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Block, []interface{}) {
 	if opts == nil {
-		return nil, nil
+		if g.component == nil {
+			return nil, nil
+		}
+		opts = &hcl2.ResourceOptions{}
 	}
 
 	var block *model.Block
 	var temps []interface{}
-	appendOption := func(name string, value model.Expression, destType model.Type) {
+	addItem := func(item model.BodyItem) {
 		if block == nil {
 			block = &model.Block{
 				Type: "options",
 				Body: &model.Body{},
 			}
 		}
-
-		value, valueTemps := g.lowerExpression(value, destType, false)
-		temps = append(temps, valueTemps...)
-
-		block.Body.Items = append(block.Body.Items, &model.Attribute{
+		block.Body.Items = append(block.Body.Items, item)
+	}
+	addOption := func(name string, value model.Expression) {
+		addItem(&model.Attribute{
 			Tokens: syntax.NewAttributeTokens(name),
 			Name:   name,
 			Value:  value,
 		})
 	}
+	appendOption := func(name string, value model.Expression, destType model.Type) {
+		value, valueTemps := g.lowerExpression(value, destType, false)
+		temps = append(temps, valueTemps...)
+		addOption(name, value)
+	}
+	// lowerObject lowers the properties of an object literal into the attributes of a block of the given type. Each
+	// property is lowered to the type returned by destType.
+	lowerObject := func(blockType string, value model.Expression,
+		destType func(key string) (model.Type, bool)) *model.Block {
+
+		object := &model.Block{Type: blockType, Body: &model.Body{}}
+		if obj, ok := value.(*model.ObjectConsExpression); ok {
+			for _, item := range obj.Items {
+				key := item.Key.(*model.LiteralValueExpression).Value.AsString()
+				typ, isInput := destType(key)
+				value, valueTemps := g.lowerExpression(item.Value, typ, isInput)
+				temps = append(temps, valueTemps...)
+				object.Body.Items = append(object.Body.Items, &model.Attribute{
+					Tokens: syntax.NewAttributeTokens(key),
+					Name:   key,
+					Value:  value,
+				})
+			}
+		}
+		return object
+	}
 
 	if opts.Parent != nil {
 		appendOption("Parent", opts.Parent, model.DynamicType)
+	} else if g.component != nil {
+		// Resources inside of a component are children of the component unless they specify another parent.
+		addOption("Parent", model.VariableReference(componentVariable))
 	}
 	if opts.Provider != nil {
 		appendOption("Provider", opts.Provider, model.DynamicType)
 	}
 	if opts.DependsOn != nil {
-		appendOption("DependsOn", opts.DependsOn, model.NewListType(resourceType))
+		if hcl2.IsStaticResourceList(opts.DependsOn) {
+			appendOption("DependsOn", opts.DependsOn, model.NewListType(resourceType))
+		} else {
+			value, temp := g.spillDependsOn(opts.DependsOn)
+			temps = append(temps, temp)
+			addOption("DependsOn", value)
+		}
 	}
 	if opts.Protect != nil {
 		appendOption("Protect", opts.Protect, model.BoolType)
@@ -289,6 +396,48 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 	if opts.IgnoreChanges != nil {
 		appendOption("IgnoreChanges", opts.IgnoreChanges, model.NewListType(model.StringType))
 	}
+	if opts.Aliases != nil {
+		// Each alias is lowered to a block of type "Alias" whose attributes are the fields of the alias. A URN is lowered
+		// to an alias with a single "urn" field.
+		aliases := &model.Block{Type: "Aliases", Body: &model.Body{}}
+		if tuple, ok := opts.Aliases.(*model.TupleConsExpression); ok {
+			for _, alias := range tuple.Expressions {
+				if _, isObject := alias.(*model.ObjectConsExpression); isObject {
+					aliases.Body.Items = append(aliases.Body.Items, lowerObject("Alias", alias,
+						func(key string) (model.Type, bool) {
+							if key == "parent" {
+								return model.DynamicType, false
+							}
+							return model.StringType, true
+						}))
+					continue
+				}
+
+				value, valueTemps := g.lowerExpression(alias, model.StringType, false)
+				temps = append(temps, valueTemps...)
+				aliases.Body.Items = append(aliases.Body.Items, &model.Block{
+					Type: "Alias",
+					Body: &model.Body{Items: []model.BodyItem{&model.Attribute{
+						Tokens: syntax.NewAttributeTokens("urn"),
+						Name:   "urn",
+						Value:  value,
+					}}},
+				})
+			}
+		}
+		addItem(aliases)
+	}
+	if opts.CustomTimeouts != nil {
+		addItem(lowerObject("Timeouts", opts.CustomTimeouts, func(string) (model.Type, bool) {
+			return model.StringType, false
+		}))
+	}
+	if opts.DeleteBeforeReplace != nil {
+		appendOption("DeleteBeforeReplace", opts.DeleteBeforeReplace, model.BoolType)
+	}
+	if opts.Version != nil {
+		appendOption("Version", opts.Version, model.StringType)
+	}
 
 	return block, temps
 }
@@ -299,8 +448,40 @@ func (g *generator) genResourceOptions(w io.Writer, block *model.Block) {
 	}
 
 	for _, item := range block.Body.Items {
-		attr := item.(*model.Attribute)
-		g.Fgenf(w, ", pulumi.%s(%v)", attr.Name, attr.Value)
+		switch item := item.(type) {
+		case *model.Attribute:
+			g.Fgenf(w, ", pulumi.%s(%v)", item.Name, item.Value)
+		case *model.Block:
+			switch item.Type {
+			case "Aliases":
+				g.Fgen(w, ", pulumi.Aliases([]pulumi.Alias{\n")
+				for _, alias := range item.Body.Items {
+					g.Fgen(w, "{\n")
+					for _, field := range alias.(*model.Block).Body.Items {
+						field := field.(*model.Attribute)
+						switch field.Name {
+						case "urn":
+							if _, isOutput := field.Value.Type().(*model.OutputType); isOutput {
+								g.Fgenf(w, "URN: pulumi.URNOutput(%v),\n", field.Value)
+							} else {
+								g.Fgenf(w, "URN: pulumi.URN(%v),\n", field.Value)
+							}
+						default:
+							g.Fgenf(w, "%s: %v,\n", Title(field.Name), field.Value)
+						}
+					}
+					g.Fgen(w, "},\n")
+				}
+				g.Fgen(w, "})")
+			case "Timeouts":
+				g.Fgen(w, ", pulumi.Timeouts(&pulumi.CustomTimeouts{\n")
+				for _, timeout := range item.Body.Items {
+					timeout := timeout.(*model.Attribute)
+					g.Fgenf(w, "%s: %v,\n", Title(timeout.Name), timeout.Value)
+				}
+				g.Fgen(w, "})")
+			}
+		}
 	}
 }
 
@@ -308,9 +489,25 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 
 	resName := makeValidIdentifier(r.Name())
 	pkg, mod, typ, _ := r.DecomposeToken()
+	if pkg == "pulumi" && mod == "providers" {
+		pkg, mod, typ = typ, "", "Provider"
+	}
 	if mod == "" || strings.HasPrefix(mod, "/") || strings.HasPrefix(mod, "index/") {
 		mod = pkg
 	}
+	qualifiedTypeName, ctor, argsType := fmt.Sprintf("%s.%s", mod, typ), fmt.Sprintf("%s.New%s", mod, typ),
+		fmt.Sprintf("%s.%sArgs", mod, typ)
+	if r.Component != nil {
+		typeName := componentTypeName(r.Component)
+		qualifiedTypeName, ctor, argsType = typeName, "New"+typeName, typeName+"Args"
+	}
+
+	// The names of the resources inside of a component are prefixed with the name of the component instance.
+	resourceName, rangedResourceName := fmt.Sprintf("%q", resName), fmt.Sprintf(`fmt.Sprintf("%s-%%v", key0)`, resName)
+	if g.component != nil {
+		resourceName = fmt.Sprintf(`fmt.Sprintf("%%s-%s", name)`, resName)
+		rangedResourceName = fmt.Sprintf(`fmt.Sprintf("%%s-%s-%%v", name, key0)`, resName)
+	}
 
 	// Compute resource options
 	options, temps := g.lowerResourceOptions(r.Options)
@@ -320,6 +517,7 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 	for _, input := range r.Inputs {
 		destType, diagnostics := r.InputType.Traverse(hcl.TraverseAttr{Name: input.Name})
 		g.diagnostics = append(g.diagnostics, diagnostics...)
+		input.Value = hcl2.RewriteSecrets(input.Value, destType.(model.Type))
 		isInput := true
 		expr, temps := g.lowerExpression(input.Value, destType.(model.Type), isInput)
 		input.Value = expr
@@ -328,18 +526,18 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 
 	instantiate := func(varName, resourceName string, w io.Writer) {
 		if g.scopeTraversalRoots.Has(varName) || strings.HasPrefix(varName, "__") {
-			g.Fgenf(w, "%s, err := %s.New%s(ctx, %s, ", varName, mod, typ, resourceName)
+			g.Fgenf(w, "%s, err := %s(ctx, %s, ", varName, ctor, resourceName)
 		} else {
 			assignment := ":="
 			if g.isErrAssigned {
 				assignment = "="
 			}
-			g.Fgenf(w, "_, err %s %s.New%s(ctx, %s, ", assignment, mod, typ, resourceName)
+			g.Fgenf(w, "_, err %s %s(ctx, %s, ", assignment, ctor, resourceName)
 		}
 		g.isErrAssigned = true
 
 		if len(r.Inputs) > 0 {
-			g.Fgenf(w, "&%s.%sArgs{\n", mod, typ)
+			g.Fgenf(w, "&%s{\n", argsType)
 			for _, attr := range r.Inputs {
 				g.Fgenf(w, "%s: ", strings.Title(attr.Name))
 				g.Fgenf(w, "%.v,\n", attr.Value)
@@ -352,7 +550,7 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 		g.genResourceOptions(w, options)
 		g.Fprint(w, ")\n")
 		g.Fgenf(w, "if err != nil {\n")
-		g.Fgenf(w, "return err\n")
+		g.genErrorReturn(w)
 		g.Fgenf(w, "}\n")
 	}
 
@@ -361,12 +559,16 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 		rangeExpr, temps := g.lowerExpression(r.Options.Range, rangeType, false)
 		g.genTemps(w, temps)
 
-		g.Fgenf(w, "var %s []*%s.%s\n", resName, mod, typ)
+		if r.HasMapRange() {
+			g.Fgenf(w, "%s := make(map[string]*%s)\n", resName, qualifiedTypeName)
+		} else {
+			g.Fgenf(w, "var %s []*%s\n", resName, qualifiedTypeName)
+		}
 
 		// ahead of range statement declaration generate the resource instantiation
 		// to detect and removed unused k,v variables
 		var buf bytes.Buffer
-		instantiate("__res", fmt.Sprintf(`fmt.Sprintf("%s-%%v", key0)`, resName), &buf)
+		instantiate("__res", rangedResourceName, &buf)
 		instantiation := buf.String()
 		isValUsed := strings.Contains(instantiation, "val0")
 		valVar := "_"
@@ -374,13 +576,25 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 			valVar = "val0"
 		}
 
-		g.Fgenf(w, "for key0, %s := range %.v {\n", valVar, rangeExpr)
+		if model.InputType(model.NumberType).ConversionFrom(rangeType) != model.NoConversion {
+			// The value of the range variable of a numeric range is the index of the current instance.
+			g.Fgenf(w, "for key0 := 0; key0 < %.v; key0++ {\n", rangeExpr)
+			if isValUsed {
+				g.Fgenf(w, "val0 := key0\n")
+			}
+		} else {
+			g.Fgenf(w, "for key0, %s := range %.v {\n", valVar, rangeExpr)
+		}
 		g.Fgen(w, instantiation)
-		g.Fgenf(w, "%s = append(%s, __res)\n", resName, resName)
+		if r.HasMapRange() {
+			g.Fgenf(w, "%s[key0] = __res\n", resName)
+		} else {
+			g.Fgenf(w, "%s = append(%s, __res)\n", resName, resName)
+		}
 		g.Fgenf(w, "}\n")
 
 	} else {
-		instantiate(resName, fmt.Sprintf("%q", resName), w)
+		instantiate(resName, resourceName, w)
 	}
 
 }
@@ -417,15 +631,8 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 
 	for _, t := range temps {
 		switch t := t.(type) {
-		case *ternaryTemp:
-			// TODO derive from ambient context
-			isInput := false
-			g.Fgenf(w, "var %s %s\n", t.Name, g.argumentTypeName(t.Value.TrueResult, t.Type(), isInput))
-			g.Fgenf(w, "if %.v {\n", t.Value.Condition)
-			g.Fgenf(w, "%s = %.v\n", t.Name, t.Value.TrueResult)
-			g.Fgenf(w, "} else {\n")
-			g.Fgenf(w, "%s = %.v\n", t.Name, t.Value.FalseResult)
-			g.Fgenf(w, "}\n")
+		case *hcl2.Temporary:
+			g.genTemporary(w, t)
 		case *jsonTemp:
 			bytesVar := fmt.Sprintf("tmp%s", strings.ToUpper(t.Name))
 			g.Fgenf(w, "%s, err := json.Marshal(", bytesVar)
@@ -435,7 +642,7 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 			if genZeroValueDecl {
 				g.Fgenf(w, "return _zero, err\n")
 			} else {
-				g.Fgenf(w, "return err\n")
+				g.genErrorReturn(w)
 			}
 			g.Fgenf(w, "}\n")
 			g.Fgenf(w, "%s := string(%s)\n", t.Name, bytesVar)
@@ -446,7 +653,7 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 			if genZeroValueDecl {
 				g.Fgenf(w, "return _zero, err\n")
 			} else {
-				g.Fgenf(w, "return err\n")
+				g.genErrorReturn(w)
 			}
 			g.Fgenf(w, "}\n")
 			namesVar := fmt.Sprintf("fileNames%s", tmpSuffix)
@@ -456,18 +663,10 @@ func (g *generator) genTempsMultiReturn(w io.Writer, temps []interface{}, zeroVa
 			g.Fgenf(w, "for %s, %s := range %s {\n", iVar, valVar, t.Name)
 			g.Fgenf(w, "%s[%s] = %s.Name()\n", namesVar, iVar, valVar)
 			g.Fgenf(w, "}\n")
-		case *splatTemp:
-			argTyp := g.argumentTypeName(t.Value.Each, t.Value.Each.Type(), false)
-			if strings.Contains(argTyp, ".") {
-				g.Fgenf(w, "var %s %sArray\n", t.Name, argTyp)
-			} else {
-				g.Fgenf(w, "var %s []%s\n", t.Name, argTyp)
-			}
-			g.Fgenf(w, "for _, val0 := range %.v {\n", t.Value.Source)
-			g.Fgenf(w, "%s = append(%s, %.v)\n", t.Name, t.Name, t.Value.Each)
-			g.Fgenf(w, "}\n")
 		case *optionalTemp:
 			g.Fgenf(w, "%s := %.v\n", t.Name, t.Value)
+		case *dependsOnTemp:
+			g.genDependsOnTemp(w, t)
 		default:
 			contract.Failf("unexpected temp type: %v", t)
 		}
@@ -493,7 +692,7 @@ func (g *generator) genLocalVariable(w io.Writer, v *hcl2.LocalVariable) {
 			g.Fgenf(w, "%s, err %s %.3v;\n", name, assignment, expr)
 			g.isErrAssigned = true
 			g.Fgenf(w, "if err != nil {\n")
-			g.Fgenf(w, "return err\n")
+			g.genErrorReturn(w)
 			g.Fgenf(w, "}\n")
 		}
 	default:
@@ -529,3 +728,74 @@ func (g *generator) useLookupInvokeForm(token string) bool {
 
 	return false
 }
+
+// genConfigVariable generates a read of the given config variable from the stack's configuration. Provider config
+// variables (e.g. `aws:region`) are read from the provider's namespace.
+func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
+	// Go rejects unused variables, so config variables that are never referenced are only required to be set.
+	referenced := g.scopeTraversalRoots.Has(v.Name())
+	if !referenced && v.DefaultValue != nil {
+		return
+	}
+
+	configObject, name, key := "cfg", makeValidIdentifier(v.Name()), v.Name()
+	if namespace := v.Namespace(); namespace != "" {
+		configObject, key = makeValidIdentifier(namespace+"Config"), v.Key()
+		name = makeValidIdentifier(v.VariableName(g.program))
+		if !g.configNamespaces.Has(namespace) {
+			g.Fgenf(w, "%s := config.New(ctx, %q)\n", configObject, namespace)
+			g.configNamespaces.Add(namespace)
+		}
+	} else if !g.configCreated {
+		g.Fgenf(w, "%s := config.New(ctx, \"\")\n", configObject)
+		g.configCreated = true
+	}
+
+	if v.ProviderConfig != nil {
+		if description := hcl2.DescribeDefaultValue(v.ProviderConfig); description != "" {
+			g.Fgenf(w, "// %s %s.\n", v.Name(), description)
+		}
+	}
+
+	getType := ""
+	switch v.Type() {
+	case model.StringType:
+	case model.BoolType:
+		getType = "Bool"
+	case model.IntType:
+		getType = "Int"
+	case model.NumberType:
+		getType = "Float64"
+	default:
+		typeName := g.argumentTypeName(nil, v.Type(), false)
+		switch {
+		case !referenced:
+			g.Fgenf(w, "%s.RequireObject(%q, new(%s))\n", configObject, key, typeName)
+		case v.DefaultValue == nil:
+			g.Fgenf(w, "var %s %s\n", name, typeName)
+			g.Fgenf(w, "%s.RequireObject(%q, &%s)\n", configObject, key, name)
+		default:
+			defaultValue, temps := g.lowerExpression(v.DefaultValue, v.Type(), false)
+			g.genTemps(w, temps)
+			g.Fgenf(w, "var %s %s\n", name, typeName)
+			g.Fgenf(w, "if err := %s.TryObject(%q, &%s); err != nil {\n", configObject, key, name)
+			g.Fgenf(w, "%s = %.v\n", name, defaultValue)
+			g.Fgenf(w, "}\n")
+		}
+		return
+	}
+
+	switch {
+	case !referenced:
+		g.Fgenf(w, "_ = %s.Require%s(%q)\n", configObject, getType, key)
+	case v.DefaultValue == nil:
+		g.Fgenf(w, "%s := %s.Require%s(%q)\n", name, configObject, getType, key)
+	default:
+		defaultValue, temps := g.lowerExpression(v.DefaultValue, v.Type(), false)
+		g.genTemps(w, temps)
+		g.Fgenf(w, "%s := %.v\n", name, defaultValue)
+		g.Fgenf(w, "if param, err := %s.Try%s(%q); err == nil {\n", configObject, getType, key)
+		g.Fgenf(w, "%s = param\n", name)
+		g.Fgenf(w, "}\n")
+	}
+}
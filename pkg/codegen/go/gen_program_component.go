@@ -0,0 +1,139 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"io"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// componentVariable is the variable that refers to the component whose constructor is being generated. Resources
+// inside of a component use it as their default parent.
+var componentVariable = &model.Variable{
+	Name:         "component",
+	VariableType: model.DynamicType,
+}
+
+// componentTypeName returns the name of the struct generated for the given component.
+func componentTypeName(c *hcl2.Component) string {
+	return Title(makeValidIdentifier(c.Name()))
+}
+
+// componentInputTypeName returns the name of the SDK type that corresponds to a component input of the given type, e.g.
+// "StringMap" for map(string). The input's argument field has type pulumi.<name>Input.
+func componentInputTypeName(t model.Type) string {
+	switch t := t.(type) {
+	case *model.ListType:
+		return componentInputTypeName(t.ElementType) + "Array"
+	case *model.MapType:
+		return componentInputTypeName(t.ElementType) + "Map"
+	}
+
+	switch t {
+	case model.BoolType:
+		return "Bool"
+	case model.IntType:
+		return "Int"
+	case model.NumberType:
+		return "Float64"
+	default:
+		return "String"
+	}
+}
+
+// genComponent generates an arguments struct, a component struct, and a constructor function for a component. The
+// resources inside of the component are children of the component, and their names are prefixed with the name of the
+// component instance.
+func (g *generator) genComponent(w io.Writer, c *hcl2.Component) {
+	typeName := componentTypeName(c)
+	inputType := c.InputType.(*model.ObjectType)
+
+	g.Fgenf(w, "type %sArgs struct {\n", typeName)
+	for _, input := range c.Inputs {
+		g.Fgenf(w, "%s pulumi.%sInput\n", Title(input.Name), componentInputTypeName(inputType.Properties[input.Name]))
+	}
+	g.Fgenf(w, "}\n\n")
+
+	g.Fgenf(w, "type %s struct {\n", typeName)
+	g.Fgenf(w, "pulumi.ResourceState\n")
+	if len(c.Outputs) != 0 {
+		g.Fgenf(w, "\n")
+	}
+	for _, output := range c.Outputs {
+		g.Fgenf(w, "%s pulumi.Output\n", Title(output.Name))
+	}
+	g.Fgenf(w, "}\n\n")
+
+	g.Fgenf(w, "func New%s(ctx *pulumi.Context, name string, args *%sArgs, ", typeName, typeName)
+	g.Fgenf(w, "opts ...pulumi.ResourceOption) (*%s, error) {\n", typeName)
+	g.Fgenf(w, "component := &%s{}\n", typeName)
+	g.Fgenf(w, "err := ctx.RegisterComponentResource(\"components:index:%s\", name, component, opts...)\n", typeName)
+	g.Fgenf(w, "if err != nil {\n")
+	g.Fgenf(w, "return nil, err\n")
+	g.Fgenf(w, "}\n")
+
+	// Convert the inputs that the component uses into outputs. Go rejects unused variables, so unused inputs are skipped.
+	used := codegen.Set{}
+	diags := c.VisitExpressions(nil, func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		if traversal, ok := x.(*model.ScopeTraversalExpression); ok {
+			used.Add(traversal.Parts[0])
+		}
+		return x, nil
+	})
+	contract.Assert(len(diags) == 0)
+	for _, input := range c.Inputs {
+		if used.Has(input) {
+			g.Fgenf(w, "%s := args.%s.To%sOutput()\n", input.Name, Title(input.Name),
+				componentInputTypeName(inputType.Properties[input.Name]))
+		}
+	}
+
+	isErrAssigned := g.isErrAssigned
+	g.component, g.isErrAssigned = c, true
+	for _, n := range hcl2.LinearizeComponent(c) {
+		g.genNode(w, n)
+	}
+	for _, output := range c.Outputs {
+		expr, temps := g.lowerExpression(output.Value, output.Type(), false)
+		g.genTemps(w, temps)
+		g.Fgenf(w, "component.%s = pulumi.ToOutput(%.v)\n", Title(output.Name), expr)
+	}
+	g.component, g.isErrAssigned = nil, isErrAssigned
+
+	g.Fgenf(w, "err = ctx.RegisterResourceOutputs(component, pulumi.Map{\n")
+	for _, output := range c.Outputs {
+		g.Fgenf(w, "%q: component.%s,\n", output.Name, Title(output.Name))
+	}
+	g.Fgenf(w, "})\n")
+	g.Fgenf(w, "if err != nil {\n")
+	g.Fgenf(w, "return nil, err\n")
+	g.Fgenf(w, "}\n")
+	g.Fgenf(w, "return component, nil\n")
+	g.Fgenf(w, "}\n\n")
+}
+
+// genErrorReturn generates a statement that returns err from the function being generated.
+func (g *generator) genErrorReturn(w io.Writer) {
+	if g.component != nil {
+		g.Fgenf(w, "return nil, err\n")
+	} else {
+		g.Fgenf(w, "return err\n")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// dependsOnTemp holds a computed list of resource dependencies. Go has no list comprehensions or ternaries, and a
+// slice of concrete resource pointers is not assignable to []pulumi.Resource, so any dependsOn value that is not a
+// static list of resources is collected into a []pulumi.Resource ahead of the resource instantiation.
+type dependsOnTemp struct {
+	Name  string
+	Value model.Expression
+}
+
+func (dt *dependsOnTemp) Type() model.Type {
+	return model.NewListType(resourceType)
+}
+
+func (dt *dependsOnTemp) Traverse(traverser hcl.Traverser) (model.Traversable, hcl.Diagnostics) {
+	return dt.Type().Traverse(traverser)
+}
+
+func (dt *dependsOnTemp) SyntaxNode() hclsyntax.Node {
+	return syntax.None
+}
+
+// spillDependsOn allocates a temporary for a computed dependsOn expression and returns a reference to the temporary.
+func (g *generator) spillDependsOn(expr model.Expression) (model.Expression, *dependsOnTemp) {
+	temp := &dependsOnTemp{
+		Name:  fmt.Sprintf("dependsOn%d", g.dependsOnCount),
+		Value: expr,
+	}
+	g.dependsOnCount++
+
+	return &model.ScopeTraversalExpression{
+		RootName:  temp.Name,
+		Traversal: hcl.Traversal{hcl.TraverseRoot{Name: ""}},
+		Parts:     []model.Traversable{temp},
+	}, temp
+}
+
+func (g *generator) genDependsOnTemp(w io.Writer, t *dependsOnTemp) {
+	g.Fgenf(w, "var %s []pulumi.Resource\n", t.Name)
+	g.genDependsOnAppend(w, t.Name, t.Value)
+}
+
+// genDependsOnAppend generates the statements necessary to append the resources denoted by expr to the slice named
+// by name. Tuples, conditionals, and for expressions are expanded into the equivalent Go control flow.
+func (g *generator) genDependsOnAppend(w io.Writer, name string, expr model.Expression) {
+	switch expr := expr.(type) {
+	case *model.TupleConsExpression:
+		for _, item := range expr.Expressions {
+			g.genDependsOnAppend(w, name, item)
+		}
+	case *model.ConditionalExpression:
+		condition, temps := g.lowerExpression(expr.Condition, model.BoolType, false)
+		g.genTemps(w, temps)
+
+		g.Fgenf(w, "if %.v {\n", condition)
+		g.genDependsOnAppend(w, name, expr.TrueResult)
+		if !isEmptyDependsOn(expr.FalseResult) {
+			g.Fgenf(w, "} else {\n")
+			g.genDependsOnAppend(w, name, expr.FalseResult)
+		}
+		g.Fgenf(w, "}\n")
+	case *model.ForExpression:
+		collection, temps := g.lowerExpression(expr.Collection, expr.Collection.Type(), false)
+		g.genTemps(w, temps)
+
+		keyVar, valueVar := "_", "_"
+		if expr.KeyVariable != nil && isVariableReferenced(expr, expr.KeyVariable) {
+			keyVar = makeValidIdentifier(expr.KeyVariable.Name)
+		}
+		if isVariableReferenced(expr, expr.ValueVariable) {
+			valueVar = makeValidIdentifier(expr.ValueVariable.Name)
+		}
+
+		g.Fgenf(w, "for %s, %s := range %.v {\n", keyVar, valueVar, collection)
+		if expr.Condition != nil {
+			condition, temps := g.lowerExpression(expr.Condition, model.BoolType, false)
+			g.genTemps(w, temps)
+			g.Fgenf(w, "if !(%.v) {\n", condition)
+			g.Fgenf(w, "continue\n")
+			g.Fgenf(w, "}\n")
+		}
+		g.genDependsOnAppend(w, name, expr.Value)
+		g.Fgenf(w, "}\n")
+	default:
+		if isEmptyDependsOn(expr) {
+			return
+		}
+
+		value, temps := g.lowerExpression(expr, expr.Type(), false)
+		g.genTemps(w, temps)
+
+		switch model.ResolveOutputs(expr.Type()).(type) {
+		case *model.ListType, *model.TupleType:
+			g.Fgenf(w, "for _, res := range %.v {\n", value)
+			g.Fgenf(w, "%s = append(%s, res)\n", name, name)
+			g.Fgenf(w, "}\n")
+		default:
+			g.Fgenf(w, "%s = append(%s, %.v)\n", name, name, value)
+		}
+	}
+}
+
+// isEmptyDependsOn returns true if the given expression contributes no dependencies, i.e. it is `null` or `[]`.
+func isEmptyDependsOn(expr model.Expression) bool {
+	switch expr := expr.(type) {
+	case *model.TupleConsExpression:
+		return len(expr.Expressions) == 0
+	case *model.LiteralValueExpression:
+		return expr.Value.IsNull()
+	case *model.ScopeTraversalExpression:
+		c, ok := expr.Parts[0].(*model.Constant)
+		return ok && c.ConstantValue.IsNull()
+	}
+	return false
+}
+
+// isVariableReferenced returns true if the given for expression's key, value, or condition refers to v.
+func isVariableReferenced(expr *model.ForExpression, v *model.Variable) bool {
+	referenced := false
+	visitor := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		if traversal, ok := x.(*model.ScopeTraversalExpression); ok && traversal.Parts[0] == v {
+			referenced = true
+		}
+		return x, nil
+	}
+	for _, x := range []model.Expression{expr.Key, expr.Value, expr.Condition} {
+		if x != nil {
+			_, diags := model.VisitExpression(x, visitor, model.IdentityVisitor)
+			contract.Assert(len(diags) == 0)
+		}
+	}
+	return referenced
+}
@@ -129,6 +129,37 @@ func TestConditionalExpression(t *testing.T) {
 	}
 }
 
+// nolint: lll
+func TestForExpression(t *testing.T) {
+	env := environment(map[string]interface{}{
+		"names": model.NewListType(model.StringType),
+	})
+	scope := env.scope()
+	cases := []exprTestCase{
+		{
+			hcl2Expr: "[for n in names: n]",
+			goCode:   "var for0 []string\nfor _, n := range names {\nfor0 = append(for0, n)\n}\nfor0",
+		},
+		{
+			hcl2Expr: "[for n in names: n if n != \"\"]",
+			goCode:   "var for0 []string\nfor _, n := range names {\nif !(n != \"\") {\ncontinue\n}\nfor0 = append(for0, n)\n}\nfor0",
+		},
+		{
+			hcl2Expr: "{for n in names: n => true}",
+			goCode:   "for0 := map[string]bool{}\nfor _, n := range names {\nfor0[n] = true\n}\nfor0",
+		},
+	}
+	genFunc := func(w io.Writer, g *generator, e model.Expression) {
+		isInput := false
+		e, temps := g.lowerExpression(e, e.Type(), isInput)
+		g.genTemps(w, temps)
+		g.Fgenf(w, "%v", e)
+	}
+	for _, c := range cases {
+		testGenerateExpression(t, c.hcl2Expr, c.goCode, scope, genFunc)
+	}
+}
+
 func TestObjectConsExpression(t *testing.T) {
 	env := environment(map[string]interface{}{
 		"a": model.StringType,
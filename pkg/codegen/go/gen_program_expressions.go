@@ -133,12 +133,16 @@ func (g *generator) GenBinaryOpExpression(w io.Writer, expr *model.BinaryOpExpre
 
 func (g *generator) GenConditionalExpression(w io.Writer, expr *model.ConditionalExpression) {
 	// Ternary expressions are not supported in go so we need to allocate temp variables in the parent scope.
-	// This is handled by lower expression and rewriteTernaries
+	// This is handled by lowerExpression.
 	contract.Failf("unlowered conditional expression @ %v", expr.SyntaxNode().Range())
 }
 
 // GenForExpression generates code for a ForExpression.
-func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) { /*TODO*/ }
+func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) {
+	// For expressions are generated as loops that assign their results to temp variables. This is handled by
+	// lowerExpression.
+	contract.Failf("unlowered for expression @ %v", expr.SyntaxNode().Range())
+}
 
 func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionCallExpression) {
 	switch expr.Name {
@@ -180,9 +184,9 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 	case hcl2.IntrinsicApply:
 		g.genApply(w, expr)
 	case "element":
-		g.genNYI(w, "element")
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 	case "entries":
-		g.genNYI(w, "call %v", expr.Name)
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 		// switch model.ResolveOutputs(expr.Args[0].Type()).(type) {
 		// case *model.ListType, *model.TupleType:
 		// 	if call, ok := expr.Args[0].(*model.FunctionCallExpression); ok && call.Name == "range" {
@@ -195,7 +199,7 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		// }
 		// g.Fgenf(w, " => new { Key = k, Value = v })")
 	case "fileArchive":
-		g.genNYI(w, "call %v", expr.Name)
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 		// g.Fgenf(w, "new FileArchive(%.v)", expr.Args[0])
 	case "fileAsset":
 		g.Fgenf(w, "pulumi.NewFileAsset(%.v)", expr.Args[0])
@@ -220,15 +224,15 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.Fgenf(w, "%.v", expr.Args[1])
 		g.Fgenf(w, "%v)", optionsBag)
 	case "length":
-		g.genNYI(w, "call %v", expr.Name)
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 		// g.Fgenf(w, "%.20v.Length", expr.Args[0])
 	case "lookup":
-		g.genNYI(w, "Lookup")
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 	case keywordRange:
-		g.genNYI(w, "call %v", expr.Name)
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 		// g.genRange(w, expr, false)
 	case "readFile":
-		g.genNYI(w, "ReadFile")
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 	case "readDir":
 		contract.Failf("unlowered toJSON function expression @ %v", expr.SyntaxNode().Range())
 	case "secret":
@@ -238,14 +242,19 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		}
 		g.Fgenf(w, "pulumi.ToSecret(%v).(%sOutput)", expr.Args[0], outputTypeName)
 	case "split":
-		g.genNYI(w, "call %v", expr.Name)
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 		// g.Fgenf(w, "%.20v.Split(%v)", expr.Args[1], expr.Args[0])
 	case "toJSON":
-		contract.Failf("unlowered toJSON function expression @ %v", expr.SyntaxNode().Range())
+		contract.Assert(model.ContainsOutputs(expr.Signature.ReturnType))
+		g.Fgenf(w, "pulumi.JSONMarshal(%.v)", rewriteInputs(expr.Args[0]))
 	case "mimeType":
 		g.Fgenf(w, "mime.TypeByExtension(path.Ext(%.v))", expr.Args[0])
 	default:
-		g.genNYI(w, "call %v", expr.Name)
+		if emitter, ok := g.program.IntrinsicEmitter(expr.Name, "go"); ok {
+			emitter.Emit(g.Formatter, w, expr)
+			return
+		}
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 	}
 }
 
@@ -309,6 +318,10 @@ func (g *generator) genLiteralValueExpression(w io.Writer, expr *model.LiteralVa
 				break
 			}
 		}
+	case *model.EnumType:
+		g.genLiteralValueExpression(w, expr, destType.ElementType)
+	case *model.SecretType:
+		g.genLiteralValueExpression(w, expr, destType.ElementType)
 	default:
 		contract.Failf("unexpected destType in GenLiteralValueExpression: %v (%v)", destType,
 			expr.SyntaxNode().Range())
@@ -419,15 +432,21 @@ func (g *generator) GenScopeTraversalExpression(w io.Writer, expr *model.ScopeTr
 func (g *generator) genScopeTraversalExpression(w io.Writer, expr *model.ScopeTraversalExpression, isInput bool) {
 	rootName := expr.RootName
 
-	if _, ok := expr.Parts[0].(*model.SplatVariable); ok {
+	switch v := expr.Parts[0].(type) {
+	case *model.SplatVariable:
 		rootName = "val0"
+	case *model.Variable:
+		// `each` is an alias for the range variable of a ranged resource.
+		if v.Name == keywordRange {
+			rootName = keywordRange
+		}
 	}
 
 	genIDCall := false
 
 	if resource, ok := expr.Parts[0].(*hcl2.Resource); ok {
 		isInput = false
-		if _, ok := hcl2.GetSchemaForType(resource.InputType); ok {
+		if _, ok := g.program.GetSchemaForType(resource.InputType); ok {
 			// convert .id into .ID()
 			last := expr.Traversal[len(expr.Traversal)-1]
 			if attr, ok := last.(hcl.TraverseAttr); ok && attr.Name == "id" {
@@ -437,6 +456,13 @@ func (g *generator) genScopeTraversalExpression(w io.Writer, expr *model.ScopeTr
 		}
 	}
 
+	// Variables of output type, e.g. the inputs of a component, already implement the corresponding input types.
+	if v, ok := expr.Parts[0].(*model.Variable); ok && len(expr.Traversal) == 1 {
+		if _, isOutput := v.VariableType.(*model.OutputType); isOutput {
+			isInput = false
+		}
+	}
+
 	// TODO if it's an array type, we need a lowering step to turn []string -> pulumi.StringArray
 	if isInput {
 		argType := g.argumentTypeName(expr, expr.Type(), isInput)
@@ -474,8 +500,22 @@ func (g *generator) genScopeTraversalExpression(w io.Writer, expr *model.ScopeTr
 		}
 	} else {
 		g.Fgen(w, makeValidIdentifier(rootName))
+		traversal, parts := expr.Traversal.SimpleSplit().Rel, expr.Parts[1:]
+		// Maps of resources, e.g. those created by ranging over a map, are indexed by key.
+		if _, isMap := model.GetTraversableType(expr.Parts[0]).(*model.MapType); isMap && len(traversal) > 0 {
+			switch key := traversal[0].(type) {
+			case hcl.TraverseAttr:
+				g.Fgenf(w, "[%q]", key.Name)
+				traversal, parts = traversal[1:], parts[1:]
+			case hcl.TraverseIndex:
+				if key.Key.Type() == cty.String {
+					g.Fgenf(w, "[%q]", key.Key.AsString())
+					traversal, parts = traversal[1:], parts[1:]
+				}
+			}
+		}
 		isRootResource := false
-		g.genRelativeTraversal(w, expr.Traversal.SimpleSplit().Rel, expr.Parts[1:], isRootResource)
+		g.genRelativeTraversal(w, traversal, parts, isRootResource)
 	}
 
 	if isInput {
@@ -563,7 +603,7 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 			tokenRange = expr.SyntaxNode().Range()
 		}
 	}
-	if schemaType, ok := hcl2.GetSchemaForType(destType.(model.Type)); ok {
+	if schemaType, ok := g.program.GetSchemaForType(destType.(model.Type)); ok {
 		switch schemaType := schemaType.(type) {
 		case *schema.ArrayType:
 			token := schemaType.ElementType.(*schema.ObjectType).Token
@@ -607,6 +647,8 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 				fmtString = "%s.%sArgs"
 			}
 			return fmt.Sprintf(fmtString, importPrefix, member)
+		case *schema.MapType:
+			// Map types are named structurally below.
 		default:
 			contract.Failf("unexpected schema type %T", schemaType)
 		}
@@ -657,7 +699,8 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 				}
 				elmType = valType
 			}
-			if allSameType && elmType != "" {
+			// The SDK has no map types for maps of maps, e.g. pulumi.StringMapMap.
+			if allSameType && elmType != "" && !strings.Contains(elmType, "Map") {
 				return fmt.Sprintf("%sMap", elmType)
 			}
 			return "pulumi.Map"
@@ -707,13 +750,19 @@ func (g *generator) argumentTypeName(expr model.Expression, destType model.Type,
 		return g.argumentTypeName(expr, destType.ElementType, isInput)
 	case *model.UnionType:
 		for _, ut := range destType.ElementTypes {
-			if _, isOpaqueType := ut.(*model.OpaqueType); isOpaqueType {
+			switch ut.(type) {
+			case *model.OpaqueType, *model.EnumType:
 				return g.argumentTypeName(expr, ut, isInput)
 			}
 		}
 		return "interface{}"
 	case *model.PromiseType:
 		return g.argumentTypeName(expr, destType.ElementType, isInput)
+	case *model.SecretType:
+		return g.argumentTypeName(expr, destType.ElementType, isInput)
+	case *model.EnumType:
+		// Use the element type for now.
+		return g.argumentTypeName(expr, destType.ElementType, isInput)
 	default:
 		contract.Failf("unexpected destType type %T", destType)
 	}
@@ -772,17 +821,16 @@ func (g *generator) lowerExpression(expr model.Expression, typ model.Type, isInp
 	expr = hcl2.RewritePropertyReferences(expr)
 	expr, diags := hcl2.RewriteApplies(expr, nameInfo(0), false /*TODO*/)
 	expr = hcl2.RewriteConversions(expr, typ)
-	expr, tTemps, ternDiags := g.rewriteTernaries(expr, g.ternaryTempSpiller)
+	expr, lTemps, lowerDiags := g.lowerer.Lower(expr)
 	expr, jTemps, jsonDiags := g.rewriteToJSON(expr, g.jsonTempSpiller)
 	expr, rTemps, readDirDiags := g.rewriteReadDir(expr, g.readDirTempSpiller)
-	expr, sTemps, splatDiags := g.rewriteSplat(expr, g.splatSpiller)
 	expr, oTemps, optDiags := g.rewriteOptionals(expr, g.optionalSpiller)
 
 	if isInput {
 		expr = rewriteInputs(expr)
 	}
 	var temps []interface{}
-	for _, t := range tTemps {
+	for _, t := range lTemps {
 		temps = append(temps, t)
 	}
 	for _, t := range jTemps {
@@ -791,29 +839,23 @@ func (g *generator) lowerExpression(expr model.Expression, typ model.Type, isInp
 	for _, t := range rTemps {
 		temps = append(temps, t)
 	}
-	for _, t := range sTemps {
-		temps = append(temps, t)
-	}
 	for _, t := range oTemps {
 		temps = append(temps, t)
 	}
-	diags = append(diags, ternDiags...)
+	diags = append(diags, lowerDiags...)
 	diags = append(diags, jsonDiags...)
 	diags = append(diags, readDirDiags...)
-	diags = append(diags, splatDiags...)
 	diags = append(diags, optDiags...)
 	contract.Assert(len(diags) == 0)
 	return expr, temps
 }
 
-func (g *generator) genNYI(w io.Writer, reason string, vs ...interface{}) {
-	message := fmt.Sprintf("not yet implemented: %s", fmt.Sprintf(reason, vs...))
-	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
-		Severity: hcl.DiagError,
-		Summary:  message,
-		Detail:   message,
-	})
-	g.Fgenf(w, "\"TODO: %s\"", fmt.Sprintf(reason, vs...))
+// genNYI emits a nil placeholder, preceded by a TODO comment, for an expression that cannot be translated.
+func (g *generator) genNYI(w io.Writer, expr model.Expression, reason string, vs ...interface{}) {
+	construct := g.program.NewUnsupportedConstruct(expr, reason, vs...)
+	g.diagnostics = append(g.diagnostics, construct.Diagnostic())
+	construct.Report(g.report)
+	g.Fgenf(w, "/* %s */ nil", construct.Comment())
 }
 
 func (g *generator) genApply(w io.Writer, expr *model.FunctionCallExpression) {
@@ -967,5 +1009,11 @@ var functionPackages = map[string][]string{
 }
 
 func (g *generator) genFunctionPackages(x *model.FunctionCallExpression) []string {
+	if x.Name == "toJSON" && model.ContainsOutputs(x.Signature.ReturnType) {
+		return nil
+	}
+	if emitter, ok := g.program.IntrinsicEmitter(x.Name, "go"); ok {
+		return emitter.Imports
+	}
 	return functionPackages[x.Name]
 }
@@ -37,6 +37,10 @@ func (js *jsonSpiller) spillExpression(x model.Expression) (model.Expression, hc
 	case *model.FunctionCallExpression:
 		switch x.Name {
 		case "toJSON":
+			// Calls that serialize outputs are generated inline using the SDK's JSON helpers.
+			if model.ContainsOutputs(x.Signature.ReturnType) {
+				return x, nil
+			}
 			temp = &jsonTemp{
 				Name:  fmt.Sprintf("json%d", js.count),
 				Value: x,
@@ -0,0 +1,83 @@
+package gen
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// newLowerer returns a lowerer that spills the expressions that cannot be written inline in Go into temporaries. Go has
+// no conditional operator and no comprehensions, so conditional, for, and splat expressions are all generated as
+// statements that assign their results to temporaries.
+func newLowerer() *hcl2.Lowerer {
+	return hcl2.NewLowerer(hcl2.LoweringOptions{
+		SplatExpressions:       hcl2.LowerToTemporary,
+		ForExpressions:         hcl2.LowerToTemporary,
+		ConditionalExpressions: hcl2.LowerToTemporary,
+	})
+}
+
+// sliceTypeName returns the name of the type of a slice whose elements have the given type.
+func sliceTypeName(elementType string) string {
+	if strings.Contains(elementType, ".") {
+		return elementType + "Array"
+	}
+	return "[]" + elementType
+}
+
+// genTemporary generates the statements that compute the value of a temporary introduced by the lowerer.
+func (g *generator) genTemporary(w io.Writer, t *hcl2.Temporary) {
+	switch v := t.Value.(type) {
+	case *model.ConditionalExpression:
+		// TODO derive from ambient context
+		isInput := false
+		g.Fgenf(w, "var %s %s\n", t.Name, g.argumentTypeName(v.TrueResult, t.Type(), isInput))
+		g.Fgenf(w, "if %.v {\n", v.Condition)
+		g.Fgenf(w, "%s = %.v\n", t.Name, v.TrueResult)
+		g.Fgenf(w, "} else {\n")
+		g.Fgenf(w, "%s = %.v\n", t.Name, v.FalseResult)
+		g.Fgenf(w, "}\n")
+	case *model.SplatExpression:
+		argTyp := g.argumentTypeName(v.Each, v.Each.Type(), false)
+		g.Fgenf(w, "var %s %s\n", t.Name, sliceTypeName(argTyp))
+		g.Fgenf(w, "for _, val0 := range %.v {\n", v.Source)
+		g.Fgenf(w, "%s = append(%s, %.v)\n", t.Name, t.Name, v.Each)
+		g.Fgenf(w, "}\n")
+	case *model.ForExpression:
+		valueType := g.argumentTypeName(v.Value, v.Value.Type(), false)
+		if v.Key != nil {
+			if v.Group {
+				valueType = sliceTypeName(valueType)
+			}
+			keyType := g.argumentTypeName(v.Key, v.Key.Type(), false)
+			g.Fgenf(w, "%s := map[%s]%s{}\n", t.Name, keyType, valueType)
+		} else {
+			g.Fgenf(w, "var %s %s\n", t.Name, sliceTypeName(valueType))
+		}
+
+		keyVariable := "_"
+		if v.KeyVariable != nil {
+			keyVariable = v.KeyVariable.Name
+		}
+		g.Fgenf(w, "for %s, %s := range %.v {\n", keyVariable, v.ValueVariable.Name, v.Collection)
+		if v.Condition != nil {
+			g.Fgenf(w, "if !(%.v) {\n", v.Condition)
+			g.Fgenf(w, "continue\n")
+			g.Fgenf(w, "}\n")
+		}
+		switch {
+		case v.Key == nil:
+			g.Fgenf(w, "%s = append(%s, %.v)\n", t.Name, t.Name, v.Value)
+		case v.Group:
+			g.Fgenf(w, "%s[%.v] = append(%s[%.v], %.v)\n", t.Name, v.Key, t.Name, v.Key, v.Value)
+		default:
+			g.Fgenf(w, "%s[%.v] = %.v\n", t.Name, v.Key, v.Value)
+		}
+		g.Fgenf(w, "}\n")
+	default:
+		contract.Failf("unexpected temporary value: %v", t.Value)
+	}
+}
@@ -67,7 +67,9 @@ func (os *optionalSpiller) spillExpressionHelper(
 						schema.NumberType,
 						schema.BoolType,
 						schema.IntType,
+						schema.Int64Type,
 						schema.StringType,
+						schema.BytesType,
 					}
 					for _, p := range primitives {
 						if p == v.Type {
@@ -2,14 +2,19 @@ package gen
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model/format"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
@@ -114,9 +119,8 @@ func newTestGenerator(t *testing.T, testFile string) *generator {
 		g := &generator{
 			program:             program,
 			jsonTempSpiller:     &jsonSpiller{},
-			ternaryTempSpiller:  &tempSpiller{},
 			readDirTempSpiller:  &readDirSpiller{},
-			splatSpiller:        &splatSpiller{},
+			lowerer:             newLowerer(),
 			optionalSpiller:     &optionalSpiller{},
 			scopeTraversalRoots: codegen.NewStringSet(),
 			arrayHelpers:        make(map[string]*promptToInputArrayHelper),
@@ -127,3 +131,74 @@ func newTestGenerator(t *testing.T, testFile string) *generator {
 	t.Fatalf("test file not found")
 	return nil
 }
+
+func TestGenProgramSourceMap(t *testing.T) {
+	g := newTestGenerator(t, "aws-s3-logging.pp")
+	files, diags, err := GenerateProgramWithOptions(g.program, codegen.GenerateProgramOptions{SourceMap: true})
+	assert.NoError(t, err)
+	assert.False(t, diags.HasErrors())
+
+	var sourceMap codegen.SourceMap
+	err = json.Unmarshal(files["main.go.map"], &sourceMap)
+	assert.NoError(t, err)
+	assert.Equal(t, "main.go", sourceMap.File)
+
+	// Each node maps to the lines generated for it in the formatted program. Note that the PCL parser numbers source lines
+	// from zero.
+	lines := strings.Split(string(files["main.go"]), "\n")
+	var mapped []string
+	for _, m := range sourceMap.Mappings {
+		mapped = append(mapped, fmt.Sprintf("%d: %s ... %s", m.Source.StartLine,
+			strings.TrimSpace(lines[m.GeneratedStartLine-1]), strings.TrimSpace(lines[m.GeneratedEndLine-1])))
+	}
+	assert.Equal(t, []string{
+		`0: logs, err := s3.NewBucket(ctx, "logs", nil) ... }`,
+		`2: bucket, err := s3.NewBucket(ctx, "bucket", &s3.BucketArgs{ ... }`,
+		`8: ctx.Export("targetBucket", bucket.Loggings.ApplyT(func(loggings []s3.BucketLogging) (string, error) { ... ` +
+			`}).(pulumi.StringOutput))`,
+	}, mapped)
+}
+
+func TestGenProgramRegisteredIntrinsics(t *testing.T) {
+	registry := hcl2.NewIntrinsicRegistry()
+	registry.MustRegister(&hcl2.IntrinsicFunction{
+		Name: "cidrsubnet",
+		Signature: model.StaticFunctionSignature{
+			Parameters: []model.Parameter{
+				{Name: "prefix", Type: model.StringType},
+				{Name: "newbits", Type: model.NumberType},
+				{Name: "netnum", Type: model.NumberType},
+			},
+			ReturnType: model.StringType,
+		},
+		Emitters: map[string]*hcl2.IntrinsicEmitter{
+			"go": {
+				Imports: []string{"github.com/example/cidr"},
+				Emit: func(f *format.Formatter, w io.Writer, call *model.FunctionCallExpression) {
+					f.Fgenf(w, "cidr.Subnet(%.v, %.v, %.v)", call.Args[0], call.Args[1], call.Args[2])
+				},
+			},
+		},
+	})
+
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(`
+output subnet {
+	value = cidrsubnet("10.0.0.0/16", 8, 1)
+}
+`), "main.pp")
+	assert.NoError(t, err)
+
+	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.PluginHost(test.NewHost(testdataPath)),
+		hcl2.Intrinsics(registry))
+	assert.NoError(t, err)
+	assert.Len(t, diags, 0)
+
+	files, diags, err := GenerateProgram(program)
+	assert.NoError(t, err)
+	assert.Len(t, diags, 0)
+
+	main := string(files["main.go"])
+	assert.Contains(t, main, `"github.com/example/cidr"`)
+	assert.Contains(t, main, `cidr.Subnet("10.0.0.0/16", 8, 1)`)
+}
@@ -0,0 +1,139 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// SDKSurface returns the exported symbols of the Go SDK that GeneratePackage generates for the given package, named
+// by their import paths relative to the SDK's root.
+func SDKSurface(tool string, pkg *schema.Package) (codegen.Surface, error) {
+	if err := pkg.ImportLanguages(map[string]schema.Language{"go": Importer}); err != nil {
+		return nil, err
+	}
+
+	goInfo, _ := pkg.Language["go"].(GoPackageInfo)
+	surface := codegen.Surface{}
+	for mod, ctx := range generatePackageContextMap(tool, pkg, goInfo) {
+		module := path.Join(pkg.Name, mod)
+		qualify := func(name string) string {
+			return module + "." + name
+		}
+		addFields := func(parent string, props []*schema.Property, typ func(*schema.Property) string, argument bool) {
+			for _, p := range props {
+				surface.Add(parent+"."+Title(p.Name), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    parent,
+					Signature: typ(p),
+					Argument:  argument,
+					Optional:  !p.IsRequired,
+				})
+			}
+		}
+		inputType := func(p *schema.Property) string { return ctx.inputType(p.Type, !p.IsRequired) }
+		outputType := func(p *schema.Property) string { return ctx.outputType(p.Type, !p.IsRequired) }
+		plainType := func(p *schema.Property) string { return ctx.plainType(p.Type, !p.IsRequired) }
+
+		for _, r := range ctx.resources {
+			name := resourceName(r)
+			res := surface.Add(qualify(name), codegen.SurfaceSymbol{Module: module, Token: r.Token, Signature: "struct"})
+			addFields(res, r.Properties, outputType, false)
+
+			surface.Add(qualify("New"+name), codegen.SurfaceSymbol{
+				Module: module,
+				Parent: res,
+				Signature: fmt.Sprintf("func(ctx *pulumi.Context, name string, args *%[1]sArgs, "+
+					"opts ...pulumi.ResourceOption) (*%[1]s, error)", name),
+			})
+			args := surface.Add(qualify(name+"Args"), codegen.SurfaceSymbol{
+				Module:    module,
+				Parent:    res,
+				Signature: "struct",
+			})
+			addFields(args, r.InputProperties, inputType, true)
+
+			if !r.IsProvider {
+				surface.Add(qualify("Get"+name), codegen.SurfaceSymbol{
+					Module: module,
+					Parent: res,
+					Signature: fmt.Sprintf("func(ctx *pulumi.Context, name string, id pulumi.IDInput, "+
+						"state *%[1]sState, opts ...pulumi.ResourceOption) (*%[1]s, error)", name),
+				})
+			}
+		}
+
+		for _, f := range ctx.functions {
+			name := ctx.functionNames[f]
+
+			signature := "func(ctx *pulumi.Context"
+			if f.Inputs != nil {
+				signature += fmt.Sprintf(", args *%sArgs", name)
+			}
+			signature += ", opts ...pulumi.InvokeOption) "
+			if f.Outputs != nil {
+				signature += fmt.Sprintf("(*%sResult, error)", name)
+			} else {
+				signature += "error"
+			}
+
+			fn := surface.Add(qualify(name), codegen.SurfaceSymbol{Module: module, Token: f.Token, Signature: signature})
+			if f.Inputs != nil {
+				args := surface.Add(qualify(name+"Args"), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    fn,
+					Signature: "struct",
+				})
+				addFields(args, f.Inputs.Properties, plainType, true)
+			}
+			if f.Outputs != nil {
+				result := surface.Add(qualify(name+"Result"), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    fn,
+					Signature: "struct",
+				})
+				addFields(result, f.Outputs.Properties, plainType, false)
+			}
+		}
+
+		for _, t := range ctx.types {
+			name := ctx.tokenToType(t.Token)
+			typ := surface.Add(qualify(name), codegen.SurfaceSymbol{Module: module, Token: t.Token, Signature: "struct"})
+			addFields(typ, t.Properties, plainType, false)
+
+			args := surface.Add(qualify(name+"Args"), codegen.SurfaceSymbol{
+				Module:    module,
+				Parent:    typ,
+				Signature: "struct",
+			})
+			addFields(args, t.Properties, inputType, true)
+		}
+
+		if mod == "config" {
+			for _, p := range pkg.Config {
+				getType, _ := configType(p.Type)
+				surface.Add(qualify("Get"+Title(p.Name)), codegen.SurfaceSymbol{
+					Module:    module,
+					Signature: "func(ctx *pulumi.Context) " + getType,
+				})
+			}
+		}
+	}
+	return surface, nil
+}
@@ -1,9 +1,12 @@
 package gen
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 )
 
 func TestInputUsage(t *testing.T) {
@@ -35,3 +38,38 @@ func TestInputUsage(t *testing.T) {
 			" of `FooInput` via:\n\n\t\t FooArgs{...}\n ",
 		usage)
 }
+
+func TestGenerateEnums(t *testing.T) {
+	var spec schema.PackageSpec
+	err := json.Unmarshal([]byte(`{
+		"name": "example",
+		"types": {
+			"example:index:Size": {
+				"type": "integer",
+				"description": "The size of a widget.",
+				"enum": [
+					{"name": "Small", "value": 1, "description": "A small widget."},
+					{"name": "Large", "value": 2, "deprecationMessage": "Use Small instead."}
+				]
+			},
+			"example:index:Region": {
+				"type": "string",
+				"enum": [{"value": "us-east-1"}, {"value": "eu-west-2"}]
+			}
+		}
+	}`), &spec)
+	assert.NoError(t, err)
+	pkg, err := schema.ImportSpec(spec, nil)
+	assert.NoError(t, err)
+
+	files, err := GeneratePackage("test", pkg)
+	assert.NoError(t, err)
+	enums := string(files["example/pulumiEnums.go"])
+	assert.Contains(t, enums, "type Size int\n")
+	assert.Contains(t, enums, "\t// Deprecated: Use Small instead.\n\tSizeLarge = Size(2)\n")
+	assert.Contains(t, enums, "func SizeValues() []Size {\n\treturn []Size{\n\t\tSizeSmall,\n\t\tSizeLarge,\n")
+	assert.Contains(t, enums, "func ParseSize(s string) (Size, error) {\n")
+	assert.Contains(t, enums, "func (e Size) IsValid() bool {\n")
+	assert.Contains(t, enums, "type Region string\n")
+	assert.Contains(t, enums, "\tRegionUsEast1 = Region(\"us-east-1\")\n")
+}
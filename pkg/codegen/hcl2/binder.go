@@ -18,6 +18,7 @@ import (
 	"os"
 	"sort"
 
+	"github.com/blang/semver"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
@@ -25,13 +26,23 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
 	"github.com/zclconf/go-cty/cty"
 )
 
 type bindOptions struct {
-	allowMissingVariables bool
-	loader                schema.Loader
-	packageCache          *PackageCache
+	allowMissingVariables    bool
+	removeUnusedLocals       bool
+	skipResourceTypechecking bool
+	loader                   schema.Loader
+	packageCache             *PackageCache
+	packages                 []*schema.Package
+	opaqueTypes              *model.OpaqueTypeRegistry
+	intrinsics               *IntrinsicRegistry
+	maxErrors                int
+	allWarningsAsErrors      bool
+	warningsAsErrors         map[DiagnosticCode]bool
+	suppressedWarnings       map[DiagnosticCode]bool
 }
 
 func (opts bindOptions) modelOptions() []model.BindOption {
@@ -44,8 +55,14 @@ func (opts bindOptions) modelOptions() []model.BindOption {
 type binder struct {
 	options bindOptions
 
-	referencedPackages map[string]*schema.Package
-	typeSchemas        map[model.Type]schema.Type
+	packageVersions    map[string]*semver.Version
+	referencedPackages map[string]*packageSchema
+	missingPackages    map[string]error
+	types              *TypeMapper
+	components         map[string]*Component
+	fixes              map[*hcl.Diagnostic][]QuickFix
+	codes              map[*hcl.Diagnostic]DiagnosticCode
+	intrinsics         map[string]string
 
 	tokens syntax.TokenMap
 	nodes  []Node
@@ -58,6 +75,59 @@ func AllowMissingVariables(options *bindOptions) {
 	options.allowMissingVariables = true
 }
 
+// RemoveUnusedLocals causes the binder to remove local variables that are not referenced by any config, resource, or
+// output, either directly or through other local variables, from the bound program and its components. A warning that
+// lists the removed locals is reported.
+func RemoveUnusedLocals(options *bindOptions) {
+	options.removeUnusedLocals = true
+}
+
+// SkipResourceTypechecking causes the binder to tolerate packages whose schemas cannot be loaded, e.g. because their
+// plugins are not installed. Rather than failing, BindProgram gives the resources and the results of the invokes of
+// such packages dynamic types and reports a warning for each of them.
+func SkipResourceTypechecking(options *bindOptions) {
+	options.skipResourceTypechecking = true
+}
+
+// MaxErrors limits the number of errors reported by BindProgram. If binding produces more than max errors, only the
+// first max errors in source order are reported, followed by an error that counts those that were omitted. Warnings
+// are always reported. A limit of zero (the default) reports all errors.
+func MaxErrors(max int) BindOption {
+	return func(options *bindOptions) {
+		options.maxErrors = max
+	}
+}
+
+// WarningsAsErrors causes BindProgram to report the warnings with the given codes as errors. If no codes are given, all
+// warnings are reported as errors. Warnings that are suppressed by SuppressWarnings are not reported at all.
+func WarningsAsErrors(codes ...DiagnosticCode) BindOption {
+	return func(options *bindOptions) {
+		if len(codes) == 0 {
+			options.allWarningsAsErrors = true
+			return
+		}
+		if options.warningsAsErrors == nil {
+			options.warningsAsErrors = map[DiagnosticCode]bool{}
+		}
+		for _, code := range codes {
+			options.warningsAsErrors[code] = true
+		}
+	}
+}
+
+// SuppressWarnings causes BindProgram to omit the warnings with the given codes from the diagnostics it reports.
+// Errors are always reported.
+func SuppressWarnings(codes ...DiagnosticCode) BindOption {
+	return func(options *bindOptions) {
+		if options.suppressedWarnings == nil {
+			options.suppressedWarnings = map[DiagnosticCode]bool{}
+		}
+		for _, code := range codes {
+			options.suppressedWarnings[code] = true
+		}
+	}
+}
+
 func PluginHost(host plugin.Host) BindOption {
 	return Loader(schema.NewPluginLoader(host))
 }
@@ -74,8 +144,31 @@ func Cache(cache *PackageCache) BindOption {
 	}
 }
 
+// OpaqueTypes sets the registry that holds the opaque types that represent the token types of package schemas. By
+// default, each call to BindProgram uses a registry of its own, so the token types of programs that are bound
+// separately are distinct even if their tokens are equal. Programs that are bound with the same registry share token
+// types.
+func OpaqueTypes(registry *model.OpaqueTypeRegistry) BindOption {
+	return func(options *bindOptions) {
+		options.opaqueTypes = registry
+	}
+}
+
+// Packages adds the given in-memory packages to the package cache used to bind a program. Programs may refer to these
+// packages as if they were backed by plugins. If a cache is supplied using the Cache option, the packages are added to
+// that cache. See PackageCache.AddPackage for details.
+func Packages(pkgs ...*schema.Package) BindOption {
+	return func(options *bindOptions) {
+		options.packages = append(options.packages, pkgs...)
+	}
+}
+
 // BindProgram performs semantic analysis on the given set of HCL2 files that represent a single program. The given
 // host, if any, is used for loading any resource plugins necessary to extract schema information.
+//
+// BindProgram binds every node in the program even if earlier nodes fail to bind, and reports the diagnostics for all
+// of the program's files in source order. See MaxErrors for limiting the number of reported errors, and
+// WarningsAsErrors and SuppressWarnings for changing how warnings are reported. Each diagnostic has a DiagnosticCode.
 func BindProgram(files []*syntax.File, opts ...BindOption) (*Program, hcl.Diagnostics, error) {
 	var options bindOptions
 	for _, o := range opts {
@@ -91,7 +184,12 @@ func BindProgram(files []*syntax.File, opts ...BindOption) (*Program, hcl.Diagno
 		if err != nil {
 			return nil, nil, err
 		}
-		options.loader = schema.NewPluginLoader(ctx.Host)
+		// Cache schemas on disk so that later bindings need not boot the providers again.
+		if dir, err := workspace.GetSchemaDir(); err == nil {
+			options.loader = schema.NewCachingPluginLoader(ctx.Host, dir)
+		} else {
+			options.loader = schema.NewPluginLoader(ctx.Host)
+		}
 
 		defer contract.IgnoreClose(ctx)
 	}
@@ -99,26 +197,26 @@ func BindProgram(files []*syntax.File, opts ...BindOption) (*Program, hcl.Diagno
 	if options.packageCache == nil {
 		options.packageCache = NewPackageCache()
 	}
+	if options.opaqueTypes == nil {
+		options.opaqueTypes = model.NewOpaqueTypeRegistry()
+	}
+	for _, pkg := range options.packages {
+		options.packageCache.AddPackage(pkg)
+	}
 
 	b := &binder{
 		options:            options,
 		tokens:             syntax.NewTokenMapForFiles(files),
-		referencedPackages: map[string]*schema.Package{},
-		typeSchemas:        map[model.Type]schema.Type{},
-		root:               model.NewRootScope(syntax.None),
+		packageVersions:    map[string]*semver.Version{},
+		referencedPackages: map[string]*packageSchema{},
+		missingPackages:    map[string]error{},
+		types:              NewTypeMapper(options.opaqueTypes),
+		components:         map[string]*Component{},
+		fixes:              map[*hcl.Diagnostic][]QuickFix{},
+		codes:              map[*hcl.Diagnostic]DiagnosticCode{},
+		intrinsics:         map[string]string{},
 	}
-
-	// Define null.
-	b.root.Define("null", &model.Constant{
-		Name:          "null",
-		ConstantValue: cty.NullVal(cty.DynamicPseudoType),
-	})
-	// Define builtin functions.
-	for name, fn := range pulumiBuiltins {
-		b.root.DefineFunction(name, fn)
-	}
-	// Define the invoke function.
-	b.root.DefineFunction(Invoke, model.NewFunction(model.GenericFunctionSignature(b.bindInvokeSignature)))
+	b.root = b.newRootScope()
 
 	var diagnostics hcl.Diagnostics
 
@@ -126,29 +224,78 @@ func BindProgram(files []*syntax.File, opts ...BindOption) (*Program, hcl.Diagno
 	sort.Slice(files, func(i, j int) bool {
 		return files[i].Name < files[j].Name
 	})
+
+	// Collect the package versions requested by resources so that all references to a package use the same schema.
 	for _, f := range files {
-		fileDiags, err := b.declareNodes(f)
-		if err != nil {
-			return nil, nil, err
-		}
-		diagnostics = append(diagnostics, fileDiags...)
+		diagnostics = append(diagnostics, b.collectPackageVersions(f)...)
+	}
+	for _, f := range files {
+		diagnostics = append(diagnostics, b.declareNodes(f)...)
+	}
+
+	// Load the schemas for the packages referenced by the nodes.
+	if err := b.loadReferencedPackageSchemas(b.nodes); err != nil {
+		return nil, nil, err
 	}
 
-	// Now bind the nodes.
+	// Define the intrinsics that call the functions of the referenced packages.
+	b.defineIntrinsics(b.root)
+	for _, c := range b.components {
+		b.defineIntrinsics(c.scope)
+	}
+
+	// Now bind the nodes, then lower any calls to intrinsics into calls to invoke.
 	for _, n := range b.nodes {
 		diagnostics = append(diagnostics, b.bindNode(n)...)
 	}
+	for _, n := range b.nodes {
+		diags := n.VisitExpressions(nil, b.lowerIntrinsicCall)
+		diagnostics = append(diagnostics, diags...)
+	}
+
+	if options.removeUnusedLocals {
+		var diags hcl.Diagnostics
+		b.nodes, diags = removeAllUnusedLocals(b.nodes)
+		for _, d := range diags {
+			diagnostics = append(diagnostics, b.withCode(d, CodeUnusedLocalsRemoved))
+		}
+	}
 
+	diagnostics = b.applyDiagnosticOptions(diagnostics)
+	sortDiagnostics(diagnostics)
 	return &Program{
 		Nodes:  b.nodes,
 		files:  files,
 		binder: b,
-	}, diagnostics, nil
+	}, b.limitErrors(diagnostics, options.maxErrors), nil
 }
 
-// declareNodes declares all of the top-level nodes in the given file. This invludes config, resources, outputs, and
-// locals.
-func (b *binder) declareNodes(file *syntax.File) (hcl.Diagnostics, error) {
+// newRootScope returns a new scope that defines null, the builtin functions, the invoke function, and the registered
+// intrinsics. The program's top-level nodes are declared in such a scope, as are the nodes inside of each component.
+func (b *binder) newRootScope() *model.Scope {
+	root := model.NewRootScope(syntax.None)
+
+	// Define null.
+	root.Define("null", &model.Constant{
+		Name:          "null",
+		ConstantValue: cty.NullVal(cty.DynamicPseudoType),
+	})
+	// Define builtin functions.
+	for name, fn := range pulumiBuiltins {
+		root.DefineFunction(name, fn)
+	}
+	// Define the invoke function.
+	root.DefineFunction(Invoke, model.NewFunction(model.GenericFunctionSignature(b.bindInvokeSignature)))
+	// Define the registered intrinsics.
+	for _, fn := range b.options.intrinsics.Functions() {
+		root.DefineFunction(fn.Name, model.NewFunction(fn.Signature))
+	}
+	return root
+}
+
+// declareNodes declares all of the top-level nodes in the given file. This invludes config, resources, providers,
+// components, outputs, and locals.
+func (b *binder) declareNodes(file *syntax.File) hcl.Diagnostics {
 	var diagnostics hcl.Diagnostics
 
 	// Declare body items in source order.
@@ -158,10 +305,6 @@ func (b *binder) declareNodes(file *syntax.File) (hcl.Diagnostics, error) {
 			v := &LocalVariable{syntax: item}
 			attrDiags := b.declareNode(item.Name, v)
 			diagnostics = append(diagnostics, attrDiags...)
-
-			if err := b.loadReferencedPackageSchemas(v); err != nil {
-				return nil, err
-			}
 		case *hclsyntax.Block:
 			switch item.Type {
 			case "config":
@@ -176,7 +319,7 @@ func (b *binder) declareNodes(file *syntax.File) (hcl.Diagnostics, error) {
 					diagnostics = append(diagnostics, diags...)
 					typ = typeExpr.Type()
 				default:
-					diagnostics = append(diagnostics, labelsErrorf(item, "config variables must have exactly one or two labels"))
+					diagnostics = append(diagnostics, b.labelsErrorf(item, "config variables must have exactly one or two labels"))
 				}
 
 				// TODO(pdg): check body for valid contents
@@ -187,13 +330,10 @@ func (b *binder) declareNodes(file *syntax.File) (hcl.Diagnostics, error) {
 				}
 				diags := b.declareNode(name, v)
 				diagnostics = append(diagnostics, diags...)
-
-				if err := b.loadReferencedPackageSchemas(v); err != nil {
-					return nil, err
-				}
 			case "resource":
 				if len(item.Labels) != 2 {
-					diagnostics = append(diagnostics, labelsErrorf(item, "resource variables must have exactly two labels"))
+					diagnostics = append(diagnostics, b.labelsErrorf(item, "resource variables must have exactly two labels"))
+					continue
 				}
 
 				resource := &Resource{
@@ -201,10 +341,21 @@ func (b *binder) declareNodes(file *syntax.File) (hcl.Diagnostics, error) {
 				}
 				declareDiags := b.declareNode(item.Labels[0], resource)
 				diagnostics = append(diagnostics, declareDiags...)
+			case "provider":
+				if len(item.Labels) != 2 {
+					diagnostics = append(diagnostics, b.labelsErrorf(item, "providers must have exactly two labels"))
+					continue
+				}
 
-				if err := b.loadReferencedPackageSchemas(resource); err != nil {
-					return nil, err
+				provider := &Provider{
+					Resource: &Resource{syntax: item},
+					Package:  item.Labels[1],
 				}
+				declareDiags := b.declareNode(item.Labels[0], provider)
+				diagnostics = append(diagnostics, declareDiags...)
+			case "component":
+				diags := b.declareComponent(item)
+				diagnostics = append(diagnostics, diags...)
 			case "output":
 				name, typ := "<unnamed>", model.Type(model.DynamicType)
 				switch len(item.Labels) {
@@ -217,7 +368,7 @@ func (b *binder) declareNodes(file *syntax.File) (hcl.Diagnostics, error) {
 					diagnostics = append(diagnostics, diags...)
 					typ = typeExpr.Type()
 				default:
-					diagnostics = append(diagnostics, labelsErrorf(item, "config variables must have exactly one or two labels"))
+					diagnostics = append(diagnostics, b.labelsErrorf(item, "config variables must have exactly one or two labels"))
 				}
 
 				// TODO(pdg): check body for valid contents
@@ -228,15 +379,11 @@ func (b *binder) declareNodes(file *syntax.File) (hcl.Diagnostics, error) {
 				}
 				diags := b.declareNode(name, v)
 				diagnostics = append(diagnostics, diags...)
-
-				if err := b.loadReferencedPackageSchemas(v); err != nil {
-					return nil, err
-				}
 			}
 		}
 	}
 
-	return diagnostics, nil
+	return diagnostics
 }
 
 // declareNode declares a single top-level node. If a node with the same name has already been declared, it returns an
@@ -244,12 +391,14 @@ func (b *binder) declareNodes(file *syntax.File) (hcl.Diagnostics, error) {
 func (b *binder) declareNode(name string, n Node) hcl.Diagnostics {
 	if !b.root.Define(name, n) {
 		existing, _ := b.root.BindReference(name)
-		return hcl.Diagnostics{errorf(existing.SyntaxNode().Range(), "%q already declared", name)}
+		return hcl.Diagnostics{b.alreadyDeclared(name, existing.SyntaxNode().Range())}
 	}
 	b.nodes = append(b.nodes, n)
 	return nil
 }
 
 func (b *binder) bindExpression(node hclsyntax.Node) (model.Expression, hcl.Diagnostics) {
-	return model.BindExpression(node, b.root, b.tokens, b.options.modelOptions()...)
+	expr, diagnostics := model.BindExpression(node, b.root, b.tokens, b.options.modelOptions()...)
+	expr, diags := model.VisitExpression(expr, nil, b.lowerIntrinsicCall)
+	return expr, append(diagnostics, diags...)
 }
@@ -0,0 +1,228 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// declareComponent declares a component and the nodes inside of it. The component's inputs are bound at this point so
+// that the nodes inside of the component can refer to them; its nodes and outputs are bound by bindComponent.
+func (b *binder) declareComponent(block *hclsyntax.Block) hcl.Diagnostics {
+	if len(block.Labels) != 1 {
+		return hcl.Diagnostics{b.labelsErrorf(block, "components must have exactly one label")}
+	}
+	name := block.Labels[0]
+	if existing, ok := b.components[name]; ok {
+		return hcl.Diagnostics{b.componentAlreadyDeclared(name, existing.SyntaxNode().Range())}
+	}
+
+	component := &Component{
+		syntax:     block,
+		scope:      b.newRootScope(),
+		OutputType: model.DynamicType,
+	}
+
+	var diagnostics hcl.Diagnostics
+	declare := func(name string, n Node) {
+		if !component.scope.Define(name, n) {
+			existing, _ := component.scope.BindReference(name)
+			diagnostics = append(diagnostics, b.alreadyDeclared(name, existing.SyntaxNode().Range()))
+			return
+		}
+		component.Nodes = append(component.Nodes, n)
+	}
+
+	inputTypes := map[string]model.Type{}
+	blockTypes := codegen.StringSet{}
+	for _, item := range model.SourceOrderBody(block.Body) {
+		switch item := item.(type) {
+		case *hclsyntax.Attribute:
+			diagnostics = append(diagnostics, b.unsupportedAttribute(item.Name, item.NameRange))
+		case *hclsyntax.Block:
+			if blockTypes.Has(item.Type) {
+				diagnostics = append(diagnostics, b.duplicateBlock(item.Type, item.TypeRange))
+				continue
+			}
+			blockTypes.Add(item.Type)
+
+			switch item.Type {
+			case "inputs":
+				for _, input := range model.SourceOrderBody(item.Body) {
+					switch input := input.(type) {
+					case *hclsyntax.Attribute:
+						typeExpr, diags := model.BindExpression(input.Expr, model.TypeScope, b.tokens)
+						diagnostics = append(diagnostics, diags...)
+						typ := typeExpr.Type()
+						if !isComponentInputType(typ) {
+							diagnostics = append(diagnostics, b.unsupportedComponentInputType(input.Name, typ, input.Expr.Range()))
+						}
+
+						v := &model.Variable{
+							Name:         input.Name,
+							VariableType: model.NewOutputType(typ),
+						}
+						component.scope.Define(input.Name, v)
+						component.Inputs = append(component.Inputs, v)
+						inputTypes[input.Name] = typ
+					case *hclsyntax.Block:
+						diagnostics = append(diagnostics, b.unsupportedBlock(input.Type, input.TypeRange))
+					}
+				}
+			case "resources":
+				for _, child := range model.SourceOrderBody(item.Body) {
+					switch child := child.(type) {
+					case *hclsyntax.Attribute:
+						declare(child.Name, &LocalVariable{syntax: child})
+					case *hclsyntax.Block:
+						if child.Type != "resource" {
+							diagnostics = append(diagnostics, b.unsupportedBlock(child.Type, child.TypeRange))
+							continue
+						}
+						if len(child.Labels) != 2 {
+							diagnostics = append(diagnostics,
+								b.labelsErrorf(child, "resource variables must have exactly two labels"))
+							continue
+						}
+						declare(child.Labels[0], &Resource{syntax: child})
+					}
+				}
+			case "outputs":
+				component.outputs = item
+			default:
+				diagnostics = append(diagnostics, b.unsupportedBlock(item.Type, item.TypeRange))
+			}
+		}
+	}
+	component.InputType = model.NewObjectType(inputTypes)
+
+	b.components[name] = component
+	b.nodes = append(b.nodes, component)
+	return diagnostics
+}
+
+// isComponentInputType returns true if the given type may be the type of a component input. Code generators must be
+// able to name the input type of each input in their target language, so inputs are limited to primitives and lists
+// and maps of primitives.
+func isComponentInputType(t model.Type) bool {
+	switch t := t.(type) {
+	case *model.ListType:
+		return isPrimitiveType(t.ElementType)
+	case *model.MapType:
+		return isPrimitiveType(t.ElementType)
+	default:
+		return isPrimitiveType(t)
+	}
+}
+
+func isPrimitiveType(t model.Type) bool {
+	switch t {
+	case model.BoolType, model.IntType, model.NumberType, model.StringType:
+		return true
+	default:
+		return false
+	}
+}
+
+// getComponent returns the component instantiated by the given resource, if any.
+func (b *binder) getComponent(node *Resource) (*Component, bool) {
+	if node.syntax.Type != "resource" || len(node.syntax.Labels) != 2 {
+		return nil, false
+	}
+	component, ok := b.components[node.syntax.Labels[1]]
+	return component, ok
+}
+
+// bindComponent binds the nodes and outputs of a component in the component's scope.
+func (b *binder) bindComponent(node *Component) hcl.Diagnostics {
+	root := b.root
+	b.root = node.scope
+	defer func() { b.root = root }()
+
+	var diagnostics hcl.Diagnostics
+	for _, n := range node.Nodes {
+		diagnostics = append(diagnostics, b.bindNode(n)...)
+	}
+
+	outputTypes := map[string]model.Type{
+		"urn": model.NewOutputType(model.StringType),
+	}
+	outputSchema := &schema.ObjectType{Token: "components:index:" + node.Name()}
+	if node.outputs != nil {
+		block, diags := model.BindBlock(node.outputs, model.StaticScope(node.scope), b.tokens,
+			b.options.modelOptions()...)
+		diagnostics = append(diagnostics, diags...)
+
+		for _, item := range block.Body.Items {
+			switch item := item.(type) {
+			case *model.Attribute:
+				if item.Name == "urn" {
+					diagnostics = append(diagnostics, b.reservedOutputName(item.Syntax.NameRange))
+					continue
+				}
+				node.Outputs = append(node.Outputs, item)
+				outputTypes[item.Name] = model.NewOutputType(model.ResolveOutputs(item.Value.Type()))
+				outputSchema.Properties = append(outputSchema.Properties, &schema.Property{
+					Name: item.Name,
+					Type: schema.AnyType,
+				})
+			case *model.Block:
+				diagnostics = append(diagnostics, b.unsupportedBlock(item.Type, item.Syntax.TypeRange))
+			}
+		}
+	}
+	// Annotate the output type with a schema so that code generators treat the component's outputs as the properties
+	// of an object rather than as the entries of a map.
+	node.OutputType = model.NewObjectType(outputTypes, outputSchema)
+
+	return diagnostics
+}
+
+// checkComponentInputs typechecks the inputs of a component instance against the component's inputs, all of which are
+// required.
+func (b *binder) checkComponentInputs(node *Resource) hcl.Diagnostics {
+	inputType := node.Component.InputType.(*model.ObjectType)
+
+	attrNames := codegen.StringSet{}
+	for _, attr := range node.Inputs {
+		attrNames.Add(attr.Name)
+	}
+	unsetNames := unsetProperties(inputType.Properties, attrNames)
+
+	var diagnostics hcl.Diagnostics
+	for _, attr := range node.Inputs {
+		typ, ok := inputType.Properties[attr.Name]
+		if !ok {
+			diag := b.unsupportedAttribute(attr.Name, attr.Syntax.NameRange)
+			fixes := renameAttributeFix(attr.Name, attr.Syntax.NameRange, unsetNames)
+			diagnostics = append(diagnostics, b.withFixes(diag, fixes...))
+			continue
+		}
+		if model.InputType(typ).ConversionFrom(attr.Value.Type()) == model.NoConversion {
+			diagnostics = append(diagnostics, model.ExprNotConvertible(model.InputType(typ), attr.Value))
+		}
+	}
+	for _, input := range node.Component.Inputs {
+		if !attrNames.Has(input.Name) {
+			diag := b.missingRequiredAttribute(input.Name, node.syntax.Body.MissingItemRange())
+			diagnostics = append(diagnostics, b.withFixes(diag, insertAttributeFix(input.Name, input.Type(), node.syntax)))
+		}
+	}
+	return diagnostics
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 )
 
@@ -61,6 +62,12 @@ func (b *binder) bindNode(node Node) hcl.Diagnostics {
 	case *Resource:
 		diags := b.bindResource(node)
 		diagnostics = append(diagnostics, diags...)
+	case *Provider:
+		diags := b.bindResource(node.Resource)
+		diagnostics = append(diagnostics, diags...)
+	case *Component:
+		diags := b.bindComponent(node)
+		diagnostics = append(diagnostics, diags...)
 	case *OutputVariable:
 		diags := b.bindOutputVariable(node)
 		diagnostics = append(diagnostics, diags...)
@@ -74,6 +81,12 @@ func (b *binder) bindNode(node Node) hcl.Diagnostics {
 
 // getDependencies returns the dependencies for the given node.
 func (b *binder) getDependencies(node Node) []Node {
+	// The nodes inside of a component are bound in the component's own scope, so a component does not depend on any of
+	// the nodes in the scope that declares it.
+	if _, ok := node.(*Component); ok {
+		return nil
+	}
+
 	depSet := codegen.Set{}
 	var deps []Node
 	diags := hclsyntax.VisitAll(node.SyntaxNode(), func(node hclsyntax.Node) hcl.Diagnostics {
@@ -97,21 +110,65 @@ func (b *binder) getDependencies(node Node) []Node {
 		return nil
 	})
 	contract.Assert(len(diags) == 0)
+
+	// A component instance depends on its component.
+	if r, ok := node.(*Resource); ok {
+		if component, ok := b.getComponent(r); ok {
+			deps = append(deps, component)
+		}
+	}
 	return SourceOrderNodes(deps)
 }
 
 func (b *binder) bindConfigVariable(node *ConfigVariable) hcl.Diagnostics {
-	block, diagnostics := model.BindBlock(node.syntax, model.StaticScope(b.root), b.tokens, b.options.modelOptions()...)
+	var diagnostics hcl.Diagnostics
+	if pkg, ok := b.referencedPackages[node.Namespace()]; ok {
+		diagnostics = b.bindProviderConfigVariable(node, pkg.schema)
+	}
+
+	block, blockDiags := model.BindBlock(node.syntax, model.StaticScope(b.root), b.tokens, b.options.modelOptions()...)
+	diagnostics = append(diagnostics, blockDiags...)
 	if defaultValue, ok := block.Body.Attribute("default"); ok {
 		node.DefaultValue = defaultValue.Value
 		if model.InputType(node.typ).ConversionFrom(node.DefaultValue.Type()) == model.NoConversion {
-			diagnostics = append(diagnostics, model.ExprNotConvertible(model.InputType(node.typ), node.DefaultValue))
+			diagnostics = append(diagnostics, b.exprNotConvertible(model.InputType(node.typ), node.DefaultValue))
 		}
 	}
 	node.Definition = block
 	return diagnostics
 }
 
+// bindProviderConfigVariable checks a config variable that names a provider configuration key (e.g. `aws:region`)
+// against the provider's configuration schema. If the config variable does not specify a type, it takes on the type of
+// the configuration key.
+func (b *binder) bindProviderConfigVariable(node *ConfigVariable, pkg *schema.Package) hcl.Diagnostics {
+	key := node.Key()
+
+	var prop *schema.Property
+	for _, p := range pkg.Config {
+		if p.Name == key {
+			prop = p
+			break
+		}
+	}
+	if prop == nil {
+		return hcl.Diagnostics{b.unknownConfigKey(pkg.Name, key, node.syntax.LabelRanges[0])}
+	}
+	node.ProviderConfig = prop
+
+	configType := b.types.ModelType(prop.Type)
+	if node.typ == model.DynamicType {
+		node.typ = configType
+		return nil
+	}
+	if model.InputType(configType).ConversionFrom(node.typ) == model.NoConversion {
+		typeRange := node.syntax.LabelRanges[1]
+		diag := b.configTypeMismatch(node.syntax.Labels[0], node.typ, configType, typeRange)
+		return hcl.Diagnostics{b.withFixes(diag, changeTypeFix(configType, typeRange)...)}
+	}
+	return nil
+}
+
 func (b *binder) bindLocalVariable(node *LocalVariable) hcl.Diagnostics {
 	attr, diagnostics := model.BindAttribute(node.syntax, b.root, b.tokens, b.options.modelOptions()...)
 	node.Definition = attr
@@ -123,7 +180,7 @@ func (b *binder) bindOutputVariable(node *OutputVariable) hcl.Diagnostics {
 	if value, ok := block.Body.Attribute("value"); ok {
 		node.Value = value.Value
 		if model.InputType(node.typ).ConversionFrom(node.Value.Type()) == model.NoConversion {
-			diagnostics = append(diagnostics, model.ExprNotConvertible(model.InputType(node.typ), node.Value))
+			diagnostics = append(diagnostics, b.exprNotConvertible(model.InputType(node.typ), node.Value))
 		}
 	}
 	node.Definition = block
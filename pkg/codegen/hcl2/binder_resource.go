@@ -16,6 +16,8 @@
 package hcl2
 
 import (
+	"time"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
@@ -27,6 +29,9 @@ import (
 )
 
 func getResourceToken(node *Resource) (string, hcl.Range) {
+	if node.syntax.Type == "provider" {
+		return "pulumi:providers:" + node.syntax.Labels[1], node.syntax.LabelRanges[1]
+	}
 	return node.syntax.Labels[1], node.syntax.LabelRanges[1]
 }
 
@@ -47,9 +52,16 @@ func (b *binder) bindResourceTypes(node *Resource) hcl.Diagnostics {
 	// Set the input and output types to dynamic by default.
 	node.InputType, node.OutputType = model.DynamicType, model.DynamicType
 
+	// Take the input and output types of a component instance from its component.
+	if component, ok := b.getComponent(node); ok {
+		node.Token, node.Component = component.Name(), component
+		node.InputType, node.OutputType = model.InputType(component.InputType), component.OutputType
+		return nil
+	}
+
 	// Find the resource's schema.
 	token, tokenRange := getResourceToken(node)
-	pkg, module, name, diagnostics := DecomposeToken(token, tokenRange)
+	pkg, module, name, diagnostics := b.decomposeToken(token, tokenRange)
 	if diagnostics.HasErrors() {
 		return diagnostics
 	}
@@ -59,9 +71,13 @@ func (b *binder) bindResourceTypes(node *Resource) hcl.Diagnostics {
 		pkg, isProvider = name, true
 	}
 
-	pkgSchema, ok := b.options.packageCache.entries[pkg]
+	if _, ok := b.missingPackages[pkg]; ok {
+		node.Token = token
+		return hcl.Diagnostics{b.missingPackageSchema(pkg, token, tokenRange)}
+	}
+	pkgSchema, ok := b.referencedPackages[pkg]
 	if !ok {
-		return hcl.Diagnostics{unknownPackage(pkg, tokenRange)}
+		return hcl.Diagnostics{b.unknownPackage(pkg, tokenRange)}
 	}
 
 	var inputProperties, properties []*schema.Property
@@ -74,7 +90,9 @@ func (b *binder) bindResourceTypes(node *Resource) hcl.Diagnostics {
 			}
 		}
 		if !ok {
-			return hcl.Diagnostics{unknownResourceType(token, tokenRange)}
+			suggestion := pkgSchema.closestResourceToken(token)
+			diag := b.unknownResourceType(token, suggestion, tokenRange)
+			return hcl.Diagnostics{b.withFixes(diag, useTokenFix(suggestion, tokenRange)...)}
 		}
 		inputProperties, properties = res.InputProperties, res.Properties
 	} else {
@@ -83,14 +101,18 @@ func (b *binder) bindResourceTypes(node *Resource) hcl.Diagnostics {
 	node.Token = token
 
 	// Create input and output types for the schema.
-	inputType := model.InputType(b.schemaTypeToType(&schema.ObjectType{Properties: inputProperties}))
+	inputType := model.InputType(b.types.ModelType(&schema.ObjectType{Properties: inputProperties}))
 
 	outputProperties := map[string]model.Type{
 		"id":  model.NewOutputType(model.StringType),
 		"urn": model.NewOutputType(model.StringType),
 	}
 	for _, prop := range properties {
-		outputProperties[prop.Name] = model.NewOutputType(b.schemaTypeToType(prop.Type))
+		t := b.types.ModelType(prop.Type)
+		if prop.Secret {
+			t = model.NewSecretType(t)
+		}
+		outputProperties[prop.Name] = model.NewOutputType(t)
 	}
 	outputType := model.NewObjectType(outputProperties, &schema.ObjectType{Properties: properties})
 
@@ -118,11 +140,14 @@ func newResourceScopes(root *model.Scope, resource *Resource, rangeKey, rangeVal
 			properties["key"] = rangeKey
 		}
 
-		scopes.withRange = root.Push(syntax.None)
-		scopes.withRange.Define("range", &model.Variable{
+		// The range variable may be referred to as either `range` or `each`.
+		rangeVariable := &model.Variable{
 			Name:         "range",
 			VariableType: model.NewObjectType(properties),
-		})
+		}
+		scopes.withRange = root.Push(syntax.None)
+		scopes.withRange.Define("range", rangeVariable)
+		scopes.withRange.Define("each", rangeVariable)
 	}
 	return scopes
 }
@@ -216,6 +241,8 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 					diags := rangeExpr.Typecheck(false)
 					contract.Assert(len(diags) == 0)
 
+					// The value of the range variable of a numeric range is the index of the current instance.
+					rangeValue = model.NumberType
 					node.VariableType = rangeExpr.Type()
 				default:
 					rk, rv, diags := model.GetCollectionTypes(typ, rng.Range())
@@ -229,6 +256,14 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 						Collection: expr,
 						Value:      model.VariableReference(resourceVar),
 					}
+					// Resources created by ranging over a map or object are addressed by key rather than by index.
+					if isMapRange(typ) {
+						keyVar := &model.Variable{
+							Name:         "k",
+							VariableType: rangeKey,
+						}
+						iterationExpr.KeyVariable, iterationExpr.Key = keyVar, model.VariableReference(keyVar)
+					}
 					diags = iterationExpr.Typecheck(false)
 					contract.Ignore(diags) // Any relevant diagnostics were reported by GetCollectionTypes.
 
@@ -252,12 +287,12 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 			switch item.Type {
 			case "options":
 				if options != nil {
-					diagnostics = append(diagnostics, duplicateBlock(item.Type, item.Syntax.TypeRange))
+					diagnostics = append(diagnostics, b.duplicateBlock(item.Type, item.Syntax.TypeRange))
 				} else {
 					options = item
 				}
 			default:
-				diagnostics = append(diagnostics, unsupportedBlock(item.Type, item.Syntax.TypeRange))
+				diagnostics = append(diagnostics, b.unsupportedBlock(item.Type, item.Syntax.TypeRange))
 			}
 		}
 	}
@@ -267,24 +302,65 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 		attrNames := codegen.StringSet{}
 		for _, attr := range node.Inputs {
 			attrNames.Add(attr.Name)
+		}
+		unsetNames := unsetProperties(objectType.Properties, attrNames)
 
+		for _, attr := range node.Inputs {
 			if typ, ok := objectType.Properties[attr.Name]; ok {
 				if !typ.ConversionFrom(attr.Value.Type()).Exists() {
-					diagnostics = append(diagnostics, model.ExprNotConvertible(typ, attr.Value))
+					diagnostics = append(diagnostics, b.exprNotConvertible(typ, attr.Value))
 				}
 			} else {
-				diagnostics = append(diagnostics, unsupportedAttribute(attr.Name, attr.Syntax.NameRange))
+				diag := b.unsupportedAttribute(attr.Name, attr.Syntax.NameRange)
+				fixes := renameAttributeFix(attr.Name, attr.Syntax.NameRange, unsetNames)
+				diagnostics = append(diagnostics, b.withFixes(diag, fixes...))
+			}
+		}
+
+		for _, k := range unsetNames {
+			if typ := objectType.Properties[k]; !model.IsOptionalType(typ) {
+				diag := b.missingRequiredAttribute(k, node.Definition.Body.Syntax.MissingItemRange())
+				diagnostics = append(diagnostics, b.withFixes(diag, insertAttributeFix(k, typ, node.syntax)))
 			}
 		}
+	}
 
-		for _, k := range codegen.SortedKeys(objectType.Properties) {
-			if !model.IsOptionalType(objectType.Properties[k]) && !attrNames.Has(k) {
-				diagnostics = append(diagnostics,
-					missingRequiredAttribute(k, node.Definition.Body.Syntax.MissingItemRange()))
+	// Typecheck the configuration of an explicit provider against its package's configuration schema. Configuration
+	// that is not set by the provider block is read from the stack's configuration, so none of it is required here.
+	if inputType, ok := findType(node.InputType, isObjectType); ok && node.syntax.Type == "provider" {
+		objectType := inputType.(*model.ObjectType)
+		for _, attr := range node.Inputs {
+			typ, ok := objectType.Properties[attr.Name]
+			if !ok {
+				diag := b.unknownConfigKey(node.syntax.Labels[1], attr.Name, attr.Syntax.NameRange)
+				diagnostics = append(diagnostics, diag)
+				continue
+			}
+			if model.InputType(typ).ConversionFrom(attr.Value.Type()) == model.NoConversion {
+				diagnostics = append(diagnostics, b.exprNotConvertible(model.InputType(typ), attr.Value))
 			}
 		}
 	}
 
+	// Typecheck the inputs of a component instance.
+	if node.Component != nil {
+		diagnostics = append(diagnostics, b.checkComponentInputs(node)...)
+	}
+
+	// Check any literal values assigned to enum-typed attributes.
+	if inputType, ok := findType(node.InputType, isObjectType); ok {
+		for _, attr := range node.Inputs {
+			if typ, ok := inputType.(*model.ObjectType).Properties[attr.Name]; ok {
+				diagnostics = append(diagnostics, b.checkEnumValues(typ, attr.Value)...)
+			}
+		}
+	}
+
+	// Warn about the required properties of a resource's schema that are not set but that have default values.
+	if node.Component == nil && node.syntax.Type == "resource" {
+		diagnostics = append(diagnostics, b.checkDefaultedProperties(node)...)
+	}
+
 	// Typecheck the options block.
 	if options != nil {
 		resourceOptions := &ResourceOptions{}
@@ -303,24 +379,54 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 				case "provider":
 					t = model.DynamicType
 					resourceOptions.Provider = item.Value
+					provider, diags := b.checkProvider(node, item.Value)
+					resourceOptions.ProviderResource = provider
+					if len(diags) != 0 {
+						diagnostics = append(diagnostics, diags...)
+						continue
+					}
 				case "dependsOn":
 					t = model.NewListType(model.DynamicType)
 					resourceOptions.DependsOn = item.Value
+					if !isResourceListType(item.Value.Type()) {
+						diagnostics = append(diagnostics, b.dependsOnMustBeResources(item.Value))
+						continue
+					}
 				case "protect":
 					t = model.BoolType
 					resourceOptions.Protect = item.Value
 				case "ignoreChanges":
 					t = model.NewListType(ResourcePropertyType)
 					resourceOptions.IgnoreChanges = item.Value
+				case "aliases":
+					t = model.NewListType(model.NewUnionType(model.StringType, aliasType))
+					resourceOptions.Aliases = item.Value
+					if diags := b.checkAliases(item.Value); len(diags) != 0 {
+						diagnostics = append(diagnostics, diags...)
+						continue
+					}
+				case "customTimeouts":
+					t = customTimeoutsType
+					resourceOptions.CustomTimeouts = item.Value
+					if diags := b.checkCustomTimeouts(item.Value); len(diags) != 0 {
+						diagnostics = append(diagnostics, diags...)
+						continue
+					}
+				case "deleteBeforeReplace":
+					t = model.BoolType
+					resourceOptions.DeleteBeforeReplace = item.Value
+				case "version":
+					t = model.StringType
+					resourceOptions.Version = item.Value
 				default:
-					diagnostics = append(diagnostics, unsupportedAttribute(item.Name, item.Syntax.NameRange))
+					diagnostics = append(diagnostics, b.unknownResourceOption(item.Name, item.Syntax.NameRange))
 					continue
 				}
 				if model.InputType(t).ConversionFrom(item.Value.Type()) == model.NoConversion {
-					diagnostics = append(diagnostics, model.ExprNotConvertible(model.InputType(t), item.Value))
+					diagnostics = append(diagnostics, b.exprNotConvertible(model.InputType(t), item.Value))
 				}
 			case *model.Block:
-				diagnostics = append(diagnostics, unsupportedBlock(item.Type, item.Syntax.TypeRange))
+				diagnostics = append(diagnostics, b.unsupportedBlock(item.Type, item.Syntax.TypeRange))
 			}
 		}
 		node.Options = resourceOptions
@@ -329,3 +435,191 @@ func (b *binder) bindResourceBody(node *Resource) hcl.Diagnostics {
 	node.Definition = block
 	return diagnostics
 }
+
+// checkDefaultedProperties returns a warning for each required property of a resource's schema that is not set by the
+// resource but that has a default value in the schema. The default value is used for such properties.
+func (b *binder) checkDefaultedProperties(node *Resource) hcl.Diagnostics {
+	inputType, ok := findType(node.InputType, isObjectType)
+	if !ok {
+		return nil
+	}
+
+	attrNames := codegen.StringSet{}
+	for _, attr := range node.Inputs {
+		attrNames.Add(attr.Name)
+	}
+
+	properties := inputType.(*model.ObjectType).Properties
+
+	var diagnostics hcl.Diagnostics
+	for _, k := range unsetProperties(properties, attrNames) {
+		prop, ok := b.types.SchemaProperty(inputType, k)
+		if ok && prop.IsRequired && prop.DefaultValue != nil {
+			diag := b.missingRequiredAttributeWithDefault(k, node.syntax.Body.MissingItemRange())
+			diagnostics = append(diagnostics, b.withFixes(diag, insertAttributeFix(k, properties[k], node.syntax)))
+		}
+	}
+	return diagnostics
+}
+
+// checkAliases checks that the value of an aliases option is a list literal of URNs and alias object literals. Code
+// generators must construct each alias object using its language's alias type, so its properties must be known.
+func (b *binder) checkAliases(expr model.Expression) hcl.Diagnostics {
+	tuple, ok := expr.(*model.TupleConsExpression)
+	if !ok {
+		return hcl.Diagnostics{b.aliasesMustBeLiteral(expr)}
+	}
+
+	var diagnostics hcl.Diagnostics
+	for _, item := range tuple.Expressions {
+		obj, ok := item.(*model.ObjectConsExpression)
+		if !ok {
+			if model.ResolveOutputs(item.Type()) != model.StringType {
+				diagnostics = append(diagnostics, b.aliasesMustBeLiteral(item))
+			}
+			continue
+		}
+		for _, prop := range obj.Items {
+			key, ok := prop.Key.(*model.LiteralValueExpression)
+			if !ok || key.Value.Type() != cty.String {
+				diagnostics = append(diagnostics, b.aliasesMustBeLiteral(prop.Key))
+				continue
+			}
+			if _, ok := aliasType.Properties[key.Value.AsString()]; !ok {
+				diagnostics = append(diagnostics, b.unsupportedAttribute(key.Value.AsString(), prop.Key.SyntaxNode().Range()))
+			}
+		}
+	}
+	return diagnostics
+}
+
+// checkProvider checks that the value of a provider option that refers to a resource in the program refers to a
+// provider for the package of the given resource, and returns the provider. Components may contain resources from
+// several packages, so the package of a provider passed to a component instance is not checked.
+func (b *binder) checkProvider(node *Resource, expr model.Expression) (*Resource, hcl.Diagnostics) {
+	traversal, ok := expr.(*model.ScopeTraversalExpression)
+	if !ok || len(traversal.Parts) == 0 {
+		return nil, nil
+	}
+	// Only the provider itself or an element of a ranged provider may be used as a provider.
+	for _, traverser := range traversal.Traversal[1:] {
+		if _, ok := traverser.(hcl.TraverseIndex); !ok {
+			return nil, nil
+		}
+	}
+	rootNode, ok := traversal.Parts[0].(Node)
+	if !ok {
+		return nil, nil
+	}
+	provider, ok := NodeResource(rootNode)
+	if !ok {
+		return nil, nil
+	}
+
+	// Resources whose tokens did not bind have already been reported.
+	pkg, module, name, diags := provider.DecomposeToken()
+	if diags.HasErrors() {
+		return nil, nil
+	}
+	if pkg != "pulumi" || module != "providers" {
+		return nil, hcl.Diagnostics{b.providerMustBeProvider(expr)}
+	}
+
+	if node.Component == nil {
+		resourcePkg, _, _, diags := node.DecomposeToken()
+		if !diags.HasErrors() && resourcePkg != name {
+			_, providerRange := getResourceToken(provider)
+			_, resourceRange := getResourceToken(node)
+			return nil, hcl.Diagnostics{b.providerPackageMismatch(provider.Name(), name, providerRange, resourcePkg,
+				resourceRange, expr)}
+		}
+	}
+	return provider, nil
+}
+
+// checkCustomTimeouts checks that the value of a customTimeouts option is an object literal that maps operations to
+// duration strings, e.g. { create = "5m" }.
+func (b *binder) checkCustomTimeouts(expr model.Expression) hcl.Diagnostics {
+	obj, ok := expr.(*model.ObjectConsExpression)
+	if !ok {
+		return hcl.Diagnostics{b.customTimeoutsMustBeLiteral(expr)}
+	}
+
+	var diagnostics hcl.Diagnostics
+	for _, item := range obj.Items {
+		key, ok := item.Key.(*model.LiteralValueExpression)
+		if !ok || key.Value.Type() != cty.String {
+			diagnostics = append(diagnostics, b.customTimeoutsMustBeLiteral(item.Key))
+			continue
+		}
+		if _, ok := customTimeoutsType.Properties[key.Value.AsString()]; !ok {
+			diagnostics = append(diagnostics, b.unsupportedAttribute(key.Value.AsString(), item.Key.SyntaxNode().Range()))
+			continue
+		}
+		value, ok := extractStringValue(item.Value)
+		if !ok {
+			diagnostics = append(diagnostics, b.customTimeoutsMustBeLiteral(item.Value))
+			continue
+		}
+		if _, err := time.ParseDuration(value); err != nil {
+			diagnostics = append(diagnostics, b.invalidCustomTimeout(value, item.Value))
+		}
+	}
+	return diagnostics
+}
+
+// isMapRange returns true if the given range type is a map or object type. Ranged resources that iterate over maps
+// and objects are bound as maps of resources keyed by the keys of the range.
+func isMapRange(t model.Type) bool {
+	switch model.ResolveOutputs(t).(type) {
+	case *model.MapType, *model.ObjectType:
+		return true
+	default:
+		return false
+	}
+}
+
+// isResourceType returns true if the given type may be the type of a resource variable. Resource variables are typed as
+// objects that carry `id` and `urn` outputs; dynamically-typed values are assumed to be resources.
+func isResourceType(t model.Type) bool {
+	switch t := model.ResolveOutputs(t).(type) {
+	case *model.ObjectType:
+		_, hasURN := t.Properties["urn"]
+		return hasURN
+	case *model.UnionType:
+		for _, t := range t.ElementTypes {
+			if t != model.NoneType && !isResourceType(t) {
+				return false
+			}
+		}
+		return true
+	default:
+		return t == model.DynamicType
+	}
+}
+
+// isResourceListType returns true if the given type is a list of resources. The list may be computed (e.g. by a for
+// expression over a ranged resource or a conditional expression), so unions of list types are accepted. Lists of
+// resources may also be nested, in which case they are flattened.
+func isResourceListType(t model.Type) bool {
+	switch t := model.ResolveOutputs(t).(type) {
+	case *model.ListType:
+		return isResourceType(t.ElementType) || isResourceListType(t.ElementType)
+	case *model.TupleType:
+		for _, t := range t.ElementTypes {
+			if !isResourceType(t) && !isResourceListType(t) {
+				return false
+			}
+		}
+		return true
+	case *model.UnionType:
+		for _, t := range t.ElementTypes {
+			if t != model.NoneType && !isResourceListType(t) {
+				return false
+			}
+		}
+		return true
+	default:
+		return t == model.DynamicType
+	}
+}
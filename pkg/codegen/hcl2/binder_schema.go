@@ -16,15 +16,22 @@ package hcl2
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/blang/semver"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/texttheater/golang-levenshtein/levenshtein"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type packageSchema struct {
@@ -33,41 +40,74 @@ type packageSchema struct {
 	functions map[string]*schema.Function
 }
 
+// PackageCache caches the schemas of the packages referenced by programs, keyed by package name and version. It also
+// memoizes the schema types that GetSchemaForType reconstructs for the model types of the programs bound using the
+// cache.
 type PackageCache struct {
 	m sync.RWMutex
 
 	entries map[string]*packageSchema
+
+	arrayTypes  map[schema.Type]*schema.ArrayType
+	mapTypes    map[schema.Type]*schema.MapType
+	schemaTypes map[model.Type]schema.Type
 }
 
 func NewPackageCache() *PackageCache {
 	return &PackageCache{
-		entries: map[string]*packageSchema{},
+		entries:     map[string]*packageSchema{},
+		arrayTypes:  map[schema.Type]*schema.ArrayType{},
+		mapTypes:    map[schema.Type]*schema.MapType{},
+		schemaTypes: map[model.Type]schema.Type{},
+	}
+}
+
+// packageCacheKey returns the key under which the schema for the given version of a package is cached. A nil version
+// refers to whichever version of the package the loader finds.
+func packageCacheKey(name string, version *semver.Version) string {
+	if version == nil {
+		return name
 	}
+	return name + "@" + version.String()
 }
 
-func (c *PackageCache) getPackageSchema(name string) (*packageSchema, bool) {
+func (c *PackageCache) getPackageSchema(key string) (*packageSchema, bool) {
 	c.m.RLock()
 	defer c.m.RUnlock()
 
-	schema, ok := c.entries[name]
+	schema, ok := c.entries[key]
 	return schema, ok
 }
 
-// loadPackageSchema loads the schema for a given package by loading the corresponding provider and calling its
-// GetSchema method.
-//
-// TODO: schema and provider versions
-func (c *PackageCache) loadPackageSchema(loader schema.Loader, name string) (*packageSchema, error) {
-	if s, ok := c.getPackageSchema(name); ok {
+// loadPackageSchema loads the schema for a given version of a package by loading the corresponding provider and calling
+// its GetSchema method. If version is nil, the latest version of the provider that is installed is used.
+func (c *PackageCache) loadPackageSchema(loader schema.Loader, name string,
+	version *semver.Version) (*packageSchema, error) {
+
+	key := packageCacheKey(name, version)
+	if s, ok := c.getPackageSchema(key); ok {
 		return s, nil
 	}
 
-	version := (*semver.Version)(nil)
 	pkg, err := loader.LoadPackage(name, version)
 	if err != nil {
 		return nil, err
 	}
+	schema := newPackageSchema(pkg)
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if s, ok := c.entries[key]; ok {
+		return s, nil
+	}
+	c.entries[key] = schema
 
+	return schema, nil
+}
+
+// newPackageSchema indexes the resources and functions of a package by their canonical tokens.
+func newPackageSchema(pkg *schema.Package) *packageSchema {
 	resources := map[string]*schema.Resource{}
 	for _, r := range pkg.Resources {
 		resources[canonicalizeToken(r.Token, pkg)] = r
@@ -77,21 +117,81 @@ func (c *PackageCache) loadPackageSchema(loader schema.Loader, name string) (*pa
 		functions[canonicalizeToken(f.Token, pkg)] = f
 	}
 
-	schema := &packageSchema{
+	return &packageSchema{
 		schema:    pkg,
 		resources: resources,
 		functions: functions,
 	}
+}
+
+// AddPackage adds an in-memory package to the cache. Programs that are bound using the cache refer to the package by
+// its name; the package's loader is never consulted for it. If the package has a version, the package is also cached
+// under that version, so that programs that request that version of the package bind against it as well. The package
+// replaces any schema that was previously cached for its name or version.
+//
+// AddPackage allows programs to be bound against packages that are not backed by a plugin, e.g. in tests or by tools
+// that synthesize schemas.
+func (c *PackageCache) AddPackage(pkg *schema.Package) {
+	schema := newPackageSchema(pkg)
 
 	c.m.Lock()
 	defer c.m.Unlock()
 
-	if s, ok := c.entries[name]; ok {
-		return s, nil
+	c.entries[packageCacheKey(pkg.Name, nil)] = schema
+	if pkg.Version != nil {
+		c.entries[packageCacheKey(pkg.Name, pkg.Version)] = schema
 	}
-	c.entries[name] = schema
+}
 
-	return schema, nil
+// Packages returns the schemas of the packages in the cache, sorted by name and then by version. A schema that is
+// cached under several keys is returned once.
+func (c *PackageCache) Packages() []*schema.Package {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	seen := map[*schema.Package]bool{}
+	var pkgs []*schema.Package
+	for _, s := range c.entries {
+		if !seen[s.schema] {
+			seen[s.schema] = true
+			pkgs = append(pkgs, s.schema)
+		}
+	}
+	sort.Slice(pkgs, func(i, j int) bool {
+		if pkgs[i].Name != pkgs[j].Name {
+			return pkgs[i].Name < pkgs[j].Name
+		}
+		vi, vj := pkgs[i].Version, pkgs[j].Version
+		return vi == nil && vj != nil || vi != nil && vj != nil && vi.LT(*vj)
+	})
+	return pkgs
+}
+
+// PackageDescriptor names a version of a package whose schema a PackageCache should load.
+type PackageDescriptor struct {
+	// Name is the name of the package.
+	Name string
+	// Version is the version of the package, or nil for whichever version the loader finds.
+	Version *semver.Version
+}
+
+// Preload loads the schemas of the given packages into the cache, so that later calls to BindProgram that share the
+// cache need not load them. The schemas are loaded concurrently. If any of the packages fails to load, the returned
+// error describes every failure; the schemas of the other packages are still cached. A cache may be preloaded while it
+// is in use by other calls to Preload or BindProgram.
+func (c *PackageCache) Preload(loader schema.Loader, pkgs []PackageDescriptor) error {
+	errs := make([]error, len(pkgs))
+	forEachConcurrently(len(pkgs), func(i int) {
+		_, errs[i] = c.loadPackageSchema(loader, pkgs[i].Name, pkgs[i].Version)
+	})
+
+	var failures []error
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, errors.Wrapf(err, "loading %s", packageCacheKey(pkgs[i].Name, pkgs[i].Version)))
+		}
+	}
+	return combineErrors(failures)
 }
 
 // canonicalizeToken converts a Pulumi token into its canonical "pkg:module:member" form.
@@ -100,18 +200,163 @@ func canonicalizeToken(tok string, pkg *schema.Package) string {
 	return fmt.Sprintf("%s:%s:%s", pkg.Name, pkg.TokenToModule(tok), member)
 }
 
-// loadReferencedPackageSchemas loads the schemas for any pacakges referenced by a given node.
-func (b *binder) loadReferencedPackageSchemas(n Node) error {
-	// TODO: package versions
-	packageNames := codegen.StringSet{}
+// closestToken returns the schema token of the candidate whose canonical token is closest to the given token, or "" if
+// no candidate is close enough to be a likely match. Candidates map canonical tokens to schema tokens.
+func closestToken(token string, pkg *schema.Package, candidates map[string]string) string {
+	// The token may be in either its schema or its canonical form.
+	forms := [][]rune{[]rune(strings.ToLower(token))}
+	if canon := canonicalizeToken(token, pkg); canon != token {
+		forms = append(forms, []rune(strings.ToLower(canon)))
+	}
+
+	// Allow at least two edits, and roughly one edit for every eight characters of longer tokens.
+	maxDistance := 2
+	if d := len(token) / 8; d > maxDistance {
+		maxDistance = d
+	}
+	options := levenshtein.Options{InsCost: 1, DelCost: 1, SubCost: 1, Matches: levenshtein.IdenticalRunes}
+
+	closest, closestDistance := "", maxDistance+1
+	for candidate, schemaToken := range candidates {
+		target := []rune(strings.ToLower(candidate))
+		for _, form := range forms {
+			distance := levenshtein.DistanceForStrings(form, target, options)
+			if distance < closestDistance || distance == closestDistance && schemaToken < closest {
+				closest, closestDistance = schemaToken, distance
+			}
+		}
+	}
+	return closest
+}
+
+// closestResourceToken returns the schema token of the package's resource whose token is closest to the given token.
+func (s *packageSchema) closestResourceToken(token string) string {
+	candidates := make(map[string]string, len(s.resources))
+	for canon, r := range s.resources {
+		candidates[canon] = r.Token
+	}
+	return closestToken(token, s.schema, candidates)
+}
 
+// closestFunctionToken returns the schema token of the package's function whose token is closest to the given token.
+func (s *packageSchema) closestFunctionToken(token string) string {
+	candidates := make(map[string]string, len(s.functions))
+	for canon, f := range s.functions {
+		candidates[canon] = f.Token
+	}
+	return closestToken(token, s.schema, candidates)
+}
+
+// collectPackageVersions records the package versions requested by the resources declared in the given file. A
+// resource requests a version of its package with a `version` attribute in its options block, e.g.
+//
+//     resource bucket "aws:s3:Bucket" {
+//         options {
+//             version = "3.2.1"
+//         }
+//     }
+//
+// A program binds against a single version of each package, so the resources in a program must not request different
+// versions of the same package.
+func (b *binder) collectPackageVersions(file *syntax.File) hcl.Diagnostics {
+	var blocks []*hclsyntax.Block
+	for _, item := range model.SourceOrderBody(file.Body) {
+		block, ok := item.(*hclsyntax.Block)
+		if !ok {
+			continue
+		}
+		blocks = append(blocks, block)
+
+		// The resources inside of a component may request versions as well.
+		if block.Type == "component" {
+			for _, resources := range block.Body.Blocks {
+				if resources.Type == "resources" {
+					blocks = append(blocks, resources.Body.Blocks...)
+				}
+			}
+		}
+	}
+
+	var diagnostics hcl.Diagnostics
+	for _, block := range blocks {
+		if (block.Type != "resource" && block.Type != "provider") || len(block.Labels) != 2 {
+			continue
+		}
+
+		packageName := block.Labels[1]
+		if block.Type == "resource" {
+			pkg, module, name, diags := DecomposeToken(block.Labels[1], block.LabelRanges[1])
+			if diags.HasErrors() {
+				continue
+			}
+			packageName = pkg
+			if pkg == "pulumi" && module == "providers" {
+				packageName = name
+			}
+		}
+
+		for _, options := range block.Body.Blocks {
+			attr, ok := options.Body.Attributes["version"]
+			if options.Type != "options" || !ok {
+				continue
+			}
+
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+				diagnostics = append(diagnostics, b.versionMustBeStringLiteral(attr.Expr.Range()))
+				continue
+			}
+			version, err := semver.ParseTolerant(value.AsString())
+			if err != nil {
+				diagnostics = append(diagnostics, b.invalidVersion(value.AsString(), err, attr.Expr.Range()))
+				continue
+			}
+
+			if existing, ok := b.packageVersions[packageName]; ok && !existing.EQ(version) {
+				diagnostics = append(diagnostics, b.conflictingPackageVersions(packageName, *existing, version,
+					attr.Expr.Range()))
+				continue
+			}
+			b.packageVersions[packageName] = &version
+		}
+	}
+	return diagnostics
+}
+
+// loadPackageSchema loads the schema for the version of the given package that the program requests, if any.
+func (b *binder) loadPackageSchema(name string) (*packageSchema, error) {
+	return b.options.packageCache.loadPackageSchema(b.options.loader, name, b.packageVersions[name])
+}
+
+// maxConcurrentSchemaLoads bounds the number of package schemas that the binder loads at once.
+const maxConcurrentSchemaLoads = 8
+
+// referencedPackageNames adds the names of the packages referenced by the given node to packageNames. Config variables
+// may name a provider configuration key (e.g. `aws:region`), but the namespace of such a key may also refer to some
+// other project, so the package it names, if any, is added to optionalNames instead. The same is true of the packages
+// named by calls to intrinsics (e.g. `aws_getRegion`). The packages referenced by the nodes inside of a component are
+// referenced by the component.
+func referencedPackageNames(n Node, packageNames, optionalNames codegen.StringSet) {
+	if p, ok := n.(*Provider); ok {
+		packageNames.Add(p.Package)
+	}
 	if r, ok := n.(*Resource); ok {
+		// Malformed tokens--including the names of components--do not reference a package. Binding the resource
+		// reports any malformed tokens.
 		token, tokenRange := getResourceToken(r)
-		packageName, _, _, _ := DecomposeToken(token, tokenRange)
-		if packageName != "pulumi" {
+		packageName, module, name, diags := DecomposeToken(token, tokenRange)
+		if packageName == "pulumi" && module == "providers" {
+			packageName = name
+		}
+		if packageName != "pulumi" && !diags.HasErrors() {
 			packageNames.Add(packageName)
 		}
 	}
+	if c, ok := n.(*Component); ok {
+		for _, n := range c.Nodes {
+			referencedPackageNames(n, packageNames, optionalNames)
+		}
+	}
 
 	diags := hclsyntax.VisitAll(n.SyntaxNode(), func(node hclsyntax.Node) hcl.Diagnostics {
 		call, ok := node.(*hclsyntax.FunctionCallExpr)
@@ -120,149 +365,341 @@ func (b *binder) loadReferencedPackageSchemas(n Node) error {
 		}
 		token, tokenRange, ok := getInvokeToken(call)
 		if !ok {
+			// Calls to intrinsics may refer to a package's functions.
+			if name, ok := intrinsicPackageName(call.Name); ok && name != "pulumi" {
+				optionalNames.Add(name)
+			}
 			return nil
 		}
-		packageName, _, _, _ := DecomposeToken(token, tokenRange)
-		if packageName != "pulumi" {
+		packageName, _, _, diags := DecomposeToken(token, tokenRange)
+		if packageName != "pulumi" && !diags.HasErrors() {
 			packageNames.Add(packageName)
 		}
 		return nil
 	})
 	contract.Assert(len(diags) == 0)
 
+	if c, ok := n.(*ConfigVariable); ok {
+		if name := c.Namespace(); name != "" && name != "pulumi" {
+			optionalNames.Add(name)
+		}
+	}
+}
+
+// loadReferencedPackageSchemas loads the schemas for any packages referenced by the given nodes. The schemas are loaded
+// concurrently. If any referenced package fails to load, the returned error describes every failure, unless the
+// SkipResourceTypechecking option is set, in which case the failures are recorded in missingPackages.
+func (b *binder) loadReferencedPackageSchemas(nodes []Node) error {
+	packageNames, optionalNames := codegen.StringSet{}, codegen.StringSet{}
+	for _, n := range nodes {
+		referencedPackageNames(n, packageNames, optionalNames)
+	}
+
+	var names []string
 	for _, name := range packageNames.SortedValues() {
-		if _, ok := b.referencedPackages[name]; ok {
-			continue
+		if _, ok := b.referencedPackages[name]; !ok {
+			names = append(names, name)
 		}
-		pkg, err := b.options.packageCache.loadPackageSchema(b.options.loader, name)
-		if err != nil {
-			return err
+	}
+	for _, name := range optionalNames.SortedValues() {
+		if _, ok := b.referencedPackages[name]; !ok && !packageNames.Has(name) {
+			names = append(names, name)
 		}
-		b.referencedPackages[name] = pkg.schema
 	}
-	return nil
+
+	schemas := make([]*packageSchema, len(names))
+	errs := make([]error, len(names))
+	forEachConcurrently(len(names), func(i int) {
+		schemas[i], errs[i] = b.loadPackageSchema(names[i])
+	})
+
+	var failures []error
+	for i, name := range names {
+		switch {
+		case errs[i] == nil:
+			b.referencedPackages[name] = schemas[i]
+		case packageNames.Has(name) && b.options.skipResourceTypechecking:
+			b.missingPackages[name] = errs[i]
+		case packageNames.Has(name):
+			failures = append(failures, errs[i])
+		}
+	}
+	return combineErrors(failures)
 }
 
-// schemaTypeToType converts a schema.Type to a model Type.
-func (b *binder) schemaTypeToType(src schema.Type) (result model.Type) {
-	defer func() {
-		b.typeSchemas[result] = src
-	}()
-
-	switch src := src.(type) {
-	case *schema.ArrayType:
-		return model.NewListType(b.schemaTypeToType(src.ElementType))
-	case *schema.MapType:
-		return model.NewMapType(b.schemaTypeToType(src.ElementType))
-	case *schema.ObjectType:
-		properties := map[string]model.Type{}
-		for _, prop := range src.Properties {
-			t := b.schemaTypeToType(prop.Type)
-			if !prop.IsRequired {
-				t = model.NewOptionalType(t)
+// forEachConcurrently calls f for each index in [0, n), running at most maxConcurrentSchemaLoads calls at once.
+func forEachConcurrently(n int, f func(i int)) {
+	var wg sync.WaitGroup
+	indices := make(chan int)
+	for w := 0; w < maxConcurrentSchemaLoads && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				f(i)
 			}
-			properties[prop.Name] = t
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
+
+// combineErrors returns nil if there are no errors, the error itself if there is one, and an error that describes all
+// of them otherwise.
+func combineErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return multierror.Append(nil, errs...)
+	}
+}
+
+// schemaTypeName returns the token of the schema object type associated with the given type, if any.
+func (b *binder) schemaTypeName(t model.Type) (string, bool) {
+	if _, ok := t.(*model.ObjectType); !ok {
+		return "", false
+	}
+	if obj, ok := b.types.SchemaType(t); ok {
+		if obj, ok := obj.(*schema.ObjectType); ok && obj.Token != "" {
+			return obj.Token, true
 		}
-		return model.NewObjectType(properties, src)
-	case *schema.TokenType:
-		t, ok := model.GetOpaqueType(src.Token)
+	}
+	return "", false
+}
+
+// findType returns the first type accepted by the given predicate among the given type and the types it wraps, if any.
+// Unions, outputs, and promises are searched, so the element types of optional and input types are found.
+func findType(t model.Type, accept func(t model.Type) bool) (model.Type, bool) {
+	switch t := t.(type) {
+	case *model.UnionType:
+		for _, t := range t.ElementTypes {
+			if found, ok := findType(t, accept); ok {
+				return found, true
+			}
+		}
+		return nil, false
+	case *model.OutputType:
+		return findType(t.ElementType, accept)
+	case *model.PromiseType:
+		return findType(t.ElementType, accept)
+	case *model.SecretType:
+		return findType(t.ElementType, accept)
+	default:
+		return t, accept(t)
+	}
+}
+
+func isObjectType(t model.Type) bool {
+	_, ok := t.(*model.ObjectType)
+	return ok
+}
+
+// checkEnumValues checks that the literal values in the given expression that are assigned to enum types are members
+// of those enums. Values that are not known statically are not checked.
+func (b *binder) checkEnumValues(typ model.Type, expr model.Expression) hcl.Diagnostics {
+	switch expr := expr.(type) {
+	case *model.LiteralValueExpression:
+		return b.checkEnumValue(typ, expr.Value, expr)
+	case *model.TemplateExpression:
+		if len(expr.Parts) == 1 {
+			if lit, ok := expr.Parts[0].(*model.LiteralValueExpression); ok {
+				return b.checkEnumValue(typ, lit.Value, expr)
+			}
+		}
+	case *model.ObjectConsExpression:
+		t, ok := findType(typ, func(t model.Type) bool {
+			switch t.(type) {
+			case *model.ObjectType, *model.MapType:
+				return true
+			default:
+				return false
+			}
+		})
 		if !ok {
-			tt, err := model.NewOpaqueType(src.Token)
-			contract.IgnoreError(err)
-			t = tt
+			return nil
 		}
 
-		if src.UnderlyingType != nil {
-			underlyingType := b.schemaTypeToType(src.UnderlyingType)
-			return model.NewUnionType(t, underlyingType)
+		var diagnostics hcl.Diagnostics
+		for _, item := range expr.Items {
+			switch t := t.(type) {
+			case *model.ObjectType:
+				key, ok := item.Key.(*model.LiteralValueExpression)
+				if !ok || key.Value.Type() != cty.String {
+					continue
+				}
+				if propertyType, ok := t.Properties[key.Value.AsString()]; ok {
+					diagnostics = append(diagnostics, b.checkEnumValues(propertyType, item.Value)...)
+				}
+			case *model.MapType:
+				diagnostics = append(diagnostics, b.checkEnumValues(t.ElementType, item.Value)...)
+			}
 		}
-		return t
-	case *schema.UnionType:
-		types := make([]model.Type, len(src.ElementTypes))
-		for i, src := range src.ElementTypes {
-			types[i] = b.schemaTypeToType(src)
+		return diagnostics
+	case *model.TupleConsExpression:
+		t, ok := findType(typ, func(t model.Type) bool {
+			_, ok := t.(*model.ListType)
+			return ok
+		})
+		if !ok {
+			return nil
 		}
-		return model.NewUnionType(types...)
-	default:
-		switch src {
-		case schema.BoolType:
-			return model.BoolType
-		case schema.IntType:
-			return model.IntType
-		case schema.NumberType:
-			return model.NumberType
-		case schema.StringType:
-			return model.StringType
-		case schema.ArchiveType:
-			return ArchiveType
-		case schema.AssetType:
-			return AssetType
-		case schema.JSONType:
-			fallthrough
-		case schema.AnyType:
-			return model.DynamicType
-		default:
-			return model.NoneType
+
+		var diagnostics hcl.Diagnostics
+		for _, x := range expr.Expressions {
+			diagnostics = append(diagnostics, b.checkEnumValues(t.(*model.ListType).ElementType, x)...)
 		}
+		return diagnostics
+	}
+	return nil
+}
+
+// checkEnumValue checks that the given value is a member of the enum type it is assigned to, if any.
+func (b *binder) checkEnumValue(typ model.Type, value cty.Value, expr model.Expression) hcl.Diagnostics {
+	t, ok := findType(typ, func(t model.Type) bool {
+		_, ok := t.(*model.EnumType)
+		return ok
+	})
+	if !ok || value.IsNull() {
+		return nil
+	}
+	if enum := t.(*model.EnumType); !enum.Contains(value) {
+		return hcl.Diagnostics{b.invalidEnumValue(value, enum, expr.SyntaxNode().Range())}
 	}
+	return nil
 }
 
-var schemaArrayTypes = make(map[schema.Type]*schema.ArrayType)
+// defaultSchemaTypes holds the schema types reconstructed by GetSchemaForType.
+var defaultSchemaTypes = NewPackageCache()
 
 // GetSchemaForType extracts the schema.Type associated with a model.Type, if any.
 //
 // The result may be a *schema.UnionType if multiple schema types are associaged with the input type.
+//
+// The schema types that are reconstructed for list and map types are shared by all callers. Program.GetSchemaForType
+// uses the cache with which the program was bound instead.
 func GetSchemaForType(t model.Type) (schema.Type, bool) {
+	return defaultSchemaTypes.GetSchemaForType(t)
+}
+
+// GetSchemaForType extracts the schema.Type associated with a model.Type, if any. Each list or map type whose element
+// type is associated with a schema type is associated with a single array or map type in the cache, so schema types
+// that are extracted using the same cache may be compared by identity.
+//
+// The result may be a *schema.UnionType if multiple schema types are associaged with the input type.
+func (c *PackageCache) GetSchemaForType(t model.Type) (schema.Type, bool) {
 	switch t := t.(type) {
 	case *model.ListType:
-		element, ok := GetSchemaForType(t.ElementType)
+		element, ok := c.GetSchemaForType(t.ElementType)
 		if !ok {
 			return nil, false
 		}
-		if t, ok := schemaArrayTypes[element]; ok {
-			return t, true
+		return c.arrayType(element), true
+	case *model.MapType:
+		element, ok := c.GetSchemaForType(t.ElementType)
+		if !ok {
+			return nil, false
 		}
-		schemaArrayTypes[element] = &schema.ArrayType{ElementType: element}
-		return schemaArrayTypes[element], true
+		return c.mapType(element), true
 	case *model.ObjectType:
 		if len(t.Annotations) == 0 {
 			return nil, false
 		}
-		for _, a := range t.Annotations {
-			if t, ok := a.(schema.Type); ok {
-				return t, true
+		return c.memoizeSchemaType(t, func() (schema.Type, bool) {
+			for _, a := range t.Annotations {
+				if t, ok := a.(schema.Type); ok {
+					return t, true
+				}
 			}
-		}
-		return nil, false
+			return nil, false
+		})
 	case *model.OutputType:
-		return GetSchemaForType(t.ElementType)
+		return c.GetSchemaForType(t.ElementType)
 	case *model.PromiseType:
-		return GetSchemaForType(t.ElementType)
+		return c.GetSchemaForType(t.ElementType)
+	case *model.SecretType:
+		return c.GetSchemaForType(t.ElementType)
 	case *model.UnionType:
-		schemas := codegen.Set{}
-		for _, t := range t.ElementTypes {
-			if s, ok := GetSchemaForType(t); ok {
-				if union, ok := s.(*schema.UnionType); ok {
-					for _, s := range union.ElementTypes {
+		return c.memoizeSchemaType(t, func() (schema.Type, bool) {
+			schemas := codegen.Set{}
+			for _, t := range t.ElementTypes {
+				if s, ok := c.GetSchemaForType(t); ok {
+					if union, ok := s.(*schema.UnionType); ok {
+						for _, s := range union.ElementTypes {
+							schemas.Add(s)
+						}
+					} else {
 						schemas.Add(s)
 					}
-				} else {
-					schemas.Add(s)
 				}
 			}
-		}
-		if len(schemas) == 0 {
-			return nil, false
-		}
-		schemaTypes := make([]schema.Type, 0, len(schemas))
-		for t := range schemas {
-			schemaTypes = append(schemaTypes, t.(schema.Type))
-		}
-		if len(schemaTypes) == 1 {
-			return schemaTypes[0], true
-		}
-		return &schema.UnionType{ElementTypes: schemaTypes}, true
+			if len(schemas) == 0 {
+				return nil, false
+			}
+			schemaTypes := make([]schema.Type, 0, len(schemas))
+			for t := range schemas {
+				schemaTypes = append(schemaTypes, t.(schema.Type))
+			}
+			if len(schemaTypes) == 1 {
+				return schemaTypes[0], true
+			}
+			return &schema.UnionType{ElementTypes: schemaTypes}, true
+		})
 	default:
 		return nil, false
 	}
 }
+
+// arrayType returns the array type with the given element type.
+func (c *PackageCache) arrayType(element schema.Type) *schema.ArrayType {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	t, ok := c.arrayTypes[element]
+	if !ok {
+		t = &schema.ArrayType{ElementType: element}
+		c.arrayTypes[element] = t
+	}
+	return t
+}
+
+// mapType returns the map type with the given element type.
+func (c *PackageCache) mapType(element schema.Type) *schema.MapType {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	t, ok := c.mapTypes[element]
+	if !ok {
+		t = &schema.MapType{ElementType: element}
+		c.mapTypes[element] = t
+	}
+	return t
+}
+
+// memoizeSchemaType returns the schema type associated with the given model type, calling compute to find the schema
+// type if it has not been memoized. The lock is not held while compute runs, so if multiple goroutines compute the
+// schema type for the same model type, the first result to be memoized wins.
+func (c *PackageCache) memoizeSchemaType(t model.Type, compute func() (schema.Type, bool)) (schema.Type, bool) {
+	c.m.RLock()
+	result, ok := c.schemaTypes[t]
+	c.m.RUnlock()
+	if ok {
+		return result, result != nil
+	}
+
+	result, _ = compute()
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	if existing, ok := c.schemaTypes[t]; ok {
+		result = existing
+	} else {
+		c.schemaTypes[t] = result
+	}
+	return result, result != nil
+}
@@ -1,8 +1,18 @@
 package hcl2
 
 import (
+	"encoding/json"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/blang/semver"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
@@ -12,7 +22,564 @@ func BenchmarkLoadPackage(b *testing.B) {
 	loader := schema.NewPluginLoader(test.NewHost(testdataPath))
 
 	for n := 0; n < b.N; n++ {
-		_, err := NewPackageCache().loadPackageSchema(loader, "aws")
+		_, err := NewPackageCache().loadPackageSchema(loader, "aws", nil)
 		contract.AssertNoError(err)
 	}
 }
+
+// versionRecordingLoader records the versions of the packages it is asked to load. Every version of a package has the
+// same schema.
+type versionRecordingLoader struct {
+	schema.Loader
+
+	m     sync.Mutex
+	loads []string
+}
+
+func (l *versionRecordingLoader) LoadPackage(pkg string, version *semver.Version) (*schema.Package, error) {
+	l.m.Lock()
+	l.loads = append(l.loads, packageCacheKey(pkg, version))
+	l.m.Unlock()
+
+	return l.Loader.LoadPackage(pkg, nil)
+}
+
+func TestPackageCacheVersions(t *testing.T) {
+	loader := &versionRecordingLoader{Loader: schema.NewPluginLoader(test.NewHost(testdataPath))}
+	cache := NewPackageCache()
+
+	v1, v2 := semver.MustParse("1.0.0"), semver.MustParse("2.0.0")
+	for _, version := range []*semver.Version{nil, &v1, &v2, nil, &v1} {
+		_, err := cache.loadPackageSchema(loader, "aws", version)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"aws", "aws@1.0.0", "aws@2.0.0"}, loader.loads)
+}
+
+func TestBindPackageVersions(t *testing.T) {
+	bind := func(text string) (*versionRecordingLoader, []string) {
+		parser := syntax.NewParser()
+		err := parser.ParseFile(strings.NewReader(text), "test.pp")
+		assert.NoError(t, err)
+		assert.False(t, parser.Diagnostics.HasErrors())
+
+		loader := &versionRecordingLoader{Loader: schema.NewPluginLoader(test.NewHost(testdataPath))}
+		_, diags, err := BindProgram(parser.Files, Loader(loader), Cache(NewPackageCache()))
+		assert.NoError(t, err)
+
+		var errors []string
+		for _, d := range diags {
+			errors = append(errors, d.Summary)
+		}
+		return loader, errors
+	}
+
+	// Every reference to a package uses the version requested by any of its resources.
+	loader, errors := bind(`
+config region "string" {
+	default = invoke("aws:index:getRegion", {}).name
+}
+
+resource provider "pulumi:providers:aws" {
+	region = region
+}
+
+resource bucket "aws:s3:Bucket" {
+	options {
+		provider = provider
+		version = "3.2.1"
+	}
+}
+`)
+	assert.Empty(t, errors)
+	assert.Equal(t, []string{"aws@3.2.1"}, loader.loads)
+
+	_, errors = bind(`
+resource logs "aws:s3:Bucket" {
+	options {
+		version = "3.2.1"
+	}
+}
+
+resource site "aws:s3:Bucket" {
+	options {
+		version = "3.3.0"
+	}
+}
+`)
+	assert.Equal(t, []string{
+		"version 3.3.0 of package 'aws' conflicts with version 3.2.1 requested elsewhere in the program",
+	}, errors)
+
+	_, errors = bind(`
+config version "string" {
+}
+
+resource site "aws:s3:Bucket" {
+	options {
+		version = version
+	}
+}
+`)
+	assert.Equal(t, []string{"version must be a string literal"}, errors)
+}
+
+func TestBindReportsAllSchemaLoadErrors(t *testing.T) {
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(`
+config zone "string" {
+	default = "us-east-1a"
+}
+
+resource bucket "aws:s3:Bucket" {
+}
+
+resource cluster "gcp:container:Cluster" {
+	location = zone
+}
+
+resource group "azure:core:ResourceGroup" {
+}
+
+resource id "random:index:RandomId" {
+	byteLength = 8
+}
+`), "test.pp")
+	assert.NoError(t, err)
+	assert.False(t, parser.Diagnostics.HasErrors())
+
+	loader := schema.NewPluginLoader(test.NewHost(testdataPath))
+	_, _, err = BindProgram(parser.Files, Loader(loader), Cache(NewPackageCache()))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "2 errors occurred")
+		assert.Contains(t, err.Error(), "could not find provider for package 'azure'")
+		assert.Contains(t, err.Error(), "could not find provider for package 'gcp'")
+	}
+}
+
+func TestPackageCachePreload(t *testing.T) {
+	loader := &versionRecordingLoader{Loader: schema.NewPluginLoader(test.NewHost(testdataPath))}
+	cache := NewPackageCache()
+
+	v1 := semver.MustParse("1.0.0")
+	err := cache.Preload(loader, []PackageDescriptor{{Name: "aws"}, {Name: "random", Version: &v1}})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"aws", "random@1.0.0"}, loader.loads)
+
+	// Binding a program against the preloaded cache loads nothing more.
+	parser := syntax.NewParser()
+	err = parser.ParseFile(strings.NewReader(`
+resource bucket "aws:s3:Bucket" {
+}
+`), "test.pp")
+	assert.NoError(t, err)
+	_, _, err = BindProgram(parser.Files, Loader(loader), Cache(cache))
+	assert.NoError(t, err)
+	assert.Len(t, loader.loads, 2)
+
+	err = cache.Preload(loader, []PackageDescriptor{{Name: "aws"}, {Name: "gcp"}, {Name: "azure"}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "loading gcp: could not find provider for package 'gcp'")
+		assert.Contains(t, err.Error(), "loading azure: could not find provider for package 'azure'")
+	}
+}
+
+func TestUnknownTokenSuggestions(t *testing.T) {
+	bind := func(text string) []string {
+		parser := syntax.NewParser()
+		err := parser.ParseFile(strings.NewReader(text), "test.pp")
+		assert.NoError(t, err)
+		assert.False(t, parser.Diagnostics.HasErrors())
+
+		_, diags, err := BindProgram(parser.Files, PluginHost(test.NewHost(testdataPath)))
+		assert.NoError(t, err)
+
+		var errors []string
+		for _, d := range diags {
+			errors = append(errors, d.Summary)
+		}
+		return errors
+	}
+
+	assert.Equal(t, []string{"unknown resource type 'aws:s3:Bukcet'; did you mean 'aws:s3/bucket:Bucket'?"}, bind(`
+resource bucket "aws:s3:Bukcet" {
+}
+`))
+	assert.Equal(t, []string{"unknown resource type 'aws:s3:Spaceship'"}, bind(`
+resource ship "aws:s3:Spaceship" {
+}
+`))
+	assert.Equal(t, []string{"unknown function 'aws:index:getRegon'; did you mean 'aws:index/getRegion:getRegion'?"},
+		bind(`
+output region {
+	value = invoke("aws:index:getRegon", {}).name
+}
+`))
+}
+
+// specLoader loads packages from their schemas.
+type specLoader map[string]schema.PackageSpec
+
+func (l specLoader) LoadPackage(pkg string, version *semver.Version) (*schema.Package, error) {
+	return schema.ImportSpec(l[pkg], nil)
+}
+
+func TestBindEnumValues(t *testing.T) {
+	var spec schema.PackageSpec
+	err := json.Unmarshal([]byte(`{
+		"name": "example",
+		"types": {
+			"example:index:Color": {
+				"type": "string",
+				"enum": [{"value": "red"}, {"value": "blue"}]
+			},
+			"example:index:Style": {
+				"type": "object",
+				"properties": {"colors": {"type": "array", "items": {"$ref": "#/types/example:index:Color"}}}
+			}
+		},
+		"resources": {
+			"example:index:Widget": {
+				"inputProperties": {
+					"color": {"$ref": "#/types/example:index:Color"},
+					"style": {"$ref": "#/types/example:index:Style"}
+				}
+			}
+		},
+		"functions": {
+			"example:index:getWidget": {
+				"inputs": {"properties": {"color": {"$ref": "#/types/example:index:Color"}}}
+			}
+		}
+	}`), &spec)
+	assert.NoError(t, err)
+
+	bind := func(text string) []string {
+		parser := syntax.NewParser()
+		err := parser.ParseFile(strings.NewReader(text), "test.pp")
+		assert.NoError(t, err)
+		assert.False(t, parser.Diagnostics.HasErrors())
+
+		_, diags, err := BindProgram(parser.Files, Loader(specLoader{"example": spec}), Cache(NewPackageCache()))
+		assert.NoError(t, err)
+
+		var errors []string
+		for _, d := range diags {
+			errors = append(errors, d.Summary)
+		}
+		return errors
+	}
+
+	assert.Empty(t, bind(`
+config color string {
+}
+resource a "example:index:Widget" {
+	color = "red"
+	style = { colors = ["blue", color] }
+}
+`))
+	assert.Equal(t, []string{
+		`"green" is not a valid value of enum 'example:index:Color'; expected one of "red", "blue"`,
+		`"Blue" is not a valid value of enum 'example:index:Color'; expected one of "red", "blue"`,
+	}, bind(`
+resource a "example:index:Widget" {
+	color = "green"
+	style = { colors = ["red", "Blue"] }
+}
+`))
+	assert.Equal(t, []string{
+		`"green" is not a valid value of enum 'example:index:Color'; expected one of "red", "blue"`,
+	}, bind(`
+output widget {
+	value = invoke("example:index:getWidget", { color = "green" })
+}
+`))
+}
+
+// failingLoader fails to load every package.
+type failingLoader struct{}
+
+func (failingLoader) LoadPackage(pkg string, version *semver.Version) (*schema.Package, error) {
+	return nil, errors.Errorf("could not find provider for package '%s'", pkg)
+}
+
+func TestBindSyntheticPackages(t *testing.T) {
+	pkg, err := schema.ImportSpec(schema.PackageSpec{
+		Name:    "synthetic",
+		Version: "1.2.0",
+		Resources: map[string]schema.ResourceSpec{
+			"synthetic:index:Widget": {
+				InputProperties: map[string]schema.PropertySpec{
+					"size": {TypeSpec: schema.TypeSpec{Type: "integer"}},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	bind := func(text string, opts ...BindOption) ([]string, error) {
+		parser := syntax.NewParser()
+		err := parser.ParseFile(strings.NewReader(text), "test.pp")
+		assert.NoError(t, err)
+		assert.False(t, parser.Diagnostics.HasErrors())
+
+		_, diags, err := BindProgram(parser.Files, append([]BindOption{Loader(failingLoader{})}, opts...)...)
+
+		var errors []string
+		for _, d := range diags {
+			errors = append(errors, d.Summary)
+		}
+		return errors, err
+	}
+
+	const unversioned = `
+resource widget "synthetic:index:Widget" {
+	size = 3
+}
+`
+	const versioned = `
+resource widget "synthetic:index:Widget" {
+	size = 3
+	options {
+		version = "1.2.0"
+	}
+}
+`
+
+	// Programs may refer to the package with or without its version.
+	for _, text := range []string{unversioned, versioned} {
+		diags, err := bind(text, Packages(pkg))
+		assert.NoError(t, err)
+		assert.Empty(t, diags)
+	}
+
+	// A package added to a cache is available to every program bound using the cache.
+	cache := NewPackageCache()
+	cache.AddPackage(pkg)
+	diags, err := bind(unversioned, Cache(cache))
+	assert.NoError(t, err)
+	assert.Empty(t, diags)
+
+	// Other versions of the package are still loaded by the loader.
+	_, err = bind(strings.Replace(versioned, "1.2.0", "1.3.0", 1), Cache(cache))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not find provider for package 'synthetic'")
+	}
+}
+
+func TestSkipResourceTypechecking(t *testing.T) {
+	const text = `
+resource bucket "private:storage:Bucket" {
+	size = 3
+}
+
+output info {
+	value = invoke("private:storage:getInfo", { bucket = bucket.id }).region
+}
+`
+
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(text), "test.pp")
+	assert.NoError(t, err)
+
+	// Without the option, a missing package schema fails the bind.
+	_, _, err = BindProgram(parser.Files, Loader(failingLoader{}))
+	assert.Error(t, err)
+
+	// With the option, the resource and the invoke have dynamic types and are reported as warnings.
+	program, diags, err := BindProgram(parser.Files, Loader(failingLoader{}), SkipResourceTypechecking)
+	assert.NoError(t, err)
+	assert.False(t, diags.HasErrors())
+	if assert.Len(t, diags, 2) {
+		for _, d := range diags {
+			assert.Equal(t, hcl.DiagWarning, d.Severity)
+			assert.Contains(t, d.Summary, "could not find provider for package 'private'")
+			code, _ := program.DiagnosticCode(d)
+			assert.Equal(t, CodeMissingPackageSchema, code)
+		}
+	}
+
+	bucket, ok := program.Nodes[0].(*Resource)
+	if assert.True(t, ok) {
+		assert.Equal(t, "private:storage:Bucket", bucket.Token)
+		assert.Equal(t, model.DynamicType, bucket.InputType)
+		assert.Equal(t, model.DynamicType, bucket.OutputType)
+	}
+}
+
+func TestBindTokenTypes(t *testing.T) {
+	pkg, err := schema.ImportSpec(schema.PackageSpec{
+		Name: "example",
+		Resources: map[string]schema.ResourceSpec{
+			"example:index:Widget": {
+				InputProperties: map[string]schema.PropertySpec{
+					"opaque": {TypeSpec: schema.TypeSpec{Ref: "#/types/example:index:Opaque"}},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	bind := func(opts ...BindOption) model.Type {
+		parser := syntax.NewParser()
+		err := parser.ParseFile(strings.NewReader(`
+resource widget "example:index:Widget" {
+}
+`), "test.pp")
+		assert.NoError(t, err)
+
+		program, diags, err := BindProgram(parser.Files, append(opts, Loader(failingLoader{}), Packages(pkg))...)
+		assert.NoError(t, err)
+		assert.Empty(t, diags)
+
+		// Find the token type in the resource's input type.
+		var tokenType model.Type
+		var find func(t model.Type)
+		find = func(t model.Type) {
+			switch t := t.(type) {
+			case *model.OpaqueType:
+				if t.Name == "example:index:Opaque" {
+					tokenType = t
+				}
+			case *model.UnionType:
+				for _, t := range t.ElementTypes {
+					find(t)
+				}
+			case *model.ObjectType:
+				find(t.Properties["opaque"])
+			case *model.OutputType:
+				find(t.ElementType)
+			}
+		}
+		find(program.Nodes[0].(*Resource).InputType)
+		assert.NotNil(t, tokenType)
+		return tokenType
+	}
+
+	// Each program's token types are distinct unless the programs share a registry.
+	t1, t2 := bind(), bind()
+	assert.False(t, t1.Equals(t2))
+
+	registry := model.NewOpaqueTypeRegistry()
+	t3, t4 := bind(OpaqueTypes(registry)), bind(OpaqueTypes(registry))
+	assert.True(t, t3.Equals(t4))
+
+	_, ok := model.GetOpaqueType("example:index:Opaque")
+	assert.False(t, ok)
+}
+
+func TestBindTypeMismatchDiagnostics(t *testing.T) {
+	pkg, err := schema.ImportSpec(schema.PackageSpec{
+		Name: "example",
+		Config: schema.ConfigSpec{
+			Variables: map[string]schema.PropertySpec{
+				"name":    {TypeSpec: schema.TypeSpec{Type: "string"}},
+				"logging": {TypeSpec: schema.TypeSpec{Ref: "#/types/example:index:Logging"}},
+			},
+		},
+		Types: map[string]schema.ObjectTypeSpec{
+			"example:index:Logging": {
+				Type: "object",
+				Properties: map[string]schema.PropertySpec{
+					"targetBucket": {TypeSpec: schema.TypeSpec{Type: "string"}},
+					"targetPrefix": {TypeSpec: schema.TypeSpec{Type: "string"}},
+				},
+				Required: []string{"targetBucket"},
+			},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	parser := syntax.NewParser()
+	err = parser.ParseFile(strings.NewReader(`
+provider example "example" {
+	name = "example"
+	logging = {
+		targetPrefix = "log/"
+		targetBucket = [1]
+	}
+}
+`), "test.pp")
+	assert.NoError(t, err)
+
+	_, diags, err := BindProgram(parser.Files, Loader(failingLoader{}), Packages(pkg))
+	assert.NoError(t, err)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "cannot assign expression of type object({targetBucket = tuple(number), ...}) to location of "+
+			"type input(optional(example:index:Logging))", diags[0].Summary)
+		assert.Equal(t, "targetBucket: cannot assign type tuple(number) to type input(string)", diags[0].Detail)
+	}
+}
+
+func TestGetSchemaForTypeConcurrent(t *testing.T) {
+	objectSchema := &schema.ObjectType{Token: "example:index:Object"}
+	object := model.NewObjectType(map[string]model.Type{}, objectSchema)
+	types := []model.Type{
+		model.NewListType(object),
+		model.NewMapType(object),
+		model.NewUnionType(object, model.NewOutputType(object)),
+	}
+
+	cache := NewPackageCache()
+	results := make([][]schema.Type, 8)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for _, typ := range types {
+				s, ok := cache.GetSchemaForType(typ)
+				assert.True(t, ok)
+				results[i] = append(results[i], s)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Each goroutine must see the same schema types.
+	assert.Equal(t, &schema.ArrayType{ElementType: objectSchema}, results[0][0])
+	assert.Equal(t, &schema.MapType{ElementType: objectSchema}, results[0][1])
+	assert.Equal(t, objectSchema, results[0][2])
+	for _, r := range results[1:] {
+		for i, s := range r {
+			assert.True(t, s == results[0][i])
+		}
+	}
+
+	// Caches do not share reconstructed types.
+	s, ok := NewPackageCache().GetSchemaForType(types[0])
+	assert.True(t, ok)
+	assert.False(t, s == results[0][0])
+}
+
+func TestBindIntrinsics(t *testing.T) {
+	program, diags := bindTestProgram(t, `
+ami = aws_getAmi({
+	owners = ["137112412989"]
+	mostRecent = true
+})
+vpc = aws_ec2_getVpc({ default = true }, "provider")
+`)
+	assert.Len(t, diags, 0)
+
+	// Calls to intrinsics are bound as calls to invoke with the functions' canonical tokens.
+	tokens := map[string]string{"ami": "aws::getAmi", "vpc": "aws:ec2:getVpc"}
+	for _, n := range program.Nodes {
+		call := n.(*LocalVariable).Definition.Value.(*model.FunctionCallExpression)
+		assert.Equal(t, Invoke, call.Name)
+		assert.Equal(t, "token", call.Signature.Parameters[0].Name)
+
+		lit := call.Args[0].(*model.TemplateExpression).Parts[0].(*model.LiteralValueExpression)
+		assert.Equal(t, tokens[n.Name()], lit.Value.AsString())
+		assert.IsType(t, &model.PromiseType{}, call.Type())
+	}
+
+	// The arguments to intrinsics are typechecked.
+	_, diags = bindTestProgram(t, `
+ami = aws_getAmi({
+	mostRecent = [true]
+})
+`)
+	assert.Len(t, diags.Errs(), 1)
+}
@@ -2,12 +2,16 @@ package hcl2
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
 )
@@ -49,3 +53,456 @@ func TestBindProgram(t *testing.T) {
 		})
 	}
 }
+
+func bindTestProgram(t *testing.T, text string, opts ...BindOption) (*Program, hcl.Diagnostics) {
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(text), "test.pp")
+	if err != nil {
+		t.Fatalf("could not parse program: %v", err)
+	}
+	if parser.Diagnostics.HasErrors() {
+		t.Fatalf("failed to parse program: %v", parser.Diagnostics)
+	}
+
+	opts = append([]BindOption{PluginHost(test.NewHost(testdataPath))}, opts...)
+	program, diags, err := BindProgram(parser.Files, opts...)
+	assert.NoError(t, err)
+	return program, diags
+}
+
+func TestBindDependsOn(t *testing.T) {
+	cases := []struct {
+		name      string
+		dependsOn string
+		errors    int
+	}{
+		{name: "static list", dependsOn: "[provider]"},
+		{name: "ranged resource", dependsOn: "buckets"},
+		{name: "for expression", dependsOn: "[for b in buckets: b]"},
+		{name: "filtered for expression", dependsOn: "[for i, b in buckets: b if i > 0]"},
+		{name: "conditional", dependsOn: "true ? [provider] : []"},
+		{name: "mixed", dependsOn: "[provider, buckets]"},
+		{name: "not a resource", dependsOn: "[\"foo\"]", errors: 1},
+		{name: "not a list", dependsOn: "42", errors: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, diags := bindTestProgram(t, `
+resource provider "pulumi:providers:aws" {
+	region = "us-west-2"
+}
+
+resource buckets "aws:s3:Bucket" {
+	options {
+		range = 2
+	}
+}
+
+resource bucket "aws:s3:Bucket" {
+	options {
+		dependsOn = `+c.dependsOn+`
+	}
+}
+`)
+			assert.Len(t, diags.Errs(), c.errors)
+		})
+	}
+}
+
+func TestBindResourceOptions(t *testing.T) {
+	cases := []struct {
+		name    string
+		options string
+		errors  int
+	}{
+		{name: "alias URN", options: `aliases = ["urn:pulumi:stack::project::aws:s3/bucket:Bucket::old"]`},
+		{name: "alias object", options: `aliases = [{ name = "old", parent = provider }]`},
+		{name: "alias with unknown property", options: `aliases = [{ urn = "old" }]`, errors: 1},
+		{name: "aliases not a list", options: `aliases = "old"`, errors: 1},
+		{name: "custom timeouts", options: `customTimeouts = { create = "5m", update = "1h", delete = "30s" }`},
+		{name: "unknown timeout", options: `customTimeouts = { read = "5m" }`, errors: 1},
+		{name: "invalid timeout", options: `customTimeouts = { create = "5 minutes" }`, errors: 1},
+		{name: "computed timeout", options: `customTimeouts = { create = provider.region }`, errors: 1},
+		{name: "delete before replace", options: `deleteBeforeReplace = true`},
+		{name: "delete before replace not a bool", options: `deleteBeforeReplace = [true]`, errors: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, diags := bindTestProgram(t, `
+resource provider "pulumi:providers:aws" {
+	region = "us-west-2"
+}
+
+resource bucket "aws:s3:Bucket" {
+	options {
+		`+c.options+`
+	}
+}
+`)
+			assert.Len(t, diags.Errs(), c.errors)
+		})
+	}
+}
+
+func TestBindProviders(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		errors int
+	}{
+		{name: "config", config: `region = "us-east-1"`},
+		{name: "no config"},
+		{name: "unknown key", config: `regoin = "us-east-1"`, errors: 1},
+		{name: "type mismatch", config: `region = ["us-east-1"]`, errors: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			program, diags := bindTestProgram(t, `
+provider usEast1 "aws" {
+	`+c.config+`
+}
+
+resource bucket "aws:s3:Bucket" {
+	options {
+		provider = usEast1
+	}
+}
+`)
+			assert.Len(t, diags.Errs(), c.errors)
+
+			provider := program.Nodes[0].(*Provider)
+			assert.Equal(t, "aws", provider.Package)
+			assert.Equal(t, "pulumi:providers:aws", provider.Token)
+			assert.Equal(t, "usEast1", provider.Name())
+			assert.Equal(t, []Node{provider}, program.Nodes[1].(*Resource).getDependencies())
+		})
+	}
+}
+
+func TestBindProviderOption(t *testing.T) {
+	cases := []struct {
+		name     string
+		provider string
+		errors   []string
+	}{
+		{name: "provider block", provider: "usEast1"},
+		{name: "provider resource", provider: "explicit"},
+		{name: "ranged provider", provider: "regions[0]"},
+		{name: "computed provider", provider: "true ? usEast1 : explicit"},
+		{
+			name:     "package mismatch",
+			provider: "random",
+			errors: []string{"provider 'random' is for package 'random', but the resource belongs to package 'aws': " +
+				"the provider's package is declared at test.pp:10,17-25 and the resource's type at test.pp:15,17-32"},
+		},
+		{name: "not a provider", provider: "other", errors: []string{"provider must be a provider resource"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			program, diags := bindTestProgram(t, `
+provider usEast1 "aws" {
+}
+resource explicit "pulumi:providers:aws" {
+}
+provider regions "aws" {
+	options {
+		range = 2
+	}
+}
+provider random "random" {
+}
+resource other "aws:s3:Bucket" {
+}
+
+resource bucket "aws:s3:Bucket" {
+	options {
+		provider = `+c.provider+`
+	}
+}
+`)
+			var errors []string
+			for _, d := range diags.Errs() {
+				errors = append(errors, d.(*hcl.Diagnostic).Detail)
+			}
+			assert.Equal(t, c.errors, errors)
+
+			var bucket *Resource
+			for _, n := range program.Nodes {
+				if r, ok := n.(*Resource); ok && r.Name() == "bucket" {
+					bucket = r
+				}
+			}
+			provider := bucket.Options.ProviderResource
+			switch {
+			case c.errors != nil || strings.Contains(c.provider, "?"):
+				assert.Nil(t, provider)
+			default:
+				assert.Equal(t, strings.TrimSuffix(c.provider, "[0]"), provider.Name())
+			}
+		})
+	}
+}
+func TestBindComponents(t *testing.T) {
+	cases := []struct {
+		name   string
+		inputs string
+		args   string
+		errors int
+	}{
+		{name: "valid", inputs: `cidrBlock = string`, args: `cidrBlock = "10.0.0.0/16"`},
+		{name: "missing input", inputs: `cidrBlock = string`, errors: 1},
+		{name: "unknown input", inputs: `cidrBlock = string`, args: "cidrBlock = \"10.0.0.0/16\"\n\tcidr = \"\"", errors: 1},
+		{name: "unsupported input type", inputs: `cidrBlock = object({})`, args: `cidrBlock = {}`, errors: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			program, diags := bindTestProgram(t, `
+component vpc {
+	inputs {
+		`+c.inputs+`
+	}
+	resources {
+		resource vpc "aws:ec2:Vpc" {
+			cidrBlock = cidrBlock
+		}
+	}
+	outputs {
+		vpcId = vpc.id
+	}
+}
+
+resource main "vpc" {
+	`+c.args+`
+}
+`)
+			assert.Len(t, diags.Errs(), c.errors)
+
+			component := program.Nodes[0].(*Component)
+			assert.Equal(t, "vpc", component.Name())
+			assert.Len(t, component.Nodes, 1)
+
+			main := program.Nodes[1].(*Resource)
+			assert.Equal(t, component, main.Component)
+			assert.Equal(t, []Node{component}, main.getDependencies())
+		})
+	}
+}
+
+func TestBindRemoveUnusedLocals(t *testing.T) {
+	const text = `
+prefix = "site"
+unused = "${prefix}-unused"
+alsoUnused = unused
+bucketName = "${prefix}-bucket"
+
+component site {
+	resources {
+		name = "index.html"
+		scratch = "scratch"
+		resource bucket "aws:s3:Bucket" {
+		}
+	}
+	outputs {
+		index = name
+	}
+}
+
+resource bucket "aws:s3:Bucket" {
+	bucket = bucketName
+}
+`
+	program, diags := bindTestProgram(t, text)
+	assert.Len(t, diags, 0)
+	assert.Len(t, program.Nodes, 6)
+
+	program, diags = bindTestProgram(t, text, RemoveUnusedLocals)
+	if assert.Len(t, diags, 2) {
+		assert.Equal(t, hcl.DiagWarning, diags[0].Severity)
+		assert.Equal(t, "removed unused local variables: scratch", diags[0].Summary)
+		assert.Equal(t, "removed unused local variables: unused, alsoUnused", diags[1].Summary)
+	}
+
+	var names []string
+	for _, n := range program.Nodes {
+		switch n := n.(type) {
+		case *LocalVariable:
+			names = append(names, n.Name())
+		case *Component:
+			names = append(names, n.Name())
+			assert.Len(t, n.Nodes, 2)
+		case *Resource:
+			names = append(names, n.Name())
+		}
+	}
+	assert.Equal(t, []string{"prefix", "bucketName", "site", "bucket"}, names)
+}
+
+func TestBindRangedResources(t *testing.T) {
+	cases := []struct {
+		name     string
+		rng      string
+		instance string
+		isMap    bool
+	}{
+		{name: "number", rng: "2", instance: "buckets[0]"},
+		{name: "list", rng: `["a", "b"]`, instance: "buckets[0]"},
+		{name: "map", rng: `{ a = "one", b = "two" }`, instance: `buckets["a"]`, isMap: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			program, diags := bindTestProgram(t, `
+resource buckets "aws:s3:Bucket" {
+	options {
+		range = `+c.rng+`
+	}
+}
+
+output bucketId {
+	value = `+c.instance+`.id
+}
+`)
+			assert.Len(t, diags.Errs(), 0)
+
+			_, isMap := program.Nodes[0].(*Resource).VariableType.(*model.MapType)
+			assert.Equal(t, c.isMap, isMap)
+		})
+	}
+}
+
+func TestBindRangeVariables(t *testing.T) {
+	cases := []struct {
+		name   string
+		rng    string
+		expr   string
+		typ    model.Type
+		errors int
+	}{
+		{name: "number value", rng: "2", expr: "each.value", typ: model.NumberType},
+		{name: "number key", rng: "2", expr: "each.key", errors: 1},
+		{name: "list key", rng: `["a", "b"]`, expr: "each.key", typ: model.NumberType},
+		{name: "list value", rng: `["a", "b"]`, expr: "range.value", typ: model.StringType},
+		{name: "map key", rng: `{ a = "one", b = "two" }`, expr: "each.key", typ: model.StringType},
+		{name: "map value", rng: `{ a = "one", b = "two" }`, expr: "each.value", typ: model.StringType},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			program, diags := bindTestProgram(t, `
+resource buckets "aws:s3:Bucket" {
+	options {
+		range = `+c.rng+`
+	}
+	bucket = `+c.expr+`
+}
+`)
+			assert.Len(t, diags.Errs(), c.errors)
+			if c.errors == 0 {
+				assert.Equal(t, c.typ, program.Nodes[0].(*Resource).Inputs[0].Value.Type())
+			}
+		})
+	}
+}
+
+func TestBindProviderConfig(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		typ    string
+		errors int
+	}{
+		{name: "typed key", config: `config "aws:region" "string" {}`, typ: "string"},
+		{name: "untyped key", config: `config "aws:region" {}`, typ: "union(string, type(aws:index/region:Region))"},
+		{name: "unknown key", config: `config "aws:regoin" "string" {}`, errors: 1},
+		{name: "type mismatch", config: `config "aws:region" "list(string)" {}`, errors: 1},
+		{name: "project key", config: `config "myproject:region" "string" {}`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			program, diags := bindTestProgram(t, c.config)
+			assert.Len(t, diags.Errs(), c.errors)
+			if c.errors == 0 && program.Nodes[0].(*ConfigVariable).Namespace() == "aws" {
+				v := program.Nodes[0].(*ConfigVariable)
+				assert.NotNil(t, v.ProviderConfig)
+				assert.Equal(t, c.typ, v.Type().String())
+				assert.Equal(t, "defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables",
+					DescribeDefaultValue(v.ProviderConfig))
+			}
+		})
+	}
+}
+
+func TestBindProgramWithSyntaxErrors(t *testing.T) {
+	const source = `resource bucket "aws:s3:Bucket" {
+	website = {
+		indexDocument = "index.html"
+	}
+}
+
+resource broken "aws:s3:Bucket" {
+	bucket = "b" +
+}
+
+output bucketName {
+	value = bucket.bucket
+}
+`
+
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(source), "recovery.pp")
+	assert.NoError(t, err)
+	assert.True(t, parser.Diagnostics.HasErrors())
+	assert.Len(t, parser.Files[0].Unparsed, 1)
+
+	// The remainder of the program should bind without errors.
+	program, diags, err := BindProgram(parser.Files, PluginHost(test.NewHost(testdataPath)))
+	assert.NoError(t, err)
+	assert.False(t, diags.HasErrors())
+	assert.Len(t, program.Nodes, 2)
+}
+
+func TestBindReportsAllErrors(t *testing.T) {
+	const text = `
+x = y + missing1
+y = missing2
+
+resource bucket "aws:s3:Bucket" {
+	bucket = missing3
+}
+
+output bucketName {
+	value = missing4
+}
+`
+	// Locals are bound after their dependencies, but diagnostics are reported in source order.
+	_, diags := bindTestProgram(t, text)
+	var summaries []string
+	for _, d := range diags {
+		summaries = append(summaries, fmt.Sprintf("%d: %s", d.Subject.Start.Line, d.Summary))
+	}
+	assert.Equal(t, []string{
+		`1: undefined variable missing1`,
+		`2: undefined variable missing2`,
+		`5: undefined variable missing3`,
+		`9: undefined variable missing4`,
+	}, summaries)
+
+	_, diags = bindTestProgram(t, text, MaxErrors(2))
+	if assert.Len(t, diags, 3) {
+		assert.Equal(t, diags[0].Summary, "undefined variable missing1")
+		assert.Equal(t, diags[1].Summary, "undefined variable missing2")
+		assert.Equal(t, diags[2].Summary, "too many errors: 2 more errors were not reported")
+	}
+
+	// Malformed resources are reported without preventing the rest of the program from binding.
+	_, diags = bindTestProgram(t, `
+resource bucket {
+}
+
+output bucketName {
+	value = missing
+}
+`)
+	if assert.Len(t, diags, 2) {
+		assert.Equal(t, "resource variables must have exactly two labels", diags[0].Summary)
+		assert.Equal(t, "undefined variable missing", diags[1].Summary)
+	}
+}
@@ -15,19 +15,90 @@
 package hcl2
 
 import (
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
 )
 
-// Component represents a component definition in a program.
+// Component represents a reusable component definition in a program, e.g.
 //
-// TODO(pdg): implement
+//     component vpc {
+//         inputs {
+//             cidrBlock = string
+//         }
+//         resources {
+//             resource vpc "aws:ec2:Vpc" {
+//                 cidrBlock = cidrBlock
+//             }
+//         }
+//         outputs {
+//             vpcId = vpc.id
+//         }
+//     }
+//
+// A component is instantiated by a resource whose type is the component's name, e.g. `resource net "vpc" { ... }`.
+// The resources and local variables inside of a component are bound in a scope of their own that contains the
+// component's inputs but none of the program's top-level nodes.
 type Component struct {
-	Syntax *hclsyntax.Block
+	node
+
+	syntax  *hclsyntax.Block
+	outputs *hclsyntax.Block
+	scope   *model.Scope
+
+	// The component's inputs, in source order. Inside of the component, each input is a variable of type output(T),
+	// where T is the input's declared type.
+	Inputs []*model.Variable
+	// The type of the component's inputs. This is always an object type whose properties are the inputs' declared types.
+	InputType model.Type
+	// The type of the component's outputs. This is always an object type that contains the component's URN and each of
+	// its outputs.
+	OutputType model.Type
+
+	// The resources and local variables inside of the component, in source order.
+	Nodes []Node
+	// The component's outputs, in source order.
+	Outputs []*model.Attribute
+}
+
+// SyntaxNode returns the syntax node associated with the component.
+func (c *Component) SyntaxNode() hclsyntax.Node {
+	return c.syntax
+}
 
-	InputTypes  map[string]model.Type
-	OutputTypes map[string]model.Type
+// Type returns the type of the component's outputs.
+func (c *Component) Type() model.Type {
+	return c.OutputType
+}
+
+func (c *Component) Traverse(traverser hcl.Traverser) (model.Traversable, hcl.Diagnostics) {
+	return c.OutputType.Traverse(traverser)
+}
+
+func (c *Component) VisitExpressions(pre, post model.ExpressionVisitor) hcl.Diagnostics {
+	var diagnostics hcl.Diagnostics
+	for _, n := range c.Nodes {
+		diagnostics = append(diagnostics, n.VisitExpressions(pre, post)...)
+	}
+	for _, o := range c.Outputs {
+		diagnostics = append(diagnostics, model.VisitExpressions(o, pre, post)...)
+	}
+	return diagnostics
+}
+
+// Name returns the name of the component.
+func (c *Component) Name() string {
+	return c.syntax.Labels[0]
+}
 
-	Children []*Resource
-	Locals   []*LocalVariable
+// ProgramNodes returns the nodes of a program followed by the nodes inside of each of its components. Code generators
+// use this list to collect the imports needed by a program.
+func ProgramNodes(p *Program) []Node {
+	nodes := p.Nodes
+	for _, n := range p.Nodes {
+		if c, ok := n.(*Component); ok {
+			nodes = append(nodes[:len(nodes):len(nodes)], c.Nodes...)
+		}
+	}
+	return nodes
 }
@@ -15,9 +15,13 @@
 package hcl2
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 )
 
 // ConfigVariable represents a program- or component-scoped input variable. The value for a config variable may come
@@ -32,6 +36,8 @@ type ConfigVariable struct {
 	Definition *model.Block
 	// The default value for the config variable, if any.
 	DefaultValue model.Expression
+	// The schema for the provider configuration key named by the config variable, if any (e.g. `aws:region`).
+	ProviderConfig *schema.Property
 }
 
 // SyntaxNode returns the syntax node associated with the config variable.
@@ -55,3 +61,79 @@ func (cv *ConfigVariable) Name() string {
 func (cv *ConfigVariable) Type() model.Type {
 	return cv.typ
 }
+
+// Namespace returns the configuration namespace of the config variable. Config variables whose names are of the form
+// "namespace:key" (e.g. "aws:region") are read from the given namespace; all other config variables are read from the
+// project's namespace, in which case the result is the empty string.
+func (cv *ConfigVariable) Namespace() string {
+	namespace, _ := cv.splitName()
+	return namespace
+}
+
+// Key returns the name of the config variable within its namespace.
+func (cv *ConfigVariable) Key() string {
+	_, key := cv.splitName()
+	return key
+}
+
+// VariableName returns the name of the variable that holds the config variable's value in a program generated from
+// the given program. A config variable in a namespace is named after its key (e.g. `region` for `aws:region`) unless
+// another config variable or another node of the program would have the same name, in which case the name includes
+// the namespace (e.g. `awsRegion`). Other config variables are named after themselves.
+func (cv *ConfigVariable) VariableName(program *Program) string {
+	namespace, key := cv.splitName()
+	if namespace == "" || key == "" {
+		return cv.Name()
+	}
+	for _, n := range program.Nodes {
+		if n == cv {
+			continue
+		}
+		name := n.Name()
+		if other, ok := n.(*ConfigVariable); ok {
+			name = other.Key()
+		}
+		if name == key {
+			return namespace + strings.ToUpper(key[:1]) + key[1:]
+		}
+	}
+	return key
+}
+
+func (cv *ConfigVariable) splitName() (string, string) {
+	if len(cv.syntax.Labels) == 0 {
+		return "", ""
+	}
+	name := cv.syntax.Labels[0]
+	if i := strings.Index(name, ":"); i != -1 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// DescribeDefaultValue returns a short, human-readable description of the default value for the given property, or
+// the empty string if the property has no default. Code generators use this to document provider configuration.
+func DescribeDefaultValue(prop *schema.Property) string {
+	dv := prop.DefaultValue
+	if dv == nil {
+		return ""
+	}
+
+	var parts []string
+	switch len(dv.Environment) {
+	case 0:
+	case 1:
+		parts = append(parts, fmt.Sprintf("the value of the %s environment variable", dv.Environment[0]))
+	default:
+		vars := strings.Join(dv.Environment[:len(dv.Environment)-1], ", ")
+		parts = append(parts, fmt.Sprintf("the value of the %s or %s environment variables", vars,
+			dv.Environment[len(dv.Environment)-1]))
+	}
+	if dv.Value != nil {
+		parts = append(parts, fmt.Sprintf("%v", dv.Value))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "defaults to " + strings.Join(parts, ", otherwise ")
+}
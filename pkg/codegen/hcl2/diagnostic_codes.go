@@ -0,0 +1,138 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"github.com/hashicorp/hcl/v2"
+)
+
+// DiagnosticCode is a stable identifier for the kind of problem described by a diagnostic reported by the binder.
+// Codes never change meaning and are never reused, so tools may match on them, e.g. to enforce a policy on the
+// diagnostics reported when converting programs in a CI pipeline. The code of a diagnostic is available from
+// Program.DiagnosticCode.
+//
+// Error codes begin with "PCL0"; warning codes begin with "PCL1".
+type DiagnosticCode string
+
+const (
+	// CodeExpressionError identifies errors reported while binding and type checking expressions, e.g. references to
+	// undefined variables or calls with the wrong number of arguments.
+	CodeExpressionError DiagnosticCode = "PCL0001"
+	// CodeTooManyErrors identifies the error that counts the errors that were omitted because of MaxErrors.
+	CodeTooManyErrors DiagnosticCode = "PCL0002"
+	// CodeInvalidLabels identifies blocks with the wrong number of labels.
+	CodeInvalidLabels DiagnosticCode = "PCL0003"
+	// CodeAlreadyDeclared identifies declarations whose names are already declared.
+	CodeAlreadyDeclared DiagnosticCode = "PCL0004"
+	// CodeReservedOutputName identifies component outputs with reserved names.
+	CodeReservedOutputName DiagnosticCode = "PCL0005"
+	// CodeMalformedToken identifies resource type and function tokens that are not of the form 'pkg:module:member'.
+	CodeMalformedToken DiagnosticCode = "PCL0006"
+	// CodeUnknownPackage identifies tokens that refer to packages that are not referenced by the program.
+	CodeUnknownPackage DiagnosticCode = "PCL0007"
+	// CodeUnknownResourceType identifies resource type tokens that are not defined by their package.
+	CodeUnknownResourceType DiagnosticCode = "PCL0008"
+	// CodeUnknownFunction identifies function tokens that are not defined by their package.
+	CodeUnknownFunction DiagnosticCode = "PCL0009"
+	// CodeInvalidEnumValue identifies literal values that are not members of the enum they are assigned to.
+	CodeInvalidEnumValue DiagnosticCode = "PCL0010"
+	// CodeUnsupportedBlock identifies blocks that are not supported by their containing block.
+	CodeUnsupportedBlock DiagnosticCode = "PCL0011"
+	// CodeUnsupportedAttribute identifies attributes that are not supported by their containing block.
+	CodeUnsupportedAttribute DiagnosticCode = "PCL0012"
+	// CodeMissingRequiredAttribute identifies required attributes that are not set.
+	CodeMissingRequiredAttribute DiagnosticCode = "PCL0013"
+	// CodeTokenMustBeStringLiteral identifies invoke tokens that are not string literals.
+	CodeTokenMustBeStringLiteral DiagnosticCode = "PCL0014"
+	// CodeVersionMustBeStringLiteral identifies package versions that are not string literals.
+	CodeVersionMustBeStringLiteral DiagnosticCode = "PCL0015"
+	// CodeInvalidVersion identifies package versions that are not valid semantic versions.
+	CodeInvalidVersion DiagnosticCode = "PCL0016"
+	// CodeConflictingPackageVersions identifies package versions that conflict with versions requested elsewhere.
+	CodeConflictingPackageVersions DiagnosticCode = "PCL0017"
+	// CodeDuplicateBlock identifies blocks that may appear at most once but are repeated.
+	CodeDuplicateBlock DiagnosticCode = "PCL0018"
+	// CodeDependsOnMustBeResources identifies dependsOn options that are not lists of resources.
+	CodeDependsOnMustBeResources DiagnosticCode = "PCL0019"
+	// CodeProviderMustBeProvider identifies provider options that do not refer to provider resources.
+	CodeProviderMustBeProvider DiagnosticCode = "PCL0020"
+	// CodeProviderPackageMismatch identifies provider options that refer to providers for other packages.
+	CodeProviderPackageMismatch DiagnosticCode = "PCL0021"
+	// CodeAliasesMustBeLiteral identifies aliases options that are not lists of URNs and alias objects.
+	CodeAliasesMustBeLiteral DiagnosticCode = "PCL0022"
+	// CodeCustomTimeoutsMustBeLiteral identifies customTimeouts options that are not objects of duration strings.
+	CodeCustomTimeoutsMustBeLiteral DiagnosticCode = "PCL0023"
+	// CodeInvalidCustomTimeout identifies custom timeouts that are not valid durations.
+	CodeInvalidCustomTimeout DiagnosticCode = "PCL0024"
+	// CodeUnknownConfigKey identifies provider configuration keys that are not defined by their package.
+	CodeUnknownConfigKey DiagnosticCode = "PCL0025"
+	// CodeConfigTypeMismatch identifies config variables whose types do not match their provider configuration.
+	CodeConfigTypeMismatch DiagnosticCode = "PCL0026"
+	// CodeUnsupportedComponentInputType identifies component inputs whose types are not supported.
+	CodeUnsupportedComponentInputType DiagnosticCode = "PCL0027"
+	// CodeNotConvertible identifies values that cannot be converted to the type of the location they are assigned to.
+	CodeNotConvertible DiagnosticCode = "PCL0028"
+
+	// CodeUnusedLocalsRemoved identifies the warning that lists the locals removed by RemoveUnusedLocals.
+	CodeUnusedLocalsRemoved DiagnosticCode = "PCL1001"
+	// CodeMissingRequiredAttributeWithDefault identifies required attributes that are not set but have default values
+	// in their package's schema.
+	CodeMissingRequiredAttributeWithDefault DiagnosticCode = "PCL1002"
+	// CodeDynamicInvoke identifies invokes whose arguments have a dynamic type, and so cannot be checked against the
+	// inputs of the function.
+	CodeDynamicInvoke DiagnosticCode = "PCL1003"
+	// CodeUnknownResourceOption identifies resource options that are not known to the binder and are ignored.
+	CodeUnknownResourceOption DiagnosticCode = "PCL1004"
+	// CodeMissingPackageSchema identifies resources and invokes that are not type checked because the schema for their
+	// package could not be loaded. See SkipResourceTypechecking.
+	CodeMissingPackageSchema DiagnosticCode = "PCL1005"
+)
+
+// DiagnosticCode returns the code of the given diagnostic, which must have been reported when the program was bound.
+// Diagnostics that were not reported by the binder have no code.
+func (p *Program) DiagnosticCode(d *hcl.Diagnostic) (DiagnosticCode, bool) {
+	code, ok := p.binder.codes[d]
+	return code, ok
+}
+
+// withCode records the code of a diagnostic and returns the diagnostic.
+func (b *binder) withCode(d *hcl.Diagnostic, code DiagnosticCode) *hcl.Diagnostic {
+	b.codes[d] = code
+	return d
+}
+
+// applyDiagnosticOptions assigns CodeExpressionError to each of the given diagnostics that does not yet have a code,
+// then applies the WarningsAsErrors and SuppressWarnings options.
+func (b *binder) applyDiagnosticOptions(diagnostics hcl.Diagnostics) hcl.Diagnostics {
+	var result hcl.Diagnostics
+	for _, d := range diagnostics {
+		code, ok := b.codes[d]
+		if !ok {
+			code = CodeExpressionError
+			b.withCode(d, code)
+		}
+
+		if d.Severity == hcl.DiagWarning {
+			if b.options.suppressedWarnings[code] {
+				continue
+			}
+			if b.options.allWarningsAsErrors || b.options.warningsAsErrors[code] {
+				d.Severity = hcl.DiagError
+			}
+		}
+		result = append(result, d)
+	}
+	return result
+}
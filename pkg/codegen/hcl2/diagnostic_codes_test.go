@@ -0,0 +1,110 @@
+package hcl2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestDiagnosticCodes(t *testing.T) {
+	pkg, err := schema.ImportSpec(schema.PackageSpec{
+		Name: "synthetic",
+		Resources: map[string]schema.ResourceSpec{
+			"synthetic:index:Widget": {
+				InputProperties: map[string]schema.PropertySpec{
+					"size": {TypeSpec: schema.TypeSpec{Type: "integer"}, Default: 3.0},
+				},
+				RequiredInputs: []string{"size"},
+			},
+		},
+		Functions: map[string]schema.FunctionSpec{
+			"synthetic:index:getWidget": {
+				Inputs: &schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{
+						"name": {TypeSpec: schema.TypeSpec{Type: "string"}},
+					},
+				},
+			},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	const text = `config args {
+}
+
+resource widget "synthetic:index:Widget" {
+	options {
+		retainOnDelete = true
+	}
+}
+
+resource gadget "synthetic:index:Gadget" {
+}
+
+output thing {
+	value = invoke("synthetic:index:getWidget", args)
+}
+
+output missing {
+	value = undefined
+}
+`
+
+	type diagnostic struct {
+		Code     DiagnosticCode
+		Severity hcl.DiagnosticSeverity
+	}
+	bind := func(opts ...BindOption) []diagnostic {
+		parser := syntax.NewParser()
+		err := parser.ParseFile(strings.NewReader(text), "test.pp")
+		assert.NoError(t, err)
+		assert.False(t, parser.Diagnostics.HasErrors())
+
+		program, diags, err := BindProgram(parser.Files, append([]BindOption{Packages(pkg)}, opts...)...)
+		assert.NoError(t, err)
+
+		var result []diagnostic
+		for _, d := range diags {
+			code, ok := program.DiagnosticCode(d)
+			assert.True(t, ok)
+			result = append(result, diagnostic{Code: code, Severity: d.Severity})
+		}
+		return result
+	}
+
+	// Every diagnostic has a code.
+	assert.Equal(t, []diagnostic{
+		{CodeMissingRequiredAttributeWithDefault, hcl.DiagWarning},
+		{CodeUnknownResourceOption, hcl.DiagWarning},
+		{CodeUnknownResourceType, hcl.DiagError},
+		{CodeDynamicInvoke, hcl.DiagWarning},
+		{CodeExpressionError, hcl.DiagError},
+	}, bind())
+
+	// Selected warnings may be reported as errors or suppressed.
+	assert.Equal(t, []diagnostic{
+		{CodeMissingRequiredAttributeWithDefault, hcl.DiagError},
+		{CodeUnknownResourceType, hcl.DiagError},
+		{CodeDynamicInvoke, hcl.DiagWarning},
+		{CodeExpressionError, hcl.DiagError},
+	}, bind(WarningsAsErrors(CodeMissingRequiredAttributeWithDefault), SuppressWarnings(CodeUnknownResourceOption)))
+
+	// All warnings may be reported as errors. Errors cannot be suppressed.
+	assert.Equal(t, []diagnostic{
+		{CodeMissingRequiredAttributeWithDefault, hcl.DiagError},
+		{CodeUnknownResourceOption, hcl.DiagError},
+		{CodeUnknownResourceType, hcl.DiagError},
+		{CodeDynamicInvoke, hcl.DiagError},
+		{CodeExpressionError, hcl.DiagError},
+	}, bind(WarningsAsErrors(), SuppressWarnings(CodeUnknownResourceType)))
+
+	// Diagnostics that were not reported by the binder have no code.
+	program, _ := bindTestProgram(t, "")
+	_, ok := program.DiagnosticCode(&hcl.Diagnostic{})
+	assert.False(t, ok)
+}
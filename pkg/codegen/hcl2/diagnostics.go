@@ -2,10 +2,14 @@ package hcl2
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/blang/semver"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func errorf(subject hcl.Range, f string, args ...interface{}) *hcl.Diagnostic {
@@ -22,7 +26,73 @@ func diagf(severity hcl.DiagnosticSeverity, subject hcl.Range, f string, args ..
 	}
 }
 
-func labelsErrorf(block *hclsyntax.Block, f string, args ...interface{}) *hcl.Diagnostic {
+func unusedLocalsRemoved(names []string) *hcl.Diagnostic {
+	message := fmt.Sprintf("removed unused local variables: %s", strings.Join(names, ", "))
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  message,
+		Detail:   message,
+	}
+}
+
+// exprNotConvertible returns a diagnostic that describes why the given expression cannot be assigned to a location of
+// the given type. Types that were converted from package schemas are printed using their schema tokens.
+func (b *binder) exprNotConvertible(destType model.Type, expr model.Expression) *hcl.Diagnostic {
+	printer := model.TypePrinter{TypeName: b.schemaTypeName}
+	return b.withCode(printer.ExprNotConvertible(destType, expr), CodeNotConvertible)
+}
+
+func (b *binder) tooManyErrors(omitted int) *hcl.Diagnostic {
+	message := fmt.Sprintf("too many errors: %d more errors were not reported", omitted)
+	return b.withCode(&hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  message,
+		Detail:   message,
+	}, CodeTooManyErrors)
+}
+
+// sortDiagnostics sorts diagnostics by the position of their subjects. Diagnostics without subjects are sorted after
+// those with subjects. Diagnostics at the same position retain their relative order.
+func sortDiagnostics(diagnostics hcl.Diagnostics) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Subject, diagnostics[j].Subject
+		switch {
+		case a == nil || b == nil:
+			return a != nil && b == nil
+		case a.Filename != b.Filename:
+			return a.Filename < b.Filename
+		case a.Start.Line != b.Start.Line:
+			return a.Start.Line < b.Start.Line
+		default:
+			return a.Start.Column < b.Start.Column
+		}
+	})
+}
+
+// limitErrors removes all but the first max errors from the given diagnostics and appends an error that counts the
+// errors that were removed. If max is zero, the diagnostics are returned unchanged.
+func (b *binder) limitErrors(diagnostics hcl.Diagnostics, max int) hcl.Diagnostics {
+	if max <= 0 {
+		return diagnostics
+	}
+
+	var limited hcl.Diagnostics
+	errors := 0
+	for _, d := range diagnostics {
+		if d.Severity == hcl.DiagError {
+			if errors++; errors > max {
+				continue
+			}
+		}
+		limited = append(limited, d)
+	}
+	if errors > max {
+		limited = append(limited, b.tooManyErrors(errors-max))
+	}
+	return limited
+}
+
+func (b *binder) labelsErrorf(block *hclsyntax.Block, f string, args ...interface{}) *hcl.Diagnostic {
 	startRange := block.LabelRanges[0]
 
 	diagRange := hcl.Range{
@@ -30,41 +100,180 @@ func labelsErrorf(block *hclsyntax.Block, f string, args ...interface{}) *hcl.Di
 		Start:    startRange.Start,
 		End:      block.LabelRanges[len(block.LabelRanges)-1].End,
 	}
-	return errorf(diagRange, f, args...)
+	return b.withCode(errorf(diagRange, f, args...), CodeInvalidLabels)
+}
+
+func (b *binder) alreadyDeclared(name string, subject hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(subject, "%q already declared", name), CodeAlreadyDeclared)
+}
+
+func (b *binder) componentAlreadyDeclared(name string, subject hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(subject, "component %q already declared", name), CodeAlreadyDeclared)
+}
+
+func (b *binder) reservedOutputName(nameRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(nameRange, "'urn' is a reserved output name"), CodeReservedOutputName)
 }
 
 func malformedToken(token string, sourceRange hcl.Range) *hcl.Diagnostic {
 	return errorf(sourceRange, "malformed token '%v': expected 'pkg:module:member'", token)
 }
 
-func unknownPackage(pkg string, tokenRange hcl.Range) *hcl.Diagnostic {
-	return errorf(tokenRange, "unknown package '%s'", pkg)
+func (b *binder) unknownPackage(pkg string, tokenRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(tokenRange, "unknown package '%s'", pkg), CodeUnknownPackage)
+}
+
+func (b *binder) missingPackageSchema(pkg, token string, tokenRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(diagf(hcl.DiagWarning, tokenRange, "'%s' is not type checked: the schema for package '%s' "+
+		"could not be loaded: %v", token, pkg, b.missingPackages[pkg]), CodeMissingPackageSchema)
+}
+
+func (b *binder) unknownResourceType(token, suggestion string, tokenRange hcl.Range) *hcl.Diagnostic {
+	if suggestion != "" {
+		return b.withCode(errorf(tokenRange, "unknown resource type '%s'; did you mean '%s'?", token, suggestion),
+			CodeUnknownResourceType)
+	}
+	return b.withCode(errorf(tokenRange, "unknown resource type '%s'", token), CodeUnknownResourceType)
+}
+
+func (b *binder) unknownFunction(token, suggestion string, tokenRange hcl.Range) *hcl.Diagnostic {
+	if suggestion != "" {
+		return b.withCode(errorf(tokenRange, "unknown function '%s'; did you mean '%s'?", token, suggestion),
+			CodeUnknownFunction)
+	}
+	return b.withCode(errorf(tokenRange, "unknown function '%s'", token), CodeUnknownFunction)
+}
+
+func (b *binder) invalidEnumValue(value cty.Value, enum *model.EnumType, valueRange hcl.Range) *hcl.Diagnostic {
+	elements := make([]string, len(enum.Elements))
+	for i, e := range enum.Elements {
+		elements[i] = formatEnumValue(e)
+	}
+	return b.withCode(errorf(valueRange, "%s is not a valid value of enum '%s'; expected one of %s",
+		formatEnumValue(value), enum.Token, strings.Join(elements, ", ")), CodeInvalidEnumValue)
+}
+
+func formatEnumValue(value cty.Value) string {
+	switch value.Type() {
+	case cty.String:
+		return fmt.Sprintf("%q", value.AsString())
+	case cty.Number:
+		return value.AsBigFloat().Text('g', -1)
+	case cty.Bool:
+		return fmt.Sprintf("%v", value.True())
+	default:
+		return value.GoString()
+	}
+}
+
+func (b *binder) unsupportedBlock(blockType string, typeRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(typeRange, "unsupported block of type '%v'", blockType), CodeUnsupportedBlock)
+}
+
+func (b *binder) unsupportedAttribute(attrName string, nameRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(nameRange, "unsupported attribute '%v'", attrName), CodeUnsupportedAttribute)
+}
+
+func (b *binder) unknownResourceOption(optionName string, nameRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(diagf(hcl.DiagWarning, nameRange, "unknown resource option '%v' will be ignored", optionName),
+		CodeUnknownResourceOption)
+}
+
+func (b *binder) missingRequiredAttribute(attrName string, missingRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(missingRange, "missing required attribute '%v'", attrName), CodeMissingRequiredAttribute)
+}
+
+func (b *binder) missingRequiredAttributeWithDefault(attrName string, missingRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(diagf(hcl.DiagWarning, missingRange,
+		"missing required attribute '%v'; its schema's default value will be used", attrName),
+		CodeMissingRequiredAttributeWithDefault)
+}
+
+func (b *binder) tokenMustBeStringLiteral(tokenExpr model.Expression) *hcl.Diagnostic {
+	return b.withCode(errorf(tokenExpr.SyntaxNode().Range(), "invoke token must be a string literal"),
+		CodeTokenMustBeStringLiteral)
+}
+
+func (b *binder) dynamicInvokeArgs(token string, argsExpr model.Expression) *hcl.Diagnostic {
+	return b.withCode(diagf(hcl.DiagWarning, argsExpr.SyntaxNode().Range(),
+		"the arguments to '%s' have a dynamic type and cannot be checked against the function's inputs", token),
+		CodeDynamicInvoke)
+}
+
+func (b *binder) versionMustBeStringLiteral(versionRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(versionRange, "version must be a string literal"), CodeVersionMustBeStringLiteral)
 }
 
-func unknownResourceType(token string, tokenRange hcl.Range) *hcl.Diagnostic {
-	return errorf(tokenRange, "unknown resource type '%s'", token)
+func (b *binder) invalidVersion(version string, err error, versionRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(versionRange, "invalid version '%s': %v", version, err), CodeInvalidVersion)
+}
+
+func (b *binder) conflictingPackageVersions(pkg string, version, other semver.Version,
+	versionRange hcl.Range) *hcl.Diagnostic {
+
+	return b.withCode(errorf(versionRange,
+		"version %v of package '%s' conflicts with version %v requested elsewhere in the program", other, pkg,
+		version), CodeConflictingPackageVersions)
+}
+
+func (b *binder) duplicateBlock(blockType string, typeRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(typeRange, "duplicate block of type '%v'", blockType), CodeDuplicateBlock)
+}
+
+func (b *binder) dependsOnMustBeResources(expr model.Expression) *hcl.Diagnostic {
+	return b.withCode(errorf(expr.SyntaxNode().Range(), "dependsOn must be a list of resources"),
+		CodeDependsOnMustBeResources)
+}
+
+func (b *binder) providerMustBeProvider(expr model.Expression) *hcl.Diagnostic {
+	return b.withCode(errorf(expr.SyntaxNode().Range(), "provider must be a provider resource"),
+		CodeProviderMustBeProvider)
+}
+
+// providerPackageMismatch returns a diagnostic for a provider option that refers to a provider for a different package
+// than the resource's. The diagnostic's subject is the option's value; its detail gives the locations of the
+// provider's package and of the resource's type.
+func (b *binder) providerPackageMismatch(providerName, providerPkg string, providerRange hcl.Range,
+	resourcePkg string, resourceRange hcl.Range, expr model.Expression) *hcl.Diagnostic {
+
+	summary := fmt.Sprintf("provider '%s' is for package '%s', but the resource belongs to package '%s'", providerName,
+		providerPkg, resourcePkg)
+	subject := expr.SyntaxNode().Range()
+	return b.withCode(&hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  summary,
+		Detail: fmt.Sprintf("%s: the provider's package is declared at %v and the resource's type at %v", summary,
+			providerRange, resourceRange),
+		Subject: &subject,
+	}, CodeProviderPackageMismatch)
 }
 
-func unknownFunction(token string, tokenRange hcl.Range) *hcl.Diagnostic {
-	return errorf(tokenRange, "unknown function '%s'", token)
+func (b *binder) aliasesMustBeLiteral(expr model.Expression) *hcl.Diagnostic {
+	return b.withCode(errorf(expr.SyntaxNode().Range(), "aliases must be a list of URNs and alias objects"),
+		CodeAliasesMustBeLiteral)
 }
 
-func unsupportedBlock(blockType string, typeRange hcl.Range) *hcl.Diagnostic {
-	return errorf(typeRange, "unsupported block of type '%v'", blockType)
+func (b *binder) customTimeoutsMustBeLiteral(expr model.Expression) *hcl.Diagnostic {
+	return b.withCode(errorf(expr.SyntaxNode().Range(), "customTimeouts must be an object of duration strings"),
+		CodeCustomTimeoutsMustBeLiteral)
 }
 
-func unsupportedAttribute(attrName string, nameRange hcl.Range) *hcl.Diagnostic {
-	return errorf(nameRange, "unsupported attribute '%v'", attrName)
+func (b *binder) invalidCustomTimeout(value string, expr model.Expression) *hcl.Diagnostic {
+	return b.withCode(errorf(expr.SyntaxNode().Range(),
+		"invalid timeout '%s': expected a duration such as \"5m\" or \"1h30m\"", value), CodeInvalidCustomTimeout)
 }
 
-func missingRequiredAttribute(attrName string, missingRange hcl.Range) *hcl.Diagnostic {
-	return errorf(missingRange, "missing required attribute '%v'", attrName)
+func (b *binder) unknownConfigKey(pkg, key string, keyRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(keyRange, "unknown configuration key '%s' for package '%s'", key, pkg),
+		CodeUnknownConfigKey)
 }
 
-func tokenMustBeStringLiteral(tokenExpr model.Expression) *hcl.Diagnostic {
-	return errorf(tokenExpr.SyntaxNode().Range(), "invoke token must be a string literal")
+func (b *binder) configTypeMismatch(name string, typ, configType model.Type, typeRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(typeRange, "config variable '%s' has type %v, which is not assignable to its provider "+
+		"configuration type %v", name, typ, configType), CodeConfigTypeMismatch)
 }
 
-func duplicateBlock(blockType string, typeRange hcl.Range) *hcl.Diagnostic {
-	return errorf(typeRange, "duplicate block of type '%v'", blockType)
+func (b *binder) unsupportedComponentInputType(name string, typ model.Type, typeRange hcl.Range) *hcl.Diagnostic {
+	return b.withCode(errorf(typeRange, "component input '%s' has type %v; component inputs must be primitives or "+
+		"lists or maps of primitives", name, typ), CodeUnsupportedComponentInputType)
 }
@@ -208,7 +208,7 @@ var pulumiBuiltins = map[string]*model.Function{
 					Name: "value",
 					Type: valueType,
 				}},
-				ReturnType: model.NewOutputType(valueType),
+				ReturnType: model.NewOutputType(model.NewSecretType(valueType)),
 			}, nil
 		})),
 	"split": model.NewFunction(model.StaticFunctionSignature{
@@ -224,11 +224,25 @@ var pulumiBuiltins = map[string]*model.Function{
 		},
 		ReturnType: model.NewListType(model.StringType),
 	}),
-	"toJSON": model.NewFunction(model.StaticFunctionSignature{
+	"toJSON": model.NewFunction(model.GenericFunctionSignature(getToJSONSignature)),
+}
+
+// getToJSONSignature returns the signature of a call to toJSON. If the value to serialize contains outputs, the value
+// is passed as-is and the call returns an output: code generators use their SDK's JSON helpers for such calls so that
+// the result is unknown or secret if any of the outputs are. Otherwise, the call observes the value and returns a
+// string.
+func getToJSONSignature(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+	signature := model.StaticFunctionSignature{
 		Parameters: []model.Parameter{{
 			Name: "value",
 			Type: model.DynamicType,
 		}},
 		ReturnType: model.StringType,
-	}),
+	}
+
+	if len(args) == 1 && model.ContainsOutputs(args[0].Type()) {
+		signature.Parameters[0].Type = args[0].Type()
+		signature.ReturnType = model.NewOutputType(model.StringType)
+	}
+	return signature, nil
 }
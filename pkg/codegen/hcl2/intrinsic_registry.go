@@ -0,0 +1,146 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model/format"
+)
+
+// An IntrinsicEmitter generates the code for calls to a registered intrinsic in a single target language.
+type IntrinsicEmitter struct {
+	// Imports lists the imports required by the generated code, in the form used by the language's program generator:
+	// Go package paths, .NET namespaces, Node.js modules, Python modules, or fully-qualified Java class names.
+	Imports []string
+	// Emit writes the code for the given call to w. The call's arguments may be generated using the formatter.
+	Emit func(f *format.Formatter, w io.Writer, call *model.FunctionCallExpression)
+}
+
+// An IntrinsicFunction is a function that is defined by an embedder rather than by PCL itself or by a package schema,
+// e.g. a function that models a Terraform function that has no Pulumi equivalent. The binder checks calls to the
+// function against its signature. Program generators emit calls to the function using its emitters, which are keyed
+// by the name of the target language ("go", "csharp", "nodejs", "python", or "java"). Calls to the function in
+// languages without an emitter are reported as unsupported.
+type IntrinsicFunction struct {
+	// Name is the name of the function.
+	Name string
+	// Signature is the signature of the function.
+	Signature model.FunctionSignature
+	// Emitters maps the name of each target language to the emitter for that language.
+	Emitters map[string]*IntrinsicEmitter
+}
+
+// IntrinsicRegistry is a set of intrinsic functions indexed by name. The functions in a registry are defined in the
+// root scope of each program that is bound with the registry; see the Intrinsics option. A registry may be used
+// concurrently.
+type IntrinsicRegistry struct {
+	m         sync.RWMutex
+	functions map[string]*IntrinsicFunction
+}
+
+// NewIntrinsicRegistry creates a new, empty registry of intrinsic functions.
+func NewIntrinsicRegistry() *IntrinsicRegistry {
+	return &IntrinsicRegistry{functions: map[string]*IntrinsicFunction{}}
+}
+
+// Register adds the given function to the registry. The function's name must be a valid identifier that does not
+// contain underscores, which are reserved for the intrinsics that call the functions of packages, and must not name
+// a builtin function. It is an error to register two functions with the same name in a single registry.
+func (r *IntrinsicRegistry) Register(fn *IntrinsicFunction) error {
+	switch {
+	case !hclsyntax.ValidIdentifier(fn.Name):
+		return errors.Errorf("%q is not a valid intrinsic name", fn.Name)
+	case strings.Contains(fn.Name, "_"):
+		return errors.Errorf("intrinsic name %s must not contain underscores", fn.Name)
+	case fn.Name == Invoke || pulumiBuiltins[fn.Name] != nil:
+		return errors.Errorf("%s is a builtin function", fn.Name)
+	case fn.Signature == nil:
+		return errors.Errorf("intrinsic %s has no signature", fn.Name)
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if _, ok := r.functions[fn.Name]; ok {
+		return errors.Errorf("intrinsic %s is already registered", fn.Name)
+	}
+	r.functions[fn.Name] = fn
+	return nil
+}
+
+// MustRegister adds the given function to the registry, and panics if the function cannot be registered.
+func (r *IntrinsicRegistry) MustRegister(fn *IntrinsicFunction) {
+	if err := r.Register(fn); err != nil {
+		panic(err)
+	}
+}
+
+// Get fetches the intrinsic function with the given name.
+func (r *IntrinsicRegistry) Get(name string) (*IntrinsicFunction, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	fn, ok := r.functions[name]
+	return fn, ok
+}
+
+// Functions returns the registered functions sorted by name.
+func (r *IntrinsicRegistry) Functions() []*IntrinsicFunction {
+	if r == nil {
+		return nil
+	}
+
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	functions := make([]*IntrinsicFunction, 0, len(r.functions))
+	for _, fn := range r.functions {
+		functions = append(functions, fn)
+	}
+	sort.Slice(functions, func(i, j int) bool {
+		return functions[i].Name < functions[j].Name
+	})
+	return functions
+}
+
+// Intrinsics sets the registry that holds the intrinsic functions that may be called by a program in addition to the
+// builtin functions.
+func Intrinsics(registry *IntrinsicRegistry) BindOption {
+	return func(options *bindOptions) {
+		options.intrinsics = registry
+	}
+}
+
+// IntrinsicEmitter returns the emitter for calls to the registered intrinsic with the given name in the given target
+// language, if the program was bound with such an intrinsic and the intrinsic has an emitter for the language.
+func (p *Program) IntrinsicEmitter(name, language string) (*IntrinsicEmitter, bool) {
+	fn, ok := p.binder.options.intrinsics.Get(name)
+	if !ok {
+		return nil, false
+	}
+	emitter, ok := fn.Emitters[language]
+	return emitter, ok && emitter != nil && emitter.Emit != nil
+}
@@ -0,0 +1,106 @@
+package hcl2
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model/format"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+)
+
+func newCidrSubnetIntrinsic() *IntrinsicFunction {
+	return &IntrinsicFunction{
+		Name: "cidrsubnet",
+		Signature: model.StaticFunctionSignature{
+			Parameters: []model.Parameter{
+				{Name: "prefix", Type: model.StringType},
+				{Name: "newbits", Type: model.NumberType},
+				{Name: "netnum", Type: model.NumberType},
+			},
+			ReturnType: model.StringType,
+		},
+		Emitters: map[string]*IntrinsicEmitter{
+			"go": {
+				Imports: []string{"github.com/example/cidr"},
+				Emit: func(f *format.Formatter, w io.Writer, call *model.FunctionCallExpression) {
+					f.Fgenf(w, "cidr.Subnet(%.v, %.v, %.v)", call.Args[0], call.Args[1], call.Args[2])
+				},
+			},
+		},
+	}
+}
+
+func TestIntrinsicRegistry(t *testing.T) {
+	signature := model.StaticFunctionSignature{ReturnType: model.StringType}
+
+	registry := NewIntrinsicRegistry()
+	assert.NoError(t, registry.Register(newCidrSubnetIntrinsic()))
+	assert.NoError(t, registry.Register(&IntrinsicFunction{Name: "base64encode", Signature: signature}))
+
+	invalid := []*IntrinsicFunction{
+		{Name: "cidrsubnet", Signature: signature},
+		{Name: "not a name", Signature: signature},
+		{Name: "aws_getAmi", Signature: signature},
+		{Name: "fileAsset", Signature: signature},
+		{Name: Invoke, Signature: signature},
+		{Name: "md5"},
+	}
+	for _, fn := range invalid {
+		assert.Error(t, registry.Register(fn), fn.Name)
+	}
+
+	fn, ok := registry.Get("cidrsubnet")
+	assert.True(t, ok)
+	assert.Equal(t, "cidrsubnet", fn.Name)
+	_, ok = registry.Get("md5")
+	assert.False(t, ok)
+
+	var names []string
+	for _, fn := range registry.Functions() {
+		names = append(names, fn.Name)
+	}
+	assert.Equal(t, []string{"base64encode", "cidrsubnet"}, names)
+}
+
+func TestBindRegisteredIntrinsics(t *testing.T) {
+	registry := NewIntrinsicRegistry()
+	registry.MustRegister(newCidrSubnetIntrinsic())
+
+	bind := func(text string, opts ...BindOption) (*Program, int) {
+		parser := syntax.NewParser()
+		err := parser.ParseFile(strings.NewReader(text), "test.pp")
+		assert.NoError(t, err)
+		assert.Len(t, parser.Diagnostics, 0)
+
+		program, diags, err := BindProgram(parser.Files, append(opts, Loader(failingLoader{}))...)
+		assert.NoError(t, err)
+		return program, len(diags.Errs())
+	}
+
+	program, errs := bind(`subnet = cidrsubnet("10.0.0.0/16", 8, 1)`, Intrinsics(registry))
+	if assert.Equal(t, 0, errs) {
+		call := program.Nodes[0].(*LocalVariable).Definition.Value.(*model.FunctionCallExpression)
+		assert.Equal(t, "cidrsubnet", call.Name)
+		assert.Equal(t, model.StringType, call.Type())
+	}
+
+	// The arguments to registered intrinsics are typechecked.
+	_, errs = bind(`subnet = cidrsubnet(["10.0.0.0/16"], 8, 1)`, Intrinsics(registry))
+	assert.Equal(t, 1, errs)
+
+	// Programs that are bound without the registry may not call its intrinsics.
+	_, errs = bind(`subnet = cidrsubnet("10.0.0.0/16", 8, 1)`)
+	assert.Equal(t, 1, errs)
+
+	emitter, ok := program.IntrinsicEmitter("cidrsubnet", "go")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"github.com/example/cidr"}, emitter.Imports)
+	_, ok = program.IntrinsicEmitter("cidrsubnet", "python")
+	assert.False(t, ok)
+	_, ok = program.IntrinsicEmitter("fileAsset", "go")
+	assert.False(t, ok)
+}
@@ -26,6 +26,8 @@ const (
 	IntrinsicConvert = "__convert"
 	// IntrinsicInput is the name of the input intrinsic.
 	IntrinsicInput = "__input"
+	// IntrinsicMap is the name of the map intrinsic.
+	IntrinsicMap = "__map"
 )
 
 func isOutput(t model.Type) bool {
@@ -108,3 +110,40 @@ func ParseConvertCall(c *model.FunctionCallExpression) (model.Expression, model.
 	contract.Assert(c.Name == IntrinsicConvert)
 	return c.Args[0], c.Signature.ReturnType
 }
+
+// NewMapCall returns a new expression that represents a call to IntrinsicMap. The call produces a list that contains
+// the result of applying then to each element of the given collection for which filter, if any, returns true.
+func NewMapCall(collection model.Expression, then, filter *model.AnonymousFunctionExpression,
+	resultType model.Type) *model.FunctionCallExpression {
+
+	signature := model.StaticFunctionSignature{
+		Parameters: []model.Parameter{
+			{Name: "collection", Type: collection.Type()},
+			{Name: "then", Type: then.Type()},
+		},
+		ReturnType: resultType,
+	}
+	args := []model.Expression{collection, then}
+	if filter != nil {
+		signature.Parameters = append(signature.Parameters, model.Parameter{Name: "filter", Type: filter.Type()})
+		args = append(args, filter)
+	}
+
+	return &model.FunctionCallExpression{
+		Name:      IntrinsicMap,
+		Signature: signature,
+		Args:      args,
+	}
+}
+
+// ParseMapCall extracts the collection, the function applied to each element, and the filter, if any, from a call to
+// the map intrinsic.
+func ParseMapCall(c *model.FunctionCallExpression) (collection model.Expression,
+	then, filter *model.AnonymousFunctionExpression) {
+
+	contract.Assert(c.Name == IntrinsicMap)
+	if len(c.Args) > 2 {
+		filter = c.Args[2].(*model.AnonymousFunctionExpression)
+	}
+	return c.Args[0], c.Args[1].(*model.AnonymousFunctionExpression), filter
+}
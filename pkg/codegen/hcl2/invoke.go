@@ -15,9 +15,12 @@
 package hcl2
 
 import (
+	"strings"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -66,22 +69,25 @@ func (b *binder) bindInvokeSignature(args []model.Expression) (model.StaticFunct
 
 	template, ok := args[0].(*model.TemplateExpression)
 	if !ok || len(template.Parts) != 1 {
-		return signature, hcl.Diagnostics{tokenMustBeStringLiteral(args[0])}
+		return signature, hcl.Diagnostics{b.tokenMustBeStringLiteral(args[0])}
 	}
 	lit, ok := template.Parts[0].(*model.LiteralValueExpression)
 	if !ok || lit.Type() != model.StringType {
-		return signature, hcl.Diagnostics{tokenMustBeStringLiteral(args[0])}
+		return signature, hcl.Diagnostics{b.tokenMustBeStringLiteral(args[0])}
 	}
 
 	token, tokenRange := lit.Value.AsString(), args[0].SyntaxNode().Range()
-	pkg, _, _, diagnostics := DecomposeToken(token, tokenRange)
+	pkg, _, _, diagnostics := b.decomposeToken(token, tokenRange)
 	if diagnostics.HasErrors() {
 		return signature, diagnostics
 	}
 
-	pkgSchema, ok := b.options.packageCache.entries[pkg]
+	if _, ok := b.missingPackages[pkg]; ok {
+		return signature, hcl.Diagnostics{b.missingPackageSchema(pkg, token, tokenRange)}
+	}
+	pkgSchema, ok := b.referencedPackages[pkg]
 	if !ok {
-		return signature, hcl.Diagnostics{unknownPackage(pkg, tokenRange)}
+		return signature, hcl.Diagnostics{b.unknownPackage(pkg, tokenRange)}
 	}
 
 	fn, ok := pkgSchema.functions[token]
@@ -92,22 +98,139 @@ func (b *binder) bindInvokeSignature(args []model.Expression) (model.StaticFunct
 		}
 	}
 	if !ok {
-		return signature, hcl.Diagnostics{unknownFunction(token, tokenRange)}
+		suggestion := pkgSchema.closestFunctionToken(token)
+		diag := b.unknownFunction(token, suggestion, tokenRange)
+		return signature, hcl.Diagnostics{b.withFixes(diag, useTokenFix(suggestion, tokenRange)...)}
 	}
 
 	// Create args and result types for the schema.
+	signature.Parameters[1].Type, signature.ReturnType = b.functionTypes(fn)
+	if len(args) > 1 {
+		diagnostics = append(b.checkEnumValues(signature.Parameters[1].Type, args[1]), b.checkDynamicArgs(token, args[1])...)
+	}
+
+	return signature, diagnostics
+}
+
+// checkDynamicArgs warns if the args passed to the function with the given token have a dynamic type, as such args
+// cannot be checked against the function's inputs.
+func (b *binder) checkDynamicArgs(token string, args model.Expression) hcl.Diagnostics {
+	if model.ResolveOutputs(args.Type()) != model.DynamicType {
+		return nil
+	}
+	return hcl.Diagnostics{b.dynamicInvokeArgs(token, args)}
+}
+
+// functionTypes returns the type of the args passed to the given function and the type of its result, which is a
+// promise of the function's outputs.
+func (b *binder) functionTypes(fn *schema.Function) (model.Type, model.Type) {
+	var argsType, returnType model.Type
 	if fn.Inputs == nil {
-		signature.Parameters[1].Type = model.NewOptionalType(model.NewObjectType(map[string]model.Type{}))
+		argsType = model.NewOptionalType(model.NewObjectType(map[string]model.Type{}))
 	} else {
-		signature.Parameters[1].Type = b.schemaTypeToType(fn.Inputs)
+		argsType = b.types.ModelType(fn.Inputs)
 	}
 
 	if fn.Outputs == nil {
-		signature.ReturnType = model.NewObjectType(map[string]model.Type{})
+		returnType = model.NewObjectType(map[string]model.Type{})
 	} else {
-		signature.ReturnType = b.schemaTypeToType(fn.Outputs)
+		returnType = b.types.ModelType(fn.Outputs)
+	}
+	return argsType, model.NewPromiseType(returnType)
+}
+
+// intrinsicName returns the name of the intrinsic that calls the function with the given canonical token. Functions in
+// a package's index module are named `pkg_member` (e.g. `aws_getRegion`); other functions are named
+// `pkg_module_member` (e.g. `aws_ec2_getAmi`). A function has no intrinsic if its name would not be a valid identifier
+// or if its package or module name contains an underscore.
+func intrinsicName(token string) (string, bool) {
+	pkg, module, member, diags := DecomposeToken(token, hcl.Range{})
+	if diags.HasErrors() || strings.Contains(pkg, "_") || strings.Contains(module, "_") {
+		return "", false
+	}
+
+	name := pkg + "_" + member
+	if module != "" && module != "index" {
+		name = pkg + "_" + module + "_" + member
+	}
+	return name, hclsyntax.ValidIdentifier(name)
+}
+
+// intrinsicPackageName returns the name of the package whose function is called by the intrinsic with the given name,
+// if the name has the form of an intrinsic name.
+func intrinsicPackageName(name string) (string, bool) {
+	if i := strings.IndexByte(name, '_'); i > 0 {
+		return name[:i], true
+	}
+	return "", false
+}
+
+// defineIntrinsics defines the intrinsics that call the functions of the referenced packages in the given scope. Each
+// intrinsic accepts the same arguments as a call to invoke with the function's token, and calls to intrinsics are
+// bound as calls to invoke. See lowerIntrinsicCall.
+func (b *binder) defineIntrinsics(scope *model.Scope) {
+	for _, pkgSchema := range b.referencedPackages {
+		for token, fn := range pkgSchema.functions {
+			name, ok := intrinsicName(token)
+			if !ok {
+				continue
+			}
+			b.intrinsics[name] = token
+
+			token, fn := token, fn
+			scope.DefineFunction(name, model.NewFunction(model.GenericFunctionSignature(
+				func(args []model.Expression) (model.StaticFunctionSignature, hcl.Diagnostics) {
+					argsType, returnType := b.functionTypes(fn)
+					signature := model.StaticFunctionSignature{
+						Parameters: []model.Parameter{
+							{
+								Name: "args",
+								Type: argsType,
+							},
+							{
+								Name: "provider",
+								Type: model.NewOptionalType(model.StringType),
+							},
+						},
+						ReturnType: returnType,
+					}
+					var diagnostics hcl.Diagnostics
+					if len(args) > 0 {
+						diagnostics = append(b.checkEnumValues(signature.Parameters[0].Type, args[0]),
+							b.checkDynamicArgs(token, args[0])...)
+					}
+					return signature, diagnostics
+				})))
+		}
+	}
+}
+
+// lowerIntrinsicCall rewrites a call to an intrinsic into the equivalent call to invoke in place, so that the call is
+// indistinguishable from an invoke to code generators. Other expressions are returned unchanged.
+func (b *binder) lowerIntrinsicCall(x model.Expression) (model.Expression, hcl.Diagnostics) {
+	call, ok := x.(*model.FunctionCallExpression)
+	if !ok {
+		return x, nil
+	}
+	token, ok := b.intrinsics[call.Name]
+	if !ok {
+		return x, nil
+	}
+
+	tokenRange := call.Syntax.NameRange
+	tokenArg := &model.TemplateExpression{
+		Syntax: &hclsyntax.TemplateExpr{SrcRange: tokenRange},
+		Parts: []model.Expression{&model.LiteralValueExpression{
+			Syntax: &hclsyntax.LiteralValueExpr{Val: cty.StringVal(token), SrcRange: tokenRange},
+			Value:  cty.StringVal(token),
+		}},
 	}
-	signature.ReturnType = model.NewPromiseType(signature.ReturnType)
+	diags := tokenArg.Typecheck(true)
 
-	return signature, nil
+	call.Name, call.Args = Invoke, append([]model.Expression{tokenArg}, call.Args...)
+	call.Signature.Parameters = append([]model.Parameter{{Name: "token", Type: model.StringType}},
+		call.Signature.Parameters...)
+	// The printed form of the call is that of the equivalent invoke.
+	call.Tokens = nil
+	return call, diags
 }
@@ -0,0 +1,106 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint finds common mistakes in bound PCL programs, e.g. config variables that are never used or secrets that
+// are exported as plain outputs. Each kind of mistake is found by a Rule. The rules in DefaultRules are run by
+// `pulumi lint`; tools may define rules of their own with NewRule and run them alongside the defaults.
+//
+// Unlike the diagnostics reported by the binder, the problems found by rules do not prevent a program from being
+// converted or deployed, so they are always reported as warnings.
+package lint
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+)
+
+// Rule finds a kind of mistake in a program.
+type Rule interface {
+	// Name returns the name of the rule, e.g. "unused-config". Names are used to enable and disable rules.
+	Name() string
+	// Description returns a one-line description of the mistakes found by the rule.
+	Description() string
+	// Check returns a diagnostic for each mistake that the rule finds in the given program.
+	Check(program *hcl2.Program) hcl.Diagnostics
+}
+
+type rule struct {
+	name        string
+	description string
+	check       func(program *hcl2.Program) hcl.Diagnostics
+}
+
+func (r *rule) Name() string {
+	return r.name
+}
+
+func (r *rule) Description() string {
+	return r.description
+}
+
+func (r *rule) Check(program *hcl2.Program) hcl.Diagnostics {
+	return r.check(program)
+}
+
+// NewRule creates a rule with the given name and description that finds mistakes using the given function.
+func NewRule(name, description string, check func(program *hcl2.Program) hcl.Diagnostics) Rule {
+	return &rule{name: name, description: description, check: check}
+}
+
+// DefaultRules returns the rules that are run by `pulumi lint`, sorted by name.
+func DefaultRules() []Rule {
+	return []Rule{
+		MissingDependsOn(KnownOrderingHazards),
+		OutputReference(),
+		PlainSecretOutput(),
+		UnusedConfig(),
+	}
+}
+
+// Problem is a mistake found in a program by a rule.
+type Problem struct {
+	// The name of the rule that found the problem.
+	Rule string
+	// The description of the problem.
+	Diagnostic *hcl.Diagnostic
+}
+
+// Lint runs the given rules against a program and returns the problems they find, sorted by position. Problems at the
+// same position are sorted by the order of their rules. The severity of each problem's diagnostic is a warning.
+func Lint(program *hcl2.Program, rules []Rule) []Problem {
+	var problems []Problem
+	for _, r := range rules {
+		for _, d := range r.Check(program) {
+			d.Severity = hcl.DiagWarning
+			problems = append(problems, Problem{Rule: r.Name(), Diagnostic: d})
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].Diagnostic.Subject, problems[j].Diagnostic.Subject
+		switch {
+		case a == nil || b == nil:
+			return a != nil && b == nil
+		case a.Filename != b.Filename:
+			return a.Filename < b.Filename
+		case a.Start.Line != b.Start.Line:
+			return a.Start.Line < b.Start.Line
+		default:
+			return a.Start.Column < b.Start.Column
+		}
+	})
+	return problems
+}
@@ -0,0 +1,191 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
+)
+
+var testdataPath = filepath.Join("..", "..", "internal", "test", "testdata")
+
+func bindProgram(t *testing.T, source string) *hcl2.Program {
+	parser := syntax.NewParser()
+	err := parser.ParseFile(bytes.NewReader([]byte(source)), "main.pp")
+	if err != nil {
+		t.Fatalf("could not parse program: %v", err)
+	}
+	if parser.Diagnostics.HasErrors() {
+		t.Fatalf("failed to parse program: %v", parser.Diagnostics)
+	}
+
+	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.PluginHost(test.NewHost(testdataPath)))
+	if err != nil {
+		t.Fatalf("could not bind program: %v", err)
+	}
+	if diags.HasErrors() {
+		t.Fatalf("failed to bind program: %v", diags)
+	}
+	return program
+}
+
+// summaries returns the rule and summary of each problem.
+func summaries(problems []Problem) []string {
+	result := make([]string, len(problems))
+	for i, p := range problems {
+		result[i] = fmt.Sprintf("[%s] %s", p.Rule, p.Diagnostic.Summary)
+	}
+	return result
+}
+
+func TestUnusedConfig(t *testing.T) {
+	program := bindProgram(t, `config used string {}
+config unused string {}
+config usedByDefault string {}
+config withDefault string {
+	default = usedByDefault
+}
+config "aws:region" string {}
+
+resource bucket "aws:s3:Bucket" {
+	bucket = "${used}-${withDefault}"
+}
+`)
+
+	assert.Equal(t, []string{
+		"[unused-config] config variable 'unused' is not used by the program",
+	}, summaries(Lint(program, []Rule{UnusedConfig()})))
+}
+
+func TestOutputReference(t *testing.T) {
+	program := bindProgram(t, `resource bucket "aws:s3:Bucket" {}
+
+output bucketName {
+	value = bucket.bucket
+}
+
+resource object "aws:s3:BucketObject" {
+	bucket = bucketName
+	key = "index.html"
+}
+`)
+
+	assert.Equal(t, []string{
+		"[output-reference] 'object' refers to output 'bucketName', which is not registered until the program " +
+			"completes; refer to the output's value instead",
+	}, summaries(Lint(program, []Rule{OutputReference()})))
+}
+
+func TestPlainSecretOutput(t *testing.T) {
+	program := bindProgram(t, `resource dbCluster "aws:rds:Cluster" {
+	masterPassword = secret("foobar")
+}
+
+output password string {
+	value = dbCluster.masterPassword
+}
+
+output secretPassword {
+	value = dbCluster.masterPassword
+}
+`)
+
+	assert.Equal(t, []string{
+		"[plain-secret-output] output 'password' exports a secret value as a plain string; remove the output's " +
+			"type so that the value remains secret",
+	}, summaries(Lint(program, []Rule{PlainSecretOutput()})))
+}
+
+func TestMissingDependsOn(t *testing.T) {
+	program := bindProgram(t, `resource role "aws:iam:Role" {
+	assumeRolePolicy = "{}"
+}
+
+resource policyAttachment "aws:iam:RolePolicyAttachment" {
+	role = role.id
+	policyArn = "arn:aws:iam::aws:policy/AmazonEKSClusterPolicy"
+}
+
+resource cluster "aws:eks:Cluster" {
+	roleArn = role.arn
+	vpcConfig = {
+		subnetIds = ["subnet-1"]
+	}
+}
+
+resource clusterWithDependsOn "aws:eks:Cluster" {
+	roleArn = role.arn
+	vpcConfig = {
+		subnetIds = ["subnet-1"]
+	}
+
+	options {
+		dependsOn = [policyAttachment]
+	}
+}
+
+resource otherRole "aws:iam:Role" {
+	assumeRolePolicy = "{}"
+}
+
+resource clusterWithOtherRole "aws:eks:Cluster" {
+	roleArn = otherRole.arn
+	vpcConfig = {
+		subnetIds = ["subnet-1"]
+	}
+}
+`)
+
+	assert.Equal(t, []string{
+		"[missing-depends-on] resource 'cluster' does not depend on 'policyAttachment': an EKS cluster cannot be " +
+			"created until the policies of its role are attached; add 'policyAttachment' to the resource's dependsOn " +
+			"option",
+	}, summaries(Lint(program, []Rule{MissingDependsOn(KnownOrderingHazards)})))
+}
+
+func TestLint(t *testing.T) {
+	program := bindProgram(t, `config unused string {}
+
+resource bucket "aws:s3:Bucket" {}
+
+output bucketName {
+	value = bucket.bucket
+}
+
+output bucketNameAgain {
+	value = bucketName
+}
+`)
+
+	// Rules may be defined by the program's users, and their problems are always warnings.
+	custom := NewRule("no-outputs", "outputs", func(program *hcl2.Program) hcl.Diagnostics {
+		var diagnostics hcl.Diagnostics
+		for _, n := range program.Nodes {
+			if ov, ok := n.(*hcl2.OutputVariable); ok {
+				d := warningf(defRange(ov), "output '%s'", ov.Name())
+				d.Severity = hcl.DiagError
+				diagnostics = append(diagnostics, d)
+			}
+		}
+		return diagnostics
+	})
+
+	problems := Lint(program, append(DefaultRules(), custom))
+	assert.Equal(t, []string{
+		"[unused-config] config variable 'unused' is not used by the program",
+		"[no-outputs] output 'bucketName'",
+		"[no-outputs] output 'bucketNameAgain'",
+		"[output-reference] 'bucketNameAgain' refers to output 'bucketName', which is not registered until the " +
+			"program completes; refer to the output's value instead",
+	}, summaries(problems))
+	for _, p := range problems {
+		assert.Equal(t, hcl.DiagWarning, p.Diagnostic.Severity)
+	}
+}
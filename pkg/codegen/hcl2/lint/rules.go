@@ -0,0 +1,273 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+func warningf(subject hcl.Range, f string, args ...interface{}) *hcl.Diagnostic {
+	message := fmt.Sprintf(f, args...)
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  message,
+		Detail:   message,
+		Subject:  &subject,
+	}
+}
+
+// defRange returns the range of the definition of the given node, e.g. the type and labels of a block.
+func defRange(n hcl2.Node) hcl.Range {
+	switch syntax := n.SyntaxNode().(type) {
+	case *hclsyntax.Block:
+		return syntax.DefRange()
+	case *hclsyntax.Attribute:
+		return syntax.NameRange
+	default:
+		return syntax.Range()
+	}
+}
+
+// reference is a reference to a node from an expression within another node.
+type reference struct {
+	node       hcl2.Node
+	expression *model.ScopeTraversalExpression
+}
+
+// references returns the references to other nodes from the expressions of the given node, in source order.
+func references(n hcl2.Node) []reference {
+	var refs []reference
+	diags := n.VisitExpressions(nil, func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		if traversal, ok := x.(*model.ScopeTraversalExpression); ok && len(traversal.Parts) != 0 {
+			if target, ok := traversal.Parts[0].(hcl2.Node); ok && target != n {
+				refs = append(refs, reference{node: target, expression: traversal})
+			}
+		}
+		return x, nil
+	})
+	contract.Assert(len(diags) == 0)
+	return refs
+}
+
+// UnusedConfig returns a rule that finds config variables that are not used by the program. Config variables in a
+// provider's namespace (e.g. `aws:region`) are read by the provider, so they are never reported.
+func UnusedConfig() Rule {
+	return NewRule("unused-config", "config variables that are not used by the program", checkUnusedConfig)
+}
+
+func checkUnusedConfig(program *hcl2.Program) hcl.Diagnostics {
+	used := codegen.Set{}
+	for _, n := range program.Nodes {
+		for _, ref := range references(n) {
+			used.Add(ref.node)
+		}
+	}
+
+	var diagnostics hcl.Diagnostics
+	for _, n := range program.Nodes {
+		if cv, ok := n.(*hcl2.ConfigVariable); ok && cv.Namespace() == "" && !used.Has(cv) {
+			diagnostics = append(diagnostics, warningf(defRange(cv),
+				"config variable '%s' is not used by the program", cv.Name()))
+		}
+	}
+	return diagnostics
+}
+
+// OutputReference returns a rule that finds references to outputs. Stack outputs are not registered until the
+// program completes, so the generated programs cannot read them back; other nodes should refer to the values of the
+// outputs instead.
+func OutputReference() Rule {
+	return NewRule("output-reference", "references to outputs, which are not registered until the program completes",
+		checkOutputReference)
+}
+
+func checkOutputReference(program *hcl2.Program) hcl.Diagnostics {
+	var diagnostics hcl.Diagnostics
+	for _, n := range program.Nodes {
+		for _, ref := range references(n) {
+			if ov, ok := ref.node.(*hcl2.OutputVariable); ok {
+				diagnostics = append(diagnostics, warningf(ref.expression.SyntaxNode().Range(),
+					"'%s' refers to output '%s', which is not registered until the program completes; refer to the "+
+						"output's value instead", n.Name(), ov.Name()))
+			}
+		}
+	}
+	return diagnostics
+}
+
+// PlainSecretOutput returns a rule that finds outputs that export secret values as plain values. An output exports a
+// secret as a plain value if it declares a type that is not secret for a secret value, or if it exports the value of a
+// secret provider configuration key without wrapping it in a call to `secret`.
+func PlainSecretOutput() Rule {
+	return NewRule("plain-secret-output", "secret values that are exported as plain outputs", checkPlainSecretOutput)
+}
+
+func checkPlainSecretOutput(program *hcl2.Program) hcl.Diagnostics {
+	var diagnostics hcl.Diagnostics
+	for _, n := range program.Nodes {
+		ov, ok := n.(*hcl2.OutputVariable)
+		if !ok || ov.Value == nil || model.IsSecretType(ov.Value.Type()) {
+			continue
+		}
+
+		for _, ref := range references(ov) {
+			if cv, ok := ref.node.(*hcl2.ConfigVariable); ok && cv.ProviderConfig != nil && cv.ProviderConfig.Secret {
+				diagnostics = append(diagnostics, warningf(ref.expression.SyntaxNode().Range(),
+					"output '%s' exports the secret configuration value '%s' in plain text; wrap the value in a call "+
+						"to secret", ov.Name(), cv.Name()))
+			}
+		}
+	}
+	for _, n := range program.Nodes {
+		ov, ok := n.(*hcl2.OutputVariable)
+		if !ok || ov.Value == nil || ov.Type() == model.DynamicType || model.IsSecretType(ov.Type()) {
+			continue
+		}
+		if model.IsSecretType(ov.Value.Type()) {
+			diagnostics = append(diagnostics, warningf(defRange(ov),
+				"output '%s' exports a secret value as a plain %v; remove the output's type so that the value remains "+
+					"secret", ov.Name(), ov.Type()))
+		}
+	}
+	return diagnostics
+}
+
+// OrderingHazard describes a pair of resource types whose resources must be created in an order that their references
+// do not imply. A resource of the first type that shares a referenced resource with a resource of the second type must
+// depend on the latter, e.g. an EKS cluster must depend on the attachments of the policies to its role.
+type OrderingHazard struct {
+	// The type token of the resource that must be created last.
+	Resource string
+	// The type token of the resource that must be created first.
+	DependsOn string
+	// Why the resources must be created in this order.
+	Reason string
+}
+
+// KnownOrderingHazards lists the ordering hazards that are checked by the missing-depends-on rule in DefaultRules.
+var KnownOrderingHazards = []OrderingHazard{
+	{
+		Resource:  "aws:ecs/service:Service",
+		DependsOn: "aws:lb/listener:Listener",
+		Reason:    "an ECS service cannot register its tasks with a target group that has no listener",
+	},
+	{
+		Resource:  "aws:ecs/service:Service",
+		DependsOn: "aws:alb/listener:Listener",
+		Reason:    "an ECS service cannot register its tasks with a target group that has no listener",
+	},
+	{
+		Resource:  "aws:ecs/service:Service",
+		DependsOn: "aws:elasticloadbalancingv2/listener:Listener",
+		Reason:    "an ECS service cannot register its tasks with a target group that has no listener",
+	},
+	{
+		Resource:  "aws:eks/cluster:Cluster",
+		DependsOn: "aws:iam/rolePolicyAttachment:RolePolicyAttachment",
+		Reason:    "an EKS cluster cannot be created until the policies of its role are attached",
+	},
+	{
+		Resource:  "aws:eks/nodeGroup:NodeGroup",
+		DependsOn: "aws:iam/rolePolicyAttachment:RolePolicyAttachment",
+		Reason:    "an EKS node group cannot be created until the policies of its role are attached",
+	},
+	{
+		Resource:  "aws:lambda/function:Function",
+		DependsOn: "aws:iam/rolePolicyAttachment:RolePolicyAttachment",
+		Reason:    "a Lambda function may fail when invoked before the policies of its role are attached",
+	},
+}
+
+// MissingDependsOn returns a rule that finds resources that must depend on other resources because of the given
+// ordering hazards but do not, either through references or through their dependsOn options.
+func MissingDependsOn(hazards []OrderingHazard) Rule {
+	return NewRule("missing-depends-on", "resources that are missing dependencies on resources that must be created "+
+		"first", func(program *hcl2.Program) hcl.Diagnostics {
+		return checkMissingDependsOn(program, hazards)
+	})
+}
+
+func checkMissingDependsOn(program *hcl2.Program, hazards []OrderingHazard) hcl.Diagnostics {
+	// Collect the resources of each type and the nodes referenced by each node.
+	resources := map[string][]*hcl2.Resource{}
+	dependencies := map[hcl2.Node][]hcl2.Node{}
+	for _, n := range program.Nodes {
+		if r, ok := hcl2.NodeResource(n); ok {
+			token := r.Token
+			if s, ok := program.ResourceSchema(r.Token); ok {
+				token = s.Token
+			}
+			resources[token] = append(resources[token], r)
+		}
+		for _, ref := range references(n) {
+			dependencies[n] = append(dependencies[n], ref.node)
+		}
+	}
+
+	dependsOn := func(from, to hcl2.Node) bool {
+		visited := codegen.Set{}
+		var visit func(n hcl2.Node) bool
+		visit = func(n hcl2.Node) bool {
+			if n == to {
+				return true
+			}
+			if visited.Has(n) {
+				return false
+			}
+			visited.Add(n)
+			for _, d := range dependencies[n] {
+				if visit(d) {
+					return true
+				}
+			}
+			return false
+		}
+		return visit(from)
+	}
+	sharesResource := func(r, d *hcl2.Resource) bool {
+		for _, rd := range dependencies[r] {
+			if _, ok := hcl2.NodeResource(rd); !ok {
+				continue
+			}
+			for _, dd := range dependencies[d] {
+				if rd == dd {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var diagnostics hcl.Diagnostics
+	for _, hazard := range hazards {
+		for _, r := range resources[hazard.Resource] {
+			for _, d := range resources[hazard.DependsOn] {
+				if sharesResource(r, d) && !dependsOn(r, d) && !dependsOn(d, r) {
+					diagnostics = append(diagnostics, warningf(defRange(r),
+						"resource '%s' does not depend on '%s': %s; add '%s' to the resource's dependsOn option",
+						r.Name(), d.Name(), hazard.Reason, d.Name()))
+				}
+			}
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,127 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+)
+
+// CompletionKind is the kind of item suggested by a completion.
+type CompletionKind int
+
+const (
+	// ResourceCompletion suggests the token of a resource type.
+	ResourceCompletion CompletionKind = iota
+	// FunctionCompletion suggests the token of a function.
+	FunctionCompletion
+)
+
+// Completion is an item suggested for the text at a position in a source file.
+type Completion struct {
+	// Label is the text of the item.
+	Label string
+	// Kind is the kind of the item.
+	Kind CompletionKind
+	// Description is the documentation of the item from its package's schema, if any.
+	Description string
+	// Range is the range of the text that the item replaces.
+	Range hcl.Range
+}
+
+// Complete returns the items suggested for the text at the given byte offset in the named source file, sorted by
+// label. Resource type tokens are suggested within the type label of a resource block, and function tokens are
+// suggested within the token argument of an invoke. The tokens are those of the packages in the workspace's package
+// cache whose prefix matches the text between the start of the token and the offset.
+//
+// Completion works on the source file's syntax rather than its bound program, so it is available while the token is
+// incomplete. Servers may preload the package cache so that tokens are suggested before a program refers to them.
+func (w *Workspace) Complete(filename string, offset int) []Completion {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	file, ok := w.file(filename)
+	if !ok {
+		return nil
+	}
+
+	var kind CompletionKind
+	var tokenRange hcl.Range
+	found := false
+	diags := hclsyntax.VisitAll(file.Body, func(n hclsyntax.Node) hcl.Diagnostics {
+		switch n := n.(type) {
+		case *hclsyntax.Block:
+			if n.Type == "resource" && len(n.LabelRanges) >= 2 && insideQuotes(n.LabelRanges[1], offset) {
+				kind, tokenRange, found = ResourceCompletion, n.LabelRanges[1], true
+			}
+		case *hclsyntax.FunctionCallExpr:
+			if n.Name == hcl2.Invoke && len(n.Args) != 0 {
+				if template, ok := n.Args[0].(*hclsyntax.TemplateExpr); ok && insideQuotes(template.SrcRange, offset) {
+					kind, tokenRange, found = FunctionCompletion, template.SrcRange, true
+				}
+			}
+		}
+		return nil
+	})
+	if diags.HasErrors() || !found {
+		return nil
+	}
+
+	// Replace the text between the quotes.
+	start, end := tokenRange.Start, tokenRange.End
+	start.Byte, start.Column = start.Byte+1, start.Column+1
+	end.Byte, end.Column = end.Byte-1, end.Column-1
+	replace := hcl.Range{Filename: tokenRange.Filename, Start: start, End: end}
+	prefix := strings.ToLower(string(file.Bytes[start.Byte:offset]))
+
+	var completions []Completion
+	seen := map[string]bool{}
+	add := func(token, description string) {
+		if !seen[token] && strings.HasPrefix(strings.ToLower(token), prefix) {
+			seen[token] = true
+			completions = append(completions, Completion{
+				Label:       token,
+				Kind:        kind,
+				Description: description,
+				Range:       replace,
+			})
+		}
+	}
+	for _, pkg := range w.cache.Packages() {
+		switch kind {
+		case ResourceCompletion:
+			for _, r := range pkg.Resources {
+				add(r.Token, r.Comment)
+			}
+		case FunctionCompletion:
+			for _, f := range pkg.Functions {
+				add(f.Token, f.Comment)
+			}
+		}
+	}
+	sort.Slice(completions, func(i, j int) bool {
+		return completions[i].Label < completions[j].Label
+	})
+	return completions
+}
+
+// insideQuotes returns true if the given range of a quoted string contains the given offset between its quotes.
+func insideQuotes(rng hcl.Range, offset int) bool {
+	return offset > rng.Start.Byte && offset < rng.End.Byte
+}
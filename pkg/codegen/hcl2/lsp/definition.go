@@ -0,0 +1,84 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// Definition is the schema definition of a resource type or function that is referred to by a token in a source file.
+// Servers may use the package and token to locate the definition in the package's schema or its documentation.
+type Definition struct {
+	// Range is the range of the token in the source file.
+	Range hcl.Range
+	// Package is the package that defines the resource type or function.
+	Package *schema.Package
+	// Resource is the schema of the resource type, if the token refers to a resource type.
+	Resource *schema.Resource
+	// Function is the schema of the function, if the token refers to a function.
+	Function *schema.Function
+}
+
+// Definition returns the schema definition of the resource type or function whose token is at the given byte offset
+// in the named source file. Tokens are the type tokens of resources and the function tokens of invokes.
+func (w *Workspace) Definition(filename string, offset int) (*Definition, bool) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.program == nil {
+		return nil, false
+	}
+	n, ok := nodeAt(w.program, filename, offset)
+	if !ok {
+		return nil, false
+	}
+
+	if r, ok := hcl2.NodeResource(n); ok {
+		if tokenRange, ok := resourceTokenRange(r); ok && containsOffset(tokenRange, filename, offset) {
+			res, ok := w.program.ResourceSchema(r.Token)
+			if !ok {
+				return nil, false
+			}
+			return &Definition{Range: tokenRange, Package: w.tokenPackage(r.Token), Resource: res}, true
+		}
+	}
+
+	path, ok := expressionAt(n, filename, offset)
+	if !ok {
+		return nil, false
+	}
+	token, arg, ok := invokeToken(path)
+	if !ok {
+		return nil, false
+	}
+	fn, ok := w.program.FunctionSchema(token)
+	if !ok {
+		return nil, false
+	}
+	return &Definition{Range: arg.SyntaxNode().Range(), Package: w.tokenPackage(token), Function: fn}, true
+}
+
+// tokenPackage returns the program's package for the given token.
+func (w *Workspace) tokenPackage(token string) *schema.Package {
+	name, _, _, _ := hcl2.DecomposeToken(token, hcl.Range{})
+	for _, pkg := range w.program.Packages() {
+		if pkg.Name == name {
+			return pkg
+		}
+	}
+	return nil
+}
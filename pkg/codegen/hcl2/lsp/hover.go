@@ -0,0 +1,184 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Hover describes the item at a position in a source file.
+type Hover struct {
+	// Range is the range of the item.
+	Range hcl.Range
+	// Type is the type of the item, if it has one.
+	Type model.Type
+	// Description is the documentation of the item from its package's schema, if any.
+	Description string
+}
+
+// Hover describes the item at the given byte offset in the named source file. The item may be a resource's type
+// token, the name of one of a resource's input attributes, or an expression. Descriptions are taken from the schemas
+// of the program's packages.
+func (w *Workspace) Hover(filename string, offset int) (*Hover, bool) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.program == nil {
+		return nil, false
+	}
+	n, ok := nodeAt(w.program, filename, offset)
+	if !ok {
+		return nil, false
+	}
+
+	if r, ok := hcl2.NodeResource(n); ok {
+		if tokenRange, ok := resourceTokenRange(r); ok && containsOffset(tokenRange, filename, offset) {
+			hover := &Hover{Range: tokenRange}
+			if res, ok := w.program.ResourceSchema(r.Token); ok {
+				hover.Description = res.Comment
+			}
+			return hover, true
+		}
+
+		for _, attr := range r.Inputs {
+			if attr.Syntax == nil || !containsOffset(attr.Syntax.NameRange, filename, offset) {
+				continue
+			}
+			hover := &Hover{Range: attr.Syntax.NameRange}
+			if t, diags := r.InputType.Traverse(hcl.TraverseAttr{Name: attr.Name}); !diags.HasErrors() {
+				hover.Type = t.(model.Type)
+			}
+			if prop, ok := w.program.TypeMapper().SchemaProperty(r.InputType, attr.Name); ok {
+				hover.Description = prop.Comment
+			}
+			return hover, true
+		}
+	}
+
+	path, ok := expressionAt(n, filename, offset)
+	if !ok {
+		return nil, false
+	}
+	expr := path[len(path)-1]
+	if token, arg, ok := invokeToken(path); ok {
+		hover := &Hover{Range: arg.SyntaxNode().Range(), Type: arg.Type()}
+		if fn, ok := w.program.FunctionSchema(token); ok {
+			hover.Description = fn.Comment
+		}
+		return hover, true
+	}
+	hover := &Hover{Range: expr.SyntaxNode().Range(), Type: expr.Type()}
+	if traversal, ok := expr.(*model.ScopeTraversalExpression); ok {
+		hover.Description = w.traversalDescription(traversal)
+	}
+	return hover, true
+}
+
+// traversalDescription returns the description of the property accessed by the last step of a traversal, if the
+// property is described by a schema.
+func (w *Workspace) traversalDescription(x *model.ScopeTraversalExpression) string {
+	last := len(x.Traversal) - 1
+	attr, ok := x.Traversal[last].(hcl.TraverseAttr)
+	if !ok || last == 0 || last >= len(x.Parts) {
+		return ""
+	}
+
+	var receiverType model.Type
+	switch receiver := x.Parts[last-1].(type) {
+	case hcl2.Node:
+		receiverType = receiver.Type()
+	case model.Type:
+		receiverType = receiver
+	default:
+		return ""
+	}
+	if prop, ok := w.program.TypeMapper().SchemaProperty(receiverType, attr.Name); ok {
+		return prop.Comment
+	}
+	return ""
+}
+
+// nodeAt returns the top-level node of the program that contains the given offset in the named file.
+func nodeAt(program *hcl2.Program, filename string, offset int) (hcl2.Node, bool) {
+	for _, n := range program.Nodes {
+		if containsOffset(n.SyntaxNode().Range(), filename, offset) {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// expressionAt returns the expressions of the given node that contain the given offset in the named file, ordered from
+// outermost to innermost.
+func expressionAt(n hcl2.Node, filename string, offset int) ([]model.Expression, bool) {
+	var path, stack []model.Expression
+	pre := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		stack = append(stack, x)
+		if containsOffset(x.SyntaxNode().Range(), filename, offset) && len(stack) > len(path) {
+			path = append([]model.Expression(nil), stack...)
+		}
+		return x, nil
+	}
+	post := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		stack = stack[:len(stack)-1]
+		return x, nil
+	}
+	n.VisitExpressions(pre, post)
+	return path, len(path) != 0
+}
+
+// invokeToken returns the token of the function called by an invoke if the path of expressions passes through the
+// token argument of the call. The token argument is returned along with the token.
+func invokeToken(path []model.Expression) (string, model.Expression, bool) {
+	for i := len(path) - 1; i > 0; i-- {
+		call, ok := path[i-1].(*model.FunctionCallExpression)
+		if !ok || call.Name != hcl2.Invoke || len(call.Args) == 0 || call.Args[0] != path[i] {
+			continue
+		}
+
+		var lit *model.LiteralValueExpression
+		switch x := path[i].(type) {
+		case *model.LiteralValueExpression:
+			lit = x
+		case *model.TemplateExpression:
+			if len(x.Parts) == 1 {
+				lit, _ = x.Parts[0].(*model.LiteralValueExpression)
+			}
+		}
+		if lit == nil || lit.Value.Type() != cty.String {
+			return "", nil, false
+		}
+		return lit.Value.AsString(), path[i], true
+	}
+	return "", nil, false
+}
+
+// resourceTokenRange returns the range of the label that holds a resource's type token.
+func resourceTokenRange(r *hcl2.Resource) (hcl.Range, bool) {
+	block, ok := r.SyntaxNode().(*hclsyntax.Block)
+	if !ok || len(block.LabelRanges) < 2 {
+		return hcl.Range{}, false
+	}
+	return block.LabelRanges[1], true
+}
+
+// containsOffset returns true if the given range is in the named file and contains the given offset.
+func containsOffset(rng hcl.Range, filename string, offset int) bool {
+	return rng.Filename == filename && rng.ContainsOffset(offset)
+}
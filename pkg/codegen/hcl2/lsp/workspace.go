@@ -0,0 +1,146 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lsp implements the editor features of a language server for PCL programs on top of the HCL2 binder. A
+// server binary that speaks the Language Server Protocol can keep a Workspace for each program that is open in the
+// editor and translate its requests into calls to the Workspace's methods.
+//
+// Positions within source files are given as byte offsets. Servers convert the line and character positions used by
+// the protocol to byte offsets using the text of the file.
+package lsp
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+)
+
+// Workspace holds the source files of a PCL program and the result of binding them. A Workspace is safe for
+// concurrent use.
+type Workspace struct {
+	m sync.Mutex
+
+	cache   *hcl2.PackageCache
+	options []hcl2.BindOption
+
+	files       map[string]*document
+	program     *hcl2.Program
+	diagnostics hcl.Diagnostics
+}
+
+// document is a parsed source file.
+type document struct {
+	file        *syntax.File
+	diagnostics hcl.Diagnostics
+}
+
+// NewWorkspace creates a new, empty workspace that binds its program using the given options. The package schemas
+// loaded by the binder are cached in the given cache, which is also the source of the tokens offered by Complete. If
+// the cache is nil, the workspace creates its own.
+func NewWorkspace(cache *hcl2.PackageCache, options ...hcl2.BindOption) *Workspace {
+	if cache == nil {
+		cache = hcl2.NewPackageCache()
+	}
+	return &Workspace{
+		cache:   cache,
+		options: append(append([]hcl2.BindOption{}, options...), hcl2.Cache(cache)),
+		files:   map[string]*document{},
+	}
+}
+
+// SetFile sets the text of the named source file and rebinds the workspace's program. Binding is incremental: only
+// the named file is parsed, and the package schemas loaded by earlier binds are reused. An error is returned if the
+// program could not be bound, e.g. because a package's schema could not be loaded; problems with the program itself
+// are reported by Diagnostics.
+func (w *Workspace) SetFile(name string, text []byte) error {
+	parser := syntax.NewParser()
+	if err := parser.ParseFile(bytes.NewReader(text), name); err != nil {
+		return err
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	w.files[name] = &document{file: parser.Files[0], diagnostics: parser.Diagnostics}
+	return w.bind()
+}
+
+// RemoveFile removes the named source file from the workspace and rebinds the workspace's program.
+func (w *Workspace) RemoveFile(name string) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	delete(w.files, name)
+	return w.bind()
+}
+
+// Program returns the workspace's bound program, or nil if the program could not be bound.
+func (w *Workspace) Program() *hcl2.Program {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	return w.program
+}
+
+// Diagnostics returns the syntax errors in the workspace's source files followed by the diagnostics reported when its
+// program was bound.
+func (w *Workspace) Diagnostics() hcl.Diagnostics {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	var diagnostics hcl.Diagnostics
+	for _, name := range w.fileNames() {
+		diagnostics = append(diagnostics, w.files[name].diagnostics...)
+	}
+	return append(diagnostics, w.diagnostics...)
+}
+
+// fileNames returns the sorted names of the workspace's source files.
+func (w *Workspace) fileNames() []string {
+	names := make([]string, 0, len(w.files))
+	for name := range w.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bind binds the workspace's source files.
+func (w *Workspace) bind() error {
+	files := make([]*syntax.File, 0, len(w.files))
+	for _, name := range w.fileNames() {
+		files = append(files, w.files[name].file)
+	}
+
+	program, diagnostics, err := hcl2.BindProgram(files, w.options...)
+	if err != nil {
+		w.program, w.diagnostics = nil, nil
+		return err
+	}
+	w.program, w.diagnostics = program, diagnostics
+	return nil
+}
+
+// file returns the parsed source file with the given name, if any.
+func (w *Workspace) file(name string) (*syntax.File, bool) {
+	doc, ok := w.files[name]
+	if !ok {
+		return nil, false
+	}
+	return doc.file, true
+}
@@ -0,0 +1,138 @@
+package lsp
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
+)
+
+var testdataPath = filepath.Join("..", "..", "internal", "test", "testdata")
+
+const program = `resource bucket "aws:s3:Bucket" {
+	acl = "private"
+}
+
+output region {
+	value = invoke("aws:index:getRegion", {}).name
+}
+
+output arn {
+	value = bucket.arn
+}
+`
+
+// offsetOf returns the byte offset of the first occurrence of the given text in the program, plus delta.
+func offsetOf(t *testing.T, text string, delta int) int {
+	i := strings.Index(program, text)
+	if i == -1 {
+		t.Fatalf("%q not found", text)
+	}
+	return i + delta
+}
+
+func newTestWorkspace(t *testing.T) *Workspace {
+	w := NewWorkspace(nil, hcl2.PluginHost(test.NewHost(testdataPath)))
+	assert.NoError(t, w.SetFile("main.pp", []byte(program)))
+	assert.Empty(t, w.Diagnostics())
+	return w
+}
+
+func TestHover(t *testing.T) {
+	w := newTestWorkspace(t)
+	p := w.Program()
+
+	bucket, ok := p.ResourceSchema("aws:s3/bucket:Bucket")
+	assert.True(t, ok)
+	region, ok := p.FunctionSchema("aws:index/getRegion:getRegion")
+	assert.True(t, ok)
+
+	// Resource type tokens are described by their resource's schema.
+	hover, ok := w.Hover("main.pp", offsetOf(t, "aws:s3:Bucket", 1))
+	assert.True(t, ok)
+	assert.Equal(t, bucket.Comment, hover.Description)
+	assert.Equal(t, `"aws:s3:Bucket"`, program[hover.Range.Start.Byte:hover.Range.End.Byte])
+
+	// Input attributes are described by their property's schema.
+	hover, ok = w.Hover("main.pp", offsetOf(t, "acl", 1))
+	assert.True(t, ok)
+	assert.True(t, hover.Type.AssignableFrom(model.StringType))
+	assert.NotEmpty(t, hover.Description)
+
+	// Function tokens are described by their function's schema.
+	hover, ok = w.Hover("main.pp", offsetOf(t, "getRegion", 0))
+	assert.True(t, ok)
+	assert.Equal(t, region.Comment, hover.Description)
+
+	// Expressions have types, and property accesses are described by their property's schema.
+	hover, ok = w.Hover("main.pp", offsetOf(t, "bucket.arn", 8))
+	assert.True(t, ok)
+	assert.Equal(t, model.NewOutputType(model.StringType), hover.Type)
+	assert.Equal(t, "bucket.arn", program[hover.Range.Start.Byte:hover.Range.End.Byte])
+	assert.NotEmpty(t, hover.Description)
+
+	_, ok = w.Hover("main.pp", offsetOf(t, "\n\noutput region", 1))
+	assert.False(t, ok)
+	_, ok = w.Hover("other.pp", 0)
+	assert.False(t, ok)
+}
+
+func TestDefinition(t *testing.T) {
+	w := newTestWorkspace(t)
+
+	def, ok := w.Definition("main.pp", offsetOf(t, "aws:s3:Bucket", 1))
+	assert.True(t, ok)
+	assert.Equal(t, "aws", def.Package.Name)
+	assert.Equal(t, "aws:s3/bucket:Bucket", def.Resource.Token)
+	assert.Nil(t, def.Function)
+
+	def, ok = w.Definition("main.pp", offsetOf(t, "getRegion", 0))
+	assert.True(t, ok)
+	assert.Equal(t, "aws", def.Package.Name)
+	assert.Equal(t, "aws:index/getRegion:getRegion", def.Function.Token)
+	assert.Nil(t, def.Resource)
+
+	_, ok = w.Definition("main.pp", offsetOf(t, "acl", 0))
+	assert.False(t, ok)
+}
+
+func TestComplete(t *testing.T) {
+	w := newTestWorkspace(t)
+
+	// Completion works on incomplete tokens in files that do not bind.
+	const text = "resource object \"aws:s3/bucketP\" {\n}\n\noutput ami {\n\tvalue = invoke(\"aws:index/getAmi\", {})\n}\n"
+	assert.NoError(t, w.SetFile("object.pp", []byte(text)))
+	assert.NotEmpty(t, w.Diagnostics())
+
+	labels := func(completions []Completion) []string {
+		var labels []string
+		for _, c := range completions {
+			labels = append(labels, c.Label)
+		}
+		return labels
+	}
+
+	completions := w.Complete("object.pp", strings.Index(text, "P\"")+1)
+	assert.Equal(t, []string{
+		"aws:s3/bucketPolicy:BucketPolicy",
+		"aws:s3/bucketPublicAccessBlock:BucketPublicAccessBlock",
+	}, labels(completions))
+	assert.Equal(t, ResourceCompletion, completions[0].Kind)
+	assert.Equal(t, "aws:s3/bucketP", text[completions[0].Range.Start.Byte:completions[0].Range.End.Byte])
+
+	completions = w.Complete("object.pp", strings.Index(text, "Ami\"")+3)
+	assert.Equal(t, []string{"aws:index/getAmi:getAmi", "aws:index/getAmiIds:getAmiIds"}, labels(completions))
+	assert.Equal(t, FunctionCompletion, completions[0].Kind)
+
+	assert.Empty(t, w.Complete("object.pp", strings.Index(text, "object")))
+
+	// Removing the file rebinds the program without it.
+	assert.NoError(t, w.RemoveFile("object.pp"))
+	assert.Empty(t, w.Diagnostics())
+	assert.Empty(t, w.Complete("object.pp", strings.Index(text, "P\"")+1))
+}
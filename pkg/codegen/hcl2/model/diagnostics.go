@@ -34,9 +34,11 @@ func diagf(severity hcl.DiagnosticSeverity, subject hcl.Range, f string, args ..
 	}
 }
 
+// ExprNotConvertible returns a diagnostic that describes why the given expression cannot be assigned to a location of
+// the given type. See TypePrinter.ExprNotConvertible for details.
 func ExprNotConvertible(destType Type, expr Expression) *hcl.Diagnostic {
-	return errorf(expr.SyntaxNode().Range(), "cannot assign expression of type %v to location of type %v", expr.Type(),
-		destType)
+	var printer TypePrinter
+	return printer.ExprNotConvertible(destType, expr)
 }
 
 func objectKeysMustBeStrings(expr Expression) *hcl.Diagnostic {
@@ -0,0 +1,232 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// FoldConstants replaces the constant subexpressions of an expression with their values. The following expressions
+// are folded:
+//
+// - unary and binary operations whose operands are literal values, e.g. `1 + 2` or `!true`
+// - conditional expressions whose conditions are literal values
+// - literal parts of template expressions, e.g. `"${"foo"}-${1}-${x}"` becomes `"foo-1-${x}"`
+// - calls to `element` whose arguments are a tuple and a literal index
+//
+// Expressions are only folded if the types of their operands are known statically. Expressions whose evaluation fails,
+// e.g. division by zero, are left as-is.
+func FoldConstants(x Expression) (Expression, hcl.Diagnostics) {
+	return VisitExpression(x, IdentityVisitor, FoldConstant)
+}
+
+// FoldConstant folds a single expression whose operands have already been folded. It is intended for use as a
+// post-order visitor; FoldConstants folds an entire expression tree.
+func FoldConstant(x Expression) (Expression, hcl.Diagnostics) {
+	switch x := x.(type) {
+	case *BinaryOpExpression:
+		if isConstant(x.LeftOperand) && isConstant(x.RightOperand) {
+			return foldValue(x), nil
+		}
+	case *ConditionalExpression:
+		if isConstant(x.Condition) {
+			result := x.FalseResult
+			if condition, _ := constantValue(x.Condition); condition.True() {
+				result = x.TrueResult
+			}
+			return replaceExpression(x, result), nil
+		}
+	case *FunctionCallExpression:
+		if x.Name == "element" && len(x.Args) == 2 {
+			return foldElement(x), nil
+		}
+	case *TemplateExpression:
+		return foldTemplate(x), nil
+	case *UnaryOpExpression:
+		if isConstant(x.Operand) {
+			return foldValue(x), nil
+		}
+	}
+	return x, nil
+}
+
+// constantValue returns the value of the given expression if the expression is a literal value of a primitive type.
+// Templates that consist of a single literal string are also constant.
+func constantValue(x Expression) (cty.Value, bool) {
+	if template, ok := x.(*TemplateExpression); ok && len(template.Parts) == 1 {
+		x = template.Parts[0]
+	}
+
+	lit, ok := x.(*LiteralValueExpression)
+	if !ok || lit.Value.IsNull() || !lit.Value.IsKnown() {
+		return cty.NilVal, false
+	}
+	switch lit.Type() {
+	case BoolType, IntType, NumberType, StringType:
+		return lit.Value, true
+	default:
+		return cty.NilVal, false
+	}
+}
+
+// isConstant returns true if the given expression is a constant.
+func isConstant(x Expression) bool {
+	_, ok := constantValue(x)
+	return ok
+}
+
+// newLiteral creates a typechecked literal value expression for the given value.
+func newLiteral(value cty.Value) *LiteralValueExpression {
+	lit := &LiteralValueExpression{Value: value}
+	lit.Typecheck(false)
+	return lit
+}
+
+// foldValue evaluates an expression whose operands are constants. If evaluation fails, the expression is returned
+// unchanged.
+func foldValue(x Expression) Expression {
+	value, diags := x.Evaluate(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() {
+		return x
+	}
+	if value.Type() == cty.Number && value.AsBigFloat().IsInf() {
+		return x
+	}
+	return newLiteral(value)
+}
+
+// foldElement folds a call to `element` whose list is a tuple and whose index is a constant whole number. The index
+// wraps around the end of the list.
+func foldElement(x *FunctionCallExpression) Expression {
+	list, ok := x.Args[0].(*TupleConsExpression)
+	if !ok || len(list.Expressions) == 0 || !isConstant(x.Args[1]) {
+		return x
+	}
+
+	value, _ := constantValue(x.Args[1])
+	index, err := convert.Convert(value, cty.Number)
+	if err != nil {
+		return x
+	}
+	var i int
+	if err := gocty.FromCtyValue(index, &i); err != nil || i < 0 {
+		return x
+	}
+	return replaceExpression(x, list.Expressions[i%len(list.Expressions)])
+}
+
+// replaceExpression replaces an expression with one of its subexpressions. The subexpression takes on the trivia of
+// the expression it replaces.
+func replaceExpression(x, with Expression) Expression {
+	with.SetLeadingTrivia(x.GetLeadingTrivia())
+	with.SetTrailingTrivia(x.GetTrailingTrivia())
+	return with
+}
+
+// foldTemplate merges each run of constant parts of a template expression into a single string literal. The template's
+// interpolation delimiters are attached to the trivia of its parts and quotes, so the delimiters that surround the
+// parts of a run are replaced with those that surround the new literal.
+func foldTemplate(x *TemplateExpression) Expression {
+	var parts []Expression
+	folded := false
+	for i := 0; i < len(x.Parts); {
+		str, ok := constantString(x.Parts[i])
+		if !ok {
+			parts, i = append(parts, x.Parts[i]), i+1
+			continue
+		}
+
+		// Find the end of the run. Runs of a single string literal need no folding.
+		j := i + 1
+		for ; j < len(x.Parts); j++ {
+			s, ok := constantString(x.Parts[j])
+			if !ok {
+				break
+			}
+			str += s
+		}
+		if lit, ok := x.Parts[i].(*LiteralValueExpression); ok && j == i+1 && lit.Type() == StringType {
+			parts, i = append(parts, lit), j
+			continue
+		}
+
+		// The delimiters between two adjacent interpolations are attached to the trailing trivia of the first. If the
+		// run follows an interpolation, the new literal carries the delimiter that closes that interpolation.
+		lit := newLiteral(cty.StringVal(str))
+		var leading, trailing syntax.TriviaList
+		if i > 0 {
+			prev := parts[len(parts)-1]
+			prev.SetTrailingTrivia(removeTemplateDelimiters(prev.GetTrailingTrivia()))
+			leading = syntax.TriviaList{syntax.NewTemplateDelimiter(hclsyntax.TokenTemplateSeqEnd)}
+		}
+		if j < len(x.Parts) {
+			trailing = syntax.TriviaList{syntax.NewTemplateDelimiter(hclsyntax.TokenTemplateInterp)}
+		}
+		lit.Tokens = &syntax.LiteralValueTokens{
+			Value: []syntax.Token{{
+				Raw:            hclsyntax.Token{Type: hclsyntax.TokenQuotedLit},
+				LeadingTrivia:  leading,
+				TrailingTrivia: trailing,
+			}},
+		}
+		parts, i, folded = append(parts, lit), j, true
+	}
+	if !folded {
+		return x
+	}
+
+	// If the template now begins or ends with a literal, remove the delimiters from its quotes.
+	if x.Tokens != nil {
+		if _, ok := parts[0].(*LiteralValueExpression); ok {
+			x.Tokens.Open.TrailingTrivia = removeTemplateDelimiters(x.Tokens.Open.TrailingTrivia)
+		}
+		if _, ok := parts[len(parts)-1].(*LiteralValueExpression); ok {
+			x.Tokens.Close.LeadingTrivia = removeTemplateDelimiters(x.Tokens.Close.LeadingTrivia)
+		}
+	}
+
+	x.Parts = parts
+	x.Typecheck(false)
+	return x
+}
+
+// removeTemplateDelimiters removes any template delimiters from a list of trivia.
+func removeTemplateDelimiters(trivia syntax.TriviaList) syntax.TriviaList {
+	var result syntax.TriviaList
+	for _, t := range trivia {
+		if _, ok := t.(syntax.TemplateDelimiter); !ok {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// constantString returns the string value of a constant expression.
+func constantString(x Expression) (string, bool) {
+	value, ok := constantValue(x)
+	if !ok {
+		return "", false
+	}
+	str, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return "", false
+	}
+	return str.AsString(), true
+}
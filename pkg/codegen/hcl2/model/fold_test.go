@@ -0,0 +1,69 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoldConstants(t *testing.T) {
+	env := environment(map[string]interface{}{
+		"x": StringType,
+		"n": NumberType,
+		"o": NewOutputType(StringType),
+		"element": NewFunction(StaticFunctionSignature{
+			Parameters: []Parameter{
+				{Name: "list", Type: DynamicType},
+				{Name: "index", Type: NumberType},
+			},
+			ReturnType: DynamicType,
+		}),
+	})
+	scope := env.scope()
+
+	cases := []struct {
+		x        string
+		expected string
+	}{
+		{x: `1 + 2 * 3`, expected: `7`},
+		{x: `n + 2 * 3`, expected: `n + 6`},
+		{x: `-(4 / 2)`, expected: `-2`},
+		{x: `!(1 < 2)`, expected: `false`},
+		{x: `1 / 0`, expected: `1 / 0`},
+		{x: `"a" == "a" ? x : "b"`, expected: `x`},
+		{x: `n == 1 ? x : "b"`, expected: `n == 1 ? x : "b"`},
+		{x: `"${"foo"}-${1 + 1}-${x}-${true}"`, expected: `"foo-2-${x}-true"`},
+		{x: `"${o}-${x}"`, expected: `"${o}-${x}"`},
+		{x: `"${"a"}${x}${1}"`, expected: `"a${x}1"`},
+		{x: `"${x}${"\n"}${x}"`, expected: `"${x}\n${x}"`},
+		{x: `element(["a", x, "c"], 1)`, expected: `x`},
+		{x: `element(["a", "b", "c"], 3 + 1)`, expected: `"b"`},
+		{x: `element(["a", "b", "c"], n)`, expected: `element(["a", "b", "c"], n)`},
+	}
+	for _, c := range cases {
+		t.Run(c.x, func(t *testing.T) {
+			expr, diags := BindExpressionText(c.x, scope, hcl.Pos{})
+			assert.Len(t, diags, 0)
+
+			folded, diags := FoldConstants(expr)
+			assert.Len(t, diags, 0)
+			assert.Equal(t, c.expected, fmt.Sprintf("%v", folded))
+		})
+	}
+}
@@ -61,19 +61,38 @@ var (
 )
 
 func assignableFrom(dest, src Type, assignableFrom func() bool) bool {
-	return dest.Equals(src) || dest == DynamicType || assignableFrom()
+	if dest.Equals(src) || dest == DynamicType {
+		return true
+	}
+	// A value of an enum type may be used wherever a value of its element type may be used.
+	if src, isEnum := src.(*EnumType); isEnum && dest.AssignableFrom(src.ElementType) {
+		return true
+	}
+	// A secret value may be used wherever a value of its element type may be used.
+	if src, isSecret := src.(*SecretType); isSecret {
+		return dest.AssignableFrom(src.ElementType)
+	}
+	return assignableFrom()
 }
 
 func conversionFrom(dest, src Type, unifying bool, conversionFrom func() ConversionKind) ConversionKind {
 	if dest.Equals(src) || dest == DynamicType {
 		return SafeConversion
 	}
+	if src, isSecret := src.(*SecretType); isSecret {
+		return dest.conversionFrom(src.ElementType, unifying)
+	}
 	if src, isUnion := src.(*UnionType); isUnion {
 		return src.conversionTo(dest, unifying)
 	}
 	if src == DynamicType {
 		return UnsafeConversion
 	}
+	if src, isEnum := src.(*EnumType); isEnum {
+		if kind := dest.conversionFrom(src.ElementType, unifying); kind.Exists() {
+			return kind
+		}
+	}
 	return conversionFrom()
 }
 
@@ -84,6 +103,12 @@ func unify(t0, t1 Type, unify func() (Type, ConversionKind)) (Type, ConversionKi
 	if t0 == DynamicType {
 		t0, t1 = t1, t0
 	}
+	// Unify with secret types from the secret side so that the result remains secret.
+	if _, isSecret := t1.(*SecretType); isSecret {
+		if _, isSecret = t0.(*SecretType); !isSecret {
+			return t1.unify(t0)
+		}
+	}
 
 	switch {
 	case t0.Equals(t1):
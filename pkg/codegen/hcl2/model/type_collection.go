@@ -25,6 +25,8 @@ func unwrapIterableSourceType(t Type) Type {
 			t = tt.ElementType
 		case *PromiseType:
 			t = tt.ElementType
+		case *SecretType:
+			t = tt.ElementType
 		default:
 			return t
 		}
@@ -41,6 +43,8 @@ func wrapIterableResultType(sourceType, iterableType Type) Type {
 			sourceType, iterableType = t.ElementType, NewOutputType(iterableType)
 		case *PromiseType:
 			sourceType, iterableType = t.ElementType, NewPromiseType(iterableType)
+		case *SecretType:
+			sourceType, iterableType = t.ElementType, NewSecretType(iterableType)
 		default:
 			return iterableType
 		}
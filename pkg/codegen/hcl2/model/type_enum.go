@@ -0,0 +1,110 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// EnumType represents a named type whose values are drawn from a fixed set of values of its element type.
+type EnumType struct {
+	// Token is the type's identifier.
+	Token string
+	// ElementType is the type of the enum's values.
+	ElementType Type
+	// Elements is the set of values permitted by the enum.
+	Elements []cty.Value
+	// Annotations records any annotations associated with the enum type.
+	Annotations []interface{}
+}
+
+// NewEnumType creates a new enum type with the given token, element type, and permitted values.
+func NewEnumType(token string, elementType Type, elements []cty.Value, annotations ...interface{}) *EnumType {
+	return &EnumType{Token: token, ElementType: elementType, Elements: elements, Annotations: annotations}
+}
+
+// SyntaxNode returns the syntax node for the type. This is always syntax.None.
+func (*EnumType) SyntaxNode() hclsyntax.Node {
+	return syntax.None
+}
+
+// Traverse attempts to traverse the enum type with the given traverser. This always fails.
+func (t *EnumType) Traverse(traverser hcl.Traverser) (Traversable, hcl.Diagnostics) {
+	return DynamicType, hcl.Diagnostics{unsupportedReceiverType(t, traverser.SourceRange())}
+}
+
+// Equals returns true if this type has the same identity as the given type.
+func (t *EnumType) Equals(other Type) bool {
+	if t == other {
+		return true
+	}
+	otherEnum, ok := other.(*EnumType)
+	return ok && t.Token == otherEnum.Token
+}
+
+// AssignableFrom returns true if this type is assignable from the indicated source type. An enum(token, T) is
+// assignable from values of type enum(token, T) and from values that are assignable to T. Whether or not a value is a
+// member of the enum is checked separately (see Contains), as the set of values of a type is not known statically.
+func (t *EnumType) AssignableFrom(src Type) bool {
+	return assignableFrom(t, src, func() bool {
+		return t.ElementType.AssignableFrom(src)
+	})
+}
+
+// ConversionFrom returns the kind of conversion (if any) that is possible from the source type to this type. An
+// enum(token, T) is convertible from any type that is convertible to T.
+func (t *EnumType) ConversionFrom(src Type) ConversionKind {
+	return t.conversionFrom(src, false)
+}
+
+func (t *EnumType) conversionFrom(src Type, unifying bool) ConversionKind {
+	return conversionFrom(t, src, unifying, func() ConversionKind {
+		return t.ElementType.conversionFrom(src, unifying)
+	})
+}
+
+// Contains returns true if the given value is one of the enum's permitted values once it has been converted to the type
+// of those values. Unknown values are always considered to be members of the enum.
+func (t *EnumType) Contains(value cty.Value) bool {
+	if !value.IsKnown() {
+		return true
+	}
+	for _, element := range t.Elements {
+		v, err := convert.Convert(value, element.Type())
+		if err != nil {
+			continue
+		}
+		if eq := element.Equals(v); eq.IsKnown() && eq.True() {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *EnumType) String() string {
+	return t.Token
+}
+
+func (t *EnumType) unify(other Type) (Type, ConversionKind) {
+	return unify(t, other, func() (Type, ConversionKind) {
+		return t.ElementType.unify(other)
+	})
+}
+
+func (*EnumType) isType() {}
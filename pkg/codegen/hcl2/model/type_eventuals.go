@@ -46,6 +46,9 @@ func resolveEventuals(t Type, resolveOutputs bool) (Type, typeTransform) {
 			transform = makePromise
 		}
 		return element, transform
+	case *SecretType:
+		resolved, transform := resolveEventuals(t.ElementType, resolveOutputs)
+		return NewSecretType(resolved), transform
 	case *MapType:
 		resolved, transform := resolveEventuals(t.ElementType, resolveOutputs)
 		return NewMapType(resolved), transform
@@ -121,6 +124,8 @@ func ContainsEventuals(t Type) (containsOutputs, containsPromises bool) {
 		return true, false
 	case *PromiseType:
 		return ContainsOutputs(t.ElementType), true
+	case *SecretType:
+		return ContainsEventuals(t.ElementType)
 	case *MapType:
 		return ContainsEventuals(t.ElementType)
 	case *ListType:
@@ -160,6 +165,8 @@ func ContainsOutputs(t Type) bool {
 		return true
 	case *PromiseType:
 		return ContainsOutputs(t.ElementType)
+	case *SecretType:
+		return ContainsOutputs(t.ElementType)
 	case *MapType:
 		return ContainsOutputs(t.ElementType)
 	case *ListType:
@@ -197,6 +204,8 @@ func ContainsPromises(t Type) bool {
 	switch t := t.(type) {
 	case *PromiseType:
 		return true
+	case *SecretType:
+		return ContainsPromises(t.ElementType)
 	case *MapType:
 		return ContainsPromises(t.ElementType)
 	case *ListType:
@@ -229,13 +238,20 @@ func ContainsPromises(t Type) bool {
 	}
 }
 
+// liftOperationType lifts the result type of an operation into the eventual types of its arguments. If any argument is
+// secret, the result is also secret.
 func liftOperationType(resultType Type, arguments ...Expression) Type {
 	var transform typeTransform
+	secret := false
 	for _, arg := range arguments {
 		_, t := resolveEventuals(arg.Type(), true)
 		if t > transform {
 			transform = t
 		}
+		secret = secret || IsSecretType(arg.Type())
+	}
+	if secret {
+		resultType = NewSecretType(resultType)
 	}
 	return transform.do(resultType)
 }
@@ -16,6 +16,7 @@ package model
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -34,16 +35,67 @@ type OpaqueType struct {
 	s string
 }
 
-// The set of opaque types, indexed by name.
-var opaqueTypes = map[string]*OpaqueType{}
+// OpaqueTypeRegistry is a set of opaque types indexed by name. Opaque types are compared by identity, so two opaque
+// types with the same name are only equal if they were fetched from the same registry. A registry may be used
+// concurrently.
+type OpaqueTypeRegistry struct {
+	m     sync.RWMutex
+	types map[string]*OpaqueType
+}
 
-// GetOpaqueType fetches the opaque type for the given name.
-func GetOpaqueType(name string) (*OpaqueType, bool) {
-	t, ok := opaqueTypes[name]
+// NewOpaqueTypeRegistry creates a new, empty registry of opaque types.
+func NewOpaqueTypeRegistry() *OpaqueTypeRegistry {
+	return &OpaqueTypeRegistry{types: map[string]*OpaqueType{}}
+}
+
+// Get fetches the opaque type for the given name.
+func (r *OpaqueTypeRegistry) Get(name string) (*OpaqueType, bool) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	t, ok := r.types[name]
 	return t, ok
 }
 
-// MustNewOpaqueType creates a new opaque type with the given name.
+// New creates a new opaque type with the given name. It is an error to create two opaque types with the same name in
+// a single registry.
+func (r *OpaqueTypeRegistry) New(name string, annotations ...interface{}) (*OpaqueType, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if _, ok := r.types[name]; ok {
+		return nil, errors.Errorf("opaque type %s is already defined", name)
+	}
+
+	t := &OpaqueType{Name: name, Annotations: annotations}
+	r.types[name] = t
+	return t, nil
+}
+
+// GetOrNew fetches the opaque type for the given name, creating it if it does not exist.
+func (r *OpaqueTypeRegistry) GetOrNew(name string) *OpaqueType {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	t, ok := r.types[name]
+	if !ok {
+		t = &OpaqueType{Name: name}
+		r.types[name] = t
+	}
+	return t
+}
+
+// The process-wide set of opaque types. This registry holds the builtin types (e.g. string and number) and any other
+// types that are shared by all programs. The types that are defined by package schemas are registered with the
+// registry of the binder that loads the schemas instead.
+var opaqueTypes = NewOpaqueTypeRegistry()
+
+// GetOpaqueType fetches the opaque type for the given name from the process-wide registry.
+func GetOpaqueType(name string) (*OpaqueType, bool) {
+	return opaqueTypes.Get(name)
+}
+
+// MustNewOpaqueType creates a new opaque type with the given name in the process-wide registry.
 func MustNewOpaqueType(name string, annotations ...interface{}) *OpaqueType {
 	t, err := NewOpaqueType(name, annotations...)
 	if err != nil {
@@ -52,15 +104,9 @@ func MustNewOpaqueType(name string, annotations ...interface{}) *OpaqueType {
 	return t
 }
 
-// NewOpaqueType creates a new opaque type with the given name.
+// NewOpaqueType creates a new opaque type with the given name in the process-wide registry.
 func NewOpaqueType(name string, annotations ...interface{}) (*OpaqueType, error) {
-	if _, ok := opaqueTypes[name]; ok {
-		return nil, errors.Errorf("opaque type %s is already defined", name)
-	}
-
-	t := &OpaqueType{Name: name, Annotations: annotations}
-	opaqueTypes[name] = t
-	return t, nil
+	return opaqueTypes.New(name, annotations...)
 }
 
 // SyntaxNode returns the syntax node for the type. This is always syntax.None.
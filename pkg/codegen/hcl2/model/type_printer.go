@@ -0,0 +1,324 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// TypePrinter prints types in a form that is suitable for diagnostics. Unlike Type.String, the printer prints named
+// types by name, prints input and optional types as input(T) and optional(T), and omits annotations.
+type TypePrinter struct {
+	// TypeName returns the name of a type, if it has one (e.g. the schema token of an object type). Named types are
+	// printed by name rather than structurally. If TypeName is nil, only enum and opaque types are named.
+	TypeName func(t Type) (string, bool)
+}
+
+// TypeConflict describes a location within a value whose type cannot be converted to the type expected at that
+// location.
+type TypeConflict struct {
+	// Path is the path to the location within the value, e.g. `logging.targetBucket` or `rules[0]`. The path is empty
+	// if the conflict is at the root of the value.
+	Path string
+	// DestType is the type expected at the location.
+	DestType Type
+	// SourceType is the type of the value at the location.
+	SourceType Type
+}
+
+// Print prints the given type.
+func (p *TypePrinter) Print(t Type) string {
+	return p.print(t, nil)
+}
+
+// Conflicts returns the locations within a value of type src that prevent it from being converted to a value of type
+// dest. If the conversion exists, Conflicts returns nil. Conflicts are found by descending into object properties and
+// list, tuple, and map elements; if src and dest do not share a structure, the only conflict is at the root.
+func (p *TypePrinter) Conflicts(dest, src Type) []TypeConflict {
+	return p.conflicts("", dest, src)
+}
+
+// ExprNotConvertible returns a diagnostic that describes why the given expression cannot be assigned to a location of
+// the given type. Members of object types that do not conflict are elided from the printed types, and the path to
+// each conflict is listed in the diagnostic's detail.
+func (p *TypePrinter) ExprNotConvertible(destType Type, expr Expression) *hcl.Diagnostic {
+	conflicts := p.Conflicts(destType, expr.Type())
+
+	var keep map[string]bool
+	var details []string
+	for _, c := range conflicts {
+		if c.Path == "" {
+			continue
+		}
+		if keep == nil {
+			keep = map[string]bool{}
+		}
+		keep[strings.FieldsFunc(c.Path, func(r rune) bool { return r == '.' || r == '[' })[0]] = true
+		details = append(details, p.describeConflict(c))
+	}
+
+	diag := errorf(expr.SyntaxNode().Range(), "cannot assign expression of type %v to location of type %v",
+		p.print(expr.Type(), keep), p.print(destType, keep))
+	if len(details) != 0 {
+		diag.Detail = strings.Join(details, "\n")
+	}
+	return diag
+}
+
+// describeConflict returns a one-line description of a conflict.
+func (p *TypePrinter) describeConflict(c TypeConflict) string {
+	if c.SourceType == NoneType {
+		return fmt.Sprintf("%s: missing required property of type %v", c.Path, p.Print(c.DestType))
+	}
+	return fmt.Sprintf("%s: cannot assign type %v to type %v", c.Path, p.Print(c.SourceType), p.Print(c.DestType))
+}
+
+// typeName returns the name of the given type, if any.
+func (p *TypePrinter) typeName(t Type) (string, bool) {
+	if p.TypeName != nil {
+		if name, ok := p.TypeName(t); ok {
+			return name, true
+		}
+	}
+	switch t := t.(type) {
+	case *EnumType:
+		return t.Token, true
+	case *OpaqueType:
+		return t.String(), true
+	case noneType:
+		return t.String(), true
+	}
+	return "", false
+}
+
+// print prints the given type. If keep is non-nil, only the listed properties of the outermost object types are
+// printed; the others are elided.
+func (p *TypePrinter) print(t Type, keep map[string]bool) string {
+	if name, ok := p.typeName(t); ok {
+		return name
+	}
+
+	switch t := t.(type) {
+	case *ObjectType:
+		var properties []string
+		elided := false
+		for _, k := range sortedKeys(t.Properties) {
+			if keep != nil && !keep[k] {
+				elided = true
+				continue
+			}
+			properties = append(properties, fmt.Sprintf("%s = %s", k, p.Print(t.Properties[k])))
+		}
+		if elided {
+			properties = append(properties, "...")
+		}
+		return fmt.Sprintf("object({%s})", strings.Join(properties, ", "))
+	case *ListType:
+		return fmt.Sprintf("list(%s)", p.Print(t.ElementType))
+	case *MapType:
+		return fmt.Sprintf("map(%s)", p.Print(t.ElementType))
+	case *SetType:
+		return fmt.Sprintf("set(%s)", p.Print(t.ElementType))
+	case *OutputType:
+		return fmt.Sprintf("output(%s)", p.print(t.ElementType, keep))
+	case *PromiseType:
+		return fmt.Sprintf("promise(%s)", p.print(t.ElementType, keep))
+	case *SecretType:
+		return fmt.Sprintf("secret(%s)", p.print(t.ElementType, keep))
+	case *TupleType:
+		elements := make([]string, len(t.ElementTypes))
+		for i, e := range t.ElementTypes {
+			elements[i] = p.Print(e)
+		}
+		return fmt.Sprintf("tuple(%s)", strings.Join(elements, ", "))
+	case *UnionType:
+		return p.printUnion(t, keep)
+	default:
+		return t.String()
+	}
+}
+
+// printUnion prints a union type. Input types, i.e. union(T, output(T)), are printed as input(T), and optional types,
+// i.e. union(T, none), are printed as optional(T).
+func (p *TypePrinter) printUnion(t *UnionType, keep map[string]bool) string {
+	var elements, outputs []Type
+	optional := false
+	for _, e := range t.ElementTypes {
+		switch e := e.(type) {
+		case noneType:
+			optional = true
+		case *OutputType:
+			outputs = append(outputs, e.ElementType)
+		default:
+			elements = append(elements, e)
+		}
+	}
+
+	// InputType also wraps the element types of T, so outputs are resolved before the elements are compared. The
+	// outputs of an input type may themselves be optional, e.g. if an optional type is passed to InputType.
+	if len(elements) != 0 && len(outputs) != 0 {
+		elementType, isInput := NewUnionType(elements...), true
+		for _, o := range outputs {
+			if !ResolveOutputs(elementType).Equals(ResolveOutputs(removeNone(o))) {
+				isInput = false
+				break
+			}
+		}
+		if isInput {
+			s := p.print(elementType, keep)
+			if optional {
+				s = fmt.Sprintf("optional(%s)", s)
+			}
+			return fmt.Sprintf("input(%s)", s)
+		}
+	}
+
+	if optional {
+		return fmt.Sprintf("optional(%s)", p.print(removeNone(t), keep))
+	}
+
+	printed := make([]string, len(t.ElementTypes))
+	for i, e := range t.ElementTypes {
+		printed[i] = p.print(e, keep)
+	}
+	return fmt.Sprintf("union(%s)", strings.Join(printed, ", "))
+}
+
+// removeNone removes the none type from a union type.
+func removeNone(t Type) Type {
+	union, ok := t.(*UnionType)
+	if !ok {
+		return t
+	}
+	var elements []Type
+	for _, e := range union.ElementTypes {
+		if e != NoneType {
+			elements = append(elements, e)
+		}
+	}
+	return NewUnionType(elements...)
+}
+
+// conflicts returns the conflicts between dest and src at the given path.
+func (p *TypePrinter) conflicts(path string, dest, src Type) []TypeConflict {
+	if dest.ConversionFrom(src).Exists() {
+		return nil
+	}
+	root := []TypeConflict{{Path: path, DestType: dest, SourceType: src}}
+
+	var conflicts []TypeConflict
+	switch dest := dest.(type) {
+	case *UnionType:
+		// Compare the source type with each element of the union that shares its structure, and report the conflicts
+		// for the closest match.
+		for _, e := range dest.ElementTypes {
+			if sameStructure(e, src) {
+				if c := p.conflicts(path, e, src); conflicts == nil || len(c) < len(conflicts) {
+					conflicts = c
+				}
+			}
+		}
+	case *ObjectType:
+		if src, ok := src.(*ObjectType); ok {
+			for _, k := range sortedKeys(dest.Properties) {
+				propertyType, ok := src.Properties[k]
+				if !ok {
+					propertyType = NoneType
+				}
+				conflicts = append(conflicts, p.conflicts(joinPath(path, k), dest.Properties[k], propertyType)...)
+			}
+		}
+	case *MapType:
+		switch src := src.(type) {
+		case *ObjectType:
+			for _, k := range sortedKeys(src.Properties) {
+				conflicts = append(conflicts, p.conflicts(joinPath(path, k), dest.ElementType, src.Properties[k])...)
+			}
+		case *MapType:
+			conflicts = p.conflicts(path+"[*]", dest.ElementType, src.ElementType)
+		}
+	case *ListType:
+		switch src := src.(type) {
+		case *TupleType:
+			for i, e := range src.ElementTypes {
+				conflicts = append(conflicts, p.conflicts(fmt.Sprintf("%s[%d]", path, i), dest.ElementType, e)...)
+			}
+		case *ListType:
+			conflicts = p.conflicts(path+"[*]", dest.ElementType, src.ElementType)
+		}
+	case *OutputType:
+		if src, ok := src.(*OutputType); ok {
+			conflicts = p.conflicts(path, dest.ElementType, src.ElementType)
+		}
+	case *PromiseType:
+		if src, ok := src.(*PromiseType); ok {
+			conflicts = p.conflicts(path, dest.ElementType, src.ElementType)
+		}
+	case *SecretType:
+		conflicts = p.conflicts(path, dest.ElementType, src)
+	}
+	if len(conflicts) == 0 {
+		return root
+	}
+	return conflicts
+}
+
+// sameStructure returns true if values of type src may be structurally compared with values of type dest.
+func sameStructure(dest, src Type) bool {
+	switch dest.(type) {
+	case *ObjectType:
+		_, ok := src.(*ObjectType)
+		return ok
+	case *MapType:
+		switch src.(type) {
+		case *ObjectType, *MapType:
+			return true
+		}
+	case *ListType:
+		switch src.(type) {
+		case *TupleType, *ListType:
+			return true
+		}
+	case *OutputType:
+		_, ok := src.(*OutputType)
+		return ok
+	case *PromiseType:
+		_, ok := src.(*PromiseType)
+		return ok
+	}
+	return false
+}
+
+// joinPath appends a property name to a path.
+func joinPath(path, property string) string {
+	if path == "" {
+		return property
+	}
+	return path + "." + property
+}
+
+// sortedKeys returns the keys of a property map in sorted order.
+func sortedKeys(properties map[string]Type) []string {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,86 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypePrinterPrint(t *testing.T) {
+	logging := NewObjectType(map[string]Type{
+		"targetBucket": StringType,
+		"targetPrefix": NewOptionalType(StringType),
+	})
+	names := &TypePrinter{TypeName: func(t Type) (string, bool) {
+		if t == logging {
+			return "aws:s3/BucketLogging:BucketLogging", true
+		}
+		return "", false
+	}}
+
+	cases := []struct {
+		typ      Type
+		expected string
+	}{
+		{typ: NewListType(StringType), expected: "list(string)"},
+		{typ: NewOptionalType(IntType), expected: "optional(int)"},
+		{typ: InputType(StringType), expected: "input(string)"},
+		{typ: InputType(NewOptionalType(StringType)), expected: "input(optional(string))"},
+		{typ: NewUnionType(StringType, NumberType), expected: "union(number, string)"},
+		{typ: logging, expected: "object({targetBucket = string, targetPrefix = optional(string)})"},
+		{typ: InputType(logging), expected: "input(object({targetBucket = input(string), " +
+			"targetPrefix = input(optional(string))}))"},
+	}
+	for _, c := range cases {
+		t.Run(c.expected, func(t *testing.T) {
+			var printer TypePrinter
+			assert.Equal(t, c.expected, printer.Print(c.typ))
+		})
+	}
+
+	assert.Equal(t, "list(aws:s3/BucketLogging:BucketLogging)", names.Print(NewListType(logging)))
+}
+
+func TestTypePrinterConflicts(t *testing.T) {
+	dest := NewObjectType(map[string]Type{
+		"bucket": StringType,
+		"logging": NewObjectType(map[string]Type{
+			"targetBucket": StringType,
+			"targetPrefix": NewOptionalType(StringType),
+		}),
+		"tags": NewMapType(StringType),
+	})
+	src := NewObjectType(map[string]Type{
+		"bucket": StringType,
+		"logging": NewObjectType(map[string]Type{
+			"targetPrefix": StringType,
+		}),
+		"tags": NewObjectType(map[string]Type{
+			"name":  StringType,
+			"owner": NewListType(StringType),
+		}),
+	})
+
+	var printer TypePrinter
+	assert.Equal(t, []TypeConflict{
+		{Path: "logging.targetBucket", DestType: StringType, SourceType: NoneType},
+		{Path: "tags.owner", DestType: StringType, SourceType: NewListType(StringType)},
+	}, printer.Conflicts(dest, src))
+
+	assert.Nil(t, printer.Conflicts(dest, dest))
+	assert.Equal(t, []TypeConflict{{DestType: dest, SourceType: NumberType}}, printer.Conflicts(dest, NumberType))
+}
@@ -0,0 +1,126 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+)
+
+// SecretType represents values that must be kept secret, e.g. the values of properties that are marked as secret by a
+// package's schema. A secret type is interchangeable with its element type; it only records that values of the type
+// should be encrypted when they are stored.
+type SecretType struct {
+	// ElementType is the element type of the secret.
+	ElementType Type
+}
+
+// NewSecretType creates a new secret type with the given element type. Secret types are nested within eventual types,
+// so the secret type of output(T) is output(secret(T)), and the secret type of secret(T) is secret(T).
+func NewSecretType(elementType Type) Type {
+	switch elementType := elementType.(type) {
+	case *SecretType:
+		return elementType
+	case *OutputType:
+		return NewOutputType(NewSecretType(elementType.ElementType))
+	case *PromiseType:
+		return NewPromiseType(NewSecretType(elementType.ElementType))
+	default:
+		return &SecretType{ElementType: elementType}
+	}
+}
+
+// IsSecretType returns true if the given type is a secret type or an eventual or optional secret type.
+func IsSecretType(t Type) bool {
+	switch t := t.(type) {
+	case *SecretType:
+		return true
+	case *OutputType:
+		return IsSecretType(t.ElementType)
+	case *PromiseType:
+		return IsSecretType(t.ElementType)
+	case *UnionType:
+		for _, e := range t.ElementTypes {
+			if e != NoneType && !IsSecretType(e) {
+				return false
+			}
+		}
+		return len(t.ElementTypes) != 0
+	default:
+		return false
+	}
+}
+
+// SyntaxNode returns the syntax node for the type. This is always syntax.None.
+func (*SecretType) SyntaxNode() hclsyntax.Node {
+	return syntax.None
+}
+
+// Traverse attempts to traverse the secret type with the given traverser. The result type of traverse(secret(T)) is
+// secret(traverse(T)).
+func (t *SecretType) Traverse(traverser hcl.Traverser) (Traversable, hcl.Diagnostics) {
+	element, diagnostics := t.ElementType.Traverse(traverser)
+	return NewSecretType(element.(Type)), diagnostics
+}
+
+// Equals returns true if this type has the same identity as the given type.
+func (t *SecretType) Equals(other Type) bool {
+	if t == other {
+		return true
+	}
+	otherSecret, ok := other.(*SecretType)
+	return ok && t.ElementType.Equals(otherSecret.ElementType)
+}
+
+// AssignableFrom returns true if this type is assignable from the indicated source type. A secret(T) is assignable
+// from values of type secret(U) and U, where T is assignable from U.
+func (t *SecretType) AssignableFrom(src Type) bool {
+	return assignableFrom(t, src, func() bool {
+		return t.ElementType.AssignableFrom(src)
+	})
+}
+
+// ConversionFrom returns the kind of conversion (if any) that is possible from the source type to this type. A
+// secret(T) is convertible from a type U or secret(U) if U is convertible to T.
+func (t *SecretType) ConversionFrom(src Type) ConversionKind {
+	return t.conversionFrom(src, false)
+}
+
+func (t *SecretType) conversionFrom(src Type, unifying bool) ConversionKind {
+	return conversionFrom(t, src, unifying, func() ConversionKind {
+		return t.ElementType.conversionFrom(src, unifying)
+	})
+}
+
+func (t *SecretType) String() string {
+	return fmt.Sprintf("secret(%v)", t.ElementType)
+}
+
+func (t *SecretType) unify(other Type) (Type, ConversionKind) {
+	return unify(t, other, func() (Type, ConversionKind) {
+		// Unify based on the element type, and keep the result secret.
+		if other, ok := other.(*SecretType); ok {
+			elementType, conversionKind := t.ElementType.unify(other.ElementType)
+			return NewSecretType(elementType), conversionKind
+		}
+		elementType, conversionKind := t.ElementType.unify(other)
+		return NewSecretType(elementType), conversionKind
+	})
+}
+
+func (t *SecretType) isType() {}
@@ -294,6 +294,52 @@ func TestPromiseType(t *testing.T) {
 	})))
 }
 
+func TestSecretType(t *testing.T) {
+	typ := NewSecretType(StringType)
+
+	// Test that creating a secret type with an element type that is also a secret does not create a new type.
+	assert.Equal(t, typ, NewSecretType(typ))
+
+	// Test that secret types are nested within eventual types.
+	assert.Equal(t, NewOutputType(typ), NewSecretType(NewOutputType(StringType)))
+	assert.Equal(t, NewPromiseType(typ), NewSecretType(NewPromiseType(StringType)))
+	assert.Equal(t, NewOutputType(typ), NewOutputType(NewSecretType(NewOutputType(StringType))))
+
+	// Test that IsSecretType recognizes secret, eventual secret, and optional secret types.
+	assert.True(t, IsSecretType(typ))
+	assert.True(t, IsSecretType(NewOutputType(typ)))
+	assert.True(t, IsSecretType(NewOptionalType(typ)))
+	assert.True(t, IsSecretType(InputType(NewOptionalType(typ))))
+	assert.False(t, IsSecretType(StringType))
+	assert.False(t, IsSecretType(NewUnionType(typ, IntType)))
+
+	// Test that secret(T) is assignable to and from T.
+	assert.True(t, typ.AssignableFrom(typ))
+	assert.True(t, typ.AssignableFrom(StringType))
+	assert.True(t, StringType.AssignableFrom(typ))
+	assert.False(t, typ.AssignableFrom(NewSecretType(BoolType)))
+
+	// Test that conversions to and from secret(T) are those to and from T.
+	assert.Equal(t, SafeConversion, typ.ConversionFrom(IntType))
+	assert.Equal(t, SafeConversion, StringType.ConversionFrom(NewSecretType(IntType)))
+	assert.Equal(t, UnsafeConversion, NewSecretType(IntType).ConversionFrom(StringType))
+
+	// Test that traversing a secret(T) returns a secret(U), where U is the result of the inner traversal.
+	typ = NewSecretType(NewMapType(StringType))
+	testTraverse(t, typ, hcl.TraverseAttr{Name: "foo"}, NewSecretType(StringType), false)
+	testTraverse(t, typ, hcl.TraverseIndex{Key: cty.StringVal("foo")}, NewSecretType(StringType), false)
+
+	// Test that unifying a secret type with another type produces a secret type.
+	unified, _ := UnifyTypes(NewSecretType(StringType), StringType)
+	assert.Equal(t, NewSecretType(StringType), unified)
+	unified, _ = UnifyTypes(IntType, NewSecretType(StringType))
+	assert.Equal(t, NewSecretType(StringType), unified)
+
+	// Test that eventual types resolve through secret types.
+	assert.Equal(t, NewSecretType(BoolType), ResolveOutputs(NewOutputType(NewSecretType(BoolType))))
+	assert.True(t, ContainsOutputs(NewSecretType(NewListType(NewOutputType(BoolType)))))
+}
+
 func TestMapType(t *testing.T) {
 	typ := NewMapType(DynamicType)
 
@@ -528,6 +574,64 @@ func TestOpaqueType(t *testing.T) {
 	assert.NotEqual(t, foo, bar)
 }
 
+func TestOpaqueTypeRegistry(t *testing.T) {
+	r1, r2 := NewOpaqueTypeRegistry(), NewOpaqueTypeRegistry()
+
+	foo, err := r1.New("foo")
+	assert.NoError(t, err)
+	_, err = r1.New("foo")
+	assert.Error(t, err)
+	assert.Equal(t, foo, r1.GetOrNew("foo"))
+
+	// Registries are independent of each other and of the process-wide registry.
+	_, ok := r2.Get("foo")
+	assert.False(t, ok)
+	foo2 := r2.GetOrNew("foo")
+	assert.False(t, foo.Equals(foo2))
+
+	_, ok = r1.Get("string")
+	assert.False(t, ok)
+	str, err := r1.New("string")
+	assert.NoError(t, err)
+	assert.False(t, str.Equals(StringType))
+}
+
+func TestEnumType(t *testing.T) {
+	typ := NewEnumType("pkg:index:Color", StringType, []cty.Value{cty.StringVal("red"), cty.StringVal("blue")})
+
+	// Test that enum types with the same token are equal.
+	assert.True(t, typ.Equals(NewEnumType("pkg:index:Color", StringType, nil)))
+	assert.False(t, typ.Equals(NewEnumType("pkg:index:Size", StringType, nil)))
+
+	// Test that an enum type is assignable from itself and from its element type.
+	assert.True(t, typ.AssignableFrom(typ))
+	assert.True(t, typ.AssignableFrom(StringType))
+	assert.False(t, typ.AssignableFrom(NewListType(StringType)))
+
+	// Test that an enum type's element type is assignable and convertible from the enum type.
+	assert.True(t, StringType.AssignableFrom(typ))
+	assert.True(t, InputType(StringType).AssignableFrom(typ))
+	assert.Equal(t, SafeConversion, StringType.ConversionFrom(typ))
+	assert.Equal(t, SafeConversion, NumberType.ConversionFrom(NewEnumType("pkg:index:Size", IntType, nil)))
+
+	// Test that an enum type is convertible from anything that is convertible to its element type.
+	assert.Equal(t, SafeConversion, typ.ConversionFrom(NumberType))
+	assert.Equal(t, NoConversion, typ.ConversionFrom(NewListType(StringType)))
+
+	// Test that an enum type contains exactly its elements.
+	assert.True(t, typ.Contains(cty.StringVal("red")))
+	assert.False(t, typ.Contains(cty.StringVal("green")))
+	assert.True(t, typ.Contains(cty.UnknownVal(cty.String)))
+
+	// Test that an enum type unifies with its element type.
+	unified, kind := typ.unify(StringType)
+	assert.Equal(t, StringType, unified)
+	assert.Equal(t, SafeConversion, kind)
+
+	// Test that traversing an enum type fails.
+	testTraverse(t, typ, hcl.TraverseAttr{Name: "foo"}, DynamicType, true)
+}
+
 func TestInputType(t *testing.T) {
 	// Test that InputType(DynamicType) just returns DynamicType.
 	assert.Equal(t, DynamicType, InputType(DynamicType))
@@ -99,6 +99,19 @@ func (p *Program) BindExpression(node hclsyntax.Node) (model.Expression, hcl.Dia
 	return p.binder.bindExpression(node)
 }
 
+// GetSchemaForType extracts the schema.Type associated with a model.Type, if any. The schema types reconstructed for
+// list and map types are memoized by the package cache with which the program was bound.
+func (p *Program) GetSchemaForType(t model.Type) (schema.Type, bool) {
+	return p.binder.options.packageCache.GetSchemaForType(t)
+}
+
+// TypeMapper returns the TypeMapper that converted the program's schema types to model types. The mapper can be used
+// to convert additional schema types using the same opaque types, or to recover the schema types of the program's
+// model types.
+func (p *Program) TypeMapper() *TypeMapper {
+	return p.binder.types
+}
+
 // Packages returns the list of package schemas used by this program.
 func (p *Program) Packages() []*schema.Package {
 	keys := make([]string, 0, len(p.binder.referencedPackages))
@@ -109,7 +122,46 @@ func (p *Program) Packages() []*schema.Package {
 
 	values := make([]*schema.Package, 0, len(p.binder.referencedPackages))
 	for _, k := range keys {
-		values = append(values, p.binder.referencedPackages[k])
+		values = append(values, p.binder.referencedPackages[k].schema)
 	}
 	return values
 }
+
+// ResourceSchema returns the schema for the resource type with the given token if the type belongs to one of the
+// program's packages. The token may be in its schema form (e.g. "aws:s3/bucket:Bucket") or its canonical form (e.g.
+// "aws:s3:Bucket").
+func (p *Program) ResourceSchema(token string) (*schema.Resource, bool) {
+	pkgSchema, ok := p.lookupPackage(token)
+	if !ok {
+		return nil, false
+	}
+	if r, ok := pkgSchema.resources[token]; ok {
+		return r, true
+	}
+	r, ok := pkgSchema.resources[canonicalizeToken(token, pkgSchema.schema)]
+	return r, ok
+}
+
+// FunctionSchema returns the schema for the function with the given token if the function belongs to one of the
+// program's packages. The token may be in its schema form or its canonical form.
+func (p *Program) FunctionSchema(token string) (*schema.Function, bool) {
+	pkgSchema, ok := p.lookupPackage(token)
+	if !ok {
+		return nil, false
+	}
+	if f, ok := pkgSchema.functions[token]; ok {
+		return f, true
+	}
+	f, ok := pkgSchema.functions[canonicalizeToken(token, pkgSchema.schema)]
+	return f, ok
+}
+
+// lookupPackage returns the schema for the package of the given token if the package is referenced by the program.
+func (p *Program) lookupPackage(token string) (*packageSchema, bool) {
+	pkg, _, _, diags := DecomposeToken(token, hcl.Range{})
+	if diags.HasErrors() {
+		return nil, false
+	}
+	pkgSchema, ok := p.binder.referencedPackages[pkg]
+	return pkgSchema, ok
+}
@@ -0,0 +1,43 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+// Provider represents an explicit provider instance inside of a program, e.g.
+//
+//     provider usEast1 "aws" {
+//         region = "us-east-1"
+//     }
+//
+// A provider is bound as a resource of type `pulumi:providers:<package>` whose inputs are the package's configuration
+// variables. Other resources use the provider by referring to it in their `provider` option.
+type Provider struct {
+	*Resource
+
+	// Package is the name of the package whose provider is instantiated.
+	Package string
+}
+
+// NodeResource returns the resource instantiated by the given node, if any. This is the node itself for a resource
+// node and the provider resource for an explicit provider.
+func NodeResource(n Node) (*Resource, bool) {
+	switch n := n.(type) {
+	case *Resource:
+		return n, true
+	case *Provider:
+		return n.Resource, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/texttheater/golang-levenshtein/levenshtein"
+)
+
+// QuickFix is a suggested fix for the problem described by a binder diagnostic. A fix is applied by applying all of
+// its edits to the program's source files. Fixes are available from Program.QuickFixes.
+type QuickFix struct {
+	// Title is a short, human-readable description of the fix, e.g. "Rename to 'bucket'".
+	Title string `json:"title"`
+	// Edits are the edits that make up the fix. Edits do not overlap.
+	Edits []TextEdit `json:"edits"`
+}
+
+// TextEdit replaces the text in a range of a source file with new text. An edit whose range is empty inserts the new
+// text at the start of the range.
+type TextEdit struct {
+	// Range is the range of the text to replace. Its positions are those of the diagnostic's subject.
+	Range hcl.Range `json:"range"`
+	// NewText is the replacement text.
+	NewText string `json:"newText"`
+}
+
+// QuickFixes returns the fixes suggested for the given diagnostic, which must have been reported when the program was
+// bound. Diagnostics that have no suggested fixes return nil.
+func (p *Program) QuickFixes(d *hcl.Diagnostic) []QuickFix {
+	return p.binder.fixes[d]
+}
+
+// withFixes records the given fixes for a diagnostic and returns the diagnostic.
+func (b *binder) withFixes(d *hcl.Diagnostic, fixes ...QuickFix) *hcl.Diagnostic {
+	if len(fixes) != 0 {
+		b.fixes[d] = append(b.fixes[d], fixes...)
+	}
+	return d
+}
+
+// replaceFix returns a fix that replaces the text in the given range.
+func replaceFix(title string, rng hcl.Range, newText string) QuickFix {
+	return QuickFix{
+		Title: title,
+		Edits: []TextEdit{{Range: rng, NewText: newText}},
+	}
+}
+
+// useTokenFix returns a fix that replaces a quoted token with the suggested token, if any.
+func useTokenFix(suggestion string, tokenRange hcl.Range) []QuickFix {
+	if suggestion == "" {
+		return nil
+	}
+	return []QuickFix{replaceFix(fmt.Sprintf("Change to '%s'", suggestion), tokenRange, fmt.Sprintf("%q", suggestion))}
+}
+
+// renameAttributeFix returns a fix that renames an unsupported attribute to the closest of the given property names,
+// if any is close enough to be a likely match.
+func renameAttributeFix(name string, nameRange hcl.Range, properties []string) []QuickFix {
+	// Allow at least two edits, and roughly one edit for every four characters of longer names.
+	maxDistance := 2
+	if d := len(name) / 4; d > maxDistance {
+		maxDistance = d
+	}
+	options := levenshtein.Options{InsCost: 1, DelCost: 1, SubCost: 1, Matches: levenshtein.IdenticalRunes}
+
+	source := []rune(strings.ToLower(name))
+	closest, closestDistance := "", maxDistance+1
+	for _, p := range properties {
+		distance := levenshtein.DistanceForStrings(source, []rune(strings.ToLower(p)), options)
+		if distance < closestDistance || distance == closestDistance && p < closest {
+			closest, closestDistance = p, distance
+		}
+	}
+	if closest == "" {
+		return nil
+	}
+	return []QuickFix{replaceFix(fmt.Sprintf("Rename to '%s'", closest), nameRange, closest)}
+}
+
+// insertAttributeFix returns a fix that inserts an attribute with the given name and a placeholder value of the given
+// type at the end of the given top-level block.
+func insertAttributeFix(name string, typ model.Type, block *hclsyntax.Block) QuickFix {
+	attr := fmt.Sprintf("\t%s = %s\n", name, placeholderValue(typ))
+
+	// If the block's braces share a line, the attribute begins on a line of its own. Otherwise, it is inserted at the
+	// start of the line that holds the closing brace.
+	closeBrace := block.CloseBraceRange.Start
+	if closeBrace.Line == block.OpenBraceRange.Start.Line {
+		attr = "\n" + attr
+	} else {
+		closeBrace = hcl.Pos{Line: closeBrace.Line, Column: 1, Byte: closeBrace.Byte - (closeBrace.Column - 1)}
+	}
+	rng := hcl.Range{Filename: block.CloseBraceRange.Filename, Start: closeBrace, End: closeBrace}
+	return replaceFix(fmt.Sprintf("Add attribute '%s'", name), rng, attr)
+}
+
+// placeholderValue returns the source text of a placeholder value of the given type.
+func placeholderValue(t model.Type) string {
+	switch t := model.ResolveOutputs(t).(type) {
+	case *model.UnionType:
+		for _, e := range t.ElementTypes {
+			if e != model.NoneType {
+				return placeholderValue(e)
+			}
+		}
+	case *model.EnumType:
+		if len(t.Elements) != 0 {
+			return formatEnumValue(t.Elements[0])
+		}
+	case *model.ListType, *model.SetType, *model.TupleType:
+		return "[]"
+	case *model.MapType, *model.ObjectType:
+		return "{}"
+	default:
+		switch t {
+		case model.StringType:
+			return `""`
+		case model.IntType, model.NumberType:
+			return "0"
+		case model.BoolType:
+			return "false"
+		}
+	}
+	return "null"
+}
+
+// changeTypeFix returns a fix that replaces a quoted type label with the given type, if the type can be written as a
+// type expression.
+func changeTypeFix(typ model.Type, typeRange hcl.Range) []QuickFix {
+	var printer model.TypePrinter
+	text := printer.Print(typ)
+	if parsed, diags := model.BindExpressionText(text, model.TypeScope, typeRange.Start); diags.HasErrors() ||
+		!parsed.Type().Equals(typ) {
+		return nil
+	}
+	return []QuickFix{replaceFix(fmt.Sprintf("Change type to '%s'", text), typeRange, fmt.Sprintf("%q", text))}
+}
+
+// unsetProperties returns the sorted names of the given properties that are not set.
+func unsetProperties(properties map[string]model.Type, set codegen.StringSet) []string {
+	var names []string
+	for _, k := range codegen.SortedKeys(properties) {
+		if !set.Has(k) {
+			names = append(names, k)
+		}
+	}
+	return names
+}
@@ -0,0 +1,111 @@
+package hcl2
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// applyQuickFix applies the edits of a fix to the given source text.
+func applyQuickFix(text string, fix QuickFix) string {
+	for i := len(fix.Edits) - 1; i >= 0; i-- {
+		e := fix.Edits[i]
+		text = text[:e.Range.Start.Byte] + e.NewText + text[e.Range.End.Byte:]
+	}
+	return text
+}
+
+func TestQuickFixes(t *testing.T) {
+	const component = `component vpc {
+	inputs {
+		cidrBlock = string
+		enableDnsSupport = bool
+	}
+	resources {
+		resource vpc "aws:ec2:Vpc" {
+			cidrBlock = cidrBlock
+			enableDnsSupport = enableDnsSupport
+		}
+	}
+}
+`
+
+	// Each case lists the titles of the fixes suggested for the program's diagnostics in order. The named fix, or the
+	// first fix if none is named, is applied to produce the expected text.
+	cases := []struct {
+		name     string
+		text     string
+		titles   []string
+		fix      string
+		expected string
+	}{
+		{
+			name:     "unknown resource type",
+			text:     "resource bucket \"aws:s3:Bukcet\" {\n}\n",
+			titles:   []string{"Change to 'aws:s3/bucket:Bucket'"},
+			expected: "resource bucket \"aws:s3/bucket:Bucket\" {\n}\n",
+		},
+		{
+			name:     "unknown function",
+			text:     "output region {\n\tvalue = invoke(\"aws:index:getRegon\", {}).name\n}\n",
+			titles:   []string{"Change to 'aws:index/getRegion:getRegion'"},
+			expected: "output region {\n\tvalue = invoke(\"aws:index/getRegion:getRegion\", {}).name\n}\n",
+		},
+		{
+			name:     "config type mismatch",
+			text:     "config \"aws:maxRetries\" \"list(string)\" {\n}\n",
+			titles:   []string{"Change type to 'int'"},
+			expected: "config \"aws:maxRetries\" \"int\" {\n}\n",
+		},
+		{
+			name: "misspelled input",
+			text: component + "resource main \"vpc\" {\n\tcidrBlock = \"10.0.0.0/16\"\n" +
+				"\tenableDnsSuport = true\n}\n",
+			titles: []string{"Add attribute 'enableDnsSupport'", "Rename to 'enableDnsSupport'"},
+			fix:    "Rename to 'enableDnsSupport'",
+			expected: component + "resource main \"vpc\" {\n\tcidrBlock = \"10.0.0.0/16\"\n" +
+				"\tenableDnsSupport = true\n}\n",
+		},
+		{
+			name:     "missing input",
+			text:     component + "resource main \"vpc\" {\n\tenableDnsSupport = true\n}\n",
+			titles:   []string{"Add attribute 'cidrBlock'"},
+			expected: component + "resource main \"vpc\" {\n\tenableDnsSupport = true\n\tcidrBlock = \"\"\n}\n",
+		},
+		{
+			name:     "missing input in empty block",
+			text:     component + "resource main \"vpc\" {}\n",
+			titles:   []string{"Add attribute 'cidrBlock'", "Add attribute 'enableDnsSupport'"},
+			expected: component + "resource main \"vpc\" {\n\tcidrBlock = \"\"\n}\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			program, diags := bindTestProgram(t, c.text)
+
+			apply := c.fix
+			if apply == "" {
+				apply = c.titles[0]
+			}
+
+			var titles []string
+			fixed := c.text
+			for _, d := range diags {
+				for _, fix := range program.QuickFixes(d) {
+					titles = append(titles, fix.Title)
+					if fix.Title == apply {
+						fixed = applyQuickFix(c.text, fix)
+					}
+				}
+			}
+			assert.Equal(t, c.titles, titles)
+
+			assert.Equal(t, c.expected, fixed)
+		})
+	}
+
+	// Diagnostics that were not reported by the binder have no fixes.
+	program, _ := bindTestProgram(t, component)
+	assert.Nil(t, program.QuickFixes(&hcl.Diagnostic{}))
+}
@@ -0,0 +1,65 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+)
+
+// Report records the program's resources, its dynamically-typed top-level values, and any top-level items that could
+// not be parsed in the given conversion report. Program generators call Report before generating code, and then record
+// each construct they cannot translate with UnsupportedConstruct.Report. A nil report is ignored.
+func (p *Program) Report(report *codegen.ConversionReport) {
+	if report == nil {
+		return
+	}
+
+	for _, file := range p.files {
+		for i := range file.Unparsed {
+			report.AddUnsupported("top-level item could not be parsed", &file.Unparsed[i])
+		}
+	}
+
+	for _, n := range p.Nodes {
+		switch n := n.(type) {
+		case *Resource:
+			rng := n.syntax.DefRange()
+			if n.InputType == model.DynamicType {
+				token, _ := getResourceToken(n)
+				report.AddResource(fmt.Sprintf("resource %s has unknown type %q", n.Name(), token), &rng, true)
+				continue
+			}
+			report.AddResource(fmt.Sprintf("resource %s", n.Name()), &rng, false)
+
+			for _, item := range n.Inputs {
+				reportDynamicExpression(report, fmt.Sprintf("input %s of resource %s", item.Name, n.Name()), item.Value)
+			}
+		case *LocalVariable:
+			reportDynamicExpression(report, fmt.Sprintf("local variable %s", n.Name()), n.Definition.Value)
+		case *OutputVariable:
+			reportDynamicExpression(report, fmt.Sprintf("output %s", n.Name()), n.Value)
+		}
+	}
+}
+
+func reportDynamicExpression(report *codegen.ConversionReport, summary string, expr model.Expression) {
+	if expr == nil || expr.Type() != model.DynamicType {
+		return
+	}
+	report.AddDynamicExpression(summary+" has a dynamic type", sourceRange(expr.SyntaxNode()))
+}
@@ -0,0 +1,81 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+)
+
+func TestProgramReport(t *testing.T) {
+	program, diags := bindTestProgram(t, `
+resource bucket "aws:s3:Bucket" {
+	website = {
+		indexDocument = "index.html"
+	}
+}
+
+resource widget "aws:s3:Widget" {
+	size = 42
+}
+
+widgetSize = widget.size
+
+output bucketName {
+	value = bucket.id
+}
+`)
+	assert.True(t, diags.HasErrors())
+
+	report := &codegen.ConversionReport{}
+	program.Report(report)
+
+	assert.Equal(t, 2, report.ResourceCount)
+	assert.Equal(t, 1, report.ConvertedResourceCount)
+	if assert.Len(t, report.UnresolvedResources, 1) {
+		assert.Equal(t, `resource widget has unknown type "aws:s3:Widget"`, report.UnresolvedResources[0].Summary)
+		assert.Equal(t, 7, report.UnresolvedResources[0].Location.StartLine)
+	}
+	if assert.Len(t, report.DynamicExpressions, 1) {
+		assert.Equal(t, "local variable widgetSize has a dynamic type", report.DynamicExpressions[0].Summary)
+	}
+	assert.Len(t, report.Unsupported, 0)
+	assert.Equal(t, 0, report.TODOCount)
+
+	widgetSize, ok := program.Nodes[2].(*LocalVariable)
+	if !assert.True(t, ok) {
+		return
+	}
+	program.NewUnsupportedConstruct(widgetSize.Definition.Value, "traversals are not supported").Report(report)
+	if assert.Len(t, report.Unsupported, 1) {
+		assert.Equal(t, "traversals are not supported: widget.size", report.Unsupported[0].Summary)
+		assert.Equal(t, 11, report.Unsupported[0].Location.StartLine)
+	}
+	assert.Equal(t, 1, report.TODOCount)
+
+	bytes, err := report.JSON()
+	assert.NoError(t, err)
+
+	var roundTripped codegen.ConversionReport
+	assert.NoError(t, json.Unmarshal(bytes, &roundTripped))
+	assert.Equal(t, report, &roundTripped)
+
+	// A nil report is ignored.
+	program.Report(nil)
+}
@@ -15,10 +15,13 @@
 package hcl2
 
 import (
+	"time"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // ResourceOptions represents a resource instantiation's options.
@@ -32,12 +35,93 @@ type ResourceOptions struct {
 	Parent model.Expression
 	// The provider to use.
 	Provider model.Expression
+	// The provider resource referenced by the provider option, if the option refers to a provider in the program.
+	ProviderResource *Resource
 	// The explicit dependencies of the resource.
 	DependsOn model.Expression
 	// Whether or not the resource is protected.
 	Protect model.Expression
 	// A list of properties that are not considered when diffing the resource.
 	IgnoreChanges model.Expression
+	// A list of URNs and alias objects that name the resource's previous identities. This is always a list literal.
+	Aliases model.Expression
+	// The timeouts for the resource's create, update, and delete operations. This is always an object literal whose
+	// values are duration strings; CustomTimeouts returns the timeouts it sets.
+	CustomTimeouts model.Expression
+	// Whether or not the resource must be deleted before it is replaced.
+	DeleteBeforeReplace model.Expression
+	// The version of the resource's package to use. The program is bound against the schema for this version.
+	Version model.Expression
+}
+
+// IsStaticResourceList returns true if the given expression is a list literal of individual resource references, e.g.
+// the value of a dependsOn option that does not contain for expressions, conditionals, or ranged resources. Code
+// generators may emit such lists directly; computed lists typically require additional lowering.
+func IsStaticResourceList(expr model.Expression) bool {
+	tuple, ok := expr.(*model.TupleConsExpression)
+	if !ok {
+		return false
+	}
+	for _, item := range tuple.Expressions {
+		switch model.ResolveOutputs(item.Type()).(type) {
+		case *model.ListType, *model.TupleType, *model.UnionType:
+			return false
+		}
+	}
+	return true
+}
+
+// aliasType is the type of the alias objects in a resource's aliases option. An alias object names a previous identity
+// of the resource by the parts of its URN that differ from the resource's current URN.
+var aliasType = model.NewObjectType(map[string]model.Type{
+	"name":    model.NewOptionalType(model.StringType),
+	"type":    model.NewOptionalType(model.StringType),
+	"parent":  model.NewOptionalType(model.DynamicType),
+	"stack":   model.NewOptionalType(model.StringType),
+	"project": model.NewOptionalType(model.StringType),
+})
+
+// customTimeoutsType is the type of a resource's customTimeouts option.
+var customTimeoutsType = model.NewObjectType(map[string]model.Type{
+	"create": model.NewOptionalType(model.StringType),
+	"update": model.NewOptionalType(model.StringType),
+	"delete": model.NewOptionalType(model.StringType),
+})
+
+// CustomTimeout is one of the timeouts set by a resource's customTimeouts option.
+type CustomTimeout struct {
+	// The operation the timeout applies to: one of "create", "update", or "delete".
+	Operation string
+	// The timeout as written in the program, e.g. "5m".
+	Value string
+	// The parsed timeout.
+	Duration time.Duration
+}
+
+// CustomTimeouts returns the timeouts set by a bound customTimeouts option in the order in which they are written.
+func CustomTimeouts(expr model.Expression) []CustomTimeout {
+	obj, ok := expr.(*model.ObjectConsExpression)
+	if !ok {
+		return nil
+	}
+
+	var timeouts []CustomTimeout
+	for _, item := range obj.Items {
+		key, ok := item.Key.(*model.LiteralValueExpression)
+		if !ok || key.Value.Type() != cty.String {
+			continue
+		}
+		value, ok := extractStringValue(item.Value)
+		if !ok {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			continue
+		}
+		timeouts = append(timeouts, CustomTimeout{Operation: key.Value.AsString(), Value: value, Duration: duration})
+	}
+	return timeouts
 }
 
 // Resource represents a resource instantiation inside of a program or component.
@@ -49,8 +133,10 @@ type Resource struct {
 	// The definition of the resource.
 	Definition *model.Block
 
-	// Token is the type token for this resource.
+	// Token is the type token for this resource. For an instance of a component, this is the component's name.
 	Token string
+	// The component instantiated by the resource, if any.
+	Component *Component
 
 	// The type of the resource's inputs. This will always be either Any or an object type.
 	InputType model.Type
@@ -90,6 +176,12 @@ func (r *Resource) Name() string {
 	return r.Definition.Labels[0]
 }
 
+// HasMapRange returns true if the resource is instantiated once per entry of a map or object. The resource variable of
+// such a resource is a map of resources with the same keys as the range rather than a list of resources.
+func (r *Resource) HasMapRange() bool {
+	return r.Options != nil && r.Options.Range != nil && isMapRange(r.Options.Range.Type())
+}
+
 // DecomposeToken attempts to decompose the resource's type token into its package, module, and type. If decomposition
 // fails, a description of the failure is returned in the diagnostics.
 func (r *Resource) DecomposeToken() (string, string, string, hcl.Diagnostics) {
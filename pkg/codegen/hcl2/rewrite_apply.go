@@ -608,27 +608,13 @@ func (ctx *inspectContext) PostVisit(expr model.Expression) (model.Expression, h
 //
 // As an example, assuming that resource.id is an output, this transforms the following expression:
 //
-//     toJSON({
-//         Version = "2012-10-17"
-//         Statement = [{
-//             Effect = "Allow"
-//             Principal = "*"
-//             Action = [ "s3:GetObject" ]
-//             Resource = [ "arn:aws:s3:::${resource.id}/*" ]
-//         }]
-//     })
+//     [ "arn:aws:s3:::${resource.id}/*" ]
 //
 // into this expression:
 //
-//     __apply(resource.id, eval(id, toJSON({
-//         Version = "2012-10-17"
-//         Statement = [{
-//             Effect = "Allow"
-//             Principal = "*"
-//             Action = [ "s3:GetObject" ]
-//             Resource = [ "arn:aws:s3:::${id}/*" ]
-//         }]
-//     })))
+//     [ __apply(resource.id, eval(id, "arn:aws:s3:::${id}/*")) ]
+//
+// Note that the tuple itself does not observe the output: only the template expression inside of it does.
 //
 // Here is a more advanced example, assuming that resource is an object whose properties are all outputs, this
 // expression:
@@ -88,15 +88,16 @@ func TestApplyRewriter(t *testing.T) {
 											Resource = [ "arn:aws:s3:::${resource.id}/*" ]
 										}]
 									})`,
-			output: `__apply(resource.id,eval(id, toJSON({
+			output: `toJSON({
 										Version = "2012-10-17"
 										Statement = [{
 											Effect = "Allow"
 											Principal = "*"
 											Action = [ "s3:GetObject" ]
-											Resource = [ "arn:aws:s3:::${id}/*" ]
+											Resource = [
+                __apply(resource.id,eval(id,  "arn:aws:s3:::${id}/*")) ]
 										}]
-									})))`,
+									})`,
 		},
 		{
 			input:  `getPromise().property`,
@@ -0,0 +1,206 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// A Lowering describes how a Lowerer rewrites a kind of expression.
+type Lowering int
+
+const (
+	// LowerNone leaves expressions unchanged.
+	LowerNone Lowering = iota
+	// LowerToMap rewrites splat expressions and for expressions that produce lists into calls to the map intrinsic.
+	// For example, `a[*].b` is rewritten into `__map(a, eval(__item, __item.b))`, and `[for x in a: x.b if x.c]` is
+	// rewritten into `__map(a, eval(x, x.b), eval(x, x.c))`. For expressions that have a key variable, produce maps, or
+	// iterate over maps are left unchanged.
+	LowerToMap
+	// LowerToTemporary replaces expressions with references to temporaries whose values are the replaced expressions.
+	// Program generators must define the temporaries before the code that uses them.
+	LowerToTemporary
+)
+
+// LoweringOptions selects the kinds of expressions that a Lowerer rewrites and how it rewrites them. A program
+// generator requests the lowerings for the constructs that its target language cannot express directly, e.g. a
+// language without a conditional operator lowers conditional expressions to temporaries.
+type LoweringOptions struct {
+	// SplatExpressions determines how splat expressions are lowered.
+	SplatExpressions Lowering
+	// ForExpressions determines how for expressions are lowered.
+	ForExpressions Lowering
+	// ConditionalExpressions determines how conditional expressions are lowered. Conditional expressions may not be
+	// lowered to calls to the map intrinsic.
+	ConditionalExpressions Lowering
+}
+
+// A Temporary is a variable introduced by a Lowerer to hold the value of an expression that it lowered.
+type Temporary struct {
+	// The name of the temporary.
+	Name string
+	// The expression whose value the temporary holds.
+	Value model.Expression
+}
+
+// Type returns the type of the temporary.
+func (t *Temporary) Type() model.Type {
+	return t.Value.Type()
+}
+
+func (t *Temporary) Traverse(traverser hcl.Traverser) (model.Traversable, hcl.Diagnostics) {
+	return t.Type().Traverse(traverser)
+}
+
+func (t *Temporary) SyntaxNode() hclsyntax.Node {
+	return syntax.None
+}
+
+// A Lowerer rewrites splat, for, and conditional expressions into simpler forms so that program generators need not
+// implement the rewrites themselves. Temporaries are numbered across calls to Lower, so a single Lowerer should be used
+// for each program in order to give each temporary a unique name.
+type Lowerer struct {
+	options LoweringOptions
+	counts  map[string]int
+}
+
+// NewLowerer creates a new Lowerer with the given options.
+func NewLowerer(options LoweringOptions) *Lowerer {
+	contract.Assert(options.ConditionalExpressions != LowerToMap)
+
+	return &Lowerer{
+		options: options,
+		counts:  map[string]int{},
+	}
+}
+
+// Lower rewrites the given expression according to the Lowerer's options. Expressions are rewritten from the inside
+// out, so the temporaries for nested expressions precede the temporaries for the expressions that contain them. Lower
+// returns the rewritten expression and the temporaries that it introduced, in the order in which they must be defined.
+func (l *Lowerer) Lower(x model.Expression) (model.Expression, []*Temporary, hcl.Diagnostics) {
+	var temps []*Temporary
+	spill := func(prefix string, x model.Expression) model.Expression {
+		temp := &Temporary{
+			Name:  fmt.Sprintf("%s%d", prefix, l.counts[prefix]),
+			Value: x,
+		}
+		l.counts[prefix]++
+		temps = append(temps, temp)
+
+		return &model.ScopeTraversalExpression{
+			RootName:  temp.Name,
+			Traversal: hcl.Traversal{hcl.TraverseRoot{Name: temp.Name}},
+			Parts:     []model.Traversable{temp},
+		}
+	}
+
+	rewriter := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		switch x := x.(type) {
+		case *model.SplatExpression:
+			switch l.options.SplatExpressions {
+			case LowerToMap:
+				return lowerSplatToMap(x), nil
+			case LowerToTemporary:
+				return spill("splat", x), nil
+			}
+		case *model.ForExpression:
+			switch l.options.ForExpressions {
+			case LowerToMap:
+				if m, ok := lowerForToMap(x); ok {
+					return m, nil
+				}
+			case LowerToTemporary:
+				return spill("for", x), nil
+			}
+		case *model.ConditionalExpression:
+			if l.options.ConditionalExpressions == LowerToTemporary {
+				return spill("tmp", x), nil
+			}
+		}
+		return x, nil
+	}
+
+	x, diags := model.VisitExpression(x, model.IdentityVisitor, rewriter)
+	return x, temps, diags
+}
+
+// lowerSplatToMap rewrites a splat expression into a call to the map intrinsic whose function takes the item being
+// processed as its parameter.
+func lowerSplatToMap(x *model.SplatExpression) model.Expression {
+	item := &model.Variable{
+		Name:         "__item",
+		VariableType: x.Item.Type(),
+	}
+
+	// Replace references to the splat's item with references to the function's parameter.
+	each, diags := model.VisitExpression(x.Each, model.IdentityVisitor,
+		func(expr model.Expression) (model.Expression, hcl.Diagnostics) {
+			traversal, ok := expr.(*model.ScopeTraversalExpression)
+			if !ok || traversal.Parts[0] != model.Traversable(x.Item) {
+				return expr, nil
+			}
+
+			parts := append([]model.Traversable{item}, traversal.Parts[1:]...)
+			return &model.ScopeTraversalExpression{
+				RootName:  item.Name,
+				Traversal: hcl.TraversalJoin(hcl.Traversal{hcl.TraverseRoot{Name: item.Name}}, traversal.Traversal[1:]),
+				Parts:     parts,
+			}, nil
+		})
+	contract.Assert(len(diags) == 0)
+
+	return NewMapCall(x.Source, newLoweredFunction(item, each), nil, x.Type())
+}
+
+// lowerForToMap rewrites a for expression that produces a list from a list into a call to the map intrinsic. The
+// expression's value variable becomes the parameter of the function and its filter, if any. lowerForToMap returns
+// false if the expression cannot be rewritten.
+func lowerForToMap(x *model.ForExpression) (model.Expression, bool) {
+	if x.KeyVariable != nil || x.Key != nil || x.Group {
+		return nil, false
+	}
+	switch x.Collection.Type().(type) {
+	case *model.ListType, *model.TupleType:
+	default:
+		return nil, false
+	}
+
+	var filter *model.AnonymousFunctionExpression
+	if x.Condition != nil {
+		filter = newLoweredFunction(x.ValueVariable, x.Condition)
+	}
+	return NewMapCall(x.Collection, newLoweredFunction(x.ValueVariable, x.Value), filter, x.Type()), true
+}
+
+// newLoweredFunction returns an anonymous function of a single parameter with the given body.
+func newLoweredFunction(parameter *model.Variable, body model.Expression) *model.AnonymousFunctionExpression {
+	return &model.AnonymousFunctionExpression{
+		Signature: model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: parameter.Name,
+				Type: parameter.Type(),
+			}},
+			ReturnType: body.Type(),
+		},
+		Parameters: []*model.Variable{parameter},
+		Body:       body,
+	}
+}
@@ -0,0 +1,145 @@
+package hcl2
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLowerer(t *testing.T) {
+	type temp struct {
+		name, value string
+	}
+
+	toMap := LoweringOptions{
+		SplatExpressions: LowerToMap,
+		ForExpressions:   LowerToMap,
+	}
+	toTemporary := LoweringOptions{
+		SplatExpressions:       LowerToTemporary,
+		ForExpressions:         LowerToTemporary,
+		ConditionalExpressions: LowerToTemporary,
+	}
+
+	cases := []struct {
+		input, output string
+		options       LoweringOptions
+		temps         []temp
+	}{
+		{
+			input:   `a[*].b`,
+			output:  `a[*].b`,
+			options: LoweringOptions{},
+		},
+		{
+			input:   `a[*].b`,
+			output:  `__map(a, eval(__item, __item.b))`,
+			options: toMap,
+		},
+		{
+			input:   `[for x in a: x.b]`,
+			output:  `__map( a,eval(x,  x.b))`,
+			options: toMap,
+		},
+		{
+			input:   `[for x in a: x.b if x.c]`,
+			output:  `__map( a,eval(x,  x.b),eval(x,  x.c))`,
+			options: toMap,
+		},
+		{
+			input:   `[for x in a: [for y in x.d: y]]`,
+			output:  `__map( a, eval(x, __map( x.d,eval(y,  y))))`,
+			options: toMap,
+		},
+		{
+			input:   `[for k, x in a: x.b]`,
+			output:  `[for k, x in a: x.b]`,
+			options: toMap,
+		},
+		{
+			input:   `{for x in a: x.b => x.c}`,
+			output:  `{for x in a: x.b => x.c}`,
+			options: toMap,
+		},
+		{
+			input:   `x.c ? x.b : "default"`,
+			output:  `x.c ? x.b : "default"`,
+			options: toMap,
+		},
+		{
+			input:   `x.c ? a[*].b : [x.b]`,
+			output:  `tmp0`,
+			options: toTemporary,
+			temps: []temp{
+				{"splat0", ` a[*].b`},
+				{"tmp0", `x.c ? splat0 : [x.b]`},
+			},
+		},
+		{
+			input:   `{values = [for x in a: x.b if x.c]}`,
+			output:  `{values = for0}`,
+			options: toTemporary,
+			temps: []temp{
+				{"for0", ` [for x in a: x.b if x.c]`},
+			},
+		},
+	}
+
+	elementType := model.NewObjectType(map[string]model.Type{
+		"b": model.StringType,
+		"c": model.BoolType,
+		"d": model.NewListType(model.StringType),
+	})
+
+	scope := model.NewRootScope(syntax.None)
+	scope.Define("a", &model.Variable{
+		Name:         "a",
+		VariableType: model.NewListType(elementType),
+	})
+	scope.Define("x", &model.Variable{
+		Name:         "x",
+		VariableType: elementType,
+	})
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			expr, diags := model.BindExpressionText(c.input, scope, hcl.Pos{})
+			assert.Len(t, diags, 0)
+
+			expr, temps, diags := NewLowerer(c.options).Lower(expr)
+			assert.Len(t, diags, 0)
+			assert.Equal(t, c.output, fmt.Sprintf("%v", expr))
+
+			actual := make([]temp, len(temps))
+			for i, t := range temps {
+				actual[i] = temp{t.Name, fmt.Sprintf("%v", t.Value)}
+			}
+			if len(c.temps) == 0 {
+				c.temps = []temp{}
+			}
+			assert.Equal(t, c.temps, actual)
+		})
+	}
+}
+
+func TestLowererNumbersTemporaries(t *testing.T) {
+	scope := model.NewRootScope(syntax.None)
+	scope.Define("c", &model.Variable{
+		Name:         "c",
+		VariableType: model.BoolType,
+	})
+
+	lowerer := NewLowerer(LoweringOptions{ConditionalExpressions: LowerToTemporary})
+	for i := 0; i < 3; i++ {
+		expr, diags := model.BindExpressionText(`c ? "a" : "b"`, scope, hcl.Pos{})
+		assert.Len(t, diags, 0)
+
+		expr, temps, _ := lowerer.Lower(expr)
+		assert.Equal(t, fmt.Sprintf("tmp%d", i), fmt.Sprintf("%v", expr))
+		assert.Len(t, temps, 1)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// RewriteSecrets wraps each value within an expression that is assigned to a location of a secret type in a call to
+// the `secret` builtin, unless the value is already secret. Values are found by descending into object and tuple
+// construction expressions. For example, if the `masterPassword` property of a resource is secret, RewriteSecrets
+// rewrites the value of the property in
+//
+//     resource cluster "aws:rds:Cluster" {
+//         masterPassword = "foobar"
+//     }
+//
+// to `secret("foobar")`, so that programs generated from the result do not store the password in plaintext.
+func RewriteSecrets(x model.Expression, to model.Type) model.Expression {
+	if model.IsSecretType(to) {
+		if model.IsSecretType(x.Type()) {
+			return x
+		}
+		return newSecretCall(x)
+	}
+
+	switch x := x.(type) {
+	case *model.ObjectConsExpression:
+		for i := range x.Items {
+			item := &x.Items[i]
+
+			var traverser hcl.Traverser
+			if lit, ok := item.Key.(*model.LiteralValueExpression); ok {
+				traverser = hcl.TraverseIndex{Key: lit.Value}
+			} else {
+				traverser = model.MakeTraverser(model.StringType)
+			}
+			valueType, diags := to.Traverse(traverser)
+			contract.Ignore(diags)
+
+			item.Value = RewriteSecrets(item.Value, valueType.(model.Type))
+		}
+		diags := x.Typecheck(false)
+		contract.Assert(len(diags) == 0)
+	case *model.TupleConsExpression:
+		for i := range x.Expressions {
+			valueType, diags := to.Traverse(hcl.TraverseIndex{Key: cty.NumberIntVal(int64(i))})
+			contract.Ignore(diags)
+
+			x.Expressions[i] = RewriteSecrets(x.Expressions[i], valueType.(model.Type))
+		}
+		diags := x.Typecheck(false)
+		contract.Assert(len(diags) == 0)
+	}
+	return x
+}
+
+// newSecretCall returns a call to the `secret` builtin with the given argument.
+func newSecretCall(x model.Expression) *model.FunctionCallExpression {
+	args := []model.Expression{x}
+	signature, diags := pulumiBuiltins["secret"].GetSignature(args)
+	contract.Assert(len(diags) == 0)
+
+	call := &model.FunctionCallExpression{
+		Name:      "secret",
+		Signature: signature,
+		Args:      args,
+	}
+	diags = call.Typecheck(false)
+	contract.Assert(len(diags) == 0)
+	return call
+}
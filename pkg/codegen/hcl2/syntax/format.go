@@ -0,0 +1,65 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syntax
+
+import (
+	"bytes"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Format returns the canonical formatting of the given PCL source text. Formatting is deterministic and idempotent:
+//
+// - each line is indented by two spaces per level of nesting,
+// - tokens are separated by the standard HCL spacing, and the equals signs of adjacent attributes are aligned,
+// - runs of blank lines are collapsed into a single blank line, and
+// - leading blank lines are removed and the text ends with a single newline.
+//
+// Comments and the contents of heredoc templates are preserved. Format does not check the source text for syntax
+// errors; text that does not lex is returned with as much formatting applied as possible.
+func Format(src []byte) []byte {
+	formatted := hclwrite.Format(src)
+
+	tokens, diags := hclsyntax.LexConfig(formatted, "", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return formatted
+	}
+
+	var buf bytes.Buffer
+	offset := 0
+	for _, t := range tokens {
+		end := t.Range.End.Byte
+		if t.Type == hclsyntax.TokenNewline {
+			// Drop the newline, along with any whitespace that precedes it, if it would begin the text or a second
+			// consecutive blank line.
+			if buf.Len() == 0 || bytes.HasSuffix(buf.Bytes(), []byte("\n\n")) {
+				offset = end
+				continue
+			}
+		}
+		buf.Write(formatted[offset:end])
+		offset = end
+	}
+	buf.Write(formatted[offset:])
+
+	// End the text with exactly one newline.
+	text := bytes.TrimRight(buf.Bytes(), "\n")
+	if len(text) == 0 {
+		return nil
+	}
+	return append(text, '\n')
+}
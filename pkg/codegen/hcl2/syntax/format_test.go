@@ -0,0 +1,74 @@
+package syntax
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{name: "empty"},
+		{name: "blank lines", src: "\n\n\n", expected: ""},
+		{
+			name:     "spacing",
+			src:      "resource  bucket \"aws:s3:Bucket\"{\nwebsite={indexDocument=\"index.html\"}\n}",
+			expected: "resource bucket \"aws:s3:Bucket\" {\n  website = { indexDocument = \"index.html\" }\n}\n",
+		},
+		{
+			name:     "alignment",
+			src:      "config region string {\n\tdefault = \"us-west-2\"\n\tdescription = \"The region\"\n}\n",
+			expected: "config region string {\n  default     = \"us-west-2\"\n  description = \"The region\"\n}\n",
+		},
+		{
+			name:     "collapse blank lines",
+			src:      "\n\n// A bucket.\n\n\n\nresource bucket \"aws:s3:Bucket\" {\n\n\n\n\tacl = \"private\"\n}\n\n\n",
+			expected: "// A bucket.\n\nresource bucket \"aws:s3:Bucket\" {\n\n  acl = \"private\"\n}\n",
+		},
+		{
+			name:     "heredoc",
+			src:      "output text {\nvalue = <<EOT\na\n\n\n\n    b\nEOT\n}\n",
+			expected: "output text {\n  value = <<EOT\na\n\n\n\n    b\nEOT\n}\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := Format([]byte(c.src))
+			assert.Equal(t, c.expected, string(actual))
+			assert.Equal(t, c.expected, string(Format(actual)))
+		})
+	}
+}
+
+func TestFormatTestdata(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "internal", "test", "testdata", "*.pp"))
+	if err != nil {
+		t.Fatalf("could not list test programs: %v", err)
+	}
+	for _, path := range files {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			contents, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("could not read %v: %v", path, err)
+			}
+
+			// Formatting must be idempotent and must produce a program that parses.
+			formatted := Format(contents)
+			assert.Equal(t, string(formatted), string(Format(formatted)))
+
+			parser := NewParser()
+			err = parser.ParseFile(bytes.NewReader(formatted), filepath.Base(path))
+			assert.NoError(t, err)
+			assert.False(t, parser.Diagnostics.HasErrors(), "%v", parser.Diagnostics)
+			assert.True(t, strings.HasSuffix(string(formatted), "\n"))
+		})
+	}
+}
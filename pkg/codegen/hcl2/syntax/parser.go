@@ -24,10 +24,11 @@ import (
 
 // File represents a single parsed HCL2 source file.
 type File struct {
-	Name   string          // The name of the file.
-	Body   *hclsyntax.Body // The body of the parsed file.
-	Bytes  []byte          // The raw bytes of the source file.
-	Tokens TokenMap        // A map from syntax nodes to token information.
+	Name     string          // The name of the file.
+	Body     *hclsyntax.Body // The body of the parsed file.
+	Bytes    []byte          // The raw bytes of the source file.
+	Tokens   TokenMap        // A map from syntax nodes to token information.
+	Unparsed []hcl.Range     // The ranges of any top-level items that could not be parsed.
 }
 
 // Parser is a parser for HCL2 source files.
@@ -44,23 +45,39 @@ func NewParser() *Parser {
 
 // ParseFile attempts to parse the contents of the given io.Reader as HCL2. If parsing fails, any diagnostics generated
 // will be added to the parser's diagnostics.
+//
+// If the file contains syntax errors, the parser recovers at the granularity of top-level attributes and blocks: each
+// item that can be parsed is retained in the file's body, and the ranges of the items that cannot be parsed are
+// recorded in the file's Unparsed list. This allows callers to bind and convert as much of a program as possible.
 func (p *Parser) ParseFile(r io.Reader, filename string) error {
 	src, err := ioutil.ReadAll(r)
 	if err != nil {
 		return err
 	}
 
+	var unparsed []hcl.Range
 	hclFile, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{})
+	body := hclFile.Body.(*hclsyntax.Body)
 	if !diags.HasErrors() {
 		tokens, _ := hclsyntax.LexConfig(src, filename, hcl.Pos{})
-		mapTokens(tokens, filename, hclFile.Body.(*hclsyntax.Body), hclFile.Bytes, p.tokens, hcl.Pos{})
+		mapTokens(tokens, filename, body, hclFile.Bytes, p.tokens, hcl.Pos{})
+	} else {
+		recoveredBody, recoveredUnparsed, recoveredDiags := parseFileWithRecovery(src, filename, p.tokens)
+		body, unparsed = recoveredBody, recoveredUnparsed
+
+		// Prefer the diagnostics from recovery, as they are reported per-item. If recovery did not encounter any errors,
+		// though, the original errors must have been non-local, so report those instead.
+		if recoveredDiags.HasErrors() {
+			diags = recoveredDiags
+		}
 	}
 
 	p.Files = append(p.Files, &File{
-		Name:   filename,
-		Body:   hclFile.Body.(*hclsyntax.Body),
-		Bytes:  hclFile.Bytes,
-		Tokens: p.tokens,
+		Name:     filename,
+		Body:     body,
+		Bytes:    src,
+		Tokens:   p.tokens,
+		Unparsed: unparsed,
 	})
 	p.Diagnostics = append(p.Diagnostics, diags...)
 	return nil
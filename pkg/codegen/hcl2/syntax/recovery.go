@@ -0,0 +1,165 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syntax
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// topLevelItem describes the extent of a single top-level attribute or block in an HCL2 source file.
+type topLevelItem struct {
+	start hcl.Pos
+	end   hcl.Pos
+}
+
+// splitTopLevelItems splits the given token stream into the extents of its top-level items. Items end at newlines
+// that are not nested inside of brackets, braces, parentheses, or templates.
+//
+// In order to limit the damage caused by unbalanced delimiters, an identifier that begins a line in the first column
+// always begins a new item. Top-level items are conventionally unindented, so this allows the splitter to resynchronize
+// after an unterminated block.
+func splitTopLevelItems(tokens hclsyntax.Tokens) []topLevelItem {
+	var items []topLevelItem
+
+	depth, heredoc := 0, 0
+	start, inItem, atLineStart := hcl.Pos{}, false, true
+	var leadingComment *hcl.Pos
+	finish := func(end hcl.Pos) {
+		if inItem {
+			items = append(items, topLevelItem{start: start, end: end})
+			inItem, leadingComment = false, nil
+		}
+	}
+
+	for _, tok := range tokens {
+		switch tok.Type {
+		case hclsyntax.TokenEOF:
+			finish(tok.Range.Start)
+			return items
+		case hclsyntax.TokenNewline:
+			if depth == 0 && heredoc == 0 {
+				finish(tok.Range.End)
+			}
+			atLineStart = true
+			continue
+		case hclsyntax.TokenComment:
+			// Comments that precede an item are included in its extent so that they are preserved as trivia.
+			if !inItem && leadingComment == nil {
+				commentStart := tok.Range.Start
+				leadingComment = &commentStart
+			}
+			// Single-line comments consume their trailing newline.
+			if inItem && depth == 0 && heredoc == 0 && len(tok.Bytes) > 0 && tok.Bytes[len(tok.Bytes)-1] == '\n' {
+				finish(tok.Range.End)
+				atLineStart = true
+			}
+			continue
+		}
+
+		// Note that the first line of a file may begin at column 0.
+		if atLineStart && heredoc == 0 && tok.Type == hclsyntax.TokenIdent && tok.Range.Start.Column <= 1 {
+			finish(tok.Range.Start)
+			depth = 0
+		}
+		atLineStart = false
+
+		if !inItem {
+			start, inItem = tok.Range.Start, true
+			if leadingComment != nil {
+				start = *leadingComment
+			}
+		}
+
+		switch tok.Type {
+		case hclsyntax.TokenOBrace, hclsyntax.TokenOBrack, hclsyntax.TokenOParen, hclsyntax.TokenOQuote,
+			hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl:
+			depth++
+		case hclsyntax.TokenCBrace, hclsyntax.TokenCBrack, hclsyntax.TokenCParen, hclsyntax.TokenCQuote,
+			hclsyntax.TokenTemplateSeqEnd:
+			if depth > 0 {
+				depth--
+			}
+		case hclsyntax.TokenOHeredoc:
+			heredoc++
+		case hclsyntax.TokenCHeredoc:
+			if heredoc > 0 {
+				heredoc--
+			}
+		}
+	}
+
+	if len(tokens) > 0 {
+		finish(tokens[len(tokens)-1].Range.End)
+	}
+	return items
+}
+
+// parseFileWithRecovery parses the given source as HCL2, recovering from errors at the granularity of top-level
+// attributes and blocks. Each top-level item is parsed independently; items that fail to parse are omitted from the
+// resulting body and their extents are returned alongside the diagnostics that describe the failure. Tokens for all
+// successfully-parsed items are recorded in the given token map.
+func parseFileWithRecovery(src []byte, filename string, tokens tokenMap) (*hclsyntax.Body, []hcl.Range,
+	hcl.Diagnostics) {
+
+	// Any lexical errors will be reported when the item that contains them is parsed.
+	rawTokens, _ := hclsyntax.LexConfig(src, filename, hcl.Pos{})
+
+	body := &hclsyntax.Body{
+		Attributes: hclsyntax.Attributes{},
+		SrcRange:   hcl.Range{Filename: filename},
+	}
+	if len(rawTokens) > 0 {
+		eof := rawTokens[len(rawTokens)-1].Range
+		body.SrcRange.End, body.EndRange = eof.End, eof
+	}
+
+	var diagnostics hcl.Diagnostics
+	var skipped []hcl.Range
+	for _, item := range splitTopLevelItems(rawTokens) {
+		itemSrc := src[item.start.Byte:item.end.Byte]
+		itemRange := hcl.Range{Filename: filename, Start: item.start, End: item.end}
+
+		itemFile, itemDiags := hclsyntax.ParseConfig(itemSrc, filename, item.start)
+		diagnostics = append(diagnostics, itemDiags...)
+		if itemDiags.HasErrors() {
+			skipped = append(skipped, itemRange)
+			continue
+		}
+		itemBody := itemFile.Body.(*hclsyntax.Body)
+
+		itemTokens, _ := hclsyntax.LexConfig(itemSrc, filename, item.start)
+		mapTokens(itemTokens, filename, itemBody, itemSrc, tokens, item.start)
+
+		for name, attr := range itemBody.Attributes {
+			if existing, ok := body.Attributes[name]; ok {
+				diagnostics = append(diagnostics, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Attribute redefined",
+					Detail: fmt.Sprintf("The argument %q was already set at %s. Each argument may be set only once.",
+						name, existing.NameRange),
+					Subject: &attr.NameRange,
+				})
+				continue
+			}
+			body.Attributes[name] = attr
+		}
+		body.Blocks = append(body.Blocks, itemBody.Blocks...)
+	}
+
+	return body, skipped, diagnostics
+}
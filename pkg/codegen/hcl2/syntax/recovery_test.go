@@ -0,0 +1,84 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syntax
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFileWithRecovery(t *testing.T) {
+	const source = `// A valid resource.
+resource a "aws:s3:Bucket" {
+	bucket = "a"
+}
+
+// A resource with a syntax error.
+resource b "aws:s3:Bucket" {
+	bucket = "b" +
+}
+
+c = "c"
+
+# An unterminated block.
+resource d "aws:s3:Bucket" {
+	bucket = "d"
+
+output e {
+	value = a.bucket
+}
+`
+
+	parser := NewParser()
+	err := parser.ParseFile(strings.NewReader(source), "recovery.pp")
+	assert.NoError(t, err)
+	assert.True(t, parser.Diagnostics.HasErrors())
+
+	file := parser.Files[0]
+	assert.Len(t, file.Unparsed, 2)
+
+	var labels []string
+	for _, block := range file.Body.Blocks {
+		labels = append(labels, block.Labels[0])
+	}
+	assert.Equal(t, []string{"a", "e"}, labels)
+	assert.Contains(t, file.Body.Attributes, "c")
+
+	// Leading comments on recovered items should be preserved.
+	tokens := file.Tokens.ForNode(file.Body.Blocks[0])
+	assert.NotNil(t, tokens)
+}
+
+func TestSplitTopLevelItems(t *testing.T) {
+	const source = `a = {
+	b = 1
+}
+c = <<EOT
+d
+EOT
+resource e "f" {
+`
+
+	parser := NewParser()
+	err := parser.ParseFile(strings.NewReader(source), "split.pp")
+	assert.NoError(t, err)
+
+	file := parser.Files[0]
+	assert.Len(t, file.Unparsed, 1)
+	assert.Contains(t, file.Body.Attributes, "a")
+	assert.Contains(t, file.Body.Attributes, "c")
+}
@@ -0,0 +1,326 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// primitiveSchemaTypes maps the model types that represent primitive schema types to those schema types. Several schema
+// types share a model type (e.g. string and bytes, or any and JSON); each model type maps to the most general of them.
+var primitiveSchemaTypes = map[model.Type]schema.Type{
+	model.BoolType:    schema.BoolType,
+	model.IntType:     schema.IntType,
+	model.NumberType:  schema.NumberType,
+	model.StringType:  schema.StringType,
+	model.DynamicType: schema.AnyType,
+	ArchiveType:       schema.ArchiveType,
+	AssetType:         schema.AssetType,
+}
+
+// TypeMapper converts between schema types and model types. Schema types are converted to model types as follows:
+//
+// - primitive types are converted to the corresponding model types; int64 is converted to int, bytes to string, and
+//   JSON to dynamic
+// - array and map types are converted to list and map types
+// - object types are converted to object types that are annotated with the schema type. Optional properties have
+//   optional types, and secret properties have secret types.
+// - enum types are converted to enum types that are annotated with the schema type
+// - token types are converted to opaque types named by their tokens. If a token type has an underlying type, it is
+//   converted to the union of the opaque type and the underlying type.
+// - union types are converted to union types
+//
+// A TypeMapper remembers the schema type from which each model type it creates was converted, so SchemaType recovers
+// the exact schema type for those model types. For other model types, SchemaType reconstructs a schema type from the
+// types' structure and annotations. Unlike GetSchemaForType, which only recovers object types and the types that are
+// built from them, SchemaType also recovers primitive, enum, and token types. A TypeMapper is safe for concurrent use.
+type TypeMapper struct {
+	opaqueTypes *model.OpaqueTypeRegistry
+
+	m sync.RWMutex
+
+	// schemaTypes maps model types to the schema types from which they were converted or reconstructed. Types that have
+	// no schema type map to nil.
+	schemaTypes map[model.Type]schema.Type
+	arrayTypes  map[schema.Type]*schema.ArrayType
+	mapTypes    map[schema.Type]*schema.MapType
+}
+
+// NewTypeMapper creates a new TypeMapper that represents token types using opaque types from the given registry. If
+// the registry is nil, the mapper uses a registry of its own.
+func NewTypeMapper(opaqueTypes *model.OpaqueTypeRegistry) *TypeMapper {
+	if opaqueTypes == nil {
+		opaqueTypes = model.NewOpaqueTypeRegistry()
+	}
+	return &TypeMapper{
+		opaqueTypes: opaqueTypes,
+		schemaTypes: map[model.Type]schema.Type{},
+		arrayTypes:  map[schema.Type]*schema.ArrayType{},
+		mapTypes:    map[schema.Type]*schema.MapType{},
+	}
+}
+
+// ModelType converts a schema type to a model type.
+func (m *TypeMapper) ModelType(src schema.Type) model.Type {
+	result := m.modelType(src)
+	if _, isPrimitive := primitiveSchemaTypes[result]; !isPrimitive && result != model.NoneType {
+		m.m.Lock()
+		m.schemaTypes[result] = src
+		m.m.Unlock()
+	}
+	return result
+}
+
+// PropertyType converts the type of a schema property to a model type. If the property is secret, the result is
+// secret. If the property is not required, the result is optional.
+func (m *TypeMapper) PropertyType(prop *schema.Property) model.Type {
+	t := m.ModelType(prop.Type)
+	if prop.Secret {
+		t = model.NewSecretType(t)
+	}
+	if !prop.IsRequired {
+		t = model.NewOptionalType(t)
+	}
+	return t
+}
+
+func (m *TypeMapper) modelType(src schema.Type) model.Type {
+	switch src := src.(type) {
+	case *schema.ArrayType:
+		return model.NewListType(m.ModelType(src.ElementType))
+	case *schema.MapType:
+		return model.NewMapType(m.ModelType(src.ElementType))
+	case *schema.ObjectType:
+		properties := map[string]model.Type{}
+		for _, prop := range src.Properties {
+			properties[prop.Name] = m.PropertyType(prop)
+		}
+		return model.NewObjectType(properties, src)
+	case *schema.TokenType:
+		t := m.opaqueTypes.GetOrNew(src.Token)
+
+		if src.UnderlyingType != nil {
+			underlyingType := m.ModelType(src.UnderlyingType)
+			return model.NewUnionType(t, underlyingType)
+		}
+		return t
+	case *schema.EnumType:
+		elements := make([]cty.Value, len(src.Elements))
+		for i, e := range src.Elements {
+			elements[i] = enumValue(e.Value)
+		}
+		return model.NewEnumType(src.Token, m.ModelType(src.ElementType), elements, src)
+	case *schema.UnionType:
+		types := make([]model.Type, len(src.ElementTypes))
+		for i, src := range src.ElementTypes {
+			types[i] = m.ModelType(src)
+		}
+		return model.NewUnionType(types...)
+	default:
+		switch src {
+		case schema.BoolType:
+			return model.BoolType
+		case schema.IntType, schema.Int64Type:
+			return model.IntType
+		case schema.NumberType:
+			return model.NumberType
+		case schema.StringType, schema.BytesType:
+			return model.StringType
+		case schema.ArchiveType:
+			return ArchiveType
+		case schema.AssetType:
+			return AssetType
+		case schema.JSONType:
+			fallthrough
+		case schema.AnyType:
+			return model.DynamicType
+		default:
+			return model.NoneType
+		}
+	}
+}
+
+// SchemaType returns the schema type associated with a model type, if any. If the model type was created by ModelType,
+// the result is the schema type from which it was converted. Otherwise, the result is reconstructed as follows:
+//
+// - primitive types are converted to the corresponding schema types
+// - list and map types are converted to array and map types. Each list or map type whose element type has a schema
+//   type is converted to a single array or map type, so reconstructed schema types may be compared by identity.
+// - object and enum types are converted to the schema types with which they are annotated
+// - output, promise, and secret types are converted to the schema types of their element types
+// - union types are converted to the union of the schema types of their elements, ignoring elements that have no
+//   schema type. The result may be a *schema.UnionType if multiple schema types are associated with the union.
+func (m *TypeMapper) SchemaType(t model.Type) (schema.Type, bool) {
+	if s, ok := primitiveSchemaTypes[t]; ok {
+		return s, true
+	}
+
+	m.m.RLock()
+	s, ok := m.schemaTypes[t]
+	m.m.RUnlock()
+	if ok {
+		return s, s != nil
+	}
+
+	switch t := t.(type) {
+	case *model.ListType:
+		element, ok := m.SchemaType(t.ElementType)
+		if !ok {
+			return nil, false
+		}
+		return m.arrayType(element), true
+	case *model.MapType:
+		element, ok := m.SchemaType(t.ElementType)
+		if !ok {
+			return nil, false
+		}
+		return m.mapType(element), true
+	case *model.ObjectType:
+		return m.memoize(t, schemaAnnotation(t.Annotations))
+	case *model.EnumType:
+		return m.memoize(t, schemaAnnotation(t.Annotations))
+	case *model.OutputType:
+		return m.SchemaType(t.ElementType)
+	case *model.PromiseType:
+		return m.SchemaType(t.ElementType)
+	case *model.SecretType:
+		return m.SchemaType(t.ElementType)
+	case *model.UnionType:
+		return m.memoize(t, m.unionSchemaType(t))
+	default:
+		return nil, false
+	}
+}
+
+// SchemaProperty returns the schema property with the given name of the schema object type associated with a model
+// type, if any. The property records the information that model types do not, e.g. whether or not it is secret.
+func (m *TypeMapper) SchemaProperty(t model.Type, name string) (*schema.Property, bool) {
+	s, ok := m.SchemaType(t)
+	if !ok {
+		return nil, false
+	}
+	obj, ok := s.(*schema.ObjectType)
+	if !ok {
+		return nil, false
+	}
+	for _, prop := range obj.Properties {
+		if prop.Name == name {
+			return prop, true
+		}
+	}
+	return nil, false
+}
+
+// schemaAnnotation returns the first schema type in the given annotations, if any.
+func schemaAnnotation(annotations []interface{}) schema.Type {
+	for _, a := range annotations {
+		if t, ok := a.(schema.Type); ok {
+			return t
+		}
+	}
+	return nil
+}
+
+// unionSchemaType reconstructs the schema type of a union type.
+func (m *TypeMapper) unionSchemaType(t *model.UnionType) schema.Type {
+	schemas := codegen.Set{}
+	for _, t := range t.ElementTypes {
+		if s, ok := m.SchemaType(t); ok {
+			if union, ok := s.(*schema.UnionType); ok {
+				for _, s := range union.ElementTypes {
+					schemas.Add(s)
+				}
+			} else {
+				schemas.Add(s)
+			}
+		}
+	}
+	if len(schemas) == 0 {
+		return nil
+	}
+	schemaTypes := make([]schema.Type, 0, len(schemas))
+	for t := range schemas {
+		schemaTypes = append(schemaTypes, t.(schema.Type))
+	}
+	if len(schemaTypes) == 1 {
+		return schemaTypes[0]
+	}
+	return &schema.UnionType{ElementTypes: schemaTypes}
+}
+
+// memoize records the schema type reconstructed for the given model type. If another goroutine recorded a schema type
+// for the model type first, that schema type is returned instead.
+func (m *TypeMapper) memoize(t model.Type, s schema.Type) (schema.Type, bool) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if existing, ok := m.schemaTypes[t]; ok {
+		s = existing
+	} else {
+		m.schemaTypes[t] = s
+	}
+	return s, s != nil
+}
+
+// arrayType returns the array type with the given element type.
+func (m *TypeMapper) arrayType(element schema.Type) *schema.ArrayType {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	t, ok := m.arrayTypes[element]
+	if !ok {
+		t = &schema.ArrayType{ElementType: element}
+		m.arrayTypes[element] = t
+	}
+	return t
+}
+
+// mapType returns the map type with the given element type.
+func (m *TypeMapper) mapType(element schema.Type) *schema.MapType {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	t, ok := m.mapTypes[element]
+	if !ok {
+		t = &schema.MapType{ElementType: element}
+		m.mapTypes[element] = t
+	}
+	return t
+}
+
+// enumValue converts the value of a schema enum to a cty value.
+func enumValue(v interface{}) cty.Value {
+	switch v := v.(type) {
+	case bool:
+		return cty.BoolVal(v)
+	case int32:
+		return cty.NumberIntVal(int64(v))
+	case int64:
+		return cty.NumberIntVal(v)
+	case float64:
+		return cty.NumberFloatVal(v)
+	case string:
+		return cty.StringVal(v)
+	default:
+		contract.Failf("unexpected enum value of type %T", v)
+		return cty.NilVal
+	}
+}
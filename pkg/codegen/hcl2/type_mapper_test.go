@@ -0,0 +1,110 @@
+package hcl2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestTypeMapperRoundTrip(t *testing.T) {
+	enum := &schema.EnumType{
+		Token:       "example:index:Color",
+		ElementType: schema.StringType,
+		Elements:    []*schema.Enum{{Value: "red"}, {Value: "blue"}},
+	}
+	token := &schema.TokenType{Token: "example:index:Opaque", UnderlyingType: schema.StringType}
+	object := &schema.ObjectType{
+		Token: "example:index:Object",
+		Properties: []*schema.Property{
+			{Name: "color", Type: enum, IsRequired: true},
+			{Name: "password", Type: schema.StringType, Secret: true},
+		},
+	}
+
+	cases := []schema.Type{
+		schema.BoolType,
+		schema.IntType,
+		schema.NumberType,
+		schema.StringType,
+		schema.AnyType,
+		schema.ArchiveType,
+		schema.AssetType,
+		&schema.ArrayType{ElementType: schema.StringType},
+		&schema.MapType{ElementType: object},
+		object,
+		enum,
+		token,
+		&schema.UnionType{ElementTypes: []schema.Type{schema.StringType, object}},
+	}
+	for _, c := range cases {
+		t.Run(c.String(), func(t *testing.T) {
+			types := NewTypeMapper(nil)
+			s, ok := types.SchemaType(types.ModelType(c))
+			assert.True(t, ok)
+			assert.Equal(t, c, s)
+		})
+	}
+}
+
+func TestTypeMapperModelTypes(t *testing.T) {
+	registry := model.NewOpaqueTypeRegistry()
+	types := NewTypeMapper(registry)
+
+	// Types that share a model type map back to the most general schema type.
+	assert.Equal(t, model.IntType, types.ModelType(schema.Int64Type))
+	assert.Equal(t, model.StringType, types.ModelType(schema.BytesType))
+	assert.Equal(t, model.DynamicType, types.ModelType(schema.JSONType))
+	s, ok := types.SchemaType(model.IntType)
+	assert.True(t, ok)
+	assert.Equal(t, schema.IntType, s)
+
+	// Token types are represented by opaque types from the mapper's registry.
+	tokenType := types.ModelType(&schema.TokenType{Token: "example:index:Opaque"})
+	opaque, ok := registry.Get("example:index:Opaque")
+	assert.True(t, ok)
+	assert.Equal(t, opaque, tokenType)
+
+	// Optional properties have optional types.
+	assert.Equal(t, model.NewOptionalType(model.StringType),
+		types.PropertyType(&schema.Property{Name: "p", Type: schema.StringType}))
+	assert.Equal(t, model.StringType,
+		types.PropertyType(&schema.Property{Name: "p", Type: schema.StringType, IsRequired: true}))
+}
+
+func TestTypeMapperReconstructedTypes(t *testing.T) {
+	object := &schema.ObjectType{
+		Token: "example:index:Object",
+		Properties: []*schema.Property{
+			{Name: "name", Type: schema.StringType, IsRequired: true},
+			{Name: "password", Type: schema.StringType, Secret: true},
+		},
+	}
+
+	types := NewTypeMapper(nil)
+	objectType := types.ModelType(object)
+
+	// Types derived from converted types are associated with the same schema types.
+	input := model.InputType(objectType)
+	s, ok := types.SchemaType(input)
+	assert.True(t, ok)
+	assert.Equal(t, object, s)
+
+	list, ok := types.SchemaType(model.NewListType(input))
+	assert.True(t, ok)
+	assert.Equal(t, &schema.ArrayType{ElementType: object}, list)
+	list2, _ := types.SchemaType(model.NewListType(model.NewOutputType(objectType)))
+	assert.True(t, list == list2)
+
+	_, ok = types.SchemaType(model.NewObjectType(map[string]model.Type{}))
+	assert.False(t, ok)
+
+	// Schema properties record the information that model types do not.
+	prop, ok := types.SchemaProperty(input, "password")
+	assert.True(t, ok)
+	assert.True(t, prop.Secret)
+	_, ok = types.SchemaProperty(input, "missing")
+	assert.False(t, ok)
+}
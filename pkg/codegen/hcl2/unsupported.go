@@ -0,0 +1,124 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+)
+
+// UnsupportedConstruct describes an expression that a code generator is unable to translate. Rather than dropping the
+// expression, code generators emit a TODO comment that records the expression's source and the reason it could not be
+// translated, followed by a placeholder value, and report a diagnostic that points at the original expression.
+type UnsupportedConstruct struct {
+	// The reason the expression could not be translated.
+	Reason string
+	// The source of the expression, printed as PCL on a single line.
+	Source string
+	// The location of the expression in the original program, if any.
+	Range *hcl.Range
+}
+
+// NewUnsupportedConstruct creates a new UnsupportedConstruct for the given expression. The reason is formatted using
+// the given arguments. The construct's source is the text of the expression in the program's source files; code
+// generators typically rewrite expressions before generating them, so the rewritten expression is only printed if it
+// did not originate from a source file.
+func (p *Program) NewUnsupportedConstruct(expr model.Expression, reason string,
+	args ...interface{}) *UnsupportedConstruct {
+	rng := sourceRange(expr.SyntaxNode())
+
+	source, ok := p.sourceText(rng)
+	if !ok {
+		// Print the expression without its surrounding comments and whitespace.
+		if expr.HasLeadingTrivia() {
+			leading := expr.GetLeadingTrivia()
+			expr.SetLeadingTrivia(nil)
+			defer expr.SetLeadingTrivia(leading)
+		}
+		if expr.HasTrailingTrivia() {
+			trailing := expr.GetTrailingTrivia()
+			expr.SetTrailingTrivia(nil)
+			defer expr.SetTrailingTrivia(trailing)
+		}
+		source = fmt.Sprintf("%v", expr)
+	}
+
+	return &UnsupportedConstruct{
+		Reason: fmt.Sprintf(reason, args...),
+		Source: strings.Join(strings.Fields(source), " "),
+		Range:  rng,
+	}
+}
+
+// sourceText returns the text of the given range of the program's source files, if the range lies within one of them.
+func (p *Program) sourceText(rng *hcl.Range) (string, bool) {
+	if rng == nil {
+		return "", false
+	}
+	for _, f := range p.files {
+		if f.Name == rng.Filename && rng.Start.Byte <= rng.End.Byte && rng.End.Byte <= len(f.Bytes) {
+			return string(f.Bytes[rng.Start.Byte:rng.End.Byte]), true
+		}
+	}
+	return "", false
+}
+
+// sourceRange returns the range of the given syntax node, or nil if the node did not originate from a source file.
+func sourceRange(node hclsyntax.Node) *hcl.Range {
+	if node == nil || node == syntax.None {
+		return nil
+	}
+	if v := reflect.ValueOf(node); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	if rng := node.Range(); rng.Filename != "" {
+		return &rng
+	}
+	return nil
+}
+
+// Comment returns the text of the TODO comment for the construct. The text fits on a single line and never contains
+// the end of a block comment, so it may be used with either line or block comments.
+func (u *UnsupportedConstruct) Comment() string {
+	text := fmt.Sprintf("TODO: %s: %s", u.Reason, u.Source)
+	if u.Range != nil {
+		text = fmt.Sprintf("%s (%v)", text, u.Range)
+	}
+	return strings.Replace(text, "*/", "* /", -1)
+}
+
+// Diagnostic returns an error diagnostic that describes the construct.
+func (u *UnsupportedConstruct) Diagnostic() *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "not yet implemented: " + u.Reason,
+		Detail:   fmt.Sprintf("%s could not be translated: %s", u.Source, u.Reason),
+		Subject:  u.Range,
+	}
+}
+
+// Report records the construct and the TODO comment that replaces it in the given conversion report. A nil report is
+// ignored.
+func (u *UnsupportedConstruct) Report(report *codegen.ConversionReport) {
+	report.AddUnsupported(fmt.Sprintf("%s: %s", u.Reason, u.Source), u.Range)
+	report.AddTODO()
+}
@@ -0,0 +1,31 @@
+package hcl2
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsupportedConstruct(t *testing.T) {
+	program, diags := bindTestProgram(t, `
+siteDir = "www"
+files = readDir(siteDir) // all files
+`)
+	assert.Len(t, diags, 0)
+
+	files, ok := program.Nodes[1].(*LocalVariable)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	construct := program.NewUnsupportedConstruct(files.Definition.Value, "function %v is not supported", "readDir")
+	assert.Equal(t, "function readDir is not supported", construct.Reason)
+	assert.Equal(t, "readDir(siteDir)", construct.Source)
+	assert.Equal(t, "TODO: function readDir is not supported: readDir(siteDir) (test.pp:2,9-25)", construct.Comment())
+
+	diag := construct.Diagnostic()
+	assert.Equal(t, hcl.DiagError, diag.Severity)
+	assert.Equal(t, "not yet implemented: function readDir is not supported", diag.Summary)
+	assert.Equal(t, construct.Range, diag.Subject)
+}
@@ -0,0 +1,103 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// unusedLocals returns the local variables in the given list of nodes that are not referenced by any config, resource,
+// or output, either directly or through other local variables. Nodes that are referenced by any of the given
+// attributes, e.g. the outputs of a component, are also considered referenced.
+func unusedLocals(nodes []Node, attrs []*model.Attribute) []*LocalVariable {
+	used := codegen.Set{}
+	var markUsed func(n Node)
+	markUsed = func(n Node) {
+		if !used.Has(n) {
+			used.Add(n)
+			for _, d := range n.getDependencies() {
+				markUsed(d)
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		if _, isLocal := n.(*LocalVariable); !isLocal {
+			markUsed(n)
+		}
+	}
+	for _, attr := range attrs {
+		diags := model.VisitExpressions(attr, nil, func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+			if traversal, ok := x.(*model.ScopeTraversalExpression); ok && len(traversal.Parts) != 0 {
+				if n, ok := traversal.Parts[0].(Node); ok {
+					markUsed(n)
+				}
+			}
+			return x, nil
+		})
+		contract.Assert(len(diags) == 0)
+	}
+
+	var unused []*LocalVariable
+	for _, n := range nodes {
+		if lv, isLocal := n.(*LocalVariable); isLocal && !used.Has(lv) {
+			unused = append(unused, lv)
+		}
+	}
+	return unused
+}
+
+// removeUnusedLocals removes the unused local variables from the given list of nodes. If any locals are removed, the
+// returned diagnostics contain a warning that lists them.
+func removeUnusedLocals(nodes []Node, attrs []*model.Attribute) ([]Node, hcl.Diagnostics) {
+	unused := unusedLocals(nodes, attrs)
+	if len(unused) == 0 {
+		return nodes, nil
+	}
+
+	removed := codegen.Set{}
+	names := make([]string, len(unused))
+	for i, lv := range unused {
+		removed.Add(lv)
+		names[i] = lv.Name()
+	}
+
+	kept := make([]Node, 0, len(nodes)-len(unused))
+	for _, n := range nodes {
+		if !removed.Has(n) {
+			kept = append(kept, n)
+		}
+	}
+	return kept, hcl.Diagnostics{unusedLocalsRemoved(names)}
+}
+
+// removeAllUnusedLocals removes the unused local variables from the program's nodes and from the nodes of each of its
+// components.
+func removeAllUnusedLocals(nodes []Node) ([]Node, hcl.Diagnostics) {
+	var diagnostics hcl.Diagnostics
+	for _, n := range nodes {
+		if c, ok := n.(*Component); ok {
+			var diags hcl.Diagnostics
+			c.Nodes, diags = removeUnusedLocals(c.Nodes, c.Outputs)
+			diagnostics = append(diagnostics, diags...)
+		}
+	}
+
+	nodes, diags := removeUnusedLocals(nodes, nil)
+	return nodes, append(diagnostics, diags...)
+}
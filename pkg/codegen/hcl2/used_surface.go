@@ -0,0 +1,129 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl2
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// usedSurface records the resources and functions of a package that a set of programs use.
+type usedSurface struct {
+	schema *packageSchema
+	tokens codegen.StringSet
+}
+
+// UsedPackages returns the subset of each package used by the given programs that contains only the resources and
+// functions that the programs reference, together with the types that those refer to. Passing the subsets to a
+// language's GeneratePackage produces SDKs that contain only what the programs use, which build far faster than the
+// SDKs for the full packages. The programs must use the same version of each package.
+func UsedPackages(programs ...*Program) ([]*schema.Package, error) {
+	surfaces := map[string]*usedSurface{}
+	for _, p := range programs {
+		for name, pkg := range p.binder.referencedPackages {
+			s, ok := surfaces[name]
+			if !ok {
+				surfaces[name] = &usedSurface{schema: pkg, tokens: codegen.StringSet{}}
+				continue
+			}
+			if !sameVersion(s.schema.schema, pkg.schema) {
+				return nil, errors.Errorf("the programs use different versions of package %s", name)
+			}
+		}
+	}
+
+	for _, p := range programs {
+		for _, n := range ProgramNodes(p) {
+			if r, ok := n.(*Resource); ok {
+				pkg, module, _, diags := r.DecomposeToken()
+				if !diags.HasErrors() && (pkg != "pulumi" || module != "providers") {
+					if s, ok := surfaces[pkg]; ok {
+						if res, ok := s.schema.resources[r.Token]; ok {
+							s.tokens.Add(res.Token)
+						}
+					}
+				}
+			}
+
+			diags := n.VisitExpressions(nil, func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+				call, ok := x.(*model.FunctionCallExpression)
+				if !ok || call.Name != Invoke || len(call.Args) == 0 {
+					return x, nil
+				}
+				token, ok := invokeCallToken(call)
+				if !ok {
+					return x, nil
+				}
+				pkg, _, _, diags := DecomposeToken(token, call.Args[0].SyntaxNode().Range())
+				if diags.HasErrors() {
+					return x, nil
+				}
+				if s, ok := surfaces[pkg]; ok {
+					if fn, ok := s.schema.functions[token]; ok {
+						s.tokens.Add(fn.Token)
+					}
+				}
+				return x, nil
+			})
+			if diags.HasErrors() {
+				return nil, diags
+			}
+		}
+	}
+
+	names := make([]string, 0, len(surfaces))
+	for name := range surfaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	packages := make([]*schema.Package, 0, len(names))
+	for _, name := range names {
+		s := surfaces[name]
+		pkg, err := s.schema.schema.Filter(s.tokens.SortedValues()...)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// invokeCallToken returns the token of the function called by a bound call to invoke.
+func invokeCallToken(call *model.FunctionCallExpression) (string, bool) {
+	template, ok := call.Args[0].(*model.TemplateExpression)
+	if !ok || len(template.Parts) != 1 {
+		return "", false
+	}
+	lit, ok := template.Parts[0].(*model.LiteralValueExpression)
+	if !ok || lit.Type() != model.StringType {
+		return "", false
+	}
+	return lit.Value.AsString(), true
+}
+
+// sameVersion returns true if the two schemas are for the same version of a package.
+func sameVersion(a, b *schema.Package) bool {
+	if a.Version == nil || b.Version == nil {
+		return a.Version == b.Version
+	}
+	return a.Version.EQ(*b.Version)
+}
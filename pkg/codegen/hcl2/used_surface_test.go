@@ -0,0 +1,70 @@
+package hcl2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
+)
+
+func TestUsedPackages(t *testing.T) {
+	bind := func(text string) *Program {
+		parser := syntax.NewParser()
+		err := parser.ParseFile(strings.NewReader(text), "test.pp")
+		assert.NoError(t, err)
+		assert.False(t, parser.Diagnostics.HasErrors())
+
+		program, diags, err := BindProgram(parser.Files, PluginHost(test.NewHost(testdataPath)))
+		assert.NoError(t, err)
+		assert.False(t, diags.HasErrors())
+		return program
+	}
+
+	site := bind(`
+resource provider "pulumi:providers:aws" {
+	region = invoke("aws:index:getRegion", {}).name
+}
+
+resource bucket "aws:s3:Bucket" {
+	website = {
+		indexDocument = "index.html"
+	}
+	options {
+		provider = provider
+	}
+}
+`)
+	suffix := bind(`
+resource suffix "random:index:RandomString" {
+	length = 8
+}
+
+resource logs "aws:s3:Bucket" {
+	bucket = "logs-${suffix.result}"
+}
+`)
+
+	packages, err := UsedPackages(site, suffix)
+	assert.NoError(t, err)
+	if assert.Len(t, packages, 2) {
+		aws, random := packages[0], packages[1]
+
+		assert.Equal(t, "aws", aws.Name)
+		if assert.Len(t, aws.Resources, 1) {
+			assert.Equal(t, "aws:s3/bucket:Bucket", aws.Resources[0].Token)
+		}
+		if assert.Len(t, aws.Functions, 1) {
+			assert.Equal(t, "aws:index/getRegion:getRegion", aws.Functions[0].Token)
+		}
+		assert.NotNil(t, aws.Provider)
+
+		assert.Equal(t, "random", random.Name)
+		if assert.Len(t, random.Resources, 1) {
+			assert.Equal(t, "random:index/randomString:RandomString", random.Resources[0].Token)
+		}
+		assert.Empty(t, random.Functions)
+	}
+}
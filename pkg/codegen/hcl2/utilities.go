@@ -50,6 +50,16 @@ func DecomposeToken(tok string, sourceRange hcl.Range) (string, string, string,
 	return components[0], components[1], components[2], nil
 }
 
+// decomposeToken decomposes a token in the same way as DecomposeToken, and records the code of the diagnostic that is
+// reported if the token is malformed.
+func (b *binder) decomposeToken(tok string, sourceRange hcl.Range) (string, string, string, hcl.Diagnostics) {
+	pkg, module, member, diags := DecomposeToken(tok, sourceRange)
+	for _, d := range diags {
+		b.withCode(d, CodeMalformedToken)
+	}
+	return pkg, module, member, diags
+}
+
 func linearizeNode(n Node, done codegen.Set, list *[]Node) {
 	if !done.Has(n) {
 		for _, d := range n.getDependencies() {
@@ -135,3 +145,21 @@ func Linearize(p *Program) []Node {
 
 	return nodes
 }
+
+// LinearizeComponent performs a topological sort of the nodes inside of a component. As with Linearize, the sort is
+// stable, and nodes are kept in source order as much as possible. Components instantiated by the nodes are not
+// included in the result.
+func LinearizeComponent(c *Component) []Node {
+	doneNodes, nodes := codegen.Set{}, make([]Node, 0, len(c.Nodes))
+	for _, n := range c.Nodes {
+		for _, d := range n.getDependencies() {
+			if _, ok := d.(*Component); ok {
+				doneNodes.Add(d)
+			}
+		}
+	}
+	for _, n := range c.Nodes {
+		linearizeNode(n, doneNodes, &nodes)
+	}
+	return nodes
+}
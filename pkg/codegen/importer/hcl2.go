@@ -153,11 +153,11 @@ func typeRank(t schema.Type) int {
 	switch t {
 	case schema.BoolType:
 		return 1
-	case schema.IntType:
+	case schema.IntType, schema.Int64Type:
 		return 2
 	case schema.NumberType:
 		return 3
-	case schema.StringType:
+	case schema.StringType, schema.BytesType:
 		return 4
 	case schema.AssetType:
 		return 5
@@ -168,7 +168,9 @@ func typeRank(t schema.Type) int {
 	case schema.AnyType:
 		return 13
 	default:
-		switch t.(type) {
+		switch t := t.(type) {
+		case *schema.EnumType:
+			return typeRank(t.ElementType)
 		case *schema.TokenType:
 			return 8
 		case *schema.ArrayType:
@@ -308,6 +310,11 @@ func zeroValue(t schema.Type) model.Expression {
 			}
 		}
 		return &model.ObjectConsExpression{Items: items}
+	case *schema.EnumType:
+		// Use the first value of the enum, as the zero value of its element type may not be a value of the enum.
+		x, err := generateValue(t.ElementType, resource.NewPropertyValue(t.Elements[0].Value))
+		contract.IgnoreError(err)
+		return x
 	case *schema.TokenType:
 		if t.UnderlyingType != nil {
 			return zeroValue(t.UnderlyingType)
@@ -319,11 +326,11 @@ func zeroValue(t schema.Type) model.Expression {
 		x, err := generateValue(t, resource.NewBoolProperty(false))
 		contract.IgnoreError(err)
 		return x
-	case schema.IntType, schema.NumberType:
+	case schema.IntType, schema.Int64Type, schema.NumberType:
 		x, err := generateValue(t, resource.NewNumberProperty(0))
 		contract.IgnoreError(err)
 		return x
-	case schema.StringType:
+	case schema.StringType, schema.BytesType:
 		x, err := generateValue(t, resource.NewStringProperty(""))
 		contract.IgnoreError(err)
 		return x
@@ -386,6 +393,12 @@ func generateValue(typ schema.Type, value resource.PropertyValue) (model.Express
 		return nil, fmt.Errorf("cannot define computed values")
 	case value.IsNull():
 		return model.VariableReference(Null), nil
+	case value.IsDecimal():
+		v, err := cty.ParseNumberVal(value.DecimalValue().String())
+		if err != nil {
+			return nil, err
+		}
+		return &model.LiteralValueExpression{Value: v}, nil
 	case value.IsNumber():
 		return &model.LiteralValueExpression{
 			Value: cty.NumberFloatVal(value.NumberValue()),
@@ -453,6 +466,8 @@ func generateValue(typ schema.Type, value resource.PropertyValue) (model.Express
 			},
 			Args: []model.Expression{arg},
 		}, nil
+	case value.IsBytes():
+		return generateValue(typ, resource.NewStringProperty(value.BytesValue().Base64()))
 	case value.IsString():
 		return &model.TemplateExpression{
 			Parts: []model.Expression{
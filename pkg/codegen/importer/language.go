@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
@@ -64,28 +65,42 @@ func (e *DiagnosticsError) String() string {
 func GenerateLanguageDefinitions(w io.Writer, loader schema.Loader, gen LanguageGenerator, states []*resource.State,
 	names NameTable) error {
 
-	var hcl2Text bytes.Buffer
+	blocks := make([]*model.Block, len(states))
 	for i, state := range states {
 		hcl2Def, err := GenerateHCL2Definition(loader, state, names)
 		if err != nil {
 			return err
 		}
+		blocks[i] = hcl2Def
+	}
 
+	program, err := bindHCL2Blocks(loader, blocks)
+	if err != nil {
+		return err
+	}
+	return gen(w, program)
+}
+
+// bindHCL2Blocks binds the program made up of the given blocks. References to variables that are not defined by the
+// blocks are allowed.
+func bindHCL2Blocks(loader schema.Loader, blocks []*model.Block) (*hcl2.Program, error) {
+	var hcl2Text bytes.Buffer
+	for i, block := range blocks {
 		pre := ""
 		if i > 0 {
 			pre = "\n"
 		}
-		_, err = fmt.Fprintf(&hcl2Text, "%s%v", pre, hcl2Def)
+		_, err := fmt.Fprintf(&hcl2Text, "%s%v", pre, block)
 		contract.IgnoreError(err)
 	}
 
 	parser := syntax.NewParser()
 	if err := parser.ParseFile(&hcl2Text, string("anonymous.pp")); err != nil {
-		return err
+		return nil, err
 	}
 	if parser.Diagnostics.HasErrors() {
 		// HCL2 text generation should always generate proper code.
-		return fmt.Errorf("internal error: %w", &DiagnosticsError{
+		return nil, fmt.Errorf("internal error: %w", &DiagnosticsError{
 			diagnostics:         parser.Diagnostics,
 			newDiagnosticWriter: parser.NewDiagnosticWriter,
 		})
@@ -93,16 +108,15 @@ func GenerateLanguageDefinitions(w io.Writer, loader schema.Loader, gen Language
 
 	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.Loader(loader), hcl2.AllowMissingVariables)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if diags.HasErrors() {
 		// It is possible that the provided states do not contain appropriately-shaped inputs, so this may be user
 		// error.
-		return &DiagnosticsError{
+		return nil, &DiagnosticsError{
 			diagnostics:         diags,
 			newDiagnosticWriter: program.NewDiagnosticWriter,
 		}
 	}
-
-	return gen(w, program)
+	return program, nil
 }
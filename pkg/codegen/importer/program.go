@@ -0,0 +1,409 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// GenerateHCL2Program generates a Pulumi HCL2 program that manages the resources described by the given states, e.g.
+// the resources in a stack's checkpoint. The states must be ordered such that each resource follows its dependencies,
+// as they are in a checkpoint.
+//
+// Each custom resource is defined by a resource block that sets the resource's inputs. Explicit providers are defined
+// by provider blocks. Input values that match the ID or a string output of one of the resource's dependencies are
+// replaced with references to that dependency; dependencies that are not referenced are listed in the resource's
+// dependsOn option. Resources whose generated URN would differ from their URN in the state, e.g. because their name is
+// not a valid identifier or because their parent is not part of the program, are given an alias to their original URN.
+//
+// The stack resource, default providers, component resources, read resources, and resources that are pending deletion
+// are not part of the program.
+func GenerateHCL2Program(loader schema.Loader, states []*resource.State) ([]*model.Block, error) {
+	var exported []*resource.State
+	for _, state := range states {
+		if isExportable(state) {
+			exported = append(exported, state)
+		}
+	}
+
+	names, byURN := makeProgramNames(exported), map[resource.URN]*resource.State{}
+	for _, state := range exported {
+		byURN[state.URN] = state
+	}
+
+	blocks := make([]*model.Block, 0, len(exported))
+	for _, state := range exported {
+		block, err := generateProgramBlock(loader, state, names, byURN)
+		if err != nil {
+			return nil, fmt.Errorf("generating definition for %v: %w", state.URN, err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// GenerateProgram generates and binds a Pulumi HCL2 program that manages the resources described by the given states.
+// See GenerateHCL2Program for details.
+func GenerateProgram(loader schema.Loader, states []*resource.State) (*hcl2.Program, error) {
+	blocks, err := GenerateHCL2Program(loader, states)
+	if err != nil {
+		return nil, err
+	}
+	return bindHCL2Blocks(loader, blocks)
+}
+
+// GenerateLanguageProgram generates a program in the language implemented by the given generator that manages the
+// resources described by the given states. See GenerateHCL2Program for details.
+func GenerateLanguageProgram(w io.Writer, loader schema.Loader, gen LanguageGenerator,
+	states []*resource.State) error {
+
+	program, err := GenerateProgram(loader, states)
+	if err != nil {
+		return err
+	}
+	return gen(w, program)
+}
+
+// isExportable returns true if the given resource should be defined by a program generated from a stack's state.
+func isExportable(state *resource.State) bool {
+	switch {
+	case state.Delete, state.External, !state.Custom:
+		return false
+	case state.Type == resource.RootStackType:
+		return false
+	case providers.IsProviderType(state.Type):
+		return !providers.IsDefaultProvider(state.URN)
+	default:
+		return true
+	}
+}
+
+// makeProgramNames assigns each resource a unique name that is a valid HCL2 identifier. Resources keep their names
+// where possible.
+func makeProgramNames(states []*resource.State) NameTable {
+	names, taken := NameTable{}, map[string]bool{}
+	for _, state := range states {
+		base := makeValidIdentifier(string(state.URN.Name()))
+
+		name := base
+		for i := 2; taken[name]; i++ {
+			name = fmt.Sprintf("%s_%d", base, i)
+		}
+
+		names[state.URN], taken[name] = name, true
+	}
+	return names
+}
+
+// makeValidIdentifier replaces characters that are not allowed in HCL2 identifiers with underscores.
+func makeValidIdentifier(name string) string {
+	if hclsyntax.ValidIdentifier(name) {
+		return name
+	}
+
+	var builder strings.Builder
+	for i, c := range name {
+		switch {
+		case i == 0 && !unicode.IsLetter(c) && c != '_':
+			builder.WriteRune('_')
+			if unicode.IsDigit(c) {
+				builder.WriteRune(c)
+			}
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-':
+			builder.WriteRune(c)
+		default:
+			builder.WriteRune('_')
+		}
+	}
+	if builder.Len() == 0 {
+		return "_"
+	}
+	return builder.String()
+}
+
+// generateProgramBlock generates the resource or provider block that defines the given resource.
+func generateProgramBlock(loader schema.Loader, state *resource.State, names NameTable,
+	byURN map[resource.URN]*resource.State) (*model.Block, error) {
+
+	blockType, token := "resource", string(state.Type)
+	var properties []*schema.Property
+	if providers.IsProviderType(state.Type) {
+		pkgName := string(providers.GetProviderPackage(state.Type))
+		pkg, err := loader.LoadPackage(pkgName, nil)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.Provider != nil {
+			properties = pkg.Provider.InputProperties
+		}
+		blockType, token = "provider", pkgName
+	} else {
+		pkg, err := loader.LoadPackage(string(state.Type.Package()), nil)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := pkg.GetResource(token)
+		if !ok {
+			return nil, fmt.Errorf("unknown resource type '%v'", token)
+		}
+		properties = r.InputProperties
+	}
+
+	refs := newDependencyReferences(state, names, byURN)
+
+	var items []model.BodyItem
+	for _, p := range properties {
+		value := state.Inputs[resource.PropertyKey(p.Name)]
+		if blockType == "provider" {
+			value = decodeProviderInput(p, value)
+		}
+
+		x, err := generatePropertyValue(p, value)
+		if err != nil {
+			return nil, err
+		}
+		if x != nil {
+			if x, err = refs.replace(x); err != nil {
+				return nil, err
+			}
+			items = append(items, &model.Attribute{
+				Tokens: syntax.NewAttributeTokens(p.Name),
+				Name:   p.Name,
+				Value:  x,
+			})
+		}
+	}
+
+	resourceOptions, err := makeProgramResourceOptions(state, names, refs)
+	if err != nil {
+		return nil, err
+	}
+	if resourceOptions != nil {
+		items = append(items, resourceOptions)
+	}
+
+	name := names[state.URN]
+	return &model.Block{
+		Tokens: syntax.NewBlockTokens(blockType, name, token),
+		Type:   blockType,
+		Labels: []string{name, token},
+		Body: &model.Body{
+			Items: items,
+		},
+	}, nil
+}
+
+// decodeProviderInput decodes the value of a provider input. The engine passes provider configuration as strings, so
+// the inputs of an explicit provider whose types are not strings are recorded as JSON-encoded strings.
+func decodeProviderInput(property *schema.Property, value resource.PropertyValue) resource.PropertyValue {
+	if !value.IsString() {
+		return value
+	}
+	switch property.Type {
+	case schema.StringType, schema.BytesType:
+		return value
+	}
+	if e, ok := property.Type.(*schema.EnumType); ok && e.ElementType == schema.StringType {
+		return value
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(value.StringValue()), &v); err != nil {
+		return value
+	}
+	return resource.NewPropertyValue(v)
+}
+
+// makeProgramResourceOptions generates the options block for a resource in a program generated from a stack's state.
+func makeProgramResourceOptions(state *resource.State, names NameTable,
+	refs *dependencyReferences) (*model.Block, error) {
+
+	var resourceOptions *model.Block
+
+	parentType := tokens.Type("")
+	if name, ok := names[state.Parent]; ok {
+		resourceOptions = appendResourceOption(resourceOptions, "parent", newVariableReference(name))
+		parentType = state.Parent.QualifiedType()
+	}
+
+	if state.Provider != "" && !providers.IsProviderType(state.Type) {
+		ref, err := providers.ParseReference(state.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider reference %v: %w", state.Provider, err)
+		}
+		if !providers.IsDefaultProvider(ref.URN()) {
+			name, ok := names[ref.URN()]
+			if !ok {
+				return nil, fmt.Errorf("no definition for provider %v", ref.URN())
+			}
+			resourceOptions = appendResourceOption(resourceOptions, "provider", newVariableReference(name))
+		}
+	}
+
+	if deps := refs.unreferenced(); len(deps) != 0 {
+		resourceOptions = appendResourceOption(resourceOptions, "dependsOn", &model.TupleConsExpression{
+			Tokens:      syntax.NewTupleConsTokens(len(deps)),
+			Expressions: deps,
+		})
+	}
+
+	if state.Protect {
+		resourceOptions = appendResourceOption(resourceOptions, "protect", &model.LiteralValueExpression{
+			Tokens: syntax.NewLiteralValueTokens(cty.True),
+			Value:  cty.True,
+		})
+	}
+
+	urn := resource.NewURN(state.URN.Stack(), state.URN.Project(), parentType, state.Type,
+		tokens.QName(names[state.URN]))
+	if urn != state.URN {
+		alias := &model.TemplateExpression{
+			Parts: []model.Expression{
+				&model.LiteralValueExpression{
+					Value: cty.StringVal(string(state.URN)),
+				},
+			},
+		}
+		resourceOptions = appendResourceOption(resourceOptions, "aliases", &model.TupleConsExpression{
+			Tokens:      syntax.NewTupleConsTokens(1),
+			Expressions: []model.Expression{alias},
+		})
+	}
+
+	return resourceOptions, nil
+}
+
+// dependencyReferences replaces the string values of a resource's inputs that match the ID or a string output of one
+// of the resource's dependencies with references to the dependency, and tracks the dependencies that are referenced.
+type dependencyReferences struct {
+	// The names of the resource's dependencies that are part of the program, in order.
+	dependencies []string
+	// A map from each string value that can be replaced to the dependency and property that produce it.
+	values map[string]dependencyProperty
+	// The names of the dependencies that have been referenced.
+	referenced map[string]bool
+}
+
+// dependencyProperty identifies a property of a dependency.
+type dependencyProperty struct {
+	name     string
+	property string
+}
+
+func newDependencyReferences(state *resource.State, names NameTable,
+	byURN map[resource.URN]*resource.State) *dependencyReferences {
+
+	refs := &dependencyReferences{
+		values:     map[string]dependencyProperty{},
+		referenced: map[string]bool{},
+	}
+	for _, urn := range state.Dependencies {
+		dep, ok := byURN[urn]
+		if !ok {
+			continue
+		}
+		name := names[urn]
+		refs.dependencies = append(refs.dependencies, name)
+
+		addValue := func(value, property string) {
+			if _, has := refs.values[value]; !has && value != "" {
+				refs.values[value] = dependencyProperty{name: name, property: property}
+			}
+		}
+		if dep.ID != "" {
+			addValue(string(dep.ID), "id")
+		}
+		for _, k := range dep.Outputs.StableKeys() {
+			if v := dep.Outputs[k]; v.IsString() && !strings.HasPrefix(string(k), "__") {
+				addValue(v.StringValue(), string(k))
+			}
+		}
+	}
+	return refs
+}
+
+// replace replaces each string literal in the given expression that matches a value produced by a dependency with a
+// reference to that value.
+func (refs *dependencyReferences) replace(x model.Expression) (model.Expression, error) {
+	if len(refs.values) == 0 {
+		return x, nil
+	}
+
+	replaceValue := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		template, ok := x.(*model.TemplateExpression)
+		if !ok || len(template.Parts) != 1 {
+			return x, nil
+		}
+		lit, ok := template.Parts[0].(*model.LiteralValueExpression)
+		if !ok || lit.Value.Type() != cty.String {
+			return x, nil
+		}
+		prop, ok := refs.values[lit.Value.AsString()]
+		if !ok {
+			return x, nil
+		}
+
+		refs.referenced[prop.name] = true
+		return newPropertyReference(prop.name, prop.property), nil
+	}
+
+	x, diags := model.VisitExpression(x, model.IdentityVisitor, replaceValue)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return x, nil
+}
+
+// unreferenced returns references to the dependencies whose values have not been referenced.
+func (refs *dependencyReferences) unreferenced() []model.Expression {
+	var deps []model.Expression
+	for _, name := range refs.dependencies {
+		if !refs.referenced[name] {
+			deps = append(deps, newVariableReference(name))
+		}
+	}
+	return deps
+}
+
+func newPropertyReference(name, property string) model.Expression {
+	root := &model.Variable{
+		Name:         name,
+		VariableType: model.DynamicType,
+	}
+	return &model.ScopeTraversalExpression{
+		RootName: name,
+		Traversal: hcl.Traversal{
+			hcl.TraverseRoot{Name: name},
+			hcl.TraverseAttr{Name: property},
+		},
+		Parts: []model.Traversable{root, model.DynamicType},
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func TestGenerateProgram(t *testing.T) {
+	urn := func(parent resource.URN, typ tokens.Type, name string) resource.URN {
+		parentType := tokens.Type("")
+		if parent != "" && parent.Type() != resource.RootStackType {
+			parentType = parent.QualifiedType()
+		}
+		return resource.NewURN("stack", "project", parentType, typ, tokens.QName(name))
+	}
+	providerRef := func(state *resource.State) string {
+		ref, err := providers.NewReference(state.URN, state.ID)
+		assert.NoError(t, err)
+		return ref.String()
+	}
+
+	stackURN := urn("", resource.RootStackType, "project-stack")
+	defaultProvider := &resource.State{
+		Type:   providers.MakeProviderType("aws"),
+		URN:    urn(stackURN, providers.MakeProviderType("aws"), "default_2_13_1"),
+		Custom: true,
+		ID:     "7fa4a0a4-5d5c-4f5a-8b8a-6dbd6b0e4f9b",
+		Parent: stackURN,
+	}
+	explicitProvider := &resource.State{
+		Type:   providers.MakeProviderType("aws"),
+		URN:    urn(stackURN, providers.MakeProviderType("aws"), "usEast1"),
+		Custom: true,
+		ID:     "0b58a2d1-21a4-4a1f-9c0b-6a9c6f8b2c6e",
+		Parent: stackURN,
+		Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"region":                    "us-east-1",
+			"maxRetries":                "3",
+			"skipCredentialsValidation": "true",
+			"version":                   "2.13.1",
+		}),
+	}
+	component := &resource.State{
+		Type:   "my:index:Component",
+		URN:    urn(stackURN, "my:index:Component", "app"),
+		Parent: stackURN,
+	}
+	bucket := &resource.State{
+		Type:     "aws:s3/bucket:Bucket",
+		URN:      urn(component.URN, "aws:s3/bucket:Bucket", "my-bucket"),
+		Custom:   true,
+		ID:       "my-bucket-1234",
+		Parent:   component.URN,
+		Provider: providerRef(explicitProvider),
+		Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"acl": "private",
+		}),
+		Outputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"acl":    "private",
+			"arn":    "arn:aws:s3:::my-bucket-1234",
+			"bucket": "my-bucket-1234",
+		}),
+	}
+	index := &resource.State{
+		Type:         "aws:s3/bucketObject:BucketObject",
+		URN:          urn(stackURN, "aws:s3/bucketObject:BucketObject", "index.html"),
+		Custom:       true,
+		ID:           "index.html",
+		Parent:       stackURN,
+		Provider:     providerRef(defaultProvider),
+		Dependencies: []resource.URN{bucket.URN},
+		Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"bucket":      "my-bucket-1234",
+			"key":         "index.html",
+			"contentType": "text/html",
+			"tags": map[string]interface{}{
+				"bucketArn": "arn:aws:s3:::my-bucket-1234",
+			},
+		}),
+	}
+	readme := &resource.State{
+		Type:         "aws:s3/bucketObject:BucketObject",
+		URN:          urn(stackURN, "aws:s3/bucketObject:BucketObject", "readme"),
+		Custom:       true,
+		ID:           "README.md",
+		Parent:       stackURN,
+		Provider:     providerRef(defaultProvider),
+		Dependencies: []resource.URN{bucket.URN, index.URN},
+		Protect:      true,
+		Inputs: resource.NewPropertyMapFromMap(map[string]interface{}{
+			"bucket": "my-bucket-1234",
+			"key":    "README.md",
+		}),
+	}
+	deleted := &resource.State{
+		Type:   "aws:s3/bucket:Bucket",
+		URN:    urn(stackURN, "aws:s3/bucket:Bucket", "old-bucket"),
+		Custom: true,
+		ID:     "old-bucket-5678",
+		Parent: stackURN,
+		Delete: true,
+	}
+	read := &resource.State{
+		Type:     "aws:s3/bucket:Bucket",
+		URN:      urn(stackURN, "aws:s3/bucket:Bucket", "shared"),
+		Custom:   true,
+		ID:       "shared-bucket",
+		Parent:   stackURN,
+		External: true,
+	}
+	states := []*resource.State{
+		{Type: resource.RootStackType, URN: stackURN},
+		defaultProvider,
+		explicitProvider,
+		component,
+		bucket,
+		index,
+		deleted,
+		read,
+		readme,
+	}
+
+	loader := schema.NewPluginLoader(test.NewHost(testdataPath))
+	program, err := GenerateProgram(loader, states)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	var resources []string
+	for _, n := range program.Nodes {
+		var r *hcl2.Resource
+		switch n := n.(type) {
+		case *hcl2.Resource:
+			r = n
+		case *hcl2.Provider:
+			r = n.Resource
+		default:
+			continue
+		}
+
+		attrs := []string{r.Name() + " " + r.Token}
+		for _, attr := range r.Inputs {
+			attrs = append(attrs, fmt.Sprintf("%s = %s", attr.Name, describeExpr(t, attr.Value)))
+		}
+		if r.Options != nil {
+			options := []struct {
+				name  string
+				value model.Expression
+			}{
+				{"parent", r.Options.Parent},
+				{"provider", r.Options.Provider},
+				{"dependsOn", r.Options.DependsOn},
+				{"protect", r.Options.Protect},
+				{"aliases", r.Options.Aliases},
+			}
+			for _, o := range options {
+				if o.value != nil {
+					attrs = append(attrs, fmt.Sprintf("options.%s = %s", o.name, describeExpr(t, o.value)))
+				}
+			}
+		}
+		resources = append(resources, strings.Join(attrs, "\n"))
+	}
+	assert.Equal(t, []string{
+		`usEast1 pulumi:providers:aws
+maxRetries = 3
+region = "us-east-1"
+skipCredentialsValidation = true`,
+		`my-bucket aws:s3:Bucket
+acl = "private"
+options.provider = usEast1
+options.aliases = ["urn:pulumi:stack::project::my:index:Component$aws:s3/bucket:Bucket::my-bucket"]`,
+		`index_html aws:s3:BucketObject
+bucket = my-bucket.id
+contentType = "text/html"
+key = "index.html"
+tags = {bucketArn = my-bucket.arn}
+options.aliases = ["urn:pulumi:stack::project::aws:s3/bucketObject:BucketObject::index.html"]`,
+		`readme aws:s3:BucketObject
+bucket = my-bucket.id
+key = "README.md"
+options.dependsOn = [index_html]
+options.protect = true`,
+	}, resources)
+}
+
+// describeExpr returns a compact description of a literal, reference, or collection expression.
+func describeExpr(t *testing.T, x model.Expression) string {
+	switch x := x.(type) {
+	case *model.LiteralValueExpression:
+		switch x.Value.Type() {
+		case cty.Bool:
+			return fmt.Sprintf("%v", x.Value.True())
+		case cty.Number:
+			return x.Value.AsBigFloat().String()
+		case cty.String:
+			return fmt.Sprintf("%q", x.Value.AsString())
+		}
+	case *model.TemplateExpression:
+		// The parser may split a string literal into several parts, e.g. at a '$'.
+		var text strings.Builder
+		for _, part := range x.Parts {
+			lit, ok := part.(*model.LiteralValueExpression)
+			if !ok || lit.Value.Type() != cty.String {
+				assert.Failf(t, "", "unexpected template part %v", part)
+				return ""
+			}
+			text.WriteString(lit.Value.AsString())
+		}
+		return fmt.Sprintf("%q", text.String())
+	case *model.ScopeTraversalExpression:
+		text := x.RootName
+		for _, traverser := range x.Traversal[1:] {
+			if attr, ok := traverser.(hcl.TraverseAttr); ok {
+				text += "." + attr.Name
+			}
+		}
+		return text
+	case *model.TupleConsExpression:
+		elements := make([]string, len(x.Expressions))
+		for i, x := range x.Expressions {
+			elements[i] = describeExpr(t, x)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *model.ObjectConsExpression:
+		items := make([]string, len(x.Items))
+		for i, item := range x.Items {
+			key := describeExpr(t, item.Key)
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				key = unquoted
+			}
+			items[i] = key + " = " + describeExpr(t, item.Value)
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	assert.Failf(t, "", "unexpected expression %v", x)
+	return ""
+}
+
+func TestMakeValidIdentifier(t *testing.T) {
+	cases := map[string]string{
+		"bucket":       "bucket",
+		"my-bucket":    "my-bucket",
+		"index.html":   "index_html",
+		"0-bucket":     "_0-bucket",
+		"-bucket":      "_bucket",
+		"bucket/files": "bucket_files",
+		"":             "_",
+	}
+	for name, expected := range cases {
+		assert.Equal(t, expected, makeValidIdentifier(name), name)
+	}
+}
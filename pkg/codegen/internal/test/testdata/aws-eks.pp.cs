@@ -222,66 +222,60 @@ class MyStack : Stack
             },
         });
         var clusterName = eksCluster.Name;
-        var kubeconfig = Output.Tuple(eksCluster.Endpoint, eksCluster.CertificateAuthority, eksCluster.Name).Apply(values =>
+        var kubeconfig = Output.JsonSerialize(Output.Create(new Dictionary<string, object?>
         {
-            var endpoint = values.Item1;
-            var certificateAuthority = values.Item2;
-            var name = values.Item3;
-            return JsonSerializer.Serialize(new Dictionary<string, object?>
-            {
-                { "apiVersion", "v1" },
-                { "clusters", new[]
+            { "apiVersion", "v1" },
+            { "clusters", new[]
+                {
+                    new Dictionary<string, object?>
                     {
-                        new Dictionary<string, object?>
+                        { "cluster", new Dictionary<string, object?>
                         {
-                            { "cluster", new Dictionary<string, object?>
-                            {
-                                { "server", endpoint },
-                                { "certificate-authority-data", certificateAuthority.Data },
-                            } },
-                            { "name", "kubernetes" },
-                        },
-                    }
-                 },
-                { "contexts", new[]
+                            { "server", eksCluster.Endpoint },
+                            { "certificate-authority-data", eksCluster.CertificateAuthority.Apply(certificateAuthority => certificateAuthority.Data) },
+                        } },
+                        { "name", "kubernetes" },
+                    },
+                }
+             },
+            { "contexts", new[]
+                {
+                    new Dictionary<string, object?>
                     {
-                        new Dictionary<string, object?>
+                        { "contest", new Dictionary<string, object?>
                         {
-                            { "contest", new Dictionary<string, object?>
-                            {
-                                { "cluster", "kubernetes" },
-                                { "user", "aws" },
-                            } },
-                        },
-                    }
-                 },
-                { "current-context", "aws" },
-                { "kind", "Config" },
-                { "users", new[]
+                            { "cluster", "kubernetes" },
+                            { "user", "aws" },
+                        } },
+                    },
+                }
+             },
+            { "current-context", "aws" },
+            { "kind", "Config" },
+            { "users", new[]
+                {
+                    new Dictionary<string, object?>
                     {
-                        new Dictionary<string, object?>
+                        { "name", "aws" },
+                        { "user", new Dictionary<string, object?>
                         {
-                            { "name", "aws" },
-                            { "user", new Dictionary<string, object?>
+                            { "exec", new Dictionary<string, object?>
                             {
-                                { "exec", new Dictionary<string, object?>
-                                {
-                                    { "apiVersion", "client.authentication.k8s.io/v1alpha1" },
-                                    { "command", "aws-iam-authenticator" },
-                                } },
-                                { "args", new[]
-                                    {
-                                        "token",
-                                        "-i",
-                                        name,
-                                    }
-                                 },
+                                { "apiVersion", "client.authentication.k8s.io/v1alpha1" },
+                                { "command", "aws-iam-authenticator" },
                             } },
-                        },
-                    }
-                 },
-            });
-        });
+                            { "args", new object?[]
+                                {
+                                    "token",
+                                    "-i",
+                                    eksCluster.Name,
+                                }
+                             },
+                        } },
+                    },
+                }
+             },
+        }));
 
         return new Dictionary<string, Output<string>>
         {
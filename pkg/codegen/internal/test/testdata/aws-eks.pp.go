@@ -233,55 +233,46 @@ func main() {
 			return err
 		}
 		ctx.Export("clusterName", eksCluster.Name)
-		ctx.Export("kubeconfig", pulumi.All(eksCluster.Endpoint, eksCluster.CertificateAuthority, eksCluster.Name).ApplyT(func(_args []interface{}) (pulumi.String, error) {
-			endpoint := _args[0].(string)
-			certificateAuthority := _args[1].(eks.ClusterCertificateAuthority)
-			name := _args[2].(string)
-			var _zero pulumi.String
-			tmpJSON2, err := json.Marshal(map[string]interface{}{
-				"apiVersion": "v1",
-				"clusters": []map[string]interface{}{
-					map[string]interface{}{
-						"cluster": map[string]interface{}{
-							"server":                     endpoint,
-							"certificate-authority-data": certificateAuthority.Data,
-						},
-						"name": "kubernetes",
+		ctx.Export("kubeconfig", pulumi.JSONMarshal(pulumi.Map{
+			"apiVersion": pulumi.String("v1"),
+			"clusters": pulumi.MapArray{
+				pulumi.Map{
+					"cluster": pulumi.StringMap{
+						"server": eksCluster.Endpoint,
+						"certificate-authority-data": eksCluster.CertificateAuthority.ApplyT(func(certificateAuthority eks.ClusterCertificateAuthority) (string, error) {
+							return certificateAuthority.Data, nil
+						}).(pulumi.StringOutput),
 					},
+					"name": pulumi.String("kubernetes"),
 				},
-				"contexts": []map[string]interface{}{
-					map[string]interface{}{
-						"contest": map[string]interface{}{
-							"cluster": "kubernetes",
-							"user":    "aws",
-						},
+			},
+			"contexts": pulumi.MapArray{
+				pulumi.Map{
+					"contest": pulumi.StringMap{
+						"cluster": pulumi.String("kubernetes"),
+						"user":    pulumi.String("aws"),
 					},
 				},
-				"current-context": "aws",
-				"kind":            "Config",
-				"users": []map[string]interface{}{
-					map[string]interface{}{
-						"name": "aws",
-						"user": map[string]interface{}{
-							"exec": map[string]interface{}{
-								"apiVersion": "client.authentication.k8s.io/v1alpha1",
-								"command":    "aws-iam-authenticator",
-							},
-							"args": []string{
-								"token",
-								"-i",
-								name,
-							},
+			},
+			"current-context": pulumi.String("aws"),
+			"kind":            pulumi.String("Config"),
+			"users": pulumi.MapArray{
+				pulumi.Map{
+					"name": pulumi.String("aws"),
+					"user": pulumi.Map{
+						"exec": pulumi.StringMap{
+							"apiVersion": pulumi.String("client.authentication.k8s.io/v1alpha1"),
+							"command":    pulumi.String("aws-iam-authenticator"),
+						},
+						"args": pulumi.Array{
+							pulumi.String("token"),
+							pulumi.String("-i"),
+							eksCluster.Name,
 						},
 					},
 				},
-			})
-			if err != nil {
-				return _zero, err
-			}
-			json2 := string(tmpJSON2)
-			return pulumi.String(json2), nil
-		}).(pulumi.StringOutput))
+			},
+		}))
 		return nil
 	})
 }
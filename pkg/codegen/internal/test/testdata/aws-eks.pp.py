@@ -28,21 +28,21 @@ eks_route_table = aws.ec2.RouteTable("eksRouteTable",
 # Subnets, one for each AZ in a region
 zones = aws.get_availability_zones()
 vpc_subnet = []
-for range in [{"key": k, "value": v} for [k, v] in enumerate(zones.names)]:
-    vpc_subnet.append(aws.ec2.Subnet(f"vpcSubnet-{range['key']}",
+for vpc_subnet_key, vpc_subnet_value in enumerate(zones.names):
+    vpc_subnet.append(aws.ec2.Subnet(f"vpcSubnet-{vpc_subnet_key}",
         assign_ipv6_address_on_creation=False,
         vpc_id=eks_vpc.id,
         map_public_ip_on_launch=True,
-        cidr_block=f"10.100.{range['key']}.0/24",
-        availability_zone=range["value"],
+        cidr_block=f"10.100.{vpc_subnet_key}.0/24",
+        availability_zone=vpc_subnet_value,
         tags={
-            "Name": f"pulumi-sn-{range['value']}",
+            "Name": f"pulumi-sn-{vpc_subnet_value}",
         }))
 rta = []
-for range in [{"key": k, "value": v} for [k, v] in enumerate(zones.names)]:
-    rta.append(aws.ec2.RouteTableAssociation(f"rta-{range['key']}",
+for rta_key, rta_value in enumerate(zones.names):
+    rta.append(aws.ec2.RouteTableAssociation(f"rta-{rta_key}",
         route_table_id=eks_route_table.id,
-        subnet_id=vpc_subnet[range["key"]].id))
+        subnet_id=vpc_subnet[rta_key].id))
 subnet_ids = [__item.id for __item in vpc_subnet]
 eks_security_group = aws.ec2.SecurityGroup("eksSecurityGroup",
     vpc_id=eks_vpc.id,
@@ -130,12 +130,12 @@ node_group = aws.eks.NodeGroup("nodeGroup",
         "min_size": 1,
     })
 pulumi.export("clusterName", eks_cluster.name)
-pulumi.export("kubeconfig", pulumi.Output.all(eks_cluster.endpoint, eks_cluster.certificate_authority, eks_cluster.name).apply(lambda endpoint, certificate_authority, name: json.dumps({
+pulumi.export("kubeconfig", pulumi.Output.json_dumps({
     "apiVersion": "v1",
     "clusters": [{
         "cluster": {
-            "server": endpoint,
-            "certificate-authority-data": certificate_authority["data"],
+            "server": eks_cluster.endpoint,
+            "certificate-authority-data": eks_cluster.certificate_authority["data"],
         },
         "name": "kubernetes",
     }],
@@ -157,8 +157,8 @@ pulumi.export("kubeconfig", pulumi.Output.all(eks_cluster.endpoint, eks_cluster.
             "args": [
                 "token",
                 "-i",
-                name,
+                eks_cluster.name,
             ],
         },
     }],
-})))
+}))
@@ -30,8 +30,8 @@ export = async () => {
     });
     // Subnets, one for each AZ in a region
     const zones = await aws.getAvailabilityZones({});
-    const vpcSubnet: aws.ec2.Subnet[];
-    for (const range of zones.names.map((k, v) => {key: k, value: v})) {
+    const vpcSubnet: aws.ec2.Subnet[] = [];
+    for (const range of zones.names.map((v, k) => ({key: k, value: v}))) {
         vpcSubnet.push(new aws.ec2.Subnet(`vpcSubnet-${range.key}`, {
             assignIpv6AddressOnCreation: false,
             vpcId: eksVpc.id,
@@ -43,8 +43,8 @@ export = async () => {
             },
         }));
     }
-    const rta: aws.ec2.RouteTableAssociation[];
-    for (const range of zones.names.map((k, v) => {key: k, value: v})) {
+    const rta: aws.ec2.RouteTableAssociation[] = [];
+    for (const range of zones.names.map((v, k) => ({key: k, value: v}))) {
         rta.push(new aws.ec2.RouteTableAssociation(`rta-${range.key}`, {
             routeTableId: eksRouteTable.id,
             subnetId: vpcSubnet[range.key].id,
@@ -145,12 +145,12 @@ export = async () => {
         },
     });
     const clusterName = eksCluster.name;
-    const kubeconfig = pulumi.all([eksCluster.endpoint, eksCluster.certificateAuthority, eksCluster.name]).apply(([endpoint, certificateAuthority, name]) => JSON.stringify({
+    const kubeconfig = pulumi.jsonStringify({
         apiVersion: "v1",
         clusters: [{
             cluster: {
-                server: endpoint,
-                "certificate-authority-data": certificateAuthority.data,
+                server: eksCluster.endpoint,
+                "certificate-authority-data": eksCluster.certificateAuthority.apply(certificateAuthority => certificateAuthority.data),
             },
             name: "kubernetes",
         }],
@@ -172,11 +172,11 @@ export = async () => {
                 args: [
                     "token",
                     "-i",
-                    name,
+                    eksCluster.name,
                 ],
             },
         }],
-    }));
+    });
     return {
         clusterName: clusterName,
         kubeconfig: kubeconfig,
@@ -0,0 +1,15 @@
+// Read the current region, the default VPC, and its subnets using the functions of the AWS package.
+region = aws_getRegion({})
+vpc = aws_ec2_getVpc({
+	default = true
+})
+subnets = aws_ec2_getSubnetIds({
+	vpcId = vpc.id
+})
+
+output regionName {
+	value = region.name
+}
+output subnetIds {
+	value = subnets.ids
+}
@@ -0,0 +1,25 @@
+using Pulumi;
+using Aws = Pulumi.Aws;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var region = Output.Create(Aws.GetRegion.InvokeAsync());
+        var vpc = Output.Create(Aws.Ec2.GetVpc.InvokeAsync(new Aws.Ec2.GetVpcArgs
+        {
+            Default = true,
+        }));
+        var subnets = vpc.Apply(vpc => Output.Create(Aws.Ec2.GetSubnetIds.InvokeAsync(new Aws.Ec2.GetSubnetIdsArgs
+        {
+            VpcId = vpc.Id,
+        })));
+        this.RegionName = region.Apply(region => region.Name);
+        this.SubnetIds = subnets.Apply(subnets => subnets.Ids);
+    }
+
+    [Output("regionName")]
+    public Output<string> RegionName { get; set; }
+    [Output("subnetIds")]
+    public Output<string> SubnetIds { get; set; }
+}
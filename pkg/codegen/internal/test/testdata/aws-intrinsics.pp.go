@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/ec2"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		region, err := aws.GetRegion(ctx, nil, nil)
+		if err != nil {
+			return err
+		}
+		opt0 := true
+		vpc, err := ec2.LookupVpc(ctx, &ec2.LookupVpcArgs{
+			Default: &opt0,
+		}, nil)
+		if err != nil {
+			return err
+		}
+		subnets, err := ec2.GetSubnetIds(ctx, &ec2.GetSubnetIdsArgs{
+			VpcId: vpc.Id,
+		}, nil)
+		if err != nil {
+			return err
+		}
+		ctx.Export("regionName", region.Name)
+		ctx.Export("subnetIds", subnets.Ids)
+		return nil
+	})
+}
@@ -0,0 +1,27 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.aws.AwsFunctions;
+import com.pulumi.aws.ec2.Ec2Functions;
+import com.pulumi.aws.ec2.inputs.GetSubnetIdsArgs;
+import com.pulumi.aws.ec2.inputs.GetVpcArgs;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        // Read the current region, the default VPC, and its subnets using the functions of the AWS package.
+        final var region = AwsFunctions.getRegion();
+        final var vpc = Ec2Functions.getVpc(GetVpcArgs.builder()
+            .default_(true)
+            .build());
+        final var subnets = vpc.apply(vpcValue -> Ec2Functions.getSubnetIds(GetSubnetIdsArgs.builder()
+            .vpcId(vpcValue.id())
+            .build()));
+        ctx.export("regionName", region.applyValue(regionValue -> regionValue.name()));
+        ctx.export("subnetIds", subnets.applyValue(subnetsValue -> subnetsValue.ids()));
+    }
+}
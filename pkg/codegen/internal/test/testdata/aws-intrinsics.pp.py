@@ -0,0 +1,8 @@
+import pulumi
+import pulumi_aws as aws
+
+region = aws.get_region()
+vpc = aws.ec2.get_vpc(default=True)
+subnets = aws.ec2.get_subnet_ids(vpc_id=vpc.id)
+pulumi.export("regionName", region.name)
+pulumi.export("subnetIds", subnets.ids)
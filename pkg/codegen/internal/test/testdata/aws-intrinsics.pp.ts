@@ -0,0 +1,12 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const region = aws.getRegion({});
+const vpc = aws.ec2.getVpc({
+    "default": true,
+});
+const subnets = vpc.then(vpc => aws.ec2.getSubnetIds({
+    vpcId: vpc.id,
+}));
+export const regionName = region.then(region => region.name);
+export const subnetIds = subnets.then(subnets => subnets.ids);
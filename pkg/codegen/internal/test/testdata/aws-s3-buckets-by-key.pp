@@ -0,0 +1,15 @@
+config bucketNames "map(string)" {
+	description = "The names of the buckets to create, by purpose"
+}
+
+// Create a bucket for each entry in `bucketNames`, addressed by the entry's key
+resource bucket "aws:s3:Bucket" {
+	options {
+		range = bucketNames
+	}
+
+	bucket = range.value
+}
+
+// Stack outputs
+output siteBucketArn { value = bucket["site"].arn }
@@ -0,0 +1,26 @@
+using System.Collections.Generic;
+using System.Linq;
+using Pulumi;
+using Aws = Pulumi.Aws;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var config = new Config();
+        var bucketNames = config.RequireObject<dynamic>("bucketNames");
+        // Create a bucket for each entry in `bucketNames`, addressed by the entry's key
+        var bucket = new Dictionary<string, Aws.S3.Bucket>();
+        foreach (var range in bucketNames.Select(pair => new { pair.Key, pair.Value }))
+        {
+            bucket.Add(range.Key, new Aws.S3.Bucket($"bucket-{range.Key}", new Aws.S3.BucketArgs
+            {
+                Bucket = range.Value,
+            }));
+        }
+        this.SiteBucketArn = bucket["site"].Arn;
+    }
+
+    [Output("siteBucketArn")]
+    public Output<string> SiteBucketArn { get; set; }
+}
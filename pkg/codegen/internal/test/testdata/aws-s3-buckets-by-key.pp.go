@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		cfg := config.New(ctx, "")
+		var bucketNames map[string]string
+		cfg.RequireObject("bucketNames", &bucketNames)
+		bucket := make(map[string]*s3.Bucket)
+		for key0, val0 := range bucketNames {
+			__res, err := s3.NewBucket(ctx, fmt.Sprintf("bucket-%v", key0), &s3.BucketArgs{
+				Bucket: pulumi.String(val0),
+			})
+			if err != nil {
+				return err
+			}
+			bucket[key0] = __res
+		}
+		ctx.Export("siteBucketArn", bucket["site"].Arn)
+		return nil
+	})
+}
@@ -0,0 +1,10 @@
+import pulumi
+import pulumi_aws as aws
+
+config = pulumi.Config()
+bucket_names = config.require_object("bucketNames")
+# Create a bucket for each entry in `bucketNames`, addressed by the entry's key
+bucket = {}
+for bucket_key, bucket_value in bucket_names.items():
+    bucket[bucket_key] = aws.s3.Bucket(f"bucket-{bucket_key}", bucket=bucket_value)
+pulumi.export("siteBucketArn", bucket["site"].arn)
@@ -0,0 +1,11 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const bucketNames = config.requireObject("bucketNames");
+// Create a bucket for each entry in `bucketNames`, addressed by the entry's key
+const bucket: Record<string, aws.s3.Bucket> = {};
+for (const range of Object.entries(bucketNames).map(([k, v]) => ({key: k, value: v}))) {
+    bucket[range.key] = new aws.s3.Bucket(`bucket-${range.key}`, {bucket: range.value});
+}
+export const siteBucketArn = bucket.site.arn;
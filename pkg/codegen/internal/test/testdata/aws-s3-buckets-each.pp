@@ -0,0 +1,28 @@
+config bucketNames "map(string)" {
+	description = "The names of the buckets to create, by purpose"
+}
+
+// Create a bucket for each entry in `bucketNames`, tagged with the entry's key
+resource bucket "aws:s3:Bucket" {
+	options {
+		range = bucketNames
+	}
+
+	bucket = each.value
+	tags = {
+		Purpose = each.key
+	}
+}
+
+// Create a fixed number of replica buckets
+resource replica "aws:s3:Bucket" {
+	options {
+		range = 2
+	}
+
+	bucket = "replica-${each.value}"
+}
+
+// Stack outputs
+output siteBucketArn { value = bucket["site"].arn }
+output firstReplicaArn { value = replica[0].arn }
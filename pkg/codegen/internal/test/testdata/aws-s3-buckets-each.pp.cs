@@ -0,0 +1,43 @@
+using System.Collections.Generic;
+using System.Linq;
+using Pulumi;
+using Aws = Pulumi.Aws;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var config = new Config();
+        var bucketNames = config.RequireObject<dynamic>("bucketNames");
+        // Create a bucket for each entry in `bucketNames`, tagged with the entry's key
+        var bucket = new Dictionary<string, Aws.S3.Bucket>();
+        foreach (var range in bucketNames.Select(pair => new { pair.Key, pair.Value }))
+        {
+            bucket.Add(range.Key, new Aws.S3.Bucket($"bucket-{range.Key}", new Aws.S3.BucketArgs
+            {
+                Bucket = range.Value,
+                Tags = 
+                {
+                    { "Purpose", range.Key },
+                },
+            }));
+        }
+        // Create a fixed number of replica buckets
+        var replica = new List<Aws.S3.Bucket>();
+        for (var rangeIndex = 0; rangeIndex < 2; rangeIndex++)
+        {
+            var range = new { Value = rangeIndex };
+            replica.Add(new Aws.S3.Bucket($"replica-{range.Value}", new Aws.S3.BucketArgs
+            {
+                Bucket = $"replica-{range.Value}",
+            }));
+        }
+        this.SiteBucketArn = bucket["site"].Arn;
+        this.FirstReplicaArn = replica[0].Arn;
+    }
+
+    [Output("siteBucketArn")]
+    public Output<string> SiteBucketArn { get; set; }
+    [Output("firstReplicaArn")]
+    public Output<string> FirstReplicaArn { get; set; }
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		cfg := config.New(ctx, "")
+		var bucketNames map[string]string
+		cfg.RequireObject("bucketNames", &bucketNames)
+		bucket := make(map[string]*s3.Bucket)
+		for key0, val0 := range bucketNames {
+			__res, err := s3.NewBucket(ctx, fmt.Sprintf("bucket-%v", key0), &s3.BucketArgs{
+				Bucket: pulumi.String(val0),
+				Tags: pulumi.StringMap{
+					"Purpose": pulumi.String(key0),
+				},
+			})
+			if err != nil {
+				return err
+			}
+			bucket[key0] = __res
+		}
+		var replica []*s3.Bucket
+		for key0 := 0; key0 < 2; key0++ {
+			val0 := key0
+			__res, err := s3.NewBucket(ctx, fmt.Sprintf("replica-%v", key0), &s3.BucketArgs{
+				Bucket: pulumi.String(fmt.Sprintf("%v%v", "replica-", val0)),
+			})
+			if err != nil {
+				return err
+			}
+			replica = append(replica, __res)
+		}
+		ctx.Export("siteBucketArn", bucket["site"].Arn)
+		ctx.Export("firstReplicaArn", replica[0].Arn)
+		return nil
+	})
+}
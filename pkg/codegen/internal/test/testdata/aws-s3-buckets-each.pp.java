@@ -0,0 +1,40 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.aws.s3.Bucket;
+import com.pulumi.aws.s3.BucketArgs;
+import com.pulumi.codegen.internal.KeyedValue;
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.Map;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        final var config = ctx.config();
+        final var bucketNames = config.requireObject("bucketNames", Map.class);
+        // Create a bucket for each entry in `bucketNames`, tagged with the entry's key
+        final var bucket = new HashMap<String, Bucket>();
+        for (var range : KeyedValue.of(bucketNames)) {
+            bucket.put(range.key(), new Bucket("bucket-" + range.key(), BucketArgs.builder()
+                .bucket(range.value())
+                .tags(Map.of("Purpose", range.key()))
+                .build()));
+        }
+        // Create a fixed number of replica buckets
+        final var replica = new ArrayList<Bucket>();
+        for (var rangeIndex = 0; rangeIndex < 2; rangeIndex++) {
+            final var range = new KeyedValue<>(rangeIndex, rangeIndex);
+            replica.add(new Bucket("replica-" + range.key(), BucketArgs.builder()
+                .bucket(String.format("replica-%s", range.value()))
+                .build()));
+        }
+        // Stack outputs
+        ctx.export("siteBucketArn", bucket.get("site").arn());
+        ctx.export("firstReplicaArn", replica.get(0).arn());
+    }
+}
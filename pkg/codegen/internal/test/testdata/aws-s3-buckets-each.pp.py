@@ -0,0 +1,19 @@
+import pulumi
+import pulumi_aws as aws
+
+config = pulumi.Config()
+bucket_names = config.require_object("bucketNames")
+# Create a bucket for each entry in `bucketNames`, tagged with the entry's key
+bucket = {}
+for bucket_key, bucket_value in bucket_names.items():
+    bucket[bucket_key] = aws.s3.Bucket(f"bucket-{bucket_key}",
+        bucket=bucket_value,
+        tags={
+            "Purpose": bucket_key,
+        })
+# Create a fixed number of replica buckets
+replica = []
+for replica_value in range(0, 2):
+    replica.append(aws.s3.Bucket(f"replica-{replica_value}", bucket=f"replica-{replica_value}"))
+pulumi.export("siteBucketArn", bucket["site"].arn)
+pulumi.export("firstReplicaArn", replica[0].arn)
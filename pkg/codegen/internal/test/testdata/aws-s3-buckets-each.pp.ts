@@ -0,0 +1,22 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const bucketNames = config.requireObject("bucketNames");
+// Create a bucket for each entry in `bucketNames`, tagged with the entry's key
+const bucket: Record<string, aws.s3.Bucket> = {};
+for (const range of Object.entries(bucketNames).map(([k, v]) => ({key: k, value: v}))) {
+    bucket[range.key] = new aws.s3.Bucket(`bucket-${range.key}`, {
+        bucket: range.value,
+        tags: {
+            Purpose: range.key,
+        },
+    });
+}
+// Create a fixed number of replica buckets
+const replica: aws.s3.Bucket[] = [];
+for (const range = {value: 0}; range.value < 2; range.value++) {
+    replica.push(new aws.s3.Bucket(`replica-${range.value}`, {bucket: `replica-${range.value}`}));
+}
+export const siteBucketArn = bucket.site.arn;
+export const firstReplicaArn = replica[0].arn;
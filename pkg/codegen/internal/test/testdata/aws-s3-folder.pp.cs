@@ -1,7 +1,6 @@
 using System.Collections.Generic;
 using System.IO;
 using System.Linq;
-using System.Text.Json;
 using Pulumi;
 using Aws = Pulumi.Aws;
 
@@ -27,7 +26,7 @@ class MyStack : Stack
                 Bucket = siteBucket.Id,
                 Key = range.Value,
                 Source = new FileAsset($"{siteDir}/{range.Value}"),
-                ContentType = "TODO: call mimeType",
+                ContentType = /* TODO: function mimeType is not supported: mimeType(range.value) (aws-s3-folder.pp:19,16-37) */ null,
             }));
         }
         // set the MIME type of the file
@@ -35,7 +34,7 @@ class MyStack : Stack
         var bucketPolicy = new Aws.S3.BucketPolicy("bucketPolicy", new Aws.S3.BucketPolicyArgs
         {
             Bucket = siteBucket.Id,
-            Policy = siteBucket.Id.Apply(id => JsonSerializer.Serialize(new Dictionary<string, object?>
+            Policy = Output.JsonSerialize(Output.Create(new Dictionary<string, object?>
             {
                 { "Version", "2012-10-17" },
                 { "Statement", new[]
@@ -51,7 +50,7 @@ class MyStack : Stack
                              },
                             { "Resource", new[]
                                 {
-                                    $"arn:aws:s3:::{id}/*",
+                                    siteBucket.Id.Apply(id => $"arn:aws:s3:::{id}/*"),
                                 }
                              },
                         },
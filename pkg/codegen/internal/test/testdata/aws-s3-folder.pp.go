@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"mime"
@@ -45,29 +44,23 @@ func main() {
 		}
 		_, err = s3.NewBucketPolicy(ctx, "bucketPolicy", &s3.BucketPolicyArgs{
 			Bucket: siteBucket.ID(),
-			Policy: siteBucket.ID().ApplyT(func(id string) (pulumi.String, error) {
-				var _zero pulumi.String
-				tmpJSON0, err := json.Marshal(map[string]interface{}{
-					"Version": "2012-10-17",
-					"Statement": []map[string]interface{}{
-						map[string]interface{}{
-							"Effect":    "Allow",
-							"Principal": "*",
-							"Action": []string{
-								"s3:GetObject",
-							},
-							"Resource": []string{
-								fmt.Sprintf("%v%v%v", "arn:aws:s3:::", id, "/*"),
-							},
+			Policy: pulumi.JSONMarshal(pulumi.Map{
+				"Version": pulumi.String("2012-10-17"),
+				"Statement": pulumi.MapArray{
+					pulumi.Map{
+						"Effect":    pulumi.String("Allow"),
+						"Principal": pulumi.String("*"),
+						"Action": pulumi.StringArray{
+							pulumi.String("s3:GetObject"),
+						},
+						"Resource": pulumi.StringArray{
+							siteBucket.ID().ApplyT(func(id string) (string, error) {
+								return fmt.Sprintf("%v%v%v", "arn:aws:s3:::", id, "/*"), nil
+							}).(pulumi.StringOutput),
 						},
 					},
-				})
-				if err != nil {
-					return _zero, err
-				}
-				json0 := string(tmpJSON0)
-				return pulumi.String(json0), nil
-			}).(pulumi.StringOutput),
+				},
+			}),
 		})
 		if err != nil {
 			return err
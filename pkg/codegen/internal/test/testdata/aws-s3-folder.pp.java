@@ -0,0 +1,88 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.asset.FileAsset;
+import com.pulumi.aws.s3.Bucket;
+import com.pulumi.aws.s3.BucketArgs;
+import com.pulumi.aws.s3.BucketObject;
+import com.pulumi.aws.s3.BucketObjectArgs;
+import com.pulumi.aws.s3.BucketPolicy;
+import com.pulumi.aws.s3.BucketPolicyArgs;
+import com.pulumi.aws.s3.inputs.BucketWebsiteArgs;
+import com.pulumi.codegen.internal.KeyedValue;
+import java.io.IOException;
+import java.io.UncheckedIOException;
+import java.nio.file.Files;
+import java.nio.file.Paths;
+import java.util.ArrayList;
+import java.util.List;
+
+import static com.pulumi.codegen.internal.Serialization.jsonArray;
+import static com.pulumi.codegen.internal.Serialization.jsonObject;
+import static com.pulumi.codegen.internal.Serialization.jsonProperty;
+import static com.pulumi.codegen.internal.Serialization.serializeJson;
+import static java.util.stream.Collectors.toList;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        // Create a bucket and expose a website index document
+        var siteBucket = new Bucket("siteBucket", BucketArgs.builder()
+            .website(BucketWebsiteArgs.builder()
+                .indexDocument("index.html")
+                .build())
+            .build());
+        final var siteDir = "www"; // directory for content files
+        // For each file in the directory, create an S3 object stored in `siteBucket`
+        final var files = new ArrayList<BucketObject>();
+        for (var range : KeyedValue.of(readDir(siteDir))) {
+            files.add(new BucketObject("files-" + range.key(), BucketObjectArgs.builder()
+                // Reference the s3.Bucket object
+                .bucket(siteBucket.id())
+                // Set the key appropriately
+                .key(range.value())
+                // use fileAsset to point to a file
+                .source(new FileAsset(String.format("%s/%s", siteDir, range.value())))
+                // set the MIME type of the file
+                .contentType(mimeType(range.value()))
+                .build()));
+        }
+        // Set the access policy for the bucket so all objects are readable
+        var bucketPolicy = new BucketPolicy("bucketPolicy", BucketPolicyArgs.builder()
+            // refer to the bucket created earlier
+            .bucket(siteBucket.id())
+            // The policy is JSON-encoded.
+            .policy(siteBucket.id().applyValue(id -> serializeJson(
+                jsonObject(
+                    jsonProperty("Version", "2012-10-17"),
+                    jsonProperty("Statement", jsonArray(jsonObject(
+                        jsonProperty("Effect", "Allow"),
+                        jsonProperty("Principal", "*"),
+                        jsonProperty("Action", jsonArray("s3:GetObject")),
+                        jsonProperty("Resource", jsonArray(String.format("arn:aws:s3:::%s/*", id))))))))))
+            .build());
+        // Stack outputs
+        ctx.export("bucketName", siteBucket.bucket());
+        ctx.export("websiteUrl", siteBucket.websiteEndpoint());
+    }
+
+    private static String mimeType(String path) {
+        try {
+            return Files.probeContentType(Paths.get(path));
+        } catch (IOException e) {
+            throw new UncheckedIOException(e);
+        }
+    }
+
+    private static List<String> readDir(String path) {
+        try (var files = Files.list(Paths.get(path))) {
+            return files.map(file -> file.getFileName().toString()).sorted().collect(toList());
+        } catch (IOException e) {
+            throw new UncheckedIOException(e);
+        }
+    }
+}
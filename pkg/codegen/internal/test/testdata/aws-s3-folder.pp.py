@@ -1,5 +1,4 @@
 import pulumi
-import json
 import os
 import pulumi_aws as aws
 
@@ -10,24 +9,25 @@ site_bucket = aws.s3.Bucket("siteBucket", website={
 site_dir = "www"
 # For each file in the directory, create an S3 object stored in `siteBucket`
 files = []
-for range in [{"key": k, "value": v} for [k, v] in enumerate(os.listdir(site_dir))]:
-    files.append(aws.s3.BucketObject(f"files-{range['key']}",
+for files_key, files_value in enumerate(os.listdir(site_dir)):
+    files.append(aws.s3.BucketObject(f"files-{files_key}",
         bucket=site_bucket.id,
-        key=range["value"],
-        source=pulumi.FileAsset(f"{site_dir}/{range['value']}"),
-        content_type=(lambda: raise Exception("FunctionCallExpression: mimeType (aws-s3-folder.pp:19,16-37)"))()))
+        key=files_value,
+        source=pulumi.FileAsset(f"{site_dir}/{files_value}"),
+        content_type=(None  # TODO: function mimeType is not supported: mimeType(range.value) (aws-s3-folder.pp:19,16-37)
+        )))
 # set the MIME type of the file
 # Set the access policy for the bucket so all objects are readable
 bucket_policy = aws.s3.BucketPolicy("bucketPolicy",
     bucket=site_bucket.id,
-    policy=site_bucket.id.apply(lambda id: json.dumps({
+    policy=pulumi.Output.json_dumps({
         "Version": "2012-10-17",
         "Statement": [{
             "Effect": "Allow",
             "Principal": "*",
             "Action": ["s3:GetObject"],
-            "Resource": [f"arn:aws:s3:::{id}/*"],
+            "Resource": [site_bucket.id.apply(lambda id: f"arn:aws:s3:::{id}/*")],
         }],
-    })))
+    }))
 pulumi.export("bucketName", site_bucket.bucket)
 pulumi.export("websiteUrl", site_bucket.website_endpoint)
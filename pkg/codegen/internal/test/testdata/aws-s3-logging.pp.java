@@ -0,0 +1,23 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.aws.s3.Bucket;
+import com.pulumi.aws.s3.BucketArgs;
+import com.pulumi.aws.s3.inputs.BucketLoggingArgs;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        var logs = new Bucket("logs");
+        var bucket = new Bucket("bucket", BucketArgs.builder()
+            .loggings(BucketLoggingArgs.builder()
+                .targetBucket(logs.bucket())
+                .build())
+            .build());
+        ctx.export("targetBucket", bucket.loggings().applyValue(loggings -> loggings.get(0).targetBucket()));
+    }
+}
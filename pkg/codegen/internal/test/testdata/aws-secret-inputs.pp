@@ -0,0 +1,3 @@
+resource dbCluster "aws:rds:Cluster" {
+	masterPassword = "foobar"
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/rds"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		_, err := rds.NewCluster(ctx, "dbCluster", &rds.ClusterArgs{
+			MasterPassword: pulumi.ToSecret("foobar").(pulumi.StringOutput),
+		})
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,34 @@
+component vpc {
+	inputs {
+		cidrBlock = string
+		tags = map(string)
+	}
+
+	resources {
+		resource vpc "aws:ec2:Vpc" {
+			cidrBlock = cidrBlock
+			tags = tags
+		}
+
+		resource subnet "aws:ec2:Subnet" {
+			vpcId = vpc.id
+			cidrBlock = cidrBlock
+		}
+	}
+
+	outputs {
+		vpcId = vpc.id
+		subnetId = subnet.id
+	}
+}
+
+resource main "vpc" {
+	cidrBlock = "10.0.0.0/16"
+	tags = {
+		Name = "main"
+	}
+}
+
+output vpcId {
+	value = main.vpcId
+}
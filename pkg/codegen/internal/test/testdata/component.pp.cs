@@ -0,0 +1,65 @@
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using Pulumi;
+using Aws = Pulumi.Aws;
+
+class VpcArgs
+{
+    public Input<string> CidrBlock { get; set; } = null!;
+    public InputMap<string> Tags { get; set; } = null!;
+}
+
+class Vpc : ComponentResource
+{
+    public Output<string> VpcId { get; }
+    public Output<string> SubnetId { get; }
+
+    public Vpc(string name, VpcArgs args, ComponentResourceOptions? options = null)
+        : base("components:index:Vpc", name, options)
+    {
+        var cidrBlock = args.CidrBlock.ToOutput();
+        var tags = args.Tags.ToOutput();
+        var vpc = new Aws.Ec2.Vpc($"{name}-vpc", new Aws.Ec2.VpcArgs
+        {
+            CidrBlock = cidrBlock,
+            Tags = tags,
+        }, new CustomResourceOptions
+        {
+            Parent = this,
+        });
+        var subnet = new Aws.Ec2.Subnet($"{name}-subnet", new Aws.Ec2.SubnetArgs
+        {
+            VpcId = vpc.Id,
+            CidrBlock = cidrBlock,
+        }, new CustomResourceOptions
+        {
+            Parent = this,
+        });
+        this.VpcId = vpc.Id;
+        this.SubnetId = subnet.Id;
+        this.RegisterOutputs(new Dictionary<string, object?>
+        {
+            { "vpcId", this.VpcId },
+            { "subnetId", this.SubnetId },
+        });
+    }
+}
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var main = new Vpc("main", new VpcArgs
+        {
+            CidrBlock = "10.0.0.0/16",
+            Tags = 
+            {
+                { "Name", "main" },
+            },
+        });
+        this.VpcId = main.VpcId;
+    }
+
+    [Output("vpcId")]
+    public Output<string> VpcId { get; set; }
+}
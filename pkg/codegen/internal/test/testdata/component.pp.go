@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/ec2"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+type VpcArgs struct {
+	CidrBlock pulumi.StringInput
+	Tags      pulumi.StringMapInput
+}
+
+type Vpc struct {
+	pulumi.ResourceState
+
+	VpcId    pulumi.Output
+	SubnetId pulumi.Output
+}
+
+func NewVpc(ctx *pulumi.Context, name string, args *VpcArgs, opts ...pulumi.ResourceOption) (*Vpc, error) {
+	component := &Vpc{}
+	err := ctx.RegisterComponentResource("components:index:Vpc", name, component, opts...)
+	if err != nil {
+		return nil, err
+	}
+	cidrBlock := args.CidrBlock.ToStringOutput()
+	tags := args.Tags.ToStringMapOutput()
+	vpc, err := ec2.NewVpc(ctx, fmt.Sprintf("%s-vpc", name), &ec2.VpcArgs{
+		CidrBlock: cidrBlock,
+		Tags:      tags,
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, err
+	}
+	subnet, err := ec2.NewSubnet(ctx, fmt.Sprintf("%s-subnet", name), &ec2.SubnetArgs{
+		VpcId:     vpc.ID(),
+		CidrBlock: cidrBlock,
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, err
+	}
+	component.VpcId = pulumi.ToOutput(vpc.ID())
+	component.SubnetId = pulumi.ToOutput(subnet.ID())
+	err = ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"vpcId":    component.VpcId,
+		"subnetId": component.SubnetId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		main, err := NewVpc(ctx, "main", &VpcArgs{
+			CidrBlock: pulumi.String("10.0.0.0/16"),
+			Tags: pulumi.StringMap{
+				"Name": pulumi.String("main"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		ctx.Export("vpcId", main.VpcId)
+		return nil
+	})
+}
@@ -0,0 +1,16 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        // TODO: components are not supported: vpc
+        // TODO: instances of components are not supported: main
+        ctx.export("vpcId", main.vpcId());
+    }
+}
@@ -0,0 +1,31 @@
+import pulumi
+import pulumi_aws as aws
+
+class Vpc(pulumi.ComponentResource):
+    def __init__(self, name, cidr_block, tags, opts=None):
+        super().__init__("components:index:Vpc", name, None, opts)
+
+        cidr_block = pulumi.Output.from_input(cidr_block)
+        tags = pulumi.Output.from_input(tags)
+        vpc = aws.ec2.Vpc(f"{name}-vpc",
+            cidr_block=cidr_block,
+            tags=tags,
+            opts=ResourceOptions(parent=self))
+        subnet = aws.ec2.Subnet(f"{name}-subnet",
+            vpc_id=vpc.id,
+            cidr_block=cidr_block,
+            opts=ResourceOptions(parent=self))
+        self.vpc_id = vpc.id
+        self.subnet_id = subnet.id
+        self.register_outputs({
+            "vpcId": self.vpc_id,
+            "subnetId": self.subnet_id,
+        })
+
+
+main = Vpc("main",
+    cidr_block="10.0.0.0/16",
+    tags={
+        "Name": "main",
+    })
+pulumi.export("vpcId", main.vpc_id)
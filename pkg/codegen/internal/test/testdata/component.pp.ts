@@ -0,0 +1,45 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+interface VpcArgs {
+    cidrBlock: pulumi.Input<string>;
+    tags: pulumi.Input<Record<string, pulumi.Input<string>>>;
+}
+
+class Vpc extends pulumi.ComponentResource {
+    public readonly vpcId: pulumi.Output<string>;
+    public readonly subnetId: pulumi.Output<string>;
+
+    constructor(name: string, args: VpcArgs, opts?: pulumi.ComponentResourceOptions) {
+        super("components:index:Vpc", name, {}, opts);
+
+        const cidrBlock = pulumi.output(args.cidrBlock);
+        const tags = pulumi.output(args.tags);
+        const vpc = new aws.ec2.Vpc(`${name}-vpc`, {
+            cidrBlock: cidrBlock,
+            tags: tags,
+        }, {
+            parent: this,
+        });
+        const subnet = new aws.ec2.Subnet(`${name}-subnet`, {
+            vpcId: vpc.id,
+            cidrBlock: cidrBlock,
+        }, {
+            parent: this,
+        });
+        this.vpcId = vpc.id;
+        this.subnetId = subnet.id;
+        this.registerOutputs({
+            vpcId: this.vpcId,
+            subnetId: this.subnetId,
+        });
+    }
+}
+
+const main = new Vpc("main", {
+    cidrBlock: "10.0.0.0/16",
+    tags: {
+        Name: "main",
+    },
+});
+export const vpcId = main.vpcId;
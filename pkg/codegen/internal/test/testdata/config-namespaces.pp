@@ -0,0 +1,16 @@
+config "aws:region" "string" {
+}
+
+config "proj:region" "string" {
+}
+
+config "aws:profile" "string" {
+}
+
+config region "string" {
+	default = "us-west-2"
+}
+
+output defaultRegion {
+	value = region
+}
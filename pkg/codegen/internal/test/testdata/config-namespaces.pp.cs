@@ -0,0 +1,21 @@
+using Pulumi;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var awsConfig = new Config("aws");
+        // aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+        var awsRegion = awsConfig.Require("region");
+        var projConfig = new Config("proj");
+        var projRegion = projConfig.Require("region");
+        // aws:profile defaults to the value of the AWS_PROFILE environment variable.
+        var profile = awsConfig.Require("profile");
+        var config = new Config();
+        var region = config.Get("region") ?? "us-west-2";
+        this.DefaultRegion = region;
+    }
+
+    [Output("defaultRegion")]
+    public Output<string> DefaultRegion { get; set; }
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		awsConfig := config.New(ctx, "aws")
+		// aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+		_ = awsConfig.Require("region")
+		projConfig := config.New(ctx, "proj")
+		_ = projConfig.Require("region")
+		// aws:profile defaults to the value of the AWS_PROFILE environment variable.
+		_ = awsConfig.Require("profile")
+		cfg := config.New(ctx, "")
+		region := "us-west-2"
+		if param, err := cfg.Try("region"); err == nil {
+			region = param
+		}
+		ctx.Export("defaultRegion", region)
+		return nil
+	})
+}
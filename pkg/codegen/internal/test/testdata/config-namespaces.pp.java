@@ -0,0 +1,24 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.core.Output;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        final var awsConfig = ctx.config("aws");
+        // aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+        final var awsRegion = awsConfig.require("region");
+        final var projConfig = ctx.config("proj");
+        final var projRegion = projConfig.require("region");
+        // aws:profile defaults to the value of the AWS_PROFILE environment variable.
+        final var profile = awsConfig.require("profile");
+        final var config = ctx.config();
+        final var region = config.get("region").orElse("us-west-2");
+        ctx.export("defaultRegion", Output.of(region));
+    }
+}
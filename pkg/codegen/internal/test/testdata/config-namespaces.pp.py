@@ -0,0 +1,14 @@
+import pulumi
+
+aws_config = pulumi.Config("aws")
+# aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+aws_region = aws_config.require("region")
+proj_config = pulumi.Config("proj")
+proj_region = proj_config.require("region")
+# aws:profile defaults to the value of the AWS_PROFILE environment variable.
+profile = aws_config.require("profile")
+config = pulumi.Config()
+region = config.get("region")
+if region is None:
+    region = "us-west-2"
+pulumi.export("defaultRegion", region)
@@ -0,0 +1,12 @@
+import * as pulumi from "@pulumi/pulumi";
+
+const awsConfig = new pulumi.Config("aws");
+// aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+const awsRegion = awsConfig.require("region");
+const projConfig = new pulumi.Config("proj");
+const projRegion = projConfig.require("region");
+// aws:profile defaults to the value of the AWS_PROFILE environment variable.
+const profile = awsConfig.require("profile");
+const config = new pulumi.Config();
+const region = config.get("region") || "us-west-2";
+export const defaultRegion = region;
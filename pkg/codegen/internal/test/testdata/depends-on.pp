@@ -0,0 +1,38 @@
+config createLogs bool {
+	description = "Whether the log buckets are created before the other buckets"
+}
+
+resource provider "pulumi:providers:aws" {
+	region = "us-west-2"
+}
+
+resource logs "aws:s3:Bucket" {
+	options {
+		range = 2
+	}
+}
+
+// Depend on the provider and on every log bucket.
+resource mixed "aws:s3:Bucket" {
+	options {
+		dependsOn = [provider, logs]
+	}
+}
+
+resource conditional "aws:s3:Bucket" {
+	options {
+		dependsOn = [provider, createLogs ? logs : []]
+	}
+}
+
+resource filtered "aws:s3:Bucket" {
+	options {
+		dependsOn = [for i, b in logs: b if i > 0]
+	}
+}
+
+resource nested "aws:s3:Bucket" {
+	options {
+		dependsOn = [for b in logs: [provider, b]]
+	}
+}
@@ -0,0 +1,51 @@
+using System.Collections.Generic;
+using System.Linq;
+using Pulumi;
+using Aws = Pulumi.Aws;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var config = new Config();
+        var createLogs = config.RequireBoolean("createLogs");
+        var provider = new Aws.Provider("provider", new Aws.ProviderArgs
+        {
+            Region = "us-west-2",
+        });
+        var logs = new List<Aws.S3.Bucket>();
+        for (var rangeIndex = 0; rangeIndex < 2; rangeIndex++)
+        {
+            var range = new { Value = rangeIndex };
+            logs.Add(new Aws.S3.Bucket($"logs-{range.Value}", new Aws.S3.BucketArgs
+            {
+            }));
+        }
+        // Depend on the provider and on every log bucket.
+        var mixed = new Aws.S3.Bucket("mixed", new Aws.S3.BucketArgs
+        {
+        }, new CustomResourceOptions
+        {
+            DependsOn = new Resource[] { provider }.Concat(logs.Cast<Resource>()).ToArray(),
+        });
+        var conditional = new Aws.S3.Bucket("conditional", new Aws.S3.BucketArgs
+        {
+        }, new CustomResourceOptions
+        {
+            DependsOn = new Resource[] { provider }.Concat((createLogs ? (IEnumerable<Resource>)logs.Cast<Resource>() : new Resource[] { })).ToArray(),
+        });
+        var filtered = new Aws.S3.Bucket("filtered", new Aws.S3.BucketArgs
+        {
+        }, new CustomResourceOptions
+        {
+            DependsOn = logs.Where((b, i) => i > 0).Select((b, i) => (Resource)b).ToArray(),
+        });
+        var nested = new Aws.S3.Bucket("nested", new Aws.S3.BucketArgs
+        {
+        }, new CustomResourceOptions
+        {
+            DependsOn = logs.SelectMany(b => new Resource[] { provider, b }).ToArray(),
+        });
+    }
+
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		cfg := config.New(ctx, "")
+		createLogs := cfg.RequireBool("createLogs")
+		provider, err := aws.NewProvider(ctx, "provider", &aws.ProviderArgs{
+			Region: pulumi.String("us-west-2"),
+		})
+		if err != nil {
+			return err
+		}
+		var logs []*s3.Bucket
+		for key0 := 0; key0 < 2; key0++ {
+			__res, err := s3.NewBucket(ctx, fmt.Sprintf("logs-%v", key0), nil)
+			if err != nil {
+				return err
+			}
+			logs = append(logs, __res)
+		}
+		var dependsOn0 []pulumi.Resource
+		dependsOn0 = append(dependsOn0, provider)
+		for _, res := range logs {
+			dependsOn0 = append(dependsOn0, res)
+		}
+		_, err = s3.NewBucket(ctx, "mixed", nil, pulumi.DependsOn(dependsOn0))
+		if err != nil {
+			return err
+		}
+		var dependsOn1 []pulumi.Resource
+		dependsOn1 = append(dependsOn1, provider)
+		if createLogs {
+			for _, res := range logs {
+				dependsOn1 = append(dependsOn1, res)
+			}
+		}
+		_, err = s3.NewBucket(ctx, "conditional", nil, pulumi.DependsOn(dependsOn1))
+		if err != nil {
+			return err
+		}
+		var dependsOn2 []pulumi.Resource
+		for i, b := range logs {
+			if !(i > 0) {
+				continue
+			}
+			dependsOn2 = append(dependsOn2, b)
+		}
+		_, err = s3.NewBucket(ctx, "filtered", nil, pulumi.DependsOn(dependsOn2))
+		if err != nil {
+			return err
+		}
+		var dependsOn3 []pulumi.Resource
+		for _, b := range logs {
+			dependsOn3 = append(dependsOn3, provider)
+			dependsOn3 = append(dependsOn3, b)
+		}
+		_, err = s3.NewBucket(ctx, "nested", nil, pulumi.DependsOn(dependsOn3))
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,43 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.aws.Provider;
+import com.pulumi.aws.ProviderArgs;
+import com.pulumi.aws.s3.Bucket;
+import com.pulumi.aws.s3.BucketArgs;
+import com.pulumi.codegen.internal.KeyedValue;
+import com.pulumi.resources.CustomResourceOptions;
+import java.util.ArrayList;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        final var config = ctx.config();
+        final var createLogs = config.requireBoolean("createLogs");
+        var provider = new Provider("provider", ProviderArgs.builder()
+            .region("us-west-2")
+            .build());
+        final var logs = new ArrayList<Bucket>();
+        for (var rangeIndex = 0; rangeIndex < 2; rangeIndex++) {
+            final var range = new KeyedValue<>(rangeIndex, rangeIndex);
+            logs.add(new Bucket("logs-" + range.key()));
+        }
+        // Depend on the provider and on every log bucket.
+        var mixed = new Bucket("mixed", BucketArgs.Empty, CustomResourceOptions.builder()
+            .dependsOn(/* TODO: computed lists of resources are not supported: [provider, logs] (depends-on.pp:17,15-31) */ null)
+            .build());
+        var conditional = new Bucket("conditional", BucketArgs.Empty, CustomResourceOptions.builder()
+            .dependsOn(/* TODO: computed lists of resources are not supported: [provider, createLogs ? logs : []] (depends-on.pp:23,15-49) */ null)
+            .build());
+        var filtered = new Bucket("filtered", BucketArgs.Empty, CustomResourceOptions.builder()
+            .dependsOn(/* TODO: computed lists of resources are not supported: [for i, b in logs: b if i > 0] (depends-on.pp:29,15-45) */ null)
+            .build());
+        var nested = new Bucket("nested", BucketArgs.Empty, CustomResourceOptions.builder()
+            .dependsOn(/* TODO: computed lists of resources are not supported: [for b in logs: [provider, b]] (depends-on.pp:35,15-45) */ null)
+            .build());
+    }
+}
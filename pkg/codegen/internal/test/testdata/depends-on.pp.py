@@ -0,0 +1,23 @@
+import pulumi
+import pulumi_aws as aws
+
+config = pulumi.Config()
+create_logs = config.require_bool("createLogs")
+provider = aws.Provider("provider", region="us-west-2")
+logs = []
+for logs_value in range(0, 2):
+    logs.append(aws.s3.Bucket(f"logs-{logs_value}"))
+# Depend on the provider and on every log bucket.
+mixed = aws.s3.Bucket("mixed", opts=ResourceOptions(depends_on=[
+        provider,
+        *logs,
+    ]))
+conditional = aws.s3.Bucket("conditional", opts=ResourceOptions(depends_on=[
+        provider,
+        *(logs if create_logs else []),
+    ]))
+filtered = aws.s3.Bucket("filtered", opts=ResourceOptions(depends_on=[b for i, b in enumerate(logs) if i > 0]))
+nested = aws.s3.Bucket("nested", opts=ResourceOptions(depends_on=[r for rs in [[
+        provider,
+        b,
+    ] for b in logs] for r in rs]))
@@ -0,0 +1,32 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const config = new pulumi.Config();
+const createLogs = config.requireBoolean("createLogs");
+const provider = new aws.Provider("provider", {region: "us-west-2"});
+const logs: aws.s3.Bucket[] = [];
+for (const range = {value: 0}; range.value < 2; range.value++) {
+    logs.push(new aws.s3.Bucket(`logs-${range.value}`, {}));
+}
+// Depend on the provider and on every log bucket.
+const mixed = new aws.s3.Bucket("mixed", {}, {
+    dependsOn: [
+        provider,
+        ...logs,
+    ],
+});
+const conditional = new aws.s3.Bucket("conditional", {}, {
+    dependsOn: [
+        provider,
+        ...(createLogs ? logs : []),
+    ],
+});
+const filtered = new aws.s3.Bucket("filtered", {}, {
+    dependsOn: logs.map((v, k) => [k, v]).filter(([i, b]) => i > 0).map(([i, b]) => b),
+});
+const nested = new aws.s3.Bucket("nested", {}, {
+    dependsOn: ([] as pulumi.Resource[]).concat(...logs.map(b => [
+        provider,
+        b,
+    ])),
+});
@@ -0,0 +1,9 @@
+provider usEast1 "aws" {
+	region = "us-east-1"
+}
+
+resource bucket "aws:s3:Bucket" {
+	options {
+		provider = usEast1
+	}
+}
@@ -0,0 +1,20 @@
+using Pulumi;
+using Aws = Pulumi.Aws;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var usEast1 = new Aws.Provider("usEast1", new Aws.ProviderArgs
+        {
+            Region = "us-east-1",
+        });
+        var bucket = new Aws.S3.Bucket("bucket", new Aws.S3.BucketArgs
+        {
+        }, new CustomResourceOptions
+        {
+            Provider = usEast1,
+        });
+    }
+
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		usEast1, err := aws.NewProvider(ctx, "usEast1", &aws.ProviderArgs{
+			Region: pulumi.String("us-east-1"),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = s3.NewBucket(ctx, "bucket", nil, pulumi.Provider(usEast1))
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
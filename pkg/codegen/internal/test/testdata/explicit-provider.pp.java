@@ -0,0 +1,24 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.aws.Provider;
+import com.pulumi.aws.ProviderArgs;
+import com.pulumi.aws.s3.Bucket;
+import com.pulumi.aws.s3.BucketArgs;
+import com.pulumi.resources.CustomResourceOptions;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        var usEast1 = new Provider("usEast1", ProviderArgs.builder()
+            .region("us-east-1")
+            .build());
+        var bucket = new Bucket("bucket", BucketArgs.Empty, CustomResourceOptions.builder()
+            .provider(usEast1)
+            .build());
+    }
+}
@@ -0,0 +1,5 @@
+import pulumi
+import pulumi_aws as aws
+
+us_east1 = aws.Provider("usEast1", region="us-east-1")
+bucket = aws.s3.Bucket("bucket", opts=ResourceOptions(provider=us_east1))
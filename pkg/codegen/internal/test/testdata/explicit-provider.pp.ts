@@ -0,0 +1,7 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const usEast1 = new aws.Provider("usEast1", {region: "us-east-1"});
+const bucket = new aws.s3.Bucket("bucket", {}, {
+    provider: usEast1,
+});
@@ -0,0 +1,24 @@
+config "aws:region" "string" {
+	description = "The region in which resources are created"
+}
+
+config "aws:skipCredentialsValidation" {
+}
+
+config "aws:maxRetries" "int" {
+	default = 5
+}
+
+config siteDir "string" {
+	default = "www"
+}
+
+config indexDocument "string" {
+}
+
+resource siteBucket "aws:s3:Bucket" {
+	bucket = siteDir
+	website = {
+		indexDocument = indexDocument
+	}
+}
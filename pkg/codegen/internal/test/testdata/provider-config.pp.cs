@@ -0,0 +1,26 @@
+using Pulumi;
+using Aws = Pulumi.Aws;
+
+class MyStack : Stack
+{
+    public MyStack()
+    {
+        var awsConfig = new Config("aws");
+        // aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+        var region = awsConfig.Require("region");
+        var skipCredentialsValidation = awsConfig.RequireBoolean("skipCredentialsValidation");
+        var maxRetries = awsConfig.GetNumber("maxRetries") ?? 5;
+        var config = new Config();
+        var siteDir = config.Get("siteDir") ?? "www";
+        var indexDocument = config.Require("indexDocument");
+        var siteBucket = new Aws.S3.Bucket("siteBucket", new Aws.S3.BucketArgs
+        {
+            Bucket = siteDir,
+            Website = new Aws.S3.Inputs.BucketWebsiteArgs
+            {
+                IndexDocument = indexDocument,
+            },
+        });
+    }
+
+}
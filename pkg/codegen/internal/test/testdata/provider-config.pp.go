@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi/config"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		awsConfig := config.New(ctx, "aws")
+		// aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+		_ = awsConfig.Require("region")
+		_ = awsConfig.RequireBool("skipCredentialsValidation")
+		cfg := config.New(ctx, "")
+		siteDir := "www"
+		if param, err := cfg.Try("siteDir"); err == nil {
+			siteDir = param
+		}
+		indexDocument := cfg.Require("indexDocument")
+		_, err := s3.NewBucket(ctx, "siteBucket", &s3.BucketArgs{
+			Bucket: pulumi.String(siteDir),
+			Website: &s3.BucketWebsiteArgs{
+				IndexDocument: pulumi.String(indexDocument),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
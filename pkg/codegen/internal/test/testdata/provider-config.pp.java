@@ -0,0 +1,30 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.aws.s3.Bucket;
+import com.pulumi.aws.s3.BucketArgs;
+import com.pulumi.aws.s3.inputs.BucketWebsiteArgs;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        final var awsConfig = ctx.config("aws");
+        // aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+        final var region = awsConfig.require("region");
+        final var skipCredentialsValidation = awsConfig.requireBoolean("skipCredentialsValidation");
+        final var maxRetries = awsConfig.getInteger("maxRetries").orElse(5);
+        final var config = ctx.config();
+        final var siteDir = config.get("siteDir").orElse("www");
+        final var indexDocument = config.require("indexDocument");
+        var siteBucket = new Bucket("siteBucket", BucketArgs.builder()
+            .bucket(siteDir)
+            .website(BucketWebsiteArgs.builder()
+                .indexDocument(indexDocument)
+                .build())
+            .build());
+    }
+}
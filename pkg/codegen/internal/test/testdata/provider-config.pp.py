@@ -0,0 +1,20 @@
+import pulumi
+import pulumi_aws as aws
+
+aws_config = pulumi.Config("aws")
+# aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+region = aws_config.require("region")
+skip_credentials_validation = aws_config.require_bool("skipCredentialsValidation")
+max_retries = aws_config.get_int("maxRetries")
+if max_retries is None:
+    max_retries = 5
+config = pulumi.Config()
+site_dir = config.get("siteDir")
+if site_dir is None:
+    site_dir = "www"
+index_document = config.require("indexDocument")
+site_bucket = aws.s3.Bucket("siteBucket",
+    bucket=site_dir,
+    website={
+        "indexDocument": index_document,
+    })
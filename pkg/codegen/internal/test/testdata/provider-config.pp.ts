@@ -0,0 +1,17 @@
+import * as pulumi from "@pulumi/pulumi";
+import * as aws from "@pulumi/aws";
+
+const awsConfig = new pulumi.Config("aws");
+// aws:region defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variables.
+const region = awsConfig.require("region");
+const skipCredentialsValidation = awsConfig.requireBoolean("skipCredentialsValidation");
+const maxRetries = awsConfig.getNumber("maxRetries") || 5;
+const config = new pulumi.Config();
+const siteDir = config.get("siteDir") || "www";
+const indexDocument = config.require("indexDocument");
+const siteBucket = new aws.s3.Bucket("siteBucket", {
+    bucket: siteDir,
+    website: {
+        indexDocument: indexDocument,
+    },
+});
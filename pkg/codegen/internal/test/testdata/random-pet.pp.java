@@ -0,0 +1,18 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.random.RandomPet;
+import com.pulumi.random.RandomPetArgs;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        var random_pet = new RandomPet("random_pet", RandomPetArgs.builder()
+            .prefix("doggo")
+            .build());
+    }
+}
@@ -8,5 +8,17 @@ resource bucket1 "aws:s3:Bucket" {
 		dependsOn = [provider]
 		protect = true
 		ignoreChanges = [bucket, lifecycleRules[0]]
+		version = "1.0.0"
+	}
+}
+
+resource bucket2 "aws:s3:Bucket" {
+	options {
+		aliases = ["urn:pulumi:stack::project::aws:s3/bucket:Bucket::oldBucket", { name = "bucket", parent = provider }]
+		customTimeouts = {
+			create = "5m"
+			delete = "1h30m"
+		}
+		deleteBeforeReplace = true
 	}
 }
@@ -24,6 +24,30 @@ class MyStack : Stack
                 "bucket",
                 "lifecycleRules[0]",
             },
+            Version = "1.0.0",
+        });
+        var bucket2 = new Aws.S3.Bucket("bucket2", new Aws.S3.BucketArgs
+        {
+        }, new CustomResourceOptions
+        {
+            Aliases =
+            {
+                new Alias
+                {
+                    Urn = "urn:pulumi:stack::project::aws:s3/bucket:Bucket::oldBucket",
+                },
+                new Alias
+                {
+                    Name = "bucket",
+                    Parent = provider,
+                },
+            },
+            CustomTimeouts = new CustomTimeouts
+            {
+                Create = System.TimeSpan.FromMinutes(5),
+                Delete = System.TimeSpan.FromMinutes(90),
+            },
+            DeleteBeforeReplace = true,
         });
     }
 
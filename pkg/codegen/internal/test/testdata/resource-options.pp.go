@@ -1,14 +1,14 @@
 package main
 
 import (
-	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/providers"
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws"
 	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/s3"
 	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
 )
 
 func main() {
 	pulumi.Run(func(ctx *pulumi.Context) error {
-		provider, err := providers.Newaws(ctx, "provider", &providers.awsArgs{
+		provider, err := aws.NewProvider(ctx, "provider", &aws.ProviderArgs{
 			Region: pulumi.String("us-west-2"),
 		})
 		if err != nil {
@@ -19,7 +19,22 @@ func main() {
 		}), pulumi.Protect(true), pulumi.IgnoreChanges([]string{
 			"bucket",
 			"lifecycleRules[0]",
-		}))
+		}), pulumi.Version("1.0.0"))
+		if err != nil {
+			return err
+		}
+		_, err = s3.NewBucket(ctx, "bucket2", nil, pulumi.Aliases([]pulumi.Alias{
+			{
+				URN: pulumi.URN("urn:pulumi:stack::project::aws:s3/bucket:Bucket::oldBucket"),
+			},
+			{
+				Name:   pulumi.String("bucket"),
+				Parent: provider,
+			},
+		}), pulumi.Timeouts(&pulumi.CustomTimeouts{
+			Create: "5m",
+			Delete: "1h30m",
+		}), pulumi.DeleteBeforeReplace(true))
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,42 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.aws.Provider;
+import com.pulumi.aws.ProviderArgs;
+import com.pulumi.aws.s3.Bucket;
+import com.pulumi.aws.s3.BucketArgs;
+import com.pulumi.core.Alias;
+import com.pulumi.resources.CustomResourceOptions;
+import com.pulumi.resources.CustomTimeouts;
+import java.time.Duration;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        var provider = new Provider("provider", ProviderArgs.builder()
+            .region("us-west-2")
+            .build());
+        var bucket1 = new Bucket("bucket1", BucketArgs.Empty, CustomResourceOptions.builder()
+            .provider(provider)
+            .dependsOn(provider)
+            .protect(true)
+            .ignoreChanges("bucket", "lifecycleRules[0]")
+            .version("1.0.0")
+            .build());
+        var bucket2 = new Bucket("bucket2", BucketArgs.Empty, CustomResourceOptions.builder()
+            .aliases(Alias.withUrn("urn:pulumi:stack::project::aws:s3/bucket:Bucket::oldBucket"), Alias.builder()
+                .name("bucket")
+                .parent(provider)
+                .build())
+            .customTimeouts(CustomTimeouts.builder()
+                .create(Duration.ofMinutes(5))
+                .delete(Duration.ofMinutes(90))
+                .build())
+            .deleteBeforeReplace(true)
+            .build());
+    }
+}
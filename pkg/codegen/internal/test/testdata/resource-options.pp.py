@@ -1,12 +1,18 @@
 import pulumi
 import pulumi_aws as aws
-import pulumi_pulumi as pulumi
 
-provider = pulumi.providers.Aws("provider", region="us-west-2")
+provider = aws.Provider("provider", region="us-west-2")
 bucket1 = aws.s3.Bucket("bucket1", opts=ResourceOptions(provider=provider,
     depends_on=[provider],
     protect=True,
     ignore_changes=[
         "bucket",
         "lifecycleRules[0]",
-    ]))
+    ],
+    version="1.0.0"))
+bucket2 = aws.s3.Bucket("bucket2", opts=ResourceOptions(aliases=[
+        "urn:pulumi:stack::project::aws:s3/bucket:Bucket::oldBucket",
+        pulumi.Alias(name="bucket", parent=provider),
+    ],
+    custom_timeouts=pulumi.CustomTimeouts(create="5m", delete="1h30m"),
+    delete_before_replace=True))
@@ -10,4 +10,19 @@ const bucket1 = new aws.s3.Bucket("bucket1", {}, {
         "bucket",
         "lifecycleRules[0]",
     ],
+    version: "1.0.0",
+});
+const bucket2 = new aws.s3.Bucket("bucket2", {}, {
+    aliases: [
+        "urn:pulumi:stack::project::aws:s3/bucket:Bucket::oldBucket",
+        {
+            name: "bucket",
+            parent: provider,
+        },
+    ],
+    customTimeouts: {
+        create: "5m",
+        "delete": "1h30m",
+    },
+    deleteBeforeReplace: true,
 });
@@ -0,0 +1,19 @@
+package myproject;
+
+import com.pulumi.Context;
+import com.pulumi.Pulumi;
+import com.pulumi.aws.rds.Cluster;
+import com.pulumi.aws.rds.ClusterArgs;
+import com.pulumi.core.Output;
+
+public class App {
+    public static void main(String[] args) {
+        Pulumi.run(App::stack);
+    }
+
+    public static void stack(Context ctx) {
+        var dbCluster = new Cluster("dbCluster", ClusterArgs.builder()
+            .masterPassword(Output.ofSecret("foobar"))
+            .build());
+    }
+}
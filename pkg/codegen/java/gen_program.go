@@ -0,0 +1,733 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package java generates Java programs from PCL programs. A generated program is a single class whose stack method
+// declares the program's configuration, variables, resources, and outputs in dependency order. Resource arguments are
+// constructed with builders, and values that depend on outputs are computed with applyValue.
+package java
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model/format"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// programFile is the name of the generated program.
+const programFile = "App.java"
+
+type generator struct {
+	// The formatter to use when generating code.
+	*format.Formatter
+	program *hcl2.Program
+	// Type names per invoke function token.
+	functionArgs map[string]string
+	// The names of the local variables of the stack method, which the parameters of lambdas may not shadow.
+	locals codegen.StringSet
+	// The qualified names of the imported classes by simple name, and the statically imported members.
+	imports       map[string]string
+	staticImports codegen.StringSet
+	// The names of the helper methods that the stack method calls, e.g. readDir.
+	helpers codegen.StringSet
+
+	configCreated    bool
+	configNamespaces codegen.StringSet
+	diagnostics      hcl.Diagnostics
+	// The report that records the fidelity of the conversion, if any.
+	report *codegen.ConversionReport
+}
+
+// GenerateProgram generates a Java program for the given PCL program. Constructs that cannot be translated, e.g.
+// components and for expressions, are replaced with TODO comments and reported as errors.
+func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
+	return GenerateProgramWithOptions(program, codegen.GenerateProgramOptions{})
+}
+
+// GenerateProgramWithOptions generates a Java program for the given PCL program. Only the options' Report is supported;
+// the Java generator does not emit source maps.
+func GenerateProgramWithOptions(program *hcl2.Program,
+	options codegen.GenerateProgramOptions) (map[string][]byte, hcl.Diagnostics, error) {
+
+	// Linearize the nodes into an order appropriate for procedural code generation.
+	nodes := hcl2.Linearize(program)
+
+	functionArgs := make(map[string]string)
+	for _, p := range program.Packages() {
+		for _, f := range p.Functions {
+			if f.Inputs != nil {
+				functionArgs[f.Inputs.Token] = f.Token
+			}
+		}
+	}
+
+	g := &generator{
+		program:      program,
+		functionArgs: functionArgs,
+		locals:       codegen.NewStringSet("args", "ctx", "config", "range", "rangeIndex", "values"),
+		imports: map[string]string{
+			"App":     "",
+			"Context": "com.pulumi.Context",
+			"Pulumi":  "com.pulumi.Pulumi",
+		},
+		staticImports:    codegen.NewStringSet(),
+		helpers:          codegen.NewStringSet(),
+		configNamespaces: codegen.NewStringSet(),
+		report:           options.Report,
+	}
+	g.Formatter = format.NewFormatter(g)
+	program.Report(g.report)
+
+	for _, n := range nodes {
+		g.locals.Add(g.variableName(n))
+	}
+
+	// Generate the body of the stack method first, as it determines the classes that must be imported.
+	var body bytes.Buffer
+	g.Indented(func() {
+		g.Indented(func() {
+			for _, n := range program.Nodes {
+				if c, ok := n.(*hcl2.Component); ok {
+					g.genNYIDeclaration(&body, c.SyntaxNode().Range(), c.Name(), "components are not supported")
+				}
+			}
+			for _, n := range nodes {
+				g.genNode(&body, n)
+			}
+		})
+	})
+
+	// The helper methods follow the stack method, but the classes they use must be imported by the preamble.
+	var helpers bytes.Buffer
+	g.genHelperMethods(&helpers)
+
+	var index bytes.Buffer
+	g.genPreamble(&index)
+	_, err := index.Write(body.Bytes())
+	contract.IgnoreError(err)
+	g.genPostamble(&index, helpers.Bytes())
+
+	return map[string][]byte{programFile: index.Bytes()}, g.diagnostics, nil
+}
+
+// genLeadingTrivia generates the list of leading trivia associated with a given token.
+func (g *generator) genLeadingTrivia(w io.Writer, token syntax.Token) {
+	for _, t := range token.LeadingTrivia {
+		if c, ok := t.(syntax.Comment); ok {
+			g.genComment(w, c)
+		}
+	}
+}
+
+// genTrailingTrivia generates the list of trailing trivia associated with a given token.
+func (g *generator) genTrailingTrivia(w io.Writer, token syntax.Token) {
+	for _, t := range token.TrailingTrivia {
+		if c, ok := t.(syntax.Comment); ok {
+			g.genComment(w, c)
+		}
+	}
+}
+
+// genTrivia generates the list of trivia associated with a given token.
+func (g *generator) genTrivia(w io.Writer, token syntax.Token) {
+	g.genLeadingTrivia(w, token)
+	g.genTrailingTrivia(w, token)
+}
+
+// genLineComment appends the comments in the given trivia that continue the line of the preceding token, if any, to
+// the current line of output.
+func (g *generator) genLineComment(w io.Writer, trivia syntax.TriviaList) {
+	_, line := splitComments(trivia)
+	for _, l := range line {
+		g.Fgenf(w, " //%s", l)
+	}
+}
+
+// splitComments splits the lines of the comments in the given trivia into those that start on a line of their own and
+// those that follow the preceding token on its line. The trivia that precedes a token begins at the start of a line
+// unless it is on the same line as the preceding token.
+func splitComments(trivia syntax.TriviaList) (head, line []string) {
+	ownLine := len(trivia) != 0 && trivia[0].Range().Start.Column <= 1
+	for _, t := range trivia {
+		switch t := t.(type) {
+		case syntax.Whitespace:
+			ownLine = ownLine || bytes.IndexByte(t.Bytes(), '\n') != -1
+		case syntax.Comment:
+			if ownLine {
+				head = append(head, t.Lines...)
+			} else {
+				line = append(line, t.Lines...)
+			}
+			ownLine = bytes.HasSuffix(t.Bytes(), []byte("\n"))
+		}
+	}
+	return head, line
+}
+
+// attributeComments returns the lines of the comments that document each of the given resource's inputs: those on the
+// lines above the input, and those on the same line. A comment on the same line as an input is scanned as trivia of
+// the next input's name, or of the resource's closing brace.
+func attributeComments(r *hcl2.Resource) [][]string {
+	comments := make([][]string, len(r.Inputs))
+	for i, attr := range r.Inputs {
+		head, _ := splitComments(attr.Tokens.GetName(attr.Name).LeadingTrivia)
+
+		next := r.Definition.Tokens.GetCloseBrace().LeadingTrivia
+		if i+1 < len(r.Inputs) {
+			nextAttr := r.Inputs[i+1]
+			next = nextAttr.Tokens.GetName(nextAttr.Name).LeadingTrivia
+		}
+		_, line := splitComments(next)
+		_, valueLine := splitComments(attr.Value.GetTrailingTrivia())
+
+		comments[i] = append(append(head, valueLine...), line...)
+	}
+	return comments
+}
+
+// genComment generates a comment into the output.
+func (g *generator) genComment(w io.Writer, comment syntax.Comment) {
+	for _, l := range comment.Lines {
+		g.Fgenf(w, "%s//%s\n", g.Indent, l)
+	}
+}
+
+// genPreamble generates the package declaration, the imports, and the declarations of the class and its methods.
+func (g *generator) genPreamble(w io.Writer) {
+	g.Fprint(w, "package myproject;\n\n")
+
+	var imports []string
+	for _, qualifiedName := range g.imports {
+		if qualifiedName != "" {
+			imports = append(imports, qualifiedName)
+		}
+	}
+	sort.Strings(imports)
+	for _, qualifiedName := range imports {
+		g.Fprintf(w, "import %s;\n", qualifiedName)
+	}
+	if len(g.staticImports) != 0 {
+		g.Fprint(w, "\n")
+		for _, member := range g.staticImports.SortedValues() {
+			g.Fprintf(w, "import static %s;\n", member)
+		}
+	}
+	g.Fprint(w, "\n")
+
+	g.Fprint(w, "public class App {\n")
+	g.Fprint(w, "    public static void main(String[] args) {\n")
+	g.Fprint(w, "        Pulumi.run(App::stack);\n")
+	g.Fprint(w, "    }\n\n")
+	g.Fprint(w, "    public static void stack(Context ctx) {\n")
+}
+
+// genPostamble closes the stack method, writes the given helper methods, and closes the class.
+func (g *generator) genPostamble(w io.Writer, helpers []byte) {
+	g.Fprint(w, "    }\n")
+	_, err := w.Write(helpers)
+	contract.IgnoreError(err)
+	g.Fprint(w, "}\n")
+}
+
+// helperMethod records that the stack method calls the helper method with the given name and returns the name.
+func (g *generator) helperMethod(name string) string {
+	g.helpers.Add(name)
+	return name
+}
+
+// genHelperMethods generates the helper methods that the stack method calls. The helpers wrap the java.nio.file
+// methods that throw IOException, which neither the stack method nor the lambdas it contains may throw.
+func (g *generator) genHelperMethods(w io.Writer) {
+	for _, name := range g.helpers.SortedValues() {
+		ioException, uncheckedIOException := g.importClass("java.io.IOException"),
+			g.importClass("java.io.UncheckedIOException")
+		files, paths := g.importClass("java.nio.file.Files"), g.importClass("java.nio.file.Paths")
+
+		g.Fprint(w, "\n")
+		switch name {
+		case "mimeType":
+			g.Fprint(w, "    private static String mimeType(String path) {\n")
+			g.Fprint(w, "        try {\n")
+			g.Fprintf(w, "            return %s.probeContentType(%s.get(path));\n", files, paths)
+		case "readDir":
+			g.Fprintf(w, "    private static %s<String> readDir(String path) {\n", g.importClass("java.util.List"))
+			g.Fprintf(w, "        try (var files = %s.list(%s.get(path))) {\n", files, paths)
+			g.Fprintf(w, "            return files.map(file -> file.getFileName().toString()).sorted().collect(%s());\n",
+				g.importStatic("java.util.stream.Collectors.toList"))
+		default:
+			contract.Failf("unknown helper method %v", name)
+		}
+		g.Fprintf(w, "        } catch (%s e) {\n", ioException)
+		g.Fprintf(w, "            throw new %s(e);\n", uncheckedIOException)
+		g.Fprint(w, "        }\n")
+		g.Fprint(w, "    }\n")
+	}
+}
+
+func (g *generator) genNode(w io.Writer, n hcl2.Node) {
+	switch n := n.(type) {
+	case *hcl2.Resource:
+		g.genResource(w, n)
+	case *hcl2.Provider:
+		g.genResource(w, n.Resource)
+	case *hcl2.ConfigVariable:
+		g.genConfigVariable(w, n)
+	case *hcl2.LocalVariable:
+		g.genLocalVariable(w, n)
+	case *hcl2.OutputVariable:
+		g.genOutputVariable(w, n)
+	}
+}
+
+// variableName returns the name of the local variable declared for the given node.
+func (g *generator) variableName(n hcl2.Node) string {
+	if v, ok := n.(*hcl2.ConfigVariable); ok {
+		return makeValidIdentifier(v.VariableName(g.program))
+	}
+	return makeValidIdentifier(n.Name())
+}
+
+// importClass returns the name by which the program refers to the class with the given qualified name. The class is
+// imported unless another class with the same simple name is already imported, in which case its qualified name is
+// used.
+func (g *generator) importClass(qualifiedName string) string {
+	simpleName := qualifiedName[strings.LastIndex(qualifiedName, ".")+1:]
+	if existing, ok := g.imports[simpleName]; ok && existing != qualifiedName {
+		return qualifiedName
+	}
+	g.imports[simpleName] = qualifiedName
+	return simpleName
+}
+
+// importStatic statically imports the given member and returns its simple name.
+func (g *generator) importStatic(qualifiedName string) string {
+	g.staticImports.Add(qualifiedName)
+	return qualifiedName[strings.LastIndex(qualifiedName, ".")+1:]
+}
+
+// modulePackage returns the Java package for the given Pulumi package and module, e.g. com.pulumi.aws.ec2.
+func modulePackage(pkg, module string) string {
+	module = strings.Split(module, "/")[0]
+	if module == "" || module == "index" {
+		return "com.pulumi." + packageName(pkg)
+	}
+	return fmt.Sprintf("com.pulumi.%s.%s", packageName(pkg), packageName(module))
+}
+
+// resourceTypeName returns the names of the Java class and the argument class for the given resource.
+func (g *generator) resourceTypeName(r *hcl2.Resource) (string, string) {
+	// Compute the resource type from the Pulumi type token.
+	pkg, module, member, diags := r.DecomposeToken()
+	contract.Assert(len(diags) == 0)
+	if pkg == "pulumi" && module == "providers" {
+		pkg, module, member = member, "", "Provider"
+	}
+
+	qualifiedName := fmt.Sprintf("%s.%s", modulePackage(pkg, module), Title(member))
+	return g.importClass(qualifiedName), g.importClass(qualifiedName + "Args")
+}
+
+// functionName returns the name of the Java class that declares the function with the given token and the name of the
+// function's method, e.g. Ec2Functions and getVpc.
+func (g *generator) functionName(tokenArg model.Expression) (string, string) {
+	token := tokenArg.(*model.TemplateExpression).Parts[0].(*model.LiteralValueExpression).Value.AsString()
+	tokenRange := tokenArg.SyntaxNode().Range()
+
+	pkg, module, member, diags := hcl2.DecomposeToken(token, tokenRange)
+	contract.Assert(len(diags) == 0)
+	className := Title(packageName(pkg))
+	if module := strings.Split(module, "/")[0]; module != "" && module != "index" {
+		className = Title(packageName(module))
+	}
+
+	qualifiedName := fmt.Sprintf("%s.%sFunctions", modulePackage(pkg, module), className)
+	return g.importClass(qualifiedName), makeValidIdentifier(camel(member))
+}
+
+// argumentTypeName returns the name of the Java argument class for the given expression and model type, if any. The
+// classes of the arguments of resources and functions are declared in the inputs package of their module.
+func (g *generator) argumentTypeName(expr model.Expression, destType model.Type) string {
+	schemaType, ok := g.program.GetSchemaForType(destType)
+	if !ok {
+		return ""
+	}
+
+	objType, ok := schemaType.(*schema.ObjectType)
+	if !ok {
+		return ""
+	}
+
+	token := objType.Token
+	if f, ok := g.functionArgs[token]; ok {
+		token = f
+	}
+
+	pkg, module, member, diags := hcl2.DecomposeToken(token, expr.SyntaxNode().Range())
+	contract.Assert(len(diags) == 0)
+	return g.importClass(fmt.Sprintf("%s.inputs.%sArgs", modulePackage(pkg, module), Title(member)))
+}
+
+// makeResourceName returns the expression that should be emitted for a resource's "name" parameter given its base name
+// and the key expression, if any.
+func (g *generator) makeResourceName(baseName, key string) string {
+	if key == "" {
+		return fmt.Sprintf(`"%s"`, baseName)
+	}
+	return fmt.Sprintf(`"%s-" + %s`, baseName, key)
+}
+
+// genResourceOptions generates the builder for the options of a resource, if the resource has any. The options are
+// preceded by a comma so that they can be appended to the resource's constructor arguments.
+func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
+	if opts == nil {
+		return ""
+	}
+
+	var result bytes.Buffer
+	g.Indented(func() {
+		appendOption := func(name string, gen func()) {
+			if result.Len() == 0 {
+				g.Fgenf(&result, ", %s.builder()", g.importClass("com.pulumi.resources.CustomResourceOptions"))
+			}
+			g.Fgenf(&result, "\n%s.%s(", g.Indent, name)
+			gen()
+			g.Fgen(&result, ")")
+		}
+		appendValue := func(name string, value model.Expression) {
+			appendOption(name, func() { g.genBuilderArgument(&result, g.lowerExpression(value, value.Type())) })
+		}
+
+		if opts.Parent != nil {
+			appendValue("parent", opts.Parent)
+		}
+		if opts.Provider != nil {
+			appendValue("provider", opts.Provider)
+		}
+		if opts.DependsOn != nil {
+			if hcl2.IsStaticResourceList(opts.DependsOn) {
+				appendValue("dependsOn", opts.DependsOn)
+			} else {
+				appendOption("dependsOn", func() {
+					g.genNYI(&result, opts.DependsOn, "computed lists of resources are not supported")
+				})
+			}
+		}
+		if opts.Protect != nil {
+			appendValue("protect", opts.Protect)
+		}
+		if opts.IgnoreChanges != nil {
+			appendValue("ignoreChanges", opts.IgnoreChanges)
+		}
+		if opts.Aliases != nil {
+			appendOption("aliases", func() { g.genAliases(&result, opts.Aliases) })
+		}
+		if opts.CustomTimeouts != nil {
+			appendOption("customTimeouts", func() {
+				g.Fgenf(&result, "%s.builder()", g.importClass("com.pulumi.resources.CustomTimeouts"))
+				g.Indented(func() {
+					for _, timeout := range hcl2.CustomTimeouts(opts.CustomTimeouts) {
+						g.Fgenf(&result, "\n%s.%s(%s)", g.Indent, timeout.Operation, g.duration(timeout.Duration))
+					}
+					g.Fgenf(&result, "\n%s.build()", g.Indent)
+				})
+			})
+		}
+		if opts.DeleteBeforeReplace != nil {
+			appendValue("deleteBeforeReplace", opts.DeleteBeforeReplace)
+		}
+		if opts.Version != nil {
+			appendValue("version", opts.Version)
+		}
+
+		if result.Len() != 0 {
+			g.Fgenf(&result, "\n%s.build()", g.Indent)
+		}
+	})
+	return result.String()
+}
+
+// genAliases generates the arguments of the aliases option. An alias is either a URN or an object that describes the
+// alias.
+func (g *generator) genAliases(w io.Writer, aliases model.Expression) {
+	tuple, ok := aliases.(*model.TupleConsExpression)
+	if !ok {
+		g.genNYI(w, aliases, "computed aliases are not supported")
+		return
+	}
+
+	aliasClass := g.importClass("com.pulumi.core.Alias")
+	for i, alias := range tuple.Expressions {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+
+		obj, ok := alias.(*model.ObjectConsExpression)
+		if !ok {
+			g.Fgenf(w, "%s.withUrn(%.v)", aliasClass, g.lowerExpression(alias, alias.Type()))
+			continue
+		}
+		g.Fgenf(w, "%s.builder()", aliasClass)
+		g.Indented(func() {
+			for _, item := range obj.Items {
+				key := item.Key.(*model.LiteralValueExpression).Value.AsString()
+				g.Fgenf(w, "\n%s.%s(%.v)", g.Indent, makeValidIdentifier(key),
+					g.lowerExpression(item.Value, item.Value.Type()))
+			}
+			g.Fgenf(w, "\n%s.build()", g.Indent)
+		})
+	}
+}
+
+// duration generates a Java expression of type java.time.Duration for the given duration. The expression uses the
+// largest unit that represents the duration exactly, e.g. Duration.ofMinutes(5) for a duration of 5m.
+func (g *generator) duration(d time.Duration) string {
+	units := []struct {
+		method string
+		unit   time.Duration
+	}{
+		{"ofHours", time.Hour},
+		{"ofMinutes", time.Minute},
+		{"ofSeconds", time.Second},
+		{"ofMillis", time.Millisecond},
+	}
+	durationClass := g.importClass("java.time.Duration")
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%s.%s(%d)", durationClass, u.method, d/u.unit)
+		}
+	}
+	return fmt.Sprintf("%s.ofNanos(%d)", durationClass, d)
+}
+
+// genResource handles the generation of instantiations of non-builtin resources.
+func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
+	if r.Component != nil {
+		g.genNYIDeclaration(w, r.SyntaxNode().Range(), r.Name(), "instances of components are not supported")
+		return
+	}
+
+	className, argsClassName := g.resourceTypeName(r)
+
+	// Collect the inputs' comments before the inputs are rewritten.
+	comments := attributeComments(r)
+
+	// Add conversions to input properties
+	for _, input := range r.Inputs {
+		destType, diagnostics := r.InputType.Traverse(hcl.TraverseAttr{Name: input.Name})
+		g.diagnostics = append(g.diagnostics, diagnostics...)
+		input.Value = hcl2.RewriteSecrets(input.Value, destType.(model.Type))
+		input.Value = g.lowerExpression(input.Value, destType.(model.Type))
+	}
+
+	name := r.Name()
+	variableName := makeValidIdentifier(name)
+
+	g.genTrivia(w, r.Definition.Tokens.GetType(""))
+	for _, l := range r.Definition.Tokens.GetLabels(nil) {
+		g.genTrivia(w, l)
+	}
+	g.genTrivia(w, r.Definition.Tokens.GetOpenBrace())
+
+	instantiate := func(resName string) {
+		g.Fgenf(w, "new %s(%s", className, resName)
+		if len(r.Inputs) == 0 {
+			if options := g.genResourceOptions(r.Options); options != "" {
+				g.Fgenf(w, ", %s.Empty%s", argsClassName, options)
+			}
+			g.Fgen(w, ")")
+			return
+		}
+
+		g.Fgenf(w, ", %s.builder()", argsClassName)
+		g.Indented(func() {
+			for i, attr := range r.Inputs {
+				for _, l := range comments[i] {
+					g.Fgenf(w, "\n%s//%s", g.Indent, l)
+				}
+				g.Fgenf(w, "\n%s.%s(", g.Indent, makeValidIdentifier(attr.Name))
+				g.genBuilderArgument(w, attr.Value)
+				g.Fgen(w, ")")
+			}
+			g.Fgenf(w, "\n%s.build()", g.Indent)
+
+			// The options follow the arguments, so their builder is indented beneath the arguments' builder.
+			g.Fgen(w, g.genResourceOptions(r.Options))
+		})
+		g.Fgen(w, ")")
+	}
+
+	if r.Options != nil && r.Options.Range != nil {
+		rangeType := model.ResolveOutputs(r.Options.Range.Type())
+		rangeExpr := g.lowerExpression(r.Options.Range, rangeType)
+
+		if r.HasMapRange() {
+			g.Fgenf(w, "%sfinal var %s = new %s<String, %s>();\n", g.Indent, variableName,
+				g.importClass("java.util.HashMap"), className)
+		} else {
+			g.Fgenf(w, "%sfinal var %s = new %s<%s>();\n", g.Indent, variableName,
+				g.importClass("java.util.ArrayList"), className)
+		}
+
+		keyedValue := g.importClass("com.pulumi.codegen.internal.KeyedValue")
+		switch {
+		case model.ContainsOutputs(r.Options.Range.Type()) || model.ContainsPromises(r.Options.Range.Type()):
+			// A Java program cannot iterate over an output.
+			g.Fgenf(w, "%sfor (var range : %s.of(", g.Indent, keyedValue)
+			g.genNYI(w, r.Options.Range, "ranges over eventual values are not supported")
+			g.Fgen(w, ")) {\n")
+		case model.InputType(model.NumberType).ConversionFrom(rangeExpr.Type()) != model.NoConversion:
+			g.Fgenf(w, "%sfor (var rangeIndex = 0; rangeIndex < %.12o; rangeIndex++) {\n", g.Indent, rangeExpr)
+			g.Fgenf(w, "%s    final var range = new %s<>(rangeIndex, rangeIndex);\n", g.Indent, keyedValue)
+		default:
+			g.Fgenf(w, "%sfor (var range : %s.of(%.v)) {\n", g.Indent, keyedValue, rangeExpr)
+		}
+
+		resName := g.makeResourceName(name, "range.key()")
+		g.Indented(func() {
+			if r.HasMapRange() {
+				g.Fgenf(w, "%s%s.put(range.key(), ", g.Indent, variableName)
+			} else {
+				g.Fgenf(w, "%s%s.add(", g.Indent, variableName)
+			}
+			instantiate(resName)
+			g.Fgen(w, ");\n")
+		})
+		g.Fgenf(w, "%s}\n", g.Indent)
+	} else {
+		g.Fgenf(w, "%svar %s = ", g.Indent, variableName)
+		instantiate(g.makeResourceName(name, ""))
+		g.Fgen(w, ";\n")
+	}
+
+	// Comments on the same line as the last input were generated with the input.
+	closeBrace := r.Definition.Tokens.GetCloseBrace()
+	if len(r.Inputs) == 0 {
+		g.genTrivia(w, closeBrace)
+	} else {
+		head, _ := splitComments(closeBrace.LeadingTrivia)
+		for _, l := range head {
+			g.Fgenf(w, "%s//%s\n", g.Indent, l)
+		}
+		g.genTrailingTrivia(w, closeBrace)
+	}
+}
+
+func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
+	configObject, key := "config", v.Name()
+	if namespace := v.Namespace(); namespace != "" {
+		configObject, key = makeValidIdentifier(namespace+"Config"), v.Key()
+		if !g.configNamespaces.Has(namespace) {
+			g.Fgenf(w, "%sfinal var %s = ctx.config(\"%s\");\n", g.Indent, configObject, namespace)
+			g.configNamespaces.Add(namespace)
+		}
+	} else if !g.configCreated {
+		g.Fgenf(w, "%sfinal var config = ctx.config();\n", g.Indent)
+		g.configCreated = true
+	}
+
+	if v.ProviderConfig != nil {
+		if description := hcl2.DescribeDefaultValue(v.ProviderConfig); description != "" {
+			g.Fgenf(w, "%s// %s %s.\n", g.Indent, v.Name(), description)
+		}
+	}
+
+	getType, objectClass := "", ""
+	switch v.Type() {
+	case model.StringType:
+	case model.IntType:
+		getType = "Integer"
+	case model.NumberType:
+		getType = "Double"
+	case model.BoolType:
+		getType = "Boolean"
+	default:
+		getType, objectClass = "Object", "Object"
+		switch v.Type().(type) {
+		case *model.ListType:
+			objectClass = g.importClass("java.util.List")
+		case *model.MapType, *model.ObjectType:
+			objectClass = g.importClass("java.util.Map")
+		}
+	}
+
+	args := fmt.Sprintf("\"%s\"", key)
+	if objectClass != "" {
+		args = fmt.Sprintf("%s, %s.class", args, objectClass)
+	}
+
+	g.Fgenf(w, "%sfinal var %s = ", g.Indent, g.variableName(v))
+	if v.DefaultValue == nil {
+		g.Fgenf(w, "%s.require%s(%s);\n", configObject, getType, args)
+	} else {
+		g.Fgenf(w, "%s.get%s(%s).orElse(%.v);\n", configObject, getType, args,
+			g.lowerExpression(v.DefaultValue, v.DefaultValue.Type()))
+	}
+}
+
+func (g *generator) genLocalVariable(w io.Writer, v *hcl2.LocalVariable) {
+	g.genLeadingTrivia(w, v.Definition.Tokens.GetName(v.Name()))
+	trailing := v.Definition.Value.GetTrailingTrivia()
+
+	expr := g.lowerExpression(v.Definition.Value, v.Type())
+	g.Fgenf(w, "%sfinal var %s = %.v;", g.Indent, g.variableName(v), expr)
+	g.genLineComment(w, trailing)
+	g.Fgen(w, "\n")
+}
+
+func (g *generator) genOutputVariable(w io.Writer, v *hcl2.OutputVariable) {
+	g.genLeadingTrivia(w, v.Definition.Tokens.GetType(""))
+	trailing := v.Value.GetTrailingTrivia()
+
+	expr := g.lowerExpression(v.Value, v.Type())
+	g.Fgenf(w, "%sctx.export(\"%s\", ", g.Indent, v.Name())
+	if isOutput(expr) {
+		g.Fgenf(w, "%.v", expr)
+	} else {
+		g.Fgenf(w, "%s.of(%.v)", g.importClass("com.pulumi.core.Output"), expr)
+	}
+	g.Fgen(w, ");")
+	g.genLineComment(w, trailing)
+	g.Fgen(w, "\n")
+}
+
+// genNYI replaces an untranslatable expression with a commented null.
+func (g *generator) genNYI(w io.Writer, expr model.Expression, reason string, vs ...interface{}) {
+	construct := g.program.NewUnsupportedConstruct(expr, reason, vs...)
+	g.diagnostics = append(g.diagnostics, construct.Diagnostic())
+	construct.Report(g.report)
+	g.Fgenf(w, "/* %s */ null", construct.Comment())
+}
+
+// genNYIDeclaration replaces an untranslatable declaration with a TODO comment.
+func (g *generator) genNYIDeclaration(w io.Writer, subject hcl.Range, name, reason string) {
+	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "not yet implemented: " + reason,
+		Detail:   fmt.Sprintf("%s could not be translated: %s", name, reason),
+		Subject:  &subject,
+	})
+	g.report.AddUnsupported(fmt.Sprintf("%s: %s", reason, name), &subject)
+	g.report.AddTODO()
+	g.Fgenf(w, "%s// TODO: %s: %s\n", g.Indent, reason, name)
+}
@@ -0,0 +1,630 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// nameInfo names the parameters of the lambdas generated for applies. Java does not allow the parameters of a lambda
+// to shadow the local variables of the enclosing method, so a name that is also the name of a local is suffixed.
+type nameInfo codegen.StringSet
+
+func (locals nameInfo) Format(name string) string {
+	name = makeValidIdentifier(name)
+	if codegen.StringSet(locals).Has(name) {
+		return name + "Value"
+	}
+	return name
+}
+
+// lowerExpression amends the expression with intrinsics for Java generation.
+func (g *generator) lowerExpression(expr model.Expression, typ model.Type) model.Expression {
+	expr = hcl2.RewritePropertyReferences(expr)
+	expr = g.resolveJSONArguments(expr)
+	expr, diags := hcl2.RewriteApplies(expr, nameInfo(g.locals), true)
+	contract.Assert(len(diags) == 0)
+	expr = hcl2.RewriteConversions(expr, typ)
+	expr, temps, diags := hcl2.NewLowerer(loweringOptions).Lower(expr)
+	contract.Assert(len(temps) == 0 && len(diags) == 0)
+	return expr
+}
+
+// loweringOptions lowers splat expressions and for expressions into calls to the map intrinsic, which are generated as
+// stream pipelines.
+var loweringOptions = hcl2.LoweringOptions{
+	SplatExpressions: hcl2.LowerToMap,
+	ForExpressions:   hcl2.LowerToMap,
+}
+
+// resolveJSONArguments changes the signature of each call to toJSON whose argument contains outputs so that the call
+// requires the resolved value of its argument. The apply rewriter then moves the call into an apply, as values are
+// serialized before they are wrapped in outputs.
+func (g *generator) resolveJSONArguments(x model.Expression) model.Expression {
+	rewriter := func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		call, ok := x.(*model.FunctionCallExpression)
+		if !ok || call.Name != "toJSON" || !model.ContainsOutputs(call.Args[0].Type()) {
+			return x, nil
+		}
+
+		resolved := *call
+		resolved.Signature = model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "value",
+				Type: model.ResolveOutputs(call.Args[0].Type()),
+			}},
+			ReturnType: model.NewOutputType(model.StringType),
+		}
+		return &resolved, nil
+	}
+	x, diags := model.VisitExpression(x, model.IdentityVisitor, rewriter)
+	contract.Assert(len(diags) == 0)
+	return x
+}
+
+// isEventualType returns true if values of the given type are outputs or promises. Promises are generated as outputs.
+func isEventualType(t model.Type) bool {
+	switch t.(type) {
+	case *model.OutputType, *model.PromiseType:
+		return true
+	default:
+		return false
+	}
+}
+
+// isOutput returns true if the given expression is generated as an output. Invokes are generated as calls that return
+// outputs and calls to toJSON as calls that return strings, regardless of the types of their arguments.
+func isOutput(x model.Expression) bool {
+	if call, ok := x.(*model.FunctionCallExpression); ok {
+		switch call.Name {
+		case hcl2.IntrinsicConvert:
+			return isOutput(call.Args[0])
+		case hcl2.Invoke:
+			return true
+		case "toJSON":
+			return false
+		}
+	}
+	return isEventualType(x.Type())
+}
+
+func (g *generator) GetPrecedence(expr model.Expression) int {
+	// Precedence is derived from
+	// https://docs.oracle.com/javase/tutorial/java/nutsandbolts/operators.html
+	switch expr := expr.(type) {
+	case *model.ConditionalExpression:
+		return 4
+	case *model.BinaryOpExpression:
+		switch expr.Operation {
+		case hclsyntax.OpLogicalOr:
+			return 5
+		case hclsyntax.OpLogicalAnd:
+			return 6
+		case hclsyntax.OpEqual, hclsyntax.OpNotEqual:
+			if isStringComparison(expr) {
+				if expr.Operation == hclsyntax.OpNotEqual {
+					return 17
+				}
+				return 20
+			}
+			return 11
+		case hclsyntax.OpGreaterThan, hclsyntax.OpGreaterThanOrEqual, hclsyntax.OpLessThan,
+			hclsyntax.OpLessThanOrEqual:
+			return 12
+		case hclsyntax.OpAdd, hclsyntax.OpSubtract:
+			return 14
+		case hclsyntax.OpMultiply, hclsyntax.OpDivide, hclsyntax.OpModulo:
+			return 15
+		default:
+			contract.Failf("unexpected binary expression %v", expr)
+		}
+	case *model.UnaryOpExpression:
+		return 17
+	case *model.AnonymousFunctionExpression:
+		return 1
+	case *model.FunctionCallExpression, *model.ForExpression, *model.IndexExpression,
+		*model.RelativeTraversalExpression, *model.SplatExpression, *model.TemplateJoinExpression:
+		return 20
+	case *model.LiteralValueExpression, *model.ObjectConsExpression, *model.ScopeTraversalExpression,
+		*model.TemplateExpression, *model.TupleConsExpression:
+		return 22
+	default:
+		contract.Failf("unexpected expression %v of type %T", expr, expr)
+	}
+	return 0
+}
+
+func (g *generator) GenAnonymousFunctionExpression(w io.Writer, expr *model.AnonymousFunctionExpression) {
+	switch len(expr.Signature.Parameters) {
+	case 0:
+		g.Fgenf(w, "() -> %.v", expr.Body)
+	case 1:
+		g.Fgenf(w, "%s -> %.v", expr.Signature.Parameters[0].Name, expr.Body)
+	default:
+		g.Fgen(w, "values -> {\n")
+		g.Indented(func() {
+			for i, p := range expr.Signature.Parameters {
+				g.Fgenf(w, "%svar %s = values.t%d;\n", g.Indent, p.Name, i+1)
+			}
+			g.Fgenf(w, "%sreturn %.v;\n", g.Indent, expr.Body)
+		})
+		g.Fgenf(w, "%s}", g.Indent)
+	}
+}
+
+// isStringComparison returns true if the given expression compares strings for equality, which must be done using
+// String.equals rather than the == operator.
+func isStringComparison(expr *model.BinaryOpExpression) bool {
+	if expr.Operation != hclsyntax.OpEqual && expr.Operation != hclsyntax.OpNotEqual {
+		return false
+	}
+	return model.ResolveOutputs(expr.LeftOperand.Type()) == model.StringType ||
+		model.ResolveOutputs(expr.RightOperand.Type()) == model.StringType
+}
+
+func (g *generator) GenBinaryOpExpression(w io.Writer, expr *model.BinaryOpExpression) {
+	if isStringComparison(expr) {
+		if expr.Operation == hclsyntax.OpNotEqual {
+			g.Fgen(w, "!")
+		}
+		g.Fgenf(w, "%.20v.equals(%.v)", expr.LeftOperand, expr.RightOperand)
+		return
+	}
+
+	opstr, precedence := "", g.GetPrecedence(expr)
+	switch expr.Operation {
+	case hclsyntax.OpAdd:
+		opstr = "+"
+	case hclsyntax.OpDivide:
+		opstr = "/"
+	case hclsyntax.OpEqual:
+		opstr = "=="
+	case hclsyntax.OpGreaterThan:
+		opstr = ">"
+	case hclsyntax.OpGreaterThanOrEqual:
+		opstr = ">="
+	case hclsyntax.OpLessThan:
+		opstr = "<"
+	case hclsyntax.OpLessThanOrEqual:
+		opstr = "<="
+	case hclsyntax.OpLogicalAnd:
+		opstr = "&&"
+	case hclsyntax.OpLogicalOr:
+		opstr = "||"
+	case hclsyntax.OpModulo:
+		opstr = "%"
+	case hclsyntax.OpMultiply:
+		opstr = "*"
+	case hclsyntax.OpNotEqual:
+		opstr = "!="
+	case hclsyntax.OpSubtract:
+		opstr = "-"
+	default:
+		opstr, precedence = ",", 1
+	}
+
+	g.Fgenf(w, "%.[1]*[2]v %[3]v %.[1]*[4]o", precedence, expr.LeftOperand, opstr, expr.RightOperand)
+}
+
+func (g *generator) GenConditionalExpression(w io.Writer, expr *model.ConditionalExpression) {
+	g.Fgenf(w, "%.4v ? %.4v : %.4v", expr.Condition, expr.TrueResult, expr.FalseResult)
+}
+
+func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) {
+	g.genNYI(w, expr, "for expressions are not supported")
+}
+
+func (g *generator) genApply(w io.Writer, expr *model.FunctionCallExpression) {
+	// Extract the list of outputs and the continuation expression from the `__apply` arguments.
+	applyArgs, then := hcl2.ParseApplyCall(expr)
+
+	// A continuation that returns an output is flattened by apply rather than applyValue.
+	method := "applyValue"
+	if isOutput(then.Body) {
+		method = "apply"
+	}
+
+	if len(applyArgs) == 1 {
+		// If we only have a single output, just generate a normal `.applyValue`
+		g.Fgenf(w, "%.20v.%s(%.v)", applyArgs[0], method, then)
+	} else {
+		// Otherwise, generate a call to `Output.tuple().applyValue()`.
+		g.Fgenf(w, "%s.tuple(", g.importClass("com.pulumi.core.Output"))
+		for i, o := range applyArgs {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.Fgenf(w, "%.v", o)
+		}
+
+		g.Fgenf(w, ").%s(%.v)", method, then)
+	}
+}
+
+func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionCallExpression) {
+	switch expr.Name {
+	case hcl2.IntrinsicConvert:
+		switch arg := expr.Args[0].(type) {
+		case *model.ObjectConsExpression:
+			g.genObjectConsExpression(w, arg, expr.Type())
+		default:
+			g.Fgenf(w, "%.v", expr.Args[0]) // <- probably wrong w.r.t. precedence
+		}
+	case hcl2.IntrinsicApply:
+		g.genApply(w, expr)
+	case hcl2.IntrinsicMap:
+		collection, then, filter := hcl2.ParseMapCall(expr)
+		g.Fgenf(w, "%.20v.stream()", collection)
+		if filter != nil {
+			g.Fgenf(w, ".filter(%.v)", filter)
+		}
+		g.Fgenf(w, ".map(%.v).collect(%s())", then, g.importStatic("java.util.stream.Collectors.toList"))
+	case "element":
+		g.Fgenf(w, "%.20v.get(%.v)", expr.Args[0], expr.Args[1])
+	case "fileArchive":
+		g.Fgenf(w, "new %s(%.v)", g.importClass("com.pulumi.asset.FileArchive"), expr.Args[0])
+	case "fileAsset":
+		g.Fgenf(w, "new %s(%.v)", g.importClass("com.pulumi.asset.FileAsset"), expr.Args[0])
+	case hcl2.Invoke:
+		g.genInvoke(w, expr)
+	case "length":
+		if model.ResolveOutputs(expr.Args[0].Type()) == model.StringType {
+			g.Fgenf(w, "%.20v.length()", expr.Args[0])
+		} else {
+			g.Fgenf(w, "%.20v.size()", expr.Args[0])
+		}
+	case "lookup":
+		if len(expr.Args) == 3 {
+			g.Fgenf(w, "%.20v.getOrDefault(%.v, %.v)", expr.Args[0], expr.Args[1], expr.Args[2])
+		} else {
+			g.Fgenf(w, "%.20v.get(%.v)", expr.Args[0], expr.Args[1])
+		}
+	case "mimeType":
+		g.Fgenf(w, "%s(%.v)", g.helperMethod("mimeType"), expr.Args[0])
+	case "readDir":
+		g.Fgenf(w, "%s(%.v)", g.helperMethod("readDir"), expr.Args[0])
+	case "secret":
+		g.Fgenf(w, "%s.ofSecret(%.v)", g.importClass("com.pulumi.core.Output"), expr.Args[0])
+	case "split":
+		g.Fgenf(w, "%s.of(%.20v.split(%s.quote(%.v)))", g.importClass("java.util.List"), expr.Args[1],
+			g.importClass("java.util.regex.Pattern"), expr.Args[0])
+	case "toJSON":
+		g.Fgenf(w, "%s(", g.importStatic("com.pulumi.codegen.internal.Serialization.serializeJson"))
+		g.Indented(func() {
+			g.Fgenf(w, "\n%s", g.Indent)
+			g.genJSON(w, expr.Args[0])
+		})
+		g.Fgen(w, ")")
+	default:
+		if emitter, ok := g.program.IntrinsicEmitter(expr.Name, "java"); ok {
+			for _, i := range emitter.Imports {
+				g.importClass(i)
+			}
+			emitter.Emit(g.Formatter, w, expr)
+			return
+		}
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
+	}
+}
+
+// genInvoke generates a call to the method that invokes a function, e.g. Ec2Functions.getVpc. The arguments and the
+// options of the invoke are constructed with builders.
+func (g *generator) genInvoke(w io.Writer, expr *model.FunctionCallExpression) {
+	className, method := g.functionName(expr.Args[0])
+	g.Fgenf(w, "%s.%s(", className, method)
+
+	args := expr.Args[1]
+	if obj, ok := args.(*model.FunctionCallExpression); ok && obj.Name == hcl2.IntrinsicConvert {
+		args = obj.Args[0]
+	}
+	if obj, ok := args.(*model.ObjectConsExpression); !ok || len(obj.Items) != 0 || len(expr.Args) == 3 {
+		g.Fgenf(w, "%.v", expr.Args[1])
+	}
+
+	if len(expr.Args) == 3 {
+		g.Fgenf(w, ", %s.builder()", g.importClass("com.pulumi.deployment.InvokeOptions"))
+		g.Indented(func() {
+			if obj, ok := expr.Args[2].(*model.ObjectConsExpression); ok {
+				for _, item := range obj.Items {
+					key := item.Key.(*model.LiteralValueExpression).Value.AsString()
+					g.Fgenf(w, "\n%s.%s(%.v)", g.Indent, makeValidIdentifier(key), item.Value)
+				}
+			} else {
+				g.Fgenf(w, "\n%s.", g.Indent)
+				g.genNYI(w, expr.Args[2], "computed invoke options are not supported")
+			}
+			g.Fgenf(w, "\n%s.build()", g.Indent)
+		})
+	}
+	g.Fgen(w, ")")
+}
+
+// genJSON generates the argument of a call to serializeJson. Objects and lists are constructed with the jsonObject and
+// jsonArray helpers.
+func (g *generator) genJSON(w io.Writer, expr model.Expression) {
+	switch expr := expr.(type) {
+	case *model.ObjectConsExpression:
+		g.Fgenf(w, "%s(", g.importStatic("com.pulumi.codegen.internal.Serialization.jsonObject"))
+		jsonProperty := g.importStatic("com.pulumi.codegen.internal.Serialization.jsonProperty")
+		g.Indented(func() {
+			for i, item := range expr.Items {
+				if i > 0 {
+					g.Fgen(w, ",")
+				}
+				g.Fgenf(w, "\n%s%s(%.v, ", g.Indent, jsonProperty, item.Key)
+				g.genJSON(w, item.Value)
+				g.Fgen(w, ")")
+			}
+		})
+		g.Fgen(w, ")")
+	case *model.TupleConsExpression:
+		g.Fgenf(w, "%s(", g.importStatic("com.pulumi.codegen.internal.Serialization.jsonArray"))
+		for i, item := range expr.Expressions {
+			if i > 0 {
+				g.Fgen(w, ", ")
+			}
+			g.genJSON(w, item)
+		}
+		g.Fgen(w, ")")
+	default:
+		g.Fgenf(w, "%.v", expr)
+	}
+}
+
+func (g *generator) GenIndexExpression(w io.Writer, expr *model.IndexExpression) {
+	g.Fgenf(w, "%.20v.get(%.v)", expr.Collection, expr.Key)
+}
+
+// escapeString escapes a string for use in a Java string literal as per
+// https://docs.oracle.com/javase/specs/jls/se11/html/jls-3.html#jls-3.10.6
+func escapeString(v string) string {
+	builder := strings.Builder{}
+	for _, c := range v {
+		switch c {
+		case '"', '\\':
+			builder.WriteRune('\\')
+			builder.WriteRune(c)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if c < ' ' {
+				fmt.Fprintf(&builder, `\u%04x`, c)
+			} else {
+				builder.WriteRune(c)
+			}
+		}
+	}
+	return builder.String()
+}
+
+func (g *generator) GenLiteralValueExpression(w io.Writer, expr *model.LiteralValueExpression) {
+	switch expr.Type() {
+	case model.BoolType:
+		g.Fgenf(w, "%v", expr.Value.True())
+	case model.NoneType:
+		g.Fgen(w, "null")
+	case model.NumberType, model.IntType:
+		bf := expr.Value.AsBigFloat()
+		if i, acc := bf.Int64(); acc == big.Exact {
+			g.Fgenf(w, "%d", i)
+		} else {
+			f, _ := bf.Float64()
+			g.Fgenf(w, "%g", f)
+		}
+	case model.StringType:
+		g.Fgenf(w, "\"%s\"", escapeString(expr.Value.AsString()))
+	default:
+		contract.Failf("unexpected literal type in GenLiteralValueExpression: %v (%v)", expr.Type(),
+			expr.SyntaxNode().Range())
+	}
+}
+
+func (g *generator) GenObjectConsExpression(w io.Writer, expr *model.ObjectConsExpression) {
+	g.genObjectConsExpression(w, expr, expr.Type())
+}
+
+// genObjectConsExpression generates an object. An object with an argument class is constructed with the class's
+// builder; any other object is generated as a map.
+func (g *generator) genObjectConsExpression(w io.Writer, expr *model.ObjectConsExpression, destType model.Type) {
+	if typeName := g.argumentTypeName(expr, destType); typeName != "" {
+		g.Fgenf(w, "%s.builder()", typeName)
+		g.Indented(func() {
+			for _, item := range expr.Items {
+				lit := item.Key.(*model.LiteralValueExpression)
+				g.Fgenf(w, "\n%s.%s(", g.Indent, makeValidIdentifier(lit.Value.AsString()))
+				g.genBuilderArgument(w, item.Value)
+				g.Fgen(w, ")")
+			}
+			g.Fgenf(w, "\n%s.build()", g.Indent)
+		})
+		return
+	}
+
+	mapClass := g.importClass("java.util.Map")
+	switch len(expr.Items) {
+	case 0:
+		g.Fgenf(w, "%s.of()", mapClass)
+	case 1:
+		g.Fgenf(w, "%s.of(%.v, %.v)", mapClass, expr.Items[0].Key, expr.Items[0].Value)
+	default:
+		g.Fgenf(w, "%s.ofEntries(", mapClass)
+		g.Indented(func() {
+			for i, item := range expr.Items {
+				if i > 0 {
+					g.Fgen(w, ",")
+				}
+				g.Fgenf(w, "\n%s%s.entry(%.v, %.v)", g.Indent, mapClass, item.Key, item.Value)
+			}
+		})
+		g.Fgen(w, ")")
+	}
+}
+
+// genBuilderArgument generates the argument of a builder method. The elements of a list are passed as the arguments
+// of the method, which accepts them as varargs.
+func (g *generator) genBuilderArgument(w io.Writer, expr model.Expression) {
+	if call, ok := expr.(*model.FunctionCallExpression); ok && call.Name == hcl2.IntrinsicConvert {
+		if _, isTuple := call.Args[0].(*model.TupleConsExpression); isTuple {
+			expr = call.Args[0]
+		}
+	}
+
+	tuple, ok := expr.(*model.TupleConsExpression)
+	if !ok || len(tuple.Expressions) == 0 {
+		g.Fgenf(w, "%.v", expr)
+		return
+	}
+	for i, item := range tuple.Expressions {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		g.Fgenf(w, "%.v", item)
+	}
+}
+
+// genRelativeTraversal generates the given traversal. The properties of objects are accessed using their getters and
+// the elements of lists and maps using get. receivers holds the type of the value traversed by each part.
+func (g *generator) genRelativeTraversal(w io.Writer, traversal hcl.Traversal, receivers []model.Type) {
+	for i, part := range traversal {
+		var key cty.Value
+		switch part := part.(type) {
+		case hcl.TraverseAttr:
+			key = cty.StringVal(part.Name)
+		case hcl.TraverseIndex:
+			key = part.Key
+		default:
+			contract.Failf("unexpected traversal part of type %T (%v)", part, part.SourceRange())
+		}
+
+		switch key.Type() {
+		case cty.String:
+			if _, isMap := model.ResolveOutputs(receivers[i]).(*model.MapType); isMap {
+				g.Fgenf(w, ".get(\"%s\")", escapeString(key.AsString()))
+			} else {
+				g.Fgenf(w, ".%s()", makeValidIdentifier(key.AsString()))
+			}
+		case cty.Number:
+			idx, _ := key.AsBigFloat().Int64()
+			g.Fgenf(w, ".get(%d)", idx)
+		default:
+			contract.Failf("unexpected traversal key of type %T (%v)", key, key.AsString())
+		}
+	}
+}
+
+func (g *generator) GenRelativeTraversalExpression(w io.Writer, expr *model.RelativeTraversalExpression) {
+	g.Fgenf(w, "%.20v", expr.Source)
+
+	receivers := []model.Type{expr.Source.Type()}
+	for _, p := range expr.Parts {
+		receivers = append(receivers, model.GetTraversableType(p))
+	}
+	g.genRelativeTraversal(w, expr.Traversal, receivers)
+}
+
+func (g *generator) GenScopeTraversalExpression(w io.Writer, expr *model.ScopeTraversalExpression) {
+	rootName := makeValidIdentifier(expr.RootName)
+	switch v := expr.Parts[0].(type) {
+	case *model.SplatVariable:
+		rootName = "__item"
+	case *model.Variable:
+		// `each` is an alias for the range variable of a ranged resource.
+		if v.Name == "range" {
+			rootName = "range"
+		}
+	case hcl2.Node:
+		rootName = g.variableName(v)
+	}
+	g.Fgen(w, rootName)
+
+	receivers := make([]model.Type, len(expr.Parts))
+	for i, p := range expr.Parts {
+		receivers[i] = model.GetTraversableType(p)
+	}
+	g.genRelativeTraversal(w, expr.Traversal.SimpleSplit().Rel, receivers)
+}
+
+func (g *generator) GenSplatExpression(w io.Writer, expr *model.SplatExpression) {
+	g.Fgenf(w, "%.20v.stream().map(__item -> %.v).collect(%s())", expr.Source, expr.Each,
+		g.importStatic("java.util.stream.Collectors.toList"))
+}
+
+func (g *generator) GenTemplateExpression(w io.Writer, expr *model.TemplateExpression) {
+	// Templates that contain expressions are generated as calls to String.format.
+	var format strings.Builder
+	var args []model.Expression
+	for _, expr := range expr.Parts {
+		if lit, ok := expr.(*model.LiteralValueExpression); ok && lit.Type() == model.StringType {
+			format.WriteString(lit.Value.AsString())
+		} else {
+			format.WriteString("\x00")
+			args = append(args, expr)
+		}
+	}
+
+	if len(args) == 0 {
+		g.Fgenf(w, "\"%s\"", escapeString(format.String()))
+		return
+	}
+
+	formatString := strings.Replace(escapeString(strings.Replace(format.String(), "%", "%%", -1)), `\u0000`, "%s", -1)
+	g.Fgenf(w, "String.format(\"%s\"", formatString)
+	for _, arg := range args {
+		g.Fgenf(w, ", %.v", arg)
+	}
+	g.Fgen(w, ")")
+}
+
+func (g *generator) GenTemplateJoinExpression(w io.Writer, expr *model.TemplateJoinExpression) {
+	g.genNYI(w, expr, "template join expressions are not supported")
+}
+
+func (g *generator) GenTupleConsExpression(w io.Writer, expr *model.TupleConsExpression) {
+	g.Fgenf(w, "%s.of(", g.importClass("java.util.List"))
+	for i, v := range expr.Expressions {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		g.Fgenf(w, "%.v", v)
+	}
+	g.Fgen(w, ")")
+}
+
+func (g *generator) GenUnaryOpExpression(w io.Writer, expr *model.UnaryOpExpression) {
+	opstr, precedence := "", g.GetPrecedence(expr)
+	switch expr.Operation {
+	case hclsyntax.OpLogicalNot:
+		opstr = "!"
+	case hclsyntax.OpNegate:
+		opstr = "-"
+	}
+	g.Fgenf(w, "%[2]v%.[1]*[3]v", precedence, opstr, expr.Operand)
+}
@@ -0,0 +1,99 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// isReservedWord returns true if s is a Java keyword or literal as per
+// https://docs.oracle.com/javase/specs/jls/se11/html/jls-3.html#jls-3.9
+func isReservedWord(s string) bool {
+	switch s {
+	case "abstract", "assert", "boolean", "break", "byte", "case", "catch", "char", "class", "const", "continue",
+		"default", "do", "double", "else", "enum", "extends", "final", "finally", "float", "for", "goto", "if",
+		"implements", "import", "instanceof", "int", "interface", "long", "native", "new", "package", "private",
+		"protected", "public", "return", "short", "static", "strictfp", "super", "switch", "synchronized", "this",
+		"throw", "throws", "transient", "try", "void", "volatile", "while", "_":
+		return true
+	// Treat the literals and the reserved type name as keywords, as none of them may be used as an identifier.
+	case "true", "false", "null", "var":
+		return true
+	default:
+		return false
+	}
+}
+
+// isLegalIdentifierStart returns true if it is legal for c to be the first character of a Java identifier as per
+// https://docs.oracle.com/javase/specs/jls/se11/html/jls-3.html#jls-3.8
+func isLegalIdentifierStart(c rune) bool {
+	return c == '_' || c == '$' || unicode.IsLetter(c)
+}
+
+// isLegalIdentifierPart returns true if it is legal for c to be part of a Java identifier (besides the first character)
+// as per https://docs.oracle.com/javase/specs/jls/se11/html/jls-3.html#jls-3.8
+func isLegalIdentifierPart(c rune) bool {
+	return isLegalIdentifierStart(c) || unicode.IsDigit(c)
+}
+
+// makeValidIdentifier replaces characters that are not allowed in Java identifiers with underscores. A reserved word is
+// suffixed with an underscore. No attempt is made to ensure that the result is unique.
+func makeValidIdentifier(name string) string {
+	var builder strings.Builder
+	for i, c := range name {
+		if i == 0 && !isLegalIdentifierStart(c) || i > 0 && !isLegalIdentifierPart(c) {
+			builder.WriteRune('_')
+		} else {
+			builder.WriteRune(c)
+		}
+	}
+	name = builder.String()
+	if isReservedWord(name) {
+		return name + "_"
+	}
+	return name
+}
+
+// Title converts the input string to a title case where only the initial letter is upper-cased.
+func Title(s string) string {
+	if s == "" {
+		return ""
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+// camel converts the input string to camel case where only the initial letter is lower-cased.
+func camel(s string) string {
+	if s == "" {
+		return ""
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// packageName returns the Java package name for a Pulumi package or module name, which must be a lower-case sequence
+// of letters and digits.
+func packageName(name string) string {
+	var builder strings.Builder
+	for _, c := range strings.ToLower(name) {
+		if isLegalIdentifierPart(c) && c != '$' {
+			builder.WriteRune(c)
+		}
+	}
+	return makeValidIdentifier(builder.String())
+}
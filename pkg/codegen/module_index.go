@@ -0,0 +1,190 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgavlin/goldmark/ast"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// referenceDocsURL is the base URL of the Pulumi package reference documentation.
+const referenceDocsURL = "https://www.pulumi.com/docs/reference/pkg/"
+
+// ModuleIndexEntry describes a single resource, function, or type in a module of a generated SDK.
+type ModuleIndexEntry struct {
+	// Name is the name of the member in the SDK's language.
+	Name string
+	// Summary is the first sentence of the member's description.
+	Summary string
+	// Link is the URL of the member's reference documentation, if any.
+	Link string
+}
+
+// ModuleIndex summarizes the resources, functions, and types in a module of a generated SDK. SDK generators use module
+// indices to generate a README or package documentation for each module.
+type ModuleIndex struct {
+	// Title is the name of the module in the SDK's language.
+	Title string
+	// Link is the URL of the module's reference documentation.
+	Link string
+
+	Resources []ModuleIndexEntry
+	Functions []ModuleIndexEntry
+	Types     []ModuleIndexEntry
+
+	pkg    *schema.Package
+	module string
+}
+
+// NewModuleIndex creates an empty index for the given module of a package. The module's title is its name in the SDK's
+// language.
+func NewModuleIndex(pkg *schema.Package, module, title string) *ModuleIndex {
+	return &ModuleIndex{
+		Title:  title,
+		Link:   ReferenceDocsLink(pkg, module, ""),
+		pkg:    pkg,
+		module: module,
+	}
+}
+
+// ReferenceDocsLink returns the URL of the reference documentation for a resource or function in the given module of a
+// package. If member is empty, the URL of the module's documentation is returned.
+func ReferenceDocsLink(pkg *schema.Package, module, member string) string {
+	link := referenceDocsURL + strings.ToLower(pkg.Name) + "/"
+	if module != "" {
+		link += strings.ToLower(module) + "/"
+	}
+	if member != "" {
+		link += strings.ToLower(member) + "/"
+	}
+	return link
+}
+
+// AddResource adds a resource to the index. The resource's name in the SDK's language must be provided.
+func (idx *ModuleIndex) AddResource(name string, r *schema.Resource) {
+	idx.Resources = append(idx.Resources, ModuleIndexEntry{
+		Name:    name,
+		Summary: DescriptionSummary(r.Comment),
+		Link:    ReferenceDocsLink(idx.pkg, idx.module, tokenMemberName(r.Token)),
+	})
+}
+
+// AddFunction adds a function to the index. The function's name in the SDK's language must be provided.
+func (idx *ModuleIndex) AddFunction(name string, f *schema.Function) {
+	idx.Functions = append(idx.Functions, ModuleIndexEntry{
+		Name:    name,
+		Summary: DescriptionSummary(f.Comment),
+		Link:    ReferenceDocsLink(idx.pkg, idx.module, tokenMemberName(f.Token)),
+	})
+}
+
+// AddType adds an object type to the index. The type's name in the SDK's language must be provided. Types do not have
+// reference documentation of their own, so their entries have no links.
+func (idx *ModuleIndex) AddType(name string, t *schema.ObjectType) {
+	idx.Types = append(idx.Types, ModuleIndexEntry{
+		Name:    name,
+		Summary: DescriptionSummary(t.Comment),
+	})
+}
+
+// IsEmpty returns true if the index has no entries.
+func (idx *ModuleIndex) IsEmpty() bool {
+	return len(idx.Resources) == 0 && len(idx.Functions) == 0 && len(idx.Types) == 0
+}
+
+// Markdown renders the index as a Markdown document that is headed by the index's title.
+func (idx *ModuleIndex) Markdown() string {
+	return fmt.Sprintf("# %s\n\n%s", idx.Title, idx.MarkdownBody())
+}
+
+// MarkdownBody renders the index as a Markdown document without a title. The entries in each section are sorted by
+// name.
+func (idx *ModuleIndex) MarkdownBody() string {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "The reference documentation for this module is available at <%s>.\n", idx.Link)
+
+	genSection := func(title string, entries []ModuleIndexEntry) {
+		if len(entries) == 0 {
+			return
+		}
+
+		sorted := append([]ModuleIndexEntry(nil), entries...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+		fmt.Fprintf(&buffer, "\n## %s\n\n", title)
+		for _, e := range sorted {
+			name := "`" + e.Name + "`"
+			if e.Link != "" {
+				name = fmt.Sprintf("[%s](%s)", name, e.Link)
+			}
+			if e.Summary == "" {
+				fmt.Fprintf(&buffer, "- %s\n", name)
+			} else {
+				fmt.Fprintf(&buffer, "- %s: %s\n", name, e.Summary)
+			}
+		}
+	}
+	genSection("Resources", idx.Resources)
+	genSection("Functions", idx.Functions)
+	genSection("Types", idx.Types)
+
+	return buffer.String()
+}
+
+// DescriptionSummary returns the first sentence of the first paragraph of a schema description. Examples and other
+// shortcodes are ignored.
+func DescriptionSummary(description string) string {
+	if description == "" {
+		return ""
+	}
+
+	source := []byte(description)
+	parsed := schema.ParseDocs(source)
+	for c := parsed.FirstChild(); c != nil; c = c.NextSibling() {
+		paragraph, ok := c.(*ast.Paragraph)
+		if !ok {
+			continue
+		}
+
+		var text strings.Builder
+		lines := paragraph.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			line := lines.At(i)
+			if i > 0 {
+				text.WriteByte(' ')
+			}
+			text.Write(bytes.TrimSpace(line.Value(source)))
+		}
+
+		summary := text.String()
+		if end := strings.Index(summary, ". "); end != -1 {
+			summary = summary[:end+1]
+		}
+		return summary
+	}
+	return ""
+}
+
+// tokenMemberName returns the member name of a resource or function token.
+func tokenMemberName(token string) string {
+	components := strings.Split(token, ":")
+	return components[len(components)-1]
+}
@@ -0,0 +1,66 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleIndexMarkdown(t *testing.T) {
+	pkg := &schema.Package{Name: "aws"}
+
+	idx := NewModuleIndex(pkg, "s3", "S3")
+	assert.True(t, idx.IsEmpty())
+
+	idx.AddResource("BucketPolicy", &schema.Resource{
+		Token:   "aws:s3/bucketPolicy:BucketPolicy",
+		Comment: "Attaches a policy to an S3 bucket resource.",
+	})
+	idx.AddResource("Bucket", &schema.Resource{
+		Token:   "aws:s3/bucket:Bucket",
+		Comment: "Provides a S3 bucket resource. This is the second sentence.\n\n## Example Usage\n",
+	})
+	idx.AddFunction("getBucket", &schema.Function{Token: "aws:s3/getBucket:getBucket"})
+	idx.AddType("BucketWebsite", &schema.ObjectType{Token: "aws:s3/BucketWebsite:BucketWebsite"})
+	assert.False(t, idx.IsEmpty())
+
+	expected := "# S3\n\n" +
+		"The reference documentation for this module is available at <https://www.pulumi.com/docs/reference/pkg/aws/s3/>.\n" +
+		"\n## Resources\n\n" +
+		"- [`Bucket`](https://www.pulumi.com/docs/reference/pkg/aws/s3/bucket/): Provides a S3 bucket resource.\n" +
+		"- [`BucketPolicy`](https://www.pulumi.com/docs/reference/pkg/aws/s3/bucketpolicy/): " +
+		"Attaches a policy to an S3 bucket resource.\n" +
+		"\n## Functions\n\n" +
+		"- [`getBucket`](https://www.pulumi.com/docs/reference/pkg/aws/s3/getbucket/)\n" +
+		"\n## Types\n\n" +
+		"- `BucketWebsite`\n"
+	assert.Equal(t, expected, idx.Markdown())
+}
+
+func TestDescriptionSummary(t *testing.T) {
+	cases := map[string]string{
+		"":                               "",
+		"A single sentence.":             "A single sentence.",
+		"First line\nsecond line. More.": "First line second line.",
+		"## Heading\n\nThe body. More.":  "The body.",
+		"{{% examples %}}\n{{% /examples %}}\n\nAfter the examples.": "After the examples.",
+	}
+	for description, expected := range cases {
+		assert.Equal(t, expected, DescriptionSummary(description))
+	}
+}
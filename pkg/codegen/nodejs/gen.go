@@ -75,6 +75,7 @@ type modContext struct {
 	pkg              *schema.Package
 	mod              string
 	types            []*schema.ObjectType
+	enums            []*schema.EnumType
 	resources        []*schema.Resource
 	functions        []*schema.Function
 	typeDetails      map[*schema.ObjectType]*typeDetails
@@ -150,6 +151,9 @@ func (mod *modContext) typeString(t schema.Type, input, wrapInput, optional bool
 		typ = fmt.Sprintf("{[key: string]: %v}", mod.typeString(t.ElementType, input, wrapInput, false, constValue))
 	case *schema.ObjectType:
 		typ = mod.tokenToType(t.Token, input)
+	case *schema.EnumType:
+		// Use the element type for now.
+		return mod.typeString(t.ElementType, input, wrapInput, optional, constValue)
 	case *schema.TokenType:
 		typ = tokenToName(t.Token)
 	case *schema.UnionType:
@@ -175,10 +179,16 @@ func (mod *modContext) typeString(t schema.Type, input, wrapInput, optional bool
 		switch t {
 		case schema.BoolType:
 			typ = "boolean"
-		case schema.IntType, schema.NumberType:
+		case schema.IntType, schema.Int64Type, schema.NumberType:
 			typ = "number"
 		case schema.StringType:
 			typ = "string"
+		case schema.BytesType:
+			// Byte strings are base64-encoded on the wire. Inputs may also be given as Buffers.
+			typ = "string"
+			if input {
+				typ = "string | Buffer"
+			}
 		case schema.ArchiveType:
 			typ = "pulumi.asset.Archive"
 		case schema.AssetType:
@@ -206,6 +216,9 @@ func isStringType(t schema.Type) bool {
 	for tt, ok := t.(*schema.TokenType); ok; tt, ok = t.(*schema.TokenType) {
 		t = tt.UnderlyingType
 	}
+	if enum, ok := t.(*schema.EnumType); ok {
+		t = enum.ElementType
+	}
 
 	return t == schema.StringType
 }
@@ -219,6 +232,7 @@ func printComment(w io.Writer, comment, deprecationMessage, indent string) {
 		return
 	}
 
+	comment = codegen.ConvertDocs(comment, codegen.MarkdownDocFormat)
 	lines := strings.Split(sanitizeComment(comment), "\n")
 	for len(lines) > 0 && lines[len(lines)-1] == "" {
 		lines = lines[:len(lines)-1]
@@ -240,6 +254,13 @@ func printComment(w io.Writer, comment, deprecationMessage, indent string) {
 	fmt.Fprintf(w, "%s */\n", indent)
 }
 
+// genDeprecationWarning emits the statement that warns the caller of a constructor or function that the named resource
+// or function is deprecated.
+func genDeprecationWarning(w io.Writer, indent, name, deprecationMessage string) {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(deprecationMessage)
+	fmt.Fprintf(w, "%spulumi.log.warn(\"%s is deprecated: %s\")\n", indent, name, escaped)
+}
+
 func (mod *modContext) genPlainType(w io.Writer, name, comment string, properties []*schema.Property, input, wrapInput, readonly bool, level int) {
 	indent := strings.Repeat("    ", level)
 
@@ -247,7 +268,7 @@ func (mod *modContext) genPlainType(w io.Writer, name, comment string, propertie
 
 	fmt.Fprintf(w, "%sexport interface %s {\n", indent, name)
 	for _, p := range properties {
-		printComment(w, p.Comment, p.DeprecationMessage, indent+"    ")
+		printComment(w, codegen.PropertyComment(p), p.DeprecationMessage, indent+"    ")
 
 		prefix := ""
 		if readonly {
@@ -276,9 +297,9 @@ func tsPrimitiveValue(value interface{}) (string, error) {
 			return "true", nil
 		}
 		return "false", nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return strconv.FormatInt(v.Int(), 10), nil
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return strconv.FormatUint(v.Uint(), 10), nil
 	case reflect.Float32, reflect.Float64:
 		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
@@ -311,7 +332,7 @@ func (mod *modContext) getDefaultValue(dv *schema.DefaultValue, t schema.Type) (
 		switch t {
 		case schema.BoolType:
 			getType = "Boolean"
-		case schema.IntType, schema.NumberType:
+		case schema.IntType, schema.Int64Type, schema.NumberType:
 			getType = "Number"
 		}
 
@@ -390,6 +411,10 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 			fmt.Fprintf(w, "     * @param state Any extra arguments used during the lookup.\n")
 		}
 		fmt.Fprintf(w, "     * @param opts Optional settings to control the behavior of the CustomResource.\n")
+		if r.DeprecationMessage != "" {
+			fmt.Fprintf(w, "     *\n")
+			fmt.Fprintf(w, "     * @deprecated %s\n", r.DeprecationMessage)
+		}
 		fmt.Fprintf(w, "     */\n")
 
 		stateParam, stateRef := "", "undefined, "
@@ -399,7 +424,7 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 
 		fmt.Fprintf(w, "    public static get(name: string, id: pulumi.Input<pulumi.ID>, %sopts?: pulumi.CustomResourceOptions): %s {\n", stateParam, name)
 		if r.DeprecationMessage != "" && mod.compatibility != kubernetes20 {
-			fmt.Fprintf(w, "        pulumi.log.warn(\"%s is deprecated: %s\")\n", name, r.DeprecationMessage)
+			genDeprecationWarning(w, "        ", name, r.DeprecationMessage)
 		}
 		fmt.Fprintf(w, "        return new %s(name, %s{ ...opts, id: id });\n", name, stateRef)
 		fmt.Fprintf(w, "    }\n")
@@ -435,7 +460,7 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 		allOptionalInputs = allOptionalInputs && !prop.IsRequired
 	}
 	for _, prop := range r.Properties {
-		printComment(w, prop.Comment, prop.DeprecationMessage, "    ")
+		printComment(w, codegen.PropertyComment(prop), prop.DeprecationMessage, "    ")
 
 		// Make a little comment in the code so it's easy to pick out output properties.
 		var outcomment string
@@ -458,6 +483,10 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 	fmt.Fprintf(w, "     * @param name The _unique_ name of the resource.\n")
 	fmt.Fprintf(w, "     * @param args The arguments to use to populate this resource's properties.\n")
 	fmt.Fprintf(w, "     * @param opts A bag of options that control this resource's behavior.\n")
+	if r.DeprecationMessage != "" {
+		fmt.Fprintf(w, "     *\n")
+		fmt.Fprintf(w, "     * @deprecated %s\n", r.DeprecationMessage)
+	}
 	fmt.Fprintf(w, "     */\n")
 
 	// k8s provider "get" methods don't require args, so make args optional.
@@ -479,9 +508,6 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 		trailingBrace, optionsType = " {", "ResourceOptions"
 	}
 
-	if r.DeprecationMessage != "" {
-		fmt.Fprintf(w, "    /** @deprecated %s */\n", r.DeprecationMessage)
-	}
 	fmt.Fprintf(w, "    constructor(name: string, args%s: %s, opts?: pulumi.%s)%s\n", argsFlags, argsType,
 		optionsType, trailingBrace)
 
@@ -497,12 +523,15 @@ func (mod *modContext) genResource(w io.Writer, r *schema.Resource) error {
 				argsType, stateType)
 		} else {
 			// Otherwise, write out a constructor with no state and required opts, then another with all optional params.
+			if r.DeprecationMessage != "" {
+				fmt.Fprintf(w, "    /** @deprecated %s */\n", r.DeprecationMessage)
+			}
 			fmt.Fprintf(w, "    constructor(name: string, state: undefined, opts: pulumi.CustomResourceOptions)\n")
 			fmt.Fprintf(w, "    constructor(name: string, argsOrState?: %s, opts?: pulumi.CustomResourceOptions) {\n",
 				argsType)
 		}
 		if r.DeprecationMessage != "" && mod.compatibility != kubernetes20 {
-			fmt.Fprintf(w, "        pulumi.log.warn(\"%s is deprecated: %s\")\n", name, r.DeprecationMessage)
+			genDeprecationWarning(w, "        ", name, r.DeprecationMessage)
 		}
 		fmt.Fprintf(w, "        let inputs: pulumi.Inputs = {};\n")
 
@@ -634,11 +663,7 @@ func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) {
 	name := tokenToFunctionName(fun.Token)
 
 	// Write the TypeDoc/JSDoc for the data source function.
-	printComment(w, codegen.FilterExamples(fun.Comment, "typescript"), "", "")
-
-	if fun.DeprecationMessage != "" {
-		fmt.Fprintf(w, "/** @deprecated %s */\n", fun.DeprecationMessage)
-	}
+	printComment(w, codegen.FilterExamples(fun.Comment, "typescript"), fun.DeprecationMessage, "")
 
 	// Now, emit the function signature.
 	var argsig string
@@ -665,7 +690,7 @@ func (mod *modContext) genFunction(w io.Writer, fun *schema.Function) {
 	}
 	fmt.Fprintf(w, "export function %s(%sopts?: pulumi.InvokeOptions): Promise<%s> {\n", name, argsig, retty)
 	if fun.DeprecationMessage != "" && mod.compatibility != kubernetes20 {
-		fmt.Fprintf(w, "    pulumi.log.warn(\"%s is deprecated: %s\")\n", name, fun.DeprecationMessage)
+		genDeprecationWarning(w, "    ", name, fun.DeprecationMessage)
 	}
 
 	// Zero initialize the args if empty and necessary.
@@ -880,6 +905,10 @@ func (mod *modContext) configGetter(v *schema.Property) (string, string) {
 		return "get", ""
 	}
 
+	if enum, ok := v.Type.(*schema.EnumType); ok && enum.ElementType == schema.StringType {
+		return "get", ""
+	}
+
 	if tok, ok := v.Type.(*schema.TokenType); ok && tok.UnderlyingType == schema.StringType {
 		return "get", fmt.Sprintf("<%s>", mod.typeString(v.Type, false, false, false, nil))
 	}
@@ -902,7 +931,7 @@ func (mod *modContext) genConfig(w io.Writer, variables []*schema.Property) erro
 	for _, p := range variables {
 		getfunc, cast := mod.configGetter(p)
 
-		printComment(w, p.Comment, "", "")
+		printComment(w, codegen.PropertyComment(p), p.DeprecationMessage, "")
 
 		configFetch := fmt.Sprintf("%s__config.%s(\"%s\")", cast, getfunc, p.Name)
 		// TODO: handle ConstValues https://github.com/pulumi/pulumi/issues/4755
@@ -953,16 +982,55 @@ func (mod *modContext) genTypes() (string, string) {
 	mod.genHeader(outputs, mod.sdkImports(true, false), imports)
 
 	// Build a namespace tree out of the types, then emit them.
+	root := mod.typeNamespaces()
+
+	var genNamespace func(io.Writer, *typeNamespace, bool, int)
+	genNamespace = func(w io.Writer, ns *typeNamespace, input bool, level int) {
+		indent := strings.Repeat("    ", level)
 
-	type namespace struct {
-		name     string
-		types    []*schema.ObjectType
-		children []*namespace
+		sort.Slice(ns.types, func(i, j int) bool {
+			return tokenToName(ns.types[i].Token) < tokenToName(ns.types[j].Token)
+		})
+		for i, t := range ns.types {
+			if input && mod.details(t).inputType || !input && mod.details(t).outputType {
+				mod.genType(w, t, input, level)
+				if i != len(ns.types)-1 {
+					fmt.Fprintf(w, "\n")
+				}
+			}
+		}
+
+		sort.Slice(ns.children, func(i, j int) bool {
+			return ns.children[i].name < ns.children[j].name
+		})
+		for i, ns := range ns.children {
+			fmt.Fprintf(w, "%sexport namespace %s {\n", indent, ns.name)
+			genNamespace(w, ns, input, level+1)
+			fmt.Fprintf(w, "%s}\n", indent)
+			if i != len(ns.children)-1 {
+				fmt.Fprintf(w, "\n")
+			}
+		}
 	}
+	genNamespace(inputs, root, true, 0)
+	genNamespace(outputs, root, false, 0)
 
-	namespaces := map[string]*namespace{}
-	var getNamespace func(string) *namespace
-	getNamespace = func(mod string) *namespace {
+	return inputs.String(), outputs.String()
+}
+
+// typeNamespace is a node in the tree of namespaces that holds the members of the types module.
+type typeNamespace struct {
+	name     string
+	types    []*schema.ObjectType
+	enums    []*schema.EnumType
+	children []*typeNamespace
+}
+
+// typeNamespaces builds a namespace tree out of the object and enum types in the module and returns its root.
+func (mod *modContext) typeNamespaces() *typeNamespace {
+	namespaces := map[string]*typeNamespace{}
+	var getNamespace func(string) *typeNamespace
+	getNamespace = func(mod string) *typeNamespace {
 		ns, ok := namespaces[mod]
 		if !ok {
 			name := mod
@@ -970,7 +1038,7 @@ func (mod *modContext) genTypes() (string, string) {
 				name = path.Base(mod)
 			}
 
-			ns = &namespace{name: name}
+			ns = &typeNamespace{name: name}
 			if mod != "" {
 				parentMod := path.Dir(mod)
 				if parentMod == "." {
@@ -985,47 +1053,137 @@ func (mod *modContext) genTypes() (string, string) {
 		return ns
 	}
 
-	for _, t := range mod.types {
-		modName := mod.pkg.TokenToModule(t.Token)
+	getModNamespace := func(token string) *typeNamespace {
+		modName := mod.pkg.TokenToModule(token)
 		if override, ok := mod.modToPkg[modName]; ok {
 			modName = override
 		}
-		ns := getNamespace(modName)
+		return getNamespace(modName)
+	}
+	for _, t := range mod.types {
+		ns := getModNamespace(t.Token)
 		ns.types = append(ns.types, t)
 	}
+	for _, t := range mod.enums {
+		ns := getModNamespace(t.Token)
+		ns.enums = append(ns.enums, t)
+	}
+
+	return getNamespace("")
+}
 
-	var genNamespace func(io.Writer, *namespace, bool, int)
-	genNamespace = func(w io.Writer, ns *namespace, input bool, level int) {
+// genEnums emits the enum types in the module. Each enum is a union of its values that is merged with a namespace of
+// the same name. The namespace holds a constant for each value along with functions that list, parse, and validate
+// the values.
+func (mod *modContext) genEnums() (string, error) {
+	w := &bytes.Buffer{}
+	mod.genHeader(w, nil, nil)
+
+	var genNamespace func(*typeNamespace, int) error
+	genNamespace = func(ns *typeNamespace, level int) error {
 		indent := strings.Repeat("    ", level)
 
-		sort.Slice(ns.types, func(i, j int) bool {
-			return tokenToName(ns.types[i].Token) < tokenToName(ns.types[j].Token)
+		sort.Slice(ns.enums, func(i, j int) bool {
+			return tokenToName(ns.enums[i].Token) < tokenToName(ns.enums[j].Token)
 		})
-		for i, t := range ns.types {
-			if input && mod.details(t).inputType || !input && mod.details(t).outputType {
-				mod.genType(w, t, input, level)
-				if i != len(ns.types)-1 {
-					fmt.Fprintf(w, "\n")
-				}
+		for i, t := range ns.enums {
+			if err := mod.genEnum(w, t, indent); err != nil {
+				return err
+			}
+			if i != len(ns.enums)-1 {
+				fmt.Fprintf(w, "\n")
 			}
 		}
 
-		sort.Slice(ns.children, func(i, j int) bool {
-			return ns.children[i].name < ns.children[j].name
+		var children []*typeNamespace
+		for _, child := range ns.children {
+			if child.hasEnums() {
+				children = append(children, child)
+			}
+		}
+		sort.Slice(children, func(i, j int) bool {
+			return children[i].name < children[j].name
 		})
-		for i, ns := range ns.children {
-			fmt.Fprintf(w, "%sexport namespace %s {\n", indent, ns.name)
-			genNamespace(w, ns, input, level+1)
-			fmt.Fprintf(w, "%s}\n", indent)
-			if i != len(ns.children)-1 {
+		for i, child := range children {
+			if i != 0 || len(ns.enums) != 0 {
 				fmt.Fprintf(w, "\n")
 			}
+			fmt.Fprintf(w, "%sexport namespace %s {\n", indent, child.name)
+			if err := genNamespace(child, level+1); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s}\n", indent)
 		}
+		return nil
+	}
+	if err := genNamespace(mod.typeNamespaces(), 0); err != nil {
+		return "", err
 	}
-	genNamespace(inputs, namespaces[""], true, 0)
-	genNamespace(outputs, namespaces[""], false, 0)
 
-	return inputs.String(), outputs.String()
+	return w.String(), nil
+}
+
+// hasEnums returns true if the namespace or any of its descendants holds an enum type.
+func (ns *typeNamespace) hasEnums() bool {
+	if len(ns.enums) != 0 {
+		return true
+	}
+	for _, child := range ns.children {
+		if child.hasEnums() {
+			return true
+		}
+	}
+	return false
+}
+
+func (mod *modContext) genEnum(w io.Writer, t *schema.EnumType, indent string) error {
+	memberNames, err := codegen.EnumMemberNames(t)
+	if err != nil {
+		return err
+	}
+	name := tokenToName(t.Token)
+
+	values := make([]string, len(t.Elements))
+	for i, e := range t.Elements {
+		if values[i], err = tsPrimitiveValue(e.Value); err != nil {
+			return err
+		}
+	}
+
+	printComment(w, t.Comment, "", indent)
+	fmt.Fprintf(w, "%sexport type %s = %s;\n", indent, name, strings.Join(values, " | "))
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "%sexport namespace %s {\n", indent, name)
+	for i, e := range t.Elements {
+		printComment(w, e.Comment, e.DeprecationMessage, indent+"    ")
+		fmt.Fprintf(w, "%s    export const %s: %s = %s;\n", indent, memberNames[i], name, values[i])
+	}
+	fmt.Fprintf(w, "\n")
+
+	printComment(w, fmt.Sprintf("Returns the values of %s.", name), "", indent+"    ")
+	fmt.Fprintf(w, "%s    export function values(): %s[] {\n", indent, name)
+	fmt.Fprintf(w, "%s        return [%s];\n", indent, strings.Join(memberNames, ", "))
+	fmt.Fprintf(w, "%s    }\n", indent)
+	fmt.Fprintf(w, "\n")
+
+	parseComment := fmt.Sprintf("Returns the value of %s whose string form is `s`, or throws an error if there is none.", name)
+	printComment(w, parseComment, "", indent+"    ")
+	fmt.Fprintf(w, "%s    export function parse(s: string): %s {\n", indent, name)
+	fmt.Fprintf(w, "%s        for (const v of values()) {\n", indent)
+	fmt.Fprintf(w, "%s            if (String(v) === s) {\n", indent)
+	fmt.Fprintf(w, "%s                return v;\n", indent)
+	fmt.Fprintf(w, "%s            }\n", indent)
+	fmt.Fprintf(w, "%s        }\n", indent)
+	fmt.Fprintf(w, "%s        throw new Error(`\"${s}\" is not a valid %s`);\n", indent, name)
+	fmt.Fprintf(w, "%s    }\n", indent)
+	fmt.Fprintf(w, "\n")
+
+	printComment(w, fmt.Sprintf("Returns true if `v` is one of the values of %s.", name), "", indent+"    ")
+	fmt.Fprintf(w, "%s    export function isValid(v: any): v is %s {\n", indent, name)
+	fmt.Fprintf(w, "%s        return values().indexOf(v) !== -1;\n", indent)
+	fmt.Fprintf(w, "%s    }\n", indent)
+	fmt.Fprintf(w, "%s}\n", indent)
+	return nil
 }
 
 type fs map[string][]byte
@@ -1042,6 +1200,8 @@ func (mod *modContext) isReservedSourceFileName(name string) bool {
 		return true
 	case "input.ts", "output.ts":
 		return len(mod.types) != 0
+	case "enums.ts":
+		return len(mod.enums) != 0
 	case "utilities.ts":
 		return mod.mod == ""
 	case "vars.ts":
@@ -1141,11 +1301,43 @@ func (mod *modContext) gen(fs fs) error {
 		fs.add(path.Join(modDir, "output.ts"), []byte(output))
 	}
 
+	// Enums
+	if len(mod.enums) > 0 {
+		enums, err := mod.genEnums()
+		if err != nil {
+			return err
+		}
+		fs.add(path.Join(modDir, "enums.ts"), []byte(enums))
+	}
+
 	// Index
 	fs.add(path.Join(modDir, "index.ts"), []byte(mod.genIndex(files)))
+
+	// Module README
+	if mod.mod != "" {
+		if idx := mod.genModuleIndex(); !idx.IsEmpty() {
+			fs.add(path.Join(modDir, "README.md"), []byte(idx.Markdown()))
+		}
+	}
 	return nil
 }
 
+// genModuleIndex summarizes the resources, functions, and types in the module.
+func (mod *modContext) genModuleIndex() *codegen.ModuleIndex {
+	title, _ := DocLanguageHelper{}.GetModuleDocLink(mod.pkg, mod.mod)
+	idx := codegen.NewModuleIndex(mod.pkg, mod.mod, title)
+	for _, r := range mod.resources {
+		idx.AddResource(resourceName(r), r)
+	}
+	for _, f := range mod.functions {
+		idx.AddFunction(tokenToFunctionName(f.Token), f)
+	}
+	for _, t := range mod.types {
+		idx.AddType(tokenToName(t.Token), t)
+	}
+	return idx
+}
+
 // genIndex emits an index module, optionally re-exporting other members or submodules.
 func (mod *modContext) genIndex(exports []string) string {
 	w := &bytes.Buffer{}
@@ -1184,6 +1376,9 @@ func (mod *modContext) genIndex(exports []string) string {
 		children.Add("input")
 		children.Add("output")
 	}
+	if len(mod.enums) > 0 {
+		children.Add("enums")
+	}
 
 	// Finally, if there are submodules, export them.
 	if len(children) > 0 {
@@ -1459,14 +1654,17 @@ func generateModuleContextMap(tool string, pkg *schema.Package, info NodePackage
 
 	// Create the types module.
 	for _, t := range pkg.Types {
-		if obj, ok := t.(*schema.ObjectType); ok {
-			types.types = append(types.types, obj)
+		switch t := t.(type) {
+		case *schema.ObjectType:
+			types.types = append(types.types, t)
+		case *schema.EnumType:
+			types.enums = append(types.enums, t)
 		}
 	}
-	if len(types.types) > 0 {
-		typeDetails, typeList := types.typeDetails, types.types
+	if len(types.types) > 0 || len(types.enums) > 0 {
+		typeDetails, typeList, enumList := types.typeDetails, types.types, types.enums
 		types = getMod("types")
-		types.typeDetails, types.types = typeDetails, typeList
+		types.typeDetails, types.types, types.enums = typeDetails, typeList, enumList
 	}
 
 	// Add Typescript source files to the corresponding modules. Note that we only add the file names; the contents are
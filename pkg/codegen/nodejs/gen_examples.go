@@ -0,0 +1,97 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// ExampleWorkspace returns a workspace that compiles the TypeScript examples in the given package's schema against the
+// SDK that GeneratePackage generates for the package. The workspace's tsconfig.json maps the SDK's package name to the
+// generated sources; each example is compiled by its own project that extends that configuration.
+func ExampleWorkspace(tool string, pkg *schema.Package) (*codegen.ExampleWorkspace, error) {
+	sdk, err := GeneratePackage(tool, pkg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info, _ := pkg.Language["nodejs"].(NodePackageInfo)
+	packageName := info.PackageName
+	if packageName == "" {
+		packageName = fmt.Sprintf("@pulumi/%s", pkg.Name)
+	}
+
+	files := map[string][]byte{}
+	for p, contents := range sdk {
+		files[path.Join("sdk", p)] = contents
+	}
+
+	packageJSON, err := json.MarshalIndent(map[string]interface{}{
+		"name":    "examples",
+		"private": true,
+		"dependencies": map[string]string{
+			"@pulumi/pulumi": "^2.0.0",
+		},
+		"devDependencies": map[string]string{
+			"@types/node": "^10.0.0",
+			"typescript":  "^3.7.0",
+		},
+	}, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	files["package.json"] = append(packageJSON, '\n')
+
+	tsconfig, err := json.MarshalIndent(map[string]interface{}{
+		"compilerOptions": map[string]interface{}{
+			"target":           "es2016",
+			"module":           "commonjs",
+			"moduleResolution": "node",
+			"strict":           true,
+			"noEmit":           true,
+			"baseUrl":          ".",
+			"paths": map[string][]string{
+				packageName:        {"sdk"},
+				packageName + "/*": {"sdk/*"},
+			},
+		},
+	}, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	files["tsconfig.json"] = append(tsconfig, '\n')
+
+	workspace := &codegen.ExampleWorkspace{
+		Files: files,
+		Setup: [][]string{{"npm", "install"}},
+	}
+	for i, example := range codegen.PackageExamples(pkg, "typescript") {
+		dir := fmt.Sprintf("examples/%03d", i)
+		files[path.Join(dir, "index.ts")] = []byte(example.Code)
+		files[path.Join(dir, "tsconfig.json")] = []byte(
+			"{\n    \"extends\": \"../../tsconfig.json\",\n    \"files\": [\"index.ts\"]\n}\n")
+		workspace.Builds = append(workspace.Builds, codegen.ExampleBuild{
+			Example: example,
+			Dir:     ".",
+			Command: []string{"npx", "tsc", "-p", dir},
+		})
+	}
+	return workspace, nil
+}
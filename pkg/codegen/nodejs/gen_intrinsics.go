@@ -21,6 +21,10 @@ const (
 	intrinsicAwait = "__await"
 	// intrinsicInterpolate is the name of the interpolate intrinsic.
 	intrinsicInterpolate = "__interpolate"
+	// intrinsicSpread is the name of the spread intrinsic.
+	intrinsicSpread = "__spread"
+	// intrinsicFlatten is the name of the flatten intrinsic.
+	intrinsicFlatten = "__flatten"
 )
 
 // newAwaitCall creates a new call to the await intrinsic.
@@ -56,3 +60,35 @@ func newInterpolateCall(args []model.Expression) *model.FunctionCallExpression {
 		Args: args,
 	}
 }
+
+// newSpreadCall creates a new call to the spread intrinsic that represents a list whose elements are spliced into an
+// enclosing array literal using spread syntax.
+func newSpreadCall(list model.Expression) *model.FunctionCallExpression {
+	return &model.FunctionCallExpression{
+		Name: intrinsicSpread,
+		Signature: model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "list",
+				Type: list.Type(),
+			}},
+			ReturnType: list.Type(),
+		},
+		Args: []model.Expression{list},
+	}
+}
+
+// newFlattenCall creates a new call to the flatten intrinsic that represents the concatenation of a list of lists of
+// resources.
+func newFlattenCall(lists model.Expression) *model.FunctionCallExpression {
+	return &model.FunctionCallExpression{
+		Name: intrinsicFlatten,
+		Signature: model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "lists",
+				Type: lists.Type(),
+			}},
+			ReturnType: model.NewListType(model.DynamicType),
+		},
+		Args: []model.Expression{lists},
+	}
+}
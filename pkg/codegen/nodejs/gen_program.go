@@ -39,23 +39,54 @@ type generator struct {
 	program     *hcl2.Program
 	diagnostics hcl.Diagnostics
 
-	asyncMain     bool
-	configCreated bool
+	asyncMain        bool
+	configCreated    bool
+	configNamespaces codegen.StringSet
+
+	// The component whose constructor is being generated, if any.
+	component *hcl2.Component
+	// The writer that records the source map for the generated program, if any.
+	sourceMap *codegen.SourceMapWriter
+	// The report that records the fidelity of the conversion, if any.
+	report *codegen.ConversionReport
 }
 
 func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
+	return GenerateProgramWithOptions(program, codegen.GenerateProgramOptions{})
+}
+
+// GenerateProgramWithOptions generates a TypeScript program for the given PCL program. The options control the
+// generator's optional outputs, e.g. a source map for the generated program.
+func GenerateProgramWithOptions(program *hcl2.Program,
+	options codegen.GenerateProgramOptions) (map[string][]byte, hcl.Diagnostics, error) {
+
 	// Linearize the nodes into an order appropriate for procedural code generation.
 	nodes := hcl2.Linearize(program)
 
 	g := &generator{
-		program: program,
+		program:          program,
+		configNamespaces: codegen.NewStringSet(),
 	}
 	g.Formatter = format.NewFormatter(g)
 
+	g.report = options.Report
+	program.Report(g.report)
+
 	var index bytes.Buffer
-	g.genPreamble(&index, program)
+	var w io.Writer = &index
+	if options.SourceMap {
+		g.sourceMap = codegen.NewSourceMapWriter(&index, "index.ts")
+		w = g.sourceMap
+	}
+
+	g.genPreamble(w, program)
 	for _, n := range nodes {
-		if r, ok := n.(*hcl2.Resource); ok && requiresAsyncMain(r) {
+		if c, ok := n.(*hcl2.Component); ok {
+			g.sourceMap.Map(c.SyntaxNode().Range(), func() { g.genComponent(w, c) })
+		}
+	}
+	for _, n := range nodes {
+		if r, ok := hcl2.NodeResource(n); ok && requiresAsyncMain(r) {
 			g.asyncMain = true
 			break
 		}
@@ -64,12 +95,12 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 	indenter := func(f func()) { f() }
 	if g.asyncMain {
 		indenter = g.Indented
-		g.Fgenf(&index, "export = async () => {\n")
+		g.Fgenf(w, "export = async () => {\n")
 	}
 
 	indenter(func() {
 		for _, n := range nodes {
-			g.genNode(&index, n)
+			g.genNode(w, n)
 		}
 
 		if g.asyncMain {
@@ -94,19 +125,26 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 				}
 			}
 			if result != nil {
-				g.Fgenf(&index, "%sreturn %v;\n", g.Indent, result)
+				g.Fgenf(w, "%sreturn %v;\n", g.Indent, result)
 			}
 		}
 
 	})
 
 	if g.asyncMain {
-		g.Fgenf(&index, "}\n")
+		g.Fgenf(w, "}\n")
 	}
 
 	files := map[string][]byte{
 		"index.ts": index.Bytes(),
 	}
+	if g.sourceMap != nil {
+		sourceMap, err := g.sourceMap.SourceMap().JSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		files["index.ts.map"] = sourceMap
+	}
 	return files, g.diagnostics, nil
 }
 
@@ -150,14 +188,17 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 	// Accumulate other imports for the various providers and packages. Don't emit them yet, as we need to sort them
 	// later on.
 	importSet := codegen.NewStringSet("@pulumi/pulumi")
-	for _, n := range program.Nodes {
-		if r, isResource := n.(*hcl2.Resource); isResource {
-			pkg, _, _, _ := r.DecomposeToken()
+	for _, n := range hcl2.ProgramNodes(program) {
+		if r, isResource := hcl2.NodeResource(n); isResource && r.Component == nil {
+			pkg, mod, name, _ := r.DecomposeToken()
+			if pkg == "pulumi" && mod == "providers" {
+				pkg = name
+			}
 			importSet.Add("@pulumi/" + makeValidIdentifier(pkg))
 		}
 		diags := n.VisitExpressions(nil, func(n model.Expression) (model.Expression, hcl.Diagnostics) {
 			if call, ok := n.(*model.FunctionCallExpression); ok {
-				if i := g.getFunctionImports(call); i != "" {
+				for _, i := range g.getFunctionImports(call) {
 					importSet.Add(i)
 				}
 			}
@@ -188,16 +229,20 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 }
 
 func (g *generator) genNode(w io.Writer, n hcl2.Node) {
-	switch n := n.(type) {
-	case *hcl2.Resource:
-		g.genResource(w, n)
-	case *hcl2.ConfigVariable:
-		g.genConfigVariable(w, n)
-	case *hcl2.LocalVariable:
-		g.genLocalVariable(w, n)
-	case *hcl2.OutputVariable:
-		g.genOutputVariable(w, n)
-	}
+	g.sourceMap.Map(n.SyntaxNode().Range(), func() {
+		switch n := n.(type) {
+		case *hcl2.Resource:
+			g.genResource(w, n)
+		case *hcl2.Provider:
+			g.genResource(w, n.Resource)
+		case *hcl2.ConfigVariable:
+			g.genConfigVariable(w, n)
+		case *hcl2.LocalVariable:
+			g.genLocalVariable(w, n)
+		case *hcl2.OutputVariable:
+			g.genOutputVariable(w, n)
+		}
+	})
 }
 
 func requiresAsyncMain(r *hcl2.Resource) bool {
@@ -221,6 +266,13 @@ func resourceTypeName(r *hcl2.Resource) (string, string, string, hcl.Diagnostics
 // makeResourceName returns the expression that should be emitted for a resource's "name" parameter given its base name
 // and the count variable name, if any.
 func (g *generator) makeResourceName(baseName, count string) string {
+	// The names of the resources inside of a component are prefixed with the name of the component instance.
+	if g.component != nil {
+		baseName = "${name}-" + baseName
+		if count == "" {
+			return fmt.Sprintf("`%s`", baseName)
+		}
+	}
 	if count == "" {
 		return fmt.Sprintf(`"%s"`, baseName)
 	}
@@ -229,7 +281,10 @@ func (g *generator) makeResourceName(baseName, count string) string {
 
 func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	if opts == nil {
-		return ""
+		if g.component == nil {
+			return ""
+		}
+		opts = &hcl2.ResourceOptions{}
 	}
 
 	// Turn the resource options into an ObjectConsExpression and generate it.
@@ -249,12 +304,19 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 
 	if opts.Parent != nil {
 		appendOption("parent", opts.Parent)
+	} else if g.component != nil {
+		// Resources inside of a component are children of the component unless they specify another parent.
+		appendOption("parent", model.VariableReference(thisVariable))
 	}
 	if opts.Provider != nil {
 		appendOption("provider", opts.Provider)
 	}
 	if opts.DependsOn != nil {
-		appendOption("dependsOn", opts.DependsOn)
+		if hcl2.IsStaticResourceList(opts.DependsOn) {
+			appendOption("dependsOn", opts.DependsOn)
+		} else {
+			appendOption("dependsOn", flattenResourceList(opts.DependsOn))
+		}
 	}
 	if opts.Protect != nil {
 		appendOption("protect", opts.Protect)
@@ -262,6 +324,18 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 	if opts.IgnoreChanges != nil {
 		appendOption("ignoreChanges", opts.IgnoreChanges)
 	}
+	if opts.Aliases != nil {
+		appendOption("aliases", opts.Aliases)
+	}
+	if opts.CustomTimeouts != nil {
+		appendOption("customTimeouts", opts.CustomTimeouts)
+	}
+	if opts.DeleteBeforeReplace != nil {
+		appendOption("deleteBeforeReplace", opts.DeleteBeforeReplace)
+	}
+	if opts.Version != nil {
+		appendOption("version", opts.Version)
+	}
 
 	if object == nil {
 		return ""
@@ -274,17 +348,28 @@ func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) string {
 
 // genResource handles the generation of instantiations of non-builtin resources.
 func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
-	pkg, module, memberName, diagnostics := resourceTypeName(r)
-	g.diagnostics = append(g.diagnostics, diagnostics...)
+	var qualifiedMemberName string
+	if r.Component != nil {
+		qualifiedMemberName = componentClassName(r.Component)
+	} else {
+		pkg, module, memberName, diagnostics := resourceTypeName(r)
+		g.diagnostics = append(g.diagnostics, diagnostics...)
 
-	if module != "" {
-		module = "." + module
+		if module != "" {
+			module = "." + module
+		}
+		qualifiedMemberName = fmt.Sprintf("%s%s.%s", pkg, module, memberName)
 	}
 
-	qualifiedMemberName := fmt.Sprintf("%s%s.%s", pkg, module, memberName)
-
 	optionsBag := g.genResourceOptions(r.Options)
 
+	// Wrap secret input properties
+	for _, attr := range r.Inputs {
+		destType, diagnostics := r.InputType.Traverse(hcl.TraverseAttr{Name: attr.Name})
+		g.diagnostics = append(g.diagnostics, diagnostics...)
+		attr.Value = hcl2.RewriteSecrets(attr.Value, destType.(model.Type))
+	}
+
 	name := r.Name()
 	variableName := makeValidIdentifier(name)
 
@@ -334,7 +419,11 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 			})
 			g.Fgenf(w, "%s}\n", g.Indent)
 		} else {
-			g.Fgenf(w, "%sconst %s: %s[];\n", g.Indent, variableName, qualifiedMemberName)
+			if r.HasMapRange() {
+				g.Fgenf(w, "%sconst %s: Record<string, %s> = {};\n", g.Indent, variableName, qualifiedMemberName)
+			} else {
+				g.Fgenf(w, "%sconst %s: %s[] = [];\n", g.Indent, variableName, qualifiedMemberName)
+			}
 
 			resKey := "key"
 			if model.InputType(model.NumberType).ConversionFrom(rangeExpr.Type()) != model.NoConversion {
@@ -350,9 +439,15 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 
 			resName := g.makeResourceName(name, "range."+resKey)
 			g.Indented(func() {
-				g.Fgenf(w, "%s%s.push(", g.Indent, variableName)
-				instantiate(resName)
-				g.Fgenf(w, ");\n")
+				if r.HasMapRange() {
+					g.Fgenf(w, "%s%s[range.key] = ", g.Indent, variableName)
+					instantiate(resName)
+					g.Fgenf(w, ";\n")
+				} else {
+					g.Fgenf(w, "%s%s.push(", g.Indent, variableName)
+					instantiate(resName)
+					g.Fgenf(w, ");\n")
+				}
 			})
 			g.Fgenf(w, "%s}\n", g.Indent)
 		}
@@ -368,11 +463,25 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
 	// TODO(pdg): trivia
 
-	if !g.configCreated {
+	configObject, name, key := "config", v.Name(), v.Name()
+	if namespace := v.Namespace(); namespace != "" {
+		configObject, key = makeValidIdentifier(namespace+"Config"), v.Key()
+		name = makeValidIdentifier(v.VariableName(g.program))
+		if !g.configNamespaces.Has(namespace) {
+			g.Fprintf(w, "%sconst %s = new pulumi.Config(\"%s\");\n", g.Indent, configObject, namespace)
+			g.configNamespaces.Add(namespace)
+		}
+	} else if !g.configCreated {
 		g.Fprintf(w, "%sconst config = new pulumi.Config();\n", g.Indent)
 		g.configCreated = true
 	}
 
+	if v.ProviderConfig != nil {
+		if description := hcl2.DescribeDefaultValue(v.ProviderConfig); description != "" {
+			g.Fgenf(w, "%s// %s %s.\n", g.Indent, v.Name(), description)
+		}
+	}
+
 	getType := "Object"
 	switch v.Type() {
 	case model.StringType:
@@ -388,7 +497,7 @@ func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
 		getOrRequire = "require"
 	}
 
-	g.Fgenf(w, "%[1]sconst %[2]s = config.%[3]s%[4]s(\"%[2]s\")", g.Indent, v.Name(), getOrRequire, getType)
+	g.Fgenf(w, "%sconst %s = %s.%s%s(\"%s\")", g.Indent, name, configObject, getOrRequire, getType, key)
 	if v.DefaultValue != nil {
 		g.Fgenf(w, " || %.v", g.lowerExpression(v.DefaultValue))
 	}
@@ -409,12 +518,10 @@ func (g *generator) genOutputVariable(w io.Writer, v *hcl2.OutputVariable) {
 	g.Fgenf(w, "%s%sconst %s = %.3v;\n", g.Indent, export, makeValidIdentifier(v.Name()), g.lowerExpression(v.Value))
 }
 
-func (g *generator) genNYI(w io.Writer, reason string, vs ...interface{}) {
-	message := fmt.Sprintf("not yet implemented: %s", fmt.Sprintf(reason, vs...))
-	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
-		Severity: hcl.DiagError,
-		Summary:  message,
-		Detail:   message,
-	})
-	g.Fgenf(w, "(() => throw new Error(%q))()", fmt.Sprintf(reason, vs...))
+// genNYI replaces an expression that cannot be translated with `undefined` and a TODO comment.
+func (g *generator) genNYI(w io.Writer, expr model.Expression, reason string, vs ...interface{}) {
+	construct := g.program.NewUnsupportedConstruct(expr, reason, vs...)
+	g.diagnostics = append(g.diagnostics, construct.Diagnostic())
+	construct.Report(g.report)
+	g.Fgenf(w, "/* %s */ undefined", construct.Comment())
 }
@@ -0,0 +1,152 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+)
+
+// thisVariable is the variable that refers to the component whose constructor is being generated. Resources inside of
+// a component use it as their default parent.
+var thisVariable = &model.Variable{
+	Name:         "this",
+	VariableType: model.DynamicType,
+}
+
+// componentClassName returns the name of the class generated for the given component.
+func componentClassName(c *hcl2.Component) string {
+	return title(makeValidIdentifier(c.Name()))
+}
+
+// componentTypeName returns the TypeScript type for a component input or output of the given type. Types that have no
+// simple TypeScript equivalent are typed as `any`.
+func componentTypeName(t model.Type, input bool) string {
+	elementTypeName := func(t model.Type) string {
+		if input {
+			return fmt.Sprintf("pulumi.Input<%s>", componentTypeName(t, false))
+		}
+		return componentTypeName(t, false)
+	}
+
+	switch t := t.(type) {
+	case *model.ListType:
+		return elementTypeName(t.ElementType) + "[]"
+	case *model.MapType:
+		return fmt.Sprintf("Record<string, %s>", elementTypeName(t.ElementType))
+	}
+
+	switch t {
+	case model.BoolType:
+		return "boolean"
+	case model.IntType, model.NumberType:
+		return "number"
+	case model.StringType:
+		return "string"
+	default:
+		return "any"
+	}
+}
+
+// propertyName returns the name of the property that holds the given component input or output.
+func propertyName(name string) string {
+	if !isLegalIdentifier(name) {
+		return fmt.Sprintf("%q", name)
+	}
+	return name
+}
+
+// outputField returns the expression that refers to the field that holds the given component output.
+func outputField(name string) string {
+	if !isLegalIdentifier(name) {
+		return fmt.Sprintf("this[%q]", name)
+	}
+	return "this." + name
+}
+
+// genComponent generates an arguments interface and a ComponentResource class for a component. The resources inside of
+// the component are children of the component, and their names are prefixed with the name of the component instance.
+func (g *generator) genComponent(w io.Writer, c *hcl2.Component) {
+	className := componentClassName(c)
+	inputType := c.InputType.(*model.ObjectType)
+	outputType := c.OutputType.(*model.ObjectType)
+
+	g.Fgenf(w, "interface %sArgs {\n", className)
+	g.Indented(func() {
+		for _, input := range c.Inputs {
+			typeName := componentTypeName(inputType.Properties[input.Name], true)
+			g.Fgenf(w, "%s%s: pulumi.Input<%s>;\n", g.Indent, propertyName(input.Name), typeName)
+		}
+	})
+	g.Fgenf(w, "}\n\n")
+
+	g.Fgenf(w, "class %s extends pulumi.ComponentResource {\n", className)
+	g.Indented(func() {
+		for _, output := range c.Outputs {
+			typeName := componentTypeName(model.ResolveOutputs(outputType.Properties[output.Name]), false)
+			g.Fgenf(w, "%spublic readonly %s: pulumi.Output<%s>;\n", g.Indent, propertyName(output.Name), typeName)
+		}
+		if len(c.Outputs) != 0 {
+			g.Fgenf(w, "\n")
+		}
+
+		g.Fgenf(w, "%sconstructor(name: string, args: %sArgs, opts?: pulumi.ComponentResourceOptions) {\n", g.Indent,
+			className)
+		g.Indented(func() {
+			g.Fgenf(w, "%ssuper(\"components:index:%s\", name, {}, opts);\n\n", g.Indent, className)
+
+			for _, input := range c.Inputs {
+				arg := "args." + input.Name
+				if !isLegalIdentifier(input.Name) {
+					arg = fmt.Sprintf("args[%q]", input.Name)
+				}
+				g.Fgenf(w, "%sconst %s = pulumi.output(%s);\n", g.Indent, makeValidIdentifier(input.Name), arg)
+			}
+
+			g.component = c
+			for _, n := range hcl2.LinearizeComponent(c) {
+				g.genNode(w, n)
+			}
+			g.component = nil
+
+			for _, output := range c.Outputs {
+				value := g.lowerExpression(output.Value)
+				field := outputField(output.Name)
+				if _, isOutput := value.Type().(*model.OutputType); isOutput {
+					g.Fgenf(w, "%s%s = %v;\n", g.Indent, field, value)
+				} else {
+					g.Fgenf(w, "%s%s = pulumi.output(%v);\n", g.Indent, field, value)
+				}
+			}
+
+			if len(c.Outputs) == 0 {
+				g.Fgenf(w, "%sthis.registerOutputs();\n", g.Indent)
+				return
+			}
+			g.Fgenf(w, "%sthis.registerOutputs({\n", g.Indent)
+			g.Indented(func() {
+				for _, output := range c.Outputs {
+					g.Fgenf(w, "%s%s: %s,\n", g.Indent, propertyName(output.Name), outputField(output.Name))
+				}
+			})
+			g.Fgenf(w, "%s});\n", g.Indent)
+		})
+		g.Fgenf(w, "%s}\n", g.Indent)
+	})
+	g.Fgenf(w, "}\n\n")
+}
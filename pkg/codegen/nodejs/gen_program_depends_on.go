@@ -0,0 +1,85 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// flattenResourceList rewrites a computed list of resources, e.g. the value of a dependsOn option that mixes
+// individual resources with ranged resources, conditionals, or for expressions, into an expression that evaluates to a
+// flat array of resources. Nested lists are spliced into their enclosing array literal using spread syntax.
+func flattenResourceList(expr model.Expression) model.Expression {
+	var diags hcl.Diagnostics
+	switch expr := expr.(type) {
+	case *model.TupleConsExpression:
+		for i, item := range expr.Expressions {
+			if isResourceList(item) {
+				expr.Expressions[i] = newSpreadCall(flattenResourceList(item))
+			}
+		}
+		diags = expr.Typecheck(false)
+	case *model.ConditionalExpression:
+		expr.TrueResult = flattenResourceList(expr.TrueResult)
+		expr.FalseResult = flattenResourceList(expr.FalseResult)
+		diags = expr.Typecheck(false)
+	case *model.ForExpression:
+		if isResourceList(expr.Value) {
+			expr.Value = flattenResourceList(expr.Value)
+			diags = expr.Typecheck(false)
+			contract.Assert(len(diags) == 0)
+			return newFlattenCall(expr)
+		}
+	default:
+		if !isResourceList(expr) {
+			return newResourceList(expr)
+		}
+	}
+	contract.Assert(len(diags) == 0)
+	return expr
+}
+
+// newResourceList returns an array literal that contains the given resource, or an empty array literal if the given
+// expression is null.
+func newResourceList(expr model.Expression) model.Expression {
+	var items []model.Expression
+	if lit, ok := expr.(*model.LiteralValueExpression); !ok || !lit.Value.IsNull() {
+		items = []model.Expression{expr}
+	}
+	list := &model.TupleConsExpression{
+		Tokens:      syntax.NewTupleConsTokens(len(items)),
+		Expressions: items,
+	}
+	diags := list.Typecheck(false)
+	contract.Assert(len(diags) == 0)
+	return list
+}
+
+// isResourceList returns true if the given expression evaluates to a list of resources rather than to a single
+// resource.
+func isResourceList(expr model.Expression) bool {
+	switch expr.(type) {
+	case *model.TupleConsExpression, *model.ConditionalExpression, *model.ForExpression:
+		return true
+	}
+	switch model.ResolveOutputs(expr.Type()).(type) {
+	case *model.ListType, *model.TupleType, *model.UnionType:
+		return true
+	}
+	return false
+}
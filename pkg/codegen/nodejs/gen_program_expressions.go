@@ -158,7 +158,7 @@ func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) {
 
 	fnParams, reduceParams := expr.ValueVariable.Name, expr.ValueVariable.Name
 	if expr.KeyVariable != nil {
-		reduceParams = fmt.Sprintf("[%.v, %.v]", expr.KeyVariable.Name, expr.ValueVariable.Name)
+		reduceParams = fmt.Sprintf("[%v, %v]", expr.KeyVariable.Name, expr.ValueVariable.Name)
 		fnParams = fmt.Sprintf("(%v)", reduceParams)
 	}
 
@@ -274,14 +274,20 @@ var functionImports = map[string]string{
 	"readDir":            "fs",
 }
 
-func (g *generator) getFunctionImports(x *model.FunctionCallExpression) string {
+func (g *generator) getFunctionImports(x *model.FunctionCallExpression) []string {
+	if emitter, ok := g.program.IntrinsicEmitter(x.Name, "nodejs"); ok {
+		return emitter.Imports
+	}
 	if x.Name != hcl2.Invoke {
-		return functionImports[x.Name]
+		if i, ok := functionImports[x.Name]; ok {
+			return []string{i}
+		}
+		return nil
 	}
 
 	pkg, _, _, diags := functionName(x.Args[0])
 	contract.Assert(len(diags) == 0)
-	return "@pulumi/" + pkg
+	return []string{"@pulumi/" + pkg}
 }
 
 func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionCallExpression) {
@@ -290,6 +296,10 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 		g.genApply(w, expr)
 	case intrinsicAwait:
 		g.Fgenf(w, "await %.17v", expr.Args[0])
+	case intrinsicSpread:
+		g.Fgenf(w, "...%.17v", expr.Args[0])
+	case intrinsicFlatten:
+		g.Fgenf(w, "([] as pulumi.Resource[]).concat(...%.17v)", expr.Args[0])
 	case intrinsicInterpolate:
 		g.Fgen(w, "pulumi.interpolate`")
 		for _, part := range expr.Args {
@@ -309,11 +319,11 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 				g.genRange(w, call, true)
 				return
 			}
-			g.Fgenf(w, "%.20v.map((k, v)", expr.Args[0])
+			g.Fgenf(w, "%.20v.map((v, k)", expr.Args[0])
 		case *model.MapType, *model.ObjectType:
 			g.Fgenf(w, "Object.entries(%.v).map(([k, v])", expr.Args[0])
 		}
-		g.Fgenf(w, " => {key: k, value: v})")
+		g.Fgenf(w, " => ({key: k, value: v}))")
 	case "fileArchive":
 		g.Fgenf(w, "new pulumi.asset.FileArchive(%.v)", expr.Args[0])
 	case "fileAsset":
@@ -352,13 +362,17 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 	case "split":
 		g.Fgenf(w, "%.20v.split(%v)", expr.Args[1], expr.Args[0])
 	case "toJSON":
-		g.Fgenf(w, "JSON.stringify(%v)", expr.Args[0])
+		if model.ContainsOutputs(expr.Signature.ReturnType) {
+			g.Fgenf(w, "pulumi.jsonStringify(%v)", expr.Args[0])
+		} else {
+			g.Fgenf(w, "JSON.stringify(%v)", expr.Args[0])
+		}
 	default:
-		var rng hcl.Range
-		if expr.Syntax != nil {
-			rng = expr.Syntax.Range()
+		if emitter, ok := g.program.IntrinsicEmitter(expr.Name, "nodejs"); ok {
+			emitter.Emit(g.Formatter, w, expr)
+			return
 		}
-		g.genNYI(w, "FunctionCallExpression: %v (%v)", expr.Name, rng)
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 	}
 }
 
@@ -519,8 +533,19 @@ func (g *generator) GenRelativeTraversalExpression(w io.Writer, expr *model.Rela
 
 func (g *generator) GenScopeTraversalExpression(w io.Writer, expr *model.ScopeTraversalExpression) {
 	rootName := makeValidIdentifier(expr.RootName)
-	if _, ok := expr.Parts[0].(*model.SplatVariable); ok {
-		rootName = "__item"
+	switch expr.Parts[0] {
+	case thisVariable:
+		rootName = "this"
+	default:
+		switch v := expr.Parts[0].(type) {
+		case *model.SplatVariable:
+			rootName = "__item"
+		case *model.Variable:
+			// `each` is an alias for the range variable of a ranged resource.
+			if v.Name == "range" {
+				rootName = "range"
+			}
+		}
 	}
 
 	g.Fgen(w, rootName)
@@ -551,7 +576,7 @@ func (g *generator) GenTemplateExpression(w io.Writer, expr *model.TemplateExpre
 }
 
 func (g *generator) GenTemplateJoinExpression(w io.Writer, expr *model.TemplateJoinExpression) {
-	g.genNYI(w, "TemplateJoinExpression")
+	g.genNYI(w, expr, "template join expressions are not supported")
 }
 
 func (g *generator) GenTupleConsExpression(w io.Writer, expr *model.TupleConsExpression) {
@@ -0,0 +1,165 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// SDKSurface returns the exported symbols of the NodeJS SDK that GeneratePackage generates for the given package, named
+// by their TypeScript paths from the package's root.
+func SDKSurface(tool string, pkg *schema.Package) (codegen.Surface, error) {
+	if err := pkg.ImportLanguages(map[string]schema.Language{"nodejs": Importer}); err != nil {
+		return nil, err
+	}
+	info, _ := pkg.Language["nodejs"].(NodePackageInfo)
+
+	modules, err := generateModuleContextMap(tool, pkg, info, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	surface := codegen.Surface{}
+	for modName, mod := range modules {
+		module := pkg.Name
+		if modName != "" {
+			module += "." + strings.Replace(modName, "/", ".", -1)
+		}
+		qualify := func(name string) string {
+			return module + "." + name
+		}
+		// addMembers adds the properties of an interface. The properties of an argument interface may be omitted if
+		// they are optional, while reading an optional property of any other interface may produce undefined.
+		addMembers := func(parent string, props []*schema.Property, input, wrapInput, argument bool) {
+			for _, p := range props {
+				signature := mod.typeString(p.Type, input, wrapInput, false, p.ConstValue)
+				if !argument && !p.IsRequired {
+					signature += " | undefined"
+				}
+				surface.Add(parent+"."+p.Name, codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    parent,
+					Signature: signature,
+					Argument:  argument,
+					Optional:  !p.IsRequired,
+				})
+			}
+		}
+
+		for _, r := range mod.resources {
+			name := resourceName(r)
+			res := surface.Add(qualify(name), codegen.SurfaceSymbol{Module: module, Token: r.Token, Signature: "class"})
+			for _, p := range r.Properties {
+				required := p.IsRequired || mod.compatibility == kubernetes20
+				surface.Add(res+"."+p.Name, codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    res,
+					Signature: fmt.Sprintf("pulumi.Output<%s>", mod.typeString(p.Type, false, false, !required, p.ConstValue)),
+				})
+			}
+
+			args := surface.Add(qualify(name+"Args"), codegen.SurfaceSymbol{
+				Module:    module,
+				Parent:    res,
+				Signature: "interface",
+			})
+			addMembers(args, r.InputProperties, true, true, true)
+			if r.StateInputs != nil {
+				state := surface.Add(qualify(name+"State"), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    res,
+					Signature: "interface",
+				})
+				addMembers(state, r.StateInputs.Properties, true, true, true)
+			}
+		}
+
+		for _, f := range mod.functions {
+			name := tokenToFunctionName(f.Token)
+
+			var argsig string
+			if f.Inputs != nil {
+				optFlag := "?"
+				for _, p := range f.Inputs.Properties {
+					if p.IsRequired {
+						optFlag = ""
+						break
+					}
+				}
+				argsig = fmt.Sprintf("args%s: %sArgs, ", optFlag, title(name))
+			}
+			retty := "void"
+			if f.Outputs != nil {
+				retty = title(name) + "Result"
+			}
+
+			fn := surface.Add(qualify(name), codegen.SurfaceSymbol{
+				Module:    module,
+				Token:     f.Token,
+				Signature: fmt.Sprintf("(%sopts?: pulumi.InvokeOptions) => Promise<%s>", argsig, retty),
+			})
+			if f.Inputs != nil {
+				args := surface.Add(qualify(title(name)+"Args"), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    fn,
+					Signature: "interface",
+				})
+				addMembers(args, f.Inputs.Properties, true, false, true)
+			}
+			if f.Outputs != nil {
+				result := surface.Add(qualify(title(name)+"Result"), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    fn,
+					Signature: "interface",
+				})
+				addMembers(result, f.Outputs.Properties, false, false, false)
+			}
+		}
+
+		for _, t := range mod.types {
+			details := mod.details(t)
+			if details.inputType {
+				typ := surface.Add(pkg.Name+".types."+mod.tokenToType(t.Token, true), codegen.SurfaceSymbol{
+					Module:    module,
+					Token:     t.Token + "#input",
+					Signature: "interface",
+				})
+				addMembers(typ, t.Properties, true, !details.functionType, true)
+			}
+			if details.outputType {
+				typ := surface.Add(pkg.Name+".types."+mod.tokenToType(t.Token, false), codegen.SurfaceSymbol{
+					Module:    module,
+					Token:     t.Token + "#output",
+					Signature: "interface",
+				})
+				addMembers(typ, t.Properties, false, false, false)
+			}
+		}
+
+		if modName == "config" {
+			for _, p := range pkg.Config {
+				surface.Add(qualify(p.Name), codegen.SurfaceSymbol{
+					Module:    module,
+					Signature: mod.typeString(p.Type, false, false, true, nil),
+				})
+			}
+		}
+	}
+	return surface, nil
+}
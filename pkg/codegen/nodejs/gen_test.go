@@ -0,0 +1,46 @@
+package nodejs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+func TestGenerateEnums(t *testing.T) {
+	var spec schema.PackageSpec
+	err := json.Unmarshal([]byte(`{
+		"name": "example",
+		"language": {"nodejs": {}},
+		"types": {
+			"example:index:Size": {
+				"type": "integer",
+				"description": "The size of a widget.",
+				"enum": [
+					{"name": "Small", "value": 1, "description": "A small widget."},
+					{"name": "Large", "value": 2, "deprecationMessage": "Use Small instead."}
+				]
+			},
+			"example:compute:Region": {
+				"type": "string",
+				"enum": [{"value": "us-east-1"}, {"value": "eu-west-2"}]
+			}
+		}
+	}`), &spec)
+	assert.NoError(t, err)
+	pkg, err := schema.ImportSpec(spec, nil)
+	assert.NoError(t, err)
+
+	files, err := GeneratePackage("test", pkg, nil)
+	assert.NoError(t, err)
+	enums := string(files["types/enums.ts"])
+	assert.Contains(t, enums, "export type Size = 1 | 2;\n\nexport namespace Size {\n")
+	assert.Contains(t, enums, "     * @deprecated Use Small instead.\n     */\n    export const Large: Size = 2;\n")
+	assert.Contains(t, enums, "    export function values(): Size[] {\n        return [Small, Large];\n")
+	assert.Contains(t, enums, "    export function parse(s: string): Size {\n")
+	assert.Contains(t, enums, "    export function isValid(v: any): v is Size {\n")
+	assert.Contains(t, enums, "export namespace compute {\n    export type Region = \"us-east-1\" | \"eu-west-2\";\n")
+	assert.Contains(t, string(files["types/index.ts"]), "import * as enums from \"./enums\";\n")
+}
@@ -65,6 +65,7 @@ type modContext struct {
 	mod                  string
 	resources            []*schema.Resource
 	functions            []*schema.Function
+	enums                []*schema.EnumType
 	children             []*modContext
 	snakeCaseToCamelCase map[string]string
 	camelCaseToSnakeCase map[string]string
@@ -243,11 +244,40 @@ func (mod *modContext) gen(fs fs) error {
 		addFile(PyName(tokenToName(f.Token))+".py", fun)
 	}
 
+	// Enums
+	if len(mod.enums) > 0 {
+		enums, err := mod.genEnums()
+		if err != nil {
+			return err
+		}
+		addFile("_enums.py", enums)
+	}
+
 	// Index
 	fs.add(path.Join(dir, "__init__.py"), []byte(mod.genInit(exports)))
+
+	// Module README
+	if mod.mod != "" {
+		if idx := mod.genModuleIndex(); !idx.IsEmpty() {
+			fs.add(path.Join(dir, "README.md"), []byte(idx.Markdown()))
+		}
+	}
 	return nil
 }
 
+// genModuleIndex summarizes the resources and functions in the module.
+func (mod *modContext) genModuleIndex() *codegen.ModuleIndex {
+	title, _ := DocLanguageHelper{}.GetModuleDocLink(mod.pkg, mod.mod)
+	idx := codegen.NewModuleIndex(mod.pkg, mod.mod, title)
+	for _, r := range mod.resources {
+		idx.AddResource(tokenToName(r.Token), r)
+	}
+	for _, f := range mod.functions {
+		idx.AddFunction(PyName(tokenToName(f.Token)), f)
+	}
+	return idx
+}
+
 func (mod *modContext) submodulesExist() bool {
 	return len(mod.children) > 0
 }
@@ -300,6 +330,94 @@ func (mod *modContext) genInit(exports []string) string {
 	return w.String()
 }
 
+// genEnums emits the enum types in the module. Each enum is an Enum class whose members are the enum's values and
+// that has class methods that list, parse, and validate the values.
+func (mod *modContext) genEnums() (string, error) {
+	w := &bytes.Buffer{}
+	mod.genHeader(w, false, false)
+
+	fmt.Fprintf(w, "from enum import Enum\n")
+	fmt.Fprintf(w, "from typing import Any, List\n")
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "__all__ = [\n")
+	for _, t := range mod.enums {
+		fmt.Fprintf(w, "    '%s',\n", tokenToName(t.Token))
+	}
+	fmt.Fprintf(w, "]\n")
+
+	for _, t := range mod.enums {
+		fmt.Fprintf(w, "\n\n")
+		if err := mod.genEnum(w, t); err != nil {
+			return "", err
+		}
+	}
+
+	return w.String(), nil
+}
+
+func (mod *modContext) genEnum(w io.Writer, t *schema.EnumType) error {
+	memberNames, err := codegen.EnumMemberNames(t)
+	if err != nil {
+		return err
+	}
+	name := tokenToName(t.Token)
+
+	// Mix the element type into the class so that the members compare equal to their values. Python does not allow
+	// subclasses of bool, so boolean enums are plain Enums.
+	switch t.ElementType {
+	case schema.StringType:
+		fmt.Fprintf(w, "class %s(str, Enum):\n", name)
+	case schema.IntType:
+		fmt.Fprintf(w, "class %s(int, Enum):\n", name)
+	case schema.NumberType:
+		fmt.Fprintf(w, "class %s(float, Enum):\n", name)
+	default:
+		fmt.Fprintf(w, "class %s(Enum):\n", name)
+	}
+	printComment(w, codegen.ConvertDocs(t.Comment, codegen.ReSTDocFormat), "    ")
+	for i, e := range t.Elements {
+		value, err := getPrimitiveValue(e.Value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "    %s = %s\n", strings.ToUpper(PyName(memberNames[i])), value)
+
+		comment := codegen.ConvertDocs(e.Comment, codegen.ReSTDocFormat)
+		if e.DeprecationMessage != "" {
+			if comment != "" {
+				comment += "\n\n"
+			}
+			comment += "Deprecated: " + e.DeprecationMessage
+		}
+		printComment(w, comment, "    ")
+	}
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "    @classmethod\n")
+	fmt.Fprintf(w, "    def values(cls) -> List['%s']:\n", name)
+	printComment(w, fmt.Sprintf("Returns the values of %s.", name), "        ")
+	fmt.Fprintf(w, "        return list(cls)\n")
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "    @classmethod\n")
+	fmt.Fprintf(w, "    def parse(cls, s: str) -> '%s':\n", name)
+	parseComment := fmt.Sprintf("Returns the value of %s whose string form is `s`, or raises a ValueError if there is none.",
+		name)
+	printComment(w, parseComment, "        ")
+	fmt.Fprintf(w, "        for v in cls:\n")
+	fmt.Fprintf(w, "            if str(v.value) == s:\n")
+	fmt.Fprintf(w, "                return v\n")
+	fmt.Fprintf(w, "        raise ValueError(f\"{s!r} is not a valid %s\")\n", name)
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "    @classmethod\n")
+	fmt.Fprintf(w, "    def is_valid(cls, value: Any) -> bool:\n")
+	printComment(w, fmt.Sprintf("Returns True if `value` is one of the values of %s.", name), "        ")
+	fmt.Fprintf(w, "        return any(v.value == value for v in cls)\n")
+	return nil
+}
+
 // emitConfigVariables emits all config vaiables in the given module, returning the resulting file.
 func (mod *modContext) genConfig(variables []*schema.Property) (string, error) {
 	w := &bytes.Buffer{}
@@ -321,7 +439,7 @@ func (mod *modContext) genConfig(variables []*schema.Property) (string, error) {
 		}
 
 		fmt.Fprintf(w, "%s = %s\n", PyName(p.Name), configFetch)
-		printComment(w, p.Comment, "")
+		printComment(w, codegen.ConvertDocs(codegen.PropertyComment(p), codegen.ReSTDocFormat), "")
 		fmt.Fprintf(w, "\n")
 	}
 
@@ -334,7 +452,7 @@ func (mod *modContext) genAwaitableType(w io.Writer, obj *schema.ObjectType) str
 	// Produce a class definition with optional """ comment.
 	fmt.Fprint(w, "\n")
 	fmt.Fprintf(w, "class %s:\n", baseName)
-	printComment(w, obj.Comment, "    ")
+	printComment(w, codegen.ConvertDocs(obj.Comment, codegen.ReSTDocFormat), "    ")
 
 	// Now generate an initializer with properties for all inputs.
 	fmt.Fprintf(w, "    def __init__(__self__")
@@ -350,15 +468,14 @@ func (mod *modContext) genAwaitableType(w io.Writer, obj *schema.ObjectType) str
 		fmt.Fprintf(w, "            raise TypeError(\"Expected argument '%s' to be a %s\")\n", pname, ptype)
 
 		if prop.DeprecationMessage != "" {
-			escaped := strings.ReplaceAll(prop.DeprecationMessage, `"`, `\"`)
 			fmt.Fprintf(w, "        if %s is not None:\n", pname)
-			fmt.Fprintf(w, "            warnings.warn(\"%s\", DeprecationWarning)\n", escaped)
-			fmt.Fprintf(w, "            pulumi.log.warn(\"%s is deprecated: %s\")\n\n", pname, escaped)
+			genDeprecationWarnings(w, "            ", pname, prop.DeprecationMessage)
+			fmt.Fprintf(w, "\n")
 		}
 
 		// Now perform the assignment, and follow it with a """ doc comment if there was one found.
 		fmt.Fprintf(w, "        __self__.%[1]s = %[1]s\n", pname)
-		printComment(w, prop.Comment, "        ")
+		printComment(w, codegen.ConvertDocs(codegen.PropertyComment(prop), codegen.ReSTDocFormat), "        ")
 	}
 
 	awaitableName := "Awaitable" + baseName
@@ -414,11 +531,6 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 		baseType = "pulumi.ProviderResource"
 	}
 
-	if !res.IsProvider && res.DeprecationMessage != "" && mod.compatibility != kubernetes20 {
-		escaped := strings.ReplaceAll(res.DeprecationMessage, `"`, `\"`)
-		fmt.Fprintf(w, "warnings.warn(\"%s\", DeprecationWarning)\n\n", escaped)
-	}
-
 	name := pyClassName(tokenToName(res.Token))
 	if res.IsProvider {
 		name = "Provider"
@@ -431,8 +543,7 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 		name := PyName(prop.Name)
 		ty := pyType(prop.Type)
 		fmt.Fprintf(w, "    %s: pulumi.Output[%s]\n", name, ty)
-		if prop.Comment != "" {
-			doc := prop.Comment
+		if doc := codegen.ConvertDocs(codegen.PropertyComment(prop), codegen.ReSTDocFormat); doc != "" {
 
 			// Exclude nested docs in kubernetes provider
 			if mod.compatibility != kubernetes20 {
@@ -451,10 +562,6 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 		}
 	}
 
-	if res.DeprecationMessage != "" && mod.compatibility != kubernetes20 {
-		escaped := strings.ReplaceAll(res.DeprecationMessage, `"`, `\"`)
-		fmt.Fprintf(w, "    warnings.warn(\"%s\", DeprecationWarning)\n\n", escaped)
-	}
 	// Now generate an initializer with arguments for all input properties.
 	fmt.Fprintf(w, "    def __init__(__self__, resource_name, opts=None")
 
@@ -468,7 +575,7 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 	fmt.Fprintf(w, ", __props__=None, __name__=None, __opts__=None):\n")
 	mod.genInitDocstring(w, res)
 	if res.DeprecationMessage != "" && mod.compatibility != kubernetes20 {
-		fmt.Fprintf(w, "        pulumi.log.warn(\"%s is deprecated: %s\")\n", name, res.DeprecationMessage)
+		genDeprecationWarnings(w, "        ", name, res.DeprecationMessage)
 	}
 	fmt.Fprintf(w, "        if __name__ is not None:\n")
 	fmt.Fprintf(w, "            warnings.warn(\"explicit use of __name__ is deprecated\", DeprecationWarning)\n")
@@ -513,10 +620,8 @@ func (mod *modContext) genResource(res *schema.Resource) (string, error) {
 
 		// Check that the property isn't deprecated
 		if prop.DeprecationMessage != "" {
-			escaped := strings.ReplaceAll(prop.DeprecationMessage, `"`, `\"`)
 			fmt.Fprintf(w, "            if %s is not None:\n", pname)
-			fmt.Fprintf(w, "                warnings.warn(\"%s\", DeprecationWarning)\n", escaped)
-			fmt.Fprintf(w, "                pulumi.log.warn(\"%s is deprecated: %s\")\n", pname, escaped)
+			genDeprecationWarnings(w, "                ", pname, prop.DeprecationMessage)
 		}
 
 		// And add it to the dictionary.
@@ -656,17 +761,20 @@ func (mod *modContext) writeAlias(w io.Writer, alias *schema.Alias) {
 	fmt.Fprint(w, ")")
 }
 
+// genDeprecationWarnings emits the statements that warn the caller of a constructor or function that the named
+// resource, function, or argument is deprecated.
+func genDeprecationWarnings(w io.Writer, indent, name, deprecationMessage string) {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(deprecationMessage)
+	fmt.Fprintf(w, "%swarnings.warn(\"%s\", DeprecationWarning)\n", indent, escaped)
+	fmt.Fprintf(w, "%spulumi.log.warn(\"%s is deprecated: %s\")\n", indent, name, escaped)
+}
+
 func (mod *modContext) genFunction(fun *schema.Function) (string, error) {
 	w := &bytes.Buffer{}
 	mod.genHeader(w, true, false)
 
 	name := PyName(tokenToName(fun.Token))
 
-	if fun.DeprecationMessage != "" {
-		escaped := strings.ReplaceAll(fun.DeprecationMessage, `"`, `\"`)
-		fmt.Fprintf(w, "warnings.warn(\"%s\", DeprecationWarning)\n", escaped)
-	}
-
 	// If there is a return type, emit it.
 	retTypeName := ""
 	var rets []*schema.Property
@@ -692,7 +800,7 @@ func (mod *modContext) genFunction(fun *schema.Function) (string, error) {
 	// If this func has documentation, write it at the top of the docstring, otherwise use a generic comment.
 	docs := &bytes.Buffer{}
 	if fun.Comment != "" {
-		fmt.Fprintln(docs, codegen.FilterExamples(fun.Comment, "python"))
+		fmt.Fprintln(docs, codegen.ConvertDocs(codegen.FilterExamples(fun.Comment, "python"), codegen.ReSTDocFormat))
 	} else {
 		fmt.Fprintln(docs, "Use this data source to access information about an existing resource.")
 	}
@@ -708,7 +816,7 @@ func (mod *modContext) genFunction(fun *schema.Function) (string, error) {
 	printComment(w, docs.String(), "    ")
 
 	if fun.DeprecationMessage != "" {
-		fmt.Fprintf(w, "    pulumi.log.warn(\"%s is deprecated: %s\")\n", name, fun.DeprecationMessage)
+		genDeprecationWarnings(w, "    ", name, fun.DeprecationMessage)
 	}
 
 	// Copy the function arguments into a dictionary.
@@ -1027,7 +1135,7 @@ func (mod *modContext) genInitDocstring(w io.Writer, res *schema.Resource) {
 
 	// If this resource has documentation, write it at the top of the docstring, otherwise use a generic comment.
 	if res.Comment != "" {
-		fmt.Fprintln(b, codegen.FilterExamples(res.Comment, "python"))
+		fmt.Fprintln(b, codegen.ConvertDocs(codegen.FilterExamples(res.Comment, "python"), codegen.ReSTDocFormat))
 	} else {
 		fmt.Fprintf(b, "Create a %s resource with the given unique name, props, and options.\n", tokenToName(res.Token))
 	}
@@ -1074,7 +1182,8 @@ func (mod *modContext) genGetDocstring(w io.Writer, res *schema.Resource) {
 }
 
 func (mod *modContext) genPropDocstring(w io.Writer, prop *schema.Property, wrapInput bool) {
-	if prop.Comment == "" {
+	comment := codegen.ConvertDocs(codegen.PropertyComment(prop), codegen.ReSTDocFormat)
+	if comment == "" {
 		return
 	}
 
@@ -1086,7 +1195,7 @@ func (mod *modContext) genPropDocstring(w io.Writer, prop *schema.Property, wrap
 
 	// If this property has some documentation associated with it, we need to split it so that it is indented
 	// in a way that Sphinx can understand.
-	lines := strings.Split(prop.Comment, "\n")
+	lines := strings.Split(comment, "\n")
 	for len(lines) > 0 && lines[len(lines)-1] == "" {
 		lines = lines[:len(lines)-1]
 	}
@@ -1134,8 +1243,9 @@ func (mod *modContext) genNestedStructureBullets(w io.Writer, nested []*nestedVa
 			typ = fmt.Sprintf("pulumi.Input[%s]", typ)
 		}
 
+		comment := codegen.ConvertDocs(codegen.PropertyComment(nes.prop), codegen.ReSTDocFormat)
 		docPrefix := " - "
-		if nes.prop.Comment == "" {
+		if comment == "" {
 			// If there's no doc available, just write a new line.
 			docPrefix = "\n"
 		}
@@ -1143,7 +1253,7 @@ func (mod *modContext) genNestedStructureBullets(w io.Writer, nested []*nestedVa
 
 		// If this property has some documentation associated with it, we need to split it so that it is indented
 		// in a way that Sphinx can understand.
-		lines := strings.Split(nes.prop.Comment, "\n")
+		lines := strings.Split(comment, "\n")
 		for len(lines) > 0 && lines[len(lines)-1] == "" {
 			lines = lines[:len(lines)-1]
 		}
@@ -1201,6 +1311,8 @@ func pyType(typ schema.Type) string {
 		return "list"
 	case *schema.MapType, *schema.ObjectType, *schema.UnionType:
 		return "dict"
+	case *schema.EnumType:
+		return pyType(typ.ElementType)
 	case *schema.TokenType:
 		if typ.UnderlyingType != nil {
 			return pyType(typ.UnderlyingType)
@@ -1210,9 +1322,9 @@ func pyType(typ schema.Type) string {
 		switch typ {
 		case schema.BoolType:
 			return "bool"
-		case schema.IntType, schema.NumberType:
+		case schema.IntType, schema.Int64Type, schema.NumberType:
 			return "float"
-		case schema.StringType:
+		case schema.StringType, schema.BytesType:
 			return "str"
 		case schema.ArchiveType:
 			return "pulumi.Archive"
@@ -1228,6 +1340,9 @@ func isStringType(t schema.Type) bool {
 	for tt, ok := t.(*schema.TokenType); ok; tt, ok = t.(*schema.TokenType) {
 		t = tt.UnderlyingType
 	}
+	if enum, ok := t.(*schema.EnumType); ok {
+		t = enum.ElementType
+	}
 
 	return t == schema.StringType
 }
@@ -1254,9 +1369,9 @@ func getPrimitiveValue(value interface{}) (string, error) {
 			return "True", nil
 		}
 		return "False", nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return strconv.FormatInt(v.Int(), 10), nil
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return strconv.FormatUint(v.Uint(), 10), nil
 	case reflect.Float32, reflect.Float64:
 		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
@@ -1289,7 +1404,7 @@ func getDefaultValue(dv *schema.DefaultValue, t schema.Type) (string, error) {
 		switch t {
 		case schema.BoolType:
 			envFunc = "_utilities.get_env_bool"
-		case schema.IntType:
+		case schema.IntType, schema.Int64Type:
 			envFunc = "_utilities.get_env_int"
 		case schema.NumberType:
 			envFunc = "_utilities.get_env_float"
@@ -1376,6 +1491,13 @@ func generateModuleContextMap(tool string, pkg *schema.Package, info PackageInfo
 		mod.functions = append(mod.functions, f)
 	}
 
+	for _, t := range pkg.Types {
+		if enum, ok := t.(*schema.EnumType); ok {
+			mod := getModFromToken(enum.Token)
+			mod.enums = append(mod.enums, enum)
+		}
+	}
+
 	if _, ok := modules["types"]; ok {
 		return nil, errors.New("this provider has a `types` module which is reserved for input/output types")
 	}
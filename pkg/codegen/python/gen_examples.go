@@ -0,0 +1,50 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// ExampleWorkspace returns a workspace that compiles the Python examples in the given package's schema alongside the
+// SDK that GeneratePackage generates for the package. Python has no separate compilation step, so each example is
+// byte-compiled, which catches syntax errors but not references to missing names.
+func ExampleWorkspace(tool string, pkg *schema.Package) (*codegen.ExampleWorkspace, error) {
+	sdk, err := GeneratePackage(tool, pkg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for p, contents := range sdk {
+		files[path.Join("sdk", p)] = contents
+	}
+
+	workspace := &codegen.ExampleWorkspace{Files: files}
+	for i, example := range codegen.PackageExamples(pkg, "python") {
+		dir := fmt.Sprintf("examples/%03d", i)
+		files[path.Join(dir, "__main__.py")] = []byte(example.Code)
+		workspace.Builds = append(workspace.Builds, codegen.ExampleBuild{
+			Example: example,
+			Dir:     dir,
+			Command: []string{"python3", "-m", "py_compile", "__main__.py"},
+		})
+	}
+	return workspace, nil
+}
@@ -14,7 +14,14 @@
 
 package python
 
+import "github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+
 const (
+	// intrinsicSpread is the name of the spread intrinsic.
+	intrinsicSpread = "__spread"
+	// intrinsicFlatten is the name of the flatten intrinsic.
+	intrinsicFlatten = "__flatten"
+
 // intrinsicDataSource is the name of the data source intrinsic.
 //	intrinsicDataSource = "__dataSource"
 )
@@ -51,3 +58,35 @@ const (
 //	optionsBag = c.Args[2].(*il.BoundLiteral).Value.(string)
 //	return
 //}
+
+// newSpreadCall creates a new call to the spread intrinsic that represents a list whose elements are unpacked into an
+// enclosing list display.
+func newSpreadCall(list model.Expression) *model.FunctionCallExpression {
+	return &model.FunctionCallExpression{
+		Name: intrinsicSpread,
+		Signature: model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "list",
+				Type: list.Type(),
+			}},
+			ReturnType: list.Type(),
+		},
+		Args: []model.Expression{list},
+	}
+}
+
+// newFlattenCall creates a new call to the flatten intrinsic that represents the concatenation of a list of lists of
+// resources.
+func newFlattenCall(lists model.Expression) *model.FunctionCallExpression {
+	return &model.FunctionCallExpression{
+		Name: intrinsicFlatten,
+		Signature: model.StaticFunctionSignature{
+			Parameters: []model.Parameter{{
+				Name: "lists",
+				Type: lists.Type(),
+			}},
+			ReturnType: model.NewListType(model.DynamicType),
+		},
+		Args: []model.Expression{lists},
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -37,9 +38,20 @@ type generator struct {
 	program     *hcl2.Program
 	diagnostics hcl.Diagnostics
 
-	configCreated bool
-	casingTables  map[string]map[string]string
-	quotes        map[model.Expression]string
+	configCreated    bool
+	configNamespaces codegen.StringSet
+	casingTables     map[string]map[string]string
+	quotes           map[model.Expression]string
+
+	// The component whose constructor is being generated, if any.
+	component *hcl2.Component
+	// The names of the loop variables that hold the key and value of each instance of the ranged resource that is
+	// being generated, if any.
+	rangeKey, rangeValue string
+	// The writer that records the source map for the generated program, if any.
+	sourceMap *codegen.SourceMapWriter
+	// The report that records the fidelity of the conversion, if any.
+	report *codegen.ConversionReport
 }
 
 type objectTypeInfo struct {
@@ -48,6 +60,14 @@ type objectTypeInfo struct {
 }
 
 func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
+	return GenerateProgramWithOptions(program, codegen.GenerateProgramOptions{})
+}
+
+// GenerateProgramWithOptions generates a Python program for the given PCL program. The options control the
+// generator's optional outputs, e.g. a source map for the generated program.
+func GenerateProgramWithOptions(program *hcl2.Program,
+	options codegen.GenerateProgramOptions) (map[string][]byte, hcl.Diagnostics, error) {
+
 	g, err := newGenerator(program)
 	if err != nil {
 		return nil, nil, err
@@ -56,15 +76,36 @@ func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics,
 	// Linearize the nodes into an order appropriate for procedural code generation.
 	nodes := hcl2.Linearize(program)
 
+	g.report = options.Report
+	program.Report(g.report)
+
 	var main bytes.Buffer
-	g.genPreamble(&main, program)
+	var w io.Writer = &main
+	if options.SourceMap {
+		g.sourceMap = codegen.NewSourceMapWriter(&main, "__main__.py")
+		w = g.sourceMap
+	}
+
+	g.genPreamble(w, program)
+	for _, n := range nodes {
+		if c, ok := n.(*hcl2.Component); ok {
+			g.sourceMap.Map(c.SyntaxNode().Range(), func() { g.genComponent(w, c) })
+		}
+	}
 	for _, n := range nodes {
-		g.genNode(&main, n)
+		g.genNode(w, n)
 	}
 
 	files := map[string][]byte{
 		"__main__.py": main.Bytes(),
 	}
+	if g.sourceMap != nil {
+		sourceMap, err := g.sourceMap.SourceMap().JSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		files["__main__.py.map"] = sourceMap
+	}
 	return files, g.diagnostics, nil
 }
 
@@ -97,9 +138,10 @@ func newGenerator(program *hcl2.Program) (*generator, error) {
 	}
 
 	g := &generator{
-		program:      program,
-		casingTables: casingTables,
-		quotes:       map[model.Expression]string{},
+		program:          program,
+		configNamespaces: codegen.NewStringSet(),
+		casingTables:     casingTables,
+		quotes:           map[model.Expression]string{},
 	}
 	g.Formatter = format.NewFormatter(g)
 
@@ -145,14 +187,17 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 
 	// Accumulate other imports for the various providers. Don't emit them yet, as we need to sort them later on.
 	importSet := codegen.NewStringSet("pulumi")
-	for _, n := range program.Nodes {
-		if r, isResource := n.(*hcl2.Resource); isResource {
-			pkg, _, _, _ := r.DecomposeToken()
+	for _, n := range hcl2.ProgramNodes(program) {
+		if r, isResource := hcl2.NodeResource(n); isResource && r.Component == nil {
+			pkg, mod, name, _ := r.DecomposeToken()
+			if pkg == "pulumi" && mod == "providers" {
+				pkg = name
+			}
 			importSet.Add("pulumi_" + makeValidIdentifier(pkg))
 		}
 		diags := n.VisitExpressions(nil, func(n model.Expression) (model.Expression, hcl.Diagnostics) {
 			if call, ok := n.(*model.FunctionCallExpression); ok {
-				if i := g.getFunctionImports(call); i != "" {
+				for _, i := range g.getFunctionImports(call) {
 					importSet.Add(i)
 				}
 			}
@@ -182,22 +227,29 @@ func (g *generator) genPreamble(w io.Writer, program *hcl2.Program) {
 }
 
 func (g *generator) genNode(w io.Writer, n hcl2.Node) {
-	switch n := n.(type) {
-	case *hcl2.Resource:
-		g.genResource(w, n)
-	case *hcl2.ConfigVariable:
-		g.genConfigVariable(w, n)
-	case *hcl2.LocalVariable:
-		g.genLocalVariable(w, n)
-	case *hcl2.OutputVariable:
-		g.genOutputVariable(w, n)
-	}
+	g.sourceMap.Map(n.SyntaxNode().Range(), func() {
+		switch n := n.(type) {
+		case *hcl2.Resource:
+			g.genResource(w, n)
+		case *hcl2.Provider:
+			g.genResource(w, n.Resource)
+		case *hcl2.ConfigVariable:
+			g.genConfigVariable(w, n)
+		case *hcl2.LocalVariable:
+			g.genLocalVariable(w, n)
+		case *hcl2.OutputVariable:
+			g.genOutputVariable(w, n)
+		}
+	})
 }
 
 // resourceTypeName computes the python package, module, and type name for the given resource.
 func resourceTypeName(r *hcl2.Resource) (string, string, string, hcl.Diagnostics) {
 	// Compute the resource type from the Pulumi type token.
 	pkg, module, member, diagnostics := r.DecomposeToken()
+	if pkg == "pulumi" && module == "providers" {
+		pkg, module, member = member, "", "Provider"
+	}
 
 	components := strings.Split(module, ".")
 	for i, component := range components {
@@ -210,6 +262,13 @@ func resourceTypeName(r *hcl2.Resource) (string, string, string, hcl.Diagnostics
 // makeResourceName returns the expression that should be emitted for a resource's "name" parameter given its base name
 // and the count variable name, if any.
 func (g *generator) makeResourceName(baseName, count string) string {
+	// The names of the resources inside of a component are prefixed with the name of the component instance.
+	if g.component != nil {
+		baseName = "{name}-" + baseName
+		if count == "" {
+			return fmt.Sprintf(`f"%s"`, baseName)
+		}
+	}
 	if count == "" {
 		return fmt.Sprintf(`"%s"`, baseName)
 	}
@@ -218,7 +277,10 @@ func (g *generator) makeResourceName(baseName, count string) string {
 
 func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Block, []*quoteTemp) {
 	if opts == nil {
-		return nil, nil
+		if g.component == nil {
+			return nil, nil
+		}
+		opts = &hcl2.ResourceOptions{}
 	}
 
 	var block *model.Block
@@ -243,12 +305,19 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 
 	if opts.Parent != nil {
 		appendOption("parent", opts.Parent)
+	} else if g.component != nil {
+		// Resources inside of a component are children of the component unless they specify another parent.
+		appendOption("parent", model.VariableReference(selfVariable))
 	}
 	if opts.Provider != nil {
 		appendOption("provider", opts.Provider)
 	}
 	if opts.DependsOn != nil {
-		appendOption("depends_on", opts.DependsOn)
+		if hcl2.IsStaticResourceList(opts.DependsOn) {
+			appendOption("depends_on", opts.DependsOn)
+		} else {
+			appendOption("depends_on", flattenResourceList(opts.DependsOn))
+		}
 	}
 	if opts.Protect != nil {
 		appendOption("protect", opts.Protect)
@@ -256,6 +325,18 @@ func (g *generator) lowerResourceOptions(opts *hcl2.ResourceOptions) (*model.Blo
 	if opts.IgnoreChanges != nil {
 		appendOption("ignore_changes", opts.IgnoreChanges)
 	}
+	if opts.Aliases != nil {
+		appendOption("aliases", opts.Aliases)
+	}
+	if opts.CustomTimeouts != nil {
+		appendOption("custom_timeouts", opts.CustomTimeouts)
+	}
+	if opts.DeleteBeforeReplace != nil {
+		appendOption("delete_before_replace", opts.DeleteBeforeReplace)
+	}
+	if opts.Version != nil {
+		appendOption("version", opts.Version)
+	}
 
 	return block, temps
 }
@@ -276,20 +357,100 @@ func (g *generator) genResourceOptions(w io.Writer, block *model.Block, hasInput
 				g.Fprintf(w, ",\n%s", g.Indent)
 			}
 			attr := item.(*model.Attribute)
-			g.Fgenf(w, "%s=%v", attr.Name, attr.Value)
+			switch attr.Name {
+			case "aliases":
+				g.Fprint(w, "aliases=")
+				g.genAliases(w, attr.Value)
+			case "custom_timeouts":
+				g.Fprint(w, "custom_timeouts=")
+				g.genKeywordCall(w, "pulumi.CustomTimeouts", attr.Value)
+			default:
+				g.Fgenf(w, "%s=%v", attr.Name, attr.Value)
+			}
 		}
 	})
 	g.Fprint(w, ")")
 }
 
+// genAliases generates the value of a resource's aliases option. Alias objects are generated as calls to
+// pulumi.Alias; URNs are generated as-is.
+func (g *generator) genAliases(w io.Writer, aliases model.Expression) {
+	tuple, ok := aliases.(*model.TupleConsExpression)
+	if !ok {
+		g.Fgenf(w, "%v", aliases)
+		return
+	}
+
+	genAlias := func(alias model.Expression) {
+		if _, isObject := alias.(*model.ObjectConsExpression); isObject {
+			g.genKeywordCall(w, "pulumi.Alias", alias)
+		} else {
+			g.Fgenf(w, "%.v", alias)
+		}
+	}
+
+	switch len(tuple.Expressions) {
+	case 0:
+		g.Fgen(w, "[]")
+	case 1:
+		g.Fgen(w, "[")
+		genAlias(tuple.Expressions[0])
+		g.Fgen(w, "]")
+	default:
+		g.Fgen(w, "[")
+		g.Indented(func() {
+			for _, alias := range tuple.Expressions {
+				g.Fgenf(w, "\n%s", g.Indent)
+				genAlias(alias)
+				g.Fgen(w, ",")
+			}
+		})
+		g.Fgen(w, "\n", g.Indent, "]")
+	}
+}
+
+// genKeywordCall generates an object literal as a call to the given constructor that passes each of the object's
+// properties as a keyword argument.
+func (g *generator) genKeywordCall(w io.Writer, constructor string, expr model.Expression) {
+	obj, ok := expr.(*model.ObjectConsExpression)
+	if !ok {
+		g.Fgenf(w, "%v", expr)
+		return
+	}
+
+	g.Fgenf(w, "%s(", constructor)
+	for i, item := range obj.Items {
+		if i > 0 {
+			g.Fgen(w, ", ")
+		}
+		key := item.Key.(*model.LiteralValueExpression).Value.AsString()
+		if key == "type" {
+			// The Python SDK spells this argument "type_" in order to avoid shadowing the builtin.
+			key = "type_"
+		}
+		g.Fgenf(w, "%s=%.v", key, item.Value)
+	}
+	g.Fgen(w, ")")
+}
+
 // genResource handles the generation of instantiations of non-builtin resources.
 func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
-	pkg, module, memberName, diagnostics := resourceTypeName(r)
-	g.diagnostics = append(g.diagnostics, diagnostics...)
-	if module != "" {
-		module = "." + module
+	var pkg, qualifiedMemberName string
+	if r.Component != nil {
+		qualifiedMemberName = componentClassName(r.Component)
+	} else {
+		p, module, memberName, diagnostics := resourceTypeName(r)
+		g.diagnostics = append(g.diagnostics, diagnostics...)
+		if module != "" {
+			module = "." + module
+		}
+		pkg, qualifiedMemberName = p, fmt.Sprintf("%s%s.%s", p, module, memberName)
+	}
+
+	if r.Options != nil && r.Options.Range != nil {
+		g.rangeKey, g.rangeValue = g.rangeVariableNames(r)
+		defer func() { g.rangeKey, g.rangeValue = "", "" }()
 	}
-	qualifiedMemberName := fmt.Sprintf("%s%s.%s", pkg, module, memberName)
 
 	optionsBag, temps := g.lowerResourceOptions(r.Options)
 
@@ -303,6 +464,10 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 
 	casingTable := g.casingTables[pkg]
 	for _, attr := range r.Inputs {
+		destType, diagnostics := r.InputType.Traverse(hcl.TraverseAttr{Name: attr.Name})
+		g.diagnostics = append(g.diagnostics, diagnostics...)
+		attr.Value = hcl2.RewriteSecrets(attr.Value, destType.(model.Type))
+
 		g.lowerObjectKeys(attr.Value, casingTable)
 
 		value, valueTemps := g.lowerExpression(attr.Value)
@@ -342,21 +507,33 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 				g.Fprint(w, "\n")
 			})
 		} else {
-			g.Fgenf(w, "%s%s = []\n", g.Indent, name)
-
-			resKey := "key"
-			if model.InputType(model.NumberType).ConversionFrom(rangeExpr.Type()) != model.NoConversion {
-				g.Fgenf(w, "%sfor range in [{\"value\": i} for i in range(0, %.v)]:\n", g.Indent, rangeExpr)
-				resKey = "value"
-			} else {
-				g.Fgenf(w, "%sfor range in [{\"key\": k, \"value\": v} for [k, v] in enumerate(%.v)]:\n", g.Indent, rangeExpr)
+			// The key and value of each instance are bound to the variables named by rangeVariableNames. References to
+			// the range variable are rewritten to refer to these variables by lowerRangeReferences.
+			resKey := g.rangeKey
+			switch {
+			case r.HasMapRange():
+				g.Fgenf(w, "%s%s = {}\n", g.Indent, name)
+				g.Fgenf(w, "%sfor %s, %s in %.16v.items():\n", g.Indent, g.rangeKey, g.rangeValue, rangeExpr)
+			case model.InputType(model.NumberType).ConversionFrom(rangeExpr.Type()) != model.NoConversion:
+				g.Fgenf(w, "%s%s = []\n", g.Indent, name)
+				g.Fgenf(w, "%sfor %s in range(0, %.v):\n", g.Indent, g.rangeValue, rangeExpr)
+				resKey = g.rangeValue
+			default:
+				g.Fgenf(w, "%s%s = []\n", g.Indent, name)
+				g.Fgenf(w, "%sfor %s, %s in enumerate(%.v):\n", g.Indent, g.rangeKey, g.rangeValue, rangeExpr)
 			}
 
-			resName := g.makeResourceName(r.Name(), fmt.Sprintf("range['%s']", resKey))
+			resName := g.makeResourceName(r.Name(), resKey)
 			g.Indented(func() {
-				g.Fgenf(w, "%s%s.append(", g.Indent, name)
-				instantiate(resName)
-				g.Fprint(w, ")\n")
+				if r.HasMapRange() {
+					g.Fgenf(w, "%s%s[%s] = ", g.Indent, name, g.rangeKey)
+					instantiate(resName)
+					g.Fprint(w, "\n")
+				} else {
+					g.Fgenf(w, "%s%s.append(", g.Indent, name)
+					instantiate(resName)
+					g.Fprint(w, ")\n")
+				}
 			})
 		}
 	} else {
@@ -368,6 +545,32 @@ func (g *generator) genResource(w io.Writer, r *hcl2.Resource) {
 	g.genTrivia(w, r.Definition.Tokens.GetCloseBrace())
 }
 
+// rangeVariableNames returns the names of the loop variables that hold the key and value of each instance of the given
+// ranged resource, e.g. `subnet_key` and `subnet_value`. Python's loop variables are not scoped to the loop, so the
+// names must not be the name of anything else that the program declares.
+func (g *generator) rangeVariableNames(r *hcl2.Resource) (string, string) {
+	declared := codegen.NewStringSet()
+	for _, n := range g.program.Nodes {
+		if v, ok := n.(*hcl2.ConfigVariable); ok {
+			declared.Add(PyName(v.VariableName(g.program)))
+		} else {
+			declared.Add(PyName(n.Name()))
+		}
+	}
+
+	base := PyName(r.Name())
+	for i := 1; ; i++ {
+		suffix := ""
+		if i > 1 {
+			suffix = strconv.Itoa(i)
+		}
+		key, value := base+"_key"+suffix, base+"_value"+suffix
+		if !declared.Has(key) && !declared.Has(value) {
+			return key, value
+		}
+	}
+}
+
 func (g *generator) genTemps(w io.Writer, temps []*quoteTemp) {
 	for _, t := range temps {
 		// TODO(pdg): trivia
@@ -378,11 +581,24 @@ func (g *generator) genTemps(w io.Writer, temps []*quoteTemp) {
 func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
 	// TODO(pdg): trivia
 
-	if !g.configCreated {
+	configObject, key := "config", v.Name()
+	if namespace := v.Namespace(); namespace != "" {
+		configObject, key = PyName(namespace+"Config"), v.Key()
+		if !g.configNamespaces.Has(namespace) {
+			g.Fprintf(w, "%s%s = pulumi.Config(\"%s\")\n", g.Indent, configObject, namespace)
+			g.configNamespaces.Add(namespace)
+		}
+	} else if !g.configCreated {
 		g.Fprintf(w, "%sconfig = pulumi.Config()\n", g.Indent)
 		g.configCreated = true
 	}
 
+	if v.ProviderConfig != nil {
+		if description := hcl2.DescribeDefaultValue(v.ProviderConfig); description != "" {
+			g.Fgenf(w, "%s# %s %s.\n", g.Indent, v.Name(), description)
+		}
+	}
+
 	getType := "_object"
 	switch v.Type() {
 	case model.StringType:
@@ -407,8 +623,8 @@ func (g *generator) genConfigVariable(w io.Writer, v *hcl2.ConfigVariable) {
 	}
 	g.genTemps(w, temps)
 
-	name := PyName(v.Name())
-	g.Fgenf(w, "%s%s = config.%s%s(\"%s\")\n", g.Indent, name, getOrRequire, getType, v.Name())
+	name := PyName(v.VariableName(g.program))
+	g.Fgenf(w, "%s%s = %s.%s%s(\"%s\")\n", g.Indent, name, configObject, getOrRequire, getType, key)
 	if defaultValue != nil {
 		g.Fgenf(w, "%sif %s is None:\n", g.Indent, name)
 		g.Indented(func() {
@@ -433,12 +649,11 @@ func (g *generator) genOutputVariable(w io.Writer, v *hcl2.OutputVariable) {
 	g.Fgenf(w, "%spulumi.export(\"%s\", %.v)\n", g.Indent, v.Name(), value)
 }
 
-func (g *generator) genNYI(w io.Writer, reason string, vs ...interface{}) {
-	message := fmt.Sprintf("not yet implemented: %s", fmt.Sprintf(reason, vs...))
-	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
-		Severity: hcl.DiagError,
-		Summary:  message,
-		Detail:   message,
-	})
-	g.Fgenf(w, "(lambda: raise Exception(%q))()", fmt.Sprintf(reason, vs...))
+// genNYI emits None for an untranslatable expression. Python has no inline comments, so the TODO comment and the
+// placeholder are wrapped in parentheses, which permits a line break in the middle of an expression.
+func (g *generator) genNYI(w io.Writer, expr model.Expression, reason string, vs ...interface{}) {
+	construct := g.program.NewUnsupportedConstruct(expr, reason, vs...)
+	g.diagnostics = append(g.diagnostics, construct.Diagnostic())
+	construct.Report(g.report)
+	g.Fgenf(w, "(None  # %s\n%s)", construct.Comment(), g.Indent)
 }
@@ -0,0 +1,88 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"io"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+)
+
+// selfVariable is the variable that refers to the component whose constructor is being generated. Resources inside of
+// a component use it as their default parent.
+var selfVariable = &model.Variable{
+	Name:         "self",
+	VariableType: model.DynamicType,
+}
+
+// componentClassName returns the name of the class generated for the given component.
+func componentClassName(c *hcl2.Component) string {
+	return title(makeValidIdentifier(c.Name()))
+}
+
+// genComponent generates a ComponentResource class for a component. Each of the component's inputs is a parameter of
+// the class's constructor. The resources inside of the component are children of the component, and their names are
+// prefixed with the name of the component instance.
+func (g *generator) genComponent(w io.Writer, c *hcl2.Component) {
+	className := componentClassName(c)
+
+	g.Fgenf(w, "class %s(pulumi.ComponentResource):\n", className)
+	g.Indented(func() {
+		g.Fgenf(w, "%sdef __init__(self, name", g.Indent)
+		for _, input := range c.Inputs {
+			g.Fgenf(w, ", %s", PyName(input.Name))
+		}
+		g.Fgenf(w, ", opts=None):\n")
+
+		g.Indented(func() {
+			g.Fgenf(w, "%ssuper().__init__(\"components:index:%s\", name, None, opts)\n\n", g.Indent, className)
+
+			for _, input := range c.Inputs {
+				name := PyName(input.Name)
+				g.Fgenf(w, "%s%s = pulumi.Output.from_input(%s)\n", g.Indent, name, name)
+			}
+
+			g.component = c
+			for _, n := range hcl2.LinearizeComponent(c) {
+				g.genNode(w, n)
+			}
+			g.component = nil
+
+			for _, output := range c.Outputs {
+				value, temps := g.lowerExpression(output.Value)
+				g.genTemps(w, temps)
+				if _, isOutput := value.Type().(*model.OutputType); isOutput {
+					g.Fgenf(w, "%sself.%s = %.v\n", g.Indent, PyName(output.Name), value)
+				} else {
+					g.Fgenf(w, "%sself.%s = pulumi.Output.from_input(%.v)\n", g.Indent, PyName(output.Name), value)
+				}
+			}
+
+			if len(c.Outputs) == 0 {
+				g.Fgenf(w, "%sself.register_outputs({})\n", g.Indent)
+				return
+			}
+			g.Fgenf(w, "%sself.register_outputs({\n", g.Indent)
+			g.Indented(func() {
+				for _, output := range c.Outputs {
+					g.Fgenf(w, "%s\"%s\": self.%s,\n", g.Indent, output.Name, PyName(output.Name))
+				}
+			})
+			g.Fgenf(w, "%s})\n", g.Indent)
+		})
+	})
+	g.Fgenf(w, "\n\n")
+}
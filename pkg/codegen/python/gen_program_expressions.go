@@ -26,6 +26,7 @@ func (nameInfo) Format(name string) string {
 func (g *generator) lowerExpression(expr model.Expression) (model.Expression, []*quoteTemp) {
 	// TODO(pdg): diagnostics
 
+	expr = lowerRangeReferences(expr, g.rangeKey, g.rangeValue)
 	expr = hcl2.RewritePropertyReferences(expr)
 	expr, _ = hcl2.RewriteApplies(expr, nameInfo(0), false)
 	expr, _ = g.lowerProxyApplies(expr)
@@ -139,7 +140,13 @@ func (g *generator) GenForExpression(w io.Writer, expr *model.ForExpression) {
 	if expr.KeyVariable == nil {
 		g.Fgenf(w, " for %v in %.v", expr.ValueVariable.Name, expr.Collection)
 	} else {
-		g.Fgenf(w, " for %v, %v in %.v", expr.KeyVariable.Name, expr.ValueVariable.Name, expr.Collection)
+		g.Fgenf(w, " for %v, %v in ", expr.KeyVariable.Name, expr.ValueVariable.Name)
+		switch expr.Collection.Type().(type) {
+		case *model.ListType, *model.TupleType:
+			g.Fgenf(w, "enumerate(%.v)", expr.Collection)
+		default:
+			g.Fgenf(w, "%.16v.items()", expr.Collection)
+		}
 	}
 
 	if expr.Condition != nil {
@@ -186,20 +193,33 @@ var functionImports = map[string]string{
 	"toJSON":      "json",
 }
 
-func (g *generator) getFunctionImports(x *model.FunctionCallExpression) string {
+func (g *generator) getFunctionImports(x *model.FunctionCallExpression) []string {
+	if x.Name == "toJSON" && model.ContainsOutputs(x.Signature.ReturnType) {
+		return []string{"pulumi"}
+	}
+	if emitter, ok := g.program.IntrinsicEmitter(x.Name, "python"); ok {
+		return emitter.Imports
+	}
 	if x.Name != hcl2.Invoke {
-		return functionImports[x.Name]
+		if i, ok := functionImports[x.Name]; ok {
+			return []string{i}
+		}
+		return nil
 	}
 
 	pkg, _, _, diags := functionName(x.Args[0])
 	contract.Assert(len(diags) == 0)
-	return "pulumi_" + pkg
+	return []string{"pulumi_" + pkg}
 }
 
 func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionCallExpression) {
 	switch expr.Name {
 	case hcl2.IntrinsicApply:
 		g.genApply(w, expr)
+	case intrinsicSpread:
+		g.Fgenf(w, "*%.7v", expr.Args[0])
+	case intrinsicFlatten:
+		g.Fgenf(w, "[r for rs in %.v for r in rs]", expr.Args[0])
 	case "element":
 		g.Fgenf(w, "%.16v[%.v]", expr.Args[0], expr.Args[1])
 	case "entries":
@@ -279,13 +299,17 @@ func (g *generator) GenFunctionCallExpression(w io.Writer, expr *model.FunctionC
 	case "split":
 		g.Fgenf(w, "%.16v.split(%.v)", expr.Args[1], expr.Args[0])
 	case "toJSON":
-		g.Fgenf(w, "json.dumps(%.v)", expr.Args[0])
+		if model.ContainsOutputs(expr.Signature.ReturnType) {
+			g.Fgenf(w, "pulumi.Output.json_dumps(%.v)", expr.Args[0])
+		} else {
+			g.Fgenf(w, "json.dumps(%.v)", expr.Args[0])
+		}
 	default:
-		var rng hcl.Range
-		if expr.Syntax != nil {
-			rng = expr.Syntax.Range()
+		if emitter, ok := g.program.IntrinsicEmitter(expr.Name, "python"); ok {
+			emitter.Emit(g.Formatter, w, expr)
+			return
 		}
-		g.genNYI(w, "FunctionCallExpression: %v (%v)", expr.Name, rng)
+		g.genNYI(w, expr, "function %v is not supported", expr.Name)
 	}
 }
 
@@ -445,7 +469,7 @@ func (g *generator) GenTemplateExpression(w io.Writer, expr *model.TemplateExpre
 }
 
 func (g *generator) GenTemplateJoinExpression(w io.Writer, expr *model.TemplateJoinExpression) {
-	g.genNYI(w, "TemplateJoinExpression")
+	g.genNYI(w, expr, "template join expressions are not supported")
 }
 
 func (g *generator) GenTupleConsExpression(w io.Writer, expr *model.TupleConsExpression) {
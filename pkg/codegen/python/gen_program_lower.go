@@ -122,3 +122,45 @@ func (g *generator) lowerObjectKeys(expr model.Expression, camelCaseToSnakeCase
 		}
 	}
 }
+
+// lowerRangeReferences rewrites references to the key or value of the range variable of a ranged resource, e.g.
+// `range.value` or `each.key`, into references to the given key and value variables that are bound by the loop that
+// instantiates the resource.
+func lowerRangeReferences(expr model.Expression, key, value string) model.Expression {
+	rewriter := func(expr model.Expression) (model.Expression, hcl.Diagnostics) {
+		traversal, ok := expr.(*model.ScopeTraversalExpression)
+		if !ok || len(traversal.Parts) < 2 {
+			return expr, nil
+		}
+		if v, ok := traversal.Parts[0].(*model.Variable); !ok || v.Name != "range" {
+			return expr, nil
+		}
+		attr, ok := traversal.Traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			return expr, nil
+		}
+
+		var name string
+		switch attr.Name {
+		case "key":
+			name = key
+		case "value":
+			name = value
+		default:
+			return expr, nil
+		}
+
+		element := &model.Variable{
+			Name:         name,
+			VariableType: model.GetTraversableType(traversal.Parts[1]),
+		}
+		return &model.ScopeTraversalExpression{
+			RootName:  element.Name,
+			Traversal: hcl.TraversalJoin(hcl.Traversal{hcl.TraverseRoot{Name: element.Name}}, traversal.Traversal[2:]),
+			Parts:     append([]model.Traversable{element}, traversal.Parts[2:]...),
+		}, nil
+	}
+	expr, diags := model.VisitExpression(expr, model.IdentityVisitor, rewriter)
+	contract.Assert(len(diags) == 0)
+	return expr
+}
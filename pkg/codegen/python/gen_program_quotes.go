@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen"
-	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
 	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
@@ -53,9 +52,8 @@ func (g *generator) rewriteTraversal(traversal hcl.Traversal, source model.Expre
 		keyVal, objectKey := key.AsString(), false
 
 		receiver := parts[i]
-		if schemaType, ok := hcl2.GetSchemaForType(model.GetTraversableType(receiver)); ok {
-			obj := schemaType.(*schema.ObjectType)
-
+		schemaType, _ := g.program.GetSchemaForType(model.GetTraversableType(receiver))
+		if obj, ok := schemaType.(*schema.ObjectType); ok {
 			info, ok := obj.Language["python"].(objectTypeInfo)
 			if ok {
 				objectKey = !info.isDictionary
@@ -112,6 +110,19 @@ func (g *generator) rewriteTraversal(traversal hcl.Traversal, source model.Expre
 		}
 		checkDiags := currentExpression.Typecheck(false)
 		diagnostics = append(diagnostics, checkDiags...)
+
+		currentParts = []model.Traversable{currentExpression.Type()}
+	}
+
+	// Any attributes that follow the last index, e.g. the `arn` in `buckets["site"].arn`, apply to the index.
+	if currentExpression != source && len(currentTraversal) > 0 {
+		currentExpression = &model.RelativeTraversalExpression{
+			Source:    currentExpression,
+			Traversal: currentTraversal,
+			Parts:     currentParts,
+		}
+		checkDiags := currentExpression.Typecheck(false)
+		diagnostics = append(diagnostics, checkDiags...)
 	}
 
 	if currentExpression == source {
@@ -45,10 +45,11 @@ resource rta "aws:ec2:RouteTableAssociation" {
 	prop, ok := rta.Definition.Body.Attribute("subnetId")
 	assert.True(t, ok)
 
+	g.rangeKey, g.rangeValue = g.rangeVariableNames(rta)
 	x, temps := g.lowerExpression(prop.Value)
 	assert.Len(t, temps, 0)
 
 	x.SetLeadingTrivia(nil)
 	x.SetTrailingTrivia(nil)
-	assert.Equal(t, "vpcSubnet[range[key]].id", fmt.Sprintf("%v", x))
+	assert.Equal(t, "vpcSubnet[rta_key].id", fmt.Sprintf("%v", x))
 }
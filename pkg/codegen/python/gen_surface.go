@@ -0,0 +1,107 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
+
+// SDKSurface returns the exported symbols of the Python SDK that GeneratePackage generates for the given package, named
+// by their Python paths. The Python SDK represents object types as dictionaries, so the surface describes the
+// properties of resources and the arguments and results of functions, but not the keys of those dictionaries.
+func SDKSurface(tool string, pkg *schema.Package) (codegen.Surface, error) {
+	if err := pkg.ImportLanguages(map[string]schema.Language{"python": Importer}); err != nil {
+		return nil, err
+	}
+	info, _ := pkg.Language["python"].(PackageInfo)
+
+	modules, err := generateModuleContextMap(tool, pkg, info, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	surface := codegen.Surface{}
+	for modName, mod := range modules {
+		module := pyPack(pkg.Name)
+		if modName != "" {
+			module += "." + strings.Replace(modName, "/", ".", -1)
+		}
+		qualify := func(name string) string {
+			return module + "." + name
+		}
+		addParameters := func(parent string, props []*schema.Property) {
+			for _, p := range props {
+				surface.Add(parent+"("+PyName(p.Name)+")", codegen.SurfaceSymbol{
+					Module:   module,
+					Parent:   parent,
+					Argument: true,
+					Optional: !p.IsRequired,
+				})
+			}
+		}
+
+		for _, r := range mod.resources {
+			name := pyClassName(tokenToName(r.Token))
+			if r.IsProvider {
+				name = "Provider"
+			}
+
+			res := surface.Add(qualify(name), codegen.SurfaceSymbol{Module: module, Token: r.Token, Signature: "class"})
+			for _, p := range r.Properties {
+				surface.Add(res+"."+PyName(p.Name), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    res,
+					Signature: "pulumi.Output[" + pyType(p.Type) + "]",
+				})
+			}
+			addParameters(res, r.InputProperties)
+		}
+
+		for _, f := range mod.functions {
+			fn := surface.Add(qualify(PyName(tokenToName(f.Token))), codegen.SurfaceSymbol{
+				Module:    module,
+				Token:     f.Token,
+				Signature: "def",
+			})
+			if f.Inputs != nil {
+				addParameters(fn, f.Inputs.Properties)
+			}
+			if f.Outputs != nil {
+				result := surface.Add(qualify(pyClassName(tokenToName(f.Outputs.Token))), codegen.SurfaceSymbol{
+					Module:    module,
+					Parent:    fn,
+					Signature: "class",
+				})
+				for _, p := range f.Outputs.Properties {
+					surface.Add(result+"."+PyName(p.Name), codegen.SurfaceSymbol{
+						Module:    module,
+						Parent:    result,
+						Signature: pyType(p.Type),
+					})
+				}
+			}
+		}
+
+		if modName == "config" {
+			for _, p := range pkg.Config {
+				surface.Add(qualify(PyName(p.Name)), codegen.SurfaceSymbol{Module: module, Signature: pyType(p.Type)})
+			}
+		}
+	}
+	return surface, nil
+}
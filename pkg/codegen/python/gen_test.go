@@ -1,6 +1,13 @@
 package python
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+)
 
 var pathTests = []struct {
 	input    string
@@ -26,3 +33,40 @@ func TestRelPathToRelImport(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateEnums(t *testing.T) {
+	var spec schema.PackageSpec
+	err := json.Unmarshal([]byte(`{
+		"name": "example",
+		"language": {"python": {}},
+		"types": {
+			"example:index:Size": {
+				"type": "integer",
+				"description": "The size of a widget.",
+				"enum": [
+					{"name": "Small", "value": 1, "description": "A small widget."},
+					{"name": "Large", "value": 2, "deprecationMessage": "Use Small instead."}
+				]
+			},
+			"example:index:Region": {
+				"type": "string",
+				"enum": [{"value": "us-east-1"}, {"value": "eu-west-2"}]
+			}
+		}
+	}`), &spec)
+	assert.NoError(t, err)
+	pkg, err := schema.ImportSpec(spec, nil)
+	assert.NoError(t, err)
+
+	files, err := GeneratePackage("test", pkg, nil)
+	assert.NoError(t, err)
+	enums := string(files["pulumi_example/_enums.py"])
+	assert.Contains(t, enums, "__all__ = [\n    'Region',\n    'Size',\n]\n")
+	assert.Contains(t, enums, "class Size(int, Enum):\n")
+	assert.Contains(t, enums, "    LARGE = 2\n    \"\"\"\n    Deprecated: Use Small instead.\n")
+	assert.Contains(t, enums, "    def values(cls) -> List['Size']:\n")
+	assert.Contains(t, enums, "    def parse(cls, s: str) -> 'Size':\n")
+	assert.Contains(t, enums, "    def is_valid(cls, value: Any) -> bool:\n")
+	assert.Contains(t, enums, "class Region(str, Enum):\n    US_EAST1 = 'us-east-1'\n")
+	assert.Contains(t, string(files["pulumi_example/__init__.py"]), "from ._enums import *\n")
+}
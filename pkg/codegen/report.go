@@ -0,0 +1,102 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// ReportItem describes a single entry in a conversion report.
+type ReportItem struct {
+	// Summary is a short description of the entry.
+	Summary string `json:"summary"`
+	// Location is the range of source text the entry refers to, if any.
+	Location *SourceRange `json:"location,omitempty"`
+}
+
+// ConversionReport summarizes the fidelity of a program conversion so that conversion completeness can be tracked over
+// time. A program generator fills in the report passed in its GenerateProgramOptions.
+type ConversionReport struct {
+	// ResourceCount is the number of resources in the program.
+	ResourceCount int `json:"resourceCount"`
+	// ConvertedResourceCount is the number of resources whose type was resolved against a package schema.
+	ConvertedResourceCount int `json:"convertedResourceCount"`
+	// UnresolvedResources lists the resources whose type could not be resolved. Their inputs and outputs are untyped.
+	UnresolvedResources []ReportItem `json:"unresolvedResources,omitempty"`
+	// DynamicExpressions lists the top-level values whose type was downgraded to dynamic.
+	DynamicExpressions []ReportItem `json:"dynamicExpressions,omitempty"`
+	// Unsupported lists the constructs that could not be parsed or translated.
+	Unsupported []ReportItem `json:"unsupported,omitempty"`
+	// TODOCount is the number of TODO comments inserted into the generated code in place of unsupported constructs.
+	TODOCount int `json:"todoCount"`
+}
+
+func newReportItem(summary string, rng *hcl.Range) ReportItem {
+	item := ReportItem{Summary: summary}
+	if rng != nil {
+		location := NewSourceRange(*rng)
+		item.Location = &location
+	}
+	return item
+}
+
+// AddResource records a resource. If unresolved is true, the resource's type could not be resolved. A nil report
+// ignores the resource.
+func (r *ConversionReport) AddResource(summary string, rng *hcl.Range, unresolved bool) {
+	if r == nil {
+		return
+	}
+	r.ResourceCount++
+	if unresolved {
+		r.UnresolvedResources = append(r.UnresolvedResources, newReportItem(summary, rng))
+	} else {
+		r.ConvertedResourceCount++
+	}
+}
+
+// AddDynamicExpression records a value whose type was downgraded to dynamic. A nil report ignores the value.
+func (r *ConversionReport) AddDynamicExpression(summary string, rng *hcl.Range) {
+	if r == nil {
+		return
+	}
+	r.DynamicExpressions = append(r.DynamicExpressions, newReportItem(summary, rng))
+}
+
+// AddUnsupported records a construct that could not be parsed or translated. A nil report ignores the construct.
+func (r *ConversionReport) AddUnsupported(summary string, rng *hcl.Range) {
+	if r == nil {
+		return
+	}
+	r.Unsupported = append(r.Unsupported, newReportItem(summary, rng))
+}
+
+// AddTODO records a TODO comment inserted into the generated code. A nil report ignores the comment.
+func (r *ConversionReport) AddTODO() {
+	if r == nil {
+		return
+	}
+	r.TODOCount++
+}
+
+// JSON returns the JSON encoding of the report.
+func (r *ConversionReport) JSON() ([]byte, error) {
+	bytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// EmbeddedSchemaIndexSuffix is the suffix of the sidecar file that locates the schema embedded in a provider plugin's
+// binary. The sidecar for the binary at path is path + EmbeddedSchemaIndexSuffix.
+const EmbeddedSchemaIndexSuffix = ".schema-index"
+
+// EmbeddedSchemaIndex locates the schema that a provider plugin embeds in its binary, so that the schema can be read
+// without booting the plugin and transferring the schema over gRPC.
+type EmbeddedSchemaIndex struct {
+	// FormatVersion is the version of the schema format, as passed to the provider's GetSchema method.
+	FormatVersion int `json:"formatVersion"`
+	// Offset is the offset in bytes of the schema from the start of the binary.
+	Offset int64 `json:"offset"`
+	// Length is the length in bytes of the schema.
+	Length int64 `json:"length"`
+	// BinarySize is the size in bytes of the binary, which guards against an index that is stale because the binary
+	// was rebuilt.
+	BinarySize int64 `json:"binarySize"`
+}
+
+// EmbedSchema appends the given schema to the provider plugin binary at binaryPath and writes the sidecar index that
+// locates it. Operating systems ignore data that trails an executable, so the plugin runs as before.
+func EmbedSchema(binaryPath string, schema []byte) error {
+	if !jsoniter.Valid(schema) {
+		return errors.New("the schema is not valid JSON")
+	}
+
+	f, err := os.OpenFile(binaryPath, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(schema); err != nil {
+		return err
+	}
+
+	index, err := json.Marshal(EmbeddedSchemaIndex{
+		Offset:     offset,
+		Length:     int64(len(schema)),
+		BinarySize: offset + int64(len(schema)),
+	})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(binaryPath+EmbeddedSchemaIndexSuffix, index, 0600)
+}
+
+// readEmbeddedSchemaIndex reads the sidecar index of the provider plugin binary at binaryPath. It returns nil if the
+// binary has no index.
+func readEmbeddedSchemaIndex(binaryPath string) (*EmbeddedSchemaIndex, error) {
+	bytes, err := ioutil.ReadFile(binaryPath + EmbeddedSchemaIndexSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var index EmbeddedSchemaIndex
+	if err = json.Unmarshal(bytes, &index); err != nil {
+		return nil, errors.Wrapf(err, "reading the schema index of %s", binaryPath)
+	}
+	return &index, nil
+}
+
+// decodeEmbeddedSchema decodes the schema embedded in the provider plugin binary at binaryPath into spec. The schema
+// is memory-mapped where the platform supports it, so it is never copied in full. It returns false if the binary has
+// no usable embedded schema.
+func decodeEmbeddedSchema(binaryPath string, spec *PackageSpec) (bool, error) {
+	index, err := readEmbeddedSchemaIndex(binaryPath)
+	if err != nil || index == nil {
+		return false, err
+	}
+	if index.FormatVersion != 0 {
+		return false, nil
+	}
+
+	f, err := os.Open(binaryPath)
+	if err != nil {
+		return false, err
+	}
+	defer contract.IgnoreClose(f)
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() != index.BinarySize || index.Offset < 0 || index.Length <= 0 ||
+		index.Offset+index.Length > info.Size() {
+		return false, nil
+	}
+
+	schema, unmap, err := mapFileRange(f, index.Offset, index.Length)
+	if err != nil {
+		return false, err
+	}
+	defer unmap()
+
+	if err = jsoniter.Unmarshal(schema, spec); err != nil {
+		return false, errors.Wrapf(err, "reading the schema embedded in %s", binaryPath)
+	}
+	return true, nil
+}
@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-embed-schema-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	binary := filepath.Join(dir, "pulumi-resource-embedded")
+	assert.NoError(t, ioutil.WriteFile(binary, []byte("#!/bin/sh\nexit 0\n"), 0700))
+
+	// A binary without an index has no embedded schema.
+	var spec PackageSpec
+	ok, err := decodeEmbeddedSchema(binary, &spec)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Error(t, EmbedSchema(binary, []byte(`{"name": `)))
+	assert.NoError(t, EmbedSchema(binary, []byte(`{"name": "embedded", "version": "1.0.0"}`)))
+
+	ok, err = decodeEmbeddedSchema(binary, &spec)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "embedded", spec.Name)
+	assert.Equal(t, "1.0.0", spec.Version)
+
+	// An index that no longer matches the binary is ignored.
+	f, err := os.OpenFile(binary, os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte("rebuilt"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	ok, err = decodeEmbeddedSchema(binary, &PackageSpec{})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -1,13 +1,22 @@
 package schema
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"sync"
 
 	"github.com/blang/semver"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
 )
 
 type Loader interface {
@@ -19,6 +28,8 @@ type pluginLoader struct {
 
 	host    plugin.Host
 	entries map[string]*Package
+
+	cacheDir string // the directory in which schemas are cached on disk, or "" to only cache them in memory.
 }
 
 func NewPluginLoader(host plugin.Host) Loader {
@@ -28,6 +39,18 @@ func NewPluginLoader(host plugin.Host) Loader {
 	}
 }
 
+// NewCachingPluginLoader returns a loader that caches the schemas it loads from provider plugins in cacheDir, as
+// <pkg>-<version>.json. Later loads of the same version of a package, including those by other processes, read the
+// schema from disk rather than booting the plugin. Plugins that are found on $PATH are never cached, as they are
+// usually development builds whose schemas change without a change in version.
+func NewCachingPluginLoader(host plugin.Host, cacheDir string) Loader {
+	return &pluginLoader{
+		host:     host,
+		entries:  map[string]*Package{},
+		cacheDir: cacheDir,
+	}
+}
+
 func (l *pluginLoader) getPackage(key string) (*Package, bool) {
 	l.m.RLock()
 	defer l.m.RUnlock()
@@ -46,10 +69,75 @@ func (l *pluginLoader) LoadPackage(pkg string, version *semver.Version) (*Packag
 		return p, nil
 	}
 
+	var spec PackageSpec
+	if !l.loadEmbeddedSchema(pkg, version, &spec) {
+		schemaBytes, err := l.loadSchemaBytes(pkg, version)
+		if err != nil {
+			return nil, err
+		}
+		if err := jsoniter.Unmarshal(schemaBytes, &spec); err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := ImportSpec(spec, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if p, ok := l.entries[key]; ok {
+		return p, nil
+	}
+	l.entries[key] = p
+
+	return p, nil
+}
+
+// loadEmbeddedSchema decodes the schema that the provider plugin for the given version of a package embeds in its
+// binary, if the plugin is installed and has an embedded schema. It returns false if the schema must be loaded some
+// other way.
+func (l *pluginLoader) loadEmbeddedSchema(pkg string, version *semver.Version, spec *PackageSpec) bool {
+	_, path, err := workspace.GetPluginPath(workspace.ResourcePlugin, pkg, version)
+	if err != nil || path == "" {
+		return false
+	}
+
+	ok, err := decodeEmbeddedSchema(path, spec)
+	if err != nil {
+		logging.V(5).Infof("ignoring the schema embedded in %s: %v", path, err)
+		*spec = PackageSpec{}
+		return false
+	}
+	return ok
+}
+
+// loadSchemaBytes returns the schema for the given version of a package, reading it from the on-disk cache if possible
+// and otherwise from the package's provider plugin.
+func (l *pluginLoader) loadSchemaBytes(pkg string, version *semver.Version) ([]byte, error) {
+	cacheable := l.cacheDir != "" && !pluginOnPath(pkg)
+	if cacheable {
+		// If no version was requested, the host loads the latest installed version of the plugin.
+		cacheVersion := version
+		if cacheVersion == nil {
+			cacheVersion = latestInstalledPluginVersion(pkg)
+		}
+		if cacheVersion != nil {
+			if schemaBytes, ok := l.readCachedSchema(pkg, *cacheVersion); ok {
+				return schemaBytes, nil
+			}
+		}
+	}
+
 	provider, err := l.host.Provider(tokens.Package(pkg), version)
 	if err != nil {
 		return nil, err
 	}
+	if provider == nil {
+		return nil, errors.Errorf("could not find provider for package '%s'", pkg)
+	}
 
 	schemaFormatVersion := 0
 	schemaBytes, err := provider.GetSchema(schemaFormatVersion)
@@ -57,23 +145,87 @@ func (l *pluginLoader) LoadPackage(pkg string, version *semver.Version) (*Packag
 		return nil, err
 	}
 
-	var spec PackageSpec
-	if err := jsoniter.Unmarshal(schemaBytes, &spec); err != nil {
-		return nil, err
+	// Cache the schema under the version of the plugin that the host actually loaded, which may be newer than the
+	// version that was requested.
+	if cacheable {
+		if info, err := provider.GetPluginInfo(); err == nil && info.Version != nil {
+			l.writeCachedSchema(pkg, *info.Version, schemaBytes)
+		}
 	}
 
-	p, err := ImportSpec(spec, nil)
+	return schemaBytes, nil
+}
+
+func (l *pluginLoader) cachedSchemaPath(pkg string, version semver.Version) string {
+	return filepath.Join(l.cacheDir, fmt.Sprintf("%s-%s.json", pkg, version))
+}
+
+// readCachedSchema reads the cached schema for the given version of a package, if there is one.
+func (l *pluginLoader) readCachedSchema(pkg string, version semver.Version) ([]byte, bool) {
+	path := l.cachedSchemaPath(pkg, version)
+	schemaBytes, err := ioutil.ReadFile(path)
 	if err != nil {
-		return nil, err
+		if !os.IsNotExist(err) {
+			logging.V(5).Infof("reading cached schema %s: %v", path, err)
+		}
+		return nil, false
 	}
+	if !jsoniter.Valid(schemaBytes) {
+		logging.V(5).Infof("ignoring invalid cached schema %s", path)
+		return nil, false
+	}
+	return schemaBytes, true
+}
 
-	l.m.Lock()
-	defer l.m.Unlock()
+// writeCachedSchema caches the schema for the given version of a package. The schema is written to a temporary file
+// that is then renamed, so that concurrent loads never read a partial schema. Failures are logged and ignored.
+func (l *pluginLoader) writeCachedSchema(pkg string, version semver.Version, schemaBytes []byte) {
+	if err := os.MkdirAll(l.cacheDir, 0700); err != nil {
+		logging.V(5).Infof("creating schema cache %s: %v", l.cacheDir, err)
+		return
+	}
 
-	if p, ok := l.entries[pkg]; ok {
-		return p, nil
+	f, err := ioutil.TempFile(l.cacheDir, pkg+"-*.tmp")
+	if err != nil {
+		logging.V(5).Infof("caching schema for %s@%s: %v", pkg, version, err)
+		return
 	}
-	l.entries[key] = p
+	_, err = f.Write(schemaBytes)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), l.cachedSchemaPath(pkg, version))
+	}
+	if err != nil {
+		logging.V(5).Infof("caching schema for %s@%s: %v", pkg, version, err)
+		contract.IgnoreError(os.Remove(f.Name()))
+	}
+}
 
-	return p, nil
+// pluginOnPath returns true if the resource plugin for the given package is found on $PATH, in which case the host
+// loads it regardless of the installed versions.
+func pluginOnPath(pkg string) bool {
+	info := workspace.PluginInfo{Kind: workspace.ResourcePlugin, Name: pkg}
+	_, err := exec.LookPath(info.FilePrefix())
+	return err == nil
+}
+
+// latestInstalledPluginVersion returns the latest installed version of the resource plugin for the given package, or
+// nil if the plugin is not installed.
+func latestInstalledPluginVersion(pkg string) *semver.Version {
+	plugins, err := workspace.GetPlugins()
+	if err != nil {
+		logging.V(5).Infof("listing installed plugins: %v", err)
+		return nil
+	}
+
+	var latest *semver.Version
+	for _, p := range plugins {
+		if p.Kind == workspace.ResourcePlugin && p.Name == pkg && p.Version != nil &&
+			(latest == nil || p.Version.GT(*latest)) {
+			latest = p.Version
+		}
+	}
+	return latest
 }
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+)
+
+func TestCachingPluginLoader(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "pulumi-schema-cache-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	version := semver.MustParse("1.2.3")
+	loads := 0
+	host := deploytest.NewPluginHost(nil, nil, nil,
+		deploytest.NewProviderLoader("cached", version, func() (plugin.Provider, error) {
+			loads++
+			return &deploytest.Provider{
+				Name:    "cached",
+				Version: version,
+				GetSchemaF: func(version int) ([]byte, error) {
+					return []byte(`{"name": "cached", "version": "1.2.3"}`), nil
+				},
+			}, nil
+		}))
+
+	// The first load boots the provider and caches its schema on disk.
+	pkg, err := NewCachingPluginLoader(host, cacheDir).LoadPackage("cached", &version)
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", pkg.Name)
+	assert.Equal(t, 1, loads)
+	_, err = os.Stat(filepath.Join(cacheDir, "cached-1.2.3.json"))
+	assert.NoError(t, err)
+
+	// A new loader reads the schema from disk.
+	pkg, err = NewCachingPluginLoader(host, cacheDir).LoadPackage("cached", &version)
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", pkg.Name)
+	assert.Equal(t, 1, loads)
+
+	// An invalid cache entry is ignored.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(cacheDir, "cached-1.2.3.json"), []byte(`{"name": `), 0600))
+	pkg, err = NewCachingPluginLoader(host, cacheDir).LoadPackage("cached", &version)
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", pkg.Name)
+	assert.Equal(t, 2, loads)
+}
@@ -0,0 +1,34 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// +build !windows
+
+package schema
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// mapFileRange memory-maps length bytes of f, starting at offset. The returned function unmaps them.
+func mapFileRange(f *os.File, offset, length int64) ([]byte, func(), error) {
+	// The offset of a mapping must be a multiple of the page size.
+	start := offset - offset%int64(os.Getpagesize())
+	data, err := syscall.Mmap(int(f.Fd()), start, int(offset-start+length), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data[offset-start:], func() { contract.IgnoreError(syscall.Munmap(data)) }, nil
+}
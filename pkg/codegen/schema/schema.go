@@ -50,6 +50,8 @@ const (
 	assetType   primitiveType = 6
 	anyType     primitiveType = 7
 	jsonType    primitiveType = 8
+	int64Type   primitiveType = 9
+	bytesType   primitiveType = 10
 )
 
 //nolint: goconst
@@ -59,6 +61,10 @@ func (t primitiveType) String() string {
 		return "boolean"
 	case intType:
 		return "integer"
+	case int64Type:
+		return "int64"
+	case bytesType:
+		return "bytes"
 	case numberType:
 		return "number"
 	case stringType:
@@ -78,8 +84,8 @@ func (t primitiveType) String() string {
 
 func (primitiveType) isType() {}
 
-// IsPrimitiveType returns true if the given Type is a primitive type. The primitive types are bool, int, number,
-// string, archive, asset, and any.
+// IsPrimitiveType returns true if the given Type is a primitive type. The primitive types are bool, int, int64,
+// number, string, bytes, archive, asset, and any.
 func IsPrimitiveType(t Type) bool {
 	_, ok := t.(primitiveType)
 	return ok
@@ -90,10 +96,14 @@ var (
 	BoolType Type = boolType
 	// IntType represents the set of 32-bit integer values.
 	IntType Type = intType
+	// Int64Type represents the set of 64-bit integer values.
+	Int64Type Type = int64Type
 	// NumberType represents the set of IEEE754 double-precision values.
 	NumberType Type = numberType
 	// StringType represents the set of UTF-8 string values.
 	StringType Type = stringType
+	// BytesType represents the set of byte strings. Byte strings are base64-encoded on the wire.
+	BytesType Type = bytesType
 	// ArchiveType represents the set of Pulumi Archive values.
 	ArchiveType Type = archiveType
 	// AssetType represents the set of Pulumi Asset values.
@@ -200,6 +210,36 @@ func (t *TokenType) String() string {
 
 func (*TokenType) isType() {}
 
+// EnumType represents a set of named values of a primitive type.
+type EnumType struct {
+	// Token is the type's Pulumi type token.
+	Token string
+	// Comment is the description of the type, if any.
+	Comment string
+	// Elements are the values of the enum.
+	Elements []*Enum
+	// ElementType is the primitive type of the enum's values.
+	ElementType Type
+}
+
+func (t *EnumType) String() string {
+	return t.Token
+}
+
+func (*EnumType) isType() {}
+
+// Enum is a value of an enum type.
+type Enum struct {
+	// Value is the value of the enum. Its type is the element type of the enum.
+	Value interface{}
+	// Comment is the description of the value, if any.
+	Comment string
+	// Name is the name of the value, if any. Generated SDKs derive the names of unnamed values from the values.
+	Name string
+	// DeprecationMessage indicates whether or not the value is deprecated.
+	DeprecationMessage string
+}
+
 // DefaultValue describes a default value for a property.
 type DefaultValue struct {
 	// Value specifies a static default value, if any. This value must be representable in the Pulumi schema type
@@ -259,12 +299,24 @@ type Resource struct {
 	StateInputs *ObjectType
 	// Aliases is the list of aliases for the resource.
 	Aliases []*Alias
+	// Methods is the list of operational methods that can be called on existing instances of the resource.
+	Methods []*Method
 	// DeprecationMessage indicates whether or not the resource is deprecated.
 	DeprecationMessage string
 	// Language specifies additional language-specific data about the resource.
 	Language map[string]interface{}
 }
 
+// Method describes an operational action, such as rebooting an instance, that can be performed on an existing
+// resource.
+type Method struct {
+	// Name is the name of the method.
+	Name string
+	// Function describes the method's arguments and results. Its token is the resource's token followed by a slash
+	// and the method's name.
+	Function *Function
+}
+
 // Function describes a Pulumi function.
 type Function struct {
 	// Token is the function's Pulumi type token.
@@ -552,11 +604,111 @@ func (pkg *Package) GetFunction(token string) (*Function, bool) {
 	return f, ok
 }
 
+// Filter returns a copy of the package that contains only the resources and functions with the given tokens, together
+// with every type that they or their methods refer to. The copy keeps the package's
+// provider and configuration, and the types that those refer to. Filter is useful for building small test fixtures and
+// for trimming generated SDKs to the subset of a package that a program uses.
+func (pkg *Package) Filter(tokens ...string) (*Package, error) {
+	filtered := *pkg
+	filtered.Resources, filtered.Functions = nil, nil
+	filtered.resourceTable, filtered.functionTable = map[string]*Resource{}, map[string]*Function{}
+
+	referenced := map[Type]bool{}
+	addProperties := func(properties []*Property) {
+		for _, p := range properties {
+			addReferencedTypes(p.Type, referenced)
+		}
+	}
+	addFunctionTypes := func(f *Function) {
+		if f.Inputs != nil {
+			addReferencedTypes(f.Inputs, referenced)
+		}
+		if f.Outputs != nil {
+			addReferencedTypes(f.Outputs, referenced)
+		}
+	}
+
+	addProperties(pkg.Config)
+	if pkg.Provider != nil {
+		addProperties(pkg.Provider.InputProperties)
+		addProperties(pkg.Provider.Properties)
+	}
+
+	for _, token := range tokens {
+		if r, ok := pkg.resourceTable[token]; ok {
+			if _, ok := filtered.resourceTable[token]; ok {
+				continue
+			}
+			filtered.Resources = append(filtered.Resources, r)
+			filtered.resourceTable[token] = r
+			addProperties(r.InputProperties)
+			addProperties(r.Properties)
+			if r.StateInputs != nil {
+				addReferencedTypes(r.StateInputs, referenced)
+			}
+			for _, m := range r.Methods {
+				addFunctionTypes(m.Function)
+			}
+			continue
+		}
+		if f, ok := pkg.functionTable[token]; ok {
+			if _, ok := filtered.functionTable[token]; !ok {
+				filtered.Functions = append(filtered.Functions, f)
+				filtered.functionTable[token] = f
+				addFunctionTypes(f)
+			}
+			continue
+		}
+		return nil, errors.Errorf("package %s has no resource or function %s", pkg.Name, token)
+	}
+
+	filtered.Types = nil
+	for _, t := range pkg.Types {
+		if referenced[t] {
+			filtered.Types = append(filtered.Types, t)
+		}
+	}
+	return &filtered, nil
+}
+
+// addReferencedTypes adds the given type and every type that it refers to to the referenced set.
+func addReferencedTypes(t Type, referenced map[Type]bool) {
+	if referenced[t] {
+		return
+	}
+	referenced[t] = true
+
+	switch t := t.(type) {
+	case *ArrayType:
+		addReferencedTypes(t.ElementType, referenced)
+	case *MapType:
+		addReferencedTypes(t.ElementType, referenced)
+	case *UnionType:
+		for _, e := range t.ElementTypes {
+			addReferencedTypes(e, referenced)
+		}
+		if t.DefaultType != nil {
+			addReferencedTypes(t.DefaultType, referenced)
+		}
+	case *ObjectType:
+		for _, p := range t.Properties {
+			addReferencedTypes(p.Type, referenced)
+		}
+	case *TokenType:
+		if t.UnderlyingType != nil {
+			addReferencedTypes(t.UnderlyingType, referenced)
+		}
+	}
+}
+
 // TypeSpec is the serializable form of a reference to a type.
 type TypeSpec struct {
 	// Type is the primitive or composite type, if any. May be "bool", "integer", "number", "string", "array", or
 	// "object".
 	Type string `json:"type,omitempty"`
+	// Format refines a primitive type, if any. May be "int64", which indicates that an "integer" type holds 64-bit
+	// values, or "byte", which indicates that a "string" type holds base64-encoded binary data.
+	Format string `json:"format,omitempty"`
 	// Ref is a reference to a type in this or another document. For example, the built-in Archive, Asset, and Any
 	// types are referenced as "pulumi.json#/Archive", "pulumi.json#/Asset", and "pulumi.json#/Any", respectively.
 	// A type from this document is referenced as "#/types/pulumi:type:token".
@@ -565,7 +717,9 @@ type TypeSpec struct {
 	AdditionalProperties *TypeSpec `json:"additionalProperties,omitempty"`
 	// Items, if set, describes the element type of an array.
 	Items *TypeSpec `json:"items,omitempty"`
-	// OneOf indicates that values of the type may be one of any of the listed types.
+	// OneOf indicates that values of the type may be one of any of the listed types. If Type is also set, it names the
+	// union's default member: the member that targets without unions use in its place, and that generated SDKs make
+	// the easiest to pass.
 	OneOf []TypeSpec `json:"oneOf,omitempty"`
 }
 
@@ -605,13 +759,28 @@ type ObjectTypeSpec struct {
 	Description string `json:"description,omitempty"`
 	// Properties is a map from property name to PropertySpec that describes the type's properties.
 	Properties map[string]PropertySpec `json:"properties,omitempty"`
-	// Type must be "object".
+	// Type must be "object", unless the type is an enum type, in which case it is the primitive type of the enum's
+	// values.
 	Type string `json:"type,omitempty"`
 	// Requires is a list of the names of the type's required properties. These properties must be set for inputs and
 	// will always be set for outputs.
 	Required []string `json:"required,omitempty"`
 	// Language specifies additional language-specific data about the type.
 	Language map[string]json.RawMessage `json:"language,omitempty"`
+	// Enum, if set, lists the values of an enum type. Only the types of a package may be enum types.
+	Enum []EnumValueSpec `json:"enum,omitempty"`
+}
+
+// EnumValueSpec is the serializable form of a value of an enum type.
+type EnumValueSpec struct {
+	// Name is the name of the value, if any.
+	Name string `json:"name,omitempty"`
+	// Description is the description of the value, if any.
+	Description string `json:"description,omitempty"`
+	// Value is the value. Its type must be the type of the enum.
+	Value interface{} `json:"value"`
+	// DeprecationMessage indicates whether or not the value is deprecated.
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
 }
 
 // AliasSpec is the serializable form of an alias description.
@@ -637,6 +806,9 @@ type ResourceSpec struct {
 	StateInputs *ObjectTypeSpec `json:"stateInputs,omitempty"`
 	// Aliases is the list of aliases for the resource.
 	Aliases []AliasSpec `json:"aliases,omitempty"`
+	// Methods is a map from method name to FunctionSpec that describes the operational methods, such as rebooting an
+	// instance, that can be called on existing instances of the resource.
+	Methods map[string]FunctionSpec `json:"methods,omitempty"`
 	// DeprecationMessage indicates whether or not the resource is deprecated.
 	DeprecationMessage string `json:"deprecationMessage,omitempty"`
 	// Language specifies additional language-specific data about the resource.
@@ -764,6 +936,9 @@ func ImportSpec(spec PackageSpec, languages map[string]Language) (*Package, erro
 	for _, t := range types.objects {
 		typeList = append(typeList, t)
 	}
+	for _, t := range types.enums {
+		typeList = append(typeList, t)
+	}
 	for _, t := range types.arrays {
 		typeList = append(typeList, t)
 	}
@@ -813,21 +988,35 @@ func ImportSpec(spec PackageSpec, languages map[string]Language) (*Package, erro
 
 type types struct {
 	objects map[string]*ObjectType
+	enums   map[string]*EnumType
 	arrays  map[Type]*ArrayType
 	maps    map[Type]*MapType
 	unions  map[string]*UnionType
 	tokens  map[string]*TokenType
 }
 
-func (t *types) bindPrimitiveType(name string) (Type, error) {
+func (t *types) bindPrimitiveType(name, format string) (Type, error) {
+	switch {
+	case format == "", name == "integer" && format == "int64", name == "string" && format == "byte":
+		// OK
+	default:
+		return nil, errors.Errorf("unsupported format %v for primitive type %v", format, name)
+	}
+
 	switch name {
 	case "boolean":
 		return BoolType, nil
 	case "integer":
+		if format == "int64" {
+			return Int64Type, nil
+		}
 		return IntType, nil
 	case "number":
 		return NumberType, nil
 	case "string":
+		if format == "byte" {
+			return BytesType, nil
+		}
 		return StringType, nil
 	default:
 		return nil, errors.Errorf("unknown primitive type %v", name)
@@ -859,6 +1048,9 @@ func (t *types) bindType(spec TypeSpec) (Type, error) {
 		if typ, ok := t.objects[token]; ok {
 			return typ, nil
 		}
+		if typ, ok := t.enums[token]; ok {
+			return typ, nil
+		}
 		typ, ok := t.tokens[token]
 		if !ok {
 			typ = &TokenType{Token: token}
@@ -881,7 +1073,7 @@ func (t *types) bindType(spec TypeSpec) (Type, error) {
 
 		var defaultType Type
 		if spec.Type != "" {
-			dt, err := t.bindPrimitiveType(spec.Type)
+			dt, err := t.bindPrimitiveType(spec.Type, spec.Format)
 			if err != nil {
 				return nil, err
 			}
@@ -910,7 +1102,7 @@ func (t *types) bindType(spec TypeSpec) (Type, error) {
 
 	switch spec.Type {
 	case "boolean", "integer", "number", "string":
-		return t.bindPrimitiveType(spec.Type)
+		return t.bindPrimitiveType(spec.Type, spec.Format)
 	case "array":
 		if spec.Items == nil {
 			return nil, errors.Errorf("missing \"items\" property in type spec")
@@ -967,6 +1159,15 @@ func bindConstValue(value interface{}, typ Type) (interface{}, error) {
 			return nil, errors.Errorf("invalid constant of type number for integer property")
 		}
 		value = int32(v)
+	case Int64Type:
+		v, ok := value.(float64)
+		if !ok {
+			return nil, errors.Errorf("invalid constant of type %T for integer property", value)
+		}
+		if math.Trunc(v) != v || v < math.MinInt64 || v >= math.MaxInt64 {
+			return nil, errors.Errorf("invalid constant of type number for integer property")
+		}
+		value = int64(v)
 	case NumberType:
 		if _, ok := value.(float64); !ok {
 			return nil, errors.Errorf("invalid constant of type %T for number property", value)
@@ -1002,6 +1203,15 @@ func bindDefaultValue(value interface{}, spec *DefaultSpec, typ Type) (*DefaultV
 				return nil, errors.Errorf("invalid default of type number for integer property")
 			}
 			value = int32(v)
+		case Int64Type:
+			v, ok := value.(float64)
+			if !ok {
+				return nil, errors.Errorf("invalid default of type %T for integer property", value)
+			}
+			if math.Trunc(v) != v || v < math.MinInt64 || v >= math.MaxInt64 {
+				return nil, errors.Errorf("invalid default of type number for integer property")
+			}
+			value = int64(v)
 		case NumberType:
 			if _, ok := value.(float64); !ok {
 				return nil, errors.Errorf("invalid default of type %T for number property", value)
@@ -1113,9 +1323,41 @@ func (t *types) bindObjectType(token string, spec ObjectTypeSpec) (*ObjectType,
 	return obj, nil
 }
 
+func (t *types) bindEnumType(token string, spec ObjectTypeSpec) (*EnumType, error) {
+	elementType, err := t.bindPrimitiveType(spec.Type, "")
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]*Enum, len(spec.Enum))
+	for i, e := range spec.Enum {
+		if e.Value == nil {
+			return nil, errors.New("enum values must not be null")
+		}
+		value, err := bindConstValue(e.Value, elementType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "value %v", e.Value)
+		}
+		elements[i] = &Enum{
+			Value:              value,
+			Comment:            e.Description,
+			Name:               e.Name,
+			DeprecationMessage: e.DeprecationMessage,
+		}
+	}
+
+	return &EnumType{
+		Token:       token,
+		Comment:     spec.Description,
+		Elements:    elements,
+		ElementType: elementType,
+	}, nil
+}
+
 func bindTypes(objects map[string]ObjectTypeSpec) (*types, error) {
 	typs := &types{
 		objects: map[string]*ObjectType{},
+		enums:   map[string]*EnumType{},
 		arrays:  map[Type]*ArrayType{},
 		maps:    map[Type]*MapType{},
 		unions:  map[string]*UnionType{},
@@ -1124,6 +1366,14 @@ func bindTypes(objects map[string]ObjectTypeSpec) (*types, error) {
 
 	// Declare object types before processing properties.
 	for token, spec := range objects {
+		if len(spec.Enum) != 0 {
+			enum, err := typs.bindEnumType(token, spec)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to bind enum type %s", token)
+			}
+			typs.enums[token] = enum
+			continue
+		}
 		if spec.Type != "object" {
 			return nil, errors.Errorf("type %s must be an object, not a %s", token, spec.Type)
 		}
@@ -1137,6 +1387,9 @@ func bindTypes(objects map[string]ObjectTypeSpec) (*types, error) {
 
 	// Process properties.
 	for token, spec := range objects {
+		if len(spec.Enum) != 0 {
+			continue
+		}
 		if err := typs.bindObjectTypeDetails(typs.objects[token], token, spec); err != nil {
 			return nil, errors.Wrapf(err, "failed to bind type %s", token)
 		}
@@ -1175,6 +1428,18 @@ func bindResource(token string, spec ResourceSpec, types *types) (*Resource, err
 		aliases = append(aliases, &Alias{Name: a.Name, Project: a.Project, Type: a.Type})
 	}
 
+	var methods []*Method
+	for name, methodSpec := range spec.Methods {
+		f, err := bindFunction(token+"/"+name, methodSpec, types)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error binding method %v", name)
+		}
+		methods = append(methods, &Method{Name: name, Function: f})
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
 	language := make(map[string]interface{})
 	for name, raw := range spec.Language {
 		language[name] = raw
@@ -1187,6 +1452,7 @@ func bindResource(token string, spec ResourceSpec, types *types) (*Resource, err
 		Properties:         properties,
 		StateInputs:        stateInputs,
 		Aliases:            aliases,
+		Methods:            methods,
 		DeprecationMessage: spec.DeprecationMessage,
 		Language:           language,
 	}, nil
@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterPackage(t *testing.T) {
+	contents, err := ioutil.ReadFile(filepath.Join(testdataPath, "aws.json"))
+	assert.NoError(t, err)
+	var spec PackageSpec
+	assert.NoError(t, json.Unmarshal(contents, &spec))
+	pkg, err := ImportSpec(spec, nil)
+	assert.NoError(t, err)
+
+	filtered, err := pkg.Filter("aws:s3/bucket:Bucket", "aws:index/getRegion:getRegion", "aws:s3/bucket:Bucket")
+	assert.NoError(t, err)
+
+	assert.Len(t, filtered.Resources, 1)
+	_, ok := filtered.GetResource("aws:s3/bucket:Bucket")
+	assert.True(t, ok)
+	_, ok = filtered.GetResource("aws:ec2/instance:Instance")
+	assert.False(t, ok)
+	assert.Len(t, filtered.Functions, 1)
+	_, ok = filtered.GetFunction("aws:index/getRegion:getRegion")
+	assert.True(t, ok)
+	assert.Equal(t, pkg.Provider, filtered.Provider)
+
+	objects := map[string]bool{}
+	for _, typ := range filtered.Types {
+		if obj, ok := typ.(*ObjectType); ok {
+			objects[obj.Token] = true
+		}
+	}
+	assert.True(t, objects["aws:s3/BucketCorsRule:BucketCorsRule"])
+	for token := range objects {
+		assert.False(t, strings.HasPrefix(token, "aws:ec2/"), "unexpected type %v", token)
+	}
+
+	// The original package is left alone.
+	assert.Len(t, pkg.Resources, len(spec.Resources))
+	_, ok = pkg.GetResource("aws:ec2/instance:Instance")
+	assert.True(t, ok)
+
+	_, err = pkg.Filter("aws:s3/bucket:Bucket", "aws:s3/missing:Missing")
+	assert.EqualError(t, err, "package aws has no resource or function aws:s3/missing:Missing")
+}
+
+func TestImportEnumTypes(t *testing.T) {
+	importSpec := func(types string) (*Package, error) {
+		var spec PackageSpec
+		err := json.Unmarshal([]byte(`{
+			"name": "example",
+			"types": `+types+`,
+			"resources": {
+				"example:index:Widget": {
+					"inputProperties": {"size": {"$ref": "#/types/example:index:Size"}}
+				}
+			}
+		}`), &spec)
+		assert.NoError(t, err)
+		return ImportSpec(spec, nil)
+	}
+
+	pkg, err := importSpec(`{
+		"example:index:Size": {
+			"type": "integer",
+			"description": "The size of a widget.",
+			"enum": [
+				{"name": "Small", "value": 1, "description": "A small widget."},
+				{"name": "Large", "value": 2, "deprecationMessage": "Use Small instead."}
+			]
+		}
+	}`)
+	assert.NoError(t, err)
+
+	res, ok := pkg.GetResource("example:index:Widget")
+	assert.True(t, ok)
+	enum, ok := res.InputProperties[0].Type.(*EnumType)
+	if assert.True(t, ok) {
+		assert.Equal(t, "example:index:Size", enum.Token)
+		assert.Equal(t, "The size of a widget.", enum.Comment)
+		assert.Equal(t, IntType, enum.ElementType)
+		assert.Equal(t, []*Enum{
+			{Value: int32(1), Name: "Small", Comment: "A small widget."},
+			{Value: int32(2), Name: "Large", DeprecationMessage: "Use Small instead."},
+		}, enum.Elements)
+	}
+	assert.Contains(t, pkg.Types, Type(enum))
+
+	_, err = importSpec(`{"example:index:Size": {"type": "integer", "enum": [{"value": "small"}]}}`)
+	assert.Error(t, err)
+	_, err = importSpec(`{"example:index:Size": {"type": "integer", "enum": [{"value": null}]}}`)
+	assert.Error(t, err)
+}
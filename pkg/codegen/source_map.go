@@ -0,0 +1,192 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// GenerateProgramOptions controls the optional outputs of the program generators.
+type GenerateProgramOptions struct {
+	// SourceMap causes the generator to emit a source map for each generated file. The source map for a file is named
+	// after the file with a ".map" suffix, e.g. "index.ts.map". See SourceMap for its format.
+	SourceMap bool
+	// Report, if non-nil, is filled in with a summary of the conversion's fidelity: the resources that were converted,
+	// the values that were downgraded to dynamic, and the constructs that could not be translated.
+	Report *ConversionReport
+}
+
+// SourceRange is a range of text in a source file. Its lines and columns are those of the hcl.Range from which it was
+// created.
+type SourceRange struct {
+	Filename    string `json:"filename"`
+	StartLine   int    `json:"startLine"`
+	StartColumn int    `json:"startColumn"`
+	EndLine     int    `json:"endLine"`
+	EndColumn   int    `json:"endColumn"`
+}
+
+// NewSourceRange converts an HCL range to a SourceRange. The range is used as-is, so programs whose nodes carry the
+// ranges of the code from which they were converted (e.g. Terraform) map generated code back to that code.
+func NewSourceRange(rng hcl.Range) SourceRange {
+	return SourceRange{
+		Filename:    rng.Filename,
+		StartLine:   rng.Start.Line,
+		StartColumn: rng.Start.Column,
+		EndLine:     rng.End.Line,
+		EndColumn:   rng.End.Column,
+	}
+}
+
+// SourceMapping associates a range of lines in a generated file with the range of source code from which the lines
+// were generated. Line numbers are 1-based and inclusive.
+type SourceMapping struct {
+	GeneratedStartLine int         `json:"generatedStartLine"`
+	GeneratedEndLine   int         `json:"generatedEndLine"`
+	Source             SourceRange `json:"source"`
+}
+
+// SourceMap associates the lines of a generated file with the source code from which they were generated. Mappings
+// may nest: the lines generated for a component contain the lines generated for the resources inside of it. Mappings
+// are ordered by their starting lines.
+type SourceMap struct {
+	// File is the name of the generated file.
+	File string `json:"file"`
+	// Mappings is the list of mappings in the file.
+	Mappings []SourceMapping `json:"mappings"`
+}
+
+// JSON returns the JSON encoding of the source map.
+func (m *SourceMap) JSON() ([]byte, error) {
+	bytes, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
+
+// Reformat adjusts the line numbers of the source map after its file has been reformatted, e.g. by gofmt. Formatters
+// may add or remove blank lines, but must preserve the order and number of non-blank lines. If they do not, the source
+// map is left unchanged.
+func (m *SourceMap) Reformat(unformatted, formatted []byte) {
+	nonBlankLines := func(text []byte) []int {
+		var lines []int
+		for i, line := range bytes.Split(text, []byte{'\n'}) {
+			if len(bytes.TrimSpace(line)) != 0 {
+				lines = append(lines, i+1)
+			}
+		}
+		return lines
+	}
+	before, after := nonBlankLines(unformatted), nonBlankLines(formatted)
+	if len(before) != len(after) {
+		return
+	}
+
+	lineMap := map[int]int{}
+	for i, line := range before {
+		lineMap[line] = after[i]
+	}
+
+	// Blank lines may not survive formatting, so mappings that start or end on blank lines are shrunk to the nearest
+	// non-blank lines inside of the mapping.
+	remap := func(line, direction int) int {
+		for l := line; l >= 1 && l <= before[len(before)-1]; l += direction {
+			if mapped, ok := lineMap[l]; ok {
+				return mapped
+			}
+		}
+		return line
+	}
+	for i := range m.Mappings {
+		mapping := &m.Mappings[i]
+		mapping.GeneratedStartLine = remap(mapping.GeneratedStartLine, 1)
+		mapping.GeneratedEndLine = remap(mapping.GeneratedEndLine, -1)
+	}
+}
+
+// SourceMapWriter is an io.Writer that records a source map for the text written to it. A nil *SourceMapWriter
+// records nothing, so generators can call Map unconditionally.
+type SourceMapWriter struct {
+	w io.Writer
+
+	// The number of bytes that have been written.
+	bytes int
+	// The number of complete lines that have been written.
+	lines int
+	// True if the current line is not empty.
+	partial bool
+
+	sourceMap SourceMap
+}
+
+// NewSourceMapWriter creates a new SourceMapWriter that writes to the given writer. The file is the name of the
+// generated file.
+func NewSourceMapWriter(w io.Writer, file string) *SourceMapWriter {
+	return &SourceMapWriter{
+		w:         w,
+		sourceMap: SourceMap{File: file, Mappings: []SourceMapping{}},
+	}
+}
+
+// Write writes the given bytes to the underlying writer and updates the writer's line count.
+func (w *SourceMapWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if n > 0 {
+		w.bytes += n
+		written := p[:n]
+		w.lines += bytes.Count(written, []byte{'\n'})
+		w.partial = written[len(written)-1] != '\n'
+	}
+	return n, err
+}
+
+// Map calls gen, which must write to the SourceMapWriter, and maps the lines it writes to the given source range. If
+// gen writes nothing, no mapping is recorded.
+func (w *SourceMapWriter) Map(source hcl.Range, gen func()) {
+	if w == nil {
+		gen()
+		return
+	}
+
+	index := len(w.sourceMap.Mappings)
+	w.sourceMap.Mappings = append(w.sourceMap.Mappings, SourceMapping{
+		GeneratedStartLine: w.lines + 1,
+		Source:             NewSourceRange(source),
+	})
+
+	start := w.bytes
+	gen()
+
+	end := w.lines
+	if w.partial {
+		end++
+	}
+	if w.bytes == start {
+		// Nothing was written. Remove the mapping and any mappings recorded by gen.
+		w.sourceMap.Mappings = w.sourceMap.Mappings[:index]
+		return
+	}
+	w.sourceMap.Mappings[index].GeneratedEndLine = end
+}
+
+// SourceMap returns the source map recorded by the writer.
+func (w *SourceMapWriter) SourceMap() *SourceMap {
+	return &w.sourceMap
+}
@@ -0,0 +1,82 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func sourceRange(line int) hcl.Range {
+	return hcl.Range{
+		Filename: "main.pp",
+		Start:    hcl.Pos{Line: line, Column: 1},
+		End:      hcl.Pos{Line: line + 1, Column: 1},
+	}
+}
+
+func TestSourceMapWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	w := NewSourceMapWriter(&buffer, "index.ts")
+
+	fmt.Fprintf(w, "import * as pulumi from \"@pulumi/pulumi\";\n\n")
+	w.Map(sourceRange(1), func() {
+		fmt.Fprintf(w, "const a = new Resource(\"a\", {\n")
+		fmt.Fprintf(w, "    value: 1,\n")
+		fmt.Fprintf(w, "});\n")
+	})
+	w.Map(sourceRange(5), func() {})
+	w.Map(sourceRange(7), func() {
+		fmt.Fprintf(w, "export const b = ")
+		w.Map(sourceRange(8), func() { fmt.Fprintf(w, "a.value") })
+		fmt.Fprintf(w, ";\n")
+	})
+
+	assert.Equal(t, []SourceMapping{
+		{GeneratedStartLine: 3, GeneratedEndLine: 5, Source: NewSourceRange(sourceRange(1))},
+		{GeneratedStartLine: 6, GeneratedEndLine: 6, Source: NewSourceRange(sourceRange(7))},
+		{GeneratedStartLine: 6, GeneratedEndLine: 6, Source: NewSourceRange(sourceRange(8))},
+	}, w.SourceMap().Mappings)
+}
+
+func TestSourceMapWriterNil(t *testing.T) {
+	var w *SourceMapWriter
+
+	called := false
+	w.Map(sourceRange(1), func() { called = true })
+	assert.True(t, called)
+}
+
+func TestSourceMapReformat(t *testing.T) {
+	unformatted := "package main\nfunc main() {\n\n\n\tfoo()\n\n\tbar()\n}\n"
+	formatted := "package main\n\nfunc main() {\n\n\tfoo()\n\n\tbar()\n}\n"
+
+	m := SourceMap{
+		File: "main.go",
+		Mappings: []SourceMapping{
+			{GeneratedStartLine: 3, GeneratedEndLine: 5},
+			{GeneratedStartLine: 7, GeneratedEndLine: 7},
+		},
+	}
+	m.Reformat([]byte(unformatted), []byte(formatted))
+	assert.Equal(t, []SourceMapping{
+		{GeneratedStartLine: 5, GeneratedEndLine: 5},
+		{GeneratedStartLine: 7, GeneratedEndLine: 7},
+	}, m.Mappings)
+}
@@ -0,0 +1,168 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SurfaceSymbol is an exported symbol of a generated SDK, e.g. a class, a function, or a property.
+type SurfaceSymbol struct {
+	// Module is the name of the module (package, namespace) that contains the symbol.
+	Module string
+	// Parent is the name of the symbol that contains this symbol, if any.
+	Parent string
+	// Token identifies the schema entity that the symbol was generated from. Two symbols with the same token but
+	// different names are the same entity under different names.
+	Token string
+	// Signature describes the type of the symbol in the SDK's language.
+	Signature string
+	// Argument is true if the symbol is an argument that callers pass to the SDK.
+	Argument bool
+	// Optional is true if the symbol is an argument that callers may omit.
+	Optional bool
+}
+
+// Surface describes the exported symbols of a generated SDK, keyed by their fully qualified names.
+type Surface map[string]SurfaceSymbol
+
+// Add adds a symbol to the surface and returns its name.
+func (s Surface) Add(name string, symbol SurfaceSymbol) string {
+	s[name] = symbol
+	return name
+}
+
+// SurfaceChangeKind is the kind of a source-incompatible change to the surface of an SDK.
+type SurfaceChangeKind string
+
+const (
+	// ModuleRemoved means that a module and all of its symbols were removed.
+	ModuleRemoved SurfaceChangeKind = "module removed"
+	// SymbolRemoved means that a symbol was removed.
+	SymbolRemoved SurfaceChangeKind = "removed"
+	// SymbolRenamed means that a symbol is now available under a different name.
+	SymbolRenamed SurfaceChangeKind = "renamed"
+	// SignatureChanged means that the type of a symbol changed.
+	SignatureChanged SurfaceChangeKind = "type changed"
+	// ArgumentRequired means that an argument that callers could omit is now required.
+	ArgumentRequired SurfaceChangeKind = "now required"
+	// RequiredArgumentAdded means that a symbol has a new argument that callers must pass.
+	RequiredArgumentAdded SurfaceChangeKind = "required argument added"
+)
+
+// SurfaceChange is a source-incompatible change to the surface of an SDK. Code that uses the old symbol does not
+// compile, type check, or run against the new SDK.
+type SurfaceChange struct {
+	Kind SurfaceChangeKind
+	// Name is the name of the changed symbol or module in the old SDK.
+	Name string
+	// NewName is the name of a renamed symbol in the new SDK.
+	NewName string
+	// Old and New are the signatures of a symbol whose type changed.
+	Old, New string
+}
+
+func (c SurfaceChange) String() string {
+	switch c.Kind {
+	case SymbolRenamed:
+		return fmt.Sprintf("%s: renamed to %s", c.Name, c.NewName)
+	case SignatureChanged:
+		return fmt.Sprintf("%s: type changed from %s to %s", c.Name, c.Old, c.New)
+	default:
+		return fmt.Sprintf("%s: %s", c.Name, c.Kind)
+	}
+}
+
+// CompareSurfaces returns the source-incompatible changes between two surfaces of the same SDK, sorted by name. The
+// changes to the members of a removed or renamed symbol and to the symbols of a removed module are not reported
+// individually. Additions are compatible unless they add a required argument.
+func CompareSurfaces(old, new Surface) []SurfaceChange {
+	newModules, newTokens := StringSet{}, map[string]string{}
+	for name, sym := range new {
+		newModules.Add(sym.Module)
+		if sym.Token != "" {
+			newTokens[sym.Token] = name
+		}
+	}
+
+	var changes []SurfaceChange
+	removedModules, removed := StringSet{}, StringSet{}
+	for _, sym := range old {
+		if !newModules.Has(sym.Module) && !removedModules.Has(sym.Module) {
+			removedModules.Add(sym.Module)
+			changes = append(changes, SurfaceChange{Kind: ModuleRemoved, Name: sym.Module})
+		}
+	}
+	for name, sym := range old {
+		if _, ok := new[name]; ok || removedModules.Has(sym.Module) {
+			continue
+		}
+		removed.Add(name)
+	}
+
+	// isHidden returns true if the given symbol of the old surface belongs to a symbol that is reported as removed.
+	isHidden := func(sym SurfaceSymbol) bool {
+		for parent := sym.Parent; parent != ""; parent = old[parent].Parent {
+			if removed.Has(parent) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for name, sym := range old {
+		if removedModules.Has(sym.Module) || isHidden(sym) {
+			continue
+		}
+
+		newSym, ok := new[name]
+		switch {
+		case !ok:
+			if newName, ok := newTokens[sym.Token]; ok && sym.Token != "" {
+				changes = append(changes, SurfaceChange{Kind: SymbolRenamed, Name: name, NewName: newName})
+			} else {
+				changes = append(changes, SurfaceChange{Kind: SymbolRemoved, Name: name})
+			}
+		case newSym.Signature != sym.Signature:
+			changes = append(changes, SurfaceChange{
+				Kind: SignatureChanged,
+				Name: name,
+				Old:  sym.Signature,
+				New:  newSym.Signature,
+			})
+		case sym.Argument && sym.Optional && !newSym.Optional:
+			changes = append(changes, SurfaceChange{Kind: ArgumentRequired, Name: name})
+		}
+	}
+
+	// Callers of a symbol that already existed must now pass any new required arguments.
+	for name, sym := range new {
+		if _, ok := old[name]; ok || !sym.Argument || sym.Optional {
+			continue
+		}
+		if _, ok := old[sym.Parent]; ok && !removed.Has(sym.Parent) {
+			changes = append(changes, SurfaceChange{Kind: RequiredArgumentAdded, Name: name})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Kind < changes[j].Kind
+	})
+	return changes
+}
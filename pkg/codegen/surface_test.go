@@ -0,0 +1,73 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareSurfaces(t *testing.T) {
+	old := Surface{
+		"s3.Bucket":             {Module: "s3", Token: "aws:s3:Bucket", Signature: "class"},
+		"s3.Bucket.acl":         {Module: "s3", Parent: "s3.Bucket", Signature: "string"},
+		"s3.Bucket.region":      {Module: "s3", Parent: "s3.Bucket", Signature: "string"},
+		"s3.BucketArgs":         {Module: "s3", Parent: "s3.Bucket", Signature: "interface"},
+		"s3.BucketArgs.acl":     {Module: "s3", Parent: "s3.BucketArgs", Argument: true, Optional: true},
+		"s3.BucketArgs.policy":  {Module: "s3", Parent: "s3.BucketArgs", Argument: true, Optional: true},
+		"s3.Policy":             {Module: "s3", Token: "aws:s3:Policy", Signature: "class"},
+		"s3.Policy.document":    {Module: "s3", Parent: "s3.Policy", Signature: "string"},
+		"s3.Object":             {Module: "s3", Token: "aws:s3:Object", Signature: "class"},
+		"s3.Object.key":         {Module: "s3", Parent: "s3.Object", Signature: "string"},
+		"sqs.Queue":             {Module: "sqs", Token: "aws:sqs:Queue", Signature: "class"},
+		"sqs.Queue.arn":         {Module: "sqs", Parent: "sqs.Queue", Signature: "string"},
+		"s3.getBucket":          {Module: "s3", Token: "aws:s3:getBucket", Signature: "function"},
+		"s3.GetBucketArgs":      {Module: "s3", Parent: "s3.getBucket", Signature: "interface"},
+		"s3.GetBucketArgs.name": {Module: "s3", Parent: "s3.GetBucketArgs", Argument: true},
+	}
+	new := Surface{
+		"s3.Bucket":              {Module: "s3", Token: "aws:s3:Bucket", Signature: "class"},
+		"s3.Bucket.acl":          {Module: "s3", Parent: "s3.Bucket", Signature: "string | undefined"},
+		"s3.Bucket.tags":         {Module: "s3", Parent: "s3.Bucket", Signature: "string"},
+		"s3.BucketArgs":          {Module: "s3", Parent: "s3.Bucket", Signature: "interface"},
+		"s3.BucketArgs.acl":      {Module: "s3", Parent: "s3.BucketArgs", Argument: true},
+		"s3.BucketArgs.policy":   {Module: "s3", Parent: "s3.BucketArgs", Argument: true, Optional: true},
+		"s3.BucketArgs.region":   {Module: "s3", Parent: "s3.BucketArgs", Argument: true},
+		"s3.BucketArgs.tags":     {Module: "s3", Parent: "s3.BucketArgs", Argument: true, Optional: true},
+		"s3.PolicyV2":            {Module: "s3", Token: "aws:s3:Policy", Signature: "class"},
+		"s3.PolicyV2.document":   {Module: "s3", Parent: "s3.PolicyV2", Signature: "string"},
+		"s3.getBucket":           {Module: "s3", Token: "aws:s3:getBucket", Signature: "function"},
+		"s3.GetBucketArgs":       {Module: "s3", Parent: "s3.getBucket", Signature: "interface"},
+		"s3.GetBucketArgs.name":  {Module: "s3", Parent: "s3.GetBucketArgs", Argument: true, Optional: true},
+		"s3.GetBucketArgs.owner": {Module: "s3", Parent: "s3.GetBucketArgs", Argument: true, Optional: true},
+	}
+
+	var descriptions []string
+	for _, c := range CompareSurfaces(old, new) {
+		descriptions = append(descriptions, c.String())
+	}
+	assert.Equal(t, []string{
+		"s3.Bucket.acl: type changed from string to string | undefined",
+		"s3.Bucket.region: removed",
+		"s3.BucketArgs.acl: now required",
+		"s3.BucketArgs.region: required argument added",
+		"s3.Object: removed",
+		"s3.Policy: renamed to s3.PolicyV2",
+		"sqs: module removed",
+	}, descriptions)
+
+	assert.Empty(t, CompareSurfaces(old, old))
+}
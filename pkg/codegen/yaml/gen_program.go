@@ -0,0 +1,569 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yaml generates declarative YAML representations of PCL programs. A generated program lists the program's
+// configuration, variables, resources, and outputs in source order. Values are written as YAML, references to other
+// declarations as `${name.property}` interpolations, and calls to functions as single-key objects whose key is the name
+// of the function prefixed with "fn::", e.g. `fn::invoke`.
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/model"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+)
+
+// programFile is the name of the generated program.
+const programFile = "Main.yaml"
+
+// builtins maps the names of the PCL builtins that have YAML equivalents to the names of those equivalents.
+var builtins = map[string]string{
+	"fileArchive": "fn::fileArchive",
+	"fileAsset":   "fn::fileAsset",
+	"readFile":    "fn::readFile",
+	"secret":      "fn::secret",
+	"split":       "fn::split",
+	"toJSON":      "fn::toJSON",
+}
+
+type generator struct {
+	program     *hcl2.Program
+	diagnostics hcl.Diagnostics
+	// The report that records the fidelity of the conversion, if any.
+	report *codegen.ConversionReport
+}
+
+// GenerateProgram generates a YAML representation of the given PCL program. Constructs that have no YAML
+// representation, e.g. for expressions and ranged resources, are replaced with null values and TODO comments, and
+// reported as errors.
+func GenerateProgram(program *hcl2.Program) (map[string][]byte, hcl.Diagnostics, error) {
+	return GenerateProgramWithOptions(program, codegen.GenerateProgramOptions{})
+}
+
+// GenerateProgramWithOptions generates a YAML representation of the given PCL program. Only the options' Report is
+// supported; YAML programs have no source maps.
+func GenerateProgramWithOptions(program *hcl2.Program,
+	options codegen.GenerateProgramOptions) (map[string][]byte, hcl.Diagnostics, error) {
+
+	g := &generator{program: program, report: options.Report}
+	program.Report(g.report)
+
+	configuration, variables := newMapping(), newMapping()
+	resources, outputs := newMapping(), newMapping()
+	for _, n := range program.Nodes {
+		switch n := n.(type) {
+		case *hcl2.ConfigVariable:
+			addEntry(configuration, n.Name(), g.genConfigVariable(n), leadingComments(n.Definition.Tokens.GetType("")))
+		case *hcl2.LocalVariable:
+			addEntry(variables, n.Name(), g.genExpression(n.Definition.Value),
+				leadingComments(n.Definition.Tokens.GetName(n.Name())))
+			addLineComment(variables, trailingComments(n.Definition.Value.GetTrailingTrivia()))
+		case *hcl2.Resource, *hcl2.Provider:
+			r, _ := hcl2.NodeResource(n)
+			addEntry(resources, r.Name(), g.genResource(r), leadingComments(r.Definition.Tokens.GetType("")))
+		case *hcl2.OutputVariable:
+			addEntry(outputs, n.Name(), g.genExpression(n.Value), leadingComments(n.Definition.Tokens.GetType("")))
+			addLineComment(outputs, trailingComments(n.Value.GetTrailingTrivia()))
+		case *hcl2.Component:
+			g.genNYIBlock(n.SyntaxNode().Range(), n.Name(), "components are not supported")
+		}
+	}
+
+	root := newMapping()
+	for _, section := range []struct {
+		name  string
+		value *yaml.Node
+	}{
+		{"configuration", configuration},
+		{"variables", variables},
+		{"resources", resources},
+		{"outputs", outputs},
+	} {
+		if len(section.value.Content) != 0 {
+			addEntry(root, section.name, section.value, "")
+		}
+	}
+
+	var buf bytes.Buffer
+	if len(root.Content) != 0 {
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(root); err != nil {
+			return nil, nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return map[string][]byte{programFile: buf.Bytes()}, g.diagnostics, nil
+}
+
+// genConfigVariable generates the declaration of a config variable, which records its type and default value.
+func (g *generator) genConfigVariable(v *hcl2.ConfigVariable) *yaml.Node {
+	config := newMapping()
+	addEntry(config, "type", newString(configTypeName(v.Type())), "")
+	if v.DefaultValue != nil {
+		addEntry(config, "default", g.genExpression(v.DefaultValue), "")
+	}
+	return config
+}
+
+// configTypeName returns the name of the given config type.
+func configTypeName(t model.Type) string {
+	t = model.ResolveOutputs(t)
+	if union, ok := t.(*model.UnionType); ok {
+		// Optional types are unions with none.
+		var elements []model.Type
+		for _, e := range union.ElementTypes {
+			if e != model.NoneType {
+				elements = append(elements, e)
+			}
+		}
+		if len(elements) == 1 {
+			t = elements[0]
+		}
+	}
+
+	switch t := t.(type) {
+	case *model.ListType:
+		return fmt.Sprintf("List<%s>", configTypeName(t.ElementType))
+	case *model.MapType:
+		return fmt.Sprintf("Map<%s>", configTypeName(t.ElementType))
+	}
+	switch t {
+	case model.BoolType:
+		return "Boolean"
+	case model.IntType:
+		return "Integer"
+	case model.NumberType:
+		return "Number"
+	case model.StringType:
+		return "String"
+	default:
+		return "Object"
+	}
+}
+
+// genResource generates the declaration of a resource, which records its type, its input properties, and its options.
+func (g *generator) genResource(r *hcl2.Resource) *yaml.Node {
+	resource := newMapping()
+	if r.Component != nil {
+		g.genNYIBlock(r.SyntaxNode().Range(), r.Name(), "instances of components are not supported")
+	}
+	addEntry(resource, "type", newString(r.Token), "")
+
+	if len(r.Inputs) != 0 {
+		properties := newMapping()
+		for _, attr := range r.Inputs {
+			destType, diagnostics := r.InputType.Traverse(hcl.TraverseAttr{Name: attr.Name})
+			g.diagnostics = append(g.diagnostics, diagnostics...)
+			value := hcl2.RewriteSecrets(attr.Value, destType.(model.Type))
+
+			// A comment on the same line as the previous attribute is scanned as trivia of this attribute's name.
+			name := attr.Tokens.GetName(attr.Name)
+			addLineComment(properties, trailingComments(name.LeadingTrivia))
+
+			addEntry(properties, attr.Name, g.genExpression(value), leadingComments(name))
+			addLineComment(properties, trailingComments(attr.Value.GetTrailingTrivia()))
+		}
+		addLineComment(properties, trailingComments(r.Definition.Tokens.GetCloseBrace().LeadingTrivia))
+		addEntry(resource, "properties", properties, "")
+	}
+
+	if options := g.genResourceOptions(r.Options); len(options.Content) != 0 {
+		addEntry(resource, "options", options, "")
+	}
+	return resource
+}
+
+// genResourceOptions generates the options of a resource.
+func (g *generator) genResourceOptions(opts *hcl2.ResourceOptions) *yaml.Node {
+	options := newMapping()
+	if opts == nil {
+		return options
+	}
+
+	if opts.Range != nil {
+		// The resource is declared once, without its range.
+		construct := g.program.NewUnsupportedConstruct(opts.Range, "ranged resources are not supported")
+		g.diagnostics = append(g.diagnostics, construct.Diagnostic())
+		g.report.AddUnsupported(fmt.Sprintf("%s: %s", construct.Reason, construct.Source), construct.Range)
+	}
+	for _, option := range []struct {
+		name string
+		expr model.Expression
+	}{
+		{"parent", opts.Parent},
+		{"provider", opts.Provider},
+		{"dependsOn", opts.DependsOn},
+		{"protect", opts.Protect},
+		{"ignoreChanges", opts.IgnoreChanges},
+		{"aliases", opts.Aliases},
+		{"customTimeouts", opts.CustomTimeouts},
+		{"deleteBeforeReplace", opts.DeleteBeforeReplace},
+		{"version", opts.Version},
+	} {
+		switch {
+		case option.expr == nil:
+		case option.name == "ignoreChanges":
+			addEntry(options, option.name, g.genPropertyPaths(option.expr), "")
+		case option.name == "dependsOn" && !hcl2.IsStaticResourceList(option.expr):
+			// YAML sequences cannot splice in other lists, so a computed list would be emitted as a nested sequence.
+			addEntry(options, option.name, g.genNYI(option.expr, "computed lists of resources are not supported"), "")
+		default:
+			addEntry(options, option.name, g.genExpression(option.expr), "")
+		}
+	}
+	return options
+}
+
+// genPropertyPaths generates the list of property paths, e.g. "tags.Name", that is the value of an ignoreChanges
+// option. The paths are relative to the resource's inputs, and so are not interpolations.
+func (g *generator) genPropertyPaths(expr model.Expression) *yaml.Node {
+	tuple, ok := expr.(*model.TupleConsExpression)
+	if !ok {
+		return g.genNYI(expr, "computed property paths are not supported")
+	}
+
+	paths := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, item := range tuple.Expressions {
+		traversal, ok := item.(*model.ScopeTraversalExpression)
+		if !ok {
+			paths.Content = append(paths.Content, g.genNYI(item, "property paths of type %T are not supported", item))
+			continue
+		}
+		path, ok := formatTraversal(traversal.RootName, traversal.Traversal[1:])
+		if !ok {
+			paths.Content = append(paths.Content, g.genNYI(item, "property paths with computed keys are not supported"))
+			continue
+		}
+		paths.Content = append(paths.Content, newString(path))
+	}
+	return paths
+}
+
+// genExpression generates the YAML representation of the given expression.
+func (g *generator) genExpression(expr model.Expression) *yaml.Node {
+	switch expr := expr.(type) {
+	case *model.LiteralValueExpression:
+		return g.genLiteralValue(expr, expr.Value)
+	case *model.TemplateExpression:
+		if len(expr.Parts) == 1 {
+			if lit, ok := expr.Parts[0].(*model.LiteralValueExpression); ok && lit.Value.Type() == cty.String {
+				return g.genLiteralValue(lit, lit.Value)
+			}
+		}
+
+		var text strings.Builder
+		for _, part := range expr.Parts {
+			if lit, ok := part.(*model.LiteralValueExpression); ok && lit.Value.Type() == cty.String {
+				text.WriteString(escapeInterpolations(lit.Value.AsString()))
+				continue
+			}
+			ref, ok := g.genReference(part)
+			if !ok {
+				if traversal, isTraversal := part.(*model.ScopeTraversalExpression); isTraversal {
+					return g.genNYI(expr, "references to %s are not supported", traversal.RootName)
+				}
+				return g.genNYI(expr, "interpolations of %T are not supported", part)
+			}
+			text.WriteString(ref)
+		}
+		return newString(text.String())
+	case *model.ScopeTraversalExpression:
+		ref, ok := g.genReference(expr)
+		if !ok {
+			return g.genNYI(expr, "references to %s are not supported", expr.RootName)
+		}
+		return newString(ref)
+	case *model.RelativeTraversalExpression:
+		// Only single attributes of the results of invokes may be accessed, using the invoke's return field.
+		call, ok := expr.Source.(*model.FunctionCallExpression)
+		if !ok || call.Name != hcl2.Invoke || len(expr.Traversal) != 1 {
+			return g.genNYI(expr, "traversals of %T are not supported", expr.Source)
+		}
+		attr, ok := expr.Traversal[0].(hcl.TraverseAttr)
+		if !ok {
+			return g.genNYI(expr, "indexing the result of an invoke is not supported")
+		}
+		return g.genInvoke(call, attr.Name)
+	case *model.FunctionCallExpression:
+		if expr.Name == hcl2.Invoke {
+			return g.genInvoke(expr, "")
+		}
+
+		name, ok := builtins[expr.Name]
+		if !ok {
+			return g.genNYI(expr, "function %s is not supported", expr.Name)
+		}
+		var arg *yaml.Node
+		if len(expr.Args) == 1 {
+			arg = g.genExpression(expr.Args[0])
+		} else {
+			arg = newSequence()
+			for _, a := range expr.Args {
+				arg.Content = append(arg.Content, g.genExpression(a))
+			}
+		}
+		call := newMapping()
+		addEntry(call, name, arg, "")
+		return call
+	case *model.ObjectConsExpression:
+		object := newMapping()
+		for _, item := range expr.Items {
+			key, ok := item.Key.(*model.LiteralValueExpression)
+			if !ok || key.Value.Type() != cty.String {
+				if template, isTemplate := item.Key.(*model.TemplateExpression); isTemplate && len(template.Parts) == 1 {
+					key, ok = template.Parts[0].(*model.LiteralValueExpression)
+				}
+			}
+			if !ok || key.Value.Type() != cty.String {
+				return g.genNYI(expr, "objects with computed keys are not supported")
+			}
+			addEntry(object, key.Value.AsString(), g.genExpression(item.Value), "")
+		}
+		return object
+	case *model.TupleConsExpression:
+		tuple := newSequence()
+		for _, x := range expr.Expressions {
+			tuple.Content = append(tuple.Content, g.genExpression(x))
+		}
+		return tuple
+	case *model.ForExpression:
+		return g.genNYI(expr, "for expressions are not supported")
+	case *model.ConditionalExpression:
+		return g.genNYI(expr, "conditional expressions are not supported")
+	case *model.BinaryOpExpression:
+		return g.genNYI(expr, "binary operators are not supported")
+	case *model.UnaryOpExpression:
+		return g.genNYI(expr, "unary operators are not supported")
+	case *model.SplatExpression:
+		return g.genNYI(expr, "splat expressions are not supported")
+	default:
+		return g.genNYI(expr, "%T expressions are not supported", expr)
+	}
+}
+
+// genInvoke generates a call to fn::invoke. If ret is not empty, only the named attribute of the function's result is
+// returned.
+func (g *generator) genInvoke(call *model.FunctionCallExpression, ret string) *yaml.Node {
+	invoke := newMapping()
+
+	template, ok := call.Args[0].(*model.TemplateExpression)
+	if !ok || len(template.Parts) != 1 {
+		return g.genNYI(call, "invokes of computed functions are not supported")
+	}
+	token, ok := template.Parts[0].(*model.LiteralValueExpression)
+	if !ok || token.Value.Type() != cty.String {
+		return g.genNYI(call, "invokes of computed functions are not supported")
+	}
+	addEntry(invoke, "function", newString(token.Value.AsString()), "")
+
+	if len(call.Args) > 1 {
+		addEntry(invoke, "arguments", g.genExpression(call.Args[1]), "")
+	}
+	if len(call.Args) > 2 {
+		addEntry(invoke, "options", g.genExpression(call.Args[2]), "")
+	}
+	if ret != "" {
+		addEntry(invoke, "return", newString(ret), "")
+	}
+
+	result := newMapping()
+	addEntry(result, "fn::invoke", invoke, "")
+	return result
+}
+
+// genReference returns the interpolation that refers to the value of the given expression, which must be a traversal
+// of a config variable, local variable, or resource.
+func (g *generator) genReference(expr model.Expression) (string, bool) {
+	traversal, ok := expr.(*model.ScopeTraversalExpression)
+	if !ok || len(traversal.Parts) == 0 {
+		return "", false
+	}
+	switch traversal.Parts[0].(type) {
+	case *hcl2.ConfigVariable, *hcl2.LocalVariable, *hcl2.Resource, *hcl2.Provider:
+	default:
+		return "", false
+	}
+
+	path, ok := formatTraversal(traversal.RootName, traversal.Traversal[1:])
+	if !ok {
+		return "", false
+	}
+	return "${" + path + "}", true
+}
+
+// formatTraversal formats the given traversal of the named root as a path, e.g. `bucket.tags["Name"]`. It returns
+// false if the traversal has a part that cannot be represented in a path.
+func formatTraversal(root string, traversal hcl.Traversal) (string, bool) {
+	var path strings.Builder
+	path.WriteString(root)
+	for _, t := range traversal {
+		switch t := t.(type) {
+		case hcl.TraverseAttr:
+			fmt.Fprintf(&path, ".%s", t.Name)
+		case hcl.TraverseIndex:
+			switch t.Key.Type() {
+			case cty.Number:
+				fmt.Fprintf(&path, "[%s]", t.Key.AsBigFloat().Text('f', -1))
+			case cty.String:
+				fmt.Fprintf(&path, "[%q]", t.Key.AsString())
+			default:
+				return "", false
+			}
+		default:
+			return "", false
+		}
+	}
+	return path.String(), true
+}
+
+// genLiteralValue generates a YAML scalar for the given literal value.
+func (g *generator) genLiteralValue(expr model.Expression, v cty.Value) *yaml.Node {
+	switch {
+	case v.IsNull():
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case v.Type() == cty.Bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprintf("%v", v.True())}
+	case v.Type() == cty.Number:
+		f := v.AsBigFloat()
+		tag := "!!float"
+		if f.IsInt() {
+			tag = "!!int"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: f.Text('f', -1)}
+	case v.Type() == cty.String:
+		return newString(escapeInterpolations(v.AsString()))
+	default:
+		return g.genNYI(expr, "literals of type %v are not supported", v.Type().FriendlyName())
+	}
+}
+
+// genNYI reports an expression that cannot be translated and returns a null value with a TODO comment in its place.
+func (g *generator) genNYI(expr model.Expression, reason string, vs ...interface{}) *yaml.Node {
+	construct := g.program.NewUnsupportedConstruct(expr, reason, vs...)
+	g.diagnostics = append(g.diagnostics, construct.Diagnostic())
+	construct.Report(g.report)
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null", LineComment: construct.Comment()}
+}
+
+// genNYIBlock reports a declaration that cannot be translated.
+func (g *generator) genNYIBlock(subject hcl.Range, name, reason string) {
+	g.diagnostics = append(g.diagnostics, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "not yet implemented: " + reason,
+		Detail:   fmt.Sprintf("%s could not be translated: %s", name, reason),
+		Subject:  &subject,
+	})
+	g.report.AddUnsupported(fmt.Sprintf("%s: %s", reason, name), &subject)
+}
+
+// escapeInterpolations escapes the interpolation sequences in a literal string so that they are not interpreted.
+func escapeInterpolations(s string) string {
+	return strings.Replace(s, "${", "$${", -1)
+}
+
+// leadingComments returns the text of the comments that precede the given token on lines of their own, if any.
+func leadingComments(token syntax.Token) string {
+	head, _ := splitComments(token.LeadingTrivia)
+	return strings.Join(head, "\n")
+}
+
+// trailingComments returns the text of the comments in the given trivia that continue the line of the preceding
+// token, if any.
+func trailingComments(trivia syntax.TriviaList) string {
+	_, line := splitComments(trivia)
+	return strings.Join(line, " ")
+}
+
+// splitComments splits the comments in the given trivia into those that start on a line of their own and those that
+// follow the preceding token on its line. The trivia that precedes a token begins at the start of a line unless it
+// is on the same line as the preceding token.
+func splitComments(trivia syntax.TriviaList) (head, line []string) {
+	ownLine := len(trivia) != 0 && trivia[0].Range().Start.Column <= 1
+	for _, t := range trivia {
+		switch t := t.(type) {
+		case syntax.Whitespace:
+			ownLine = ownLine || bytes.IndexByte(t.Bytes(), '\n') != -1
+		case syntax.Comment:
+			for _, l := range t.Lines {
+				if ownLine {
+					head = append(head, "#"+l)
+				} else {
+					line = append(line, "#"+l)
+				}
+			}
+			ownLine = bytes.HasSuffix(t.Bytes(), []byte("\n"))
+		}
+	}
+	return head, line
+}
+
+// addLineComment attaches a comment to the line of the last entry in a YAML mapping. A comment that follows a
+// scalar value is appended to any comment that the value already carries.
+func addLineComment(mapping *yaml.Node, comment string) {
+	if comment == "" || len(mapping.Content) == 0 {
+		return
+	}
+
+	key, value := mapping.Content[len(mapping.Content)-2], mapping.Content[len(mapping.Content)-1]
+	switch {
+	case value.Kind != yaml.ScalarNode:
+		key.LineComment = comment
+	case value.LineComment != "":
+		value.LineComment += " " + comment
+	default:
+		value.LineComment = comment
+	}
+}
+
+// newMapping creates a new, empty YAML mapping.
+func newMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// newSequence creates a new, empty YAML sequence.
+func newSequence() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+}
+
+// newString creates a new YAML string. Strings that span multiple lines use the literal block style.
+func newString(s string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+	if strings.Contains(strings.TrimRight(s, "\n"), "\n") {
+		n.Style = yaml.LiteralStyle
+	}
+	return n
+}
+
+// addEntry adds an entry with the given key, value, and comment to a YAML mapping.
+func addEntry(mapping *yaml.Node, key string, value *yaml.Node, comment string) {
+	mapping.Content = append(mapping.Content, &yaml.Node{
+		Kind:        yaml.ScalarNode,
+		Tag:         "!!str",
+		Value:       key,
+		HeadComment: comment,
+	}, value)
+}
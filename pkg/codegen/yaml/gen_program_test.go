@@ -0,0 +1,104 @@
+package yaml
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v2/codegen/internal/test"
+)
+
+var testdataPath = filepath.Join("..", "internal", "test", "testdata")
+
+func TestGenProgram(t *testing.T) {
+	files, err := ioutil.ReadDir(testdataPath)
+	if err != nil {
+		t.Fatalf("could not read test data: %v", err)
+	}
+
+	for _, f := range files {
+		if filepath.Ext(f.Name()) != ".pp" {
+			continue
+		}
+
+		t.Run(f.Name(), func(t *testing.T) {
+			path := filepath.Join(testdataPath, f.Name())
+			contents, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("could not read %v: %v", path, err)
+			}
+			expected, err := ioutil.ReadFile(path + ".yaml")
+			if err != nil {
+				t.Fatalf("could not read %v: %v", path+".yaml", err)
+			}
+
+			parser := syntax.NewParser()
+			err = parser.ParseFile(bytes.NewReader(contents), f.Name())
+			if err != nil {
+				t.Fatalf("could not read %v: %v", path, err)
+			}
+			if parser.Diagnostics.HasErrors() {
+				t.Fatalf("failed to parse files: %v", parser.Diagnostics)
+			}
+
+			program, diags, err := hcl2.BindProgram(parser.Files, hcl2.PluginHost(test.NewHost(testdataPath)))
+			if err != nil {
+				t.Fatalf("could not bind program: %v", err)
+			}
+			if diags.HasErrors() {
+				t.Fatalf("failed to bind program: %v", diags)
+			}
+
+			files, diags, err := GenerateProgram(program)
+			assert.NoError(t, err)
+
+			// Constructs that YAML cannot represent are recorded in the expected output as TODO comments.
+			var errors hcl.Diagnostics
+			for _, d := range diags {
+				if !strings.HasPrefix(d.Summary, "not yet implemented") {
+					errors = append(errors, d)
+				}
+			}
+			if errors.HasErrors() {
+				t.Fatalf("failed to generate program: %v", errors)
+			}
+			assert.Equal(t, string(expected), string(files["Main.yaml"]))
+		})
+	}
+}
+
+func TestGenProgramReport(t *testing.T) {
+	parser := syntax.NewParser()
+	err := parser.ParseFile(strings.NewReader(`
+resource bucket "aws:s3:Bucket" {}
+
+names = [for n in ["a", "b"]: n]
+`), "report.pp")
+	if err != nil || parser.Diagnostics.HasErrors() {
+		t.Fatalf("failed to parse program: %v, %v", err, parser.Diagnostics)
+	}
+	program, diags, err := hcl2.BindProgram(parser.Files, hcl2.PluginHost(test.NewHost(testdataPath)))
+	if err != nil || diags.HasErrors() {
+		t.Fatalf("failed to bind program: %v, %v", err, diags)
+	}
+
+	report := &codegen.ConversionReport{}
+	_, _, err = GenerateProgramWithOptions(program, codegen.GenerateProgramOptions{Report: report})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, report.ResourceCount)
+	assert.Equal(t, 1, report.ConvertedResourceCount)
+	if assert.Len(t, report.Unsupported, 1) {
+		assert.Equal(t, `for expressions are not supported: [for n in ["a", "b"]: n]`, report.Unsupported[0].Summary)
+		assert.Equal(t, "report.pp", report.Unsupported[0].Location.Filename)
+	}
+	assert.Equal(t, 1, report.TODOCount)
+}
@@ -681,7 +681,7 @@ func printPropertyValueDiff(
 }
 
 func isPrimitive(value resource.PropertyValue) bool {
-	return value.IsNull() || value.IsString() || value.IsNumber() ||
+	return value.IsNull() || value.IsString() || value.IsNumber() || value.IsBytes() ||
 		value.IsBool() || value.IsComputed() || value.IsOutput()
 }
 
@@ -692,6 +692,10 @@ func printPrimitivePropertyValue(b io.StringWriter, v resource.PropertyValue, pl
 		writeVerbatim(b, op, "<null>")
 	} else if v.IsBool() {
 		write(b, op, "%t", v.BoolValue())
+	} else if v.IsDecimal() {
+		write(b, op, "%v", v.DecimalValue())
+	} else if v.IsBytes() {
+		write(b, op, "bytes(%d)", len(v.BytesValue()))
 	} else if v.IsNumber() {
 		write(b, op, "%v", v.NumberValue())
 	} else if v.IsString() {
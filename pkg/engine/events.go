@@ -60,6 +60,8 @@ func NewEvent(typ EventType, payload interface{}) Event {
 		_, ok = payload.(ResourceOperationFailedPayload)
 	case PolicyViolationEvent:
 		_, ok = payload.(PolicyViolationEventPayload)
+	case HoldEvent:
+		_, ok = payload.(HoldEventPayload)
 	default:
 		contract.Failf("unknown event type %v", typ)
 	}
@@ -83,6 +85,7 @@ const (
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
 	PolicyViolationEvent    EventType = "policy-violation"
+	HoldEvent               EventType = "hold"
 )
 
 func (e Event) Payload() interface{} {
@@ -116,6 +119,16 @@ type PolicyViolationEventPayload struct {
 	Prefix            string
 }
 
+// HoldEventPayload is the payload for an event with type `hold`, which is emitted when an update reaches a hold point
+// and again when it is released.
+type HoldEventPayload struct {
+	URN        resource.URN      // the resource whose change is held.
+	Changes    int               // the number of resources changed before the hold.
+	Status     deploy.HoldStatus // whether the update is waiting, or was approved, rejected, or timed out.
+	Timeout    time.Duration     // how long the update will wait for approval, or zero to wait indefinitely.
+	Approvable bool              // true if the update may be approved, rather than only time out.
+}
+
 type StdoutEventPayload struct {
 	Message string
 	Color   colors.Colorization
@@ -132,6 +145,14 @@ type SummaryEventPayload struct {
 	Duration        time.Duration     // the duration of the entire update operation (zero values for previews)
 	ResourceChanges ResourceChanges   // count of changed resources, useful for reporting
 	PolicyPacks     map[string]string // {policy-pack: version} for each policy pack applied
+	ConfigUsage     *ConfigUsage      // the config keys used by the program, or nil if the program did not report them
+}
+
+// ConfigUsage describes how a program used the configuration of its stack. Each list of keys is sorted.
+type ConfigUsage struct {
+	Read    []string `json:"read"`              // the keys that the program read
+	Unused  []string `json:"unused,omitempty"`  // the keys in the project's namespace that are set but were not read
+	Missing []string `json:"missing,omitempty"` // the keys that the program read but that are not set
 }
 
 type ResourceOperationFailedPayload struct {
@@ -511,7 +532,27 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 	})
 }
 
-func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, policyPacks map[string]string) {
+func makeConfigUsage(usage *deploy.ConfigUsage) *ConfigUsage {
+	if usage == nil {
+		return nil
+	}
+
+	keyStrings := func(keys []config.Key) []string {
+		var result []string
+		for _, k := range keys {
+			result = append(result, k.String())
+		}
+		return result
+	}
+	return &ConfigUsage{
+		Read:    keyStrings(usage.Read),
+		Unused:  keyStrings(usage.Unused),
+		Missing: keyStrings(usage.Missing),
+	}
+}
+
+func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, policyPacks map[string]string,
+	configUsage *deploy.ConfigUsage) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- NewEvent(SummaryEvent, SummaryEventPayload{
@@ -520,11 +561,13 @@ func (e *eventEmitter) previewSummaryEvent(resourceChanges ResourceChanges, poli
 		Duration:        0,
 		ResourceChanges: resourceChanges,
 		PolicyPacks:     policyPacks,
+		ConfigUsage:     makeConfigUsage(configUsage),
 	})
 }
 
 func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
-	duration time.Duration, resourceChanges ResourceChanges, policyPacks map[string]string) {
+	duration time.Duration, resourceChanges ResourceChanges, policyPacks map[string]string,
+	configUsage *deploy.ConfigUsage) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.ch <- NewEvent(SummaryEvent, SummaryEventPayload{
@@ -533,6 +576,7 @@ func (e *eventEmitter) updateSummaryEvent(maybeCorrupt bool,
 		Duration:        duration,
 		ResourceChanges: resourceChanges,
 		PolicyPacks:     policyPacks,
+		ConfigUsage:     makeConfigUsage(configUsage),
 	})
 }
 
@@ -576,6 +620,18 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 	})
 }
 
+func (e *eventEmitter) holdEvent(hold deploy.Hold, status deploy.HoldStatus) {
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.ch <- NewEvent(HoldEvent, HoldEventPayload{
+		URN:        hold.URN,
+		Changes:    hold.Changes,
+		Status:     status,
+		Timeout:    hold.Point.Timeout,
+		Approvable: hold.Approvable,
+	})
+}
+
 func diagEvent(e *eventEmitter, d *diag.Diag, prefix, msg string, sev diag.Severity,
 	ephemeral bool) {
 	contract.Requiref(e != nil, "e", "!= nil")
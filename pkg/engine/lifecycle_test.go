@@ -23,6 +23,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/mitchellh/copystructure"
@@ -571,7 +572,7 @@ func TestEmptyProgramLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   MakeBasicLifecycleSteps(t, 0),
 	}
 	p.Run(t, nil)
@@ -592,7 +593,7 @@ func TestSingleResourceDefaultProviderLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   MakeBasicLifecycleSteps(t, 2),
 	}
 	p.Run(t, nil)
@@ -626,7 +627,7 @@ func TestSingleResourceExplicitProviderLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   MakeBasicLifecycleSteps(t, 2),
 	}
 	p.Run(t, nil)
@@ -647,7 +648,7 @@ func TestSingleResourceDefaultProviderUpgrade(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	provURN := p.NewProviderURN("pkgA", "default", "")
@@ -753,7 +754,7 @@ func TestSingleResourceDefaultProviderReplace(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Config: config.Map{
 			config.MustMakeKey("pkgA", "foo"): config.NewValue("bar"),
 		},
@@ -848,7 +849,7 @@ func TestSingleResourceExplicitProviderReplace(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	// Build a basic lifecycle.
@@ -939,7 +940,7 @@ func TestSingleResourceExplicitProviderDeleteBeforeReplace(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	// Build a basic lifecycle.
@@ -1032,7 +1033,7 @@ func TestSingleResourceDiffUnavailable(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
 
@@ -1075,7 +1076,7 @@ func TestDestroyWithPendingDelete(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
@@ -1149,7 +1150,7 @@ func TestUpdateWithPendingDelete(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, nil, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
@@ -1246,7 +1247,7 @@ func TestParallelRefresh(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{Parallel: 4, host: host},
+		Options: UpdateOptions{Parallel: 4, Host: host},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -1288,7 +1289,7 @@ func TestExternalRefresh(t *testing.T) {
 	})
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Update}},
 	}
 
@@ -1300,7 +1301,7 @@ func TestExternalRefresh(t *testing.T) {
 	assert.True(t, snap.Resources[1].External)
 
 	p = &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Refresh}},
 	}
 
@@ -1357,7 +1358,7 @@ func TestRefreshInitFailure(t *testing.T) {
 	})
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
-	p.Options.host = host
+	p.Options.Host = host
 
 	//
 	// Create an old snapshot with a single initialization failure.
@@ -1446,7 +1447,7 @@ func TestCheckFailureRecord(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps: []TestStep{{
 			Op:            Update,
 			ExpectFailure: true,
@@ -1496,7 +1497,7 @@ func TestCheckFailureInvalidPropertyRecord(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps: []TestStep{{
 			Op:            Update,
 			ExpectFailure: true,
@@ -1552,7 +1553,7 @@ func TestRefreshWithDelete(t *testing.T) {
 			})
 
 			host := deploytest.NewPluginHost(nil, nil, program, loaders...)
-			p := &TestPlan{Options: UpdateOptions{host: host, Parallel: parallelFactor}}
+			p := &TestPlan{Options: UpdateOptions{Host: host, Parallel: parallelFactor}}
 
 			p.Steps = []TestStep{{Op: Update}}
 			snap := p.Run(t, nil)
@@ -1662,7 +1663,7 @@ func validateRefreshDeleteCombination(t *testing.T, names []string, targets []st
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, nil, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, nil, loaders...)
 
 	p.Steps = []TestStep{
 		{
@@ -1832,7 +1833,7 @@ func validateRefreshBasicsCombination(t *testing.T, names []string, targets []st
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, nil, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, nil, loaders...)
 
 	p.Steps = []TestStep{{
 		Op: Refresh,
@@ -1984,7 +1985,7 @@ func TestCanceledRefresh(t *testing.T) {
 	op := TestOp(Refresh)
 	options := UpdateOptions{
 		Parallel: 1,
-		host:     deploytest.NewPluginHost(nil, nil, nil, loaders...),
+		Host:     deploytest.NewPluginHost(nil, nil, nil, loaders...),
 	}
 	project, target := p.GetProject(), p.GetTarget(old)
 	validate := func(project workspace.Project, target deploy.Target, j *Journal,
@@ -2078,7 +2079,7 @@ func TestLanguageHostDiagnostics(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps: []TestStep{{
 			Op:            Update,
 			ExpectFailure: true,
@@ -2133,7 +2134,7 @@ func TestBrokenDecrypter(t *testing.T) {
 	configMap := make(config.Map)
 	configMap[key] = config.NewSecureValue("hunter2")
 	p := &TestPlan{
-		Options:   UpdateOptions{host: host},
+		Options:   UpdateOptions{Host: host},
 		Decrypter: brokenDecrypter{ErrorMessage: msg},
 		Config:    configMap,
 		Steps: []TestStep{{
@@ -2189,7 +2190,7 @@ func TestBadResourceType(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps: []TestStep{{
 			Op:            Update,
 			ExpectFailure: true,
@@ -2261,7 +2262,7 @@ func TestProviderCancellation(t *testing.T) {
 	op := TestOp(Update)
 	options := UpdateOptions{
 		Parallel: resourceCount,
-		host:     deploytest.NewPluginHost(nil, nil, program, loaders...),
+		Host:     deploytest.NewPluginHost(nil, nil, program, loaders...),
 	}
 	project, target := p.GetProject(), p.GetTarget(nil)
 
@@ -2315,7 +2316,7 @@ func TestPreviewWithPendingOperations(t *testing.T) {
 	})
 
 	op := TestOp(Update)
-	options := UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
+	options := UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
 	project, target := p.GetProject(), p.GetTarget(old)
 
 	// A preview should succeed despite the pending operations.
@@ -2363,7 +2364,7 @@ func TestUpdatePartialFailure(t *testing.T) {
 	})
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
-	p := &TestPlan{Options: UpdateOptions{host: host}}
+	p := &TestPlan{Options: UpdateOptions{Host: host}}
 
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
 	p.Steps = []TestStep{{
@@ -2433,7 +2434,14 @@ func TestStackReference(t *testing.T) {
 		})
 		assert.NoError(t, err)
 		if !info.DryRun {
-			assert.Equal(t, "bar", state["outputs"].ObjectValue()["foo"].StringValue())
+			outputs := state["outputs"].ObjectValue()
+			assert.Equal(t, "bar", outputs["foo"].StringValue())
+
+			// Internal outputs are withheld, but their names are reported.
+			assert.NotContains(t, outputs, resource.PropertyKey("baz"))
+			assert.NotContains(t, outputs, resource.InternalStackOutputsKey)
+			assert.Equal(t, []resource.PropertyValue{resource.NewStringProperty("baz")},
+				state["internalOutputNames"].ArrayValue())
 		}
 		return nil
 	})
@@ -2443,14 +2451,16 @@ func TestStackReference(t *testing.T) {
 				switch name {
 				case "other":
 					return resource.NewPropertyMapFromMap(map[string]interface{}{
-						"foo": "bar",
+						"foo":                                    "bar",
+						"baz":                                    "qux",
+						string(resource.InternalStackOutputsKey): []interface{}{"baz"},
 					}), nil
 				default:
 					return nil, errors.Errorf("unknown stack \"%s\"", name)
 				}
 			},
 		},
-		Options: UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
+		Options: UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)},
 		Steps:   MakeBasicLifecycleSteps(t, 2),
 	}
 	p.Run(t, nil)
@@ -2508,7 +2518,7 @@ func TestStackReference(t *testing.T) {
 		assert.Error(t, err)
 		return err
 	})
-	p.Options = UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
+	p.Options = UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
 	p.Steps = []TestStep{{
 		Op:            Update,
 		ExpectFailure: true,
@@ -2527,7 +2537,7 @@ func TestStackReference(t *testing.T) {
 		assert.Error(t, err)
 		return err
 	})
-	p.Options = UpdateOptions{host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
+	p.Options = UpdateOptions{Host: deploytest.NewPluginHost(nil, nil, program, loaders...)}
 	p.Run(t, nil)
 }
 
@@ -2624,7 +2634,7 @@ func TestLoadFailureShutdown(t *testing.T) {
 
 	op := TestOp(Update)
 	sink := diag.DefaultSink(sinkWriter, sinkWriter, diag.FormatOptions{Color: colors.Raw})
-	options := UpdateOptions{host: deploytest.NewPluginHost(sink, sink, program, loaders...)}
+	options := UpdateOptions{Host: deploytest.NewPluginHost(sink, sink, program, loaders...)}
 	project, target := p.GetProject(), p.GetTarget(old)
 
 	_, res := op.Run(project, target, options, true, nil, nil)
@@ -2797,7 +2807,7 @@ func TestDeleteBeforeReplace(t *testing.T) {
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -2879,7 +2889,7 @@ func TestPropertyDependenciesAdapter(t *testing.T) {
 
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Update}},
 	}
 	snap := p.Run(t, nil)
@@ -2965,7 +2975,7 @@ func TestExplicitDeleteBeforeReplace(t *testing.T) {
 		return nil
 	})
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Steps = []TestStep{{Op: Update}}
 	snap := p.Run(t, nil)
 
@@ -3151,7 +3161,7 @@ func TestSingleResourceIgnoreChanges(t *testing.T) {
 		})
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -3244,7 +3254,7 @@ func TestDefaultProviderDiff(t *testing.T) {
 		})
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -3365,7 +3375,7 @@ func TestDefaultProviderDiffReplacement(t *testing.T) {
 		})
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -3486,7 +3496,7 @@ func TestAliases(t *testing.T) {
 		})
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -3785,7 +3795,7 @@ func TestPersistentDiff(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
 
@@ -3866,7 +3876,7 @@ func TestDetailedDiffReplace(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
 
@@ -3957,7 +3967,7 @@ func TestImport(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	provURN := p.NewProviderURN("pkgA", "default", "")
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
@@ -4201,7 +4211,7 @@ func TestImportWithDifferingImportIdentifierFormat(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	provURN := p.NewProviderURN("pkgA", "default", "")
 	resURN := p.NewURN("pkgA:m:typA", "resA", "")
@@ -4260,7 +4270,7 @@ func TestCustomTimeouts(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4303,7 +4313,7 @@ func TestProviderDiffMissingOldOutputs(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Config: config.Map{
 			config.MustMakeKey("pkgA", "foo"): config.NewValue("bar"),
 		},
@@ -4395,7 +4405,7 @@ func TestRefreshStepWillPersistUpdatedIDs(t *testing.T) {
 	})
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
-	p.Options.host = host
+	p.Options.Host = host
 
 	old := &deploy.Snapshot{
 		Resources: []*resource.State{
@@ -4446,7 +4456,7 @@ func TestMissingRead(t *testing.T) {
 	})
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Update, ExpectFailure: true}},
 	}
 	p.Run(t, nil)
@@ -4484,7 +4494,7 @@ func TestImportUpdatedID(t *testing.T) {
 		assert.Equal(t, actualID, id)
 		return nil
 	})
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p.Steps = []TestStep{{Op: Refresh, SkipPreview: true}}
 	snap := p.Run(t, nil)
@@ -4575,7 +4585,7 @@ func destroySpecificTargets(
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Options.TargetDependents = targetDependents
 
 	destroyTargets := []resource.URN{}
@@ -4669,7 +4679,7 @@ func updateSpecificTargets(t *testing.T, targets []string) {
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	updateTargets := []resource.URN{}
 	for _, target := range targets {
@@ -4743,7 +4753,7 @@ func updateInvalidTarget(t *testing.T) {
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p.Options.UpdateTargets = []resource.URN{"foo"}
 	t.Logf("Updating invalid targets: %v", p.Options.UpdateTargets)
@@ -4771,7 +4781,7 @@ func TestCreateDuringTargetedUpdate_CreateMentionedAsTarget(t *testing.T) {
 	host1 := deploytest.NewPluginHost(nil, nil, program1, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host1},
+		Options: UpdateOptions{Host: host1},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4791,7 +4801,7 @@ func TestCreateDuringTargetedUpdate_CreateMentionedAsTarget(t *testing.T) {
 
 	resA := p.NewURN("pkgA:m:typA", "resA", "")
 	resB := p.NewURN("pkgA:m:typA", "resB", "")
-	p.Options.host = host2
+	p.Options.Host = host2
 	p.Options.UpdateTargets = []resource.URN{resA, resB}
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -4831,7 +4841,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateNotReferenced(t *testing.T)
 	host1 := deploytest.NewPluginHost(nil, nil, program1, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host1},
+		Options: UpdateOptions{Host: host1},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4851,7 +4861,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateNotReferenced(t *testing.T)
 
 	resA := p.NewURN("pkgA:m:typA", "resA", "")
 
-	p.Options.host = host2
+	p.Options.Host = host2
 	p.Options.UpdateTargets = []resource.URN{resA}
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -4888,7 +4898,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateReferencedByTarget(t *testin
 	host1 := deploytest.NewPluginHost(nil, nil, program1, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host1},
+		Options: UpdateOptions{Host: host1},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4913,7 +4923,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateReferencedByTarget(t *testin
 	})
 	host2 := deploytest.NewPluginHost(nil, nil, program2, loaders...)
 
-	p.Options.host = host2
+	p.Options.Host = host2
 	p.Options.UpdateTargets = []resource.URN{resA}
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -4937,7 +4947,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateReferencedByUntargetedCreate
 	host1 := deploytest.NewPluginHost(nil, nil, program1, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host1},
+		Options: UpdateOptions{Host: host1},
 	}
 
 	p.Steps = []TestStep{{Op: Update}}
@@ -4965,7 +4975,7 @@ func TestCreateDuringTargetedUpdate_UntargetedCreateReferencedByUntargetedCreate
 	})
 	host2 := deploytest.NewPluginHost(nil, nil, program2, loaders...)
 
-	p.Options.host = host2
+	p.Options.Host = host2
 	p.Options.UpdateTargets = []resource.URN{resA}
 	p.Steps = []TestStep{{
 		Op:            Update,
@@ -5041,7 +5051,7 @@ func TestDependencyChangeDBR(t *testing.T) {
 		return nil
 	})
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Steps = []TestStep{{Op: Update}}
 	snap := p.Run(t, nil)
 
@@ -5060,7 +5070,7 @@ func TestDependencyChangeDBR(t *testing.T) {
 		return nil
 	})
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Steps = []TestStep{
 		{
 			Op: Update,
@@ -5123,7 +5133,7 @@ func TestReplaceSpecificTargets(t *testing.T) {
 		}),
 	}
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	getURN := func(name string) resource.URN {
 		return pickURN(t, urns, complexTestDependencyGraphNames, name)
@@ -5169,6 +5179,403 @@ func TestReplaceSpecificTargets(t *testing.T) {
 	p.Run(t, old)
 }
 
+func TestProtectedReplaceTypes(t *testing.T) {
+	p := &TestPlan{}
+
+	urns, old, program := generateComplexTestDependencyGraph(t, p)
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+					ignoreChanges []string) (plugin.DiffResult, error) {
+
+					return plugin.DiffResult{Changes: plugin.DiffNone}, nil
+				},
+			}, nil
+		}),
+	}
+
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.ReplaceTargets = []resource.URN{pickURN(t, urns, complexTestDependencyGraphNames, "F")}
+	p.Options.ProtectedReplaceTypes = map[tokens.Type]bool{"pkgA:m:typA": true}
+
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+			evts []Event, res result.Result) result.Result {
+
+			assert.NotNil(t, res)
+			for _, entry := range j.Entries {
+				assert.NotEqual(t, deploy.OpReplace, entry.Step.Op())
+				assert.NotEqual(t, deploy.OpCreateReplacement, entry.Step.Op())
+			}
+			return res
+		},
+	}}
+
+	p.Run(t, old)
+}
+
+type holdApproverFunc func(ctx context.Context, hold deploy.Hold) (bool, error)
+
+func (f holdApproverFunc) Approve(ctx context.Context, hold deploy.Hold) (bool, error) {
+	return f(ctx, hold)
+}
+
+func TestHoldPoints(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"resA", "resB", "resC"} {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", name, true)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	var holds []deploy.Hold
+	approve := false
+	p := &TestPlan{
+		Options: UpdateOptions{
+			Host:  host,
+			Holds: []deploy.HoldPoint{{AfterChanges: 2}},
+			HoldApprover: holdApproverFunc(func(_ context.Context, hold deploy.Hold) (bool, error) {
+				holds = append(holds, hold)
+				return approve, nil
+			}),
+		},
+	}
+	project := p.GetProject()
+
+	countCreates := func(j *Journal) int {
+		creates := 0
+		for _, entry := range j.Entries {
+			if entry.Kind == JournalEntrySuccess && entry.Step.Op() == deploy.OpCreate &&
+				!providers.IsProviderType(entry.Step.Type()) {
+				creates++
+			}
+		}
+		return creates
+	}
+
+	// A rejected hold stops the update before the third resource is created.
+	_, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, j *Journal, evts []Event, res result.Result) result.Result {
+			assert.Equal(t, 2, countCreates(j))
+			return res
+		})
+	assert.NotNil(t, res)
+	assert.Len(t, holds, 1)
+	assert.Equal(t, 2, holds[0].Changes)
+	assert.True(t, holds[0].Approvable)
+	assert.Equal(t, "resC", string(holds[0].URN.Name()))
+
+	// An approved hold lets the update complete.
+	holds, approve = nil, true
+	_, res = TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, j *Journal, evts []Event, res result.Result) result.Result {
+			assert.Equal(t, 3, countCreates(j))
+
+			var statuses []deploy.HoldStatus
+			for _, evt := range evts {
+				if evt.Type == HoldEvent {
+					statuses = append(statuses, evt.Payload().(HoldEventPayload).Status)
+				}
+			}
+			assert.Equal(t, []deploy.HoldStatus{deploy.HoldWaiting, deploy.HoldApproved}, statuses)
+			return res
+		})
+	assert.Nil(t, res)
+	assert.Len(t, holds, 1)
+
+	// Previews are never held.
+	holds = nil
+	_, res = TestOp(Update).Run(project, p.GetTarget(nil), p.Options, true, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, holds, 0)
+}
+
+func TestHoldPointTimeout(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{Options: UpdateOptions{Host: host}}
+	p.Options.Holds = []deploy.HoldPoint{{Before: p.NewURN("pkgA:m:typA", "resA", ""), Timeout: time.Millisecond}}
+	project := p.GetProject()
+
+	// Without an approver, a hold that does not continue on timeout fails the update.
+	_, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+
+	// A timed pause continues once its timeout elapses.
+	p.Options.Holds[0].ContinueOnTimeout = true
+	_, res = TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+}
+
+func TestHoldPointCanceled(t *testing.T) {
+	var mu sync.Mutex
+	var created []string
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					mu.Lock()
+					created = append(created, string(urn.Name()))
+					mu.Unlock()
+					return resource.ID(urn.Name()), news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"resA", "resB"} {
+			if _, _, _, err := monitor.RegisterResource("pkgA:m:typA", name, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	// Cancel the update while it is held before the second resource.
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &TestPlan{Options: UpdateOptions{Host: host}}
+	p.Options.Holds = []deploy.HoldPoint{{Before: p.NewURN("pkgA:m:typA", "resB", "")}}
+	p.Options.HoldApprover = holdApproverFunc(func(holdCtx context.Context, _ deploy.Hold) (bool, error) {
+		cancel()
+		<-holdCtx.Done()
+		return false, holdCtx.Err()
+	})
+	project := p.GetProject()
+
+	_, res := TestOp(Update).RunWithContext(ctx, project, p.GetTarget(nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, j *Journal, _ []Event, res result.Result) result.Result {
+			for _, entry := range j.Entries {
+				assert.NotEqual(t, "resB", string(entry.Step.URN().Name()))
+			}
+			return res
+		})
+	assertIsErrorOrBailResult(t, res)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"resA"}, created)
+}
+
+func TestSerializationGroups(t *testing.T) {
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					mu.Lock()
+					active++
+					if active > maxActive {
+						maxActive = active
+					}
+					mu.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					mu.Lock()
+					active--
+					mu.Unlock()
+					return resource.ID(urn.Name()), news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	// Register the resources concurrently, so that only their serialization group keeps them from being created at
+	// the same time.
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		var wg sync.WaitGroup
+		errs := make(chan error, 4)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, _, _, err := monitor.RegisterResource("pkgA:m:typA", fmt.Sprintf("res%d", i), true,
+					deploytest.ResourceOptions{SerializationGroup: "dns"})
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps:   []TestStep{{Op: Update, SkipPreview: true}},
+	}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 5)
+	assert.Equal(t, 1, maxActive)
+}
+
+func TestSerializationGroupDeletes(t *testing.T) {
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+
+					mu.Lock()
+					active++
+					if active > maxActive {
+						maxActive = active
+					}
+					mu.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					mu.Lock()
+					active--
+					mu.Unlock()
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	register := true
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		if !register {
+			return nil
+		}
+		for i := 0; i < 4; i++ {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", fmt.Sprintf("res%d", i), true,
+				deploytest.ResourceOptions{SerializationGroup: "dns"})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{Options: UpdateOptions{Host: host, Parallel: 4}}
+	project := p.GetProject()
+
+	// The resources' group is recorded in their state.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	for _, r := range snap.Resources {
+		if !providers.IsProviderType(r.Type) {
+			assert.Equal(t, "dns", r.SerializationGroup)
+		}
+	}
+
+	// Once the program stops registering the resources, they are deleted one at a time.
+	register = false
+	_, res = TestOp(Update).Run(project, p.GetTarget(snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Equal(t, 1, maxActive)
+}
+
+func TestRefreshAdoptOrphans(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap,
+					timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					return "resA-id", news, resource.StatusOK, nil
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+
+					// Only "resA" has children; the adopted child itself has none.
+					var children []plugin.ReadChild
+					if urn.Type() == "pkgA:m:typA" {
+						children = []plugin.ReadChild{{
+							Type:    "pkgA:m:typB",
+							Name:    "node-1",
+							ID:      "node-1-id",
+							Outputs: resource.PropertyMap{"size": resource.NewNumberProperty(2)},
+						}}
+					}
+					return plugin.ReadResult{ID: id, Inputs: inputs, Outputs: state, Children: children},
+						resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps:   []TestStep{{Op: Update}},
+	}
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 2)
+	resA := snap.Resources[1]
+
+	// Without --adopt-orphans, the child is reported but not recorded.
+	p.Steps = []TestStep{{Op: Refresh}}
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 2)
+
+	// With --adopt-orphans, the child is recorded as an external resource beneath its parent.
+	p.Options.AdoptOrphans = true
+	snap = p.Run(t, snap)
+	if !assert.Len(t, snap.Resources, 3) {
+		t.FailNow()
+	}
+	child := snap.Resources[2]
+	assert.Equal(t, p.NewURN("pkgA:m:typB", "node-1", resA.URN), child.URN)
+	assert.Equal(t, resource.ID("node-1-id"), child.ID)
+	assert.Equal(t, resA.URN, child.Parent)
+	assert.Equal(t, resA.Provider, child.Provider)
+	assert.True(t, child.Custom)
+	assert.True(t, child.External)
+	assert.Equal(t, resource.PropertyMap{"size": resource.NewNumberProperty(2)}, child.Outputs)
+
+	// A second refresh must not adopt the same child twice.
+	snap = p.Run(t, snap)
+	assert.Len(t, snap.Resources, 3)
+}
+
 func TestPreviewInputPropagation(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
 		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
@@ -5214,7 +5621,7 @@ func TestPreviewInputPropagation(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 
 	project := p.GetProject()
@@ -5307,12 +5714,77 @@ func TestSingleResourceDefaultProviderGolangLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   MakeBasicLifecycleSteps(t, 4),
 	}
 	p.Run(t, nil)
 }
 
+func TestCustomAwaitGolangLifecycle(t *testing.T) {
+	created := false
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				// Custom await options are not resource inputs, so Check never sees them.
+				CheckF: func(urn resource.URN,
+					olds, news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+					_, ok := news[resource.CustomAwaitKey]
+					assert.False(t, ok)
+					return news, nil, nil
+				},
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap, timeout float64) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					await, ok := resource.GetCustomAwait(news)
+					assert.True(t, ok)
+					assert.Equal(t, resource.CustomAwait{Skip: true, Conditions: []string{"Ready"}}, await)
+					created = true
+
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(info plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		ctx, err := pulumi.NewContext(context.Background(), pulumi.RunInfo{
+			Project:     info.Project,
+			Stack:       info.Stack,
+			Parallel:    info.Parallel,
+			DryRun:      info.DryRun,
+			MonitorAddr: info.MonitorAddress,
+		})
+		assert.NoError(t, err)
+
+		return pulumi.RunWithContext(ctx, func(ctx *pulumi.Context) error {
+			var resA testResource
+			err := ctx.RegisterResource("pkgA:m:typA", "resA", &testResourceInputs{
+				Foo: pulumi.String("bar"),
+			}, &resA, pulumi.Await(&pulumi.CustomAwait{Skip: true, Conditions: []string{"Ready"}}))
+			assert.NoError(t, err)
+			return nil
+		})
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.True(t, created)
+
+	// The options are not stored in the resource's state either.
+	for _, r := range snap.Resources {
+		_, ok := r.Inputs[resource.CustomAwaitKey]
+		assert.False(t, ok)
+		_, ok = r.Outputs[resource.CustomAwaitKey]
+		assert.False(t, ok)
+	}
+}
+
 // Inspired by transformations_test.go.
 func TestSingleResourceDefaultProviderGolangTransformations(t *testing.T) {
 	loaders := []*deploytest.ProviderLoader{
@@ -5446,7 +5918,7 @@ func TestSingleResourceDefaultProviderGolangTransformations(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 	}
 	p.Steps = []TestStep{{
 		Op: Update,
@@ -5558,7 +6030,7 @@ func TestIgnoreChangesGolangLifecycle(t *testing.T) {
 
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -5652,7 +6124,7 @@ func TestExplicitDeleteBeforeReplaceGoSDK(t *testing.T) {
 
 	})
 
-	p.Options.host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
 	p.Steps = []TestStep{{Op: Update}}
 	snap := p.Run(t, nil)
 
@@ -5741,7 +6213,7 @@ func TestReadResourceGolangLifecycle(t *testing.T) {
 
 		host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 		p := &TestPlan{
-			Options: UpdateOptions{host: host},
+			Options: UpdateOptions{Host: host},
 			Steps: []TestStep{
 				{
 					Op: Update,
@@ -5931,8 +6403,59 @@ func TestProviderInheritanceGolangLifecycle(t *testing.T) {
 	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
 
 	p := &TestPlan{
-		Options: UpdateOptions{host: host},
+		Options: UpdateOptions{Host: host},
 		Steps:   []TestStep{{Op: Update}},
 	}
 	p.Run(t, nil)
 }
+
+func TestConfigUsage(t *testing.T) {
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+
+		_, failures, err := monitor.Invoke("pulumi:pulumi:reportConfigUsage", resource.NewPropertyMapFromMap(
+			map[string]interface{}{"keys": []interface{}{"test:used", "test:missing", "pkgA:foo"}}), "", "")
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Config: config.Map{
+			config.MustMakeKey("test", "used"):   config.NewValue("a"),
+			config.MustMakeKey("test", "unused"): config.NewValue("b"),
+			config.MustMakeKey("pkgA", "foo"):    config.NewValue("c"),
+			config.MustMakeKey("pkgA", "bar"):    config.NewValue("d"),
+		},
+		Steps: []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, j *Journal,
+				evts []Event, res result.Result) result.Result {
+
+				var usage *ConfigUsage
+				for _, evt := range evts {
+					if evt.Type == SummaryEvent {
+						usage = evt.Payload().(SummaryEventPayload).ConfigUsage
+					}
+				}
+
+				assert.Equal(t, &ConfigUsage{
+					Read:    []string{"pkgA:foo", "test:missing", "test:used"},
+					Unused:  []string{"test:unused"},
+					Missing: []string{"test:missing"},
+				}, usage)
+				return res
+			},
+		}},
+	}
+	p.Run(t, nil)
+}
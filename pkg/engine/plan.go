@@ -104,7 +104,7 @@ type planOptions struct {
 
 // planSourceFunc is a callback that will be used to prepare for, and evaluate, the "new" state for a stack.
 type planSourceFunc func(
-	client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
+	cancel context.Context, client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
 	target *deploy.Target, plugctx *plugin.Context, dryRun bool) (deploy.Source, error)
 
 // plan just uses the standard logic to parse arguments, options, and to create a snapshot and plan.
@@ -119,16 +119,28 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	contract.Assert(proj != nil)
 	contract.Assert(target != nil)
 	projinfo := &Projinfo{Proj: proj, Root: info.Update.GetRoot()}
-	pwd, main, plugctx, err := ProjectInfoContext(projinfo, opts.host, target,
+	pwd, main, plugctx, err := ProjectInfoContext(projinfo, opts.Host, target,
 		opts.Diag, opts.StatusDiag, info.TracingSpan)
 	if err != nil {
 		return nil, err
 	}
 
 	opts.trustDependencies = proj.TrustResourceDependencies()
+
+	// Creating the source may download plugins; abandon those downloads if the operation is canceled.
+	sourceCtx, cancelSource := context.WithCancel(context.Background())
+	defer cancelSource()
+	go func() {
+		select {
+		case <-ctx.Cancel.Canceled():
+			cancelSource()
+		case <-sourceCtx.Done():
+		}
+	}()
+
 	// Now create the state source.  This may issue an error if it can't create the source.  This entails,
 	// for example, loading any plugins which will be required to execute a program, among other things.
-	source, err := opts.SourceFunc(ctx.BackendClient, opts, proj, pwd, main, target, plugctx, dryRun)
+	source, err := opts.SourceFunc(sourceCtx, ctx.BackendClient, opts, proj, pwd, main, target, plugctx, dryRun)
 	if err != nil {
 		contract.IgnoreClose(plugctx)
 		return nil, err
@@ -137,7 +149,7 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	// Generate a plan; this API handles all interesting cases (create, update, delete).
 	localPolicyPackPaths := ConvertLocalPolicyPacksToPaths(opts.LocalPolicyPacks)
 	plan, err := deploy.NewPlan(
-		plugctx, target, target.Snapshot, source, localPolicyPackPaths, dryRun, ctx.BackendClient)
+		plugctx, target, target.Snapshot, source, localPolicyPackPaths, dryRun, ctx.BackendClient, opts.ProviderConfigs)
 	if err != nil {
 		contract.IgnoreClose(plugctx)
 		return nil, err
@@ -183,12 +195,17 @@ func (planResult *planResult) Walk(cancelCtx *Context, events deploy.Events, pre
 			Refresh:           planResult.Options.Refresh,
 			RefreshOnly:       planResult.Options.isRefresh,
 			RefreshTargets:    planResult.Options.RefreshTargets,
+			AdoptOrphans:      planResult.Options.AdoptOrphans,
 			ReplaceTargets:    planResult.Options.ReplaceTargets,
 			DestroyTargets:    planResult.Options.DestroyTargets,
 			UpdateTargets:     planResult.Options.UpdateTargets,
 			TargetDependents:  planResult.Options.TargetDependents,
 			TrustDependencies: planResult.Options.trustDependencies,
 			UseLegacyDiff:     planResult.Options.UseLegacyDiff,
+
+			ProtectedReplaceTypes: planResult.Options.ProtectedReplaceTypes,
+			Holds:                 planResult.Options.Holds,
+			HoldApprover:          planResult.Options.HoldApprover,
 		}
 		walkResult = planResult.Plan.Execute(ctx, opts, preview)
 		close(done)
@@ -230,7 +247,7 @@ func printPlan(ctx *Context, planResult *planResult, dryRun bool, policies map[s
 
 	// Emit an event with a summary of operation counts.
 	changes := ResourceChanges(actions.Ops)
-	planResult.Options.Events.previewSummaryEvent(changes, policies)
+	planResult.Options.Events.previewSummaryEvent(changes, policies, planResult.Plan.ConfigUsage())
 
 	if res != nil {
 
@@ -353,6 +370,10 @@ func (acts *planActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDia
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
 
+func (acts *planActions) OnHold(hold deploy.Hold, status deploy.HoldStatus) {
+	acts.Opts.Events.holdEvent(hold, status)
+}
+
 func assertSeen(seen map[resource.URN]deploy.Step, step deploy.Step) {
 	_, has := seen[step.URN()]
 	contract.Assertf(has, "URN '%v' had not been marked as seen", step.URN())
@@ -15,17 +15,21 @@
 package engine
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"sort"
+	"time"
 
 	"github.com/blang/semver"
+	"github.com/dustin/go-humanize"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
-	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
@@ -36,6 +40,9 @@ const (
 	preparePluginVerboseLog = 8
 )
 
+// maxConcurrentPluginDownloads is the maximum number of plugins that ensurePluginsAreInstalled will download at once.
+const maxConcurrentPluginDownloads = 4
+
 // pluginSet represents a set of plugins.
 type pluginSet map[string]workspace.PluginInfo
 
@@ -121,11 +128,13 @@ func gatherPluginsFromSnapshot(plugctx *plugin.Context, target *deploy.Target) (
 }
 
 // ensurePluginsAreInstalled inspects all plugins in the plugin set and, if any plugins are not currently installed,
-// uses the given backend client to install them. Installations are processed in parallel, though
-// ensurePluginsAreInstalled does not return until all installations are completed.
-func ensurePluginsAreInstalled(plugins pluginSet) error {
+// uses the given backend client to install them. Installations are processed in parallel, up to
+// maxConcurrentPluginDownloads at a time, though ensurePluginsAreInstalled does not return until all installations are
+// completed. Downloads that are still in progress when the given context is canceled are abandoned.
+func ensurePluginsAreInstalled(cancel context.Context, plugctx *plugin.Context, plugins pluginSet) error {
 	logging.V(preparePluginLog).Infof("ensurePluginsAreInstalled(): beginning")
 	var installTasks errgroup.Group
+	downloads := make(chan struct{}, maxConcurrentPluginDownloads)
 	for _, plug := range plugins.Values() {
 		_, path, err := workspace.GetPluginPath(plug.Kind, plug.Name, plug.Version)
 		if err == nil && path != "" {
@@ -137,9 +146,16 @@ func ensurePluginsAreInstalled(plugins pluginSet) error {
 		// Launch an install task asynchronously and add it to the current error group.
 		info := plug // don't close over the loop induction variable
 		installTasks.Go(func() error {
+			select {
+			case downloads <- struct{}{}:
+				defer func() { <-downloads }()
+			case <-cancel.Done():
+				return cancel.Err()
+			}
+
 			logging.V(preparePluginLog).Infof(
 				"ensurePluginsAreInstalled(): plugin %s %s not installed, doing install", info.Name, info.Version)
-			return installPlugin(info)
+			return installPlugin(cancel, plugctx, info)
 		})
 	}
 
@@ -148,14 +164,26 @@ func ensurePluginsAreInstalled(plugins pluginSet) error {
 	return err
 }
 
+// recordPluginUsage records that the given stack used the given plugins, so that `pulumi plugin gc` can tell which
+// stacks last used each plugin and which plugins have gone unused. Recording is best-effort.
+func recordPluginUsage(plugins pluginSet, proj *workspace.Project, target *deploy.Target) {
+	now := time.Now()
+	for _, plug := range plugins.Values() {
+		if err := plug.RecordUsage(proj.Name.String(), target.Name.String(), now); err != nil {
+			logging.V(preparePluginLog).Infof("recordPluginUsage(): failed to record use of %s: %v", plug, err)
+		}
+	}
+}
+
 // ensurePluginsAreLoaded ensures that all of the plugins in the given plugin set that match the given plugin flags are
 // loaded.
 func ensurePluginsAreLoaded(plugctx *plugin.Context, plugins pluginSet, kinds plugin.Flags) error {
 	return plugctx.Host.EnsurePlugins(plugins.Values(), kinds)
 }
 
-// installPlugin installs a plugin from the given backend client.
-func installPlugin(plugin workspace.PluginInfo) error {
+// installPlugin installs a plugin from the given backend client, reporting the progress of its download as status
+// messages.
+func installPlugin(cancel context.Context, plugctx *plugin.Context, plugin workspace.PluginInfo) error {
 	logging.V(preparePluginLog).Infof("installPlugin(%s, %s): beginning install", plugin.Name, plugin.Version)
 	if plugin.Kind == workspace.LanguagePlugin {
 		logging.V(preparePluginLog).Infof(
@@ -165,13 +193,14 @@ func installPlugin(plugin workspace.PluginInfo) error {
 
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): initiating download", plugin.Name, plugin.Version)
-	stream, size, err := plugin.Download()
+	stream, size, err := plugin.DownloadWithContext(cancel)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("[%s plugin %s-%s] installing\n", plugin.Kind, plugin.Name, plugin.Version)
-	stream = workspace.ReadCloserProgressBar(stream, size, "Downloading plugin", cmdutil.GetGlobalColorization())
+	label := fmt.Sprintf("[%s plugin %s-%s]", plugin.Kind, plugin.Name, plugin.Version)
+	plugctx.StatusDiag.Infof(diag.RawMessage("", label+" installing"))
+	stream = &pluginDownloadProgress{ReadCloser: stream, sink: plugctx.StatusDiag, label: label, size: size}
 
 	logging.V(preparePluginVerboseLog).Infof(
 		"installPlugin(%s, %s): extracting tarball to installation directory", plugin.Name, plugin.Version)
@@ -183,6 +212,38 @@ func installPlugin(plugin workspace.PluginInfo) error {
 	return nil
 }
 
+// pluginDownloadProgress reports the progress of a plugin download to a diagnostics sink. A message is reported for
+// every tenth of the download if its size is known, and for every pluginDownloadProgressStep bytes otherwise.
+type pluginDownloadProgress struct {
+	io.ReadCloser
+
+	sink     diag.Sink
+	label    string
+	size     int64 // the size of the download, or -1 if unknown.
+	read     int64 // the number of bytes read so far.
+	reported int64 // the number of bytes read as of the last report.
+}
+
+const pluginDownloadProgressStep = 10 * 1024 * 1024
+
+func (p *pluginDownloadProgress) Read(b []byte) (int, error) {
+	n, err := p.ReadCloser.Read(b)
+	p.read += int64(n)
+
+	if p.size > 0 {
+		if p.read*10/p.size > p.reported*10/p.size {
+			p.sink.Infof(diag.RawMessage("", fmt.Sprintf("%s downloading: %d%% of %s",
+				p.label, p.read*100/p.size, humanize.Bytes(uint64(p.size)))))
+			p.reported = p.read
+		}
+	} else if p.read-p.reported >= pluginDownloadProgressStep {
+		p.sink.Infof(diag.RawMessage("", fmt.Sprintf("%s downloading: %s",
+			p.label, humanize.Bytes(uint64(p.read)))))
+		p.reported = p.read
+	}
+	return n, err
+}
+
 // computeDefaultProviderPlugins computes, for every resource plugin, a mapping from packages to semver versions
 // reflecting the version of a provider that should be used as the "default" resource when registering resources. This
 // function takes two sets of plugins: a set of plugins given to us from the language host and the full set of plugins.
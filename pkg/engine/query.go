@@ -71,7 +71,7 @@ func Query(ctx *Context, q QueryInfo, opts UpdateOptions) result.Result {
 	contract.Assert(proj != nil)
 
 	pwd, main, plugctx, err := ProjectInfoContext(&Projinfo{Proj: proj, Root: q.GetRoot()},
-		opts.host, nil, diag, statusDiag, tracingSpan)
+		opts.Host, nil, diag, statusDiag, tracingSpan)
 	if err != nil {
 		return result.FromError(err)
 	}
@@ -81,7 +81,7 @@ func Query(ctx *Context, q QueryInfo, opts UpdateOptions) result.Result {
 		Events:      emitter,
 		Diag:        diag,
 		StatusDiag:  statusDiag,
-		host:        opts.host,
+		host:        opts.Host,
 		pwd:         pwd,
 		main:        main,
 		plugctx:     plugctx,
@@ -92,7 +92,7 @@ func Query(ctx *Context, q QueryInfo, opts UpdateOptions) result.Result {
 func newQuerySource(cancel context.Context, client deploy.BackendClient, q QueryInfo,
 	opts QueryOptions) (deploy.QuerySource, error) {
 
-	allPlugins, defaultProviderVersions, err := installPlugins(q.GetProject(), opts.pwd, opts.main,
+	allPlugins, defaultProviderVersions, err := installPlugins(cancel, q.GetProject(), opts.pwd, opts.main,
 		nil, opts.plugctx)
 	if err != nil {
 		return nil, err
@@ -28,6 +28,7 @@ import (
 	"github.com/pkg/errors"
 	resourceanalyzer "github.com/pulumi/pulumi/pkg/v2/resource/analyzer"
 	"github.com/pulumi/pulumi/pkg/v2/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
@@ -116,6 +117,9 @@ type UpdateOptions struct {
 	// Specific resources to refresh during a refresh operation.
 	RefreshTargets []resource.URN
 
+	// true if a refresh should adopt any unmanaged children that providers discover beneath the resources it reads.
+	AdoptOrphans bool
+
 	// Specific resources to replace during an update operation.
 	ReplaceTargets []resource.URN
 
@@ -132,11 +136,23 @@ type UpdateOptions struct {
 	// true if the engine should use legacy diffing behavior during an update.
 	UseLegacyDiff bool
 
+	// Resource types that may not be replaced. Steps that would replace a resource of one of these types fail.
+	ProtectedReplaceTypes map[tokens.Type]bool
+
+	// Points at which to hold the update until it is approved to continue, and the approver that approves it.
+	Holds        []deploy.HoldPoint
+	HoldApprover deploy.HoldApprover
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
-	// the plugin host to use for this update
-	host plugin.Host
+	// The plugin host to use for this update. If nil, a default host is created that loads plugins from the
+	// workspace.
+	Host plugin.Host
+
+	// A cache of the provider configuration checked during a preview. An update that shares the cache of the preview
+	// that preceded it does not check the same configuration again. If nil, no configuration is cached.
+	ProviderConfigs *providers.ConfigCache
 }
 
 // ResourceChanges contains the aggregate resource changes by operation type.
@@ -186,12 +202,12 @@ func Update(u UpdateInfo, ctx *Context, opts UpdateOptions, dryRun bool) (Resour
 // RunInstallPlugins calls installPlugins and just returns the error (avoids having to export pluginSet).
 func RunInstallPlugins(
 	proj *workspace.Project, pwd, main string, target *deploy.Target, plugctx *plugin.Context) error {
-	_, _, err := installPlugins(proj, pwd, main, target, plugctx)
+	_, _, err := installPlugins(context.Background(), proj, pwd, main, target, plugctx)
 	return err
 }
 
 func installPlugins(
-	proj *workspace.Project, pwd, main string, target *deploy.Target,
+	cancel context.Context, proj *workspace.Project, pwd, main string, target *deploy.Target,
 	plugctx *plugin.Context) (pluginSet, map[tokens.Package]*semver.Version, error) {
 
 	// Before launching the source, ensure that we have all of the plugins that we need in order to proceed.
@@ -225,7 +241,7 @@ func installPlugins(
 	//
 	// Note that this is purely a best-effort thing. If we can't install missing plugins, just proceed; we'll fail later
 	// with an error message indicating exactly what plugins are missing.
-	if err := ensurePluginsAreInstalled(allPlugins); err != nil {
+	if err := ensurePluginsAreInstalled(cancel, plugctx, allPlugins); err != nil {
 		logging.V(7).Infof("newUpdateSource(): failed to install missing plugins: %v", err)
 	}
 
@@ -345,18 +361,25 @@ func installAndLoadPolicyPlugins(plugctx *plugin.Context, d diag.Sink, policies
 }
 
 func newUpdateSource(
-	client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
+	cancel context.Context, client deploy.BackendClient, opts planOptions, proj *workspace.Project, pwd, main string,
 	target *deploy.Target, plugctx *plugin.Context, dryRun bool) (deploy.Source, error) {
 
+	// Check the stack's configuration against the project's config schema before doing any work, so that every
+	// missing or invalid key is reported at once rather than the program failing on the first one it reads.
+	if err := proj.ValidateStackConfig(target.Config, target.Decrypter); err != nil {
+		return nil, err
+	}
+
 	//
 	// Step 1: Install and load plugins.
 	//
 
-	allPlugins, defaultProviderVersions, err := installPlugins(proj, pwd, main, target,
+	allPlugins, defaultProviderVersions, err := installPlugins(cancel, proj, pwd, main, target,
 		plugctx)
 	if err != nil {
 		return nil, err
 	}
+	recordPluginUsage(allPlugins, proj, target)
 
 	// Once we've installed all of the plugins we need, make sure that all analyzers and language plugins are
 	// loaded up and ready to go. Provider plugins are loaded lazily by the provider registry and thus don't
@@ -446,7 +469,7 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 
 				// Print out the total number of steps performed (and their kinds), the duration, and any summary info.
 				opts.Events.updateSummaryEvent(actions.MaybeCorrupt, time.Since(start),
-					resourceChanges, policies)
+					resourceChanges, policies, planResult.Plan.ConfigUsage())
 			}
 		}
 	}
@@ -624,3 +647,7 @@ func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
+
+func (acts *updateActions) OnHold(hold deploy.Hold, status deploy.HoldStatus) {
+	acts.Opts.Events.holdEvent(hold, status)
+}
@@ -0,0 +1,125 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// CallMethod runs a method on an existing resource using the provider plugin that manages it. If the provider
+// publishes a schema, the method must be one that the schema declares for the resource's type, and the arguments must
+// include each of the method's required inputs.
+func CallMethod(source ProviderSource, res *resource.State, method string,
+	args resource.PropertyMap) (resource.PropertyMap, error) {
+
+	contract.Require(res != nil, "res")
+	contract.Require(method != "", "method")
+
+	if !res.Custom || res.ID == "" || res.Provider == "" {
+		return nil, errors.Errorf("resource '%v' is not managed by a provider, so it has no methods", res.URN)
+	}
+
+	ref, err := providers.ParseReference(res.Provider)
+	if err != nil {
+		return nil, err
+	}
+	prov, err := source.GetProvider(ref)
+	if err != nil {
+		return nil, err
+	}
+	if prov == nil {
+		return nil, errors.Errorf("could not load the provider for resource '%v'", res.URN)
+	}
+
+	if err = checkMethod(prov, res.Type, method, args); err != nil {
+		return nil, err
+	}
+
+	ret, failures, err := prov.Call(res.URN, res.ID, res.Outputs, method, args)
+	if err == plugin.ErrCallNotSupported {
+		return nil, errors.Errorf("the %v provider does not support resource methods", res.Type.Package())
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "calling %v on '%v'", method, res.URN)
+	}
+	if len(failures) > 0 {
+		reasons := make([]string, len(failures))
+		for i, failure := range failures {
+			reasons[i] = fmt.Sprintf("%v: %v", failure.Property, failure.Reason)
+		}
+		return nil, errors.Errorf("invalid arguments for %v:\n  %v", method, strings.Join(reasons, "\n  "))
+	}
+	return ret, nil
+}
+
+// checkMethod verifies a call against the provider's schema. Providers that do not publish a schema are not checked.
+func checkMethod(prov plugin.Provider, typ tokens.Type, method string, args resource.PropertyMap) error {
+	bytes, err := prov.GetSchema(0)
+	if err != nil || len(bytes) == 0 {
+		logging.V(7).Infof("not checking method %v: no schema for %v (%v)", method, typ.Package(), err)
+		return nil
+	}
+
+	var spec schema.PackageSpec
+	if err = json.Unmarshal(bytes, &spec); err != nil {
+		return errors.Wrapf(err, "unmarshaling schema for %v", typ.Package())
+	}
+	if spec.Name == "" {
+		logging.V(7).Infof("not checking method %v: the schema for %v is empty", method, typ.Package())
+		return nil
+	}
+	pkg, err := schema.ImportSpec(spec, nil)
+	if err != nil {
+		return errors.Wrapf(err, "binding schema for %v", typ.Package())
+	}
+	res, ok := pkg.GetResource(string(typ))
+	if !ok {
+		return errors.Errorf("the schema for %v does not describe resource type %v", typ.Package(), typ)
+	}
+
+	var names []string
+	for _, m := range res.Methods {
+		if m.Name != method {
+			names = append(names, m.Name)
+			continue
+		}
+		if m.Function.Inputs != nil {
+			for _, p := range m.Function.Inputs.Properties {
+				if p.IsRequired && !args.HasValue(resource.PropertyKey(p.Name)) {
+					return errors.Errorf("method %v requires the argument '%v'", method, p.Name)
+				}
+			}
+		}
+		return nil
+	}
+
+	if len(names) == 0 {
+		return errors.Errorf("resource type %v does not have any methods", typ)
+	}
+	return errors.Errorf("resource type %v does not have a method named %v; its methods are: %v",
+		typ, method, strings.Join(names, ", "))
+}
@@ -0,0 +1,108 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+)
+
+const callTestSchema = `{
+	"name": "test",
+	"resources": {
+		"test:index:Instance": {
+			"methods": {
+				"reboot": {
+					"inputs": {
+						"properties": {
+							"force": {"type": "boolean"},
+							"reason": {"type": "string"}
+						},
+						"required": ["reason"]
+					}
+				}
+			}
+		}
+	}
+}`
+
+func newCallTestResource(t *testing.T, prov plugin.Provider) (*resource.State, ProviderSource) {
+	provURN := resource.NewURN("stack", "proj", "", providers.MakeProviderType("test"), "default")
+	ref, err := providers.NewReference(provURN, "provider-id")
+	assert.NoError(t, err)
+
+	resURN := resource.NewURN("stack", "proj", "", "test:index:Instance", "instance")
+	res := resource.NewState("test:index:Instance", resURN, true, false, "instance-id",
+		resource.PropertyMap{}, resource.PropertyMap{}, "", false, false, nil, nil, ref.String(), nil, false, nil, nil,
+		nil, "")
+	return res, testProviderSource{ref: prov}
+}
+
+func TestCallMethod(t *testing.T) {
+	prov := &deploytest.Provider{
+		GetSchemaF: func(version int) ([]byte, error) {
+			return []byte(callTestSchema), nil
+		},
+		CallF: func(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+			args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+			assert.Equal(t, resource.ID("instance-id"), id)
+			assert.Equal(t, "reboot", method)
+			return resource.PropertyMap{"status": resource.NewStringProperty("rebooting")}, nil, nil
+		},
+	}
+	res, source := newCallTestResource(t, prov)
+
+	ret, err := CallMethod(source, res, "reboot", resource.PropertyMap{
+		"reason": resource.NewStringProperty("maintenance"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewStringProperty("rebooting"), ret["status"])
+
+	// The schema is checked before the provider is called.
+	_, err = CallMethod(source, res, "reboot", resource.PropertyMap{})
+	assert.EqualError(t, err, "method reboot requires the argument 'reason'")
+
+	_, err = CallMethod(source, res, "resize", resource.PropertyMap{})
+	assert.EqualError(t, err,
+		"resource type test:index:Instance does not have a method named resize; its methods are: reboot")
+}
+
+func TestCallMethodFailures(t *testing.T) {
+	prov := &deploytest.Provider{
+		CallF: func(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+			args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+			return nil, []plugin.CheckFailure{{Property: "size", Reason: "unknown size"}}, nil
+		},
+	}
+	res, source := newCallTestResource(t, prov)
+
+	_, err := CallMethod(source, res, "resize", resource.PropertyMap{"size": resource.NewStringProperty("huge")})
+	assert.EqualError(t, err, "invalid arguments for resize:\n  size: unknown size")
+}
+
+func TestCallMethodNotSupported(t *testing.T) {
+	res, source := newCallTestResource(t, &deploytest.Provider{})
+
+	_, err := CallMethod(source, res, "reboot", resource.PropertyMap{})
+	assert.EqualError(t, err, "the test provider does not support resource methods")
+}
@@ -71,7 +71,7 @@ func (ops *cloudOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
 			logging.V(6).Infof("Child resource (type %v, name %v) not found", awsLambdaFunctionTypeName, name)
 			return nil, nil
 		}
-		rawLogs, err := serverlessFunction.OperationsProvider(ops.config).GetLogs(query)
+		rawLogs, err := serverlessFunction.OperationsProvider(ops.config, nil).GetLogs(query)
 		if err != nil {
 			return nil, err
 		}
@@ -100,7 +100,7 @@ func (ops *cloudOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
 			logging.V(6).Infof("Child resource (type %v, name %v) not found", awsLambdaFunctionTypeName, name)
 			return nil, nil
 		}
-		rawLogs, err := serverlessFunction.OperationsProvider(ops.config).GetLogs(query)
+		rawLogs, err := serverlessFunction.OperationsProvider(ops.config, nil).GetLogs(query)
 		if err != nil {
 			return nil, err
 		}
@@ -148,7 +148,7 @@ func (ops *cloudOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
 			logging.V(6).Infof("Child resource (type %v, name %v) not found", awsLogGroupTypeName, name)
 			return nil, nil
 		}
-		rawLogs, err := logGroup.OperationsProvider(ops.config).GetLogs(query)
+		rawLogs, err := logGroup.OperationsProvider(ops.config, nil).GetLogs(query)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,160 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// A ProviderSource allows operations to look up the provider plugin that manages a resource.
+type ProviderSource interface {
+	// GetProvider fetches the provider plugin for the given reference. It returns nil if the plugin is not available.
+	GetProvider(ref providers.Reference) (plugin.Provider, error)
+}
+
+// NewProviderSource returns a ProviderSource that loads and configures the providers in the given set of resources
+// the first time each one is asked for. The plugins are owned by the host, and are closed along with it.
+func NewProviderSource(host plugin.Host, resources []*resource.State) ProviderSource {
+	states := make(map[resource.URN]*resource.State)
+	for _, res := range resources {
+		if providers.IsProviderType(res.Type) {
+			states[res.URN] = res
+		}
+	}
+	return &providerLoader{
+		host:      host,
+		states:    states,
+		providers: make(map[providers.Reference]plugin.Provider),
+	}
+}
+
+type providerLoader struct {
+	host      plugin.Host
+	states    map[resource.URN]*resource.State
+	m         sync.Mutex
+	providers map[providers.Reference]plugin.Provider
+}
+
+func (l *providerLoader) GetProvider(ref providers.Reference) (plugin.Provider, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	// A nil entry records a provider that could not be loaded, so that we only try once.
+	if prov, ok := l.providers[ref]; ok {
+		return prov, nil
+	}
+	l.providers[ref] = nil
+
+	state, ok := l.states[ref.URN()]
+	if !ok || state.ID != ref.ID() {
+		logging.V(7).Infof("provider %v is not present in the snapshot", ref)
+		return nil, nil
+	}
+
+	pkg := providers.GetProviderPackage(state.Type)
+	version, err := providers.GetProviderVersion(state.Inputs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing version for %v provider '%v'", pkg, state.URN)
+	}
+	prov, err := l.host.Provider(pkg, version)
+	if err != nil || prov == nil {
+		// Logs are best-effort; a missing plugin just means that its resources are handled as if it had no logs.
+		logging.V(7).Infof("could not load plugin for %v provider '%v': %v", pkg, state.URN, err)
+		return nil, nil
+	}
+	if err = prov.Configure(state.Inputs); err != nil {
+		contract.IgnoreError(l.host.CloseProvider(prov))
+		return nil, errors.Wrapf(err, "configuring provider '%v'", state.URN)
+	}
+
+	l.providers[ref] = prov
+	return prov, nil
+}
+
+// pluginOpsProvider answers operational queries by asking the provider plugin that manages a resource. Packages whose
+// operations are also implemented within the engine fall back to that implementation if their plugin does not
+// support the query.
+type pluginOpsProvider struct {
+	providers ProviderSource
+	resource  *Resource
+	fallback  func() (Provider, error)
+}
+
+var _ Provider = (*pluginOpsProvider)(nil)
+
+func (ops *pluginOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
+	logs, err := ops.getPluginLogs(query)
+	if err != plugin.ErrLogsNotSupported {
+		return logs, err
+	}
+
+	fallback, err := ops.fallback()
+	if err != nil || fallback == nil {
+		return nil, err
+	}
+	return fallback.GetLogs(query)
+}
+
+func (ops *pluginOpsProvider) getPluginLogs(query LogQuery) (*[]LogEntry, error) {
+	state := ops.resource.State
+	if state.Provider == "" || state.ID == "" {
+		return nil, plugin.ErrLogsNotSupported
+	}
+
+	ref, err := providers.ParseReference(state.Provider)
+	if err != nil {
+		return nil, err
+	}
+	prov, err := ops.providers.GetProvider(ref)
+	if err != nil {
+		return nil, err
+	}
+	if prov == nil {
+		return nil, plugin.ErrLogsNotSupported
+	}
+
+	var logs []LogEntry
+	err = prov.GetLogs(plugin.LogsRequest{
+		URN:        state.URN,
+		ID:         state.ID,
+		Properties: state.Outputs,
+		StartTime:  query.StartTime,
+		EndTime:    query.EndTime,
+	}, func(entry plugin.LogEntry) error {
+		logs = append(logs, LogEntry{
+			ID:        entry.ID,
+			Timestamp: entry.Timestamp,
+			Message:   entry.Message,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// As with the built-in providers, a resource without any logs leaves its children to be queried instead.
+	if len(logs) == 0 {
+		return nil, nil
+	}
+	return &logs, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+type testProviderSource map[providers.Reference]plugin.Provider
+
+func (s testProviderSource) GetProvider(ref providers.Reference) (plugin.Provider, error) {
+	return s[ref], nil
+}
+
+func newPluginLogsTree(t *testing.T, prov plugin.Provider) (*Resource, ProviderSource) {
+	provURN := resource.NewURN("stack", "proj", "", providers.MakeProviderType("test"), "default")
+	ref, err := providers.NewReference(provURN, "provider-id")
+	assert.NoError(t, err)
+
+	provState := resource.NewState(providers.MakeProviderType("test"), provURN, true, false, "provider-id",
+		resource.PropertyMap{}, nil, "", false, false, nil, nil, "", nil, false, nil, nil, nil, "")
+	resURN := resource.NewURN("stack", "proj", "", tokens.Type("test:index:Thing"), "thing")
+	resState := resource.NewState("test:index:Thing", resURN, true, false, "thing-id",
+		resource.PropertyMap{}, nil, "", false, false, nil, nil, ref.String(), nil, false, nil, nil, nil, "")
+
+	return NewResourceTree([]*resource.State{provState, resState}), testProviderSource{ref: prov}
+}
+
+func TestPluginGetLogs(t *testing.T) {
+	prov := &deploytest.Provider{
+		GetLogsF: func(req plugin.LogsRequest, onNext func(plugin.LogEntry) error) error {
+			assert.Equal(t, resource.ID("thing-id"), req.ID)
+			if err := onNext(plugin.LogEntry{ID: "thing", Timestamp: 2, Message: "second"}); err != nil {
+				return err
+			}
+			return onNext(plugin.LogEntry{ID: "thing", Timestamp: 1, Message: "first"})
+		},
+	}
+	tree, source := newPluginLogsTree(t, prov)
+
+	logs, err := tree.OperationsProvider(nil, source).GetLogs(LogQuery{})
+	assert.NoError(t, err)
+	if assert.NotNil(t, logs) {
+		assert.Equal(t, []LogEntry{
+			{ID: "thing", Timestamp: 1, Message: "first"},
+			{ID: "thing", Timestamp: 2, Message: "second"},
+		}, *logs)
+	}
+}
+
+func TestPluginGetLogsNotSupported(t *testing.T) {
+	tree, source := newPluginLogsTree(t, &deploytest.Provider{})
+
+	logs, err := tree.OperationsProvider(nil, source).GetLogs(LogQuery{})
+	assert.NoError(t, err)
+	if logs != nil {
+		assert.Empty(t, *logs)
+	}
+}
@@ -112,18 +112,21 @@ func (r *Resource) GetChild(typ string, name string) (*Resource, bool) {
 	return nil, false
 }
 
-// OperationsProvider gets an OperationsProvider for this resource.
-func (r *Resource) OperationsProvider(config map[config.Key]string) Provider {
+// OperationsProvider gets an OperationsProvider for this resource. If providers is non-nil, the provider plugins that
+// manage the resources are asked to answer queries before falling back to the operations built into the engine.
+func (r *Resource) OperationsProvider(config map[config.Key]string, providers ProviderSource) Provider {
 	return &resourceOperations{
-		resource: r,
-		config:   config,
+		resource:  r,
+		config:    config,
+		providers: providers,
 	}
 }
 
 // ResourceOperations is an OperationsProvider for Resources
 type resourceOperations struct {
-	resource *Resource
-	config   map[config.Key]string
+	resource  *Resource
+	config    map[config.Key]string
+	providers ProviderSource
 }
 
 var _ Provider = (*resourceOperations)(nil)
@@ -168,8 +171,9 @@ func (ops *resourceOperations) GetLogs(query LogQuery) (*[]LogEntry, error) {
 	errch := make(chan error)
 	for _, child := range ops.resource.Children {
 		childOps := &resourceOperations{
-			resource: child,
-			config:   ops.config,
+			resource:  child,
+			config:    ops.config,
+			providers: ops.providers,
 		}
 		go func() {
 			childLogs, err := childOps.GetLogs(query)
@@ -249,6 +253,19 @@ func (ops *resourceOperations) getOperationsProvider() (Provider, error) {
 	if ops.resource == nil || ops.resource.State == nil {
 		return nil, nil
 	}
+	if ops.providers != nil && ops.resource.State.Custom {
+		return &pluginOpsProvider{
+			providers: ops.providers,
+			resource:  ops.resource,
+			fallback:  ops.getBuiltinOperationsProvider,
+		}, nil
+	}
+	return ops.getBuiltinOperationsProvider()
+}
+
+// getBuiltinOperationsProvider returns the operations provider built into the engine for this resource's package, if
+// there is one.
+func (ops *resourceOperations) getBuiltinOperationsProvider() (Provider, error) {
 	switch ops.resource.State.Type.Package() {
 	case "cloud":
 		return CloudOperationsProvider(ops.config, ops.resource)
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
@@ -19,6 +21,10 @@ type builtinProvider struct {
 	backendClient BackendClient
 	context       context.Context
 	cancel        context.CancelFunc
+
+	configLock     sync.Mutex
+	configReported bool                // true if the program reported the configuration keys it read.
+	configKeys     map[config.Key]bool // the configuration keys the program read.
 }
 
 func newBuiltinProvider(backendClient BackendClient) *builtinProvider {
@@ -150,6 +156,7 @@ func (p *builtinProvider) Read(urn resource.URN, id resource.ID,
 
 const readStackOutputs = "pulumi:pulumi:readStackOutputs"
 const readStackResourceOutputs = "pulumi:pulumi:readStackResourceOutputs"
+const reportConfigUsage = "pulumi:pulumi:reportConfigUsage"
 
 func (p *builtinProvider) Invoke(tok tokens.ModuleMember,
 	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
@@ -167,6 +174,9 @@ func (p *builtinProvider) Invoke(tok tokens.ModuleMember,
 			return nil, nil, err
 		}
 		return outs, nil, nil
+	case reportConfigUsage:
+		failures := p.reportConfigUsage(args)
+		return resource.PropertyMap{}, failures, nil
 	default:
 		return nil, nil, errors.Errorf("unrecognized function name: '%v'", tok)
 	}
@@ -179,6 +189,16 @@ func (p *builtinProvider) StreamInvoke(
 	return nil, fmt.Errorf("the builtin provider does not implement streaming invokes")
 }
 
+func (p *builtinProvider) GetLogs(req plugin.LogsRequest, onNext func(plugin.LogEntry) error) error {
+	return plugin.ErrLogsNotSupported
+}
+
+func (p *builtinProvider) Call(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	return nil, nil, plugin.ErrCallNotSupported
+}
+
 func (p *builtinProvider) GetPluginInfo() (workspace.PluginInfo, error) {
 	// return an error: this should not be called for the builtin provider
 	return workspace.PluginInfo{}, errors.New("the builtin provider does not report plugin info")
@@ -198,11 +218,18 @@ func (p *builtinProvider) readStackReference(inputs resource.PropertyMap) (resou
 		return nil, errors.New("no backend client is available")
 	}
 
-	outputs, err := p.backendClient.GetStackOutputs(p.context, name.StringValue())
+	allOutputs, err := p.backendClient.GetStackOutputs(p.context, name.StringValue())
 	if err != nil {
 		return nil, err
 	}
 
+	// Never expose internal outputs, even if the backend client returned their values.
+	outputs, internal := resource.PublicStackOutputs(allOutputs)
+	internalOutputs := make([]resource.PropertyValue, len(internal))
+	for i, k := range internal {
+		internalOutputs[i] = resource.NewStringProperty(k)
+	}
+
 	secretOutputs := make([]resource.PropertyValue, 0)
 	for k, v := range outputs {
 		if v.ContainsSecrets() {
@@ -216,9 +243,10 @@ func (p *builtinProvider) readStackReference(inputs resource.PropertyMap) (resou
 	})
 
 	return resource.PropertyMap{
-		"name":              name,
-		"outputs":           resource.NewObjectProperty(outputs),
-		"secretOutputNames": resource.NewArrayProperty(secretOutputs),
+		"name":                name,
+		"outputs":             resource.NewObjectProperty(outputs),
+		"secretOutputNames":   resource.NewArrayProperty(secretOutputs),
+		"internalOutputNames": resource.NewArrayProperty(internalOutputs),
 	}, nil
 }
 
@@ -241,3 +269,52 @@ func (p *builtinProvider) readStackResourceOutputs(inputs resource.PropertyMap)
 		"outputs": resource.NewObjectProperty(outputs),
 	}, nil
 }
+
+// reportConfigUsage records the configuration keys that the program read, which are given by the "keys" argument as a
+// list of strings of the form "namespace:key". A program may report its keys any number of times.
+func (p *builtinProvider) reportConfigUsage(args resource.PropertyMap) []plugin.CheckFailure {
+	keysArg, ok := args["keys"]
+	if !ok || !keysArg.IsArray() {
+		return []plugin.CheckFailure{{Property: "keys", Reason: "expected a list of configuration keys"}}
+	}
+
+	var keys []config.Key
+	for _, v := range keysArg.ArrayValue() {
+		if !v.IsString() {
+			return []plugin.CheckFailure{{Property: "keys", Reason: "expected a list of configuration keys"}}
+		}
+		key, err := config.ParseKey(v.StringValue())
+		if err != nil {
+			return []plugin.CheckFailure{{Property: "keys", Reason: err.Error()}}
+		}
+		keys = append(keys, key)
+	}
+
+	p.configLock.Lock()
+	defer p.configLock.Unlock()
+
+	p.configReported = true
+	if p.configKeys == nil {
+		p.configKeys = make(map[config.Key]bool)
+	}
+	for _, key := range keys {
+		p.configKeys[key] = true
+	}
+	return nil
+}
+
+// configKeysRead returns the configuration keys that the program reported reading and true, or false if the program
+// did not report the keys it read.
+func (p *builtinProvider) configKeysRead() ([]config.Key, bool) {
+	p.configLock.Lock()
+	defer p.configLock.Unlock()
+
+	if !p.configReported {
+		return nil, false
+	}
+	keys := make([]config.Key, 0, len(p.configKeys))
+	for key := range p.configKeys {
+		keys = append(keys, key)
+	}
+	return keys, true
+}
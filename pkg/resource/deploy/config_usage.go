@@ -0,0 +1,68 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"sort"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// ConfigUsage describes how a program used the configuration of its stack. Programs report the configuration keys they
+// read by invoking the builtin function `pulumi:pulumi:reportConfigUsage` with a list of keys.
+type ConfigUsage struct {
+	// The configuration keys that the program read, sorted.
+	Read []config.Key
+	// The configuration keys in the project's namespace that are set for the stack but were not read, sorted. Keys in
+	// other namespaces are read by providers rather than by the program, so they are never unused.
+	Unused []config.Key
+	// The configuration keys that the program read but that are not set for the stack, sorted.
+	Missing []config.Key
+}
+
+// newConfigUsage compares the configuration keys read by a program with the configuration of its stack.
+func newConfigUsage(project tokens.PackageName, cfg config.Map, read []config.Key) *ConfigUsage {
+	usage := &ConfigUsage{Read: read}
+
+	wasRead := make(map[config.Key]bool)
+	for _, k := range read {
+		wasRead[k] = true
+		if _, ok := cfg[k]; !ok {
+			usage.Missing = append(usage.Missing, k)
+		}
+	}
+	for k := range cfg {
+		if k.Namespace() == string(project) && !wasRead[k] {
+			usage.Unused = append(usage.Unused, k)
+		}
+	}
+
+	sort.Sort(config.KeyArray(usage.Read))
+	sort.Sort(config.KeyArray(usage.Unused))
+	sort.Sort(config.KeyArray(usage.Missing))
+	return usage
+}
+
+// ConfigUsage returns the configuration keys that the plan's program read, compared with the configuration of the
+// plan's target. The result is nil if the program did not report the keys it read, e.g. because it was written using
+// an SDK that does not report them, or because it has not yet completed.
+func (p *Plan) ConfigUsage() *ConfigUsage {
+	read, ok := p.builtins.configKeysRead()
+	if !ok {
+		return nil
+	}
+	return newConfigUsage(p.source.Project(), p.target.Config, read)
+}
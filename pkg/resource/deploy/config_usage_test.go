@@ -0,0 +1,80 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+func TestReportConfigUsage(t *testing.T) {
+	p := newBuiltinProvider(nil)
+
+	// Nothing has been reported yet.
+	_, ok := p.configKeysRead()
+	assert.False(t, ok)
+
+	// Malformed reports are rejected.
+	_, failures, err := p.Invoke(reportConfigUsage, resource.PropertyMap{})
+	assert.NoError(t, err)
+	assert.Len(t, failures, 1)
+	_, failures, err = p.Invoke(reportConfigUsage, resource.NewPropertyMapFromMap(map[string]interface{}{
+		"keys": []interface{}{"not-a-key"},
+	}))
+	assert.NoError(t, err)
+	assert.Len(t, failures, 1)
+	_, ok = p.configKeysRead()
+	assert.False(t, ok)
+
+	// An empty report means that the program read no keys.
+	_, failures, err = p.Invoke(reportConfigUsage, resource.NewPropertyMapFromMap(map[string]interface{}{
+		"keys": []interface{}{},
+	}))
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	keys, ok := p.configKeysRead()
+	assert.True(t, ok)
+	assert.Empty(t, keys)
+
+	// Reports accumulate.
+	for _, k := range []string{"proj:a", "proj:b"} {
+		_, failures, err = p.Invoke(reportConfigUsage, resource.NewPropertyMapFromMap(map[string]interface{}{
+			"keys": []interface{}{k, "proj:a"},
+		}))
+		assert.NoError(t, err)
+		assert.Empty(t, failures)
+	}
+	keys, ok = p.configKeysRead()
+	assert.True(t, ok)
+	assert.ElementsMatch(t, []config.Key{config.MustMakeKey("proj", "a"), config.MustMakeKey("proj", "b")}, keys)
+}
+
+func TestNewConfigUsage(t *testing.T) {
+	cfg := config.Map{
+		config.MustMakeKey("proj", "used"):    config.NewValue("a"),
+		config.MustMakeKey("proj", "unused"):  config.NewValue("b"),
+		config.MustMakeKey("proj", "other"):   config.NewValue("c"),
+		config.MustMakeKey("aws", "region"):   config.NewValue("us-west-2"),
+		config.MustMakeKey("other", "unused"): config.NewValue("d"),
+	}
+	read := []config.Key{
+		config.MustMakeKey("proj", "used"),
+		config.MustMakeKey("proj", "missing"),
+		config.MustMakeKey("aws", "region"),
+	}
+
+	usage := newConfigUsage(tokens.PackageName("proj"), cfg, read)
+	assert.Equal(t, []config.Key{
+		config.MustMakeKey("aws", "region"),
+		config.MustMakeKey("proj", "missing"),
+		config.MustMakeKey("proj", "used"),
+	}, usage.Read)
+	assert.Equal(t, []config.Key{
+		config.MustMakeKey("proj", "other"),
+		config.MustMakeKey("proj", "unused"),
+	}, usage.Unused)
+	assert.Equal(t, []config.Key{config.MustMakeKey("proj", "missing")}, usage.Missing)
+}
@@ -57,6 +57,9 @@ type Provider struct {
 		inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error)
 	InvokeF func(tok tokens.ModuleMember,
 		inputs resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error)
+	GetLogsF func(req plugin.LogsRequest, onNext func(plugin.LogEntry) error) error
+	CallF    func(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+		args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error)
 
 	CancelF func() error
 }
@@ -175,3 +178,19 @@ func (prov *Provider) StreamInvoke(
 
 	return nil, fmt.Errorf("not implemented")
 }
+
+func (prov *Provider) GetLogs(req plugin.LogsRequest, onNext func(plugin.LogEntry) error) error {
+	if prov.GetLogsF == nil {
+		return plugin.ErrLogsNotSupported
+	}
+	return prov.GetLogsF(req, onNext)
+}
+
+func (prov *Provider) Call(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	if prov.CallF == nil {
+		return nil, nil, plugin.ErrCallNotSupported
+	}
+	return prov.CallF(urn, id, props, method, args)
+}
@@ -42,6 +42,7 @@ type ResourceOptions struct {
 	ImportID              resource.ID
 	CustomTimeouts        *resource.CustomTimeouts
 	SupportsPartialValues *bool
+	SerializationGroup    string
 }
 
 func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom bool,
@@ -117,6 +118,7 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		ImportId:                   string(opts.ImportID),
 		CustomTimeouts:             &timeouts,
 		SupportsPartialValues:      supportsPartialValues,
+		SerializationGroup:         opts.SerializationGroup,
 	}
 
 	// submit request
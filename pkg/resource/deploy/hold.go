@@ -0,0 +1,200 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/v2/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
+)
+
+// HoldPoint declares a point in a deployment at which the engine pauses until the deployment is approved to continue.
+// Each hold point is reached at most once per deployment, and only by a resource that the program registers and that
+// is about to change. Deletions of resources that the program no longer registers are not held.
+type HoldPoint struct {
+	// AfterChanges holds the deployment once this many resources have changed. Zero disables this trigger.
+	AfterChanges int
+	// Before holds the deployment before the resource with this URN changes. Empty disables this trigger.
+	Before resource.URN
+	// Timeout bounds how long to wait for approval. Zero waits indefinitely.
+	Timeout time.Duration
+	// ContinueOnTimeout continues the deployment when the timeout elapses rather than failing it. A hold point with a
+	// timeout that continues on timeout and no approver is a timed pause.
+	ContinueOnTimeout bool
+}
+
+// HoldStatus is the state of a hold.
+type HoldStatus string
+
+const (
+	HoldWaiting  HoldStatus = "waiting"   // the deployment is waiting for approval.
+	HoldApproved HoldStatus = "approved"  // the deployment was approved to continue.
+	HoldRejected HoldStatus = "rejected"  // the deployment was rejected, and will stop.
+	HoldTimedOut HoldStatus = "timed-out" // no approval arrived before the hold point's timeout.
+)
+
+// Hold describes a hold point that a deployment has reached.
+type Hold struct {
+	Point      HoldPoint    // the hold point that was reached.
+	URN        resource.URN // the resource whose change is held.
+	Changes    int          // the number of resources changed so far.
+	Approvable bool         // true if the deployment has an approver that may approve the hold.
+}
+
+// HoldApprover decides whether a deployment may continue past a hold point.
+type HoldApprover interface {
+	// Approve blocks until the hold is approved (true) or rejected (false), or until the context is done.
+	Approve(ctx context.Context, hold Hold) (bool, error)
+}
+
+// HoldEvents is notified as a deployment is held and released.
+type HoldEvents interface {
+	OnHold(hold Hold, status HoldStatus)
+}
+
+// holdTracker decides when a deployment reaches its hold points, and holds it there.
+type holdTracker struct {
+	points   []HoldPoint
+	reached  []bool
+	approver HoldApprover
+	events   Events
+	changes  int               // the number of resources changed by the steps scheduled so far.
+	inflight []completionToken // the chains scheduled since the last hold.
+}
+
+func newHoldTracker(opts Options, preview bool) *holdTracker {
+	// Previews never change anything, so there is nothing to hold.
+	if preview || len(opts.Holds) == 0 {
+		return nil
+	}
+	return &holdTracker{
+		points:   opts.Holds,
+		reached:  make([]bool, len(opts.Holds)),
+		approver: opts.HoldApprover,
+		events:   opts.Events,
+	}
+}
+
+// isChange returns true if any of the steps change their resource. Provider resources are not counted, as they are
+// usually registered implicitly rather than by the program.
+func isChange(steps []Step) bool {
+	for _, step := range steps {
+		if step.Op() != OpSame && !providers.IsProviderType(step.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduled records a chain of steps that has been scheduled for execution.
+func (h *holdTracker) scheduled(steps []Step, tok completionToken) {
+	if h == nil {
+		return
+	}
+	if isChange(steps) {
+		h.changes++
+	}
+	h.inflight = append(h.inflight, tok)
+}
+
+// hold holds the deployment if the steps for the given resource reach a hold point. Before asking for approval, it
+// waits for the steps scheduled so far to complete, so that they can be inspected. If the deployment is cancelled
+// while it is held, hold bails so that the held steps are never scheduled.
+func (h *holdTracker) hold(ctx context.Context, urn resource.URN, steps []Step) result.Result {
+	if h == nil || !isChange(steps) {
+		return nil
+	}
+	for i, point := range h.points {
+		if h.reached[i] {
+			continue
+		}
+		if (point.Before == "" || point.Before != urn) &&
+			(point.AfterChanges == 0 || h.changes < point.AfterChanges) {
+			continue
+		}
+		h.reached[i] = true
+
+		for _, tok := range h.inflight {
+			tok.Wait(ctx)
+		}
+		h.inflight = nil
+		if ctx.Err() != nil {
+			return result.Bail()
+		}
+
+		hold := Hold{Point: point, URN: urn, Changes: h.changes, Approvable: h.approver != nil}
+		if res := h.wait(ctx, hold); res != nil {
+			return res
+		}
+	}
+	return nil
+}
+
+func (h *holdTracker) wait(ctx context.Context, hold Hold) result.Result {
+	if hold.Point.Timeout == 0 && h.approver == nil {
+		return result.FromError(errors.Errorf("the deployment reached a hold point before '%v' that has no timeout, "+
+			"but there is nothing to approve it", hold.URN))
+	}
+
+	logging.V(4).Infof("holdTracker.wait(...): holding before %v after %d changes", hold.URN, hold.Changes)
+	h.notify(hold, HoldWaiting)
+
+	waitCtx, cancel := ctx, context.CancelFunc(func() {})
+	if hold.Point.Timeout != 0 {
+		waitCtx, cancel = context.WithTimeout(ctx, hold.Point.Timeout)
+	}
+	defer cancel()
+
+	approved, err := false, error(nil)
+	if h.approver != nil {
+		approved, err = h.approver.Approve(waitCtx, hold)
+	} else {
+		<-waitCtx.Done()
+		err = waitCtx.Err()
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		// The deployment was cancelled while it was held.
+		return result.Bail()
+	case err == nil && approved:
+		h.notify(hold, HoldApproved)
+		return nil
+	case err == nil:
+		h.notify(hold, HoldRejected)
+		return result.FromError(errors.Errorf("the deployment was rejected at the hold point before '%v'", hold.URN))
+	case waitCtx.Err() == context.DeadlineExceeded:
+		h.notify(hold, HoldTimedOut)
+		if hold.Point.ContinueOnTimeout {
+			return nil
+		}
+		return result.FromError(errors.Errorf("the deployment was not approved to continue before '%v' within %v",
+			hold.URN, hold.Point.Timeout))
+	default:
+		return result.FromError(errors.Wrap(err, "waiting for approval"))
+	}
+}
+
+func (h *holdTracker) notify(hold Hold, status HoldStatus) {
+	if h.events != nil {
+		h.events.OnHold(hold, status)
+	}
+}
@@ -52,12 +52,18 @@ type Options struct {
 	Refresh           bool           // whether or not to refresh before executing the plan.
 	RefreshOnly       bool           // whether or not to exit after refreshing.
 	RefreshTargets    []resource.URN // The specific resources to refresh during a refresh op.
+	AdoptOrphans      bool           // whether or not to adopt unmanaged children discovered during a refresh.
 	ReplaceTargets    []resource.URN // Specific resources to replace.
 	DestroyTargets    []resource.URN // Specific resources to destroy.
 	UpdateTargets     []resource.URN // Specific resources to update.
 	TargetDependents  bool           // true if we're allowing things to proceed, even with unspecified targets
 	TrustDependencies bool           // whether or not to trust the resource dependency graph.
 	UseLegacyDiff     bool           // whether or not to use legacy diffing behavior.
+
+	ProtectedReplaceTypes map[tokens.Type]bool // resource types that may not be replaced.
+
+	Holds        []HoldPoint  // points at which to hold the deployment until it is approved to continue.
+	HoldApprover HoldApprover // an optional approver for the deployment's hold points.
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -90,6 +96,7 @@ type PolicyEvents interface {
 type Events interface {
 	StepExecutorEvents
 	PolicyEvents
+	HoldEvents
 }
 
 // PlanPendingOperationsError is an error returned from `NewPlan` if there exist pending operations in the
@@ -117,6 +124,7 @@ type Plan struct {
 	preview              bool                             // true if this plan is to be previewed rather than applied.
 	depGraph             *graph.DependencyGraph           // the dependency graph of the old snapshot
 	providers            *providers.Registry              // the provider registry for this plan.
+	builtins             *builtinProvider                 // the builtin provider for this plan.
 }
 
 // addDefaultProviders adds any necessary default provider definitions and references to the given snapshot. Version
@@ -199,8 +207,11 @@ func addDefaultProviders(target *Target, source Source, prev *Snapshot) error {
 //
 // Note that a plan uses internal concurrency and parallelism in various ways, so it must be closed if for some reason
 // a plan isn't carried out to its final conclusion.  This will result in cancelation and reclamation of OS resources.
-func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
-	localPolicyPackPaths []string, preview bool, backendClient BackendClient) (*Plan, error) {
+//
+// If providerConfigs is non-nil, a preview records the provider configuration it checks in the cache, and an update
+// reuses the configuration recorded by a preview that shared the same cache.
+func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source, localPolicyPackPaths []string,
+	preview bool, backendClient BackendClient, providerConfigs *providers.ConfigCache) (*Plan, error) {
 
 	contract.Assert(ctx != nil)
 	contract.Assert(target != nil)
@@ -264,7 +275,7 @@ func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
 	// Create a new provider registry. Although we really only need to pass in any providers that were present in the
 	// old resource list, the registry itself will filter out other sorts of resources when processing the prior state,
 	// so we just pass all of the old resources.
-	reg, err := providers.NewRegistry(ctx.Host, oldResources, preview, builtins)
+	reg, err := providers.NewRegistry(ctx.Host, oldResources, preview, builtins, providerConfigs)
 	if err != nil {
 		return nil, err
 	}
@@ -279,6 +290,7 @@ func NewPlan(ctx *plugin.Context, target *Target, prev *Snapshot, source Source,
 		preview:              preview,
 		depGraph:             depGraph,
 		providers:            reg,
+		builtins:             builtins,
 	}, nil
 }
 
@@ -293,6 +305,10 @@ func (p *Plan) GetProvider(ref providers.Reference) (plugin.Provider, bool) {
 	return p.providers.GetProvider(ref)
 }
 
+func (p *Plan) getBuiltins() *builtinProvider {
+	return p.builtins
+}
+
 // generateURN generates a resource's URN from its parent, type, and name under the scope of the plan's stack and
 // project.
 func (p *Plan) generateURN(parent resource.URN, ty tokens.Type, name tokens.QName) resource.URN {
@@ -16,6 +16,7 @@ package deploy
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -23,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v2/resource/graph"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
@@ -36,6 +38,7 @@ type planExecutor struct {
 
 	stepGen  *stepGenerator // step generator owned by this plan
 	stepExec *stepExecutor  // step executor owned by this plan
+	holds    *holdTracker   // the plan's hold points, or nil if it has none
 }
 
 // A set is returned of all the target URNs to facilitate later callers.  The set can be 'nil'
@@ -180,6 +183,7 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 
 	// Set up a step generator and executor for this plan.
 	pe.stepExec = newStepExecutor(ctx, cancel, pe.plan, opts, preview, false)
+	pe.holds = newHoldTracker(opts, preview)
 
 	// We iterate the source in its own goroutine because iteration is blocking and we want the main loop to be able to
 	// respond to cancellation requests promptly.
@@ -232,7 +236,12 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 					return false, pe.performDeletes(ctx, updateTargetsOpt, destroyTargetsOpt)
 				}
 
-				if res := pe.handleSingleEvent(event.Event); res != nil {
+				if res := pe.handleSingleEvent(ctx, event.Event); res != nil {
+					// A bail after the context is done means that the plan was cancelled while the event was held.
+					if res.IsBail() && ctx.Err() != nil {
+						logging.V(4).Infof("planExecutor.Execute(...): context finished: %v", ctx.Err())
+						return callerCtx.Err() != nil, nil
+					}
 					if resErr := res.Error(); resErr != nil {
 						logging.V(4).Infof("planExecutor.Execute(...): error handling event: %v", resErr)
 						pe.reportError(pe.plan.generateEventURN(event.Event), resErr)
@@ -354,7 +363,7 @@ func (pe *planExecutor) performDeletes(
 
 // handleSingleEvent handles a single source event. For all incoming events, it produces a chain that needs
 // to be executed and schedules the chain for execution.
-func (pe *planExecutor) handleSingleEvent(event SourceEvent) result.Result {
+func (pe *planExecutor) handleSingleEvent(ctx context.Context, event SourceEvent) result.Result {
 	contract.Require(event != nil, "event != nil")
 
 	var steps []Step
@@ -376,7 +385,16 @@ func (pe *planExecutor) handleSingleEvent(event SourceEvent) result.Result {
 		return res
 	}
 
-	pe.stepExec.ExecuteSerial(steps)
+	if res := pe.holds.hold(ctx, pe.plan.generateEventURN(event), steps); res != nil {
+		return res
+	}
+
+	var group string
+	if e, isRegister := event.(RegisterResourceEvent); isRegister {
+		group = e.Goal().SerializationGroup
+	}
+	tok := pe.stepExec.ExecuteSerialInGroup(group, steps)
+	pe.holds.scheduled(steps, tok)
 	return nil
 }
 
@@ -457,6 +475,7 @@ func (pe *planExecutor) refresh(callerCtx context.Context, opts Options, preview
 	stepExec.WaitForCompletion()
 
 	pe.rebuildBaseState(resourceToStep, true /*refresh*/)
+	pe.adoptOrphans(steps, opts.AdoptOrphans && !preview)
 
 	// NOTE: we use the presence of an error in the caller context in order to distinguish caller-initiated
 	// cancellation from internally-initiated cancellation.
@@ -472,6 +491,58 @@ func (pe *planExecutor) refresh(callerCtx context.Context, opts Options, preview
 	return nil
 }
 
+// adoptOrphans reports any children that providers discovered beneath refreshed resources that are not present in the
+// base snapshot. If adopt is true, each orphan is also recorded in the base snapshot as an external resource, i.e. as a
+// read-only reference that Pulumi does not manage. This must be called after the base state has been rebuilt.
+func (pe *planExecutor) adoptOrphans(steps []Step, adopt bool) {
+	known := make(map[tokens.Type]map[resource.ID]bool)
+	for _, res := range pe.plan.prev.Resources {
+		if known[res.Type] == nil {
+			known[res.Type] = make(map[resource.ID]bool)
+		}
+		known[res.Type][res.ID] = true
+	}
+
+	adopted := false
+	for _, step := range steps {
+		refresh := step.(*RefreshStep)
+		parent := refresh.New()
+		if parent == nil || len(refresh.children) == 0 {
+			continue
+		}
+		for _, child := range refresh.children {
+			urn := pe.plan.generateURN(parent.URN, child.Type, child.Name)
+			if _, has := pe.plan.olds[urn]; has || known[child.Type][child.ID] {
+				continue
+			}
+
+			if !adopt {
+				pe.plan.Diag().Infof(diag.RawMessage(parent.URN, fmt.Sprintf(
+					"discovered unmanaged child %s (id=%s); refresh with --adopt-orphans to record it", urn, child.ID)))
+				continue
+			}
+
+			pe.plan.Diag().Infof(diag.RawMessage(parent.URN, fmt.Sprintf(
+				"adopted unmanaged child %s (id=%s) as an external resource; import it to manage it with Pulumi",
+				urn, child.ID)))
+
+			orphan := resource.NewState(child.Type, urn, true, false, child.ID, resource.PropertyMap{}, child.Outputs,
+				parent.URN, false, true, nil, nil, parent.Provider, nil, false, nil, nil, nil, "")
+			pe.plan.prev.Resources = append(pe.plan.prev.Resources, orphan)
+			pe.plan.olds[urn] = orphan
+			if known[child.Type] == nil {
+				known[child.Type] = make(map[resource.ID]bool)
+			}
+			known[child.Type][child.ID] = true
+			adopted = true
+		}
+	}
+
+	if adopted {
+		pe.plan.depGraph = graph.NewDependencyGraph(pe.plan.prev.Resources)
+	}
+}
+
 func (pe *planExecutor) rebuildBaseState(resourceToStep map[*resource.State]Step, refresh bool) {
 	// Rebuild this plan's map of old resources and dependency graph, stripping out any deleted
 	// resources and repairing dependency lists as necessary. Note that this updates the base
@@ -0,0 +1,71 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providers
+
+import (
+	"sync"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+)
+
+// ConfigCache records the configuration of provider resources that was successfully checked and applied during a
+// preview. When the update that follows the preview in the same invocation shares the preview's cache, the registry
+// reuses the results of those checks for providers whose configuration has not changed rather than checking it again.
+//
+// A nil *ConfigCache is valid and records nothing. A ConfigCache is safe for concurrent use.
+type ConfigCache struct {
+	m       sync.Mutex
+	entries map[resource.URN]configCacheEntry
+}
+
+// configCacheEntry is the result of checking the configuration of a single provider resource.
+type configCacheEntry struct {
+	olds   resource.PropertyMap // the old configuration that was checked.
+	news   resource.PropertyMap // the new configuration that was checked.
+	inputs resource.PropertyMap // the checked configuration returned by the provider.
+}
+
+// NewConfigCache creates a new, empty provider configuration cache.
+func NewConfigCache() *ConfigCache {
+	return &ConfigCache{entries: make(map[resource.URN]configCacheEntry)}
+}
+
+// add records the result of checking the configuration of the given provider resource. Configuration that is not yet
+// fully known is not recorded, as it may change by the time an update runs.
+func (c *ConfigCache) add(urn resource.URN, olds, news, inputs resource.PropertyMap) {
+	if c == nil || news.ContainsUnknowns() || inputs.ContainsUnknowns() {
+		return
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.entries[urn] = configCacheEntry{olds: olds, news: news, inputs: inputs}
+}
+
+// get returns the checked configuration recorded for the given provider resource if its old and new configuration
+// are the same as those that were checked.
+func (c *ConfigCache) get(urn resource.URN, olds, news resource.PropertyMap) (resource.PropertyMap, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	entry, ok := c.entries[urn]
+	if !ok || !entry.olds.DeepEquals(olds) || !entry.news.DeepEquals(news) {
+		return nil, false
+	}
+	return entry.inputs, true
+}
@@ -65,6 +65,7 @@ type Registry struct {
 	isPreview bool
 	providers map[Reference]plugin.Provider
 	builtins  plugin.Provider
+	configs   *ConfigCache
 	m         sync.RWMutex
 }
 
@@ -83,14 +84,18 @@ func loadProvider(pkg tokens.Package, version *semver.Version, host plugin.Host,
 // NewRegistry creates a new provider registry using the given host and old resources. Each provider present in the old
 // resources will be loaded, configured, and added to the returned registry under its reference. If any provider is not
 // loadable/configurable or has an invalid ID, this function returns an error.
+//
+// If configs is non-nil, a preview records the provider configuration it checks in configs, and an update reuses the
+// configuration recorded by a preview that shared the same cache.
 func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool,
-	builtins plugin.Provider) (*Registry, error) {
+	builtins plugin.Provider, configs *ConfigCache) (*Registry, error) {
 
 	r := &Registry{
 		host:      host,
 		isPreview: isPreview,
 		providers: make(map[Reference]plugin.Provider),
 		builtins:  builtins,
+		configs:   configs,
 	}
 
 	for _, res := range prev {
@@ -218,7 +223,10 @@ func (r *Registry) Configure(props resource.PropertyMap) error {
 //   to check its config
 // - we need to keep the newly-loaded provider around in case we need to diff its config
 // - if we are running a preview, we need to configure the provider, as its corresponding CRUD operations will not run
-//   (we would normally configure the provider in Create or Update).
+//   (we would normally configure the provider in Create or Update). We wait for the provider to apply its
+//   configuration so that configuration errors (e.g. bad credentials) are reported by the preview.
+// - if we are running an update that follows a preview that checked the same configuration, we reuse the results of
+//   the preview's check rather than checking the configuration again.
 func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
 
@@ -240,22 +248,30 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 		return nil, nil, errors.New("could not find plugin")
 	}
 
-	// Check the provider's config. If the check fails, unload the provider.
-	inputs, failures, err := provider.CheckConfig(urn, olds, news, allowUnknowns)
-	if len(failures) != 0 || err != nil {
-		closeErr := r.host.CloseProvider(provider)
-		contract.IgnoreError(closeErr)
-		return nil, failures, err
+	// Check the provider's config, unless the preview that preceded this update already did so. If the check fails,
+	// unload the provider.
+	inputs, ok := r.configs.get(urn, olds, news)
+	if ok && !r.isPreview {
+		logging.V(7).Infof("%s using config checked during preview", label)
+	} else {
+		var failures []plugin.CheckFailure
+		inputs, failures, err = provider.CheckConfig(urn, olds, news, allowUnknowns)
+		if len(failures) != 0 || err != nil {
+			closeErr := r.host.CloseProvider(provider)
+			contract.IgnoreError(closeErr)
+			return nil, failures, err
+		}
 	}
 
-	// If we are running a preview, configure the provider now. If we are not running a preview, we will configure the
-	// provider when it is created or updated.
+	// If we are running a preview, configure the provider now and record the checked config for the update. If we are
+	// not running a preview, we will configure the provider when it is created or updated.
 	if r.isPreview {
-		if err := provider.Configure(inputs); err != nil {
+		if err := configure(provider, inputs); err != nil {
 			closeErr := r.host.CloseProvider(provider)
 			contract.IgnoreError(closeErr)
 			return nil, nil, err
 		}
+		r.configs.add(urn, olds, news, inputs)
 	}
 
 	// Create a provider reference using the URN and the unknown ID and register the provider.
@@ -264,6 +280,17 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	return inputs, nil, nil
 }
 
+// configure configures the given provider and waits for the provider to apply its configuration.
+func configure(provider plugin.Provider, inputs resource.PropertyMap) error {
+	if err := provider.Configure(inputs); err != nil {
+		return err
+	}
+	if awaiter, ok := provider.(plugin.ConfigurationAwaiter); ok {
+		return awaiter.AwaitConfiguration()
+	}
+	return nil
+}
+
 // Diff diffs the configuration of the indicated provider. The provider corresponding to the given URN must have
 // previously been loaded by a call to Check.
 func (r *Registry) Diff(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
@@ -405,6 +432,16 @@ func (r *Registry) StreamInvoke(
 	return nil, fmt.Errorf("the provider registry does not implement streaming invokes")
 }
 
+func (r *Registry) GetLogs(req plugin.LogsRequest, onNext func(plugin.LogEntry) error) error {
+	return plugin.ErrLogsNotSupported
+}
+
+func (r *Registry) Call(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	return nil, nil, plugin.ErrCallNotSupported
+}
+
 func (r *Registry) GetPluginInfo() (workspace.PluginInfo, error) {
 	// return an error: this should not be called for the provider registry
 	return workspace.PluginInfo{}, errors.New("the provider registry does not report plugin info")
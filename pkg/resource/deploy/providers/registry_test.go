@@ -153,6 +153,14 @@ func (prov *testProvider) StreamInvoke(
 
 	return nil, fmt.Errorf("not implemented")
 }
+func (prov *testProvider) GetLogs(req plugin.LogsRequest, onNext func(plugin.LogEntry) error) error {
+	return plugin.ErrLogsNotSupported
+}
+func (prov *testProvider) Call(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+	return nil, nil, plugin.ErrCallNotSupported
+}
 func (prov *testProvider) GetPluginInfo() (workspace.PluginInfo, error) {
 	return workspace.PluginInfo{
 		Name:    "testProvider",
@@ -252,11 +260,11 @@ func newProviderState(pkg, name, id string, delete bool, inputs resource.Propert
 }
 
 func TestNewRegistryNoOldState(t *testing.T) {
-	r, err := NewRegistry(&testPluginHost{}, nil, false, nil)
+	r, err := NewRegistry(&testPluginHost{}, nil, false, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
-	r, err = NewRegistry(&testPluginHost{}, nil, true, nil)
+	r, err = NewRegistry(&testPluginHost{}, nil, true, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 }
@@ -285,7 +293,7 @@ func TestNewRegistryOldState(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false, nil)
+	r, err := NewRegistry(host, olds, false, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -319,7 +327,7 @@ func TestNewRegistryOldStateNoProviders(t *testing.T) {
 	}
 	host := newPluginHost(t, []*providerLoader{})
 
-	r, err := NewRegistry(host, olds, false, nil)
+	r, err := NewRegistry(host, olds, false, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -333,7 +341,7 @@ func TestNewRegistryOldStateWrongPackage(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false, nil)
+	r, err := NewRegistry(host, olds, false, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -349,7 +357,7 @@ func TestNewRegistryOldStateWrongVersion(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false, nil)
+	r, err := NewRegistry(host, olds, false, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -363,7 +371,7 @@ func TestNewRegistryOldStateNoID(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false, nil)
+	r, err := NewRegistry(host, olds, false, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -377,7 +385,7 @@ func TestNewRegistryOldStateUnknownID(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false, nil)
+	r, err := NewRegistry(host, olds, false, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -392,7 +400,7 @@ func TestNewRegistryOldStateDuplicates(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false, nil)
+	r, err := NewRegistry(host, olds, false, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -410,7 +418,7 @@ func TestCRUD(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, false, nil)
+	r, err := NewRegistry(host, olds, false, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -554,7 +562,7 @@ func TestCRUDPreview(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, olds, true, nil)
+	r, err := NewRegistry(host, olds, true, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -659,7 +667,7 @@ func TestCRUDPreview(t *testing.T) {
 func TestCRUDNoProviders(t *testing.T) {
 	host := newPluginHost(t, []*providerLoader{})
 
-	r, err := NewRegistry(host, []*resource.State{}, false, nil)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -680,7 +688,7 @@ func TestCRUDWrongPackage(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, []*resource.State{}, false, nil)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -701,7 +709,7 @@ func TestCRUDWrongVersion(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, []*resource.State{}, false, nil)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -722,7 +730,7 @@ func TestCRUDBadVersionNotString(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, []*resource.State{}, false, nil)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -744,7 +752,7 @@ func TestCRUDBadVersion(t *testing.T) {
 	}
 	host := newPluginHost(t, loaders)
 
-	r, err := NewRegistry(host, []*resource.State{}, false, nil)
+	r, err := NewRegistry(host, []*resource.State{}, false, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, r)
 
@@ -759,3 +767,107 @@ func TestCRUDBadVersion(t *testing.T) {
 	assert.Equal(t, "version", string(failures[0].Property))
 	assert.Nil(t, inputs)
 }
+
+// asyncTestProvider is a test provider that applies its configuration asynchronously.
+type asyncTestProvider struct {
+	*testProvider
+
+	configErr error
+}
+
+func (prov *asyncTestProvider) AwaitConfiguration() error {
+	return prov.configErr
+}
+
+func TestCheckPreviewAwaitsConfiguration(t *testing.T) {
+	configErr := errors.New("invalid credentials")
+	loaders := []*providerLoader{
+		newLoader(t, "pkgA", "", func(pkg tokens.Package, ver semver.Version) (plugin.Provider, error) {
+			simple, err := newSimpleLoader(t, "pkgA", "", nil).load()
+			if err != nil {
+				return nil, err
+			}
+			return &asyncTestProvider{testProvider: simple.(*testProvider), configErr: configErr}, nil
+		}),
+	}
+	host := newPluginHost(t, loaders)
+
+	typ := MakeProviderType("pkgA")
+	urn := resource.NewURN("test", "test", "", typ, "b")
+	olds, news := resource.PropertyMap{}, resource.PropertyMap{}
+
+	// A preview reports the error produced by applying the provider's configuration.
+	r, err := NewRegistry(host, []*resource.State{}, true, nil, nil)
+	assert.NoError(t, err)
+	_, _, err = r.Check(urn, olds, news, true)
+	assert.Equal(t, configErr, err)
+	_, ok := r.GetProvider(Reference{urn: urn, id: UnknownID})
+	assert.False(t, ok)
+
+	// An update does not configure the provider until it is created or updated.
+	r, err = NewRegistry(host, []*resource.State{}, false, nil, nil)
+	assert.NoError(t, err)
+	_, _, err = r.Check(urn, olds, news, false)
+	assert.NoError(t, err)
+}
+
+func TestCheckUsesPreviewConfig(t *testing.T) {
+	checks := 0
+	loaders := []*providerLoader{
+		newLoader(t, "pkgA", "", func(pkg tokens.Package, ver semver.Version) (plugin.Provider, error) {
+			simple, err := newSimpleLoader(t, "pkgA", "", nil).load()
+			if err != nil {
+				return nil, err
+			}
+			prov := simple.(*testProvider)
+			prov.checkConfig = func(urn resource.URN, olds,
+				news resource.PropertyMap, allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+				checks++
+				inputs := news.Copy()
+				inputs["checked"] = resource.NewBoolProperty(true)
+				return inputs, nil, nil
+			}
+			return prov, nil
+		}),
+	}
+	host := newPluginHost(t, loaders)
+	configs := NewConfigCache()
+
+	typ := MakeProviderType("pkgA")
+	known := resource.NewURN("test", "test", "", typ, "known")
+	unknown := resource.NewURN("test", "test", "", typ, "unknown")
+	olds := resource.PropertyMap{}
+	knownNews := resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}
+	unknownNews := resource.PropertyMap{"region": resource.MakeComputed(resource.NewStringProperty(""))}
+
+	// Check each provider's config during a preview.
+	preview, err := NewRegistry(host, []*resource.State{}, true, nil, configs)
+	assert.NoError(t, err)
+	for _, urn := range []resource.URN{known, unknown} {
+		news := knownNews
+		if urn == unknown {
+			news = unknownNews
+		}
+		_, _, err = preview.Check(urn, olds, news, true)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, checks)
+
+	// An update that shares the preview's cache reuses the checked config if the config has not changed.
+	update, err := NewRegistry(host, []*resource.State{}, false, nil, configs)
+	assert.NoError(t, err)
+	inputs, _, err := update.Check(known, olds, knownNews, false)
+	assert.NoError(t, err)
+	assert.True(t, inputs["checked"].BoolValue())
+	assert.Equal(t, 2, checks)
+	_, ok := update.GetProvider(Reference{urn: known, id: UnknownID})
+	assert.True(t, ok)
+
+	// Changed config and config that was not known during the preview are checked again.
+	_, _, err = update.Check(known, olds, resource.PropertyMap{"region": resource.NewStringProperty("us-east-1")}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, checks)
+	_, _, err = update.Check(unknown, olds, knownNews, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, checks)
+}
@@ -300,7 +300,7 @@ func (d *defaultProviders) newRegisterDefaultProviderEvent(
 	event := &registerResourceEvent{
 		goal: resource.NewGoal(
 			providers.MakeProviderType(req.Package()),
-			req.Name(), true, inputs, "", false, nil, "", nil, nil, nil, nil, nil, nil, "", nil),
+			req.Name(), true, inputs, "", false, nil, "", nil, nil, nil, nil, nil, nil, "", nil, ""),
 		done: done,
 	}
 	return event, done, nil
@@ -514,13 +514,36 @@ func parseProviderRequest(pkg tokens.Package, version string) (providers.Provide
 	return providers.NewProviderRequest(&parsedVersion, pkg), nil
 }
 
+// builtinsSource is a provider source that also provides the builtin provider, e.g. a plan.
+type builtinsSource interface {
+	ProviderSource
+
+	// getBuiltins returns the builtin provider.
+	getBuiltins() *builtinProvider
+}
+
+// getInvokeProvider fetches the provider plugin for an invoke. Reports of the configuration keys that the program
+// read are sent straight to the builtin provider, so that they do not require a default provider for the builtin
+// package to be registered.
+func (rm *resmon) getInvokeProvider(tok tokens.ModuleMember, req *pulumirpc.InvokeRequest) (plugin.Provider, error) {
+	if src, ok := rm.providers.(builtinsSource); ok && tok == reportConfigUsage && req.GetProvider() == "" {
+		return src.getBuiltins(), nil
+	}
+
+	providerReq, err := parseProviderRequest(tok.Package(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	return getProviderFromSource(rm.providers, rm.defaultProviders, providerReq, req.GetProvider())
+}
+
 func (rm *resmon) SupportsFeature(ctx context.Context,
 	req *pulumirpc.SupportsFeatureRequest) (*pulumirpc.SupportsFeatureResponse, error) {
 
 	hasSupport := false
 
 	switch req.Id {
-	case "secrets":
+	case "secrets", "decimals", "bytes", "configUsage":
 		hasSupport = true
 	}
 
@@ -535,11 +558,7 @@ func (rm *resmon) SupportsFeature(ctx context.Context,
 func (rm *resmon) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
 	// Fetch the token and load up the resource provider if necessary.
 	tok := tokens.ModuleMember(req.GetTok())
-	providerReq, err := parseProviderRequest(tok.Package(), req.GetVersion())
-	if err != nil {
-		return nil, err
-	}
-	prov, err := getProviderFromSource(rm.providers, rm.defaultProviders, providerReq, req.GetProvider())
+	prov, err := rm.getInvokeProvider(tok, req)
 	if err != nil {
 		return nil, err
 	}
@@ -665,6 +684,8 @@ func (rm *resmon) ReadResource(ctx context.Context,
 		Label:        label,
 		KeepUnknowns: true,
 		KeepSecrets:  true,
+		KeepDecimals: true,
+		KeepBytes:    true,
 	})
 	if err != nil {
 		return nil, err
@@ -731,6 +752,7 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	ignoreChanges := req.GetIgnoreChanges()
 	id := resource.ID(req.GetImportId())
 	customTimeouts := req.GetCustomTimeouts()
+	serializationGroup := req.GetSerializationGroup()
 	var t tokens.Type
 
 	// Custom resources must have a three-part type so that we can 1) identify if they are providers and 2) retrieve the
@@ -776,11 +798,21 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 			KeepUnknowns:       true,
 			ComputeAssetHashes: true,
 			KeepSecrets:        true,
+			KeepDecimals:       true,
+			KeepBytes:          true,
 		})
 	if err != nil {
 		return nil, err
 	}
 
+	// Custom await options are not inputs of the resource. Take them out of the properties so that the provider does
+	// not check them and they are not stored in the resource's state; the goal carries them to Create and Update.
+	var customAwait *resource.CustomAwait
+	if await, ok := resource.GetCustomAwait(props); ok {
+		customAwait = &await
+	}
+	delete(props, resource.CustomAwaitKey)
+
 	propertyDependencies := make(map[resource.PropertyKey][]resource.URN)
 	if len(req.GetPropertyDependencies()) == 0 {
 		// If this request did not specify property dependencies, treat each property as depending on every resource
@@ -836,14 +868,18 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 
 	logging.V(5).Infof(
 		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, "+
-			"provider=%v, deps=%v, deleteBeforeReplace=%v, ignoreChanges=%v, aliases=%v, customTimeouts=%v",
+			"provider=%v, deps=%v, deleteBeforeReplace=%v, ignoreChanges=%v, aliases=%v, customTimeouts=%v, "+
+			"serializationGroup=%v",
 		t, name, custom, len(props), parent, protect, provider, dependencies, deleteBeforeReplace, ignoreChanges,
-		aliases, timeouts)
+		aliases, timeouts, serializationGroup)
 
 	// Send the goal state to the engine.
+	goal := resource.NewGoal(t, name, custom, props, parent, protect, dependencies, provider, nil,
+		propertyDependencies, deleteBeforeReplace, ignoreChanges, additionalSecretOutputs, aliases, id, &timeouts,
+		serializationGroup)
+	goal.CustomAwait = customAwait
 	step := &registerResourceEvent{
-		goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies, provider, nil,
-			propertyDependencies, deleteBeforeReplace, ignoreChanges, additionalSecretOutputs, aliases, id, &timeouts),
+		goal: goal,
 		done: make(chan *RegisterResult),
 	}
 
@@ -914,6 +950,8 @@ func (rm *resmon) RegisterResourceOutputs(ctx context.Context,
 			KeepUnknowns:       true,
 			ComputeAssetHashes: true,
 			KeepSecrets:        true,
+			KeepDecimals:       true,
+			KeepBytes:          true,
 		})
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot unmarshal output properties")
@@ -52,7 +52,7 @@ func NewQuerySource(cancel context.Context, plugctx *plugin.Context, client Back
 	// Create a new builtin provider. This provider implements features such as `getStack`.
 	builtins := newBuiltinProvider(client)
 
-	reg, err := providers.NewRegistry(plugctx.Host, nil, false, builtins)
+	reg, err := providers.NewRegistry(plugctx.Host, nil, false, builtins, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to start resource monitor")
 	}
@@ -218,7 +218,7 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 				return resource.StatusOK, nil, err
 			}
 
-			id, outs, rst, err := prov.Create(s.URN(), s.new.Inputs, s.new.CustomTimeouts.Create)
+			id, outs, rst, err := prov.Create(s.URN(), providerInputs(s.reg, s.new.Inputs), s.new.CustomTimeouts.Create)
 			if err != nil {
 				if rst != resource.StatusPartialFailure {
 					return rst, nil, err
@@ -234,7 +234,9 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 
 			contract.Assert(id != "")
 
-			// Copy any of the default and output properties on the live object state.
+			// Copy any of the default and output properties on the live object state. Providers that echo their
+			// inputs also echo the custom await options, which do not belong in the resource's state.
+			delete(outs, resource.CustomAwaitKey)
 			s.new.ID = id
 			s.new.Outputs = outs
 		}
@@ -447,7 +449,7 @@ func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			}
 
 			// Update to the combination of the old "all" state, but overwritten with new inputs.
-			outs, rst, upderr := prov.Update(s.URN(), s.old.ID, s.old.Outputs, s.new.Inputs,
+			outs, rst, upderr := prov.Update(s.URN(), s.old.ID, s.old.Outputs, providerInputs(s.reg, s.new.Inputs),
 				s.new.CustomTimeouts.Update, s.ignoreChanges)
 			if upderr != nil {
 				if rst != resource.StatusPartialFailure {
@@ -463,6 +465,7 @@ func (s *UpdateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 			}
 
 			// Now copy any output state back in case the update triggered cascading updates to other properties.
+			delete(outs, resource.CustomAwaitKey)
 			s.new.Outputs = outs
 		}
 	} else {
@@ -662,10 +665,11 @@ func (s *ReadStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error
 // resource by reading its current state from its provider plugin. These steps are not issued by the step generator;
 // instead, they are issued by the plan executor as the optional first step in plan execution.
 type RefreshStep struct {
-	plan *Plan           // the plan that produced this refresh
-	old  *resource.State // the old resource state, if one exists for this urn
-	new  *resource.State // the new resource state, to be used to query the provider
-	done chan<- bool     // the channel to use to signal completion, if any
+	plan     *Plan              // the plan that produced this refresh
+	old      *resource.State    // the old resource state, if one exists for this urn
+	new      *resource.State    // the new resource state, to be used to query the provider
+	children []plugin.ReadChild // any unmanaged children the provider discovered beneath this resource
+	done     chan<- bool        // the channel to use to signal completion, if any
 }
 
 // NewRefreshStep creates a new Refresh step.
@@ -762,6 +766,8 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
 			s.old.PropertyDependencies, s.old.PendingReplacement, s.old.AdditionalSecretOutputs, s.old.Aliases,
 			&s.old.CustomTimeouts, s.old.ImportID)
+		s.new.SerializationGroup = s.old.SerializationGroup
+		s.children = refreshed.Children
 	} else {
 		s.new = nil
 	}
@@ -872,6 +878,7 @@ func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	s.old = resource.NewState(s.new.Type, s.new.URN, s.new.Custom, false, s.new.ID, read.Inputs, read.Outputs,
 		s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
 		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID)
+	s.old.SerializationGroup = s.new.SerializationGroup
 
 	// Check the user inputs using the provider inputs for defaults.
 	inputs, failures, err := prov.Check(s.new.URN, s.old.Inputs, s.new.Inputs, preview)
@@ -1064,3 +1071,18 @@ func getProvider(s Step) (plugin.Provider, error) {
 	}
 	return provider, nil
 }
+
+// providerInputs returns the inputs to pass to a provider's Create or Update method: the resource's checked inputs,
+// plus the custom await options of its registration, if any. The options are never part of the checked inputs, so
+// Check does not see them, and the steps drop them from the outputs so that they are not stored in the state.
+func providerInputs(reg RegisterResourceEvent, inputs resource.PropertyMap) resource.PropertyMap {
+	if reg == nil || reg.Goal() == nil || reg.Goal().CustomAwait == nil {
+		return inputs
+	}
+	withAwait := make(resource.PropertyMap, len(inputs)+1)
+	for k, v := range inputs {
+		withAwait[k] = v
+	}
+	withAwait[resource.CustomAwaitKey] = reg.Goal().CustomAwait.PropertyValue()
+	return withAwait
+}
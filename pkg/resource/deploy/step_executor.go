@@ -17,6 +17,7 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -74,6 +75,7 @@ func (c completionToken) Wait(ctx context.Context) {
 // incomingChain represents a request to the step executor to execute a chain.
 type incomingChain struct {
 	Chain          chain     // The chain we intend to execute
+	Groups         []string  // The serialization groups that the chain holds while it executes, in sorted order
 	CompletionChan chan bool // A completion channel to be closed when the chain has completed execution
 }
 
@@ -94,6 +96,9 @@ type stepExecutor struct {
 	workers        sync.WaitGroup     // WaitGroup tracking the worker goroutines that are owned by this step executor.
 	incomingChains chan incomingChain // Incoming chains that we are to execute
 
+	groupLocks     sync.Map // Serialization group names to the *sync.Mutex held while executing a chain in the group.
+	resourceGroups sync.Map // Resource URNs to the serialization group they were last registered in.
+
 	ctx      context.Context    // cancellation context for the current plan.
 	cancel   context.CancelFunc // CancelFunc that cancels the above context.
 	sawError atomic.Value       // atomic boolean indicating whether or not the step excecutor saw that there was an error.
@@ -108,12 +113,29 @@ type stepExecutor struct {
 // Execute submits a Chain for asynchronous execution. The execution of the chain will begin as soon as there
 // is a worker available to execute it.
 func (se *stepExecutor) ExecuteSerial(chain chain) completionToken {
+	return se.ExecuteSerialInGroup("", chain)
+}
+
+// ExecuteSerialInGroup submits a Chain for asynchronous execution like ExecuteSerial, but never executes it
+// concurrently with another chain in the same serialization group. The resources of the chain's logical steps are
+// remembered as members of the group, so that deleting them later in the plan is serialized as well. An empty group
+// places no restriction on the chain. Steps that delete a resource are serialized with the resource's own group too.
+func (se *stepExecutor) ExecuteSerialInGroup(group string, chain chain) completionToken {
+	if group != "" {
+		for _, step := range chain {
+			if step.Logical() {
+				se.resourceGroups.Store(step.URN(), group)
+			}
+		}
+	}
+	groups := se.chainGroups(group, chain)
+
 	// The select here is to avoid blocking on a send to se.incomingChains if a cancellation is pending.
 	// If one is pending, we should exit early - we will shortly be tearing down the engine and exiting.
 
 	completion := make(chan bool)
 	select {
-	case se.incomingChains <- incomingChain{Chain: chain, CompletionChan: completion}:
+	case se.incomingChains <- incomingChain{Chain: chain, Groups: groups, CompletionChan: completion}:
 	case <-se.ctx.Done():
 		close(completion)
 	}
@@ -207,9 +229,55 @@ func (se *stepExecutor) WaitForCompletion() {
 // the next few functions.
 //
 
+// chainGroups returns the serialization groups that a chain holds while it executes, in sorted order: the group it
+// was submitted in, plus the group of each resource that it deletes. A deleted resource's group is the one recorded
+// in its state or, for a resource from a checkpoint that predates serialization groups, the one that the plan last
+// registered it in.
+func (se *stepExecutor) chainGroups(group string, chain chain) []string {
+	var groups []string
+	addGroup := func(g string) {
+		if g == "" {
+			return
+		}
+		for _, existing := range groups {
+			if existing == g {
+				return
+			}
+		}
+		groups = append(groups, g)
+	}
+
+	addGroup(group)
+	for _, step := range chain {
+		if op := step.Op(); op != OpDelete && op != OpDeleteReplaced {
+			continue
+		}
+		g := step.Old().SerializationGroup
+		if g == "" {
+			if registered, has := se.resourceGroups.Load(step.URN()); has {
+				g = registered.(string)
+			}
+		}
+		addGroup(g)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
 // executeChain executes a chain, one step at a time. If any step in the chain fails to execute, or if the
-// context is canceled, the chain stops execution.
-func (se *stepExecutor) executeChain(workerID int, chain chain) {
+// context is canceled, the chain stops execution. A chain in serialization groups holds the groups' locks while it
+// executes, taking them in sorted order so that chains in overlapping groups cannot deadlock; previews apply no
+// changes, so they do not take the locks.
+func (se *stepExecutor) executeChain(workerID int, groups []string, chain chain) {
+	if !se.preview {
+		for _, group := range groups {
+			lock, _ := se.groupLocks.LoadOrStore(group, &sync.Mutex{})
+			se.log(workerID, "waiting for serialization group %q", group)
+			lock.(*sync.Mutex).Lock()
+			defer lock.(*sync.Mutex).Unlock()
+		}
+	}
+
 	for _, step := range chain {
 		select {
 		case <-se.ctx.Done():
@@ -356,7 +424,7 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 
 			se.log(workerID, "worker received chain for execution")
 			if !launchAsync {
-				se.executeChain(workerID, request.Chain)
+				se.executeChain(workerID, request.Groups, request.Chain)
 				close(request.CompletionChan)
 				continue
 			}
@@ -368,7 +436,7 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 			go func() {
 				defer se.workers.Done()
 				se.log(newWorkerID, "launching oneshot worker")
-				se.executeChain(newWorkerID, request.Chain)
+				se.executeChain(newWorkerID, request.Groups, request.Chain)
 				close(request.CompletionChan)
 			}()
 
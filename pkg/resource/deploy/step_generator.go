@@ -252,6 +252,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
 		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
 		goal.AdditionalSecretOutputs, goal.Aliases, &goal.CustomTimeouts, "")
+	new.SerializationGroup = goal.SerializationGroup
 
 	// Mark the URN/resource as having been seen. So we can run analyzers on all resources seen, as well as
 	// lookup providers for calculating replacement of resources that use the provider.
@@ -322,6 +323,7 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		} else if issueCheckErrors(sg.plan, new, urn, failures) {
 			invalid = true
 		}
+
 		new.Inputs = inputs
 	}
 
@@ -543,6 +545,9 @@ func (sg *stepGenerator) generateStepsFromDiff(
 				}
 			}
 
+			if !sg.checkReplaceAllowed(urn) {
+				return nil, result.Bail()
+			}
 			sg.replaces[urn] = true
 
 			// If we are going to perform a replacement, we need to recompute the default values.  The above logic
@@ -611,6 +616,9 @@ func (sg *stepGenerator) generateStepsFromDiff(
 							continue
 						}
 
+						if !sg.checkReplaceAllowed(dependentResource.URN) {
+							return nil, result.Bail()
+						}
 						sg.dependentReplaceKeys[dependentResource.URN] = toReplace[i].keys
 
 						logging.V(7).Infof("Planner decided to delete '%v' due to dependence on condemned resource '%v'",
@@ -764,6 +772,18 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 	return dels, nil
 }
 
+// checkReplaceAllowed reports an error if the resource's type is one that may not be replaced. It returns false if
+// step generation must stop. In preview we keep going so that the user hears about every such replacement at once.
+func (sg *stepGenerator) checkReplaceAllowed(urn resource.URN) bool {
+	typ := urn.Type()
+	if !sg.opts.ProtectedReplaceTypes[typ] {
+		return true
+	}
+	sg.plan.Diag().Errorf(diag.GetResourceWillBeReplacedButTypeIsProtected(urn), urn, typ, typ)
+	sg.sawError = true
+	return sg.plan.preview
+}
+
 func (sg *stepGenerator) determineAllowedResourcesToDeleteFromTargets(
 	targetsOpt map[resource.URN]bool) (map[resource.URN]bool, result.Result) {
 
@@ -295,6 +295,7 @@ func SerializeResource(res *resource.State, enc config.Encrypter, showSecrets bo
 		AdditionalSecretOutputs: res.AdditionalSecretOutputs,
 		Aliases:                 res.Aliases,
 		ImportID:                res.ImportID,
+		SerializationGroup:      res.SerializationGroup,
 	}
 
 	if res.CustomTimeouts.IsNotEmpty() {
@@ -365,11 +366,32 @@ func SerializePropertyValue(prop resource.PropertyValue, enc config.Encrypter,
 
 	// For assets, we need to serialize them a little carefully, so we can recover them afterwards.
 	if prop.IsAsset() {
-		return prop.AssetValue().Serialize(), nil
+		asset := prop.AssetValue()
+		serialized := asset.Serialize()
+		if len(asset.Headers) != 0 {
+			// A remote asset's headers typically hold credentials, so they are always encrypted.
+			headers := resource.MakeSecret(resource.NewPropertyValue(serialized[resource.AssetHeadersProperty]))
+			sheaders, err := SerializePropertyValue(headers, enc, showSecrets)
+			if err != nil {
+				return nil, err
+			}
+			serialized[resource.AssetHeadersProperty] = sheaders
+		}
+		return serialized, nil
 	} else if prop.IsArchive() {
 		return prop.ArchiveValue().Serialize(), nil
 	}
 
+	// Decimals are serialized as signed objects so that they are not rounded to the nearest double.
+	if prop.IsDecimal() {
+		return prop.DecimalValue().Serialize(), nil
+	}
+
+	// Byte strings are serialized as signed objects so that they are not confused with ordinary strings.
+	if prop.IsBytes() {
+		return prop.BytesValue().Serialize(), nil
+	}
+
 	if prop.IsSecret() {
 		// Since we are going to encrypt property value, we can elide encrypting sub-elements. We'll mark them as
 		// "secret" so we retain that information when deserializaing the overall structure, but there is no
@@ -426,11 +448,13 @@ func DeserializeResource(res apitype.ResourceV3, dec config.Decrypter, enc confi
 		return nil, err
 	}
 
-	return resource.NewState(
+	state := resource.NewState(
 		res.Type, res.URN, res.Custom, res.Delete, res.ID,
 		inputs, outputs, res.Parent, res.Protect, res.External, res.Dependencies, res.InitErrors, res.Provider,
 		res.PropertyDependencies, res.PendingReplacement, res.AdditionalSecretOutputs, res.Aliases, res.CustomTimeouts,
-		res.ImportID), nil
+		res.ImportID)
+	state.SerializationGroup = res.SerializationGroup
+	return state, nil
 }
 
 func DeserializeOperation(op apitype.OperationV2, dec config.Decrypter,
@@ -491,6 +515,10 @@ func DeserializePropertyValue(v interface{}, dec config.Decrypter,
 			if sig, hasSig := objmap[resource.SigKey]; hasSig {
 				switch sig {
 				case resource.AssetSig:
+					// Asset headers are written as a secret; see SerializePropertyValue.
+					if headers, has := obj[resource.AssetHeadersProperty]; has && headers.IsSecret() {
+						objmap[resource.AssetHeadersProperty] = headers.SecretValue().Element.Mappable()
+					}
 					asset, isasset, err := resource.DeserializeAsset(objmap)
 					if err != nil {
 						return resource.PropertyValue{}, err
@@ -504,6 +532,20 @@ func DeserializePropertyValue(v interface{}, dec config.Decrypter,
 					}
 					contract.Assert(isarchive)
 					return resource.NewArchiveProperty(archive), nil
+				case resource.BytesSig:
+					bytes, isbytes, err := resource.DeserializeBytes(objmap)
+					if err != nil {
+						return resource.PropertyValue{}, err
+					}
+					contract.Assert(isbytes)
+					return resource.NewBytesProperty(bytes), nil
+				case resource.DecimalSig:
+					decimal, isdecimal, err := resource.DeserializeDecimal(objmap)
+					if err != nil {
+						return resource.PropertyValue{}, err
+					}
+					contract.Assert(isdecimal)
+					return resource.NewDecimalProperty(decimal), nil
 				case resource.SecretSig:
 					ciphertext, cipherOk := objmap["ciphertext"].(string)
 					plaintext, plainOk := objmap["plaintext"].(string)
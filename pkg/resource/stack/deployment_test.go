@@ -195,6 +195,88 @@ func TestUnknownSig(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDecimalSerialization(t *testing.T) {
+	d := resource.NewDecimalProperty("-9223372036854775808")
+	v, err := SerializePropertyValue(d, config.NopEncrypter, false)
+	assert.NoError(t, err)
+
+	bytes, err := json.Marshal(v)
+	assert.NoError(t, err)
+	var raw interface{}
+	err = json.Unmarshal(bytes, &raw)
+	assert.NoError(t, err)
+
+	actual, err := DeserializePropertyValue(raw, config.NopDecrypter, config.NopEncrypter)
+	assert.NoError(t, err)
+	assert.True(t, actual.IsDecimal())
+	assert.Equal(t, d.DecimalValue(), actual.DecimalValue())
+}
+
+func TestBytesSerialization(t *testing.T) {
+	b := resource.NewBytesProperty(resource.Bytes("binary\x00data"))
+	v, err := SerializePropertyValue(b, config.NopEncrypter, false)
+	assert.NoError(t, err)
+
+	bytes, err := json.Marshal(v)
+	assert.NoError(t, err)
+	var raw interface{}
+	err = json.Unmarshal(bytes, &raw)
+	assert.NoError(t, err)
+
+	actual, err := DeserializePropertyValue(raw, config.NopDecrypter, config.NopEncrypter)
+	assert.NoError(t, err)
+	assert.True(t, actual.IsBytes())
+	assert.Equal(t, b.BytesValue(), actual.BytesValue())
+}
+
+func TestSerializationGroupSerialization(t *testing.T) {
+	res := resource.NewState("Test", "urn:pulumi:test::test::Test::resource-x", true, false, "id",
+		resource.PropertyMap{}, resource.PropertyMap{}, "", false, false, nil, nil, "", nil, false, nil, nil, nil, "")
+	res.SerializationGroup = "dns"
+
+	dep, err := SerializeResource(res, config.NopEncrypter, false /* showSecrets */)
+	assert.NoError(t, err)
+	assert.Equal(t, "dns", dep.SerializationGroup)
+
+	back, err := DeserializeResource(dep, config.NopDecrypter, config.NopEncrypter)
+	assert.NoError(t, err)
+	assert.Equal(t, "dns", back.SerializationGroup)
+}
+
+func TestRemoteAssetHeadersSerialization(t *testing.T) {
+	crypter := config.NewSymmetricCrypterFromPassphrase("password", []byte("salt"))
+	asset := &resource.Asset{
+		Sig:     resource.AssetSig,
+		Hash:    "d9bd1b3bb6a4e0c1e3c5a0bd0c6b8e4b5dd06d6a1c0e0e5c7c0b4dd5b2c3e9a1",
+		URI:     "https://example.com/asset.txt",
+		Headers: map[string]string{"Authorization": "Bearer hunter2"},
+	}
+	v, err := SerializePropertyValue(resource.NewAssetProperty(asset), crypter, false)
+	assert.NoError(t, err)
+
+	// The headers are encrypted rather than written to the checkpoint in plaintext.
+	bytes, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(bytes), "hunter2")
+	assert.Contains(t, string(bytes), resource.SecretSig)
+
+	var raw interface{}
+	err = json.Unmarshal(bytes, &raw)
+	assert.NoError(t, err)
+
+	actual, err := DeserializePropertyValue(raw, crypter, crypter)
+	assert.NoError(t, err)
+	assert.True(t, actual.IsAsset())
+	assert.Equal(t, asset.Headers, actual.AssetValue().Headers)
+	assert.Equal(t, asset.URI, actual.AssetValue().URI)
+
+	// Checkpoints written before headers were encrypted are still read.
+	plain := asset.Serialize()
+	actual, err = DeserializePropertyValue(plain, crypter, crypter)
+	assert.NoError(t, err)
+	assert.Equal(t, asset.Headers, actual.AssetValue().Headers)
+}
+
 func TestCustomSerialization(t *testing.T) {
 	textAsset, err := resource.NewTextAsset("alpha beta gamma")
 	assert.NoError(t, err)
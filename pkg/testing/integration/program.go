@@ -516,7 +516,7 @@ func GetLogs(
 	cfg := map[config.Key]string{
 		config.MustMakeKey(provider, "region"): region,
 	}
-	ops := tree.OperationsProvider(cfg)
+	ops := tree.OperationsProvider(cfg, nil)
 
 	// Validate logs from example
 	logs, err := ops.GetLogs(query)
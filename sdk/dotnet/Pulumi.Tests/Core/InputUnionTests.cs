@@ -0,0 +1,32 @@
+﻿// Copyright 2016-2020, Pulumi Corporation
+
+using System.Threading.Tasks;
+using Xunit;
+
+namespace Pulumi.Tests.Core
+{
+    public class InputUnionTests : PulumiTest
+    {
+        [Fact]
+        public Task ConvertsFromEachMember()
+            => RunInPreview(async () =>
+            {
+                InputUnion<string, int> fromValue = "a";
+                InputUnion<string, int> fromOutput = Output.Create(1);
+                Input<string> input = "b";
+                InputUnion<string, int> fromInput = input;
+
+                var value = await fromValue.ToOutput().DataTask.ConfigureAwait(false);
+                Assert.True(value.Value.IsT0);
+                Assert.Equal("a", value.Value.AsT0);
+
+                var output = await fromOutput.ToOutput().DataTask.ConfigureAwait(false);
+                Assert.True(output.Value.IsT1);
+                Assert.Equal(1, output.Value.AsT1);
+
+                var converted = await fromInput.ToOutput().DataTask.ConfigureAwait(false);
+                Assert.True(converted.Value.IsT0);
+                Assert.Equal("b", converted.Value.AsT0);
+            });
+    }
+}
@@ -0,0 +1,59 @@
+﻿// Copyright 2016-2020, Pulumi Corporation
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.IO;
+using System.Linq;
+using System.Text.Json;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+using Xunit;
+
+namespace Pulumi.Tests.Core
+{
+    /// <summary>
+    /// Runs the cases shared by all of the SDKs that describe how an apply whose callback returns
+    /// an output is flattened. Resources are not compared, as the cases' resources cannot be
+    /// created outside of a deployment.
+    /// </summary>
+    public class OutputConformanceTests : PulumiTest
+    {
+        private static readonly string CasesPath = Path.Combine(AppContext.BaseDirectory, "conformance", "apply.json");
+
+        private static IEnumerable<JsonElement> LoadCases()
+        {
+            using var document = JsonDocument.Parse(File.ReadAllText(CasesPath));
+            return document.RootElement.GetProperty("cases").EnumerateArray().Select(c => c.Clone()).ToList();
+        }
+
+        public static IEnumerable<object[]> CaseNames()
+            => LoadCases().Select(c => new object[] { c.GetProperty("name").GetString() });
+
+        private static Output<string> CreateOutput(JsonElement spec)
+            => new Output<string>(Task.FromResult(OutputData.Create(
+                ImmutableHashSet<Resource>.Empty,
+                spec.GetProperty("value").GetString(),
+                spec.GetProperty("known").GetBoolean(),
+                spec.GetProperty("secret").GetBoolean())));
+
+        [Theory]
+        [MemberData(nameof(CaseNames))]
+        public Task ApplyFlattensInnerOutput(string name)
+            => RunInPreview(async () =>
+            {
+                var c = LoadCases().Single(x => x.GetProperty("name").GetString() == name);
+                var inner = CreateOutput(c.GetProperty("inner"));
+                var result = CreateOutput(c.GetProperty("outer")).Apply(_ => inner);
+
+                var expected = c.GetProperty("result");
+                var data = await result.DataTask.ConfigureAwait(false);
+                Assert.Equal(expected.GetProperty("known").GetBoolean(), data.IsKnown);
+                Assert.Equal(expected.GetProperty("secret").GetBoolean(), data.IsSecret);
+                if (data.IsKnown)
+                {
+                    Assert.Equal(expected.GetProperty("value").GetString(), data.Value);
+                }
+            });
+    }
+}
@@ -1,5 +1,6 @@
 ﻿// Copyright 2016-2019, Pulumi Corporation
 
+using System.Collections.Generic;
 using System.Collections.Immutable;
 using System.Linq;
 using System.Threading.Tasks;
@@ -293,6 +294,18 @@ namespace Pulumi.Tests.Core
                     var data = await o.DataTask.ConfigureAwait(false);
                     Assert.Equal(new[] { 1, 2 }, data.Value);
                 });
+
+            [Fact]
+            public Task JsonSerializeIsUnknownIfNestedOutputIsUnknown()
+                => RunInPreview(async () =>
+                {
+                    var o = Output.JsonSerialize(Output.Create(new Dictionary<string, object?>
+                    {
+                        ["bucket"] = CreateOutput("foo", isKnown: false),
+                    }));
+                    var data = await o.DataTask.ConfigureAwait(false);
+                    Assert.False(data.IsKnown);
+                });
         }
 
         public class NormalTests
@@ -528,6 +541,32 @@ namespace Pulumi.Tests.Core
                     Assert.True(data.IsSecret);
                     Assert.Equal("inner", data.Value);
                 });
+
+            [Fact]
+            public Task JsonSerializeResolvesNestedOutputs()
+                => RunInNormal(async () =>
+                {
+                    var o = Output.JsonSerialize(Output.Create(new Dictionary<string, object?>
+                    {
+                        ["bucket"] = CreateOutput("foo", isKnown: true, isSecret: true),
+                        ["paths"] = new object[] { "a", CreateOutput("b", isKnown: true) },
+                    }));
+                    var data = await o.DataTask.ConfigureAwait(false);
+                    Assert.True(data.IsKnown);
+                    Assert.True(data.IsSecret);
+                    Assert.Equal("{\"bucket\":\"foo\",\"paths\":[\"a\",\"b\"]}", data.Value);
+                });
+
+            [Fact]
+            public Task JsonDeserializePropagatesSecret()
+                => RunInNormal(async () =>
+                {
+                    var json = CreateOutput("[\"a\",\"b\"]", isKnown: true, isSecret: true);
+                    var o = Output.JsonDeserialize<string[]>(json);
+                    var data = await o.DataTask.ConfigureAwait(false);
+                    Assert.True(data.IsSecret);
+                    Assert.Equal(new[] { "a", "b" }, data.Value);
+                });
         }
     }
 }
@@ -34,6 +34,12 @@ namespace Pulumi
         public static implicit operator InputUnion<T0, T1>(Output<T1> value)
             => new InputUnion<T0, T1>(value.Apply(v => Union<T0, T1>.FromT1(v)));
 
+        public static implicit operator InputUnion<T0, T1>(Input<T0> value)
+            => new InputUnion<T0, T1>(value.Apply(v => Union<T0, T1>.FromT0(v)));
+
+        public static implicit operator InputUnion<T0, T1>(Input<T1> value)
+            => new InputUnion<T0, T1>(value.Apply(v => Union<T0, T1>.FromT1(v)));
+
         #endregion
     }
 }
@@ -0,0 +1,81 @@
+// Copyright 2016-2020, Pulumi Corporation
+
+using System.Collections;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Text.Json;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi
+{
+    public static partial class Output
+    {
+        /// <summary>
+        /// Serializes the value of an <see cref="Output{T}"/> to JSON. Any <see cref="Input{T}"/>s
+        /// or <see cref="Output{T}"/>s nested within dictionaries or lists in the value are
+        /// resolved before it is serialized.
+        /// <para/>
+        /// If any of the nested <see cref="Output{T}"/>s are not known, the final result will be
+        /// not known.  Similarly, if any of them are secrets, then the final result will be a
+        /// secret.
+        /// </summary>
+        public static Output<string> JsonSerialize<T>(Output<T> value, JsonSerializerOptions? options = null)
+            => new Output<string>(JsonSerializeHelperAsync(value, options));
+
+        /// <summary>
+        /// Deserializes a JSON string. The result is not known or secret if the string is.
+        /// </summary>
+        public static Output<T> JsonDeserialize<T>(Output<string> json, JsonSerializerOptions? options = null)
+            => json.Apply(s => JsonSerializer.Deserialize<T>(s, options)!);
+
+        private static async Task<OutputData<string>> JsonSerializeHelperAsync<T>(
+            Output<T> output, JsonSerializerOptions? options)
+        {
+            var resources = ImmutableHashSet.CreateBuilder<Resource>();
+            var isKnown = true;
+            var isSecret = false;
+
+            var value = await ResolveAsync(output).ConfigureAwait(false);
+
+            // During previews only serialize the value if all of its outputs are known.
+            if (!isKnown && Deployment.Instance.IsDryRun)
+            {
+                return new OutputData<string>(resources.ToImmutable(), default!, isKnown, isSecret);
+            }
+            return OutputData.Create(resources.ToImmutable(), JsonSerializer.Serialize(value, options), isKnown, isSecret);
+
+            async Task<object?> ResolveAsync(object? v)
+            {
+                switch (v)
+                {
+                    case IInput i:
+                        return await ResolveAsync(i.ToOutput()).ConfigureAwait(false);
+                    case IOutput o:
+                        var data = await o.GetDataAsync().ConfigureAwait(false);
+                        resources.UnionWith(data.Resources);
+                        (isKnown, isSecret) = OutputData.Combine(data, isKnown, isSecret);
+                        return await ResolveAsync(data.Value).ConfigureAwait(false);
+                    case string _:
+                        return v;
+                    case IDictionary dictionary:
+                        var map = new Dictionary<string, object?>();
+                        foreach (DictionaryEntry entry in dictionary)
+                        {
+                            map[entry.Key.ToString()!] = await ResolveAsync(entry.Value).ConfigureAwait(false);
+                        }
+                        return map;
+                    case IEnumerable enumerable:
+                        var list = new List<object?>();
+                        foreach (var element in enumerable)
+                        {
+                            list.Add(await ResolveAsync(element).ConfigureAwait(false));
+                        }
+                        return list;
+                    default:
+                        return v;
+                }
+            }
+        }
+    }
+}
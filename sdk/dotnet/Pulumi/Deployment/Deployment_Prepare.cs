@@ -47,6 +47,12 @@ namespace Pulumi
             {
                 var customOpts = options as CustomResourceOptions;
                 providerRef = await ProviderResource.RegisterAsync(customOpts?.Provider).ConfigureAwait(false);
+
+                // Custom await options are sent to the provider alongside the resource's inputs.
+                if (customOpts?.CustomAwait != null)
+                {
+                    serializedProps.Fields[Constants.CustomAwaitPropertyName] = customOpts.CustomAwait.ToValue();
+                }
             }
 
             // Collect the URNs for explicit/implicit dependencies for the engine so that it can understand
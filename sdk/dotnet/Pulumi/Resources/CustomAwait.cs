@@ -0,0 +1,53 @@
+﻿// Copyright 2016-2020, Pulumi Corporation
+
+using System.Collections.Generic;
+using System.Linq;
+using Google.Protobuf.WellKnownTypes;
+
+namespace Pulumi
+{
+    /// <summary>
+    /// Optional await settings to supply in <see cref="CustomResourceOptions.CustomAwait"/>.
+    /// Providers that do not support custom await settings ignore them.
+    /// </summary>
+    public sealed class CustomAwait
+    {
+        /// <summary>
+        /// When set to <c>true</c>, the provider does not wait for the resource to become ready.
+        /// </summary>
+        public bool Skip { get; set; }
+
+        private List<string>? _conditions;
+
+        /// <summary>
+        /// An optional list of provider-specific conditions that must hold before the resource is
+        /// considered ready.
+        /// </summary>
+        public List<string> Conditions
+        {
+            get => _conditions ?? (_conditions = new List<string>());
+            set => _conditions = value;
+        }
+
+        internal static CustomAwait? Clone(CustomAwait? customAwait)
+            => customAwait == null ? null : new CustomAwait
+            {
+                Skip = customAwait.Skip,
+                Conditions = customAwait.Conditions.ToList(),
+            };
+
+        internal Value ToValue()
+        {
+            var result = new Struct();
+            if (Skip)
+            {
+                result.Fields["skip"] = Value.ForBool(true);
+            }
+            if (Conditions.Count > 0)
+            {
+                result.Fields["conditions"] = Value.ForList(Conditions.Select(Value.ForString).ToArray());
+            }
+            return Value.ForStruct(result);
+        }
+    }
+}
@@ -38,6 +38,12 @@ namespace Pulumi
         /// </summary>
         public string? ImportId { get; set; }
 
+        /// <summary>
+        /// An optional configuration block that controls how the resource's provider waits for the
+        /// resource to become ready after it is created or updated.
+        /// </summary>
+        public CustomAwait? CustomAwait { get; set; }
+
         internal override ResourceOptions Clone()
             => CreateCustomResourceOptionsCopy(this);
 
@@ -75,6 +81,7 @@ namespace Pulumi
 
             options1.DeleteBeforeReplace = options2.DeleteBeforeReplace ?? options1.DeleteBeforeReplace;
             options1.ImportId = options2.ImportId ?? options1.ImportId;
+            options1.CustomAwait = options2.CustomAwait ?? options1.CustomAwait;
 
             options1.AdditionalSecretOutputs.AddRange(options2.AdditionalSecretOutputs);
             return options1;
@@ -30,6 +30,7 @@ namespace Pulumi
             copy.AdditionalSecretOutputs = customOptions?.AdditionalSecretOutputs.ToList() ?? new List<string>();
             copy.DeleteBeforeReplace = customOptions?.DeleteBeforeReplace;
             copy.ImportId = customOptions?.ImportId;
+            copy.CustomAwait = CustomAwait.Clone(customOptions?.CustomAwait);
 
             return copy;
         }
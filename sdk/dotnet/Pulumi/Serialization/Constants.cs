@@ -39,5 +39,10 @@ namespace Pulumi.Serialization
 
         public const string IdPropertyName = "id";
         public const string UrnPropertyName = "urn";
+
+        /// <summary>
+        /// CustomAwaitPropertyName is the input property used to send custom await options to providers.
+        /// </summary>
+        public const string CustomAwaitPropertyName = "__customAwait";
     }
 }
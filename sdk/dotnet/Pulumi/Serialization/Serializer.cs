@@ -34,6 +34,7 @@ namespace Pulumi.Serialization
         /// <item><see cref="int"/>s</item>
         /// <item><see cref="double"/>s</item>
         /// <item><see cref="string"/>s</item>
+        /// <item><see cref="byte"/> arrays. These are serialized as base64 strings.</item>
         /// <item><see cref="Asset"/>s</item>
         /// <item><see cref="Archive"/>s</item>
         /// <item><see cref="Resource"/>s</item>
@@ -84,6 +85,16 @@ namespace Pulumi.Serialization
                 return prop;
             }
 
+            if (prop is byte[] bytes)
+            {
+                if (_excessiveDebugOutput)
+                {
+                    Log.Debug($"Serialize property[{ctx}]: bytes");
+                }
+
+                return Convert.ToBase64String(bytes);
+            }
+
             if (prop is InputArgs args)
                 return await SerializeInputArgsAsync(ctx, args).ConfigureAwait(false);
 
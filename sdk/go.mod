@@ -8,6 +8,7 @@ require (
 	github.com/cheggaaa/pb v1.0.18
 	github.com/codahale/hdrhistogram v0.0.0-20161010025455-3a0bb77429bd // indirect
 	github.com/djherbis/times v1.2.0
+	github.com/dustin/go-humanize v1.0.0
 	github.com/fatih/color v1.9.0 // indirect
 	github.com/gofrs/flock v0.7.1
 	github.com/gogo/protobuf v1.3.1 // indirect
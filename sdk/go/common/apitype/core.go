@@ -289,6 +289,9 @@ type ResourceV3 struct {
 	CustomTimeouts *resource.CustomTimeouts `json:"customTimeouts,omitempty" yaml:"customTimeouts,omitempty"`
 	// ImportID is the import input used for imported resources.
 	ImportID resource.ID `json:"importID,omitempty" yaml:"importID,omitempty"`
+	// SerializationGroup is the name of the group of resources that the engine never operates on concurrently, if
+	// any. It is recorded so that the resource's deletion is serialized with the rest of its group.
+	SerializationGroup string `json:"serializationGroup,omitempty" yaml:"serializationGroup,omitempty"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
@@ -360,6 +363,8 @@ const (
 	// VCSRepositoryKindTag is a tag that represents the kind of the cloud VCS that this stack
 	// may be associated with (inferred by the CLI based on the git remote info).
 	VCSRepositoryKindTag StackTagName = "vcs:kind"
+	// StackFrozenTag is a tag that marks a stack as frozen. Its value is the reason that the stack was frozen.
+	StackFrozenTag StackTagName = "pulumi:frozen"
 )
 
 // Stack describes a Stack running on a Pulumi Cloud.
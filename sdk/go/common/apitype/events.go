@@ -15,11 +15,22 @@
 package apitype
 
 // The "engine events" defined here are a fork of the types and enums defined in the engine
-// package. The duplication is intentional to insulate the Pulumi service from various kinds of
-// breaking changes.
+// package. The duplication is intentional to insulate the Pulumi service, and any other consumer
+// of engine events such as CI integrations or alternative displays, from changes to the engine.
 //
-// The types aren't versioned in the same manner as Resource, Deployment, and Checkpoint (see
-// apitype/migrate). So care must be taken if these are ever returned from the service to the CLI.
+// Unlike Resource, Deployment, and Checkpoint (see apitype/migrate), engine events are never
+// migrated. Instead, their JSON encoding is versioned by EngineEventSchemaVersion and described by
+// events.schema.json, and every change within a version must be backwards compatible:
+//
+// - new event kinds and new fields may be added, and consumers must ignore any they do not know;
+// - existing fields may not be removed, renamed, or change type, and existing string enums may
+//   not lose values.
+//
+// Any other change requires a new schema version. The tests in this package enforce these rules
+// against a recording of every event kind at the current version.
+
+// EngineEventSchemaVersion is the current version of the engine event schema.
+const EngineEventSchemaVersion = 1
 
 // CancelEvent is emitted when the user initiates a cancellation of the update in progress, or
 // the update successfully completes.
@@ -182,6 +193,16 @@ type ResOpFailedEvent struct {
 	Steps    int               `json:"steps"`
 }
 
+// HoldEvent is emitted when an update reaches a hold point, and again when it is released.
+type HoldEvent struct {
+	URN     string `json:"urn"`
+	Changes int    `json:"changes"`
+	// Status is one of "waiting", "approved", "rejected", or "timed-out".
+	Status         string `json:"status"`
+	TimeoutSeconds int    `json:"timeoutSeconds,omitempty"`
+	Approvable     bool   `json:"approvable,omitempty"`
+}
+
 // EngineEvent describes a Pulumi engine event, such as a change to a resource or diagnostic
 // message. EngineEvent is a discriminated union of all possible event types, and exactly one
 // field will be non-nil.
@@ -207,6 +228,7 @@ type EngineEvent struct {
 	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
 	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
 	PolicyEvent      *PolicyEvent       `json:"policyEvent,omitempty"`
+	HoldEvent        *HoldEvent         `json:"holdEvent,omitempty"`
 }
 
 // EngineEventBatch is a group of engine events.
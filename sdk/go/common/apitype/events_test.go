@@ -0,0 +1,132 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEngineEventsV1Compatibility checks that a recording of every kind of engine event at schema version 1 still
+// decodes, and that it encodes back to exactly the same JSON. A failure here means that a change to the event types is
+// not backwards compatible and requires a new schema version.
+func TestEngineEventsV1Compatibility(t *testing.T) {
+	assert.Equal(t, 1, EngineEventSchemaVersion)
+
+	data, err := ioutil.ReadFile("testdata/events-v1.json")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var recorded []json.RawMessage
+	if !assert.NoError(t, json.Unmarshal(data, &recorded)) {
+		t.FailNow()
+	}
+
+	kinds := make(map[string]bool)
+	for _, raw := range recorded {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+
+		var event EngineEvent
+		if !assert.NoError(t, decoder.Decode(&event), "decoding %s", raw) {
+			continue
+		}
+
+		encoded, err := json.Marshal(event)
+		if assert.NoError(t, err) {
+			assert.JSONEq(t, string(raw), string(encoded))
+		}
+
+		v := reflect.ValueOf(event)
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.Kind() == reflect.Ptr && !f.IsNil() {
+				kinds[v.Type().Field(i).Name] = true
+			}
+		}
+	}
+
+	// Every kind of event must be part of the recording.
+	typ := reflect.TypeOf(EngineEvent{})
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i); f.Type.Kind() == reflect.Ptr {
+			assert.True(t, kinds[f.Name], "%s is missing from testdata/events-v1.json", f.Name)
+		}
+	}
+}
+
+// TestEngineEventsSchema checks that the JSON schema describes every property of every engine event.
+func TestEngineEventsSchema(t *testing.T) {
+	data, err := ioutil.ReadFile("events.schema.json")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var schema map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal(data, &schema)) {
+		t.FailNow()
+	}
+	definitions, _ := schema["definitions"].(map[string]interface{})
+
+	// resolve follows a schema's reference, if it has one.
+	resolve := func(node map[string]interface{}) map[string]interface{} {
+		ref, ok := node["$ref"].(string)
+		if !ok {
+			return node
+		}
+		def, _ := definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{})
+		assert.NotNil(t, def, "unresolved reference %s", ref)
+		return def
+	}
+
+	var check func(path string, typ reflect.Type, node map[string]interface{})
+	check = func(path string, typ reflect.Type, node map[string]interface{}) {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if node == nil {
+			return
+		}
+
+		switch typ.Kind() {
+		case reflect.Struct:
+			properties, _ := node["properties"].(map[string]interface{})
+			for i := 0; i < typ.NumField(); i++ {
+				field := typ.Field(i)
+				name := strings.Split(field.Tag.Get("json"), ",")[0]
+				if name == "" || name == "-" {
+					continue
+				}
+				property, ok := properties[name].(map[string]interface{})
+				if assert.True(t, ok, "%s.%s is missing from the schema", path, name) {
+					check(path+"."+name, field.Type, resolve(property))
+				}
+			}
+		case reflect.Map:
+			if elem, ok := node["additionalProperties"].(map[string]interface{}); ok {
+				check(path+"[]", typ.Elem(), resolve(elem))
+			}
+		case reflect.Slice:
+			if elem, ok := node["items"].(map[string]interface{}); ok {
+				check(path+"[]", typ.Elem(), resolve(elem))
+			}
+		}
+	}
+	check("EngineEvent", reflect.TypeOf(EngineEvent{}), schema)
+}
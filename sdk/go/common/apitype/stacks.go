@@ -30,6 +30,14 @@ type StackSummary struct {
 	ResourceCount *int `json:"resourceCount,omitempty"`
 }
 
+// StackFreeze describes a freeze that prevents changes to a stack's state, e.g. during incident response or a change
+// freeze. The CLI refuses to run operations that would change a frozen stack's state unless the freeze is explicitly
+// overridden.
+type StackFreeze struct {
+	// Reason explains why the stack is frozen.
+	Reason string `json:"reason"`
+}
+
 // ListStacksResponse returns a set of stack summaries. This call is designed to be inexpensive.
 type ListStacksResponse struct {
 	Stacks []StackSummary `json:"stacks"`
@@ -22,6 +22,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -44,6 +45,9 @@ const (
 	// BookkeepingDir is the name of our bookkeeping folder, we store state here (like .git for git).
 	// Copied from workspace.BookkeepingDir to break import cycle.
 	BookkeepingDir = ".pulumi"
+	// PulumiIgnoreFile is the name of the file that lists the paths to leave out of a directory archive.
+	// Copied from workspace.IgnoreFile to break import cycle.
+	PulumiIgnoreFile = ".pulumiignore"
 )
 
 // Asset is a serialized asset reference.  It is a union: thus, only one of its fields will be non-nil.  Several helper
@@ -59,14 +63,22 @@ type Asset struct {
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
 	// URI will contain a non-empty URI (file://, http://, https://, or custom) for URI-backed assets.
 	URI string `json:"uri,omitempty" yaml:"uri,omitempty"`
+	// Headers contains additional HTTP headers, such as authorization tokens, to send when fetching a URI-backed
+	// asset. The engine encrypts the headers when it writes the asset to a checkpoint.
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// PinnedHash, if non-empty, is the SHA256 hash that the asset's contents are required to have. Reading an asset
+	// whose contents do not match its pinned hash fails.
+	PinnedHash string `json:"pinnedHash,omitempty" yaml:"pinnedHash,omitempty"`
 }
 
 const (
-	AssetSig          = "c44067f5952c0a294b673a41bacd8c17" // a randomly assigned type hash for assets.
-	AssetHashProperty = "hash"                             // the dynamic property for an asset's hash.
-	AssetTextProperty = "text"                             // the dynamic property for an asset's text.
-	AssetPathProperty = "path"                             // the dynamic property for an asset's path.
-	AssetURIProperty  = "uri"                              // the dynamic property for an asset's URI.
+	AssetSig                = "c44067f5952c0a294b673a41bacd8c17" // a randomly assigned type hash for assets.
+	AssetHashProperty       = "hash"                             // the dynamic property for an asset's hash.
+	AssetTextProperty       = "text"                             // the dynamic property for an asset's text.
+	AssetPathProperty       = "path"                             // the dynamic property for an asset's path.
+	AssetURIProperty        = "uri"                              // the dynamic property for an asset's URI.
+	AssetHeadersProperty    = "headers"                          // the dynamic property for an asset's URI headers.
+	AssetPinnedHashProperty = "pinnedHash"                       // the dynamic property for an asset's pinned hash.
 )
 
 // NewTextAsset produces a new asset and its corresponding SHA256 hash from the given text.
@@ -90,6 +102,14 @@ func NewURIAsset(uri string) (*Asset, error) {
 	return a, err
 }
 
+// NewAuthenticatedURIAsset produces a new asset from the given network URI that is fetched using the given HTTP
+// headers. If pinnedHash is non-empty, the asset's contents must have the given SHA256 hash.
+func NewAuthenticatedURIAsset(uri string, headers map[string]string, pinnedHash string) (*Asset, error) {
+	a := &Asset{Sig: AssetSig, URI: uri, Headers: headers, PinnedHash: pinnedHash}
+	err := a.EnsureHash()
+	return a, err
+}
+
 func (a *Asset) IsText() bool { return !a.IsPath() && !a.IsURI() }
 func (a *Asset) IsPath() bool { return a.Path != "" }
 func (a *Asset) IsURI() bool  { return a.URI != "" }
@@ -240,6 +260,16 @@ func (a *Asset) Serialize() map[string]interface{} {
 	if a.URI != "" {
 		result[AssetURIProperty] = a.URI
 	}
+	if len(a.Headers) != 0 {
+		headers := make(map[string]interface{}, len(a.Headers))
+		for k, v := range a.Headers {
+			headers[k] = v
+		}
+		result[AssetHeadersProperty] = headers
+	}
+	if a.PinnedHash != "" {
+		result[AssetPinnedHashProperty] = a.PinnedHash
+	}
 	return result
 }
 
@@ -283,8 +313,31 @@ func DeserializeAsset(obj map[string]interface{}) (*Asset, bool, error) {
 		}
 		uri = u
 	}
+	var headers map[string]string
+	if v, has := obj[AssetHeadersProperty]; has {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return &Asset{}, false, errors.Errorf("unexpected asset headers of type %T", v)
+		}
+		headers = make(map[string]string, len(m))
+		for k, hv := range m {
+			h, ok := hv.(string)
+			if !ok {
+				return &Asset{}, false, errors.Errorf("unexpected asset header %q of type %T", k, hv)
+			}
+			headers[k] = h
+		}
+	}
+	var pinnedHash string
+	if v, has := obj[AssetPinnedHashProperty]; has {
+		h, ok := v.(string)
+		if !ok {
+			return &Asset{}, false, errors.Errorf("unexpected asset pinned hash of type %T", v)
+		}
+		pinnedHash = h
+	}
 
-	return &Asset{Hash: hash, Text: text, Path: path, URI: uri}, true, nil
+	return &Asset{Hash: hash, Text: text, Path: path, URI: uri, Headers: headers, PinnedHash: pinnedHash}, true, nil
 }
 
 // HasContents indicates whether or not an asset's contents can be read.
@@ -306,16 +359,24 @@ func (a *Asset) Bytes() ([]byte, error) {
 	return ioutil.ReadAll(blob)
 }
 
-// Read begins reading an asset.
+// Read begins reading an asset. If the asset has a pinned hash, the returned blob fails with an error at the end of
+// its contents if the contents do not match the pinned hash.
 func (a *Asset) Read() (*Blob, error) {
+	var blob *Blob
+	var err error
 	if a.IsText() {
-		return a.readText()
+		blob, err = a.readText()
 	} else if a.IsPath() {
-		return a.readPath()
+		blob, err = a.readPath()
 	} else if a.IsURI() {
-		return a.readURI()
+		blob, err = a.readURI()
+	} else {
+		return nil, errors.New("unrecognized asset type")
+	}
+	if err != nil || a.PinnedHash == "" {
+		return blob, err
 	}
-	return nil, errors.New("unrecognized asset type")
+	return &Blob{rd: newVerifyingReader(blob.rd, a.PinnedHash), sz: blob.sz}, nil
 }
 
 func (a *Asset) readText() (*Blob, error) {
@@ -359,10 +420,25 @@ func (a *Asset) readURI() (*Blob, error) {
 	contract.Assertf(isurl, "Expected a URI-based asset")
 	switch s := url.Scheme; s {
 	case "http", "https":
-		resp, err := httputil.GetWithRetry(url.String(), http.DefaultClient)
+		req, err := http.NewRequest("GET", url.String(), nil)
 		if err != nil {
 			return nil, err
 		}
+		for k, v := range a.Headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := httputil.DoWithRetry(req, http.DefaultClient)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			contract.IgnoreClose(resp.Body)
+			return nil, errors.Errorf("failed to fetch asset '%v': %v", a.URI, resp.Status)
+		}
+		// If the server told us how large the asset is, stream it rather than buffering it in memory.
+		if resp.ContentLength >= 0 {
+			return &Blob{rd: resp.Body, sz: resp.ContentLength}, nil
+		}
 		return NewReadCloserBlob(resp.Body)
 	case "file":
 		contract.Assert(url.User == nil)
@@ -381,25 +457,66 @@ func (a *Asset) readURI() (*Blob, error) {
 	}
 }
 
-// EnsureHash computes the SHA256 hash of the asset's contents and stores it on the object.
+// EnsureHash computes the SHA256 hash of the asset's contents and stores it on the object. The contents are streamed
+// through the hash rather than read into memory. The hashes of path-based assets are memoized until the file changes.
 func (a *Asset) EnsureHash() error {
 	if a.Hash == "" {
-		blob, err := a.Read()
-		if err != nil {
-			return err
+		var hash string
+		var err error
+		if path, ispath := a.GetPath(); ispath {
+			hash, err = cachedPathHash("asset", path, a.computeHash)
+		} else {
+			hash, err = a.computeHash()
 		}
-		defer contract.IgnoreClose(blob)
-
-		hash := sha256.New()
-		_, err = io.Copy(hash, blob)
 		if err != nil {
 			return err
 		}
-		a.Hash = hex.EncodeToString(hash.Sum(nil))
+		a.Hash = hash
+	}
+	if a.PinnedHash != "" && a.Hash != a.PinnedHash {
+		return errors.Errorf("asset hash %v does not match its pinned hash %v", a.Hash, a.PinnedHash)
 	}
 	return nil
 }
 
+func (a *Asset) computeHash() (string, error) {
+	blob, err := a.Read()
+	if err != nil {
+		return "", err
+	}
+	defer contract.IgnoreClose(blob)
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, blob); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// verifyingReader is a reader that checks the SHA256 hash of the data it reads once the data is exhausted.
+type verifyingReader struct {
+	rd       io.ReadCloser
+	hash     hash.Hash
+	expected string
+}
+
+func newVerifyingReader(rd io.ReadCloser, expected string) io.ReadCloser {
+	return &verifyingReader{rd: rd, hash: sha256.New(), expected: expected}
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	_, _ = r.hash.Write(p[:n]) // hash.Hash.Write never returns an error.
+	if err == io.EOF {
+		if actual := hex.EncodeToString(r.hash.Sum(nil)); actual != r.expected {
+			return n, errors.Errorf("asset hash %v does not match its pinned hash %v", actual, r.expected)
+		}
+	}
+	return n, err
+}
+
+func (r *verifyingReader) Close() error { return r.rd.Close() }
+
 // Blob is a blob that implements ReadCloser and offers Len functionality.
 type Blob struct {
 	rd io.ReadCloser // an underlying reader.
@@ -825,15 +942,19 @@ func (a *Archive) readPath() (ArchiveReader, error) {
 
 		// Accumulate the list of asset paths. This list is ordered deterministically by filepath.Walk.
 		assetPaths := []string{}
+		ignorer := newArchiveIgnorer(path)
 		if walkerr := filepath.Walk(path, func(filePath string, f os.FileInfo, fileerr error) error {
 			// If there was an error, exit.
 			if fileerr != nil {
 				return fileerr
 			}
 
-			// If this is a .pulumi directory, we will skip this by default.
-			// TODO[pulumi/pulumi#122]: when we support .pulumiignore, this will be customizable.
-			if f.Name() == BookkeepingDir {
+			// Skip the .pulumi directory and anything matched by a .pulumiignore file.
+			ignored, err := ignorer.ignored(filePath, f.IsDir())
+			if err != nil {
+				return errors.Wrapf(err, "reading %v", PulumiIgnoreFile)
+			}
+			if ignored {
 				if f.IsDir() {
 					return filepath.SkipDir
 				}
@@ -1090,38 +1211,53 @@ func (a *Archive) ReadSourceArchive() (ArchiveFormat, io.ReadCloser, error) {
 	return NotArchive, nil, nil
 }
 
-// EnsureHash computes the SHA256 hash of the archive's contents and stores it on the object.
+// EnsureHash computes the SHA256 hash of the archive's contents and stores it on the object. The hashes of path-based
+// archives are memoized until the file or directory changes.
 func (a *Archive) EnsureHash() error {
 	if a.Hash == "" {
-		hash := sha256.New()
-
-		// Attempt to compute the hash in the most efficient way.  First try to open the archive directly and copy it
-		// to the hash.  This avoids traversing any of the contents and just treats it as a byte stream.
-		f, r, err := a.ReadSourceArchive()
+		var hash string
+		var err error
+		if path, ispath := a.GetPath(); ispath {
+			hash, err = cachedPathHash("archive", path, a.computeHash)
+		} else {
+			hash, err = a.computeHash()
+		}
 		if err != nil {
 			return err
 		}
-		if f != NotArchive && r != nil {
-			defer contract.IgnoreClose(r)
-			_, err = io.Copy(hash, r)
-			if err != nil {
-				return err
-			}
-		} else {
-			// Otherwise, it's not an archive; we'll need to transform it into one.  Pick tar since it avoids
-			// any superfluous compression which doesn't actually help us in this situation.
-			err := a.Archive(TarArchive, hash)
-			if err != nil {
-				return err
-			}
-		}
-
-		// Finally, encode the resulting hash as a string and we're done.
-		a.Hash = hex.EncodeToString(hash.Sum(nil))
+		a.Hash = hash
 	}
 	return nil
 }
 
+func (a *Archive) computeHash() (string, error) {
+	hash := sha256.New()
+
+	// Attempt to compute the hash in the most efficient way.  First try to open the archive directly and copy it
+	// to the hash.  This avoids traversing any of the contents and just treats it as a byte stream.
+	f, r, err := a.ReadSourceArchive()
+	if err != nil {
+		return "", err
+	}
+	if f != NotArchive && r != nil {
+		defer contract.IgnoreClose(r)
+		_, err = io.Copy(hash, r)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		// Otherwise, it's not an archive; we'll need to transform it into one.  Pick tar since it avoids
+		// any superfluous compression which doesn't actually help us in this situation.
+		err := a.Archive(TarArchive, hash)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Finally, encode the resulting hash as a string and we're done.
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // ArchiveFormat indicates what archive and/or compression format an archive uses.
 type ArchiveFormat int
 
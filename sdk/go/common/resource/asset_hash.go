@@ -0,0 +1,130 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// pathHashes memoizes the content hashes of path-based assets and archives. Hashes are keyed by a fingerprint of the
+// path's metadata, so a file or directory whose contents change is hashed again, while one that is referenced by many
+// resources--e.g. the code archive shared by a set of functions--is only read once per process.
+var pathHashes = struct {
+	sync.Mutex
+	m map[string]string
+}{m: make(map[string]string)}
+
+// racyInterval is the period after a change to a file during which a further change may not alter the file's
+// metadata: timestamps have a limited resolution, so a file that is written twice within one tick keeps the same
+// times. The hashes of paths that have changed within this interval are not memoized.
+var racyInterval = 2 * time.Second
+
+// pathFingerprint returns a string that identifies the current state of the file or directory at the given path,
+// along with the time of the most recent change to any file involved. The fingerprint covers the name, size, mode,
+// modification time, and identity (see fileIdentity) of every file beneath the path, but not their contents;
+// computing it only requires stat-ing the files involved. Files that are left out of an archive are left out of its
+// fingerprint as well, so that changes to e.g. build outputs listed in .pulumiignore do not force the archive to be
+// hashed again.
+//
+// On Linux and macOS, a file's identity includes its inode number and change time, so rewriting a file always changes
+// the fingerprint. Elsewhere, a file that is rewritten with contents of the same size and whose modification time is
+// then restored keeps its fingerprint, and its memoized hash goes stale.
+func pathFingerprint(kind, path string) (string, time.Time, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var ignorer *archiveIgnorer
+	if kind == "archive" {
+		ignorer = newArchiveIgnorer(abs)
+	}
+
+	var latest time.Time
+	hash := sha256.New()
+	err = filepath.Walk(abs, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if ignorer != nil {
+			ignored, err := ignorer.ignored(p, info.IsDir())
+			if err != nil {
+				return err
+			}
+			if ignored {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		// Archives include the contents of symlinked files, so fingerprint the target rather than the link.
+		if info.Mode()&os.ModeSymlink != 0 {
+			if target, err := os.Stat(p); err == nil {
+				info = target
+			}
+		}
+		identity, changed := fileIdentity(info)
+		for _, t := range []time.Time{info.ModTime(), changed} {
+			if t.After(latest) {
+				latest = t
+			}
+		}
+		_, err = fmt.Fprintf(hash, "%s\x00%d\x00%v\x00%d\x00%s\n", p, info.Size(), info.Mode(), info.ModTime().UnixNano(),
+			identity)
+		return err
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return kind + ":" + hex.EncodeToString(hash.Sum(nil)), latest, nil
+}
+
+// cachedPathHash computes the content hash of the file or directory at the given path using the given function,
+// returning a memoized hash if the path has not changed since it was last hashed.
+func cachedPathHash(kind, path string, compute func() (string, error)) (string, error) {
+	fingerprint, latest, err := pathFingerprint(kind, path)
+	if err != nil {
+		// Let the hash function report the problem with the path.
+		return compute()
+	}
+	if time.Since(latest) < racyInterval {
+		// The path may change again without changing its fingerprint, so don't trust or record a memoized hash.
+		return compute()
+	}
+
+	pathHashes.Lock()
+	hash, ok := pathHashes.m[fingerprint]
+	pathHashes.Unlock()
+	if ok {
+		return hash, nil
+	}
+
+	hash, err = compute()
+	if err != nil {
+		return "", err
+	}
+
+	pathHashes.Lock()
+	pathHashes.m[fingerprint] = hash
+	pathHashes.Unlock()
+	return hash, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package resource
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileIdentitySupported is true if file identities include inode numbers and change times on this platform.
+const fileIdentitySupported = true
+
+// fileIdentity returns the device and inode numbers and the change time of the given file. Unlike its modification
+// time, a file's change time is updated by every write and cannot be set by the file's owner.
+func fileIdentity(info os.FileInfo) (string, time.Time) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", info.ModTime()
+	}
+	ctime := time.Unix(st.Ctim.Unix())
+	return fmt.Sprintf("%d:%d:%d", st.Dev, st.Ino, ctime.UnixNano()), ctime
+}
@@ -0,0 +1,31 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !linux,!darwin
+
+package resource
+
+import (
+	"os"
+	"time"
+)
+
+// fileIdentitySupported is true if file identities include inode numbers and change times on this platform.
+const fileIdentitySupported = false
+
+// fileIdentity returns an empty identity on this platform, so path fingerprints only cover file names, sizes, modes,
+// and modification times.
+func fileIdentity(info os.FileInfo) (string, time.Time) {
+	return "", info.ModTime()
+}
@@ -0,0 +1,83 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"os"
+	"path/filepath"
+
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// archiveIgnorer decides which files beneath a directory archive's root are left out of the archive. The bookkeeping
+// directory is always skipped; beyond that, any directory in the tree may contain a .pulumiignore file whose
+// gitignore-style patterns apply to the paths beneath it.
+type archiveIgnorer struct {
+	root     string
+	matchers map[string]*ignore.GitIgnore
+}
+
+func newArchiveIgnorer(root string) *archiveIgnorer {
+	return &archiveIgnorer{root: filepath.Clean(root), matchers: make(map[string]*ignore.GitIgnore)}
+}
+
+// matcher returns the compiled .pulumiignore file in the given directory, or nil if there is none.
+func (i *archiveIgnorer) matcher(dir string) (*ignore.GitIgnore, error) {
+	if m, ok := i.matchers[dir]; ok {
+		return m, nil
+	}
+
+	var m *ignore.GitIgnore
+	ignoreFile := filepath.Join(dir, PulumiIgnoreFile)
+	if info, err := os.Stat(ignoreFile); err == nil && !info.IsDir() {
+		if m, err = ignore.CompileIgnoreFile(ignoreFile); err != nil {
+			return nil, err
+		}
+	}
+	i.matchers[dir] = m
+	return m, nil
+}
+
+// ignored returns true if the file or directory at the given path should be left out of the archive.
+func (i *archiveIgnorer) ignored(path string, isDir bool) (bool, error) {
+	path = filepath.Clean(path)
+	if path == i.root {
+		return false, nil
+	}
+	if filepath.Base(path) == BookkeepingDir {
+		return true, nil
+	}
+
+	// Check the ignore files in each directory from the path's parent up to the root of the archive.
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		m, err := i.matcher(dir)
+		if err != nil {
+			return false, err
+		}
+		if m != nil {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return false, err
+			}
+			rel = filepath.ToSlash(rel)
+			if m.MatchesPath(rel) || isDir && m.MatchesPath(rel+"/") {
+				return true, nil
+			}
+		}
+		if dir == i.root || dir == filepath.Dir(dir) {
+			return false, nil
+		}
+	}
+}
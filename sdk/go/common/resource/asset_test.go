@@ -22,10 +22,14 @@ import (
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -302,6 +306,108 @@ asset jumps over the archive.
 `)
 }
 
+func TestAssetHashCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "asset-hash-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "file.txt")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("hello"), 0600))
+	mtime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, os.Chtimes(path, mtime, mtime))
+
+	computed := 0
+	compute := func() (string, error) {
+		computed++
+		return fmt.Sprintf("hash%d", computed), nil
+	}
+
+	// The file has just been written, so its hash is not memoized.
+	for i := 0; i < 2; i++ {
+		_, err = cachedPathHash("asset", path, compute)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, computed)
+
+	// Once the file has settled, its hash is computed once and then memoized.
+	racy := racyInterval
+	racyInterval = 0
+	defer func() { racyInterval = racy }()
+
+	computed = 0
+	for i := 0; i < 2; i++ {
+		_, err = cachedPathHash("asset", path, compute)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, computed)
+
+	first, err := NewPathAsset(path)
+	assert.NoError(t, err)
+
+	// Rewriting the file with contents of the same size and restoring its modification time still changes its
+	// identity where the platform exposes one.
+	assert.NoError(t, ioutil.WriteFile(path, []byte("world"), 0600))
+	assert.NoError(t, os.Chtimes(path, mtime, mtime))
+	second, err := NewPathAsset(path)
+	assert.NoError(t, err)
+	if fileIdentitySupported {
+		assert.NotEqual(t, first.Hash, second.Hash)
+	}
+
+	// Touching the file causes its contents to be hashed again.
+	mtime = mtime.Add(time.Second)
+	assert.NoError(t, os.Chtimes(path, mtime, mtime))
+	third, err := NewPathAsset(path)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Hash, third.Hash)
+
+	// Directory archives are invalidated by changes to any file beneath them.
+	archive, err := NewPathArchive(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other.txt"), []byte("!"), 0600))
+	changed, err := NewPathArchive(dir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, archive.Hash, changed.Hash)
+}
+
+func TestAuthenticatedURIAsset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := w.Write([]byte("private contents"))
+		contract.IgnoreError(err)
+	}))
+	defer server.Close()
+
+	wrongHash := strings.Repeat("0", 64)
+	expected, err := NewTextAsset("private contents")
+	assert.NoError(t, err)
+
+	_, err = NewURIAsset(server.URL)
+	assert.Error(t, err)
+
+	headers := map[string]string{"Authorization": "Bearer secret"}
+	asset, err := NewAuthenticatedURIAsset(server.URL, headers, expected.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Hash, asset.Hash)
+	assertAssetTextEquals(t, asset, "private contents")
+
+	// Headers and pinned hashes survive serialization.
+	actual, isasset, err := DeserializeAsset(asset.Serialize())
+	assert.NoError(t, err)
+	assert.True(t, isasset)
+	assert.Equal(t, headers, actual.Headers)
+	assert.Equal(t, expected.Hash, actual.PinnedHash)
+
+	// Contents that do not match the pinned hash are rejected.
+	_, err = NewAuthenticatedURIAsset(server.URL, headers, wrongHash)
+	assert.Error(t, err)
+	_, err = (&Asset{URI: server.URL, Headers: headers, PinnedHash: wrongHash}).Bytes()
+	assert.Error(t, err)
+}
+
 func TestArchiveDir(t *testing.T) {
 	arch, err := NewPathArchive("../../../../pkg/resource/testdata/test_dir")
 	assert.Nil(t, err)
@@ -315,6 +421,54 @@ func TestArchiveDir(t *testing.T) {
 	validateTestDirArchive(t, arch)
 }
 
+func TestArchiveDirPulumiIgnore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive-ignore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		".pulumiignore":           "*.log\nbuild/\n",
+		"index.js":                "index",
+		"debug.log":               "log",
+		"build/out.js":            "out",
+		"lib/.pulumiignore":       "secret.txt\n",
+		"lib/util.js":             "util",
+		"lib/secret.txt":          "secret",
+		"lib/nested/trace.log":    "log",
+		".pulumi/stacks/dev.json": "{}",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	}
+
+	arch, err := NewPathArchive(dir)
+	assert.NoError(t, err)
+
+	r, err := arch.Open()
+	assert.NoError(t, err)
+	defer contract.IgnoreClose(r)
+	var names []string
+	for {
+		name, blob, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		_, err = ioutil.ReadAll(blob)
+		assert.NoError(t, err)
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{".pulumiignore", "index.js", "lib/.pulumiignore", "lib/util.js"}, names)
+
+	// Changes to ignored files do not change the archive's hash.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "build", "out.js"), []byte("changed"), 0600))
+	same, err := NewPathArchive(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, arch.Hash, same.Hash)
+}
+
 func TestArchiveTar(t *testing.T) {
 	// Note that test data was generated using the Go 1.9 headers
 	arch, err := NewPathArchive("../../../../pkg/resource/testdata/test_dir.tar")
@@ -0,0 +1,69 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+)
+
+// BytesSig is the unique bytes signature. Byte strings are serialized as objects that carry this signature under
+// SigKey and the base64 encoding of their contents under BytesValueKey.
+const BytesSig = "672ab1f1e5d185b098bbe231ed195e9a"
+
+// BytesValueKey is the key of the base64-encoded contents of a serialized byte string.
+const BytesValueKey = "value"
+
+// Bytes is an arbitrary sequence of bytes, such as a certificate or a compiled module.
+type Bytes []byte
+
+// DecodeBytes decodes a standard base64 string into a byte string.
+func DecodeBytes(s string) (Bytes, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base64 byte string")
+	}
+	return Bytes(b), nil
+}
+
+// Base64 returns the standard base64 encoding of the byte string. This is the encoding used on the wire.
+func (b Bytes) Base64() string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// Serialize returns a weakly typed map that contains the right signature for serialization purposes.
+func (b Bytes) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		SigKey:        BytesSig,
+		BytesValueKey: b.Base64(),
+	}
+}
+
+// DeserializeBytes checks to see if the map contains a byte string, and if it does, deserializes it.
+func DeserializeBytes(obj map[string]interface{}) (Bytes, bool, error) {
+	if obj[SigKey] != BytesSig {
+		return nil, false, nil
+	}
+	s, ok := obj[BytesValueKey].(string)
+	if !ok {
+		return nil, false, errors.New("malformed byte string: missing value")
+	}
+	b, err := DecodeBytes(s)
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytesPropertyValues(t *testing.T) {
+	v := NewPropertyValue([]byte{0xde, 0xad, 0xbe, 0xef})
+	assert.True(t, v.IsBytes())
+	assert.False(t, v.IsString())
+	assert.False(t, v.IsArray())
+	assert.Equal(t, "bytes", v.TypeString())
+	assert.Equal(t, "3q2+7w==", v.BytesValue().Base64())
+
+	assert.True(t, v.DeepEquals(NewBytesProperty(Bytes{0xde, 0xad, 0xbe, 0xef})))
+	assert.False(t, v.DeepEquals(NewBytesProperty(Bytes{0xde, 0xad})))
+	assert.False(t, v.DeepEquals(NewStringProperty("3q2+7w==")))
+	assert.False(t, NewStringProperty("3q2+7w==").DeepEquals(v))
+}
+
+func TestDeserializeBytes(t *testing.T) {
+	b, ok, err := DeserializeBytes(Bytes("hello").Serialize())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Bytes("hello"), b)
+
+	_, ok, err = DeserializeBytes(map[string]interface{}{"foo": "bar"})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = DeserializeBytes(map[string]interface{}{SigKey: BytesSig, BytesValueKey: "not base64!"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// CustomAwaitKey is the well-known input property used to transport a resource's custom await options to its provider.
+// Because the key is internal, it is never displayed to users.
+const CustomAwaitKey PropertyKey = "__customAwait"
+
+// CustomAwait describes how a provider should wait for a resource to become ready after a create or update. Providers
+// that do not support custom await options ignore them.
+type CustomAwait struct {
+	// Skip, when true, indicates that the provider should not wait for the resource to become ready.
+	Skip bool
+	// Conditions is an optional list of provider-specific conditions that must hold before the resource is ready.
+	Conditions []string
+}
+
+// PropertyValue returns the representation of the await options that is stored under CustomAwaitKey.
+func (c CustomAwait) PropertyValue() PropertyValue {
+	obj := PropertyMap{}
+	if c.Skip {
+		obj["skip"] = NewBoolProperty(true)
+	}
+	if len(c.Conditions) != 0 {
+		conditions := make([]PropertyValue, len(c.Conditions))
+		for i, cond := range c.Conditions {
+			conditions[i] = NewStringProperty(cond)
+		}
+		obj["conditions"] = NewArrayProperty(conditions)
+	}
+	return NewObjectProperty(obj)
+}
+
+// GetCustomAwait returns the custom await options, if any, that are present in the given resource inputs. Values of
+// the wrong type are ignored.
+func GetCustomAwait(inputs PropertyMap) (CustomAwait, bool) {
+	v, ok := inputs[CustomAwaitKey]
+	if !ok || !v.IsObject() {
+		return CustomAwait{}, false
+	}
+	obj := v.ObjectValue()
+
+	var await CustomAwait
+	if skip, ok := obj["skip"]; ok && skip.IsBool() {
+		await.Skip = skip.BoolValue()
+	}
+	if conditions, ok := obj["conditions"]; ok && conditions.IsArray() {
+		for _, cond := range conditions.ArrayValue() {
+			if cond.IsString() {
+				await.Conditions = append(await.Conditions, cond.StringValue())
+			}
+		}
+	}
+	return await, true
+}
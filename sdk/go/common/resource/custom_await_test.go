@@ -0,0 +1,33 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomAwaitRoundTrip(t *testing.T) {
+	_, ok := GetCustomAwait(PropertyMap{})
+	assert.False(t, ok)
+
+	await := CustomAwait{Skip: true, Conditions: []string{"Ready", "Available"}}
+	actual, ok := GetCustomAwait(PropertyMap{CustomAwaitKey: await.PropertyValue()})
+	assert.True(t, ok)
+	assert.Equal(t, await, actual)
+
+	assert.True(t, IsInternalPropertyKey(CustomAwaitKey))
+}
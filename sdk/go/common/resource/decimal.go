@@ -0,0 +1,118 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DecimalSig is the unique decimal signature. Decimals are serialized as objects that carry this signature under
+// SigKey and the decimal's canonical string representation under DecimalValueKey.
+const DecimalSig = "fe60fdd1cd12c4e738b157e2238d2ae8"
+
+// DecimalValueKey is the key of the string representation of a serialized decimal.
+const DecimalValueKey = "value"
+
+// Decimal is an exact decimal number, represented by its canonical string form (e.g. "9007199254740993" or "0.1").
+// Decimal property values carry integers and fractions that cannot be represented exactly by an IEEE754 double.
+type Decimal string
+
+var decimalRegexp = regexp.MustCompile(`^([+-]?)([0-9]+)(?:\.([0-9]+))?$`)
+
+// ParseDecimal parses a decimal literal of the form [+-]digits[.digits] and returns its canonical form.
+func ParseDecimal(s string) (Decimal, error) {
+	m := decimalRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return "", errors.Errorf("invalid decimal %q", s)
+	}
+	sign, whole, frac := m[1], strings.TrimLeft(m[2], "0"), strings.TrimRight(m[3], "0")
+	if whole == "" {
+		whole = "0"
+	}
+	if sign == "+" || (whole == "0" && frac == "") {
+		sign = ""
+	}
+	if frac != "" {
+		return Decimal(sign + whole + "." + frac), nil
+	}
+	return Decimal(sign + whole), nil
+}
+
+// DecimalFromInt64 returns the decimal representation of the given integer.
+func DecimalFromInt64(v int64) Decimal {
+	return Decimal(strconv.FormatInt(v, 10))
+}
+
+// DecimalFromUint64 returns the decimal representation of the given unsigned integer.
+func DecimalFromUint64(v uint64) Decimal {
+	return Decimal(strconv.FormatUint(v, 10))
+}
+
+// DecimalFromFloat64 returns the shortest decimal representation that uniquely identifies the given double.
+func DecimalFromFloat64(v float64) (Decimal, error) {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return "", errors.Errorf("%v has no decimal representation", v)
+	}
+	return ParseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// Float64 returns the double that is nearest to the decimal. Decimals that are out of the range of a double return the
+// appropriately-signed infinity.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(string(d), 64)
+	return f
+}
+
+// String returns the canonical string representation of the decimal.
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// decimal returns the decimal representation of a number value.
+func (v PropertyValue) decimal() (Decimal, error) {
+	if v.IsDecimal() {
+		return v.DecimalValue(), nil
+	}
+	return DecimalFromFloat64(v.NumberValue())
+}
+
+// Serialize returns a weakly typed map that contains the right signature for serialization purposes.
+func (d Decimal) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		SigKey:          DecimalSig,
+		DecimalValueKey: string(d),
+	}
+}
+
+// DeserializeDecimal checks to see if the map contains a decimal, and if it does, deserializes it.
+func DeserializeDecimal(obj map[string]interface{}) (Decimal, bool, error) {
+	if obj[SigKey] != DecimalSig {
+		return "", false, nil
+	}
+	s, ok := obj[DecimalValueKey].(string)
+	if !ok {
+		return "", false, errors.New("malformed decimal: missing value")
+	}
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return "", false, err
+	}
+	return d, true, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]Decimal{
+		"0":                    "0",
+		"-0":                   "0",
+		"+42":                  "42",
+		"007":                  "7",
+		"1.50":                 "1.5",
+		"-0.000":               "0",
+		"-12.034":              "-12.034",
+		"9007199254740993":     "9007199254740993",
+		"12345678901234567890": "12345678901234567890",
+	}
+	for s, expected := range cases {
+		actual, err := ParseDecimal(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, actual, s)
+	}
+
+	for _, s := range []string{"", "-", "1.", ".5", "1e10", "0x10", "NaN"} {
+		_, err := ParseDecimal(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestDecimalFromFloat64(t *testing.T) {
+	d, err := DecimalFromFloat64(0.1)
+	assert.NoError(t, err)
+	assert.Equal(t, Decimal("0.1"), d)
+
+	_, err = DecimalFromFloat64(math.Inf(1))
+	assert.Error(t, err)
+}
+
+func TestDecimalPropertyValues(t *testing.T) {
+	small := NewPropertyValue(int64(42))
+	assert.False(t, small.IsDecimal())
+	assert.Equal(t, float64(42), small.NumberValue())
+
+	big := NewPropertyValue(int64(math.MaxInt64))
+	assert.True(t, big.IsNumber())
+	assert.True(t, big.IsDecimal())
+	assert.Equal(t, Decimal("9223372036854775807"), big.DecimalValue())
+
+	huge := NewPropertyValue(uint64(math.MaxUint64))
+	assert.True(t, huge.IsDecimal())
+	assert.Equal(t, Decimal("18446744073709551615"), huge.DecimalValue())
+
+	// Decimals compare equal to doubles with the same value, but distinct large integers do not collapse.
+	assert.True(t, NewDecimalProperty("1.5").DeepEquals(NewNumberProperty(1.5)))
+	assert.False(t, NewPropertyValue(int64(1<<53+1)).DeepEquals(NewPropertyValue(int64(1<<53))))
+}
+
+func TestDeserializeDecimal(t *testing.T) {
+	d, ok, err := DeserializeDecimal(Decimal("-1.25").Serialize())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Decimal("-1.25"), d)
+
+	_, ok, err = DeserializeDecimal(map[string]interface{}{"foo": "bar"})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = DeserializeDecimal(map[string]interface{}{SigKey: DecimalSig, DecimalValueKey: "one"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// PluginContainerRuntimeEnvVar names the container runtime used to run plugins that are installed as container
+// images, e.g. "podman". The runtime must accept the same arguments as Docker. The default is "docker".
+const PluginContainerRuntimeEnvVar = "PULUMI_PLUGIN_CONTAINER_RUNTIME"
+
+// containerHostEnv are the environment variables that describe the host rather than the plugin's configuration. They
+// are not passed to containerized plugins, which have their own.
+var containerHostEnv = map[string]bool{
+	"PATH": true, "HOME": true, "HOSTNAME": true, "PWD": true, "OLDPWD": true, "SHELL": true, "TMPDIR": true,
+}
+
+// nextContainerID is used to give each container launched by this process a unique name.
+var nextContainerID int32
+
+// pluginContainer runs a plugin inside a container, for plugins that are installed as container images rather than
+// as native binaries (see workspace.PluginContainer).
+//
+// Plugins and the engine connect to each other's gRPC servers over loopback TCP, so containers share the host's
+// network namespace. Host networking is only available to Linux containers running on a Linux host.
+type pluginContainer struct {
+	runtime   string                     // the path to the container runtime's executable.
+	container *workspace.PluginContainer // the image in which the plugin runs.
+}
+
+// newPluginContainer prepares to run plugins in the given container.
+func newPluginContainer(container *workspace.PluginContainer) (*pluginContainer, error) {
+	if runtime.GOOS != "linux" {
+		return nil, errors.Errorf("plugin image %s cannot be run: containerized plugins are only supported on Linux",
+			container.Image)
+	}
+
+	name := os.Getenv(PluginContainerRuntimeEnvVar)
+	if name == "" {
+		name = "docker"
+	}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin image %s cannot be run: the container runtime %s was not found on "+
+			"the $PATH", container.Image, name)
+	}
+	return &pluginContainer{runtime: path, container: container}, nil
+}
+
+// Command returns the program and arguments that run the plugin with the given arguments in a new container with the
+// given name. The plugin's working directory is mounted into the container at the same path, and the variables in
+// the given environment are passed to the container, save those that describe the host. A nil environment stands
+// for the current process's environment.
+func (c *pluginContainer) Command(name, pwd string, args, env []string) (string, []string) {
+	if env == nil {
+		env = os.Environ()
+	}
+
+	runArgs := []string{"run", "--rm", "--interactive", "--init", "--network", "host", "--name", name}
+	if c.container.Platform != "" {
+		runArgs = append(runArgs, "--platform", c.container.Platform)
+	}
+	if pwd != "" {
+		runArgs = append(runArgs, "--volume", pwd+":"+pwd, "--workdir", pwd)
+	}
+	for _, kv := range env {
+		key := kv
+		if eq := strings.Index(kv, "="); eq >= 0 {
+			key = kv[:eq]
+		}
+		if key != "" && !containerHostEnv[key] {
+			// Only the name is passed so that values are not visible in the runtime's command line. The runtime reads
+			// the values from its own environment.
+			runArgs = append(runArgs, "--env", key)
+		}
+	}
+	runArgs = append(runArgs, c.container.Image)
+
+	logging.V(7).Infof("running plugin image %s in container %s", c.container.Image, name)
+	return c.runtime, append(runArgs, args...)
+}
+
+// Remove removes the container with the given name. Killing the runtime's client process does not stop the container
+// it started, so containers are removed explicitly when their plugins are closed.
+func (c *pluginContainer) Remove(name string) error {
+	if out, err := exec.Command(c.runtime, "rm", "--force", name).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "removing plugin container %s: %s", name, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// newContainerName returns a unique name for a container that runs a plugin.
+func newContainerName() string {
+	return fmt.Sprintf("pulumi-plugin-%d-%d", os.Getpid(), atomic.AddInt32(&nextContainerID, 1))
+}
@@ -0,0 +1,83 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+func TestPluginContainerCommand(t *testing.T) {
+	c := &pluginContainer{
+		runtime:   "/usr/bin/docker",
+		container: &workspace.PluginContainer{Image: "example:v1", Platform: "linux/arm64"},
+	}
+
+	bin, args := c.Command("pulumi-plugin-1-1", "/work", []string{"127.0.0.1:1234"},
+		[]string{"PATH=/bin", "HOME=/root", "AWS_REGION=us-west-2", "PULUMI_RUNTIME_FOO=bar"})
+	assert.Equal(t, "/usr/bin/docker", bin)
+	assert.Equal(t, []string{
+		"run", "--rm", "--interactive", "--init", "--network", "host", "--name", "pulumi-plugin-1-1",
+		"--platform", "linux/arm64",
+		"--volume", "/work:/work", "--workdir", "/work",
+		"--env", "AWS_REGION", "--env", "PULUMI_RUNTIME_FOO",
+		"example:v1", "127.0.0.1:1234",
+	}, args)
+}
+
+func TestGetProviderContainer(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("containerized plugins are only supported on Linux")
+	}
+
+	dir, err := ioutil.TempDir("", "provider-container")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Any executable on the $PATH serves as the runtime, as no containers are run.
+	defer os.Setenv(PluginContainerRuntimeEnvVar, os.Getenv(PluginContainerRuntimeEnvVar))
+	assert.NoError(t, os.Setenv(PluginContainerRuntimeEnvVar, "true"))
+
+	path := filepath.Join(dir, "pulumi-resource-example")
+
+	// Plugins found on the $PATH and plugins without container metadata run natively.
+	c, err := getProviderContainer("", path)
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+	c, err = getProviderContainer(dir, path)
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pulumi-container.json"), []byte(`{"image":"example:v1"}`),
+		0600))
+	c, err = getProviderContainer(dir, path)
+	assert.NoError(t, err)
+	if assert.NotNil(t, c) {
+		assert.Equal(t, "example:v1", c.container.Image)
+	}
+
+	// A native binary takes precedence over the container.
+	assert.NoError(t, ioutil.WriteFile(path, nil, 0700))
+	c, err = getProviderContainer(dir, path)
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+}
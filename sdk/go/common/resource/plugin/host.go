@@ -363,6 +363,8 @@ func (host *defaultHost) EnsurePlugins(plugins []workspace.PluginInfo, kinds Fla
 						errors.Wrapf(err, "failed to load resource plugin %s", plugin.Name))
 				}
 			}
+		case workspace.PersisterPlugin:
+			// Persisters store the checkpoints of stacks and are loaded by backends rather than by the host.
 		default:
 			contract.Failf("unexpected plugin kind: %s", plugin.Kind)
 		}
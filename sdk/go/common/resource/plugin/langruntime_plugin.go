@@ -61,7 +61,7 @@ func NewLanguageRuntime(host Host, ctx *Context, runtime string,
 	}
 	args = append(args, host.ServerAddr())
 
-	plug, err := newPlugin(ctx, ctx.Pwd, path, runtime, args, nil /*env*/)
+	plug, err := newPlugin(ctx, ctx.Pwd, path, runtime, args, nil /*env*/, nil /*sandbox*/, nil /*container*/)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,81 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// Persister stores the checkpoints of stacks on behalf of the engine, e.g. in a system that the built-in backends do
+// not support.  Checkpoints are exchanged in their JSON-encoded form, so persisters need not understand their contents.
+type Persister interface {
+	// Closer closes any underlying OS resources associated with this persister (like processes, RPC channels, etc).
+	io.Closer
+	// Name fetches a persister's name.
+	Name() string
+	// Load returns the schema version and JSON-encoded deployment of the given stack's latest checkpoint.  The version
+	// is 0 if the stack has no checkpoint.
+	Load(stack tokens.QName) (int, json.RawMessage, error)
+	// Save opens a stream that carries the changes made to the given stack's checkpoint during an update.
+	Save(stack tokens.QName) (CheckpointStream, error)
+	// GetPluginInfo returns this plugin's information.
+	GetPluginInfo() (workspace.PluginInfo, error)
+}
+
+// CheckpointStream carries the changes made to a stack's checkpoint to a persister.  The first mutation sent on a
+// stream applies to an empty checkpoint, and each subsequent mutation applies to the result of the one before it.
+type CheckpointStream interface {
+	// Send sends a change to the persister.
+	Send(mutation CheckpointMutation) error
+	// Close closes the stream and waits until the persister has made every change durable.
+	Close() error
+}
+
+// CheckpointMutation is a change to a stack's checkpoint.  The deployment's resources are replaced by splicing: the
+// mutation removes DeleteCount resources at index Start and inserts its own Resources in their place.  Everything
+// else in the deployment is replaced by Header.
+type CheckpointMutation struct {
+	// Version is the schema version of the deployment.
+	Version int
+	// Header is the JSON-encoded deployment, without its resources.
+	Header json.RawMessage
+	// Start is the index of the first resource to remove.
+	Start int
+	// DeleteCount is the number of resources to remove.
+	DeleteCount int
+	// Resources are the JSON-encoded resources to insert.
+	Resources []json.RawMessage
+}
+
+// Apply returns the resources that result from applying the mutation to the given resources.  The given slice is not
+// modified.
+func (m CheckpointMutation) Apply(resources []json.RawMessage) ([]json.RawMessage, error) {
+	if m.Start < 0 || m.DeleteCount < 0 || m.Start+m.DeleteCount > len(resources) {
+		return nil, errors.Errorf("cannot remove %d resources at index %d from a checkpoint with %d resources",
+			m.DeleteCount, m.Start, len(resources))
+	}
+
+	result := make([]json.RawMessage, 0, len(resources)-m.DeleteCount+len(m.Resources))
+	result = append(result, resources[:m.Start]...)
+	result = append(result, m.Resources...)
+	result = append(result, resources[m.Start+m.DeleteCount:]...)
+	return result, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/rpcutil/rpcerror"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// persister reflects a persister plugin, loaded dynamically to store the checkpoints of stacks.
+type persister struct {
+	ctx    *Context
+	name   string
+	plug   *plugin
+	client pulumirpc.SnapshotPersisterClient
+}
+
+var _ Persister = (*persister)(nil)
+
+// NewPersister binds to a given persister's plugin by name and creates a gRPC connection to it.  If the associated
+// plugin could not be found by name on the PATH, or an error occurs while creating the child process, an error is
+// returned.
+func NewPersister(ctx *Context, name string) (Persister, error) {
+	// Load the plugin's path by using the standard workspace logic.
+	_, path, err := workspace.GetPluginPath(
+		workspace.PersisterPlugin, strings.Replace(name, tokens.QNameDelimiter, "_", -1), nil)
+	if err != nil {
+		return nil, rpcerror.Convert(err)
+	} else if path == "" {
+		return nil, workspace.NewMissingError(workspace.PluginInfo{
+			Kind: workspace.PersisterPlugin,
+			Name: name,
+		})
+	}
+
+	plug, err := newPlugin(ctx, ctx.Pwd, path, fmt.Sprintf("%v (persister)", name), nil /*args*/, nil, /*env*/
+		nil /*sandbox*/, nil /*container*/)
+	if err != nil {
+		return nil, err
+	}
+	contract.Assertf(plug != nil, "unexpected nil persister plugin for %s", name)
+
+	return &persister{
+		ctx:    ctx,
+		name:   name,
+		plug:   plug,
+		client: pulumirpc.NewSnapshotPersisterClient(plug.Conn),
+	}, nil
+}
+
+func (p *persister) Name() string { return p.name }
+
+// label returns a base label for tracing functions.
+func (p *persister) label() string {
+	return fmt.Sprintf("Persister[%s]", p.name)
+}
+
+// Load returns the schema version and JSON-encoded deployment of the given stack's latest checkpoint.
+func (p *persister) Load(stack tokens.QName) (int, json.RawMessage, error) {
+	label := fmt.Sprintf("%s.Load(%s)", p.label(), stack)
+	logging.V(7).Infof("%s executing", label)
+
+	resp, err := p.client.Load(p.ctx.Request(), &pulumirpc.LoadCheckpointRequest{Stack: string(stack)})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+		return 0, nil, rpcError
+	}
+
+	logging.V(7).Infof("%s success: version=%d, #bytes=%d", label, resp.GetVersion(), len(resp.GetDeployment()))
+	return int(resp.GetVersion()), json.RawMessage(resp.GetDeployment()), nil
+}
+
+// Save opens a stream that carries the changes made to the given stack's checkpoint to the plugin.
+func (p *persister) Save(stack tokens.QName) (CheckpointStream, error) {
+	label := fmt.Sprintf("%s.Save(%s)", p.label(), stack)
+	logging.V(7).Infof("%s executing", label)
+
+	client, err := p.client.Save(p.ctx.Request())
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+		return nil, rpcError
+	}
+	return &checkpointStream{label: label, stack: stack, client: client}, nil
+}
+
+// GetPluginInfo returns this plugin's information.
+func (p *persister) GetPluginInfo() (workspace.PluginInfo, error) {
+	label := fmt.Sprintf("%s.GetPluginInfo()", p.label())
+	logging.V(7).Infof("%s executing", label)
+	resp, err := p.client.GetPluginInfo(p.ctx.Request(), &pbempty.Empty{})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+		return workspace.PluginInfo{}, rpcError
+	}
+
+	var version *semver.Version
+	if v := resp.Version; v != "" {
+		sv, err := semver.ParseTolerant(v)
+		if err != nil {
+			return workspace.PluginInfo{}, err
+		}
+		version = &sv
+	}
+
+	return workspace.PluginInfo{
+		Name:    p.name,
+		Path:    p.plug.Bin,
+		Kind:    workspace.PersisterPlugin,
+		Version: version,
+	}, nil
+}
+
+// Close tears down the underlying plugin RPC connection and process.
+func (p *persister) Close() error {
+	return p.plug.Close()
+}
+
+// checkpointStream sends the changes to a single stack's checkpoint to a persister plugin.
+type checkpointStream struct {
+	label  string
+	stack  tokens.QName
+	client pulumirpc.SnapshotPersister_SaveClient
+}
+
+func (s *checkpointStream) Send(mutation CheckpointMutation) error {
+	resources := make([][]byte, len(mutation.Resources))
+	for i, r := range mutation.Resources {
+		resources[i] = r
+	}
+
+	logging.V(9).Infof("%s sending mutation: start=%d, deleteCount=%d, #resources=%d",
+		s.label, mutation.Start, mutation.DeleteCount, len(resources))
+	if err := s.client.Send(&pulumirpc.CheckpointMutation{
+		Stack:       string(s.stack),
+		Version:     int32(mutation.Version),
+		Header:      mutation.Header,
+		Start:       int32(mutation.Start),
+		DeleteCount: int32(mutation.DeleteCount),
+		Resources:   resources,
+	}); err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", s.label, rpcError)
+		return rpcError
+	}
+	return nil
+}
+
+func (s *checkpointStream) Close() error {
+	if _, err := s.client.CloseAndRecv(); err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", s.label, rpcError)
+		return rpcError
+	}
+	logging.V(7).Infof("%s success", s.label)
+	return nil
+}
@@ -41,8 +41,13 @@ import (
 )
 
 type plugin struct {
-	stdoutDone <-chan bool
-	stderrDone <-chan bool
+	stdoutDone  <-chan bool
+	stderrDone  <-chan bool
+	stopMonitor func() // stops sampling the plugin's resource usage, if non-nil.
+
+	container     *pluginContainer // the container in which the plugin runs, if any.
+	containerName string           // the name of the plugin's container, if any.
+	relay         *sandboxRelay    // the relay that connects a sandboxed plugin to the engine, if any.
 
 	Bin  string
 	Args []string
@@ -71,7 +76,9 @@ var errRunPolicyModuleNotFound = errors.New("pulumi SDK does not support policy
 // errPluginNotFound is returned when we try to execute a plugin but it is not found on disk.
 var errPluginNotFound = errors.New("plugin not found")
 
-func newPlugin(ctx *Context, pwd, bin, prefix string, args, env []string) (*plugin, error) {
+func newPlugin(ctx *Context, pwd, bin, prefix string, args, env []string, sandbox *PluginSandbox,
+	container *pluginContainer) (*plugin, error) {
+
 	if logging.V(9) {
 		var argstr string
 		for i, arg := range args {
@@ -83,8 +90,13 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, args, env []string) (*plug
 		logging.V(9).Infof("Launching plugin '%v' from '%v' with args: %v", prefix, bin, argstr)
 	}
 
+	memoryLimit, err := GetPluginMemoryLimit()
+	if err != nil {
+		return nil, err
+	}
+
 	// Try to execute the binary.
-	plug, err := execPlugin(bin, args, pwd, env)
+	plug, err := execPlugin(bin, args, pwd, env, sandbox, container)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to load plugin %s", bin)
 	}
@@ -178,12 +190,16 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, args, env []string) (*plug
 	go runtrace(plug.Stdout, false, stdoutDone)
 
 	// Now that we have the port, go ahead and create a gRPC client connection to it.
-	conn, err := grpc.Dial(
-		"127.0.0.1:"+port,
+	dialOpts := []grpc.DialOption{
 		grpc.WithInsecure(),
 		grpc.WithUnaryInterceptor(rpcutil.OpenTracingClientInterceptor()),
 		rpcutil.GrpcChannelOptions(),
-	)
+	}
+	if plug.relay != nil {
+		// The port belongs to the plugin's own network namespace.
+		dialOpts = append(dialOpts, grpc.WithContextDialer(plug.relay.dial))
+	}
+	conn, err := grpc.Dial("127.0.0.1:"+port, dialOpts...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not dial plugin [%v] over RPC", bin)
 	}
@@ -230,13 +246,17 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, args, env []string) (*plug
 		}
 	}
 
-	// Done; store the connection and return the plugin info.
+	// Done; store the connection, start sampling the plugin's resource usage, and return the plugin info.
 	plug.Conn = conn
+	plug.stopMonitor = monitorResourceUsage(ctx, prefix, plug.Proc, memoryLimit)
 	return plug, nil
 }
 
-// execPlugin starts the plugin executable.
-func execPlugin(bin string, pluginArgs []string, pwd string, env []string) (*plugin, error) {
+// execPlugin starts the plugin executable, inside the given container or sandbox if either is non-nil. A plugin that
+// runs in a container is only sandboxed to the extent that its environment is filtered.
+func execPlugin(bin string, pluginArgs []string, pwd string, env []string, sandbox *PluginSandbox,
+	container *pluginContainer) (*plugin, error) {
+
 	var args []string
 	// Flow the logging information if set.
 	if logging.LogFlow {
@@ -253,10 +273,40 @@ func execPlugin(bin string, pluginArgs []string, pwd string, env []string) (*plu
 	}
 	args = append(args, pluginArgs...)
 
-	cmd := exec.Command(bin, args...)
+	cmdBin, cmdArgs := bin, args
+	var containerName string
+	var relay *sandboxRelay
+	switch {
+	case container != nil:
+		if sandbox != nil {
+			env = sandbox.FilterEnv(env)
+		}
+		containerName = newContainerName()
+		cmdBin, cmdArgs = container.Command(containerName, pwd, args, env)
+	case sandbox != nil:
+		var err error
+		if !sandbox.Network {
+			// Analyzer and provider plugins receive the engine's address as their first argument.
+			if len(pluginArgs) == 0 {
+				return nil, errors.New("cannot sandbox a plugin that does not connect to the engine")
+			}
+			if relay, err = newSandboxRelay(pluginArgs[0]); err != nil {
+				return nil, err
+			}
+		}
+		if cmdBin, cmdArgs, err = sandbox.Command(bin, args, relay); err != nil {
+			if relay != nil {
+				contract.IgnoreClose(relay)
+			}
+			return nil, err
+		}
+		env = sandbox.FilterEnv(env)
+	}
+
+	cmd := exec.Command(cmdBin, cmdArgs...)
 	cmdutil.RegisterProcessGroup(cmd)
 	cmd.Dir = pwd
-	if len(env) > 0 {
+	if env != nil {
 		cmd.Env = env
 	}
 	in, _ := cmd.StdinPipe()
@@ -277,17 +327,23 @@ func execPlugin(bin string, pluginArgs []string, pwd string, env []string) (*plu
 			}
 
 		}
+		if relay != nil {
+			contract.IgnoreClose(relay)
+		}
 		return nil, err
 	}
 
 	return &plugin{
-		Bin:    bin,
-		Args:   args,
-		Env:    env,
-		Proc:   cmd.Process,
-		Stdin:  in,
-		Stdout: out,
-		Stderr: err,
+		container:     container,
+		containerName: containerName,
+		relay:         relay,
+		Bin:           bin,
+		Args:          args,
+		Env:           env,
+		Proc:          cmd.Process,
+		Stdin:         in,
+		Stdout:        out,
+		Stderr:        err,
 	}, nil
 }
 
@@ -296,6 +352,10 @@ func (p *plugin) Close() error {
 		contract.IgnoreClose(p.Conn)
 	}
 
+	if p.stopMonitor != nil {
+		p.stopMonitor()
+	}
+
 	var result error
 
 	// On each platform, plugins are not loaded directly, instead a shell launches each plugin as a child process, so
@@ -318,5 +378,18 @@ func (p *plugin) Close() error {
 		<-p.stderrDone
 	}
 
+	// Killing the container runtime's client does not stop the plugin's container, so remove it as well.
+	if p.container != nil {
+		if err := p.container.Remove(p.containerName); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if p.relay != nil {
+		if err := p.relay.Close(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
 	return result
 }
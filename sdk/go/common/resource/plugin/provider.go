@@ -16,6 +16,9 @@ package plugin
 
 import (
 	"io"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
@@ -80,6 +83,14 @@ type Provider interface {
 		tok tokens.ModuleMember,
 		args resource.PropertyMap,
 		onNext func(resource.PropertyMap) error) ([]CheckFailure, error)
+	// GetLogs sends the log entries produced by a resource to onNext, oldest first. If the request asks to follow
+	// the logs, GetLogs keeps sending new entries until onNext returns an error. Providers that cannot supply logs
+	// return ErrLogsNotSupported.
+	GetLogs(req LogsRequest, onNext func(LogEntry) error) error
+	// Call runs a method declared by a resource's schema against an existing instance of that resource. Providers that
+	// do not implement any methods return ErrCallNotSupported.
+	Call(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+		args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
 
@@ -91,6 +102,36 @@ type Provider interface {
 	SignalCancellation() error
 }
 
+// ConfigurationAwaiter is implemented by providers whose Configure method returns before the provider has applied its
+// configuration. AwaitConfiguration blocks until the configuration has been applied and returns the error, if any,
+// that applying it produced.
+type ConfigurationAwaiter interface {
+	AwaitConfiguration() error
+}
+
+// ErrLogsNotSupported is returned by Provider.GetLogs when the provider cannot supply logs for a resource.
+var ErrLogsNotSupported = errors.New("provider does not support retrieving logs")
+
+// ErrCallNotSupported is returned by Provider.Call when the provider does not implement resource methods.
+var ErrCallNotSupported = errors.New("provider does not support calling resource methods")
+
+// LogsRequest describes the log entries requested from a call to GetLogs.
+type LogsRequest struct {
+	URN        resource.URN         // the URN of the resource whose logs to fetch.
+	ID         resource.ID          // the ID of the resource.
+	Properties resource.PropertyMap // the current properties of the resource.
+	StartTime  *time.Time           // if non-nil, only return entries produced at or after this time.
+	EndTime    *time.Time           // if non-nil, only return entries produced before this time.
+	Follow     bool                 // true to keep sending new entries as they are produced.
+}
+
+// LogEntry is a single line of output from a running resource.
+type LogEntry struct {
+	ID        string // the source of the entry within the resource, such as a function or pod name.
+	Timestamp int64  // the Unix time at which the entry was produced, in milliseconds.
+	Message   string // the text of the entry.
+}
+
 // CheckFailure indicates that a call to check failed; it contains the property and reason for the failure.
 type CheckFailure struct {
 	Property resource.PropertyKey // the property that failed checking.
@@ -208,4 +249,19 @@ type ReadResult struct {
 	// Outputs contains the new outputs/state for the resource, if any. If this field is nil, the resource does not
 	// exist.
 	Outputs resource.PropertyMap
+	// Children contains any resources the provider discovered beneath this resource that it did not create, e.g.
+	// resources that the cloud created implicitly alongside it.
+	Children []ReadChild
+}
+
+// ReadChild describes a resource discovered by a call to Read beneath the resource being read.
+type ReadChild struct {
+	// Type is the type token of the child resource.
+	Type tokens.Type
+	// Name is the name of the child resource. Together with its type and parent, it determines the child's URN.
+	Name tokens.QName
+	// ID is the provider ID of the child resource.
+	ID resource.ID
+	// Outputs contains the live state of the child resource.
+	Outputs resource.PropertyMap
 }
@@ -15,11 +15,13 @@
 package plugin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -28,6 +30,7 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
@@ -55,7 +58,8 @@ const kubernetesProviderType = "pulumi:providers:kubernetes"
 type provider struct {
 	ctx           *Context                         // a plugin context for caching, etc.
 	pkg           tokens.Package                   // the Pulumi package containing this provider's resources.
-	plug          *plugin                          // the actual plugin process wrapper.
+	path          string                           // the path to the plugin's executable.
+	conn          *restartingConn                  // the connection to the plugin, which restarts it if it exits.
 	clientRaw     pulumirpc.ResourceProviderClient // the raw provider client; usually unsafe to use directly.
 	cfgerr        error                            // non-nil if a configure call fails.
 	cfgknown      bool                             // true if all configuration values are known.
@@ -68,7 +72,7 @@ type provider struct {
 func NewProvider(host Host, ctx *Context, pkg tokens.Package, version *semver.Version,
 	options map[string]interface{}) (Provider, error) {
 	// Load the plugin's path by using the standard workspace logic.
-	_, path, err := workspace.GetPluginPath(
+	dir, path, err := workspace.GetPluginPath(
 		workspace.ResourcePlugin, strings.Replace(string(pkg), tokens.QNameDelimiter, "_", -1), version)
 	if err != nil {
 		return nil, err
@@ -80,28 +84,68 @@ func NewProvider(host Host, ctx *Context, pkg tokens.Package, version *semver.Ve
 		})
 	}
 
+	// If the plugin's directory has no native binary, the plugin may instead be installed as a container image.
+	container, err := getProviderContainer(dir, path)
+	if err != nil {
+		return nil, err
+	}
+
 	// Runtime options are passed as environment variables to the provider.
 	env := os.Environ()
 	for k, v := range options {
 		env = append(env, fmt.Sprintf("PULUMI_RUNTIME_%s=%v", strings.ToUpper(k), v))
 	}
 
-	plug, err := newPlugin(ctx, ctx.Pwd, path, fmt.Sprintf("%v (resource)", pkg),
-		[]string{host.ServerAddr()}, env)
+	prefix := fmt.Sprintf("%v (resource)", pkg)
+	launch := func() (*providerProcess, error) {
+		plug, err := newPlugin(ctx, ctx.Pwd, path, prefix, []string{host.ServerAddr()}, env,
+			GetPluginSandbox(string(pkg)), container)
+		if err != nil {
+			return nil, err
+		}
+		contract.Assertf(plug != nil, "unexpected nil resource plugin for %s", pkg)
+		return &providerProcess{conn: plug.Conn, exited: plug.stdoutDone, close: plug.Close}, nil
+	}
+	proc, err := launch()
 	if err != nil {
 		return nil, err
 	}
-	contract.Assertf(plug != nil, "unexpected nil resource plugin for %s", pkg)
+
+	// If the plugin's process exits unexpectedly, restart it rather than failing the rest of the deployment.
+	conn := newRestartingConn(fmt.Sprintf("resource plugin %s", pkg), proc, launch, maxProviderRestarts)
+	conn.onRestart = func(restarts int) {
+		ctx.Diag.Warningf(diag.Message("", /*urn*/
+			"resource plugin %s exited unexpectedly and was restarted (restart %d of %d)"),
+			pkg, restarts, maxProviderRestarts)
+	}
 
 	return &provider{
 		ctx:       ctx,
 		pkg:       pkg,
-		plug:      plug,
-		clientRaw: pulumirpc.NewResourceProviderClient(plug.Conn),
+		path:      path,
+		conn:      conn,
+		clientRaw: pulumirpc.NewResourceProviderClient(conn),
 		cfgdone:   make(chan bool),
 	}, nil
 }
 
+// getProviderContainer returns the container in which to run the provider plugin installed in the given directory, or
+// nil if the plugin's binary is present and the plugin runs natively.
+func getProviderContainer(dir, path string) (*pluginContainer, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	container, err := workspace.GetPluginContainer(dir)
+	if err != nil || container == nil {
+		return nil, err
+	}
+	return newPluginContainer(container)
+}
+
 func (p *provider) Pkg() tokens.Package { return p.pkg }
 
 // label returns a base label for tracing functions.
@@ -341,6 +385,11 @@ func (p *provider) ensureConfigured() error {
 	return p.cfgerr
 }
 
+// AwaitConfiguration blocks until the most recent call to Configure has completed and returns its error, if any.
+func (p *provider) AwaitConfiguration() error {
+	return p.ensureConfigured()
+}
+
 // annotateSecrets copies the "secretness" from the ins to the outs. If there are values with the same keys for the
 // outs and the ins, if they are both objects, they are transformed recursively. Otherwise, if the value in the ins
 // contains a secret, the entire out value is marked as a secret.  This is very close to how we project secrets
@@ -766,6 +815,7 @@ func (p *provider) Read(urn resource.URN, id resource.ID,
 	var readID resource.ID
 	var liveObject *_struct.Struct
 	var liveInputs *_struct.Struct
+	var liveChildren []*pulumirpc.ChildResource
 	var resourceError error
 	var resourceStatus = resource.StatusOK
 	resp, err := client.Read(p.ctx.Request(), &pulumirpc.ReadRequest{
@@ -786,6 +836,7 @@ func (p *provider) Read(urn resource.URN, id resource.ID,
 		readID = resource.ID(resp.GetId())
 		liveObject = resp.GetProperties()
 		liveInputs = resp.GetInputs()
+		liveChildren = resp.GetChildren()
 	}
 
 	// If the resource was missing, simply return a nil property map.
@@ -815,6 +866,24 @@ func (p *provider) Read(urn resource.URN, id resource.ID,
 		}
 	}
 
+	var children []ReadChild
+	for _, child := range liveChildren {
+		childState, err := UnmarshalProperties(child.GetProperties(), MarshalOptions{
+			Label:          fmt.Sprintf("%s.children[%s].outputs", label, child.GetName()),
+			RejectUnknowns: true,
+			KeepSecrets:    true,
+		})
+		if err != nil {
+			return ReadResult{}, resourceStatus, err
+		}
+		children = append(children, ReadChild{
+			Type:    tokens.Type(child.GetType()),
+			Name:    tokens.QName(child.GetName()),
+			ID:      resource.ID(child.GetId()),
+			Outputs: childState,
+		})
+	}
+
 	// If we could not pass secrets to the provider, retain the secret bit on any property with the same name. This
 	// allows us to retain metadata about secrets in many cases, even for providers that do not understand secrets
 	// natively.
@@ -823,11 +892,13 @@ func (p *provider) Read(urn resource.URN, id resource.ID,
 		annotateSecrets(newState, state)
 	}
 
-	logging.V(7).Infof("%s success; #outs=%d, #inputs=%d", label, len(newState), len(newInputs))
+	logging.V(7).Infof("%s success; #outs=%d, #inputs=%d, #children=%d", label, len(newState), len(newInputs),
+		len(children))
 	return ReadResult{
-		ID:      readID,
-		Outputs: newState,
-		Inputs:  newInputs,
+		ID:       readID,
+		Outputs:  newState,
+		Inputs:   newInputs,
+		Children: children,
 	}, resourceStatus, resourceError
 }
 
@@ -1086,6 +1157,153 @@ func (p *provider) StreamInvoke(
 	}
 }
 
+// GetLogs streams the log entries produced by a resource.
+func (p *provider) GetLogs(req LogsRequest, onNext func(LogEntry) error) error {
+	contract.Assert(req.URN != "")
+
+	label := fmt.Sprintf("%s.GetLogs(%s)", p.label(), req.URN)
+	logging.V(7).Infof("%s executing (follow=%v)", label, req.Follow)
+
+	mprops, err := MarshalProperties(req.Properties, MarshalOptions{
+		Label:              label,
+		ElideAssetContents: true,
+		KeepSecrets:        p.acceptSecrets,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Get the RPC client and ensure it's configured.
+	client, err := p.getClient()
+	if err != nil {
+		return err
+	}
+
+	var startTime, endTime int64
+	if req.StartTime != nil {
+		startTime = req.StartTime.UnixNano() / int64(time.Millisecond)
+	}
+	if req.EndTime != nil {
+		endTime = req.EndTime.UnixNano() / int64(time.Millisecond)
+	}
+
+	// Cancel the call when we return so that a provider following the logs stops streaming them.
+	ctx, cancel := context.WithCancel(p.ctx.Request())
+	defer cancel()
+
+	// Errors from a streaming call may not surface until the first message is received, so both the call and each
+	// receive are checked for providers that predate GetLogs.
+	convertErr := func(err error) error {
+		rpcError := rpcerror.Convert(err)
+		if rpcError.Code() == codes.Unimplemented {
+			logging.V(7).Infof("%s unimplemented rpc: logs are not supported", label)
+			return ErrLogsNotSupported
+		}
+		logging.V(7).Infof("%s failed: %v", label, rpcError.Message())
+		return rpcError
+	}
+
+	stream, err := client.GetLogs(ctx, &pulumirpc.GetLogsRequest{
+		Urn:        string(req.URN),
+		Id:         string(req.ID),
+		Properties: mprops,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Follow:     req.Follow,
+	})
+	if err != nil {
+		return convertErr(err)
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return convertErr(err)
+		}
+
+		if err := onNext(LogEntry{
+			ID:        entry.GetId(),
+			Timestamp: entry.GetTimestamp(),
+			Message:   entry.GetMessage(),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// Call runs a method declared by a resource's schema against an existing instance of that resource.
+func (p *provider) Call(urn resource.URN, id resource.ID, props resource.PropertyMap, method string,
+	args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error) {
+
+	contract.Assert(urn != "")
+	contract.Assert(id != "")
+	contract.Assert(method != "")
+
+	label := fmt.Sprintf("%s.Call(%s, %s)", p.label(), urn, method)
+	logging.V(7).Infof("%s executing (#props=%d, #args=%d)", label, len(props), len(args))
+
+	mprops, err := MarshalProperties(props, MarshalOptions{
+		Label:              fmt.Sprintf("%s.properties", label),
+		ElideAssetContents: true,
+		KeepSecrets:        p.acceptSecrets,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	margs, err := MarshalProperties(args, MarshalOptions{
+		Label:       fmt.Sprintf("%s.args", label),
+		KeepSecrets: p.acceptSecrets,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get the RPC client and ensure it's configured.
+	client, err := p.getClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.Call(p.ctx.Request(), &pulumirpc.CallRequest{
+		Urn:        string(urn),
+		Id:         string(id),
+		Properties: mprops,
+		Method:     method,
+		Args:       margs,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		if rpcError.Code() == codes.Unimplemented {
+			logging.V(7).Infof("%s unimplemented rpc: methods are not supported", label)
+			return nil, nil, ErrCallNotSupported
+		}
+		logging.V(7).Infof("%s failed: %v", label, rpcError.Message())
+		return nil, nil, rpcError
+	}
+
+	// Unmarshal any return values.
+	ret, err := UnmarshalProperties(resp.GetReturn(), MarshalOptions{
+		Label:          fmt.Sprintf("%s.returns", label),
+		RejectUnknowns: true,
+		KeepSecrets:    true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// And now any arguments that failed verification.
+	var failures []CheckFailure
+	for _, failure := range resp.GetFailures() {
+		failures = append(failures, CheckFailure{resource.PropertyKey(failure.Property), failure.Reason})
+	}
+
+	logging.V(7).Infof("%s success (#ret=%d,#failures=%d)", label, len(ret), len(failures))
+	return ret, failures, nil
+}
+
 // GetPluginInfo returns this plugin's information.
 func (p *provider) GetPluginInfo() (workspace.PluginInfo, error) {
 	label := fmt.Sprintf("%s.GetPluginInfo()", p.label())
@@ -1111,7 +1329,7 @@ func (p *provider) GetPluginInfo() (workspace.PluginInfo, error) {
 
 	return workspace.PluginInfo{
 		Name:    string(p.pkg),
-		Path:    p.plug.Bin,
+		Path:    p.path,
 		Kind:    workspace.ResourcePlugin,
 		Version: version,
 	}, nil
@@ -1135,7 +1353,7 @@ func (p *provider) SignalCancellation() error {
 
 // Close tears down the underlying plugin RPC connection and process.
 func (p *provider) Close() error {
-	return p.plug.Close()
+	return p.conn.Close()
 }
 
 // createConfigureError creates a nice error message from an RPC error that
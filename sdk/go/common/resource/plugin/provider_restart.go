@@ -0,0 +1,223 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// maxProviderRestarts is the number of times a provider plugin whose process exits unexpectedly is restarted before
+// its failures are reported to the caller.
+const maxProviderRestarts = 3
+
+// providerExitTimeout is how long to wait for a provider plugin's process to exit after an RPC to the plugin fails
+// because its connection is unavailable. If the process exits within this time, the failure is attributed to the
+// process having crashed.
+var providerExitTimeout = 5 * time.Second
+
+const providerConfigureMethod = "/pulumirpc.ResourceProvider/Configure"
+
+// restartSafeProviderMethods holds the provider RPCs that may be sent again to a restarted plugin if the plugin exits
+// while they are in progress. These RPCs do not change the state of any resources, so sending them twice has the same
+// effect as sending them once. Create, Update, Delete, and Call are not retried, as they may have taken effect before
+// the plugin exited.
+var restartSafeProviderMethods = map[string]bool{
+	"/pulumirpc.ResourceProvider/GetSchema":     true,
+	"/pulumirpc.ResourceProvider/CheckConfig":   true,
+	"/pulumirpc.ResourceProvider/DiffConfig":    true,
+	providerConfigureMethod:                     true,
+	"/pulumirpc.ResourceProvider/Invoke":        true,
+	"/pulumirpc.ResourceProvider/Check":         true,
+	"/pulumirpc.ResourceProvider/Diff":          true,
+	"/pulumirpc.ResourceProvider/Read":          true,
+	"/pulumirpc.ResourceProvider/GetPluginInfo": true,
+}
+
+// providerProcess is a running provider plugin process and the RPC connection to it.
+type providerProcess struct {
+	conn   grpc.ClientConnInterface // the RPC connection to the plugin.
+	exited <-chan bool              // closed when the plugin's process exits.
+	close  func() error             // tears down the connection and the process.
+}
+
+// restartingConn is an RPC connection to a provider plugin that monitors the plugin's process and restarts it if the
+// process exits unexpectedly, e.g. because it was killed by the operating system for using too much memory. A
+// restarted plugin is sent the most recent Configure request that was sent to the plugin before it exited.
+//
+// A plugin whose process has exited is restarted before the next RPC is sent to it. If the process exits while an RPC
+// is in progress, the plugin is restarted and the RPC is sent again if it is one of restartSafeProviderMethods;
+// otherwise, the RPC fails with an Unavailable error, as it is not known whether or not it took effect. A plugin is
+// restarted at most maxRestarts times, after which the failures of its RPCs are returned to the caller.
+type restartingConn struct {
+	label       string                           // a label for the plugin, for logging and errors.
+	launch      func() (*providerProcess, error) // launches a new plugin process.
+	onRestart   func(restarts int)               // called after each restart, if non-nil.
+	maxRestarts int                              // the maximum number of restarts.
+
+	m         sync.Mutex
+	proc      *providerProcess            // the current plugin process.
+	gen       int                         // incremented each time the plugin is restarted.
+	restarts  int                         // the number of restarts so far.
+	closed    bool                        // true once the connection has been closed.
+	configure *pulumirpc.ConfigureRequest // the most recent Configure request, if any.
+}
+
+// newRestartingConn creates a new restarting connection to the given running plugin process.
+func newRestartingConn(label string, proc *providerProcess, launch func() (*providerProcess, error),
+	maxRestarts int) *restartingConn {
+
+	return &restartingConn{
+		label:       label,
+		launch:      launch,
+		maxRestarts: maxRestarts,
+		proc:        proc,
+	}
+}
+
+// Invoke sends a unary RPC to the plugin, restarting the plugin if its process has exited.
+func (c *restartingConn) Invoke(ctx context.Context, method string, args, reply interface{},
+	opts ...grpc.CallOption) error {
+
+	if req, ok := args.(*pulumirpc.ConfigureRequest); ok && method == providerConfigureMethod {
+		c.m.Lock()
+		c.configure = req
+		c.m.Unlock()
+	}
+
+	for {
+		proc, gen, err := c.process(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = proc.conn.Invoke(ctx, method, args, reply, opts...)
+		if err == nil || !proc.crashed(err) {
+			return err
+		}
+
+		logging.V(7).Infof("%s exited during %s", c.label, method)
+		if restartErr := c.restart(ctx, gen); restartErr != nil {
+			logging.V(7).Infof("%s could not be restarted: %v", c.label, restartErr)
+			return err
+		}
+		if !restartSafeProviderMethods[method] {
+			return status.Errorf(codes.Unavailable,
+				"%s exited while %s was in progress; the operation may or may not have completed", c.label, method)
+		}
+	}
+}
+
+// NewStream opens a streaming RPC to the plugin, restarting the plugin if its process has exited. Streams are not
+// resumed if the plugin exits while they are open.
+func (c *restartingConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string,
+	opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+	proc, _, err := c.process(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return proc.conn.NewStream(ctx, desc, method, opts...)
+}
+
+// Close tears down the connection and the plugin's process. The plugin is not restarted after it has been closed.
+func (c *restartingConn) Close() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.closed = true
+	return c.proc.close()
+}
+
+// process returns the current plugin process and its generation. If the process has exited, it is restarted first.
+func (c *restartingConn) process(ctx context.Context) (*providerProcess, int, error) {
+	c.m.Lock()
+	proc, gen := c.proc, c.gen
+	c.m.Unlock()
+
+	select {
+	case <-proc.exited:
+		logging.V(7).Infof("%s exited between RPCs", c.label)
+		if err := c.restart(ctx, gen); err != nil {
+			return nil, 0, status.Errorf(codes.Unavailable, "%s exited unexpectedly: %v", c.label, err)
+		}
+
+		c.m.Lock()
+		defer c.m.Unlock()
+		return c.proc, c.gen, nil
+	default:
+		return proc, gen, nil
+	}
+}
+
+// restart replaces the plugin process of the given generation with a new process and sends it the most recent
+// Configure request. If the process has already been replaced, restart does nothing.
+func (c *restartingConn) restart(ctx context.Context, gen int) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	switch {
+	case c.gen != gen:
+		return nil
+	case c.closed:
+		return errors.New("the plugin has been closed")
+	case c.restarts >= c.maxRestarts:
+		return errors.Errorf("the plugin has already been restarted %d times", c.restarts)
+	}
+
+	contract.IgnoreError(c.proc.close())
+	c.restarts++
+
+	proc, err := c.launch()
+	if err != nil {
+		return errors.Wrap(err, "relaunching the plugin")
+	}
+	if c.configure != nil {
+		if err = proc.conn.Invoke(ctx, providerConfigureMethod, c.configure, &pulumirpc.ConfigureResponse{}); err != nil {
+			contract.IgnoreError(proc.close())
+			return errors.Wrap(err, "reconfiguring the plugin")
+		}
+	}
+
+	c.proc, c.gen = proc, c.gen+1
+	logging.V(7).Infof("%s restarted (%d of %d)", c.label, c.restarts, c.maxRestarts)
+	if c.onRestart != nil {
+		c.onRestart(c.restarts)
+	}
+	return nil
+}
+
+// crashed returns true if the given RPC error was caused by the plugin's process exiting.
+func (p *providerProcess) crashed(err error) bool {
+	if status.Code(err) != codes.Unavailable {
+		return false
+	}
+	select {
+	case <-p.exited:
+		return true
+	case <-time.After(providerExitTimeout):
+		return false
+	}
+}
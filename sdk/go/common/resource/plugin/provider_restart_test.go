@@ -0,0 +1,178 @@
+package plugin
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
+)
+
+// fakeProviderProcess is an in-process provider plugin "process" whose crash may be simulated.
+type fakeProviderProcess struct {
+	pulumirpc.UnimplementedResourceProviderServer
+
+	server    *grpc.Server
+	exited    chan bool
+	crashOnce sync.Once
+
+	m          sync.Mutex
+	configured bool
+	crashOn    string // the name of an RPC that crashes the process.
+}
+
+func (p *fakeProviderProcess) crash() {
+	p.crashOnce.Do(func() {
+		close(p.exited)
+		go p.server.Stop()
+	})
+}
+
+func (p *fakeProviderProcess) setCrashOn(method string) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.crashOn = method
+}
+
+func (p *fakeProviderProcess) isConfigured() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.configured
+}
+
+func (p *fakeProviderProcess) handle(ctx context.Context, method string) error {
+	p.m.Lock()
+	crash := p.crashOn == method
+	p.m.Unlock()
+	if crash {
+		p.crash()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (p *fakeProviderProcess) Configure(ctx context.Context,
+	req *pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+
+	p.m.Lock()
+	p.configured = true
+	p.m.Unlock()
+	return &pulumirpc.ConfigureResponse{}, nil
+}
+
+func (p *fakeProviderProcess) Check(ctx context.Context,
+	req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+
+	if err := p.handle(ctx, "Check"); err != nil {
+		return nil, err
+	}
+	p.m.Lock()
+	defer p.m.Unlock()
+	if !p.configured {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return &pulumirpc.CheckResponse{Inputs: req.News}, nil
+}
+
+func (p *fakeProviderProcess) Create(ctx context.Context,
+	req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
+
+	if err := p.handle(ctx, "Create"); err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CreateResponse{Id: "id"}, nil
+}
+
+func (p *fakeProviderProcess) Cancel(context.Context, *pbempty.Empty) (*pbempty.Empty, error) {
+	return &pbempty.Empty{}, nil
+}
+
+// newFakeProviderLauncher returns a function that launches fake provider processes, along with the list of processes
+// that it has launched.
+func newFakeProviderLauncher() (func() (*providerProcess, error), *[]*fakeProviderProcess) {
+	var processes []*fakeProviderProcess
+	launch := func() (*providerProcess, error) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+		fake := &fakeProviderProcess{server: grpc.NewServer(), exited: make(chan bool)}
+		pulumirpc.RegisterResourceProviderServer(fake.server, fake)
+		go func() {
+			contract.IgnoreError(fake.server.Serve(listener))
+		}()
+
+		conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+		if err != nil {
+			return nil, err
+		}
+		processes = append(processes, fake)
+		return &providerProcess{
+			conn:   conn,
+			exited: fake.exited,
+			close: func() error {
+				fake.crash()
+				return conn.Close()
+			},
+		}, nil
+	}
+	return launch, &processes
+}
+
+func TestRestartingConn(t *testing.T) {
+	launch, processes := newFakeProviderLauncher()
+	proc, err := launch()
+	assert.NoError(t, err)
+
+	var restarts []int
+	conn := newRestartingConn("test plugin", proc, launch, 3)
+	conn.onRestart = func(n int) { restarts = append(restarts, n) }
+	client := pulumirpc.NewResourceProviderClient(conn)
+	ctx := context.Background()
+
+	_, err = client.Configure(ctx, &pulumirpc.ConfigureRequest{})
+	assert.NoError(t, err)
+	_, err = client.Check(ctx, &pulumirpc.CheckRequest{})
+	assert.NoError(t, err)
+
+	// A plugin that exits between RPCs is restarted and reconfigured before the next RPC.
+	(*processes)[0].crash()
+	_, err = client.Check(ctx, &pulumirpc.CheckRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, *processes, 2)
+	assert.True(t, (*processes)[1].isConfigured())
+
+	// A plugin that exits during an RPC that is safe to retry is restarted, and the RPC is sent again.
+	(*processes)[1].setCrashOn("Check")
+	_, err = client.Check(ctx, &pulumirpc.CheckRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, *processes, 3)
+
+	// A plugin that exits during an RPC that is not safe to retry is restarted, but the RPC fails.
+	(*processes)[2].setCrashOn("Create")
+	_, err = client.Create(ctx, &pulumirpc.CreateRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, err.Error(), "may or may not have completed")
+	assert.Len(t, *processes, 4)
+	_, err = client.Create(ctx, &pulumirpc.CreateRequest{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 3}, restarts)
+
+	// Once the plugin has been restarted the maximum number of times, it is not restarted again.
+	(*processes)[3].crash()
+	_, err = client.Check(ctx, &pulumirpc.CheckRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Len(t, *processes, 4)
+
+	assert.NoError(t, conn.Close())
+}
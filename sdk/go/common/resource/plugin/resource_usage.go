@@ -0,0 +1,210 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// PluginMemoryLimitEnvVar limits the memory that each plugin may use, e.g. "512MB". A plugin whose processes use more
+// memory than this is terminated.
+const PluginMemoryLimitEnvVar = "PULUMI_PLUGIN_MEMORY_LIMIT"
+
+// memoryLimitInterval is how often plugins are sampled to enforce the memory limit when resource usage sampling is
+// not otherwise enabled.
+var memoryLimitInterval = time.Second
+
+// PluginResourceUsage is the resource usage of a single plugin, sampled periodically while the plugin runs. The usage
+// of a plugin includes the usage of any child processes it starts.
+type PluginResourceUsage struct {
+	// Name is the name of the plugin, e.g. "aws (resource)".
+	Name string `json:"name"`
+	// PID is the process ID of the plugin.
+	PID int `json:"pid"`
+	// Start is the time at which the plugin was launched.
+	Start time.Time `json:"start"`
+	// Samples is the number of times the plugin's usage was sampled.
+	Samples int `json:"samples"`
+	// CPUTime is the total user and system CPU time used by the plugin as of the last sample.
+	CPUTime time.Duration `json:"cpuTime"`
+	// PeakMemory is the largest resident set size of the plugin over all samples, in bytes.
+	PeakMemory uint64 `json:"peakMemory"`
+	// MemoryLimitExceeded is true if the plugin was terminated for exceeding the plugin memory limit.
+	MemoryLimitExceeded bool `json:"memoryLimitExceeded,omitempty"`
+}
+
+// processUsage is a single sample of the resource usage of a process and its descendants.
+type processUsage struct {
+	cpuTime time.Duration // the total user and system CPU time used.
+	memory  uint64        // the total resident set size, in bytes.
+}
+
+// resourceUsage records the usage of each plugin launched while sampling is enabled.
+var resourceUsage struct {
+	m        sync.Mutex
+	interval time.Duration
+	plugins  []*PluginResourceUsage
+}
+
+// SampleResourceUsage enables sampling of the CPU and memory usage of the plugins that are launched after it is called
+// at the given interval. An interval of zero disables sampling. Sampling is only supported on Linux; elsewhere, no
+// usage is recorded.
+func SampleResourceUsage(interval time.Duration) {
+	resourceUsage.m.Lock()
+	defer resourceUsage.m.Unlock()
+	resourceUsage.interval = interval
+}
+
+// ResourceUsage returns the sampled resource usage of each plugin launched while sampling was enabled, in the order in
+// which the plugins were launched.
+func ResourceUsage() []PluginResourceUsage {
+	resourceUsage.m.Lock()
+	defer resourceUsage.m.Unlock()
+
+	result := make([]PluginResourceUsage, len(resourceUsage.plugins))
+	for i, usage := range resourceUsage.plugins {
+		result[i] = *usage
+	}
+	return result
+}
+
+// GetPluginMemoryLimit returns the memory limit for plugins configured by the environment in bytes, or zero if plugin
+// memory is not limited.
+func GetPluginMemoryLimit() (uint64, error) {
+	limit := os.Getenv(PluginMemoryLimitEnvVar)
+	if limit == "" {
+		return 0, nil
+	}
+	bytes, err := humanize.ParseBytes(limit)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s %q", PluginMemoryLimitEnvVar, limit)
+	}
+	if !resourceUsageSupported {
+		return 0, errors.Errorf("plugin memory limits are only supported on Linux; unset %s to run plugins "+
+			"without them", PluginMemoryLimitEnvVar)
+	}
+	return bytes, nil
+}
+
+// resourceMonitor samples the resource usage of a plugin's process.
+type resourceMonitor struct {
+	ctx         *Context
+	proc        *os.Process
+	memoryLimit uint64
+
+	usage   *PluginResourceUsage // the usage of the plugin, recorded under resourceUsage.m.
+	done    chan struct{}        // closed to stop sampling.
+	stopped chan struct{}        // closed once sampling has stopped.
+}
+
+// monitorResourceUsage starts sampling the resource usage of the given plugin process and enforcing the given memory
+// limit, if it is non-zero. It returns a function that takes a final sample and stops sampling.
+func monitorResourceUsage(ctx *Context, name string, proc *os.Process, memoryLimit uint64) func() {
+	resourceUsage.m.Lock()
+	interval := resourceUsage.interval
+	resourceUsage.m.Unlock()
+
+	// Sample the plugin if its usage is being recorded or if it needs to be kept within the memory limit.
+	record := interval != 0 && resourceUsageSupported
+	if !record {
+		if memoryLimit == 0 {
+			return func() {}
+		}
+		interval = memoryLimitInterval
+	}
+
+	m := &resourceMonitor{
+		ctx:         ctx,
+		proc:        proc,
+		memoryLimit: memoryLimit,
+		usage:       &PluginResourceUsage{Name: name, PID: proc.Pid, Start: time.Now()},
+		done:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	if record {
+		resourceUsage.m.Lock()
+		resourceUsage.plugins = append(resourceUsage.plugins, m.usage)
+		resourceUsage.m.Unlock()
+	}
+
+	go m.run(interval)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(m.done)
+			<-m.stopped
+		})
+	}
+}
+
+// run samples the plugin at the given interval until the plugin exits or sampling is stopped.
+func (m *resourceMonitor) run(interval time.Duration) {
+	defer close(m.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for m.sample() {
+		select {
+		case <-m.done:
+			m.sample()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample records the current resource usage of the plugin and terminates the plugin if it is using more memory than
+// it is allowed. It returns false if the plugin is no longer running.
+func (m *resourceMonitor) sample() bool {
+	usage, err := sampleProcessTree(m.proc.Pid)
+	if err != nil {
+		logging.V(7).Infof("no longer sampling plugin %s: %v", m.usage.Name, err)
+		return false
+	}
+
+	resourceUsage.m.Lock()
+	m.usage.Samples++
+	if usage.cpuTime > m.usage.CPUTime {
+		m.usage.CPUTime = usage.cpuTime
+	}
+	if usage.memory > m.usage.PeakMemory {
+		m.usage.PeakMemory = usage.memory
+	}
+	exceeded := m.memoryLimit != 0 && usage.memory > m.memoryLimit
+	m.usage.MemoryLimitExceeded = m.usage.MemoryLimitExceeded || exceeded
+	resourceUsage.m.Unlock()
+
+	if !exceeded {
+		return true
+	}
+
+	m.ctx.Diag.Warningf(diag.Message("", /*urn*/
+		"plugin %s is using %s of memory, more than the limit of %s set by %s; terminating it"),
+		m.usage.Name, humanize.Bytes(usage.memory), humanize.Bytes(m.memoryLimit), PluginMemoryLimitEnvVar)
+	contract.IgnoreError(cmdutil.KillChildren(m.proc.Pid))
+	contract.IgnoreError(m.proc.Kill())
+	return false
+}
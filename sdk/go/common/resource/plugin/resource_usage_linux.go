@@ -0,0 +1,122 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package plugin
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// resourceUsageSupported is true if plugins' resource usage can be sampled on this platform.
+const resourceUsageSupported = true
+
+// clockTicksPerSecond is the unit of the CPU times reported by /proc. This is USER_HZ, which is 100 on all of the
+// architectures that Linux supports.
+const clockTicksPerSecond = 100
+
+// procStat holds the fields of /proc/[pid]/stat that are used to sample a process's resource usage.
+type procStat struct {
+	state   byte   // the state of the process, e.g. 'R' or 'Z'.
+	ppid    int    // the ID of the process's parent.
+	cpuTime uint64 // the user and system CPU time used by the process, in clock ticks.
+	rss     uint64 // the resident set size of the process, in pages.
+}
+
+// sampleProcessTree returns the resource usage of the given process and all of its descendants. An error is returned
+// if the process is no longer running.
+func sampleProcessTree(pid int) (processUsage, error) {
+	dir, err := os.Open("/proc")
+	if err != nil {
+		return processUsage{}, err
+	}
+	names, err := dir.Readdirnames(-1)
+	contract.IgnoreClose(dir)
+	if err != nil {
+		return processUsage{}, err
+	}
+
+	stats, children := map[int]procStat{}, map[int][]int{}
+	for _, name := range names {
+		id, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		// Processes may exit while /proc is being read. These are skipped.
+		stat, err := readProcStat(id)
+		if err != nil {
+			continue
+		}
+		stats[id] = stat
+		children[stat.ppid] = append(children[stat.ppid], id)
+	}
+
+	if root, ok := stats[pid]; !ok || root.state == 'Z' || root.state == 'X' {
+		return processUsage{}, errors.Errorf("process %d has exited", pid)
+	}
+
+	var ticks, pages uint64
+	for queue := []int{pid}; len(queue) > 0; queue = queue[1:] {
+		stat := stats[queue[0]]
+		ticks, pages = ticks+stat.cpuTime, pages+stat.rss
+		queue = append(queue, children[queue[0]]...)
+	}
+	return processUsage{
+		cpuTime: time.Duration(ticks) * time.Second / clockTicksPerSecond,
+		memory:  pages * uint64(os.Getpagesize()),
+	}, nil
+}
+
+// readProcStat reads the status of the given process from /proc/[pid]/stat. See proc(5) for the file's format.
+func readProcStat(pid int) (procStat, error) {
+	data, err := ioutil.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return procStat{}, err
+	}
+
+	// The second field is the process's name in parentheses, which may itself contain spaces and parentheses, so the
+	// remaining fields are found after the last closing parenthesis. They begin with the third field, the state.
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return procStat{}, errors.Errorf("malformed /proc/%d/stat", pid)
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 22 || len(fields[0]) != 1 {
+		return procStat{}, errors.Errorf("malformed /proc/%d/stat", pid)
+	}
+
+	// The fields that are used are ppid (4), utime (14), stime (15), and rss (24).
+	var values [4]uint64
+	for i, field := range []int{4, 14, 15, 24} {
+		if values[i], err = strconv.ParseUint(fields[field-3], 10, 64); err != nil {
+			return procStat{}, errors.Wrapf(err, "malformed /proc/%d/stat", pid)
+		}
+	}
+	return procStat{
+		state:   fields[0][0],
+		ppid:    int(values[0]),
+		cpuTime: values[1] + values[2],
+		rss:     values[3],
+	}, nil
+}
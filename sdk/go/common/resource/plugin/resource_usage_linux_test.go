@@ -0,0 +1,81 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
+)
+
+func TestSampleProcessTree(t *testing.T) {
+	usage, err := sampleProcessTree(os.Getpid())
+	require.NoError(t, err)
+	assert.NotZero(t, usage.memory)
+
+	_, err = sampleProcessTree(-1)
+	assert.Error(t, err)
+}
+
+func TestMonitorResourceUsage(t *testing.T) {
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	ctx := &Context{Diag: sink}
+
+	SampleResourceUsage(10 * time.Millisecond)
+	defer SampleResourceUsage(0)
+
+	// A plugin that stays within the memory limit is sampled until it is stopped.
+	cmd := exec.Command("sleep", "10")
+	require.NoError(t, cmd.Start())
+	defer func() { _ = cmd.Process.Kill() }()
+
+	stop := monitorResourceUsage(ctx, "sleep (resource)", cmd.Process, 1<<40)
+	time.Sleep(50 * time.Millisecond)
+	stop()
+	stop()
+
+	usages := ResourceUsage()
+	require.NotEmpty(t, usages)
+	usage := usages[len(usages)-1]
+	assert.Equal(t, "sleep (resource)", usage.Name)
+	assert.Equal(t, cmd.Process.Pid, usage.PID)
+	assert.True(t, usage.Samples >= 2)
+	assert.NotZero(t, usage.PeakMemory)
+	assert.False(t, usage.MemoryLimitExceeded)
+
+	// A plugin that exceeds the memory limit is terminated.
+	cmd = exec.Command("sleep", "10")
+	require.NoError(t, cmd.Start())
+	defer func() { _ = cmd.Process.Kill() }()
+
+	stop = monitorResourceUsage(ctx, "sleep (resource)", cmd.Process, 1)
+	assert.Error(t, cmd.Wait())
+	stop()
+
+	usages = ResourceUsage()
+	usage = usages[len(usages)-1]
+	assert.Equal(t, cmd.Process.Pid, usage.PID)
+	assert.True(t, usage.MemoryLimitExceeded)
+}
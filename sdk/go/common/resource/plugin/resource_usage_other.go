@@ -0,0 +1,30 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !linux
+
+package plugin
+
+import (
+	"github.com/pkg/errors"
+)
+
+// resourceUsageSupported is true if plugins' resource usage can be sampled on this platform.
+const resourceUsageSupported = false
+
+// sampleProcessTree returns the resource usage of the given process and all of its descendants. Sampling is not
+// supported on this platform.
+func sampleProcessTree(pid int) (processUsage, error) {
+	return processUsage{}, errors.New("sampling plugin resource usage is only supported on Linux")
+}
@@ -37,6 +37,8 @@ type MarshalOptions struct {
 	KeepSecrets        bool   // true if we are keeping secrets (otherwise we replace them with their underlying value).
 	RejectAssets       bool   // true if we should return errors on Asset and Archive values.
 	SkipInternalKeys   bool   // true to skip internal property keys (keys that start with "__") in the resulting map.
+	KeepDecimals       bool   // true if we are keeping decimals (otherwise we replace them with the nearest double).
+	KeepBytes          bool   // true if we are keeping byte strings (otherwise we replace them with base64 strings).
 }
 
 const (
@@ -99,6 +101,9 @@ func MarshalPropertyValue(v resource.PropertyValue, opts MarshalOptions) (*struc
 				BoolValue: v.BoolValue(),
 			},
 		}, nil
+	} else if v.IsDecimal() && opts.KeepDecimals {
+		decimal := resource.NewObjectProperty(resource.NewPropertyMapFromMap(v.DecimalValue().Serialize()))
+		return MarshalPropertyValue(decimal, opts)
 	} else if v.IsNumber() {
 		return &structpb.Value{
 			Kind: &structpb.Value_NumberValue{
@@ -107,6 +112,12 @@ func MarshalPropertyValue(v resource.PropertyValue, opts MarshalOptions) (*struc
 		}, nil
 	} else if v.IsString() {
 		return MarshalString(v.StringValue(), opts), nil
+	} else if v.IsBytes() {
+		if !opts.KeepBytes {
+			return MarshalString(v.BytesValue().Base64(), opts), nil
+		}
+		bytes := resource.NewObjectProperty(resource.NewPropertyMapFromMap(v.BytesValue().Serialize()))
+		return MarshalPropertyValue(bytes, opts)
 	} else if v.IsArray() {
 		var elems []*structpb.Value
 		for _, elem := range v.ArrayValue() {
@@ -345,6 +356,30 @@ func UnmarshalPropertyValue(v *structpb.Value, opts MarshalOptions) (*resource.P
 			}
 			s := resource.MakeSecret(value)
 			return &s, nil
+		case resource.BytesSig:
+			b, isbytes, err := resource.DeserializeBytes(objmap)
+			if err != nil {
+				return nil, err
+			}
+			contract.Assert(isbytes)
+			if !opts.KeepBytes {
+				m := resource.NewStringProperty(b.Base64())
+				return &m, nil
+			}
+			m := resource.NewBytesProperty(b)
+			return &m, nil
+		case resource.DecimalSig:
+			d, isdecimal, err := resource.DeserializeDecimal(objmap)
+			if err != nil {
+				return nil, err
+			}
+			contract.Assert(isdecimal)
+			if !opts.KeepDecimals {
+				m := resource.NewNumberProperty(d.Float64())
+				return &m, nil
+			}
+			m := resource.NewDecimalProperty(d)
+			return &m, nil
 		default:
 			return nil, errors.Errorf("unrecognized signature '%v' in property map", sig)
 		}
@@ -251,6 +251,49 @@ func TestSupportedSecret(t *testing.T) {
 	assert.Equal(t, "foo", val.SecretValue().Element.StringValue())
 }
 
+func TestDecimalSerialize(t *testing.T) {
+	d := resource.NewDecimalProperty("12345678901234567890.5")
+
+	prop, err := MarshalPropertyValue(d, MarshalOptions{KeepDecimals: true})
+	assert.NoError(t, err)
+	val, err := UnmarshalPropertyValue(prop, MarshalOptions{KeepDecimals: true})
+	assert.NoError(t, err)
+	assert.True(t, val.IsDecimal())
+	assert.Equal(t, d.DecimalValue(), val.DecimalValue())
+
+	// Decimals are received as doubles by clients that do not keep them.
+	val, err = UnmarshalPropertyValue(prop, MarshalOptions{})
+	assert.NoError(t, err)
+	assert.False(t, val.IsDecimal())
+	assert.Equal(t, 12345678901234567890.5, val.NumberValue())
+
+	// Decimals are sent as doubles to clients that do not keep them.
+	prop, err = MarshalPropertyValue(d, MarshalOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 12345678901234567890.5, prop.GetNumberValue())
+}
+
+func TestBytesSerialize(t *testing.T) {
+	b := resource.NewBytesProperty(resource.Bytes{0xde, 0xad, 0xbe, 0xef})
+
+	prop, err := MarshalPropertyValue(b, MarshalOptions{KeepBytes: true})
+	assert.NoError(t, err)
+	val, err := UnmarshalPropertyValue(prop, MarshalOptions{KeepBytes: true})
+	assert.NoError(t, err)
+	assert.True(t, val.IsBytes())
+	assert.Equal(t, b.BytesValue(), val.BytesValue())
+
+	// Byte strings are received as base64 strings by clients that do not keep them.
+	val, err = UnmarshalPropertyValue(prop, MarshalOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "3q2+7w==", val.StringValue())
+
+	// Byte strings are sent as base64 strings to clients that do not keep them.
+	prop, err = MarshalPropertyValue(b, MarshalOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "3q2+7w==", prop.GetStringValue())
+}
+
 func TestUnknownSig(t *testing.T) {
 	rawProp := resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]interface{}{
 		resource.SigKey: "foobar",
@@ -0,0 +1,202 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/workspace"
+)
+
+// The environment variables that configure the sandbox in which analyzer and provider plugins run.
+const (
+	// PluginSandboxEnvVar enables the sandbox when truthy.
+	PluginSandboxEnvVar = "PULUMI_PLUGIN_SANDBOX"
+	// PluginSandboxEnvEnvVar is a comma-separated list of additional environment variables to pass to plugins.
+	PluginSandboxEnvEnvVar = "PULUMI_PLUGIN_SANDBOX_ENV"
+	// PluginSandboxWritableEnvVar is a list of paths, separated like $PATH, that plugins may write to.
+	PluginSandboxWritableEnvVar = "PULUMI_PLUGIN_SANDBOX_WRITABLE"
+	// PluginSandboxNetworkEnvVar is a comma-separated list of the names of the plugins that may access the network.
+	PluginSandboxNetworkEnvVar = "PULUMI_PLUGIN_SANDBOX_NETWORK"
+)
+
+// sandboxBaseEnv are the environment variables that every sandboxed plugin receives, since most programs can't run
+// without them.
+var sandboxBaseEnv = []string{"PATH", "HOME", "USER", "LANG", "LC_ALL", "TMPDIR", "TEMP", "TMP", "SYSTEMROOT"}
+
+// sandboxSecretEnv are the Pulumi environment variables that hold credentials for the CLI itself. They are never
+// passed to sandboxed plugins, even though other PULUMI_ variables are.
+var sandboxSecretEnv = []string{"PULUMI_ACCESS_TOKEN", "PULUMI_CONFIG_PASSPHRASE", "PULUMI_CONFIG_PASSPHRASE_FILE"}
+
+// PluginSandbox restricts what analyzer and provider plugins can see and change, so that third-party plugins can be
+// run with less risk on shared machines. Sandboxed plugins receive only a minimal environment, and on Linux they run
+// under bubblewrap (bwrap) with a read-only view of the file system, a private /tmp, and, unless they are declared to
+// need it, no network access.
+//
+// The Pulumi home directory (~/.pulumi by default), which holds the CLI's credentials and local stack state, is
+// hidden from sandboxed plugins apart from its plugins and bin directories. Other credential files, e.g.
+// ~/.aws/credentials or ~/.kube/config, remain readable, since providers rely on them.
+//
+// A plugin without network access runs in its own network namespace, and a relay forwards its gRPC connections to
+// and from the engine (see sandboxRelay). The relay runs the executable that hosts the engine with the
+// SandboxRelayCommand subcommand, so that executable must be the Pulumi CLI; plugins that run in a container are
+// not cut off from the network.
+type PluginSandbox struct {
+	// Env lists the names of the environment variables that plugins receive in addition to a minimal base set.
+	Env []string
+	// Writable lists the paths that plugins may write to.
+	Writable []string
+	// Network is true if the plugin may access the network.
+	Network bool
+}
+
+// GetPluginSandbox returns the sandbox configured by the environment for the plugin with the given name, or nil if
+// plugins are not sandboxed.
+func GetPluginSandbox(name string) *PluginSandbox {
+	if !cmdutil.IsTruthy(os.Getenv(PluginSandboxEnvVar)) {
+		return nil
+	}
+
+	sandbox := &PluginSandbox{}
+	for _, name := range strings.Split(os.Getenv(PluginSandboxEnvEnvVar), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			sandbox.Env = append(sandbox.Env, name)
+		}
+	}
+	for _, path := range filepath.SplitList(os.Getenv(PluginSandboxWritableEnvVar)) {
+		if path != "" {
+			sandbox.Writable = append(sandbox.Writable, path)
+		}
+	}
+	for _, plugin := range strings.Split(os.Getenv(PluginSandboxNetworkEnvVar), ",") {
+		if strings.TrimSpace(plugin) == name {
+			sandbox.Network = true
+		}
+	}
+	return sandbox
+}
+
+// FilterEnv returns the subset of the given environment that a sandboxed plugin may see. A nil environment stands
+// for the current process's environment.
+func (sandbox *PluginSandbox) FilterEnv(env []string) []string {
+	if env == nil {
+		env = os.Environ()
+	}
+
+	allowed := make(map[string]bool)
+	for _, name := range append(sandboxBaseEnv, sandbox.Env...) {
+		allowed[name] = true
+	}
+	secret := make(map[string]bool)
+	for _, name := range sandboxSecretEnv {
+		secret[name] = true
+	}
+
+	result := []string{}
+	for _, kv := range env {
+		name := kv
+		if eq := strings.Index(kv, "="); eq >= 0 {
+			name = kv[:eq]
+		}
+		if allowed[name] || strings.HasPrefix(name, "PULUMI_") && !secret[name] {
+			result = append(result, kv)
+		}
+	}
+	return result
+}
+
+// Command returns the program and arguments that run the given plugin inside the sandbox. If the plugin may not
+// access the network, the given relay connects it to the engine.
+func (sandbox *PluginSandbox) Command(bin string, args []string, relay *sandboxRelay) (string, []string, error) {
+	if runtime.GOOS != "linux" {
+		return "", nil, errors.Errorf("plugin sandboxing is only supported on Linux; unset %s to run plugins "+
+			"without it", PluginSandboxEnvVar)
+	}
+	bwrap, err := exec.LookPath("bwrap")
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "plugin sandboxing requires bubblewrap (bwrap) on the $PATH")
+	}
+
+	sandboxArgs := []string{
+		"--ro-bind", "/", "/",
+		"--dev", "/dev",
+		"--proc", "/proc",
+		"--tmpfs", "/tmp",
+		"--die-with-parent",
+	}
+	masks, err := pulumiHomeMasks()
+	if err != nil {
+		return "", nil, err
+	}
+	sandboxArgs = append(sandboxArgs, masks...)
+	for _, path := range sandbox.Writable {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "resolving writable sandbox path %q", path)
+		}
+		sandboxArgs = append(sandboxArgs, "--bind", abs, abs)
+	}
+	if relay == nil {
+		sandboxArgs = append(sandboxArgs, "--", bin)
+	} else {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", nil, errors.Wrap(err, "locating the plugin sandbox relay")
+		}
+		// Bind the relay's executable explicitly in case it lives beneath /tmp or the Pulumi home directory.
+		sandboxArgs = append(sandboxArgs,
+			"--unshare-net",
+			"--ro-bind", exe, exe,
+			"--bind", relay.dir, relay.dir,
+			"--", exe, SandboxRelayCommand, relay.dir, relay.engineAddr, bin)
+	}
+
+	logging.V(7).Infof("sandboxing plugin %s with writable paths %v and network access %v",
+		bin, sandbox.Writable, sandbox.Network)
+	return bwrap, append(sandboxArgs, args...), nil
+}
+
+// pulumiHomeMasks returns the bubblewrap arguments that hide the Pulumi home directory from a sandboxed plugin. The
+// directory's plugins and bin subdirectories, which hold the plugins themselves, stay visible.
+func pulumiHomeMasks() ([]string, error) {
+	home, err := workspace.GetPulumiHomeDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "locating the Pulumi home directory")
+	}
+	if home, err = filepath.Abs(home); err != nil {
+		return nil, errors.Wrap(err, "locating the Pulumi home directory")
+	}
+	if _, err := os.Stat(home); err != nil {
+		// There is nothing to hide.
+		return nil, nil
+	}
+
+	args := []string{"--tmpfs", home}
+	for _, dir := range []string{workspace.PluginDir, "bin"} {
+		path := filepath.Join(home, dir)
+		if _, err := os.Stat(path); err == nil {
+			args = append(args, "--ro-bind", path, path)
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// SandboxRelayCommand is the hidden CLI subcommand that runs the plugin's half of a sandbox relay. The sandbox runs
+// the executable that hosts the engine with this subcommand, followed by the arguments that RunSandboxRelay expects.
+const SandboxRelayCommand = "__sandbox-relay"
+
+// The names of the sockets through which the engine and a sandboxed plugin reach one another.
+const (
+	// sandboxEngineSocket accepts the plugin's connections to the engine.
+	sandboxEngineSocket = "engine.sock"
+	// sandboxPluginSocket accepts the engine's connections to the plugin.
+	sandboxPluginSocket = "plugin.sock"
+)
+
+// sandboxRelay connects the engine to a plugin that runs in its own network namespace. Plugins and the engine talk
+// gRPC over loopback TCP, but a plugin without network access has a loopback interface of its own. Inside the
+// sandbox, a relay process listens on the engine's address and starts the plugin; the two halves of the relay then
+// forward connections in each direction over Unix domain sockets in a directory that is shared with the sandbox.
+type sandboxRelay struct {
+	dir        string       // the directory that holds the relay's sockets.
+	engineAddr string       // the address of the engine's gRPC server.
+	listener   net.Listener // accepts the plugin's connections to the engine.
+}
+
+// newSandboxRelay creates the engine's half of the relay for a plugin that connects to the engine at the given
+// address.
+func newSandboxRelay(engineAddr string) (*sandboxRelay, error) {
+	dir, err := ioutil.TempDir("", "pulumi-sandbox")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating plugin sandbox relay directory")
+	}
+	listener, err := net.Listen("unix", filepath.Join(dir, sandboxEngineSocket))
+	if err != nil {
+		contract.IgnoreError(os.RemoveAll(dir))
+		return nil, errors.Wrap(err, "listening for plugin sandbox connections")
+	}
+
+	relay := &sandboxRelay{dir: dir, engineAddr: engineAddr, listener: listener}
+	go relay.serve()
+	return relay, nil
+}
+
+// serve forwards the plugin's connections to the engine until the relay is closed.
+func (relay *sandboxRelay) serve() {
+	for {
+		conn, err := relay.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			engine, err := net.Dial("tcp", relay.engineAddr)
+			if err != nil {
+				logging.V(5).Infof("sandboxed plugin could not reach the engine at %s: %v", relay.engineAddr, err)
+				contract.IgnoreClose(conn)
+				return
+			}
+			relayConns(conn, engine)
+		}()
+	}
+}
+
+// dial connects to the given loopback address inside the plugin's sandbox. It is suitable for use as a gRPC dialer.
+func (relay *sandboxRelay) dial(ctx context.Context, addr string) (net.Conn, error) {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", filepath.Join(relay.dir, sandboxPluginSocket))
+	if err != nil {
+		return nil, err
+	}
+	// The first line of each connection tells the plugin's half of the relay which port to connect to.
+	if _, err = fmt.Fprintf(conn, "%s\n", port); err != nil {
+		contract.IgnoreClose(conn)
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Close stops relaying connections and removes the relay's sockets.
+func (relay *sandboxRelay) Close() error {
+	contract.IgnoreClose(relay.listener)
+	return os.RemoveAll(relay.dir)
+}
+
+// RunSandboxRelay runs the plugin's half of a sandbox relay inside the sandbox. The arguments are the directory that
+// holds the relay's sockets, the engine's address, and the plugin's command line. It returns the plugin's exit code.
+func RunSandboxRelay(args []string) int {
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "error: expected a relay directory\n")
+		return 1
+	}
+	return runSandboxRelay(args[0], args[1:])
+}
+
+// sandboxRelayServer is the plugin's half of a sandbox relay.
+type sandboxRelayServer struct {
+	engine net.Listener // accepts the plugin's connections to the engine.
+	plugin net.Listener // accepts the engine's connections to the plugin.
+}
+
+// serveSandboxRelay starts the plugin's half of the relay whose sockets are in the given directory. Connections to
+// the given address are forwarded to the engine.
+func serveSandboxRelay(dir, engineAddr string) (*sandboxRelayServer, error) {
+	engine, err := net.Listen("tcp", engineAddr)
+	if err != nil {
+		return nil, err
+	}
+	plugin, err := net.Listen("unix", filepath.Join(dir, sandboxPluginSocket))
+	if err != nil {
+		contract.IgnoreClose(engine)
+		return nil, err
+	}
+
+	server := &sandboxRelayServer{engine: engine, plugin: plugin}
+	go server.serve(engine, func(net.Conn) (net.Conn, error) {
+		return net.Dial("unix", filepath.Join(dir, sandboxEngineSocket))
+	})
+	go server.serve(plugin, func(conn net.Conn) (net.Conn, error) {
+		port, err := readRelayPort(conn)
+		if err != nil {
+			return nil, err
+		}
+		return net.Dial("tcp", net.JoinHostPort("127.0.0.1", port))
+	})
+	return server, nil
+}
+
+// serve forwards each connection accepted by the given listener to the connection returned by dial.
+func (server *sandboxRelayServer) serve(listener net.Listener, dial func(conn net.Conn) (net.Conn, error)) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			target, err := dial(conn)
+			if err != nil {
+				contract.IgnoreClose(conn)
+				return
+			}
+			relayConns(conn, target)
+		}()
+	}
+}
+
+// Close stops relaying connections.
+func (server *sandboxRelayServer) Close() error {
+	contract.IgnoreClose(server.engine)
+	return server.plugin.Close()
+}
+
+// readRelayPort reads the port number that starts a connection to the plugin's half of the relay. It reads a byte at
+// a time so that nothing after the newline is consumed.
+func readRelayPort(conn net.Conn) (string, error) {
+	var port string
+	b := make([]byte, 1)
+	for {
+		if _, err := conn.Read(b); err != nil {
+			return "", err
+		}
+		if b[0] == '\n' {
+			break
+		}
+		port += string(b)
+	}
+	if _, err := strconv.Atoi(port); err != nil {
+		return "", errors.Errorf("invalid relay port %q", port)
+	}
+	return port, nil
+}
+
+// relayConns copies data between the two connections until either of them is closed, and then closes both.
+func relayConns(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	forward := func(dst, src net.Conn) {
+		_, err := io.Copy(dst, src)
+		contract.IgnoreError(err)
+		done <- struct{}{}
+	}
+	go forward(a, b)
+	go forward(b, a)
+
+	<-done
+	contract.IgnoreClose(a)
+	contract.IgnoreClose(b)
+}
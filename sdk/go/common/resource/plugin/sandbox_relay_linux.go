@@ -0,0 +1,54 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// runSandboxRelay runs the plugin's half of the relay whose sockets are in the given directory. The arguments are the
+// engine's address followed by the plugin's command line. It returns the plugin's exit code.
+func runSandboxRelay(dir string, args []string) int {
+	if len(args) < 2 {
+		fmt.Fprintf(os.Stderr, "error: expected an engine address and a plugin to run\n")
+		return 1
+	}
+
+	server, err := serveSandboxRelay(dir, args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: starting plugin sandbox relay: %v\n", err)
+		return 1
+	}
+	defer contract.IgnoreClose(server)
+
+	cmd := exec.Command(args[1], args[2:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() > 0 {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "error: running sandboxed plugin: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,29 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !linux
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+)
+
+// runSandboxRelay runs the plugin's half of the relay whose sockets are in the given directory. Plugins can only be
+// sandboxed on Linux.
+func runSandboxRelay(dir string, args []string) int {
+	fmt.Fprintf(os.Stderr, "error: plugin sandboxing is only supported on Linux\n")
+	return 1
+}
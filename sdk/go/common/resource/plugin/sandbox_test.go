@@ -0,0 +1,176 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginSandboxFilterEnv(t *testing.T) {
+	sandbox := &PluginSandbox{Env: []string{"AWS_REGION"}}
+	env := sandbox.FilterEnv([]string{
+		"PATH=/usr/bin",
+		"AWS_REGION=us-west-2",
+		"AWS_SECRET_ACCESS_KEY=secret",
+		"PULUMI_CONFIG={}",
+		"PULUMI_ACCESS_TOKEN=token",
+		"PULUMI_CONFIG_PASSPHRASE=passphrase",
+	})
+	assert.Equal(t, []string{"PATH=/usr/bin", "AWS_REGION=us-west-2", "PULUMI_CONFIG={}"}, env)
+
+	// An empty result must not be mistaken for "inherit the environment".
+	env = sandbox.FilterEnv([]string{"SECRET=1"})
+	assert.NotNil(t, env)
+	assert.Empty(t, env)
+}
+
+func TestPluginSandboxNetwork(t *testing.T) {
+	for _, kv := range [][2]string{{PluginSandboxEnvVar, "true"}, {PluginSandboxNetworkEnvVar, "aws, kubernetes"}} {
+		old, ok := os.LookupEnv(kv[0])
+		assert.NoError(t, os.Setenv(kv[0], kv[1]))
+		defer func(name string) {
+			if ok {
+				os.Setenv(name, old)
+			} else {
+				os.Unsetenv(name)
+			}
+		}(kv[0])
+	}
+
+	assert.True(t, GetPluginSandbox("aws").Network)
+	assert.True(t, GetPluginSandbox("kubernetes").Network)
+	assert.False(t, GetPluginSandbox("gcp").Network)
+}
+
+func TestPluginSandboxCommand(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("plugin sandboxing is only supported on Linux")
+	}
+
+	// Put a stand-in for bubblewrap on the $PATH.
+	dir, err := ioutil.TempDir("", "sandbox-bin")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bwrap"), []byte("#!/bin/sh\n"), 0700))
+	path := os.Getenv("PATH")
+	assert.NoError(t, os.Setenv("PATH", dir))
+	defer os.Setenv("PATH", path)
+
+	sandbox := &PluginSandbox{Network: true}
+	_, args, err := sandbox.Command("plugin", []string{"127.0.0.1:1234"}, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, args, "--unshare-net")
+	assert.Equal(t, []string{"--", "plugin", "127.0.0.1:1234"}, args[len(args)-3:])
+
+	relay := &sandboxRelay{dir: "/tmp/relay", engineAddr: "127.0.0.1:1234"}
+	sandbox = &PluginSandbox{}
+	_, args, err = sandbox.Command("plugin", []string{"127.0.0.1:1234"}, relay)
+	assert.NoError(t, err)
+	assert.Contains(t, args, "--unshare-net")
+	exe, err := os.Executable()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--", exe, SandboxRelayCommand, "/tmp/relay", "127.0.0.1:1234", "plugin",
+		"127.0.0.1:1234"}, args[len(args)-7:])
+}
+
+func TestPluginSandboxHidesPulumiHome(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("plugin sandboxing is only supported on Linux")
+	}
+
+	home, err := ioutil.TempDir("", "sandbox-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	plugins := filepath.Join(home, "plugins")
+	assert.NoError(t, os.Mkdir(plugins, 0700))
+	pulumiHome := os.Getenv("PULUMI_HOME")
+	assert.NoError(t, os.Setenv("PULUMI_HOME", home))
+	defer os.Setenv("PULUMI_HOME", pulumiHome)
+
+	masks, err := pulumiHomeMasks()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--tmpfs", home, "--ro-bind", plugins, plugins}, masks)
+
+	assert.NoError(t, os.Setenv("PULUMI_HOME", filepath.Join(home, "missing")))
+	masks, err = pulumiHomeMasks()
+	assert.NoError(t, err)
+	assert.Empty(t, masks)
+}
+
+func TestSandboxRelay(t *testing.T) {
+	// echo starts a server that echoes back the first line it receives on each connection.
+	echo := func(prefix string) net.Listener {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					line, err := bufio.NewReader(conn).ReadString('\n')
+					if err == nil {
+						fmt.Fprintf(conn, "%s%s", prefix, line)
+					}
+				}()
+			}
+		}()
+		return l
+	}
+	roundTrip := func(conn net.Conn, line string) string {
+		defer conn.Close()
+		_, err := fmt.Fprintf(conn, "%s\n", line)
+		assert.NoError(t, err)
+		reply, err := bufio.NewReader(conn).ReadString('\n')
+		assert.NoError(t, err)
+		return strings.TrimSpace(reply)
+	}
+
+	engine, plugin := echo("engine: "), echo("plugin: ")
+	defer engine.Close()
+	defer plugin.Close()
+
+	relay, err := newSandboxRelay(engine.Addr().String())
+	assert.NoError(t, err)
+	defer relay.Close()
+
+	// Without a separate network namespace, the plugin's half of the relay can't listen on the engine's address.
+	server, err := serveSandboxRelay(relay.dir, "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer server.Close()
+
+	// Connections from the plugin reach the engine.
+	conn, err := net.Dial("tcp", server.engine.Addr().String())
+	assert.NoError(t, err)
+	assert.Equal(t, "engine: hello", roundTrip(conn, "hello"))
+
+	// Connections from the engine reach the plugin.
+	conn, err = relay.dial(context.Background(), plugin.Addr().String())
+	assert.NoError(t, err)
+	assert.Equal(t, "plugin: hello", roundTrip(conn, "hello"))
+}
@@ -189,6 +189,8 @@ func NewObjectProperty(v PropertyMap) PropertyValue    { return PropertyValue{v}
 func NewComputedProperty(v Computed) PropertyValue     { return PropertyValue{v} }
 func NewOutputProperty(v Output) PropertyValue         { return PropertyValue{v} }
 func NewSecretProperty(v *Secret) PropertyValue        { return PropertyValue{v} }
+func NewDecimalProperty(v Decimal) PropertyValue       { return PropertyValue{v} }
+func NewBytesProperty(v Bytes) PropertyValue           { return PropertyValue{v} }
 
 func MakeComputed(v PropertyValue) PropertyValue {
 	return NewComputedProperty(Computed{Element: v})
@@ -202,6 +204,24 @@ func MakeSecret(v PropertyValue) PropertyValue {
 	return NewSecretProperty(&Secret{Element: v})
 }
 
+// newIntegerProperty returns a number property for the given integer. Integers that cannot be represented exactly by a
+// double are returned as decimals.
+func newIntegerProperty(v int64) PropertyValue {
+	if v > maxExactInt || v < -maxExactInt {
+		return NewDecimalProperty(DecimalFromInt64(v))
+	}
+	return NewNumberProperty(float64(v))
+}
+
+// newUnsignedIntegerProperty returns a number property for the given unsigned integer. Integers that cannot be
+// represented exactly by a double are returned as decimals.
+func newUnsignedIntegerProperty(v uint64) PropertyValue {
+	if v > maxExactInt {
+		return NewDecimalProperty(DecimalFromUint64(v))
+	}
+	return NewNumberProperty(float64(v))
+}
+
 // NewPropertyValue turns a value into a property value, provided it is of a legal "JSON-like" kind.
 func NewPropertyValue(v interface{}) PropertyValue {
 	return NewPropertyValueRepl(v, nil, nil)
@@ -228,23 +248,29 @@ func NewPropertyValueRepl(v interface{},
 	case bool:
 		return NewBoolProperty(t)
 	case int:
-		return NewNumberProperty(float64(t))
+		return newIntegerProperty(int64(t))
 	case uint:
-		return NewNumberProperty(float64(t))
+		return newUnsignedIntegerProperty(uint64(t))
 	case int32:
 		return NewNumberProperty(float64(t))
 	case uint32:
 		return NewNumberProperty(float64(t))
 	case int64:
-		return NewNumberProperty(float64(t))
+		return newIntegerProperty(t)
 	case uint64:
-		return NewNumberProperty(float64(t))
+		return newUnsignedIntegerProperty(t)
 	case float32:
 		return NewNumberProperty(float64(t))
 	case float64:
 		return NewNumberProperty(t)
 	case string:
 		return NewStringProperty(t)
+	case Decimal:
+		return NewDecimalProperty(t)
+	case []byte:
+		return NewBytesProperty(t)
+	case Bytes:
+		return NewBytesProperty(t)
 	case *Asset:
 		return NewAssetProperty(t)
 	case *Archive:
@@ -355,8 +381,20 @@ func (v PropertyValue) ContainsSecrets() bool {
 // BoolValue fetches the underlying bool value (panicking if it isn't a bool).
 func (v PropertyValue) BoolValue() bool { return v.V.(bool) }
 
-// NumberValue fetches the underlying number value (panicking if it isn't a number).
-func (v PropertyValue) NumberValue() float64 { return v.V.(float64) }
+// NumberValue fetches the underlying number value (panicking if it isn't a number). Decimal values are converted to
+// the nearest double.
+func (v PropertyValue) NumberValue() float64 {
+	if d, ok := v.V.(Decimal); ok {
+		return d.Float64()
+	}
+	return v.V.(float64)
+}
+
+// DecimalValue fetches the underlying decimal value (panicking if it isn't a decimal).
+func (v PropertyValue) DecimalValue() Decimal { return v.V.(Decimal) }
+
+// BytesValue fetches the underlying byte string (panicking if it isn't a byte string).
+func (v PropertyValue) BytesValue() Bytes { return v.V.(Bytes) }
 
 // StringValue fetches the underlying string value (panicking if it isn't a string).
 func (v PropertyValue) StringValue() string { return v.V.(string) }
@@ -393,9 +431,25 @@ func (v PropertyValue) IsBool() bool {
 	return is
 }
 
-// IsNumber returns true if the underlying value is a number.
+// IsNumber returns true if the underlying value is a number. Both doubles and decimals are numbers.
 func (v PropertyValue) IsNumber() bool {
-	_, is := v.V.(float64)
+	switch v.V.(type) {
+	case float64, Decimal:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDecimal returns true if the underlying value is a decimal.
+func (v PropertyValue) IsDecimal() bool {
+	_, is := v.V.(Decimal)
+	return is
+}
+
+// IsBytes returns true if the underlying value is a byte string.
+func (v PropertyValue) IsBytes() bool {
+	_, is := v.V.(Bytes)
 	return is
 }
 
@@ -457,6 +511,8 @@ func (v PropertyValue) TypeString() string {
 		return "number"
 	} else if v.IsString() {
 		return "string"
+	} else if v.IsBytes() {
+		return "bytes"
 	} else if v.IsArray() {
 		return "[]"
 	} else if v.IsAsset() {
@@ -498,6 +554,8 @@ func (v PropertyValue) MapRepl(replk func(string) (string, bool),
 		return v.NumberValue()
 	} else if v.IsString() {
 		return v.StringValue()
+	} else if v.IsBytes() {
+		return v.BytesValue()
 	} else if v.IsArray() {
 		arr := []interface{}{}
 		for _, e := range v.ArrayValue() {
@@ -535,6 +593,9 @@ type Property struct {
 	Value PropertyValue
 }
 
+// maxExactInt is the largest integer n such that all integers in [-n, n] are exactly representable by a double.
+const maxExactInt = 1 << 53
+
 // SigKey is sometimes used to encode type identity inside of a map.  This is required when flattening into ordinary
 // maps, like we do when performing serialization, to ensure recoverability of type identities later on.
 const SigKey = "4dabf18193072939515e22adb298388d"
@@ -15,6 +15,7 @@
 package resource
 
 import (
+	"bytes"
 	"sort"
 )
 
@@ -323,6 +324,26 @@ func (v PropertyValue) DeepEquals(other PropertyValue) bool {
 		return vs.Element.DeepEquals(os.Element)
 	}
 
+	// Byte strings are equal if their contents are equal.
+	if v.IsBytes() {
+		if !other.IsBytes() {
+			return false
+		}
+		return bytes.Equal(v.BytesValue(), other.BytesValue())
+	} else if other.IsBytes() {
+		return false
+	}
+
+	// A decimal is equal to a double if the double's shortest decimal representation is the same decimal.
+	if v.IsDecimal() || other.IsDecimal() {
+		if !v.IsNumber() || !other.IsNumber() {
+			return false
+		}
+		vd, verr := v.decimal()
+		od, oerr := other.decimal()
+		return verr == nil && oerr == nil && vd == od
+	}
+
 	// For all other cases, primitives are equal if their values are equal.
 	return v.V == other.V
 }
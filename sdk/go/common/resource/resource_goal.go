@@ -37,13 +37,16 @@ type Goal struct {
 	Aliases                 []URN                 // additional URNs that should be aliased to this resource.
 	ID                      ID                    // the expected ID of the resource, if any.
 	CustomTimeouts          CustomTimeouts        // an optional config object for resource options
+	SerializationGroup      string                // resources in the same group are never operated on concurrently.
+	CustomAwait             *CustomAwait          // optional options for how the provider waits for readiness.
 }
 
 // NewGoal allocates a new resource goal state.
 func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
 	parent URN, protect bool, dependencies []URN, provider string, initErrors []string,
 	propertyDependencies map[PropertyKey][]URN, deleteBeforeReplace *bool, ignoreChanges []string,
-	additionalSecretOutputs []PropertyKey, aliases []URN, id ID, customTimeouts *CustomTimeouts) *Goal {
+	additionalSecretOutputs []PropertyKey, aliases []URN, id ID, customTimeouts *CustomTimeouts,
+	serializationGroup string) *Goal {
 
 	g := &Goal{
 		Type:                    t,
@@ -61,6 +64,7 @@ func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
 		AdditionalSecretOutputs: additionalSecretOutputs,
 		Aliases:                 aliases,
 		ID:                      id,
+		SerializationGroup:      serializationGroup,
 	}
 
 	if customTimeouts != nil {
@@ -43,6 +43,7 @@ type State struct {
 	Aliases                 []URN                 // TODO
 	CustomTimeouts          CustomTimeouts        // A config block that will be used to configure timeouts for CRUD operations
 	ImportID                ID                    // the resource's import id, if this was an imported resource.
+	SerializationGroup      string                // resources in the same group are never operated on concurrently.
 }
 
 // NewState creates a new resource value from existing resource state information.
@@ -15,6 +15,8 @@
 package resource
 
 import (
+	"sort"
+
 	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
 )
 
@@ -25,3 +27,42 @@ const RootStackType tokens.Type = "pulumi:pulumi:Stack"
 func DefaultRootStackURN(stack tokens.QName, proj tokens.PackageName) URN {
 	return NewURN(stack, proj, "", RootStackType, tokens.QName(string(proj)+"-"+string(stack)))
 }
+
+// InternalStackOutputsKey is the name of the root stack output that lists the names of the stack's internal outputs.
+// Internal outputs are visible to the stack's owners, but are not exposed to other stacks through stack references.
+// Only Go programs can mark outputs as internal, using pulumi.Context.ExportInternal; the Node.js and Python SDKs have
+// no equivalent.
+const InternalStackOutputsKey PropertyKey = "pulumi:internalOutputs"
+
+// PublicStackOutputs splits a root stack's outputs into the outputs that stack references may consume and the names
+// of the stack's internal outputs. The public outputs do not include InternalStackOutputsKey. The internal output
+// names are sorted.
+func PublicStackOutputs(outputs PropertyMap) (PropertyMap, []string) {
+	internal := map[string]bool{}
+	if names, ok := outputs[InternalStackOutputsKey]; ok {
+		if names.IsSecret() {
+			names = names.SecretValue().Element
+		}
+		if names.IsArray() {
+			for _, name := range names.ArrayValue() {
+				if name.IsString() {
+					internal[name.StringValue()] = true
+				}
+			}
+		}
+	}
+
+	public := PropertyMap{}
+	for k, v := range outputs {
+		if k != InternalStackOutputsKey && !internal[string(k)] {
+			public[k] = v
+		}
+	}
+
+	names := make([]string, 0, len(internal))
+	for name := range internal {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return public, names
+}
@@ -0,0 +1,50 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicStackOutputs(t *testing.T) {
+	outputs := NewPropertyMapFromMap(map[string]interface{}{
+		"url":                           "https://example.com",
+		"dbHost":                        "db.internal",
+		"dbPort":                        5432,
+		"replicas":                      3,
+		string(InternalStackOutputsKey): []interface{}{"dbPort", "dbHost", "missing"},
+	})
+
+	public, internal := PublicStackOutputs(outputs)
+	assert.Equal(t, NewPropertyMapFromMap(map[string]interface{}{
+		"url":      "https://example.com",
+		"replicas": 3,
+	}), public)
+	assert.Equal(t, []string{"dbHost", "dbPort", "missing"}, internal)
+
+	// The outputs of stacks that do not mark any outputs as internal are all public.
+	delete(outputs, InternalStackOutputsKey)
+	public, internal = PublicStackOutputs(outputs)
+	assert.Equal(t, outputs, public)
+	assert.Empty(t, internal)
+
+	// The list of internal outputs may itself be secret.
+	outputs[InternalStackOutputsKey] = MakeSecret(NewArrayProperty([]PropertyValue{NewStringProperty("dbHost")}))
+	public, internal = PublicStackOutputs(outputs)
+	assert.NotContains(t, public, PropertyKey("dbHost"))
+	assert.Equal(t, []string{"dbHost"}, internal)
+}
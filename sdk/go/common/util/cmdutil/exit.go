@@ -28,6 +28,57 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/result"
 )
 
+// The codes with which commands that operate on stacks exit. These codes are stable, so that scripts can tell the
+// outcomes of these commands apart without parsing their output.
+const (
+	// ExitSuccess indicates that a command succeeded. Commands that preview or apply changes exit with it whether or
+	// not there were changes, unless they were asked to report changes with ExitPreviewChanges or ExitChangesApplied.
+	ExitSuccess = 0
+	// ExitPreviewChanges indicates that a preview succeeded and proposed changes.
+	ExitPreviewChanges = 3
+	// ExitChangesApplied indicates that an update, refresh or destroy succeeded and changed the stack.
+	ExitChangesApplied = 4
+	// ExitUnexpectedChanges indicates that an operation run with --expect-no-changes proposed or made changes.
+	ExitUnexpectedChanges = 5
+	// ExitPolicyViolation indicates that an operation failed because a resource violated a mandatory policy.
+	ExitPolicyViolation = 6
+	// ExitConflict indicates that an operation could not start because another operation on the stack is in progress.
+	ExitConflict = 7
+	// ExitProviderFailure indicates that an operation failed because a resource provider failed to create, update,
+	// delete or read a resource.
+	ExitProviderFailure = 8
+	// ExitFailure indicates any other failure. Most shells report it as 255.
+	ExitFailure = -1
+)
+
+// exitCodeResult is a result that causes RunResultFunc to exit with a code other than ExitFailure.
+type exitCodeResult struct {
+	result.Result
+
+	code int
+}
+
+// ResultWithExitCode returns a result that is reported like res, but that causes RunResultFunc to exit with the given
+// code. If res is nil, RunResultFunc prints nothing and exits with the code.
+func ResultWithExitCode(code int, res result.Result) result.Result {
+	if res == nil {
+		res = result.Bail()
+	}
+	return exitCodeResult{Result: res, code: code}
+}
+
+// ExitCode returns the code with which RunResultFunc exits for the given result.
+func ExitCode(res result.Result) int {
+	switch res := res.(type) {
+	case nil:
+		return ExitSuccess
+	case exitCodeResult:
+		return res.code
+	default:
+		return ExitFailure
+	}
+}
+
 // DetailedError extracts a detailed error message, including stack trace, if there is one.
 func DetailedError(err error) string {
 	msg := errorMessage(err)
@@ -110,17 +161,20 @@ func RunFunc(run func(cmd *cobra.Command, args []string) error) func(*cobra.Comm
 func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) func(*cobra.Command, []string) {
 	return func(cmd *cobra.Command, args []string) {
 		if res := run(cmd, args); res != nil {
+			code := ExitCode(res)
+
 			// Sadly, the fact that we hard-exit below means that it's up to us to replicate the Cobra post-run
 			// behavior here.
 			if postRunErr := runPostCommandHooks(cmd, args); postRunErr != nil {
-				res = result.Merge(res, result.FromError(postRunErr))
+				res, code = result.Merge(res, result.FromError(postRunErr)), ExitFailure
 			}
 
 			// If we were asked to bail, that means we already printed out a message.  We just need
 			// to quit at this point (with an error code so no one thinks we succeeded).  Bailing
-			// always indicates a failure, just one we don't need to print a message for.
+			// indicates a failure, just one we don't need to print a message for, unless the command
+			// asked to exit with a code that says otherwise.
 			if res.IsBail() {
-				os.Exit(-1)
+				os.Exit(code)
 				return
 			}
 
@@ -135,7 +189,7 @@ func RunResultFunc(run func(cmd *cobra.Command, args []string) result.Result) fu
 				logging.V(3).Infof(DetailedError(err))
 			}
 
-			ExitError(msg)
+			exitErrorCode(code, msg)
 		}
 	}
 }
@@ -147,9 +201,14 @@ func Exit(err error) {
 
 // ExitError issues an error and exits with a standard error exit code.
 func ExitError(msg string) {
+	exitErrorCode(ExitFailure, msg)
+}
+
+// exitErrorCode issues an error and exits with the given error exit code.
+func exitErrorCode(code int, msg string) {
 	// Escape percent sign before passing the message as a format string (e.g., msg could contain %PATH% on Windows).
 	format := strings.Replace(msg, "%", "%%", -1)
-	exitErrorCodef(-1, format)
+	exitErrorCodef(code, format)
 }
 
 // exitErrorCodef formats the message with arguments, issues an error and exists with the given error exit code.
@@ -33,8 +33,12 @@ const (
 	BackupDir = "backups"
 	// BookkeepingDir is the name of our bookkeeping folder, we store state here (like .git for git).
 	BookkeepingDir = ".pulumi"
+	// ChunkDir is the name of the directory that holds the content-addressed chunks of historical checkpoints.
+	ChunkDir = "chunks"
 	// ConfigDir is the name of the folder that holds local configuration information.
 	ConfigDir = "config"
+	// FreezeDir is the name of the directory that holds the freezes of frozen stacks.
+	FreezeDir = "freezes"
 	// GitDir is the name of the folder git uses to store information.
 	GitDir = ".git"
 	// HistoryDir is the name of the directory that holds historical information for projects.
@@ -43,6 +47,8 @@ const (
 	PluginDir = "plugins"
 	// PolicyDir is the name of the directory that holds policy packs.
 	PolicyDir = "policies"
+	// SchemaDir is the name of the directory that caches the schemas of resource plugins.
+	SchemaDir = "schemas"
 	// StackDir is the name of the directory that holds stack information for projects.
 	StackDir = "stacks"
 	// TemplateDir is the name of the directory containing templates.
@@ -203,6 +209,11 @@ func isMarkupFile(path string, expect string) bool {
 	return false
 }
 
+// GetSchemaDir returns the directory in which the schemas of resource plugins are cached on the current machine.
+func GetSchemaDir() (string, error) {
+	return GetPulumiPath(SchemaDir)
+}
+
 // GetCachedVersionFilePath returns the location where the CLI caches information from pulumi.com on the newest
 // available version of the CLI
 func GetCachedVersionFilePath() (string, error) {
@@ -0,0 +1,81 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// pluginContainerFile is the name of the file in a plugin's directory that names the container image in which the
+// plugin runs when the directory does not hold a native binary for the current OS and architecture. The file may be
+// included in the plugin's tarball or written by InstallContainer.
+const pluginContainerFile = "pulumi-container.json"
+
+// PluginContainer describes the container image in which a plugin runs. The image's entrypoint must be the plugin's
+// executable; the arguments that are normally passed to the executable are passed to the container.
+type PluginContainer struct {
+	// Image is a reference to the plugin's image, e.g. "ghcr.io/example/pulumi-resource-example:v1.2.3".
+	Image string `json:"image"`
+	// Platform is the platform of the image to run, e.g. "linux/amd64", if it is not the host's own platform.
+	Platform string `json:"platform,omitempty"`
+}
+
+// GetPluginContainer returns the container in which the plugin installed in the given directory runs, or nil if the
+// plugin does not run in a container.
+func GetPluginContainer(dir string) (*PluginContainer, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, pluginContainerFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var container PluginContainer
+	if err = json.Unmarshal(b, &container); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", filepath.Join(dir, pluginContainerFile))
+	}
+	if container.Image == "" {
+		return nil, errors.Errorf("%s does not name an image", filepath.Join(dir, pluginContainerFile))
+	}
+	return &container, nil
+}
+
+// InstallContainer installs a plugin that runs in the given container into the cache. The image itself is pulled by
+// the container runtime when the plugin is first run.
+func (info PluginInfo) InstallContainer(container PluginContainer) error {
+	if container.Image == "" {
+		return errors.New("a container image is required")
+	}
+
+	dir, err := info.DirPath()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrap(err, "creating plugin directory")
+	}
+
+	b, err := json.MarshalIndent(container, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, pluginContainerFile), b, 0600)
+}
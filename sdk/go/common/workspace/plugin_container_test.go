@@ -0,0 +1,62 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginContainer(t *testing.T) {
+	home, err := ioutil.TempDir("", "plugin-container")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	defer os.Setenv(PulumiHomeEnvVar, os.Getenv(PulumiHomeEnvVar))
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+
+	version := semver.MustParse("1.2.3")
+	info := PluginInfo{Name: "example", Kind: ResourcePlugin, Version: &version}
+	dir, err := info.DirPath()
+	assert.NoError(t, err)
+
+	// A plugin directory without container metadata runs natively.
+	container, err := GetPluginContainer(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, container)
+
+	expected := PluginContainer{Image: "ghcr.io/example/pulumi-resource-example:v1.2.3", Platform: "linux/amd64"}
+	assert.NoError(t, info.InstallContainer(expected))
+	assert.True(t, HasPlugin(info))
+
+	container, err = GetPluginContainer(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, container)
+
+	// The installed plugin is found even though it has no binary.
+	matchDir, _, err := GetPluginPath(ResourcePlugin, "example", &version)
+	assert.NoError(t, err)
+	assert.Equal(t, dir, matchDir)
+
+	// Metadata that does not name an image is an error.
+	assert.Error(t, info.InstallContainer(PluginContainer{}))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, pluginContainerFile), []byte(`{"platform":"x"}`), 0600))
+	_, err = GetPluginContainer(dir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/httputil"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/version"
+)
+
+// maxPluginDownloadResumes is the number of times a single plugin download will be resumed after its connection fails.
+const maxPluginDownloadResumes = 5
+
+// getPluginRange requests the plugin tarball at the given endpoint, starting at the given offset. If the server does
+// not honor the range request, the bytes before the offset are skipped.
+func getPluginRange(ctx context.Context, endpoint string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
+	req.Header.Set("User-Agent", userAgent)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		contract.IgnoreClose(resp.Body)
+		return nil, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
+	}
+
+	if offset > 0 && resp.StatusCode != http.StatusPartialContent {
+		logging.V(7).Infof("server ignored range request for %s; skipping %d bytes", endpoint, offset)
+		if _, err := io.CopyN(ioutil.Discard, resp.Body, offset); err != nil {
+			contract.IgnoreClose(resp.Body)
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// pluginDownload is the body of a plugin download. If the connection fails or is closed before all of the plugin's
+// bytes have been read, the download is resumed from the current offset.
+type pluginDownload struct {
+	ctx      context.Context
+	endpoint string
+	body     io.ReadCloser
+	size     int64 // the total size of the download, or -1 if unknown.
+	offset   int64 // the number of bytes read so far.
+	resumes  int   // the number of times the download has been resumed.
+	err      error // the error that ended the download, if any.
+}
+
+func (d *pluginDownload) Read(p []byte) (int, error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+
+	n, err := d.body.Read(p)
+	d.offset += int64(n)
+	switch {
+	case err == nil:
+		return n, nil
+	case err == io.EOF && (d.size < 0 || d.offset == d.size):
+		return n, io.EOF
+	case err == io.EOF && d.offset > d.size:
+		return n, errors.Errorf("plugin download from %s is larger than the expected %d bytes", d.endpoint, d.size)
+	case err == io.EOF:
+		err = io.ErrUnexpectedEOF
+	}
+
+	// The connection failed. Unless the download was canceled or has failed too often, pick up where it left off.
+	if d.ctx.Err() != nil || d.resumes >= maxPluginDownloadResumes {
+		return n, err
+	}
+	d.resumes++
+	logging.V(7).Infof("resuming plugin download from %s at byte %d after error: %v", d.endpoint, d.offset, err)
+
+	contract.IgnoreClose(d.body)
+	resp, resumeErr := getPluginRange(d.ctx, d.endpoint, d.offset)
+	if resumeErr != nil {
+		d.body, d.err = ioutil.NopCloser(bytes.NewReader(nil)), errors.Wrapf(resumeErr,
+			"resuming plugin download after error: %v", err)
+		return n, d.err
+	}
+	d.body = resp.Body
+	return n, nil
+}
+
+func (d *pluginDownload) Close() error {
+	return d.body.Close()
+}
@@ -0,0 +1,101 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlakyPluginServer returns a server whose first response is cut off halfway through the given data. If
+// honorRange is true, later requests honor Range headers; otherwise they always return all of the data. The returned
+// function returns the Range header of each request the server has received.
+func newFlakyPluginServer(data []byte, honorRange bool) (*httptest.Server, func() []string) {
+	var lock sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		first := len(ranges) == 1
+		lock.Unlock()
+
+		if first {
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			_, _ = w.Write(data[:len(data)/2])
+			panic(http.ErrAbortHandler)
+		}
+		if !honorRange {
+			r.Header.Del("Range")
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	}))
+	return server, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), ranges...)
+	}
+}
+
+func TestPluginDownloadResumes(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 100000)
+
+	for _, honorRange := range []bool{true, false} {
+		server, ranges := newFlakyPluginServer(data, honorRange)
+
+		resp, err := getPluginRange(context.Background(), server.URL, 0)
+		assert.NoError(t, err)
+		download := &pluginDownload{
+			ctx:      context.Background(),
+			endpoint: server.URL,
+			body:     resp.Body,
+			size:     resp.ContentLength,
+		}
+		actual, err := ioutil.ReadAll(download)
+		assert.NoError(t, err)
+		assert.NoError(t, download.Close())
+		assert.True(t, bytes.Equal(data, actual))
+
+		requested := ranges()
+		assert.Len(t, requested, 2)
+		assert.Equal(t, "", requested[0])
+		assert.Regexp(t, `^bytes=\d+-$`, requested[1])
+		server.Close()
+	}
+}
+
+func TestPluginDownloadCanceled(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 100000)
+	server, ranges := newFlakyPluginServer(data, true)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, err := getPluginRange(ctx, server.URL, 0)
+	assert.NoError(t, err)
+	cancel()
+
+	download := &pluginDownload{ctx: ctx, endpoint: server.URL, body: resp.Body, size: resp.ContentLength}
+	_, err = ioutil.ReadAll(download)
+	assert.Error(t, err)
+	assert.Len(t, ranges(), 1)
+}
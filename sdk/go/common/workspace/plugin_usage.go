@@ -0,0 +1,160 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+)
+
+// pluginUsageFile is the name of the file in a plugin's directory that records which stacks have used the plugin.
+const pluginUsageFile = ".pulumi-usage.json"
+
+// PluginUsage records the last time that a stack used a plugin.
+type PluginUsage struct {
+	Project  string    `json:"project"`
+	Stack    string    `json:"stack"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// Usages returns the stacks that have used this plugin, most recent first.
+func (info PluginInfo) Usages() ([]PluginUsage, error) {
+	dir, err := info.DirPath()
+	if err != nil {
+		return nil, err
+	}
+	return readPluginUsages(dir)
+}
+
+func readPluginUsages(dir string) ([]PluginUsage, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, pluginUsageFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var usages []PluginUsage
+	if err = json.Unmarshal(b, &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+// RecordUsage records that the given stack used this plugin at the given time. Usage is only recorded for plugins
+// in the plugin cache.
+func (info PluginInfo) RecordUsage(project, stack string, now time.Time) error {
+	dir, err := info.DirPath()
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	usages, err := readPluginUsages(dir)
+	if err != nil {
+		// A damaged usage file only loses history; start over rather than failing.
+		usages = nil
+	}
+
+	found := false
+	for i := range usages {
+		if usages[i].Project == project && usages[i].Stack == stack {
+			usages[i].LastUsed, found = now, true
+		}
+	}
+	if !found {
+		usages = append(usages, PluginUsage{Project: project, Stack: stack, LastUsed: now})
+	}
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].LastUsed.After(usages[j].LastUsed) })
+
+	b, err := json.MarshalIndent(usages, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	// Several updates may use the same plugin at once, so replace the file rather than writing it in place.
+	tmp, err := ioutil.TempFile(dir, pluginUsageFile)
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(dir, pluginUsageFile))
+	}
+	if err != nil {
+		contract.IgnoreError(os.Remove(tmp.Name()))
+	}
+	return err
+}
+
+// PluginEvictionPolicy describes which plugins should be removed from the plugin cache.
+type PluginEvictionPolicy struct {
+	// MaxAge, if non-zero, evicts plugins that have not been used for longer than this.
+	MaxAge time.Duration
+	// MaxSize, if non-zero, evicts the least recently used plugins until the cache is no larger than this.
+	MaxSize int64
+	// Keep, if non-nil, returns true for plugins that must not be evicted.
+	Keep func(PluginInfo) bool
+}
+
+// SelectPluginsToEvict returns the plugins that the given policy evicts from the given set of cached plugins, least
+// recently used first.
+func SelectPluginsToEvict(plugins []PluginInfo, policy PluginEvictionPolicy, now time.Time) []PluginInfo {
+	lastUsed := func(p PluginInfo) time.Time {
+		if p.LastUsedTime.IsZero() {
+			return p.InstallTime
+		}
+		return p.LastUsedTime
+	}
+
+	candidates := make([]PluginInfo, len(plugins))
+	copy(candidates, plugins)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return lastUsed(candidates[i]).Before(lastUsed(candidates[j]))
+	})
+
+	var total int64
+	for _, p := range candidates {
+		total += p.Size
+	}
+
+	var evicted []PluginInfo
+	for _, p := range candidates {
+		if policy.Keep != nil && policy.Keep(p) {
+			continue
+		}
+		tooOld := policy.MaxAge != 0 && now.Sub(lastUsed(p)) > policy.MaxAge
+		tooBig := policy.MaxSize != 0 && total > policy.MaxSize
+		if tooOld || tooBig {
+			evicted = append(evicted, p)
+			total -= p.Size
+		}
+	}
+	return evicted
+}
@@ -0,0 +1,119 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectPluginsToEvict(t *testing.T) {
+	now := time.Now()
+	plugin := func(name string, size int64, age time.Duration) PluginInfo {
+		return PluginInfo{Name: name, Kind: ResourcePlugin, Size: size, LastUsedTime: now.Add(-age)}
+	}
+	names := func(plugins []PluginInfo) []string {
+		var result []string
+		for _, p := range plugins {
+			result = append(result, p.Name)
+		}
+		return result
+	}
+
+	plugins := []PluginInfo{
+		plugin("new", 10, time.Hour),
+		plugin("old", 10, 30*24*time.Hour),
+		plugin("middle", 10, 24*time.Hour),
+		// A plugin without a recorded use falls back to its install time.
+		{Name: "installed", Kind: ResourcePlugin, Size: 10, InstallTime: now.Add(-2 * 24 * time.Hour)},
+	}
+
+	// Age only.
+	evicted := SelectPluginsToEvict(plugins, PluginEvictionPolicy{MaxAge: 7 * 24 * time.Hour}, now)
+	assert.Equal(t, []string{"old"}, names(evicted))
+
+	// Size only: least recently used first until the cache fits.
+	evicted = SelectPluginsToEvict(plugins, PluginEvictionPolicy{MaxSize: 20}, now)
+	assert.Equal(t, []string{"old", "installed"}, names(evicted))
+
+	// Kept plugins are never evicted, even if that leaves the cache over its quota.
+	evicted = SelectPluginsToEvict(plugins, PluginEvictionPolicy{
+		MaxSize: 10,
+		Keep:    func(p PluginInfo) bool { return p.Name == "old" },
+	}, now)
+	assert.Equal(t, []string{"installed", "middle", "new"}, names(evicted))
+}
+
+func TestRecordPluginUsage(t *testing.T) {
+	home, err := ioutil.TempDir("", "plugin-usage")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	defer os.Setenv(PulumiHomeEnvVar, os.Getenv(PulumiHomeEnvVar))
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+
+	info := PluginInfo{Name: "test", Kind: ResourcePlugin}
+
+	// Recording usage of a plugin that is not in the cache does nothing.
+	assert.NoError(t, info.RecordUsage("proj", "dev", time.Now()))
+	usages, err := info.Usages()
+	assert.NoError(t, err)
+	assert.Empty(t, usages)
+
+	dir, err := info.DirPath()
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(dir, 0700))
+
+	t0 := time.Now().Add(-time.Hour).Round(0)
+	assert.NoError(t, info.RecordUsage("proj", "dev", t0))
+	assert.NoError(t, info.RecordUsage("proj", "prod", t0.Add(time.Minute)))
+	assert.NoError(t, info.RecordUsage("proj", "dev", t0.Add(2*time.Minute)))
+
+	usages, err = info.Usages()
+	assert.NoError(t, err)
+	if assert.Len(t, usages, 2) {
+		assert.Equal(t, "dev", usages[0].Stack)
+		assert.True(t, usages[0].LastUsed.Equal(t0.Add(2*time.Minute)))
+		assert.Equal(t, "prod", usages[1].Stack)
+	}
+}
+
+func TestDeletePluginRemovesLockFile(t *testing.T) {
+	home, err := ioutil.TempDir("", "plugin-delete")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	defer os.Setenv(PulumiHomeEnvVar, os.Getenv(PulumiHomeEnvVar))
+	assert.NoError(t, os.Setenv(PulumiHomeEnvVar, home))
+
+	info := PluginInfo{Name: "test", Kind: ResourcePlugin}
+	dir, err := info.DirPath()
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(dir, 0700))
+	assert.NoError(t, info.RecordUsage("proj", "dev", time.Now()))
+	assert.NoError(t, ioutil.WriteFile(dir+".lock", nil, 0600))
+
+	assert.NoError(t, info.Delete())
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(dir + ".lock")
+	assert.True(t, os.IsNotExist(err))
+
+	// Plugins without a lock file are deleted too.
+	assert.NoError(t, os.MkdirAll(dir, 0700))
+	assert.NoError(t, info.Delete())
+}
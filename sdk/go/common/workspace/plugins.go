@@ -15,10 +15,12 @@
 package workspace
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
@@ -38,9 +40,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v2/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/archive"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
-	"github.com/pulumi/pulumi/sdk/v2/go/common/util/httputil"
 	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
-	"github.com/pulumi/pulumi/sdk/v2/go/common/version"
 )
 
 const (
@@ -87,6 +87,7 @@ type PluginInfo struct {
 	InstallTime  time.Time       // the time the plugin was installed.
 	LastUsedTime time.Time       // the last time the plugin was used.
 	ServerURL    string          // an optional server to use when downloading this plugin.
+	Checksum     string          // an optional SHA256 checksum of the plugin's tarball, verified when installing.
 }
 
 // Dir gets the expected plugin directory for this plugin.
@@ -135,13 +136,20 @@ func (info PluginInfo) FilePath() (string, error) {
 }
 
 // Delete removes the plugin from the cache.  It also deletes any supporting files in the cache, which includes
-// any files that contain the same prefix as the plugin itself.
+// any files that contain the same prefix as the plugin itself, such as the `<dir>.lock` file that guards the
+// plugin's installation.
 func (info PluginInfo) Delete() error {
 	dir, err := info.DirPath()
 	if err != nil {
 		return err
 	}
-	return os.RemoveAll(dir)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.Remove(dir + ".lock"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
 // SetFileMetadata adds extra metadata from the given file, representing this plugin's directory.
@@ -167,11 +175,25 @@ func (info *PluginInfo) SetFileMetadata(path string) error {
 	}
 
 	info.LastUsedTime = tinfo.AccessTime()
+
+	// Not every file system tracks access times, so prefer the last recorded use if it is more recent.
+	if usages, err := readPluginUsages(path); err == nil && len(usages) > 0 {
+		if usages[0].LastUsed.After(info.LastUsedTime) {
+			info.LastUsedTime = usages[0].LastUsed
+		}
+	}
 	return nil
 }
 
 // Download fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
 func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
+	return info.DownloadWithContext(context.Background())
+}
+
+// DownloadWithContext fetches an io.ReadCloser for this plugin and also returns the size of the response (if known).
+// The download is abandoned if the given context is canceled. If the connection fails partway through the download,
+// the download is resumed from where it left off rather than restarted.
+func (info PluginInfo) DownloadWithContext(ctx context.Context) (io.ReadCloser, int64, error) {
 	// Figure out the OS/ARCH pair for the download URL.
 	var os string
 	switch runtime.GOOS {
@@ -201,24 +223,18 @@ func (info PluginInfo) Download() (io.ReadCloser, int64, error) {
 		serverURL,
 		url.QueryEscape(fmt.Sprintf("pulumi-%s-%s-v%s-%s-%s.tar.gz", info.Kind, info.Name, info.Version, os, arch)))
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	resp, err := getPluginRange(ctx, endpoint, 0)
 	if err != nil {
 		return nil, -1, err
 	}
 
-	userAgent := fmt.Sprintf("pulumi-cli/1 (%s; %s)", version.Version, runtime.GOOS)
-	req.Header.Set("User-Agent", userAgent)
-
-	resp, err := httputil.DoWithRetry(req, http.DefaultClient)
-	if err != nil {
-		return nil, -1, err
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, -1, errors.Errorf("%d HTTP error fetching plugin from %s", resp.StatusCode, endpoint)
+	download := &pluginDownload{
+		ctx:      ctx,
+		endpoint: endpoint,
+		body:     resp.Body,
+		size:     resp.ContentLength,
 	}
-
-	return resp.Body, resp.ContentLength, nil
+	return download, resp.ContentLength, nil
 }
 
 // Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format.
@@ -255,6 +271,14 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 			return err
 		}
 
+		if info.Checksum != "" {
+			sum := sha256.Sum256(tarballBytes)
+			if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, info.Checksum) {
+				return errors.Errorf("plugin tarball checksum %s does not match the expected checksum %s",
+					actual, info.Checksum)
+			}
+		}
+
 		return archive.UnTGZ(tarballBytes, tempDir)
 	})()
 	if err != nil {
@@ -300,12 +324,14 @@ const (
 	LanguagePlugin PluginKind = "language"
 	// ResourcePlugin is a plugin that can be used as a resource provider for custom CRUD operations.
 	ResourcePlugin PluginKind = "resource"
+	// PersisterPlugin is a plugin that can be used to store the checkpoints of stacks.
+	PersisterPlugin PluginKind = "persister"
 )
 
 // IsPluginKind returns true if k is a valid plugin kind, and false otherwise.
 func IsPluginKind(k string) bool {
 	switch PluginKind(k) {
-	case AnalyzerPlugin, LanguagePlugin, ResourcePlugin:
+	case AnalyzerPlugin, LanguagePlugin, ResourcePlugin, PersisterPlugin:
 		return true
 	default:
 		return false
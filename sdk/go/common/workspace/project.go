@@ -85,6 +85,10 @@ type Project struct {
 
 	// Config indicates where to store the Pulumi.<stack-name>.yaml files, combined with the folder Pulumi.yaml is in.
 	Config string `json:"config,omitempty" yaml:"config,omitempty"`
+	// ConfigSchema optionally declares the configuration values the program reads, keyed by configuration key.
+	ConfigSchema map[string]ProjectConfigType `json:"configSchema,omitempty" yaml:"configSchema,omitempty"`
+	// Confirmation optionally requires extra confirmation of destructive operations on the project's stacks.
+	Confirmation *ProjectConfirmation `json:"confirmation,omitempty" yaml:"confirmation,omitempty"`
 
 	// Template is an optional template manifest, if this project is a template.
 	Template *ProjectTemplate `json:"template,omitempty" yaml:"template,omitempty"`
@@ -101,7 +105,10 @@ func (proj *Project) Validate() error {
 		return errors.New("project is missing a 'runtime' attribute")
 	}
 
-	return nil
+	if err := proj.validateConfigSchema(); err != nil {
+		return err
+	}
+	return proj.Confirmation.validate()
 }
 
 // TrustResourceDependencies returns whether or not this project's runtime can be trusted to accurately report
@@ -167,6 +174,23 @@ type ProjectStack struct {
 	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
 	// Config is an optional config bag.
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
+	// ConfigSources records, for each configuration key that was imported from another stack's outputs, where its
+	// value came from. It is keyed by the configuration key in `namespace:name` form.
+	ConfigSources map[string]ConfigSource `json:"configsources,omitempty" yaml:"configsources,omitempty"`
+}
+
+// ConfigSource is the provenance of a configuration value that was imported from an output of another stack.
+type ConfigSource struct {
+	// Stack is the fully qualified name of the stack that the value was imported from.
+	Stack string `json:"stack" yaml:"stack"`
+	// Output is the name of the stack output that the value was imported from.
+	Output string `json:"output" yaml:"output"`
+	// Secret is true if the output was a secret when it was imported.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+	// Updated is the time, in RFC3339 format, of the source stack's last update when the value was imported.
+	Updated string `json:"updated,omitempty" yaml:"updated,omitempty"`
+	// Imported is the time, in RFC3339 format, at which the value was imported.
+	Imported string `json:"imported" yaml:"imported"`
 }
 
 // Save writes a project definition to a file.
@@ -380,7 +404,36 @@ func save(path string, value interface{}, mkDirAll bool) error {
 		}
 	}
 
-	// Changing the permissions on these file is ~ a breaking change, so disable golint.
-	//nolint: gosec
-	return ioutil.WriteFile(path, b, 0644)
+	// Write the file atomically: write the contents to a temporary file next to the destination, then rename it over
+	// the destination. This ensures that a failed write never leaves a truncated file behind. If the destination is a
+	// symlink, write through it to the file it refers to, and keep the permissions of any existing file.
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	// New files get the permissions that these files have always been written with.
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, mode)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		contract.IgnoreError(os.Remove(tmpPath))
+		return err
+	}
+	return nil
 }
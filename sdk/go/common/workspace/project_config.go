@@ -0,0 +1,148 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/tokens"
+)
+
+// The types a project may declare for a configuration value.
+const (
+	ConfigTypeString  = "string"
+	ConfigTypeInteger = "integer"
+	ConfigTypeNumber  = "number"
+	ConfigTypeBoolean = "boolean"
+	ConfigTypeArray   = "array"
+	ConfigTypeObject  = "object"
+)
+
+// ProjectConfigType declares a configuration value that a project's program reads.
+type ProjectConfigType struct {
+	// Type is the optional type of the value: one of string, integer, number, boolean, array, or object.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Description is an optional description of the value.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Required indicates that every stack must set the value.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	// Secret indicates that the value must be encrypted.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+// configSchemaKey returns the configuration key for the given config schema entry. Names without a namespace refer to
+// the project's own configuration.
+func (proj *Project) configSchemaKey(name string) (config.Key, error) {
+	if !strings.Contains(name, tokens.TokenDelimiter) {
+		name = fmt.Sprintf("%s:%s", proj.Name, name)
+	}
+	return config.ParseKey(name)
+}
+
+// validateConfigSchema checks that the project's config schema is well-formed.
+func (proj *Project) validateConfigSchema() error {
+	for name, typ := range proj.ConfigSchema {
+		if _, err := proj.configSchemaKey(name); err != nil {
+			return errors.Wrapf(err, "invalid key '%s' in configSchema", name)
+		}
+		switch typ.Type {
+		case "", ConfigTypeString, ConfigTypeInteger, ConfigTypeNumber, ConfigTypeBoolean, ConfigTypeArray,
+			ConfigTypeObject:
+		default:
+			return errors.Errorf("configSchema key '%s' has unknown type '%s'", name, typ.Type)
+		}
+	}
+	return nil
+}
+
+// ValidateStackConfig checks the given stack configuration against the project's config schema. Rather than stopping
+// at the first problem, it returns a single error that lists every required key that is missing and every value that
+// does not match its declared type. The decrypter is only used if a secret value needs to be type-checked.
+func (proj *Project) ValidateStackConfig(cfg config.Map, decrypter config.Decrypter) error {
+	var names []string
+	for name := range proj.ConfigSchema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		typ := proj.ConfigSchema[name]
+		key, err := proj.configSchemaKey(name)
+		if err != nil {
+			return errors.Wrapf(err, "invalid key '%s' in configSchema", name)
+		}
+
+		v, has := cfg[key]
+		if !has {
+			if typ.Required {
+				problems = append(problems, fmt.Sprintf("%s is required but not set", name))
+			}
+			continue
+		}
+		if typ.Secret && !v.Secure() {
+			problems = append(problems, fmt.Sprintf("%s must be a secret; set it with --secret", name))
+		}
+		if typ.Type == "" || typ.Type == ConfigTypeString && !v.Object() {
+			continue
+		}
+
+		raw, err := v.Value(decrypter)
+		if err != nil {
+			return errors.Wrapf(err, "could not decrypt configuration value '%s'", name)
+		}
+		if !configValueHasType(raw, v.Object(), typ.Type) {
+			problems = append(problems, fmt.Sprintf("%s must be of type %s", name, typ.Type))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.Errorf("the stack configuration does not match the project's configSchema:\n  - %s",
+		strings.Join(problems, "\n  - "))
+}
+
+// configValueHasType returns true if the given configuration value is of the given type.
+func configValueHasType(raw string, object bool, typ string) bool {
+	switch typ {
+	case ConfigTypeString:
+		return !object
+	case ConfigTypeInteger:
+		_, err := strconv.ParseInt(raw, 10, 64)
+		return !object && err == nil
+	case ConfigTypeNumber:
+		_, err := strconv.ParseFloat(raw, 64)
+		return !object && err == nil
+	case ConfigTypeBoolean:
+		_, err := strconv.ParseBool(raw)
+		return !object && err == nil
+	case ConfigTypeArray:
+		var arr []interface{}
+		return object && json.Unmarshal([]byte(raw), &arr) == nil
+	case ConfigTypeObject:
+		var obj map[string]interface{}
+		return object && json.Unmarshal([]byte(raw), &obj) == nil
+	default:
+		return true
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource/config"
+)
+
+func TestValidateStackConfig(t *testing.T) {
+	proj := &Project{
+		Name:    "app",
+		Runtime: NewProjectRuntimeInfo("nodejs", nil),
+		ConfigSchema: map[string]ProjectConfigType{
+			"dbHost":     {Required: true},
+			"dbPassword": {Required: true, Secret: true},
+			"replicas":   {Type: ConfigTypeInteger},
+			"debug":      {Type: ConfigTypeBoolean},
+			"tags":       {Type: ConfigTypeObject},
+			"aws:region": {Type: ConfigTypeString, Required: true},
+		},
+	}
+	assert.NoError(t, proj.Validate())
+
+	crypter := config.NewSymmetricCrypterFromPassphrase("test", []byte("salt"))
+	password, err := crypter.EncryptValue("hunter2")
+	assert.NoError(t, err)
+
+	valid := config.Map{
+		config.MustMakeKey("app", "dbHost"):     config.NewValue("db.example.com"),
+		config.MustMakeKey("app", "dbPassword"): config.NewSecureValue(password),
+		config.MustMakeKey("app", "replicas"):   config.NewValue("3"),
+		config.MustMakeKey("app", "tags"):       config.NewObjectValue(`{"env":"dev"}`),
+		config.MustMakeKey("aws", "region"):     config.NewValue("us-west-2"),
+	}
+	assert.NoError(t, proj.ValidateStackConfig(valid, crypter))
+
+	// Every problem is reported, not just the first.
+	invalid := config.Map{
+		config.MustMakeKey("app", "dbPassword"): config.NewValue("hunter2"),
+		config.MustMakeKey("app", "replicas"):   config.NewValue("three"),
+		config.MustMakeKey("app", "debug"):      config.NewValue("true"),
+		config.MustMakeKey("app", "tags"):       config.NewValue("env=dev"),
+	}
+	err = proj.ValidateStackConfig(invalid, config.NewPanicCrypter())
+	assert.EqualError(t, err, "the stack configuration does not match the project's configSchema:\n"+
+		"  - aws:region is required but not set\n"+
+		"  - dbHost is required but not set\n"+
+		"  - dbPassword must be a secret; set it with --secret\n"+
+		"  - replicas must be of type integer\n"+
+		"  - tags must be of type object")
+
+	proj.ConfigSchema["size"] = ProjectConfigType{Type: "enum"}
+	assert.Error(t, proj.Validate())
+}
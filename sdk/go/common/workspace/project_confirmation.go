@@ -0,0 +1,124 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+)
+
+// The operations that a stack name confirmation rule may apply to.
+const (
+	ConfirmOperationUpdate  = "update"
+	ConfirmOperationRefresh = "refresh"
+	ConfirmOperationDestroy = "destroy"
+)
+
+// ProjectConfirmation configures the confirmation that destructive operations on a project's stacks require beyond
+// the usual prompt, which --yes skips.
+type ProjectConfirmation struct {
+	// StackName lists rules under which the name of the stack must be typed, or given with --confirm-stack, to
+	// confirm an operation.
+	StackName []StackNameConfirmation `json:"stackName,omitempty" yaml:"stackName,omitempty"`
+	// ReplaceTypes lists resource types that may only be replaced if the replacement is allowed explicitly with
+	// --allow-replace.
+	ReplaceTypes []string `json:"replaceTypes,omitempty" yaml:"replaceTypes,omitempty"`
+}
+
+// StackNameConfirmation is a rule that selects operations whose stack name must be confirmed. An operation is
+// selected if it matches every part of the rule that is present.
+type StackNameConfirmation struct {
+	// Operations lists the operations the rule applies to: update, refresh, or destroy. Empty means all of them.
+	Operations []string `json:"operations,omitempty" yaml:"operations,omitempty"`
+	// Stacks lists globs, such as `prod-*`, one of which the stack's name must match.
+	Stacks []string `json:"stacks,omitempty" yaml:"stacks,omitempty"`
+	// Tags lists tags that the stack must have with the given values.
+	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// validate checks that the confirmation rules are well-formed.
+func (c *ProjectConfirmation) validate() error {
+	if c == nil {
+		return nil
+	}
+	for i, rule := range c.StackName {
+		for _, op := range rule.Operations {
+			switch op {
+			case ConfirmOperationUpdate, ConfirmOperationRefresh, ConfirmOperationDestroy:
+			default:
+				return errors.Errorf("confirmation.stackName[%d] has unknown operation '%s'; expected one of "+
+					"update, refresh, or destroy", i, op)
+			}
+		}
+		for _, glob := range rule.Stacks {
+			if _, err := path.Match(glob, ""); err != nil {
+				return errors.Wrapf(err, "confirmation.stackName[%d] has invalid stack glob '%s'", i, glob)
+			}
+		}
+	}
+	for i, typ := range c.ReplaceTypes {
+		if typ == "" {
+			return errors.Errorf("confirmation.replaceTypes[%d] is empty", i)
+		}
+	}
+	return nil
+}
+
+// RequiresStackName returns true if the name of the given stack must be confirmed before performing the operation.
+func (c *ProjectConfirmation) RequiresStackName(operation, stack string, tags map[string]string) bool {
+	if c == nil {
+		return false
+	}
+	for _, rule := range c.StackName {
+		if rule.matches(operation, stack, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule StackNameConfirmation) matches(operation, stack string, tags map[string]string) bool {
+	if len(rule.Operations) > 0 && !containsString(rule.Operations, operation) {
+		return false
+	}
+	if len(rule.Stacks) > 0 {
+		matched := false
+		for _, glob := range rule.Stacks {
+			if ok, _ := path.Match(glob, stack); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for k, v := range rule.Tags {
+		if actual, has := tags[k]; !has || actual != v {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
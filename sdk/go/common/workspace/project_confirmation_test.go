@@ -0,0 +1,67 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiresStackName(t *testing.T) {
+	proj := &Project{
+		Name:    "app",
+		Runtime: NewProjectRuntimeInfo("nodejs", nil),
+		Confirmation: &ProjectConfirmation{
+			StackName: []StackNameConfirmation{
+				{Operations: []string{ConfirmOperationDestroy}, Tags: map[string]string{"env": "prod"}},
+				{Stacks: []string{"shared-*"}},
+			},
+		},
+	}
+	assert.NoError(t, proj.Validate())
+
+	c := proj.Confirmation
+	prod := map[string]string{"env": "prod"}
+	assert.True(t, c.RequiresStackName(ConfirmOperationDestroy, "web", prod))
+	assert.False(t, c.RequiresStackName(ConfirmOperationUpdate, "web", prod))
+	assert.False(t, c.RequiresStackName(ConfirmOperationDestroy, "web", map[string]string{"env": "dev"}))
+	assert.False(t, c.RequiresStackName(ConfirmOperationDestroy, "web", nil))
+	assert.True(t, c.RequiresStackName(ConfirmOperationUpdate, "shared-network", nil))
+	assert.False(t, c.RequiresStackName(ConfirmOperationUpdate, "network", nil))
+
+	var none *ProjectConfirmation
+	assert.False(t, none.RequiresStackName(ConfirmOperationDestroy, "web", prod))
+}
+
+func TestValidateConfirmation(t *testing.T) {
+	proj := &Project{
+		Name:    "app",
+		Runtime: NewProjectRuntimeInfo("nodejs", nil),
+		Confirmation: &ProjectConfirmation{
+			StackName: []StackNameConfirmation{{Operations: []string{"deploy"}}},
+		},
+	}
+	assert.EqualError(t, proj.Validate(),
+		"confirmation.stackName[0] has unknown operation 'deploy'; expected one of update, refresh, or destroy")
+
+	proj.Confirmation = &ProjectConfirmation{
+		StackName: []StackNameConfirmation{{Stacks: []string{"prod-["}}},
+	}
+	assert.Error(t, proj.Validate())
+
+	proj.Confirmation = &ProjectConfirmation{ReplaceTypes: []string{"aws:rds/instance:Instance", ""}}
+	assert.EqualError(t, proj.Validate(), "confirmation.replaceTypes[1] is empty")
+}
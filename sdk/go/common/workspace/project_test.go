@@ -2,6 +2,10 @@ package workspace
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -36,3 +40,33 @@ func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
 	doTest(yaml.Marshal, yaml.Unmarshal)
 	doTest(json.Marshal, json.Unmarshal)
 }
+
+func TestSaveKeepsModeAndSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes and symlinks are not portable to Windows")
+	}
+
+	dir, err := ioutil.TempDir("", "project-save")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	target := filepath.Join(dir, "stack.yaml")
+	assert.NoError(t, ioutil.WriteFile(target, []byte("config: {}\n"), 0600))
+	link := filepath.Join(dir, "Pulumi.dev.yaml")
+	assert.NoError(t, os.Symlink(target, link))
+
+	stack := &ProjectStack{SecretsProvider: "passphrase"}
+	assert.NoError(t, stack.Save(link))
+
+	info, err := os.Lstat(link)
+	assert.NoError(t, err)
+	assert.True(t, info.Mode()&os.ModeSymlink != 0)
+
+	info, err = os.Stat(target)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	b, err := ioutil.ReadFile(target)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "passphrase")
+}
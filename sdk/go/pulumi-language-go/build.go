@@ -0,0 +1,135 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/executable"
+	"github.com/pulumi/pulumi/sdk/v2/go/common/util/logging"
+)
+
+// buildStampSuffix is appended to a build target's path to name the file that records the digest of the sources the
+// target was built from.
+const buildStampSuffix = ".pulumi-build.sha256"
+
+// buildEnvVars are the environment variables that change the output of `go build`, and so are part of a build's
+// digest along with its sources.
+var buildEnvVars = []string{"GOOS", "GOARCH", "GOFLAGS", "CGO_ENABLED", "GOEXPERIMENT"}
+
+// compileProgram builds the Go program in the given directory to the given target path and returns the target's
+// absolute path. If the target was already built from the same sources, it is reused as is.
+func compileProgram(dir, target string) (string, error) {
+	if runtime.GOOS == "windows" && !strings.HasSuffix(target, ".exe") {
+		target += ".exe"
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(dir, target)
+	}
+
+	digest, err := programDigest(dir, target)
+	if err != nil {
+		return "", errors.Wrap(err, "computing the digest of the program's sources")
+	}
+
+	stampPath := target + buildStampSuffix
+	if _, err := os.Stat(target); err == nil {
+		if stamp, err := ioutil.ReadFile(stampPath); err == nil && string(stamp) == digest {
+			logging.V(5).Infof("program binary %s is up to date", target)
+			return target, nil
+		}
+	}
+
+	gobin, err := executable.FindExecutable("go")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to find 'go' executable to build the program")
+	}
+
+	logging.V(5).Infof("building program binary %s", target)
+	// #nosec G204
+	cmd := exec.Command(gobin, "build", "-o", target, dir)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		contract.IgnoreError(os.Remove(stampPath))
+		return "", errors.Wrapf(err, "unable to build program:\n%s", output)
+	}
+	if err := ioutil.WriteFile(stampPath, []byte(digest), 0600); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// programDigest returns a digest of the Go sources and module files under the given directory, along with the
+// environment variables that affect the build. The build target itself is excluded.
+func programDigest(dir, target string) (string, error) {
+	h := sha256.New()
+	for _, name := range buildEnvVars {
+		fmt.Fprintf(h, "%s=%s\x00", name, os.Getenv(name))
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// Skip the directories that `go build` ignores, along with node_modules.
+			if path != dir && (strings.HasPrefix(info.Name(), ".") || strings.HasPrefix(info.Name(), "_") ||
+				info.Name() == "node_modules" || info.Name() == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path == target || path == target+buildStampSuffix {
+			return nil
+		}
+		switch {
+		case strings.HasSuffix(path, "_test.go"):
+			return nil
+		case strings.HasSuffix(path, ".go"), info.Name() == "go.mod", info.Name() == "go.sum":
+		default:
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer contract.IgnoreClose(f)
+
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(rel), info.Size())
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
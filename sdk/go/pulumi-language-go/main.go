@@ -42,11 +42,14 @@ import (
 	pulumirpc "github.com/pulumi/pulumi/sdk/v2/proto/go"
 )
 
-func findProgram(binary string) (*exec.Cmd, error) {
+func findProgram(binary, buildTarget string) (*exec.Cmd, error) {
 	// we default to execution via `go run`
 	// the user can explicitly opt in to using a binary executable by specifying
 	// runtime.options.binary in the Pulumi.yaml
 	if binary != "" {
+		if filepath.IsAbs(binary) {
+			return exec.Command(binary), nil
+		}
 		program, err := executable.FindExecutable(binary)
 		if err != nil {
 			return nil, errors.Wrap(err, "expected to find prebuilt executable")
@@ -54,6 +57,21 @@ func findProgram(binary string) (*exec.Cmd, error) {
 		return exec.Command(program), nil
 	}
 
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get current working directory")
+	}
+
+	// or may ask for the program to be compiled to runtime.options.buildTarget, which is only rebuilt when the
+	// program's sources change
+	if buildTarget != "" {
+		program, err := compileProgram(cwd, buildTarget)
+		if err != nil {
+			return nil, err
+		}
+		return exec.Command(program), nil
+	}
+
 	// Fall back to 'go run' style executions
 	logging.V(5).Infof("No prebuilt executable specified, attempting invocation via 'go run'")
 	program, err := executable.FindExecutable("go")
@@ -61,11 +79,6 @@ func findProgram(binary string) (*exec.Cmd, error) {
 		return nil, errors.Wrap(err, "problem executing program (could not run language executor)")
 	}
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to get current working directory")
-	}
-
 	goFileSearchPattern := filepath.Join(cwd, "*.go")
 	if matches, err := filepath.Glob(goFileSearchPattern); err != nil || len(matches) == 0 {
 		return nil, errors.Errorf("Failed to find go files for 'go run' matching %s", goFileSearchPattern)
@@ -78,8 +91,11 @@ func findProgram(binary string) (*exec.Cmd, error) {
 func main() {
 	var tracing string
 	var binary string
+	var buildTarget string
 	flag.StringVar(&tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
 	flag.StringVar(&binary, "binary", "", "Look on path for a binary executable with this name")
+	flag.StringVar(&buildTarget, "buildTarget", "",
+		"Build the program to this path, rebuilding only when its sources change, instead of using 'go run'")
 
 	flag.Parse()
 	args := flag.Args()
@@ -95,7 +111,7 @@ func main() {
 	// Fire up a gRPC server, letting the kernel choose a free port.
 	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
-			host := newLanguageHost(engineAddress, tracing, binary)
+			host := newLanguageHost(engineAddress, tracing, binary, buildTarget)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
@@ -118,13 +134,15 @@ type goLanguageHost struct {
 	engineAddress string
 	tracing       string
 	binary        string
+	buildTarget   string
 }
 
-func newLanguageHost(engineAddress, tracing, binary string) pulumirpc.LanguageRuntimeServer {
+func newLanguageHost(engineAddress, tracing, binary, buildTarget string) pulumirpc.LanguageRuntimeServer {
 	return &goLanguageHost{
 		engineAddress: engineAddress,
 		tracing:       tracing,
 		binary:        binary,
+		buildTarget:   buildTarget,
 	}
 }
 
@@ -242,7 +260,7 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 		return nil, errors.Wrap(err, "failed to prepare environment")
 	}
 
-	cmd, err := findProgram(host.binary)
+	cmd, err := findProgram(host.binary, host.buildTarget)
 	if err != nil {
 		return nil, err
 	}
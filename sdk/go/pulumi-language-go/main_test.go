@@ -1,6 +1,9 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -64,3 +67,36 @@ func TestGetPlugin(t *testing.T) {
 	assert.Equal(t, nonZeroPatchPlugin.Name, "kubernetes")
 	assert.Equal(t, nonZeroPatchPlugin.Version, "v1.5.8")
 }
+
+func TestProgramDigest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "program-digest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, contents string) {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	}
+	write("go.mod", "module example.com/program\n")
+	write("main.go", "package main\n\nfunc main() {}\n")
+	target := filepath.Join(dir, "bin", "program")
+
+	digest, err := programDigest(dir, target)
+	assert.NoError(t, err)
+
+	// The build target, tests, and ignored directories don't affect the digest.
+	write("bin/program", "binary")
+	write("main_test.go", "package main\n")
+	write(".git/HEAD", "ref: refs/heads/master\n")
+	write("testdata/data.go", "package testdata\n")
+	unchanged, err := programDigest(dir, target)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, unchanged)
+
+	// Changing a source file does.
+	write("util/util.go", "package util\n")
+	changed, err := programDigest(dir, target)
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest, changed)
+}
@@ -41,14 +41,20 @@ type Asset interface {
 	Text() string
 	// URI returns a URI, for remote network-based assets.
 	URI() string
+	// Headers returns the HTTP headers used to fetch a remote network-based asset, if any.
+	Headers() map[string]string
+	// PinnedHash returns the SHA256 hash that the asset's contents must have, if any.
+	PinnedHash() string
 
 	isAsset()
 }
 
 type asset struct {
-	path string
-	text string
-	uri  string
+	path       string
+	text       string
+	uri        string
+	headers    map[string]string
+	pinnedHash string
 }
 
 // NewFileAsset creates an asset backed by a file and specified by that file's path.
@@ -66,6 +72,22 @@ func NewRemoteAsset(uri string) Asset {
 	return &asset{uri: uri}
 }
 
+// RemoteAssetOptions configures how a remote asset is fetched.
+type RemoteAssetOptions struct {
+	// Headers contains additional HTTP headers, such as authorization tokens, to send when fetching the asset. The
+	// engine encrypts the headers when it writes the asset to the stack's checkpoint.
+	Headers map[string]string
+	// PinnedHash, if non-empty, is the hex-encoded SHA256 hash that the asset's contents must have. The engine
+	// verifies the contents against this hash before the asset is used.
+	PinnedHash string
+}
+
+// NewRemoteAssetWithOptions creates an asset backed by a remote file and specified by that file's URL. The options
+// provide credentials for fetching the file and pin its contents.
+func NewRemoteAssetWithOptions(uri string, opts RemoteAssetOptions) Asset {
+	return &asset{uri: uri, headers: opts.Headers, pinnedHash: opts.PinnedHash}
+}
+
 // Path returns the asset's file path, if this is a file asset, or an empty string otherwise.
 func (a *asset) Path() string { return a.path }
 
@@ -75,6 +97,12 @@ func (a *asset) Text() string { return a.text }
 // URI returns the asset's URL, if this is a remote asset, or an empty string otherwise.
 func (a *asset) URI() string { return a.uri }
 
+// Headers returns the HTTP headers used to fetch the asset, if this is a remote asset with headers.
+func (a *asset) Headers() map[string]string { return a.headers }
+
+// PinnedHash returns the SHA256 hash that the asset's contents must have, or an empty string if it is not pinned.
+func (a *asset) PinnedHash() string { return a.pinnedHash }
+
 func (a *asset) isAsset() {}
 
 func (a *asset) isAssetOrArchive() {}
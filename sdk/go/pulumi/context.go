@@ -43,6 +43,7 @@ type Context struct {
 	info        RunInfo
 	stack       Resource
 	exports     map[string]Input
+	internal    map[string]bool // the names of exports that are not visible to stack references.
 	monitor     pulumirpc.ResourceMonitorClient
 	monitorConn *grpc.ClientConn
 	engine      pulumirpc.EngineClient
@@ -52,6 +53,13 @@ type Context struct {
 	rpcsLock    *sync.Mutex // a lock protecting the RPC count and event.
 	rpcError    error       // the first error (if any) encountered during an RPC.
 
+	keepDecimals    bool // true if the resource monitor accepts lossless decimal numbers.
+	keepBytes       bool // true if the resource monitor accepts byte strings.
+	keepConfigUsage bool // true if the resource monitor accepts reports of the configuration keys that were read.
+
+	configKeysRead map[string]bool // the configuration keys that the program has read.
+	configKeysLock sync.Mutex      // a lock protecting the configuration keys that the program has read.
+
 	Log Log // the logging interface for the Pulumi log stream.
 }
 
@@ -93,6 +101,17 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 		engine = &mockEngine{}
 	}
 
+	// Only send lossless decimals, byte strings, and configuration usage to resource monitors that understand them.
+	keepDecimals, keepBytes, keepConfigUsage := false, false, false
+	if monitor != nil {
+		supportsFeature := func(id string) bool {
+			resp, err := monitor.SupportsFeature(ctx, &pulumirpc.SupportsFeatureRequest{Id: id})
+			return err == nil && resp.GetHasSupport()
+		}
+		keepDecimals, keepBytes = supportsFeature("decimals"), supportsFeature("bytes")
+		keepConfigUsage = supportsFeature("configUsage")
+	}
+
 	mutex := &sync.Mutex{}
 	log := &logState{
 		engine: engine,
@@ -102,6 +121,7 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 		ctx:         ctx,
 		info:        info,
 		exports:     make(map[string]Input),
+		internal:    make(map[string]bool),
 		monitorConn: monitorConn,
 		monitor:     monitor,
 		engineConn:  engineConn,
@@ -110,9 +130,24 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 		rpcsLock:    mutex,
 		rpcsDone:    sync.NewCond(mutex),
 		Log:         log,
+
+		keepDecimals:    keepDecimals,
+		keepBytes:       keepBytes,
+		keepConfigUsage: keepConfigUsage,
+		configKeysRead:  make(map[string]bool),
 	}, nil
 }
 
+// marshalOptions returns the options used to marshal properties that are sent to the resource monitor.
+func (ctx *Context) marshalOptions(keepUnknowns bool) plugin.MarshalOptions {
+	return plugin.MarshalOptions{
+		KeepUnknowns: keepUnknowns,
+		KeepSecrets:  true,
+		KeepDecimals: ctx.keepDecimals,
+		KeepBytes:    ctx.keepBytes,
+	}
+}
+
 // Close implements io.Closer and relinquishes any outstanding resources held by the context.
 func (ctx *Context) Close() error {
 	if ctx.engineConn != nil {
@@ -142,10 +177,39 @@ func (ctx *Context) DryRun() bool { return ctx.info.DryRun }
 
 // GetConfig returns the config value, as a string, and a bool indicating whether it exists or not.
 func (ctx *Context) GetConfig(key string) (string, bool) {
+	ctx.configKeysLock.Lock()
+	ctx.configKeysRead[key] = true
+	ctx.configKeysLock.Unlock()
+
 	v, ok := ctx.info.Config[key]
 	return v, ok
 }
 
+// reportConfigUsage reports the configuration keys that the program read to the engine, which compares them with the
+// configuration of the stack. Keys that are not of the form "namespace:name" cannot be set in the stack's
+// configuration, so they are not reported.
+func (ctx *Context) reportConfigUsage() error {
+	if !ctx.keepConfigUsage {
+		return nil
+	}
+
+	ctx.configKeysLock.Lock()
+	keys := make([]string, 0, len(ctx.configKeysRead))
+	for key := range ctx.configKeysRead {
+		if strings.Contains(key, ":") {
+			keys = append(keys, key)
+		}
+	}
+	ctx.configKeysLock.Unlock()
+	sort.Strings(keys)
+
+	type reportConfigUsageArgs struct {
+		Keys []string `pulumi:"keys"`
+	}
+	var result struct{}
+	return ctx.Invoke("pulumi:pulumi:reportConfigUsage", &reportConfigUsageArgs{Keys: keys}, &result)
+}
+
 // Invoke will invoke a provider's function, identified by its token tok. This function call is synchronous.
 //
 // args and result must be pointers to struct values fields and appropriately tagged and typed for use with Pulumi.
@@ -190,7 +254,7 @@ func (ctx *Context) Invoke(tok string, args interface{}, result interface{}, opt
 	keepUnknowns := ctx.DryRun()
 	rpcArgs, err := plugin.MarshalProperties(
 		resolvedArgsMap,
-		plugin.MarshalOptions{KeepUnknowns: keepUnknowns, KeepSecrets: true},
+		ctx.marshalOptions(keepUnknowns),
 	)
 	if err != nil {
 		return fmt.Errorf("marshaling arguments: %w", err)
@@ -228,7 +292,7 @@ func (ctx *Context) Invoke(tok string, args interface{}, result interface{}, opt
 	// Otherwsie, simply unmarshal the output properties and return the result.
 	outProps, err := plugin.UnmarshalProperties(
 		resp.Return,
-		plugin.MarshalOptions{KeepSecrets: true, KeepUnknowns: keepUnknowns},
+		plugin.MarshalOptions{KeepSecrets: true, KeepUnknowns: keepUnknowns, KeepDecimals: true, KeepBytes: true},
 	)
 	if err != nil {
 		return err
@@ -484,6 +548,7 @@ func (ctx *Context) RegisterResource(
 			AcceptSecrets:           true,
 			AdditionalSecretOutputs: inputs.additionalSecretOutputs,
 			Version:                 inputs.version,
+			SerializationGroup:      inputs.serializationGroup,
 		})
 		if err != nil {
 			logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -718,7 +783,7 @@ func (state *resourceState) resolve(dryrun bool, err error, inputs *resourceInpu
 	if err == nil {
 		outprops, err = plugin.UnmarshalProperties(
 			result,
-			plugin.MarshalOptions{KeepSecrets: true, KeepUnknowns: dryrun},
+			plugin.MarshalOptions{KeepSecrets: true, KeepUnknowns: dryrun, KeepDecimals: true, KeepBytes: true},
 		)
 	}
 	if err != nil {
@@ -792,6 +857,7 @@ type resourceInputs struct {
 	aliases                 []string
 	additionalSecretOutputs []string
 	version                 string
+	serializationGroup      string
 }
 
 // prepareResourceInputs prepares the inputs for a resource operation, shared between read and register.
@@ -814,11 +880,14 @@ func (ctx *Context) prepareResourceInputs(props Input, t string,
 		return nil, fmt.Errorf("marshaling properties: %w", err)
 	}
 
+	// Custom await options are sent to the provider alongside the resource's inputs.
+	addCustomAwait(resolvedProps, opts.CustomAwait)
+
 	// Marshal all properties for the RPC call.
 	keepUnknowns := ctx.DryRun()
 	rpcProps, err := plugin.MarshalProperties(
 		resolvedProps,
-		plugin.MarshalOptions{KeepUnknowns: keepUnknowns, KeepSecrets: true})
+		ctx.marshalOptions(keepUnknowns))
 	if err != nil {
 		return nil, fmt.Errorf("marshaling properties: %w", err)
 	}
@@ -877,6 +946,7 @@ func (ctx *Context) prepareResourceInputs(props Input, t string,
 		aliases:                 aliases,
 		additionalSecretOutputs: additionalSecretOutputs,
 		version:                 version,
+		serializationGroup:      opts.SerializationGroup,
 	}, nil
 }
 
@@ -890,6 +960,15 @@ func getTimeouts(custom *CustomTimeouts) *pulumirpc.RegisterResourceRequest_Cust
 	return &timeouts
 }
 
+func addCustomAwait(props resource.PropertyMap, custom *CustomAwait) {
+	if custom != nil {
+		props[resource.CustomAwaitKey] = resource.CustomAwait{
+			Skip:       custom.Skip,
+			Conditions: custom.Conditions,
+		}.PropertyValue()
+	}
+}
+
 // getOpts returns a set of resource options from an array of them. This includes the parent URN, any dependency URNs,
 // a boolean indicating whether the resource is to be protected, and the URN and ID of the resource's provider, if any.
 func (ctx *Context) getOpts(t string, providers map[string]ProviderResource, opts *resourceOptions) (
@@ -1036,7 +1115,7 @@ func (ctx *Context) RegisterResourceOutputs(resource Resource, outs Map) error {
 		keepUnknowns := ctx.DryRun()
 		outsMarshalled, err := plugin.MarshalProperties(
 			outsResolved.ObjectValue(),
-			plugin.MarshalOptions{KeepUnknowns: keepUnknowns, KeepSecrets: true})
+			ctx.marshalOptions(keepUnknowns))
 		if err != nil {
 			return
 		}
@@ -1057,6 +1136,38 @@ func (ctx *Context) RegisterResourceOutputs(resource Resource, outs Map) error {
 // Export registers a key and value pair with the current context's stack.
 func (ctx *Context) Export(name string, value Input) {
 	ctx.exports[name] = value
+	delete(ctx.internal, name)
+}
+
+// ExportInternal registers a key and value pair with the current context's stack as an internal output. Internal
+// outputs are visible to the stack's owners, e.g. in `pulumi stack output`, but other stacks cannot read them through
+// stack references.
+func (ctx *Context) ExportInternal(name string, value Input) {
+	ctx.exports[name] = value
+	ctx.internal[name] = true
+}
+
+// stackOutputs returns the outputs to register with the current context's stack, including the list of internal
+// outputs, if any.
+func (ctx *Context) stackOutputs() Map {
+	outputs := Map{}
+	for k, v := range ctx.exports {
+		outputs[k] = v
+	}
+	if len(ctx.internal) != 0 {
+		names := make([]string, 0, len(ctx.internal))
+		for name := range ctx.internal {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		namesArray := make(StringArray, len(names))
+		for i, name := range names {
+			namesArray[i] = String(name)
+		}
+		outputs[string(resource.InternalStackOutputsKey)] = namesArray
+	}
+	return outputs
 }
 
 // RegisterStackTransformation adds a transformation to all future resources constructed in this Pulumi stack.
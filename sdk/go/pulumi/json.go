@@ -0,0 +1,58 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// JSONMarshal returns an output that resolves to the JSON encoding of v once all of the inputs that v contains have
+// resolved. The result is unknown if any of those inputs is unknown, and secret if any of them is secret.
+func JSONMarshal(v interface{}) StringOutput {
+	return JSONMarshalWithContext(context.Background(), v)
+}
+
+// JSONMarshalWithContext returns an output that resolves to the JSON encoding of v once all of the inputs that v
+// contains have resolved. The result is unknown if any of those inputs is unknown, and secret if any of them is
+// secret.
+func JSONMarshalWithContext(ctx context.Context, v interface{}) StringOutput {
+	return ToOutputWithContext(ctx, v).ApplyTWithContext(ctx, func(_ context.Context, v interface{}) (string, error) {
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes), nil
+	}).(StringOutput)
+}
+
+// JSONUnmarshal returns an output that resolves to the value decoded from the JSON document in data. The result is
+// unknown if data is unknown, and secret if data is secret.
+func JSONUnmarshal(data StringInput) AnyOutput {
+	return JSONUnmarshalWithContext(context.Background(), data)
+}
+
+// JSONUnmarshalWithContext returns an output that resolves to the value decoded from the JSON document in data. The
+// result is unknown if data is unknown, and secret if data is secret.
+func JSONUnmarshalWithContext(ctx context.Context, data StringInput) AnyOutput {
+	return data.ToStringOutputWithContext(ctx).ApplyTWithContext(ctx,
+		func(_ context.Context, data string) (interface{}, error) {
+			var v interface{}
+			if err := json.Unmarshal([]byte(data), &v); err != nil {
+				return nil, err
+			}
+			return v, nil
+		}).(AnyOutput)
+}
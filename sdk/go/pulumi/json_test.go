@@ -0,0 +1,53 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONMarshal(t *testing.T) {
+	// Inputs nested in the value are resolved, and their secretness is kept.
+	out := JSONMarshal(map[string]interface{}{
+		"bucket": ToSecret(String("foo")),
+		"paths":  []interface{}{"a", String("b")},
+	})
+	v, known, secret, err := await(out)
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, `{"bucket":"foo","paths":["a","b"]}`, v)
+
+	// An unknown input makes the result unknown.
+	unknown := StringOutput{newOutputState(stringType)}
+	unknown.resolve("", false, false)
+	_, known, _, err = await(JSONMarshal(map[string]interface{}{"bucket": unknown}))
+	assert.NoError(t, err)
+	assert.False(t, known)
+}
+
+func TestJSONUnmarshal(t *testing.T) {
+	out := JSONUnmarshal(ToSecret(String(`{"paths":["a","b"]}`)).(StringOutput))
+	v, known, secret, err := await(out)
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, map[string]interface{}{"paths": []interface{}{"a", "b"}}, v)
+
+	_, _, _, err = await(JSONUnmarshal(String("{")))
+	assert.Error(t, err)
+}
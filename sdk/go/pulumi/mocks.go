@@ -52,6 +52,11 @@ func (m *mockMonitor) SupportsFeature(ctx context.Context, in *pulumirpc.Support
 func (m *mockMonitor) Invoke(ctx context.Context, in *pulumirpc.InvokeRequest,
 	opts ...grpc.CallOption) (*pulumirpc.InvokeResponse, error) {
 
+	// Reports of the configuration keys that were read are handled by the engine, so there is nothing to mock.
+	if in.GetTok() == "pulumi:pulumi:reportConfigUsage" {
+		return &pulumirpc.InvokeResponse{}, nil
+	}
+
 	args, err := plugin.UnmarshalProperties(in.GetArgs(), plugin.MarshalOptions{KeepSecrets: true})
 	if err != nil {
 		return nil, err
@@ -151,6 +151,15 @@ type CustomTimeouts struct {
 	Delete string
 }
 
+// CustomAwait describes how a resource's provider should wait for the resource to become ready after it is created or
+// updated. Providers that do not support custom await options ignore them.
+type CustomAwait struct {
+	// Skip, when true, tells the provider not to wait for the resource to become ready.
+	Skip bool
+	// Conditions is an optional list of provider-specific conditions that must hold before the resource is ready.
+	Conditions []string
+}
+
 type resourceOptions struct {
 	// Parent is an optional parent resource to which this resource belongs.
 	Parent Resource
@@ -171,6 +180,12 @@ type resourceOptions struct {
 	Import IDInput
 	// CustomTimeouts is an optional configuration block used for CRUD operations
 	CustomTimeouts *CustomTimeouts
+	// CustomAwait is an optional configuration block used to customize how the provider waits for the resource to
+	// become ready.
+	CustomAwait *CustomAwait
+	// SerializationGroup is an optional name of a group of resources that the engine never operates on concurrently,
+	// for APIs that tolerate only one change at a time.
+	SerializationGroup string
 	// Ignore changes to any of the specified properties.
 	IgnoreChanges []string
 	// Aliases is an optional list of identifiers used to find and use existing resources.
@@ -319,6 +334,22 @@ func Timeouts(o *CustomTimeouts) ResourceOption {
 	})
 }
 
+// Await is an optional configuration block used to customize how the provider waits for the resource to become ready.
+func Await(o *CustomAwait) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.CustomAwait = o
+	})
+}
+
+// SerializationGroup places this resource in the named group of resources, which the engine never creates, updates,
+// or deletes concurrently. This serializes changes for APIs that tolerate only one at a time without chaining the
+// resources with DependsOn.
+func SerializationGroup(o string) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.SerializationGroup = o
+	})
+}
+
 // An optional version, corresponding to the version of the provider plugin that should be used when operating on
 // this resource. This version overrides the version information inferred from the current package and should
 // rarely be used.
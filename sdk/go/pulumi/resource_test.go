@@ -280,3 +280,21 @@ func assertTransformations(t *testing.T, t1 []ResourceTransformation, t2 []Resou
 		assert.Equal(t, p1, p2)
 	}
 }
+
+func TestResourceOptionMergingCustomAwait(t *testing.T) {
+	a1 := &CustomAwait{Skip: true}
+	a2 := &CustomAwait{Conditions: []string{"Ready"}}
+
+	// last value wins
+	opts := merge(Await(a1), Await(a2))
+	assert.Equal(t, a2, opts.CustomAwait)
+
+	opts = merge(Await(a1), Await(nil))
+	assert.Nil(t, opts.CustomAwait)
+}
+
+func TestResourceOptionMergingSerializationGroup(t *testing.T) {
+	// last value wins
+	opts := merge(SerializationGroup("dns"), SerializationGroup("iam"))
+	assert.Equal(t, "iam", opts.SerializationGroup)
+}
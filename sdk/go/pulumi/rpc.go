@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/context"
@@ -233,9 +234,11 @@ func marshalInputAndDetermineSecret(v interface{},
 		switch v := v.(type) {
 		case *asset:
 			return resource.NewAssetProperty(&resource.Asset{
-				Path: v.Path(),
-				Text: v.Text(),
-				URI:  v.URI(),
+				Path:       v.Path(),
+				Text:       v.Text(),
+				URI:        v.URI(),
+				Headers:    v.Headers(),
+				PinnedHash: v.PinnedHash(),
 			}), deps, secret, nil
 		case *archive:
 			var assets map[string]interface{}
@@ -281,9 +284,9 @@ func marshalInputAndDetermineSecret(v interface{},
 		case reflect.Bool:
 			return resource.NewBoolProperty(rv.Bool()), deps, secret, nil
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return resource.NewNumberProperty(float64(rv.Int())), deps, secret, nil
+			return resource.NewPropertyValue(rv.Int()), deps, secret, nil
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			return resource.NewNumberProperty(float64(rv.Uint())), deps, secret, nil
+			return resource.NewPropertyValue(rv.Uint()), deps, secret, nil
 		case reflect.Float32, reflect.Float64:
 			return resource.NewNumberProperty(rv.Float()), deps, secret, nil
 		case reflect.Ptr, reflect.Interface:
@@ -303,6 +306,11 @@ func marshalInputAndDetermineSecret(v interface{},
 				return resource.PropertyValue{}, deps, secret, nil
 			}
 
+			// Byte slices are marshaled as byte strings rather than as arrays of numbers.
+			if rv.Type().Elem().Kind() == reflect.Uint8 && rv.Kind() == reflect.Slice {
+				return resource.NewBytesProperty(append(resource.Bytes(nil), rv.Bytes()...)), deps, secret, nil
+			}
+
 			destElem := destType.Elem()
 
 			// If an array or a slice, create a new array by recursing into elements.
@@ -415,6 +423,10 @@ func unmarshalPropertyValue(v resource.PropertyValue) (interface{}, bool, error)
 		case asset.IsText():
 			return NewStringAsset(asset.Text), false, nil
 		case asset.IsURI():
+			if len(asset.Headers) != 0 || asset.PinnedHash != "" {
+				opts := RemoteAssetOptions{Headers: asset.Headers, PinnedHash: asset.PinnedHash}
+				return NewRemoteAssetWithOptions(asset.URI, opts), false, nil
+			}
 			return NewRemoteAsset(asset.URI), false, nil
 		}
 		return nil, false, errors.New("expected asset to be one of File, String, or Remote; got none")
@@ -505,12 +517,28 @@ func unmarshalOutput(v resource.PropertyValue, dest reflect.Value) (bool, error)
 		if !v.IsNumber() {
 			return false, fmt.Errorf("expected an %v, got a %s", dest.Type(), v.TypeString())
 		}
+		if v.IsDecimal() {
+			i, err := strconv.ParseInt(v.DecimalValue().String(), 10, 64)
+			if err != nil {
+				return false, fmt.Errorf("expected an %v, got %v", dest.Type(), v.DecimalValue())
+			}
+			dest.SetInt(i)
+			return false, nil
+		}
 		dest.SetInt(int64(v.NumberValue()))
 		return false, nil
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if !v.IsNumber() {
 			return false, fmt.Errorf("expected an %v, got a %s", dest.Type(), v.TypeString())
 		}
+		if v.IsDecimal() {
+			u, err := strconv.ParseUint(v.DecimalValue().String(), 10, 64)
+			if err != nil {
+				return false, fmt.Errorf("expected an %v, got %v", dest.Type(), v.DecimalValue())
+			}
+			dest.SetUint(u)
+			return false, nil
+		}
 		dest.SetUint(uint64(v.NumberValue()))
 		return false, nil
 	case reflect.Float32, reflect.Float64:
@@ -526,6 +554,21 @@ func unmarshalOutput(v resource.PropertyValue, dest reflect.Value) (bool, error)
 		dest.SetString(v.StringValue())
 		return false, nil
 	case reflect.Slice:
+		if dest.Type().Elem().Kind() == reflect.Uint8 {
+			// Byte strings may arrive as their base64 encoding if the other side does not keep them.
+			switch {
+			case v.IsBytes():
+				dest.SetBytes(append([]byte(nil), v.BytesValue()...))
+				return false, nil
+			case v.IsString():
+				b, err := resource.DecodeBytes(v.StringValue())
+				if err != nil {
+					return false, err
+				}
+				dest.SetBytes(b)
+				return false, nil
+			}
+		}
 		if !v.IsArray() {
 			return false, fmt.Errorf("expected a %v, got a %s", dest.Type(), v.TypeString())
 		}
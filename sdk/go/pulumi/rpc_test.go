@@ -396,6 +396,46 @@ func TestUnmarshalSecret(t *testing.T) {
 	assert.True(t, isSecret)
 }
 
+func TestMarshalLargeIntegers(t *testing.T) {
+	const big = int64(1<<62 + 1)
+
+	v, _, err := marshalInput(big, reflect.TypeOf(big), true)
+	assert.Nil(t, err)
+	assert.True(t, v.IsDecimal())
+
+	var iv int64
+	_, err = unmarshalOutput(v, reflect.ValueOf(&iv).Elem())
+	assert.Nil(t, err)
+	assert.Equal(t, big, iv)
+
+	var uv uint64
+	_, err = unmarshalOutput(resource.NewDecimalProperty("18446744073709551615"), reflect.ValueOf(&uv).Elem())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(18446744073709551615), uv)
+
+	_, err = unmarshalOutput(resource.NewDecimalProperty("1.5"), reflect.ValueOf(&iv).Elem())
+	assert.NotNil(t, err)
+}
+
+func TestMarshalBytes(t *testing.T) {
+	data := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	v, _, err := marshalInput(data, reflect.TypeOf(data), true)
+	assert.Nil(t, err)
+	assert.True(t, v.IsBytes())
+
+	var bv []byte
+	_, err = unmarshalOutput(v, reflect.ValueOf(&bv).Elem())
+	assert.Nil(t, err)
+	assert.Equal(t, data, bv)
+
+	// Byte strings may also be received in their base64 encoding.
+	bv = nil
+	_, err = unmarshalOutput(resource.NewStringProperty("3q2+7w=="), reflect.ValueOf(&bv).Elem())
+	assert.Nil(t, err)
+	assert.Equal(t, data, bv)
+}
+
 func TestUnmarshalInternalMapValue(t *testing.T) {
 	m := make(map[string]interface{})
 	m["foo"] = "bar"
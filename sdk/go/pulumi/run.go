@@ -103,7 +103,12 @@ func RunWithContext(ctx *Context, body RunFunc) error {
 	}
 
 	// Register all the outputs to the stack object.
-	if err = ctx.RegisterResourceOutputs(ctx.stack, Map(ctx.exports)); err != nil {
+	if err = ctx.RegisterResourceOutputs(ctx.stack, ctx.stackOutputs()); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	// Report the configuration keys that the program read.
+	if err = ctx.reportConfigUsage(); err != nil {
 		result = multierror.Append(result, err)
 	}
 
@@ -229,3 +229,25 @@ func TestInvoke(t *testing.T) {
 	}, WithMocks("project", "stack", mocks))
 	assert.NoError(t, err)
 }
+
+func TestConfigKeysRead(t *testing.T) {
+	mocks := &testMonitor{
+		CallF: func(token string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+			// Reports of the configuration keys that were read must not reach the mocks.
+			assert.Fail(t, "unexpected call to %s", token)
+			return nil, nil
+		},
+	}
+
+	err := RunErr(func(ctx *Context) error {
+		_, ok := ctx.GetConfig("project:name")
+		assert.False(t, ok)
+		_, ok = ctx.GetConfig("aws:region")
+		assert.False(t, ok)
+
+		assert.Equal(t, map[string]bool{"project:name": true, "aws:region": true}, ctx.configKeysRead)
+		assert.True(t, ctx.keepConfigUsage)
+		return nil
+	}, WithMocks("project", "stack", mocks))
+	assert.NoError(t, err)
+}
@@ -1,6 +1,9 @@
 package pulumi
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+)
 
 // StackReference manages a reference to a Pulumi stack.
 type StackReference struct {
@@ -10,14 +13,26 @@ type StackReference struct {
 	Name StringOutput `pulumi:"name"`
 	// Outputs resolves with exports from the named stack
 	Outputs MapOutput `pulumi:"outputs"`
+	// InternalOutputNames resolves with the names of the named stack's internal exports, which stack references
+	// cannot read
+	InternalOutputNames StringArrayOutput `pulumi:"internalOutputNames"`
 }
 
-// GetOutput returns a stack output keyed by the given name as an AnyOutput
+// GetOutput returns a stack output keyed by the given name as an AnyOutput. The output is rejected if the named
+// stack marks the requested output as internal.
 func (s *StackReference) GetOutput(name StringInput) AnyOutput {
-	return All(name, s.Outputs).
-		ApplyT(func(args []interface{}) interface{} {
-			n, outs := args[0].(string), args[1].(map[string]interface{})
-			return outs[n]
+	return All(name, s.Name, s.Outputs, s.InternalOutputNames).
+		ApplyT(func(args []interface{}) (interface{}, error) {
+			n, stack, outs := args[0].(string), args[1].(string), args[2].(map[string]interface{})
+			if internal, ok := args[3].([]string); ok {
+				for _, k := range internal {
+					if k == n {
+						return nil, fmt.Errorf("stack %q does not export output %q: the output is internal to the stack",
+							stack, n)
+					}
+				}
+			}
+			return outs[n], nil
 		}).(AnyOutput)
 }
 
@@ -57,3 +57,50 @@ func TestStackReference(t *testing.T) {
 	}, WithMocks("project", "stack", mocks))
 	assert.NoError(t, err)
 }
+
+func TestStackReferenceInternalOutputs(t *testing.T) {
+	mocks := &testMonitor{
+		NewResourceF: func(typeToken, name string, inputs resource.PropertyMap,
+			provider, id string) (string, resource.PropertyMap, error) {
+			return inputs["name"].StringValue(), resource.NewPropertyMapFromMap(map[string]interface{}{
+				"name":                "stack",
+				"outputs":             map[string]interface{}{"foo": "bar"},
+				"internalOutputNames": []interface{}{"baz"},
+			}), nil
+		},
+	}
+	err := RunErr(func(ctx *Context) error {
+		ref, err := NewStackReference(ctx, "stack", nil)
+		assert.NoError(t, err)
+		foo, _, _, err := await(ref.GetOutput(String("foo")))
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", foo)
+		_, _, _, err = await(ref.GetOutput(String("baz")))
+		assert.EqualError(t, err, `stack "stack" does not export output "baz": the output is internal to the stack`)
+		return nil
+	}, WithMocks("project", "stack", mocks))
+	assert.NoError(t, err)
+}
+
+func TestExportInternal(t *testing.T) {
+	err := RunErr(func(ctx *Context) error {
+		ctx.Export("url", String("https://example.com"))
+		ctx.ExportInternal("dbHost", String("db.internal"))
+		ctx.ExportInternal("dbPort", Int(5432))
+
+		// Exporting an internal output again publicly makes it public.
+		ctx.ExportInternal("url", String("https://example.com"))
+		ctx.Export("url", String("https://example.com"))
+
+		outputs, _, _, err := await(ctx.stackOutputs().ToMapOutput())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"url":                    "https://example.com",
+			"dbHost":                 "db.internal",
+			"dbPort":                 5432,
+			"pulumi:internalOutputs": []string{"dbHost", "dbPort"},
+		}, outputs)
+		return nil
+	}, WithMocks("project", "stack", &testMonitor{}))
+	assert.NoError(t, err)
+}
@@ -96,9 +96,25 @@ func (o *OutputState) dependencies() []Resource {
 	if o == nil {
 		return nil
 	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 	return o.deps
 }
 
+// addDependencies adds the given resources to the dependencies of the output. The dependencies of an output must be
+// complete before it is fulfilled.
+func (o *OutputState) addDependencies(deps ...Resource) {
+	if o == nil || len(deps) == 0 {
+		return
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	// Copy the existing dependencies: they may be shared with the output this one was applied to.
+	o.deps = append(append([]Resource(nil), o.deps...), deps...)
+}
+
 func (o *OutputState) fulfill(value interface{}, known, secret bool, err error) {
 	o.fulfillValue(reflect.ValueOf(value), known, secret, err)
 }
@@ -141,10 +157,11 @@ func (o *OutputState) reject(err error) {
 }
 
 func (o *OutputState) await(ctx context.Context) (interface{}, bool, bool, error) {
+	secret := false
 	for {
 		if o == nil {
 			// If the state is nil, treat its value as resolved and unknown.
-			return nil, false, false, nil
+			return nil, false, secret, nil
 		}
 
 		o.mutex.Lock()
@@ -156,8 +173,10 @@ func (o *OutputState) await(ctx context.Context) (interface{}, bool, bool, error
 		}
 		o.mutex.Unlock()
 
+		// The value is secret if this output or any output it resolved to is secret.
+		secret = secret || o.secret
 		if !o.known || o.err != nil {
-			return nil, o.known, o.secret, o.err
+			return nil, o.known, secret, o.err
 		}
 
 		// If the result is an Output, await it in turn.
@@ -166,7 +185,7 @@ func (o *OutputState) await(ctx context.Context) (interface{}, bool, bool, error
 		// the element type of the outer output. We should reconsider this.
 		ov, ok := o.value.(Output)
 		if !ok {
-			return o.value, true, o.secret, nil
+			return o.value, true, secret, nil
 		}
 		o = ov.getState()
 	}
@@ -337,6 +356,14 @@ func (o *OutputState) ApplyWithContext(ctx context.Context, applier func(context
 //        return []rune(v)
 //    }).(pulumi.AnyOutput)
 //
+// If T is itself an Output type, the result is flattened: it is of type T, resolves to the value of the Output
+// returned by the applier, depends on that Output's dependencies as well as this one's, and is secret if either
+// Output is secret.
+//
+//    bucketOutput.ApplyT(func(name string) pulumi.StringOutput {
+//        return lookupObject(name).Key
+//    }).(pulumi.StringOutput)
+//
 func (o *OutputState) ApplyT(applier interface{}) Output {
 	return o.ApplyTWithContext(context.Background(), makeContextful(applier, o.elementType()))
 }
@@ -374,6 +401,9 @@ func (o *OutputState) ApplyTWithContext(ctx context.Context, applier interface{}
 	resultType := anyOutputType
 	if ot, ok := concreteTypeToOutputType.Load(fn.Type().Out(0)); ok {
 		resultType = ot.(reflect.Type)
+	} else if rt := fn.Type().Out(0); rt.Kind() == reflect.Struct && rt.Implements(outputType) {
+		// The result of an applier that returns an Output is flattened, so it has the type of that Output.
+		resultType = rt
 	}
 
 	result := newOutput(resultType, o.dependencies()...)
@@ -395,6 +425,15 @@ func (o *OutputState) ApplyTWithContext(ctx context.Context, applier interface{}
 			return
 		}
 
+		// If the applier returned an Output, flatten it: the result takes on the inner output's value and
+		// dependencies, and is secret if either output is.
+		if inner, ok := results[0].Interface().(Output); ok {
+			v, known, innerSecret, err := inner.getState().await(ctx)
+			result.getState().addDependencies(inner.getState().dependencies()...)
+			result.fulfill(v, known, secret || innerSecret, err)
+			return
+		}
+
 		// Fulfill the result.
 		result.fulfillValue(results[0], true, secret, nil)
 	}()
@@ -17,8 +17,11 @@ package pulumi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -445,3 +448,86 @@ func TestNil(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, true, v)
 }
+
+type applyConformanceOutput struct {
+	Value     interface{} `json:"value"`
+	Known     bool        `json:"known"`
+	Secret    bool        `json:"secret"`
+	Resources []string    `json:"resources"`
+}
+
+// Test that applies whose appliers return outputs are flattened as described by the SDKs' shared conformance cases.
+func TestApplyConformance(t *testing.T) {
+	bytes, err := ioutil.ReadFile(filepath.Join("..", "..", "conformance", "apply.json"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var suite struct {
+		Cases []struct {
+			Name   string                 `json:"name"`
+			Outer  applyConformanceOutput `json:"outer"`
+			Inner  applyConformanceOutput `json:"inner"`
+			Result applyConformanceOutput `json:"result"`
+		} `json:"cases"`
+	}
+	if !assert.NoError(t, json.Unmarshal(bytes, &suite)) {
+		t.FailNow()
+	}
+
+	resources := make(map[string]Resource)
+	newOutput := func(o applyConformanceOutput) AnyOutput {
+		var deps []Resource
+		for _, name := range o.Resources {
+			if _, ok := resources[name]; !ok {
+				resources[name] = &ResourceState{name: name}
+			}
+			deps = append(deps, resources[name])
+		}
+		out := AnyOutput{newOutputState(anyType, deps...)}
+		out.resolve(o.Value, o.Known, o.Secret)
+		return out
+	}
+
+	for _, c := range suite.Cases {
+		t.Run(c.Name, func(t *testing.T) {
+			outer, inner := newOutput(c.Outer), newOutput(c.Inner)
+			result := outer.ApplyT(func(interface{}) AnyOutput {
+				return inner
+			}).(AnyOutput)
+
+			v, known, secret, err := await(result)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Result.Known, known)
+			assert.Equal(t, c.Result.Secret, secret)
+			if c.Result.Known {
+				assert.Equal(t, c.Result.Value, v)
+			}
+
+			names := make(map[string]bool)
+			for _, r := range result.dependencies() {
+				names[r.getName()] = true
+			}
+			expected := make(map[string]bool)
+			for _, name := range c.Result.Resources {
+				expected[name] = true
+			}
+			assert.Equal(t, expected, names)
+		})
+	}
+}
+
+// Test that an applier that returns a typed output produces an output of that type.
+func TestApplyFlattensTypedOutput(t *testing.T) {
+	out := String("foo").ToStringOutput().ApplyT(func(v string) StringOutput {
+		return ToSecret(String(v + "bar")).(StringOutput)
+	})
+	s, ok := out.(StringOutput)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	v, known, secret, err := await(s)
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.True(t, secret)
+	assert.Equal(t, "foobar", v)
+}
@@ -85,10 +85,40 @@ export class RemoteAsset extends Asset {
      * The URI where the asset lives.
      */
     public readonly uri: Promise<string>;
+    /**
+     * Additional HTTP headers, such as authorization tokens, to send when fetching the asset.
+     */
+    public readonly headers?: Promise<Record<string, string>>;
+    /**
+     * The SHA256 hash that the asset's contents must have, if any.
+     */
+    public readonly pinnedHash?: Promise<string>;
 
-    constructor(uri: string | Promise<string>) {
+    constructor(uri: string | Promise<string>, opts?: RemoteAssetOptions) {
         super();
         this.uri = Promise.resolve(uri);
+        if (opts && opts.headers !== undefined) {
+            this.headers = Promise.resolve(opts.headers);
+        }
+        if (opts && opts.pinnedHash !== undefined) {
+            this.pinnedHash = Promise.resolve(opts.pinnedHash);
+        }
     }
 }
 
+/**
+ * RemoteAssetOptions configures how a remote asset is fetched.
+ */
+export interface RemoteAssetOptions {
+    /**
+     * Additional HTTP headers, such as authorization tokens, to send when fetching the asset. The engine encrypts the
+     * headers when it writes the asset to the stack's checkpoint.
+     */
+    headers?: Record<string, string> | Promise<Record<string, string>>;
+    /**
+     * The hex-encoded SHA256 hash that the asset's contents must have. The engine verifies the contents against this
+     * hash before the asset is used.
+     */
+    pinnedHash?: string | Promise<string>;
+}
+
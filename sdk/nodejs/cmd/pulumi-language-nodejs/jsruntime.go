@@ -0,0 +1,75 @@
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// The JavaScript runtimes that a program may be run with. The runtime is chosen with the `jsruntime` option in the
+// runtime section of Pulumi.yaml.
+const (
+	nodeRuntime = "node"
+	denoRuntime = "deno"
+	bunRuntime  = "bun"
+)
+
+// checkJSRuntime returns an error if the given runtime is not one that the language host knows how to run.
+func checkJSRuntime(runtime string) error {
+	switch runtime {
+	case nodeRuntime, denoRuntime, bunRuntime:
+		return nil
+	default:
+		return errors.Errorf("unsupported jsruntime %q; expected one of %q, %q, or %q",
+			runtime, nodeRuntime, denoRuntime, bunRuntime)
+	}
+}
+
+// jsRuntimeArgs returns the arguments that must precede the run script on the given runtime's command line.
+func jsRuntimeArgs(runtime string) []string {
+	switch runtime {
+	case denoRuntime:
+		// Deno needs permission to reach the engine and to read the program, and must resolve packages from the
+		// project's node_modules directory rather than its own cache so that it sees what npm or yarn installed.
+		return []string{"run", "--allow-all", "--node-modules-dir"}
+	case bunRuntime:
+		return []string{"run"}
+	default:
+		return nil
+	}
+}
+
+// resolveNodeModule resolves a module name such as `@pulumi/pulumi/cmd/run` to a file by searching the node_modules
+// directories from the given directory upwards, as Node's require does. Runtimes other than Node can't be asked to
+// resolve the module themselves in a portable way, so the language host does this on their behalf.
+func resolveNodeModule(mod, dir string) (string, error) {
+	for {
+		base := filepath.Join(dir, "node_modules", filepath.FromSlash(mod))
+		for _, candidate := range []string{base, base + ".js", filepath.Join(base, "index.js")} {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.Errorf("could not find module %s in any node_modules directory", mod)
+		}
+		dir = parent
+	}
+}
@@ -77,19 +77,30 @@ const (
 func main() {
 	var tracing string
 	var typescript bool
+	var jsRuntime string
 	flag.StringVar(&tracing, "tracing", "",
 		"Emit tracing to a Zipkin-compatible tracing endpoint")
 	flag.BoolVar(&typescript, "typescript", true,
 		"Use ts-node at runtime to support typescript source natively")
+	flag.StringVar(&jsRuntime, "jsruntime", nodeRuntime,
+		"The JavaScript runtime to run the program with: node, deno, or bun")
 	flag.Parse()
 
 	args := flag.Args()
 	logging.InitLogging(false, 0, false)
 	cmdutil.InitTracing("pulumi-language-nodejs", "pulumi-language-nodejs", tracing)
 
-	nodePath, err := exec.LookPath("node")
+	if err := checkJSRuntime(jsRuntime); err != nil {
+		cmdutil.Exit(err)
+	}
+	nodePath, err := exec.LookPath(jsRuntime)
 	if err != nil {
-		cmdutil.Exit(errors.Wrapf(err, "could not find node on the $PATH"))
+		cmdutil.Exit(errors.Wrapf(err, "could not find %s on the $PATH", jsRuntime))
+	}
+
+	// Deno and Bun compile TypeScript themselves, so ts-node is only needed under Node.
+	if jsRuntime != nodeRuntime {
+		typescript = false
 	}
 
 	runPath := os.Getenv("PULUMI_LANGUAGE_NODEJS_RUN_PATH")
@@ -97,7 +108,14 @@ func main() {
 		runPath = defaultRunPath
 	}
 
-	runPath, err = locateModule(runPath, nodePath)
+	if jsRuntime == nodeRuntime {
+		runPath, err = locateModule(runPath, nodePath)
+	} else {
+		var cwd string
+		if cwd, err = os.Getwd(); err == nil {
+			runPath, err = resolveNodeModule(runPath, cwd)
+		}
+	}
 	if err != nil {
 		cmdutil.ExitError(
 			"It looks like the Pulumi SDK has not been installed. Have you run npm install or yarn install?")
@@ -112,7 +130,7 @@ func main() {
 	// Fire up a gRPC server, letting the kernel choose a free port.
 	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
-			host := newLanguageHost(nodePath, runPath, engineAddress, tracing, typescript)
+			host := newLanguageHost(nodePath, runPath, engineAddress, tracing, typescript, jsRuntime)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
@@ -149,16 +167,18 @@ type nodeLanguageHost struct {
 	engineAddress string
 	tracing       string
 	typescript    bool
+	jsRuntime     string
 }
 
 func newLanguageHost(nodePath, runPath, engineAddress,
-	tracing string, typescript bool) pulumirpc.LanguageRuntimeServer {
+	tracing string, typescript bool, jsRuntime string) pulumirpc.LanguageRuntimeServer {
 	return &nodeLanguageHost{
 		nodeBin:       nodePath,
 		runPath:       runPath,
 		engineAddress: engineAddress,
 		tracing:       tracing,
 		typescript:    typescript,
+		jsRuntime:     jsRuntime,
 	}
 }
 
@@ -546,7 +566,7 @@ func (host *nodeLanguageHost) execNodejs(
 // by enumerating all of the optional and non-optional arguments present
 // in a RunRequest.
 func (host *nodeLanguageHost) constructArguments(req *pulumirpc.RunRequest, address, pipesDirectory string) []string {
-	args := []string{host.runPath}
+	args := append(jsRuntimeArgs(host.jsRuntime), host.runPath)
 	maybeAppendArg := func(k, v string) {
 		if v != "" {
 			args = append(args, "--"+k, v)
@@ -15,6 +15,9 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -61,6 +64,35 @@ func TestArgumentConstruction(t *testing.T) {
 		args := strings.Join(host.constructArguments(rr, "", ""), " ")
 		assert.Contains(tt, args, "foobar")
 	})
+
+	t.Run("RuntimeArgsPrecedeRunPath", func(tt *testing.T) {
+		host := &nodeLanguageHost{runPath: "run.js", jsRuntime: denoRuntime}
+		rr := &pulumirpc.RunRequest{}
+		args := host.constructArguments(rr, "", "")
+		assert.Equal(tt, []string{"run", "--allow-all", "--node-modules-dir", "run.js"}, args[:4])
+	})
+}
+
+func TestResolveNodeModule(t *testing.T) {
+	t.Parallel()
+
+	root, err := ioutil.TempDir("", "resolve-node-module")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	runDir := filepath.Join(root, "node_modules", "@pulumi", "pulumi", "cmd", "run")
+	assert.NoError(t, os.MkdirAll(runDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(runDir, "index.js"), nil, 0600))
+	program := filepath.Join(root, "src", "program")
+	assert.NoError(t, os.MkdirAll(program, 0700))
+
+	// Modules are found in the node_modules directories of parent directories.
+	path, err := resolveNodeModule(defaultRunPath, program)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(runDir, "index.js"), path)
+
+	_, err = resolveNodeModule("missing-module", program)
+	assert.Error(t, err)
 }
 
 func TestConfig(t *testing.T) {
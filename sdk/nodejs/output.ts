@@ -997,3 +997,30 @@ export function interpolate(literals: TemplateStringsArray, ...placeholders: Inp
         return result;
     });
 }
+
+/**
+ * [jsonStringify] returns an [Output] of the JSON encoding of [obj], as produced by
+ * [JSON.stringify].  [obj] may contain any sort of [Input] values, which are resolved before it is
+ * encoded.  The result is unknown if any of those inputs is unknown, and secret if any of them is
+ * secret.  This can be used like so:
+ *
+ * ```ts
+ *      // 'bucket' is a resource that exposes [Output] properties.
+ *      let policy: Output<string> = pulumi.jsonStringify({
+ *          Statement: [{ Effect: "Allow", Resource: pulumi.interpolate `arn:aws:s3:::${bucket.id}/*` }],
+ *      });
+ * ```
+ */
+export function jsonStringify(obj: Input<any>, replacer?: (this: any, key: string, value: any) => any,
+                              space?: string | number): Output<string> {
+    return output(obj).apply(o => JSON.stringify(o, replacer, space));
+}
+
+/**
+ * [jsonParse] returns an [Output] of the value decoded from the JSON document in [text], as
+ * produced by [JSON.parse].  The result is unknown if [text] is unknown, and secret if [text] is
+ * secret.
+ */
+export function jsonParse(text: Input<string>, reviver?: (this: any, key: string, value: any) => any): Output<any> {
+    return output(text).apply(t => JSON.parse(t, reviver));
+}
@@ -0,0 +1,123 @@
+// GENERATED CODE -- DO NOT EDIT!
+
+// Original file comments:
+// Copyright 2016-2020, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+'use strict';
+var grpc = require('@grpc/grpc-js');
+var persister_pb = require('./persister_pb.js');
+var plugin_pb = require('./plugin_pb.js');
+var google_protobuf_empty_pb = require('google-protobuf/google/protobuf/empty_pb.js');
+
+function serialize_google_protobuf_Empty(arg) {
+  if (!(arg instanceof google_protobuf_empty_pb.Empty)) {
+    throw new Error('Expected argument of type google.protobuf.Empty');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_google_protobuf_Empty(buffer_arg) {
+  return google_protobuf_empty_pb.Empty.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_CheckpointMutation(arg) {
+  if (!(arg instanceof persister_pb.CheckpointMutation)) {
+    throw new Error('Expected argument of type pulumirpc.CheckpointMutation');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_CheckpointMutation(buffer_arg) {
+  return persister_pb.CheckpointMutation.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_LoadCheckpointRequest(arg) {
+  if (!(arg instanceof persister_pb.LoadCheckpointRequest)) {
+    throw new Error('Expected argument of type pulumirpc.LoadCheckpointRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_LoadCheckpointRequest(buffer_arg) {
+  return persister_pb.LoadCheckpointRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_LoadCheckpointResponse(arg) {
+  if (!(arg instanceof persister_pb.LoadCheckpointResponse)) {
+    throw new Error('Expected argument of type pulumirpc.LoadCheckpointResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_LoadCheckpointResponse(buffer_arg) {
+  return persister_pb.LoadCheckpointResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_PluginInfo(arg) {
+  if (!(arg instanceof plugin_pb.PluginInfo)) {
+    throw new Error('Expected argument of type pulumirpc.PluginInfo');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_PluginInfo(buffer_arg) {
+  return plugin_pb.PluginInfo.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+
+// SnapshotPersister stores the checkpoints of stacks on behalf of the engine, e.g. in a database or some other
+// system that the built-in backends do not support.
+var SnapshotPersisterService = exports.SnapshotPersisterService = {
+  // GetPluginInfo returns generic information about this plugin, like its version.
+getPluginInfo: {
+    path: '/pulumirpc.SnapshotPersister/GetPluginInfo',
+    requestStream: false,
+    responseStream: false,
+    requestType: google_protobuf_empty_pb.Empty,
+    responseType: plugin_pb.PluginInfo,
+    requestSerialize: serialize_google_protobuf_Empty,
+    requestDeserialize: deserialize_google_protobuf_Empty,
+    responseSerialize: serialize_pulumirpc_PluginInfo,
+    responseDeserialize: deserialize_pulumirpc_PluginInfo,
+  },
+  // Load returns the latest checkpoint of a stack.
+load: {
+    path: '/pulumirpc.SnapshotPersister/Load',
+    requestStream: false,
+    responseStream: false,
+    requestType: persister_pb.LoadCheckpointRequest,
+    responseType: persister_pb.LoadCheckpointResponse,
+    requestSerialize: serialize_pulumirpc_LoadCheckpointRequest,
+    requestDeserialize: deserialize_pulumirpc_LoadCheckpointRequest,
+    responseSerialize: serialize_pulumirpc_LoadCheckpointResponse,
+    responseDeserialize: deserialize_pulumirpc_LoadCheckpointResponse,
+  },
+  // Save streams the changes made to a stack's checkpoint over the course of an update. The first mutation in a
+// stream applies to an empty checkpoint, and each subsequent mutation applies to the result of the one before it.
+// The plugin must not respond until every mutation it has received is durable.
+save: {
+    path: '/pulumirpc.SnapshotPersister/Save',
+    requestStream: true,
+    responseStream: false,
+    requestType: persister_pb.CheckpointMutation,
+    responseType: google_protobuf_empty_pb.Empty,
+    requestSerialize: serialize_pulumirpc_CheckpointMutation,
+    requestDeserialize: deserialize_pulumirpc_CheckpointMutation,
+    responseSerialize: serialize_google_protobuf_Empty,
+    responseDeserialize: deserialize_google_protobuf_Empty,
+  },
+};
+
+exports.SnapshotPersisterClient = grpc.makeGenericClientConstructor(SnapshotPersisterService);
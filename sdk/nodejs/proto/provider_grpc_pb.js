@@ -33,6 +33,28 @@ function deserialize_google_protobuf_Empty(buffer_arg) {
   return google_protobuf_empty_pb.Empty.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_CallRequest(arg) {
+  if (!(arg instanceof provider_pb.CallRequest)) {
+    throw new Error('Expected argument of type pulumirpc.CallRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_CallRequest(buffer_arg) {
+  return provider_pb.CallRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_CallResponse(arg) {
+  if (!(arg instanceof provider_pb.CallResponse)) {
+    throw new Error('Expected argument of type pulumirpc.CallResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_CallResponse(buffer_arg) {
+  return provider_pb.CallResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_CheckRequest(arg) {
   if (!(arg instanceof provider_pb.CheckRequest)) {
     throw new Error('Expected argument of type pulumirpc.CheckRequest');
@@ -132,6 +154,17 @@ function deserialize_pulumirpc_DiffResponse(buffer_arg) {
   return provider_pb.DiffResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_GetLogsRequest(arg) {
+  if (!(arg instanceof provider_pb.GetLogsRequest)) {
+    throw new Error('Expected argument of type pulumirpc.GetLogsRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GetLogsRequest(buffer_arg) {
+  return provider_pb.GetLogsRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_GetSchemaRequest(arg) {
   if (!(arg instanceof provider_pb.GetSchemaRequest)) {
     throw new Error('Expected argument of type pulumirpc.GetSchemaRequest');
@@ -176,6 +209,17 @@ function deserialize_pulumirpc_InvokeResponse(buffer_arg) {
   return provider_pb.InvokeResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_LogEntry(arg) {
+  if (!(arg instanceof provider_pb.LogEntry)) {
+    throw new Error('Expected argument of type pulumirpc.LogEntry');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_LogEntry(buffer_arg) {
+  return provider_pb.LogEntry.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_PluginInfo(arg) {
   if (!(arg instanceof plugin_pb.PluginInfo)) {
     throw new Error('Expected argument of type pulumirpc.PluginInfo');
@@ -386,6 +430,32 @@ delete: {
     responseSerialize: serialize_google_protobuf_Empty,
     responseDeserialize: deserialize_google_protobuf_Empty,
   },
+  // GetLogs streams the log entries produced by a resource, oldest first. Unless the request asks to follow the
+// logs, the stream ends once all entries in the requested time range have been sent.
+getLogs: {
+    path: '/pulumirpc.ResourceProvider/GetLogs',
+    requestStream: false,
+    responseStream: true,
+    requestType: provider_pb.GetLogsRequest,
+    responseType: provider_pb.LogEntry,
+    requestSerialize: serialize_pulumirpc_GetLogsRequest,
+    requestDeserialize: deserialize_pulumirpc_GetLogsRequest,
+    responseSerialize: serialize_pulumirpc_LogEntry,
+    responseDeserialize: deserialize_pulumirpc_LogEntry,
+  },
+  // Call runs one of the operational methods, such as rebooting an instance, that a resource's schema declares
+// against an existing instance of that resource.
+call: {
+    path: '/pulumirpc.ResourceProvider/Call',
+    requestStream: false,
+    responseStream: false,
+    requestType: provider_pb.CallRequest,
+    responseType: provider_pb.CallResponse,
+    requestSerialize: serialize_pulumirpc_CallRequest,
+    requestDeserialize: deserialize_pulumirpc_CallRequest,
+    responseSerialize: serialize_pulumirpc_CallResponse,
+    responseDeserialize: deserialize_pulumirpc_CallResponse,
+  },
   // Cancel signals the provider to abort all outstanding resource operations.
 cancel: {
     path: '/pulumirpc.ResourceProvider/Cancel',
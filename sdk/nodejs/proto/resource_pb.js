@@ -1229,7 +1229,8 @@ proto.pulumirpc.RegisterResourceRequest.toObject = function(includeInstance, msg
     importid: jspb.Message.getFieldWithDefault(msg, 16, ""),
     customtimeouts: (f = msg.getCustomtimeouts()) && proto.pulumirpc.RegisterResourceRequest.CustomTimeouts.toObject(includeInstance, f),
     deletebeforereplacedefined: jspb.Message.getBooleanFieldWithDefault(msg, 18, false),
-    supportspartialvalues: jspb.Message.getBooleanFieldWithDefault(msg, 19, false)
+    supportspartialvalues: jspb.Message.getBooleanFieldWithDefault(msg, 19, false),
+    serializationgroup: jspb.Message.getFieldWithDefault(msg, 20, "")
   };
 
   if (includeInstance) {
@@ -1346,6 +1347,10 @@ proto.pulumirpc.RegisterResourceRequest.deserializeBinaryFromReader = function(m
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setSupportspartialvalues(value);
       break;
+    case 20:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setSerializationgroup(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1507,6 +1512,13 @@ proto.pulumirpc.RegisterResourceRequest.serializeBinaryToWriter = function(messa
       f
     );
   }
+  f = message.getSerializationgroup();
+  if (f.length > 0) {
+    writer.writeString(
+      20,
+      f
+    );
+  }
 };
 
 
@@ -2316,6 +2328,24 @@ proto.pulumirpc.RegisterResourceRequest.prototype.setSupportspartialvalues = fun
 };
 
 
+/**
+ * optional string serializationGroup = 20;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getSerializationgroup = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 20, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setSerializationgroup = function(value) {
+  return jspb.Message.setProto3StringField(this, 20, value);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -499,6 +499,11 @@ export interface ResourceOptions {
      * An optional customTimeouts configuration block.
      */
     customTimeouts?: CustomTimeouts;
+    /**
+     * An optional name of a group of resources that the engine never creates, updates, or deletes concurrently. This
+     * serializes changes for APIs that tolerate only one at a time without chaining the resources with dependsOn.
+     */
+    serializationGroup?: string;
     /**
      * Optional list of transformations to apply to this resource during construction. The
      * transformations are applied in order, and are applied prior to transformation applied to
@@ -605,10 +610,28 @@ export interface CustomResourceOptions extends ResourceOptions {
      */
     import?: ID;
 
+    /**
+     * An optional customAwait configuration block that controls how the resource's provider waits for the resource
+     * to become ready after it is created or updated.
+     */
+    customAwait?: CustomAwait;
+
     // !!! IMPORTANT !!! If you add a new field to this type, make sure to add test that verifies
     // that mergeOptions works properly for it.
 }
 
+export interface CustomAwait {
+    /**
+     * When set to true, the provider does not wait for the resource to become ready.
+     */
+    skip?: boolean;
+    /**
+     * An optional list of provider-specific conditions that must hold before the resource is considered ready.
+     * Providers that do not support custom await conditions ignore them.
+     */
+    conditions?: string[];
+}
+
 /**
  * ComponentResourceOptions is a bag of optional settings that control a component resource's behavior.
  */
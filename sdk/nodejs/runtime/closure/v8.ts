@@ -18,11 +18,14 @@
 // high-quality error messages.
 //
 // As a side-effect of importing this file, we must enable the --allow-natives-syntax V8 flag. This
-// is because we are using V8 intrinsics in order to implement this module.
+// is because we are using V8 intrinsics in order to implement this module. Runtimes other than
+// Node.js don't support the flag, and closure serialization fails with a clear error on them instead.
 import * as v8 from "v8";
-v8.setFlagsFromString("--allow-natives-syntax");
 
 import * as v8Hooks from "./v8Hooks";
+if (!v8Hooks.nonNodeRuntime) {
+    v8.setFlagsFromString("--allow-natives-syntax");
+}
 
 import * as v8_v10andLower from "./v8_v10andLower";
 import * as v8_v11andHigher from "./v8_v11andHigher";
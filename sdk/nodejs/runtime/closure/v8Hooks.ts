@@ -18,16 +18,29 @@
 // Node v11 and higher.
 
 import * as v8 from "v8";
-v8.setFlagsFromString("--allow-natives-syntax");
 
 import * as semver from "semver";
 
+/**
+ * The name of the JavaScript runtime hosting this process if it is not Node.js, i.e. "deno" or "bun". These runtimes
+ * run most Node.js programs, but do not provide the V8 intrinsics and inspector APIs that closure serialization needs.
+ * @internal
+ */
+export const nonNodeRuntime: string | undefined =
+    (<any>process.versions).deno ? "deno" :
+    (<any>process.versions).bun ? "bun" :
+    undefined;
+
+if (!nonNodeRuntime) {
+    v8.setFlagsFromString("--allow-natives-syntax");
+}
+
 // On node11 and above, create an 'inspector session' that can be used to keep track of what is
 // happening through a supported API.  Pre-11 we can just call into % intrinsics for the same data.
 /** @internal */
 export const isNodeAtLeastV11 = semver.gte(process.version, "11.0.0");
 
-const session = isNodeAtLeastV11
+const session = isNodeAtLeastV11 && !nonNodeRuntime
     ? createInspectorSessionAsync()
     : Promise.resolve<import("inspector").Session>(<any>undefined);
 
@@ -60,6 +73,11 @@ async function createInspectorSessionAsync(): Promise<import("inspector").Sessio
  * @internal
  */
 export async function getSessionAsync() {
+    if (nonNodeRuntime) {
+        throw new Error(
+            `Function serialization is not supported when running under ${nonNodeRuntime}, as it relies on ` +
+            `inspector APIs that only Node.js provides. Run this program with Node.js to use serialized functions.`);
+    }
     if (!isNodeAtLeastV11) {
         throw new Error("Should not call getSessionAsync unless on Node11 or above.");
     }
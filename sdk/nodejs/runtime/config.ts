@@ -19,6 +19,11 @@ const configEnvKey = "PULUMI_CONFIG";
 
 const config: {[key: string]: string} = parseConfig();
 
+/**
+ * configKeysRead records the configuration keys that the program has read, so that they can be reported to the engine.
+ */
+const configKeysRead = new Set<string>();
+
 /**
  * allConfig returns a copy of the full config map.
  */
@@ -37,9 +42,17 @@ export function setConfig(k: string, v: string): void {
  * getConfig returns a configuration variable's value or undefined if it is unset.
  */
 export function getConfig(k: string): string | undefined {
+    configKeysRead.add(k);
     return config[k];
 }
 
+/**
+ * getConfigKeysRead returns the configuration keys that the program has read, sorted.
+ */
+export function getConfigKeysRead(): string[] {
+    return Array.from(configKeysRead).sort();
+}
+
 function parseConfig() {
     const parsedConfig: {[key: string]: string} = {};
     const envConfig = process.env[configEnvKey];
@@ -65,6 +65,12 @@ export class MockMonitor {
 
     public async invoke(req: any, callback: (err: any, innerResponse: any) => void) {
         try {
+            // Reports of the configuration keys that were read are handled by the engine, so there is nothing to mock.
+            if (req.getTok() === "pulumi:pulumi:reportConfigUsage") {
+                callback(null, new provproto.InvokeResponse());
+                return;
+            }
+
             const result = this.mocks.call(req.getTok(), deserializeProperties(req.getArgs()), req.getProvider());
             const response = new provproto.InvokeResponse();
             response.setReturn(structproto.Struct.fromJavaScript(await serializeProperties("", result)));
@@ -22,6 +22,7 @@ import { ResolvedResource } from "../queryable";
 import {
     ComponentResource,
     createUrn,
+    CustomAwait,
     CustomResource,
     CustomResourceOptions,
     ID,
@@ -154,6 +155,22 @@ export function readResource(res: Resource, t: string, name: string, props: Inpu
     }), label);
 }
 
+/**
+ * customAwaitKey is the well-known input property used to transport a resource's custom await options to its provider.
+ */
+export const customAwaitKey = "__customAwait";
+
+function serializeCustomAwait(customAwait: CustomAwait): Record<string, any> {
+    const result: Record<string, any> = {};
+    if (customAwait.skip) {
+        result.skip = true;
+    }
+    if (customAwait.conditions !== undefined && customAwait.conditions.length > 0) {
+        result.conditions = customAwait.conditions;
+    }
+    return result;
+}
+
 /**
  * registerResource registers a new resource object with a given type t and name.  It returns the auto-generated
  * URN and the ID that will resolve after the deployment has completed.  All properties will be initialized to property
@@ -194,6 +211,7 @@ export function registerResource(res: Resource, t: string, name: string, custom:
         req.setAliasesList(resop.aliases);
         req.setImportid(resop.import || "");
         req.setSupportspartialvalues(true);
+        req.setSerializationgroup(opts.serializationGroup || "");
 
         const customTimeouts = new resproto.RegisterResourceRequest.CustomTimeouts();
         if (opts.customTimeouts != null) {
@@ -326,6 +344,11 @@ async function prepareResource(label: string, res: Resource, custom: boolean,
         const customOpts = <CustomResourceOptions>opts;
         importID = customOpts.import;
         providerRef = await ProviderResource.register(opts.provider);
+
+        // Custom await options are sent to the provider alongside the resource's inputs.
+        if (customOpts.customAwait !== undefined) {
+            serializedProps[customAwaitKey] = serializeCustomAwait(customOpts.customAwait);
+        }
     }
 
     // Collect the URNs for explicit/implicit dependencies for the engine so that it can understand
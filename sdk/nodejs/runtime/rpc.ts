@@ -244,6 +244,14 @@ export async function serializeProperty(ctx: string, prop: Input<any>, dependent
         return prop;
     }
 
+    if (Buffer.isBuffer(prop)) {
+        // Byte strings are sent as their base64 encoding.
+        if (excessiveDebugOutput) {
+            log.debug(`Serialize property [${ctx}]: Buffer`);
+        }
+        return prop.toString("base64");
+    }
+
     if (asset.Asset.isInstance(prop) || asset.Archive.isInstance(prop)) {
         // Serializing an asset or archive requires the use of a magical signature key, since otherwise it would look
         // like any old weakly typed object/map when received by the other side of the RPC boundary.
@@ -436,7 +444,10 @@ export function deserializeProperty(prop: any): any {
                         return new asset.StringAsset(<string>prop["text"]);
                     }
                     else if (prop["uri"]) {
-                        return new asset.RemoteAsset(<string>prop["uri"]);
+                        return new asset.RemoteAsset(<string>prop["uri"], {
+                            headers: prop["headers"],
+                            pinnedHash: prop["pinnedHash"],
+                        });
                     }
                     else {
                         throw new Error("Invalid asset encountered when unmarshaling resource property");
@@ -422,18 +422,30 @@ export async function setRootResource(res: ComponentResource): Promise<void> {
  * bit in a special way.
  */
 export function monitorSupportsSecrets(): Promise<boolean> {
+    return monitorSupportsFeature("secrets");
+}
+
+/**
+ * monitorSupportsConfigUsage returns a promise that when resolved tells you if the resource monitor we are connected
+ * to accepts reports of the configuration keys that the program read.
+ */
+export function monitorSupportsConfigUsage(): Promise<boolean> {
+    return monitorSupportsFeature("configUsage");
+}
+
+function monitorSupportsFeature(id: string): Promise<boolean> {
     const monitorRef: any = getMonitor();
     if (!monitorRef) {
         return Promise.resolve(false);
     }
 
     const req = new resproto.SupportsFeatureRequest();
-    req.setId("secrets");
+    req.setId(id);
 
     return new Promise<boolean>((resolve, reject) => {
         monitorRef.supportsFeature(req, (err: grpc.ServiceError, resp: any) => {
             // Back-compat case - if the monitor doesn't let us ask if it supports a feature, it doesn't support
-            // secrets.
+            // the feature.
             if (err && err.code === grpc.status.UNIMPLEMENTED) {
                 return resolve(false);
             }
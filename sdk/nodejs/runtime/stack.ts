@@ -16,7 +16,9 @@ import * as asset from "../asset";
 import { getProject, getStack } from "../metadata";
 import { Inputs, Output, output, secret } from "../output";
 import { ComponentResource, Resource, ResourceTransformation } from "../resource";
-import { getRootResource, isDryRun, isQueryMode, setRootResource } from "./settings";
+import { getConfigKeysRead } from "./config";
+import { invoke } from "./invoke";
+import { getRootResource, isDryRun, isQueryMode, monitorSupportsConfigUsage, setRootResource } from "./settings";
 
 /**
  * rootPulumiStackTypeName is the type name that should be used to construct the root component in the tree of Pulumi
@@ -89,10 +91,26 @@ class Stack extends ComponentResource<Inputs> {
             super.registerOutputs(outputs);
         }
 
+        await reportConfigUsage();
+
         return outputs!;
     }
 }
 
+/**
+ * reportConfigUsage reports the configuration keys that the program read to the engine, which compares them with the
+ * configuration of the stack. Keys that are not of the form "namespace:name" cannot be set in the stack's
+ * configuration, so they are not reported.
+ */
+async function reportConfigUsage(): Promise<void> {
+    if (!await monitorSupportsConfigUsage()) {
+        return;
+    }
+
+    const keys = getConfigKeysRead().filter(k => k.indexOf(":") >= 0);
+    await invoke("pulumi:pulumi:reportConfigUsage", { keys });
+}
+
 async function massage(prop: any, objectStack: any[]): Promise<any> {
     if (prop === undefined ||
         prop === null ||
@@ -55,6 +55,20 @@ describe("options", () => {
             }));
         });
 
+        describe("customAwait", () => {
+            it("overwrites value from opts1 if given value in opts2", asyncTest(async () => {
+                const result = mergeOptions({ customAwait: { skip: true } }, { customAwait: { conditions: ["Ready"] } });
+                assert.deepStrictEqual(result.customAwait, { conditions: ["Ready"] });
+            }));
+        });
+
+        describe("serializationGroup", () => {
+            it("overwrites value from opts1 if given value in opts2", asyncTest(async () => {
+                const result = mergeOptions({ serializationGroup: "dns" }, { serializationGroup: "iam" });
+                assert.strictEqual(result.serializationGroup, "iam");
+            }));
+        });
+
         describe("array", () => {
             it("keeps value from opts1 if not provided in opts2", asyncTest(async () => {
                 const result = mergeOptions({ ignoreChanges: ["a"] }, {});
@@ -15,7 +15,9 @@
 // tslint:disable
 
 import * as assert from "assert";
-import { Output, all, concat, interpolate, output, unknown } from "../output";
+import * as fs from "fs";
+import * as path from "path";
+import { Output, all, concat, interpolate, jsonParse, jsonStringify, output, secret, unknown } from "../output";
 import { Resource } from "../resource";
 import * as runtime from "../runtime";
 import { asyncTest } from "./util";
@@ -996,3 +998,67 @@ describe("output", () => {
         }));
     });
 });
+
+describe("json", () => {
+    it("stringifies nested inputs", asyncTest(async () => {
+        runtime._setIsDryRun(false);
+
+        const result = jsonStringify({ bucket: secret("foo"), paths: ["a", Promise.resolve("b")] });
+        assert.equal(await result.isKnown, true);
+        assert.equal(await result.isSecret, true);
+        assert.equal(await result.promise(), `{"bucket":"foo","paths":["a","b"]}`);
+    }));
+
+    it("is unknown if a nested input is unknown", asyncTest(async () => {
+        runtime._setIsDryRun(true);
+
+        const inner = new Output(new Set(), Promise.resolve("foo"), Promise.resolve(false), Promise.resolve(false),
+            Promise.resolve(new Set()));
+        const result = jsonStringify({ bucket: inner });
+        assert.equal(await result.isKnown, false);
+    }));
+
+    it("parses secret text", asyncTest(async () => {
+        runtime._setIsDryRun(false);
+
+        const result = jsonParse(secret(`{"paths":["a","b"]}`));
+        assert.equal(await result.isSecret, true);
+        assert.deepEqual(await result.promise(), { paths: ["a", "b"] });
+    }));
+});
+
+// The cases shared by all of the SDKs that describe how an apply whose callback returns an output is flattened.
+describe("apply conformance", () => {
+    const suite = JSON.parse(fs.readFileSync(
+        path.join(__dirname, "..", "..", "..", "conformance", "apply.json"), "utf8"));
+
+    // Resources are represented by their names.
+    const resources = new Map<string, Resource>();
+    function createOutput(spec: any): Output<any> {
+        const deps = spec.resources.map((name: string) => {
+            if (!resources.has(name)) {
+                resources.set(name, <any>{ name });
+            }
+            return resources.get(name)!;
+        });
+        return new Output(deps, Promise.resolve(spec.value), Promise.resolve(spec.known),
+            Promise.resolve(spec.secret), Promise.resolve(deps));
+    }
+
+    for (const c of suite.cases) {
+        it(c.name, asyncTest(async () => {
+            runtime._setIsDryRun(true);
+
+            const inner = createOutput(c.inner);
+            const result = createOutput(c.outer).apply(_ => inner);
+
+            assert.equal(await result.isKnown, c.result.known);
+            assert.equal(await result.isSecret, c.result.secret);
+            const names = Array.from(await result.allResources!()).map((r: any) => r.name).sort();
+            assert.deepEqual(names, c.result.resources.slice().sort());
+            if (c.result.known) {
+                assert.equal(await result.promise(), c.result.value);
+            }
+        }));
+    }
+});
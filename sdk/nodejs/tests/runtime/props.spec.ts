@@ -41,6 +41,15 @@ describe("runtime", () => {
             assert.equal(result.id, "foo");
             assert.equal(result.urn, "bar");
         }));
+        it("marshals buffers as base64 strings", asyncTest(async () => {
+            const inputs: Inputs = {
+                "aBuf": Buffer.from([0xde, 0xad, 0xbe, 0xef]),
+            };
+            const transfer = gstruct.Struct.fromJavaScript(
+                await runtime.serializeProperties("test", inputs));
+            const result = runtime.deserializeProperties(transfer);
+            assert.equal(result.aBuf, "3q2+7w==");
+        }));
         it("marshals secrets correctly", asyncTest(async () => {
             runtime._setTestModeEnabled(true);
             const inputs: Inputs = {
@@ -0,0 +1,426 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: persister.proto
+
+package pulumirpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type LoadCheckpointRequest struct {
+	Stack                string   `protobuf:"bytes,1,opt,name=stack,proto3" json:"stack,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoadCheckpointRequest) Reset()         { *m = LoadCheckpointRequest{} }
+func (m *LoadCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadCheckpointRequest) ProtoMessage()    {}
+func (*LoadCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd8bb59e70df2ed3, []int{0}
+}
+
+func (m *LoadCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadCheckpointRequest.Unmarshal(m, b)
+}
+func (m *LoadCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *LoadCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadCheckpointRequest.Merge(m, src)
+}
+func (m *LoadCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_LoadCheckpointRequest.Size(m)
+}
+func (m *LoadCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadCheckpointRequest proto.InternalMessageInfo
+
+func (m *LoadCheckpointRequest) GetStack() string {
+	if m != nil {
+		return m.Stack
+	}
+	return ""
+}
+
+type LoadCheckpointResponse struct {
+	Version              int32    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Deployment           []byte   `protobuf:"bytes,2,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoadCheckpointResponse) Reset()         { *m = LoadCheckpointResponse{} }
+func (m *LoadCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*LoadCheckpointResponse) ProtoMessage()    {}
+func (*LoadCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd8bb59e70df2ed3, []int{1}
+}
+
+func (m *LoadCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadCheckpointResponse.Unmarshal(m, b)
+}
+func (m *LoadCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *LoadCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadCheckpointResponse.Merge(m, src)
+}
+func (m *LoadCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_LoadCheckpointResponse.Size(m)
+}
+func (m *LoadCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadCheckpointResponse proto.InternalMessageInfo
+
+func (m *LoadCheckpointResponse) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *LoadCheckpointResponse) GetDeployment() []byte {
+	if m != nil {
+		return m.Deployment
+	}
+	return nil
+}
+
+// CheckpointMutation is a change to a stack's checkpoint. The deployment's resources are replaced by splicing: the
+// mutation removes deleteCount resources at index start and inserts its own resources in their place.
+type CheckpointMutation struct {
+	Stack                string   `protobuf:"bytes,1,opt,name=stack,proto3" json:"stack,omitempty"`
+	Version              int32    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Header               []byte   `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
+	Start                int32    `protobuf:"varint,4,opt,name=start,proto3" json:"start,omitempty"`
+	DeleteCount          int32    `protobuf:"varint,5,opt,name=deleteCount,proto3" json:"deleteCount,omitempty"`
+	Resources            [][]byte `protobuf:"bytes,6,rep,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointMutation) Reset()         { *m = CheckpointMutation{} }
+func (m *CheckpointMutation) String() string { return proto.CompactTextString(m) }
+func (*CheckpointMutation) ProtoMessage()    {}
+func (*CheckpointMutation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd8bb59e70df2ed3, []int{2}
+}
+
+func (m *CheckpointMutation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointMutation.Unmarshal(m, b)
+}
+func (m *CheckpointMutation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointMutation.Marshal(b, m, deterministic)
+}
+func (m *CheckpointMutation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointMutation.Merge(m, src)
+}
+func (m *CheckpointMutation) XXX_Size() int {
+	return xxx_messageInfo_CheckpointMutation.Size(m)
+}
+func (m *CheckpointMutation) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointMutation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointMutation proto.InternalMessageInfo
+
+func (m *CheckpointMutation) GetStack() string {
+	if m != nil {
+		return m.Stack
+	}
+	return ""
+}
+
+func (m *CheckpointMutation) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *CheckpointMutation) GetHeader() []byte {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CheckpointMutation) GetStart() int32 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *CheckpointMutation) GetDeleteCount() int32 {
+	if m != nil {
+		return m.DeleteCount
+	}
+	return 0
+}
+
+func (m *CheckpointMutation) GetResources() [][]byte {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*LoadCheckpointRequest)(nil), "pulumirpc.LoadCheckpointRequest")
+	proto.RegisterType((*LoadCheckpointResponse)(nil), "pulumirpc.LoadCheckpointResponse")
+	proto.RegisterType((*CheckpointMutation)(nil), "pulumirpc.CheckpointMutation")
+}
+
+func init() {
+	proto.RegisterFile("persister.proto", fileDescriptor_cd8bb59e70df2ed3)
+}
+
+var fileDescriptor_cd8bb59e70df2ed3 = []byte{
+	// 339 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x51, 0xed, 0x4e, 0xc2, 0x40,
+	0x10, 0xa4, 0x7c, 0x19, 0x56, 0x8c, 0xf1, 0x22, 0xa4, 0xa9, 0x1f, 0xa9, 0xfd, 0xd5, 0x3f, 0x96,
+	0x44, 0x5f, 0x40, 0x43, 0x8c, 0x31, 0x91, 0x84, 0x94, 0x27, 0x28, 0xed, 0x02, 0x0d, 0xe5, 0xee,
+	0xbc, 0xdb, 0x23, 0xe1, 0xb5, 0x7c, 0x29, 0x5f, 0xc3, 0xb4, 0x05, 0xa9, 0x8a, 0xfe, 0x9c, 0xd9,
+	0xd9, 0xdd, 0xc9, 0x0c, 0x9c, 0x4a, 0x54, 0x3a, 0xd5, 0x84, 0x2a, 0x90, 0x4a, 0x90, 0x60, 0x1d,
+	0x69, 0x32, 0xb3, 0x4a, 0x95, 0x8c, 0x9d, 0xae, 0xcc, 0xcc, 0x3c, 0xe5, 0xe5, 0xc0, 0xb9, 0x98,
+	0x0b, 0x31, 0xcf, 0x70, 0x50, 0xa0, 0xa9, 0x99, 0x0d, 0x70, 0x25, 0x69, 0x53, 0x0e, 0xbd, 0x5b,
+	0xe8, 0xbd, 0x8a, 0x28, 0x19, 0x2e, 0x30, 0x5e, 0x4a, 0x91, 0x72, 0x0a, 0xf1, 0xcd, 0xa0, 0x26,
+	0x76, 0x0e, 0x2d, 0x4d, 0x51, 0xbc, 0xb4, 0x2d, 0xd7, 0xf2, 0x3b, 0x61, 0x09, 0xbc, 0x10, 0xfa,
+	0x3f, 0xe5, 0x5a, 0x0a, 0xae, 0x91, 0xd9, 0x70, 0xb4, 0xce, 0x1d, 0x09, 0x5e, 0x6c, 0xb4, 0xc2,
+	0x1d, 0x64, 0xd7, 0x00, 0x09, 0xca, 0x4c, 0x6c, 0x56, 0xc8, 0xc9, 0xae, 0xbb, 0x96, 0xdf, 0x0d,
+	0x2b, 0x8c, 0xf7, 0x6e, 0x01, 0xdb, 0x1f, 0x1c, 0x19, 0x8a, 0x28, 0x5f, 0x3b, 0x68, 0xa0, 0xfa,
+	0xa6, 0xfe, 0xfd, 0x4d, 0x1f, 0xda, 0x0b, 0x8c, 0x12, 0x54, 0x76, 0xa3, 0x78, 0xb1, 0x45, 0xdb,
+	0x3b, 0x8a, 0xec, 0x66, 0xa1, 0x2f, 0x01, 0x73, 0xe1, 0x38, 0xc1, 0x0c, 0x09, 0x87, 0xc2, 0x70,
+	0xb2, 0x5b, 0xc5, 0xac, 0x4a, 0xb1, 0x4b, 0xe8, 0x28, 0xd4, 0xc2, 0xa8, 0x18, 0xb5, 0xdd, 0x76,
+	0x1b, 0x7e, 0x37, 0xdc, 0x13, 0x77, 0x1f, 0x16, 0x9c, 0x4d, 0x78, 0x24, 0xf5, 0x42, 0xd0, 0x78,
+	0xd7, 0x04, 0x7b, 0x80, 0x93, 0x67, 0xa4, 0x71, 0x91, 0xfe, 0x0b, 0x9f, 0x09, 0xd6, 0x0f, 0xca,
+	0xf0, 0x83, 0x5d, 0xf8, 0xc1, 0x53, 0x1e, 0xbe, 0xd3, 0x0b, 0xbe, 0xda, 0x0a, 0xf6, 0x72, 0xaf,
+	0xc6, 0x46, 0xd0, 0xcc, 0x03, 0x66, 0x6e, 0x45, 0x70, 0xb0, 0x20, 0xe7, 0xe6, 0x1f, 0x45, 0xd9,
+	0x89, 0x57, 0x63, 0x8f, 0xd0, 0x9c, 0x44, 0x6b, 0x64, 0x57, 0x15, 0xf1, 0xef, 0xac, 0x9d, 0x3f,
+	0x6c, 0x7a, 0x35, 0xdf, 0x9a, 0xb6, 0x0b, 0xee, 0xfe, 0x73, 0x00, 0x07, 0x02, 0x35, 0xc7, 0x71,
+	0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SnapshotPersisterClient is the client API for SnapshotPersister service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SnapshotPersisterClient interface {
+	// GetPluginInfo returns generic information about this plugin, like its version.
+	GetPluginInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PluginInfo, error)
+	// Load returns the latest checkpoint of a stack.
+	Load(ctx context.Context, in *LoadCheckpointRequest, opts ...grpc.CallOption) (*LoadCheckpointResponse, error)
+	// Save streams the changes made to a stack's checkpoint over the course of an update. The first mutation in a
+	// stream applies to an empty checkpoint, and each subsequent mutation applies to the result of the one before it.
+	// The plugin must not respond until every mutation it has received is durable.
+	Save(ctx context.Context, opts ...grpc.CallOption) (SnapshotPersister_SaveClient, error)
+}
+
+type snapshotPersisterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnapshotPersisterClient(cc grpc.ClientConnInterface) SnapshotPersisterClient {
+	return &snapshotPersisterClient{cc}
+}
+
+func (c *snapshotPersisterClient) GetPluginInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PluginInfo, error) {
+	out := new(PluginInfo)
+	err := c.cc.Invoke(ctx, "/pulumirpc.SnapshotPersister/GetPluginInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapshotPersisterClient) Load(ctx context.Context, in *LoadCheckpointRequest, opts ...grpc.CallOption) (*LoadCheckpointResponse, error) {
+	out := new(LoadCheckpointResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.SnapshotPersister/Load", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapshotPersisterClient) Save(ctx context.Context, opts ...grpc.CallOption) (SnapshotPersister_SaveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SnapshotPersister_serviceDesc.Streams[0], "/pulumirpc.SnapshotPersister/Save", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &snapshotPersisterSaveClient{stream}
+	return x, nil
+}
+
+type SnapshotPersister_SaveClient interface {
+	Send(*CheckpointMutation) error
+	CloseAndRecv() (*empty.Empty, error)
+	grpc.ClientStream
+}
+
+type snapshotPersisterSaveClient struct {
+	grpc.ClientStream
+}
+
+func (x *snapshotPersisterSaveClient) Send(m *CheckpointMutation) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *snapshotPersisterSaveClient) CloseAndRecv() (*empty.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(empty.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SnapshotPersisterServer is the server API for SnapshotPersister service.
+type SnapshotPersisterServer interface {
+	// GetPluginInfo returns generic information about this plugin, like its version.
+	GetPluginInfo(context.Context, *empty.Empty) (*PluginInfo, error)
+	// Load returns the latest checkpoint of a stack.
+	Load(context.Context, *LoadCheckpointRequest) (*LoadCheckpointResponse, error)
+	// Save streams the changes made to a stack's checkpoint over the course of an update. The first mutation in a
+	// stream applies to an empty checkpoint, and each subsequent mutation applies to the result of the one before it.
+	// The plugin must not respond until every mutation it has received is durable.
+	Save(SnapshotPersister_SaveServer) error
+}
+
+// UnimplementedSnapshotPersisterServer can be embedded to have forward compatible implementations.
+type UnimplementedSnapshotPersisterServer struct {
+}
+
+func (*UnimplementedSnapshotPersisterServer) GetPluginInfo(ctx context.Context, req *empty.Empty) (*PluginInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPluginInfo not implemented")
+}
+func (*UnimplementedSnapshotPersisterServer) Load(ctx context.Context, req *LoadCheckpointRequest) (*LoadCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Load not implemented")
+}
+func (*UnimplementedSnapshotPersisterServer) Save(srv SnapshotPersister_SaveServer) error {
+	return status.Errorf(codes.Unimplemented, "method Save not implemented")
+}
+
+func RegisterSnapshotPersisterServer(s *grpc.Server, srv SnapshotPersisterServer) {
+	s.RegisterService(&_SnapshotPersister_serviceDesc, srv)
+}
+
+func _SnapshotPersister_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotPersisterServer).GetPluginInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.SnapshotPersister/GetPluginInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotPersisterServer).GetPluginInfo(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapshotPersister_Load_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotPersisterServer).Load(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.SnapshotPersister/Load",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotPersisterServer).Load(ctx, req.(*LoadCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapshotPersister_Save_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SnapshotPersisterServer).Save(&snapshotPersisterSaveServer{stream})
+}
+
+type SnapshotPersister_SaveServer interface {
+	SendAndClose(*empty.Empty) error
+	Recv() (*CheckpointMutation, error)
+	grpc.ServerStream
+}
+
+type snapshotPersisterSaveServer struct {
+	grpc.ServerStream
+}
+
+func (x *snapshotPersisterSaveServer) SendAndClose(m *empty.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *snapshotPersisterSaveServer) Recv() (*CheckpointMutation, error) {
+	m := new(CheckpointMutation)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _SnapshotPersister_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pulumirpc.SnapshotPersister",
+	HandlerType: (*SnapshotPersisterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPluginInfo",
+			Handler:    _SnapshotPersister_GetPluginInfo_Handler,
+		},
+		{
+			MethodName: "Load",
+			Handler:    _SnapshotPersister_Load_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Save",
+			Handler:       _SnapshotPersister_Save_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "persister.proto",
+}
@@ -1010,12 +1010,13 @@ func (m *ReadRequest) GetInputs() *_struct.Struct {
 }
 
 type ReadResponse struct {
-	Id                   string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties,proto3" json:"properties,omitempty"`
-	Inputs               *_struct.Struct `protobuf:"bytes,3,opt,name=inputs,proto3" json:"inputs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Id                   string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Properties           *_struct.Struct  `protobuf:"bytes,2,opt,name=properties,proto3" json:"properties,omitempty"`
+	Inputs               *_struct.Struct  `protobuf:"bytes,3,opt,name=inputs,proto3" json:"inputs,omitempty"`
+	Children             []*ChildResource `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
@@ -1064,6 +1065,13 @@ func (m *ReadResponse) GetInputs() *_struct.Struct {
 	return nil
 }
 
+func (m *ReadResponse) GetChildren() []*ChildResource {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
 type UpdateRequest struct {
 	Id                   string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Urn                  string          `protobuf:"bytes,2,opt,name=urn,proto3" json:"urn,omitempty"`
@@ -1310,6 +1318,322 @@ func (m *ErrorResourceInitFailed) GetInputs() *_struct.Struct {
 	return nil
 }
 
+type GetLogsRequest struct {
+	Urn                  string          `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
+	Id                   string          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,3,opt,name=properties,proto3" json:"properties,omitempty"`
+	StartTime            int64           `protobuf:"varint,4,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              int64           `protobuf:"varint,5,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Follow               bool            `protobuf:"varint,6,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetLogsRequest) Reset()         { *m = GetLogsRequest{} }
+func (m *GetLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLogsRequest) ProtoMessage()    {}
+func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{21}
+}
+
+func (m *GetLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsRequest.Unmarshal(m, b)
+}
+func (m *GetLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsRequest.Merge(m, src)
+}
+func (m *GetLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLogsRequest.Size(m)
+}
+func (m *GetLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsRequest proto.InternalMessageInfo
+
+func (m *GetLogsRequest) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *GetLogsRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetLogsRequest) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *GetLogsRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *GetLogsRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *GetLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+// LogEntry is a single line of output from a running resource, such as a function invocation or a container.
+type LogEntry struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp            int64    `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{22}
+}
+
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (m *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(m, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LogEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *LogEntry) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type CallRequest struct {
+	Urn                  string          `protobuf:"bytes,1,opt,name=urn,proto3" json:"urn,omitempty"`
+	Id                   string          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,3,opt,name=properties,proto3" json:"properties,omitempty"`
+	Method               string          `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	Args                 *_struct.Struct `protobuf:"bytes,5,opt,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CallRequest) Reset()         { *m = CallRequest{} }
+func (m *CallRequest) String() string { return proto.CompactTextString(m) }
+func (*CallRequest) ProtoMessage()    {}
+func (*CallRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{23}
+}
+
+func (m *CallRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallRequest.Unmarshal(m, b)
+}
+func (m *CallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallRequest.Marshal(b, m, deterministic)
+}
+func (m *CallRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallRequest.Merge(m, src)
+}
+func (m *CallRequest) XXX_Size() int {
+	return xxx_messageInfo_CallRequest.Size(m)
+}
+func (m *CallRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallRequest proto.InternalMessageInfo
+
+func (m *CallRequest) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *CallRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CallRequest) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *CallRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *CallRequest) GetArgs() *_struct.Struct {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+type CallResponse struct {
+	Return               *_struct.Struct `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	Failures             []*CheckFailure `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CallResponse) Reset()         { *m = CallResponse{} }
+func (m *CallResponse) String() string { return proto.CompactTextString(m) }
+func (*CallResponse) ProtoMessage()    {}
+func (*CallResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{24}
+}
+
+func (m *CallResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallResponse.Unmarshal(m, b)
+}
+func (m *CallResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallResponse.Marshal(b, m, deterministic)
+}
+func (m *CallResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallResponse.Merge(m, src)
+}
+func (m *CallResponse) XXX_Size() int {
+	return xxx_messageInfo_CallResponse.Size(m)
+}
+func (m *CallResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallResponse proto.InternalMessageInfo
+
+func (m *CallResponse) GetReturn() *_struct.Struct {
+	if m != nil {
+		return m.Return
+	}
+	return nil
+}
+
+func (m *CallResponse) GetFailures() []*CheckFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
+type ChildResource struct {
+	Type                 string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name                 string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Id                   string          `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,4,opt,name=properties,proto3" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ChildResource) Reset()         { *m = ChildResource{} }
+func (m *ChildResource) String() string { return proto.CompactTextString(m) }
+func (*ChildResource) ProtoMessage()    {}
+func (*ChildResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6a9f3c02af3d1c8, []int{25}
+}
+
+func (m *ChildResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChildResource.Unmarshal(m, b)
+}
+func (m *ChildResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChildResource.Marshal(b, m, deterministic)
+}
+func (m *ChildResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChildResource.Merge(m, src)
+}
+func (m *ChildResource) XXX_Size() int {
+	return xxx_messageInfo_ChildResource.Size(m)
+}
+func (m *ChildResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChildResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChildResource proto.InternalMessageInfo
+
+func (m *ChildResource) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ChildResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ChildResource) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ChildResource) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("pulumirpc.PropertyDiff_Kind", PropertyDiff_Kind_name, PropertyDiff_Kind_value)
 	proto.RegisterEnum("pulumirpc.DiffResponse_DiffChanges", DiffResponse_DiffChanges_name, DiffResponse_DiffChanges_value)
@@ -1337,92 +1661,110 @@ func init() {
 	proto.RegisterType((*UpdateResponse)(nil), "pulumirpc.UpdateResponse")
 	proto.RegisterType((*DeleteRequest)(nil), "pulumirpc.DeleteRequest")
 	proto.RegisterType((*ErrorResourceInitFailed)(nil), "pulumirpc.ErrorResourceInitFailed")
+	proto.RegisterType((*GetLogsRequest)(nil), "pulumirpc.GetLogsRequest")
+	proto.RegisterType((*LogEntry)(nil), "pulumirpc.LogEntry")
+	proto.RegisterType((*CallRequest)(nil), "pulumirpc.CallRequest")
+	proto.RegisterType((*CallResponse)(nil), "pulumirpc.CallResponse")
+	proto.RegisterType((*ChildResource)(nil), "pulumirpc.ChildResource")
 }
 
 func init() { proto.RegisterFile("provider.proto", fileDescriptor_c6a9f3c02af3d1c8) }
 
 var fileDescriptor_c6a9f3c02af3d1c8 = []byte{
-	// 1274 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xb6, 0x2c, 0xc7, 0x89, 0x8f, 0x7f, 0xaa, 0x2e, 0xd0, 0xba, 0x6e, 0x2e, 0x3c, 0x82, 0x0b,
-	0x43, 0xc1, 0xe9, 0xa4, 0x17, 0xd0, 0x4e, 0x3b, 0x25, 0x89, 0x9d, 0x36, 0xd3, 0x36, 0x0d, 0x4a,
-	0xcb, 0xcf, 0x55, 0x51, 0xa5, 0xb5, 0xa3, 0xb1, 0x2d, 0x89, 0xd5, 0xca, 0x4c, 0xb8, 0xe6, 0x82,
-	0x57, 0xe0, 0x09, 0xb8, 0x62, 0x98, 0xe1, 0x09, 0xb8, 0xe7, 0x19, 0x78, 0x04, 0xde, 0x81, 0xd9,
-	0x1f, 0xc9, 0xbb, 0xb6, 0x93, 0x3a, 0xa1, 0x03, 0x77, 0x3a, 0x7b, 0xce, 0xee, 0x39, 0xdf, 0xb7,
-	0x67, 0xbf, 0x5d, 0x41, 0x23, 0x26, 0xd1, 0x34, 0xf0, 0x31, 0xe9, 0xc6, 0x24, 0xa2, 0x11, 0xaa,
-	0xc4, 0xe9, 0x38, 0x9d, 0x04, 0x24, 0xf6, 0x5a, 0xb5, 0x78, 0x9c, 0x0e, 0x83, 0x50, 0x38, 0x5a,
-	0x37, 0x87, 0x51, 0x34, 0x1c, 0xe3, 0x2d, 0x6e, 0xbd, 0x4e, 0x07, 0x5b, 0x78, 0x12, 0xd3, 0x53,
-	0xe9, 0xdc, 0x9c, 0x77, 0x26, 0x94, 0xa4, 0x1e, 0x15, 0x5e, 0xfb, 0x63, 0xb0, 0x1e, 0x61, 0x7a,
-	0xec, 0x9d, 0xe0, 0x89, 0xeb, 0xe0, 0xef, 0x52, 0x9c, 0x50, 0xd4, 0x84, 0xf5, 0x29, 0x26, 0x49,
-	0x10, 0x85, 0x4d, 0xa3, 0x6d, 0x74, 0xd6, 0x9c, 0xcc, 0xb4, 0x6f, 0xc1, 0x55, 0x25, 0x3a, 0x89,
-	0xa3, 0x30, 0xc1, 0xe8, 0x1a, 0x94, 0x13, 0x3e, 0xc2, 0xa3, 0x2b, 0x8e, 0xb4, 0xec, 0xbf, 0x0d,
-	0xb0, 0xf6, 0xa2, 0x70, 0x10, 0x0c, 0x53, 0x82, 0xb3, 0xb5, 0x1f, 0x43, 0x65, 0xea, 0x92, 0xc0,
-	0x7d, 0x3d, 0xc6, 0x49, 0xd3, 0x68, 0x9b, 0x9d, 0xea, 0xf6, 0x47, 0xdd, 0x1c, 0x57, 0x77, 0x3e,
-	0xbe, 0xfb, 0x65, 0x16, 0xdc, 0x0f, 0x29, 0x39, 0x75, 0x66, 0x93, 0xd1, 0x2d, 0x28, 0xb9, 0x64,
-	0x98, 0x34, 0x8b, 0x6d, 0xa3, 0x53, 0xdd, 0xbe, 0xde, 0x15, 0x30, 0xbb, 0x19, 0xcc, 0xee, 0x31,
-	0x87, 0xe9, 0xf0, 0x20, 0xf4, 0x01, 0xd4, 0x5d, 0xcf, 0xc3, 0x31, 0x3d, 0xc6, 0x1e, 0xc1, 0x34,
-	0x69, 0x9a, 0x6d, 0xa3, 0xb3, 0xe1, 0xe8, 0x83, 0xad, 0xfb, 0xd0, 0xd0, 0xf3, 0x21, 0x0b, 0xcc,
-	0x11, 0x3e, 0x95, 0xc0, 0xd8, 0x27, 0x7a, 0x17, 0xd6, 0xa6, 0xee, 0x38, 0xc5, 0x3c, 0x6f, 0xc5,
-	0x11, 0xc6, 0xbd, 0xe2, 0x67, 0x86, 0x7d, 0x17, 0xae, 0x2a, 0xe5, 0x4b, 0x72, 0x16, 0x12, 0x1b,
-	0x4b, 0x12, 0xdb, 0xbf, 0x1b, 0x70, 0x23, 0x9f, 0xdb, 0x27, 0x24, 0x22, 0xcf, 0x82, 0x24, 0x09,
-	0xc2, 0xe1, 0x13, 0x7c, 0x9a, 0xa0, 0x2f, 0xa0, 0x3a, 0x99, 0x99, 0x92, 0xb5, 0xad, 0x65, 0xac,
-	0xcd, 0x4f, 0xed, 0xce, 0xbe, 0x1d, 0x75, 0x8d, 0xd6, 0x2e, 0xc0, 0xcc, 0x85, 0x10, 0x94, 0x42,
-	0x77, 0x82, 0x25, 0x4c, 0xfe, 0x8d, 0xda, 0x50, 0xf5, 0x71, 0xe2, 0x91, 0x20, 0xa6, 0xac, 0x11,
-	0x04, 0x5a, 0x75, 0xc8, 0xfe, 0xd1, 0x80, 0xfa, 0x41, 0x38, 0x8d, 0x46, 0xf9, 0xe6, 0x5a, 0x60,
-	0xd2, 0x68, 0x94, 0xb1, 0x45, 0xa3, 0xd1, 0xc5, 0x36, 0xa9, 0x05, 0x1b, 0x59, 0xc7, 0xf3, 0xfd,
-	0xa9, 0x38, 0xb9, 0xad, 0xf6, 0x64, 0x89, 0xbb, 0xf2, 0x9e, 0x9c, 0x42, 0x23, 0xab, 0x42, 0x72,
-	0xbe, 0x05, 0x65, 0x82, 0x69, 0x4a, 0x44, 0xfb, 0x9e, 0x93, 0x56, 0x86, 0xa1, 0x3b, 0xb0, 0x31,
-	0x70, 0x83, 0x71, 0x4a, 0x30, 0xab, 0xd4, 0xe4, 0x53, 0x14, 0x76, 0x4f, 0xb0, 0x37, 0xda, 0x17,
-	0x7e, 0x27, 0x0f, 0xb4, 0x7f, 0x80, 0x1a, 0xf7, 0x28, 0xe0, 0xb3, 0x94, 0x15, 0x87, 0x7d, 0x32,
-	0xf0, 0xd1, 0xd8, 0x7f, 0x33, 0x78, 0x16, 0xc4, 0x82, 0x43, 0xfc, 0xbd, 0x68, 0xcc, 0xf3, 0x82,
-	0x59, 0x90, 0x9d, 0x42, 0x5d, 0xe6, 0x9e, 0x41, 0x0e, 0xc2, 0x38, 0x95, 0xfd, 0x75, 0x1e, 0x64,
-	0x11, 0x76, 0x39, 0xc8, 0xbb, 0x12, 0xb2, 0xf4, 0xc8, 0x0d, 0x8b, 0x31, 0xa1, 0xd9, 0x11, 0xc9,
-	0x6d, 0xa6, 0x0a, 0x04, 0xbb, 0x49, 0xde, 0x3a, 0xd2, 0xb2, 0x7f, 0x33, 0xa0, 0xda, 0x0b, 0x06,
-	0x83, 0x8c, 0xb6, 0x06, 0x14, 0x03, 0x5f, 0xce, 0x2e, 0x06, 0x7e, 0x46, 0x63, 0x71, 0x91, 0x46,
-	0xf3, 0x22, 0x34, 0x96, 0x56, 0xa0, 0x91, 0x1d, 0xce, 0x60, 0x18, 0x46, 0x04, 0xef, 0x9d, 0xb8,
-	0xe1, 0x10, 0x27, 0xcd, 0xb5, 0xb6, 0xd9, 0xa9, 0x38, 0xfa, 0xa0, 0xfd, 0x87, 0x01, 0xb5, 0x23,
-	0x09, 0x8b, 0x55, 0x8e, 0x6e, 0x43, 0x69, 0x14, 0x84, 0xa2, 0xe8, 0xc6, 0xf6, 0xa6, 0xc2, 0x9b,
-	0x1a, 0xd6, 0x7d, 0x12, 0x84, 0xbe, 0xc3, 0x23, 0xd1, 0x26, 0x54, 0x38, 0xef, 0x6c, 0x9c, 0x43,
-	0xdb, 0x70, 0x66, 0x03, 0xf6, 0xb7, 0x50, 0x62, 0xb1, 0x68, 0x1d, 0xcc, 0x9d, 0x5e, 0xcf, 0x2a,
-	0xa0, 0x2b, 0x50, 0xdd, 0xe9, 0xf5, 0x5e, 0x39, 0xfd, 0xa3, 0xa7, 0x3b, 0x7b, 0x7d, 0xcb, 0x40,
-	0x00, 0xe5, 0x5e, 0xff, 0x69, 0xff, 0x45, 0xdf, 0x2a, 0x22, 0x04, 0x0d, 0xf1, 0x9d, 0xfb, 0x4d,
-	0xe6, 0x7f, 0x79, 0xd4, 0xdb, 0x79, 0xd1, 0xb7, 0x4a, 0xcc, 0x2f, 0xbe, 0x73, 0xff, 0x9a, 0xfd,
-	0x97, 0x09, 0x35, 0x41, 0xba, 0xec, 0x97, 0x16, 0x6c, 0x10, 0x1c, 0x8f, 0x5d, 0x4f, 0xaa, 0x70,
-	0xc5, 0xc9, 0x6d, 0x76, 0xd4, 0x12, 0x2a, 0x04, 0xba, 0xc8, 0x5d, 0x99, 0x89, 0x6e, 0xc3, 0x3b,
-	0x3e, 0x1e, 0x63, 0x8a, 0x77, 0xf1, 0x20, 0x62, 0x22, 0xc7, 0x67, 0x48, 0x2d, 0x5d, 0xe6, 0x42,
-	0x0f, 0x60, 0xdd, 0x93, 0xdc, 0x96, 0x38, 0x5b, 0xef, 0x2b, 0x6c, 0xa9, 0x15, 0x71, 0x43, 0x32,
-	0xee, 0x64, 0x73, 0x98, 0xd8, 0xfa, 0xc1, 0x60, 0x90, 0x6d, 0x8c, 0x30, 0xd0, 0x33, 0xa8, 0xf9,
-	0x98, 0xba, 0xc1, 0x18, 0xfb, 0x9c, 0xd0, 0x32, 0xef, 0xdf, 0x0f, 0xcf, 0x5c, 0x59, 0x89, 0x15,
-	0xb7, 0x88, 0x36, 0x1d, 0x75, 0xe0, 0xca, 0x89, 0x9b, 0xa8, 0x51, 0xcd, 0x75, 0x8e, 0x68, 0x7e,
-	0xb8, 0xf5, 0x35, 0x5c, 0x5d, 0x58, 0x6c, 0xc9, 0x15, 0xf1, 0x89, 0x7a, 0x45, 0xe8, 0x07, 0x4b,
-	0x6d, 0x10, 0xf5, 0xee, 0x78, 0x20, 0x0e, 0x85, 0x24, 0x00, 0x59, 0x50, 0xeb, 0x1d, 0xec, 0xef,
-	0xbf, 0x7a, 0x79, 0xf8, 0xe4, 0xf0, 0xf9, 0x57, 0x87, 0x56, 0x01, 0xd5, 0xa1, 0xc2, 0x47, 0x0e,
-	0x9f, 0x1f, 0xb2, 0x86, 0xc8, 0xcc, 0xe3, 0xe7, 0xcf, 0xfa, 0x56, 0xd1, 0xa6, 0x50, 0xdf, 0x23,
-	0xd8, 0xa5, 0xf8, 0x6c, 0x31, 0xfa, 0x14, 0x40, 0x9e, 0xcd, 0x00, 0xbf, 0x51, 0x92, 0x94, 0x50,
-	0xd6, 0x0e, 0x34, 0x98, 0xe0, 0x28, 0xa5, 0x7c, 0xa3, 0x0d, 0x27, 0x33, 0xed, 0x6f, 0xa0, 0x91,
-	0x65, 0x95, 0x6d, 0x35, 0x7f, 0x98, 0x2f, 0x9b, 0xd4, 0xfe, 0xd9, 0x80, 0xaa, 0x83, 0x5d, 0x7f,
-	0x75, 0x95, 0xd0, 0x53, 0x99, 0xab, 0xe3, 0x9b, 0x49, 0x67, 0x69, 0x25, 0xe9, 0xb4, 0x7f, 0x32,
-	0xa0, 0x26, 0x6a, 0x7b, 0xcb, 0xa8, 0x95, 0x52, 0xcc, 0xd5, 0x4a, 0xf9, 0xd3, 0x80, 0xfa, 0xcb,
-	0xd8, 0x57, 0x36, 0xfe, 0xff, 0x94, 0x53, 0xa5, 0x53, 0xd6, 0xb4, 0x4e, 0x59, 0x14, 0xda, 0xf2,
-	0x32, 0xa1, 0x3d, 0x80, 0x46, 0x06, 0x46, 0x32, 0xab, 0x33, 0x69, 0xac, 0xde, 0x3f, 0xec, 0x6d,
-	0xd2, 0xe3, 0x7a, 0xf4, 0x1f, 0x74, 0x90, 0x82, 0xbb, 0xa4, 0x9f, 0x90, 0x5f, 0x0d, 0xb8, 0xce,
-	0xdf, 0x64, 0x0e, 0x4e, 0xa2, 0x94, 0x78, 0xf8, 0x20, 0x0c, 0xe8, 0x3e, 0x17, 0x90, 0xb7, 0xd7,
-	0x35, 0x4d, 0x58, 0x17, 0x77, 0x2b, 0x2b, 0x9a, 0xeb, 0xb5, 0x34, 0x2f, 0xdc, 0xda, 0xdb, 0xbf,
-	0xac, 0x83, 0x95, 0x95, 0x7a, 0x94, 0x3d, 0xbd, 0x1e, 0x43, 0x25, 0x7f, 0xf4, 0xa3, 0x9b, 0x8a,
-	0x98, 0xcd, 0xff, 0x38, 0xb4, 0x36, 0x97, 0x3b, 0xc5, 0x66, 0xda, 0x05, 0xb4, 0x0b, 0x55, 0xfe,
-	0x7e, 0x10, 0xef, 0x55, 0xb4, 0xf0, 0xe2, 0xc8, 0xd6, 0x69, 0x2e, 0x3a, 0xf2, 0x35, 0x1e, 0x02,
-	0x70, 0xa5, 0x14, 0x4b, 0x5c, 0x5b, 0x10, 0x7d, 0xb1, 0xc2, 0xf5, 0x33, 0x2e, 0x03, 0xbb, 0xc0,
-	0xe0, 0xe4, 0xef, 0x65, 0x0d, 0xce, 0xfc, 0xbf, 0x87, 0x06, 0x67, 0xe1, 0x65, 0xcf, 0x4b, 0x29,
-	0x8b, 0x97, 0x27, 0x52, 0x0b, 0xd6, 0x9e, 0xc4, 0xad, 0x1b, 0x4b, 0x3c, 0xf9, 0x02, 0x8f, 0xa0,
-	0x76, 0x4c, 0x09, 0x76, 0x27, 0xff, 0x6a, 0x99, 0xdb, 0x06, 0xba, 0x0f, 0x6b, 0x9c, 0xa7, 0xcb,
-	0x51, 0x7a, 0x17, 0x4a, 0xfc, 0x22, 0xbc, 0x04, 0x99, 0x0f, 0xa1, 0x2c, 0xae, 0x00, 0xad, 0x76,
-	0xed, 0x2e, 0xd2, 0x6a, 0xd7, 0xef, 0x0b, 0x91, 0x9b, 0x69, 0xa9, 0x96, 0x5b, 0x11, 0x7e, 0x2d,
-	0xb7, 0x2a, 0xba, 0x22, 0xb7, 0x90, 0x0b, 0x2d, 0xb7, 0x26, 0x87, 0x5a, 0x6e, 0x5d, 0x5b, 0xec,
-	0x02, 0xba, 0x0f, 0x65, 0xa1, 0x11, 0xda, 0x02, 0x9a, 0x6c, 0xb4, 0xae, 0x2d, 0x1c, 0x99, 0x3e,
-	0xfb, 0xb7, 0xb6, 0x0b, 0xe8, 0x1e, 0x94, 0xf7, 0xdc, 0xd0, 0xc3, 0x63, 0x74, 0x46, 0xcc, 0x39,
-	0x73, 0x3f, 0x87, 0xfa, 0x23, 0x4c, 0x8f, 0xf8, 0x3f, 0xfc, 0x41, 0x38, 0x88, 0xce, 0x5c, 0xe2,
-	0x3d, 0xf5, 0xed, 0x90, 0x87, 0xdb, 0x85, 0xd7, 0x65, 0x1e, 0x78, 0xe7, 0x9f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x22, 0xcd, 0xb2, 0x66, 0x24, 0x10, 0x00, 0x00,
+	// 1482 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x36, 0x45, 0x49, 0x96, 0x46, 0x3f, 0x51, 0x36, 0xad, 0x2d, 0x33, 0x3e, 0x18, 0x6c, 0x0f,
+	0x6e, 0xd3, 0xca, 0x81, 0x53, 0xa0, 0x4d, 0x90, 0x20, 0xb5, 0x2d, 0xd9, 0x31, 0xe2, 0x38, 0x2e,
+	0x9d, 0xf4, 0xe7, 0x94, 0x32, 0xe4, 0x4a, 0x26, 0x4c, 0x91, 0xec, 0x72, 0xe9, 0xc0, 0x3d, 0xf5,
+	0xd0, 0x97, 0xe8, 0xb1, 0x0f, 0x50, 0x14, 0xe8, 0xb5, 0x40, 0xd1, 0x7b, 0x9f, 0xa1, 0x8f, 0xd0,
+	0x77, 0x28, 0xf6, 0x87, 0xd4, 0x52, 0x3f, 0x8e, 0xec, 0x06, 0xe9, 0x8d, 0xb3, 0x33, 0xbb, 0x33,
+	0xf3, 0xed, 0xec, 0xb7, 0xb3, 0x84, 0x66, 0x44, 0xc2, 0x33, 0xcf, 0xc5, 0xa4, 0x13, 0x91, 0x90,
+	0x86, 0xa8, 0x1a, 0x25, 0x7e, 0x32, 0xf4, 0x48, 0xe4, 0x18, 0xf5, 0xc8, 0x4f, 0x06, 0x5e, 0x20,
+	0x14, 0xc6, 0xcd, 0x41, 0x18, 0x0e, 0x7c, 0xbc, 0xc1, 0xa5, 0x97, 0x49, 0x7f, 0x03, 0x0f, 0x23,
+	0x7a, 0x2e, 0x95, 0xab, 0xe3, 0xca, 0x98, 0x92, 0xc4, 0xa1, 0x42, 0x6b, 0x7e, 0x04, 0xad, 0x3d,
+	0x4c, 0x8f, 0x9d, 0x13, 0x3c, 0xb4, 0x2d, 0xfc, 0x5d, 0x82, 0x63, 0x8a, 0xda, 0xb0, 0x78, 0x86,
+	0x49, 0xec, 0x85, 0x41, 0x5b, 0x5b, 0xd3, 0xd6, 0x4b, 0x56, 0x2a, 0x9a, 0xb7, 0xe0, 0xba, 0x62,
+	0x1d, 0x47, 0x61, 0x10, 0x63, 0xb4, 0x04, 0xe5, 0x98, 0x8f, 0x70, 0xeb, 0xaa, 0x25, 0x25, 0xf3,
+	0x1f, 0x0d, 0x5a, 0x3b, 0x61, 0xd0, 0xf7, 0x06, 0x09, 0xc1, 0xe9, 0xda, 0x8f, 0xa0, 0x7a, 0x66,
+	0x13, 0xcf, 0x7e, 0xe9, 0xe3, 0xb8, 0xad, 0xad, 0xe9, 0xeb, 0xb5, 0xcd, 0x0f, 0x3b, 0x59, 0x5e,
+	0x9d, 0x71, 0xfb, 0xce, 0x97, 0xa9, 0x71, 0x2f, 0xa0, 0xe4, 0xdc, 0x1a, 0x4d, 0x46, 0xb7, 0xa0,
+	0x68, 0x93, 0x41, 0xdc, 0x2e, 0xac, 0x69, 0xeb, 0xb5, 0xcd, 0xe5, 0x8e, 0x48, 0xb3, 0x93, 0xa6,
+	0xd9, 0x39, 0xe6, 0x69, 0x5a, 0xdc, 0x08, 0xbd, 0x0f, 0x0d, 0xdb, 0x71, 0x70, 0x44, 0x8f, 0xb1,
+	0x43, 0x30, 0x8d, 0xdb, 0xfa, 0x9a, 0xb6, 0x5e, 0xb1, 0xf2, 0x83, 0xc6, 0x7d, 0x68, 0xe6, 0xfd,
+	0xa1, 0x16, 0xe8, 0xa7, 0xf8, 0x5c, 0x26, 0xc6, 0x3e, 0xd1, 0x3b, 0x50, 0x3a, 0xb3, 0xfd, 0x04,
+	0x73, 0xbf, 0x55, 0x4b, 0x08, 0xf7, 0x0a, 0x9f, 0x69, 0xe6, 0x5d, 0xb8, 0xae, 0x84, 0x2f, 0xc1,
+	0x99, 0x70, 0xac, 0x4d, 0x71, 0x6c, 0xfe, 0xa6, 0xc1, 0x4a, 0x36, 0xb7, 0x47, 0x48, 0x48, 0x9e,
+	0x78, 0x71, 0xec, 0x05, 0x83, 0xc7, 0xf8, 0x3c, 0x46, 0x5f, 0x40, 0x6d, 0x38, 0x12, 0x25, 0x6a,
+	0x1b, 0xd3, 0x50, 0x1b, 0x9f, 0xda, 0x19, 0x7d, 0x5b, 0xea, 0x1a, 0xc6, 0x36, 0xc0, 0x48, 0x85,
+	0x10, 0x14, 0x03, 0x7b, 0x88, 0x65, 0x9a, 0xfc, 0x1b, 0xad, 0x41, 0xcd, 0xc5, 0xb1, 0x43, 0xbc,
+	0x88, 0xb2, 0x42, 0x10, 0xd9, 0xaa, 0x43, 0xe6, 0x8f, 0x1a, 0x34, 0xf6, 0x83, 0xb3, 0xf0, 0x34,
+	0xdb, 0xdc, 0x16, 0xe8, 0x34, 0x3c, 0x4d, 0xd1, 0xa2, 0xe1, 0xe9, 0xe5, 0x36, 0xc9, 0x80, 0x4a,
+	0x5a, 0xf1, 0x7c, 0x7f, 0xaa, 0x56, 0x26, 0xab, 0x35, 0x59, 0xe4, 0xaa, 0xac, 0x26, 0xcf, 0xa0,
+	0x99, 0x46, 0x21, 0x31, 0xdf, 0x80, 0x32, 0xc1, 0x34, 0x21, 0xa2, 0x7c, 0x2f, 0x70, 0x2b, 0xcd,
+	0xd0, 0x1d, 0xa8, 0xf4, 0x6d, 0xcf, 0x4f, 0x08, 0x66, 0x91, 0xea, 0x7c, 0x8a, 0x82, 0xee, 0x09,
+	0x76, 0x4e, 0x77, 0x85, 0xde, 0xca, 0x0c, 0xcd, 0xef, 0xa1, 0xce, 0x35, 0x4a, 0xf2, 0xa9, 0xcb,
+	0xaa, 0xc5, 0x3e, 0x59, 0xf2, 0xa1, 0xef, 0xbe, 0x3e, 0x79, 0x66, 0xc4, 0x8c, 0x03, 0xfc, 0x4a,
+	0x14, 0xe6, 0x45, 0xc6, 0xcc, 0xc8, 0x4c, 0xa0, 0x21, 0x7d, 0x8f, 0x52, 0xf6, 0x82, 0x28, 0x91,
+	0xf5, 0x75, 0x51, 0xca, 0xc2, 0xec, 0x6a, 0x29, 0x6f, 0x43, 0x5d, 0xd5, 0xc8, 0x0d, 0x8b, 0x30,
+	0xa1, 0xe9, 0x11, 0xc9, 0x64, 0xc6, 0x0a, 0x04, 0xdb, 0x71, 0x56, 0x3a, 0x52, 0x32, 0x7f, 0xd5,
+	0xa0, 0xd6, 0xf5, 0xfa, 0xfd, 0x14, 0xb6, 0x26, 0x14, 0x3c, 0x57, 0xce, 0x2e, 0x78, 0x6e, 0x0a,
+	0x63, 0x61, 0x12, 0x46, 0xfd, 0x32, 0x30, 0x16, 0xe7, 0x80, 0x91, 0x1d, 0x4e, 0x6f, 0x10, 0x84,
+	0x04, 0xef, 0x9c, 0xd8, 0xc1, 0x00, 0xc7, 0xed, 0xd2, 0x9a, 0xbe, 0x5e, 0xb5, 0xf2, 0x83, 0xe6,
+	0x9f, 0x1a, 0xd4, 0x8f, 0x64, 0x5a, 0x2c, 0x72, 0x74, 0x1b, 0x8a, 0xa7, 0x5e, 0x20, 0x82, 0x6e,
+	0x6e, 0xae, 0x2a, 0xb8, 0xa9, 0x66, 0x9d, 0xc7, 0x5e, 0xe0, 0x5a, 0xdc, 0x12, 0xad, 0x42, 0x95,
+	0xe3, 0xce, 0xc6, 0x79, 0x6a, 0x15, 0x6b, 0x34, 0x60, 0x7e, 0x0b, 0x45, 0x66, 0x8b, 0x16, 0x41,
+	0xdf, 0xea, 0x76, 0x5b, 0x0b, 0xe8, 0x1a, 0xd4, 0xb6, 0xba, 0xdd, 0x17, 0x56, 0xef, 0xe8, 0x60,
+	0x6b, 0xa7, 0xd7, 0xd2, 0x10, 0x40, 0xb9, 0xdb, 0x3b, 0xe8, 0x3d, 0xeb, 0xb5, 0x0a, 0x08, 0x41,
+	0x53, 0x7c, 0x67, 0x7a, 0x9d, 0xe9, 0x9f, 0x1f, 0x75, 0xb7, 0x9e, 0xf5, 0x5a, 0x45, 0xa6, 0x17,
+	0xdf, 0x99, 0xbe, 0x64, 0xfe, 0xad, 0x43, 0x5d, 0x80, 0x2e, 0xeb, 0xc5, 0x80, 0x0a, 0xc1, 0x91,
+	0x6f, 0x3b, 0x92, 0x85, 0xab, 0x56, 0x26, 0xb3, 0xa3, 0x16, 0x53, 0x41, 0xd0, 0x05, 0xae, 0x4a,
+	0x45, 0x74, 0x1b, 0x6e, 0xb8, 0xd8, 0xc7, 0x14, 0x6f, 0xe3, 0x7e, 0xc8, 0x48, 0x8e, 0xcf, 0x90,
+	0x5c, 0x3a, 0x4d, 0x85, 0x1e, 0xc0, 0xa2, 0x23, 0xb1, 0x2d, 0x72, 0xb4, 0xde, 0x53, 0xd0, 0x52,
+	0x23, 0xe2, 0x82, 0x44, 0xdc, 0x4a, 0xe7, 0x30, 0xb2, 0x75, 0xbd, 0x7e, 0x3f, 0xdd, 0x18, 0x21,
+	0xa0, 0x27, 0x50, 0x77, 0x31, 0xb5, 0x3d, 0x1f, 0xbb, 0x1c, 0xd0, 0x32, 0xaf, 0xdf, 0x0f, 0x66,
+	0xae, 0xac, 0xd8, 0x8a, 0x5b, 0x24, 0x37, 0x1d, 0xad, 0xc3, 0xb5, 0x13, 0x3b, 0x56, 0xad, 0xda,
+	0x8b, 0x3c, 0xa3, 0xf1, 0x61, 0xe3, 0x6b, 0xb8, 0x3e, 0xb1, 0xd8, 0x94, 0x2b, 0xe2, 0x63, 0xf5,
+	0x8a, 0xc8, 0x1f, 0x2c, 0xb5, 0x40, 0xd4, 0xbb, 0xe3, 0x01, 0xd4, 0x14, 0x00, 0x50, 0x0b, 0xea,
+	0xdd, 0xfd, 0xdd, 0xdd, 0x17, 0xcf, 0x0f, 0x1f, 0x1f, 0x3e, 0xfd, 0xea, 0xb0, 0xb5, 0x80, 0x1a,
+	0x50, 0xe5, 0x23, 0x87, 0x4f, 0x0f, 0x59, 0x41, 0xa4, 0xe2, 0xf1, 0xd3, 0x27, 0xbd, 0x56, 0xc1,
+	0xa4, 0xd0, 0xd8, 0x21, 0xd8, 0xa6, 0x78, 0x36, 0x19, 0x7d, 0x0a, 0x20, 0xcf, 0xa6, 0x87, 0x5f,
+	0x4b, 0x49, 0x8a, 0x29, 0x2b, 0x07, 0xea, 0x0d, 0x71, 0x98, 0x50, 0xbe, 0xd1, 0x9a, 0x95, 0x8a,
+	0xe6, 0x37, 0xd0, 0x4c, 0xbd, 0xca, 0xb2, 0x1a, 0x3f, 0xcc, 0x57, 0x75, 0x6a, 0xfe, 0xa4, 0x41,
+	0xcd, 0xc2, 0xb6, 0x3b, 0x3f, 0x4b, 0xe4, 0x5d, 0xe9, 0xf3, 0xe7, 0x37, 0xa2, 0xce, 0xe2, 0x5c,
+	0xd4, 0x69, 0xfe, 0xa1, 0x41, 0x5d, 0xc4, 0xf6, 0x86, 0xb3, 0x56, 0x42, 0xd1, 0xe7, 0x63, 0xf1,
+	0x4f, 0xa0, 0xe2, 0x9c, 0x78, 0xbe, 0x4b, 0x30, 0xbb, 0x16, 0xd9, 0x29, 0x68, 0xe7, 0x58, 0xdc,
+	0xf3, 0x59, 0x94, 0x61, 0x42, 0x1c, 0x6c, 0x65, 0x96, 0xe6, 0x5f, 0x1a, 0x34, 0x9e, 0x47, 0xae,
+	0x52, 0x2e, 0xff, 0x27, 0x09, 0x2b, 0xf5, 0x55, 0xca, 0xd5, 0xd7, 0x24, 0x3d, 0x97, 0xa7, 0xd1,
+	0xf3, 0x3e, 0x34, 0xd3, 0x64, 0xe4, 0x7e, 0xe4, 0xf1, 0xd7, 0xe6, 0xaf, 0x3a, 0xd6, 0xd1, 0x74,
+	0x39, 0x8b, 0xbd, 0x85, 0xba, 0x53, 0xf2, 0x2e, 0xe6, 0xcf, 0xd5, 0x2f, 0x1a, 0x2c, 0xf3, 0x4e,
+	0x2e, 0xdd, 0xbb, 0xfd, 0xc0, 0xa3, 0xbb, 0x9c, 0x76, 0xde, 0x5c, 0xad, 0xb5, 0x61, 0x51, 0xdc,
+	0xc8, 0x2c, 0x68, 0xce, 0xf2, 0x52, 0xbc, 0xfc, 0x81, 0xf8, 0x5d, 0x83, 0xe6, 0x1e, 0xa6, 0x07,
+	0xe1, 0x20, 0x9e, 0xcd, 0x3f, 0x22, 0xf0, 0xc2, 0x8c, 0xc0, 0x2f, 0x81, 0xdb, 0x2a, 0x54, 0x63,
+	0x6a, 0x13, 0xfa, 0xcc, 0x1b, 0x62, 0x1e, 0xa1, 0x6e, 0x8d, 0x06, 0x58, 0x5a, 0x38, 0x70, 0xb9,
+	0xae, 0xc4, 0x75, 0xa9, 0xc8, 0x1a, 0x92, 0x7e, 0xe8, 0xfb, 0xe1, 0xab, 0x76, 0x99, 0xb3, 0xbb,
+	0x94, 0x4c, 0x0b, 0x2a, 0x07, 0xe1, 0x40, 0x70, 0xf9, 0x38, 0xba, 0xab, 0x50, 0x65, 0x9b, 0x12,
+	0x53, 0x7b, 0x18, 0xf1, 0xd8, 0x75, 0x6b, 0x34, 0xc0, 0x7c, 0x0d, 0x71, 0x1c, 0xdb, 0x03, 0x2c,
+	0xdb, 0xd5, 0x54, 0x64, 0x3b, 0x58, 0xdb, 0xb1, 0x7d, 0xff, 0x2d, 0xc0, 0xb1, 0x04, 0xe5, 0x21,
+	0xa6, 0x27, 0xa1, 0x2b, 0xfb, 0x62, 0x29, 0x65, 0x9d, 0x77, 0x69, 0x8e, 0xce, 0xdb, 0xa4, 0x50,
+	0x17, 0xe1, 0xbe, 0xd5, 0x0e, 0xfa, 0x07, 0x0d, 0x1a, 0x39, 0x8e, 0x62, 0x0f, 0x11, 0x7a, 0x1e,
+	0x65, 0x0f, 0x11, 0xf6, 0x9d, 0x3d, 0x4e, 0x0a, 0xca, 0xe3, 0x44, 0xa0, 0xa7, 0xcf, 0x40, 0xaf,
+	0x38, 0x37, 0x7a, 0x9b, 0x3f, 0x57, 0xa0, 0x95, 0x7a, 0x3f, 0x4a, 0xdf, 0x1a, 0x8f, 0xa0, 0x9a,
+	0xbd, 0x72, 0xd1, 0x4d, 0x25, 0x8f, 0xf1, 0x97, 0xb2, 0xb1, 0x3a, 0x5d, 0x29, 0x50, 0x34, 0x17,
+	0xd0, 0x36, 0xd4, 0x78, 0xee, 0xe2, 0x81, 0x86, 0x26, 0x30, 0x49, 0xd7, 0x69, 0x4f, 0x2a, 0xb2,
+	0x35, 0x1e, 0x02, 0xf0, 0xd6, 0x40, 0x2c, 0xb1, 0x34, 0xd1, 0xe5, 0x88, 0x15, 0x96, 0x67, 0x74,
+	0x3f, 0xe6, 0x02, 0x4b, 0x27, 0x7b, 0x20, 0xe6, 0xd2, 0x19, 0x7f, 0x6c, 0x1b, 0xab, 0xd3, 0x95,
+	0x4a, 0x28, 0x65, 0xf1, 0xd4, 0x42, 0x6a, 0xc0, 0xb9, 0x37, 0xa0, 0xb1, 0x32, 0x45, 0x93, 0x2d,
+	0xb0, 0x07, 0xf5, 0x63, 0x4a, 0xb0, 0x3d, 0xfc, 0x4f, 0xcb, 0xdc, 0xd6, 0xd0, 0x7d, 0x28, 0x71,
+	0x9c, 0xae, 0x06, 0xe9, 0x5d, 0x28, 0xf2, 0xce, 0xef, 0x0a, 0x60, 0x3e, 0x84, 0xb2, 0xe8, 0x79,
+	0x72, 0xb1, 0xe7, 0x9a, 0x2f, 0x63, 0x65, 0x8a, 0x46, 0xf5, 0xcd, 0x9a, 0x87, 0x9c, 0x6f, 0xa5,
+	0xd3, 0x31, 0x96, 0x27, 0xc6, 0x55, 0xdf, 0xe2, 0xa6, 0xcb, 0xf9, 0xce, 0xdd, 0xe4, 0xc6, 0xca,
+	0x14, 0x4d, 0xb6, 0xc0, 0x7d, 0x28, 0x8b, 0xeb, 0x2d, 0xb7, 0x40, 0xee, 0xc6, 0x33, 0x96, 0x26,
+	0x8e, 0x4d, 0x8f, 0xfd, 0x4c, 0x32, 0x17, 0x58, 0x2f, 0x2f, 0x59, 0x1e, 0xad, 0xe4, 0xeb, 0x5e,
+	0x61, 0x7e, 0xe3, 0x86, 0xa2, 0x4a, 0x79, 0x95, 0x6f, 0xd9, 0x5d, 0x28, 0x32, 0x8e, 0xc9, 0x25,
+	0xae, 0x70, 0xa4, 0xb1, 0x3c, 0x31, 0x9e, 0xc5, 0x7d, 0x0f, 0xca, 0x3b, 0x76, 0xe0, 0x60, 0x1f,
+	0xcd, 0x88, 0xee, 0x82, 0xa8, 0x3f, 0x87, 0xc6, 0x1e, 0xa6, 0x47, 0xfc, 0x77, 0xd9, 0x7e, 0xd0,
+	0x0f, 0x67, 0x2e, 0xf1, 0xae, 0xda, 0xa6, 0x67, 0xe6, 0xe6, 0xc2, 0xcb, 0x32, 0x37, 0xbc, 0xf3,
+	0xef, 0x00, 0xb7, 0xff, 0x5c, 0x85, 0x8f, 0x13, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1468,6 +1810,12 @@ type ResourceProviderClient interface {
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	// Delete tears down an existing resource with the given ID.  If it fails, the resource is assumed to still exist.
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetLogs streams the log entries produced by a resource, oldest first. Unless the request asks to follow the
+	// logs, the stream ends once all entries in the requested time range have been sent.
+	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (ResourceProvider_GetLogsClient, error)
+	// Call runs one of the operational methods, such as rebooting an instance, that a resource's schema declares
+	// against an existing instance of that resource.
+	Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error)
 	// Cancel signals the provider to abort all outstanding resource operations.
 	Cancel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
@@ -1613,6 +1961,47 @@ func (c *resourceProviderClient) Delete(ctx context.Context, in *DeleteRequest,
 	return out, nil
 }
 
+func (c *resourceProviderClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (ResourceProvider_GetLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ResourceProvider_serviceDesc.Streams[1], "/pulumirpc.ResourceProvider/GetLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourceProviderGetLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResourceProvider_GetLogsClient interface {
+	Recv() (*LogEntry, error)
+	grpc.ClientStream
+}
+
+type resourceProviderGetLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourceProviderGetLogsClient) Recv() (*LogEntry, error) {
+	m := new(LogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resourceProviderClient) Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.ResourceProvider/Call", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resourceProviderClient) Cancel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/pulumirpc.ResourceProvider/Cancel", in, out, opts...)
@@ -1664,6 +2053,12 @@ type ResourceProviderServer interface {
 	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	// Delete tears down an existing resource with the given ID.  If it fails, the resource is assumed to still exist.
 	Delete(context.Context, *DeleteRequest) (*empty.Empty, error)
+	// GetLogs streams the log entries produced by a resource, oldest first. Unless the request asks to follow the
+	// logs, the stream ends once all entries in the requested time range have been sent.
+	GetLogs(*GetLogsRequest, ResourceProvider_GetLogsServer) error
+	// Call runs one of the operational methods, such as rebooting an instance, that a resource's schema declares
+	// against an existing instance of that resource.
+	Call(context.Context, *CallRequest) (*CallResponse, error)
 	// Cancel signals the provider to abort all outstanding resource operations.
 	Cancel(context.Context, *empty.Empty) (*empty.Empty, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
@@ -1710,6 +2105,12 @@ func (*UnimplementedResourceProviderServer) Update(ctx context.Context, req *Upd
 func (*UnimplementedResourceProviderServer) Delete(ctx context.Context, req *DeleteRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (*UnimplementedResourceProviderServer) GetLogs(req *GetLogsRequest, srv ResourceProvider_GetLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (*UnimplementedResourceProviderServer) Call(ctx context.Context, req *CallRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
 func (*UnimplementedResourceProviderServer) Cancel(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
@@ -1940,6 +2341,45 @@ func _ResourceProvider_Delete_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceProvider_GetLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourceProviderServer).GetLogs(m, &resourceProviderGetLogsServer{stream})
+}
+
+type ResourceProvider_GetLogsServer interface {
+	Send(*LogEntry) error
+	grpc.ServerStream
+}
+
+type resourceProviderGetLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourceProviderGetLogsServer) Send(m *LogEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ResourceProvider_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceProviderServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceProvider/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceProviderServer).Call(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ResourceProvider_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -2024,6 +2464,10 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _ResourceProvider_Delete_Handler,
 		},
+		{
+			MethodName: "Call",
+			Handler:    _ResourceProvider_Call_Handler,
+		},
 		{
 			MethodName: "Cancel",
 			Handler:    _ResourceProvider_Cancel_Handler,
@@ -2039,6 +2483,11 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ResourceProvider_StreamInvoke_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetLogs",
+			Handler:       _ResourceProvider_GetLogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "provider.proto",
 }
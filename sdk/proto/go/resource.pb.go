@@ -295,6 +295,7 @@ type RegisterResourceRequest struct {
 	CustomTimeouts             *RegisterResourceRequest_CustomTimeouts                  `protobuf:"bytes,17,opt,name=customTimeouts,proto3" json:"customTimeouts,omitempty"`
 	DeleteBeforeReplaceDefined bool                                                     `protobuf:"varint,18,opt,name=deleteBeforeReplaceDefined,proto3" json:"deleteBeforeReplaceDefined,omitempty"`
 	SupportsPartialValues      bool                                                     `protobuf:"varint,19,opt,name=supportsPartialValues,proto3" json:"supportsPartialValues,omitempty"`
+	SerializationGroup         string                                                   `protobuf:"bytes,20,opt,name=serializationGroup,proto3" json:"serializationGroup,omitempty"`
 	XXX_NoUnkeyedLiteral       struct{}                                                 `json:"-"`
 	XXX_unrecognized           []byte                                                   `json:"-"`
 	XXX_sizecache              int32                                                    `json:"-"`
@@ -458,6 +459,13 @@ func (m *RegisterResourceRequest) GetSupportsPartialValues() bool {
 	return false
 }
 
+func (m *RegisterResourceRequest) GetSerializationGroup() string {
+	if m != nil {
+		return m.SerializationGroup
+	}
+	return ""
+}
+
 // PropertyDependencies describes the resources that a particular property depends on.
 type RegisterResourceRequest_PropertyDependencies struct {
 	Urns                 []string `protobuf:"bytes,1,rep,name=urns,proto3" json:"urns,omitempty"`
@@ -697,63 +705,63 @@ func init() {
 func init() { proto.RegisterFile("resource.proto", fileDescriptor_d1b72f771c35e3b8) }
 
 var fileDescriptor_d1b72f771c35e3b8 = []byte{
-	// 882 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x51, 0x6f, 0x23, 0x35,
-	0x10, 0xbe, 0x4d, 0x7a, 0x69, 0x32, 0xed, 0xa5, 0xc5, 0x2d, 0xa9, 0x6f, 0x41, 0xa5, 0x2c, 0x3c,
-	0x04, 0x1e, 0xd2, 0xbb, 0x82, 0x74, 0x07, 0x42, 0x20, 0x71, 0x77, 0xa0, 0x7b, 0x38, 0x71, 0x6c,
-	0x11, 0x02, 0x24, 0x90, 0xdc, 0xdd, 0x69, 0x6e, 0xe9, 0x66, 0x6d, 0x6c, 0x6f, 0xa5, 0xbc, 0xf1,
-	0xca, 0xaf, 0xe0, 0x2f, 0xf1, 0x5b, 0xf8, 0x05, 0xc8, 0x5e, 0x3b, 0x64, 0xb3, 0x9b, 0x36, 0x70,
-	0x6f, 0x9e, 0x6f, 0xc6, 0xb3, 0xf6, 0x37, 0xdf, 0x8c, 0x17, 0x86, 0x12, 0x15, 0x2f, 0x65, 0x82,
-	0x13, 0x21, 0xb9, 0xe6, 0x64, 0x20, 0xca, 0xbc, 0x9c, 0x65, 0x52, 0x24, 0xe1, 0x5b, 0x53, 0xce,
-	0xa7, 0x39, 0x9e, 0x5a, 0xc7, 0x45, 0x79, 0x79, 0x8a, 0x33, 0xa1, 0xe7, 0x55, 0x5c, 0xf8, 0xf6,
-	0xaa, 0x53, 0x69, 0x59, 0x26, 0xda, 0x79, 0x87, 0x42, 0xf2, 0xeb, 0x2c, 0x45, 0x59, 0xd9, 0xd1,
-	0x18, 0x46, 0xe7, 0xa5, 0x10, 0x5c, 0x6a, 0xf5, 0x15, 0x32, 0x5d, 0x4a, 0x8c, 0xf1, 0xb7, 0x12,
-	0x95, 0x26, 0x43, 0xe8, 0x64, 0x29, 0x0d, 0x4e, 0x82, 0xf1, 0x20, 0xee, 0x64, 0x69, 0xf4, 0x09,
-	0x1c, 0x35, 0x22, 0x95, 0xe0, 0x85, 0x42, 0x72, 0x0c, 0xf0, 0x8a, 0x29, 0xe7, 0xb5, 0x5b, 0xfa,
-	0xf1, 0x12, 0x12, 0xfd, 0xdd, 0x81, 0x83, 0x18, 0x59, 0x1a, 0xbb, 0x1b, 0xad, 0xf9, 0x04, 0x21,
-	0xb0, 0xa5, 0xe7, 0x02, 0x69, 0xc7, 0x22, 0x76, 0x6d, 0xb0, 0x82, 0xcd, 0x90, 0x76, 0x2b, 0xcc,
-	0xac, 0xc9, 0x08, 0x7a, 0x82, 0x49, 0x2c, 0x34, 0xdd, 0xb2, 0xa8, 0xb3, 0xc8, 0x23, 0x00, 0x21,
-	0xb9, 0x40, 0xa9, 0x33, 0x54, 0xf4, 0xee, 0x49, 0x30, 0xde, 0x39, 0x3b, 0x9a, 0x54, 0x7c, 0x4c,
-	0x3c, 0x1f, 0x93, 0x73, 0xcb, 0x47, 0xbc, 0x14, 0x4a, 0x22, 0xd8, 0x4d, 0x51, 0x60, 0x91, 0x62,
-	0x91, 0x98, 0xad, 0xbd, 0x93, 0xee, 0x78, 0x10, 0xd7, 0x30, 0x12, 0x42, 0xdf, 0x73, 0x47, 0xb7,
-	0xed, 0x67, 0x17, 0x36, 0xa1, 0xb0, 0x7d, 0x8d, 0x52, 0x65, 0xbc, 0xa0, 0x7d, 0xeb, 0xf2, 0x26,
-	0x79, 0x1f, 0xee, 0xb1, 0x24, 0x41, 0xa1, 0xcf, 0x31, 0x91, 0xa8, 0x15, 0x1d, 0x58, 0x76, 0xea,
-	0x20, 0x79, 0x0c, 0x47, 0x2c, 0x4d, 0x33, 0x9d, 0xf1, 0x82, 0xe5, 0x15, 0xf8, 0x4d, 0xa9, 0x45,
-	0xa9, 0x15, 0x05, 0x7b, 0x94, 0x75, 0x6e, 0xf3, 0x65, 0x96, 0x67, 0x4c, 0xa1, 0xa2, 0x3b, 0x36,
-	0xd2, 0x9b, 0x11, 0x83, 0xc3, 0x3a, 0xe7, 0xae, 0x58, 0xfb, 0xd0, 0x2d, 0x65, 0xe1, 0x58, 0x37,
-	0xcb, 0x15, 0xda, 0x3a, 0x1b, 0xd3, 0x16, 0xfd, 0xd5, 0x87, 0xa3, 0x18, 0xa7, 0x99, 0xd2, 0x28,
-	0x57, 0x6b, 0xeb, 0x6b, 0x19, 0xb4, 0xd4, 0xb2, 0xd3, 0x5a, 0xcb, 0x6e, 0xad, 0x96, 0x23, 0xe8,
-	0x25, 0xa5, 0xd2, 0x7c, 0x66, 0x6b, 0xdc, 0x8f, 0x9d, 0x45, 0x4e, 0xa1, 0xc7, 0x2f, 0x7e, 0xc5,
-	0x44, 0xdf, 0x56, 0x5f, 0x17, 0x66, 0x18, 0x32, 0x2e, 0xb3, 0xa3, 0x67, 0x33, 0x79, 0xb3, 0x51,
-	0xf5, 0xed, 0x5b, 0xaa, 0xde, 0x5f, 0xa9, 0xba, 0x80, 0x43, 0x47, 0xc6, 0xfc, 0xe9, 0x72, 0x9e,
-	0xc1, 0x49, 0x77, 0xbc, 0x73, 0xf6, 0xd9, 0x64, 0xd1, 0xb0, 0x93, 0x35, 0x24, 0x4d, 0x5e, 0xb6,
-	0x6c, 0x7f, 0x56, 0x68, 0x39, 0x8f, 0x5b, 0x33, 0x93, 0x07, 0x70, 0x90, 0x62, 0x8e, 0x1a, 0xbf,
-	0xc4, 0x4b, 0x6e, 0x1a, 0x50, 0xe4, 0x2c, 0x41, 0x0a, 0xf6, 0x5e, 0x6d, 0xae, 0x65, 0x65, 0xee,
-	0x34, 0x94, 0x99, 0x4d, 0x0b, 0x2e, 0xf1, 0xc9, 0x2b, 0x56, 0x4c, 0x51, 0xd1, 0x5d, 0x7b, 0xfd,
-	0x3a, 0xd8, 0xd4, 0xef, 0xbd, 0xff, 0xa8, 0xdf, 0xe1, 0xc6, 0xfa, 0xdd, 0xab, 0xe9, 0xd7, 0x30,
-	0x9f, 0xcd, 0xcc, 0xf8, 0x78, 0x9e, 0xd2, 0xfd, 0x8a, 0x79, 0x6f, 0x93, 0x1f, 0x61, 0x58, 0xc9,
-	0xe1, 0xbb, 0x6c, 0x86, 0xdc, 0x7c, 0xe6, 0x0d, 0x2b, 0x86, 0x87, 0x1b, 0x70, 0xfe, 0xa4, 0xb6,
-	0x31, 0x5e, 0x49, 0x44, 0x3e, 0x87, 0xb0, 0x85, 0xc7, 0xa7, 0x78, 0x99, 0x15, 0x98, 0x52, 0x62,
-	0x6f, 0x7f, 0x43, 0x04, 0xf9, 0x18, 0xde, 0x54, 0x6e, 0x4c, 0xbe, 0x64, 0x52, 0x67, 0x2c, 0xff,
-	0x9e, 0xe5, 0x25, 0x2a, 0x7a, 0x60, 0xb7, 0xb6, 0x3b, 0xc3, 0x0f, 0xe1, 0xb0, 0x4d, 0x0b, 0xa6,
-	0x63, 0x4a, 0x59, 0x28, 0x1a, 0x58, 0x6e, 0xec, 0x3a, 0xfc, 0x01, 0x86, 0xf5, 0x3b, 0xd8, 0x5e,
-	0x91, 0xc8, 0xb4, 0xef, 0x36, 0x67, 0x19, 0xbc, 0x14, 0xa9, 0xc1, 0xab, 0x8e, 0x73, 0x96, 0xc1,
-	0xab, 0x1b, 0xf8, 0x9e, 0xab, 0xac, 0xf0, 0xf7, 0x00, 0xee, 0xaf, 0x95, 0xa4, 0x19, 0x1c, 0x57,
-	0x38, 0xf7, 0x83, 0xe3, 0x0a, 0xe7, 0xe4, 0x05, 0xdc, 0xbd, 0x36, 0xe7, 0x77, 0x33, 0xe3, 0xd1,
-	0xff, 0x54, 0x7c, 0x5c, 0x65, 0xf9, 0xb4, 0xf3, 0x38, 0x88, 0xfe, 0x0c, 0x80, 0x36, 0xf7, 0xae,
-	0x1d, 0x5d, 0xd5, 0x0b, 0xd2, 0x59, 0xbc, 0x20, 0xff, 0x4e, 0x87, 0xee, 0x66, 0xd3, 0x61, 0x04,
-	0x3d, 0xa5, 0xd9, 0x45, 0x8e, 0x7e, 0xcc, 0x54, 0x96, 0xd1, 0x65, 0xb5, 0x32, 0xef, 0x88, 0xd5,
-	0xa5, 0x33, 0x23, 0x84, 0xe3, 0xd5, 0x03, 0x3a, 0x31, 0xfb, 0xd1, 0xd7, 0x3c, 0xe6, 0x43, 0xd8,
-	0xe6, 0xae, 0x1f, 0x6e, 0x19, 0xaf, 0x3e, 0xee, 0xec, 0x8f, 0x2d, 0xd8, 0xf3, 0xf9, 0x5f, 0xf0,
-	0x22, 0xd3, 0x5c, 0x92, 0x9f, 0x60, 0x6f, 0xe5, 0x09, 0x26, 0xef, 0x2e, 0x71, 0xde, 0xfe, 0x90,
-	0x87, 0xd1, 0x4d, 0x21, 0x15, 0xb3, 0xd1, 0x1d, 0xf2, 0x05, 0xf4, 0x9e, 0x17, 0xd7, 0xfc, 0x0a,
-	0x09, 0x5d, 0x8a, 0xaf, 0x20, 0x9f, 0xe9, 0x7e, 0x8b, 0x67, 0x91, 0xe0, 0x6b, 0xd8, 0x3d, 0xd7,
-	0x12, 0xd9, 0xec, 0xb5, 0xd2, 0x3c, 0x08, 0xc8, 0xb7, 0xb0, 0xbb, 0xfc, 0x70, 0x91, 0xe3, 0x9a,
-	0xac, 0x1a, 0x7f, 0x11, 0xe1, 0x3b, 0x6b, 0xfd, 0x8b, 0xb3, 0xfd, 0x0c, 0xfb, 0xab, 0x35, 0x23,
-	0xd1, 0xed, 0x6a, 0x0d, 0xdf, 0xbb, 0x31, 0x66, 0x91, 0xfe, 0x97, 0xe6, 0x33, 0xe8, 0xe7, 0xdb,
-	0x07, 0x37, 0x64, 0xa8, 0xcb, 0x26, 0x1c, 0x35, 0x34, 0xf1, 0xcc, 0xfc, 0xd6, 0x45, 0x77, 0x2e,
-	0x7a, 0x16, 0xf9, 0xe8, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x00, 0xa9, 0x5c, 0x60, 0x13, 0x0a,
-	0x00, 0x00,
+	// 895 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5f, 0x6f, 0x1b, 0x45,
+	0x10, 0xef, 0xd9, 0xa9, 0x63, 0x4f, 0x52, 0x27, 0x6c, 0x82, 0xbd, 0x3d, 0x50, 0x08, 0x07, 0x0f,
+	0x86, 0x07, 0xa7, 0x0d, 0x48, 0x2d, 0x08, 0x81, 0x44, 0x5b, 0xaa, 0x3e, 0x54, 0x94, 0x0b, 0x42,
+	0x80, 0x04, 0xd2, 0xe6, 0x6e, 0xe2, 0x2e, 0x39, 0xdf, 0x2e, 0xbb, 0x7b, 0x91, 0xcc, 0x53, 0x5e,
+	0xf9, 0x14, 0x7c, 0x47, 0x3e, 0x01, 0xda, 0xbd, 0x5b, 0xe3, 0xb3, 0xcf, 0x89, 0xa1, 0x6f, 0x3b,
+	0x7f, 0x6f, 0xe7, 0x37, 0xbf, 0x99, 0x3d, 0xe8, 0x2b, 0xd4, 0xa2, 0x50, 0x09, 0x8e, 0xa5, 0x12,
+	0x46, 0x90, 0x9e, 0x2c, 0xb2, 0x62, 0xca, 0x95, 0x4c, 0xc2, 0x77, 0x26, 0x42, 0x4c, 0x32, 0x3c,
+	0x71, 0x86, 0xf3, 0xe2, 0xe2, 0x04, 0xa7, 0xd2, 0xcc, 0x4a, 0xbf, 0xf0, 0xdd, 0x65, 0xa3, 0x36,
+	0xaa, 0x48, 0x4c, 0x65, 0xed, 0x4b, 0x25, 0xae, 0x78, 0x8a, 0xaa, 0x94, 0xa3, 0x11, 0x0c, 0xce,
+	0x0a, 0x29, 0x85, 0x32, 0xfa, 0x1b, 0x64, 0xa6, 0x50, 0x18, 0xe3, 0xef, 0x05, 0x6a, 0x43, 0xfa,
+	0xd0, 0xe2, 0x29, 0x0d, 0x8e, 0x83, 0x51, 0x2f, 0x6e, 0xf1, 0x34, 0xfa, 0x0c, 0x86, 0x2b, 0x9e,
+	0x5a, 0x8a, 0x5c, 0x23, 0x39, 0x02, 0x78, 0xcd, 0x74, 0x65, 0x75, 0x21, 0xdd, 0x78, 0x41, 0x13,
+	0xfd, 0xdd, 0x82, 0x83, 0x18, 0x59, 0x1a, 0x57, 0x15, 0xad, 0xf9, 0x04, 0x21, 0xb0, 0x65, 0x66,
+	0x12, 0x69, 0xcb, 0x69, 0xdc, 0xd9, 0xea, 0x72, 0x36, 0x45, 0xda, 0x2e, 0x75, 0xf6, 0x4c, 0x06,
+	0xd0, 0x91, 0x4c, 0x61, 0x6e, 0xe8, 0x96, 0xd3, 0x56, 0x12, 0x79, 0x04, 0x20, 0x95, 0x90, 0xa8,
+	0x0c, 0x47, 0x4d, 0xef, 0x1e, 0x07, 0xa3, 0x9d, 0xd3, 0xe1, 0xb8, 0xc4, 0x63, 0xec, 0xf1, 0x18,
+	0x9f, 0x39, 0x3c, 0xe2, 0x05, 0x57, 0x12, 0xc1, 0x6e, 0x8a, 0x12, 0xf3, 0x14, 0xf3, 0xc4, 0x86,
+	0x76, 0x8e, 0xdb, 0xa3, 0x5e, 0x5c, 0xd3, 0x91, 0x10, 0xba, 0x1e, 0x3b, 0xba, 0xed, 0x3e, 0x3b,
+	0x97, 0x09, 0x85, 0xed, 0x2b, 0x54, 0x9a, 0x8b, 0x9c, 0x76, 0x9d, 0xc9, 0x8b, 0xe4, 0x43, 0xb8,
+	0xc7, 0x92, 0x04, 0xa5, 0x39, 0xc3, 0x44, 0xa1, 0xd1, 0xb4, 0xe7, 0xd0, 0xa9, 0x2b, 0xc9, 0x63,
+	0x18, 0xb2, 0x34, 0xe5, 0x86, 0x8b, 0x9c, 0x65, 0xa5, 0xf2, 0xdb, 0xc2, 0xc8, 0xc2, 0x68, 0x0a,
+	0xee, 0x2a, 0xeb, 0xcc, 0xf6, 0xcb, 0x2c, 0xe3, 0x4c, 0xa3, 0xa6, 0x3b, 0xce, 0xd3, 0x8b, 0x11,
+	0x83, 0xc3, 0x3a, 0xe6, 0x55, 0xb3, 0xf6, 0xa1, 0x5d, 0xa8, 0xbc, 0x42, 0xdd, 0x1e, 0x97, 0x60,
+	0x6b, 0x6d, 0x0c, 0x5b, 0x74, 0xdd, 0x83, 0x61, 0x8c, 0x13, 0xae, 0x0d, 0xaa, 0xe5, 0xde, 0xfa,
+	0x5e, 0x06, 0x0d, 0xbd, 0x6c, 0x35, 0xf6, 0xb2, 0x5d, 0xeb, 0xe5, 0x00, 0x3a, 0x49, 0xa1, 0x8d,
+	0x98, 0xba, 0x1e, 0x77, 0xe3, 0x4a, 0x22, 0x27, 0xd0, 0x11, 0xe7, 0xbf, 0x61, 0x62, 0x6e, 0xeb,
+	0x6f, 0xe5, 0x66, 0x11, 0xb2, 0x26, 0x1b, 0xd1, 0x71, 0x99, 0xbc, 0xb8, 0xd2, 0xf5, 0xed, 0x5b,
+	0xba, 0xde, 0x5d, 0xea, 0xba, 0x84, 0xc3, 0x0a, 0x8c, 0xd9, 0xd3, 0xc5, 0x3c, 0xbd, 0xe3, 0xf6,
+	0x68, 0xe7, 0xf4, 0x8b, 0xf1, 0x7c, 0x60, 0xc7, 0x6b, 0x40, 0x1a, 0xbf, 0x6a, 0x08, 0x7f, 0x96,
+	0x1b, 0x35, 0x8b, 0x1b, 0x33, 0x93, 0x07, 0x70, 0x90, 0x62, 0x86, 0x06, 0xbf, 0xc6, 0x0b, 0xa1,
+	0x30, 0x46, 0x99, 0xb1, 0x04, 0x29, 0xb8, 0xba, 0x9a, 0x4c, 0x8b, 0xcc, 0xdc, 0x59, 0x61, 0x26,
+	0x9f, 0xe4, 0x42, 0xe1, 0x93, 0xd7, 0x2c, 0x9f, 0xa0, 0xa6, 0xbb, 0xae, 0xfc, 0xba, 0x72, 0x95,
+	0xbf, 0xf7, 0xfe, 0x23, 0x7f, 0xfb, 0x1b, 0xf3, 0x77, 0xaf, 0xc6, 0x5f, 0x8b, 0x3c, 0x9f, 0x4a,
+	0xa1, 0xcc, 0x8b, 0x94, 0xee, 0x97, 0xc8, 0x7b, 0x99, 0xfc, 0x04, 0xfd, 0x92, 0x0e, 0xdf, 0xf3,
+	0x29, 0x0a, 0xfb, 0x99, 0xb7, 0x1c, 0x19, 0x1e, 0x6e, 0x80, 0xf9, 0x93, 0x5a, 0x60, 0xbc, 0x94,
+	0x88, 0x7c, 0x09, 0x61, 0x03, 0x8e, 0x4f, 0xf1, 0x82, 0xe7, 0x98, 0x52, 0xe2, 0xaa, 0xbf, 0xc1,
+	0x83, 0x7c, 0x0a, 0x6f, 0xeb, 0x6a, 0x4d, 0xbe, 0x62, 0xca, 0x70, 0x96, 0xfd, 0xc0, 0xb2, 0x02,
+	0x35, 0x3d, 0x70, 0xa1, 0xcd, 0x46, 0x32, 0x06, 0xa2, 0x51, 0x71, 0x96, 0xf1, 0x3f, 0x98, 0x85,
+	0xe9, 0xb9, 0x12, 0x85, 0xa4, 0x87, 0xae, 0xec, 0x06, 0x4b, 0xf8, 0x31, 0x1c, 0x36, 0x71, 0xc7,
+	0x4e, 0x58, 0xa1, 0x72, 0x4d, 0x03, 0x87, 0xa5, 0x3b, 0x87, 0x3f, 0x42, 0xbf, 0x5e, 0xb3, 0x9b,
+	0x2d, 0x85, 0xcc, 0xf8, 0xe9, 0xac, 0x24, 0xab, 0x2f, 0x64, 0xca, 0x8c, 0x9f, 0xd0, 0x4a, 0xb2,
+	0xfa, 0xb2, 0x62, 0x3f, 0xa3, 0xa5, 0x14, 0x5e, 0x07, 0x70, 0x7f, 0x2d, 0x85, 0xed, 0xa2, 0xb9,
+	0xc4, 0x99, 0x5f, 0x34, 0x97, 0x38, 0x23, 0x2f, 0xe1, 0xee, 0x95, 0xad, 0xb7, 0xda, 0x31, 0x8f,
+	0xfe, 0xe7, 0x84, 0xc4, 0x65, 0x96, 0xcf, 0x5b, 0x8f, 0x83, 0xe8, 0xaf, 0x00, 0xe8, 0x6a, 0xec,
+	0xda, 0x55, 0x57, 0xbe, 0x38, 0xad, 0xf9, 0x8b, 0xf3, 0xef, 0x36, 0x69, 0x6f, 0xb6, 0x4d, 0x06,
+	0xd0, 0xd1, 0x86, 0x9d, 0x67, 0xe8, 0xd7, 0x52, 0x29, 0x59, 0x1e, 0x97, 0x27, 0xfb, 0xee, 0x38,
+	0x1e, 0x57, 0x62, 0x84, 0x70, 0xb4, 0x7c, 0xc1, 0x8a, 0xfc, 0x7e, 0x55, 0xae, 0x5e, 0xf3, 0x21,
+	0x6c, 0x8b, 0x6a, 0x7e, 0x6e, 0x59, 0xc7, 0xde, 0xef, 0xf4, 0xcf, 0x2d, 0xd8, 0xf3, 0xf9, 0x5f,
+	0x8a, 0x9c, 0x1b, 0xa1, 0xc8, 0xcf, 0xb0, 0xb7, 0xf4, 0x64, 0x93, 0xf7, 0x17, 0x30, 0x6f, 0x7e,
+	0xf8, 0xc3, 0xe8, 0x26, 0x97, 0x12, 0xd9, 0xe8, 0x0e, 0xf9, 0x0a, 0x3a, 0x2f, 0xf2, 0x2b, 0x71,
+	0x89, 0x84, 0x2e, 0xf8, 0x97, 0x2a, 0x9f, 0xe9, 0x7e, 0x83, 0x65, 0x9e, 0xe0, 0x39, 0xec, 0x9e,
+	0x19, 0x85, 0x6c, 0xfa, 0x46, 0x69, 0x1e, 0x04, 0xe4, 0x3b, 0xd8, 0x5d, 0x7c, 0xe8, 0xc8, 0x51,
+	0x8d, 0x56, 0x2b, 0x7f, 0x1d, 0xe1, 0x7b, 0x6b, 0xed, 0xf3, 0xbb, 0xfd, 0x02, 0xfb, 0xcb, 0x3d,
+	0x23, 0xd1, 0xed, 0x6c, 0x0d, 0x3f, 0xb8, 0xd1, 0x67, 0x9e, 0xfe, 0x57, 0x18, 0xae, 0xa1, 0x04,
+	0xf9, 0xe8, 0x86, 0x0c, 0x75, 0xda, 0x84, 0x83, 0x15, 0x4e, 0x3c, 0xb3, 0xbf, 0x81, 0xd1, 0x9d,
+	0xf3, 0x8e, 0xd3, 0x7c, 0xf2, 0xcf, 0x00, 0x23, 0x13, 0x3a, 0x49, 0x43, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -54,6 +54,7 @@ from .resource import (
     Resource,
     CustomResource,
     CustomTimeouts,
+    CustomAwait,
     ComponentResource,
     ProviderResource,
     ResourceOptions,
@@ -16,7 +16,7 @@
 Assets are the Pulumi notion of data blobs that can be passed to resources.
 """
 from os import PathLike, fspath
-from typing import Dict, Union
+from typing import Dict, Optional, Union
 
 
 class Asset:
@@ -58,13 +58,28 @@ class RemoteAsset(Asset):
     dictates the protocol for fetching contents: "file://" specifies a local file, "http://"
     and "https://" specify HTTP and HTTPS, respectively. Note that specific providers may recognize
     alternative schemes; this is merely the base-most set that all providers support.
+
+    Additional HTTP headers, such as authorization tokens, may be sent when fetching the asset. The engine encrypts
+    the headers when it writes the asset to the stack's checkpoint. If a pinned hash is given, the engine verifies
+    that the SHA256 hash of the asset's contents matches it before the asset is used.
     """
     uri: str
+    headers: Optional[Dict[str, str]]
+    pinned_hash: Optional[str]
 
-    def __init__(self, uri: str) -> None:
+    def __init__(self,
+                 uri: str,
+                 headers: Optional[Dict[str, str]] = None,
+                 pinned_hash: Optional[str] = None) -> None:
         if not isinstance(uri, str):
             raise TypeError("RemoteAsset URI must be a string")
+        if headers is not None and not isinstance(headers, dict):
+            raise TypeError("RemoteAsset headers must be a dictionary")
+        if pinned_hash is not None and not isinstance(pinned_hash, str):
+            raise TypeError("RemoteAsset pinned hash must be a string")
         self.uri = uri
+        self.headers = headers
+        self.pinned_hash = pinned_hash
 
 
 class Archive:
@@ -12,6 +12,7 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 import asyncio
+import json
 from functools import reduce
 from inspect import isawaitable
 from typing import (
@@ -367,6 +368,37 @@ class Output(Generic[T]):
         # invariant http://mypy.readthedocs.io/en/latest/common_issues.html#variance
         return Output.all(*transformed_items).apply("".join) # type: ignore
 
+    @staticmethod
+    def json_dumps(obj: Input[Any], **kwargs: Any) -> 'Output[str]':
+        """
+        Serializes an Input to a JSON string once all of the Outputs nested within it have resolved.
+
+        The resulting Output is unknown if any of the nested Outputs is unknown, and secret if any of them is
+        secret. This can be used like so:
+
+            policy = Output.json_dumps({"Resource": [bucket.arn]})
+
+        :param Input[Any] obj: The Input to serialize.
+        :param kwargs: Additional arguments to pass to json.dumps.
+        :return: An output containing the JSON string.
+        :rtype: Output[str]
+        """
+
+        return Output.from_input(obj).apply(lambda v: json.dumps(v, **kwargs))
+
+    @staticmethod
+    def json_loads(s: Input[str], **kwargs: Any) -> 'Output[Any]':
+        """
+        Deserializes a JSON string Input. The result is unknown or secret if the string is.
+
+        :param Input[str] s: The JSON string to deserialize.
+        :param kwargs: Additional arguments to pass to json.loads.
+        :return: An output containing the deserialized value.
+        :rtype: Output[Any]
+        """
+
+        return Output.from_input(s).apply(lambda v: json.loads(v, **kwargs))
+
 
 class Unknown:
     """
@@ -54,6 +54,26 @@ class CustomTimeouts:
         self.delete = delete
 
 
+class CustomAwait:
+    skip: Optional[bool]
+    """
+    skip, when True, indicates that the provider should not wait for the resource to become ready.
+    """
+
+    conditions: Optional[List[str]]
+    """
+    conditions is an optional list of provider-specific conditions that must hold before the resource
+    is considered ready. Providers that do not support custom await conditions ignore them.
+    """
+
+    def __init__(self,
+                 skip: Optional[bool] = None,
+                 conditions: Optional[List[str]] = None) -> None:
+
+        self.skip = skip
+        self.conditions = conditions
+
+
 def inherited_child_alias(
         child_name: str,
         parent_name: str,
@@ -353,6 +373,19 @@ class ResourceOptions:
     An optional customTimeouts config block.
     """
 
+    custom_await: Optional['CustomAwait']
+    """
+    An optional customAwait config block that controls how the resource's provider waits for the
+    resource to become ready after it is created or updated.
+    """
+
+    serialization_group: Optional[str]
+    """
+    An optional name of a group of resources that the engine never creates, updates, or deletes
+    concurrently. This serializes changes for APIs that tolerate only one at a time without chaining
+    the resources with depends_on.
+    """
+
     transformations: Optional[List[ResourceTransformation]]
     """
     Optional list of transformations to apply to this resource during construction. The
@@ -388,7 +421,9 @@ class ResourceOptions:
                  id: Optional['Input[str]'] = None,
                  import_: Optional[str] = None,
                  custom_timeouts: Optional['CustomTimeouts'] = None,
-                 transformations: Optional[List[ResourceTransformation]] = None) -> None:
+                 transformations: Optional[List[ResourceTransformation]] = None,
+                 custom_await: Optional['CustomAwait'] = None,
+                 serialization_group: Optional[str] = None) -> None:
         """
         :param Optional[Resource] parent: If provided, the currently-constructing resource should be the child of
                the provided parent resource.
@@ -413,6 +448,10 @@ class ResourceOptions:
         :param Optional[CustomTimeouts] customTimeouts: If provided, a config block for custom timeout information.
         :param Optional[transformations] transformations: If provided, a list of transformations to apply to this resource
                during construction.
+        :param Optional[CustomAwait] custom_await: If provided, a config block that controls how the resource's provider
+               waits for the resource to become ready.
+        :param Optional[str] serialization_group: If provided, the name of a group of resources that are never created,
+               updated, or deleted concurrently.
         """
 
         # Expose 'merge' again this this object, but this time as an instance method.
@@ -434,6 +473,8 @@ class ResourceOptions:
         self.id = id
         self.import_ = import_
         self.transformations = transformations
+        self.custom_await = custom_await
+        self.serialization_group = serialization_group
 
         if depends_on is not None:
             for dep in depends_on:
@@ -502,6 +543,8 @@ class ResourceOptions:
         dest.delete_before_replace = dest.delete_before_replace if source.delete_before_replace is None else source.delete_before_replace
         dest.version = dest.version if source.version is None else source.version
         dest.custom_timeouts = dest.custom_timeouts if source.custom_timeouts is None else source.custom_timeouts
+        dest.custom_await = dest.custom_await if source.custom_await is None else source.custom_await
+        dest.serialization_group = dest.serialization_group if source.serialization_group is None else source.serialization_group
         dest.id = dest.id if source.id is None else source.id
         dest.import_ = dest.import_ if source.import_ is None else source.import_
 
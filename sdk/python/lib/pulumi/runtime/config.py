@@ -15,7 +15,7 @@
 """
 Runtime support for the Pulumi configuration system.  Please use pulumi.Config instead.
 """
-from typing import Dict, Any
+from typing import Dict, Any, List, Set
 
 import json
 import os
@@ -23,6 +23,9 @@ import os
 # default to an empty map for config.
 CONFIG: Dict[str, Any] = dict()
 
+# the configuration keys that the program has read, so that they can be reported to the engine.
+_CONFIG_KEYS_READ: Set[str] = set()
+
 
 def set_config(k: str, v: Any):
     """
@@ -61,6 +64,8 @@ def get_config(k: str) -> Any:
     """
     Returns a configuration variable's value or None if it is unset.
     """
+    _CONFIG_KEYS_READ.add(k)
+
     # If the config has been set explicitly, use it.
     if k in list(CONFIG.keys()):
         return CONFIG[k]
@@ -76,3 +81,10 @@ def get_config(k: str) -> Any:
         return env_dict[k]
 
     return None
+
+
+def get_config_keys_read() -> List[str]:
+    """
+    Returns the configuration keys that the program has read, sorted.
+    """
+    return sorted(_CONFIG_KEYS_READ)
@@ -23,6 +23,8 @@ from .engine_pb2 import *
 from .engine_pb2_grpc import *
 from .language_pb2 import *
 from .language_pb2_grpc import *
+from .persister_pb2 import *
+from .persister_pb2_grpc import *
 from .plugin_pb2 import *
 from .plugin_pb2_grpc import *
 from .provider_pb2 import *
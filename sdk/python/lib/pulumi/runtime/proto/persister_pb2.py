@@ -0,0 +1,233 @@
+# -*- coding: utf-8 -*-
+# Generated by the protocol buffer compiler.  DO NOT EDIT!
+# source: persister.proto
+
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import message as _message
+from google.protobuf import reflection as _reflection
+from google.protobuf import symbol_database as _symbol_database
+# @@protoc_insertion_point(imports)
+
+_sym_db = _symbol_database.Default()
+
+
+from . import plugin_pb2 as plugin__pb2
+from google.protobuf import empty_pb2 as google_dot_protobuf_dot_empty__pb2
+
+
+DESCRIPTOR = _descriptor.FileDescriptor(
+  name='persister.proto',
+  package='pulumirpc',
+  syntax='proto3',
+  serialized_options=None,
+  serialized_pb=b'\n\x0fpersister.proto\x12\tpulumirpc\x1a\x0cplugin.proto\x1a\x1bgoogle/protobuf/empty.proto\"&\n\x15LoadCheckpointRequest\x12\r\n\x05stack\x18\x01 \x01(\t\"=\n\x16LoadCheckpointResponse\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x12\n\ndeployment\x18\x02 \x01(\x0c\"{\n\x12\x43heckpointMutation\x12\r\n\x05stack\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\x05\x12\x0e\n\x06header\x18\x03 \x01(\x0c\x12\r\n\x05start\x18\x04 \x01(\x05\x12\x13\n\x0b\x64\x65leteCount\x18\x05 \x01(\x05\x12\x11\n\tresources\x18\x06 \x03(\x0c\x32\xe7\x01\n\x11SnapshotPersister\x12@\n\rGetPluginInfo\x12\x16.google.protobuf.Empty\x1a\x15.pulumirpc.PluginInfo\"\x00\x12M\n\x04Load\x12 .pulumirpc.LoadCheckpointRequest\x1a!.pulumirpc.LoadCheckpointResponse\"\x00\x12\x41\n\x04Save\x12\x1d.pulumirpc.CheckpointMutation\x1a\x16.google.protobuf.Empty\"\x00(\x01\x62\x06proto3'
+  ,
+  dependencies=[plugin__pb2.DESCRIPTOR,google_dot_protobuf_dot_empty__pb2.DESCRIPTOR,])
+
+
+
+
+_LOADCHECKPOINTREQUEST = _descriptor.Descriptor(
+  name='LoadCheckpointRequest',
+  full_name='pulumirpc.LoadCheckpointRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='stack', full_name='pulumirpc.LoadCheckpointRequest.stack', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=73,
+  serialized_end=111,
+)
+
+
+_LOADCHECKPOINTRESPONSE = _descriptor.Descriptor(
+  name='LoadCheckpointResponse',
+  full_name='pulumirpc.LoadCheckpointResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='version', full_name='pulumirpc.LoadCheckpointResponse.version', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='deployment', full_name='pulumirpc.LoadCheckpointResponse.deployment', index=1,
+      number=2, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=113,
+  serialized_end=174,
+)
+
+
+_CHECKPOINTMUTATION = _descriptor.Descriptor(
+  name='CheckpointMutation',
+  full_name='pulumirpc.CheckpointMutation',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='stack', full_name='pulumirpc.CheckpointMutation.stack', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='version', full_name='pulumirpc.CheckpointMutation.version', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='header', full_name='pulumirpc.CheckpointMutation.header', index=2,
+      number=3, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='start', full_name='pulumirpc.CheckpointMutation.start', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='deleteCount', full_name='pulumirpc.CheckpointMutation.deleteCount', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+    _descriptor.FieldDescriptor(
+      name='resources', full_name='pulumirpc.CheckpointMutation.resources', index=5,
+      number=6, type=12, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=176,
+  serialized_end=299,
+)
+
+DESCRIPTOR.message_types_by_name['LoadCheckpointRequest'] = _LOADCHECKPOINTREQUEST
+DESCRIPTOR.message_types_by_name['LoadCheckpointResponse'] = _LOADCHECKPOINTRESPONSE
+DESCRIPTOR.message_types_by_name['CheckpointMutation'] = _CHECKPOINTMUTATION
+_sym_db.RegisterFileDescriptor(DESCRIPTOR)
+
+LoadCheckpointRequest = _reflection.GeneratedProtocolMessageType('LoadCheckpointRequest', (_message.Message,), {
+  'DESCRIPTOR' : _LOADCHECKPOINTREQUEST,
+  '__module__' : 'persister_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.LoadCheckpointRequest)
+  })
+_sym_db.RegisterMessage(LoadCheckpointRequest)
+
+LoadCheckpointResponse = _reflection.GeneratedProtocolMessageType('LoadCheckpointResponse', (_message.Message,), {
+  'DESCRIPTOR' : _LOADCHECKPOINTRESPONSE,
+  '__module__' : 'persister_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.LoadCheckpointResponse)
+  })
+_sym_db.RegisterMessage(LoadCheckpointResponse)
+
+CheckpointMutation = _reflection.GeneratedProtocolMessageType('CheckpointMutation', (_message.Message,), {
+  'DESCRIPTOR' : _CHECKPOINTMUTATION,
+  '__module__' : 'persister_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.CheckpointMutation)
+  })
+_sym_db.RegisterMessage(CheckpointMutation)
+
+
+
+_SNAPSHOTPERSISTER = _descriptor.ServiceDescriptor(
+  name='SnapshotPersister',
+  full_name='pulumirpc.SnapshotPersister',
+  file=DESCRIPTOR,
+  index=0,
+  serialized_options=None,
+  serialized_start=302,
+  serialized_end=533,
+  methods=[
+  _descriptor.MethodDescriptor(
+    name='GetPluginInfo',
+    full_name='pulumirpc.SnapshotPersister.GetPluginInfo',
+    index=0,
+    containing_service=None,
+    input_type=google_dot_protobuf_dot_empty__pb2._EMPTY,
+    output_type=plugin__pb2._PLUGININFO,
+    serialized_options=None,
+  ),
+  _descriptor.MethodDescriptor(
+    name='Load',
+    full_name='pulumirpc.SnapshotPersister.Load',
+    index=1,
+    containing_service=None,
+    input_type=_LOADCHECKPOINTREQUEST,
+    output_type=_LOADCHECKPOINTRESPONSE,
+    serialized_options=None,
+  ),
+  _descriptor.MethodDescriptor(
+    name='Save',
+    full_name='pulumirpc.SnapshotPersister.Save',
+    index=2,
+    containing_service=None,
+    input_type=_CHECKPOINTMUTATION,
+    output_type=google_dot_protobuf_dot_empty__pb2._EMPTY,
+    serialized_options=None,
+  ),
+])
+_sym_db.RegisterServiceDescriptor(_SNAPSHOTPERSISTER)
+
+DESCRIPTOR.services_by_name['SnapshotPersister'] = _SNAPSHOTPERSISTER
+
+# @@protoc_insertion_point(module_scope)
@@ -0,0 +1,86 @@
+# Generated by the gRPC Python protocol compiler plugin. DO NOT EDIT!
+import grpc
+
+from google.protobuf import empty_pb2 as google_dot_protobuf_dot_empty__pb2
+from . import persister_pb2 as persister__pb2
+from . import plugin_pb2 as plugin__pb2
+
+
+class SnapshotPersisterStub(object):
+  """SnapshotPersister stores the checkpoints of stacks on behalf of the engine, e.g. in a database or some other
+  system that the built-in backends do not support.
+  """
+
+  def __init__(self, channel):
+    """Constructor.
+
+    Args:
+      channel: A grpc.Channel.
+    """
+    self.GetPluginInfo = channel.unary_unary(
+        '/pulumirpc.SnapshotPersister/GetPluginInfo',
+        request_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
+        response_deserializer=plugin__pb2.PluginInfo.FromString,
+        )
+    self.Load = channel.unary_unary(
+        '/pulumirpc.SnapshotPersister/Load',
+        request_serializer=persister__pb2.LoadCheckpointRequest.SerializeToString,
+        response_deserializer=persister__pb2.LoadCheckpointResponse.FromString,
+        )
+    self.Save = channel.stream_unary(
+        '/pulumirpc.SnapshotPersister/Save',
+        request_serializer=persister__pb2.CheckpointMutation.SerializeToString,
+        response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+        )
+
+
+class SnapshotPersisterServicer(object):
+  """SnapshotPersister stores the checkpoints of stacks on behalf of the engine, e.g. in a database or some other
+  system that the built-in backends do not support.
+  """
+
+  def GetPluginInfo(self, request, context):
+    """GetPluginInfo returns generic information about this plugin, like its version.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def Load(self, request, context):
+    """Load returns the latest checkpoint of a stack.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def Save(self, request_iterator, context):
+    """Save streams the changes made to a stack's checkpoint over the course of an update. The first mutation in a
+    stream applies to an empty checkpoint, and each subsequent mutation applies to the result of the one before it.
+    The plugin must not respond until every mutation it has received is durable.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+
+def add_SnapshotPersisterServicer_to_server(servicer, server):
+  rpc_method_handlers = {
+      'GetPluginInfo': grpc.unary_unary_rpc_method_handler(
+          servicer.GetPluginInfo,
+          request_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+          response_serializer=plugin__pb2.PluginInfo.SerializeToString,
+      ),
+      'Load': grpc.unary_unary_rpc_method_handler(
+          servicer.Load,
+          request_deserializer=persister__pb2.LoadCheckpointRequest.FromString,
+          response_serializer=persister__pb2.LoadCheckpointResponse.SerializeToString,
+      ),
+      'Save': grpc.stream_unary_rpc_method_handler(
+          servicer.Save,
+          request_deserializer=persister__pb2.CheckpointMutation.FromString,
+          response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
+      ),
+  }
+  generic_handler = grpc.method_handlers_generic_handler(
+      'pulumirpc.SnapshotPersister', rpc_method_handlers)
+  server.add_generic_rpc_handlers((generic_handler,))
@@ -77,6 +77,16 @@ class ResourceProviderStub(object):
         request_serializer=provider__pb2.DeleteRequest.SerializeToString,
         response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
         )
+    self.GetLogs = channel.unary_stream(
+        '/pulumirpc.ResourceProvider/GetLogs',
+        request_serializer=provider__pb2.GetLogsRequest.SerializeToString,
+        response_deserializer=provider__pb2.LogEntry.FromString,
+        )
+    self.Call = channel.unary_unary(
+        '/pulumirpc.ResourceProvider/Call',
+        request_serializer=provider__pb2.CallRequest.SerializeToString,
+        response_deserializer=provider__pb2.CallResponse.FromString,
+        )
     self.Cancel = channel.unary_unary(
         '/pulumirpc.ResourceProvider/Cancel',
         request_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
@@ -185,6 +195,22 @@ class ResourceProviderServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def GetLogs(self, request, context):
+    """GetLogs streams the log entries produced by a resource, oldest first. Unless the request asks to follow the
+    logs, the stream ends once all entries in the requested time range have been sent.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def Call(self, request, context):
+    """Call runs one of the operational methods, such as rebooting an instance, that a resource's schema declares
+    against an existing instance of that resource.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
   def Cancel(self, request, context):
     """Cancel signals the provider to abort all outstanding resource operations.
     """
@@ -262,6 +288,16 @@ def add_ResourceProviderServicer_to_server(servicer, server):
           request_deserializer=provider__pb2.DeleteRequest.FromString,
           response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
       ),
+      'GetLogs': grpc.unary_stream_rpc_method_handler(
+          servicer.GetLogs,
+          request_deserializer=provider__pb2.GetLogsRequest.FromString,
+          response_serializer=provider__pb2.LogEntry.SerializeToString,
+      ),
+      'Call': grpc.unary_unary_rpc_method_handler(
+          servicer.Call,
+          request_deserializer=provider__pb2.CallRequest.FromString,
+          response_serializer=provider__pb2.CallResponse.SerializeToString,
+      ),
       'Cancel': grpc.unary_unary_rpc_method_handler(
           servicer.Cancel,
           request_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
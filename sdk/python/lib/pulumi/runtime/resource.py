@@ -27,6 +27,7 @@ from ..metadata import get_project, get_stack
 
 if TYPE_CHECKING:
     from .. import Resource, ResourceOptions, CustomResource, Inputs, Output
+    from ..resource import CustomAwait
 
 
 class ResourceResolverOperations(NamedTuple):
@@ -65,6 +66,15 @@ class ResourceResolverOperations(NamedTuple):
     """
 
 
+def serialize_custom_await(custom_await: 'CustomAwait') -> Dict[str, Any]:
+    result: Dict[str, Any] = {}
+    if custom_await.skip:
+        result["skip"] = True
+    if custom_await.conditions:
+        result["conditions"] = list(custom_await.conditions)
+    return result
+
+
 # Prepares for an RPC that will manufacture a resource, and hence deals with input and output properties.
 # pylint: disable=too-many-locals
 async def prepare_resource(res: 'Resource',
@@ -96,6 +106,11 @@ async def prepare_resource(res: 'Resource',
         if parent is not None:
             parent_urn = await parent.urn.future()
 
+    # Custom await options are sent to the provider alongside the resource's inputs.
+    if custom and opts is not None and opts.custom_await is not None:
+        # pylint: disable=unsupported-assignment-operation
+        serialized_props[rpc.CUSTOM_AWAIT_KEY] = serialize_custom_await(opts.custom_await)
+
     # Construct the provider reference, if we were given a provider to use.
     provider_ref = None
     if custom and opts is not None and opts.provider is not None:
@@ -404,6 +419,7 @@ def _register_resource(res: 'Resource',
                 customTimeouts=custom_timeouts,
                 aliases=resolver.aliases,
                 supportsPartialValues=True,
+                serializationGroup=opts.serialization_group or "",
             )
 
             from ..resource import create_urn # pylint: disable=import-outside-toplevel
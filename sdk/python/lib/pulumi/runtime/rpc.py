@@ -16,6 +16,7 @@ Support for serializing and deserializing properties going into or flowing
 out of RPC calls.
 """
 import asyncio
+import base64
 import functools
 import inspect
 from typing import List, Any, Callable, Dict, Optional, TYPE_CHECKING, cast
@@ -45,6 +46,9 @@ _special_archive_sig = "0def7320c3a5731c473e5ecbe6d01bc7"
 _special_secret_sig = "1b47061264138c4ac30d75fd1eb44270"
 """special_secret_sig is a randomly assigned hash used to identify secrets in maps. See pkg/resource/properties.go"""
 
+CUSTOM_AWAIT_KEY = "__customAwait"
+"""CUSTOM_AWAIT_KEY is the input property used to send custom await options to providers."""
+
 _INT_OR_FLOAT = six.integer_types + (float,)
 
 def isLegalProtobufValue(value: Any) -> bool:
@@ -99,6 +103,10 @@ async def serialize_property(value: 'Input[Any]',
     if known_types.is_unknown(value):
         return UNKNOWN
 
+    if isinstance(value, (bytes, bytearray)):
+        # Byte strings are sent as their base64 encoding.
+        return base64.b64encode(value).decode("ascii")
+
     if known_types.is_custom_resource(value):
         resource = cast('CustomResource', value)
         deps.append(resource)
@@ -121,6 +129,11 @@ async def serialize_property(value: 'Input[Any]',
         elif hasattr(value, "uri"):
             remote_asset = cast('RemoteAsset', value)
             obj["uri"] = await serialize_property(remote_asset.uri, deps, input_transformer)
+            if getattr(remote_asset, "headers", None) is not None:
+                # Header names are sent verbatim rather than translated like property names.
+                obj["headers"] = await serialize_property(remote_asset.headers, deps)
+            if getattr(remote_asset, "pinned_hash", None) is not None:
+                obj["pinnedHash"] = await serialize_property(remote_asset.pinned_hash, deps, input_transformer)
         else:
             raise AssertionError(f"unknown asset type: {value}")
 
@@ -219,7 +232,10 @@ def deserialize_properties(props_struct: struct_pb2.Struct, keep_unknowns: Optio
             if "text" in props_struct:
                 return StringAsset(props_struct["text"])
             if "uri" in props_struct:
-                return RemoteAsset(props_struct["uri"])
+                headers = props_struct["headers"] if "headers" in props_struct else None
+                return RemoteAsset(props_struct["uri"],
+                                   headers=dict(headers) if headers is not None else None,
+                                   pinned_hash=props_struct["pinnedHash"] if "pinnedHash" in props_struct else None)
             raise AssertionError("Invalid asset encountered when unmarshalling resource property")
         if props_struct[_special_sig_key] == _special_archive_sig:
             # This is an archive. Re-hydrate this object into an Archive.
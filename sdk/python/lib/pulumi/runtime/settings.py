@@ -204,11 +204,19 @@ def set_root_resource(root: 'Resource'):
 
 
 async def monitor_supports_secrets() -> bool:
+    return await _monitor_supports_feature("secrets")
+
+
+async def monitor_supports_config_usage() -> bool:
+    return await _monitor_supports_feature("configUsage")
+
+
+async def _monitor_supports_feature(feature: str) -> bool:
     monitor = SETTINGS.monitor
     if not monitor:
         return False
 
-    req = resource_pb2.SupportsFeatureRequest(id="secrets")
+    req = resource_pb2.SupportsFeatureRequest(id=feature)
     def do_rpc_call():
         try:
             resp = monitor.SupportsFeature(req)
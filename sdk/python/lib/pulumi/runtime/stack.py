@@ -21,7 +21,10 @@ from inspect import isawaitable
 from typing import Callable, Any, Dict, List, TYPE_CHECKING
 
 from ..resource import ComponentResource, Resource, ResourceTransformation
-from .settings import get_project, get_stack, get_root_resource, is_dry_run, set_root_resource
+from ..runtime.proto import provider_pb2
+from . import rpc
+from .config import get_config_keys_read
+from .settings import get_monitor, get_project, get_stack, get_root_resource, is_dry_run, set_root_resource, monitor_supports_config_usage
 from .rpc_manager import RPC_MANAGER
 from .sync_await import _all_tasks, _get_current_task
 from .. import log
@@ -81,6 +84,26 @@ async def run_in_stack(func: Callable):
     is meant for internal runtime use only and is used by the Python SDK entrypoint program.
     """
     await run_pulumi_func(lambda: Stack(func))
+    await report_config_usage()
+
+
+async def report_config_usage():
+    """
+    Report the configuration keys that the program read to the engine, which compares them with the
+    configuration of the stack. Keys that are not of the form "namespace:name" cannot be set in the
+    stack's configuration, so they are not reported.
+    """
+    if not await monitor_supports_config_usage():
+        return
+
+    keys = [k for k in get_config_keys_read() if ":" in k]
+    args = await rpc.serialize_properties({"keys": keys}, {})
+    req = provider_pb2.InvokeRequest(tok="pulumi:pulumi:reportConfigUsage", args=args)
+
+    monitor = get_monitor()
+    resp = await asyncio.get_event_loop().run_in_executor(None, lambda: monitor.Invoke(req))
+    if resp.failures:
+        raise Exception(f"reporting configuration usage failed: {resp.failures[0].reason}")
 
 
 class Stack(ComponentResource):
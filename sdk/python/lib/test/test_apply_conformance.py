@@ -0,0 +1,63 @@
+# Copyright 2016-2020, Pulumi Corporation.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+import asyncio
+import json
+import os
+import unittest
+
+from pulumi.output import Output
+from pulumi.runtime import settings
+
+CONFORMANCE_CASES = os.path.join(os.path.dirname(__file__), "..", "..", "..", "conformance", "apply.json")
+
+
+def create_output(spec: dict) -> Output:
+    """
+    Creates an output from a conformance case's description of one. Resources are represented by their names.
+    """
+    def resolved(value):
+        fut = asyncio.Future()
+        fut.set_result(value)
+        return fut
+    return Output(set(spec["resources"]), resolved(spec["value"]), resolved(spec["known"]), resolved(spec["secret"]))
+
+
+class ApplyConformanceTests(unittest.TestCase):
+    """
+    Runs the cases shared by all of the SDKs that describe how an apply whose callback returns an output is flattened.
+    """
+    def test_apply_conformance(self):
+        with open(CONFORMANCE_CASES) as f:
+            cases = json.load(f)["cases"]
+
+        for case in cases:
+            with self.subTest(case["name"]):
+                settings.SETTINGS.dry_run = True
+
+                async def run():
+                    outer = create_output(case["outer"])
+                    inner = create_output(case["inner"])
+                    result = outer.apply(lambda _: inner)
+
+                    expected = case["result"]
+                    self.assertEqual(expected["known"], await result.is_known())
+                    self.assertEqual(expected["secret"], await result.is_secret())
+                    self.assertEqual(set(expected["resources"]), await result.resources())
+                    if expected["known"]:
+                        self.assertEqual(expected["value"], await result.future())
+
+                loop = asyncio.new_event_loop()
+                asyncio.set_event_loop(loop)
+                loop.run_until_complete(run())
+                loop.close()
@@ -59,6 +59,11 @@ class NextSerializationTests(unittest.TestCase):
         props = await rpc.serialize_property(test_list, [])
         self.assertEqual(test_list, props)
 
+    @async_test
+    async def test_bytes(self):
+        prop = await rpc.serialize_property(b"\xde\xad\xbe\xef", [])
+        self.assertEqual("3q2+7w==", prop)
+
     @async_test
     async def test_future(self):
         fut = asyncio.Future()
@@ -864,6 +869,33 @@ class NextSerializationTests(unittest.TestCase):
         self.assertEqual(42, await out.future())
         self.assertEqual(42, await out.apply(lambda v: v).future())
 
+    @async_test
+    async def test_json_dumps(self):
+        settings.SETTINGS.dry_run = False
+
+        out = Output.json_dumps({"bucket": self.create_output("foo", True, True), "paths": ["a", "b"]})
+
+        self.assertTrue(await out.is_known())
+        self.assertTrue(await out.is_secret())
+        self.assertEqual(await out.future(), '{"bucket": "foo", "paths": ["a", "b"]}')
+
+    @async_test
+    async def test_json_dumps_unknown(self):
+        settings.SETTINGS.dry_run = True
+
+        out = Output.json_dumps({"bucket": self.create_output("foo", False)})
+
+        self.assertFalse(await out.is_known())
+
+    @async_test
+    async def test_json_loads(self):
+        settings.SETTINGS.dry_run = False
+
+        out = Output.json_loads(self.create_output('{"paths": ["a", "b"]}', True, True))
+
+        self.assertTrue(await out.is_secret())
+        self.assertEqual(await out.future(), {"paths": ["a", "b"]})
+
 
 class DeserializationTests(unittest.TestCase):
     def test_unsupported_sig(self):
@@ -119,17 +119,9 @@ func InstallDependencies(root string, showOutput bool, saveProj func(virtualenv
 
 	// Create the virtual environment by running `python -m venv venv`.
 	venvDir := filepath.Join(root, "venv")
-	cmd, err := Command("-m", "venv", venvDir)
-	if err != nil {
+	if err := CreateVirtualEnv(venvDir); err != nil {
 		return err
 	}
-	if output, err := cmd.CombinedOutput(); err != nil {
-		if len(output) > 0 {
-			os.Stdout.Write(output)
-			fmt.Println()
-		}
-		return errors.Wrapf(err, "creating virtual environment at %s", venvDir)
-	}
 
 	// Save project with venv info.
 	if err := saveProj("venv"); err != nil {
@@ -141,6 +133,28 @@ func InstallDependencies(root string, showOutput bool, saveProj func(virtualenv
 		fmt.Println()
 	}
 
+	return InstallRequirements(root, venvDir, showOutput)
+}
+
+// CreateVirtualEnv creates a new virtual environment in the given directory by running `python -m venv`.
+func CreateVirtualEnv(venvDir string) error {
+	cmd, err := Command("-m", "venv", venvDir)
+	if err != nil {
+		return err
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if len(output) > 0 {
+			os.Stdout.Write(output)
+			fmt.Println()
+		}
+		return errors.Wrapf(err, "creating virtual environment at %s", venvDir)
+	}
+	return nil
+}
+
+// InstallRequirements installs the dependencies listed in the root directory's requirements.txt into the given
+// virtual environment. It does nothing if there is no requirements.txt.
+func InstallRequirements(root, venvDir string, showOutput bool) error {
 	// If `requirements.txt` doesn't exist, just exit early.
 	requirementsPath := filepath.Join(root, "requirements.txt")
 	if _, err := os.Stat(requirementsPath); os.IsNotExist(err) {